@@ -0,0 +1,261 @@
+// Command catalog-sync copies the global content catalog (items with their hints and companies,
+// engineering blogs with their articles) from one environment to another.
+//
+//	catalog-sync export [-db URL] [-out catalog.json]
+//	catalog-sync apply [-db URL] -in catalog.json [-dry-run]
+//	catalog-sync copy -from URL -to URL [-dry-run]
+//	catalog-sync import-eng-blogs [-db URL] -in eng-blogs.json [-dry-run]
+//
+// Applying matches entries by natural key, never deletes, and prints the changes it makes (or
+// would make, with -dry-run) as JSON. The database URL defaults to DATABASE_URL.
+//
+// import-eng-blogs applies a list of engineering blogs in the format of the bundled seed data
+// (internal/seed/data/eng-blogs.json). Blogs repeated by name or link are merged first, and a
+// blog named like an existing one updates it in place.
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"interview-prep-app/internal/catalog"
+	"interview-prep-app/internal/config"
+	"interview-prep-app/internal/database"
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+	"interview-prep-app/internal/services"
+
+	"github.com/joho/godotenv"
+)
+
+const usage = `usage:
+  catalog-sync export [-db URL] [-out catalog.json]
+  catalog-sync apply [-db URL] -in catalog.json [-dry-run]
+  catalog-sync copy -from URL -to URL [-dry-run]
+  catalog-sync import-eng-blogs [-db URL] -in eng-blogs.json [-dry-run]`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found, using system environment variables")
+	}
+	cfg := config.Load()
+
+	var err error
+	switch os.Args[1] {
+	case "export":
+		err = runExport(cfg, os.Args[2:])
+	case "apply":
+		err = runApply(cfg, os.Args[2:])
+	case "copy":
+		err = runCopy(cfg, os.Args[2:])
+	case "import-eng-blogs":
+		err = runImportEngBlogs(cfg, os.Args[2:])
+	default:
+		err = fmt.Errorf("unknown command: %s\n%s", os.Args[1], usage)
+	}
+
+	if err == flag.ErrHelp {
+		os.Exit(0)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// runExport writes the catalog of a database as a JSON snapshot
+func runExport(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	dbURL := flags.String("db", cfg.DatabaseURL, "database to export from")
+	out := flags.String("out", "", "file to write the snapshot to (default stdout)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	db, service, err := openCatalog(*dbURL, cfg.Environment)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	snapshot, err := service.Export()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+
+	if *out == "" {
+		_, err = os.Stdout.Write(append(data, '\n'))
+		return err
+	}
+
+	if err := os.WriteFile(*out, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	log.Printf("Exported %d items, %d companies and %d blogs to %s", len(snapshot.Items), len(snapshot.Companies), len(snapshot.EngBlogs), *out)
+	return nil
+}
+
+// runApply applies a snapshot file to a database
+func runApply(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("apply", flag.ContinueOnError)
+	dbURL := flags.String("db", cfg.DatabaseURL, "database to apply the snapshot to")
+	in := flags.String("in", "", "snapshot file to apply")
+	dryRun := flags.Bool("dry-run", false, "report the changes without writing them")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *in == "" {
+		return fmt.Errorf("-in is required")
+	}
+
+	data, err := os.ReadFile(*in)
+	if err != nil {
+		return fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	var snapshot models.CatalogSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return fmt.Errorf("failed to parse snapshot: %w", err)
+	}
+
+	db, service, err := openCatalog(*dbURL, cfg.Environment)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	return apply(db, service, &snapshot, *dryRun)
+}
+
+// runCopy exports the catalog of one database and applies it to another
+func runCopy(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("copy", flag.ContinueOnError)
+	from := flags.String("from", "", "database to copy the catalog from")
+	to := flags.String("to", "", "database to copy the catalog to")
+	dryRun := flags.Bool("dry-run", false, "report the changes without writing them")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *from == "" || *to == "" {
+		return fmt.Errorf("-from and -to are required")
+	}
+
+	sourceDB, source, err := openCatalog(*from, "")
+	if err != nil {
+		return err
+	}
+	defer sourceDB.Close()
+
+	snapshot, err := source.Export()
+	if err != nil {
+		return err
+	}
+
+	targetDB, target, err := openCatalog(*to, cfg.Environment)
+	if err != nil {
+		return err
+	}
+	defer targetDB.Close()
+
+	return apply(targetDB, target, snapshot, *dryRun)
+}
+
+// runImportEngBlogs applies a list of engineering blogs in the seed data format
+func runImportEngBlogs(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("import-eng-blogs", flag.ContinueOnError)
+	dbURL := flags.String("db", cfg.DatabaseURL, "database to import the blogs into")
+	in := flags.String("in", "", "JSON file listing the blogs and their articles")
+	dryRun := flags.Bool("dry-run", false, "report the changes without writing them")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *in == "" {
+		return fmt.Errorf("-in is required")
+	}
+
+	data, err := os.ReadFile(*in)
+	if err != nil {
+		return fmt.Errorf("failed to read blogs: %w", err)
+	}
+
+	var blogs []models.EngBlog
+	if err := json.Unmarshal(data, &blogs); err != nil {
+		return fmt.Errorf("failed to parse blogs: %w", err)
+	}
+
+	db, service, err := openCatalog(*dbURL, cfg.Environment)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	current, err := service.Export()
+	if err != nil {
+		return err
+	}
+
+	snapshot, notes := catalog.EngBlogSnapshot(blogs, current.EngBlogs)
+	for _, note := range notes {
+		log.Println(note)
+	}
+	log.Printf("Read %d blogs from %s, %d after merging duplicates", len(blogs), *in, len(snapshot.EngBlogs))
+
+	return apply(db, service, snapshot, *dryRun)
+}
+
+// apply applies a snapshot and prints the resulting changes. Without an event bus to tell the
+// server, users' stats aggregates are marked stale here when the catalog changed.
+func apply(db *sql.DB, service *services.CatalogService, snapshot *models.CatalogSnapshot, dryRun bool) error {
+	diff, err := service.Apply(snapshot, dryRun)
+	if err != nil {
+		return err
+	}
+
+	if !dryRun && len(diff.Changes) > 0 {
+		if _, err := repositories.NewStatsRepository(db).MarkAllAggregatesStale(); err != nil {
+			log.Printf("Warning: failed to mark stats stale: %v", err)
+		}
+	}
+
+	data, err := json.MarshalIndent(diff, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode changes: %w", err)
+	}
+	fmt.Println(string(data))
+
+	verb := "Applied"
+	if dryRun {
+		verb = "Dry run:"
+	}
+	log.Printf("%s %d created, %d updated, %d unchanged", verb, diff.Created, diff.Updated, diff.Unchanged)
+	return nil
+}
+
+// openCatalog connects to a database and returns a catalog service on it
+func openCatalog(dbURL, environment string) (*sql.DB, *services.CatalogService, error) {
+	if dbURL == "" {
+		return nil, nil, fmt.Errorf("database URL is required")
+	}
+
+	db, err := database.NewConnection(dbURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	categoryService := services.NewCategoryService(repositories.NewCategoryRepository(db))
+	return db, services.NewCatalogService(repositories.NewCatalogRepository(db), categoryService, nil, environment), nil
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRunDone(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer pmk_test" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"code":"unauthorized","message":"Invalid or revoked API key","details":null}`))
+			return
+		}
+		if r.Method != http.MethodPut || r.URL.Path != "/api/v1/items/7/complete" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"id":7,"title":"Two Sum","status":"completed"}`))
+	}))
+	defer server.Close()
+
+	var out bytes.Buffer
+	if err := runDone([]string{"-url", server.URL, "-key", "pmk_test", "7"}, &out); err != nil {
+		t.Fatalf("runDone returned error: %v", err)
+	}
+	if out.String() != "Completed #7 Two Sum\n" {
+		t.Errorf("Unexpected output %q", out.String())
+	}
+
+	err := runDone([]string{"-url", server.URL, "-key", "pmk_wrong", "7"}, &out)
+	if err == nil || !strings.Contains(err.Error(), "Invalid or revoked API key") {
+		t.Errorf("Expected the API's error message, got %v", err)
+	}
+}
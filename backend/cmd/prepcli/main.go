@@ -0,0 +1,264 @@
+// Command prepcli works through the prep list from a terminal, talking to the REST API with a
+// personal API key (create one with POST /api/v1/user/api-keys).
+//
+//	prepcli next [-json]
+//	prepcli done [-quality solved|reviewed_solution] [-json] <item-id>
+//	prepcli stats [-json]
+//	prepcli add -title TITLE -link URL -category CATEGORY -subcategory NAME [-json]
+//
+// Every command also takes -url and -key, which default to PREPCLI_URL (or
+// http://localhost:8080) and PREPCLI_API_KEY. add needs the content:write permission.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"interview-prep-app/internal/models"
+)
+
+const usage = `usage:
+  prepcli next [-json]
+  prepcli done [-quality solved|reviewed_solution] [-json] <item-id>
+  prepcli stats [-json]
+  prepcli add -title TITLE -link URL -category CATEGORY -subcategory NAME [-json]
+
+every command also takes -url (default $PREPCLI_URL) and -key (default $PREPCLI_API_KEY)`
+
+// defaultURL is the server prepcli talks to when neither -url nor PREPCLI_URL is set
+const defaultURL = "http://localhost:8080"
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "next":
+		err = runNext(os.Args[2:], os.Stdout)
+	case "done":
+		err = runDone(os.Args[2:], os.Stdout)
+	case "stats":
+		err = runStats(os.Args[2:], os.Stdout)
+	case "add":
+		err = runAdd(os.Args[2:], os.Stdout)
+	case "help", "-h", "-help", "--help":
+		fmt.Println(usage)
+	default:
+		err = fmt.Errorf("unknown command: %s\n%s", os.Args[1], usage)
+	}
+
+	if err == flag.ErrHelp {
+		os.Exit(0)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "prepcli:", err)
+		os.Exit(1)
+	}
+}
+
+// options are the flags every command takes
+type options struct {
+	url     *string
+	key     *string
+	rawJSON *bool
+}
+
+// commonFlags adds the shared flags to a command's flag set
+func commonFlags(flags *flag.FlagSet) *options {
+	url := os.Getenv("PREPCLI_URL")
+	if url == "" {
+		url = defaultURL
+	}
+
+	return &options{
+		url:     flags.String("url", url, "server to talk to"),
+		key:     flags.String("key", os.Getenv("PREPCLI_API_KEY"), "personal API key"),
+		rawJSON: flags.Bool("json", false, "print the API's JSON response"),
+	}
+}
+
+// client returns a client for the chosen server, once a key is set
+func (o *options) client() (*client, error) {
+	if *o.key == "" {
+		return nil, fmt.Errorf("an API key is required: set PREPCLI_API_KEY or pass -key")
+	}
+	return newClient(*o.url, *o.key), nil
+}
+
+// runNext prints the next item to work on
+func runNext(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("next", flag.ContinueOnError)
+	opts := commonFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	api, err := opts.client()
+	if err != nil {
+		return err
+	}
+
+	var item models.ItemWithProgress
+	if err := api.do("GET", "/items/next", nil, &item); err != nil {
+		return err
+	}
+
+	if *opts.rawJSON {
+		return printJSON(out, item)
+	}
+	printItem(out, &item)
+	return nil
+}
+
+// runDone marks an item completed
+func runDone(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("done", flag.ContinueOnError)
+	opts := commonFlags(flags)
+	quality := flags.String("quality", "", "solved or reviewed_solution (default inferred from hint use)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("done takes exactly one item ID")
+	}
+
+	id, err := strconv.Atoi(flags.Arg(0))
+	if err != nil || id <= 0 {
+		return fmt.Errorf("invalid item ID: %s", flags.Arg(0))
+	}
+
+	api, err := opts.client()
+	if err != nil {
+		return err
+	}
+
+	var body interface{}
+	if *quality != "" {
+		body = models.CompleteItemRequest{Quality: models.CompletionQuality(*quality)}
+	}
+
+	var item models.ItemWithProgress
+	if err := api.do("PUT", fmt.Sprintf("/items/%d/complete", id), body, &item); err != nil {
+		return err
+	}
+
+	if *opts.rawJSON {
+		return printJSON(out, item)
+	}
+	fmt.Fprintf(out, "Completed #%d %s\n", item.ID, item.Title)
+	return nil
+}
+
+// runStats prints the user's progress
+func runStats(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("stats", flag.ContinueOnError)
+	opts := commonFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	api, err := opts.client()
+	if err != nil {
+		return err
+	}
+
+	var stats models.Stats
+	if err := api.do("GET", "/stats", nil, &stats); err != nil {
+		return err
+	}
+
+	if *opts.rawJSON {
+		return printJSON(out, stats)
+	}
+	printStats(out, &stats)
+	return nil
+}
+
+// runAdd adds an item to the catalog
+func runAdd(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("add", flag.ContinueOnError)
+	opts := commonFlags(flags)
+	title := flags.String("title", "", "item title")
+	link := flags.String("link", "", "link to the problem or article")
+	category := flags.String("category", "", "dsa, lld, hld or miscellaneous")
+	subcategory := flags.String("subcategory", "", "subcategory, e.g. arrays")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var missing []string
+	for name, value := range map[string]string{"-title": *title, "-link": *link, "-category": *category, "-subcategory": *subcategory} {
+		if strings.TrimSpace(value) == "" {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("%s required", strings.Join(missing, ", "))
+	}
+
+	api, err := opts.client()
+	if err != nil {
+		return err
+	}
+
+	req := models.CreateItemRequest{
+		Title:       *title,
+		Link:        *link,
+		Category:    models.Category(*category),
+		Subcategory: *subcategory,
+	}
+
+	var item models.Item
+	if err := api.do("POST", "/items", req, &item); err != nil {
+		return err
+	}
+
+	if *opts.rawJSON {
+		return printJSON(out, item)
+	}
+	fmt.Fprintf(out, "Added #%d %s (%s/%s)\n", item.ID, item.Title, item.Category, item.Subcategory)
+	return nil
+}
+
+// printItem prints an item for reading in a terminal
+func printItem(out io.Writer, item *models.ItemWithProgress) {
+	fmt.Fprintf(out, "#%d %s\n", item.ID, item.Title)
+	fmt.Fprintf(out, "  %s / %s\n", item.Category, item.Subcategory)
+	fmt.Fprintf(out, "  %s\n", item.Link)
+	if item.Notes != "" {
+		fmt.Fprintf(out, "  Notes: %s\n", item.Notes)
+	}
+	fmt.Fprintf(out, "\nMark it done with: prepcli done %d\n", item.ID)
+}
+
+// printStats prints progress for reading in a terminal
+func printStats(out io.Writer, stats *models.Stats) {
+	fmt.Fprintf(out, "Progress:    %d/%d completed (%.1f%%)\n", stats.CompletedItems, stats.TotalItems, stats.ProgressPercentage)
+	fmt.Fprintf(out, "Today:       %d of %d", stats.CompletedToday, stats.DailyGoal)
+	if stats.DailyGoalMet {
+		fmt.Fprint(out, " - goal met")
+	}
+	fmt.Fprintln(out)
+	fmt.Fprintf(out, "Streak:      %d days (longest %d)\n", stats.CurrentStreak, stats.LongestStreak)
+	fmt.Fprintf(out, "Reviews due: %d\n", stats.ReviewsDue)
+}
+
+// printJSON prints a response as indented JSON
+func printJSON(out io.Writer, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode response: %w", err)
+	}
+	_, err = fmt.Fprintln(out, string(data))
+	return err
+}
@@ -1,19 +1,81 @@
 package main
 
 import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
 	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
 
+	"interview-prep-app/internal/billing"
+	"interview-prep-app/internal/chaos"
 	"interview-prep-app/internal/config"
 	"interview-prep-app/internal/database"
+	"interview-prep-app/internal/events"
+	"interview-prep-app/internal/github"
 	"interview-prep-app/internal/handlers"
+	"interview-prep-app/internal/health"
+	"interview-prep-app/internal/jobs"
+	"interview-prep-app/internal/llm"
+	"interview-prep-app/internal/mailer"
+	"interview-prep-app/internal/metrics"
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/plugins"
+	"interview-prep-app/internal/push"
 	"interview-prep-app/internal/repositories"
+	"interview-prep-app/internal/requestuser"
+	"interview-prep-app/internal/runner"
+	"interview-prep-app/internal/secrets"
+	"interview-prep-app/internal/seed"
 	"interview-prep-app/internal/services"
+	"interview-prep-app/internal/storage"
+	"interview-prep-app/internal/tokens"
+	"interview-prep-app/internal/web"
 	"interview-prep-app/pkg/server"
 
 	"github.com/joho/godotenv"
 )
 
+// serveOptions holds the flags for the serve command
+type serveOptions struct {
+	standalone bool
+}
+
+// parseArgs parses `[serve] [--standalone]`; running with no arguments is the same as `serve`
+func parseArgs(args []string) (*serveOptions, error) {
+	if len(args) > 0 && args[0] == "serve" {
+		args = args[1:]
+	}
+
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	opts := &serveOptions{}
+	flags.BoolVar(&opts.standalone, "standalone", false, "serve the embedded frontend and seed starter content (self-hosted single-binary mode)")
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+	if flags.NArg() > 0 {
+		return nil, fmt.Errorf("unknown command: %s", flags.Arg(0))
+	}
+
+	return opts, nil
+}
+
 func main() {
+	opts, err := parseArgs(os.Args[1:])
+	if err != nil {
+		if err == flag.ErrHelp {
+			os.Exit(0)
+		}
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, "usage: server [serve] [--standalone]")
+		os.Exit(2)
+	}
+
 	// Load environment variables
 	if err := godotenv.Load(); err != nil {
 		log.Println("=====>>>>>>>>No .env file found, using system environment variables")
@@ -21,9 +83,45 @@ func main() {
 
 	// Load configuration
 	cfg := config.Load()
+	if err := cfg.ValidateOAuth(); err != nil {
+		log.Fatal("Invalid OAuth configuration:", err)
+	}
+	if _, err := cfg.LegacySunset(); err != nil {
+		log.Fatal("Invalid legacy route configuration:", err)
+	}
+	flagOverrides, err := cfg.FeatureFlagOverrides()
+	if err != nil {
+		log.Fatal("Invalid feature flag configuration:", err)
+	}
+	tokenIssuer, err := tokens.NewIssuer(cfg)
+	if err != nil {
+		log.Fatal("Invalid JWT configuration:", err)
+	}
+
+	// Route database calls through the fault-injecting driver when debug endpoints are enabled
+	var injector *chaos.Injector
+	driverName := database.DriverFor(cfg.DatabaseURL)
+	if cfg.DebugEndpointsEnabled() {
+		injector = chaos.NewInjector()
+		driverName, err = chaos.RegisterDriver(injector, driverName)
+		if err != nil {
+			log.Fatal("Failed to register fault-injecting database driver:", err)
+		}
+		log.Println("Debug endpoints enabled: fault injection is available under /debug")
+	}
+
+	// Time every query, logging slow ones
+	driverName, err = database.RegisterInstrumentedDriver(driverName, time.Duration(cfg.DBSlowQueryThresholdMs)*time.Millisecond)
+	if err != nil {
+		log.Fatal("Failed to instrument database driver:", err)
+	}
 
 	// Initialize database
-	db, err := database.NewConnection(cfg.DatabaseURL)
+	db, err := database.NewConnectionWithDriver(driverName, cfg.DatabaseURL, database.PoolConfig{
+		MaxOpenConns:    int(cfg.DBMaxOpenConns),
+		MaxIdleConns:    int(cfg.DBMaxIdleConns),
+		ConnMaxLifetime: time.Duration(cfg.DBConnMaxLifetimeSeconds) * time.Second,
+	})
 	if err != nil {
 		log.Fatal("Failed to connect to database:", err)
 	}
@@ -34,34 +132,411 @@ func main() {
 		log.Fatal("Failed to run migrations:", err)
 	}
 
-	// Initialize repositories
+	// Configure optional row-level security for per-user tables
+	if err := database.ConfigureRowSecurity(db, cfg.DBRowSecurity); err != nil {
+		log.Fatal("Failed to configure row security:", err)
+	}
+	repositories.SetRowSecurityEnabled(cfg.DBRowSecurity)
+	repositories.SetHideDeadLinks(cfg.LinkCheckHideDead)
+
+	// Initialize repositories and the transaction manager services compose them with
+	txManager := repositories.NewTxManager(db)
 	itemRepo := repositories.NewItemRepository(db)
 	statsRepo := repositories.NewStatsRepository(db)
 	userRepo := repositories.NewUserRepository(db)
 	userProgressRepo := repositories.NewUserProgressRepository(db)
 	engBlogRepo := repositories.NewEngBlogRepository(db)
 	testRepo := repositories.NewTestRepository(db)
+	attachmentRepo := repositories.NewAttachmentRepository(db)
+	hintRepo := repositories.NewHintRepository(db)
+	shareRepo := repositories.NewShareRepository(db)
+	orgRepo := repositories.NewOrgRepository(db)
+	groupRepo := repositories.NewGroupRepository(db)
+	notificationRepo := repositories.NewNotificationRepository(db)
+	deviceRepo := repositories.NewDeviceRepository(db)
+	webhookRepo := repositories.NewWebhookRepository(db)
+	calendarRepo := repositories.NewCalendarRepository(db)
+	profileRepo := repositories.NewProfileRepository(db)
+	reportRepo := repositories.NewReportRepository(db)
+	githubRepo := repositories.NewGitHubRepository(db)
+	jobRepo := repositories.NewJobRepository(db)
+	flagRepo := repositories.NewFlagRepository(db)
+	roleRepo := repositories.NewRoleRepository(db)
+	apiKeyRepo := repositories.NewAPIKeyRepository(db)
+	lifecycleRepo := repositories.NewLifecycleRepository(db)
+	flashcardRepo := repositories.NewFlashcardRepository(db)
+	companyRepo := repositories.NewCompanyRepository(db)
+	categoryRepo := repositories.NewCategoryRepository(db)
+	interviewRepo := repositories.NewInterviewRepository(db)
+	behavioralRepo := repositories.NewBehavioralRepository(db)
+	quizRepo := repositories.NewQuizRepository(db)
+	designNotesRepo := repositories.NewDesignNotesRepository(db)
+	focusRepo := repositories.NewFocusSessionRepository(db)
+	submissionRepo := repositories.NewSubmissionRepository(db)
+	aiUsageRepo := repositories.NewAIUsageRepository(db)
+	aiQuestionRepo := repositories.NewAIQuestionRepository(db)
+	documentRepo := repositories.NewDocumentRepository(db)
+	mockInterviewRepo := repositories.NewMockInterviewRepository(db)
+	embeddingRepo := repositories.NewEmbeddingRepository(db)
+	adminSearchRepo := repositories.NewAdminSearchRepository(db)
+	billingRepo := repositories.NewBillingRepository(db)
+	catalogRepo := repositories.NewCatalogRepository(db)
+	feedbackRepo := repositories.NewFeedbackRepository(db)
+	linkCheckRepo := repositories.NewLinkCheckRepository(db)
+
+	// Load bundled starter content on empty databases when self-hosting
+	if opts.standalone {
+		if err := seed.EngBlogs(engBlogRepo); err != nil {
+			log.Fatal("Failed to seed starter content:", err)
+		}
+	}
+
+	// Initialize file storage
+	fileStorage, err := storage.New(cfg)
+	if err != nil {
+		log.Fatal("Failed to initialize file storage:", err)
+	}
+
+	// Initialize encryption for secret attachments (optional)
+	var keyring *secrets.Keyring
+	if cfg.AttachmentEncryptionKey != "" {
+		keyring, err = secrets.NewKeyring(cfg.AttachmentEncryptionKey)
+		if err != nil {
+			log.Fatal("Invalid ATTACHMENT_ENCRYPTION_KEY:", err)
+		}
+	}
+
+	// Initialize outgoing email
+	mail := mailer.New(cfg)
+
+	// Initialize the code runner that judges DSA submissions (nil when not configured)
+	codeRunner := runner.New(cfg)
+
+	// Initialize the language model behind AI features (nil when not configured)
+	llmProvider, err := llm.New(cfg)
+	if err != nil {
+		log.Fatal("Failed to initialize the LLM provider:", err)
+	}
+
+	// Initialize the embedding model behind similar items (nil when not configured, or when the
+	// database lacks pgvector)
+	embedder, err := llm.NewEmbedder(cfg)
+	if err != nil {
+		log.Fatal("Failed to initialize the embedding model:", err)
+	}
+	if embedder != nil {
+		if enabled, err := embeddingRepo.Enabled(); err != nil || !enabled {
+			log.Println("EMBEDDING_MODEL is set but the pgvector extension is unavailable: similar items are disabled")
+			embedder = nil
+		}
+	}
+
+	// Initialize push notification senders for the configured platforms
+	pushSenders, err := push.NewSenders(cfg)
+	if err != nil {
+		log.Fatal("Failed to initialize push notifications:", err)
+	}
+
+	// Initialize the event bus services publish to
+	bus, err := events.New(cfg)
+	if err != nil {
+		log.Fatal("Failed to initialize event bus:", err)
+	}
+	defer bus.Close()
+
+	// Initialize Stripe billing (optional)
+	var stripeClient *billing.Client
+	if cfg.BillingEnabled() {
+		stripeClient = billing.NewClient(cfg.StripeSecretKey)
+	}
+
+	// Initialize the GitHub integration for committing solutions (optional)
+	var githubClient *github.Client
+	if cfg.GitHubClientID != "" {
+		if cfg.GitHubClientSecret == "" || keyring == nil {
+			log.Fatal("GITHUB_CLIENT_ID requires GITHUB_CLIENT_SECRET and ATTACHMENT_ENCRYPTION_KEY")
+		}
+		githubClient = github.NewClient(cfg.GitHubClientID, cfg.GitHubClientSecret)
+	}
 
 	// Initialize services
-	itemService := services.NewItemService(itemRepo, statsRepo, testRepo)
-	statsService := services.NewStatsService(itemRepo, statsRepo)
-	userService := services.NewUserService(userRepo, statsRepo)
-	testService := services.NewTestService(testRepo, itemRepo)
+	notificationService := services.NewNotificationService(deviceRepo, pushSenders)
+	reminderChannels := append([]plugins.NotificationChannel{notificationService}, plugins.NotificationChannels()...)
+	billingService := services.NewBillingService(billingRepo, userRepo, stripeClient, cfg.StripeWebhookSecret, services.BillingPlans{
+		ProPriceID:       cfg.StripeProPriceID,
+		FreeMonthlyTests: int(cfg.BillingFreeMonthlyTests),
+		FreeOrgSeats:     int(cfg.BillingFreeOrgSeats),
+		TrialDays:        int(cfg.BillingTrialDays),
+		TrialNoticeDays:  int(cfg.BillingTrialNoticeDays),
+	}, mail, reminderChannels, cfg.AppBaseURL)
+	webhookService := services.NewWebhookService(webhookRepo, cfg.WebhookAllowPrivateTargets)
+	var linkEnricher *services.LinkEnricher
+	if cfg.LinkEnrichmentEnabled {
+		linkEnricher = services.NewLinkEnricher(time.Duration(cfg.LinkEnrichmentTimeoutSeconds) * time.Second)
+	}
+	categoryService := services.NewCategoryService(categoryRepo)
+	itemService := services.NewItemService(itemRepo, testRepo, hintRepo, statsRepo, txManager, categoryService, bus, linkEnricher)
+	statsService := services.NewStatsService(itemRepo, statsRepo, focusRepo, submissionRepo, categoryService)
+	statsWorker := services.NewStatsWorker(statsRepo)
+	metricsWorker := services.NewMetricsWorker(userRepo)
+	userService := services.NewUserService(userRepo, statsRepo, orgRepo, roleRepo, bus, services.OAuthProviders{
+		Google:   cfg.OAuthGoogle,
+		Facebook: cfg.OAuthFacebook,
+		Apple:    cfg.OAuthApple,
+	})
+	sessionService := services.NewSessionService(userRepo, mail, reminderChannels, cfg.AppBaseURL)
+	apiKeyService := services.NewAPIKeyService(apiKeyRepo, userRepo)
+	testService := services.NewTestService(testRepo, itemRepo, billingService, bus)
+	attachmentService := services.NewAttachmentService(attachmentRepo, itemRepo, fileStorage, cfg.UploadMaxBytes, cfg.UploadAllowedTypes, keyring)
+	hintService := services.NewHintService(hintRepo, itemRepo)
+	flashcardService := services.NewFlashcardService(flashcardRepo, itemRepo)
+	progressService := services.NewProgressService(userProgressRepo, itemRepo, bus)
+	companyService := services.NewCompanyService(companyRepo, itemRepo)
+	interviewService := services.NewInterviewService(interviewRepo, itemRepo, companyRepo)
+	behavioralService := services.NewBehavioralService(behavioralRepo)
+	quizService := services.NewQuizService(quizRepo, categoryService)
+	designNotesService := services.NewDesignNotesService(designNotesRepo, itemRepo)
+	flagService := services.NewFlagService(flagRepo, flagOverrides)
+	githubService := services.NewGitHubService(githubRepo, attachmentRepo, githubClient, keyring, cfg.JWTSecret, cfg.AppBaseURL)
+	submissionService := services.NewSubmissionService(submissionRepo, itemRepo, codeRunner, githubService)
+	focusService := services.NewFocusSessionService(focusRepo, itemRepo, testRepo)
+	recommendationService := services.NewRecommendationService(itemRepo)
+	catalogService := services.NewCatalogService(catalogRepo, categoryService, bus, cfg.Environment)
+	feedbackService := services.NewFeedbackService(feedbackRepo, itemRepo)
+	linkCheckService := services.NewLinkCheckService(linkCheckRepo, time.Duration(cfg.LinkCheckIntervalHours)*time.Hour)
+	aiBudgetService := services.NewAIBudgetService(aiUsageRepo, userRepo, billingService, map[models.Role]models.AIBudget{
+		models.RoleUser:  {MonthlyCalls: cfg.AIMonthlyCallBudget, MonthlyTokens: cfg.AIMonthlyTokenBudget},
+		models.RoleAdmin: {MonthlyCalls: cfg.AIAdminMonthlyCallBudget, MonthlyTokens: cfg.AIAdminMonthlyTokenBudget},
+	})
+	aiQuestionService := services.NewAIQuestionService(aiQuestionRepo, itemRepo, aiBudgetService, llmProvider)
+	aiSummaryService := services.NewAISummaryService(documentRepo, aiUsageRepo, aiBudgetService, categoryService, llmProvider)
+	documentService := services.NewDocumentService(documentRepo)
+	mockInterviewService := services.NewMockInterviewService(mockInterviewRepo, itemRepo, testRepo, aiBudgetService, llmProvider)
+	embeddingService := services.NewEmbeddingService(embeddingRepo, itemRepo, embedder)
+	adminSearchService := services.NewAdminSearchService(adminSearchRepo)
+	shareService := services.NewShareService(shareRepo, itemRepo, userRepo, mail, cfg.AppBaseURL)
+	orgService := services.NewOrgService(orgRepo, userRepo, billingService, mail, cfg.AppBaseURL)
+	groupService := services.NewGroupService(groupRepo, itemRepo, userRepo, mail, cfg.AppBaseURL)
+	reminderService := services.NewReminderService(notificationRepo, statsRepo, mail, reminderChannels, cfg.AppBaseURL)
+	profileService := services.NewProfileService(profileRepo, userRepo, statsRepo)
+	reportService := services.NewReportService(reportRepo, userRepo, statsService, cfg.ReportSigningKey, cfg.PublicBaseURL)
+	calendarService := services.NewCalendarService(calendarRepo, itemRepo, statsRepo, notificationRepo, cfg.PublicBaseURL, cfg.AppBaseURL)
+	lifecycleService := services.NewLifecycleService(lifecycleRepo, fileStorage, int(cfg.ArchiveInactiveMonths), time.Duration(cfg.AccountDeletionGraceDays)*24*time.Hour)
+	ingestionService := services.NewIngestionService(itemService, itemRepo, plugins.ItemSources())
+
+	// Event subscribers
+	statsWorker.Subscribe(bus)
+	metricsWorker.Subscribe(bus)
+	webhookService.Subscribe(bus)
+	notificationService.Subscribe(bus)
+	embeddingService.Subscribe(bus)
 
 	// Initialize handlers
 	itemHandler := handlers.NewItemHandler(itemService, userService)
 	statsHandler := handlers.NewStatsHandler(statsService)
-	authHandler := handlers.NewAuthHandler(cfg, userService)
-	engBlogHandler := handlers.NewEngBlogHandler(engBlogRepo)
+	authHandler := handlers.NewAuthHandler(cfg, userService, sessionService, apiKeyService, tokenIssuer)
+	engBlogHandler := handlers.NewEngBlogHandler(engBlogRepo, itemService, userService)
 	testHandler := handlers.NewTestHandler(testService)
+	attachmentHandler := handlers.NewAttachmentHandler(attachmentService, fileStorage)
+	hintHandler := handlers.NewHintHandler(hintService, userService)
+	flashcardHandler := handlers.NewFlashcardHandler(flashcardService)
+	progressHandler := handlers.NewProgressHandler(progressService)
+	companyHandler := handlers.NewCompanyHandler(companyService, userService)
+	categoryHandler := handlers.NewCategoryHandler(categoryService, userService)
+	behavioralHandler := handlers.NewBehavioralHandler(behavioralService, userService)
+	quizHandler := handlers.NewQuizHandler(quizService, userService)
+	documentHandler := handlers.NewDocumentHandler(documentService)
+	mockInterviewHandler := handlers.NewMockInterviewHandler(mockInterviewService)
+	similarItemHandler := handlers.NewSimilarItemHandler(embeddingService)
+	designNotesHandler := handlers.NewDesignNotesHandler(designNotesService)
+	submissionHandler := handlers.NewSubmissionHandler(submissionService, userService)
+	interviewHandler := handlers.NewInterviewHandler(interviewService)
+	focusHandler := handlers.NewFocusSessionHandler(focusService)
+	aiHandler := handlers.NewAIHandler(aiBudgetService, aiQuestionService, aiSummaryService)
+	recommendationHandler := handlers.NewRecommendationHandler(recommendationService)
+	catalogHandler := handlers.NewCatalogHandler(catalogService, userService)
+	feedbackHandler := handlers.NewFeedbackHandler(feedbackService, userService)
+	linkCheckHandler := handlers.NewLinkCheckHandler(linkCheckService, userService)
+	billingHandler := handlers.NewBillingHandler(billingService, userService)
+	shareHandler := handlers.NewShareHandler(shareService)
+	orgHandler := handlers.NewOrgHandler(orgService, userService)
+	groupHandler := handlers.NewGroupHandler(groupService)
+	notificationHandler := handlers.NewNotificationHandler(reminderService, notificationService)
+	webhookHandler := handlers.NewWebhookHandler(webhookService)
+	apiKeyHandler := handlers.NewAPIKeyHandler(apiKeyService)
+	calendarHandler := handlers.NewCalendarHandler(calendarService)
+	profileHandler := handlers.NewProfileHandler(profileService)
+	reportHandler := handlers.NewReportHandler(reportService)
+	githubHandler := handlers.NewGitHubHandler(githubService)
+	flagHandler := handlers.NewFlagHandler(flagService, userService)
+	adminHandler := handlers.NewAdminHandler(userService, adminSearchService)
+	lifecycleHandler := handlers.NewLifecycleHandler(lifecycleService, userService)
+	healthHandler := handlers.NewHealthHandler(healthChecks(cfg, db, fileStorage), userService)
+	configHandler := handlers.NewConfigHandler(cfg, categoryService)
+
+	// Background jobs
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	scheduler := jobs.NewScheduler(jobRepo)
+	jobService := services.NewJobService(jobRepo, scheduler)
+	if cfg.RefreshTokenCleanupIntervalHours > 0 {
+		scheduler.Register("cleanup-refresh-tokens", time.Duration(cfg.RefreshTokenCleanupIntervalHours)*time.Hour, func(ctx context.Context) error {
+			return userService.CleanupExpiredTokens()
+		})
+	}
+	scheduler.Register("prune-job-runs", 24*time.Hour, func(ctx context.Context) error {
+		return jobService.PruneRuns()
+	})
+	if cfg.RemindersEnabled {
+		scheduler.Register("send-reminders", time.Duration(cfg.ReminderIntervalMinutes)*time.Minute, func(ctx context.Context) error {
+			return reminderService.SendDueReminders(time.Now())
+		})
+	}
+	scheduler.Register("aggregate-org-analytics", 6*time.Hour, orgService.AggregateCohorts)
+	if cfg.ArchiveInactiveMonths > 0 {
+		scheduler.Register("archive-inactive-users", 24*time.Hour, lifecycleService.ArchiveInactiveUsers)
+	}
+	scheduler.Register("purge-deleted-accounts", time.Hour, lifecycleService.PurgeDeletedAccounts)
+	scheduler.Register("delete-expired-reports", 24*time.Hour, func(ctx context.Context) error {
+		return reportService.DeleteExpiredReports()
+	})
+	scheduler.Register("refresh-stats-aggregates", 10*time.Minute, statsWorker.RefreshStaleAggregates)
+	scheduler.Register("release-scheduled-items", time.Minute, itemService.ReleaseScheduledItems)
+	if billingService.Enabled() {
+		scheduler.Register("expire-trials", time.Hour, billingService.ExpireTrials)
+		scheduler.Register("send-dunning-reminders", 6*time.Hour, billingService.SendDunningReminders)
+	}
+	scheduler.Register("deliver-webhooks", time.Duration(cfg.WebhookDeliveryIntervalSeconds)*time.Second, webhookService.DeliverDue)
+	scheduler.Register("enqueue-weekly-digests", time.Hour, webhookService.EnqueueWeeklyDigests)
+	if ingestionService.HasSources() {
+		scheduler.Register("sync-item-sources", time.Duration(cfg.PluginSyncIntervalMinutes)*time.Minute, ingestionService.SyncSources)
+	}
+	if cfg.LinkCheckIntervalHours > 0 {
+		// Runs hourly so large catalogs are checked in batches; each link is re-checked once per interval
+		scheduler.Register("check-links", time.Hour, linkCheckService.CheckLinks)
+	}
+	if embeddingService.Enabled() {
+		scheduler.Register("refresh-item-embeddings", 10*time.Minute, embeddingService.RefreshEmbeddings)
+	}
+	scheduler.Start(ctx)
+	jobHandler := handlers.NewJobHandler(jobService, userService)
+
+	var debugHandler *handlers.DebugHandler
+	if injector != nil {
+		debugHandler = handlers.NewDebugHandler(injector, jobService, userService)
+	}
+
+	var metricsHandler *handlers.MetricsHandler
+	if cfg.MetricsToken != "" {
+		metricsHandler = handlers.NewMetricsHandler(metrics.Default, cfg.MetricsToken)
+	}
 
 	// Initialize and start server
-	srv := server.New(cfg, itemHandler, statsHandler, authHandler, engBlogHandler, testHandler, userProgressRepo)
+	srv := server.New(cfg, server.Handlers{
+		Item:        itemHandler,
+		Stats:       statsHandler,
+		Auth:        authHandler,
+		EngBlog:     engBlogHandler,
+		Test:        testHandler,
+		Attachment:  attachmentHandler,
+		Hint:        hintHandler,
+		Share:       shareHandler,
+		Org:         orgHandler,
+		Group:       groupHandler,
+		Notify:      notificationHandler,
+		Webhook:     webhookHandler,
+		APIKey:      apiKeyHandler,
+		Calendar:    calendarHandler,
+		Profile:     profileHandler,
+		Report:      reportHandler,
+		GitHub:      githubHandler,
+		Flag:        flagHandler,
+		Admin:       adminHandler,
+		Job:         jobHandler,
+		Lifecycle:   lifecycleHandler,
+		Flashcard:   flashcardHandler,
+		Progress:    progressHandler,
+		Company:     companyHandler,
+		Category:    categoryHandler,
+		Behavioral:  behavioralHandler,
+		Quiz:        quizHandler,
+		Document:    documentHandler,
+		Interviewer: mockInterviewHandler,
+		Similar:     similarItemHandler,
+		DesignNotes: designNotesHandler,
+		Submission:  submissionHandler,
+		Interview:   interviewHandler,
+		Focus:       focusHandler,
+		AI:          aiHandler,
+		Billing:     billingHandler,
+		Recommend:   recommendationHandler,
+		Catalog:     catalogHandler,
+		Feedback:    feedbackHandler,
+		LinkCheck:   linkCheckHandler,
+		Config:      configHandler,
+		Health:      healthHandler,
+		Debug:       debugHandler,
+		Metrics:     metricsHandler,
+		LoadUser:    loadRequestUser(userService, notificationRepo),
+
+		EvaluateFlags: flagService.Evaluate,
+	}, userProgressRepo)
+
+	if opts.standalone {
+		if assets, ok := web.Assets(); ok {
+			srv.ServeFrontend(assets)
+			log.Println("Standalone mode: serving the embedded frontend")
+		} else {
+			log.Println("Standalone mode: no frontend embedded in this build, serving the API only")
+		}
+	}
 
 	log.Printf("Server starting on port %s", cfg.Port)
-	log.Printf("Server configuration: %+v", cfg)
+	log.Printf("Server configuration: %s", cfg)
 	if err := srv.Start(); err != nil {
 		log.Fatal("Failed to start server:", err)
 	}
 }
 
+// loadRequestUser loads a request's user with the settings handlers need, such as their timezone
+func loadRequestUser(userService *services.UserService, notificationRepo *repositories.NotificationRepository) requestuser.LoadFunc {
+	return func(userID int) (*requestuser.User, error) {
+		user, err := userService.GetByID(userID)
+		if err != nil {
+			return nil, err
+		}
+
+		settings, err := notificationRepo.GetPreferences(userID)
+		if err != nil {
+			return nil, err
+		}
+
+		return requestuser.New(user, settings), nil
+	}
+}
+
+// healthChecks lists the dependencies reported under /healthz. Only Postgres is required for
+// the API to serve requests; the rest degrade individual features when down.
+func healthChecks(cfg *config.Config, db *sql.DB, fileStorage storage.Storage) []health.Check {
+	checks := []health.Check{
+		{Name: "postgres", Required: true, Probe: health.Postgres(db)},
+		{Name: "object_storage", Probe: fileStorage.Check},
+		{Name: "leetcode", Probe: health.HTTP(&http.Client{}, http.MethodHead, "https://leetcode.com/graphql")},
+	}
+
+	if cfg.SMTPHost != "" {
+		checks = append(checks, health.Check{Name: "smtp", Probe: health.SMTP(cfg.SMTPHost, cfg.SMTPPort)})
+	}
+
+	if cfg.EventBusBackend == "nats" {
+		if parsed, err := url.Parse(cfg.EventBusURL); err == nil && parsed.Host != "" {
+			address := parsed.Host
+			if parsed.Port() == "" {
+				address = net.JoinHostPort(parsed.Hostname(), "4222")
+			}
+			checks = append(checks, health.Check{Name: "event_bus", Probe: health.TCP(address)})
+		}
+	}
+
+	return checks
+}
@@ -0,0 +1,236 @@
+// Package billing talks to Stripe: customers, Checkout and customer portal sessions, and
+// verification of webhook events.
+package billing
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"interview-prep-app/pkg/apperr"
+)
+
+const (
+	stripeAPIURL = "https://api.stripe.com/v1"
+
+	// webhookTolerance is how old a signed webhook may be before it's rejected as a replay
+	webhookTolerance = 5 * time.Minute
+)
+
+// ErrInvalidSignature is returned when a webhook payload doesn't carry a valid, recent signature
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+// Client calls the Stripe API with a secret key
+type Client struct {
+	secretKey string
+	baseURL   string
+	client    *http.Client
+}
+
+// NewClient creates a Stripe client
+func NewClient(secretKey string) *Client {
+	return &Client{
+		secretKey: secretKey,
+		baseURL:   stripeAPIURL,
+		client:    &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// CreateCustomer creates a Stripe customer tagged with the app's user ID and returns its ID
+func (c *Client) CreateCustomer(ctx context.Context, email string, userID int) (string, error) {
+	form := url.Values{}
+	form.Set("email", email)
+	form.Set("metadata[user_id]", strconv.Itoa(userID))
+
+	var customer struct {
+		ID string `json:"id"`
+	}
+	if err := c.post(ctx, "/customers", form, &customer); err != nil {
+		return "", apperr.Errorf("failed to create Stripe customer: %w", err)
+	}
+
+	return customer.ID, nil
+}
+
+// CreateCheckoutSession starts a subscription Checkout for the customer and returns its URL
+func (c *Client) CreateCheckoutSession(ctx context.Context, customerID, priceID, successURL, cancelURL string) (string, error) {
+	form := url.Values{}
+	form.Set("mode", "subscription")
+	form.Set("customer", customerID)
+	form.Set("line_items[0][price]", priceID)
+	form.Set("line_items[0][quantity]", "1")
+	form.Set("success_url", successURL)
+	form.Set("cancel_url", cancelURL)
+
+	var session struct {
+		URL string `json:"url"`
+	}
+	if err := c.post(ctx, "/checkout/sessions", form, &session); err != nil {
+		return "", apperr.Errorf("failed to create checkout session: %w", err)
+	}
+
+	return session.URL, nil
+}
+
+// CreatePortalSession opens the customer portal for managing the subscription and returns its URL
+func (c *Client) CreatePortalSession(ctx context.Context, customerID, returnURL string) (string, error) {
+	form := url.Values{}
+	form.Set("customer", customerID)
+	form.Set("return_url", returnURL)
+
+	var session struct {
+		URL string `json:"url"`
+	}
+	if err := c.post(ctx, "/billing_portal/sessions", form, &session); err != nil {
+		return "", apperr.Errorf("failed to create portal session: %w", err)
+	}
+
+	return session.URL, nil
+}
+
+// post sends a form-encoded request and decodes the JSON response into out
+func (c *Client) post(ctx context.Context, path string, form url.Values, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.secretKey)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode >= 300 {
+		var apiErr struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if json.Unmarshal(body, &apiErr) == nil && apiErr.Error.Message != "" {
+			return fmt.Errorf("stripe returned %d: %s", resp.StatusCode, apiErr.Error.Message)
+		}
+		return fmt.Errorf("stripe returned %d", resp.StatusCode)
+	}
+
+	return json.Unmarshal(body, out)
+}
+
+// Event is a Stripe webhook event
+type Event struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	Data struct {
+		Object json.RawMessage `json:"object"`
+	} `json:"data"`
+}
+
+// Subscription is the subset of a Stripe subscription object the app tracks
+type Subscription struct {
+	ID                string `json:"id"`
+	Customer          string `json:"customer"`
+	Status            string `json:"status"`
+	CancelAtPeriodEnd bool   `json:"cancel_at_period_end"`
+	CurrentPeriodEnd  int64  `json:"current_period_end"`
+	TrialEnd          int64  `json:"trial_end"`
+	Items             struct {
+		Data []struct {
+			Price struct {
+				ID string `json:"id"`
+			} `json:"price"`
+		} `json:"data"`
+	} `json:"items"`
+}
+
+// PriceIDs returns the IDs of the prices the subscription is for
+func (s *Subscription) PriceIDs() []string {
+	ids := make([]string, 0, len(s.Items.Data))
+	for _, item := range s.Items.Data {
+		ids = append(ids, item.Price.ID)
+	}
+	return ids
+}
+
+// Invoice is the subset of a Stripe invoice object the app uses for dunning
+type Invoice struct {
+	ID                 string `json:"id"`
+	Customer           string `json:"customer"`
+	Subscription       string `json:"subscription"`
+	AttemptCount       int    `json:"attempt_count"`
+	NextPaymentAttempt int64  `json:"next_payment_attempt"`
+	HostedInvoiceURL   string `json:"hosted_invoice_url"`
+}
+
+// ParseWebhook verifies the Stripe-Signature header of a webhook payload against the endpoint
+// secret and decodes the event
+func ParseWebhook(payload []byte, signatureHeader, secret string, now time.Time) (*Event, error) {
+	var timestamp int64
+	var signatures []string
+	for _, part := range strings.Split(signatureHeader, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			timestamp, _ = strconv.ParseInt(value, 10, 64)
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+
+	if timestamp == 0 || len(signatures) == 0 {
+		return nil, ErrInvalidSignature
+	}
+
+	signedAt := time.Unix(timestamp, 0)
+	if now.Sub(signedAt) > webhookTolerance || signedAt.Sub(now) > webhookTolerance {
+		return nil, ErrInvalidSignature
+	}
+
+	expected := computeSignature(payload, timestamp, secret)
+	valid := false
+	for _, signature := range signatures {
+		decoded, err := hex.DecodeString(signature)
+		if err == nil && hmac.Equal(decoded, expected) {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return nil, ErrInvalidSignature
+	}
+
+	var event Event
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("invalid webhook payload: %w", err)
+	}
+
+	return &event, nil
+}
+
+// computeSignature returns the HMAC-SHA256 Stripe signs "<timestamp>.<payload>" with
+func computeSignature(payload []byte, timestamp int64, secret string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,39 @@
+package billing
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func signedHeader(payload []byte, secret string, at time.Time) string {
+	return fmt.Sprintf("t=%d,v1=%s", at.Unix(), hex.EncodeToString(computeSignature(payload, at.Unix(), secret)))
+}
+
+func TestParseWebhookVerifiesSignature(t *testing.T) {
+	payload := []byte(`{"id":"evt_1","type":"customer.subscription.updated","data":{"object":{"id":"sub_1","status":"active"}}}`)
+	now := time.Unix(1700000000, 0)
+
+	event, err := ParseWebhook(payload, signedHeader(payload, "whsec_test", now), "whsec_test", now)
+	if err != nil {
+		t.Fatalf("expected valid signature, got %v", err)
+	}
+	if event.ID != "evt_1" || event.Type != "customer.subscription.updated" {
+		t.Fatalf("unexpected event: %+v", event)
+	}
+
+	if _, err := ParseWebhook(payload, signedHeader(payload, "other_secret", now), "whsec_test", now); err != ErrInvalidSignature {
+		t.Fatalf("expected ErrInvalidSignature for wrong secret, got %v", err)
+	}
+
+	tampered := []byte(`{"id":"evt_2"}`)
+	if _, err := ParseWebhook(tampered, signedHeader(payload, "whsec_test", now), "whsec_test", now); err != ErrInvalidSignature {
+		t.Fatalf("expected ErrInvalidSignature for tampered payload, got %v", err)
+	}
+
+	old := now.Add(-10 * time.Minute)
+	if _, err := ParseWebhook(payload, signedHeader(payload, "whsec_test", old), "whsec_test", now); err != ErrInvalidSignature {
+		t.Fatalf("expected ErrInvalidSignature for stale signature, got %v", err)
+	}
+}
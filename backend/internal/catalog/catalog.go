@@ -0,0 +1,279 @@
+// Package catalog compares catalog snapshots: it validates a snapshot exported from one
+// environment and works out, by natural keys, what applying it to another would change.
+package catalog
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"interview-prep-app/internal/models"
+)
+
+// NormalizeLink puts a link in the form catalog entries are matched on
+func NormalizeLink(link string) string {
+	return strings.TrimRight(strings.ToLower(strings.TrimSpace(link)), "/")
+}
+
+// Validate checks a snapshot can be applied: a supported version, required fields and no natural
+// key used twice. Whether its categories exist depends on the target environment, so that's left
+// to the caller.
+func Validate(snapshot *models.CatalogSnapshot) error {
+	if snapshot.Version < 1 || snapshot.Version > models.CatalogSnapshotVersion {
+		return fmt.Errorf("unsupported snapshot version: %d", snapshot.Version)
+	}
+
+	companies := map[string]bool{}
+	for _, company := range snapshot.Companies {
+		if company.Slug == "" || strings.TrimSpace(company.Name) == "" {
+			return fmt.Errorf("company slug and name are required")
+		}
+		if companies[company.Slug] {
+			return fmt.Errorf("duplicate company: %s", company.Slug)
+		}
+		companies[company.Slug] = true
+	}
+
+	items := map[string]bool{}
+	for _, item := range snapshot.Items {
+		if strings.TrimSpace(item.Title) == "" {
+			return fmt.Errorf("item title is required: %s", item.Link)
+		}
+		if err := validateLink(item.Link); err != nil {
+			return fmt.Errorf("item %q: %w", item.Title, err)
+		}
+		if item.Category == "" {
+			return fmt.Errorf("item %q: category is required", item.Title)
+		}
+		if strings.TrimSpace(item.Subcategory) == "" {
+			return fmt.Errorf("item %q: subcategory is required", item.Title)
+		}
+		key := NormalizeLink(item.Link)
+		if items[key] {
+			return fmt.Errorf("duplicate item link: %s", item.Link)
+		}
+		items[key] = true
+
+		for _, hint := range item.Hints {
+			if strings.TrimSpace(hint) == "" {
+				return fmt.Errorf("item %q: hints cannot be empty", item.Title)
+			}
+		}
+		for _, slug := range item.Companies {
+			if !companies[slug] {
+				return fmt.Errorf("item %q: company %s is not in the snapshot", item.Title, slug)
+			}
+		}
+	}
+
+	blogs := map[string]bool{}
+	for _, blog := range snapshot.EngBlogs {
+		if strings.TrimSpace(blog.Name) == "" {
+			return fmt.Errorf("blog name is required: %s", blog.Link)
+		}
+		if err := validateLink(blog.Link); err != nil {
+			return fmt.Errorf("blog %q: %w", blog.Name, err)
+		}
+		key := NormalizeLink(blog.Link)
+		if blogs[key] {
+			return fmt.Errorf("duplicate blog link: %s", blog.Link)
+		}
+		blogs[key] = true
+
+		articles := map[string]bool{}
+		for _, article := range blog.Articles {
+			if strings.TrimSpace(article.Title) == "" {
+				return fmt.Errorf("blog %q: article title is required", blog.Name)
+			}
+			if err := validateLink(article.ExternalLink); err != nil {
+				return fmt.Errorf("blog %q article %q: %w", blog.Name, article.Title, err)
+			}
+			key := NormalizeLink(article.ExternalLink)
+			if articles[key] {
+				return fmt.Errorf("blog %q: duplicate article link: %s", blog.Name, article.ExternalLink)
+			}
+			articles[key] = true
+		}
+	}
+
+	return nil
+}
+
+// validateLink requires an absolute http(s) URL
+func validateLink(link string) error {
+	link = strings.ToLower(strings.TrimSpace(link))
+	if !strings.HasPrefix(link, "http://") && !strings.HasPrefix(link, "https://") {
+		return fmt.Errorf("link must be an http(s) URL: %q", link)
+	}
+	return nil
+}
+
+// Diff reports what applying incoming on top of current creates and updates. Nothing is ever
+// deleted, so entries only in current don't appear.
+func Diff(current, incoming *models.CatalogSnapshot) *models.CatalogDiff {
+	diff := &models.CatalogDiff{Changes: []models.CatalogChange{}}
+
+	currentCompanies := map[string]models.CatalogCompany{}
+	for _, company := range current.Companies {
+		currentCompanies[company.Slug] = company
+	}
+	for _, company := range incoming.Companies {
+		existing, ok := currentCompanies[company.Slug]
+		switch {
+		case !ok:
+			addChange(diff, models.CatalogEntityCompany, company.Slug, models.CatalogActionCreate, nil)
+		case existing.Name != company.Name:
+			addChange(diff, models.CatalogEntityCompany, company.Slug, models.CatalogActionUpdate, []string{"name"})
+		default:
+			diff.Unchanged++
+		}
+	}
+
+	currentItems := map[string]models.CatalogItem{}
+	for _, item := range current.Items {
+		currentItems[NormalizeLink(item.Link)] = item
+	}
+	for _, item := range incoming.Items {
+		existing, ok := currentItems[NormalizeLink(item.Link)]
+		if !ok {
+			addChange(diff, models.CatalogEntityItem, item.Link, models.CatalogActionCreate, nil)
+			continue
+		}
+
+		changed := false
+		if fields := itemFieldChanges(existing, item); len(fields) > 0 {
+			addChange(diff, models.CatalogEntityItem, item.Link, models.CatalogActionUpdate, fields)
+			changed = true
+		}
+		if HintsChanged(existing.Hints, item.Hints) {
+			action := models.CatalogActionUpdate
+			if len(existing.Hints) == 0 {
+				action = models.CatalogActionCreate
+			}
+			addChange(diff, models.CatalogEntityItemHints, item.Link, action, nil)
+			changed = true
+		}
+		if missing := missingStrings(existing.Companies, item.Companies); len(missing) > 0 {
+			addChange(diff, models.CatalogEntityItemCompanies, item.Link, models.CatalogActionCreate, missing)
+			changed = true
+		}
+		if !changed {
+			diff.Unchanged++
+		}
+	}
+
+	currentBlogs := map[string]models.CatalogEngBlog{}
+	for _, blog := range current.EngBlogs {
+		currentBlogs[NormalizeLink(blog.Link)] = blog
+	}
+	for _, blog := range incoming.EngBlogs {
+		existing, ok := currentBlogs[NormalizeLink(blog.Link)]
+		if !ok {
+			addChange(diff, models.CatalogEntityEngBlog, blog.Link, models.CatalogActionCreate, nil)
+			continue
+		}
+
+		var fields []string
+		if existing.Name != blog.Name {
+			fields = append(fields, "name")
+		}
+		if existing.OrderIdx != blog.OrderIdx {
+			fields = append(fields, "order_idx")
+		}
+		if len(fields) > 0 {
+			addChange(diff, models.CatalogEntityEngBlog, blog.Link, models.CatalogActionUpdate, fields)
+		} else {
+			diff.Unchanged++
+		}
+
+		currentArticles := map[string]models.CatalogEngBlogArticle{}
+		for _, article := range existing.Articles {
+			currentArticles[NormalizeLink(article.ExternalLink)] = article
+		}
+		for _, article := range blog.Articles {
+			existingArticle, ok := currentArticles[NormalizeLink(article.ExternalLink)]
+			if !ok {
+				addChange(diff, models.CatalogEntityEngBlogArticle, article.ExternalLink, models.CatalogActionCreate, nil)
+				continue
+			}
+
+			var fields []string
+			if existingArticle.Title != article.Title {
+				fields = append(fields, "title")
+			}
+			if existingArticle.OrderIdx != article.OrderIdx {
+				fields = append(fields, "order_idx")
+			}
+			if len(fields) > 0 {
+				addChange(diff, models.CatalogEntityEngBlogArticle, article.ExternalLink, models.CatalogActionUpdate, fields)
+			} else {
+				diff.Unchanged++
+			}
+		}
+	}
+
+	return diff
+}
+
+// HintsChanged reports whether applying incoming hints changes the current ones. Hints are
+// matched by position; current hints past the end of incoming are kept.
+func HintsChanged(current, incoming []string) bool {
+	for i, hint := range incoming {
+		if i >= len(current) || current[i] != hint {
+			return true
+		}
+	}
+	return false
+}
+
+// addChange records a change and counts it
+func addChange(diff *models.CatalogDiff, entity, key, action string, fields []string) {
+	diff.Changes = append(diff.Changes, models.CatalogChange{Entity: entity, Key: key, Action: action, Fields: fields})
+	if action == models.CatalogActionCreate {
+		diff.Created++
+	} else {
+		diff.Updated++
+	}
+}
+
+// itemFieldChanges lists the item fields that differ
+func itemFieldChanges(current, incoming models.CatalogItem) []string {
+	var fields []string
+	if current.Title != incoming.Title {
+		fields = append(fields, "title")
+	}
+	if current.Category != incoming.Category {
+		fields = append(fields, "category")
+	}
+	if current.Subcategory != incoming.Subcategory {
+		fields = append(fields, "subcategory")
+	}
+	if !AttachmentsEqual(current.Attachments, incoming.Attachments) {
+		fields = append(fields, "attachments")
+	}
+	return fields
+}
+
+// AttachmentsEqual compares item attachments, treating nil and empty as the same
+func AttachmentsEqual(a, b models.Attachments) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// missingStrings returns the values of want that aren't in have
+func missingStrings(have, want []string) []string {
+	present := map[string]bool{}
+	for _, value := range have {
+		present[value] = true
+	}
+
+	var missing []string
+	for _, value := range want {
+		if !present[value] {
+			missing = append(missing, value)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,102 @@
+package catalog
+
+import (
+	"testing"
+
+	"interview-prep-app/internal/models"
+)
+
+func TestDiffMatchesByNaturalKeys(t *testing.T) {
+	current := &models.CatalogSnapshot{
+		Version:   models.CatalogSnapshotVersion,
+		Companies: []models.CatalogCompany{{Slug: "acme", Name: "Acme"}},
+		Items: []models.CatalogItem{
+			{Title: "Two Sum", Link: "https://leetcode.com/problems/two-sum/", Category: models.CategoryDSA, Subcategory: "arrays", Hints: []string{"Use a map"}},
+		},
+		EngBlogs: []models.CatalogEngBlog{
+			{Name: "Acme Eng", Link: "https://eng.acme.com", Articles: []models.CatalogEngBlogArticle{{Title: "Scaling", ExternalLink: "https://eng.acme.com/scaling"}}},
+		},
+	}
+
+	incoming := &models.CatalogSnapshot{
+		Version:   models.CatalogSnapshotVersion,
+		Companies: []models.CatalogCompany{{Slug: "acme", Name: "Acme"}, {Slug: "globex", Name: "Globex"}},
+		Items: []models.CatalogItem{
+			{Title: "Two Sum", Link: "HTTPS://leetcode.com/problems/two-sum", Category: models.CategoryDSA, Subcategory: "hashing", Hints: []string{"Use a map"}, Companies: []string{"globex"}},
+			{Title: "Design a Cache", Link: "https://example.com/cache", Category: models.CategoryLLD, Subcategory: "lld-interview-questions"},
+		},
+		EngBlogs: []models.CatalogEngBlog{
+			{Name: "Acme Eng", Link: "https://eng.acme.com/", Articles: []models.CatalogEngBlogArticle{
+				{Title: "Scaling", ExternalLink: "https://eng.acme.com/scaling"},
+				{Title: "Caching", ExternalLink: "https://eng.acme.com/caching"},
+			}},
+		},
+	}
+
+	if err := Validate(incoming); err != nil {
+		t.Fatalf("expected valid snapshot, got %v", err)
+	}
+
+	diff := Diff(current, incoming)
+	if diff.Created != 4 || diff.Updated != 1 || diff.Unchanged != 3 {
+		t.Fatalf("unexpected counts: created=%d updated=%d unchanged=%d (%+v)", diff.Created, diff.Updated, diff.Unchanged, diff.Changes)
+	}
+
+	again := Diff(incoming, incoming)
+	if len(again.Changes) != 0 {
+		t.Fatalf("expected applying a snapshot twice to change nothing, got %+v", again.Changes)
+	}
+}
+
+func TestValidateRejectsUnknownCompanies(t *testing.T) {
+	snapshot := &models.CatalogSnapshot{
+		Version: models.CatalogSnapshotVersion,
+		Items: []models.CatalogItem{
+			{Title: "Two Sum", Link: "https://leetcode.com/problems/two-sum", Category: models.CategoryDSA, Subcategory: "arrays", Companies: []string{"initech"}},
+		},
+	}
+
+	if err := Validate(snapshot); err == nil {
+		t.Fatal("expected an error for an item tagged with a company missing from the snapshot")
+	}
+}
+
+func TestEngBlogSnapshotMergesDuplicates(t *testing.T) {
+	current := []models.CatalogEngBlog{{Name: "Acme Eng", Link: "https://eng.acme.com"}}
+
+	blogs := []models.EngBlog{
+		{Name: "Acme Eng", Link: "https://acme.com/engineering", OrderIdx: 1, PracticeProblems: []models.EngBlogProblem{
+			{Title: "Scaling", ExternalLink: "https://eng.acme.com/scaling"},
+		}},
+		{Name: "Globex", Link: "https://globex.dev/blog", OrderIdx: 2},
+		{Name: "Globex Engineering", Link: "https://globex.dev/blog/", PracticeProblems: []models.EngBlogProblem{
+			{Title: "Queues", ExternalLink: "https://globex.dev/blog/queues"},
+		}},
+		{Name: " acme eng ", Link: "https://other.acme.com", PracticeProblems: []models.EngBlogProblem{
+			{Title: "Scaling again", ExternalLink: "https://eng.acme.com/scaling/"},
+			{Title: "Caching", ExternalLink: "https://eng.acme.com/caching"},
+		}},
+	}
+
+	snapshot, notes := EngBlogSnapshot(blogs, current)
+	if err := Validate(snapshot); err != nil {
+		t.Fatalf("expected valid snapshot, got %v", err)
+	}
+
+	if len(snapshot.EngBlogs) != 2 {
+		t.Fatalf("expected 2 blogs, got %+v", snapshot.EngBlogs)
+	}
+	acme, globex := snapshot.EngBlogs[0], snapshot.EngBlogs[1]
+	if acme.Link != "https://eng.acme.com" {
+		t.Errorf("expected the existing blog's link to be adopted, got %s", acme.Link)
+	}
+	if len(acme.Articles) != 2 || acme.Articles[1].Title != "Caching" {
+		t.Errorf("expected the duplicate article to be dropped, got %+v", acme.Articles)
+	}
+	if globex.Name != "Globex" || len(globex.Articles) != 1 {
+		t.Errorf("expected blogs with the same link to merge, got %+v", globex)
+	}
+	if len(notes) != 5 {
+		t.Errorf("expected 5 notes, got %d: %v", len(notes), notes)
+	}
+}
@@ -0,0 +1,158 @@
+package catalog
+
+import (
+	"net/url"
+	"strings"
+	"unicode"
+
+	"interview-prep-app/internal/links"
+	"interview-prep-app/internal/models"
+)
+
+// DuplicateTitleThreshold is how similar two normalized titles must be, from 0 to 1, for the
+// items to be reported as likely duplicates
+const DuplicateTitleThreshold = 0.85
+
+// variantTokens mark numbered variants of a problem ("Jump Game II"), which are different
+// problems however close the rest of the title is
+var variantTokens = map[string]bool{
+	"i": true, "ii": true, "iii": true, "iv": true, "v": true,
+	"vi": true, "vii": true, "viii": true, "ix": true, "x": true,
+}
+
+// LinkKey reduces a link to the page it points at for duplicate detection: the normalized link's
+// host without "www." and path without a trailing slash, lowercased, ignoring scheme, query and fragment
+func LinkKey(link string) string {
+	link = links.Normalize(link)
+	u, err := url.Parse(link)
+	if err != nil || u.Host == "" {
+		return NormalizeLink(link)
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(u.Host), "www.")
+	return host + strings.TrimRight(strings.ToLower(u.Path), "/")
+}
+
+// NormalizeTitle lowercases a title, turns punctuation into spaces and drops a leading problem
+// number, so "1. Two-Sum" and "two sum" compare equal
+func NormalizeTitle(title string) string {
+	fields := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	for len(fields) > 1 && isNumber(fields[0]) {
+		fields = fields[1:]
+	}
+
+	return strings.Join(fields, " ")
+}
+
+// TitleSimilarity scores how alike two titles are from 0 to 1 by edit distance between their
+// normalized forms. Titles that differ in a variant number ("II", "3") score 0.
+func TitleSimilarity(a, b string) float64 {
+	a, b = NormalizeTitle(a), NormalizeTitle(b)
+	if a == b {
+		return 1
+	}
+	if a == "" || b == "" || variantOf(a) != variantOf(b) {
+		return 0
+	}
+
+	ra, rb := []rune(a), []rune(b)
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+// FindItemDuplicates returns the existing items a new item appears to duplicate: the same page
+// by LinkKey, or a title at least DuplicateTitleThreshold similar
+func FindItemDuplicates(title, link string, existing []*models.Item) []models.ItemConflict {
+	conflicts := []models.ItemConflict{}
+	key := LinkKey(link)
+	normalized := NormalizeTitle(title)
+
+	for _, item := range existing {
+		if LinkKey(item.Link) == key {
+			conflicts = append(conflicts, itemConflict(item, models.ItemConflictLink, 1))
+			continue
+		}
+
+		if !couldBeSimilar(normalized, NormalizeTitle(item.Title)) {
+			continue
+		}
+		if similarity := TitleSimilarity(title, item.Title); similarity >= DuplicateTitleThreshold {
+			conflicts = append(conflicts, itemConflict(item, models.ItemConflictTitle, similarity))
+		}
+	}
+
+	return conflicts
+}
+
+// itemConflict describes an existing item as a conflict
+func itemConflict(item *models.Item, reason models.ItemConflictReason, similarity float64) models.ItemConflict {
+	return models.ItemConflict{
+		ItemID:     item.ID,
+		Title:      item.Title,
+		Link:       item.Link,
+		Category:   item.Category,
+		Reason:     reason,
+		Similarity: similarity,
+	}
+}
+
+// couldBeSimilar rules out pairs whose lengths alone keep them under the threshold, which
+// skips most edit distance calculations when checking against a whole catalog
+func couldBeSimilar(a, b string) bool {
+	la, lb := len([]rune(a)), len([]rune(b))
+	if la < lb {
+		la, lb = lb, la
+	}
+	return la > 0 && float64(la-lb)/float64(la) <= 1-DuplicateTitleThreshold
+}
+
+// variantOf returns the variant number tokens of a normalized title
+func variantOf(title string) string {
+	variant := []string{}
+	for _, token := range strings.Fields(title) {
+		if variantTokens[token] || isNumber(token) {
+			variant = append(variant, token)
+		}
+	}
+	return strings.Join(variant, " ")
+}
+
+// isNumber reports whether a token is all digits
+func isNumber(token string) bool {
+	for _, r := range token {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return token != ""
+}
+
+// levenshtein returns the edit distance between two strings
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
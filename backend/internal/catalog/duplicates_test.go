@@ -0,0 +1,66 @@
+package catalog
+
+import (
+	"testing"
+
+	"interview-prep-app/internal/models"
+)
+
+func TestLinkKeyIgnoresSchemeWWWQueryAndSlash(t *testing.T) {
+	want := LinkKey("https://leetcode.com/problems/two-sum")
+	for _, link := range []string{
+		"http://www.leetcode.com/problems/two-sum/",
+		" HTTPS://LeetCode.com/problems/Two-Sum?envType=study-plan#description ",
+	} {
+		if got := LinkKey(link); got != want {
+			t.Fatalf("LinkKey(%q) = %q, want %q", link, got, want)
+		}
+	}
+
+	if LinkKey("https://leetcode.com/problems/two-sum-ii") == want {
+		t.Fatal("expected different problems to have different keys")
+	}
+}
+
+func TestTitleSimilarity(t *testing.T) {
+	cases := []struct {
+		a, b    string
+		similar bool
+	}{
+		{"Two Sum", "1. Two-Sum", true},
+		{"Longest Palindromic Substring", "Longest Palindromic Substrings", true},
+		{"Best Time to Buy and Sell Stock", "Best Time to Buy and Sell Stock II", false},
+		{"Jump Game II", "Jump Game III", false},
+		{"Two Sum", "Three Sum", false},
+	}
+
+	for _, tc := range cases {
+		similarity := TitleSimilarity(tc.a, tc.b)
+		if (similarity >= DuplicateTitleThreshold) != tc.similar {
+			t.Errorf("TitleSimilarity(%q, %q) = %.2f, expected similar=%v", tc.a, tc.b, similarity, tc.similar)
+		}
+	}
+}
+
+func TestFindItemDuplicates(t *testing.T) {
+	existing := []*models.Item{
+		{ID: 1, Title: "Two Sum", Link: "https://leetcode.com/problems/two-sum/"},
+		{ID: 2, Title: "Valid Parentheses", Link: "https://leetcode.com/problems/valid-parentheses/"},
+		{ID: 3, Title: "Two Sum", Link: "https://www.geeksforgeeks.org/two-sum/"},
+	}
+
+	conflicts := FindItemDuplicates("Two sum", "https://www.leetcode.com/problems/two-sum", existing)
+	if len(conflicts) != 2 {
+		t.Fatalf("expected 2 conflicts, got %+v", conflicts)
+	}
+	if conflicts[0].ItemID != 1 || conflicts[0].Reason != models.ItemConflictLink {
+		t.Fatalf("expected a link conflict with item 1, got %+v", conflicts[0])
+	}
+	if conflicts[1].ItemID != 3 || conflicts[1].Reason != models.ItemConflictTitle {
+		t.Fatalf("expected a title conflict with item 3, got %+v", conflicts[1])
+	}
+
+	if conflicts := FindItemDuplicates("Merge Intervals", "https://leetcode.com/problems/merge-intervals/", existing); len(conflicts) != 0 {
+		t.Fatalf("expected no conflicts, got %+v", conflicts)
+	}
+}
@@ -0,0 +1,76 @@
+package catalog
+
+import (
+	"fmt"
+	"strings"
+
+	"interview-prep-app/internal/models"
+)
+
+// EngBlogSnapshot turns blogs in the eng-blogs.json format into a snapshot that only holds
+// engineering blogs. Blogs listed twice, by link or by name, are merged into the first, and
+// articles repeated within a blog are dropped. A blog named like one in current but with another
+// link takes current's link, so applying updates that blog instead of adding a second one. It
+// returns a note for every merge, dropped article and adopted link.
+func EngBlogSnapshot(blogs []models.EngBlog, current []models.CatalogEngBlog) (*models.CatalogSnapshot, []string) {
+	currentLinks := map[string]bool{}
+	currentByName := map[string]string{}
+	for _, blog := range current {
+		currentLinks[NormalizeLink(blog.Link)] = true
+		currentByName[nameKey(blog.Name)] = blog.Link
+	}
+
+	snapshot := &models.CatalogSnapshot{Version: models.CatalogSnapshotVersion, EngBlogs: []models.CatalogEngBlog{}}
+	var notes []string
+	byLink := map[string]int{}
+	byName := map[string]int{}
+	var articleLinks []map[string]bool
+
+	for _, blog := range blogs {
+		link := strings.TrimSpace(blog.Link)
+		if existing, ok := currentByName[nameKey(blog.Name)]; ok && !currentLinks[NormalizeLink(link)] {
+			notes = append(notes, fmt.Sprintf("blog %q: using the existing blog's link %s instead of %s", blog.Name, existing, link))
+			link = existing
+		}
+
+		i, ok := byLink[NormalizeLink(link)]
+		if ok {
+			notes = append(notes, fmt.Sprintf("blog %q: merged into %q, which has the same link", blog.Name, snapshot.EngBlogs[i].Name))
+		} else if i, ok = byName[nameKey(blog.Name)]; ok {
+			notes = append(notes, fmt.Sprintf("blog %q: merged into the blog of the same name at %s", blog.Name, snapshot.EngBlogs[i].Link))
+		} else {
+			i = len(snapshot.EngBlogs)
+			snapshot.EngBlogs = append(snapshot.EngBlogs, models.CatalogEngBlog{
+				Name:     strings.TrimSpace(blog.Name),
+				Link:     link,
+				OrderIdx: blog.OrderIdx,
+				Articles: []models.CatalogEngBlogArticle{},
+			})
+			articleLinks = append(articleLinks, map[string]bool{})
+			byLink[NormalizeLink(link)] = i
+			byName[nameKey(blog.Name)] = i
+		}
+
+		for _, article := range blog.PracticeProblems {
+			key := NormalizeLink(article.ExternalLink)
+			if articleLinks[i][key] {
+				notes = append(notes, fmt.Sprintf("blog %q: skipped duplicate article %s", snapshot.EngBlogs[i].Name, article.ExternalLink))
+				continue
+			}
+			articleLinks[i][key] = true
+
+			snapshot.EngBlogs[i].Articles = append(snapshot.EngBlogs[i].Articles, models.CatalogEngBlogArticle{
+				Title:        strings.TrimSpace(article.Title),
+				ExternalLink: strings.TrimSpace(article.ExternalLink),
+				OrderIdx:     article.OrderIdx,
+			})
+		}
+	}
+
+	return snapshot, notes
+}
+
+// nameKey is the form blog names are matched on
+func nameKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
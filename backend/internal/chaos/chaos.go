@@ -0,0 +1,99 @@
+package chaos
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrInjected is returned by the chaos database driver when a failure is injected
+var ErrInjected = fmt.Errorf("chaos: injected database error")
+
+// Settings describes the faults currently being injected
+type Settings struct {
+	LatencyMs         int        `json:"latency_ms"`
+	LatencyPathPrefix string     `json:"latency_path_prefix,omitempty"`
+	LatencyUntil      *time.Time `json:"latency_until,omitempty"`
+	DBErrorRate       float64    `json:"db_error_rate"`
+	DBErrorsUntil     *time.Time `json:"db_errors_until,omitempty"`
+}
+
+// Injector holds fault-injection settings. It is only wired up when debug endpoints are enabled.
+type Injector struct {
+	mu       sync.Mutex
+	settings Settings
+}
+
+// NewInjector creates an injector with no faults active
+func NewInjector() *Injector {
+	return &Injector{}
+}
+
+// Settings returns the active settings, clearing any that have expired
+func (i *Injector) Settings() Settings {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	now := time.Now()
+	if i.settings.LatencyUntil != nil && now.After(*i.settings.LatencyUntil) {
+		i.settings.LatencyMs, i.settings.LatencyPathPrefix, i.settings.LatencyUntil = 0, "", nil
+	}
+	if i.settings.DBErrorsUntil != nil && now.After(*i.settings.DBErrorsUntil) {
+		i.settings.DBErrorRate, i.settings.DBErrorsUntil = 0, nil
+	}
+
+	return i.settings
+}
+
+// SetLatency delays matching requests by the given amount for the given duration (0 = until reset)
+func (i *Injector) SetLatency(delay time.Duration, pathPrefix string, duration time.Duration) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.settings.LatencyMs = int(delay / time.Millisecond)
+	i.settings.LatencyPathPrefix = pathPrefix
+	i.settings.LatencyUntil = expiry(duration)
+}
+
+// SetDBErrorRate makes the given fraction of database calls fail for the given duration (0 = until reset)
+func (i *Injector) SetDBErrorRate(rate float64, duration time.Duration) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.settings.DBErrorRate = rate
+	i.settings.DBErrorsUntil = expiry(duration)
+}
+
+// Reset clears all injected faults
+func (i *Injector) Reset() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.settings = Settings{}
+}
+
+// Latency returns how long a request to the given path should be delayed
+func (i *Injector) Latency(path string) time.Duration {
+	s := i.Settings()
+	if s.LatencyMs <= 0 || !strings.HasPrefix(path, s.LatencyPathPrefix) {
+		return 0
+	}
+	return time.Duration(s.LatencyMs) * time.Millisecond
+}
+
+// ShouldFailDB reports whether the next database call should fail
+func (i *Injector) ShouldFailDB() bool {
+	rate := i.Settings().DBErrorRate
+	return rate > 0 && rand.Float64() < rate
+}
+
+// expiry converts a duration into an optional deadline
+func expiry(duration time.Duration) *time.Time {
+	if duration <= 0 {
+		return nil
+	}
+	until := time.Now().Add(duration)
+	return &until
+}
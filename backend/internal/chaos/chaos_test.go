@@ -0,0 +1,41 @@
+package chaos
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLatencyMatchesPathPrefix(t *testing.T) {
+	inj := NewInjector()
+	inj.SetLatency(200*time.Millisecond, "/api/v1/items", 0)
+
+	if got := inj.Latency("/api/v1/items/5"); got != 200*time.Millisecond {
+		t.Errorf("expected 200ms latency for matching path, got %v", got)
+	}
+	if got := inj.Latency("/api/v1/stats"); got != 0 {
+		t.Errorf("expected no latency for other paths, got %v", got)
+	}
+}
+
+func TestSettingsExpire(t *testing.T) {
+	inj := NewInjector()
+	inj.SetDBErrorRate(1, time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+
+	if inj.ShouldFailDB() {
+		t.Error("expected injected DB errors to expire")
+	}
+}
+
+func TestResetClearsFaults(t *testing.T) {
+	inj := NewInjector()
+	inj.SetDBErrorRate(1, 0)
+	if !inj.ShouldFailDB() {
+		t.Fatal("expected DB errors at rate 1")
+	}
+
+	inj.Reset()
+	if inj.ShouldFailDB() {
+		t.Error("expected no DB errors after reset")
+	}
+}
@@ -0,0 +1,113 @@
+package chaos
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"sync"
+)
+
+var (
+	registerMu sync.Mutex
+	registered = map[string]string{}
+)
+
+// RegisterDriver registers a driver wrapping an already registered one that fails calls according
+// to the injector's settings. It returns the name to open connections with.
+func RegisterDriver(injector *Injector, baseName string) (string, error) {
+	registerMu.Lock()
+	defer registerMu.Unlock()
+
+	name := baseName + "+chaos"
+	if _, ok := registered[baseName]; ok {
+		return name, nil
+	}
+
+	// Opening doesn't connect; it only looks up the registered driver
+	db, err := sql.Open(baseName, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to find database driver %s: %w", baseName, err)
+	}
+	base := db.Driver()
+	db.Close()
+
+	sql.Register(name, &chaosDriver{base: base, injector: injector})
+	registered[baseName] = name
+	return name, nil
+}
+
+// chaosDriver wraps the real driver
+type chaosDriver struct {
+	base     driver.Driver
+	injector *Injector
+}
+
+// Unwrap returns the wrapped driver
+func (d *chaosDriver) Unwrap() driver.Driver {
+	return d.base
+}
+
+// Open opens a wrapped connection
+func (d *chaosDriver) Open(name string) (driver.Conn, error) {
+	conn, err := d.base.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return &chaosConn{Conn: conn, injector: d.injector}, nil
+}
+
+// chaosConn injects failures before delegating to the real connection
+type chaosConn struct {
+	driver.Conn
+	injector *Injector
+}
+
+// Prepare prepares a statement unless a failure is injected
+func (c *chaosConn) Prepare(query string) (driver.Stmt, error) {
+	if c.injector.ShouldFailDB() {
+		return nil, ErrInjected
+	}
+	return c.Conn.Prepare(query)
+}
+
+// BeginTx starts a transaction unless a failure is injected
+func (c *chaosConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if c.injector.ShouldFailDB() {
+		return nil, ErrInjected
+	}
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin()
+}
+
+// QueryContext runs a query unless a failure is injected
+func (c *chaosConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if c.injector.ShouldFailDB() {
+		return nil, ErrInjected
+	}
+	if queryer, ok := c.Conn.(driver.QueryerContext); ok {
+		return queryer.QueryContext(ctx, query, args)
+	}
+	return nil, driver.ErrSkip
+}
+
+// ExecContext runs a statement unless a failure is injected
+func (c *chaosConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if c.injector.ShouldFailDB() {
+		return nil, ErrInjected
+	}
+	if execer, ok := c.Conn.(driver.ExecerContext); ok {
+		return execer.ExecContext(ctx, query, args)
+	}
+	return nil, driver.ErrSkip
+}
+
+// Ping checks the connection, passing through to the real driver
+func (c *chaosConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
@@ -1,36 +1,418 @@
 package config
 
 import (
+	"fmt"
+	"net/url"
 	"os"
+	"reflect"
+	"strconv"
 	"strings"
+	"time"
 )
 
-// Config holds all configuration for the application
+// Config holds all configuration for the application. Fields tagged secret are redacted when
+// the config is printed.
 type Config struct {
-	DatabaseURL   string
-	Port          string
-	Environment   string
-	AuthUsername  string
-	AuthPassword  string
-	AuthUsers     string // Comma-separated list of usernames
-	AuthPasswords string // Comma-separated list of passwords
-	JWTSecret     string
+	DatabaseURL    string `secret:"true"`
+	Port           string
+	Environment    string
+	AuthUsername   string
+	AuthPassword   string   `secret:"true"`
+	AuthUsers      string   // Comma-separated list of usernames
+	AuthPasswords  string   `secret:"true"` // Comma-separated list of passwords
+	JWTSecret      string   `secret:"true"`
+	JWTIssuer      string   // iss claim of access tokens
+	JWTAudience    string   // aud claim of access tokens
+	JWTKeys        []string `secret:"true"` // "kid:secret" HS256 keys, newest first; the first signs. JWTSecret alone when empty
+	JWTRSAKeyFiles []string // "kid:/path/key.pem" RS256 private keys, newest first; replaces JWTKeys when set
+	JWTLegacyUntil string   // YYYY-MM-DD date until which tokens without a kid are accepted; never when empty
+	DBRowSecurity  bool     // Enforce Postgres row-level security on per-user tables
+	DebugEndpoints bool     // Expose /debug fault-injection endpoints (never in production)
+	MetricsToken   string   `secret:"true"` // Bearer token for scraping /metrics; the endpoint is off when empty
+
+	// Database connection pool and query instrumentation
+	DBMaxOpenConns           int64
+	DBMaxIdleConns           int64
+	DBConnMaxLifetimeSeconds int64
+	DBSlowQueryThresholdMs   int64 // queries slower than this are logged; 0 disables the log
+
+	// Security headers and CSRF protection
+	HSTSMaxAgeSeconds  int64    // Strict-Transport-Security max-age; 0 disables it (the default outside production)
+	FrameOptions       string   // X-Frame-Options value; empty disables it
+	CSRFEnabled        bool     // require CSRF tokens on cookie-authenticated requests
+	CSRFSessionCookies []string // cookies that authenticate a request
+
+	// Responses at least this large are gzipped for clients that accept it; 0 disables compression
+	CompressionMinBytes int64
+
+	// Unversioned routes kept from before /api/v1; they answer with Deprecation headers
+	LegacyRoutesEnabled bool
+	LegacyRoutesSunset  string // YYYY-MM-DD date announced in the Sunset header; none when empty
+
+	// Feature flags forced on or off for everyone, as "key=true" pairs, whatever the database says
+	FeatureFlags []string
+
+	// File upload storage
+	StorageBackend     string // "local" or "s3" (S3-compatible, including GCS interop)
+	StorageLocalDir    string
+	StorageSigningKey  string `secret:"true"`
+	PublicBaseURL      string
+	S3Endpoint         string
+	S3Region           string
+	S3Bucket           string
+	S3AccessKeyID      string
+	S3SecretAccessKey  string `secret:"true"`
+	UploadMaxBytes     int64
+	UploadAllowedTypes []string
+
+	// Base64 32-byte master key wrapping per-user keys for encrypted attachments (empty disables them)
+	AttachmentEncryptionKey string `secret:"true"`
+
+	// Signs the links of shared progress reports
+	ReportSigningKey string `secret:"true"`
+
+	// Outgoing email
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string `secret:"true"`
+	MailFrom     string
+	AppBaseURL   string
+
+	// Reminder emails
+	RemindersEnabled        bool
+	ReminderIntervalMinutes int64
+
+	// Plugin item sources
+	PluginSyncIntervalMinutes int64
+
+	// Push notifications (each platform is enabled only when its credentials are set)
+	VAPIDPublicKey     string
+	VAPIDPrivateKey    string `secret:"true"`
+	VAPIDSubject       string
+	FCMCredentialsFile string
+	APNSKeyFile        string
+	APNSKeyID          string
+	APNSTeamID         string
+	APNSTopic          string
+	APNSProduction     bool
+
+	// User webhooks
+	WebhookDeliveryIntervalSeconds int64
+	WebhookAllowPrivateTargets     bool
+
+	// Expired and revoked refresh token cleanup
+	RefreshTokenCleanupIntervalHours int64
+
+	// Inactive-user archival
+	ArchiveInactiveMonths int64
+
+	// Days a deleted account is kept, deactivated, before its data is purged
+	AccountDeletionGraceDays int64
+
+	// Dead link checks for item and eng blog article URLs
+	LinkCheckIntervalHours int64
+	LinkCheckHideDead      bool
+
+	// Prefill new items' attachments with their page's title and description
+	LinkEnrichmentEnabled        bool
+	LinkEnrichmentTimeoutSeconds int64
+
+	// Sandboxed code execution for judging DSA submissions (disabled when CodeRunnerURL is empty)
+	CodeRunnerURL            string // base URL of a Piston-compatible execution API
+	CodeRunnerAPIKey         string `secret:"true"`
+	CodeRunnerTimeoutSeconds int64  // per test case
+
+	// Internal event bus
+	EventBusBackend       string // "memory" (in-process) or "nats"
+	EventBusURL           string `secret:"true"`
+	EventBusSubjectPrefix string
+	EventBusQueueSize     int64
+
+	// Monthly AI budgets per role (0 means unlimited)
+	AIMonthlyCallBudget       int64
+	AIMonthlyTokenBudget      int64
+	AIAdminMonthlyCallBudget  int64
+	AIAdminMonthlyTokenBudget int64
+
+	// Language model behind AI features (disabled when LLMProvider is empty)
+	LLMProvider       string // "openai" or "anthropic"
+	LLMAPIKey         string `secret:"true"`
+	LLMModel          string
+	LLMBaseURL        string // overrides the vendor's API, e.g. for a proxy or compatible server
+	LLMTimeoutSeconds int64
+
+	// Embedding model behind similar items (disabled when EmbeddingModel is empty). It's served
+	// through an OpenAI-compatible embeddings API; the key and base URL default to the LLM ones
+	// when LLMProvider is "openai".
+	EmbeddingModel   string
+	EmbeddingAPIKey  string `secret:"true"`
+	EmbeddingBaseURL string
+
+	// Billing (disabled, with every feature available, when StripeSecretKey is empty)
+	StripeSecretKey         string `secret:"true"`
+	StripeWebhookSecret     string `secret:"true"`
+	StripeProPriceID        string
+	BillingFreeMonthlyTests int64
+	BillingFreeOrgSeats     int64
+	BillingTrialDays        int64 // 0 disables trials
+	BillingTrialNoticeDays  int64 // how long before a trial ends the user is warned
+
+	// Social login providers (a provider without a client ID rejects every login)
+	OAuthGoogle   OAuthProviderConfig
+	OAuthFacebook OAuthProviderConfig
+	OAuthApple    OAuthProviderConfig
+
+	// GitHub OAuth app for committing solutions to users' repositories (disabled when the client
+	// ID is empty). Tokens are stored encrypted, so it also needs AttachmentEncryptionKey.
+	GitHubClientID     string
+	GitHubClientSecret string `secret:"true"`
+}
+
+// OAuthProviderConfig identifies this app to a social login provider
+type OAuthProviderConfig struct {
+	ClientID     string   // Google client ID, Facebook app ID or Apple services ID
+	ClientSecret string   `secret:"true"` // Facebook app secret, needed to inspect access tokens
+	Audiences    []string // further client IDs tokens may be issued to, e.g. the mobile apps
+	RedirectURI  string
+}
+
+// String prints the config like %+v, with every set secret field redacted, so it can be logged
+func (c *Config) String() string {
+	var b strings.Builder
+	writeRedacted(&b, reflect.ValueOf(*c))
+	return b.String()
+}
+
+// writeRedacted writes a struct's fields like %+v, replacing the values of set fields tagged
+// secret and descending into nested structs
+func writeRedacted(b *strings.Builder, v reflect.Value) {
+	b.WriteString("{")
+	for i := 0; i < v.NumField(); i++ {
+		if i > 0 {
+			b.WriteString(" ")
+		}
+		field, value := v.Type().Field(i), v.Field(i)
+		b.WriteString(field.Name + ":")
+		switch {
+		case field.Tag.Get("secret") == "true" && !value.IsZero():
+			b.WriteString("[redacted]")
+		case value.Kind() == reflect.Struct:
+			writeRedacted(b, value)
+		default:
+			fmt.Fprintf(b, "%v", value.Interface())
+		}
+	}
+	b.WriteString("}")
+}
+
+// Enabled reports whether logins through the provider are accepted
+func (p OAuthProviderConfig) Enabled() bool {
+	return p.ClientID != ""
+}
+
+// AllowsAudience reports whether a token issued to the given client ID is meant for this app
+func (p OAuthProviderConfig) AllowsAudience(audience string) bool {
+	if audience == "" {
+		return false
+	}
+	if audience == p.ClientID {
+		return true
+	}
+	for _, allowed := range p.Audiences {
+		if audience == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// validate checks a provider block, naming the provider in errors
+func (p OAuthProviderConfig) validate(name string, needsSecret bool) error {
+	if !p.Enabled() {
+		if len(p.Audiences) > 0 || p.RedirectURI != "" || p.ClientSecret != "" {
+			return fmt.Errorf("%s OAuth is configured without a client ID", name)
+		}
+		return nil
+	}
+
+	if needsSecret && p.ClientSecret == "" {
+		return fmt.Errorf("%s OAuth requires a client secret", name)
+	}
+
+	if p.RedirectURI != "" {
+		parsed, err := url.Parse(p.RedirectURI)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("%s OAuth redirect URI must be an absolute http(s) URL", name)
+		}
+	}
+
+	return nil
 }
 
 // Load reads configuration from environment variables
 func Load() *Config {
 	return &Config{
-		DatabaseURL:   getEnv("DATABASE_URL", ""),
-		Port:          getEnv("PORT", "8080"),
-		Environment:   getEnv("NODE_ENV", "development"),
-		AuthUsername:  getEnv("AUTH_USERNAME", "admin"),
-		AuthPassword:  getEnv("AUTH_PASSWORD", "password"),
-		AuthUsers:     getEnv("AUTH_USERS", ""),
-		AuthPasswords: getEnv("AUTH_PASSWORDS", ""),
-		JWTSecret:     getEnv("JWT_SECRET", "default_secret_key"),
+		DatabaseURL:    getEnv("DATABASE_URL", ""),
+		Port:           getEnv("PORT", "8080"),
+		Environment:    getEnv("NODE_ENV", "development"),
+		AuthUsername:   getEnv("AUTH_USERNAME", "admin"),
+		AuthPassword:   getEnv("AUTH_PASSWORD", "password"),
+		AuthUsers:      getEnv("AUTH_USERS", ""),
+		AuthPasswords:  getEnv("AUTH_PASSWORDS", ""),
+		JWTSecret:      getEnv("JWT_SECRET", "default_secret_key"),
+		JWTIssuer:      getEnv("JWT_ISSUER", "prepmaster"),
+		JWTAudience:    getEnv("JWT_AUDIENCE", "prepmaster-api"),
+		JWTKeys:        getEnvList("JWT_KEYS", ""),
+		JWTRSAKeyFiles: getEnvList("JWT_RSA_KEY_FILES", ""),
+		JWTLegacyUntil: getEnv("JWT_LEGACY_UNTIL", ""),
+		DBRowSecurity:  getEnv("DB_ROW_SECURITY", "false") == "true",
+		DebugEndpoints: getEnv("DEBUG_ENDPOINTS", "false") == "true",
+		MetricsToken:   getEnv("METRICS_TOKEN", ""),
+
+		DBMaxOpenConns:           getEnvInt64("DB_MAX_OPEN_CONNS", 25),
+		DBMaxIdleConns:           getEnvInt64("DB_MAX_IDLE_CONNS", 25),
+		DBConnMaxLifetimeSeconds: getEnvInt64("DB_CONN_MAX_LIFETIME_SECONDS", 300),
+		DBSlowQueryThresholdMs:   getEnvInt64("DB_SLOW_QUERY_THRESHOLD_MS", 500),
+
+		HSTSMaxAgeSeconds:  getEnvInt64("HSTS_MAX_AGE_SECONDS", defaultHSTSMaxAge()),
+		FrameOptions:       getEnv("FRAME_OPTIONS", "DENY"),
+		CSRFEnabled:        getEnv("CSRF_ENABLED", "true") == "true",
+		CSRFSessionCookies: getEnvList("CSRF_SESSION_COOKIES", "prepmaster_session"),
+
+		CompressionMinBytes: getEnvInt64("COMPRESSION_MIN_BYTES", 1024),
+
+		LegacyRoutesEnabled: getEnv("LEGACY_ROUTES_ENABLED", "true") == "true",
+		LegacyRoutesSunset:  getEnv("LEGACY_ROUTES_SUNSET", ""),
+
+		FeatureFlags: getEnvList("FEATURE_FLAGS", ""),
+
+		StorageBackend:     getEnv("STORAGE_BACKEND", "local"),
+		StorageLocalDir:    getEnv("STORAGE_LOCAL_DIR", "./uploads"),
+		StorageSigningKey:  getEnv("STORAGE_SIGNING_KEY", getEnv("JWT_SECRET", "default_secret_key")),
+		PublicBaseURL:      getEnv("PUBLIC_BASE_URL", "http://localhost:8080"),
+		S3Endpoint:         getEnv("S3_ENDPOINT", "https://s3.amazonaws.com"),
+		S3Region:           getEnv("S3_REGION", "us-east-1"),
+		S3Bucket:           getEnv("S3_BUCKET", ""),
+		S3AccessKeyID:      getEnv("S3_ACCESS_KEY_ID", ""),
+		S3SecretAccessKey:  getEnv("S3_SECRET_ACCESS_KEY", ""),
+		UploadMaxBytes:     getEnvInt64("UPLOAD_MAX_BYTES", 10<<20),
+		UploadAllowedTypes: getEnvList("UPLOAD_ALLOWED_TYPES", "image/png,image/jpeg,image/gif,image/webp,application/pdf"),
+
+		AttachmentEncryptionKey: getEnv("ATTACHMENT_ENCRYPTION_KEY", ""),
+
+		ReportSigningKey: getEnv("REPORT_SIGNING_KEY", getEnv("JWT_SECRET", "default_secret_key")),
+
+		SMTPHost:     getEnv("SMTP_HOST", ""),
+		SMTPPort:     getEnv("SMTP_PORT", "587"),
+		SMTPUsername: getEnv("SMTP_USERNAME", ""),
+		SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+		MailFrom:     getEnv("MAIL_FROM", "no-reply@prepmaster.local"),
+		AppBaseURL:   getEnv("APP_BASE_URL", "http://localhost:3000"),
+
+		RemindersEnabled:        getEnv("REMINDERS_ENABLED", "true") == "true",
+		ReminderIntervalMinutes: getEnvInt64("REMINDER_INTERVAL_MINUTES", 15),
+
+		PluginSyncIntervalMinutes: getEnvInt64("PLUGIN_SYNC_INTERVAL_MINUTES", 60),
+
+		VAPIDPublicKey:     getEnv("VAPID_PUBLIC_KEY", ""),
+		VAPIDPrivateKey:    getEnv("VAPID_PRIVATE_KEY", ""),
+		VAPIDSubject:       getEnv("VAPID_SUBJECT", "mailto:"+getEnv("MAIL_FROM", "no-reply@prepmaster.local")),
+		FCMCredentialsFile: getEnv("FCM_CREDENTIALS_FILE", ""),
+		APNSKeyFile:        getEnv("APNS_KEY_FILE", ""),
+		APNSKeyID:          getEnv("APNS_KEY_ID", ""),
+		APNSTeamID:         getEnv("APNS_TEAM_ID", ""),
+		APNSTopic:          getEnv("APNS_TOPIC", ""),
+		APNSProduction:     getEnv("APNS_PRODUCTION", "false") == "true",
+
+		WebhookDeliveryIntervalSeconds: getEnvInt64("WEBHOOK_DELIVERY_INTERVAL_SECONDS", 30),
+		WebhookAllowPrivateTargets:     getEnv("WEBHOOK_ALLOW_PRIVATE_TARGETS", "false") == "true",
+
+		RefreshTokenCleanupIntervalHours: getEnvInt64("REFRESH_TOKEN_CLEANUP_INTERVAL_HOURS", 24),
+
+		ArchiveInactiveMonths: getEnvInt64("ARCHIVE_INACTIVE_MONTHS", 6),
+
+		AccountDeletionGraceDays: getEnvInt64("ACCOUNT_DELETION_GRACE_DAYS", 30),
+
+		LinkCheckIntervalHours: getEnvInt64("LINK_CHECK_INTERVAL_HOURS", 24),
+		LinkCheckHideDead:      getEnv("LINK_CHECK_HIDE_DEAD", "false") == "true",
+
+		LinkEnrichmentEnabled:        getEnv("LINK_ENRICHMENT_ENABLED", "false") == "true",
+		LinkEnrichmentTimeoutSeconds: getEnvInt64("LINK_ENRICHMENT_TIMEOUT_SECONDS", 5),
+
+		CodeRunnerURL:            getEnv("CODE_RUNNER_URL", ""),
+		CodeRunnerAPIKey:         getEnv("CODE_RUNNER_API_KEY", ""),
+		CodeRunnerTimeoutSeconds: getEnvInt64("CODE_RUNNER_TIMEOUT_SECONDS", 3),
+
+		EventBusBackend:       getEnv("EVENT_BUS_BACKEND", "memory"),
+		EventBusURL:           getEnv("EVENT_BUS_URL", ""),
+		EventBusSubjectPrefix: getEnv("EVENT_BUS_SUBJECT_PREFIX", "prepmaster.events"),
+		EventBusQueueSize:     getEnvInt64("EVENT_BUS_QUEUE_SIZE", 1024),
+
+		AIMonthlyCallBudget:       getEnvInt64("AI_MONTHLY_CALL_BUDGET", 200),
+		AIMonthlyTokenBudget:      getEnvInt64("AI_MONTHLY_TOKEN_BUDGET", 200000),
+		AIAdminMonthlyCallBudget:  getEnvInt64("AI_ADMIN_MONTHLY_CALL_BUDGET", 0),
+		AIAdminMonthlyTokenBudget: getEnvInt64("AI_ADMIN_MONTHLY_TOKEN_BUDGET", 0),
+
+		LLMProvider:       getEnv("LLM_PROVIDER", ""),
+		LLMAPIKey:         getEnv("LLM_API_KEY", ""),
+		LLMModel:          getEnv("LLM_MODEL", ""),
+		LLMBaseURL:        getEnv("LLM_BASE_URL", ""),
+		LLMTimeoutSeconds: getEnvInt64("LLM_TIMEOUT_SECONDS", 30),
+
+		EmbeddingModel:   getEnv("EMBEDDING_MODEL", ""),
+		EmbeddingAPIKey:  getEnv("EMBEDDING_API_KEY", ""),
+		EmbeddingBaseURL: getEnv("EMBEDDING_BASE_URL", ""),
+
+		StripeSecretKey:         getEnv("STRIPE_SECRET_KEY", ""),
+		StripeWebhookSecret:     getEnv("STRIPE_WEBHOOK_SECRET", ""),
+		StripeProPriceID:        getEnv("STRIPE_PRO_PRICE_ID", ""),
+		BillingFreeMonthlyTests: getEnvInt64("BILLING_FREE_MONTHLY_TESTS", 5),
+		BillingFreeOrgSeats:     getEnvInt64("BILLING_FREE_ORG_SEATS", 5),
+		BillingTrialDays:        getEnvInt64("BILLING_TRIAL_DAYS", 14),
+		BillingTrialNoticeDays:  getEnvInt64("BILLING_TRIAL_NOTICE_DAYS", 3),
+
+		OAuthGoogle: OAuthProviderConfig{
+			ClientID:    getEnv("GOOGLE_CLIENT_ID", ""),
+			Audiences:   getEnvList("GOOGLE_AUDIENCES", ""),
+			RedirectURI: getEnv("GOOGLE_REDIRECT_URI", ""),
+		},
+		OAuthFacebook: OAuthProviderConfig{
+			ClientID:     getEnv("FACEBOOK_APP_ID", ""),
+			ClientSecret: getEnv("FACEBOOK_APP_SECRET", ""),
+			Audiences:    getEnvList("FACEBOOK_AUDIENCES", ""),
+			RedirectURI:  getEnv("FACEBOOK_REDIRECT_URI", ""),
+		},
+		OAuthApple: OAuthProviderConfig{
+			ClientID:    getEnv("APPLE_CLIENT_ID", ""),
+			Audiences:   getEnvList("APPLE_AUDIENCES", ""),
+			RedirectURI: getEnv("APPLE_REDIRECT_URI", ""),
+		},
+
+		GitHubClientID:     getEnv("GITHUB_CLIENT_ID", ""),
+		GitHubClientSecret: getEnv("GITHUB_CLIENT_SECRET", ""),
 	}
 }
 
+// ValidateOAuth checks the social login provider blocks
+func (c *Config) ValidateOAuth() error {
+	if err := c.OAuthGoogle.validate("Google", false); err != nil {
+		return err
+	}
+	if err := c.OAuthFacebook.validate("Facebook", true); err != nil {
+		return err
+	}
+	return c.OAuthApple.validate("Apple", false)
+}
+
+// defaultHSTSMaxAge is a year in production, which is served over HTTPS, and off elsewhere
+func defaultHSTSMaxAge() int64 {
+	if getEnv("NODE_ENV", "development") == "production" {
+		return 365 * 24 * 60 * 60
+	}
+	return 0
+}
+
 // getEnv gets an environment variable with a fallback value
 func getEnv(key, fallback string) string {
 	if value := os.Getenv(key); value != "" {
@@ -39,6 +421,27 @@ func getEnv(key, fallback string) string {
 	return fallback
 }
 
+// getEnvInt64 gets an integer environment variable with a fallback value
+func getEnvInt64(key string, fallback int64) int64 {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return parsed
+		}
+	}
+	return fallback
+}
+
+// getEnvList gets a comma-separated environment variable as a trimmed slice
+func getEnvList(key, fallback string) []string {
+	var values []string
+	for _, value := range strings.Split(getEnv(key, fallback), ",") {
+		if trimmed := strings.TrimSpace(value); trimmed != "" {
+			values = append(values, trimmed)
+		}
+	}
+	return values
+}
+
 // ValidateCredentials checks if the provided username and password are valid
 // This method combines both multi-user and single-user authentication
 func (c *Config) ValidateCredentials(username, password string) bool {
@@ -147,6 +550,57 @@ func (c *Config) IsDevelopment() bool {
 	return c.Environment == "development"
 }
 
+// DebugEndpointsEnabled returns true if fault-injection endpoints should be exposed.
+// They are always disabled in production regardless of the flag.
+func (c *Config) DebugEndpointsEnabled() bool {
+	return c.DebugEndpoints && !c.IsProduction()
+}
+
+// LegacySunset returns the date the legacy routes are to be removed, or the zero time when
+// none is announced
+func (c *Config) LegacySunset() (time.Time, error) {
+	if c.LegacyRoutesSunset == "" {
+		return time.Time{}, nil
+	}
+	sunset, err := time.Parse("2006-01-02", c.LegacyRoutesSunset)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("LEGACY_ROUTES_SUNSET must be a YYYY-MM-DD date: %w", err)
+	}
+	return sunset, nil
+}
+
+// JWTLegacyCutoff returns when tokens without a key ID stop being accepted, or the zero time
+// when they never are
+func (c *Config) JWTLegacyCutoff() (time.Time, error) {
+	if c.JWTLegacyUntil == "" {
+		return time.Time{}, nil
+	}
+	cutoff, err := time.Parse("2006-01-02", c.JWTLegacyUntil)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("JWT_LEGACY_UNTIL must be a YYYY-MM-DD date: %w", err)
+	}
+	return cutoff, nil
+}
+
+// FeatureFlagOverrides returns the flags FEATURE_FLAGS forces on or off
+func (c *Config) FeatureFlagOverrides() (map[string]bool, error) {
+	overrides := map[string]bool{}
+	for _, pair := range c.FeatureFlags {
+		key, value, ok := strings.Cut(pair, "=")
+		on, err := strconv.ParseBool(strings.TrimSpace(value))
+		if !ok || err != nil || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("FEATURE_FLAGS entries must be key=true or key=false, got %q", pair)
+		}
+		overrides[strings.TrimSpace(key)] = on
+	}
+	return overrides, nil
+}
+
+// BillingEnabled reports whether plans are enforced; without Stripe every user gets every feature
+func (c *Config) BillingEnabled() bool {
+	return c.StripeSecretKey != ""
+}
+
 // IsProduction returns true if running in production mode
 func (c *Config) IsProduction() bool {
 	return c.Environment == "production"
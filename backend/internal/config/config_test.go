@@ -0,0 +1,109 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestOAuthProviderAllowsAudience(t *testing.T) {
+	provider := OAuthProviderConfig{ClientID: "web-client", Audiences: []string{"ios-client"}}
+
+	cases := map[string]bool{
+		"web-client":   true,
+		"ios-client":   true,
+		"other-client": false,
+		"":             false,
+	}
+	for audience, want := range cases {
+		if got := provider.AllowsAudience(audience); got != want {
+			t.Errorf("AllowsAudience(%q) = %v, want %v", audience, got, want)
+		}
+	}
+}
+
+func TestValidateOAuth(t *testing.T) {
+	cases := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{name: "nothing configured", cfg: Config{}},
+		{
+			name: "complete providers",
+			cfg: Config{
+				OAuthGoogle:   OAuthProviderConfig{ClientID: "g", RedirectURI: "https://app.example.com/callback"},
+				OAuthFacebook: OAuthProviderConfig{ClientID: "f", ClientSecret: "secret"},
+				OAuthApple:    OAuthProviderConfig{ClientID: "a", Audiences: []string{"com.example.app"}},
+			},
+		},
+		{
+			name:    "audiences without client ID",
+			cfg:     Config{OAuthGoogle: OAuthProviderConfig{Audiences: []string{"g"}}},
+			wantErr: true,
+		},
+		{
+			name:    "facebook without secret",
+			cfg:     Config{OAuthFacebook: OAuthProviderConfig{ClientID: "f"}},
+			wantErr: true,
+		},
+		{
+			name:    "relative redirect URI",
+			cfg:     Config{OAuthApple: OAuthProviderConfig{ClientID: "a", RedirectURI: "/callback"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.cfg.ValidateOAuth()
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ValidateOAuth() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestFeatureFlagOverrides(t *testing.T) {
+	cfg := Config{FeatureFlags: []string{"study_planner=true", " dark_mode = false "}}
+	overrides, err := cfg.FeatureFlagOverrides()
+	if err != nil {
+		t.Fatalf("FeatureFlagOverrides returned error: %v", err)
+	}
+	if len(overrides) != 2 || !overrides["study_planner"] || overrides["dark_mode"] {
+		t.Errorf("Unexpected overrides %v", overrides)
+	}
+
+	for _, pair := range []string{"study_planner", "study_planner=maybe", "=true"} {
+		cfg := Config{FeatureFlags: []string{pair}}
+		if _, err := cfg.FeatureFlagOverrides(); err == nil {
+			t.Errorf("Expected an error for %q", pair)
+		}
+	}
+}
+
+func TestStringRedactsSecrets(t *testing.T) {
+	cfg := &Config{
+		Port:                    "3000",
+		DatabaseURL:             "postgres://prep:hunter2@db/prep",
+		JWTKeys:                 []string{"k1:jwt-secret"},
+		AttachmentEncryptionKey: "attachment-key",
+		StripeSecretKey:         "sk_live_stripe",
+		OAuthFacebook:           OAuthProviderConfig{ClientID: "facebook-app", ClientSecret: "facebook-secret"},
+	}
+
+	printed := cfg.String()
+	for _, secret := range []string{"hunter2", "jwt-secret", "attachment-key", "sk_live_stripe", "facebook-secret"} {
+		if strings.Contains(printed, secret) {
+			t.Errorf("Expected %q to be redacted, got %s", secret, printed)
+		}
+	}
+	for _, shown := range []string{"Port:3000", "ClientID:facebook-app", "StripeSecretKey:[redacted]", "LLMAPIKey: "} {
+		if !strings.Contains(printed, shown) {
+			t.Errorf("Expected %q in %s", shown, printed)
+		}
+	}
+	if printed := fmt.Sprintf("%+v", cfg); strings.Contains(printed, "hunter2") {
+		t.Errorf("Expected %%+v to redact secrets too, got %s", printed)
+	}
+}
@@ -0,0 +1,38 @@
+package csrf
+
+import "testing"
+
+func TestVerify(t *testing.T) {
+	p := NewProtector("secret")
+	token, err := p.Issue("session-a")
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+	other, _ := NewProtector("other secret").Issue("session-a")
+	anonymous, _ := p.Issue("")
+
+	testCases := []struct {
+		name     string
+		session  string
+		cookie   string
+		header   string
+		expected bool
+	}{
+		{name: "Matching token", session: "session-a", cookie: token, header: token, expected: true},
+		{name: "Missing header", session: "session-a", cookie: token, header: "", expected: false},
+		{name: "Missing cookie", session: "session-a", cookie: "", header: token, expected: false},
+		{name: "Different tokens", session: "session-a", cookie: token, header: token + "x", expected: false},
+		{name: "Token signed with another key", session: "session-a", cookie: other, header: other, expected: false},
+		{name: "Unsigned token", session: "session-a", cookie: "abc", header: "abc", expected: false},
+		{name: "Token issued to another session", session: "session-b", cookie: token, header: token, expected: false},
+		{name: "Token issued before login", session: "session-a", cookie: anonymous, header: anonymous, expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := p.Verify(tc.session, tc.cookie, tc.header); got != tc.expected {
+				t.Errorf("Expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
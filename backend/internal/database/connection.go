@@ -9,21 +9,42 @@ import (
 	_ "github.com/lib/pq"
 )
 
-// NewConnection creates a new database connection
+// PoolConfig sizes a connection pool
+type PoolConfig struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+}
+
+// DefaultPoolConfig is the pool used by tools that don't read the server config
+var DefaultPoolConfig = PoolConfig{MaxOpenConns: 25, MaxIdleConns: 25, ConnMaxLifetime: 5 * time.Minute}
+
+// NewConnection creates a new database connection with the driver the URL's dialect needs
 func NewConnection(databaseURL string) (*sql.DB, error) {
+	return NewConnectionWithDriver(DriverFor(databaseURL), databaseURL, DefaultPoolConfig)
+}
+
+// NewConnectionWithDriver creates a new database connection using a specific registered driver,
+// which must be (or wrap) the driver for the URL's dialect
+func NewConnectionWithDriver(driverName, databaseURL string, pool PoolConfig) (*sql.DB, error) {
 	if databaseURL == "" {
 		return nil, fmt.Errorf("DATABASE_URL is required")
 	}
 
-	db, err := sql.Open("postgres", databaseURL)
+	dsn := databaseURL
+	if DialectOf(databaseURL) == DialectSQLite {
+		dsn = sqliteDSN(databaseURL)
+	}
+
+	db, err := sql.Open(driverName, dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
 	// Configure connection pool
-	db.SetMaxOpenConns(25)
-	db.SetMaxIdleConns(25)
-	db.SetConnMaxLifetime(5 * time.Minute)
+	db.SetMaxOpenConns(pool.MaxOpenConns)
+	db.SetMaxIdleConns(pool.MaxIdleConns)
+	db.SetConnMaxLifetime(pool.ConnMaxLifetime)
 
 	// Test the connection
 	if err := db.Ping(); err != nil {
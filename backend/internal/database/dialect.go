@@ -0,0 +1,91 @@
+package database
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"net/url"
+	"strings"
+)
+
+// Dialect is the SQL dialect of the database behind a connection
+type Dialect string
+
+const (
+	DialectPostgres Dialect = "postgres"
+	DialectSQLite   Dialect = "sqlite"
+)
+
+// sqliteSchemes are the DATABASE_URL prefixes that name a SQLite database file
+var sqliteSchemes = []string{"sqlite://", "sqlite3://", "sqlite:", "sqlite3:", "file:"}
+
+// sqliteDefaults are the connection settings every SQLite database is opened with unless the URL
+// overrides them. Writers take the lock when their transaction begins, so concurrent
+// transactions wait on the busy timeout instead of failing when one upgrades to a write.
+var sqliteDefaults = [][2]string{
+	{"_foreign_keys", "on"},
+	{"_journal_mode", "WAL"},
+	{"_busy_timeout", "5000"},
+	{"_txlock", "immediate"},
+}
+
+// DialectOf picks the dialect a database URL names, going by its scheme only. Everything else,
+// including key/value connection strings such as "host=db dbname=app.db", is Postgres.
+func DialectOf(databaseURL string) Dialect {
+	lower := strings.ToLower(strings.TrimSpace(databaseURL))
+	for _, scheme := range sqliteSchemes {
+		if strings.HasPrefix(lower, scheme) {
+			return DialectSQLite
+		}
+	}
+	return DialectPostgres
+}
+
+// DriverFor returns the name of the registered driver that opens a database URL
+func DriverFor(databaseURL string) string {
+	if DialectOf(databaseURL) == DialectSQLite {
+		return SQLiteDriverName
+	}
+	return "postgres"
+}
+
+// DialectFor reports the dialect of an open database, looking through drivers that wrap another
+func DialectFor(db *sql.DB) Dialect {
+	d := db.Driver()
+	for {
+		if _, ok := d.(*sqliteDriver); ok {
+			return DialectSQLite
+		}
+		wrapper, ok := d.(interface{ Unwrap() driver.Driver })
+		if !ok {
+			return DialectPostgres
+		}
+		d = wrapper.Unwrap()
+	}
+}
+
+// sqliteDSN turns a SQLite database URL into the path and options the SQLite driver opens.
+// "sqlite://./dev.db" and "sqlite:dev.db" name a relative path, "sqlite:///var/lib/app.db" an
+// absolute one; "file:" URIs are passed through.
+func sqliteDSN(databaseURL string) string {
+	dsn := strings.TrimSpace(databaseURL)
+	if lower := strings.ToLower(dsn); !strings.HasPrefix(lower, "file:") {
+		for _, scheme := range sqliteSchemes {
+			if strings.HasPrefix(lower, scheme) {
+				dsn = dsn[len(scheme):]
+				break
+			}
+		}
+	}
+
+	path, rawQuery, _ := strings.Cut(dsn, "?")
+	options, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		options = url.Values{}
+	}
+	for _, option := range sqliteDefaults {
+		if !options.Has(option[0]) {
+			options.Set(option[0], option[1])
+		}
+	}
+	return path + "?" + options.Encode()
+}
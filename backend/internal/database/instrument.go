@@ -0,0 +1,227 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"interview-prep-app/internal/metrics"
+)
+
+// slowQueryLogLength is how much of a slow query's SQL is logged
+const slowQueryLogLength = 500
+
+var (
+	queryDuration = metrics.NewHistogram(
+		"prepmaster_db_query_duration_seconds",
+		"Time until the database answered a query, by operation: select, insert, update, delete or other.",
+		[]float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5},
+		"operation",
+	)
+	slowQueries = metrics.NewCounter(
+		"prepmaster_db_slow_queries_total",
+		"Queries slower than the slow query threshold, by operation.",
+		"operation",
+	)
+)
+
+var (
+	instrumentedMu      sync.Mutex
+	instrumentedDrivers = map[string]string{}
+)
+
+// RegisterInstrumentedDriver registers a driver that wraps an already registered one, recording
+// every query's latency and logging queries slower than slowThreshold (0 disables the log). It
+// returns the name to open connections with. Only the SQL is logged, never its arguments.
+func RegisterInstrumentedDriver(baseName string, slowThreshold time.Duration) (string, error) {
+	instrumentedMu.Lock()
+	defer instrumentedMu.Unlock()
+
+	name := baseName + "+instrumented"
+	if _, ok := instrumentedDrivers[baseName]; ok {
+		return name, nil
+	}
+
+	// Opening doesn't connect; it only looks up the registered driver
+	db, err := sql.Open(baseName, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to find database driver %s: %w", baseName, err)
+	}
+	base := db.Driver()
+	db.Close()
+
+	sql.Register(name, &instrumentedDriver{base: base, slowThreshold: slowThreshold})
+	instrumentedDrivers[baseName] = name
+	return name, nil
+}
+
+// instrumentedDriver wraps another driver's connections
+type instrumentedDriver struct {
+	base          driver.Driver
+	slowThreshold time.Duration
+}
+
+// Open opens a wrapped connection
+func (d *instrumentedDriver) Open(name string) (driver.Conn, error) {
+	conn, err := d.base.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return &instrumentedConn{Conn: conn, driver: d}, nil
+}
+
+// Unwrap returns the wrapped driver
+func (d *instrumentedDriver) Unwrap() driver.Driver {
+	return d.base
+}
+
+// observe records how long a query took
+func (d *instrumentedDriver) observe(query string, started time.Time) {
+	elapsed := time.Since(started)
+	operation := queryOperation(query)
+	queryDuration.Observe(elapsed.Seconds(), operation)
+
+	if d.slowThreshold > 0 && elapsed >= d.slowThreshold {
+		slowQueries.Inc(operation)
+		log.Printf("Slow query (%s): %s", elapsed.Round(time.Millisecond), compactQuery(query))
+	}
+}
+
+// instrumentedConn times queries before delegating to the real connection. Query times cover
+// the wait for the first rows, not reading them all.
+type instrumentedConn struct {
+	driver.Conn
+	driver *instrumentedDriver
+}
+
+// PrepareContext prepares a statement whose executions are timed
+func (c *instrumentedConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	var stmt driver.Stmt
+	var err error
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		stmt, err = preparer.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.Conn.Prepare(query)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &instrumentedStmt{Stmt: stmt, query: query, driver: c.driver}, nil
+}
+
+// BeginTx starts a transaction on the real connection
+func (c *instrumentedConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin()
+}
+
+// QueryContext runs and times a query
+func (c *instrumentedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	defer c.driver.observe(query, time.Now())
+	return queryer.QueryContext(ctx, query, args)
+}
+
+// ExecContext runs and times a statement
+func (c *instrumentedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	defer c.driver.observe(query, time.Now())
+	return execer.ExecContext(ctx, query, args)
+}
+
+// Ping checks the connection, passing through to the real driver
+func (c *instrumentedConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+// ResetSession lets the real driver discard a broken connection before it's reused
+func (c *instrumentedConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+// IsValid reports whether the real connection can go back into the pool
+func (c *instrumentedConn) IsValid() bool {
+	if validator, ok := c.Conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+	return true
+}
+
+// instrumentedStmt times a prepared statement's executions
+type instrumentedStmt struct {
+	driver.Stmt
+	query  string
+	driver *instrumentedDriver
+}
+
+// ExecContext runs and times the statement
+func (s *instrumentedStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	defer s.driver.observe(s.query, time.Now())
+	if execer, ok := s.Stmt.(driver.StmtExecContext); ok {
+		return execer.ExecContext(ctx, args)
+	}
+	return s.Stmt.Exec(driverValues(args))
+}
+
+// QueryContext runs and times the statement
+func (s *instrumentedStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	defer s.driver.observe(s.query, time.Now())
+	if queryer, ok := s.Stmt.(driver.StmtQueryContext); ok {
+		return queryer.QueryContext(ctx, args)
+	}
+	return s.Stmt.Query(driverValues(args))
+}
+
+// driverValues drops the names from positional arguments for drivers without context support
+func driverValues(args []driver.NamedValue) []driver.Value {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+	return values
+}
+
+// queryOperation names a query's kind by its first keyword, keeping the metric's labels few
+func queryOperation(query string) string {
+	keyword := strings.TrimSpace(query)
+	if end := strings.IndexFunc(keyword, unicode.IsSpace); end >= 0 {
+		keyword = keyword[:end]
+	}
+
+	switch operation := strings.ToLower(keyword); operation {
+	case "select", "insert", "update", "delete":
+		return operation
+	}
+	return "other"
+}
+
+// compactQuery collapses a query's whitespace onto one line and shortens long queries for logging
+func compactQuery(query string) string {
+	compact := strings.Join(strings.Fields(query), " ")
+	if len(compact) > slowQueryLogLength {
+		compact = compact[:slowQueryLogLength] + "..."
+	}
+	return compact
+}
@@ -0,0 +1,98 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	"interview-prep-app/internal/metrics"
+)
+
+// sleepDriver answers every statement after a fixed delay
+type sleepDriver struct {
+	delay time.Duration
+}
+
+func (d sleepDriver) Open(name string) (driver.Conn, error) {
+	return &sleepConn{delay: d.delay}, nil
+}
+
+type sleepConn struct {
+	delay time.Duration
+}
+
+func (c *sleepConn) Prepare(query string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (c *sleepConn) Close() error                              { return nil }
+func (c *sleepConn) Begin() (driver.Tx, error)                 { return nil, driver.ErrSkip }
+
+func (c *sleepConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	time.Sleep(c.delay)
+	return driver.RowsAffected(1), nil
+}
+
+func TestInstrumentedDriver(t *testing.T) {
+	sql.Register("sleep-test", sleepDriver{delay: 20 * time.Millisecond})
+
+	name, err := RegisterInstrumentedDriver("sleep-test", 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("RegisterInstrumentedDriver returned error: %v", err)
+	}
+	if again, err := RegisterInstrumentedDriver("sleep-test", time.Second); err != nil || again != name {
+		t.Errorf("Expected registering twice to reuse %s, got %s, %v", name, again, err)
+	}
+
+	db, err := sql.Open(name, "")
+	if err != nil {
+		t.Fatalf("sql.Open returned error: %v", err)
+	}
+	defer db.Close()
+
+	var logged bytes.Buffer
+	defer log.SetOutput(log.Writer())
+	log.SetOutput(&logged)
+
+	if _, err := db.Exec("UPDATE items\n\t\tSET title = $1\n\t\tWHERE id = $2", "secret title", 7); err != nil {
+		t.Fatalf("Exec returned error: %v", err)
+	}
+
+	if !strings.Contains(logged.String(), "UPDATE items SET title = $1 WHERE id = $2") {
+		t.Errorf("Expected the slow query to be logged on one line, got %q", logged.String())
+	}
+	if strings.Contains(logged.String(), "secret title") {
+		t.Error("Slow query log must not include arguments")
+	}
+
+	var out bytes.Buffer
+	if err := metrics.Default.Write(&out); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+	for _, series := range []string{
+		`prepmaster_db_query_duration_seconds_count{operation="update"} 1`,
+		`prepmaster_db_slow_queries_total{operation="update"} 1`,
+	} {
+		if !strings.Contains(out.String(), series) {
+			t.Errorf("Expected %s in metrics output", series)
+		}
+	}
+}
+
+func TestQueryOperation(t *testing.T) {
+	for query, want := range map[string]string{
+		"\n\t\tSELECT id FROM items":      "select",
+		"insert into items (title)":       "insert",
+		"Update items SET title = $1":     "update",
+		"DELETE FROM refresh_tokens":      "delete",
+		"WITH deleted AS (DELETE FROM x)": "other",
+		"":                                "other",
+		"SELECT":                          "select",
+	} {
+		if got := queryOperation(query); got != want {
+			t.Errorf("queryOperation(%q) = %q, want %q", query, got, want)
+		}
+	}
+}
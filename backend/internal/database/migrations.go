@@ -6,30 +6,95 @@ import (
 	"log"
 )
 
+// postgresMigrations are run in order on every start, so each must be safe to repeat
+var postgresMigrations = []string{
+	createItemsTable,
+	createAppStatsTable,
+	insertInitialAppStats,
+	addSubcategoryColumn,
+	fixStatusValues,
+	addStarredColumn,
+	addAttachmentsColumn,
+	createUsersTable,
+	createUserProgressTable,
+	createUserStatsTable,
+	createRefreshTokensTable,
+	fixUsersUniqueConstraint,
+	addUserRoleColumn,
+	addUserProgressStarredColumn,
+	addUserStatsCompletedAllCountColumn,
+	addMiscellaneousCategory,
+	createEngBlogsTable,
+	createTestsTable,
+	createItemAttachmentsTable,
+	createItemHintsTables,
+	addUserProgressCompletionQualityColumns,
+	createItemSharesTable,
+	createOrganizationsTables,
+	createStudyGroupsTables,
+	createOrgServiceAccountsTable,
+	addDailyGoalTracking,
+	createNotificationTables,
+	createUserDevicesTable,
+	createOrgCohortSnapshotsTable,
+	createWebhooksTables,
+	createCalendarFeedsTable,
+	addUserArchival,
+	addEncryptedAttachments,
+	createFlashcardsTable,
+	createCompaniesTables,
+	addUserStatsAggregates,
+	createInterviewsTables,
+	createFocusSessionsTables,
+	createAIUsageTables,
+	createSubscriptionsTables,
+	addUserProgressSkipCount,
+	addSubscriptionLifecycle,
+	addLoginSecurity,
+	addUserStatsCounterTriggers,
+	createItemFeedbackTable,
+	addEngBlogArchival,
+	createLinkChecksTable,
+	addEngBlogArticleLinkUniqueness,
+	addUserFirstCompletedAt,
+	addItemSourceArticle,
+	createCategoriesTable,
+	createBehavioralTables,
+	createItemDesignNotesTable,
+	createSubmissionTables,
+	addAccountDeletion,
+	createPublicProfilesTable,
+	createProgressReportsTable,
+	addWebhookFormats,
+	createGitHubConnectionsTable,
+	createJobTables,
+	createFeatureFlagsTable,
+	addOrgAdminsAndCatalog,
+	createRolesTables,
+	createAPIKeysTable,
+	addEditVersions,
+	addTestResults,
+	addTestRetakes,
+	createTestEligibilityPolicy,
+	createQuizTables,
+	createAIGeneratedQuestionsTable,
+	createDocumentsTable,
+	createMockInterviewTables,
+	createItemEmbeddingsTable,
+	createItemRevisionsTable,
+	addItemPublishedColumn,
+	addItemPublishAtColumn,
+	addNotificationLocaleColumn,
+	addUserProgressHintCredit,
+}
+
 // RunMigrations executes all database migrations
 func RunMigrations(db *sql.DB) error {
-	migrations := []string{
-		createItemsTable,
-		createAppStatsTable,
-		insertInitialAppStats,
-		addSubcategoryColumn,
-		fixStatusValues,
-		addStarredColumn,
-		addAttachmentsColumn,
-		createUsersTable,
-		createUserProgressTable,
-		createUserStatsTable,
-		createRefreshTokensTable,
-		fixUsersUniqueConstraint,
-		addUserRoleColumn,
-		addUserProgressStarredColumn,
-		addUserStatsCompletedAllCountColumn,
-		addMiscellaneousCategory,
-		createEngBlogsTable,
-		createTestsTable,
+	if DialectFor(db) == DialectSQLite {
+		return runSQLiteMigrations(db)
 	}
 
-	for i, migration := range migrations {
+	for i, migration := range postgresMigrations {
 		if err := executeMigration(db, migration); err != nil {
 			return fmt.Errorf("failed to execute migration %d: %w", i+1, err)
 		}
@@ -232,12 +297,15 @@ END $$;
 const addMiscellaneousCategory = `
 DO $$ 
 BEGIN 
-    -- Drop the existing check constraint if it exists
-    ALTER TABLE items DROP CONSTRAINT IF EXISTS items_category_check;
-    
-    -- Add the new check constraint that includes 'miscellaneous'
-    ALTER TABLE items ADD CONSTRAINT items_category_check 
-        CHECK (category IN ('dsa', 'lld', 'hld', 'miscellaneous'));
+    -- Once categories are data-driven, items reference the categories table instead
+    IF NOT EXISTS (SELECT 1 FROM information_schema.tables WHERE table_name='categories') THEN
+        -- Drop the existing check constraint if it exists
+        ALTER TABLE items DROP CONSTRAINT IF EXISTS items_category_check;
+        
+        -- Add the new check constraint that includes 'miscellaneous'
+        ALTER TABLE items ADD CONSTRAINT items_category_check 
+            CHECK (category IN ('dsa', 'lld', 'hld', 'miscellaneous'));
+    END IF;
 END $$;
 `
 
@@ -285,3 +353,1306 @@ CREATE INDEX IF NOT EXISTS idx_tests_status ON tests(status);
 CREATE INDEX IF NOT EXISTS idx_tests_user_session ON tests(user_id, session_id);
 CREATE INDEX IF NOT EXISTS idx_tests_user_status ON tests(user_id, status);
 `
+
+const createItemAttachmentsTable = `
+CREATE TABLE IF NOT EXISTS item_attachments (
+    id SERIAL PRIMARY KEY,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    storage_key TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_item_attachments_item_user ON item_attachments(item_id, user_id);
+CREATE INDEX IF NOT EXISTS idx_item_attachments_user_id ON item_attachments(user_id);
+`
+
+const createItemHintsTables = `
+CREATE TABLE IF NOT EXISTS item_hints (
+    id SERIAL PRIMARY KEY,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL DEFAULT 0,
+    content TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS user_hint_reveals (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    hint_id INTEGER NOT NULL REFERENCES item_hints(id) ON DELETE CASCADE,
+    revealed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(user_id, hint_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_item_hints_item_position ON item_hints(item_id, position);
+CREATE INDEX IF NOT EXISTS idx_user_hint_reveals_user_item ON user_hint_reveals(user_id, item_id);
+`
+
+const addUserProgressCompletionQualityColumns = `
+DO $$ 
+BEGIN 
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns 
+                   WHERE table_name='user_progress' AND column_name='completion_quality') THEN
+        ALTER TABLE user_progress ADD COLUMN completion_quality VARCHAR(20) CHECK (completion_quality IN ('solved', 'reviewed_solution'));
+        ALTER TABLE user_progress ADD COLUMN next_review_at TIMESTAMP;
+        ALTER TABLE user_progress ADD COLUMN review_count INTEGER NOT NULL DEFAULT 0;
+        CREATE INDEX IF NOT EXISTS idx_user_progress_user_next_review ON user_progress(user_id, next_review_at);
+    END IF;
+END $$;
+`
+
+const createItemSharesTable = `
+CREATE TABLE IF NOT EXISTS item_shares (
+    id SERIAL PRIMARY KEY,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    sender_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    recipient_user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    recipient_email VARCHAR(255) NOT NULL,
+    note TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'dismissed')),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    responded_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_item_shares_recipient_user ON item_shares(recipient_user_id, status);
+CREATE INDEX IF NOT EXISTS idx_item_shares_recipient_email ON item_shares(LOWER(recipient_email), status);
+CREATE INDEX IF NOT EXISTS idx_item_shares_sender ON item_shares(sender_id);
+`
+
+const createOrganizationsTables = `
+CREATE TABLE IF NOT EXISTS organizations (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS organization_members (
+    org_id INTEGER NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL DEFAULT 'member' CHECK (role IN ('owner', 'member')),
+    joined_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (org_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS organization_invitations (
+    id SERIAL PRIMARY KEY,
+    org_id INTEGER NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    role VARCHAR(20) NOT NULL DEFAULT 'member' CHECK (role IN ('owner', 'member')),
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'revoked')),
+    invited_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    accepted_user_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    expires_at TIMESTAMP NOT NULL,
+    accepted_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_organization_members_user_id ON organization_members(user_id);
+CREATE INDEX IF NOT EXISTS idx_organization_invitations_org_id ON organization_invitations(org_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_organization_invitations_pending_email
+    ON organization_invitations(org_id, LOWER(email)) WHERE status = 'pending';
+`
+
+const createStudyGroupsTables = `
+CREATE TABLE IF NOT EXISTS study_groups (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    owner_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    invite_code VARCHAR(16) NOT NULL UNIQUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS study_group_members (
+    group_id INTEGER NOT NULL REFERENCES study_groups(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL DEFAULT 'member' CHECK (role IN ('owner', 'member')),
+    joined_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (group_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS study_group_item_lists (
+    id SERIAL PRIMARY KEY,
+    group_id INTEGER NOT NULL REFERENCES study_groups(id) ON DELETE CASCADE,
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS study_group_list_items (
+    list_id INTEGER NOT NULL REFERENCES study_group_item_lists(id) ON DELETE CASCADE,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (list_id, item_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_study_group_members_user_id ON study_group_members(user_id);
+CREATE INDEX IF NOT EXISTS idx_study_group_item_lists_group_id ON study_group_item_lists(group_id);
+`
+
+const createOrgServiceAccountsTable = `
+CREATE TABLE IF NOT EXISTS org_service_accounts (
+    id SERIAL PRIMARY KEY,
+    org_id INTEGER NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    key_prefix VARCHAR(16) NOT NULL,
+    key_hash VARCHAR(64) NOT NULL UNIQUE,
+    scopes TEXT[] NOT NULL DEFAULT '{}',
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    last_used_at TIMESTAMP,
+    revoked_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_org_service_accounts_org_id ON org_service_accounts(org_id);
+`
+
+const addDailyGoalTracking = `
+DO $$ 
+BEGIN 
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns 
+                   WHERE table_name='user_stats' AND column_name='daily_goal') THEN
+        ALTER TABLE user_stats ADD COLUMN daily_goal INTEGER NOT NULL DEFAULT 0;
+        ALTER TABLE user_stats ADD COLUMN goal_streak INTEGER NOT NULL DEFAULT 0;
+        ALTER TABLE user_stats ADD COLUMN longest_goal_streak INTEGER NOT NULL DEFAULT 0;
+        ALTER TABLE user_stats ADD COLUMN goals_met_count INTEGER NOT NULL DEFAULT 0;
+        ALTER TABLE user_stats ADD COLUMN last_goal_met_date DATE;
+    END IF;
+END $$;
+
+CREATE TABLE IF NOT EXISTS user_daily_goals (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    activity_date DATE NOT NULL,
+    completed_count INTEGER NOT NULL DEFAULT 0,
+    goal INTEGER NOT NULL DEFAULT 0,
+    goal_met BOOLEAN NOT NULL DEFAULT false,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, activity_date)
+);
+`
+
+const createNotificationTables = `
+CREATE TABLE IF NOT EXISTS user_notification_preferences (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    email_enabled BOOLEAN NOT NULL DEFAULT true,
+    daily_reminder BOOLEAN NOT NULL DEFAULT true,
+    reminder_time VARCHAR(5) NOT NULL DEFAULT '19:00',
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    stuck_items BOOLEAN NOT NULL DEFAULT true,
+    reviews_due BOOLEAN NOT NULL DEFAULT true,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS sent_reminders (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind VARCHAR(50) NOT NULL,
+    sent_on DATE NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, kind, sent_on)
+);
+`
+
+const createUserDevicesTable = `
+CREATE TABLE IF NOT EXISTS user_devices (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    platform VARCHAR(20) NOT NULL CHECK (platform IN ('web', 'android', 'ios')),
+    token TEXT NOT NULL,
+    p256dh TEXT NOT NULL DEFAULT '',
+    auth TEXT NOT NULL DEFAULT '',
+    label VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    last_seen_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (platform, token)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_devices_user_id ON user_devices(user_id);
+
+DO $$ 
+BEGIN 
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns 
+                   WHERE table_name='user_notification_preferences' AND column_name='push_enabled') THEN
+        ALTER TABLE user_notification_preferences ADD COLUMN push_enabled BOOLEAN NOT NULL DEFAULT true;
+        ALTER TABLE user_notification_preferences ADD COLUMN streak_alerts BOOLEAN NOT NULL DEFAULT true;
+    END IF;
+END $$;
+`
+
+const createOrgCohortSnapshotsTable = `
+CREATE TABLE IF NOT EXISTS org_cohort_snapshots (
+    org_id INTEGER NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    snapshot_date DATE NOT NULL,
+    data JSONB NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (org_id, snapshot_date)
+);
+`
+
+const createWebhooksTables = `
+CREATE TABLE IF NOT EXISTS user_webhooks (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    secret VARCHAR(100) NOT NULL,
+    events TEXT[] NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_webhooks_user_id ON user_webhooks(user_id);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id SERIAL PRIMARY KEY,
+    webhook_id INTEGER NOT NULL REFERENCES user_webhooks(id) ON DELETE CASCADE,
+    event VARCHAR(50) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'succeeded', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP,
+    last_status_code INTEGER,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    delivered_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook_id ON webhook_deliveries(webhook_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
+`
+
+const createCalendarFeedsTable = `
+CREATE TABLE IF NOT EXISTS calendar_feeds (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    last_accessed_at TIMESTAMP
+);
+`
+
+const addUserArchival = `
+DO $$ 
+BEGIN 
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns 
+                   WHERE table_name='users' AND column_name='archived_at') THEN
+        ALTER TABLE users ADD COLUMN archived_at TIMESTAMP;
+    END IF;
+END $$;
+
+CREATE TABLE IF NOT EXISTS lifecycle_runs (
+    id SERIAL PRIMARY KEY,
+    inactive_before TIMESTAMP NOT NULL,
+    users_archived INTEGER NOT NULL DEFAULT 0,
+    rows_deleted JSONB NOT NULL DEFAULT '{}',
+    bytes_reclaimed BIGINT NOT NULL DEFAULT 0,
+    started_at TIMESTAMP NOT NULL,
+    finished_at TIMESTAMP NOT NULL
+);
+`
+
+const addEncryptedAttachments = `
+CREATE TABLE IF NOT EXISTS user_encryption_keys (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    wrapped_key BYTEA NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+DO $$ 
+BEGIN 
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns 
+                   WHERE table_name='item_attachments' AND column_name='kind') THEN
+        ALTER TABLE item_attachments ADD COLUMN kind VARCHAR(20) NOT NULL DEFAULT 'file' CHECK (kind IN ('file', 'secret'));
+        ALTER TABLE item_attachments ADD COLUMN ciphertext BYTEA;
+        ALTER TABLE item_attachments ALTER COLUMN storage_key DROP NOT NULL;
+    END IF;
+END $$;
+`
+
+const createFlashcardsTable = `
+CREATE TABLE IF NOT EXISTS flashcards (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    question TEXT NOT NULL,
+    answer TEXT NOT NULL,
+    review_count INTEGER NOT NULL DEFAULT 0,
+    last_grade VARCHAR(10) CHECK (last_grade IN ('again', 'hard', 'good')),
+    last_reviewed_at TIMESTAMP,
+    next_review_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_flashcards_user_item ON flashcards(user_id, item_id);
+CREATE INDEX IF NOT EXISTS idx_flashcards_user_due ON flashcards(user_id, next_review_at);
+`
+
+const createCompaniesTables = `
+CREATE TABLE IF NOT EXISTS companies (
+    id SERIAL PRIMARY KEY,
+    slug VARCHAR(100) NOT NULL UNIQUE,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS item_companies (
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    company_id INTEGER NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (item_id, company_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_item_companies_company ON item_companies(company_id);
+`
+
+const addUserStatsAggregates = `
+DO $$ 
+BEGIN 
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns 
+                   WHERE table_name='user_stats' AND column_name='stats_refreshed_at') THEN
+        ALTER TABLE user_stats ADD COLUMN solved_items INTEGER NOT NULL DEFAULT 0;
+        ALTER TABLE user_stats ADD COLUMN reviewed_solution_items INTEGER NOT NULL DEFAULT 0;
+        ALTER TABLE user_stats ADD COLUMN stats_refreshed_at TIMESTAMP;
+    END IF;
+END $$;
+
+CREATE INDEX IF NOT EXISTS idx_user_stats_stale ON user_stats(user_id) WHERE stats_refreshed_at IS NULL;
+`
+
+const createInterviewsTables = `
+CREATE TABLE IF NOT EXISTS interviews (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    company_id INTEGER REFERENCES companies(id) ON DELETE SET NULL,
+    company_name VARCHAR(255) NOT NULL,
+    role VARCHAR(255) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'applied' CHECK (status IN ('applied', 'interviewing', 'offer', 'accepted', 'rejected', 'withdrawn')),
+    applied_at TIMESTAMP,
+    notes TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_interviews_user ON interviews(user_id);
+
+CREATE TABLE IF NOT EXISTS interview_stages (
+    id SERIAL PRIMARY KEY,
+    interview_id INTEGER NOT NULL REFERENCES interviews(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('phone_screen', 'technical', 'system_design', 'behavioral', 'onsite', 'other')),
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    position INTEGER NOT NULL DEFAULT 0,
+    scheduled_at TIMESTAMP,
+    outcome VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (outcome IN ('pending', 'passed', 'failed', 'cancelled')),
+    notes TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_interview_stages_interview ON interview_stages(interview_id, position);
+
+CREATE TABLE IF NOT EXISTS interview_stage_items (
+    stage_id INTEGER NOT NULL REFERENCES interview_stages(id) ON DELETE CASCADE,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (stage_id, item_id)
+);
+`
+
+const createFocusSessionsTables = `
+CREATE TABLE IF NOT EXISTS focus_sessions (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    item_id INTEGER REFERENCES items(id) ON DELETE SET NULL,
+    test_session_id VARCHAR(255),
+    status VARCHAR(20) NOT NULL DEFAULT 'running' CHECK (status IN ('running', 'paused', 'stopped')),
+    started_at TIMESTAMP NOT NULL,
+    running_since TIMESTAMP,
+    focused_seconds INTEGER NOT NULL DEFAULT 0,
+    ended_at TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_focus_sessions_one_active ON focus_sessions(user_id) WHERE status <> 'stopped';
+CREATE INDEX IF NOT EXISTS idx_focus_sessions_user_started ON focus_sessions(user_id, started_at);
+
+CREATE TABLE IF NOT EXISTS focus_session_events (
+    id SERIAL PRIMARY KEY,
+    session_id INTEGER NOT NULL REFERENCES focus_sessions(id) ON DELETE CASCADE,
+    kind VARCHAR(10) NOT NULL CHECK (kind IN ('start', 'pause', 'resume', 'stop')),
+    occurred_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_focus_session_events_session ON focus_session_events(session_id, occurred_at);
+`
+
+const createAIUsageTables = `
+CREATE TABLE IF NOT EXISTS ai_usage (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    period_start DATE NOT NULL,
+    calls INTEGER NOT NULL DEFAULT 0,
+    tokens BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, period_start)
+);
+
+CREATE TABLE IF NOT EXISTS ai_usage_features (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    period_start DATE NOT NULL,
+    feature VARCHAR(50) NOT NULL,
+    calls INTEGER NOT NULL DEFAULT 0,
+    tokens BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id, period_start, feature)
+);
+`
+
+const createSubscriptionsTables = `
+CREATE TABLE IF NOT EXISTS subscriptions (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    plan VARCHAR(20) NOT NULL DEFAULT 'free' CHECK (plan IN ('free', 'pro')),
+    status VARCHAR(30) NOT NULL DEFAULT 'none',
+    stripe_customer_id VARCHAR(255) UNIQUE,
+    stripe_subscription_id VARCHAR(255),
+    current_period_end TIMESTAMP,
+    cancel_at_period_end BOOLEAN NOT NULL DEFAULT false,
+    comped BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS stripe_events (
+    id VARCHAR(255) PRIMARY KEY,
+    type VARCHAR(100) NOT NULL,
+    processed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+`
+
+const addUserProgressSkipCount = `
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns
+                   WHERE table_name='user_progress' AND column_name='skip_count') THEN
+        ALTER TABLE user_progress ADD COLUMN skip_count INTEGER NOT NULL DEFAULT 0;
+    END IF;
+END $$;
+`
+
+const addSubscriptionLifecycle = `
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns
+                   WHERE table_name='subscriptions' AND column_name='trial_ends_at') THEN
+        ALTER TABLE subscriptions ADD COLUMN trial_started_at TIMESTAMP;
+        ALTER TABLE subscriptions ADD COLUMN trial_ends_at TIMESTAMP;
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns
+                   WHERE table_name='subscriptions' AND column_name='downgraded_at') THEN
+        ALTER TABLE subscriptions ADD COLUMN downgraded_at TIMESTAMP;
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns
+                   WHERE table_name='subscriptions' AND column_name='past_due_since') THEN
+        ALTER TABLE subscriptions ADD COLUMN past_due_since TIMESTAMP;
+    END IF;
+END $$;
+
+CREATE INDEX IF NOT EXISTS idx_subscriptions_status ON subscriptions(status);
+
+CREATE TABLE IF NOT EXISTS billing_notices (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind VARCHAR(50) NOT NULL,
+    reference VARCHAR(255) NOT NULL,
+    sent_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, kind, reference)
+);
+`
+
+const addLoginSecurity = `
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns
+                   WHERE table_name='refresh_tokens' AND column_name='ip_address') THEN
+        ALTER TABLE refresh_tokens ADD COLUMN ip_address VARCHAR(64) NOT NULL DEFAULT '';
+        ALTER TABLE refresh_tokens ADD COLUMN user_agent TEXT NOT NULL DEFAULT '';
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns
+                   WHERE table_name='refresh_tokens' AND column_name='alert_token') THEN
+        ALTER TABLE refresh_tokens ADD COLUMN alert_token VARCHAR(64);
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns
+                   WHERE table_name='users' AND column_name='sessions_revoked_at') THEN
+        ALTER TABLE users ADD COLUMN sessions_revoked_at TIMESTAMP;
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns
+                   WHERE table_name='users' AND column_name='password_reset_required') THEN
+        ALTER TABLE users ADD COLUMN password_reset_required BOOLEAN NOT NULL DEFAULT false;
+        ALTER TABLE users ADD COLUMN password_reset_token VARCHAR(64);
+        ALTER TABLE users ADD COLUMN password_reset_expires_at TIMESTAMP;
+    END IF;
+END $$;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_refresh_tokens_alert_token ON refresh_tokens(alert_token) WHERE alert_token IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_password_reset_token ON users(password_reset_token) WHERE password_reset_token IS NOT NULL;
+`
+
+// addUserStatsCounterTriggers keeps the user_stats item counts current as user_progress rows
+// change, so they no longer need recomputing after every write. Only fresh rows are adjusted:
+// stale ones are recomputed in full before they're read. An item with no progress row counts as
+// pending, so each row is applied as the difference it makes over having no row at all.
+const addUserStatsCounterTriggers = `
+CREATE OR REPLACE FUNCTION apply_user_progress_stats(p_user_id INTEGER, p_item_id INTEGER, p_status TEXT, p_quality TEXT, p_sign INTEGER)
+RETURNS VOID AS $$
+DECLARE
+    item_category TEXT;
+    done INTEGER := CASE WHEN p_status = 'done' THEN p_sign ELSE 0 END;
+BEGIN
+    SELECT category INTO item_category FROM items WHERE id = p_item_id;
+    -- Deleted items are accounted for when the catalog change marks stats stale
+    IF item_category IS NULL OR item_category = 'miscellaneous' THEN
+        RETURN;
+    END IF;
+
+    UPDATE user_stats SET
+        completed_items = completed_items + done,
+        in_progress_items = in_progress_items + CASE WHEN p_status = 'in-progress' THEN p_sign ELSE 0 END,
+        pending_items = pending_items - CASE WHEN COALESCE(p_status, 'pending') = 'pending' THEN 0 ELSE p_sign END,
+        dsa_completed = dsa_completed + CASE WHEN item_category = 'dsa' THEN done ELSE 0 END,
+        lld_completed = lld_completed + CASE WHEN item_category = 'lld' THEN done ELSE 0 END,
+        hld_completed = hld_completed + CASE WHEN item_category = 'hld' THEN done ELSE 0 END,
+        solved_items = solved_items + CASE WHEN COALESCE(p_quality, 'solved') = 'solved' THEN done ELSE 0 END,
+        reviewed_solution_items = reviewed_solution_items + CASE WHEN p_quality = 'reviewed_solution' THEN done ELSE 0 END,
+        updated_at = CURRENT_TIMESTAMP
+    WHERE user_id = p_user_id AND stats_refreshed_at IS NOT NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION user_progress_stats_trigger()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        PERFORM apply_user_progress_stats(OLD.user_id, OLD.item_id, OLD.status, OLD.completion_quality, -1);
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        PERFORM apply_user_progress_stats(NEW.user_id, NEW.item_id, NEW.status, NEW.completion_quality, 1);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_trigger WHERE tgname = 'user_progress_stats') THEN
+        CREATE TRIGGER user_progress_stats
+            AFTER INSERT OR DELETE OR UPDATE OF status, completion_quality, item_id, user_id ON user_progress
+            FOR EACH ROW EXECUTE PROCEDURE user_progress_stats_trigger();
+        -- Counts refreshed before the trigger existed may have missed writes since
+        UPDATE user_stats SET stats_refreshed_at = NULL;
+    END IF;
+END $$;
+`
+
+const createItemFeedbackTable = `
+CREATE TABLE IF NOT EXISTS item_feedback (
+    id SERIAL PRIMARY KEY,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    user_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    category VARCHAR(20) NOT NULL CHECK (category IN ('dead_link', 'wrong_category', 'duplicate', 'other')),
+    comment TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'resolved', 'dismissed')),
+    reviewed_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    review_note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    reviewed_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_item_feedback_status ON item_feedback(status, created_at);
+CREATE INDEX IF NOT EXISTS idx_item_feedback_item ON item_feedback(item_id);
+CREATE INDEX IF NOT EXISTS idx_item_feedback_user ON item_feedback(user_id, created_at);
+`
+
+const addEngBlogArchival = `
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns
+                   WHERE table_name='eng_blogs' AND column_name='archived_at') THEN
+        ALTER TABLE eng_blogs ADD COLUMN archived_at TIMESTAMP;
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns
+                   WHERE table_name='eng_blog_articles' AND column_name='archived_at') THEN
+        ALTER TABLE eng_blog_articles ADD COLUMN archived_at TIMESTAMP;
+    END IF;
+END $$;
+
+CREATE INDEX IF NOT EXISTS idx_eng_blogs_active ON eng_blogs(order_idx) WHERE archived_at IS NULL;
+`
+
+const createLinkChecksTable = `
+CREATE TABLE IF NOT EXISTS link_checks (
+    id SERIAL PRIMARY KEY,
+    item_id INTEGER UNIQUE REFERENCES items(id) ON DELETE CASCADE,
+    article_id INTEGER UNIQUE REFERENCES eng_blog_articles(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    status_code INTEGER,
+    error TEXT NOT NULL DEFAULT '',
+    failures INTEGER NOT NULL DEFAULT 0,
+    checked_at TIMESTAMP NOT NULL,
+    dead_since TIMESTAMP,
+    CHECK ((item_id IS NULL) <> (article_id IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_link_checks_dead ON link_checks(dead_since) WHERE dead_since IS NOT NULL;
+`
+
+const addEngBlogArticleLinkUniqueness = `
+-- Archive repeated imports of the same article, keeping the oldest copy, so each blog has at
+-- most one active article per normalized link
+UPDATE eng_blog_articles a
+SET archived_at = CURRENT_TIMESTAMP
+WHERE a.archived_at IS NULL AND EXISTS (
+    SELECT 1 FROM eng_blog_articles b
+    WHERE b.blog_id = a.blog_id AND b.archived_at IS NULL AND b.id < a.id
+      AND RTRIM(LOWER(TRIM(b.external_link)), '/') = RTRIM(LOWER(TRIM(a.external_link)), '/')
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_eng_blog_articles_blog_link
+    ON eng_blog_articles (blog_id, (RTRIM(LOWER(TRIM(external_link)), '/')))
+    WHERE archived_at IS NULL;
+`
+
+const addUserFirstCompletedAt = `
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns
+                   WHERE table_name='users' AND column_name='first_completed_at') THEN
+        ALTER TABLE users ADD COLUMN first_completed_at TIMESTAMP;
+
+        -- Backfill existing users so their next completion isn't counted as their first
+        UPDATE users u
+        SET first_completed_at = firsts.completed_at
+        FROM (
+            SELECT user_id, MIN(completed_at) AS completed_at
+            FROM user_progress
+            WHERE completed_at IS NOT NULL
+            GROUP BY user_id
+        ) firsts
+        WHERE firsts.user_id = u.id;
+    END IF;
+END $$;
+`
+
+const addItemSourceArticle = `
+-- Items promoted from an eng blog article keep a link back to it; each article is promoted at most once
+ALTER TABLE items ADD COLUMN IF NOT EXISTS source_article_id INTEGER REFERENCES eng_blog_articles(id) ON DELETE SET NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_items_source_article ON items(source_article_id) WHERE source_article_id IS NOT NULL;
+`
+
+const createCategoriesTable = `
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM information_schema.tables WHERE table_name='categories') THEN
+        CREATE TABLE categories (
+            slug VARCHAR(50) PRIMARY KEY CHECK (slug ~ '^[a-z0-9][a-z0-9_-]*$'),
+            name VARCHAR(100) NOT NULL,
+            order_idx INTEGER NOT NULL DEFAULT 0,
+            created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+            updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+        );
+
+        CREATE TABLE category_subcategories (
+            category VARCHAR(50) NOT NULL REFERENCES categories(slug) ON DELETE CASCADE,
+            name VARCHAR(100) NOT NULL,
+            order_idx INTEGER NOT NULL DEFAULT 0,
+            PRIMARY KEY (category, name)
+        );
+
+        -- Seed the categories and subcategories that used to be defined in code
+        INSERT INTO categories (slug, name, order_idx) VALUES
+            ('dsa', 'Data Structures & Algorithms', 1),
+            ('lld', 'Low Level Design', 2),
+            ('hld', 'High Level Design', 3),
+            ('miscellaneous', 'Miscellaneous', 4);
+
+        INSERT INTO category_subcategories (category, name, order_idx)
+        SELECT 'dsa', s.name, s.idx FROM unnest(ARRAY[
+            'arrays',
+            'strings',
+            'two-pointers',
+            'sliding window - fixed size',
+            'sliding window - dynamic size',
+            'prefix-sum',
+            'kadane''s algorithm',
+            'matrix (2d array)',
+            'linked-lists',
+            'linkedList in-place reversal',
+            'fast and slow pointers',
+            'stacks',
+            'monotonic stack',
+            'queues',
+            'monotonic queue',
+            'hashing',
+            'bit-manipulation',
+            'bucket sort',
+            'recursion',
+            'divide-conquer',
+            'merge sort',
+            'quickSort / quickSelect',
+            'binary search',
+            'backtracking',
+            'tree traversal - level order',
+            'tree traversal - pre order',
+            'tree traversal - in order',
+            'tree traversal - post-order',
+            'bst / ordered set',
+            'tries',
+            'heaps',
+            'two heaps',
+            'top k elements',
+            'intervals',
+            'k-way merge',
+            'data structure design',
+            'graphs',
+            'depth first search (dfs)',
+            'breadth first search (bfs)',
+            'topological sort',
+            'union find',
+            'minimum spanning tree',
+            'shortest path',
+            'eulerian circuit',
+            'greedy',
+            '1-d dp',
+            'knapsack dp',
+            'unbounded knapsack dp',
+            'longest increasing subsequence dp',
+            '2d (grid) dp',
+            'string dp',
+            'tree / graph dp',
+            'bitmask dp',
+            'digit dp',
+            'probability dp',
+            'state machine dp',
+            'string matching',
+            'binary indexed tree / segment tree',
+            'maths / geometry',
+            'line sweep',
+            'suffix array',
+            'other'
+        ]) WITH ORDINALITY AS s(name, idx);
+
+        INSERT INTO category_subcategories (category, name, order_idx)
+        SELECT 'lld', s.name, s.idx FROM unnest(ARRAY[
+            'object-oriented-programming',
+            'design-principles',
+            'uml',
+            'design-patterns-creational',
+            'design-patterns-structural',
+            'design-patterns-behavioral',
+            'lld-interview-tips',
+            'lld-interview-questions'
+        ]) WITH ORDINALITY AS s(name, idx);
+
+        INSERT INTO category_subcategories (category, name, order_idx)
+        SELECT 'hld', s.name, s.idx FROM unnest(ARRAY[
+            'introduction',
+            'core concepts',
+            'databases and storage',
+            'database scaling techniques',
+            'caching',
+            'networking',
+            'api',
+            'asynchronous communications',
+            'tradeoffs',
+            'distributed system concepts',
+            'microservices',
+            'big data processing',
+            'architectural patterns',
+            'observability',
+            'security',
+            'interview tips',
+            'interview questions'
+        ]) WITH ORDINALITY AS s(name, idx);
+
+        INSERT INTO category_subcategories (category, name, order_idx)
+        SELECT 'miscellaneous', s.name, s.idx FROM unnest(ARRAY[
+            'gre',
+            'finance',
+            'development',
+            'sql',
+            'books',
+            'test_n_revise',
+            'other'
+        ]) WITH ORDINALITY AS s(name, idx);
+
+        -- Items now reference the categories table instead of a fixed list
+        ALTER TABLE items DROP CONSTRAINT IF EXISTS items_category_check;
+        ALTER TABLE items ADD CONSTRAINT items_category_fkey
+            FOREIGN KEY (category) REFERENCES categories(slug);
+    END IF;
+END $$;
+`
+
+const createBehavioralTables = `
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM information_schema.tables WHERE table_name='behavioral_questions') THEN
+        CREATE TABLE behavioral_questions (
+            id SERIAL PRIMARY KEY,
+            question TEXT NOT NULL,
+            competencies TEXT[] NOT NULL DEFAULT '{}',
+            created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+            updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+        );
+
+        CREATE INDEX idx_behavioral_questions_competencies ON behavioral_questions USING GIN (competencies);
+
+        -- Starter questions; admins curate the bank from here
+        INSERT INTO behavioral_questions (question, competencies) VALUES
+            ('Tell me about a time you led a project without formal authority.', '{leadership,influence}'),
+            ('Describe a disagreement with a teammate and how you resolved it.', '{conflict,communication}'),
+            ('Tell me about a time you failed. What did you learn?', '{failure,ownership}'),
+            ('Describe a time you took ownership of a problem outside your responsibilities.', '{ownership}'),
+            ('Tell me about a decision you made with incomplete information.', '{ambiguity}'),
+            ('Describe a time you had to push back on a stakeholder.', '{conflict,influence,customer_focus}'),
+            ('Tell me about a time you had too many priorities. How did you decide what to drop?', '{prioritization}'),
+            ('Describe a time you helped a struggling teammate.', '{teamwork,leadership}'),
+            ('Tell me about a time you explained a complex technical topic to a non-technical audience.', '{communication}'),
+            ('Describe a time you went beyond what a customer asked for.', '{customer_focus}');
+    END IF;
+END $$;
+
+CREATE TABLE IF NOT EXISTS behavioral_answers (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    question_id INTEGER NOT NULL REFERENCES behavioral_questions(id) ON DELETE CASCADE,
+    situation TEXT NOT NULL DEFAULT '',
+    task TEXT NOT NULL DEFAULT '',
+    action TEXT NOT NULL DEFAULT '',
+    result TEXT NOT NULL DEFAULT '',
+    practice_count INTEGER NOT NULL DEFAULT 0,
+    last_practiced_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, question_id)
+);
+`
+
+const createItemDesignNotesTable = `
+CREATE TABLE IF NOT EXISTS item_design_notes (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    notes JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, item_id)
+);
+`
+
+const createSubmissionTables = `
+CREATE TABLE IF NOT EXISTS item_test_cases (
+    id SERIAL PRIMARY KEY,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    input TEXT NOT NULL DEFAULT '',
+    expected_output TEXT NOT NULL,
+    is_sample BOOLEAN NOT NULL DEFAULT false,
+    order_idx INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_item_test_cases_item ON item_test_cases(item_id, order_idx);
+
+CREATE TABLE IF NOT EXISTS submission_attempts (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    language VARCHAR(20) NOT NULL,
+    code TEXT NOT NULL,
+    passed BOOLEAN NOT NULL,
+    passed_cases INTEGER NOT NULL,
+    total_cases INTEGER NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_submission_attempts_user_item ON submission_attempts(user_id, item_id, created_at DESC);
+`
+
+const addAccountDeletion = `
+DO $$ 
+BEGIN 
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns 
+                   WHERE table_name='users' AND column_name='purge_at') THEN
+        ALTER TABLE users ADD COLUMN purge_at TIMESTAMP;
+    END IF;
+END $$;
+
+CREATE INDEX IF NOT EXISTS idx_users_purge_at ON users(purge_at) WHERE purge_at IS NOT NULL;
+`
+
+const createPublicProfilesTable = `
+CREATE TABLE IF NOT EXISTS public_profiles (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    handle VARCHAR(32) NOT NULL UNIQUE,
+    enabled BOOLEAN NOT NULL DEFAULT false,
+    show_name BOOLEAN NOT NULL DEFAULT false,
+    show_streak BOOLEAN NOT NULL DEFAULT true,
+    show_totals BOOLEAN NOT NULL DEFAULT true,
+    show_badges BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+`
+
+const createProgressReportsTable = `
+CREATE TABLE IF NOT EXISTS progress_reports (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    snapshot JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_progress_reports_expires_at ON progress_reports(expires_at);
+`
+
+const addWebhookFormats = `
+ALTER TABLE user_webhooks ADD COLUMN IF NOT EXISTS format VARCHAR(20) NOT NULL DEFAULT 'json';
+-- The first weekly digest goes out a week after a webhook is registered
+ALTER TABLE user_webhooks ADD COLUMN IF NOT EXISTS last_digest_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_webhooks_chat_format ON user_webhooks(user_id, format) WHERE format != 'json';
+`
+
+const createGitHubConnectionsTable = `
+CREATE TABLE IF NOT EXISTS github_connections (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    github_login VARCHAR(100) NOT NULL,
+    access_token BYTEA NOT NULL, -- encrypted with the user's data key
+    repository VARCHAR(200) NOT NULL DEFAULT '',
+    branch VARCHAR(100) NOT NULL DEFAULT '',
+    path_template VARCHAR(200) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE submission_attempts ADD COLUMN IF NOT EXISTS commit_sha VARCHAR(40);
+`
+
+const createJobTables = `
+CREATE TABLE IF NOT EXISTS job_locks (
+    name VARCHAR(100) PRIMARY KEY,
+    locked_by VARCHAR(200) NOT NULL,
+    locked_until TIMESTAMP NOT NULL,
+    last_started_at TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS job_runs (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    instance VARCHAR(200) NOT NULL,
+    triggered_by VARCHAR(20) NOT NULL CHECK (triggered_by IN ('schedule', 'manual')),
+    status VARCHAR(20) NOT NULL DEFAULT 'running' CHECK (status IN ('running', 'succeeded', 'failed')),
+    error TEXT NOT NULL DEFAULT '',
+    started_at TIMESTAMP NOT NULL,
+    finished_at TIMESTAMP,
+    duration_ms BIGINT
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_runs_name ON job_runs(name, started_at DESC);
+CREATE INDEX IF NOT EXISTS idx_job_runs_started_at ON job_runs(started_at);
+`
+
+const createFeatureFlagsTable = `
+CREATE TABLE IF NOT EXISTS feature_flags (
+    key VARCHAR(64) PRIMARY KEY,
+    description TEXT NOT NULL DEFAULT '',
+    enabled BOOLEAN NOT NULL DEFAULT false,
+    rollout_percent INTEGER NOT NULL DEFAULT 0 CHECK (rollout_percent BETWEEN 0 AND 100),
+    user_ids BIGINT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+`
+
+const addOrgAdminsAndCatalog = `
+ALTER TABLE organization_members DROP CONSTRAINT IF EXISTS organization_members_role_check;
+ALTER TABLE organization_members ADD CONSTRAINT organization_members_role_check
+    CHECK (role IN ('owner', 'admin', 'member'));
+
+ALTER TABLE organization_invitations DROP CONSTRAINT IF EXISTS organization_invitations_role_check;
+ALTER TABLE organization_invitations ADD CONSTRAINT organization_invitations_role_check
+    CHECK (role IN ('owner', 'admin', 'member'));
+
+CREATE TABLE IF NOT EXISTS org_catalog_items (
+    org_id INTEGER NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL DEFAULT 0,
+    note TEXT NOT NULL DEFAULT '',
+    added_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (org_id, item_id)
+);
+`
+
+const createRolesTables = `
+CREATE TABLE IF NOT EXISTS roles (
+    name VARCHAR(20) PRIMARY KEY,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role VARCHAR(20) NOT NULL REFERENCES roles(name) ON DELETE CASCADE,
+    permission VARCHAR(64) NOT NULL,
+    PRIMARY KEY (role, permission)
+);
+
+-- Built-in roles get their default permissions only when first created, so later edits stick
+WITH created AS (
+    INSERT INTO roles (name, description) VALUES
+        ('user', 'Prepares for interviews'),
+        ('admin', 'Has every permission'),
+        ('content_editor', 'Edits the catalog'),
+        ('moderator', 'Reviews feedback reported on items')
+    ON CONFLICT (name) DO NOTHING
+    RETURNING name
+)
+INSERT INTO role_permissions (role, permission)
+SELECT defaults.role, defaults.permission
+FROM (VALUES ('content_editor', 'content:write'), ('moderator', 'feedback:moderate')) AS defaults(role, permission)
+INNER JOIN created ON created.name = defaults.role;
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM information_schema.table_constraints
+                   WHERE table_name = 'users' AND constraint_name = 'users_role_fkey') THEN
+        ALTER TABLE users ADD CONSTRAINT users_role_fkey FOREIGN KEY (role) REFERENCES roles(name);
+    END IF;
+END $$;
+`
+
+const createAPIKeysTable = `
+CREATE TABLE IF NOT EXISTS user_api_keys (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    key_prefix VARCHAR(16) NOT NULL,
+    key_hash VARCHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    last_used_at TIMESTAMP,
+    revoked_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_api_keys_user_id ON user_api_keys(user_id);
+`
+
+const addEditVersions = `
+ALTER TABLE items ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE item_design_notes ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+`
+
+const addTestResults = `
+ALTER TABLE tests ADD COLUMN IF NOT EXISTS outcome VARCHAR(10) CHECK (outcome IN ('solved', 'partial', 'failed'));
+ALTER TABLE tests ADD COLUMN IF NOT EXISTS time_taken_seconds INTEGER CHECK (time_taken_seconds >= 0);
+ALTER TABLE tests ADD COLUMN IF NOT EXISTS self_rating SMALLINT CHECK (self_rating BETWEEN 1 AND 5);
+ALTER TABLE tests ADD COLUMN IF NOT EXISTS result_notes TEXT NOT NULL DEFAULT '';
+ALTER TABLE tests ADD COLUMN IF NOT EXISTS result_recorded_at TIMESTAMP;
+`
+
+const addTestRetakes = `
+ALTER TABLE tests ADD COLUMN IF NOT EXISTS parent_session_id UUID;
+CREATE INDEX IF NOT EXISTS idx_tests_parent_session ON tests(parent_session_id);
+`
+
+const createTestEligibilityPolicy = `
+CREATE TABLE IF NOT EXISTS test_eligibility_policy (
+    id BOOLEAN PRIMARY KEY DEFAULT true CHECK (id),
+    rules JSONB NOT NULL DEFAULT '[]',
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+`
+
+const createQuizTables = `
+CREATE TABLE IF NOT EXISTS quizzes (
+    id SERIAL PRIMARY KEY,
+    title VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    category VARCHAR(50) NOT NULL,
+    subcategory VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_quizzes_category ON quizzes(category, subcategory);
+
+CREATE TABLE IF NOT EXISTS quiz_questions (
+    id SERIAL PRIMARY KEY,
+    quiz_id INTEGER NOT NULL REFERENCES quizzes(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    prompt TEXT NOT NULL,
+    choices TEXT[] NOT NULL,
+    correct_choice INTEGER NOT NULL,
+    explanation TEXT NOT NULL DEFAULT '',
+    UNIQUE (quiz_id, position)
+);
+
+-- Attempts keep their graded answers, so they survive the quiz's questions being replaced
+CREATE TABLE IF NOT EXISTS quiz_attempts (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    quiz_id INTEGER NOT NULL REFERENCES quizzes(id) ON DELETE CASCADE,
+    correct INTEGER NOT NULL,
+    total INTEGER NOT NULL,
+    results JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_quiz_attempts_user ON quiz_attempts(user_id, quiz_id, created_at DESC);
+`
+
+const createAIGeneratedQuestionsTable = `
+CREATE TABLE IF NOT EXISTS ai_generated_questions (
+    id SERIAL PRIMARY KEY,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    user_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('follow_up', 'variation')),
+    questions TEXT[] NOT NULL,
+    provider VARCHAR(50) NOT NULL,
+    model VARCHAR(100) NOT NULL,
+    tokens BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_ai_generated_questions_item ON ai_generated_questions(item_id, kind, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_ai_generated_questions_user ON ai_generated_questions(user_id, created_at);
+`
+
+const createDocumentsTable = `
+CREATE TABLE IF NOT EXISTS documents (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind VARCHAR(30) NOT NULL CHECK (kind IN ('revision_sheet')),
+    title VARCHAR(255) NOT NULL,
+    category VARCHAR(50) NOT NULL,
+    subcategory VARCHAR(100) NOT NULL DEFAULT '',
+    content TEXT NOT NULL,
+    source_items INTEGER NOT NULL DEFAULT 0,
+    truncated BOOLEAN NOT NULL DEFAULT false,
+    provider VARCHAR(50) NOT NULL DEFAULT '',
+    model VARCHAR(100) NOT NULL DEFAULT '',
+    tokens BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_documents_user ON documents(user_id, created_at DESC);
+
+-- Notes stay out of AI features until the user opts in
+CREATE TABLE IF NOT EXISTS user_ai_settings (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    notes_opt_in BOOLEAN NOT NULL DEFAULT false,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+`
+
+const createMockInterviewTables = `
+CREATE TABLE IF NOT EXISTS mock_interviews (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    test_session_id VARCHAR(255),
+    status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'ended')),
+    feedback TEXT NOT NULL DEFAULT '',
+    provider VARCHAR(50) NOT NULL DEFAULT '',
+    model VARCHAR(100) NOT NULL DEFAULT '',
+    tokens BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    ended_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_mock_interviews_user ON mock_interviews(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_mock_interviews_test ON mock_interviews(user_id, test_session_id) WHERE test_session_id IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS mock_interview_messages (
+    id SERIAL PRIMARY KEY,
+    interview_id INTEGER NOT NULL REFERENCES mock_interviews(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('interviewer', 'candidate')),
+    content TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_mock_interview_messages_interview ON mock_interview_messages(interview_id, id);
+`
+
+// createItemEmbeddingsTable needs the pgvector extension. Without it, or the privilege to create
+// it, the table is left out and similar items stay disabled rather than failing startup.
+const createItemEmbeddingsTable = `
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_available_extensions WHERE name = 'vector') THEN
+        RAISE NOTICE 'pgvector is not installed: similar items are disabled';
+        RETURN;
+    END IF;
+
+    CREATE EXTENSION IF NOT EXISTS vector;
+
+    CREATE TABLE IF NOT EXISTS item_embeddings (
+        item_id INTEGER PRIMARY KEY REFERENCES items(id) ON DELETE CASCADE,
+        model VARCHAR(100) NOT NULL,
+        source_hash VARCHAR(64) NOT NULL,
+        embedding vector(1536) NOT NULL,
+        updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+    );
+
+    CREATE INDEX IF NOT EXISTS idx_item_embeddings_cosine ON item_embeddings USING hnsw (embedding vector_cosine_ops);
+EXCEPTION WHEN insufficient_privilege THEN
+    RAISE NOTICE 'not allowed to create the pgvector extension: similar items are disabled';
+END
+$$;
+`
+
+// createItemRevisionsTable keeps the content of items after each edit so an edit can be undone.
+// The first recorded edit of an item also stores its content from before, with no editor.
+const createItemRevisionsTable = `
+CREATE TABLE IF NOT EXISTS item_revisions (
+    id SERIAL PRIMARY KEY,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    editor_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    changes JSONB NOT NULL DEFAULT '{}',
+    snapshot JSONB NOT NULL,
+    reverted_from INTEGER REFERENCES item_revisions(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_item_revisions_item ON item_revisions(item_id, id DESC);
+`
+
+// addItemPublishedColumn starts existing items out published; only items created by editors who
+// can't publish are drafts
+const addItemPublishedColumn = `
+ALTER TABLE items ADD COLUMN IF NOT EXISTS published BOOLEAN NOT NULL DEFAULT TRUE;
+
+CREATE INDEX IF NOT EXISTS idx_items_drafts ON items(created_at DESC) WHERE NOT published;
+`
+
+const addItemPublishAtColumn = `
+ALTER TABLE items ADD COLUMN IF NOT EXISTS publish_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_items_scheduled ON items(publish_at) WHERE NOT published AND publish_at IS NOT NULL;
+`
+
+// addNotificationLocaleColumn stores the language a user wants messages in; empty follows their
+// browser's Accept-Language header
+const addNotificationLocaleColumn = `
+ALTER TABLE user_notification_preferences ADD COLUMN IF NOT EXISTS locale VARCHAR(16) NOT NULL DEFAULT '';
+`
+
+// addUserProgressHintCredit records the hint credit multiplier a completion earned; NULL until
+// the item is completed, and on completions from before hints cost credit
+const addUserProgressHintCredit = `
+ALTER TABLE user_progress ADD COLUMN IF NOT EXISTS hint_credit REAL;
+`
@@ -0,0 +1,58 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+)
+
+// rowSecurityTables are the per-user tables protected by row-level security policies
+var rowSecurityTables = []string{"user_progress", "tests"}
+
+// ConfigureRowSecurity enables or disables row-level security on per-user tables.
+//
+// When enabled, rows are only visible to the user named by the app.user_id setting, which the
+// repositories set per transaction. Queries that run without a user context (admin and aggregate
+// queries) are still allowed, so the policies act as a safety net for user-scoped queries rather
+// than a full access-control layer. Superusers always bypass row-level security. SQLite has no
+// row-level security, so it can't be enabled there.
+func ConfigureRowSecurity(db *sql.DB, enabled bool) error {
+	if DialectFor(db) == DialectSQLite {
+		if enabled {
+			return fmt.Errorf("row-level security needs PostgreSQL; unset DB_ROW_SECURITY to use SQLite")
+		}
+		return nil
+	}
+
+	for _, table := range rowSecurityTables {
+		var statements []string
+		if enabled {
+			statements = []string{
+				fmt.Sprintf("DROP POLICY IF EXISTS %s_user_isolation ON %s", table, table),
+				fmt.Sprintf(`CREATE POLICY %s_user_isolation ON %s
+					USING (COALESCE(current_setting('app.user_id', true), '') = '' OR user_id = current_setting('app.user_id', true)::integer)
+					WITH CHECK (COALESCE(current_setting('app.user_id', true), '') = '' OR user_id = current_setting('app.user_id', true)::integer)`,
+					table, table),
+				fmt.Sprintf("ALTER TABLE %s ENABLE ROW LEVEL SECURITY", table),
+				// The application usually connects as the table owner, which bypasses RLS unless forced
+				fmt.Sprintf("ALTER TABLE %s FORCE ROW LEVEL SECURITY", table),
+			}
+		} else {
+			statements = []string{
+				fmt.Sprintf("ALTER TABLE %s NO FORCE ROW LEVEL SECURITY", table),
+				fmt.Sprintf("ALTER TABLE %s DISABLE ROW LEVEL SECURITY", table),
+			}
+		}
+
+		for _, stmt := range statements {
+			if _, err := db.Exec(stmt); err != nil {
+				return fmt.Errorf("failed to configure row security on %s: %w", table, err)
+			}
+		}
+	}
+
+	if enabled {
+		log.Println("Row-level security enabled on per-user tables")
+	}
+	return nil
+}
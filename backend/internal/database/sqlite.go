@@ -0,0 +1,560 @@
+package database
+
+import (
+	"context"
+	crand "crypto/rand"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// SQLiteDriverName is the database/sql driver name for SQLite databases. The repositories write
+// Postgres SQL; the driver translates it as statements are prepared (see translateSQLite) and
+// supplies the Postgres functions they call. The SQLite driver needs cgo.
+const SQLiteDriverName = "sqlite"
+
+func init() {
+	sql.Register(SQLiteDriverName, &sqliteDriver{base: &sqlite3.SQLiteDriver{ConnectHook: registerSQLiteFunctions}})
+}
+
+// sqliteTimeFormat is how times are stored: UTC, so they sort and compare as text
+const sqliteTimeFormat = "2006-01-02 15:04:05.999999999"
+
+// sqliteTimeText matches the text of a stored time or date
+var sqliteTimeText = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}([ T]\d{2}:\d{2}:\d{2}(\.\d+)?Z?)?$`)
+
+// sqliteDriver opens SQLite connections that speak the repositories' Postgres SQL
+type sqliteDriver struct {
+	base *sqlite3.SQLiteDriver
+}
+
+// Open opens a translating connection
+func (d *sqliteDriver) Open(name string) (driver.Conn, error) {
+	conn, err := d.base.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return &sqliteConn{conn: conn.(*sqlite3.SQLiteConn)}, nil
+}
+
+// sqliteConn translates queries and arguments before handing them to SQLite
+type sqliteConn struct {
+	conn *sqlite3.SQLiteConn
+}
+
+// Prepare prepares a translated statement
+func (c *sqliteConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+// PrepareContext prepares a translated statement
+func (c *sqliteConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	stmt, err := c.conn.PrepareContext(ctx, translateSQLite(query))
+	if err != nil {
+		return nil, err
+	}
+	return &sqliteStmt{stmt: stmt.(*sqlite3.SQLiteStmt)}, nil
+}
+
+// Close closes the connection
+func (c *sqliteConn) Close() error {
+	return c.conn.Close()
+}
+
+// Begin starts a transaction
+func (c *sqliteConn) Begin() (driver.Tx, error) {
+	return c.conn.Begin()
+}
+
+// BeginTx starts a transaction
+func (c *sqliteConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	return c.conn.BeginTx(ctx, opts)
+}
+
+// ExecContext runs a translated statement, which may hold several separated by semicolons
+func (c *sqliteConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	return c.conn.ExecContext(ctx, translateSQLite(query), sqliteArgs(args))
+}
+
+// QueryContext runs a translated query
+func (c *sqliteConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	rows, err := c.conn.QueryContext(ctx, translateSQLite(query), sqliteArgs(args))
+	if err != nil {
+		return nil, err
+	}
+	return &sqliteRows{rows: rows.(*sqlite3.SQLiteRows)}, nil
+}
+
+// Ping checks the connection
+func (c *sqliteConn) Ping(ctx context.Context) error {
+	return c.conn.Ping(ctx)
+}
+
+// sqliteStmt converts a prepared statement's arguments and results
+type sqliteStmt struct {
+	stmt *sqlite3.SQLiteStmt
+}
+
+// Close closes the statement
+func (s *sqliteStmt) Close() error {
+	return s.stmt.Close()
+}
+
+// NumInput returns the number of placeholders
+func (s *sqliteStmt) NumInput() int {
+	return s.stmt.NumInput()
+}
+
+// Exec runs the statement
+func (s *sqliteStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.ExecContext(context.Background(), namedValues(args))
+}
+
+// Query runs the statement
+func (s *sqliteStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.QueryContext(context.Background(), namedValues(args))
+}
+
+// ExecContext runs the statement
+func (s *sqliteStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	return s.stmt.ExecContext(ctx, sqliteArgs(args))
+}
+
+// QueryContext runs the statement
+func (s *sqliteStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	rows, err := s.stmt.QueryContext(ctx, sqliteArgs(args))
+	if err != nil {
+		return nil, err
+	}
+	return &sqliteRows{rows: rows.(*sqlite3.SQLiteRows)}, nil
+}
+
+// sqliteRows returns text the way lib/pq does: as bytes, which the models' scanners expect, and
+// as times where an expression such as MAX(completed_at) computed one
+type sqliteRows struct {
+	rows     *sqlite3.SQLiteRows
+	declared []string
+}
+
+// Columns returns the column names
+func (r *sqliteRows) Columns() []string {
+	return r.rows.Columns()
+}
+
+// Close closes the rows
+func (r *sqliteRows) Close() error {
+	return r.rows.Close()
+}
+
+// Next reads the next row. Columns declared as times were parsed by the driver already.
+func (r *sqliteRows) Next(dest []driver.Value) error {
+	if err := r.rows.Next(dest); err != nil {
+		return err
+	}
+
+	if r.declared == nil {
+		r.declared = r.rows.DeclTypes()
+	}
+	for i, value := range dest {
+		text, ok := value.(string)
+		if !ok {
+			continue
+		}
+		if r.declared[i] == "" {
+			if t, ok := parseSQLiteTime(text); ok {
+				dest[i] = t
+				continue
+			}
+		}
+		dest[i] = []byte(text)
+	}
+	return nil
+}
+
+// sqliteArgs stores times as UTC text, or as a plain date at midnight so they match DATE columns
+func sqliteArgs(args []driver.NamedValue) []driver.NamedValue {
+	converted := make([]driver.NamedValue, len(args))
+	for i, arg := range args {
+		if t, ok := arg.Value.(time.Time); ok {
+			arg.Value = formatSQLiteTime(t)
+		}
+		converted[i] = arg
+	}
+	return converted
+}
+
+// namedValues numbers positional arguments
+func namedValues(args []driver.Value) []driver.NamedValue {
+	named := make([]driver.NamedValue, len(args))
+	for i, arg := range args {
+		named[i] = driver.NamedValue{Ordinal: i + 1, Value: arg}
+	}
+	return named
+}
+
+// formatSQLiteTime formats a time the way it's stored
+func formatSQLiteTime(t time.Time) string {
+	t = t.UTC()
+	if t.Equal(t.Truncate(24 * time.Hour)) {
+		return t.Format("2006-01-02")
+	}
+	return t.Format(sqliteTimeFormat)
+}
+
+// parseSQLiteTime parses a stored time or date
+func parseSQLiteTime(text string) (time.Time, bool) {
+	if !sqliteTimeText.MatchString(text) {
+		return time.Time{}, false
+	}
+	text = strings.Replace(strings.TrimSuffix(text, "Z"), "T", " ", 1)
+	for _, layout := range []string{sqliteTimeFormat, "2006-01-02"} {
+		if t, err := time.Parse(layout, text); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// registerSQLiteFunctions adds the Postgres functions the repositories call to a new connection
+func registerSQLiteFunctions(conn *sqlite3.SQLiteConn) error {
+	// RANDOM() returns a float in [0, 1) as in Postgres, not SQLite's random integer
+	functions := []struct {
+		name string
+		impl any
+		pure bool
+	}{
+		{"greatest", sqliteGreatest, true},
+		{"floor", sqliteFloor, true},
+		{"random", rand.Float64, false},
+		{"regexp", sqliteRegexp, true},
+		{"cardinality", sqliteCardinality, true},
+		{"pg_array_json", sqliteArrayJSON, true},
+		{"pg_epoch", sqliteEpoch, true},
+		{"to_char", sqliteToChar, true},
+		{"date_trunc", sqliteDateTrunc, true},
+		{"gen_random_uuid", sqliteUUID, false},
+	}
+	for _, fn := range functions {
+		if err := conn.RegisterFunc(fn.name, fn.impl, fn.pure); err != nil {
+			return fmt.Errorf("failed to register %s: %w", fn.name, err)
+		}
+	}
+
+	if err := conn.RegisterAggregator("array_agg", newArrayAgg, true); err != nil {
+		return fmt.Errorf("failed to register array_agg: %w", err)
+	}
+	if err := conn.RegisterAggregator("bool_or", newBoolOr, true); err != nil {
+		return fmt.Errorf("failed to register bool_or: %w", err)
+	}
+	return nil
+}
+
+// SQLite hands NULL arguments to generic parameters as a nil byte slice
+func isSQLNull(value any) bool {
+	b, ok := value.([]byte)
+	return value == nil || (ok && b == nil)
+}
+
+// sqliteText reads a text argument
+func sqliteText(value any) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case []byte:
+		return string(v), v != nil
+	}
+	return "", false
+}
+
+// sqliteNumber reads a numeric argument
+func sqliteNumber(value any) (float64, bool) {
+	switch v := value.(type) {
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+// sqliteGreatest is GREATEST: the largest argument, ignoring NULLs. Numbers compare as
+// numbers; anything else, times included, compares as text.
+func sqliteGreatest(args ...any) any {
+	var best any
+	for _, arg := range args {
+		if isSQLNull(arg) {
+			continue
+		}
+		if best == nil {
+			best = arg
+			continue
+		}
+		a, aNumeric := sqliteNumber(arg)
+		b, bNumeric := sqliteNumber(best)
+		if aNumeric && bNumeric {
+			if a > b {
+				best = arg
+			}
+			continue
+		}
+		if textOf(arg) > textOf(best) {
+			best = arg
+		}
+	}
+	return best
+}
+
+// textOf renders an argument as text for comparison
+func textOf(value any) string {
+	if text, ok := sqliteText(value); ok {
+		return text
+	}
+	return fmt.Sprint(value)
+}
+
+// sqliteFloor is FLOOR, which this SQLite build doesn't include
+func sqliteFloor(value any) any {
+	if n, ok := sqliteNumber(value); ok {
+		return math.Floor(n)
+	}
+	return nil
+}
+
+var sqlitePatterns sync.Map
+
+// sqliteRegexp backs the REGEXP operator the ~ operators translate to
+func sqliteRegexp(pattern, value any) (any, error) {
+	p, ok := sqliteText(pattern)
+	text, textOK := sqliteText(value)
+	if !ok || !textOK {
+		return nil, nil
+	}
+
+	compiled, ok := sqlitePatterns.Load(p)
+	if !ok {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, err
+		}
+		compiled, _ = sqlitePatterns.LoadOrStore(p, re)
+	}
+	return compiled.(*regexp.Regexp).MatchString(text), nil
+}
+
+// parsePgArray parses an array literal such as {a,"b c",NULL}, as written by pq.Array and
+// array_agg. NULL elements come back as nil.
+func parsePgArray(literal string) ([]*string, error) {
+	literal = strings.TrimSpace(literal)
+	if len(literal) < 2 || literal[0] != '{' || literal[len(literal)-1] != '}' {
+		return nil, fmt.Errorf("malformed array literal %q", literal)
+	}
+	body := literal[1 : len(literal)-1]
+	if body == "" {
+		return []*string{}, nil
+	}
+
+	var elements []*string
+	for i := 0; i <= len(body); {
+		var element strings.Builder
+		quoted := i < len(body) && body[i] == '"'
+		if quoted {
+			i++
+			for i < len(body) && body[i] != '"' {
+				if body[i] == '\\' && i+1 < len(body) {
+					i++
+				}
+				element.WriteByte(body[i])
+				i++
+			}
+			i++ // closing quote
+		}
+		for i < len(body) && body[i] != ',' {
+			element.WriteByte(body[i])
+			i++
+		}
+		i++ // separator
+
+		value := element.String()
+		if !quoted && strings.EqualFold(value, "NULL") {
+			elements = append(elements, nil)
+		} else {
+			elements = append(elements, &value)
+		}
+	}
+	return elements, nil
+}
+
+// sqliteCardinality is CARDINALITY for a stored array
+func sqliteCardinality(value any) (any, error) {
+	text, ok := sqliteText(value)
+	if !ok {
+		return nil, nil
+	}
+	elements, err := parsePgArray(text)
+	if err != nil {
+		return nil, err
+	}
+	return int64(len(elements)), nil
+}
+
+// sqliteArrayJSON converts an array literal to a JSON array so json_each can expand it for
+// = ANY(...). Elements that look like integers become numbers, so they compare equal to IDs.
+func sqliteArrayJSON(value any) (any, error) {
+	text, ok := sqliteText(value)
+	if !ok {
+		return nil, nil
+	}
+	elements, err := parsePgArray(text)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]any, len(elements))
+	for i, element := range elements {
+		if element == nil {
+			continue
+		}
+		if n, err := strconv.ParseInt(*element, 10, 64); err == nil {
+			values[i] = n
+		} else {
+			values[i] = *element
+		}
+	}
+	encoded, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	return string(encoded), nil
+}
+
+// sqliteTimeArg reads a time argument stored as text
+func sqliteTimeArg(value any) (time.Time, bool) {
+	text, ok := sqliteText(value)
+	if !ok {
+		return time.Time{}, false
+	}
+	return parseSQLiteTime(text)
+}
+
+// sqliteEpoch backs EXTRACT(EPOCH FROM ...): seconds since the epoch of a time, or a number as is
+func sqliteEpoch(value any) any {
+	if n, ok := sqliteNumber(value); ok {
+		return n
+	}
+	if t, ok := sqliteTimeArg(value); ok {
+		return float64(t.UnixNano()) / float64(time.Second)
+	}
+	return nil
+}
+
+// toCharLayouts maps the TO_CHAR patterns the repositories use to Go layouts, longest first
+var toCharLayouts = strings.NewReplacer("YYYY", "2006", "HH24", "15", "MM", "01", "DD", "02", "MI", "04", "SS", "05")
+
+// sqliteToChar is TO_CHAR for times. The result is a blob so the date-like text it produces
+// reaches the caller as text rather than being read back as a time.
+func sqliteToChar(value any, format string) any {
+	t, ok := sqliteTimeArg(value)
+	if !ok {
+		return nil
+	}
+	return []byte(t.Format(toCharLayouts.Replace(format)))
+}
+
+// sqliteDateTrunc is DATE_TRUNC; weeks start on Monday as in Postgres
+func sqliteDateTrunc(unit string, value any) (any, error) {
+	t, ok := sqliteTimeArg(value)
+	if !ok {
+		return nil, nil
+	}
+
+	switch strings.ToLower(unit) {
+	case "minute":
+		t = t.Truncate(time.Minute)
+	case "hour":
+		t = t.Truncate(time.Hour)
+	case "day":
+		t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	case "week":
+		t = time.Date(t.Year(), t.Month(), t.Day()-(int(t.Weekday())+6)%7, 0, 0, 0, 0, time.UTC)
+	case "month":
+		t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	case "year":
+		t = time.Date(t.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return nil, fmt.Errorf("unsupported date_trunc unit %q", unit)
+	}
+	return t.Format(sqliteTimeFormat), nil
+}
+
+// sqliteUUID is GEN_RANDOM_UUID: a random version 4 UUID
+func sqliteUUID() (string, error) {
+	var b [16]byte
+	if _, err := crand.Read(b[:]); err != nil {
+		return "", err
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
+
+// arrayAgg is ARRAY_AGG, producing an array literal that pq.Array scans
+type arrayAgg struct {
+	elements []string
+	rows     int
+}
+
+func newArrayAgg() *arrayAgg { return &arrayAgg{} }
+
+// Step adds a value
+func (a *arrayAgg) Step(value any) {
+	a.rows++
+	if isSQLNull(value) {
+		a.elements = append(a.elements, "NULL")
+		return
+	}
+	text := textOf(value)
+	a.elements = append(a.elements, `"`+strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(text)+`"`)
+}
+
+// Done returns the array, or NULL when there were no rows
+func (a *arrayAgg) Done() any {
+	if a.rows == 0 {
+		return nil
+	}
+	return "{" + strings.Join(a.elements, ",") + "}"
+}
+
+// boolOr is BOOL_OR
+type boolOr struct {
+	result *bool
+}
+
+func newBoolOr() *boolOr { return &boolOr{} }
+
+// Step folds in a value, skipping NULLs
+func (b *boolOr) Step(value any) {
+	if isSQLNull(value) {
+		return
+	}
+	n, _ := sqliteNumber(value)
+	result := n != 0 || (b.result != nil && *b.result)
+	b.result = &result
+}
+
+// Done returns the result, or NULL when every value was NULL
+func (b *boolOr) Done() any {
+	if b.result == nil {
+		return nil
+	}
+	return *b.result
+}
@@ -0,0 +1,1097 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// sqliteMigrations bring a SQLite database up to date; PRAGMA user_version counts those applied.
+// A new database starts from the current schema rather than replaying the Postgres history, so
+// later schema changes are appended here as well as to postgresMigrations.
+var sqliteMigrations = []string{
+	sqliteSchema + userProgressStatsTriggers(),
+	"ALTER TABLE user_progress ADD COLUMN hint_credit REAL;",
+}
+
+// runSQLiteMigrations applies the SQLite migrations a database hasn't had yet
+func runSQLiteMigrations(db *sql.DB) error {
+	var version int
+	if err := db.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	for i := version; i < len(sqliteMigrations); i++ {
+		if err := applySQLiteMigration(db, i+1, sqliteMigrations[i]); err != nil {
+			return fmt.Errorf("failed to execute migration %d: %w", i+1, err)
+		}
+	}
+
+	log.Println("Database migrations completed successfully")
+	return nil
+}
+
+// applySQLiteMigration runs a migration and records its version in one transaction
+func applySQLiteMigration(db *sql.DB, version int, migration string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(migration); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(fmt.Sprintf("PRAGMA user_version = %d", version)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// userProgressStatsTriggers keeps the user_stats counters in step with user_progress, as the
+// user_progress_stats trigger does in Postgres
+func userProgressStatsTriggers() string {
+	return fmt.Sprintf(`
+CREATE TRIGGER user_progress_stats_insert AFTER INSERT ON user_progress
+BEGIN
+%s
+END;
+
+CREATE TRIGGER user_progress_stats_delete AFTER DELETE ON user_progress
+BEGIN
+%s
+END;
+
+CREATE TRIGGER user_progress_stats_update AFTER UPDATE OF status, completion_quality, item_id, user_id ON user_progress
+BEGIN
+%s
+%s
+END;
+`, applyUserProgressStats("NEW", "1"), applyUserProgressStats("OLD", "-1"),
+		applyUserProgressStats("OLD", "-1"), applyUserProgressStats("NEW", "1"))
+}
+
+// applyUserProgressStats adds (sign 1) or removes (sign -1) a progress row's contribution to its
+// user's counters. Rows for deleted or miscellaneous items don't count; counters that are stale
+// anyway are left for the next refresh.
+func applyUserProgressStats(row, sign string) string {
+	r := strings.NewReplacer("{row}", row, "{sign}", sign)
+	return r.Replace(`UPDATE user_stats SET
+    completed_items = completed_items + CASE WHEN {row}.status = 'done' THEN {sign} ELSE 0 END,
+    in_progress_items = in_progress_items + CASE WHEN {row}.status = 'in-progress' THEN {sign} ELSE 0 END,
+    pending_items = pending_items - CASE WHEN COALESCE({row}.status, 'pending') = 'pending' THEN 0 ELSE {sign} END,
+    dsa_completed = dsa_completed + CASE WHEN {row}.status = 'done' AND (SELECT category FROM items WHERE id = {row}.item_id) = 'dsa' THEN {sign} ELSE 0 END,
+    lld_completed = lld_completed + CASE WHEN {row}.status = 'done' AND (SELECT category FROM items WHERE id = {row}.item_id) = 'lld' THEN {sign} ELSE 0 END,
+    hld_completed = hld_completed + CASE WHEN {row}.status = 'done' AND (SELECT category FROM items WHERE id = {row}.item_id) = 'hld' THEN {sign} ELSE 0 END,
+    solved_items = solved_items + CASE WHEN {row}.status = 'done' AND COALESCE({row}.completion_quality, 'solved') = 'solved' THEN {sign} ELSE 0 END,
+    reviewed_solution_items = reviewed_solution_items + CASE WHEN {row}.status = 'done' AND {row}.completion_quality = 'reviewed_solution' THEN {sign} ELSE 0 END,
+    updated_at = CURRENT_TIMESTAMP
+WHERE user_id = {row}.user_id AND stats_refreshed_at IS NOT NULL
+    AND COALESCE((SELECT category FROM items WHERE id = {row}.item_id), 'miscellaneous') <> 'miscellaneous';`)
+}
+
+// sqliteSchema is the full current schema for SQLite databases. It mirrors the end state of the
+// Postgres migrations with SQLite types: serial keys become AUTOINCREMENT integers, and JSONB,
+// array and UUID columns are stored as text. Full-text GIN indexes and the pgvector embeddings
+// table have no SQLite counterpart and are left out.
+const sqliteSchema = `
+CREATE TABLE items (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    title VARCHAR(255) NOT NULL,
+    link TEXT NOT NULL,
+    category VARCHAR(50) NOT NULL REFERENCES categories(slug),
+    subcategory VARCHAR(100) NOT NULL DEFAULT 'other',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    attachments TEXT DEFAULT '{}',
+    source_article_id INTEGER REFERENCES eng_blog_articles(id) ON DELETE SET NULL,
+    version INTEGER NOT NULL DEFAULT 1,
+    published BOOLEAN NOT NULL DEFAULT TRUE,
+    publish_at TIMESTAMP
+);
+
+CREATE TABLE app_stats (
+    id INTEGER PRIMARY KEY DEFAULT 1,
+    completed_all_count INTEGER DEFAULT 0,
+    CONSTRAINT single_row CHECK (id = 1)
+);
+
+CREATE TABLE users (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    email VARCHAR(255) NOT NULL UNIQUE,
+    name VARCHAR(255) NOT NULL,
+    password_hash VARCHAR(255),
+    auth_provider VARCHAR(50) NOT NULL CHECK (auth_provider IN ('email', 'google', 'facebook', 'apple')),
+    provider_id VARCHAR(255),
+    avatar TEXT,
+    email_verified BOOLEAN DEFAULT false,
+    is_active BOOLEAN DEFAULT true,
+    last_login_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    role VARCHAR(20) NOT NULL DEFAULT 'user' REFERENCES roles(name),
+    archived_at TIMESTAMP,
+    sessions_revoked_at TIMESTAMP,
+    password_reset_required BOOLEAN NOT NULL DEFAULT false,
+    password_reset_token VARCHAR(64),
+    password_reset_expires_at TIMESTAMP,
+    first_completed_at TIMESTAMP,
+    purge_at TIMESTAMP
+);
+
+CREATE TABLE user_progress (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    status VARCHAR(20) DEFAULT 'pending' CHECK (status IN ('done', 'pending', 'in-progress')),
+    notes TEXT,
+    started_at TIMESTAMP,
+    completed_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    starred BOOLEAN NOT NULL DEFAULT false,
+    completion_quality VARCHAR(20) CHECK (completion_quality IN ('solved', 'reviewed_solution')),
+    next_review_at TIMESTAMP,
+    review_count INTEGER NOT NULL DEFAULT 0,
+    skip_count INTEGER NOT NULL DEFAULT 0,
+    UNIQUE(user_id, item_id)
+);
+
+CREATE TABLE user_stats (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    total_items INTEGER DEFAULT 0,
+    completed_items INTEGER DEFAULT 0,
+    in_progress_items INTEGER DEFAULT 0,
+    pending_items INTEGER DEFAULT 0,
+    dsa_completed INTEGER DEFAULT 0,
+    lld_completed INTEGER DEFAULT 0,
+    hld_completed INTEGER DEFAULT 0,
+    current_streak INTEGER DEFAULT 0,
+    longest_streak INTEGER DEFAULT 0,
+    last_activity_date DATE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    completed_all_count INTEGER NOT NULL DEFAULT 0,
+    daily_goal INTEGER NOT NULL DEFAULT 0,
+    goal_streak INTEGER NOT NULL DEFAULT 0,
+    longest_goal_streak INTEGER NOT NULL DEFAULT 0,
+    goals_met_count INTEGER NOT NULL DEFAULT 0,
+    last_goal_met_date DATE,
+    solved_items INTEGER NOT NULL DEFAULT 0,
+    reviewed_solution_items INTEGER NOT NULL DEFAULT 0,
+    stats_refreshed_at TIMESTAMP
+);
+
+CREATE TABLE refresh_tokens (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token VARCHAR(255) NOT NULL UNIQUE,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    is_revoked BOOLEAN DEFAULT false,
+    ip_address VARCHAR(64) NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    alert_token VARCHAR(64)
+);
+
+CREATE TABLE eng_blogs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(255) NOT NULL,
+    link TEXT NOT NULL,
+    order_idx INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    archived_at TIMESTAMP
+);
+
+CREATE TABLE eng_blog_articles (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    blog_id INTEGER NOT NULL REFERENCES eng_blogs(id) ON DELETE CASCADE,
+    title TEXT NOT NULL,
+    order_idx INTEGER NOT NULL DEFAULT 0,
+    external_link TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    archived_at TIMESTAMP
+);
+
+CREATE TABLE tests (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    session_id TEXT NOT NULL,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    status VARCHAR(20) DEFAULT 'active' CHECK (status IN ('pending', 'completed', 'abandoned')),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    outcome VARCHAR(10) CHECK (outcome IN ('solved', 'partial', 'failed')),
+    time_taken_seconds INTEGER CHECK (time_taken_seconds >= 0),
+    self_rating INTEGER CHECK (self_rating BETWEEN 1 AND 5),
+    result_notes TEXT NOT NULL DEFAULT '',
+    result_recorded_at TIMESTAMP,
+    parent_session_id TEXT
+);
+
+CREATE TABLE item_attachments (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    storage_key TEXT UNIQUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    kind VARCHAR(20) NOT NULL DEFAULT 'file' CHECK (kind IN ('file', 'secret')),
+    ciphertext BLOB
+);
+
+CREATE TABLE item_hints (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL DEFAULT 0,
+    content TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE user_hint_reveals (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    hint_id INTEGER NOT NULL REFERENCES item_hints(id) ON DELETE CASCADE,
+    revealed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(user_id, hint_id)
+);
+
+CREATE TABLE item_shares (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    sender_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    recipient_user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    recipient_email VARCHAR(255) NOT NULL,
+    note TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'dismissed')),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    responded_at TIMESTAMP
+);
+
+CREATE TABLE organizations (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(255) NOT NULL,
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE organization_members (
+    org_id INTEGER NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL DEFAULT 'member' CHECK (role IN ('owner', 'admin', 'member')),
+    joined_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (org_id, user_id)
+);
+
+CREATE TABLE organization_invitations (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    org_id INTEGER NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    role VARCHAR(20) NOT NULL DEFAULT 'member' CHECK (role IN ('owner', 'admin', 'member')),
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'revoked')),
+    invited_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    accepted_user_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    expires_at TIMESTAMP NOT NULL,
+    accepted_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE study_groups (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    owner_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    invite_code VARCHAR(16) NOT NULL UNIQUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE study_group_members (
+    group_id INTEGER NOT NULL REFERENCES study_groups(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL DEFAULT 'member' CHECK (role IN ('owner', 'member')),
+    joined_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (group_id, user_id)
+);
+
+CREATE TABLE study_group_item_lists (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    group_id INTEGER NOT NULL REFERENCES study_groups(id) ON DELETE CASCADE,
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE study_group_list_items (
+    list_id INTEGER NOT NULL REFERENCES study_group_item_lists(id) ON DELETE CASCADE,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (list_id, item_id)
+);
+
+CREATE TABLE org_service_accounts (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    org_id INTEGER NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    key_prefix VARCHAR(16) NOT NULL,
+    key_hash VARCHAR(64) NOT NULL UNIQUE,
+    scopes TEXT NOT NULL DEFAULT '{}',
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    last_used_at TIMESTAMP,
+    revoked_at TIMESTAMP
+);
+
+CREATE TABLE user_daily_goals (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    activity_date DATE NOT NULL,
+    completed_count INTEGER NOT NULL DEFAULT 0,
+    goal INTEGER NOT NULL DEFAULT 0,
+    goal_met BOOLEAN NOT NULL DEFAULT false,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, activity_date)
+);
+
+CREATE TABLE user_notification_preferences (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    email_enabled BOOLEAN NOT NULL DEFAULT true,
+    daily_reminder BOOLEAN NOT NULL DEFAULT true,
+    reminder_time VARCHAR(5) NOT NULL DEFAULT '19:00',
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    stuck_items BOOLEAN NOT NULL DEFAULT true,
+    reviews_due BOOLEAN NOT NULL DEFAULT true,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    push_enabled BOOLEAN NOT NULL DEFAULT true,
+    streak_alerts BOOLEAN NOT NULL DEFAULT true,
+    locale VARCHAR(16) NOT NULL DEFAULT ''
+);
+
+CREATE TABLE sent_reminders (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind VARCHAR(50) NOT NULL,
+    sent_on DATE NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, kind, sent_on)
+);
+
+CREATE TABLE user_devices (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    platform VARCHAR(20) NOT NULL CHECK (platform IN ('web', 'android', 'ios')),
+    token TEXT NOT NULL,
+    p256dh TEXT NOT NULL DEFAULT '',
+    auth TEXT NOT NULL DEFAULT '',
+    label VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    last_seen_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (platform, token)
+);
+
+CREATE TABLE org_cohort_snapshots (
+    org_id INTEGER NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    snapshot_date DATE NOT NULL,
+    data TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (org_id, snapshot_date)
+);
+
+CREATE TABLE user_webhooks (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    secret VARCHAR(100) NOT NULL,
+    events TEXT NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    format VARCHAR(20) NOT NULL DEFAULT 'json',
+    last_digest_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE webhook_deliveries (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    webhook_id INTEGER NOT NULL REFERENCES user_webhooks(id) ON DELETE CASCADE,
+    event VARCHAR(50) NOT NULL,
+    payload TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'succeeded', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP,
+    last_status_code INTEGER,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    delivered_at TIMESTAMP
+);
+
+CREATE TABLE calendar_feeds (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    last_accessed_at TIMESTAMP
+);
+
+CREATE TABLE lifecycle_runs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    inactive_before TIMESTAMP NOT NULL,
+    users_archived INTEGER NOT NULL DEFAULT 0,
+    rows_deleted TEXT NOT NULL DEFAULT '{}',
+    bytes_reclaimed BIGINT NOT NULL DEFAULT 0,
+    started_at TIMESTAMP NOT NULL,
+    finished_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE user_encryption_keys (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    wrapped_key BLOB NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE flashcards (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    question TEXT NOT NULL,
+    answer TEXT NOT NULL,
+    review_count INTEGER NOT NULL DEFAULT 0,
+    last_grade VARCHAR(10) CHECK (last_grade IN ('again', 'hard', 'good')),
+    last_reviewed_at TIMESTAMP,
+    next_review_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE companies (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    slug VARCHAR(100) NOT NULL UNIQUE,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE item_companies (
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    company_id INTEGER NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (item_id, company_id)
+);
+
+CREATE TABLE interviews (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    company_id INTEGER REFERENCES companies(id) ON DELETE SET NULL,
+    company_name VARCHAR(255) NOT NULL,
+    role VARCHAR(255) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'applied' CHECK (status IN ('applied', 'interviewing', 'offer', 'accepted', 'rejected', 'withdrawn')),
+    applied_at TIMESTAMP,
+    notes TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE interview_stages (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    interview_id INTEGER NOT NULL REFERENCES interviews(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('phone_screen', 'technical', 'system_design', 'behavioral', 'onsite', 'other')),
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    position INTEGER NOT NULL DEFAULT 0,
+    scheduled_at TIMESTAMP,
+    outcome VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (outcome IN ('pending', 'passed', 'failed', 'cancelled')),
+    notes TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE interview_stage_items (
+    stage_id INTEGER NOT NULL REFERENCES interview_stages(id) ON DELETE CASCADE,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (stage_id, item_id)
+);
+
+CREATE TABLE focus_sessions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    item_id INTEGER REFERENCES items(id) ON DELETE SET NULL,
+    test_session_id VARCHAR(255),
+    status VARCHAR(20) NOT NULL DEFAULT 'running' CHECK (status IN ('running', 'paused', 'stopped')),
+    started_at TIMESTAMP NOT NULL,
+    running_since TIMESTAMP,
+    focused_seconds INTEGER NOT NULL DEFAULT 0,
+    ended_at TIMESTAMP
+);
+
+CREATE TABLE focus_session_events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    session_id INTEGER NOT NULL REFERENCES focus_sessions(id) ON DELETE CASCADE,
+    kind VARCHAR(10) NOT NULL CHECK (kind IN ('start', 'pause', 'resume', 'stop')),
+    occurred_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE ai_usage (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    period_start DATE NOT NULL,
+    calls INTEGER NOT NULL DEFAULT 0,
+    tokens BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, period_start)
+);
+
+CREATE TABLE ai_usage_features (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    period_start DATE NOT NULL,
+    feature VARCHAR(50) NOT NULL,
+    calls INTEGER NOT NULL DEFAULT 0,
+    tokens BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id, period_start, feature)
+);
+
+CREATE TABLE subscriptions (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    plan VARCHAR(20) NOT NULL DEFAULT 'free' CHECK (plan IN ('free', 'pro')),
+    status VARCHAR(30) NOT NULL DEFAULT 'none',
+    stripe_customer_id VARCHAR(255) UNIQUE,
+    stripe_subscription_id VARCHAR(255),
+    current_period_end TIMESTAMP,
+    cancel_at_period_end BOOLEAN NOT NULL DEFAULT false,
+    comped BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    trial_started_at TIMESTAMP,
+    trial_ends_at TIMESTAMP,
+    downgraded_at TIMESTAMP,
+    past_due_since TIMESTAMP
+);
+
+CREATE TABLE stripe_events (
+    id VARCHAR(255) PRIMARY KEY,
+    type VARCHAR(100) NOT NULL,
+    processed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE billing_notices (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind VARCHAR(50) NOT NULL,
+    reference VARCHAR(255) NOT NULL,
+    sent_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, kind, reference)
+);
+
+CREATE TABLE item_feedback (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    user_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    category VARCHAR(20) NOT NULL CHECK (category IN ('dead_link', 'wrong_category', 'duplicate', 'other')),
+    comment TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'resolved', 'dismissed')),
+    reviewed_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    review_note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    reviewed_at TIMESTAMP
+);
+
+CREATE TABLE link_checks (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    item_id INTEGER UNIQUE REFERENCES items(id) ON DELETE CASCADE,
+    article_id INTEGER UNIQUE REFERENCES eng_blog_articles(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    status_code INTEGER,
+    error TEXT NOT NULL DEFAULT '',
+    failures INTEGER NOT NULL DEFAULT 0,
+    checked_at TIMESTAMP NOT NULL,
+    dead_since TIMESTAMP,
+    CHECK ((item_id IS NULL) <> (article_id IS NULL))
+);
+
+CREATE TABLE categories (
+    slug VARCHAR(50) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    order_idx INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE category_subcategories (
+    category VARCHAR(50) NOT NULL REFERENCES categories(slug) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    order_idx INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (category, name)
+);
+
+CREATE TABLE behavioral_questions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    question TEXT NOT NULL,
+    competencies TEXT NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE behavioral_answers (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    question_id INTEGER NOT NULL REFERENCES behavioral_questions(id) ON DELETE CASCADE,
+    situation TEXT NOT NULL DEFAULT '',
+    task TEXT NOT NULL DEFAULT '',
+    action TEXT NOT NULL DEFAULT '',
+    result TEXT NOT NULL DEFAULT '',
+    practice_count INTEGER NOT NULL DEFAULT 0,
+    last_practiced_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, question_id)
+);
+
+CREATE TABLE item_design_notes (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    notes TEXT NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    version INTEGER NOT NULL DEFAULT 1,
+    PRIMARY KEY (user_id, item_id)
+);
+
+CREATE TABLE item_test_cases (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    input TEXT NOT NULL DEFAULT '',
+    expected_output TEXT NOT NULL,
+    is_sample BOOLEAN NOT NULL DEFAULT false,
+    order_idx INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE submission_attempts (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    language VARCHAR(20) NOT NULL,
+    code TEXT NOT NULL,
+    passed BOOLEAN NOT NULL,
+    passed_cases INTEGER NOT NULL,
+    total_cases INTEGER NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    commit_sha VARCHAR(40)
+);
+
+CREATE TABLE public_profiles (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    handle VARCHAR(32) NOT NULL UNIQUE,
+    enabled BOOLEAN NOT NULL DEFAULT false,
+    show_name BOOLEAN NOT NULL DEFAULT false,
+    show_streak BOOLEAN NOT NULL DEFAULT true,
+    show_totals BOOLEAN NOT NULL DEFAULT true,
+    show_badges BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE progress_reports (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    snapshot TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE github_connections (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    github_login VARCHAR(100) NOT NULL,
+    access_token BLOB NOT NULL,
+    repository VARCHAR(200) NOT NULL DEFAULT '',
+    branch VARCHAR(100) NOT NULL DEFAULT '',
+    path_template VARCHAR(200) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE job_locks (
+    name VARCHAR(100) PRIMARY KEY,
+    locked_by VARCHAR(200) NOT NULL,
+    locked_until TIMESTAMP NOT NULL,
+    last_started_at TIMESTAMP
+);
+
+CREATE TABLE job_runs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(100) NOT NULL,
+    instance VARCHAR(200) NOT NULL,
+    triggered_by VARCHAR(20) NOT NULL CHECK (triggered_by IN ('schedule', 'manual')),
+    status VARCHAR(20) NOT NULL DEFAULT 'running' CHECK (status IN ('running', 'succeeded', 'failed')),
+    error TEXT NOT NULL DEFAULT '',
+    started_at TIMESTAMP NOT NULL,
+    finished_at TIMESTAMP,
+    duration_ms BIGINT
+);
+
+CREATE TABLE feature_flags (
+    key VARCHAR(64) PRIMARY KEY,
+    description TEXT NOT NULL DEFAULT '',
+    enabled BOOLEAN NOT NULL DEFAULT false,
+    rollout_percent INTEGER NOT NULL DEFAULT 0 CHECK (rollout_percent BETWEEN 0 AND 100),
+    user_ids TEXT NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE org_catalog_items (
+    org_id INTEGER NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL DEFAULT 0,
+    note TEXT NOT NULL DEFAULT '',
+    added_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (org_id, item_id)
+);
+
+CREATE TABLE roles (
+    name VARCHAR(20) PRIMARY KEY,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE role_permissions (
+    role VARCHAR(20) NOT NULL REFERENCES roles(name) ON DELETE CASCADE,
+    permission VARCHAR(64) NOT NULL,
+    PRIMARY KEY (role, permission)
+);
+
+CREATE TABLE user_api_keys (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    key_prefix VARCHAR(16) NOT NULL,
+    key_hash VARCHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    last_used_at TIMESTAMP,
+    revoked_at TIMESTAMP
+);
+
+CREATE TABLE test_eligibility_policy (
+    id BOOLEAN PRIMARY KEY DEFAULT true CHECK (id),
+    rules TEXT NOT NULL DEFAULT '[]',
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE quizzes (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    title VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    category VARCHAR(50) NOT NULL,
+    subcategory VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE quiz_questions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    quiz_id INTEGER NOT NULL REFERENCES quizzes(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    prompt TEXT NOT NULL,
+    choices TEXT NOT NULL,
+    correct_choice INTEGER NOT NULL,
+    explanation TEXT NOT NULL DEFAULT '',
+    UNIQUE (quiz_id, position)
+);
+
+CREATE TABLE quiz_attempts (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    quiz_id INTEGER NOT NULL REFERENCES quizzes(id) ON DELETE CASCADE,
+    correct INTEGER NOT NULL,
+    total INTEGER NOT NULL,
+    results TEXT NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE ai_generated_questions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    user_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('follow_up', 'variation')),
+    questions TEXT NOT NULL,
+    provider VARCHAR(50) NOT NULL,
+    model VARCHAR(100) NOT NULL,
+    tokens BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE documents (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind VARCHAR(30) NOT NULL CHECK (kind IN ('revision_sheet')),
+    title VARCHAR(255) NOT NULL,
+    category VARCHAR(50) NOT NULL,
+    subcategory VARCHAR(100) NOT NULL DEFAULT '',
+    content TEXT NOT NULL,
+    source_items INTEGER NOT NULL DEFAULT 0,
+    truncated BOOLEAN NOT NULL DEFAULT false,
+    provider VARCHAR(50) NOT NULL DEFAULT '',
+    model VARCHAR(100) NOT NULL DEFAULT '',
+    tokens BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE user_ai_settings (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    notes_opt_in BOOLEAN NOT NULL DEFAULT false,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE mock_interviews (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    test_session_id VARCHAR(255),
+    status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'ended')),
+    feedback TEXT NOT NULL DEFAULT '',
+    provider VARCHAR(50) NOT NULL DEFAULT '',
+    model VARCHAR(100) NOT NULL DEFAULT '',
+    tokens BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    ended_at TIMESTAMP
+);
+
+CREATE TABLE mock_interview_messages (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    interview_id INTEGER NOT NULL REFERENCES mock_interviews(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('interviewer', 'candidate')),
+    content TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE item_revisions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    item_id INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    editor_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    changes TEXT NOT NULL DEFAULT '{}',
+    snapshot TEXT NOT NULL,
+    reverted_from INTEGER REFERENCES item_revisions(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_items_category ON items(category);
+CREATE INDEX idx_items_subcategory ON items(subcategory);
+CREATE INDEX idx_items_category_subcategory ON items(category, subcategory);
+CREATE INDEX idx_users_email ON users(email);
+CREATE INDEX idx_users_provider ON users(auth_provider, provider_id);
+CREATE INDEX idx_users_active ON users(is_active);
+CREATE UNIQUE INDEX idx_users_oauth_unique ON users(auth_provider, provider_id) WHERE provider_id IS NOT NULL;
+CREATE INDEX idx_user_progress_user_id ON user_progress(user_id);
+CREATE INDEX idx_user_progress_item_id ON user_progress(item_id);
+CREATE INDEX idx_user_progress_status ON user_progress(status);
+CREATE INDEX idx_user_progress_user_status ON user_progress(user_id, status);
+CREATE INDEX idx_refresh_tokens_user_id ON refresh_tokens(user_id);
+CREATE INDEX idx_refresh_tokens_token ON refresh_tokens(token);
+CREATE INDEX idx_refresh_tokens_expires_at ON refresh_tokens(expires_at);
+CREATE INDEX idx_users_role ON users(role);
+CREATE INDEX idx_user_progress_starred ON user_progress(starred);
+CREATE INDEX idx_user_progress_user_starred ON user_progress(user_id, starred);
+CREATE INDEX idx_eng_blogs_order ON eng_blogs(order_idx);
+CREATE INDEX idx_eng_blog_articles_blog_id ON eng_blog_articles(blog_id);
+CREATE INDEX idx_eng_blog_articles_order ON eng_blog_articles(order_idx);
+CREATE INDEX idx_eng_blog_articles_blog_order ON eng_blog_articles(blog_id, order_idx);
+CREATE INDEX idx_tests_session_id ON tests(session_id);
+CREATE INDEX idx_tests_user_id ON tests(user_id);
+CREATE INDEX idx_tests_item_id ON tests(item_id);
+CREATE INDEX idx_tests_status ON tests(status);
+CREATE INDEX idx_tests_user_session ON tests(user_id, session_id);
+CREATE INDEX idx_tests_user_status ON tests(user_id, status);
+CREATE INDEX idx_item_attachments_item_user ON item_attachments(item_id, user_id);
+CREATE INDEX idx_item_attachments_user_id ON item_attachments(user_id);
+CREATE INDEX idx_item_hints_item_position ON item_hints(item_id, position);
+CREATE INDEX idx_user_hint_reveals_user_item ON user_hint_reveals(user_id, item_id);
+CREATE INDEX idx_user_progress_user_next_review ON user_progress(user_id, next_review_at);
+CREATE INDEX idx_item_shares_recipient_user ON item_shares(recipient_user_id, status);
+CREATE INDEX idx_item_shares_recipient_email ON item_shares(LOWER(recipient_email), status);
+CREATE INDEX idx_item_shares_sender ON item_shares(sender_id);
+CREATE INDEX idx_organization_members_user_id ON organization_members(user_id);
+CREATE INDEX idx_organization_invitations_org_id ON organization_invitations(org_id);
+CREATE UNIQUE INDEX idx_organization_invitations_pending_email
+    ON organization_invitations(org_id, LOWER(email)) WHERE status = 'pending';
+CREATE INDEX idx_study_group_members_user_id ON study_group_members(user_id);
+CREATE INDEX idx_study_group_item_lists_group_id ON study_group_item_lists(group_id);
+CREATE INDEX idx_org_service_accounts_org_id ON org_service_accounts(org_id);
+CREATE INDEX idx_user_devices_user_id ON user_devices(user_id);
+CREATE INDEX idx_user_webhooks_user_id ON user_webhooks(user_id);
+CREATE INDEX idx_webhook_deliveries_webhook_id ON webhook_deliveries(webhook_id, created_at DESC);
+CREATE INDEX idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX idx_flashcards_user_item ON flashcards(user_id, item_id);
+CREATE INDEX idx_flashcards_user_due ON flashcards(user_id, next_review_at);
+CREATE INDEX idx_item_companies_company ON item_companies(company_id);
+CREATE INDEX idx_user_stats_stale ON user_stats(user_id) WHERE stats_refreshed_at IS NULL;
+CREATE INDEX idx_interviews_user ON interviews(user_id);
+CREATE INDEX idx_interview_stages_interview ON interview_stages(interview_id, position);
+CREATE UNIQUE INDEX idx_focus_sessions_one_active ON focus_sessions(user_id) WHERE status <> 'stopped';
+CREATE INDEX idx_focus_sessions_user_started ON focus_sessions(user_id, started_at);
+CREATE INDEX idx_focus_session_events_session ON focus_session_events(session_id, occurred_at);
+CREATE INDEX idx_subscriptions_status ON subscriptions(status);
+CREATE UNIQUE INDEX idx_refresh_tokens_alert_token ON refresh_tokens(alert_token) WHERE alert_token IS NOT NULL;
+CREATE UNIQUE INDEX idx_users_password_reset_token ON users(password_reset_token) WHERE password_reset_token IS NOT NULL;
+CREATE INDEX idx_item_feedback_status ON item_feedback(status, created_at);
+CREATE INDEX idx_item_feedback_item ON item_feedback(item_id);
+CREATE INDEX idx_item_feedback_user ON item_feedback(user_id, created_at);
+CREATE INDEX idx_eng_blogs_active ON eng_blogs(order_idx) WHERE archived_at IS NULL;
+CREATE INDEX idx_link_checks_dead ON link_checks(dead_since) WHERE dead_since IS NOT NULL;
+CREATE UNIQUE INDEX idx_eng_blog_articles_blog_link
+    ON eng_blog_articles (blog_id, (RTRIM(LOWER(TRIM(external_link)), '/')))
+    WHERE archived_at IS NULL;
+CREATE UNIQUE INDEX idx_items_source_article ON items(source_article_id) WHERE source_article_id IS NOT NULL;
+CREATE INDEX idx_item_test_cases_item ON item_test_cases(item_id, order_idx);
+CREATE INDEX idx_submission_attempts_user_item ON submission_attempts(user_id, item_id, created_at DESC);
+CREATE INDEX idx_users_purge_at ON users(purge_at) WHERE purge_at IS NOT NULL;
+CREATE INDEX idx_progress_reports_expires_at ON progress_reports(expires_at);
+CREATE UNIQUE INDEX idx_user_webhooks_chat_format ON user_webhooks(user_id, format) WHERE format != 'json';
+CREATE INDEX idx_job_runs_name ON job_runs(name, started_at DESC);
+CREATE INDEX idx_job_runs_started_at ON job_runs(started_at);
+CREATE INDEX idx_user_api_keys_user_id ON user_api_keys(user_id);
+CREATE INDEX idx_tests_parent_session ON tests(parent_session_id);
+CREATE INDEX idx_quizzes_category ON quizzes(category, subcategory);
+CREATE INDEX idx_quiz_attempts_user ON quiz_attempts(user_id, quiz_id, created_at DESC);
+CREATE INDEX idx_ai_generated_questions_item ON ai_generated_questions(item_id, kind, created_at DESC);
+CREATE INDEX idx_ai_generated_questions_user ON ai_generated_questions(user_id, created_at);
+CREATE INDEX idx_documents_user ON documents(user_id, created_at DESC);
+CREATE INDEX idx_mock_interviews_user ON mock_interviews(user_id, created_at DESC);
+CREATE INDEX idx_mock_interviews_test ON mock_interviews(user_id, test_session_id) WHERE test_session_id IS NOT NULL;
+CREATE INDEX idx_mock_interview_messages_interview ON mock_interview_messages(interview_id, id);
+CREATE INDEX idx_item_revisions_item ON item_revisions(item_id, id DESC);
+CREATE INDEX idx_items_drafts ON items(created_at DESC) WHERE NOT published;
+CREATE INDEX idx_items_scheduled ON items(publish_at) WHERE NOT published AND publish_at IS NOT NULL;
+
+INSERT INTO app_stats (id, completed_all_count) VALUES (1, 0);
+
+INSERT INTO categories (slug, name, order_idx) VALUES
+    ('dsa', 'Data Structures & Algorithms', 1),
+    ('lld', 'Low Level Design', 2),
+    ('hld', 'High Level Design', 3),
+    ('miscellaneous', 'Miscellaneous', 4);
+
+INSERT INTO category_subcategories (category, name, order_idx) VALUES
+    ('dsa', 'arrays', 1),
+    ('dsa', 'strings', 2),
+    ('dsa', 'two-pointers', 3),
+    ('dsa', 'sliding window - fixed size', 4),
+    ('dsa', 'sliding window - dynamic size', 5),
+    ('dsa', 'prefix-sum', 6),
+    ('dsa', 'kadane''s algorithm', 7),
+    ('dsa', 'matrix (2d array)', 8),
+    ('dsa', 'linked-lists', 9),
+    ('dsa', 'linkedList in-place reversal', 10),
+    ('dsa', 'fast and slow pointers', 11),
+    ('dsa', 'stacks', 12),
+    ('dsa', 'monotonic stack', 13),
+    ('dsa', 'queues', 14),
+    ('dsa', 'monotonic queue', 15),
+    ('dsa', 'hashing', 16),
+    ('dsa', 'bit-manipulation', 17),
+    ('dsa', 'bucket sort', 18),
+    ('dsa', 'recursion', 19),
+    ('dsa', 'divide-conquer', 20),
+    ('dsa', 'merge sort', 21),
+    ('dsa', 'quickSort / quickSelect', 22),
+    ('dsa', 'binary search', 23),
+    ('dsa', 'backtracking', 24),
+    ('dsa', 'tree traversal - level order', 25),
+    ('dsa', 'tree traversal - pre order', 26),
+    ('dsa', 'tree traversal - in order', 27),
+    ('dsa', 'tree traversal - post-order', 28),
+    ('dsa', 'bst / ordered set', 29),
+    ('dsa', 'tries', 30),
+    ('dsa', 'heaps', 31),
+    ('dsa', 'two heaps', 32),
+    ('dsa', 'top k elements', 33),
+    ('dsa', 'intervals', 34),
+    ('dsa', 'k-way merge', 35),
+    ('dsa', 'data structure design', 36),
+    ('dsa', 'graphs', 37),
+    ('dsa', 'depth first search (dfs)', 38),
+    ('dsa', 'breadth first search (bfs)', 39),
+    ('dsa', 'topological sort', 40),
+    ('dsa', 'union find', 41),
+    ('dsa', 'minimum spanning tree', 42),
+    ('dsa', 'shortest path', 43),
+    ('dsa', 'eulerian circuit', 44),
+    ('dsa', 'greedy', 45),
+    ('dsa', '1-d dp', 46),
+    ('dsa', 'knapsack dp', 47),
+    ('dsa', 'unbounded knapsack dp', 48),
+    ('dsa', 'longest increasing subsequence dp', 49),
+    ('dsa', '2d (grid) dp', 50),
+    ('dsa', 'string dp', 51),
+    ('dsa', 'tree / graph dp', 52),
+    ('dsa', 'bitmask dp', 53),
+    ('dsa', 'digit dp', 54),
+    ('dsa', 'probability dp', 55),
+    ('dsa', 'state machine dp', 56),
+    ('dsa', 'string matching', 57),
+    ('dsa', 'binary indexed tree / segment tree', 58),
+    ('dsa', 'maths / geometry', 59),
+    ('dsa', 'line sweep', 60),
+    ('dsa', 'suffix array', 61),
+    ('dsa', 'other', 62);
+
+INSERT INTO category_subcategories (category, name, order_idx) VALUES
+    ('lld', 'object-oriented-programming', 1),
+    ('lld', 'design-principles', 2),
+    ('lld', 'uml', 3),
+    ('lld', 'design-patterns-creational', 4),
+    ('lld', 'design-patterns-structural', 5),
+    ('lld', 'design-patterns-behavioral', 6),
+    ('lld', 'lld-interview-tips', 7),
+    ('lld', 'lld-interview-questions', 8);
+
+INSERT INTO category_subcategories (category, name, order_idx) VALUES
+    ('hld', 'introduction', 1),
+    ('hld', 'core concepts', 2),
+    ('hld', 'databases and storage', 3),
+    ('hld', 'database scaling techniques', 4),
+    ('hld', 'caching', 5),
+    ('hld', 'networking', 6),
+    ('hld', 'api', 7),
+    ('hld', 'asynchronous communications', 8),
+    ('hld', 'tradeoffs', 9),
+    ('hld', 'distributed system concepts', 10),
+    ('hld', 'microservices', 11),
+    ('hld', 'big data processing', 12),
+    ('hld', 'architectural patterns', 13),
+    ('hld', 'observability', 14),
+    ('hld', 'security', 15),
+    ('hld', 'interview tips', 16),
+    ('hld', 'interview questions', 17);
+
+INSERT INTO category_subcategories (category, name, order_idx) VALUES
+    ('miscellaneous', 'gre', 1),
+    ('miscellaneous', 'finance', 2),
+    ('miscellaneous', 'development', 3),
+    ('miscellaneous', 'sql', 4),
+    ('miscellaneous', 'books', 5),
+    ('miscellaneous', 'test_n_revise', 6),
+    ('miscellaneous', 'other', 7);
+
+INSERT INTO behavioral_questions (question, competencies) VALUES
+    ('Tell me about a time you led a project without formal authority.', '{leadership,influence}'),
+    ('Describe a disagreement with a teammate and how you resolved it.', '{conflict,communication}'),
+    ('Tell me about a time you failed. What did you learn?', '{failure,ownership}'),
+    ('Describe a time you took ownership of a problem outside your responsibilities.', '{ownership}'),
+    ('Tell me about a decision you made with incomplete information.', '{ambiguity}'),
+    ('Describe a time you had to push back on a stakeholder.', '{conflict,influence,customer_focus}'),
+    ('Tell me about a time you had too many priorities. How did you decide what to drop?', '{prioritization}'),
+    ('Describe a time you helped a struggling teammate.', '{teamwork,leadership}'),
+    ('Tell me about a time you explained a complex technical topic to a non-technical audience.', '{communication}'),
+    ('Describe a time you went beyond what a customer asked for.', '{customer_focus}');
+
+INSERT INTO roles (name, description) VALUES
+    ('user', 'Prepares for interviews'),
+    ('admin', 'Has every permission'),
+    ('content_editor', 'Edits the catalog'),
+    ('moderator', 'Reviews feedback reported on items');
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('content_editor', 'content:write'),
+    ('moderator', 'feedback:moderate');
+`
@@ -0,0 +1,128 @@
+package database
+
+import (
+	"database/sql"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var (
+	postgresCreateTable = regexp.MustCompile(`(?i)CREATE TABLE (?:IF NOT EXISTS )?(\w+)`)
+	postgresAlterTable  = regexp.MustCompile(`(?is)ALTER TABLE (?:IF EXISTS )?(\w+)([^;]*)`)
+	postgresAddColumn   = regexp.MustCompile(`(?i)ADD COLUMN (?:IF NOT EXISTS )?(\w+)`)
+)
+
+func openSQLite(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := NewConnection("sqlite://" + filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to open SQLite database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestSQLiteMigrations(t *testing.T) {
+	db := openSQLite(t)
+	if DialectFor(db) != DialectSQLite {
+		t.Fatal("Expected a SQLite dialect")
+	}
+
+	// Running them again on start must be a no-op
+	for i := 0; i < 2; i++ {
+		if err := RunMigrations(db); err != nil {
+			t.Fatalf("RunMigrations (run %d) returned error: %v", i+1, err)
+		}
+	}
+
+	var version int
+	if err := db.QueryRow("PRAGMA user_version").Scan(&version); err != nil || version != len(sqliteMigrations) {
+		t.Fatalf("Expected schema version %d, got %d (%v)", len(sqliteMigrations), version, err)
+	}
+
+	// Every table and added column the Postgres migrations create must exist in SQLite too
+	expected := map[string][]string{}
+	for _, migration := range postgresMigrations {
+		for _, match := range postgresCreateTable.FindAllStringSubmatch(migration, -1) {
+			if _, ok := expected[match[1]]; !ok {
+				expected[match[1]] = nil
+			}
+		}
+		for _, match := range postgresAlterTable.FindAllStringSubmatch(migration, -1) {
+			for _, column := range postgresAddColumn.FindAllStringSubmatch(match[2], -1) {
+				expected[match[1]] = append(expected[match[1]], column[1])
+			}
+		}
+	}
+	delete(expected, "item_embeddings")
+
+	for table, columns := range expected {
+		existing := map[string]bool{}
+		rows, err := db.Query("SELECT name FROM pragma_table_info($1)", table)
+		if err != nil {
+			t.Fatalf("Failed to read columns of %s: %v", table, err)
+		}
+		for rows.Next() {
+			var name string
+			if err := rows.Scan(&name); err != nil {
+				t.Fatalf("Failed to scan column of %s: %v", table, err)
+			}
+			existing[strings.ToLower(name)] = true
+		}
+		rows.Close()
+
+		if len(existing) == 0 {
+			t.Errorf("Expected table %s to exist", table)
+			continue
+		}
+		for _, column := range columns {
+			if !existing[strings.ToLower(column)] {
+				t.Errorf("Expected column %s.%s to exist", table, column)
+			}
+		}
+	}
+}
+
+func TestSQLiteUserProgressStatsTriggers(t *testing.T) {
+	db := openSQLite(t)
+	if err := RunMigrations(db); err != nil {
+		t.Fatalf("RunMigrations returned error: %v", err)
+	}
+
+	var userID, itemID int
+	if err := db.QueryRow(`INSERT INTO users (email, password_hash, name, auth_provider) VALUES ('a@example.com', 'x', 'A', 'email') RETURNING id`).Scan(&userID); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	if err := db.QueryRow(`INSERT INTO items (title, link, category) VALUES ('Two Sum', 'https://example.com', 'dsa') RETURNING id`).Scan(&itemID); err != nil {
+		t.Fatalf("Failed to create item: %v", err)
+	}
+	if _, err := db.Exec(`INSERT INTO user_stats (user_id, pending_items, stats_refreshed_at) VALUES ($1, 1, CURRENT_TIMESTAMP)`, userID); err != nil {
+		t.Fatalf("Failed to create stats: %v", err)
+	}
+
+	counts := func() (completed, pending, dsa int) {
+		t.Helper()
+		err := db.QueryRow(`SELECT completed_items, pending_items, dsa_completed FROM user_stats WHERE user_id = $1`, userID).
+			Scan(&completed, &pending, &dsa)
+		if err != nil {
+			t.Fatalf("Failed to read stats: %v", err)
+		}
+		return
+	}
+
+	if _, err := db.Exec(`INSERT INTO user_progress (user_id, item_id, status) VALUES ($1, $2, 'done')`, userID, itemID); err != nil {
+		t.Fatalf("Failed to record progress: %v", err)
+	}
+	if completed, pending, dsa := counts(); completed != 1 || pending != 0 || dsa != 1 {
+		t.Errorf("After completing: expected 1 completed, 0 pending, 1 dsa, got %d, %d, %d", completed, pending, dsa)
+	}
+
+	if _, err := db.Exec(`UPDATE user_progress SET status = 'pending' WHERE user_id = $1`, userID); err != nil {
+		t.Fatalf("Failed to reset progress: %v", err)
+	}
+	if completed, pending, dsa := counts(); completed != 0 || pending != 1 || dsa != 0 {
+		t.Errorf("After resetting: expected 0 completed, 1 pending, 0 dsa, got %d, %d, %d", completed, pending, dsa)
+	}
+}
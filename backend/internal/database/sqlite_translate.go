@@ -0,0 +1,279 @@
+package database
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// sqliteTranslations caches translated queries. Queries are built from a fixed set of templates,
+// so the cache stays small.
+var sqliteTranslations sync.Map
+
+var (
+	sqlitePlaceholder     = regexp.MustCompile(`\$(\d+)`)
+	sqliteLimitParam      = regexp.MustCompile(`(?i)\bLIMIT\s+(\?\d+)`)
+	sqliteLocking         = regexp.MustCompile(`(?i)\s+FOR\s+(UPDATE|SHARE)(\s+OF\s+\w+(\s*,\s*\w+)*)?(\s+(SKIP\s+LOCKED|NOWAIT))?`)
+	sqliteNotDistinctFrom = regexp.MustCompile(`(?i)\bIS\s+NOT\s+DISTINCT\s+FROM\b`)
+	sqliteDistinctFrom    = regexp.MustCompile(`(?i)\bIS\s+DISTINCT\s+FROM\b`)
+	sqliteILike           = regexp.MustCompile(`(?i)\bILIKE\b`)
+	sqliteRegexMatch      = regexp.MustCompile(`\s(!?)~(\*?)\s`)
+	sqliteToRegclass      = regexp.MustCompile(`(?i)\bto_regclass\((\x00\d+\x00)\)`)
+	sqliteArrayCompare    = regexp.MustCompile(`(?i)(=|<>|!=)\s*(ANY|ALL)\s*\(`)
+	sqliteExtractEpoch    = regexp.MustCompile(`(?i)\bEXTRACT\s*\(\s*EPOCH\s+FROM\s+`)
+	sqliteCastType        = regexp.MustCompile(`^::\s*([A-Za-z_][A-Za-z0-9_]*)(\s*\(\s*\d+(\s*,\s*\d+)?\s*\))?(\[\])?`)
+)
+
+// translateSQLite rewrites a Postgres query into SQLite's dialect:
+//
+//   - $1 placeholders become ?1, and a LIMIT bound to NULL means no limit as in Postgres
+//   - ::type casts become CAST(... AS INTEGER|TEXT|REAL) or date(...); casts to types SQLite
+//     stores as they are (timestamps, jsonb, arrays) are dropped
+//   - x = ANY(array) and x <> ALL(array) become IN and NOT IN over the array's elements
+//   - EXTRACT(EPOCH FROM a - b) becomes the difference of the two times in seconds
+//   - IS [NOT] DISTINCT FROM becomes IS [NOT], ILIKE becomes LIKE (which ignores case in
+//     SQLite) and the ~ operators become REGEXP
+//   - row locks are dropped: SQLite locks the whole database for a writing transaction
+//
+// The rest of the SQL the repositories write (upserts, RETURNING, FILTER, window functions,
+// UPDATE ... FROM) is understood by SQLite as is.
+func translateSQLite(query string) string {
+	if translated, ok := sqliteTranslations.Load(query); ok {
+		return translated.(string)
+	}
+
+	masked, literals := maskSQLLiterals(query)
+	masked = sqlitePlaceholder.ReplaceAllString(masked, "?$1")
+	masked = sqliteLimitParam.ReplaceAllString(masked, "LIMIT COALESCE($1, -1)")
+	masked = sqliteLocking.ReplaceAllString(masked, "")
+	masked = sqliteNotDistinctFrom.ReplaceAllString(masked, "IS")
+	masked = sqliteDistinctFrom.ReplaceAllString(masked, "IS NOT")
+	masked = sqliteILike.ReplaceAllString(masked, "LIKE")
+	masked = sqliteRegexMatch.ReplaceAllStringFunc(masked, func(op string) string {
+		match := " REGEXP "
+		if strings.Contains(op, "!") {
+			match = " NOT REGEXP "
+		}
+		if strings.Contains(op, "*") {
+			match += "'(?i)' || "
+		}
+		return match
+	})
+	masked = sqliteToRegclass.ReplaceAllString(masked, "(SELECT name FROM sqlite_master WHERE type = 'table' AND name = $1)")
+	masked = translateCasts(masked)
+	masked = translateExtractEpoch(masked)
+	masked = translateArrayCompares(masked)
+
+	translated := unmaskSQLLiterals(masked, literals)
+	sqliteTranslations.Store(query, translated)
+	return translated
+}
+
+// maskSQLLiterals swaps string literals for numbered markers so rewrites can't touch their
+// contents, and drops -- comments
+func maskSQLLiterals(query string) (string, []string) {
+	var masked strings.Builder
+	var literals []string
+	for i := 0; i < len(query); i++ {
+		switch {
+		case query[i] == '\'':
+			end := i + 1
+			for end < len(query) {
+				if query[end] == '\'' {
+					if end+1 < len(query) && query[end+1] == '\'' {
+						end += 2
+						continue
+					}
+					break
+				}
+				end++
+			}
+			literals = append(literals, query[i:min(end+1, len(query))])
+			masked.WriteString("\x00" + strconv.Itoa(len(literals)-1) + "\x00")
+			i = end
+		case strings.HasPrefix(query[i:], "--"):
+			for i < len(query) && query[i] != '\n' {
+				i++
+			}
+			masked.WriteByte('\n')
+		default:
+			masked.WriteByte(query[i])
+		}
+	}
+	return masked.String(), literals
+}
+
+// unmaskSQLLiterals puts the literals back
+func unmaskSQLLiterals(masked string, literals []string) string {
+	var query strings.Builder
+	for {
+		start := strings.IndexByte(masked, '\x00')
+		if start < 0 {
+			query.WriteString(masked)
+			return query.String()
+		}
+		end := strings.IndexByte(masked[start+1:], '\x00') + start + 1
+		n, _ := strconv.Atoi(masked[start+1 : end])
+		query.WriteString(masked[:start])
+		query.WriteString(literals[n])
+		masked = masked[end+1:]
+	}
+}
+
+// translateCasts rewrites each ::type cast
+func translateCasts(query string) string {
+	for {
+		at := strings.Index(query, "::")
+		if at < 0 {
+			return query
+		}
+
+		cast := sqliteCastType.FindStringSubmatch(query[at:])
+		start := castOperandStart(query, at)
+		if cast == nil || start == at {
+			// Not a cast we understand; leave it for SQLite to reject
+			return query
+		}
+
+		operand := query[start:at]
+		var replacement string
+		switch typ := strings.ToUpper(cast[1]); {
+		case cast[4] != "":
+			replacement = operand
+		case typ == "INT" || typ == "INTEGER" || typ == "BIGINT" || typ == "SMALLINT" || typ == "INT4" || typ == "INT8":
+			replacement = "CAST(" + operand + " AS INTEGER)"
+		case typ == "TEXT" || typ == "VARCHAR" || typ == "CHAR" || typ == "UUID":
+			replacement = "CAST(" + operand + " AS TEXT)"
+		case typ == "REAL" || typ == "FLOAT" || typ == "NUMERIC" || typ == "DECIMAL":
+			replacement = "CAST(" + operand + " AS REAL)"
+		case typ == "DATE":
+			replacement = "date(" + operand + ")"
+		default:
+			replacement = operand
+		}
+		query = query[:start] + replacement + query[at+len(cast[0]):]
+	}
+}
+
+// castOperandStart finds where the operand of the cast at position at begins: a parenthesized
+// expression or function call, a literal marker, or an identifier, column or placeholder
+func castOperandStart(query string, at int) int {
+	i := at
+	switch {
+	case i > 0 && query[i-1] == ')':
+		depth := 0
+		for i--; i >= 0; i-- {
+			if query[i] == ')' {
+				depth++
+			} else if query[i] == '(' {
+				depth--
+				if depth == 0 {
+					break
+				}
+			}
+		}
+		if i < 0 {
+			return at
+		}
+		for i > 0 && isSQLWordByte(query[i-1]) {
+			i--
+		}
+	case i > 0 && query[i-1] == '\x00':
+		i = strings.LastIndexByte(query[:i-1], '\x00')
+	default:
+		for i > 0 && (isSQLWordByte(query[i-1]) || query[i-1] == '.' || query[i-1] == '?') {
+			i--
+		}
+	}
+	return i
+}
+
+// isSQLWordByte reports whether b can be part of an identifier
+func isSQLWordByte(b byte) bool {
+	return b == '_' || ('a' <= b && b <= 'z') || ('A' <= b && b <= 'Z') || ('0' <= b && b <= '9')
+}
+
+// closingParen returns the index of the parenthesis closing the one opened just before start
+func closingParen(query string, start int) int {
+	depth := 1
+	for i := start; i < len(query); i++ {
+		switch query[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// translateExtractEpoch rewrites EXTRACT(EPOCH FROM a - b) as (pg_epoch(a) - pg_epoch(b))
+func translateExtractEpoch(query string) string {
+	for {
+		loc := sqliteExtractEpoch.FindStringIndex(query)
+		if loc == nil {
+			return query
+		}
+		end := closingParen(query, loc[1])
+		if end < 0 {
+			return query
+		}
+
+		expr := strings.TrimSpace(query[loc[1]:end])
+		for strings.HasPrefix(expr, "(") && closingParen(expr, 1) == len(expr)-1 {
+			expr = strings.TrimSpace(expr[1 : len(expr)-1])
+		}
+
+		replacement := "pg_epoch(" + expr + ")"
+		if minus := topLevelMinus(expr); minus >= 0 {
+			replacement = "(pg_epoch(" + strings.TrimSpace(expr[:minus]) + ") - pg_epoch(" + strings.TrimSpace(expr[minus+1:]) + "))"
+		}
+		query = query[:loc[0]] + replacement + query[end+1:]
+	}
+}
+
+// topLevelMinus finds a binary minus outside parentheses
+func topLevelMinus(expr string) int {
+	depth := 0
+	for i := 0; i < len(expr); i++ {
+		switch expr[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case '-':
+			if depth == 0 && i > 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// translateArrayCompares rewrites x = ANY(array) as x IN (the array's elements), and
+// x <> ALL(array) as NOT IN
+func translateArrayCompares(query string) string {
+	for {
+		loc := sqliteArrayCompare.FindStringSubmatchIndex(query)
+		if loc == nil {
+			return query
+		}
+		op := query[loc[2]:loc[3]]
+		quantifier := strings.ToUpper(query[loc[4]:loc[5]])
+		end := closingParen(query, loc[1])
+		if end < 0 || (op == "=") != (quantifier == "ANY") {
+			return query
+		}
+
+		in := "IN"
+		if quantifier == "ALL" {
+			in = "NOT IN"
+		}
+		array := query[loc[1]:end]
+		query = query[:loc[0]] + in + " (SELECT value FROM json_each(pg_array_json(" + array + ")))" + query[end+1:]
+	}
+}
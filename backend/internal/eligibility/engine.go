@@ -0,0 +1,121 @@
+// Package eligibility decides whether a user may start a test under the rules an admin set. The
+// engine only evaluates rules; the facts they're checked against come from the caller.
+package eligibility
+
+import (
+	"fmt"
+	"time"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+)
+
+// Facts looks up what rules are checked against
+type Facts interface {
+	// CountItems counts the user's items with a status, in a category and subcategory when set
+	CountItems(userID int, status models.Status, category models.Category, subcategory string) (int, error)
+	// LastTestAt is when the user's latest test started, or nil before their first
+	LastTestAt(userID int) (*time.Time, error)
+}
+
+// RuleEngine evaluates test eligibility rules for a user
+type RuleEngine struct {
+	facts Facts
+	now   func() time.Time
+}
+
+// NewRuleEngine creates a rule engine checking rules against facts
+func NewRuleEngine(facts Facts) *RuleEngine {
+	return &RuleEngine{facts: facts, now: time.Now}
+}
+
+// Evaluate checks every rule. The user can create a test when all are met, and always when
+// there are none; otherwise each unmet rule adds a reason.
+func (e *RuleEngine) Evaluate(userID int, rules []models.TestEligibilityRule) (*models.TestEligibility, error) {
+	result := &models.TestEligibility{Unmet: []string{}}
+
+	for _, rule := range rules {
+		reason, err := e.check(userID, rule, result)
+		if err != nil {
+			return nil, err
+		}
+		if reason != "" {
+			result.Unmet = append(result.Unmet, reason)
+		}
+	}
+
+	result.CanCreate = len(result.Unmet) == 0
+	switch {
+	case !result.CanCreate:
+		result.Reason = result.Unmet[0]
+	case len(rules) == 0:
+		result.Reason = "Tests can be started at any time"
+	default:
+		result.Reason = "You meet every requirement for starting a test"
+	}
+	return result, nil
+}
+
+// check returns why the user doesn't meet a rule, or "" when they do. A cooldown also sets
+// when the next test can start.
+func (e *RuleEngine) check(userID int, rule models.TestEligibilityRule, result *models.TestEligibility) (string, error) {
+	switch rule.Kind {
+	case models.TestRuleInProgress:
+		count, err := e.facts.CountItems(userID, models.StatusInProgress, rule.Category, rule.Subcategory)
+		if err != nil {
+			return "", apperr.Errorf("failed to check for in-progress items: %w", err)
+		}
+		if count == 0 {
+			return fmt.Sprintf("No %sitem is currently in progress", describe(rule)), nil
+		}
+
+	case models.TestRuleMinCompleted:
+		count, err := e.facts.CountItems(userID, models.StatusDone, rule.Category, rule.Subcategory)
+		if err != nil {
+			return "", apperr.Errorf("failed to count completed items: %w", err)
+		}
+		if count < rule.Count {
+			return fmt.Sprintf("Complete %d more %s%s (%d of %d done)", rule.Count-count, describe(rule), pluralize(rule.Count-count), count, rule.Count), nil
+		}
+
+	case models.TestRuleCooldown:
+		last, err := e.facts.LastTestAt(userID)
+		if err != nil {
+			return "", apperr.Errorf("failed to get the last test: %w", err)
+		}
+		if last == nil {
+			return "", nil
+		}
+
+		available := last.Add(time.Duration(rule.CooldownHours) * time.Hour)
+		if e.now().Before(available) {
+			if result.AvailableAt == nil || available.After(*result.AvailableAt) {
+				result.AvailableAt = &available
+			}
+			return fmt.Sprintf("The next test can start at %s", available.UTC().Format(time.RFC3339)), nil
+		}
+	}
+
+	return "", nil
+}
+
+// describe names the items a rule is about, with a trailing space: "dsa ", "miscellaneous
+// test_n_revise " or nothing for a rule about every category
+func describe(rule models.TestEligibilityRule) string {
+	switch {
+	case rule.Category == "":
+		return ""
+	case rule.Subcategory == "":
+		return string(rule.Category) + " "
+	default:
+		return string(rule.Category) + " " + rule.Subcategory + " "
+	}
+}
+
+// pluralize picks item or items for a count
+func pluralize(count int) string {
+	if count == 1 {
+		return "item"
+	}
+	return "items"
+}
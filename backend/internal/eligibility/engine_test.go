@@ -0,0 +1,105 @@
+package eligibility
+
+import (
+	"testing"
+	"time"
+
+	"interview-prep-app/internal/models"
+)
+
+// facts serves fixed counts keyed by status and category, and a fixed last test time
+type facts struct {
+	counts map[string]int
+	last   *time.Time
+}
+
+func (f *facts) CountItems(userID int, status models.Status, category models.Category, subcategory string) (int, error) {
+	return f.counts[string(status)+" "+string(category)], nil
+}
+
+func (f *facts) LastTestAt(userID int) (*time.Time, error) {
+	return f.last, nil
+}
+
+func TestEvaluate(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	lastTest := now.Add(-10 * time.Hour)
+
+	testCases := []struct {
+		name          string
+		rules         []models.TestEligibilityRule
+		facts         *facts
+		expectCreate  bool
+		expectReasons []string
+	}{
+		{
+			name:         "No rules",
+			facts:        &facts{},
+			expectCreate: true,
+		},
+		{
+			name:          "Default rule without an item in progress",
+			rules:         models.DefaultTestEligibilityRules(),
+			facts:         &facts{},
+			expectReasons: []string{"No miscellaneous test_n_revise item is currently in progress"},
+		},
+		{
+			name:         "Default rule met",
+			rules:        models.DefaultTestEligibilityRules(),
+			facts:        &facts{counts: map[string]int{"in-progress miscellaneous": 1}},
+			expectCreate: true,
+		},
+		{
+			name: "Completed items and cooldown unmet",
+			rules: []models.TestEligibilityRule{
+				{Kind: models.TestRuleMinCompleted, Category: models.CategoryDSA, Count: 5},
+				{Kind: models.TestRuleMinCompleted, Count: 3},
+				{Kind: models.TestRuleCooldown, CooldownHours: 24},
+			},
+			facts: &facts{counts: map[string]int{"done dsa": 4, "done ": 3}, last: &lastTest},
+			expectReasons: []string{
+				"Complete 1 more dsa item (4 of 5 done)",
+				"The next test can start at 2024-03-02T02:00:00Z",
+			},
+		},
+		{
+			name:         "Cooldown passed",
+			rules:        []models.TestEligibilityRule{{Kind: models.TestRuleCooldown, CooldownHours: 8}},
+			facts:        &facts{last: &lastTest},
+			expectCreate: true,
+		},
+		{
+			name:         "Cooldown before the first test",
+			rules:        []models.TestEligibilityRule{{Kind: models.TestRuleCooldown, CooldownHours: 24}},
+			facts:        &facts{},
+			expectCreate: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			engine := NewRuleEngine(tc.facts)
+			engine.now = func() time.Time { return now }
+
+			result, err := engine.Evaluate(1, tc.rules)
+			if err != nil {
+				t.Fatalf("Evaluate returned error: %v", err)
+			}
+
+			if result.CanCreate != tc.expectCreate {
+				t.Errorf("Expected can_create %v, got %v", tc.expectCreate, result.CanCreate)
+			}
+			if len(result.Unmet) != len(tc.expectReasons) {
+				t.Fatalf("Expected reasons %v, got %v", tc.expectReasons, result.Unmet)
+			}
+			for i, reason := range tc.expectReasons {
+				if result.Unmet[i] != reason {
+					t.Errorf("Expected reason %q, got %q", reason, result.Unmet[i])
+				}
+			}
+			if !tc.expectCreate && result.Reason != tc.expectReasons[0] {
+				t.Errorf("Expected the first unmet rule as the reason, got %q", result.Reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+// Package events is the internal event bus: services publish domain events (an item was
+// completed, a user registered) and subsystems such as webhooks and notifications subscribe
+// to them without the publisher knowing who is listening.
+package events
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"interview-prep-app/internal/config"
+	"interview-prep-app/pkg/apperr"
+)
+
+// Name identifies a kind of event
+type Name string
+
+const (
+	ItemCompleted   Name = "item.completed"
+	StreakChanged   Name = "streak.changed"
+	TestFinished    Name = "test.finished"
+	UserRegistered  Name = "user.registered"
+	ProgressChanged Name = "progress.changed" // a user's item statuses changed other than by completion
+	CatalogChanged  Name = "catalog.changed"  // items were added, removed or recategorized
+	ItemUpdated     Name = "item.updated"     // an item was edited
+)
+
+// handlerTimeout bounds how long a subscriber may spend on one event
+const handlerTimeout = 30 * time.Second
+
+// ErrClosed is returned when publishing to a bus that has been closed
+var ErrClosed = errors.New("event bus is closed")
+
+// Event is a published occurrence. Data holds the JSON-encoded payload so events look the
+// same whether they were delivered in-process or through a broker.
+type Event struct {
+	ID         string          `json:"id"`
+	Name       Name            `json:"name"`
+	UserID     int             `json:"user_id"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data,omitempty"`
+}
+
+// Decode unmarshals the event payload into v
+func (e Event) Decode(v interface{}) error {
+	if err := json.Unmarshal(e.Data, v); err != nil {
+		return apperr.Errorf("failed to decode %s event: %w", e.Name, err)
+	}
+	return nil
+}
+
+// Handler processes an event. Returned errors are logged; events are not redelivered.
+type Handler func(ctx context.Context, event Event) error
+
+// Bus publishes events and fans them out to subscribers. Delivery is asynchronous and
+// at-most-once, so subscribers must not be relied on for anything the publisher can't lose.
+type Bus interface {
+	// Publish sends an event; it returns once the event is queued, not when it's handled
+	Publish(ctx context.Context, name Name, userID int, data interface{}) error
+	// Subscribe registers a handler for an event. The group names the subscribing subsystem:
+	// when several server instances share a broker, each event reaches one member of each group.
+	Subscribe(name Name, group string, handler Handler)
+	// Close stops accepting events and waits for queued ones to be handled
+	Close() error
+}
+
+// New creates the event bus selected in the configuration
+func New(cfg *config.Config) (Bus, error) {
+	switch cfg.EventBusBackend {
+	case "", "memory":
+		return NewInProcessBus(cfg.EventBusQueueSize), nil
+	case "nats":
+		return NewNATSBus(cfg.EventBusURL, cfg.EventBusSubjectPrefix, cfg.EventBusQueueSize)
+	default:
+		return nil, fmt.Errorf("unsupported event bus backend: %s", cfg.EventBusBackend)
+	}
+}
+
+// NewEvent builds an event, encoding data as its payload
+func NewEvent(name Name, userID int, data interface{}) (Event, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return Event{}, apperr.Errorf("failed to encode %s event: %w", name, err)
+	}
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return Event{}, apperr.Errorf("failed to generate event ID: %w", err)
+	}
+
+	return Event{
+		ID:         hex.EncodeToString(id),
+		Name:       name,
+		UserID:     userID,
+		OccurredAt: time.Now().UTC(),
+		Data:       payload,
+	}, nil
+}
+
+// subscription is a handler registered by one subscriber group
+type subscription struct {
+	name    Name
+	group   string
+	handler Handler
+}
+
+// handle runs a subscriber, logging failures and panics so one subscriber can't take down
+// the dispatcher
+func (s *subscription) handle(event Event) {
+	ctx, cancel := context.WithTimeout(context.Background(), handlerTimeout)
+	defer cancel()
+
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Event subscriber %s panicked on %s: %v", s.group, event.Name, r)
+		}
+	}()
+
+	if err := s.handler(ctx, event); err != nil {
+		log.Printf("Event subscriber %s failed on %s: %v", s.group, event.Name, err)
+	}
+}
+
+// registry holds the local subscriptions by event name
+type registry struct {
+	mu   sync.RWMutex
+	subs map[Name][]*subscription
+}
+
+func (r *registry) add(sub *subscription) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.subs == nil {
+		r.subs = make(map[Name][]*subscription)
+	}
+	r.subs[sub.name] = append(r.subs[sub.name], sub)
+}
+
+func (r *registry) forName(name Name) []*subscription {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.subs[name]
+}
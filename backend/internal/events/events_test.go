@@ -0,0 +1,138 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInProcessBusDeliversToEachSubscriber(t *testing.T) {
+	bus := NewInProcessBus(8)
+
+	received := make(chan string, 4)
+	record := func(group string) Handler {
+		return func(ctx context.Context, e Event) error {
+			var data map[string]int
+			if err := e.Decode(&data); err != nil {
+				return err
+			}
+			received <- fmt.Sprintf("%s:%s:%d:%d", group, e.Name, e.UserID, data["item_id"])
+			return nil
+		}
+	}
+	bus.Subscribe(ItemCompleted, "webhooks", record("webhooks"))
+	bus.Subscribe(ItemCompleted, "notifications", record("notifications"))
+	bus.Subscribe(UserRegistered, "webhooks", record("unexpected"))
+
+	if err := bus.Publish(context.Background(), ItemCompleted, 7, map[string]int{"item_id": 42}); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	bus.Close()
+
+	close(received)
+	var got []string
+	for r := range received {
+		got = append(got, r)
+	}
+	want := []string{"webhooks:item.completed:7:42", "notifications:item.completed:7:42"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("received %v, want %v", got, want)
+	}
+
+	if err := bus.Publish(context.Background(), ItemCompleted, 7, nil); err != ErrClosed {
+		t.Fatalf("Publish after Close = %v, want ErrClosed", err)
+	}
+}
+
+// fakeNATSServer accepts one client and echoes each PUB back to the matching SUB
+func fakeNATSServer(t *testing.T) string {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		fmt.Fprint(conn, "INFO {\"server_id\":\"test\",\"max_payload\":1048576}\r\n")
+		reader := bufio.NewReader(conn)
+		subs := map[string]string{} // subject -> sid
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			fields := strings.Fields(line)
+			switch fields[0] {
+			case "SUB":
+				subs[fields[1]] = fields[len(fields)-1]
+			case "PING":
+				fmt.Fprint(conn, "PONG\r\n")
+			case "PUB":
+				var size int
+				fmt.Sscan(fields[2], &size)
+				payload := make([]byte, size+2)
+				if _, err := io.ReadFull(reader, payload); err != nil {
+					return
+				}
+				if sid, ok := subs[fields[1]]; ok {
+					fmt.Fprintf(conn, "MSG %s %s %d\r\n%s", fields[1], sid, size, payload)
+				}
+			}
+		}
+	}()
+
+	return "nats://" + ln.Addr().String()
+}
+
+func TestNATSBusRoundTrip(t *testing.T) {
+	bus, err := NewNATSBus(fakeNATSServer(t), "test.events", 8)
+	if err != nil {
+		t.Fatalf("NewNATSBus: %v", err)
+	}
+
+	received := make(chan Event, 1)
+	bus.Subscribe(StreakChanged, "notifications", func(ctx context.Context, e Event) error {
+		received <- e
+		return nil
+	})
+
+	// The SUB is written before the PUB on the same connection, so the server sees it first
+	if err := bus.Publish(context.Background(), StreakChanged, 3, map[string]int{"current_streak": 7}); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+
+	select {
+	case e := <-received:
+		if e.Name != StreakChanged || e.UserID != 3 || string(e.Data) != `{"current_streak":7}` {
+			t.Fatalf("unexpected event %+v", e)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("event was not delivered")
+	}
+
+	bus.Close()
+}
+
+func TestParseMsgLine(t *testing.T) {
+	sid, size, err := parseMsgLine("MSG prepmaster.events.item.completed 3 _INBOX.abc 128")
+	if err != nil || sid != 3 || size != 128 {
+		t.Fatalf("parseMsgLine with reply = (%d, %d, %v)", sid, size, err)
+	}
+
+	if _, _, err := parseMsgLine("MSG subject notanumber 12"); err == nil {
+		t.Fatal("expected an error for a non-numeric sid")
+	}
+}
@@ -0,0 +1,89 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// defaultQueueSize is used when no queue size is configured
+const defaultQueueSize = 1024
+
+// ErrQueueFull is returned when subscribers have fallen too far behind to accept more events
+var ErrQueueFull = errors.New("event bus queue is full")
+
+// InProcessBus delivers events to subscribers in the same process from a single background
+// worker, in publish order
+type InProcessBus struct {
+	registry
+
+	mu     sync.RWMutex
+	closed bool
+	queue  chan Event
+	done   chan struct{}
+}
+
+// NewInProcessBus creates an in-process bus that buffers up to queueSize unhandled events
+func NewInProcessBus(queueSize int64) *InProcessBus {
+	if queueSize <= 0 {
+		queueSize = defaultQueueSize
+	}
+
+	b := &InProcessBus{
+		queue: make(chan Event, queueSize),
+		done:  make(chan struct{}),
+	}
+	go b.run()
+	return b
+}
+
+// Publish queues an event for the subscribers
+func (b *InProcessBus) Publish(ctx context.Context, name Name, userID int, data interface{}) error {
+	event, err := NewEvent(name, userID, data)
+	if err != nil {
+		return err
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.closed {
+		return ErrClosed
+	}
+
+	select {
+	case b.queue <- event:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Subscribe registers a handler for an event
+func (b *InProcessBus) Subscribe(name Name, group string, handler Handler) {
+	b.add(&subscription{name: name, group: group, handler: handler})
+}
+
+// Close stops accepting events and waits for the queued ones to be handled
+func (b *InProcessBus) Close() error {
+	b.mu.Lock()
+	if !b.closed {
+		b.closed = true
+		close(b.queue)
+	}
+	b.mu.Unlock()
+
+	<-b.done
+	return nil
+}
+
+// run hands each queued event to its subscribers
+func (b *InProcessBus) run() {
+	defer close(b.done)
+
+	for event := range b.queue {
+		for _, sub := range b.forName(event.Name) {
+			sub.handle(event)
+		}
+	}
+}
@@ -0,0 +1,393 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"interview-prep-app/pkg/apperr"
+)
+
+const (
+	natsDefaultPort      = "4222"
+	natsDialTimeout      = 5 * time.Second
+	natsMaxReconnectWait = 30 * time.Second
+)
+
+// ErrDisconnected is returned when publishing while the broker connection is down
+var ErrDisconnected = errors.New("event bus is not connected")
+
+// NATSBus publishes events through a NATS server so every server instance sees them. Each
+// subscription joins a queue group named after its subscriber group, so an event is handled
+// by one instance per subsystem rather than once per instance. It speaks the plain-text NATS
+// client protocol directly and reconnects with backoff when the connection drops.
+type NATSBus struct {
+	addr   string
+	user   *url.Userinfo
+	prefix string
+
+	mu      sync.Mutex
+	conn    net.Conn
+	writer  *bufio.Writer
+	subs    map[int]*subscription
+	nextSID int
+	closed  bool
+
+	deliveries chan delivery
+	stop       chan struct{}
+	done       chan struct{}
+}
+
+// delivery is an event received from the broker for one subscription
+type delivery struct {
+	sub   *subscription
+	event Event
+}
+
+// natsInfo is the part of the server's INFO greeting the client acts on
+type natsInfo struct {
+	TLSRequired bool `json:"tls_required"`
+}
+
+// NewNATSBus connects to the NATS server at rawURL (nats://[user:pass@|token@]host[:port]).
+// Event subjects are "<prefix>.<event name>".
+func NewNATSBus(rawURL, prefix string, queueSize int64) (*NATSBus, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid NATS URL: %q", rawURL)
+	}
+	if u.Scheme != "nats" {
+		return nil, fmt.Errorf("unsupported NATS URL scheme %q (only nats:// is supported)", u.Scheme)
+	}
+
+	addr := u.Host
+	if u.Port() == "" {
+		addr = net.JoinHostPort(u.Hostname(), natsDefaultPort)
+	}
+	if prefix == "" {
+		prefix = "prepmaster.events"
+	}
+	if queueSize <= 0 {
+		queueSize = defaultQueueSize
+	}
+
+	b := &NATSBus{
+		addr:       addr,
+		user:       u.User,
+		prefix:     prefix,
+		subs:       make(map[int]*subscription),
+		deliveries: make(chan delivery, queueSize),
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+
+	conn, reader, err := b.dial()
+	if err != nil {
+		return nil, err
+	}
+	if err := b.attach(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	go b.readLoop(conn, reader)
+	go b.dispatch()
+	return b, nil
+}
+
+// Publish sends an event to the broker
+func (b *NATSBus) Publish(ctx context.Context, name Name, userID int, data interface{}) error {
+	event, err := NewEvent(name, userID, data)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return apperr.Errorf("failed to encode %s event: %w", name, err)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return ErrClosed
+	}
+	if b.writer == nil {
+		return ErrDisconnected
+	}
+
+	fmt.Fprintf(b.writer, "PUB %s %d\r\n", b.subject(name), len(payload))
+	b.writer.Write(payload)
+	b.writer.WriteString("\r\n")
+	if err := b.writer.Flush(); err != nil {
+		return apperr.Errorf("failed to publish %s event: %w", name, err)
+	}
+
+	return nil
+}
+
+// Subscribe registers a handler for an event in the group's queue group
+func (b *NATSBus) Subscribe(name Name, group string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextSID++
+	sub := &subscription{name: name, group: group, handler: handler}
+	b.subs[b.nextSID] = sub
+
+	// If the connection is down the subscription is sent when it's re-established
+	if b.writer != nil {
+		b.writeSub(b.nextSID, sub)
+		b.writer.Flush()
+	}
+}
+
+// Close disconnects from the broker and waits for received events to be handled
+func (b *NATSBus) Close() error {
+	b.mu.Lock()
+	if !b.closed {
+		b.closed = true
+		close(b.stop)
+		if b.conn != nil {
+			b.writer.Flush()
+			b.conn.Close()
+		}
+	}
+	b.mu.Unlock()
+
+	<-b.done
+	return nil
+}
+
+// subject is the NATS subject an event is published on
+func (b *NATSBus) subject(name Name) string {
+	return b.prefix + "." + string(name)
+}
+
+// dial opens a connection and completes the CONNECT handshake
+func (b *NATSBus) dial() (net.Conn, *bufio.Reader, error) {
+	conn, err := net.DialTimeout("tcp", b.addr, natsDialTimeout)
+	if err != nil {
+		return nil, nil, apperr.Errorf("failed to connect to NATS at %s: %w", b.addr, err)
+	}
+
+	conn.SetDeadline(time.Now().Add(natsDialTimeout))
+	reader := bufio.NewReader(conn)
+
+	line, err := reader.ReadString('\n')
+	if err != nil || !strings.HasPrefix(line, "INFO ") {
+		conn.Close()
+		return nil, nil, fmt.Errorf("unexpected NATS greeting from %s", b.addr)
+	}
+
+	var info natsInfo
+	if err := json.Unmarshal([]byte(strings.TrimSpace(line[len("INFO "):])), &info); err != nil {
+		conn.Close()
+		return nil, nil, fmt.Errorf("invalid NATS server info: %w", err)
+	}
+	if info.TLSRequired {
+		conn.Close()
+		return nil, nil, fmt.Errorf("NATS server at %s requires TLS, which is not supported", b.addr)
+	}
+
+	options := map[string]interface{}{
+		"verbose":  false,
+		"pedantic": false,
+		"lang":     "go",
+		"version":  "1",
+		"name":     "prepmaster",
+	}
+	if b.user != nil {
+		if pass, ok := b.user.Password(); ok {
+			options["user"] = b.user.Username()
+			options["pass"] = pass
+		} else {
+			options["auth_token"] = b.user.Username()
+		}
+	}
+
+	connect, err := json.Marshal(options)
+	if err != nil {
+		conn.Close()
+		return nil, nil, apperr.Errorf("failed to encode NATS CONNECT: %w", err)
+	}
+	if _, err := fmt.Fprintf(conn, "CONNECT %s\r\n", connect); err != nil {
+		conn.Close()
+		return nil, nil, apperr.Errorf("failed to send NATS CONNECT: %w", err)
+	}
+
+	conn.SetDeadline(time.Time{})
+	return conn, reader, nil
+}
+
+// attach makes conn the active connection and (re)sends every subscription
+func (b *NATSBus) attach(conn net.Conn) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return ErrClosed
+	}
+
+	b.conn = conn
+	b.writer = bufio.NewWriter(conn)
+	for sid, sub := range b.subs {
+		b.writeSub(sid, sub)
+	}
+	b.writer.WriteString("PING\r\n")
+	return b.writer.Flush()
+}
+
+// writeSub writes a SUB command; callers hold b.mu and flush
+func (b *NATSBus) writeSub(sid int, sub *subscription) {
+	fmt.Fprintf(b.writer, "SUB %s %s %d\r\n", b.subject(sub.name), sub.group, sid)
+}
+
+// readLoop reads from the broker until the bus is closed, reconnecting when the connection drops
+func (b *NATSBus) readLoop(conn net.Conn, reader *bufio.Reader) {
+	defer close(b.deliveries)
+
+	for {
+		err := b.read(reader)
+
+		b.mu.Lock()
+		closed := b.closed
+		if b.conn == conn {
+			b.conn = nil
+			b.writer = nil
+		}
+		b.mu.Unlock()
+		conn.Close()
+
+		if closed {
+			return
+		}
+
+		log.Printf("Event bus: lost NATS connection: %v", err)
+		conn, reader = b.reconnect()
+		if conn == nil {
+			return
+		}
+		log.Printf("Event bus: reconnected to NATS at %s", b.addr)
+	}
+}
+
+// reconnect dials with exponential backoff until it succeeds or the bus is closed
+func (b *NATSBus) reconnect() (net.Conn, *bufio.Reader) {
+	wait := time.Second
+	for {
+		select {
+		case <-b.stop:
+			return nil, nil
+		case <-time.After(wait):
+		}
+
+		conn, reader, err := b.dial()
+		if err == nil {
+			if err = b.attach(conn); err == nil {
+				return conn, reader
+			}
+			conn.Close()
+			if errors.Is(err, ErrClosed) {
+				return nil, nil
+			}
+		}
+
+		log.Printf("Event bus: NATS reconnect failed: %v", err)
+		wait *= 2
+		if wait > natsMaxReconnectWait {
+			wait = natsMaxReconnectWait
+		}
+	}
+}
+
+// read handles protocol messages until the connection fails
+func (b *NATSBus) read(reader *bufio.Reader) error {
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		switch {
+		case strings.HasPrefix(line, "MSG "):
+			sid, size, err := parseMsgLine(line)
+			if err != nil {
+				return err
+			}
+			payload := make([]byte, size+2) // payload is followed by CRLF
+			if _, err := io.ReadFull(reader, payload); err != nil {
+				return err
+			}
+			b.deliver(sid, payload[:size])
+		case line == "PING":
+			b.mu.Lock()
+			if b.writer != nil {
+				b.writer.WriteString("PONG\r\n")
+				b.writer.Flush()
+			}
+			b.mu.Unlock()
+		case strings.HasPrefix(line, "-ERR"):
+			log.Printf("Event bus: NATS error: %s", line)
+		}
+		// INFO, PONG and +OK need no action
+	}
+}
+
+// deliver queues a received event for the subscription it arrived on
+func (b *NATSBus) deliver(sid int, payload []byte) {
+	b.mu.Lock()
+	sub := b.subs[sid]
+	b.mu.Unlock()
+	if sub == nil {
+		return
+	}
+
+	var event Event
+	if err := json.Unmarshal(payload, &event); err != nil {
+		log.Printf("Event bus: dropping malformed event for %s: %v", sub.group, err)
+		return
+	}
+
+	b.deliveries <- delivery{sub: sub, event: event}
+}
+
+// dispatch runs subscribers for received events in arrival order
+func (b *NATSBus) dispatch() {
+	defer close(b.done)
+
+	for d := range b.deliveries {
+		d.sub.handle(d.event)
+	}
+}
+
+// parseMsgLine parses "MSG <subject> <sid> [reply-to] <#bytes>"
+func parseMsgLine(line string) (sid, size int, err error) {
+	fields := strings.Fields(line)
+	if len(fields) != 4 && len(fields) != 5 {
+		return 0, 0, fmt.Errorf("malformed NATS message: %q", line)
+	}
+
+	sid, err = strconv.Atoi(fields[2])
+	if err != nil {
+		return 0, 0, fmt.Errorf("malformed NATS message: %q", line)
+	}
+	size, err = strconv.Atoi(fields[len(fields)-1])
+	if err != nil || size < 0 {
+		return 0, 0, fmt.Errorf("malformed NATS message: %q", line)
+	}
+
+	return sid, size, nil
+}
@@ -0,0 +1,31 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// CSVWriter writes rows as RFC 4180 CSV
+type CSVWriter struct {
+	w *csv.Writer
+}
+
+// NewCSVWriter creates a CSV writer
+func NewCSVWriter(w io.Writer) *CSVWriter {
+	return &CSVWriter{w: csv.NewWriter(w)}
+}
+
+// Write writes one row
+func (c *CSVWriter) Write(row []string) error {
+	cells := make([]string, len(row))
+	for i, value := range row {
+		cells[i] = sanitizeCell(value)
+	}
+	return c.w.Write(cells)
+}
+
+// Close flushes any buffered rows
+func (c *CSVWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
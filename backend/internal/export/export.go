@@ -0,0 +1,50 @@
+// Package export writes tabular data as CSV or XLSX for users who track their prep in spreadsheets.
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Format is a supported export file format
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+)
+
+// RowWriter streams rows of string cells. Close must be called to finish the file.
+type RowWriter interface {
+	Write(row []string) error
+	Close() error
+}
+
+// NewWriter returns a RowWriter for the given format
+func NewWriter(format Format, w io.Writer) (RowWriter, error) {
+	switch format {
+	case FormatCSV:
+		return NewCSVWriter(w), nil
+	case FormatXLSX:
+		return NewXLSXWriter(w)
+	default:
+		return nil, fmt.Errorf("unsupported export format: %s. Valid formats are: %s, %s", format, FormatCSV, FormatXLSX)
+	}
+}
+
+// ContentType returns the MIME type for the format
+func (f Format) ContentType() string {
+	if f == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// sanitizeCell stops spreadsheet apps from evaluating user-supplied text (notes, titles) as a formula
+func sanitizeCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
@@ -0,0 +1,55 @@
+package export
+
+import (
+	"strconv"
+	"time"
+
+	"interview-prep-app/internal/models"
+)
+
+// itemColumns is the header row of an item export
+var itemColumns = []string{
+	"id", "title", "link", "category", "subcategory", "status", "starred",
+	"completion_quality", "completed_at", "next_review_at", "notes",
+}
+
+// WriteItems writes a header row followed by one row per item and closes the writer
+func WriteItems(w RowWriter, items []*models.ItemWithProgress) error {
+	if err := w.Write(itemColumns); err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		quality := ""
+		if item.CompletionQuality != nil {
+			quality = string(*item.CompletionQuality)
+		}
+
+		row := []string{
+			strconv.Itoa(item.ID),
+			item.Title,
+			item.Link,
+			string(item.Category),
+			item.Subcategory,
+			string(item.Status),
+			strconv.FormatBool(item.Starred),
+			quality,
+			formatTime(item.CompletedAt),
+			formatTime(item.NextReviewAt),
+			item.Notes,
+		}
+		if err := w.Write(row); err != nil {
+			return err
+		}
+	}
+
+	return w.Close()
+}
+
+// formatTime renders optional timestamps as RFC 3339, which spreadsheet apps parse as dates
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
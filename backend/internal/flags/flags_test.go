@@ -0,0 +1,67 @@
+package flags
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"interview-prep-app/internal/models"
+)
+
+func TestOn(t *testing.T) {
+	flag := &models.FeatureFlag{Key: "study_planner", Enabled: true, RolloutPercent: 30, UserIDs: []int64{42}}
+
+	on := 0
+	for userID := 1; userID <= 10000; userID++ {
+		if On(flag, userID) {
+			on++
+		}
+	}
+	// 30% of users, plus the listed one if hashing left them out
+	if on < 2700 || on > 3300 {
+		t.Errorf("Expected about 30%% of users, got %d of 10000", on)
+	}
+	if !On(flag, 42) {
+		t.Error("Expected a listed user to have the flag")
+	}
+
+	// Raising the percentage keeps everyone who already had the flag
+	wider := *flag
+	wider.RolloutPercent = 60
+	for userID := 1; userID <= 1000; userID++ {
+		if On(flag, userID) && !On(&wider, userID) {
+			t.Fatalf("User %d lost the flag when the rollout widened", userID)
+		}
+	}
+
+	disabled := *flag
+	disabled.Enabled = false
+	if On(&disabled, 42) {
+		t.Error("Expected a disabled flag to be off even for listed users")
+	}
+}
+
+func TestSetEvaluatesOnce(t *testing.T) {
+	calls := 0
+	set := NewSet(7, func(userID int) (map[string]bool, error) {
+		calls++
+		return map[string]bool{"study_planner": userID == 7}, nil
+	})
+
+	ctx := NewContext(context.Background(), set)
+	if !Enabled(ctx, "study_planner") || Enabled(ctx, "unknown") {
+		t.Error("Unexpected flag values")
+	}
+	if calls != 1 {
+		t.Errorf("Expected flags to be evaluated once, got %d", calls)
+	}
+
+	if Enabled(context.Background(), "study_planner") {
+		t.Error("Expected flags to be off without a set")
+	}
+
+	failing := NewSet(7, func(int) (map[string]bool, error) { return nil, errors.New("db down") })
+	if failing.Enabled("study_planner") {
+		t.Error("Expected flags to be off when evaluation fails")
+	}
+}
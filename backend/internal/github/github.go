@@ -0,0 +1,223 @@
+// Package github talks to GitHub on behalf of users: the OAuth web flow that connects an
+// account, and the contents API used to commit solutions to their repositories.
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"interview-prep-app/pkg/apperr"
+)
+
+const (
+	githubAPIURL   = "https://api.github.com"
+	githubOAuthURL = "https://github.com/login/oauth"
+
+	// scope lets the app write to the user's public and private repositories
+	scope = "repo"
+)
+
+// ErrNotFound is returned when a repository or file doesn't exist, or the token can't see it
+var ErrNotFound = errors.New("not found on GitHub")
+
+// Client calls GitHub as an OAuth app
+type Client struct {
+	clientID     string
+	clientSecret string
+	apiURL       string
+	oauthURL     string
+	client       *http.Client
+}
+
+// NewClient creates a GitHub client for an OAuth app
+func NewClient(clientID, clientSecret string) *Client {
+	return &Client{
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		apiURL:       githubAPIURL,
+		oauthURL:     githubOAuthURL,
+		client:       &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// AuthorizeURL returns the page where the user grants the app access. GitHub sends them back to
+// redirectURI with a code and the given state.
+func (c *Client) AuthorizeURL(state, redirectURI string) string {
+	query := url.Values{}
+	query.Set("client_id", c.clientID)
+	query.Set("redirect_uri", redirectURI)
+	query.Set("scope", scope)
+	query.Set("state", state)
+	query.Set("allow_signup", "false")
+	return c.oauthURL + "/authorize?" + query.Encode()
+}
+
+// ExchangeCode trades the code from the authorization redirect for an access token
+func (c *Client) ExchangeCode(ctx context.Context, code, redirectURI string) (string, error) {
+	form := url.Values{}
+	form.Set("client_id", c.clientID)
+	form.Set("client_secret", c.clientSecret)
+	form.Set("code", code)
+	form.Set("redirect_uri", redirectURI)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.oauthURL+"/access_token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var token struct {
+		AccessToken      string `json:"access_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := c.do(req, &token); err != nil {
+		return "", apperr.Errorf("failed to exchange GitHub code: %w", err)
+	}
+	// GitHub reports a bad or expired code with a 200 and an error field
+	if token.AccessToken == "" {
+		return "", fmt.Errorf("GitHub rejected the authorization code: %s", token.ErrorDescription)
+	}
+
+	return token.AccessToken, nil
+}
+
+// User is the subset of a GitHub user the app records
+type User struct {
+	Login string `json:"login"`
+}
+
+// GetUser returns the account the token belongs to
+func (c *Client) GetUser(ctx context.Context, token string) (*User, error) {
+	var user User
+	if err := c.api(ctx, token, http.MethodGet, "/user", nil, &user); err != nil {
+		return nil, apperr.Errorf("failed to get GitHub user: %w", err)
+	}
+	return &user, nil
+}
+
+// Repository is the subset of a GitHub repository the app checks before committing to it
+type Repository struct {
+	FullName      string `json:"full_name"`
+	DefaultBranch string `json:"default_branch"`
+	Permissions   struct {
+		Push bool `json:"push"`
+	} `json:"permissions"`
+}
+
+// GetRepository returns a repository as seen by the token's user
+func (c *Client) GetRepository(ctx context.Context, token, owner, name string) (*Repository, error) {
+	var repo Repository
+	path := "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(name)
+	if err := c.api(ctx, token, http.MethodGet, path, nil, &repo); err != nil {
+		return nil, err
+	}
+	return &repo, nil
+}
+
+// PutFile creates or replaces a file on a branch in one commit and returns the commit's SHA
+func (c *Client) PutFile(ctx context.Context, token, owner, name, branch, path, message string, content []byte) (string, error) {
+	endpoint := "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(name) + "/contents/" + escapePath(path)
+
+	// Replacing a file requires the SHA of the blob being replaced
+	var existing struct {
+		SHA string `json:"sha"`
+	}
+	err := c.api(ctx, token, http.MethodGet, endpoint+"?ref="+url.QueryEscape(branch), nil, &existing)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return "", err
+	}
+
+	body := map[string]string{
+		"message": message,
+		"content": base64.StdEncoding.EncodeToString(content),
+		"branch":  branch,
+	}
+	if existing.SHA != "" {
+		body["sha"] = existing.SHA
+	}
+
+	var result struct {
+		Commit struct {
+			SHA string `json:"sha"`
+		} `json:"commit"`
+	}
+	if err := c.api(ctx, token, http.MethodPut, endpoint, body, &result); err != nil {
+		return "", err
+	}
+
+	return result.Commit.SHA, nil
+}
+
+// api sends a JSON request to the REST API with the user's token
+func (c *Client) api(ctx context.Context, token, method, path string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		encoded, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.apiURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return c.do(req, out)
+}
+
+// do sends a request and decodes the JSON response into out
+func (c *Client) do(req *http.Request, out interface{}) error {
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	if resp.StatusCode >= 300 {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(body, &apiErr) == nil && apiErr.Message != "" {
+			return fmt.Errorf("github returned %d: %s", resp.StatusCode, apiErr.Message)
+		}
+		return fmt.Errorf("github returned %d", resp.StatusCode)
+	}
+
+	return json.Unmarshal(body, out)
+}
+
+// escapePath escapes each segment of a repository file path
+func escapePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,65 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPutFile(t *testing.T) {
+	files := map[string]string{"/repos/octo/solutions/contents/dsa/arrays/two-sum.py": "blob1"}
+	var lastBody map[string]string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			if r.URL.Query().Get("ref") != "main" {
+				t.Errorf("Expected the file to be looked up on main, got %q", r.URL.Query().Get("ref"))
+			}
+			sha, ok := files[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"message":"Not Found"}`))
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]string{"sha": sha})
+		case http.MethodPut:
+			lastBody = nil
+			json.NewDecoder(r.Body).Decode(&lastBody)
+			w.Write([]byte(`{"commit":{"sha":"abc123"}}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("id", "secret")
+	client.apiURL = server.URL
+
+	// Replacing an existing file sends its blob SHA
+	sha, err := client.PutFile(context.Background(), "token", "octo", "solutions", "main", "dsa/arrays/two-sum.py", "Add solution", []byte("print(1)"))
+	if err != nil || sha != "abc123" {
+		t.Fatalf("Expected commit abc123, got %q (%v)", sha, err)
+	}
+	if lastBody["sha"] != "blob1" || lastBody["branch"] != "main" || lastBody["content"] != base64.StdEncoding.EncodeToString([]byte("print(1)")) {
+		t.Errorf("Unexpected update body %v", lastBody)
+	}
+
+	// Creating a new file doesn't
+	if _, err := client.PutFile(context.Background(), "token", "octo", "solutions", "main", "dsa/arrays/three-sum.py", "Add solution", []byte("print(2)")); err != nil {
+		t.Fatalf("PutFile returned error: %v", err)
+	}
+	if _, ok := lastBody["sha"]; ok {
+		t.Errorf("Expected no blob SHA when creating a file, got %v", lastBody)
+	}
+
+	if _, err := client.PutFile(context.Background(), "revoked", "octo", "solutions", "main", "a.py", "Add solution", nil); err == nil {
+		t.Error("Expected an error for a rejected token")
+	}
+}
@@ -3,21 +3,25 @@ package handlers
 import (
 	"interview-prep-app/internal/models"
 	"interview-prep-app/internal/services"
+	"interview-prep-app/pkg/apperr"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
 
 // AdminHandler handles admin-only operations
 type AdminHandler struct {
-	userService *services.UserService
+	userService   *services.UserService
+	searchService *services.AdminSearchService
 }
 
 // NewAdminHandler creates a new AdminHandler
-func NewAdminHandler(userService *services.UserService) *AdminHandler {
+func NewAdminHandler(userService *services.UserService, searchService *services.AdminSearchService) *AdminHandler {
 	return &AdminHandler{
-		userService: userService,
+		userService:   userService,
+		searchService: searchService,
 	}
 }
 
@@ -31,38 +35,105 @@ func (h *AdminHandler) GetAllUsers(c *gin.Context) {
 	})
 }
 
-// UpdateUserRole updates a user's role (admin only)
+// UpdateUserRole handles PUT /admin/users/:id/role - Assigns a user a role. Requires users:manage.
+// The user's new permissions apply once their access token is renewed.
 func (h *AdminHandler) UpdateUserRole(c *gin.Context) {
-	userIDStr := c.Param("id")
-	userID, err := strconv.Atoi(userIDStr)
+	userID, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		c.Error(apperr.Validation("Invalid user ID"))
 		return
 	}
 
-	var req struct {
-		Role models.Role `json:"role" binding:"required"`
+	var req models.UpdateUserRoleRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
 	}
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+	user, err := h.userService.SetUserRole(c.GetInt("userID"), userID, &req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
 		return
 	}
 
-	// Validate role
-	if req.Role != models.RoleUser && req.Role != models.RoleAdmin {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role. Must be 'user' or 'admin'"})
+	c.JSON(http.StatusOK, user)
+}
+
+// ListRoles handles GET /admin/roles - Lists every role with its permissions. Requires users:manage.
+func (h *AdminHandler) ListRoles(c *gin.Context) {
+	roles, err := h.userService.ListRoles()
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
-	// For demonstration purposes, just return success
-	// In a real implementation, you'd add a method to update user role
-	c.JSON(http.StatusOK, gin.H{
-		"message":    "User role would be updated",
-		"user_id":    userID,
-		"new_role":   req.Role,
-		"admin_user": c.GetString("userRole"),
-	})
+	c.JSON(http.StatusOK, gin.H{"roles": roles, "permissions": models.ValidPermissions()})
+}
+
+// SaveRole handles PUT /admin/roles/:name - Creates a role or changes its description or
+// permissions. Requires users:manage.
+func (h *AdminHandler) SaveRole(c *gin.Context) {
+	var req models.SaveRoleRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	role, err := h.userService.SaveRole(models.Role(c.Param("name")), &req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, role)
+}
+
+// DeleteRole handles DELETE /admin/roles/:name - Removes a role nobody holds. Requires users:manage.
+func (h *AdminHandler) DeleteRole(c *gin.Context) {
+	if err := h.userService.DeleteRole(models.Role(c.Param("name"))); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Role deleted"})
+}
+
+// Search handles GET /admin/search?q= - Finds items, users, engineering blogs and feedback at
+// once. Query: types (comma-separated, default all) and limit (per type). Each type needs the
+// permission that guards it: content:write for items and blogs, users:manage for users and
+// feedback:moderate for feedback.
+func (h *AdminHandler) Search(c *gin.Context) {
+	var types []models.AdminSearchType
+	if typesStr := c.Query("types"); typesStr != "" {
+		for _, searchType := range strings.Split(typesStr, ",") {
+			types = append(types, models.AdminSearchType(strings.TrimSpace(searchType)))
+		}
+	}
+
+	limit := 0
+	if limitStr := c.Query("limit"); limitStr != "" {
+		var err error
+		if limit, err = strconv.Atoi(limitStr); err != nil {
+			c.Error(apperr.Validation("Invalid limit parameter"))
+			return
+		}
+	}
+
+	allowed := func(permission models.Permission) bool {
+		return requirePermission(c, h.userService, permission) == nil
+	}
+	results, err := h.searchService.Search(c.Query("q"), types, limit, allowed)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
+
+// RequirePermission checks that the current user's role grants a permission (used by middleware)
+func (h *AdminHandler) RequirePermission(c *gin.Context, permission models.Permission) error {
+	return requirePermission(c, h.userService, permission)
 }
 
 // GetAdminStats returns admin-specific statistics
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"net/http"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/services"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AIHandler handles HTTP requests for AI features and their usage budgets
+type AIHandler struct {
+	budgetService   *services.AIBudgetService
+	questionService *services.AIQuestionService
+	summaryService  *services.AISummaryService
+}
+
+// NewAIHandler creates a new AI handler
+func NewAIHandler(budgetService *services.AIBudgetService, questionService *services.AIQuestionService, summaryService *services.AISummaryService) *AIHandler {
+	return &AIHandler{
+		budgetService:   budgetService,
+		questionService: questionService,
+		summaryService:  summaryService,
+	}
+}
+
+// GetUsage handles GET /user/ai-usage
+func (h *AIHandler) GetUsage(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	usage, err := h.budgetService.GetUsage(userID.(int))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, usage)
+}
+
+// GenerateQuestion handles POST /ai/generate-question - Returns follow-up questions or variations
+// of an item, reusing stored ones unless refresh is set
+func (h *AIHandler) GenerateQuestion(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	var req models.GenerateQuestionRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	questions, err := h.questionService.Generate(c.Request.Context(), userID.(int), &req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, questions)
+}
+
+// Summarize handles POST /ai/summarize - Condenses the user's notes in a category into a revision
+// sheet, saved as a document
+func (h *AIHandler) Summarize(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	var req models.SummarizeNotesRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	document, err := h.summaryService.Summarize(c.Request.Context(), userID.(int), &req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, document)
+}
+
+// GetSettings handles GET /user/ai-settings
+func (h *AIHandler) GetSettings(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	settings, err := h.summaryService.GetSettings(userID.(int))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// UpdateSettings handles PUT /user/ai-settings - Opts in to or out of AI features reading the
+// user's notes
+func (h *AIHandler) UpdateSettings(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	var req models.UpdateAISettingsRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	settings, err := h.summaryService.UpdateSettings(userID.(int), &req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/services"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyHandler handles HTTP requests for personal API keys
+type APIKeyHandler struct {
+	apiKeyService *services.APIKeyService
+}
+
+// NewAPIKeyHandler creates a new API key handler
+func NewAPIKeyHandler(apiKeyService *services.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService: apiKeyService,
+	}
+}
+
+// GetAPIKeys handles GET /user/api-keys
+func (h *APIKeyHandler) GetAPIKeys(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	keys, err := h.apiKeyService.GetAPIKeys(userID.(int))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"api_keys": keys})
+}
+
+// CreateAPIKey handles POST /user/api-keys - the key is only in this response
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	// A leaked key mustn't be able to mint more keys that outlive its revocation
+	if _, viaKey := c.Get("apiKeyID"); viaKey {
+		c.Error(apperr.Forbidden("API keys can't create API keys; sign in to create one"))
+		return
+	}
+
+	var req models.CreateAPIKeyRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	response, err := h.apiKeyService.CreateAPIKey(userID.(int), &req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// RevokeAPIKey handles DELETE /user/api-keys/:id
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid API key ID"))
+		return
+	}
+
+	if err := h.apiKeyService.RevokeAPIKey(userID.(int), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "API key revoked successfully"})
+}
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/services"
+	"interview-prep-app/internal/storage"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AttachmentHandler handles HTTP requests for uploaded file and encrypted attachments
+type AttachmentHandler struct {
+	attachmentService *services.AttachmentService
+	storage           storage.Storage
+}
+
+// NewAttachmentHandler creates a new attachment handler
+func NewAttachmentHandler(attachmentService *services.AttachmentService, store storage.Storage) *AttachmentHandler {
+	return &AttachmentHandler{
+		attachmentService: attachmentService,
+		storage:           store,
+	}
+}
+
+// UploadAttachment handles POST /items/:id/attachments/upload
+func (h *AttachmentHandler) UploadAttachment(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.Error(apperr.Validation("Invalid item ID"))
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.Error(apperr.Validation("A file must be provided in the 'file' form field"))
+		return
+	}
+
+	attachment, err := h.attachmentService.Upload(c.Request.Context(), userID.(int), id, fileHeader)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, attachment)
+}
+
+// CreateSecretAttachment handles POST /items/:id/attachments/secret
+func (h *AttachmentHandler) CreateSecretAttachment(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.Error(apperr.Validation("Invalid item ID"))
+		return
+	}
+
+	var req models.CreateSecretAttachmentRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	attachment, err := h.attachmentService.CreateSecret(userID.(int), id, &req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, attachment)
+}
+
+// GetAttachments handles GET /items/:id/attachments
+func (h *AttachmentHandler) GetAttachments(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.Error(apperr.Validation("Invalid item ID"))
+		return
+	}
+
+	attachments, err := h.attachmentService.GetItemAttachments(userID.(int), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"attachments": attachments})
+}
+
+// DeleteAttachment handles DELETE /items/:id/attachments/:attachment_id
+func (h *AttachmentHandler) DeleteAttachment(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	attachmentID, err := strconv.Atoi(c.Param("attachment_id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid attachment ID"))
+		return
+	}
+
+	err = h.attachmentService.DeleteAttachment(c.Request.Context(), userID.(int), attachmentID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Attachment deleted successfully"})
+}
+
+// DownloadAttachment handles GET /api/v1/attachments/download for the local-disk backend.
+// Access is granted by the signed URL rather than a bearer token.
+func (h *AttachmentHandler) DownloadAttachment(c *gin.Context) {
+	local, ok := h.storage.(*storage.LocalStorage)
+	if !ok {
+		c.Error(apperr.NotFound("Downloads are served by the object store"))
+		return
+	}
+
+	key := c.Query("key")
+	if err := local.VerifySignature(key, c.Query("expires"), c.Query("signature")); err != nil {
+		c.Error(apperr.Wrap(apperr.KindForbidden, err))
+		return
+	}
+
+	file, err := local.Open(key)
+	if err != nil {
+		c.Error(apperr.NotFound("File not found"))
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		c.Error(apperr.Internal("Failed to read file"))
+		return
+	}
+
+	http.ServeContent(c.Writer, c.Request, info.Name(), info.ModTime(), file)
+}
@@ -1,44 +1,47 @@
 package handlers
 
 import (
+	"errors"
 	"interview-prep-app/internal/config"
 	"interview-prep-app/internal/models"
 	"interview-prep-app/internal/services"
+	"interview-prep-app/internal/tokens"
+	"interview-prep-app/pkg/apperr"
 	"log"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v4"
 )
 
 // AuthHandler handles authentication requests
 type AuthHandler struct {
-	config      *config.Config
-	userService *services.UserService
+	config         *config.Config
+	userService    *services.UserService
+	sessionService *services.SessionService
+	apiKeyService  *services.APIKeyService
+	tokens         *tokens.Issuer
 }
 
 // NewAuthHandler creates a new AuthHandler
-func NewAuthHandler(cfg *config.Config, userService *services.UserService) *AuthHandler {
+func NewAuthHandler(cfg *config.Config, userService *services.UserService, sessionService *services.SessionService, apiKeyService *services.APIKeyService, tokenIssuer *tokens.Issuer) *AuthHandler {
 	return &AuthHandler{
-		config:      cfg,
-		userService: userService,
+		config:         cfg,
+		userService:    userService,
+		sessionService: sessionService,
+		apiKeyService:  apiKeyService,
+		tokens:         tokenIssuer,
 	}
 }
 
 // Claims represents the JWT claims
-type Claims struct {
-	UserID   int    `json:"user_id"`
-	Email    string `json:"email"`
-	Username string `json:"username"` // Keep for backward compatibility
-	jwt.RegisteredClaims
-}
+type Claims = tokens.Claims
 
 // Register handles user registration
 func (h *AuthHandler) Register(c *gin.Context) {
 	var req models.CreateUserRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
 		return
 	}
 
@@ -50,20 +53,20 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	// Register user
 	user, err := h.userService.RegisterWithEmail(&req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(apperr.Classify(apperr.KindValidation, err))
 		return
 	}
 
 	// Generate tokens
-	token, err := h.generateToken(user.ID, user.Email)
+	token, expiresAt, err := h.tokens.Issue(user)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+		c.Error(apperr.Internal("Failed to generate token"))
 		return
 	}
 
-	refreshToken, err := h.userService.GenerateRefreshToken()
+	refreshToken, err := h.sessionService.StartSession(user, sessionMetadata(c))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate refresh token"})
+		c.Error(apperr.Internal("Failed to generate refresh token"))
 		return
 	}
 
@@ -71,15 +74,15 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		Token:        token,
 		RefreshToken: refreshToken,
 		User:         user,
-		ExpiresAt:    time.Now().Add(24 * time.Hour),
+		ExpiresAt:    expiresAt,
 	})
 }
 
 // Login handles user authentication
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req models.LoginRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
 		return
 	}
 
@@ -88,20 +91,20 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	// Authenticate user
 	user, err := h.userService.LoginWithEmail(req.Email, req.Password)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		c.Error(apperr.Wrap(apperr.KindUnauthorized, err))
 		return
 	}
 
 	// Generate tokens
-	token, err := h.generateToken(user.ID, user.Email)
+	token, expiresAt, err := h.tokens.Issue(user)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+		c.Error(apperr.Internal("Failed to generate token"))
 		return
 	}
 
-	refreshToken, err := h.userService.GenerateRefreshToken()
+	refreshToken, err := h.sessionService.StartSession(user, sessionMetadata(c))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate refresh token"})
+		c.Error(apperr.Internal("Failed to generate refresh token"))
 		return
 	}
 
@@ -109,15 +112,15 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		Token:        token,
 		RefreshToken: refreshToken,
 		User:         user,
-		ExpiresAt:    time.Now().Add(24 * time.Hour),
+		ExpiresAt:    expiresAt,
 	})
 }
 
 // OAuthLogin handles OAuth authentication
 func (h *AuthHandler) OAuthLogin(c *gin.Context) {
 	var req models.OAuthLoginRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
 		return
 	}
 
@@ -126,20 +129,20 @@ func (h *AuthHandler) OAuthLogin(c *gin.Context) {
 	// Authenticate user with OAuth
 	user, err := h.userService.LoginWithOAuth(&req)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		c.Error(apperr.Wrap(apperr.KindUnauthorized, err))
 		return
 	}
 
 	// Generate tokens
-	token, err := h.generateToken(user.ID, user.Email)
+	token, expiresAt, err := h.tokens.Issue(user)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+		c.Error(apperr.Internal("Failed to generate token"))
 		return
 	}
 
-	refreshToken, err := h.userService.GenerateRefreshToken()
+	refreshToken, err := h.sessionService.StartSession(user, sessionMetadata(c))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate refresh token"})
+		c.Error(apperr.Internal("Failed to generate refresh token"))
 		return
 	}
 
@@ -147,7 +150,7 @@ func (h *AuthHandler) OAuthLogin(c *gin.Context) {
 		Token:        token,
 		RefreshToken: refreshToken,
 		User:         user,
-		ExpiresAt:    time.Now().Add(24 * time.Hour),
+		ExpiresAt:    expiresAt,
 	})
 }
 
@@ -155,13 +158,13 @@ func (h *AuthHandler) OAuthLogin(c *gin.Context) {
 func (h *AuthHandler) GetCurrentUser(c *gin.Context) {
 	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		c.Error(apperr.Unauthorized("User not authenticated"))
 		return
 	}
 
-	user, err := h.userService.GetByID(userID.(int))
+	user, err := CurrentUser(c, h.userService, userID.(int))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		c.Error(apperr.NotFound("User not found"))
 		return
 	}
 
@@ -172,56 +175,98 @@ func (h *AuthHandler) GetCurrentUser(c *gin.Context) {
 func (h *AuthHandler) UpdateProfile(c *gin.Context) {
 	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		c.Error(apperr.Unauthorized("User not authenticated"))
 		return
 	}
 
 	var req models.UpdateUserRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
 		return
 	}
 
 	user, err := h.userService.UpdateUser(userID.(int), &req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(apperr.Classify(apperr.KindValidation, err))
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"user": user})
 }
 
-// generateToken creates a new JWT token
-func (h *AuthHandler) generateToken(userID int, email string) (string, error) {
-	expirationTime := time.Now().Add(24 * time.Hour) // Token expires in 24 hours
-	claims := &Claims{
-		UserID:   userID,
-		Email:    email,
-		Username: email, // For backward compatibility
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(expirationTime),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-		},
-	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(h.config.JWTSecret))
-}
-
 // ValidateToken validates a JWT token and returns the claims
 func (h *AuthHandler) ValidateToken(tokenString string) (*Claims, error) {
-	claims := &Claims{}
-	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		return []byte(h.config.JWTSecret), nil
-	})
-
+	claims, err := h.tokens.Verify(tokenString)
 	if err != nil {
 		return nil, err
 	}
 
-	if !token.Valid {
-		return nil, jwt.ErrSignatureInvalid
+	// Tokens issued before the user signed out every session are no longer accepted, and the
+	// user's current role stands in for the one the token was issued with
+	var issuedAt *time.Time
+	if claims.IssuedAt != nil {
+		issuedAt = &claims.IssuedAt.Time
+	}
+	role, revoked, err := h.sessionService.CheckSession(claims.UserID, issuedAt)
+	if err != nil {
+		return nil, err
 	}
+	if revoked {
+		return nil, errors.New("session revoked")
+	}
+	claims.Role = role
 
 	return claims, nil
 }
+
+// AuthenticateAPIKey resolves a personal API key to its key and user (used by middleware)
+func (h *AuthHandler) AuthenticateAPIKey(key string) (*models.APIKey, *models.User, error) {
+	return h.apiKeyService.Authenticate(key)
+}
+
+// GetJWKS handles GET /.well-known/jwks.json - the public keys other services verify access
+// tokens with. The set is empty unless tokens are signed with RS256 keys.
+func (h *AuthHandler) GetJWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=3600")
+	c.JSON(http.StatusOK, h.tokens.JWKS())
+}
+
+// DenyLogin handles POST /auth/login-alerts/:token/deny - the "this wasn't me" link of a
+// new-login alert. Every session is signed out and email accounts must choose a new password.
+func (h *AuthHandler) DenyLogin(c *gin.Context) {
+	response, err := h.sessionService.DenyLogin(c.Param("token"))
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			c.Error(apperr.NotFound("Login alert not found or already handled"))
+			return
+		}
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ResetPassword handles POST /auth/password/reset
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var req models.ResetPasswordRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	if err := h.sessionService.ResetPassword(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Password reset, please log in again"})
+}
+
+// sessionMetadata describes the device and address a request came from
+func sessionMetadata(c *gin.Context) models.SessionMetadata {
+	return models.SessionMetadata{
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+}
@@ -0,0 +1,297 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/services"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BehavioralHandler handles HTTP requests for the behavioral question bank and STAR answer drafts
+type BehavioralHandler struct {
+	behavioralService *services.BehavioralService
+	userService       *services.UserService
+}
+
+// NewBehavioralHandler creates a new behavioral handler
+func NewBehavioralHandler(behavioralService *services.BehavioralService, userService *services.UserService) *BehavioralHandler {
+	return &BehavioralHandler{
+		behavioralService: behavioralService,
+		userService:       userService,
+	}
+}
+
+// GetCompetencies handles GET /behavioral/competencies
+func (h *BehavioralHandler) GetCompetencies(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"competencies": models.ValidCompetencies()})
+}
+
+// GetQuestions handles GET /behavioral/questions - Returns a page of the question bank with the
+// user's drafts. Query: competency, answered=true for drafted questions only, limit and offset.
+func (h *BehavioralHandler) GetQuestions(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	filter := &models.BehavioralQuestionFilter{Competency: competencyQuery(c)}
+
+	if answeredStr := c.Query("answered"); answeredStr != "" {
+		answered, err := strconv.ParseBool(answeredStr)
+		if err != nil {
+			c.Error(apperr.Validation("Invalid answered parameter"))
+			return
+		}
+		filter.AnsweredOnly = answered
+	}
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil {
+			c.Error(apperr.Validation("Invalid limit parameter"))
+			return
+		}
+		filter.Limit = limit
+	}
+
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		offset, err := strconv.Atoi(offsetStr)
+		if err != nil {
+			c.Error(apperr.Validation("Invalid offset parameter"))
+			return
+		}
+		filter.Offset = offset
+	}
+
+	response, err := h.behavioralService.GetQuestions(userID.(int), filter)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetQuestion handles GET /behavioral/questions/:id
+func (h *BehavioralHandler) GetQuestion(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	id, ok := questionIDParam(c)
+	if !ok {
+		return
+	}
+
+	question, err := h.behavioralService.GetQuestion(userID.(int), id)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, question)
+}
+
+// GetPractice handles GET /behavioral/practice - Serves a random practice round, least practiced
+// questions first. Query: competency and count.
+func (h *BehavioralHandler) GetPractice(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	count := 0
+	if countStr := c.Query("count"); countStr != "" {
+		var err error
+		if count, err = strconv.Atoi(countStr); err != nil {
+			c.Error(apperr.Validation("Invalid count parameter"))
+			return
+		}
+	}
+
+	practice, err := h.behavioralService.GetPracticeQuestions(userID.(int), competencyQuery(c), count)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, practice)
+}
+
+// RecordPractice handles POST /behavioral/questions/:id/practice - Records that the user practiced answering a question
+func (h *BehavioralHandler) RecordPractice(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	id, ok := questionIDParam(c)
+	if !ok {
+		return
+	}
+
+	question, err := h.behavioralService.RecordPractice(userID.(int), id, time.Now())
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, question)
+}
+
+// SaveAnswer handles PUT /behavioral/questions/:id/answer - Saves the user's STAR draft
+func (h *BehavioralHandler) SaveAnswer(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	id, ok := questionIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req models.SaveBehavioralAnswerRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	question, err := h.behavioralService.SaveAnswer(userID.(int), id, &req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, question)
+}
+
+// DeleteAnswer handles DELETE /behavioral/questions/:id/answer
+func (h *BehavioralHandler) DeleteAnswer(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	id, ok := questionIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.behavioralService.DeleteAnswer(userID.(int), id); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Answer deleted successfully"})
+}
+
+// CreateQuestion handles POST /behavioral/questions - Requires content:write
+func (h *BehavioralHandler) CreateQuestion(c *gin.Context) {
+	if err := requirePermission(c, h.userService, models.PermissionContentWrite); err != nil {
+		c.Error(apperr.Forbidden("content:write permission required to manage behavioral questions"))
+		return
+	}
+
+	var req models.CreateBehavioralQuestionRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	question, err := h.behavioralService.CreateQuestion(&req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, question)
+}
+
+// UpdateQuestion handles PUT /behavioral/questions/:id - Requires content:write
+func (h *BehavioralHandler) UpdateQuestion(c *gin.Context) {
+	if err := requirePermission(c, h.userService, models.PermissionContentWrite); err != nil {
+		c.Error(apperr.Forbidden("content:write permission required to manage behavioral questions"))
+		return
+	}
+
+	id, ok := questionIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateBehavioralQuestionRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	question, err := h.behavioralService.UpdateQuestion(id, &req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, question)
+}
+
+// DeleteQuestion handles DELETE /behavioral/questions/:id - Requires content:write. Users' drafts for the
+// question are deleted with it.
+func (h *BehavioralHandler) DeleteQuestion(c *gin.Context) {
+	if err := requirePermission(c, h.userService, models.PermissionContentWrite); err != nil {
+		c.Error(apperr.Forbidden("content:write permission required to manage behavioral questions"))
+		return
+	}
+
+	id, ok := questionIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.behavioralService.DeleteQuestion(id); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Behavioral question deleted successfully"})
+}
+
+// competencyQuery reads the optional competency query parameter
+func competencyQuery(c *gin.Context) *models.Competency {
+	competencyStr := c.Query("competency")
+	if competencyStr == "" {
+		return nil
+	}
+
+	competency := models.Competency(competencyStr)
+	return &competency
+}
+
+// questionIDParam parses the :id path parameter, responding with 400 when it isn't a number
+func questionIDParam(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid question ID"))
+		return 0, false
+	}
+
+	return id, true
+}
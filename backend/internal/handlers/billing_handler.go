@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"interview-prep-app/internal/billing"
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/services"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxStripeWebhookBytes caps the size of an incoming Stripe webhook payload
+const maxStripeWebhookBytes = 1 << 20
+
+// BillingHandler handles HTTP requests for plans, Stripe checkout and Stripe webhooks
+type BillingHandler struct {
+	billingService *services.BillingService
+	userService    *services.UserService
+}
+
+// NewBillingHandler creates a new billing handler
+func NewBillingHandler(billingService *services.BillingService, userService *services.UserService) *BillingHandler {
+	return &BillingHandler{
+		billingService: billingService,
+		userService:    userService,
+	}
+}
+
+// GetSubscription handles GET /billing/subscription
+func (h *BillingHandler) GetSubscription(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	entitlements, err := h.billingService.GetEntitlements(userID.(int))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"billing_enabled": h.billingService.Enabled(), "entitlements": entitlements})
+}
+
+// StartTrial handles POST /billing/trial. Puts the user on a free pro trial.
+func (h *BillingHandler) StartTrial(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	entitlements, err := h.billingService.StartTrial(userID.(int))
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindUpstream, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"billing_enabled": true, "entitlements": entitlements})
+}
+
+// CreateCheckout handles POST /billing/checkout. Returns the Stripe Checkout URL for the pro plan.
+func (h *BillingHandler) CreateCheckout(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	url, err := h.billingService.CreateCheckoutURL(c.Request.Context(), userID.(int))
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindUpstream, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BillingURLResponse{URL: url})
+}
+
+// CreatePortal handles POST /billing/portal. Returns the Stripe customer portal URL.
+func (h *BillingHandler) CreatePortal(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	url, err := h.billingService.CreatePortalURL(c.Request.Context(), userID.(int))
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindUpstream, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BillingURLResponse{URL: url})
+}
+
+// StripeWebhook handles POST /billing/stripe/webhook (public, authorized by the Stripe signature)
+func (h *BillingHandler) StripeWebhook(c *gin.Context) {
+	payload, err := io.ReadAll(io.LimitReader(c.Request.Body, maxStripeWebhookBytes))
+	if err != nil {
+		c.Error(apperr.Validation("Failed to read payload"))
+		return
+	}
+
+	if err := h.billingService.HandleStripeWebhook(payload, c.GetHeader("Stripe-Signature")); err != nil {
+		switch {
+		case err == billing.ErrInvalidSignature:
+			c.Error(apperr.Classify(apperr.KindValidation, err))
+		case errors.Is(err, apperr.ErrNotFound):
+			c.Error(err)
+		default:
+			// Stripe retries on errors, so transient failures are picked up again later
+			c.Error(err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"received": true})
+}
+
+// SetUserPlan handles PUT /admin/users/:id/plan - Requires users:manage. Grants or revokes the pro plan outside of Stripe.
+func (h *BillingHandler) SetUserPlan(c *gin.Context) {
+	if err := requirePermission(c, h.userService, models.PermissionUsersManage); err != nil {
+		c.Error(apperr.Forbidden("users:manage permission required to manage plans"))
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid user ID"))
+		return
+	}
+
+	var req models.SetPlanRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	entitlements, err := h.billingService.SetPlan(id, req.Plan)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, entitlements)
+}
@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"interview-prep-app/internal/validation"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// bindJSON decodes a JSON request body into obj and validates its binding tags, like
+// c.ShouldBindJSON, but rejects keys obj doesn't declare so typos such as "subcatagory"
+// fail loudly instead of being silently dropped. All unknown top-level keys are listed.
+// Values of the wrong type and broken binding rules are reported per field.
+func bindJSON(c *gin.Context, obj interface{}) error {
+	if c.Request.Body == nil {
+		return fmt.Errorf("request body is required")
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return apperr.Errorf("failed to read request body: %w", err)
+	}
+
+	if unknown := unknownJSONFields(body, obj); len(unknown) > 0 {
+		return fmt.Errorf("unknown %s: %s", pluralizeField(len(unknown)), strings.Join(unknown, ", "))
+	}
+
+	// Catches unknown keys in nested objects, which the listing above doesn't descend into
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(obj); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) && typeErr.Field != "" {
+			return validation.Field(typeErr.Field, "type", fmt.Sprintf("%s must be a %s", typeErr.Field, jsonTypeName(typeErr.Type)))
+		}
+		return err
+	}
+
+	return validation.Struct(obj)
+}
+
+// unknownJSONFields returns the top-level keys of body that obj's struct has no field for,
+// each quoted and with a suggestion when a known field is a likely typo
+func unknownJSONFields(body []byte, obj interface{}) []string {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		// Not an object; the decoder reports a clearer error
+		return nil
+	}
+
+	t := reflect.TypeOf(obj)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	known := jsonFieldNames(t)
+	var unknown []string
+	for key := range fields {
+		if containsFold(known, key) {
+			continue
+		}
+		message := fmt.Sprintf("%q", key)
+		if suggestion := closestField(key, known); suggestion != "" {
+			message += fmt.Sprintf(" (did you mean %q?)", suggestion)
+		}
+		unknown = append(unknown, message)
+	}
+
+	sort.Strings(unknown)
+	return unknown
+}
+
+// jsonFieldNames lists the JSON keys encoding/json accepts for a struct type, including promoted fields
+func jsonFieldNames(t reflect.Type) []string {
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name := strings.Split(tag, ",")[0]
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				names = append(names, jsonFieldNames(embedded)...)
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// containsFold reports whether key matches a name the way encoding/json does (case-insensitively)
+func containsFold(names []string, key string) bool {
+	for _, name := range names {
+		if strings.EqualFold(name, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// closestField suggests the known field within two edits of key, if any
+func closestField(key string, known []string) string {
+	best, bestDistance := "", 3
+	for _, name := range known {
+		if d := editDistance(strings.ToLower(key), strings.ToLower(name)); d < bestDistance {
+			best, bestDistance = name, d
+		}
+	}
+	return best
+}
+
+// editDistance is the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr := make([]int, len(b)+1)
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev = curr
+	}
+
+	return prev[len(b)]
+}
+
+// jsonTypeName words the JSON type a Go type is decoded from
+func jsonTypeName(t reflect.Type) string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "whole number"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "list"
+	default:
+		return "object"
+	}
+}
+
+func pluralizeField(n int) string {
+	if n == 1 {
+		return "field"
+	}
+	return "fields"
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"interview-prep-app/internal/services"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CalendarHandler handles HTTP requests for the ICS calendar feed
+type CalendarHandler struct {
+	calendarService *services.CalendarService
+}
+
+// NewCalendarHandler creates a new calendar handler
+func NewCalendarHandler(calendarService *services.CalendarService) *CalendarHandler {
+	return &CalendarHandler{
+		calendarService: calendarService,
+	}
+}
+
+// GetFeed handles GET /user/calendar
+func (h *CalendarHandler) GetFeed(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	feed, err := h.calendarService.GetFeed(userID.(int))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, feed)
+}
+
+// CreateFeed handles POST /user/calendar, issuing a new feed URL and revoking the old one
+func (h *CalendarHandler) CreateFeed(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	feed, err := h.calendarService.CreateFeed(userID.(int))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, feed)
+}
+
+// DeleteFeed handles DELETE /user/calendar
+func (h *CalendarHandler) DeleteFeed(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	if err := h.calendarService.DeleteFeed(userID.(int)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Calendar feed revoked"})
+}
+
+// ServeFeed handles GET /api/v1/calendar.ics?token=... (public, authorized by the feed token)
+func (h *CalendarHandler) ServeFeed(c *gin.Context) {
+	body, err := h.calendarService.RenderFeed(c.Query("token"), time.Now())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("Cache-Control", "private, max-age=900")
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", body)
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/services"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CatalogHandler handles HTTP requests for copying the content catalog between environments
+type CatalogHandler struct {
+	catalogService *services.CatalogService
+	userService    *services.UserService
+}
+
+// NewCatalogHandler creates a new catalog handler
+func NewCatalogHandler(catalogService *services.CatalogService, userService *services.UserService) *CatalogHandler {
+	return &CatalogHandler{
+		catalogService: catalogService,
+		userService:    userService,
+	}
+}
+
+// ExportCatalog handles GET /admin/catalog/export - Requires content:write.
+// Downloads the catalog as a JSON snapshot.
+func (h *CatalogHandler) ExportCatalog(c *gin.Context) {
+	if err := requirePermission(c, h.userService, models.PermissionContentWrite); err != nil {
+		c.Error(apperr.Forbidden("content:write permission required to export the catalog"))
+		return
+	}
+
+	snapshot, err := h.catalogService.Export()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	filename := fmt.Sprintf("prepmaster-catalog-%s.json", time.Now().UTC().Format("2006-01-02"))
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.JSON(http.StatusOK, snapshot)
+}
+
+// ApplyCatalog handles POST /admin/catalog/apply?dry_run=true - Requires content:write.
+// Applies a snapshot exported from another environment; a dry run only reports the changes.
+func (h *CatalogHandler) ApplyCatalog(c *gin.Context) {
+	if err := requirePermission(c, h.userService, models.PermissionContentWrite); err != nil {
+		c.Error(apperr.Forbidden("content:write permission required to apply a catalog"))
+		return
+	}
+
+	dryRun := false
+	if dryRunStr := c.Query("dry_run"); dryRunStr != "" {
+		var err error
+		dryRun, err = strconv.ParseBool(dryRunStr)
+		if err != nil {
+			c.Error(apperr.Validation("Invalid dry_run parameter"))
+			return
+		}
+	}
+
+	var snapshot models.CatalogSnapshot
+	if err := bindJSON(c, &snapshot); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	diff, err := h.catalogService.Apply(&snapshot, dryRun)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"net/http"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/services"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CategoryHandler handles HTTP requests for categories and their subcategory lists
+type CategoryHandler struct {
+	categoryService *services.CategoryService
+	userService     *services.UserService
+}
+
+// NewCategoryHandler creates a new category handler
+func NewCategoryHandler(categoryService *services.CategoryService, userService *services.UserService) *CategoryHandler {
+	return &CategoryHandler{
+		categoryService: categoryService,
+		userService:     userService,
+	}
+}
+
+// GetCategories handles GET /categories
+func (h *CategoryHandler) GetCategories(c *gin.Context) {
+	categories, err := h.categoryService.GetCategories()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, categories)
+}
+
+// CreateCategory handles POST /categories - Requires content:write
+func (h *CategoryHandler) CreateCategory(c *gin.Context) {
+	if err := requirePermission(c, h.userService, models.PermissionContentWrite); err != nil {
+		c.Error(apperr.Forbidden("content:write permission required to manage categories"))
+		return
+	}
+
+	var req models.CreateCategoryRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	category, err := h.categoryService.CreateCategory(&req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, category)
+}
+
+// UpdateCategory handles PUT /categories/:slug - Requires content:write
+func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
+	if err := requirePermission(c, h.userService, models.PermissionContentWrite); err != nil {
+		c.Error(apperr.Forbidden("content:write permission required to manage categories"))
+		return
+	}
+
+	var req models.UpdateCategoryRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	category, err := h.categoryService.UpdateCategory(models.Category(c.Param("slug")), &req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, category)
+}
+
+// DeleteCategory handles DELETE /categories/:slug - Requires content:write. Categories that still have
+// items can't be deleted; move the items first.
+func (h *CategoryHandler) DeleteCategory(c *gin.Context) {
+	if err := requirePermission(c, h.userService, models.PermissionContentWrite); err != nil {
+		c.Error(apperr.Forbidden("content:write permission required to manage categories"))
+		return
+	}
+
+	if err := h.categoryService.DeleteCategory(models.Category(c.Param("slug"))); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Category deleted successfully"})
+}
@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/services"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CompanyHandler handles HTTP requests for companies and company-tagged items
+type CompanyHandler struct {
+	companyService *services.CompanyService
+	userService    *services.UserService
+}
+
+// NewCompanyHandler creates a new company handler
+func NewCompanyHandler(companyService *services.CompanyService, userService *services.UserService) *CompanyHandler {
+	return &CompanyHandler{
+		companyService: companyService,
+		userService:    userService,
+	}
+}
+
+// GetCompanies handles GET /companies
+func (h *CompanyHandler) GetCompanies(c *gin.Context) {
+	companies, err := h.companyService.GetCompanies()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, companies)
+}
+
+// CreateCompany handles POST /companies - Requires content:write
+func (h *CompanyHandler) CreateCompany(c *gin.Context) {
+	if err := requirePermission(c, h.userService, models.PermissionContentWrite); err != nil {
+		c.Error(apperr.Forbidden("content:write permission required to manage companies"))
+		return
+	}
+
+	var req models.CreateCompanyRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	company, err := h.companyService.CreateCompany(&req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, company)
+}
+
+// DeleteCompany handles DELETE /companies/:slug - Requires content:write
+func (h *CompanyHandler) DeleteCompany(c *gin.Context) {
+	if err := requirePermission(c, h.userService, models.PermissionContentWrite); err != nil {
+		c.Error(apperr.Forbidden("content:write permission required to manage companies"))
+		return
+	}
+
+	if err := h.companyService.DeleteCompany(c.Param("slug")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Company deleted successfully"})
+}
+
+// GetItemCompanies handles GET /items/:id/companies
+func (h *CompanyHandler) GetItemCompanies(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid item ID"))
+		return
+	}
+
+	companies, err := h.companyService.GetItemCompanies(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, companies)
+}
+
+// SetItemCompanies handles PUT /items/:id/companies - Requires content:write. Replaces the item's company tags.
+func (h *CompanyHandler) SetItemCompanies(c *gin.Context) {
+	if err := requirePermission(c, h.userService, models.PermissionContentWrite); err != nil {
+		c.Error(apperr.Forbidden("content:write permission required to tag items"))
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid item ID"))
+		return
+	}
+
+	var req models.SetItemCompaniesRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	companies, err := h.companyService.SetItemCompanies(id, &req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, companies)
+}
+
+// GetCompanyStats handles GET /stats/companies - The user's progress per company
+func (h *CompanyHandler) GetCompanyStats(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	stats, err := h.companyService.GetCompanyStatsForUser(userID.(int))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"interview-prep-app/internal/config"
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/services"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ConfigHandler serves the configuration the frontend would otherwise hard-code
+type ConfigHandler struct {
+	client          *models.ClientConfig
+	categoryService *services.CategoryService
+}
+
+// NewConfigHandler creates a new config handler. Everything but the categories only changes with
+// the server's configuration, so it's collected once.
+func NewConfigHandler(cfg *config.Config, categoryService *services.CategoryService) *ConfigHandler {
+	return &ConfigHandler{
+		client:          clientConfig(cfg),
+		categoryService: categoryService,
+	}
+}
+
+// GetClientConfig handles GET /config/client
+func (h *ConfigHandler) GetClientConfig(c *gin.Context) {
+	categories, err := h.categoryService.GetCatalogCategories()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	client := *h.client
+	client.Categories = categories
+	body, err := json.Marshal(&client)
+	if err != nil {
+		c.Error(apperr.Internal("Failed to encode client config"))
+		return
+	}
+
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "public, max-age=300")
+
+	// Compressed responses carry the ETag weakened, and clients echo it that way
+	if strings.TrimPrefix(c.GetHeader("If-None-Match"), "W/") == etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json; charset=utf-8", body)
+}
+
+// clientConfig collects the frontend-relevant parts of the server configuration
+func clientConfig(cfg *config.Config) *models.ClientConfig {
+	client := &models.ClientConfig{
+		Environment: cfg.Environment,
+		Features: map[string]bool{
+			models.ClientFeatureBilling:              cfg.BillingEnabled(),
+			models.ClientFeatureTrials:               cfg.BillingEnabled() && cfg.BillingTrialDays > 0,
+			models.ClientFeatureReminders:            cfg.RemindersEnabled,
+			models.ClientFeatureWebPush:              cfg.VAPIDPublicKey != "",
+			models.ClientFeatureEncryptedAttachments: cfg.AttachmentEncryptionKey != "",
+		},
+		OAuthProviders: []models.ClientOAuthProvider{},
+		Uploads: models.ClientUploadConfig{
+			MaxBytes:     cfg.UploadMaxBytes,
+			AllowedTypes: cfg.UploadAllowedTypes,
+		},
+		Statuses:  models.ValidStatuses(),
+		Qualities: []models.CompletionQuality{models.CompletionSolved, models.CompletionReviewedSolution},
+	}
+
+	providers := []struct {
+		provider models.AuthProvider
+		config   config.OAuthProviderConfig
+	}{
+		{models.AuthProviderGoogle, cfg.OAuthGoogle},
+		{models.AuthProviderFacebook, cfg.OAuthFacebook},
+		{models.AuthProviderApple, cfg.OAuthApple},
+	}
+	for _, p := range providers {
+		if p.config.Enabled() {
+			client.OAuthProviders = append(client.OAuthProviders, models.ClientOAuthProvider{
+				Provider:    p.provider,
+				ClientID:    p.config.ClientID,
+				RedirectURI: p.config.RedirectURI,
+			})
+		}
+	}
+
+	if client.Uploads.AllowedTypes == nil {
+		client.Uploads.AllowedTypes = []string{}
+	}
+
+	return client
+}
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"interview-prep-app/internal/chaos"
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/services"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DebugHandler handles fault-injection endpoints used to exercise error handling in staging
+type DebugHandler struct {
+	injector    *chaos.Injector
+	jobService  *services.JobService
+	userService *services.UserService
+}
+
+// NewDebugHandler creates a new debug handler
+func NewDebugHandler(injector *chaos.Injector, jobService *services.JobService, userService *services.UserService) *DebugHandler {
+	return &DebugHandler{
+		injector:    injector,
+		jobService:  jobService,
+		userService: userService,
+	}
+}
+
+// Latency returns the delay to inject for a request path (used by middleware)
+func (h *DebugHandler) Latency(path string) time.Duration {
+	return h.injector.Latency(path)
+}
+
+// GetChaos handles GET /debug/chaos - Returns the active fault settings
+func (h *DebugHandler) GetChaos(c *gin.Context) {
+	if err := requirePermission(c, h.userService, models.PermissionSystemManage); err != nil {
+		c.Error(apperr.Forbidden("system:manage permission required"))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.injector.Settings())
+}
+
+// SetLatency handles PUT /debug/chaos/latency
+func (h *DebugHandler) SetLatency(c *gin.Context) {
+	if err := requirePermission(c, h.userService, models.PermissionSystemManage); err != nil {
+		c.Error(apperr.Forbidden("system:manage permission required"))
+		return
+	}
+
+	var req struct {
+		DelayMs         int    `json:"delay_ms" binding:"min=0,max=60000"`
+		PathPrefix      string `json:"path_prefix"`
+		DurationSeconds int    `json:"duration_seconds" binding:"min=0"`
+	}
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	h.injector.SetLatency(
+		time.Duration(req.DelayMs)*time.Millisecond,
+		req.PathPrefix,
+		time.Duration(req.DurationSeconds)*time.Second,
+	)
+
+	c.JSON(http.StatusOK, h.injector.Settings())
+}
+
+// SetDBErrors handles PUT /debug/chaos/db-errors
+func (h *DebugHandler) SetDBErrors(c *gin.Context) {
+	if err := requirePermission(c, h.userService, models.PermissionSystemManage); err != nil {
+		c.Error(apperr.Forbidden("system:manage permission required"))
+		return
+	}
+
+	var req struct {
+		Rate            float64 `json:"rate" binding:"min=0,max=1"`
+		DurationSeconds int     `json:"duration_seconds" binding:"min=0"`
+	}
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	h.injector.SetDBErrorRate(req.Rate, time.Duration(req.DurationSeconds)*time.Second)
+
+	c.JSON(http.StatusOK, h.injector.Settings())
+}
+
+// ResetChaos handles DELETE /debug/chaos - Clears all injected faults
+func (h *DebugHandler) ResetChaos(c *gin.Context) {
+	if err := requirePermission(c, h.userService, models.PermissionSystemManage); err != nil {
+		c.Error(apperr.Forbidden("system:manage permission required"))
+		return
+	}
+
+	h.injector.Reset()
+	c.JSON(http.StatusOK, gin.H{"message": "All injected faults cleared"})
+}
+
+// GetJobs handles GET /debug/jobs - Lists jobs that can be triggered
+func (h *DebugHandler) GetJobs(c *gin.Context) {
+	if err := requirePermission(c, h.userService, models.PermissionSystemManage); err != nil {
+		c.Error(apperr.Forbidden("system:manage permission required"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"jobs": h.jobService.JobNames()})
+}
+
+// RunJob handles POST /debug/jobs/:name/run - Runs a scheduled job immediately and waits for it
+func (h *DebugHandler) RunJob(c *gin.Context) {
+	if err := requirePermission(c, h.userService, models.PermissionSystemManage); err != nil {
+		c.Error(apperr.Forbidden("system:manage permission required"))
+		return
+	}
+
+	name := c.Param("name")
+	start := time.Now()
+	if err := h.jobService.RunNow(c.Request.Context(), name); err != nil {
+		appErr := apperr.From(err)
+		if appErr.Kind == apperr.KindNotFound {
+			c.Error(apperr.NotFound("Job not found").WithDetails(gin.H{"jobs": h.jobService.JobNames()}))
+			return
+		}
+		c.Error(appErr.WithDetails(gin.H{"job": name}))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Job completed", "job": name, "duration_ms": time.Since(start).Milliseconds()})
+}
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/services"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DesignNotesHandler handles HTTP requests for structured design notes on HLD items
+type DesignNotesHandler struct {
+	designNotesService *services.DesignNotesService
+}
+
+// NewDesignNotesHandler creates a new design notes handler
+func NewDesignNotesHandler(designNotesService *services.DesignNotesService) *DesignNotesHandler {
+	return &DesignNotesHandler{
+		designNotesService: designNotesService,
+	}
+}
+
+// GetDesignNotes handles GET /items/:id/design-notes
+func (h *DesignNotesHandler) GetDesignNotes(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid item ID"))
+		return
+	}
+
+	notes, err := h.designNotesService.GetDesignNotes(userID.(int), id)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	setVersionETag(c, notes.Version)
+	c.JSON(http.StatusOK, notes)
+}
+
+// SaveDesignNotes handles PUT /items/:id/design-notes - Replaces the user's notes with the
+// requirements, estimation, api, data_model and diagram_link sections in the body. A version, in
+// the body or If-Match, refuses the save with 409 if the notes changed since.
+func (h *DesignNotesHandler) SaveDesignNotes(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid item ID"))
+		return
+	}
+
+	var req models.SaveDesignNotesRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	if err := matchVersion(c, &req.Version); err != nil {
+		c.Error(err)
+		return
+	}
+
+	notes, err := h.designNotesService.SaveDesignNotes(userID.(int), id, &req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	setVersionETag(c, notes.Version)
+	c.JSON(http.StatusOK, notes)
+}
+
+// DeleteDesignNotes handles DELETE /items/:id/design-notes
+func (h *DesignNotesHandler) DeleteDesignNotes(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid item ID"))
+		return
+	}
+
+	if err := h.designNotesService.DeleteDesignNotes(userID.(int), id); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Design notes deleted successfully"})
+}
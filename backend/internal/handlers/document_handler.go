@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/services"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DocumentHandler handles HTTP requests for users' saved documents
+type DocumentHandler struct {
+	documentService *services.DocumentService
+}
+
+// NewDocumentHandler creates a new document handler
+func NewDocumentHandler(documentService *services.DocumentService) *DocumentHandler {
+	return &DocumentHandler{
+		documentService: documentService,
+	}
+}
+
+// GetDocuments handles GET /documents - Lists the user's documents, newest first. Query: category.
+func (h *DocumentHandler) GetDocuments(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	var category *models.Category
+	if categoryStr := c.Query("category"); categoryStr != "" {
+		value := models.Category(categoryStr)
+		category = &value
+	}
+
+	documents, err := h.documentService.GetDocuments(userID.(int), category)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, documents)
+}
+
+// GetDocument handles GET /documents/:id
+func (h *DocumentHandler) GetDocument(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	id, ok := documentIDParam(c)
+	if !ok {
+		return
+	}
+
+	document, err := h.documentService.GetDocument(userID.(int), id)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, document)
+}
+
+// DeleteDocument handles DELETE /documents/:id
+func (h *DocumentHandler) DeleteDocument(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	id, ok := documentIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.documentService.DeleteDocument(userID.(int), id); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Document deleted successfully"})
+}
+
+// documentIDParam parses the :id path parameter, responding with 400 when it isn't a number
+func documentIDParam(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid document ID"))
+		return 0, false
+	}
+
+	return id, true
+}
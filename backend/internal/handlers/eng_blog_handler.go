@@ -1,11 +1,14 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"interview-prep-app/internal/models"
 	"interview-prep-app/internal/repositories"
+	"interview-prep-app/internal/services"
+	"interview-prep-app/pkg/apperr"
 
 	"github.com/gin-gonic/gin"
 )
@@ -13,16 +16,37 @@ import (
 // EngBlogHandler handles HTTP requests for engineering blogs
 type EngBlogHandler struct {
 	engBlogRepo *repositories.EngBlogRepository
+	itemService *services.ItemService
+	userService *services.UserService
 }
 
 // NewEngBlogHandler creates a new engineering blog handler
-func NewEngBlogHandler(engBlogRepo *repositories.EngBlogRepository) *EngBlogHandler {
+func NewEngBlogHandler(engBlogRepo *repositories.EngBlogRepository, itemService *services.ItemService, userService *services.UserService) *EngBlogHandler {
 	return &EngBlogHandler{
 		engBlogRepo: engBlogRepo,
+		itemService: itemService,
+		userService: userService,
 	}
 }
 
-// GetEngBlogs handles GET /eng-blogs - Returns all engineering blogs
+// includeArchived reports whether the request asked for archived content with
+// include_archived=true, which only users with content:write may do
+func (h *EngBlogHandler) includeArchived(c *gin.Context) (bool, bool) {
+	if c.Query("include_archived") != "true" {
+		return false, true
+	}
+
+	if err := requirePermission(c, h.userService, models.PermissionContentWrite); err != nil {
+		c.Error(apperr.Forbidden("content:write permission required to view archived engineering blogs"))
+		return false, false
+	}
+
+	return true, true
+}
+
+// GetEngBlogs handles GET /eng-blogs - Returns a page of engineering blogs; limit and offset
+// count blogs. q searches blog names and article titles.
+// Users with content:write can pass include_archived=true to list archived blogs and articles too.
 func (h *EngBlogHandler) GetEngBlogs(c *gin.Context) {
 	// Get optional query parameters
 	limitStr := c.Query("limit")
@@ -33,23 +57,28 @@ func (h *EngBlogHandler) GetEngBlogs(c *gin.Context) {
 
 	if limitStr != "" {
 		if limit, err = strconv.Atoi(limitStr); err != nil || limit < 0 {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit parameter"})
+			c.Error(apperr.Validation("Invalid limit parameter"))
 			return
 		}
 	}
 
 	if offsetStr != "" {
 		if offset, err = strconv.Atoi(offsetStr); err != nil || offset < 0 {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid offset parameter"})
+			c.Error(apperr.Validation("Invalid offset parameter"))
 			return
 		}
 	}
 
+	includeArchived, ok := h.includeArchived(c)
+	if !ok {
+		return
+	}
+
 	// Get blogs from database
-	blogs, total, err := h.engBlogRepo.GetAll(limit, offset)
+	blogs, total, err := h.engBlogRepo.GetAll(limit, offset, c.Query("q"), includeArchived)
 	if err != nil {
 		gin.DefaultErrorWriter.Write([]byte("Error loading engineering blogs from database: " + err.Error() + "\n"))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load engineering blogs data"})
+		c.Error(apperr.Internal("Failed to load engineering blogs data"))
 		return
 	}
 
@@ -58,6 +87,11 @@ func (h *EngBlogHandler) GetEngBlogs(c *gin.Context) {
 		Total: total,
 	}
 
+	// Lets pollers send If-Modified-Since; a failure only costs them the shortcut
+	if modified, err := h.engBlogRepo.LastModified(); err == nil && !modified.IsZero() {
+		c.Header("Last-Modified", modified.UTC().Format(http.TimeFormat))
+	}
+
 	c.JSON(http.StatusOK, response)
 }
 
@@ -65,12 +99,163 @@ func (h *EngBlogHandler) GetEngBlogs(c *gin.Context) {
 func (h *EngBlogHandler) GetEngBlog(c *gin.Context) {
 	id := c.Param("id")
 
-	blog, err := h.engBlogRepo.GetByID(id)
+	includeArchived, ok := h.includeArchived(c)
+	if !ok {
+		return
+	}
+
+	blog, err := h.engBlogRepo.GetByID(id, includeArchived)
 	if err != nil {
 		gin.DefaultErrorWriter.Write([]byte("Error loading engineering blog by ID: " + err.Error() + "\n"))
-		c.JSON(http.StatusNotFound, gin.H{"error": "Engineering blog not found"})
+		c.Error(apperr.NotFound("Engineering blog not found"))
 		return
 	}
 
 	c.JSON(http.StatusOK, blog)
 }
+
+// ArchiveEngBlog handles DELETE /eng-blogs/:id - Requires content:write. The blog and its articles
+// are archived rather than deleted, so they can be restored later.
+func (h *EngBlogHandler) ArchiveEngBlog(c *gin.Context) {
+	h.setBlogArchived(c, true)
+}
+
+// RestoreEngBlog handles POST /eng-blogs/:id/restore - Requires content:write
+func (h *EngBlogHandler) RestoreEngBlog(c *gin.Context) {
+	h.setBlogArchived(c, false)
+}
+
+// ArchiveArticle handles DELETE /eng-blogs/:id/articles/:articleId - Requires content:write
+func (h *EngBlogHandler) ArchiveArticle(c *gin.Context) {
+	h.setArticleArchived(c, true)
+}
+
+// RestoreArticle handles POST /eng-blogs/:id/articles/:articleId/restore - Requires content:write
+func (h *EngBlogHandler) RestoreArticle(c *gin.Context) {
+	h.setArticleArchived(c, false)
+}
+
+// setBlogArchived archives or restores a blog on behalf of an admin
+func (h *EngBlogHandler) setBlogArchived(c *gin.Context, archived bool) {
+	if err := requirePermission(c, h.userService, models.PermissionContentWrite); err != nil {
+		c.Error(apperr.Forbidden("content:write permission required to manage engineering blogs"))
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid blog ID"))
+		return
+	}
+
+	if archived {
+		err = h.engBlogRepo.ArchiveBlog(id)
+	} else {
+		err = h.engBlogRepo.RestoreBlog(id)
+	}
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if archived {
+		c.JSON(http.StatusOK, gin.H{"message": "Engineering blog archived successfully"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Engineering blog restored successfully"})
+}
+
+// setArticleArchived archives or restores a single article on behalf of an admin
+func (h *EngBlogHandler) setArticleArchived(c *gin.Context, archived bool) {
+	if err := requirePermission(c, h.userService, models.PermissionContentWrite); err != nil {
+		c.Error(apperr.Forbidden("content:write permission required to manage engineering blogs"))
+		return
+	}
+
+	blogID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid blog ID"))
+		return
+	}
+
+	articleID, err := strconv.Atoi(c.Param("articleId"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid article ID"))
+		return
+	}
+
+	if archived {
+		err = h.engBlogRepo.ArchiveArticle(blogID, articleID)
+	} else {
+		err = h.engBlogRepo.RestoreArticle(blogID, articleID)
+	}
+	if err != nil {
+		if errors.Is(err, apperr.ErrConflict) {
+			c.Error(apperr.Conflict("The blog already has an active article with this link"))
+			return
+		}
+		c.Error(err)
+		return
+	}
+
+	if archived {
+		c.JSON(http.StatusOK, gin.H{"message": "Article archived successfully"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Article restored successfully"})
+}
+
+// PromoteArticle handles POST /eng-blogs/:id/articles/:articleId/promote?force=true - Requires content:write.
+// Creates an item from the article so it can be tracked like any other prep item. The optional
+// body overrides the default hld / "case studies" category; force skips the duplicate check.
+func (h *EngBlogHandler) PromoteArticle(c *gin.Context) {
+	if err := requirePermission(c, h.userService, models.PermissionContentWrite); err != nil {
+		c.Error(apperr.Forbidden("content:write permission required to promote engineering blog articles"))
+		return
+	}
+
+	blogID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid blog ID"))
+		return
+	}
+
+	articleID, err := strconv.Atoi(c.Param("articleId"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid article ID"))
+		return
+	}
+
+	force, ok := parseForce(c)
+	if !ok {
+		return
+	}
+
+	var req models.PromoteArticleRequest
+	if c.Request.ContentLength > 0 {
+		if err := bindJSON(c, &req); err != nil {
+			c.Error(apperr.Classify(apperr.KindValidation, err))
+			return
+		}
+	}
+
+	article, blogName, err := h.engBlogRepo.GetArticle(blogID, articleID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	item, err := h.itemService.PromoteEngBlogArticle(article, blogName, &req, force)
+	if err != nil {
+		var duplicate *services.DuplicateItemError
+		switch {
+		case errors.As(err, &duplicate):
+			c.Error(apperr.Wrap(apperr.KindConflict, err).WithDetails(gin.H{"conflicts": duplicate.Conflicts}))
+		default:
+			c.Error(apperr.Classify(apperr.KindValidation, err))
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, item)
+}
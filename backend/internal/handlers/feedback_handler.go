@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/services"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FeedbackHandler handles HTTP requests for item feedback reports and their admin review queue
+type FeedbackHandler struct {
+	feedbackService *services.FeedbackService
+	userService     *services.UserService
+}
+
+// NewFeedbackHandler creates a new feedback handler
+func NewFeedbackHandler(feedbackService *services.FeedbackService, userService *services.UserService) *FeedbackHandler {
+	return &FeedbackHandler{
+		feedbackService: feedbackService,
+		userService:     userService,
+	}
+}
+
+// SubmitFeedback handles POST /items/:id/feedback
+func (h *FeedbackHandler) SubmitFeedback(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid item ID"))
+		return
+	}
+
+	var req models.CreateFeedbackRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	feedback, err := h.feedbackService.SubmitFeedback(userID.(int), id, &req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, feedback)
+}
+
+// GetQueue handles GET /admin/feedback - Requires feedback:moderate.
+// Query: status (open, resolved or dismissed; default open), category, limit and offset.
+func (h *FeedbackHandler) GetQueue(c *gin.Context) {
+	if err := requirePermission(c, h.userService, models.PermissionFeedbackModerate); err != nil {
+		c.Error(apperr.Forbidden("feedback:moderate permission required to review feedback"))
+		return
+	}
+
+	var category *models.FeedbackCategory
+	if categoryStr := c.Query("category"); categoryStr != "" {
+		value := models.FeedbackCategory(categoryStr)
+		category = &value
+	}
+
+	limit := 0
+	if limitStr := c.Query("limit"); limitStr != "" {
+		var err error
+		if limit, err = strconv.Atoi(limitStr); err != nil {
+			c.Error(apperr.Validation("Invalid limit parameter"))
+			return
+		}
+	}
+
+	offset := 0
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		var err error
+		if offset, err = strconv.Atoi(offsetStr); err != nil {
+			c.Error(apperr.Validation("Invalid offset parameter"))
+			return
+		}
+	}
+
+	queue, err := h.feedbackService.GetQueue(models.FeedbackStatus(c.Query("status")), category, limit, offset)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, queue)
+}
+
+// ResolveFeedback handles PUT /admin/feedback/:id/resolve - Requires feedback:moderate
+func (h *FeedbackHandler) ResolveFeedback(c *gin.Context) {
+	h.review(c, models.FeedbackStatusResolved)
+}
+
+// DismissFeedback handles PUT /admin/feedback/:id/dismiss - Requires feedback:moderate
+func (h *FeedbackHandler) DismissFeedback(c *gin.Context) {
+	h.review(c, models.FeedbackStatusDismissed)
+}
+
+// review closes an open report with the given status on behalf of an admin
+func (h *FeedbackHandler) review(c *gin.Context, status models.FeedbackStatus) {
+	if err := requirePermission(c, h.userService, models.PermissionFeedbackModerate); err != nil {
+		c.Error(apperr.Forbidden("feedback:moderate permission required to review feedback"))
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid feedback ID"))
+		return
+	}
+
+	// The note is optional, so an empty body is fine
+	var req models.ReviewFeedbackRequest
+	if c.Request.ContentLength > 0 {
+		if err := bindJSON(c, &req); err != nil {
+			c.Error(apperr.Classify(apperr.KindValidation, err))
+			return
+		}
+	}
+
+	if err := h.feedbackService.ReviewFeedback(c.GetInt("userID"), id, status, req.Note); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Feedback updated successfully", "status": status})
+}
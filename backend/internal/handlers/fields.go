@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// respondFields writes obj as JSON, keeping only the fields named in the fields query parameter
+// (e.g. ?fields=id,title,status) when there is one. For a list, or an object holding its list
+// under "items", the fields pick from each item and the rest of the envelope is kept. Naming a
+// field the response doesn't have is a validation error.
+func respondFields(c *gin.Context, obj interface{}) {
+	param := strings.TrimSpace(c.Query("fields"))
+	if param == "" {
+		c.JSON(http.StatusOK, obj)
+		return
+	}
+
+	var known []string
+	if t := shapedType(reflect.TypeOf(obj)); t != nil && t.Kind() == reflect.Struct {
+		known = jsonFieldNames(t)
+	}
+	wanted := map[string]bool{}
+	var unknown []string
+	for _, field := range strings.Split(param, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if !containsFold(known, field) {
+			message := fmt.Sprintf("%q", field)
+			if suggestion := closestField(field, known); suggestion != "" {
+				message += fmt.Sprintf(" (did you mean %q?)", suggestion)
+			}
+			unknown = append(unknown, message)
+			continue
+		}
+		wanted[field] = true
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		c.Error(apperr.Validation(fmt.Sprintf("unknown %s in fields: %s", pluralizeField(len(unknown)), strings.Join(unknown, ", "))))
+		return
+	}
+
+	data, err := json.Marshal(obj)
+	if err != nil {
+		c.Error(apperr.Internal("Failed to encode response"))
+		return
+	}
+
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		c.Error(apperr.Internal("Failed to encode response"))
+		return
+	}
+
+	c.JSON(http.StatusOK, pickFields(value, wanted))
+}
+
+// shapedType is the struct type whose fields ?fields= picks from: a list's element, the element
+// of an envelope's items list, or the response itself
+func shapedType(t reflect.Type) reflect.Type {
+	for t != nil && (t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice) {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return t
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if strings.Split(field.Tag.Get("json"), ",")[0] == "items" && field.Type.Kind() == reflect.Slice {
+			return shapedType(field.Type)
+		}
+	}
+	return t
+}
+
+// pickFields keeps the wanted keys of an object, of each object in a list, or of each object in
+// an envelope's items list
+func pickFields(value interface{}, wanted map[string]bool) interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		for i := range v {
+			v[i] = pickFields(v[i], wanted)
+		}
+		return v
+	case map[string]interface{}:
+		if items, ok := v["items"].([]interface{}); ok {
+			v["items"] = pickFields(items, wanted)
+			return v
+		}
+
+		picked := make(map[string]interface{}, len(wanted))
+		for key, field := range v {
+			for name := range wanted {
+				if strings.EqualFold(key, name) {
+					picked[key] = field
+				}
+			}
+		}
+		return picked
+	}
+	return value
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"net/http"
+
+	"interview-prep-app/internal/flags"
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/services"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FlagHandler handles HTTP requests for feature flags
+type FlagHandler struct {
+	flagService *services.FlagService
+	userService *services.UserService
+}
+
+// NewFlagHandler creates a new flag handler
+func NewFlagHandler(flagService *services.FlagService, userService *services.UserService) *FlagHandler {
+	return &FlagHandler{
+		flagService: flagService,
+		userService: userService,
+	}
+}
+
+// GetFlags handles GET /flags - Returns which feature flags are on for the current user
+func (h *FlagHandler) GetFlags(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	set := flags.FromContext(c)
+	if set == nil {
+		set = flags.NewSet(userID.(int), h.flagService.Evaluate)
+	}
+
+	c.Header("Cache-Control", "private, no-cache")
+	c.JSON(http.StatusOK, gin.H{"flags": set.All()})
+}
+
+// ListFlags handles GET /admin/flags - Lists every feature flag with its rollout. Requires system:manage.
+func (h *FlagHandler) ListFlags(c *gin.Context) {
+	if err := requirePermission(c, h.userService, models.PermissionSystemManage); err != nil {
+		c.Error(apperr.Forbidden("system:manage permission required to view feature flags"))
+		return
+	}
+
+	all, err := h.flagService.ListFlags()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"flags": all})
+}
+
+// UpdateFlag handles PUT /admin/flags/:key - Creates a flag or changes its rollout. Requires system:manage.
+func (h *FlagHandler) UpdateFlag(c *gin.Context) {
+	if err := requirePermission(c, h.userService, models.PermissionSystemManage); err != nil {
+		c.Error(apperr.Forbidden("system:manage permission required to change feature flags"))
+		return
+	}
+
+	var req models.UpdateFeatureFlagRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	flag, err := h.flagService.UpdateFlag(c.Param("key"), &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, flag)
+}
+
+// DeleteFlag handles DELETE /admin/flags/:key - Removes a flag. Requires system:manage.
+func (h *FlagHandler) DeleteFlag(c *gin.Context) {
+	if err := requirePermission(c, h.userService, models.PermissionSystemManage); err != nil {
+		c.Error(apperr.Forbidden("system:manage permission required to change feature flags"))
+		return
+	}
+
+	if err := h.flagService.DeleteFlag(c.Param("key")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Feature flag deleted successfully"})
+}
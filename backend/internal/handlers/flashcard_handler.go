@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/services"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FlashcardHandler handles HTTP requests for flashcards
+type FlashcardHandler struct {
+	flashcardService *services.FlashcardService
+}
+
+// NewFlashcardHandler creates a new flashcard handler
+func NewFlashcardHandler(flashcardService *services.FlashcardService) *FlashcardHandler {
+	return &FlashcardHandler{
+		flashcardService: flashcardService,
+	}
+}
+
+// GetItemFlashcards handles GET /items/:id/flashcards
+func (h *FlashcardHandler) GetItemFlashcards(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid item ID"))
+		return
+	}
+
+	cards, err := h.flashcardService.GetItemFlashcards(userID.(int), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"flashcards": cards})
+}
+
+// CreateFlashcard handles POST /items/:id/flashcards
+func (h *FlashcardHandler) CreateFlashcard(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid item ID"))
+		return
+	}
+
+	var req models.CreateFlashcardRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	card, err := h.flashcardService.CreateFlashcard(userID.(int), id, &req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, card)
+}
+
+// GetReview handles GET /flashcards/review - Returns the cards due today
+func (h *FlashcardHandler) GetReview(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	review, err := h.flashcardService.GetDueCards(userID.(int), time.Now())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, review)
+}
+
+// GradeFlashcard handles POST /flashcards/:id/grade
+func (h *FlashcardHandler) GradeFlashcard(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid flashcard ID"))
+		return
+	}
+
+	var req models.GradeFlashcardRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	card, err := h.flashcardService.GradeFlashcard(userID.(int), id, req.Grade, time.Now())
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, card)
+}
+
+// UpdateFlashcard handles PUT /flashcards/:id
+func (h *FlashcardHandler) UpdateFlashcard(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid flashcard ID"))
+		return
+	}
+
+	var req models.UpdateFlashcardRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	card, err := h.flashcardService.UpdateFlashcard(userID.(int), id, &req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, card)
+}
+
+// DeleteFlashcard handles DELETE /flashcards/:id
+func (h *FlashcardHandler) DeleteFlashcard(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid flashcard ID"))
+		return
+	}
+
+	if err := h.flashcardService.DeleteFlashcard(userID.(int), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Flashcard deleted successfully"})
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/services"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FocusSessionHandler handles HTTP requests for timed practice sessions
+type FocusSessionHandler struct {
+	focusService *services.FocusSessionService
+}
+
+// NewFocusSessionHandler creates a new focus session handler
+func NewFocusSessionHandler(focusService *services.FocusSessionService) *FocusSessionHandler {
+	return &FocusSessionHandler{
+		focusService: focusService,
+	}
+}
+
+// StartSession handles POST /sessions/start
+func (h *FocusSessionHandler) StartSession(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	var req models.StartFocusSessionRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	session, err := h.focusService.StartSession(userID.(int), &req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, session)
+}
+
+// GetActiveSession handles GET /sessions/active
+func (h *FocusSessionHandler) GetActiveSession(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	session, err := h.focusService.GetActiveSession(userID.(int))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"session": session})
+}
+
+// PauseSession handles POST /sessions/:id/pause
+func (h *FocusSessionHandler) PauseSession(c *gin.Context) {
+	h.transition(c, h.focusService.PauseSession)
+}
+
+// ResumeSession handles POST /sessions/:id/resume
+func (h *FocusSessionHandler) ResumeSession(c *gin.Context) {
+	h.transition(c, h.focusService.ResumeSession)
+}
+
+// StopSession handles POST /sessions/:id/stop
+func (h *FocusSessionHandler) StopSession(c *gin.Context) {
+	h.transition(c, h.focusService.StopSession)
+}
+
+// GetTimeSummary handles GET /sessions/time?days=30
+func (h *FocusSessionHandler) GetTimeSummary(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	days := 0
+	if daysStr := c.Query("days"); daysStr != "" {
+		var err error
+		days, err = strconv.Atoi(daysStr)
+		if err != nil {
+			c.Error(apperr.Validation("Invalid days parameter"))
+			return
+		}
+	}
+
+	summary, err := h.focusService.GetTimeSummary(userID.(int), days)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
+// transition applies a timer action to the session in the path
+func (h *FocusSessionHandler) transition(c *gin.Context, action func(userID, sessionID int) (*models.FocusSession, error)) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid session ID"))
+		return
+	}
+
+	session, err := action(userID.(int), id)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, session)
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"net/http"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/services"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GitHubHandler handles HTTP requests for the GitHub solution commit integration
+type GitHubHandler struct {
+	githubService *services.GitHubService
+}
+
+// NewGitHubHandler creates a new GitHub handler
+func NewGitHubHandler(githubService *services.GitHubService) *GitHubHandler {
+	return &GitHubHandler{
+		githubService: githubService,
+	}
+}
+
+// Authorize handles POST /integrations/github/authorize, returning the GitHub page to send the user to
+func (h *GitHubHandler) Authorize(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	resp, err := h.githubService.AuthorizeURL(userID.(int))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// CompleteAuthorization handles POST /integrations/github/callback with the code and state GitHub
+// redirected the user's browser back to the app with
+func (h *GitHubHandler) CompleteAuthorization(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	var req models.CompleteGitHubAuthorizationRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	conn, err := h.githubService.CompleteAuthorization(c.Request.Context(), userID.(int), &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, conn)
+}
+
+// GetConnection handles GET /integrations/github
+func (h *GitHubHandler) GetConnection(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	conn, err := h.githubService.GetConnection(userID.(int))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, conn)
+}
+
+// UpdateRepository handles PUT /integrations/github, choosing the repository solutions go to
+func (h *GitHubHandler) UpdateRepository(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	var req models.UpdateGitHubRepositoryRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	conn, err := h.githubService.UpdateRepository(c.Request.Context(), userID.(int), &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, conn)
+}
+
+// Disconnect handles DELETE /integrations/github
+func (h *GitHubHandler) Disconnect(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	if err := h.githubService.Disconnect(userID.(int)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "GitHub account disconnected successfully"})
+}
@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/services"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GroupHandler handles HTTP requests for study groups
+type GroupHandler struct {
+	groupService *services.GroupService
+}
+
+// NewGroupHandler creates a new group handler
+func NewGroupHandler(groupService *services.GroupService) *GroupHandler {
+	return &GroupHandler{
+		groupService: groupService,
+	}
+}
+
+// CreateGroup handles POST /groups
+func (h *GroupHandler) CreateGroup(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	var req models.CreateGroupRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	group, err := h.groupService.CreateGroup(userID.(int), &req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, group)
+}
+
+// GetGroups handles GET /groups - Returns the user's groups
+func (h *GroupHandler) GetGroups(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	groups, err := h.groupService.GetGroupsForUser(userID.(int))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"groups": groups})
+}
+
+// GetGroup handles GET /groups/:id
+func (h *GroupHandler) GetGroup(c *gin.Context) {
+	userID, groupID, ok := groupRequestIDs(c)
+	if !ok {
+		return
+	}
+
+	group, members, err := h.groupService.GetGroup(userID, groupID)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"group": group, "members": members})
+}
+
+// JoinGroup handles POST /groups/join
+func (h *GroupHandler) JoinGroup(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	var req models.JoinGroupRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	group, err := h.groupService.JoinByCode(userID.(int), req.Code)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, group)
+}
+
+// LeaveGroup handles POST /groups/:id/leave
+func (h *GroupHandler) LeaveGroup(c *gin.Context) {
+	userID, groupID, ok := groupRequestIDs(c)
+	if !ok {
+		return
+	}
+
+	if err := h.groupService.LeaveGroup(userID, groupID); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Left group successfully"})
+}
+
+// InviteToGroup handles POST /groups/:id/invite
+func (h *GroupHandler) InviteToGroup(c *gin.Context) {
+	userID, groupID, ok := groupRequestIDs(c)
+	if !ok {
+		return
+	}
+
+	var req models.InviteToGroupRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	if err := h.groupService.InviteByEmail(userID, groupID, req.Email); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Invitation sent"})
+}
+
+// GetLeaderboard handles GET /groups/:id/leaderboard
+func (h *GroupHandler) GetLeaderboard(c *gin.Context) {
+	userID, groupID, ok := groupRequestIDs(c)
+	if !ok {
+		return
+	}
+
+	entries, err := h.groupService.GetLeaderboard(userID, groupID)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"group_id": groupID, "leaderboard": entries})
+}
+
+// GetProgress handles GET /groups/:id/progress
+func (h *GroupHandler) GetProgress(c *gin.Context) {
+	userID, groupID, ok := groupRequestIDs(c)
+	if !ok {
+		return
+	}
+
+	progress, err := h.groupService.GetProgress(userID, groupID)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, progress)
+}
+
+// CreateItemList handles POST /groups/:id/lists
+func (h *GroupHandler) CreateItemList(c *gin.Context) {
+	userID, groupID, ok := groupRequestIDs(c)
+	if !ok {
+		return
+	}
+
+	var req models.CreateGroupItemListRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	list, err := h.groupService.CreateItemList(userID, groupID, &req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, list)
+}
+
+// GetItemLists handles GET /groups/:id/lists
+func (h *GroupHandler) GetItemLists(c *gin.Context) {
+	userID, groupID, ok := groupRequestIDs(c)
+	if !ok {
+		return
+	}
+
+	lists, err := h.groupService.GetItemLists(userID, groupID)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"lists": lists})
+}
+
+// groupRequestIDs extracts the authenticated user and group ID, writing an error response on failure
+func groupRequestIDs(c *gin.Context) (int, int, bool) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return 0, 0, false
+	}
+
+	groupID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid group ID"))
+		return 0, 0, false
+	}
+
+	return userID.(int), groupID, true
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"interview-prep-app/internal/health"
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/services"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// healthCheckTimeout bounds how long a single dependency probe may take
+const healthCheckTimeout = 3 * time.Second
+
+// HealthHandler reports the status of the services the API depends on
+type HealthHandler struct {
+	checks      []health.Check
+	userService *services.UserService
+}
+
+// NewHealthHandler creates a new health handler probing the given dependencies
+func NewHealthHandler(checks []health.Check, userService *services.UserService) *HealthHandler {
+	return &HealthHandler{
+		checks:      checks,
+		userService: userService,
+	}
+}
+
+// Ready handles GET /healthz/ready - 200 when every required dependency is up, 503 otherwise
+func (h *HealthHandler) Ready(c *gin.Context) {
+	var required []health.Check
+	for _, check := range h.checks {
+		if check.Required {
+			required = append(required, check)
+		}
+	}
+
+	report := health.Run(c.Request.Context(), required, healthCheckTimeout)
+	status := http.StatusOK
+	if !report.Ready() {
+		status = http.StatusServiceUnavailable
+	}
+
+	c.JSON(status, gin.H{"status": report.Status})
+}
+
+// GetDetails handles GET /healthz/details - Requires system:manage. Reports every dependency with its latency.
+func (h *HealthHandler) GetDetails(c *gin.Context) {
+	if err := requirePermission(c, h.userService, models.PermissionSystemManage); err != nil {
+		c.Error(apperr.Forbidden("system:manage permission required to view dependency health"))
+		return
+	}
+
+	report := health.Run(c.Request.Context(), h.checks, healthCheckTimeout)
+	status := http.StatusOK
+	if !report.Ready() {
+		status = http.StatusServiceUnavailable
+	}
+
+	c.JSON(status, report)
+}
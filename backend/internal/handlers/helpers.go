@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/requestuser"
+	"interview-prep-app/internal/services"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// requirePermission checks that the current user's role grants a permission. Admins hold every permission.
+func requirePermission(c *gin.Context, userService *services.UserService, permission models.Permission) error {
+	userID, exists := c.Get("userID")
+	if !exists {
+		return gin.Error{Err: gin.Error{}, Type: gin.ErrorTypePublic, Meta: "User not authenticated"}
+	}
+
+	role, err := CurrentRole(c, userService, userID.(int))
+	if err != nil {
+		return err
+	}
+
+	allowed, err := userService.HasPermission(role, permission)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return gin.Error{Err: gin.Error{}, Type: gin.ErrorTypePublic, Meta: "Permission required"}
+	}
+
+	return nil
+}
+
+// CurrentRole returns the authenticated user's role, from the access token when it carries one
+// and from the user otherwise. A role change applies once the user's token is renewed.
+func CurrentRole(c *gin.Context, userService *services.UserService, userID int) (models.Role, error) {
+	if role, ok := c.Get("userRole"); ok {
+		if role, ok := role.(models.Role); ok {
+			return role, nil
+		}
+	}
+
+	user, err := CurrentUser(c, userService, userID)
+	if err != nil {
+		return "", err
+	}
+	return user.Role, nil
+}
+
+// CurrentUser returns the authenticated user, from the request-scoped loader when the request
+// has one and from the database otherwise
+func CurrentUser(c *gin.Context, userService *services.UserService, userID int) (*models.User, error) {
+	user, err := requestuser.Get(c)
+	if err == requestuser.ErrNoLoader {
+		return userService.GetByID(userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return user.User, nil
+}
+
+// matchVersion fills in the version an edit was based on from an If-Match header, which echoes
+// the ETag the resource was read with, when the body doesn't carry one. "*" matches any version.
+func matchVersion(c *gin.Context, version **int) error {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return nil
+	}
+
+	parsed, err := strconv.Atoi(strings.Trim(strings.TrimPrefix(header, "W/"), `"`))
+	if err != nil || parsed <= 0 {
+		return apperr.Validation("If-Match must be an ETag returned by this API")
+	}
+
+	if *version != nil && **version != parsed {
+		return apperr.Validation("If-Match and version disagree")
+	}
+	*version = &parsed
+	return nil
+}
+
+// setVersionETag sets the ETag a later edit sends back in If-Match
+func setVersionETag(c *gin.Context, version int) {
+	if version > 0 {
+		c.Header("ETag", fmt.Sprintf(`"%d"`, version))
+	}
+}
@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/services"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HintHandler handles HTTP requests for item hint ladders
+type HintHandler struct {
+	hintService *services.HintService
+	userService *services.UserService
+}
+
+// NewHintHandler creates a new hint handler
+func NewHintHandler(hintService *services.HintService, userService *services.UserService) *HintHandler {
+	return &HintHandler{
+		hintService: hintService,
+		userService: userService,
+	}
+}
+
+// GetHints handles GET /items/:id/hints - Returns the hints the user has revealed
+func (h *HintHandler) GetHints(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid item ID"))
+		return
+	}
+
+	ladder, err := h.hintService.GetHintLadder(userID.(int), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ladder)
+}
+
+// RevealHint handles POST /items/:id/hints/reveal - Reveals the next hint
+func (h *HintHandler) RevealHint(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid item ID"))
+		return
+	}
+
+	ladder, err := h.hintService.RevealNextHint(userID.(int), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ladder)
+}
+
+// GetAllHints handles GET /items/:id/hints/all - Requires content:write
+func (h *HintHandler) GetAllHints(c *gin.Context) {
+	if err := requirePermission(c, h.userService, models.PermissionContentWrite); err != nil {
+		c.Error(apperr.Forbidden("content:write permission required to view all hints"))
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid item ID"))
+		return
+	}
+
+	hints, err := h.hintService.GetAllHints(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"item_id": id, "hints": hints})
+}
+
+// CreateHint handles POST /items/:id/hints - Requires content:write
+func (h *HintHandler) CreateHint(c *gin.Context) {
+	if err := requirePermission(c, h.userService, models.PermissionContentWrite); err != nil {
+		c.Error(apperr.Forbidden("content:write permission required to author hints"))
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid item ID"))
+		return
+	}
+
+	var req models.CreateHintRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	hint, err := h.hintService.CreateHint(id, &req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, hint)
+}
+
+// UpdateHint handles PUT /items/:id/hints/:hint_id - Requires content:write
+func (h *HintHandler) UpdateHint(c *gin.Context) {
+	if err := requirePermission(c, h.userService, models.PermissionContentWrite); err != nil {
+		c.Error(apperr.Forbidden("content:write permission required to edit hints"))
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid item ID"))
+		return
+	}
+
+	hintID, err := strconv.Atoi(c.Param("hint_id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid hint ID"))
+		return
+	}
+
+	var req models.UpdateHintRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	hint, err := h.hintService.UpdateHint(id, hintID, &req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, hint)
+}
+
+// DeleteHint handles DELETE /items/:id/hints/:hint_id - Requires content:write
+func (h *HintHandler) DeleteHint(c *gin.Context) {
+	if err := requirePermission(c, h.userService, models.PermissionContentWrite); err != nil {
+		c.Error(apperr.Forbidden("content:write permission required to delete hints"))
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid item ID"))
+		return
+	}
+
+	hintID, err := strconv.Atoi(c.Param("hint_id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid hint ID"))
+		return
+	}
+
+	if err := h.hintService.DeleteHint(id, hintID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Hint deleted successfully"})
+}
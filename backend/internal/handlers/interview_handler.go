@@ -0,0 +1,270 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/services"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// InterviewHandler handles HTTP requests for the interview pipeline tracker
+type InterviewHandler struct {
+	interviewService *services.InterviewService
+}
+
+// NewInterviewHandler creates a new interview handler
+func NewInterviewHandler(interviewService *services.InterviewService) *InterviewHandler {
+	return &InterviewHandler{
+		interviewService: interviewService,
+	}
+}
+
+// GetInterviews handles GET /interviews
+func (h *InterviewHandler) GetInterviews(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	interviews, err := h.interviewService.GetInterviews(userID.(int))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"interviews": interviews})
+}
+
+// CreateInterview handles POST /interviews
+func (h *InterviewHandler) CreateInterview(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	var req models.CreateInterviewRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	interview, err := h.interviewService.CreateInterview(userID.(int), &req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, interview)
+}
+
+// GetInterview handles GET /interviews/:id
+func (h *InterviewHandler) GetInterview(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid interview ID"))
+		return
+	}
+
+	interview, err := h.interviewService.GetInterview(userID.(int), id)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, interview)
+}
+
+// UpdateInterview handles PUT /interviews/:id
+func (h *InterviewHandler) UpdateInterview(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid interview ID"))
+		return
+	}
+
+	var req models.UpdateInterviewRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	interview, err := h.interviewService.UpdateInterview(userID.(int), id, &req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, interview)
+}
+
+// DeleteInterview handles DELETE /interviews/:id
+func (h *InterviewHandler) DeleteInterview(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid interview ID"))
+		return
+	}
+
+	if err := h.interviewService.DeleteInterview(userID.(int), id); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Interview deleted successfully"})
+}
+
+// CreateStage handles POST /interviews/:id/stages
+func (h *InterviewHandler) CreateStage(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid interview ID"))
+		return
+	}
+
+	var req models.CreateInterviewStageRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	stage, err := h.interviewService.AddStage(userID.(int), id, &req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, stage)
+}
+
+// UpdateStage handles PUT /interviews/:id/stages/:stage_id
+func (h *InterviewHandler) UpdateStage(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	id, stageID, ok := parseStageParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateInterviewStageRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	stage, err := h.interviewService.UpdateStage(userID.(int), id, stageID, &req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, stage)
+}
+
+// DeleteStage handles DELETE /interviews/:id/stages/:stage_id
+func (h *InterviewHandler) DeleteStage(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	id, stageID, ok := parseStageParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.interviewService.DeleteStage(userID.(int), id, stageID); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Stage deleted successfully"})
+}
+
+// SetStageItems handles PUT /interviews/:id/stages/:stage_id/items. Replaces the stage's prep list.
+func (h *InterviewHandler) SetStageItems(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	id, stageID, ok := parseStageParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.SetStagePrepItemsRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	stage, err := h.interviewService.SetStageItems(userID.(int), id, stageID, &req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, stage)
+}
+
+// parseStageParams reads the interview and stage IDs from the path, responding with 400 when either is invalid
+func parseStageParams(c *gin.Context) (int, int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid interview ID"))
+		return 0, 0, false
+	}
+
+	stageID, err := strconv.Atoi(c.Param("stage_id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid stage ID"))
+		return 0, 0, false
+	}
+
+	return id, stageID, true
+}
@@ -1,11 +1,16 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
+	"interview-prep-app/internal/export"
 	"interview-prep-app/internal/models"
 	"interview-prep-app/internal/services"
+	"interview-prep-app/pkg/apperr"
 
 	"github.com/gin-gonic/gin"
 )
@@ -24,46 +29,100 @@ func NewItemHandler(itemService *services.ItemService, userService *services.Use
 	}
 }
 
-// CreateItem handles POST /items - Admin only
+// CreateItem handles POST /items?force=true - Requires content:write. Returns 409 with the conflicting items
+// when the new item looks like a duplicate; force creates it anyway.
 func (h *ItemHandler) CreateItem(c *gin.Context) {
 	// Check if user has admin role
-	if err := h.requireAdminRole(c); err != nil {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Admin access required to create items"})
+	if err := h.requireContentWrite(c); err != nil {
+		c.Error(apperr.Forbidden("content:write permission required to create items"))
+		return
+	}
+
+	force, ok := parseForce(c)
+	if !ok {
 		return
 	}
 
 	var req models.CreateItemRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
 		return
 	}
+	if !h.canPublish(c) {
+		req.Draft = true
+	}
 
-	item, err := h.itemService.CreateItem(&req)
+	item, err := h.itemService.CreateItem(&req, force)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		var duplicate *services.DuplicateItemError
+		if errors.As(err, &duplicate) {
+			c.Error(apperr.Wrap(apperr.KindConflict, err).WithDetails(gin.H{"conflicts": duplicate.Conflicts}))
+			return
+		}
+		c.Error(apperr.Classify(apperr.KindValidation, err))
 		return
 	}
 
 	c.JSON(http.StatusCreated, item)
 }
 
-// requireAdminRole checks if the current user has admin role
-func (h *ItemHandler) requireAdminRole(c *gin.Context) error {
-	userID, exists := c.Get("userID")
-	if !exists {
-		return gin.Error{Err: gin.Error{}, Type: gin.ErrorTypePublic, Meta: "User not authenticated"}
+// BulkCreateItems handles POST /items/bulk?force=true - Requires content:write.
+// Items that are invalid or duplicate existing ones are skipped and reported; force creates duplicates anyway.
+func (h *ItemHandler) BulkCreateItems(c *gin.Context) {
+	if err := h.requireContentWrite(c); err != nil {
+		c.Error(apperr.Forbidden("content:write permission required to create items"))
+		return
 	}
 
-	user, err := h.userService.GetByID(userID.(int))
+	force, ok := parseForce(c)
+	if !ok {
+		return
+	}
+
+	var req models.BulkCreateItemsRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+	if !h.canPublish(c) {
+		for i := range req.Items {
+			req.Items[i].Draft = true
+		}
+	}
+
+	result, err := h.itemService.BulkCreateItems(req.Items, force)
 	if err != nil {
-		return err
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// parseForce reads the optional force query parameter, responding with 400 when it's malformed
+func parseForce(c *gin.Context) (bool, bool) {
+	forceStr := c.Query("force")
+	if forceStr == "" {
+		return false, true
 	}
 
-	if user.Role != models.RoleAdmin {
-		return gin.Error{Err: gin.Error{}, Type: gin.ErrorTypePublic, Meta: "Admin role required"}
+	force, err := strconv.ParseBool(forceStr)
+	if err != nil {
+		c.Error(apperr.Validation("Invalid force parameter"))
+		return false, false
 	}
 
-	return nil
+	return force, true
+}
+
+// requireContentWrite checks if the current user may edit content
+func (h *ItemHandler) requireContentWrite(c *gin.Context) error {
+	return requirePermission(c, h.userService, models.PermissionContentWrite)
+}
+
+// canPublish reports whether the user may publish items; what others create starts as a draft
+func (h *ItemHandler) canPublish(c *gin.Context) bool {
+	return requirePermission(c, h.userService, models.PermissionContentPublish) == nil
 }
 
 // GetItem handles GET /items/:id
@@ -71,29 +130,26 @@ func (h *ItemHandler) GetItem(c *gin.Context) {
 	// Get user ID from context
 	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		c.Error(apperr.Unauthorized("User not authenticated"))
 		return
 	}
 
 	idStr := c.Param("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid item ID"})
+		c.Error(apperr.Validation("Invalid item ID"))
 		return
 	}
 
 	// Use the new method that includes user progress
 	item, err := h.itemService.GetItemWithUserProgress(userID.(int), id)
 	if err != nil {
-		if err.Error() == "item not found" {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Item not found"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, item)
+	setVersionETag(c, item.Version)
+	respondFields(c, item)
 }
 
 // GetItems handles GET /items
@@ -101,7 +157,7 @@ func (h *ItemHandler) GetItems(c *gin.Context) {
 	// Get user ID from context
 	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		c.Error(apperr.Unauthorized("User not authenticated"))
 		return
 	}
 
@@ -117,15 +173,37 @@ func (h *ItemHandler) GetItems(c *gin.Context) {
 		filter.Subcategory = &subcategory
 	}
 
+	if company := c.Query("company"); company != "" {
+		filter.Company = &company
+	}
+
 	if statusStr := c.Query("status"); statusStr != "" {
 		status := models.Status(statusStr)
 		filter.Status = &status
 	}
 
+	if starredStr := c.Query("starred"); starredStr != "" {
+		starred, err := strconv.ParseBool(starredStr)
+		if err != nil {
+			c.Error(apperr.Validation("Invalid starred parameter"))
+			return
+		}
+		filter.Starred = &starred
+	}
+
+	if hasNotesStr := c.Query("has_notes"); hasNotesStr != "" {
+		hasNotes, err := strconv.ParseBool(hasNotesStr)
+		if err != nil {
+			c.Error(apperr.Validation("Invalid has_notes parameter"))
+			return
+		}
+		filter.HasNotes = &hasNotes
+	}
+
 	if limitStr := c.Query("limit"); limitStr != "" {
 		limit, err := strconv.Atoi(limitStr)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit parameter"})
+			c.Error(apperr.Validation("Invalid limit parameter"))
 			return
 		}
 		filter.Limit = &limit
@@ -134,7 +212,7 @@ func (h *ItemHandler) GetItems(c *gin.Context) {
 	if offsetStr := c.Query("offset"); offsetStr != "" {
 		offset, err := strconv.Atoi(offsetStr)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid offset parameter"})
+			c.Error(apperr.Validation("Invalid offset parameter"))
 			return
 		}
 		filter.Offset = &offset
@@ -143,11 +221,105 @@ func (h *ItemHandler) GetItems(c *gin.Context) {
 	// Use the new method that includes user progress
 	items, err := h.itemService.GetItemsWithUserProgress(userID.(int), filter)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(apperr.Classify(apperr.KindValidation, err))
 		return
 	}
 
-	c.JSON(http.StatusOK, items)
+	respondFields(c, items)
+}
+
+// ExportItems handles GET /items/export?format=csv|xlsx - Downloads the user's items with their progress.
+// Accepts the same filters as GET /items.
+func (h *ItemHandler) ExportItems(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	format := export.Format(c.DefaultQuery("format", string(export.FormatCSV)))
+	if format != export.FormatCSV && format != export.FormatXLSX {
+		c.Error(apperr.Validation("Invalid format parameter. Valid formats are: csv, xlsx"))
+		return
+	}
+
+	filter := &models.ItemFilter{}
+
+	// Parse query parameters
+	if categoryStr := c.Query("category"); categoryStr != "" {
+		category := models.Category(categoryStr)
+		filter.Category = &category
+	}
+
+	if subcategory := c.Query("subcategory"); subcategory != "" {
+		filter.Subcategory = &subcategory
+	}
+
+	if company := c.Query("company"); company != "" {
+		filter.Company = &company
+	}
+
+	if statusStr := c.Query("status"); statusStr != "" {
+		status := models.Status(statusStr)
+		filter.Status = &status
+	}
+
+	if starredStr := c.Query("starred"); starredStr != "" {
+		starred, err := strconv.ParseBool(starredStr)
+		if err != nil {
+			c.Error(apperr.Validation("Invalid starred parameter"))
+			return
+		}
+		filter.Starred = &starred
+	}
+
+	if hasNotesStr := c.Query("has_notes"); hasNotesStr != "" {
+		hasNotes, err := strconv.ParseBool(hasNotesStr)
+		if err != nil {
+			c.Error(apperr.Validation("Invalid has_notes parameter"))
+			return
+		}
+		filter.HasNotes = &hasNotes
+	}
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil {
+			c.Error(apperr.Validation("Invalid limit parameter"))
+			return
+		}
+		filter.Limit = &limit
+	}
+
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		offset, err := strconv.Atoi(offsetStr)
+		if err != nil {
+			c.Error(apperr.Validation("Invalid offset parameter"))
+			return
+		}
+		filter.Offset = &offset
+	}
+
+	items, err := h.itemService.GetItemsWithUserProgress(userID.(int), filter)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	filename := fmt.Sprintf("prepmaster-items-%s.%s", time.Now().UTC().Format("2006-01-02"), format)
+	c.Header("Content-Type", format.ContentType())
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Status(http.StatusOK)
+
+	w, err := export.NewWriter(format, c.Writer)
+	if err == nil {
+		err = export.WriteItems(w, items)
+	}
+	if err != nil {
+		// Headers are already sent, so the client just sees a truncated download
+		fmt.Printf("Warning: failed to export items for user %d: %v\n", userID.(int), err)
+	}
 }
 
 // GetItemsPaginated handles GET /items/paginated
@@ -155,7 +327,7 @@ func (h *ItemHandler) GetItemsPaginated(c *gin.Context) {
 	// Get user ID from context
 	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		c.Error(apperr.Unauthorized("User not authenticated"))
 		return
 	}
 
@@ -171,15 +343,37 @@ func (h *ItemHandler) GetItemsPaginated(c *gin.Context) {
 		filter.Subcategory = &subcategory
 	}
 
+	if company := c.Query("company"); company != "" {
+		filter.Company = &company
+	}
+
 	if statusStr := c.Query("status"); statusStr != "" {
 		status := models.Status(statusStr)
 		filter.Status = &status
 	}
 
+	if starredStr := c.Query("starred"); starredStr != "" {
+		starred, err := strconv.ParseBool(starredStr)
+		if err != nil {
+			c.Error(apperr.Validation("Invalid starred parameter"))
+			return
+		}
+		filter.Starred = &starred
+	}
+
+	if hasNotesStr := c.Query("has_notes"); hasNotesStr != "" {
+		hasNotes, err := strconv.ParseBool(hasNotesStr)
+		if err != nil {
+			c.Error(apperr.Validation("Invalid has_notes parameter"))
+			return
+		}
+		filter.HasNotes = &hasNotes
+	}
+
 	if limitStr := c.Query("limit"); limitStr != "" {
 		limit, err := strconv.Atoi(limitStr)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit parameter"})
+			c.Error(apperr.Validation("Invalid limit parameter"))
 			return
 		}
 		filter.Limit = &limit
@@ -188,7 +382,7 @@ func (h *ItemHandler) GetItemsPaginated(c *gin.Context) {
 	if offsetStr := c.Query("offset"); offsetStr != "" {
 		offset, err := strconv.Atoi(offsetStr)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid offset parameter"})
+			c.Error(apperr.Validation("Invalid offset parameter"))
 			return
 		}
 		filter.Offset = &offset
@@ -202,11 +396,11 @@ func (h *ItemHandler) GetItemsPaginated(c *gin.Context) {
 	// Use the new method that includes user progress
 	result, err := h.itemService.GetItemsPaginatedWithUserProgress(userID.(int), filter)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(apperr.Classify(apperr.KindValidation, err))
 		return
 	}
 
-	c.JSON(http.StatusOK, result)
+	respondFields(c, result)
 }
 
 // GetNextItem handles GET /items/next
@@ -214,22 +408,18 @@ func (h *ItemHandler) GetNextItem(c *gin.Context) {
 	// Get user ID from context
 	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		c.Error(apperr.Unauthorized("User not authenticated"))
 		return
 	}
 
 	// Use the new method that includes user progress
 	item, err := h.itemService.GetNextItemWithUserProgress(userID.(int))
 	if err != nil {
-		if err.Error() == "no pending items found" {
-			c.JSON(http.StatusNotFound, gin.H{"message": "No pending items found"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, item)
+	respondFields(c, item)
 }
 
 // SkipItem handles POST /items/skip
@@ -237,18 +427,14 @@ func (h *ItemHandler) SkipItem(c *gin.Context) {
 	// Get user ID from context
 	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		c.Error(apperr.Unauthorized("User not authenticated"))
 		return
 	}
 
 	// Use the new method that includes user progress
 	item, err := h.itemService.SkipItemWithUserProgress(userID.(int))
 	if err != nil {
-		if err.Error() == "no pending items found" {
-			c.JSON(http.StatusNotFound, gin.H{"message": "No pending items found"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -260,87 +446,308 @@ func (h *ItemHandler) CompleteItem(c *gin.Context) {
 	// Get user ID from context
 	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		c.Error(apperr.Unauthorized("User not authenticated"))
 		return
 	}
 
 	idStr := c.Param("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid item ID"})
+		c.Error(apperr.Validation("Invalid item ID"))
 		return
 	}
 
+	// The body is optional; without it the quality is inferred from hint usage
+	var req models.CompleteItemRequest
+	if c.Request.ContentLength > 0 {
+		if err := bindJSON(c, &req); err != nil {
+			c.Error(apperr.Classify(apperr.KindValidation, err))
+			return
+		}
+	}
+
 	// Use the new method that includes user progress
-	item, err := h.itemService.CompleteItemWithUserProgress(userID.(int), id)
+	item, err := h.itemService.CompleteItemWithUserProgress(c.Request.Context(), userID.(int), id, req.Quality)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// GetDueReviews handles GET /items/reviews/due - Returns completed items due for review
+func (h *ItemHandler) GetDueReviews(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	limit := 20
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = l
+		}
+	}
+
+	items, err := h.itemService.GetDueReviews(userID.(int), limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"items": items, "count": len(items)})
+}
+
+// ReviewItem handles PUT /items/:id/review - Records a review of a completed item
+func (h *ItemHandler) ReviewItem(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.Error(apperr.Validation("Invalid item ID"))
+		return
+	}
+
+	var req models.ReviewItemRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	item, err := h.itemService.ReviewItem(userID.(int), id, req.Quality)
 	if err != nil {
-		if err.Error() == "item not found" {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Item not found"})
+		if errors.Is(err, apperr.ErrConflict) {
+			c.Error(apperr.Conflict("Item must be completed before it can be reviewed"))
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(apperr.Classify(apperr.KindValidation, err))
 		return
 	}
 
 	c.JSON(http.StatusOK, item)
 }
 
-// UpdateItem handles PUT /items/:id - Admin only
+// UpdateItem handles PUT /items/:id - Requires content:write. A version, in the body or If-Match,
+// refuses the edit with 409 if the item changed since.
 func (h *ItemHandler) UpdateItem(c *gin.Context) {
 	// Check if user has admin role
-	if err := h.requireAdminRole(c); err != nil {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Admin access required to edit items"})
+	if err := h.requireContentWrite(c); err != nil {
+		c.Error(apperr.Forbidden("content:write permission required to edit items"))
 		return
 	}
 
 	idStr := c.Param("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid item ID"})
+		c.Error(apperr.Validation("Invalid item ID"))
 		return
 	}
 
 	var req models.UpdateItemRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
 		return
 	}
 
-	item, err := h.itemService.UpdateItem(id, &req)
+	if err := matchVersion(c, &req.Version); err != nil {
+		c.Error(err)
+		return
+	}
+
+	item, err := h.itemService.UpdateItem(c.GetInt("userID"), id, &req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	setVersionETag(c, item.Version)
+	c.JSON(http.StatusOK, item)
+}
+
+// PublishItem handles PUT /items/:id/publish - Requires content:publish. With published false in
+// the body, the item goes back to draft.
+func (h *ItemHandler) PublishItem(c *gin.Context) {
+	if !h.canPublish(c) {
+		c.Error(apperr.Forbidden("content:publish permission required to publish items"))
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid item ID"))
+		return
+	}
+
+	var req models.PublishItemRequest
+	if c.Request.ContentLength > 0 {
+		if err := bindJSON(c, &req); err != nil {
+			c.Error(apperr.Classify(apperr.KindValidation, err))
+			return
+		}
+	}
+
+	item, err := h.itemService.PublishItem(id, &req)
 	if err != nil {
-		if err.Error() == "item not found" {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Item not found"})
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	setVersionETag(c, item.Version)
+	c.JSON(http.StatusOK, item)
+}
+
+// ScheduleItems handles PUT /items/schedule - Requires content:publish. Schedules draft items to
+// be published together at publish_at, or unschedules them when it's null.
+func (h *ItemHandler) ScheduleItems(c *gin.Context) {
+	if !h.canPublish(c) {
+		c.Error(apperr.Forbidden("content:publish permission required to schedule items"))
+		return
+	}
+
+	var req models.ScheduleItemsRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	items, err := h.itemService.ScheduleItems(&req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"items": items})
+}
+
+// GetUpcomingReleases handles GET /items/upcoming?limit=20 - Lists the items scheduled to go live
+func (h *ItemHandler) GetUpcomingReleases(c *gin.Context) {
+	limit := 0
+	if limitStr := c.Query("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil {
+			c.Error(apperr.Validation("Invalid limit parameter"))
+			return
+		}
+	}
+
+	releases, err := h.itemService.GetUpcomingReleases(limit)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, releases)
+}
+
+// GetDrafts handles GET /items/drafts - Requires content:write
+func (h *ItemHandler) GetDrafts(c *gin.Context) {
+	if err := h.requireContentWrite(c); err != nil {
+		c.Error(apperr.Forbidden("content:write permission required to view draft items"))
+		return
+	}
+
+	drafts, err := h.itemService.GetDrafts()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, drafts)
+}
+
+// GetItemRevisions handles GET /items/:id/revisions - Requires content:write
+func (h *ItemHandler) GetItemRevisions(c *gin.Context) {
+	if err := h.requireContentWrite(c); err != nil {
+		c.Error(apperr.Forbidden("content:write permission required to view item revisions"))
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid item ID"))
+		return
+	}
+
+	revisions, err := h.itemService.GetItemRevisions(id)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, revisions)
+}
+
+// RevertItem handles POST /items/:id/revisions/:revision_id/revert - Requires content:write. A
+// version, in the body or If-Match, refuses the revert with 409 if the item changed since.
+func (h *ItemHandler) RevertItem(c *gin.Context) {
+	if err := h.requireContentWrite(c); err != nil {
+		c.Error(apperr.Forbidden("content:write permission required to revert items"))
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid item ID"))
+		return
+	}
+
+	revisionID, err := strconv.Atoi(c.Param("revision_id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid revision ID"))
+		return
+	}
+
+	var req models.RevertItemRequest
+	if c.Request.ContentLength > 0 {
+		if err := bindJSON(c, &req); err != nil {
+			c.Error(apperr.Classify(apperr.KindValidation, err))
 			return
 		}
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+
+	if err := matchVersion(c, &req.Version); err != nil {
+		c.Error(err)
 		return
 	}
 
+	item, err := h.itemService.RevertItem(c.GetInt("userID"), id, revisionID, &req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	setVersionETag(c, item.Version)
 	c.JSON(http.StatusOK, item)
 }
 
-// DeleteItem handles DELETE /items/:id - Admin only
+// DeleteItem handles DELETE /items/:id - Requires content:write
 func (h *ItemHandler) DeleteItem(c *gin.Context) {
 	// Check if user has admin role
-	if err := h.requireAdminRole(c); err != nil {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Admin access required to delete items"})
+	if err := h.requireContentWrite(c); err != nil {
+		c.Error(apperr.Forbidden("content:write permission required to delete items"))
 		return
 	}
 
 	idStr := c.Param("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid item ID"})
+		c.Error(apperr.Validation("Invalid item ID"))
 		return
 	}
 
 	err = h.itemService.DeleteItem(id)
 	if err != nil {
-		if err.Error() == "item not found" {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Item not found"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -352,14 +759,14 @@ func (h *ItemHandler) ResetItems(c *gin.Context) {
 	// Get user ID from context
 	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		c.Error(apperr.Unauthorized("User not authenticated"))
 		return
 	}
 
 	// Use the new method that resets user-specific progress
 	rowsAffected, err := h.itemService.ResetAllItemsWithUserProgress(userID.(int))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -376,7 +783,7 @@ func (h *ItemHandler) GetSubcategories(c *gin.Context) {
 
 	subcategories, err := h.itemService.GetCommonSubcategories(category)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(apperr.Classify(apperr.KindValidation, err))
 		return
 	}
 
@@ -391,66 +798,173 @@ func (h *ItemHandler) ToggleStar(c *gin.Context) {
 	// Get user ID from context
 	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		c.Error(apperr.Unauthorized("User not authenticated"))
 		return
 	}
 
 	idStr := c.Param("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid item ID"})
+		c.Error(apperr.Validation("Invalid item ID"))
 		return
 	}
 
 	// Use the new method that includes user progress
 	item, err := h.itemService.ToggleStarWithUserProgress(userID.(int), id)
 	if err != nil {
-		if err.Error() == "item not found" {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Item not found"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, item)
 }
 
+// BatchStar handles PUT /items/star/batch
+func (h *ItemHandler) BatchStar(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	var req models.BatchStarRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	result, err := h.itemService.SetStarredBatch(userID.(int), &req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
 // UpdateStatus handles PUT /items/:id/status
 func (h *ItemHandler) UpdateStatus(c *gin.Context) {
 	// Get user ID from context
 	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		c.Error(apperr.Unauthorized("User not authenticated"))
 		return
 	}
 
 	idStr := c.Param("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid item ID"})
+		c.Error(apperr.Validation("Invalid item ID"))
 		return
 	}
 
 	var req struct {
 		Status string `json:"status" binding:"required"`
 	}
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
 		return
 	}
 
 	status := models.Status(req.Status)
 	// Use the new method that includes user progress
-	item, err := h.itemService.UpdateStatusWithUserProgress(userID.(int), id, status)
+	item, err := h.itemService.UpdateStatusWithUserProgress(c.Request.Context(), userID.(int), id, status)
 	if err != nil {
-		if err.Error() == "item not found" {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Item not found"})
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// GetItemAnalytics handles GET /admin/analytics/items - Requires content:write.
+// Reports per-item completion, skip and star stats across all users so hard or low-quality
+// items can be found. Query: category, subcategory, min_attempts (default 1), sort, order
+// (asc or desc, default asc), limit and offset.
+func (h *ItemHandler) GetItemAnalytics(c *gin.Context) {
+	if err := h.requireContentWrite(c); err != nil {
+		c.Error(apperr.Forbidden("content:write permission required to view item analytics"))
+		return
+	}
+
+	filter := &models.ItemAnalyticsFilter{
+		MinAttempts: 1,
+		Sort:        c.Query("sort"),
+	}
+
+	if categoryStr := c.Query("category"); categoryStr != "" {
+		category := models.Category(categoryStr)
+		filter.Category = &category
+	}
+
+	if subcategory := c.Query("subcategory"); subcategory != "" {
+		filter.Subcategory = &subcategory
+	}
+
+	switch c.DefaultQuery("order", "asc") {
+	case "asc":
+	case "desc":
+		filter.Descending = true
+	default:
+		c.Error(apperr.Validation("Invalid order parameter. Must be 'asc' or 'desc'"))
+		return
+	}
+
+	if minAttemptsStr := c.Query("min_attempts"); minAttemptsStr != "" {
+		minAttempts, err := strconv.Atoi(minAttemptsStr)
+		if err != nil {
+			c.Error(apperr.Validation("Invalid min_attempts parameter"))
 			return
 		}
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		filter.MinAttempts = minAttempts
+	}
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil {
+			c.Error(apperr.Validation("Invalid limit parameter"))
+			return
+		}
+		filter.Limit = limit
+	}
+
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		offset, err := strconv.Atoi(offsetStr)
+		if err != nil {
+			c.Error(apperr.Validation("Invalid offset parameter"))
+			return
+		}
+		filter.Offset = offset
+	}
+
+	result, err := h.itemService.GetItemAnalytics(filter)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
 		return
 	}
 
-	c.JSON(http.StatusOK, item)
+	c.JSON(http.StatusOK, result)
+}
+
+// GetItemAnalyticsByID handles GET /admin/analytics/items/:id - Requires content:write
+func (h *ItemHandler) GetItemAnalyticsByID(c *gin.Context) {
+	if err := h.requireContentWrite(c); err != nil {
+		c.Error(apperr.Forbidden("content:write permission required to view item analytics"))
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid item ID"))
+		return
+	}
+
+	analytics, err := h.itemService.GetItemAnalyticsByID(id)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, analytics)
 }
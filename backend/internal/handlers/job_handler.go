@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"net/http"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/services"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JobHandler handles HTTP requests for inspecting and triggering background jobs
+type JobHandler struct {
+	jobService  *services.JobService
+	userService *services.UserService
+}
+
+// NewJobHandler creates a new job handler
+func NewJobHandler(jobService *services.JobService, userService *services.UserService) *JobHandler {
+	return &JobHandler{
+		jobService:  jobService,
+		userService: userService,
+	}
+}
+
+// GetJobs handles GET /admin/jobs - Lists the background jobs with their last runs. Requires system:manage.
+func (h *JobHandler) GetJobs(c *gin.Context) {
+	if err := requirePermission(c, h.userService, models.PermissionSystemManage); err != nil {
+		c.Error(apperr.Forbidden("system:manage permission required to view jobs"))
+		return
+	}
+
+	statuses, err := h.jobService.ListJobs()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"jobs": statuses})
+}
+
+// GetRuns handles GET /admin/jobs/:name/runs - Lists a job's recent runs. Requires system:manage.
+func (h *JobHandler) GetRuns(c *gin.Context) {
+	if err := requirePermission(c, h.userService, models.PermissionSystemManage); err != nil {
+		c.Error(apperr.Forbidden("system:manage permission required to view jobs"))
+		return
+	}
+
+	runs, err := h.jobService.GetRuns(c.Param("name"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"runs": runs})
+}
+
+// TriggerJob handles POST /admin/jobs/:name/run - Starts a job now in the background. Requires system:manage.
+func (h *JobHandler) TriggerJob(c *gin.Context) {
+	if err := requirePermission(c, h.userService, models.PermissionSystemManage); err != nil {
+		c.Error(apperr.Forbidden("system:manage permission required to run jobs"))
+		return
+	}
+
+	name := c.Param("name")
+	if err := h.jobService.Trigger(name); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "Job started", "job": name})
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/services"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LifecycleHandler handles HTTP requests for account deletion and inactive-user archival metrics
+type LifecycleHandler struct {
+	lifecycleService *services.LifecycleService
+	userService      *services.UserService
+}
+
+// NewLifecycleHandler creates a new lifecycle handler
+func NewLifecycleHandler(lifecycleService *services.LifecycleService, userService *services.UserService) *LifecycleHandler {
+	return &LifecycleHandler{
+		lifecycleService: lifecycleService,
+		userService:      userService,
+	}
+}
+
+// GetRuns handles GET /admin/lifecycle/runs - Requires system:manage.
+// Reports what recent archival passes reclaimed.
+func (h *LifecycleHandler) GetRuns(c *gin.Context) {
+	if err := requirePermission(c, h.userService, models.PermissionSystemManage); err != nil {
+		c.Error(apperr.Forbidden("system:manage permission required to view lifecycle runs"))
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	runs, err := h.lifecycleService.GetRecentRuns(limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"runs": runs})
+}
+
+// DeleteAccount handles DELETE /user/account.
+// Deletes the current user's account once they confirm their password or provider token.
+func (h *LifecycleHandler) DeleteAccount(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	var req models.DeleteAccountRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	if err := h.userService.ConfirmIdentity(userID.(int), &req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	response, err := h.lifecycleService.DeleteAccount(userID.(int))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/services"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LinkCheckHandler handles HTTP requests for the admin dead link report
+type LinkCheckHandler struct {
+	linkCheckService *services.LinkCheckService
+	userService      *services.UserService
+}
+
+// NewLinkCheckHandler creates a new link check handler
+func NewLinkCheckHandler(linkCheckService *services.LinkCheckService, userService *services.UserService) *LinkCheckHandler {
+	return &LinkCheckHandler{
+		linkCheckService: linkCheckService,
+		userService:      userService,
+	}
+}
+
+// GetDeadLinks handles GET /admin/links/dead - Requires content:write.
+// Query: type (item or eng_blog_article), limit and offset.
+func (h *LinkCheckHandler) GetDeadLinks(c *gin.Context) {
+	if err := requirePermission(c, h.userService, models.PermissionContentWrite); err != nil {
+		c.Error(apperr.Forbidden("content:write permission required to view dead links"))
+		return
+	}
+
+	var targetType *models.LinkTargetType
+	if typeStr := c.Query("type"); typeStr != "" {
+		value := models.LinkTargetType(typeStr)
+		targetType = &value
+	}
+
+	limit := 0
+	if limitStr := c.Query("limit"); limitStr != "" {
+		var err error
+		if limit, err = strconv.Atoi(limitStr); err != nil {
+			c.Error(apperr.Validation("Invalid limit parameter"))
+			return
+		}
+	}
+
+	offset := 0
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		var err error
+		if offset, err = strconv.Atoi(offsetStr); err != nil {
+			c.Error(apperr.Validation("Invalid offset parameter"))
+			return
+		}
+	}
+
+	report, err := h.linkCheckService.GetDeadLinks(targetType, limit, offset)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
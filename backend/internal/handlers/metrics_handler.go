@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MetricsHandler serves domain metrics to a Prometheus scraper authorized by a bearer token
+type MetricsHandler struct {
+	metrics http.Handler
+	token   string
+}
+
+// NewMetricsHandler creates a new metrics handler
+func NewMetricsHandler(metrics http.Handler, token string) *MetricsHandler {
+	return &MetricsHandler{
+		metrics: metrics,
+		token:   token,
+	}
+}
+
+// ServeMetrics handles GET /metrics - Requires "Authorization: Bearer <METRICS_TOKEN>"
+func (h *MetricsHandler) ServeMetrics(c *gin.Context) {
+	token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) != 1 {
+		c.Error(apperr.Unauthorized("Invalid metrics token"))
+		return
+	}
+
+	h.metrics.ServeHTTP(c.Writer, c.Request)
+}
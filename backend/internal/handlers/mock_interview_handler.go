@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/services"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MockInterviewHandler handles HTTP requests for AI mock interviews
+type MockInterviewHandler struct {
+	interviewService *services.MockInterviewService
+}
+
+// NewMockInterviewHandler creates a new mock interview handler
+func NewMockInterviewHandler(interviewService *services.MockInterviewService) *MockInterviewHandler {
+	return &MockInterviewHandler{
+		interviewService: interviewService,
+	}
+}
+
+// GetInterviews handles GET /ai/mock-interviews - Lists the user's mock interviews, newest first.
+// Query: item_id, test_session_id and limit.
+func (h *MockInterviewHandler) GetInterviews(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	var itemID *int
+	if itemIDStr := c.Query("item_id"); itemIDStr != "" {
+		id, err := strconv.Atoi(itemIDStr)
+		if err != nil {
+			c.Error(apperr.Validation("Invalid item_id parameter"))
+			return
+		}
+		itemID = &id
+	}
+
+	limit := 0
+	if limitStr := c.Query("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil {
+			c.Error(apperr.Validation("Invalid limit parameter"))
+			return
+		}
+	}
+
+	interviews, err := h.interviewService.GetInterviews(userID.(int), itemID, c.Query("test_session_id"), limit)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, interviews)
+}
+
+// StartInterview handles POST /ai/mock-interviews - Starts a mock interview on an item and
+// returns it with the interviewer's opening question
+func (h *MockInterviewHandler) StartInterview(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	var req models.StartMockInterviewRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	interview, err := h.interviewService.StartInterview(c.Request.Context(), userID.(int), &req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, interview)
+}
+
+// GetInterview handles GET /ai/mock-interviews/:id - Returns a mock interview with its transcript
+func (h *MockInterviewHandler) GetInterview(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	id, ok := mockInterviewIDParam(c)
+	if !ok {
+		return
+	}
+
+	interview, err := h.interviewService.GetInterview(userID.(int), id)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, interview)
+}
+
+// Reply handles POST /ai/mock-interviews/:id/messages - Sends the candidate's reply and returns
+// the interview with the interviewer's answer
+func (h *MockInterviewHandler) Reply(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	id, ok := mockInterviewIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req models.MockInterviewReplyRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	interview, err := h.interviewService.Reply(c.Request.Context(), userID.(int), id, &req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, interview)
+}
+
+// EndInterview handles POST /ai/mock-interviews/:id/end - Ends the interview with the
+// interviewer's feedback
+func (h *MockInterviewHandler) EndInterview(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	id, ok := mockInterviewIDParam(c)
+	if !ok {
+		return
+	}
+
+	interview, err := h.interviewService.EndInterview(c.Request.Context(), userID.(int), id)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, interview)
+}
+
+// DeleteInterview handles DELETE /ai/mock-interviews/:id
+func (h *MockInterviewHandler) DeleteInterview(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	id, ok := mockInterviewIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.interviewService.DeleteInterview(userID.(int), id); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Mock interview deleted successfully"})
+}
+
+// mockInterviewIDParam parses the :id path parameter, responding with 400 when it isn't a number
+func mockInterviewIDParam(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid mock interview ID"))
+		return 0, false
+	}
+
+	return id, true
+}
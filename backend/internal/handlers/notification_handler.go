@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/services"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NotificationHandler handles HTTP requests for notification preferences and push devices
+type NotificationHandler struct {
+	reminderService     *services.ReminderService
+	notificationService *services.NotificationService
+}
+
+// NewNotificationHandler creates a new notification handler
+func NewNotificationHandler(reminderService *services.ReminderService, notificationService *services.NotificationService) *NotificationHandler {
+	return &NotificationHandler{
+		reminderService:     reminderService,
+		notificationService: notificationService,
+	}
+}
+
+// GetPreferences handles GET /user/notifications
+func (h *NotificationHandler) GetPreferences(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	prefs, err := h.reminderService.GetPreferences(userID.(int))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, prefs)
+}
+
+// UpdatePreferences handles PUT /user/notifications
+func (h *NotificationHandler) UpdatePreferences(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	var req models.UpdateNotificationPreferencesRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	prefs, err := h.reminderService.UpdatePreferences(userID.(int), &req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, prefs)
+}
+
+// GetDevices handles GET /user/devices
+func (h *NotificationHandler) GetDevices(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	devices, err := h.notificationService.GetDevices(userID.(int))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"devices":          devices,
+		"vapid_public_key": h.notificationService.VAPIDPublicKey(),
+	})
+}
+
+// RegisterDevice handles POST /user/devices
+func (h *NotificationHandler) RegisterDevice(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	var req models.RegisterDeviceRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	device, err := h.notificationService.RegisterDevice(userID.(int), &req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, device)
+}
+
+// RemoveDevice handles DELETE /user/devices/:id
+func (h *NotificationHandler) RemoveDevice(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid device ID"))
+		return
+	}
+
+	if err := h.notificationService.RemoveDevice(userID.(int), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Device removed successfully"})
+}
@@ -0,0 +1,460 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/services"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OrgHandler handles HTTP requests for organizations and invitations
+type OrgHandler struct {
+	orgService  *services.OrgService
+	userService *services.UserService
+}
+
+// NewOrgHandler creates a new organization handler
+func NewOrgHandler(orgService *services.OrgService, userService *services.UserService) *OrgHandler {
+	return &OrgHandler{
+		orgService:  orgService,
+		userService: userService,
+	}
+}
+
+// CreateOrganization handles POST /orgs and POST /admin/orgs - The creator becomes the owner
+func (h *OrgHandler) CreateOrganization(c *gin.Context) {
+	var req models.CreateOrganizationRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	org, err := h.orgService.CreateOrganization(c.GetInt("userID"), &req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, org)
+}
+
+// BulkInvite handles POST /orgs/:id/invitations and POST /admin/orgs/:id/invitations -
+// Organization owners and admins. Accepts a CSV either as a multipart "file" field or as a
+// text/csv request body.
+func (h *OrgHandler) BulkInvite(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid organization ID"))
+		return
+	}
+
+	var body io.Reader = c.Request.Body
+	if fileHeader, err := c.FormFile("file"); err == nil {
+		file, err := fileHeader.Open()
+		if err != nil {
+			c.Error(apperr.Validation("Failed to read uploaded file"))
+			return
+		}
+		defer file.Close()
+		body = file
+	}
+
+	result, err := h.orgService.BulkInvite(id, c.GetInt("userID"), body)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// GetInvitations handles GET /orgs/:id/invitations and GET /admin/orgs/:id/invitations -
+// Organization owners and admins
+func (h *OrgHandler) GetInvitations(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid organization ID"))
+		return
+	}
+
+	invitations, err := h.orgService.GetInvitations(c.GetInt("userID"), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"invitations": invitations})
+}
+
+// AcceptInvitation handles POST /orgs/invitations/accept for users who already have an account
+func (h *OrgHandler) AcceptInvitation(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	var req models.AcceptInvitationRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	org, err := h.orgService.AcceptInvitation(userID.(int), req.Token)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			c.Error(apperr.NotFound("Invitation not found or expired"))
+			return
+		}
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Invitation accepted", "organization": org})
+}
+
+// CreateServiceAccount handles POST /orgs/:id/service-accounts - Organization owners only
+func (h *OrgHandler) CreateServiceAccount(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid organization ID"))
+		return
+	}
+
+	var req models.CreateServiceAccountRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	result, err := h.orgService.CreateServiceAccount(userID.(int), id, &req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// GetServiceAccounts handles GET /orgs/:id/service-accounts - Organization owners only
+func (h *OrgHandler) GetServiceAccounts(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid organization ID"))
+		return
+	}
+
+	accounts, err := h.orgService.GetServiceAccounts(userID.(int), id)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"service_accounts": accounts})
+}
+
+// RevokeServiceAccount handles DELETE /orgs/:id/service-accounts/:account_id - Organization owners only
+func (h *OrgHandler) RevokeServiceAccount(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid organization ID"))
+		return
+	}
+
+	accountID, err := strconv.Atoi(c.Param("account_id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid service account ID"))
+		return
+	}
+
+	if err := h.orgService.RevokeServiceAccount(userID.(int), id, accountID); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Service account revoked"})
+}
+
+// GetMemberProgress handles GET /analytics/orgs/:id/progress - Service accounts with analytics:read
+func (h *OrgHandler) GetMemberProgress(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid organization ID"))
+		return
+	}
+
+	// Service accounts can only read their own organization
+	if c.GetInt("serviceAccountOrgID") != id {
+		c.Error(apperr.Forbidden("API key is not valid for this organization"))
+		return
+	}
+
+	members, err := h.orgService.GetMemberProgress(id)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"org_id": id, "members": members})
+}
+
+// GetCohortAnalytics handles GET /admin/orgs/:id/analytics - Organization owners and admins.
+// Pass ?anonymize=true to hide member identities; admins always get them hidden.
+func (h *OrgHandler) GetCohortAnalytics(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid organization ID"))
+		return
+	}
+
+	anonymize := c.Query("anonymize") == "true"
+
+	analytics, err := h.orgService.GetCohortAnalytics(userID.(int), id, anonymize)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, analytics)
+}
+
+// GetMyOrganizations handles GET /orgs - The organizations the user belongs to
+func (h *OrgHandler) GetMyOrganizations(c *gin.Context) {
+	orgs, err := h.orgService.GetMyOrganizations(c.GetInt("userID"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"organizations": orgs})
+}
+
+// GetOrganization handles GET /orgs/:id - Organization members
+func (h *OrgHandler) GetOrganization(c *gin.Context) {
+	userID, orgID, ok := orgRequestIDs(c)
+	if !ok {
+		return
+	}
+
+	org, err := h.orgService.GetOrganization(userID, orgID)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, org)
+}
+
+// GetMembers handles GET /orgs/:id/members - Organization owners and admins
+func (h *OrgHandler) GetMembers(c *gin.Context) {
+	userID, orgID, ok := orgRequestIDs(c)
+	if !ok {
+		return
+	}
+
+	members, err := h.orgService.GetMembers(userID, orgID)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"members": members})
+}
+
+// UpdateMember handles PUT /orgs/:id/members/:user_id - Organization owners only
+func (h *OrgHandler) UpdateMember(c *gin.Context) {
+	userID, orgID, ok := orgRequestIDs(c)
+	if !ok {
+		return
+	}
+
+	memberID, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid user ID"))
+		return
+	}
+
+	var req models.UpdateOrgMemberRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	if err := h.orgService.UpdateMemberRole(userID, orgID, memberID, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Member role updated"})
+}
+
+// RemoveMember handles DELETE /orgs/:id/members/:user_id - Members can remove themselves,
+// admins can remove members and owners can remove anyone
+func (h *OrgHandler) RemoveMember(c *gin.Context) {
+	userID, orgID, ok := orgRequestIDs(c)
+	if !ok {
+		return
+	}
+
+	memberID, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid user ID"))
+		return
+	}
+
+	if err := h.orgService.RemoveMember(userID, orgID, memberID); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Member removed"})
+}
+
+// GetOrgAnalytics handles GET /orgs/:id/analytics - Organization owners and admins. Results are
+// anonymized unless an owner passes ?anonymize=false.
+func (h *OrgHandler) GetOrgAnalytics(c *gin.Context) {
+	userID, orgID, ok := orgRequestIDs(c)
+	if !ok {
+		return
+	}
+
+	anonymize := c.Query("anonymize") != "false"
+
+	analytics, err := h.orgService.GetCohortAnalytics(userID, orgID, anonymize)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, analytics)
+}
+
+// GetCatalog handles GET /orgs/:id/catalog - Organization members
+func (h *OrgHandler) GetCatalog(c *gin.Context) {
+	userID, orgID, ok := orgRequestIDs(c)
+	if !ok {
+		return
+	}
+
+	items, err := h.orgService.GetOrgCatalog(userID, orgID)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"items": items})
+}
+
+// SetCatalogItem handles PUT /orgs/:id/catalog/:item_id - Organization owners and admins
+func (h *OrgHandler) SetCatalogItem(c *gin.Context) {
+	userID, orgID, ok := orgRequestIDs(c)
+	if !ok {
+		return
+	}
+
+	itemID, err := strconv.Atoi(c.Param("item_id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid item ID"))
+		return
+	}
+
+	// Position and note are optional, so an empty body is fine
+	var req models.SetOrgCatalogItemRequest
+	if c.Request.ContentLength > 0 {
+		if err := bindJSON(c, &req); err != nil {
+			c.Error(apperr.Classify(apperr.KindValidation, err))
+			return
+		}
+	}
+
+	item, err := h.orgService.SetOrgCatalogItem(userID, orgID, itemID, &req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// RemoveCatalogItem handles DELETE /orgs/:id/catalog/:item_id - Organization owners and admins
+func (h *OrgHandler) RemoveCatalogItem(c *gin.Context) {
+	userID, orgID, ok := orgRequestIDs(c)
+	if !ok {
+		return
+	}
+
+	itemID, err := strconv.Atoi(c.Param("item_id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid item ID"))
+		return
+	}
+
+	if err := h.orgService.RemoveOrgCatalogItem(userID, orgID, itemID); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Item removed from the organization's catalog"})
+}
+
+// MemberRole resolves a user's role in an organization (used by middleware)
+func (h *OrgHandler) MemberRole(userID, orgID int) (models.OrgRole, error) {
+	return h.orgService.MemberRole(userID, orgID)
+}
+
+// AuthenticateServiceAccount resolves an API key to its service account (used by middleware)
+func (h *OrgHandler) AuthenticateServiceAccount(key string) (*models.ServiceAccount, error) {
+	return h.orgService.AuthenticateServiceAccount(key)
+}
+
+// orgRequestIDs extracts the authenticated user and organization ID, writing an error response on failure
+func orgRequestIDs(c *gin.Context) (int, int, bool) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return 0, 0, false
+	}
+
+	orgID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid organization ID"))
+		return 0, 0, false
+	}
+
+	return userID.(int), orgID, true
+}
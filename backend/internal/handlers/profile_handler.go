@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"net/http"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/services"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProfileHandler handles HTTP requests for public profiles
+type ProfileHandler struct {
+	profileService *services.ProfileService
+}
+
+// NewProfileHandler creates a new profile handler
+func NewProfileHandler(profileService *services.ProfileService) *ProfileHandler {
+	return &ProfileHandler{
+		profileService: profileService,
+	}
+}
+
+// GetSettings handles GET /user/public-profile
+func (h *ProfileHandler) GetSettings(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	settings, err := h.profileService.GetSettings(userID.(int))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// UpdateSettings handles PUT /user/public-profile
+func (h *ProfileHandler) UpdateSettings(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	var req models.UpdatePublicProfileRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	settings, err := h.profileService.UpdateSettings(userID.(int), &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// RegenerateHandle handles POST /user/public-profile/handle, revoking links to the old handle
+func (h *ProfileHandler) RegenerateHandle(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	settings, err := h.profileService.RegenerateHandle(userID.(int))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// GetPublicProfile handles GET /api/v1/public/profiles/:handle (public, authorized by the handle)
+func (h *ProfileHandler) GetPublicProfile(c *gin.Context) {
+	profile, err := h.profileService.GetPublicProfile(c.Param("handle"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// Short-lived so disabling a profile or regenerating its handle takes effect quickly
+	c.Header("Cache-Control", "public, max-age=60")
+	c.JSON(http.StatusOK, profile)
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/services"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProgressHandler handles HTTP requests for a user's raw progress records
+type ProgressHandler struct {
+	progressService *services.ProgressService
+}
+
+// NewProgressHandler creates a new progress handler
+func NewProgressHandler(progressService *services.ProgressService) *ProgressHandler {
+	return &ProgressHandler{
+		progressService: progressService,
+	}
+}
+
+// GetProgress handles GET /user/progress?status=&sort_by=&sort_order=&limit=&offset=
+func (h *ProgressHandler) GetProgress(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	filter := &models.ProgressFilter{
+		SortBy:    c.Query("sort_by"),
+		SortOrder: c.Query("sort_order"),
+	}
+
+	if statusStr := c.Query("status"); statusStr != "" {
+		status := models.Status(statusStr)
+		filter.Status = &status
+	}
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil {
+			c.Error(apperr.Validation("Invalid limit parameter"))
+			return
+		}
+		filter.Limit = limit
+	}
+
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		offset, err := strconv.Atoi(offsetStr)
+		if err != nil {
+			c.Error(apperr.Validation("Invalid offset parameter"))
+			return
+		}
+		filter.Offset = offset
+	}
+
+	progress, err := h.progressService.GetProgress(userID.(int), filter)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, progress)
+}
+
+// ImportProgress handles POST /user/import?dry_run=true - Imports progress from a CSV uploaded
+// as the "file" form field or sent as the request body
+func (h *ProgressHandler) ImportProgress(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	dryRun := false
+	if dryRunStr := c.Query("dry_run"); dryRunStr != "" {
+		var err error
+		dryRun, err = strconv.ParseBool(dryRunStr)
+		if err != nil {
+			c.Error(apperr.Validation("Invalid dry_run parameter"))
+			return
+		}
+	}
+
+	var body io.Reader = c.Request.Body
+	if fileHeader, err := c.FormFile("file"); err == nil {
+		file, err := fileHeader.Open()
+		if err != nil {
+			c.Error(apperr.Validation("Failed to read uploaded file"))
+			return
+		}
+		defer file.Close()
+		body = file
+	}
+
+	report, err := h.progressService.ImportProgress(userID.(int), body, dryRun)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	status := http.StatusOK
+	if !dryRun && report.Created > 0 {
+		status = http.StatusCreated
+	}
+	c.JSON(status, report)
+}
@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/services"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// QuizHandler handles HTTP requests for multiple-choice quizzes and users' graded attempts
+type QuizHandler struct {
+	quizService *services.QuizService
+	userService *services.UserService
+}
+
+// NewQuizHandler creates a new quiz handler
+func NewQuizHandler(quizService *services.QuizService, userService *services.UserService) *QuizHandler {
+	return &QuizHandler{
+		quizService: quizService,
+		userService: userService,
+	}
+}
+
+// GetQuizzes handles GET /quizzes - Lists quizzes with the user's latest score on each. Query:
+// category and subcategory.
+func (h *QuizHandler) GetQuizzes(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	filter := &models.QuizFilter{}
+	if categoryStr := c.Query("category"); categoryStr != "" {
+		category := models.Category(categoryStr)
+		filter.Category = &category
+	}
+	if subcategory := c.Query("subcategory"); subcategory != "" {
+		filter.Subcategory = &subcategory
+	}
+
+	quizzes, err := h.quizService.GetQuizzes(userID.(int), filter)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, quizzes)
+}
+
+// GetQuiz handles GET /quizzes/:id - Returns a quiz's questions, with the answers only for
+// users with content:write
+func (h *QuizHandler) GetQuiz(c *gin.Context) {
+	id, ok := quizIDParam(c)
+	if !ok {
+		return
+	}
+
+	withAnswers := requirePermission(c, h.userService, models.PermissionContentWrite) == nil
+	quiz, err := h.quizService.GetQuiz(id, withAnswers)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, quiz)
+}
+
+// SubmitAttempt handles POST /quizzes/:id/attempts - Grades the user's answers and records the attempt
+func (h *QuizHandler) SubmitAttempt(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	id, ok := quizIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req models.SubmitQuizAttemptRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	attempt, err := h.quizService.SubmitAttempt(userID.(int), id, &req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, attempt)
+}
+
+// GetAttempts handles GET /quizzes/:id/attempts - Lists the user's graded attempts at a quiz, newest first
+func (h *QuizHandler) GetAttempts(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	id, ok := quizIDParam(c)
+	if !ok {
+		return
+	}
+
+	attempts, err := h.quizService.GetAttempts(userID.(int), id)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, attempts)
+}
+
+// CreateQuiz handles POST /quizzes - Requires content:write
+func (h *QuizHandler) CreateQuiz(c *gin.Context) {
+	if err := requirePermission(c, h.userService, models.PermissionContentWrite); err != nil {
+		c.Error(apperr.Forbidden("content:write permission required to manage quizzes"))
+		return
+	}
+
+	var req models.SaveQuizRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	quiz, err := h.quizService.CreateQuiz(&req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, quiz)
+}
+
+// UpdateQuiz handles PUT /quizzes/:id - Requires content:write. The questions sent replace all
+// of the quiz's questions.
+func (h *QuizHandler) UpdateQuiz(c *gin.Context) {
+	if err := requirePermission(c, h.userService, models.PermissionContentWrite); err != nil {
+		c.Error(apperr.Forbidden("content:write permission required to manage quizzes"))
+		return
+	}
+
+	id, ok := quizIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req models.SaveQuizRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	quiz, err := h.quizService.UpdateQuiz(id, &req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, quiz)
+}
+
+// DeleteQuiz handles DELETE /quizzes/:id - Requires content:write. Attempts at the quiz are
+// deleted with it.
+func (h *QuizHandler) DeleteQuiz(c *gin.Context) {
+	if err := requirePermission(c, h.userService, models.PermissionContentWrite); err != nil {
+		c.Error(apperr.Forbidden("content:write permission required to manage quizzes"))
+		return
+	}
+
+	id, ok := quizIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.quizService.DeleteQuiz(id); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Quiz deleted successfully"})
+}
+
+// quizIDParam parses the :id path parameter, responding with 400 when it isn't a number
+func quizIDParam(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid quiz ID"))
+		return 0, false
+	}
+
+	return id, true
+}
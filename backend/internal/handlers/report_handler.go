@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/services"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReportHandler handles HTTP requests for shared progress reports
+type ReportHandler struct {
+	reportService *services.ReportService
+}
+
+// NewReportHandler creates a new report handler
+func NewReportHandler(reportService *services.ReportService) *ReportHandler {
+	return &ReportHandler{
+		reportService: reportService,
+	}
+}
+
+// ShareReport handles POST /user/reports/share, returning signed links to a snapshot of the
+// user's progress
+func (h *ReportHandler) ShareReport(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	// The body is optional; without one the link lasts the default time
+	var req models.ShareReportRequest
+	if c.Request.ContentLength != 0 {
+		if err := bindJSON(c, &req); err != nil {
+			c.Error(apperr.Classify(apperr.KindValidation, err))
+			return
+		}
+	}
+
+	link, err := h.reportService.ShareReport(userID.(int), &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, link)
+}
+
+// GetReport handles GET /api/v1/reports/:id?expires=...&signature=...[&format=html]
+// (public, authorized by the link signature)
+func (h *ReportHandler) GetReport(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.NotFound("report not found or expired"))
+		return
+	}
+
+	report, err := h.reportService.GetSharedReport(id, c.Query("expires"), c.Query("signature"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("Cache-Control", "private, no-store")
+	c.Header("X-Robots-Tag", "noindex")
+	if c.Query("format") != "html" {
+		c.JSON(http.StatusOK, report)
+		return
+	}
+
+	body, err := h.reportService.RenderHTML(report)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.Data(http.StatusOK, "text/html; charset=utf-8", body)
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/services"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ShareHandler handles HTTP requests for sharing items between users
+type ShareHandler struct {
+	shareService *services.ShareService
+}
+
+// NewShareHandler creates a new share handler
+func NewShareHandler(shareService *services.ShareService) *ShareHandler {
+	return &ShareHandler{
+		shareService: shareService,
+	}
+}
+
+// ShareItem handles POST /items/:id/share
+func (h *ShareHandler) ShareItem(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid item ID"))
+		return
+	}
+
+	var req models.ShareItemRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	share, err := h.shareService.ShareItem(userID.(int), id, &req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, share)
+}
+
+// GetInbox handles GET /shares/inbox - Returns pending items shared with the user
+func (h *ShareHandler) GetInbox(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	shares, err := h.shareService.GetInbox(userID.(int))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"shares": shares, "count": len(shares)})
+}
+
+// AcceptShare handles PUT /shares/:id/accept
+func (h *ShareHandler) AcceptShare(c *gin.Context) {
+	h.respond(c, models.ShareStatusAccepted)
+}
+
+// DismissShare handles PUT /shares/:id/dismiss
+func (h *ShareHandler) DismissShare(c *gin.Context) {
+	h.respond(c, models.ShareStatusDismissed)
+}
+
+// respond updates a share's status on behalf of the recipient
+func (h *ShareHandler) respond(c *gin.Context, status models.ShareStatus) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid share ID"))
+		return
+	}
+
+	if err := h.shareService.RespondToShare(userID.(int), id, status); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Share updated successfully", "status": status})
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"interview-prep-app/internal/services"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SimilarItemHandler handles HTTP requests for items related in meaning
+type SimilarItemHandler struct {
+	embeddingService *services.EmbeddingService
+}
+
+// NewSimilarItemHandler creates a new similar item handler
+func NewSimilarItemHandler(embeddingService *services.EmbeddingService) *SimilarItemHandler {
+	return &SimilarItemHandler{
+		embeddingService: embeddingService,
+	}
+}
+
+// GetSimilarItems handles GET /items/:id/similar?limit=10 - Returns the items closest in meaning
+// to an item across all categories
+func (h *SimilarItemHandler) GetSimilarItems(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid item ID"))
+		return
+	}
+
+	limit := 0
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil {
+			c.Error(apperr.Validation("Invalid limit parameter"))
+			return
+		}
+	}
+
+	similar, err := h.embeddingService.GetSimilar(c.Request.Context(), id, limit)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, similar)
+}
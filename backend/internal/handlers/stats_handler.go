@@ -5,6 +5,7 @@ import (
 
 	"interview-prep-app/internal/models"
 	"interview-prep-app/internal/services"
+	"interview-prep-app/pkg/apperr"
 
 	"github.com/gin-gonic/gin"
 )
@@ -24,18 +25,18 @@ func (h *StatsHandler) GetStats(c *gin.Context) {
 	// Get user ID from context
 	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		c.Error(apperr.Unauthorized("User not authenticated"))
 		return
 	}
 
 	// Use the new method that gets user-specific statistics
 	stats, err := h.statsService.GetOverallStatsForUser(userID.(int))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, stats)
+	respondFields(c, stats)
 }
 
 // GetDetailedStats handles GET /stats/detailed
@@ -43,18 +44,18 @@ func (h *StatsHandler) GetDetailedStats(c *gin.Context) {
 	// Get user ID from context
 	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		c.Error(apperr.Unauthorized("User not authenticated"))
 		return
 	}
 
 	// Use the new method that gets user-specific detailed statistics
 	stats, err := h.statsService.GetDetailedStatsForUser(userID.(int))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, stats)
+	respondFields(c, stats)
 }
 
 // GetCategoryStats handles GET /stats/category/:category
@@ -62,7 +63,7 @@ func (h *StatsHandler) GetCategoryStats(c *gin.Context) {
 	// Get user ID from context
 	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		c.Error(apperr.Unauthorized("User not authenticated"))
 		return
 	}
 
@@ -72,11 +73,11 @@ func (h *StatsHandler) GetCategoryStats(c *gin.Context) {
 	// Use the new method that gets user-specific category statistics
 	stats, err := h.statsService.GetCategoryStatsForUser(userID.(int), category)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(apperr.Classify(apperr.KindValidation, err))
 		return
 	}
 
-	c.JSON(http.StatusOK, stats)
+	respondFields(c, stats)
 }
 
 // GetSubcategoryStats handles GET /stats/category/:category/subcategory/:subcategory
@@ -84,7 +85,7 @@ func (h *StatsHandler) GetSubcategoryStats(c *gin.Context) {
 	// Get user ID from context
 	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		c.Error(apperr.Unauthorized("User not authenticated"))
 		return
 	}
 
@@ -95,11 +96,11 @@ func (h *StatsHandler) GetSubcategoryStats(c *gin.Context) {
 	// Use the new method that gets user-specific subcategory statistics
 	stats, err := h.statsService.GetSubcategoryStatsForUser(userID.(int), category, subcategory)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(apperr.Classify(apperr.KindValidation, err))
 		return
 	}
 
-	c.JSON(http.StatusOK, stats)
+	respondFields(c, stats)
 }
 
 // ResetCompletedAllCount handles POST /stats/reset-completed-all
@@ -107,16 +108,58 @@ func (h *StatsHandler) ResetCompletedAllCount(c *gin.Context) {
 	// Get user ID from context
 	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		c.Error(apperr.Unauthorized("User not authenticated"))
 		return
 	}
 
 	// Use the new method that resets user-specific completed all count
 	err := h.statsService.ResetUserCompletedAllCount(userID.(int))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Your completed all count has been reset to zero"})
 }
+
+// GetGoals handles GET /user/goals
+func (h *StatsHandler) GetGoals(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	progress, err := h.statsService.GetDailyGoalProgress(userID.(int))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, progress)
+}
+
+// UpdateGoals handles PUT /user/goals
+func (h *StatsHandler) UpdateGoals(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	var req models.UpdateDailyGoalRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	progress, err := h.statsService.SetDailyGoal(userID.(int), *req.DailyGoal)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, progress)
+}
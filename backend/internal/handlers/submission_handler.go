@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/services"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SubmissionHandler handles HTTP requests for DSA item test cases and judged submissions
+type SubmissionHandler struct {
+	submissionService *services.SubmissionService
+	userService       *services.UserService
+}
+
+// NewSubmissionHandler creates a new submission handler
+func NewSubmissionHandler(submissionService *services.SubmissionService, userService *services.UserService) *SubmissionHandler {
+	return &SubmissionHandler{
+		submissionService: submissionService,
+		userService:       userService,
+	}
+}
+
+// GetTestCases handles GET /items/:id/test-cases - Users with content:write see every case, other users only the samples
+func (h *SubmissionHandler) GetTestCases(c *gin.Context) {
+	itemID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid item ID"))
+		return
+	}
+
+	includeHidden := requirePermission(c, h.userService, models.PermissionContentWrite) == nil
+
+	testCases, err := h.submissionService.GetTestCases(itemID, includeHidden)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"test_cases": testCases})
+}
+
+// CreateTestCase handles POST /items/:id/test-cases - Requires content:write
+func (h *SubmissionHandler) CreateTestCase(c *gin.Context) {
+	if err := requirePermission(c, h.userService, models.PermissionContentWrite); err != nil {
+		c.Error(apperr.Forbidden("content:write permission required to manage test cases"))
+		return
+	}
+
+	itemID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid item ID"))
+		return
+	}
+
+	var req models.CreateItemTestCaseRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	testCase, err := h.submissionService.CreateTestCase(itemID, &req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, testCase)
+}
+
+// UpdateTestCase handles PUT /items/:id/test-cases/:case_id - Requires content:write
+func (h *SubmissionHandler) UpdateTestCase(c *gin.Context) {
+	if err := requirePermission(c, h.userService, models.PermissionContentWrite); err != nil {
+		c.Error(apperr.Forbidden("content:write permission required to manage test cases"))
+		return
+	}
+
+	itemID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid item ID"))
+		return
+	}
+
+	testCaseID, err := strconv.Atoi(c.Param("case_id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid test case ID"))
+		return
+	}
+
+	var req models.UpdateItemTestCaseRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	testCase, err := h.submissionService.UpdateTestCase(itemID, testCaseID, &req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, testCase)
+}
+
+// DeleteTestCase handles DELETE /items/:id/test-cases/:case_id - Requires content:write
+func (h *SubmissionHandler) DeleteTestCase(c *gin.Context) {
+	if err := requirePermission(c, h.userService, models.PermissionContentWrite); err != nil {
+		c.Error(apperr.Forbidden("content:write permission required to manage test cases"))
+		return
+	}
+
+	itemID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid item ID"))
+		return
+	}
+
+	testCaseID, err := strconv.Atoi(c.Param("case_id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid test case ID"))
+		return
+	}
+
+	if err := h.submissionService.DeleteTestCase(itemID, testCaseID); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Test case deleted successfully"})
+}
+
+// Submit handles POST /items/:id/submit - Judges a solution against the item's test cases
+func (h *SubmissionHandler) Submit(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	itemID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid item ID"))
+		return
+	}
+
+	var req models.SubmitSolutionRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	result, err := h.submissionService.Submit(c.Request.Context(), userID.(int), itemID, &req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetSubmissions handles GET /items/:id/submissions - Returns the user's recent attempts
+func (h *SubmissionHandler) GetSubmissions(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	itemID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid item ID"))
+		return
+	}
+
+	attempts, err := h.submissionService.GetAttempts(userID.(int), itemID)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"submissions": attempts})
+}
@@ -2,12 +2,20 @@ package handlers
 
 import (
 	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
 
+	"interview-prep-app/internal/models"
 	"interview-prep-app/internal/services"
+	"interview-prep-app/pkg/apperr"
 
 	"github.com/gin-gonic/gin"
 )
 
+// sessionIDPattern matches test session IDs, which are UUIDs
+var sessionIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
 // TestHandler handles HTTP requests for tests
 type TestHandler struct {
 	testService *services.TestService
@@ -25,38 +33,32 @@ func NewTestHandler(testService *services.TestService) *TestHandler {
 func (h *TestHandler) CreateTest(c *gin.Context) {
 	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		c.Error(apperr.Unauthorized("User not authenticated"))
 		return
 	}
 
 	uid, ok := userID.(int)
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID"})
+		c.Error(apperr.Internal("Invalid user ID"))
 		return
 	}
 
-	// Check if user can create a test (has miscellaneous item in progress)
-	canCreate, err := h.testService.CheckCanCreateTest(uid)
+	// Check the user meets the eligibility rules an admin set
+	eligibility, err := h.testService.CheckTestEligibility(uid)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
-	if !canCreate {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Cannot create test: no miscellaneous item is currently in progress",
-		})
+	if !eligibility.CanCreate {
+		c.Error(apperr.Validation("Cannot create test: " + strings.Join(eligibility.Unmet, "; ")).WithDetails(eligibility))
 		return
 	}
 
 	// Create the test
 	response, err := h.testService.CreateTest(uid)
 	if err != nil {
-		if err.Error() == "user already has an active test" {
-			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -68,19 +70,19 @@ func (h *TestHandler) CreateTest(c *gin.Context) {
 func (h *TestHandler) GetActiveTest(c *gin.Context) {
 	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		c.Error(apperr.Unauthorized("User not authenticated"))
 		return
 	}
 
 	uid, ok := userID.(int)
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID"})
+		c.Error(apperr.Internal("Invalid user ID"))
 		return
 	}
 
 	response, err := h.testService.GetActiveTest(uid)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -92,54 +94,121 @@ func (h *TestHandler) GetActiveTest(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// GetTestHistory lists the user's latest tests, newest first, with their items; limit caps how
+// many (at most 50)
+// GET /api/v1/tests/history
+func (h *TestHandler) GetTestHistory(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	uid, ok := userID.(int)
+	if !ok {
+		c.Error(apperr.Internal("Invalid user ID"))
+		return
+	}
+
+	limit := 0
+	if limitStr := c.Query("limit"); limitStr != "" {
+		var err error
+		if limit, err = strconv.Atoi(limitStr); err != nil || limit < 0 {
+			c.Error(apperr.Validation("Invalid limit parameter"))
+			return
+		}
+	}
+
+	sessions, err := h.testService.GetTestHistory(uid, limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"sessions": sessions})
+}
+
 // CheckCanCreateTest checks if user can create a test
 // GET /api/v1/tests/can-create
 func (h *TestHandler) CheckCanCreateTest(c *gin.Context) {
 	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		c.Error(apperr.Unauthorized("User not authenticated"))
 		return
 	}
 
 	uid, ok := userID.(int)
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID"})
+		c.Error(apperr.Internal("Invalid user ID"))
 		return
 	}
 
-	canCreate, err := h.testService.CheckCanCreateTest(uid)
+	eligibility, err := h.testService.CheckTestEligibility(uid)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"can_create": canCreate,
-		"reason":     getCanCreateReason(canCreate),
-	})
+	c.JSON(http.StatusOK, eligibility)
+}
+
+// GetTestEligibility returns the rules users must meet to start a test. Requires system:manage.
+// GET /api/v1/admin/test-eligibility
+func (h *TestHandler) GetTestEligibility(c *gin.Context) {
+	policy, err := h.testService.GetEligibilityPolicy()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+// UpdateTestEligibility replaces the rules users must meet to start a test; an empty list lets
+// anyone start one. Requires system:manage.
+// PUT /api/v1/admin/test-eligibility
+func (h *TestHandler) UpdateTestEligibility(c *gin.Context) {
+	var req models.UpdateTestEligibilityRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	policy, err := h.testService.UpdateEligibilityRules(&req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
 }
 
-// CompleteTest marks a test as completed
-// PUT /api/v1/tests/:session_id/complete
+// CompleteTest marks an item in a test as completed
+// PUT /api/v1/tests/:session_id/items/:item_id/complete
 func (h *TestHandler) CompleteTest(c *gin.Context) {
 	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		c.Error(apperr.Unauthorized("User not authenticated"))
 		return
 	}
 
 	uid, ok := userID.(int)
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID"})
+		c.Error(apperr.Internal("Invalid user ID"))
 		return
 	}
 
-	sessionID := c.Param("session_id")
-	itemId := c.Param("item_id")
+	sessionID, ok := testSessionID(c)
+	if !ok {
+		return
+	}
+	itemID, ok := testItemID(c)
+	if !ok {
+		return
+	}
 
-	err := h.testService.CompleteTest(uid, sessionID, itemId)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.testService.CompleteTest(uid, sessionID, itemID); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -149,27 +218,32 @@ func (h *TestHandler) CompleteTest(c *gin.Context) {
 	})
 }
 
-// AbandonTest marks a test as abandoned
-// PUT /api/v1/tests/:session_id/abandon
+// AbandonTest marks an item in a test as abandoned
+// PUT /api/v1/tests/:session_id/items/:item_id/abandon
 func (h *TestHandler) AbandonTest(c *gin.Context) {
 	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		c.Error(apperr.Unauthorized("User not authenticated"))
 		return
 	}
 
 	uid, ok := userID.(int)
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID"})
+		c.Error(apperr.Internal("Invalid user ID"))
 		return
 	}
 
-	sessionID := c.Param("session_id")
-	itemId := c.Param("item_id")
+	sessionID, ok := testSessionID(c)
+	if !ok {
+		return
+	}
+	itemID, ok := testItemID(c)
+	if !ok {
+		return
+	}
 
-	err := h.testService.AbandonTest(uid, sessionID, itemId)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.testService.AbandonTest(uid, sessionID, itemID); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -179,26 +253,97 @@ func (h *TestHandler) AbandonTest(c *gin.Context) {
 	})
 }
 
+// RetakeTest starts a new test with only the items missed in an earlier one
+// POST /api/v1/tests/:session_id/retake
+func (h *TestHandler) RetakeTest(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	uid, ok := userID.(int)
+	if !ok {
+		c.Error(apperr.Internal("Invalid user ID"))
+		return
+	}
+
+	sessionID, ok := testSessionID(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.testService.RetakeTest(uid, sessionID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// RecordTestResult records the user's self-assessment of an item in a test: outcome (solved,
+// partial or failed), time_taken in seconds, self_rating from 1 to 5 and notes
+// PUT /api/v1/tests/:session_id/items/:item_id/result
+func (h *TestHandler) RecordTestResult(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	uid, ok := userID.(int)
+	if !ok {
+		c.Error(apperr.Internal("Invalid user ID"))
+		return
+	}
+
+	sessionID, ok := testSessionID(c)
+	if !ok {
+		return
+	}
+	itemID, ok := testItemID(c)
+	if !ok {
+		return
+	}
+
+	var req models.RecordTestResultRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	result, err := h.testService.RecordTestResult(uid, sessionID, itemID, &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
 // DeleteTest deletes a test
 // DELETE /api/v1/tests/:session_id
 func (h *TestHandler) DeleteTest(c *gin.Context) {
 	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		c.Error(apperr.Unauthorized("User not authenticated"))
 		return
 	}
 
 	uid, ok := userID.(int)
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID"})
+		c.Error(apperr.Internal("Invalid user ID"))
 		return
 	}
 
-	sessionID := c.Param("session_id")
+	sessionID, ok := testSessionID(c)
+	if !ok {
+		return
+	}
 
-	err := h.testService.DeleteTest(uid, sessionID)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.testService.DeleteTest(uid, sessionID); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -208,10 +353,23 @@ func (h *TestHandler) DeleteTest(c *gin.Context) {
 	})
 }
 
-// getCanCreateReason returns a user-friendly reason for can/cannot create test
-func getCanCreateReason(canCreate bool) string {
-	if canCreate {
-		return "You have a miscellaneous item in progress"
+// testSessionID reads the :session_id parameter, answering with a validation error unless it's
+// a session ID
+func testSessionID(c *gin.Context) (string, bool) {
+	sessionID := c.Param("session_id")
+	if !sessionIDPattern.MatchString(sessionID) {
+		c.Error(apperr.Validation("Invalid session ID"))
+		return "", false
+	}
+	return strings.ToLower(sessionID), true
+}
+
+// testItemID reads the :item_id parameter, answering with a validation error unless it's an ID
+func testItemID(c *gin.Context) (int, bool) {
+	itemID, err := strconv.Atoi(c.Param("item_id"))
+	if err != nil || itemID <= 0 {
+		c.Error(apperr.Validation("Invalid item ID"))
+		return 0, false
 	}
-	return "No miscellaneous item is currently in progress"
+	return itemID, true
 }
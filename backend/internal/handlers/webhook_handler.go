@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/services"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookHandler handles HTTP requests for user webhooks
+type WebhookHandler struct {
+	webhookService *services.WebhookService
+}
+
+// NewWebhookHandler creates a new webhook handler
+func NewWebhookHandler(webhookService *services.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+// GetWebhooks handles GET /user/webhooks
+func (h *WebhookHandler) GetWebhooks(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	webhooks, err := h.webhookService.GetWebhooks(userID.(int))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"webhooks": webhooks})
+}
+
+// CreateWebhook handles POST /user/webhooks
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	var req models.CreateWebhookRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	webhook, err := h.webhookService.CreateWebhook(userID.(int), &req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, webhook)
+}
+
+// DeleteWebhook handles DELETE /user/webhooks/:id
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid webhook ID"))
+		return
+	}
+
+	if err := h.webhookService.DeleteWebhook(userID.(int), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Webhook deleted successfully"})
+}
+
+// GetDeliveries handles GET /user/webhooks/:id/deliveries
+func (h *WebhookHandler) GetDeliveries(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperr.Validation("Invalid webhook ID"))
+		return
+	}
+
+	deliveries, err := h.webhookService.GetDeliveries(userID.(int), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"deliveries": deliveries})
+}
+
+// integrationFormat maps the :platform route parameter to a chat webhook format
+func integrationFormat(c *gin.Context) (models.WebhookFormat, bool) {
+	format := models.WebhookFormat(c.Param("platform"))
+	if !format.IsChat() {
+		c.Error(apperr.NotFound("Unknown integration: supported platforms are slack and discord"))
+		return "", false
+	}
+	return format, true
+}
+
+// GetIntegration handles GET /integrations/:platform
+func (h *WebhookHandler) GetIntegration(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	format, ok := integrationFormat(c)
+	if !ok {
+		return
+	}
+
+	integration, err := h.webhookService.GetIntegration(userID.(int), format)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, integration)
+}
+
+// ConnectIntegration handles PUT /integrations/:platform, replacing any existing connection
+func (h *WebhookHandler) ConnectIntegration(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	format, ok := integrationFormat(c)
+	if !ok {
+		return
+	}
+
+	var req models.ConnectIntegrationRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	integration, err := h.webhookService.ConnectIntegration(userID.(int), format, &req)
+	if err != nil {
+		c.Error(apperr.Classify(apperr.KindValidation, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, integration)
+}
+
+// DisconnectIntegration handles DELETE /integrations/:platform
+func (h *WebhookHandler) DisconnectIntegration(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	format, ok := integrationFormat(c)
+	if !ok {
+		return
+	}
+
+	if err := h.webhookService.DisconnectIntegration(userID.(int), format); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Integration disconnected successfully"})
+}
+
+// TestIntegration handles POST /integrations/:platform/test
+func (h *WebhookHandler) TestIntegration(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(apperr.Unauthorized("User not authenticated"))
+		return
+	}
+
+	format, ok := integrationFormat(c)
+	if !ok {
+		return
+	}
+
+	if err := h.webhookService.TestIntegration(c.Request.Context(), userID.(int), format); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Test message sent"})
+}
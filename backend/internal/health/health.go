@@ -0,0 +1,163 @@
+// Package health probes the services the API depends on and reports their status and latency.
+package health
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"net/http"
+	"net/smtp"
+	"sync"
+	"time"
+)
+
+// Dependency statuses
+const (
+	StatusOK       = "ok"
+	StatusDown     = "down"
+	StatusDegraded = "degraded" // overall status when only optional dependencies are down
+)
+
+// Check probes one dependency. Required dependencies make the service unready when down.
+type Check struct {
+	Name     string
+	Required bool
+	Probe    func(ctx context.Context) error
+}
+
+// Result is the outcome of one check
+type Result struct {
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	Required  bool    `json:"required"`
+	LatencyMS float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Report is the outcome of running every check
+type Report struct {
+	Status       string    `json:"status"`
+	CheckedAt    time.Time `json:"checked_at"`
+	Dependencies []Result  `json:"dependencies"`
+}
+
+// Ready reports whether every required dependency is up
+func (r *Report) Ready() bool {
+	return r.Status != StatusDown
+}
+
+// Run probes every dependency concurrently, giving each at most timeout
+func Run(ctx context.Context, checks []Check, timeout time.Duration) *Report {
+	report := &Report{
+		Status:       StatusOK,
+		CheckedAt:    time.Now().UTC(),
+		Dependencies: make([]Result, len(checks)),
+	}
+
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check Check) {
+			defer wg.Done()
+			report.Dependencies[i] = run(ctx, check, timeout)
+		}(i, check)
+	}
+	wg.Wait()
+
+	for _, result := range report.Dependencies {
+		if result.Status == StatusOK {
+			continue
+		}
+		if result.Required {
+			report.Status = StatusDown
+		} else if report.Status == StatusOK {
+			report.Status = StatusDegraded
+		}
+	}
+
+	return report
+}
+
+// run probes one dependency and times it
+func run(ctx context.Context, check Check, timeout time.Duration) Result {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check.Probe(ctx)
+	result := Result{
+		Name:      check.Name,
+		Status:    StatusOK,
+		Required:  check.Required,
+		LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err == nil && ctx.Err() != nil {
+		err = ctx.Err()
+	}
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+
+	return result
+}
+
+// Postgres pings the database
+func Postgres(db *sql.DB) func(ctx context.Context) error {
+	return db.PingContext
+}
+
+// TCP dials an address
+func TCP(address string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "tcp", address)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+}
+
+// SMTP connects to a mail relay and waits for its greeting
+func SMTP(host, port string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(host, port))
+		if err != nil {
+			return err
+		}
+		if deadline, ok := ctx.Deadline(); ok {
+			conn.SetDeadline(deadline)
+		}
+
+		client, err := smtp.NewClient(conn, host)
+		if err != nil {
+			conn.Close()
+			return fmt.Errorf("no SMTP greeting: %w", err)
+		}
+		return client.Quit()
+	}
+}
+
+// HTTP requests a URL and treats any response below 500 as reachable
+func HTTP(client *http.Client, method, url string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, method, url, nil)
+		if err != nil {
+			return err
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode >= 500 {
+			return fmt.Errorf("status %d", resp.StatusCode)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,66 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func probe(err error) func(ctx context.Context) error {
+	return func(ctx context.Context) error { return err }
+}
+
+func TestRunStatuses(t *testing.T) {
+	cases := []struct {
+		name   string
+		checks []Check
+		want   string
+	}{
+		{
+			name:   "all up",
+			checks: []Check{{Name: "postgres", Required: true, Probe: probe(nil)}, {Name: "smtp", Probe: probe(nil)}},
+			want:   StatusOK,
+		},
+		{
+			name:   "optional down",
+			checks: []Check{{Name: "postgres", Required: true, Probe: probe(nil)}, {Name: "smtp", Probe: probe(errors.New("refused"))}},
+			want:   StatusDegraded,
+		},
+		{
+			name:   "required down",
+			checks: []Check{{Name: "postgres", Required: true, Probe: probe(errors.New("refused"))}, {Name: "smtp", Probe: probe(errors.New("refused"))}},
+			want:   StatusDown,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			report := Run(context.Background(), tc.checks, time.Second)
+			if report.Status != tc.want {
+				t.Fatalf("status = %s, want %s", report.Status, tc.want)
+			}
+			if len(report.Dependencies) != len(tc.checks) {
+				t.Fatalf("got %d results, want %d", len(report.Dependencies), len(tc.checks))
+			}
+			for i, result := range report.Dependencies {
+				if result.Name != tc.checks[i].Name {
+					t.Errorf("result %d is %s, want %s", i, result.Name, tc.checks[i].Name)
+				}
+			}
+		})
+	}
+}
+
+func TestRunTimesOutSlowProbes(t *testing.T) {
+	slow := Check{Name: "leetcode", Probe: func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}}
+
+	report := Run(context.Background(), []Check{slow}, 10*time.Millisecond)
+	result := report.Dependencies[0]
+	if result.Status != StatusDown || result.Error == "" {
+		t.Fatalf("slow probe reported %+v, want down with an error", result)
+	}
+}
@@ -0,0 +1,165 @@
+// Package i18n translates user-facing text. Messages are written in English in the code and the
+// English text is the key into each locale's catalog, so a message without a translation, or a
+// request for a locale without a catalog, falls back to the English it was written in. Catalogs
+// live in locales/<locale>.json and are embedded in the binary.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultLocale is the locale messages are written in, used when nothing better matches
+const DefaultLocale = "en"
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+// Plural is a message's singular and plural forms, picked by a count
+type Plural struct {
+	One   string `json:"one"`
+	Other string `json:"other"`
+}
+
+// catalog is one locale's translations. Messages are keyed by their English text; plurals by
+// their English plural form.
+type catalog struct {
+	Messages map[string]string `json:"messages"`
+	Plurals  map[string]Plural `json:"plurals"`
+}
+
+var catalogs = loadCatalogs()
+
+// loadCatalogs reads the embedded catalogs, keyed by locale. English needs no catalog.
+func loadCatalogs() map[string]*catalog {
+	entries, err := localeFiles.ReadDir("locales")
+	if err != nil {
+		panic(fmt.Sprintf("i18n: failed to read catalogs: %v", err))
+	}
+
+	loaded := map[string]*catalog{DefaultLocale: {}}
+	for _, entry := range entries {
+		data, err := localeFiles.ReadFile(path.Join("locales", entry.Name()))
+		if err != nil {
+			panic(fmt.Sprintf("i18n: failed to read %s: %v", entry.Name(), err))
+		}
+
+		var c catalog
+		if err := json.Unmarshal(data, &c); err != nil {
+			panic(fmt.Sprintf("i18n: invalid catalog %s: %v", entry.Name(), err))
+		}
+		loaded[strings.TrimSuffix(entry.Name(), ".json")] = &c
+	}
+
+	return loaded
+}
+
+// Supported returns the locales with a catalog, sorted
+func Supported() []string {
+	locales := make([]string, 0, len(catalogs))
+	for locale := range catalogs {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// IsSupported reports whether a locale has a catalog
+func IsSupported(locale string) bool {
+	_, ok := catalogs[locale]
+	return ok
+}
+
+// Match picks the supported locale an Accept-Language header prefers most, trying each
+// language's base locale ("es" for "es-MX") when its region has no catalog of its own
+func Match(acceptLanguage string) string {
+	type candidate struct {
+		tag     string
+		quality float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(part, ";")
+		tag := strings.ToLower(strings.TrimSpace(fields[0]))
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range fields[1:] {
+			if value, ok := strings.CutPrefix(strings.TrimSpace(param), "q="); ok {
+				if q, err := strconv.ParseFloat(value, 64); err == nil {
+					quality = q
+				}
+			}
+		}
+		if quality > 0 {
+			candidates = append(candidates, candidate{tag: strings.ReplaceAll(tag, "_", "-"), quality: quality})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+
+	for _, c := range candidates {
+		if IsSupported(c.tag) {
+			return c.tag
+		}
+		if base, _, found := strings.Cut(c.tag, "-"); found && IsSupported(base) {
+			return base
+		}
+	}
+
+	return DefaultLocale
+}
+
+// Resolve returns the locale to answer in: the user's preference when it's supported, and
+// otherwise the best match for their Accept-Language header
+func Resolve(preferred, acceptLanguage string) string {
+	if IsSupported(preferred) {
+		return preferred
+	}
+	return Match(acceptLanguage)
+}
+
+// T translates a message into locale, formatting it with args when there are any
+func T(locale, message string, args ...interface{}) string {
+	if c, ok := catalogs[locale]; ok {
+		if translated, ok := c.Messages[message]; ok {
+			message = translated
+		}
+	}
+
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// N translates a message whose wording depends on count, such as "%d item" and "%d items",
+// formatting the chosen form with args. A count of one takes the singular, as in every
+// supported locale.
+func N(locale, one, other string, count int, args ...interface{}) string {
+	forms := Plural{One: one, Other: other}
+	if c, ok := catalogs[locale]; ok {
+		if translated, ok := c.Plurals[other]; ok {
+			forms = translated
+		}
+	}
+
+	message := forms.Other
+	if count == 1 {
+		message = forms.One
+	}
+
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
@@ -0,0 +1,85 @@
+package i18n
+
+import (
+	"regexp"
+	"slices"
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	for header, want := range map[string]string{
+		"":                          "en",
+		"es":                        "es",
+		"es-MX,es;q=0.9":            "es",
+		"ES_ar":                     "es",
+		"fr-FR, en;q=0.8, es;q=0.9": "es",
+		"es;q=0, en":                "en",
+		"fr, de;q=0.7":              "en",
+		"*, es;q=0.1":               "es",
+		"en-GB;q=0.4, es;q=not-a-q": "es",
+		"en-US,en;q=0.9,es;q=0.8":   "en",
+	} {
+		if got := Match(header); got != want {
+			t.Errorf("Match(%q): expected %q, got %q", header, want, got)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	if got := Resolve("es", "en"); got != "es" {
+		t.Errorf("Expected the saved preference to win, got %q", got)
+	}
+	if got := Resolve("", "es-ES"); got != "es" {
+		t.Errorf("Expected Accept-Language without a preference, got %q", got)
+	}
+	if got := Resolve("xx", ""); got != DefaultLocale {
+		t.Errorf("Expected an unsupported preference to fall back to English, got %q", got)
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	if got := T("es", "item not found"); got != "elemento no encontrado" {
+		t.Errorf("Unexpected translation %q", got)
+	}
+	if got := T("es", "no such message %d", 3); got != "no such message 3" {
+		t.Errorf("Expected an untranslated message in English, got %q", got)
+	}
+	if got := T("xx", "item not found"); got != "item not found" {
+		t.Errorf("Expected an unsupported locale to get English, got %q", got)
+	}
+
+	if got := N("es", "%d review due", "%d reviews due", 1, 1); got != "1 repaso pendiente" {
+		t.Errorf("Unexpected singular %q", got)
+	}
+	if got := N("es", "%d review due", "%d reviews due", 3, 3); got != "3 repasos pendientes" {
+		t.Errorf("Unexpected plural %q", got)
+	}
+	if got := N("en", "%d review due", "%d reviews due", 0, 0); got != "0 reviews due" {
+		t.Errorf("Unexpected English plural %q", got)
+	}
+}
+
+// formatVerbs matches the fmt verbs a message takes, so a translation can't drop or reorder one
+var formatVerbs = regexp.MustCompile(`%[-+# 0]*[0-9]*(\.[0-9]+)?[a-zA-Z%]`)
+
+func TestCatalogsKeepFormatVerbs(t *testing.T) {
+	if !slices.Equal(Supported(), []string{"en", "es"}) {
+		t.Errorf("Unexpected supported locales %v", Supported())
+	}
+
+	for locale, c := range catalogs {
+		for english, translated := range c.Messages {
+			if !slices.Equal(formatVerbs.FindAllString(english, -1), formatVerbs.FindAllString(translated, -1)) {
+				t.Errorf("%s: %q doesn't take the same arguments as %q", locale, translated, english)
+			}
+		}
+		for english, forms := range c.Plurals {
+			want := formatVerbs.FindAllString(english, -1)
+			for _, form := range []string{forms.One, forms.Other} {
+				if !slices.Equal(want, formatVerbs.FindAllString(form, -1)) {
+					t.Errorf("%s: %q doesn't take the same arguments as %q", locale, form, english)
+				}
+			}
+		}
+	}
+}
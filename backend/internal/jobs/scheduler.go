@@ -0,0 +1,230 @@
+// Package jobs runs recurring background work on fixed intervals.
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"interview-prep-app/internal/models"
+)
+
+// lockLease is how long a run holds its job's lock. A crashed instance's lock expires after it;
+// a run that takes longer may overlap a run on another instance.
+const lockLease = 30 * time.Minute
+
+var (
+	// ErrNotFound is returned when triggering a job that isn't registered
+	ErrNotFound = errors.New("job not found")
+	// ErrRunning is returned when triggering a job that is already running
+	ErrRunning = errors.New("job is already running")
+)
+
+// Job is a unit of background work run on a fixed interval
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+
+	running *sync.Mutex
+}
+
+// Store coordinates jobs across server instances and keeps their run history
+type Store interface {
+	// Acquire locks a job for one run by owner, unless it's locked or was started within
+	// minGap. It reports whether the lock was taken.
+	Acquire(name, owner string, now time.Time, minGap, lease time.Duration) (bool, error)
+	// Release unlocks a job owner holds
+	Release(name, owner string) error
+	// StartRun records the start of a run and returns its ID
+	StartRun(name, instance string, trigger models.JobTrigger, startedAt time.Time) (int, error)
+	// FinishRun records how a run ended; a nil runErr means it succeeded
+	FinishRun(id int, runErr error, finishedAt time.Time) error
+}
+
+// Scheduler runs registered jobs on their intervals until its context is cancelled. With a
+// store, each interval's run happens on one instance only and every run is recorded; without
+// one, every instance runs every job and nothing is recorded.
+type Scheduler struct {
+	jobs     []Job
+	store    Store
+	instance string
+	ctx      context.Context
+}
+
+// NewScheduler creates an empty scheduler. store may be nil.
+func NewScheduler(store Store) *Scheduler {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+
+	return &Scheduler{
+		store:    store,
+		instance: fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+		ctx:      context.Background(),
+	}
+}
+
+// Register adds a job to the scheduler. Jobs must be registered before Start is called.
+func (s *Scheduler) Register(name string, interval time.Duration, run func(ctx context.Context) error) {
+	if interval <= 0 {
+		log.Printf("Job %s not scheduled: interval must be positive, got %s", name, interval)
+		return
+	}
+	s.jobs = append(s.jobs, Job{Name: name, Interval: interval, Run: run, running: &sync.Mutex{}})
+}
+
+// Jobs lists the registered jobs by name
+func (s *Scheduler) Jobs() []Job {
+	jobs := append([]Job(nil), s.jobs...)
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].Name < jobs[j].Name })
+	return jobs
+}
+
+// Start launches a goroutine per job. Each job runs once per interval; a run that
+// overlaps the next tick delays it rather than running twice at once.
+func (s *Scheduler) Start(ctx context.Context) {
+	s.ctx = ctx
+	for _, job := range s.jobs {
+		go s.loop(ctx, job)
+	}
+}
+
+// Trigger starts a run of a job now, in the background. It fails with ErrRunning if the job is
+// running here or on another instance.
+func (s *Scheduler) Trigger(name string) error {
+	job, err := s.claim(name)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		defer job.running.Unlock()
+		s.run(s.ctx, job, models.JobTriggerManual)
+	}()
+	return nil
+}
+
+// RunNow runs a job now and waits for it, returning the run's error. Like Trigger, it fails
+// with ErrRunning if the job is running here or on another instance, and the run is recorded.
+func (s *Scheduler) RunNow(ctx context.Context, name string) error {
+	job, err := s.claim(name)
+	if err != nil {
+		return err
+	}
+	defer job.running.Unlock()
+
+	return s.run(ctx, job, models.JobTriggerManual)
+}
+
+// claim locks a job for a manual run. The caller must unlock job.running once the run is done.
+func (s *Scheduler) claim(name string) (Job, error) {
+	for _, job := range s.jobs {
+		if job.Name != name {
+			continue
+		}
+
+		if !job.running.TryLock() {
+			return Job{}, ErrRunning
+		}
+		// A manual run ignores when the job last ran, but still never overlaps another run
+		if !s.acquire(job, 0) {
+			job.running.Unlock()
+			return Job{}, ErrRunning
+		}
+		return job, nil
+	}
+
+	return Job{}, ErrNotFound
+}
+
+// loop runs a single job on its ticker until the context is cancelled
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.tick(ctx, job)
+		}
+	}
+}
+
+// tick runs a job for its schedule, unless it's already running or another instance ran it
+// this interval. Instances' tickers drift apart, so any run in the last 90% of the interval counts.
+func (s *Scheduler) tick(ctx context.Context, job Job) {
+	if !job.running.TryLock() {
+		return
+	}
+	defer job.running.Unlock()
+
+	if !s.acquire(job, job.Interval*9/10) {
+		return
+	}
+	s.run(ctx, job, models.JobTriggerSchedule)
+}
+
+// acquire takes the job's lock in the store, if there is one. A store failure skips the run
+// rather than risking it running everywhere at once.
+func (s *Scheduler) acquire(job Job, minGap time.Duration) bool {
+	if s.store == nil {
+		return true
+	}
+
+	acquired, err := s.store.Acquire(job.Name, s.instance, time.Now(), minGap, lockLease)
+	if err != nil {
+		log.Printf("Job %s skipped: %v", job.Name, err)
+		return false
+	}
+	return acquired
+}
+
+// run executes a job once it holds its lock, recording the run and releasing the lock after.
+// Failures are logged and returned, and panics recovered so the loop keeps going.
+func (s *Scheduler) run(ctx context.Context, job Job, trigger models.JobTrigger) error {
+	runID := 0
+	if s.store != nil {
+		var err error
+		if runID, err = s.store.StartRun(job.Name, s.instance, trigger, time.Now()); err != nil {
+			log.Printf("Job %s: %v", job.Name, err)
+		}
+	}
+
+	runErr := s.execute(ctx, job)
+	if runErr != nil {
+		log.Printf("Job %s failed: %v", job.Name, runErr)
+	}
+
+	if s.store == nil {
+		return runErr
+	}
+	if runID != 0 {
+		if err := s.store.FinishRun(runID, runErr, time.Now()); err != nil {
+			log.Printf("Job %s: %v", job.Name, err)
+		}
+	}
+	if err := s.store.Release(job.Name, s.instance); err != nil {
+		log.Printf("Job %s: %v", job.Name, err)
+	}
+	return runErr
+}
+
+// execute calls the job, turning a panic into an error
+func (s *Scheduler) execute(ctx context.Context, job Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panicked: %v", r)
+		}
+	}()
+
+	return job.Run(ctx)
+}
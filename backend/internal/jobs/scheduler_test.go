@@ -0,0 +1,146 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"interview-prep-app/internal/models"
+)
+
+// memoryStore is a Store for one process: a job is locked until released
+type memoryStore struct {
+	mu       sync.Mutex
+	locked   map[string]bool
+	finished chan error
+	triggers []models.JobTrigger
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{locked: map[string]bool{}, finished: make(chan error, 10)}
+}
+
+func (m *memoryStore) Acquire(name, owner string, now time.Time, minGap, lease time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.locked[name] {
+		return false, nil
+	}
+	m.locked[name] = true
+	return true, nil
+}
+
+func (m *memoryStore) Release(name, owner string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.locked, name)
+	return nil
+}
+
+func (m *memoryStore) StartRun(name, instance string, trigger models.JobTrigger, startedAt time.Time) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.triggers = append(m.triggers, trigger)
+	return len(m.triggers), nil
+}
+
+func (m *memoryStore) FinishRun(id int, runErr error, finishedAt time.Time) error {
+	m.finished <- runErr
+	return nil
+}
+
+func TestTrigger(t *testing.T) {
+	store := newMemoryStore()
+	scheduler := NewScheduler(store)
+
+	release := make(chan struct{})
+	scheduler.Register("slow", time.Hour, func(ctx context.Context) error {
+		<-release
+		return errors.New("boom")
+	})
+	scheduler.Register("panics", time.Hour, func(ctx context.Context) error {
+		panic("oops")
+	})
+
+	if err := scheduler.Trigger("missing"); err != ErrNotFound {
+		t.Fatalf("Expected ErrNotFound, got %v", err)
+	}
+
+	if err := scheduler.Trigger("slow"); err != nil {
+		t.Fatalf("Trigger returned error: %v", err)
+	}
+	if err := scheduler.Trigger("slow"); err != ErrRunning {
+		t.Errorf("Expected ErrRunning while the job runs, got %v", err)
+	}
+
+	close(release)
+	if err := <-store.finished; err == nil || err.Error() != "boom" {
+		t.Errorf("Expected the run's error to be recorded, got %v", err)
+	}
+
+	// Another instance holding the lock also blocks a manual run
+	time.Sleep(10 * time.Millisecond) // let the finished run release its locks
+	if acquired, _ := store.Acquire("slow", "other-instance", time.Now(), 0, time.Minute); !acquired {
+		t.Fatal("Expected the finished run to release its lock")
+	}
+	if err := scheduler.Trigger("slow"); err != ErrRunning {
+		t.Errorf("Expected ErrRunning while another instance holds the lock, got %v", err)
+	}
+
+	if err := scheduler.Trigger("panics"); err != nil {
+		t.Fatalf("Trigger returned error: %v", err)
+	}
+	if err := <-store.finished; err == nil {
+		t.Error("Expected a panic to be recorded as a failure")
+	}
+
+	if len(store.triggers) != 2 || store.triggers[0] != models.JobTriggerManual {
+		t.Errorf("Expected two manual runs, got %v", store.triggers)
+	}
+}
+
+func TestRunNow(t *testing.T) {
+	store := newMemoryStore()
+	scheduler := NewScheduler(store)
+	scheduler.Register("fails", time.Hour, func(ctx context.Context) error {
+		return errors.New("boom")
+	})
+
+	if err := scheduler.RunNow(context.Background(), "missing"); err != ErrNotFound {
+		t.Fatalf("Expected ErrNotFound, got %v", err)
+	}
+	if err := scheduler.RunNow(context.Background(), "fails"); err == nil || err.Error() != "boom" {
+		t.Errorf("Expected the run's error, got %v", err)
+	}
+	if err := <-store.finished; err == nil {
+		t.Error("Expected the failed run to be recorded")
+	}
+
+	// The run released its locks before returning
+	if err := scheduler.Trigger("fails"); err != nil {
+		t.Errorf("Expected the job to be free after RunNow, got %v", err)
+	}
+	<-store.finished
+
+	time.Sleep(10 * time.Millisecond) // let the triggered run release its locks
+	if acquired, _ := store.Acquire("fails", "other-instance", time.Now(), 0, time.Minute); !acquired {
+		t.Fatal("Expected the triggered run to release its lock")
+	}
+	if err := scheduler.RunNow(context.Background(), "fails"); err != ErrRunning {
+		t.Errorf("Expected ErrRunning while another instance holds the lock, got %v", err)
+	}
+}
+
+func TestJobsSortedByName(t *testing.T) {
+	scheduler := NewScheduler(nil)
+	scheduler.Register("b", time.Minute, func(ctx context.Context) error { return nil })
+	scheduler.Register("a", time.Minute, func(ctx context.Context) error { return nil })
+	scheduler.Register("disabled", 0, func(ctx context.Context) error { return nil })
+
+	jobs := scheduler.Jobs()
+	if len(jobs) != 2 || jobs[0].Name != "a" || jobs[1].Name != "b" {
+		t.Errorf("Unexpected jobs %v", jobs)
+	}
+}
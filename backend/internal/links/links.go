@@ -0,0 +1,84 @@
+// Package links puts item links in a canonical form before they're stored, so the same page
+// always ends up with the same link no matter where it was copied from.
+package links
+
+import (
+	"net/url"
+	"strings"
+)
+
+// trackingParams are query parameters added by share buttons, newsletters and ad networks
+var trackingParams = map[string]bool{
+	"fbclid": true, "gclid": true, "dclid": true, "msclkid": true, "yclid": true,
+	"mc_cid": true, "mc_eid": true, "igshid": true, "si": true,
+	"ref": true, "ref_src": true, "ref_url": true,
+}
+
+// Normalize canonicalizes an http(s) link: the host is lowercased, tracking parameters are
+// dropped, and LeetCode and GeeksforGeeks pages are reduced to the problem or article itself.
+// Anything that isn't an absolute http(s) URL is returned trimmed but otherwise unchanged.
+func Normalize(link string) string {
+	link = strings.TrimSpace(link)
+	u, err := url.Parse(link)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return link
+	}
+
+	u.Host = strings.ToLower(u.Host)
+	switch strings.TrimPrefix(u.Hostname(), "www.") {
+	case "leetcode.com":
+		canonicalLeetCode(u)
+	case "geeksforgeeks.org", "practice.geeksforgeeks.org":
+		canonicalGeeksforGeeks(u)
+	default:
+		stripTrackingParams(u)
+	}
+
+	return u.String()
+}
+
+// canonicalLeetCode reduces any page of a problem (description, solutions, editorial) to
+// https://leetcode.com/problems/<slug>/
+func canonicalLeetCode(u *url.URL) {
+	u.Scheme = "https"
+	u.Host = "leetcode.com"
+	u.RawQuery = ""
+	u.Fragment = ""
+
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(segments) >= 2 && segments[0] == "problems" && segments[1] != "" {
+		u.Path = "/problems/" + strings.ToLower(segments[1]) + "/"
+		u.RawPath = ""
+	}
+}
+
+// canonicalGeeksforGeeks serves articles over https from www with a trailing slash and no query
+func canonicalGeeksforGeeks(u *url.URL) {
+	u.Scheme = "https"
+	if u.Host == "geeksforgeeks.org" {
+		u.Host = "www.geeksforgeeks.org"
+	}
+	u.RawQuery = ""
+	u.Fragment = ""
+
+	if u.Path != "" && !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+		u.RawPath = ""
+	}
+}
+
+// stripTrackingParams removes utm_* and other tracking parameters, keeping the rest of the query
+func stripTrackingParams(u *url.URL) {
+	if u.RawQuery == "" {
+		return
+	}
+
+	query := u.Query()
+	for key := range query {
+		lower := strings.ToLower(key)
+		if strings.HasPrefix(lower, "utm_") || trackingParams[lower] {
+			query.Del(key)
+		}
+	}
+	u.RawQuery = query.Encode()
+}
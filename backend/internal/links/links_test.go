@@ -0,0 +1,26 @@
+package links
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	cases := []struct {
+		in, want string
+	}{
+		{"https://leetcode.com/problems/two-sum/", "https://leetcode.com/problems/two-sum/"},
+		{" http://www.leetcode.com/problems/Two-Sum/description/?envType=study-plan-v2#top ", "https://leetcode.com/problems/two-sum/"},
+		{"https://leetcode.com/problems/two-sum/solutions/123/some-solution/", "https://leetcode.com/problems/two-sum/"},
+		{"https://leetcode.com/explore/learn/card/array/", "https://leetcode.com/explore/learn/card/array/"},
+		{"http://geeksforgeeks.org/merge-sort?ref=lbp", "https://www.geeksforgeeks.org/merge-sort/"},
+		{"https://practice.geeksforgeeks.org/problems/missing-number/1?page=1#", "https://practice.geeksforgeeks.org/problems/missing-number/1/"},
+		{"https://Example.com/post?id=7&utm_source=newsletter&fbclid=abc", "https://example.com/post?id=7"},
+		{"https://example.com/post#section", "https://example.com/post#section"},
+		{"not a url", "not a url"},
+		{"mailto:someone@example.com", "mailto:someone@example.com"},
+	}
+
+	for _, tc := range cases {
+		if got := Normalize(tc.in); got != tc.want {
+			t.Errorf("Normalize(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
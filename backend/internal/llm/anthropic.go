@@ -0,0 +1,120 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"interview-prep-app/pkg/apperr"
+)
+
+const (
+	// anthropicVersion is the Messages API version requests are made against
+	anthropicVersion = "2023-06-01"
+	// anthropicDefaultMaxTokens is sent when a prompt sets no limit, since the API requires one
+	anthropicDefaultMaxTokens = 1024
+)
+
+// AnthropicProvider completes prompts through the Anthropic Messages API (POST /v1/messages)
+type AnthropicProvider struct {
+	baseURL string
+	apiKey  string
+	model   string
+	client  *http.Client
+}
+
+type anthropicMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type anthropicRequest struct {
+	Model     string             `json:"model"`
+	System    string             `json:"system,omitempty"`
+	Messages  []anthropicMessage `json:"messages"`
+	MaxTokens int                `json:"max_tokens"`
+}
+
+type anthropicResponse struct {
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+	Usage struct {
+		InputTokens  int64 `json:"input_tokens"`
+		OutputTokens int64 `json:"output_tokens"`
+	} `json:"usage"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// Name identifies the vendor
+func (p *AnthropicProvider) Name() string { return "anthropic" }
+
+// Model is the model prompts are sent to
+func (p *AnthropicProvider) Model() string { return p.model }
+
+// Complete sends the history and a user message and joins the text blocks of the reply
+func (p *AnthropicProvider) Complete(ctx context.Context, prompt Prompt) (*Completion, error) {
+	maxTokens := prompt.MaxTokens
+	if maxTokens <= 0 {
+		maxTokens = anthropicDefaultMaxTokens
+	}
+
+	messages := make([]anthropicMessage, 0, len(prompt.History)+1)
+	for _, message := range prompt.History {
+		messages = append(messages, anthropicMessage{Role: message.Role, Content: message.Content})
+	}
+	messages = append(messages, anthropicMessage{Role: RoleUser, Content: prompt.User})
+
+	body, err := json.Marshal(anthropicRequest{
+		Model:     p.model,
+		System:    prompt.System,
+		Messages:  messages,
+		MaxTokens: maxTokens,
+	})
+	if err != nil {
+		return nil, apperr.Errorf("failed to encode completion request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/v1/messages", bytes.NewReader(body))
+	if err != nil {
+		return nil, apperr.Errorf("failed to create completion request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-api-key", p.apiKey)
+	req.Header.Set("anthropic-version", anthropicVersion)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, apperr.Errorf("failed to reach Anthropic: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var decoded anthropicResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(&decoded); err != nil {
+		return nil, apperr.Errorf("failed to decode Anthropic response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		message := ""
+		if decoded.Error != nil {
+			message = decoded.Error.Message
+		}
+		return nil, fmt.Errorf("Anthropic returned %d: %s", resp.StatusCode, message)
+	}
+
+	var text strings.Builder
+	for _, block := range decoded.Content {
+		if block.Type == "text" {
+			text.WriteString(block.Text)
+		}
+	}
+
+	return &Completion{Text: text.String(), Tokens: decoded.Usage.InputTokens + decoded.Usage.OutputTokens}, nil
+}
@@ -0,0 +1,129 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"interview-prep-app/internal/config"
+	"interview-prep-app/pkg/apperr"
+)
+
+// EmbeddingDimensions is the length of every embedding, fixed so they can be stored and indexed
+// in one vector column
+const EmbeddingDimensions = 1536
+
+// Embedder turns texts into embedding vectors whose closeness reflects closeness in meaning
+type Embedder interface {
+	// Model is the model texts are embedded with; embeddings from different models can't be compared
+	Model() string
+	// Embed returns one EmbeddingDimensions-long vector per text, in order
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// NewEmbedder returns an embedder for the configured model, or nil when none is configured
+func NewEmbedder(cfg *config.Config) (Embedder, error) {
+	if cfg.EmbeddingModel == "" {
+		return nil, nil
+	}
+
+	apiKey, url := cfg.EmbeddingAPIKey, cfg.EmbeddingBaseURL
+	if cfg.LLMProvider == "openai" {
+		if apiKey == "" {
+			apiKey = cfg.LLMAPIKey
+		}
+		if url == "" {
+			url = cfg.LLMBaseURL
+		}
+	}
+	if apiKey == "" {
+		return nil, fmt.Errorf("EMBEDDING_API_KEY is required with EMBEDDING_MODEL=%s", cfg.EmbeddingModel)
+	}
+
+	return &OpenAIEmbedder{
+		baseURL: baseURL(url, "https://api.openai.com"),
+		apiKey:  apiKey,
+		model:   cfg.EmbeddingModel,
+		client:  &http.Client{Timeout: time.Duration(cfg.LLMTimeoutSeconds) * time.Second},
+	}, nil
+}
+
+// OpenAIEmbedder embeds texts through the OpenAI embeddings API (POST /v1/embeddings)
+type OpenAIEmbedder struct {
+	baseURL string
+	apiKey  string
+	model   string
+	client  *http.Client
+}
+
+type openAIEmbeddingRequest struct {
+	Model      string   `json:"model"`
+	Input      []string `json:"input"`
+	Dimensions int      `json:"dimensions"`
+}
+
+type openAIEmbeddingResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// Model is the model texts are embedded with
+func (e *OpenAIEmbedder) Model() string { return e.model }
+
+// Embed sends the texts in one request and orders the returned vectors by their index
+func (e *OpenAIEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	body, err := json.Marshal(openAIEmbeddingRequest{Model: e.model, Input: texts, Dimensions: EmbeddingDimensions})
+	if err != nil {
+		return nil, apperr.Errorf("failed to encode embedding request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.baseURL+"/v1/embeddings", bytes.NewReader(body))
+	if err != nil {
+		return nil, apperr.Errorf("failed to create embedding request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+e.apiKey)
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, apperr.Errorf("failed to reach the embeddings API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var decoded openAIEmbeddingResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxEmbeddingResponseBytes)).Decode(&decoded); err != nil {
+		return nil, apperr.Errorf("failed to decode embeddings response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		message := ""
+		if decoded.Error != nil {
+			message = decoded.Error.Message
+		}
+		return nil, fmt.Errorf("embeddings API returned %d: %s", resp.StatusCode, message)
+	}
+
+	vectors := make([][]float32, len(texts))
+	for _, data := range decoded.Data {
+		if data.Index < 0 || data.Index >= len(texts) || len(data.Embedding) != EmbeddingDimensions {
+			return nil, fmt.Errorf("embeddings API returned an unexpected embedding at index %d", data.Index)
+		}
+		vectors[data.Index] = data.Embedding
+	}
+	for i, vector := range vectors {
+		if vector == nil {
+			return nil, fmt.Errorf("embeddings API returned no embedding for input %d", i)
+		}
+	}
+
+	return vectors, nil
+}
@@ -0,0 +1,96 @@
+// Package llm completes prompts with a hosted large language model and embeds texts for
+// semantic search. Each vendor's API sits behind the Provider interface; the configuration
+// picks one.
+package llm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"interview-prep-app/internal/config"
+)
+
+const (
+	// maxResponseBytes caps how much of a model response is read
+	maxResponseBytes = 1 << 20
+	// maxEmbeddingResponseBytes caps how much of an embeddings response is read; each vector
+	// takes tens of kilobytes as JSON
+	maxEmbeddingResponseBytes = 32 << 20
+)
+
+// Roles of the messages in a conversation
+const (
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+)
+
+// Message is one turn of an earlier conversation with the model
+type Message struct {
+	Role    string // RoleUser or RoleAssistant
+	Content string
+}
+
+// Prompt is a request for a completion. History holds the earlier turns of a conversation,
+// oldest first, and User is the next user turn.
+type Prompt struct {
+	System    string // instructions the model follows for the whole exchange
+	History   []Message
+	User      string
+	MaxTokens int
+}
+
+// Completion is the model's reply along with the tokens the call used, prompt and reply together
+type Completion struct {
+	Text   string
+	Tokens int64
+}
+
+// Provider completes prompts with one vendor's model
+type Provider interface {
+	// Name identifies the vendor, e.g. "openai"
+	Name() string
+	// Model is the model prompts are sent to
+	Model() string
+	Complete(ctx context.Context, prompt Prompt) (*Completion, error)
+}
+
+// New returns a provider for the configured vendor, or nil when none is configured
+func New(cfg *config.Config) (Provider, error) {
+	if cfg.LLMProvider == "" {
+		return nil, nil
+	}
+	if cfg.LLMAPIKey == "" || cfg.LLMModel == "" {
+		return nil, fmt.Errorf("LLM_API_KEY and LLM_MODEL are required with LLM_PROVIDER=%s", cfg.LLMProvider)
+	}
+
+	client := &http.Client{Timeout: time.Duration(cfg.LLMTimeoutSeconds) * time.Second}
+	switch cfg.LLMProvider {
+	case "openai":
+		return &OpenAIProvider{
+			baseURL: baseURL(cfg.LLMBaseURL, "https://api.openai.com"),
+			apiKey:  cfg.LLMAPIKey,
+			model:   cfg.LLMModel,
+			client:  client,
+		}, nil
+	case "anthropic":
+		return &AnthropicProvider{
+			baseURL: baseURL(cfg.LLMBaseURL, "https://api.anthropic.com"),
+			apiKey:  cfg.LLMAPIKey,
+			model:   cfg.LLMModel,
+			client:  client,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported LLM provider: %s", cfg.LLMProvider)
+	}
+}
+
+// baseURL is the configured API base URL, for a proxy or compatible server, or the vendor's
+func baseURL(configured, vendor string) string {
+	if configured == "" {
+		return vendor
+	}
+	return strings.TrimRight(configured, "/")
+}
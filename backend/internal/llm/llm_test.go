@@ -0,0 +1,171 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"interview-prep-app/internal/config"
+)
+
+func newTestServer(t *testing.T, handler http.HandlerFunc) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestOpenAIProviderComplete(t *testing.T) {
+	var got openAIRequest
+	server := newTestServer(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/v1/chat/completions" {
+			t.Errorf("path = %s, want /v1/chat/completions", req.URL.Path)
+		}
+		if auth := req.Header.Get("Authorization"); auth != "Bearer key" {
+			t.Errorf("Authorization = %q, want %q", auth, "Bearer key")
+		}
+		if err := json.NewDecoder(req.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"1. Why?"}}],"usage":{"total_tokens":42}}`))
+	})
+
+	p := &OpenAIProvider{baseURL: server.URL, apiKey: "key", model: "test-model", client: server.Client()}
+	completion, err := p.Complete(context.Background(), Prompt{System: "Be brief", User: "Ask", MaxTokens: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Model != "test-model" || len(got.Messages) != 2 || got.Messages[0].Role != "system" || got.MaxTokens != 100 {
+		t.Errorf("unexpected request: %+v", got)
+	}
+	if completion.Text != "1. Why?" || completion.Tokens != 42 {
+		t.Errorf("unexpected completion: %+v", completion)
+	}
+}
+
+func TestAnthropicProviderComplete(t *testing.T) {
+	var got anthropicRequest
+	server := newTestServer(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/v1/messages" {
+			t.Errorf("path = %s, want /v1/messages", req.URL.Path)
+		}
+		if key := req.Header.Get("x-api-key"); key != "key" {
+			t.Errorf("x-api-key = %q, want %q", key, "key")
+		}
+		if err := json.NewDecoder(req.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(`{"content":[{"type":"text","text":"1. Why?"},{"type":"text","text":"\n2. How?"}],"usage":{"input_tokens":30,"output_tokens":12}}`))
+	})
+
+	p := &AnthropicProvider{baseURL: server.URL, apiKey: "key", model: "test-model", client: server.Client()}
+	completion, err := p.Complete(context.Background(), Prompt{
+		System:  "Be brief",
+		History: []Message{{Role: RoleUser, Content: "Hi"}, {Role: RoleAssistant, Content: "Hello"}},
+		User:    "Ask",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.System != "Be brief" || len(got.Messages) != 3 || got.Messages[1].Role != RoleAssistant || got.Messages[2].Content != "Ask" || got.MaxTokens != anthropicDefaultMaxTokens {
+		t.Errorf("unexpected request: %+v", got)
+	}
+	if completion.Text != "1. Why?\n2. How?" || completion.Tokens != 42 {
+		t.Errorf("unexpected completion: %+v", completion)
+	}
+}
+
+func TestProviderErrors(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"error":{"message":"slow down"}}`))
+	})
+
+	for _, p := range []Provider{
+		&OpenAIProvider{baseURL: server.URL, client: server.Client()},
+		&AnthropicProvider{baseURL: server.URL, client: server.Client()},
+	} {
+		_, err := p.Complete(context.Background(), Prompt{User: "Ask"})
+		if err == nil || !strings.Contains(err.Error(), "429: slow down") {
+			t.Errorf("%s: expected the status and message in the error, got %v", p.Name(), err)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	if p, err := New(&config.Config{}); p != nil || err != nil {
+		t.Errorf("Expected no provider without configuration, got %v, %v", p, err)
+	}
+
+	if _, err := New(&config.Config{LLMProvider: "openai"}); err == nil {
+		t.Error("Expected an error for a provider without a key and model")
+	}
+
+	if _, err := New(&config.Config{LLMProvider: "other", LLMAPIKey: "key", LLMModel: "model"}); err == nil {
+		t.Error("Expected an error for an unsupported provider")
+	}
+
+	p, err := New(&config.Config{LLMProvider: "anthropic", LLMAPIKey: "key", LLMModel: "model", LLMBaseURL: "http://proxy/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Name() != "anthropic" || p.Model() != "model" || p.(*AnthropicProvider).baseURL != "http://proxy" {
+		t.Errorf("unexpected provider: %+v", p)
+	}
+}
+
+func TestOpenAIEmbedderEmbed(t *testing.T) {
+	var got openAIEmbeddingRequest
+	server := newTestServer(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/v1/embeddings" {
+			t.Errorf("path = %s, want /v1/embeddings", req.URL.Path)
+		}
+		if err := json.NewDecoder(req.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+
+		// Answer out of order, as the API doesn't promise any
+		first, second := make([]float32, EmbeddingDimensions), make([]float32, EmbeddingDimensions)
+		first[0], second[0] = 1, 2
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": []map[string]interface{}{
+			{"index": 1, "embedding": second},
+			{"index": 0, "embedding": first},
+		}})
+	})
+
+	e := &OpenAIEmbedder{baseURL: server.URL, apiKey: "key", model: "embed-model", client: server.Client()}
+	vectors, err := e.Embed(context.Background(), []string{"Two Sum", "LRU Cache"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Model != "embed-model" || len(got.Input) != 2 || got.Dimensions != EmbeddingDimensions {
+		t.Errorf("unexpected request: %+v", got)
+	}
+	if len(vectors) != 2 || vectors[0][0] != 1 || vectors[1][0] != 2 {
+		t.Errorf("Expected the vectors in input order, got %d vectors", len(vectors))
+	}
+}
+
+func TestNewEmbedder(t *testing.T) {
+	if e, err := NewEmbedder(&config.Config{}); e != nil || err != nil {
+		t.Errorf("Expected no embedder without configuration, got %v, %v", e, err)
+	}
+
+	if _, err := NewEmbedder(&config.Config{EmbeddingModel: "embed-model", LLMProvider: "anthropic", LLMAPIKey: "key"}); err == nil {
+		t.Error("Expected an error without an embeddings key, since the Anthropic key doesn't carry over")
+	}
+
+	e, err := NewEmbedder(&config.Config{EmbeddingModel: "embed-model", LLMProvider: "openai", LLMAPIKey: "key", LLMBaseURL: "http://proxy/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if embedder := e.(*OpenAIEmbedder); embedder.apiKey != "key" || embedder.baseURL != "http://proxy" {
+		t.Errorf("Expected the OpenAI key and base URL to carry over, got %+v", embedder)
+	}
+}
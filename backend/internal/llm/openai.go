@@ -0,0 +1,99 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"interview-prep-app/pkg/apperr"
+)
+
+// OpenAIProvider completes prompts through the OpenAI chat completions API
+// (POST /v1/chat/completions), which many compatible servers also serve
+type OpenAIProvider struct {
+	baseURL string
+	apiKey  string
+	model   string
+	client  *http.Client
+}
+
+type openAIMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type openAIRequest struct {
+	Model     string          `json:"model"`
+	Messages  []openAIMessage `json:"messages"`
+	MaxTokens int             `json:"max_tokens,omitempty"`
+}
+
+type openAIResponse struct {
+	Choices []struct {
+		Message openAIMessage `json:"message"`
+	} `json:"choices"`
+	Usage struct {
+		TotalTokens int64 `json:"total_tokens"`
+	} `json:"usage"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// Name identifies the vendor
+func (p *OpenAIProvider) Name() string { return "openai" }
+
+// Model is the model prompts are sent to
+func (p *OpenAIProvider) Model() string { return p.model }
+
+// Complete sends the prompt as a system message, the history and a user message and returns the
+// first choice
+func (p *OpenAIProvider) Complete(ctx context.Context, prompt Prompt) (*Completion, error) {
+	messages := []openAIMessage{}
+	if prompt.System != "" {
+		messages = append(messages, openAIMessage{Role: "system", Content: prompt.System})
+	}
+	for _, message := range prompt.History {
+		messages = append(messages, openAIMessage{Role: message.Role, Content: message.Content})
+	}
+	messages = append(messages, openAIMessage{Role: "user", Content: prompt.User})
+
+	body, err := json.Marshal(openAIRequest{Model: p.model, Messages: messages, MaxTokens: prompt.MaxTokens})
+	if err != nil {
+		return nil, apperr.Errorf("failed to encode completion request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/v1/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return nil, apperr.Errorf("failed to create completion request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+p.apiKey)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, apperr.Errorf("failed to reach OpenAI: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var decoded openAIResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(&decoded); err != nil {
+		return nil, apperr.Errorf("failed to decode OpenAI response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		message := ""
+		if decoded.Error != nil {
+			message = decoded.Error.Message
+		}
+		return nil, fmt.Errorf("OpenAI returned %d: %s", resp.StatusCode, message)
+	}
+	if len(decoded.Choices) == 0 {
+		return nil, fmt.Errorf("OpenAI returned no choices")
+	}
+
+	return &Completion{Text: decoded.Choices[0].Message.Content, Tokens: decoded.Usage.TotalTokens}, nil
+}
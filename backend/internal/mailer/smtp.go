@@ -0,0 +1,48 @@
+package mailer
+
+import (
+	"fmt"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"interview-prep-app/pkg/apperr"
+)
+
+// SMTPMailer delivers email through an SMTP relay
+type SMTPMailer struct {
+	host     string
+	port     string
+	username string
+	password string
+	from     string
+}
+
+// Send delivers a message over SMTP
+func (m *SMTPMailer) Send(msg Message) error {
+	for _, value := range []string{msg.To, msg.Subject} {
+		if err := validateHeader(value); err != nil {
+			return err
+		}
+	}
+
+	var auth smtp.Auth
+	if m.username != "" {
+		auth = smtp.PlainAuth("", m.username, m.password, m.host)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", m.from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", msg.Subject)
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	b.WriteString(msg.Body)
+
+	if err := smtp.SendMail(m.host+":"+m.port, auth, m.from, []string{msg.To}, []byte(b.String())); err != nil {
+		return apperr.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWritePrometheusFormat(t *testing.T) {
+	r := NewRegistry()
+	completions := r.NewCounter("prepmaster_item_completions_total", "Items completed.", "category")
+	delay := r.NewHistogram("prepmaster_user_first_completion_delay_seconds", "Time to first completion.", []float64{60, 3600})
+
+	completions.Inc("dsa")
+	completions.Add(2, "dsa")
+	completions.Inc(`lld"x`)
+	delay.Observe(30)
+	delay.Observe(120)
+	delay.Observe(7200)
+
+	var out strings.Builder
+	if err := r.Write(&out); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# HELP prepmaster_item_completions_total Items completed.
+# TYPE prepmaster_item_completions_total counter
+prepmaster_item_completions_total{category="dsa"} 3
+prepmaster_item_completions_total{category="lld\"x"} 1
+# HELP prepmaster_user_first_completion_delay_seconds Time to first completion.
+# TYPE prepmaster_user_first_completion_delay_seconds histogram
+prepmaster_user_first_completion_delay_seconds_bucket{le="60"} 1
+prepmaster_user_first_completion_delay_seconds_bucket{le="3600"} 2
+prepmaster_user_first_completion_delay_seconds_bucket{le="+Inf"} 3
+prepmaster_user_first_completion_delay_seconds_sum 7350
+prepmaster_user_first_completion_delay_seconds_count 3
+`
+	if out.String() != want {
+		t.Fatalf("unexpected output:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
+
+func TestRegisterEnforcesNamingScheme(t *testing.T) {
+	for _, name := range []string{"item_completions_total", "prepmaster_ItemCompletions_total", "prepmaster_item_completions"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected %q to be rejected", name)
+				}
+			}()
+			NewRegistry().NewCounter(name, "")
+		}()
+	}
+}
@@ -3,20 +3,22 @@ package middleware
 import (
 	"interview-prep-app/internal/handlers"
 	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/requestuser"
 	"interview-prep-app/internal/services"
-	"net/http"
+	"interview-prep-app/pkg/apperr"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 )
 
-// AuthMiddleware creates a middleware that validates JWT tokens
+// AuthMiddleware creates a middleware that validates JWT tokens, or personal API keys, which
+// act as their user
 func AuthMiddleware(authHandler *handlers.AuthHandler) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get token from Authorization header
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
+			c.Error(apperr.Unauthorized("Authorization header required"))
 			c.Abort()
 			return
 		}
@@ -24,15 +26,32 @@ func AuthMiddleware(authHandler *handlers.AuthHandler) gin.HandlerFunc {
 		// Check if the header starts with "Bearer "
 		bearerToken := strings.Split(authHeader, " ")
 		if len(bearerToken) != 2 || bearerToken[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization header format"})
+			c.Error(apperr.Unauthorized("Invalid authorization header format"))
 			c.Abort()
 			return
 		}
 
+		if services.IsAPIKey(bearerToken[1]) {
+			key, user, err := authHandler.AuthenticateAPIKey(bearerToken[1])
+			if err != nil {
+				c.Error(apperr.Unauthorized("Invalid or revoked API key"))
+				c.Abort()
+				return
+			}
+
+			c.Set("userID", user.ID)
+			c.Set("userEmail", user.Email)
+			c.Set("username", user.Email)
+			c.Set("userRole", user.Role)
+			c.Set("apiKeyID", key.ID)
+			c.Next()
+			return
+		}
+
 		// Validate token
 		claims, err := authHandler.ValidateToken(bearerToken[1])
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+			c.Error(apperr.Unauthorized("Invalid or expired token"))
 			c.Abort()
 			return
 		}
@@ -41,6 +60,29 @@ func AuthMiddleware(authHandler *handlers.AuthHandler) gin.HandlerFunc {
 		c.Set("userID", claims.UserID)
 		c.Set("userEmail", claims.Email)
 		c.Set("username", claims.Username) // For backward compatibility
+		if claims.Role != "" {
+			// The user's current role, read while validating the token, so role changes apply
+			// without waiting for a new token
+			c.Set("userRole", claims.Role)
+		}
+		c.Next()
+	}
+}
+
+// LoadUser creates a middleware that attaches a lazy loader for the authenticated user to the
+// request, so the user is fetched at most once and only if something reads it. It must run
+// after AuthMiddleware; with a nil load it does nothing.
+func LoadUser(load requestuser.LoadFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := c.Get("userID")
+		if load == nil || !exists {
+			c.Next()
+			return
+		}
+
+		loader := requestuser.NewLoader(userID.(int), load)
+		c.Set(requestuser.ContextKey, loader)
+		c.Request = c.Request.WithContext(requestuser.NewContext(c.Request.Context(), loader))
 		c.Next()
 	}
 }
@@ -51,28 +93,42 @@ func RequireRole(userService *services.UserService, requiredRole models.Role) gi
 		// Get user ID from context (should be set by AuthMiddleware)
 		userID, exists := c.Get("userID")
 		if !exists {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+			c.Error(apperr.Unauthorized("User not authenticated"))
 			c.Abort()
 			return
 		}
 
-		// Get user from database to check role
-		user, err := userService.GetByID(userID.(int))
+		// Get the role from the token, or the user when the token has none
+		role, err := handlers.CurrentRole(c, userService, userID.(int))
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+			c.Error(apperr.Unauthorized("User not found"))
 			c.Abort()
 			return
 		}
 
 		// Check if user has required role
-		if user.Role != requiredRole {
-			c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		if role != requiredRole {
+			c.Error(apperr.Forbidden("Insufficient permissions"))
 			c.Abort()
 			return
 		}
 
 		// Set user role in context for convenience
-		c.Set("userRole", user.Role)
+		c.Set("userRole", role)
+		c.Next()
+	}
+}
+
+// RequirePermission creates a middleware that requires the user's role to grant a permission.
+// Admins hold every permission.
+func RequirePermission(adminHandler *handlers.AdminHandler, permission models.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := adminHandler.RequirePermission(c, permission); err != nil {
+			c.Error(apperr.Forbidden(string(permission) + " permission required"))
+			c.Abort()
+			return
+		}
+
 		c.Next()
 	}
 }
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"strings"
+	"time"
+
+	"interview-prep-app/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ChaosLatency creates a middleware that delays requests according to the injected latency.
+// Debug endpoints are never delayed so faults can always be cleared.
+func ChaosLatency(debugHandler *handlers.DebugHandler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !strings.HasPrefix(c.Request.URL.Path, "/debug") {
+			if delay := debugHandler.Latency(c.Request.URL.Path); delay > 0 {
+				select {
+				case <-time.After(delay):
+				case <-c.Request.Context().Done():
+				}
+			}
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,206 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+var gzipWriters = sync.Pool{
+	New: func() interface{} { return gzip.NewWriter(nil) },
+}
+
+// compressibleTypes are the content types worth compressing; images, archives and attachments
+// are already compressed, and event streams must reach the client as they're written
+var compressibleTypes = []string{
+	"application/json",
+	"application/javascript",
+	"application/xml",
+	"application/manifest+json",
+	"image/svg+xml",
+	"text/html",
+	"text/css",
+	"text/plain",
+	"text/csv",
+	"text/calendar",
+	"text/javascript",
+	"text/xml",
+}
+
+// Compress creates a middleware that gzips responses of at least minBytes for clients that
+// accept it. Only complete 200 responses of compressible types are compressed, so range
+// requests and streams pass through untouched. A minBytes of 0 disables compression.
+func Compress(minBytes int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if minBytes <= 0 {
+			c.Next()
+			return
+		}
+
+		c.Writer.Header().Add("Vary", "Accept-Encoding")
+		if !acceptsGzip(c.GetHeader("Accept-Encoding")) || c.GetHeader("Upgrade") != "" {
+			c.Next()
+			return
+		}
+
+		writer := &gzipWriter{ResponseWriter: c.Writer, minBytes: minBytes}
+		c.Writer = writer
+		c.Next()
+		writer.finish()
+	}
+}
+
+// acceptsGzip reports whether an Accept-Encoding header allows gzip
+func acceptsGzip(header string) bool {
+	for _, part := range strings.Split(header, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if coding != "gzip" && coding != "*" {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+				q = parsed
+			}
+		}
+		return q > 0
+	}
+	return false
+}
+
+// compressible reports whether a response's content type is worth compressing
+func compressible(contentType string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+	for _, t := range compressibleTypes {
+		if mediaType == t {
+			return true
+		}
+	}
+	return false
+}
+
+// gzipWriter holds a response back until it's known to be large enough to compress, then
+// streams the rest through gzip
+type gzipWriter struct {
+	gin.ResponseWriter
+	minBytes int
+	status   int
+	buffer   []byte
+	decided  bool
+	gz       *gzip.Writer
+}
+
+func (w *gzipWriter) WriteHeader(code int) {
+	if !w.decided {
+		w.status = code
+	}
+}
+
+// WriteHeaderNow sends the headers, deciding against compression for a body not yet written
+func (w *gzipWriter) WriteHeaderNow() {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if !w.decided {
+		w.decide(false)
+	}
+}
+
+func (w *gzipWriter) Write(data []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	if !w.decided {
+		w.buffer = append(w.buffer, data...)
+		if len(w.buffer) >= w.minBytes {
+			w.decide(true)
+		}
+		return len(data), nil
+	}
+
+	if w.gz != nil {
+		return w.gz.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *gzipWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *gzipWriter) Status() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
+
+func (w *gzipWriter) Written() bool {
+	return w.status != 0
+}
+
+// Flush sends what's been written so far, compressing it when the response already qualifies
+func (w *gzipWriter) Flush() {
+	if !w.decided {
+		if w.status == 0 {
+			w.status = http.StatusOK
+		}
+		w.decide(len(w.buffer) >= w.minBytes)
+	}
+	if w.gz != nil {
+		w.gz.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// decide sends the headers, with or without gzip, and then anything buffered so far
+func (w *gzipWriter) decide(large bool) {
+	w.decided = true
+	header := w.ResponseWriter.Header()
+
+	if large && w.status == http.StatusOK && header.Get("Content-Encoding") == "" && compressible(header.Get("Content-Type")) {
+		header.Set("Content-Encoding", "gzip")
+		header.Del("Content-Length")
+		// The compressed bytes differ, so the validator is only weakly equal
+		if etag := header.Get("ETag"); strings.HasPrefix(etag, `"`) {
+			header.Set("ETag", "W/"+etag)
+		}
+
+		w.gz = gzipWriters.Get().(*gzip.Writer)
+		w.gz.Reset(w.ResponseWriter)
+	}
+
+	w.ResponseWriter.WriteHeader(w.status)
+	if len(w.buffer) == 0 {
+		w.ResponseWriter.WriteHeaderNow()
+		return
+	}
+
+	if w.gz != nil {
+		w.gz.Write(w.buffer)
+	} else {
+		w.ResponseWriter.Write(w.buffer)
+	}
+	w.buffer = nil
+}
+
+// finish sends a response that stayed under the threshold and closes the gzip stream
+func (w *gzipWriter) finish() {
+	if !w.decided && w.status != 0 {
+		w.decide(false)
+	}
+
+	if w.gz != nil {
+		w.gz.Close()
+		gzipWriters.Put(w.gz)
+		w.gz = nil
+	}
+}
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCompress(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	large := strings.Repeat("a", 2048)
+	router := gin.New()
+	router.Use(Compress(1024))
+	router.GET("/large", func(c *gin.Context) {
+		c.Header("ETag", `"v1"`)
+		c.JSON(http.StatusOK, gin.H{"title": large})
+	})
+	router.GET("/small", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"title": "a"})
+	})
+	router.GET("/image", func(c *gin.Context) {
+		c.Data(http.StatusOK, "image/png", []byte(large))
+	})
+
+	get := func(path, acceptEncoding string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("/large", "br, gzip;q=0.8")
+	if w.Header().Get("Content-Encoding") != "gzip" || w.Header().Get("ETag") != `W/"v1"` {
+		t.Fatalf("Expected a gzipped response with a weak ETag, got %q %q", w.Header().Get("Content-Encoding"), w.Header().Get("ETag"))
+	}
+	reader, err := gzip.NewReader(w.Body)
+	if err != nil {
+		t.Fatalf("gzip.NewReader returned error: %v", err)
+	}
+	body, err := io.ReadAll(reader)
+	if err != nil || string(body) != `{"title":"`+large+`"}` {
+		t.Errorf("Expected the JSON body after decompressing, got %q, %v", body, err)
+	}
+
+	for name, w := range map[string]*httptest.ResponseRecorder{
+		"small response":      get("/small", "gzip"),
+		"image":               get("/image", "gzip"),
+		"gzip not accepted":   get("/large", "br"),
+		"gzip refused by q=0": get("/large", "gzip;q=0, identity"),
+	} {
+		if w.Header().Get("Content-Encoding") != "" || w.Code != http.StatusOK || w.Body.Len() == 0 {
+			t.Errorf("%s: expected an uncompressed 200, got %d %q", name, w.Code, w.Header().Get("Content-Encoding"))
+		}
+		if w.Header().Get("Vary") != "Accept-Encoding" {
+			t.Errorf("%s: expected Vary: Accept-Encoding", name)
+		}
+	}
+}
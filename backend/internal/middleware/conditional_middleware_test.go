@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestConditionalGET(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(ErrorHandler())
+	router.GET("/stats", ConditionalGET(), func(c *gin.Context) {
+		c.Header("Last-Modified", "Wed, 14 Oct 2026 10:00:00 GMT")
+		c.JSON(http.StatusOK, gin.H{"completed_items": 3})
+	})
+	router.GET("/missing", ConditionalGET(), func(c *gin.Context) {
+		c.Error(apperr.NotFound("not found"))
+	})
+
+	get := func(path string, header map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		for name, value := range header {
+			req.Header.Set(name, value)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	first := get("/stats", nil)
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || etag == "" || first.Body.String() != `{"completed_items":3}` {
+		t.Fatalf("Expected a 200 with an ETag and the body, got %d %q %q", first.Code, etag, first.Body.String())
+	}
+
+	for name, header := range map[string]map[string]string{
+		"matching ETag":         {"If-None-Match": etag},
+		"weak ETag in a list":   {"If-None-Match": `"other", W/` + etag},
+		"unchanged since":       {"If-Modified-Since": "Wed, 14 Oct 2026 10:00:00 GMT"},
+		"unchanged since later": {"If-Modified-Since": "Thu, 15 Oct 2026 10:00:00 GMT"},
+	} {
+		if w := get("/stats", header); w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+			t.Errorf("%s: expected an empty 304, got %d %q", name, w.Code, w.Body.String())
+		}
+	}
+
+	for name, header := range map[string]map[string]string{
+		"stale ETag":          {"If-None-Match": `"stale"`},
+		"ETag wins over date": {"If-None-Match": `"stale"`, "If-Modified-Since": "Thu, 15 Oct 2026 10:00:00 GMT"},
+		"changed since":       {"If-Modified-Since": "Tue, 13 Oct 2026 10:00:00 GMT"},
+	} {
+		if w := get("/stats", header); w.Code != http.StatusOK || w.Body.Len() == 0 {
+			t.Errorf("%s: expected a 200 with the body, got %d", name, w.Code)
+		}
+	}
+
+	if w := get("/missing", map[string]string{"If-None-Match": "*"}); w.Code != http.StatusNotFound || w.Header().Get("ETag") != "" {
+		t.Errorf("Expected errors to pass through without an ETag, got %d %q", w.Code, w.Header().Get("ETag"))
+	}
+}
@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"interview-prep-app/internal/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+var deprecatedRequests = metrics.NewCounter(
+	"prepmaster_api_deprecated_requests_total",
+	"Requests to deprecated routes, by route.",
+	"method", "route",
+)
+
+// deprecationLogEvery is how often a deprecated route's use is logged after its first call
+const deprecationLogEvery = 100
+
+// Deprecated creates a middleware for routes kept for old clients. successors maps each
+// route, as "METHOD /path/:param", to the route replacing it; responses carry Deprecation and
+// Link headers pointing at the replacement, and a Sunset header when sunset is set. Each route's
+// calls are counted in prepmaster_api_deprecated_requests_total and logged on the first call
+// and every hundredth after it, so we know when a route can be removed.
+func Deprecated(successors map[string]string, sunset time.Time) gin.HandlerFunc {
+	calls := make(map[string]*atomic.Int64, len(successors))
+	for route := range successors {
+		calls[route] = new(atomic.Int64)
+	}
+
+	return func(c *gin.Context) {
+		route := c.Request.Method + " " + c.FullPath()
+		successor, ok := successors[route]
+		if !ok {
+			c.Next()
+			return
+		}
+
+		successor = fillParams(successor, c)
+		c.Header("Deprecation", "true")
+		c.Header("Link", "<"+successor+`>; rel="successor-version"`)
+		if !sunset.IsZero() {
+			c.Header("Sunset", sunset.UTC().Format(http.TimeFormat))
+		}
+
+		deprecatedRequests.Inc(c.Request.Method, c.FullPath())
+		if n := calls[route].Add(1); n == 1 || n%deprecationLogEvery == 0 {
+			log.Printf("Deprecated route %s called (%d calls since start); clients should move to %s", route, n, successor)
+		}
+
+		c.Next()
+	}
+}
+
+// fillParams replaces the :params of a route with the request's values
+func fillParams(route string, c *gin.Context) string {
+	segments := strings.Split(route, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			segments[i] = c.Param(segment[1:])
+		}
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"interview-prep-app/internal/i18n"
+	"interview-prep-app/internal/requestuser"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorHandler creates a middleware that turns the last error attached with c.Error into the
+// {code, message, details} envelope, with the status its apperr kind maps to. Handlers and later
+// middleware report failures with c.Error and return (or c.Abort) instead of writing a response.
+// Errors without a kind are answered as internal errors and logged with their cause. Messages
+// are translated into the user's locale; ones without a translation stay in English.
+func ErrorHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		err := c.Errors.Last().Err
+		status, response := apperr.ToResponse(err)
+		if status >= http.StatusInternalServerError {
+			fmt.Printf("Error: %s %s: %v\n", c.Request.Method, c.Request.URL.Path, err)
+		}
+
+		locale := requestLocale(c)
+		response.Message = i18n.T(locale, response.Message)
+		c.Header("Content-Language", locale)
+		c.JSON(status, response)
+	}
+}
+
+// requestLocale is the locale to answer a request in: the user's saved preference when signed in,
+// and otherwise their Accept-Language header
+func requestLocale(c *gin.Context) string {
+	preferred := ""
+	if user, err := requestuser.Get(c); err == nil && user.Settings != nil {
+		preferred = user.Settings.Locale
+	}
+
+	return i18n.Resolve(preferred, c.GetHeader("Accept-Language"))
+}
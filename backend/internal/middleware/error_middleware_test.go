@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestErrorHandlerTranslates(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(ErrorHandler())
+	router.GET("/items/1", func(c *gin.Context) {
+		c.Error(apperr.NotFound("item not found"))
+	})
+
+	for language, want := range map[string]struct {
+		body, locale string
+	}{
+		"":               {`{"code":"not_found","message":"item not found","details":null}`, "en"},
+		"es-MX,en;q=0.5": {`{"code":"not_found","message":"elemento no encontrado","details":null}`, "es"},
+		"fr":             {`{"code":"not_found","message":"item not found","details":null}`, "en"},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+		req.Header.Set("Accept-Language", language)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusNotFound || w.Body.String() != want.body {
+			t.Errorf("%q: expected a 404 with %s, got %d %s", language, want.body, w.Code, w.Body.String())
+		}
+		if got := w.Header().Get("Content-Language"); got != want.locale {
+			t.Errorf("%q: expected Content-Language %q, got %q", language, want.locale, got)
+		}
+	}
+}
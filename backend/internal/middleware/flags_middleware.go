@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"interview-prep-app/internal/flags"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FeatureFlags creates a middleware that attaches the authenticated user's feature flags to the
+// request, evaluated only if something checks one. It must run after AuthMiddleware; with a nil
+// evaluate it does nothing and every flag reads as off.
+func FeatureFlags(evaluate flags.EvaluateFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := c.Get("userID")
+		if evaluate == nil || !exists {
+			c.Next()
+			return
+		}
+
+		set := flags.NewSet(userID.(int), evaluate)
+		c.Set(flags.ContextKey, set)
+		c.Request = c.Request.WithContext(flags.NewContext(c.Request.Context(), set))
+		c.Next()
+	}
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"strconv"
+
+	"interview-prep-app/internal/handlers"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OrgScope creates a middleware for routes under /orgs/:id that only lets the organization's
+// members through, setting "orgID" and "orgRole" for handlers. Everyone else gets not found, so
+// organization IDs can't be probed. It must run after AuthMiddleware; handlers still check the
+// role an action needs.
+func OrgScope(orgHandler *handlers.OrgHandler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := c.Get("userID")
+		if !exists {
+			c.Error(apperr.Unauthorized("User not authenticated"))
+			c.Abort()
+			return
+		}
+
+		orgID, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.Error(apperr.Validation("Invalid organization ID"))
+			c.Abort()
+			return
+		}
+
+		role, err := orgHandler.MemberRole(userID.(int), orgID)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.Set("orgID", orgID)
+		c.Set("orgRole", role)
+		c.Next()
+	}
+}
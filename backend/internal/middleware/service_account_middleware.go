@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"strings"
+
+	"interview-prep-app/internal/handlers"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ServiceAccountAuth creates a middleware that authenticates service account API keys
+// and requires the given scope. Keys are accepted in the X-API-Key header or as a bearer token.
+func ServiceAccountAuth(orgHandler *handlers.OrgHandler, requiredScope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader("X-API-Key")
+		if key == "" {
+			key = strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		}
+
+		if key == "" {
+			c.Error(apperr.Unauthorized("API key required"))
+			c.Abort()
+			return
+		}
+
+		account, err := orgHandler.AuthenticateServiceAccount(key)
+		if err != nil {
+			c.Error(apperr.Unauthorized("Invalid or revoked API key"))
+			c.Abort()
+			return
+		}
+
+		if !account.HasScope(requiredScope) {
+			c.Error(apperr.Forbidden("Insufficient permissions"))
+			c.Abort()
+			return
+		}
+
+		// Set service account information in context
+		c.Set("serviceAccountID", account.ID)
+		c.Set("serviceAccountOrgID", account.OrgID)
+		c.Next()
+	}
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"time"
+)
+
+// AdminSearchType is the kind of entity an admin search result is
+type AdminSearchType string
+
+const (
+	AdminSearchItem     AdminSearchType = "item"
+	AdminSearchUser     AdminSearchType = "user"
+	AdminSearchEngBlog  AdminSearchType = "eng_blog"
+	AdminSearchFeedback AdminSearchType = "feedback"
+)
+
+// ValidAdminSearchTypes returns every kind of entity admin search covers
+func ValidAdminSearchTypes() []AdminSearchType {
+	return []AdminSearchType{AdminSearchItem, AdminSearchUser, AdminSearchEngBlog, AdminSearchFeedback}
+}
+
+// IsValidAdminSearchType checks if an admin search type is valid
+func IsValidAdminSearchType(searchType AdminSearchType) bool {
+	for _, valid := range ValidAdminSearchTypes() {
+		if searchType == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// MinAdminSearchLength is the shortest query admin search accepts
+const MinAdminSearchLength = 2
+
+// MaxAdminSearchResults caps how many results of each type one search returns
+const MaxAdminSearchResults = 50
+
+// AdminSearchResult is one entity matching an admin search. Title and Subtitle describe it the
+// way its type is usually shown: an item's title and category, a user's name and email, a
+// blog's name and link, or feedback's comment and the item it's about. Status is the entity's
+// state where it has one, such as a user's role or feedback's review status.
+type AdminSearchResult struct {
+	Type      AdminSearchType `json:"type"`
+	ID        int             `json:"id"`
+	Title     string          `json:"title"`
+	Subtitle  string          `json:"subtitle,omitempty"`
+	Status    string          `json:"status,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// AdminSearchResponse lists the entities matching an admin search, grouped by type in the
+// order of Types. Types holds only the types the admin's permissions let them search.
+type AdminSearchResponse struct {
+	Query   string              `json:"query"`
+	Types   []AdminSearchType   `json:"types"`
+	Results []AdminSearchResult `json:"results"`
+}
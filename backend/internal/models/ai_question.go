@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+)
+
+// AIQuestionKind is what a generated question asks relative to its item
+type AIQuestionKind string
+
+const (
+	// AIQuestionFollowUp digs deeper into the item, as an interviewer would after a solution
+	AIQuestionFollowUp AIQuestionKind = "follow_up"
+	// AIQuestionVariation changes the item's constraints into a related problem
+	AIQuestionVariation AIQuestionKind = "variation"
+)
+
+// MaxGeneratedQuestions caps how many questions one generation asks for
+const MaxGeneratedQuestions = 5
+
+// ValidAIQuestionKinds returns all valid generated question kinds
+func ValidAIQuestionKinds() []AIQuestionKind {
+	return []AIQuestionKind{AIQuestionFollowUp, AIQuestionVariation}
+}
+
+// IsValidAIQuestionKind checks if a generated question kind is valid
+func IsValidAIQuestionKind(kind AIQuestionKind) bool {
+	for _, valid := range ValidAIQuestionKinds() {
+		if kind == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// GenerateQuestionRequest represents the request payload for generating practice questions from
+// an item. Kind defaults to follow_up and Count to 3. Refresh skips the stored questions and asks
+// the model again.
+type GenerateQuestionRequest struct {
+	ItemID  int            `json:"item_id" binding:"required,min=1"`
+	Kind    AIQuestionKind `json:"kind,omitempty" binding:"omitempty,ai_question_kind"`
+	Count   int            `json:"count,omitempty" binding:"omitempty,min=1,max=5"`
+	Refresh bool           `json:"refresh,omitempty"`
+}
+
+// GeneratedQuestions are practice questions a model wrote for an item. They are stored and
+// served again to anyone asking for the same item and kind; Cached marks a stored result.
+type GeneratedQuestions struct {
+	ID        int            `json:"id" db:"id"`
+	ItemID    int            `json:"item_id" db:"item_id"`
+	Kind      AIQuestionKind `json:"kind" db:"kind"`
+	Questions []string       `json:"questions" db:"questions"`
+	Provider  string         `json:"provider" db:"provider"`
+	Model     string         `json:"model" db:"model"`
+	Cached    bool           `json:"cached"`
+	CreatedAt time.Time      `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+)
+
+// AIBudget caps a user's AI usage per calendar month. A zero limit means unlimited.
+type AIBudget struct {
+	MonthlyCalls  int64 `json:"monthly_calls"`
+	MonthlyTokens int64 `json:"monthly_tokens"`
+}
+
+// AIFeatureUsage is a user's AI usage of one feature in a month
+type AIFeatureUsage struct {
+	Feature string `json:"feature"`
+	Calls   int64  `json:"calls"`
+	Tokens  int64  `json:"tokens"`
+}
+
+// AIUsage is a user's AI usage in the current month against their budget
+type AIUsage struct {
+	PeriodStart     time.Time         `json:"period_start"`
+	PeriodEnd       time.Time         `json:"period_end"`
+	Calls           int64             `json:"calls"`
+	Tokens          int64             `json:"tokens"`
+	Budget          AIBudget          `json:"budget"`
+	RemainingCalls  *int64            `json:"remaining_calls"`  // nil when unlimited
+	RemainingTokens *int64            `json:"remaining_tokens"` // nil when unlimited
+	Features        []*AIFeatureUsage `json:"features"`
+}
+
+// AISettings are a user's choices about what AI features may do with their data. Notes are only
+// sent to the AI provider once NotesOptIn is set.
+type AISettings struct {
+	NotesOptIn bool       `json:"notes_opt_in"`
+	UpdatedAt  *time.Time `json:"updated_at,omitempty"`
+}
+
+// UpdateAISettingsRequest represents the request payload for changing AI settings
+type UpdateAISettingsRequest struct {
+	NotesOptIn *bool `json:"notes_opt_in" binding:"required"`
+}
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// APIKey represents a personal API key that acts as its user, for scripts and the command line
+// client
+type APIKey struct {
+	ID         int        `json:"id" db:"id"`
+	UserID     int        `json:"user_id" db:"user_id"`
+	Name       string     `json:"name" db:"name"`
+	KeyPrefix  string     `json:"key_prefix" db:"key_prefix"`
+	KeyHash    string     `json:"-" db:"key_hash"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" db:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+}
+
+// CreateAPIKeyRequest represents the request payload for creating a personal API key
+type CreateAPIKeyRequest struct {
+	Name string `json:"name" binding:"required,notblank,max=100"`
+}
+
+// CreateAPIKeyResponse includes the key itself, which is only ever shown once
+type CreateAPIKeyResponse struct {
+	APIKey *APIKey `json:"api_key"`
+	Key    string  `json:"key"`
+}
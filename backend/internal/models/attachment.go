@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+)
+
+// AttachmentKind distinguishes uploaded files from encrypted secrets
+type AttachmentKind string
+
+const (
+	AttachmentKindFile   AttachmentKind = "file"
+	AttachmentKindSecret AttachmentKind = "secret"
+)
+
+// MaxSecretAttachmentLength caps the size of an encrypted attachment's value
+const MaxSecretAttachmentLength = 4000
+
+// FileAttachment represents an uploaded file or encrypted secret attached to an item by a user.
+// For secrets, FileName holds the (unencrypted) label and Secret is only filled in for the owner.
+type FileAttachment struct {
+	ID          int            `json:"id" db:"id"`
+	ItemID      int            `json:"item_id" db:"item_id"`
+	UserID      int            `json:"user_id" db:"user_id"`
+	Kind        AttachmentKind `json:"kind" db:"kind"`
+	FileName    string         `json:"file_name" db:"file_name"`
+	ContentType string         `json:"content_type" db:"content_type"`
+	SizeBytes   int64          `json:"size_bytes" db:"size_bytes"`
+	StorageKey  string         `json:"-" db:"storage_key"`
+	Ciphertext  []byte         `json:"-" db:"ciphertext"`
+	DownloadURL string         `json:"download_url,omitempty"`
+	Secret      string         `json:"secret,omitempty"`
+	CreatedAt   time.Time      `json:"created_at" db:"created_at"`
+}
+
+// CreateSecretAttachmentRequest represents the request payload for adding an encrypted attachment
+type CreateSecretAttachmentRequest struct {
+	Label string `json:"label" binding:"required,notblank,max=255"`
+	Value string `json:"value" binding:"required,max=4000"`
+}
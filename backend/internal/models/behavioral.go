@@ -0,0 +1,107 @@
+package models
+
+import (
+	"time"
+)
+
+// Competency is a skill a behavioral question probes, used to tag and filter questions
+type Competency string
+
+const (
+	CompetencyLeadership     Competency = "leadership"
+	CompetencyConflict       Competency = "conflict"
+	CompetencyTeamwork       Competency = "teamwork"
+	CompetencyOwnership      Competency = "ownership"
+	CompetencyFailure        Competency = "failure"
+	CompetencyCommunication  Competency = "communication"
+	CompetencyAmbiguity      Competency = "ambiguity"
+	CompetencyInfluence      Competency = "influence"
+	CompetencyPrioritization Competency = "prioritization"
+	CompetencyCustomerFocus  Competency = "customer_focus"
+)
+
+// ValidCompetencies returns all valid competencies
+func ValidCompetencies() []Competency {
+	return []Competency{
+		CompetencyLeadership, CompetencyConflict, CompetencyTeamwork, CompetencyOwnership, CompetencyFailure,
+		CompetencyCommunication, CompetencyAmbiguity, CompetencyInfluence, CompetencyPrioritization, CompetencyCustomerFocus,
+	}
+}
+
+// IsValidCompetency checks if a competency is valid
+func IsValidCompetency(competency Competency) bool {
+	for _, valid := range ValidCompetencies() {
+		if competency == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// BehavioralQuestion is a question from the shared behavioral interview bank. Answer is the
+// requesting user's STAR draft, when they've started one.
+type BehavioralQuestion struct {
+	ID           int          `json:"id" db:"id"`
+	Question     string       `json:"question" db:"question"`
+	Competencies []Competency `json:"competencies" db:"competencies"`
+	CreatedAt    time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at" db:"updated_at"`
+
+	Answer *BehavioralAnswer `json:"answer,omitempty"`
+}
+
+// BehavioralAnswer is a user's STAR-format (situation, task, action, result) answer draft for a
+// question, along with how often they've practiced it
+type BehavioralAnswer struct {
+	ID              int        `json:"id" db:"id"`
+	UserID          int        `json:"user_id" db:"user_id"`
+	QuestionID      int        `json:"question_id" db:"question_id"`
+	Situation       string     `json:"situation" db:"situation"`
+	Task            string     `json:"task" db:"task"`
+	Action          string     `json:"action" db:"action"`
+	Result          string     `json:"result" db:"result"`
+	PracticeCount   int        `json:"practice_count" db:"practice_count"`
+	LastPracticedAt *time.Time `json:"last_practiced_at,omitempty" db:"last_practiced_at"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// BehavioralQuestionFilter narrows the question bank listing
+type BehavioralQuestionFilter struct {
+	Competency   *Competency
+	AnsweredOnly bool // only questions the user has drafted an answer for
+	Limit        int
+	Offset       int
+}
+
+// CreateBehavioralQuestionRequest represents the request payload for adding a question to the bank
+type CreateBehavioralQuestionRequest struct {
+	Question     string       `json:"question" binding:"required,notblank,max=1000"`
+	Competencies []Competency `json:"competencies" binding:"required,min=1,dive,competency"`
+}
+
+// UpdateBehavioralQuestionRequest represents the request payload for editing a bank question
+type UpdateBehavioralQuestionRequest struct {
+	Question     *string       `json:"question,omitempty" binding:"omitempty,notblank,max=1000"`
+	Competencies *[]Competency `json:"competencies,omitempty" binding:"omitempty,min=1,dive,competency"`
+}
+
+// SaveBehavioralAnswerRequest represents the request payload for saving a STAR answer draft.
+// Parts can be left empty while the draft is in progress.
+type SaveBehavioralAnswerRequest struct {
+	Situation string `json:"situation" binding:"max=5000"`
+	Task      string `json:"task" binding:"max=5000"`
+	Action    string `json:"action" binding:"max=5000"`
+	Result    string `json:"result" binding:"max=5000"`
+}
+
+// BehavioralQuestionsResponse is a page of the behavioral question bank
+type BehavioralQuestionsResponse struct {
+	Questions  []*BehavioralQuestion `json:"questions"`
+	Pagination PaginationMeta        `json:"pagination"`
+}
+
+// BehavioralPracticeResponse is a set of questions to practice answering out loud
+type BehavioralPracticeResponse struct {
+	Questions []*BehavioralQuestion `json:"questions"`
+}
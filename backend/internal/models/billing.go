@@ -0,0 +1,126 @@
+package models
+
+import (
+	"time"
+)
+
+// Plan is a subscription tier
+type Plan string
+
+const (
+	PlanFree Plan = "free"
+	PlanPro  Plan = "pro"
+)
+
+// IsValidPlan checks if a plan is valid
+func IsValidPlan(plan Plan) bool {
+	return plan == PlanFree || plan == PlanPro
+}
+
+// Feature is a capability gated by plan
+type Feature string
+
+const (
+	FeatureAIHints        Feature = "ai_hints"
+	FeatureUnlimitedTests Feature = "unlimited_tests"
+	FeatureOrgSeats       Feature = "org_seats"
+)
+
+// ProFeatures are the features only the pro plan includes
+var ProFeatures = []Feature{FeatureAIHints, FeatureUnlimitedTests, FeatureOrgSeats}
+
+// Subscription statuses mirror Stripe's; "none" means the user never subscribed and "expired"
+// marks a trial the app granted that ran out
+const (
+	SubscriptionStatusNone     = "none"
+	SubscriptionStatusActive   = "active"
+	SubscriptionStatusTrialing = "trialing"
+	SubscriptionStatusPastDue  = "past_due"
+	SubscriptionStatusCanceled = "canceled"
+	SubscriptionStatusExpired  = "expired"
+)
+
+// Subscription is a user's plan and the state of its Stripe subscription. Comped users were
+// granted the pro plan by an admin regardless of Stripe.
+type Subscription struct {
+	UserID               int        `json:"user_id" db:"user_id"`
+	Plan                 Plan       `json:"plan" db:"plan"`
+	Status               string     `json:"status" db:"status"`
+	StripeCustomerID     string     `json:"-" db:"stripe_customer_id"`
+	StripeSubscriptionID string     `json:"-" db:"stripe_subscription_id"`
+	CurrentPeriodEnd     *time.Time `json:"current_period_end,omitempty" db:"current_period_end"`
+	CancelAtPeriodEnd    bool       `json:"cancel_at_period_end" db:"cancel_at_period_end"`
+	Comped               bool       `json:"comped" db:"comped"`
+	TrialStartedAt       *time.Time `json:"trial_started_at,omitempty" db:"trial_started_at"`
+	TrialEndsAt          *time.Time `json:"trial_ends_at,omitempty" db:"trial_ends_at"`
+	DowngradedAt         *time.Time `json:"downgraded_at,omitempty" db:"downgraded_at"`
+	PastDueSince         *time.Time `json:"past_due_since,omitempty" db:"past_due_since"`
+	UpdatedAt            time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// IsAppTrial reports whether the subscription is a trial the app granted rather than one run by Stripe
+func (s *Subscription) IsAppTrial() bool {
+	return s.Status == SubscriptionStatusTrialing && s.StripeSubscriptionID == "" && s.TrialEndsAt != nil
+}
+
+// EffectivePlan returns the plan the user is entitled to right now
+func (s *Subscription) EffectivePlan() Plan {
+	if s.Comped {
+		return PlanPro
+	}
+	if s.Plan != PlanPro {
+		return PlanFree
+	}
+	// An app trial ends on time even if the expiry job hasn't caught up yet
+	if s.IsAppTrial() && !s.TrialEndsAt.After(time.Now()) {
+		return PlanFree
+	}
+	switch s.Status {
+	case SubscriptionStatusActive, SubscriptionStatusTrialing, SubscriptionStatusPastDue:
+		return PlanPro
+	}
+	return PlanFree
+}
+
+// Entitlements describes what a user's plan allows. Nil limits are unlimited. After a downgrade
+// the data a user built up with pro features stays readable: ReadOnlyFeatures lists those
+// features, which can be viewed but not used to create anything new.
+type Entitlements struct {
+	Plan             Plan          `json:"plan"`
+	Features         []Feature     `json:"features"`
+	ReadOnlyFeatures []Feature     `json:"read_only_features"`
+	MonthlyTests     *int          `json:"monthly_tests"`
+	OrgSeats         *int          `json:"org_seats"`
+	Subscription     *Subscription `json:"subscription,omitempty"`
+}
+
+// HasFeature reports whether the entitlements include a feature
+func (e *Entitlements) HasFeature(feature Feature) bool {
+	for _, f := range e.Features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// BillingURLResponse carries a Stripe-hosted page to redirect the user to
+type BillingURLResponse struct {
+	URL string `json:"url"`
+}
+
+// SetPlanRequest represents the request payload for an admin granting or revoking a plan
+type SetPlanRequest struct {
+	Plan Plan `json:"plan" binding:"required,plan"`
+}
+
+// BillingNoticeKind identifies a billing notification sent to a user
+type BillingNoticeKind string
+
+const (
+	BillingNoticeTrialEnding    BillingNoticeKind = "trial_ending"
+	BillingNoticeTrialExpired   BillingNoticeKind = "trial_expired"
+	BillingNoticePaymentFailed  BillingNoticeKind = "payment_failed"
+	BillingNoticePaymentOverdue BillingNoticeKind = "payment_overdue"
+	BillingNoticeDowngraded     BillingNoticeKind = "downgraded"
+)
@@ -0,0 +1,14 @@
+package models
+
+import (
+	"time"
+)
+
+// CalendarFeed describes a user's subscribable ICS feed. The feed URL embeds a secret token
+// and is only returned when the feed is created or rotated.
+type CalendarFeed struct {
+	Enabled        bool       `json:"enabled"`
+	URL            string     `json:"url,omitempty"`
+	CreatedAt      *time.Time `json:"created_at,omitempty" db:"created_at"`
+	LastAccessedAt *time.Time `json:"last_accessed_at,omitempty" db:"last_accessed_at"`
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"time"
+)
+
+// CatalogSnapshotVersion is the version of the catalog snapshot format written by this build
+const CatalogSnapshotVersion = 1
+
+// CatalogSnapshot is the global content catalog in a portable form, keyed by natural keys rather
+// than database IDs so it can be moved between environments (staging to production)
+type CatalogSnapshot struct {
+	Version    int               `json:"version"`
+	Source     string            `json:"source,omitempty"` // environment the snapshot was exported from
+	ExportedAt time.Time         `json:"exported_at"`
+	Categories []CatalogCategory `json:"categories"`
+	Companies  []CatalogCompany  `json:"companies"`
+	Items      []CatalogItem     `json:"items"`
+	EngBlogs   []CatalogEngBlog  `json:"eng_blogs"`
+}
+
+// CatalogCategory lists a category and its known subcategories. Categories are managed by admins
+// in each environment, so they're exported for reference and checked on apply rather than written.
+type CatalogCategory struct {
+	Category      Category `json:"category"`
+	Subcategories []string `json:"subcategories"`
+}
+
+// CatalogCompany is a company in the catalog, keyed by slug
+type CatalogCompany struct {
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+}
+
+// CatalogItem is an item in the catalog, keyed by link, with its hints in ladder order and the
+// slugs of the companies it's tagged with
+type CatalogItem struct {
+	Title       string      `json:"title"`
+	Link        string      `json:"link"`
+	Category    Category    `json:"category"`
+	Subcategory string      `json:"subcategory"`
+	Attachments Attachments `json:"attachments,omitempty"`
+	Hints       []string    `json:"hints,omitempty"`
+	Companies   []string    `json:"companies,omitempty"`
+}
+
+// CatalogEngBlog is an engineering blog in the catalog, keyed by link
+type CatalogEngBlog struct {
+	Name     string                  `json:"name"`
+	Link     string                  `json:"link"`
+	OrderIdx int                     `json:"order_idx"`
+	Articles []CatalogEngBlogArticle `json:"articles"`
+}
+
+// CatalogEngBlogArticle is an article of an engineering blog, keyed by link within the blog
+type CatalogEngBlogArticle struct {
+	Title        string `json:"title"`
+	ExternalLink string `json:"external_link"`
+	OrderIdx     int    `json:"order_idx"`
+}
+
+// Catalog entities a change can apply to
+const (
+	CatalogEntityCompany        = "company"
+	CatalogEntityItem           = "item"
+	CatalogEntityItemHints      = "item_hints"
+	CatalogEntityItemCompanies  = "item_companies"
+	CatalogEntityEngBlog        = "eng_blog"
+	CatalogEntityEngBlogArticle = "eng_blog_article"
+)
+
+// Catalog change actions. Applying never deletes: content missing from a snapshot is left alone.
+const (
+	CatalogActionCreate = "create"
+	CatalogActionUpdate = "update"
+)
+
+// CatalogChange is one difference between a snapshot and the target catalog
+type CatalogChange struct {
+	Entity string   `json:"entity"`
+	Key    string   `json:"key"`
+	Action string   `json:"action"`
+	Fields []string `json:"fields,omitempty"` // the fields an update changes
+}
+
+// CatalogDiff reports what applying a snapshot changes. In a dry run nothing is written.
+type CatalogDiff struct {
+	DryRun    bool            `json:"dry_run"`
+	Created   int             `json:"created"`
+	Updated   int             `json:"updated"`
+	Unchanged int             `json:"unchanged"`
+	Changes   []CatalogChange `json:"changes"`
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+)
+
+// CategoryDefinition is a content track items are filed under, with the subcategories admins
+// have listed for it. Categories live in the database, so new tracks don't need a deploy.
+type CategoryDefinition struct {
+	Slug          Category  `json:"slug" db:"slug"`
+	Name          string    `json:"name" db:"name"`
+	OrderIdx      int       `json:"order_idx" db:"order_idx"`
+	Subcategories []string  `json:"subcategories"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// CreateCategoryRequest represents the request payload for adding a category
+type CreateCategoryRequest struct {
+	Slug          Category `json:"slug" binding:"required,category,max=50"`
+	Name          string   `json:"name" binding:"required,notblank,max=100"`
+	OrderIdx      int      `json:"order_idx,omitempty"`
+	Subcategories []string `json:"subcategories,omitempty" binding:"dive,max=100"`
+}
+
+// UpdateCategoryRequest represents the request payload for updating a category. The slug can't
+// change, since items reference it; Subcategories replaces the whole list when set.
+type UpdateCategoryRequest struct {
+	Name          *string   `json:"name,omitempty" binding:"omitempty,notblank,max=100"`
+	OrderIdx      *int      `json:"order_idx,omitempty"`
+	Subcategories *[]string `json:"subcategories,omitempty" binding:"omitempty,dive,max=100"`
+}
@@ -0,0 +1,34 @@
+package models
+
+// Client feature flags reported to the frontend
+const (
+	ClientFeatureBilling              = "billing"
+	ClientFeatureTrials               = "trials"
+	ClientFeatureReminders            = "reminders"
+	ClientFeatureWebPush              = "web_push"
+	ClientFeatureEncryptedAttachments = "encrypted_attachments"
+)
+
+// ClientConfig is the backend-controlled configuration the frontend needs before rendering
+type ClientConfig struct {
+	Environment    string                `json:"environment"`
+	Features       map[string]bool       `json:"features"`
+	OAuthProviders []ClientOAuthProvider `json:"oauth_providers"`
+	Uploads        ClientUploadConfig    `json:"uploads"`
+	Categories     []CatalogCategory     `json:"categories"`
+	Statuses       []Status              `json:"statuses"`
+	Qualities      []CompletionQuality   `json:"completion_qualities"`
+}
+
+// ClientOAuthProvider is a social login provider the frontend should offer
+type ClientOAuthProvider struct {
+	Provider    AuthProvider `json:"provider"`
+	ClientID    string       `json:"client_id"`
+	RedirectURI string       `json:"redirect_uri,omitempty"`
+}
+
+// ClientUploadConfig describes the attachment uploads the backend accepts
+type ClientUploadConfig struct {
+	MaxBytes     int64    `json:"max_bytes"`
+	AllowedTypes []string `json:"allowed_types"`
+}
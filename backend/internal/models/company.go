@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+)
+
+// Company represents a company that asks interview questions; items are tagged with companies
+// so users can prepare for a specific interview loop
+type Company struct {
+	ID        int       `json:"id" db:"id"`
+	Slug      string    `json:"slug" db:"slug"`
+	Name      string    `json:"name" db:"name"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// CreateCompanyRequest represents the request payload for adding a company
+type CreateCompanyRequest struct {
+	Name string `json:"name" binding:"required,notblank"`
+	Slug string `json:"slug,omitempty"` // derived from the name when empty
+}
+
+// SetItemCompaniesRequest represents the request payload for replacing an item's company tags
+type SetItemCompaniesRequest struct {
+	Companies []string `json:"companies"` // company slugs
+}
+
+// CompanyStats represents a user's progress on the items tagged with a company
+type CompanyStats struct {
+	Company            Company `json:"company"`
+	TotalItems         int     `json:"total_items"`
+	CompletedItems     int     `json:"completed_items"`
+	InProgressItems    int     `json:"in_progress_items"`
+	PendingItems       int     `json:"pending_items"`
+	ProgressPercentage float64 `json:"progress_percentage"`
+}
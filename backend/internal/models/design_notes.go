@@ -0,0 +1,75 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// DesignAPIMethods are the HTTP methods accepted for a design notes API endpoint
+var DesignAPIMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE"}
+
+// DesignNotes is a user's structured system design write-up for an HLD item. It's embedded in the
+// PUT /items/:id/design-notes payload, so its binding tags are the document's schema.
+type DesignNotes struct {
+	Requirements DesignRequirements  `json:"requirements"`
+	Estimation   string              `json:"estimation" binding:"max=10000"`
+	API          []DesignAPIEndpoint `json:"api" binding:"max=50,dive"`
+	DataModel    string              `json:"data_model" binding:"max=10000"`
+	DiagramLink  string              `json:"diagram_link" binding:"max=2048"`
+}
+
+// DesignRequirements lists what the designed system must do and how well
+type DesignRequirements struct {
+	Functional    []string `json:"functional" binding:"max=50,dive,max=1000"`
+	NonFunctional []string `json:"non_functional" binding:"max=50,dive,max=1000"`
+}
+
+// DesignAPIEndpoint is one endpoint of the designed system's API
+type DesignAPIEndpoint struct {
+	Method      string `json:"method" binding:"required"`
+	Path        string `json:"path" binding:"required,max=500"`
+	Description string `json:"description" binding:"max=1000"`
+}
+
+// IsEmpty reports whether no section of the notes has been filled in
+func (n DesignNotes) IsEmpty() bool {
+	return len(n.Requirements.Functional) == 0 && len(n.Requirements.NonFunctional) == 0 &&
+		n.Estimation == "" && len(n.API) == 0 && n.DataModel == "" && n.DiagramLink == ""
+}
+
+// Value implements the driver.Valuer interface for database storage
+func (n DesignNotes) Value() (driver.Value, error) {
+	return json.Marshal(n)
+}
+
+// Scan implements the sql.Scanner interface for database retrieval
+func (n *DesignNotes) Scan(value interface{}) error {
+	if value == nil {
+		*n = DesignNotes{}
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into DesignNotes", value)
+	}
+
+	return json.Unmarshal(bytes, n)
+}
+
+// ItemDesignNotes is a user's design notes for an item
+type ItemDesignNotes struct {
+	ItemID    int         `json:"item_id" db:"item_id"`
+	Notes     DesignNotes `json:"notes" db:"notes"`
+	Version   int         `json:"version,omitempty" db:"version"`
+	UpdatedAt *time.Time  `json:"updated_at,omitempty" db:"updated_at"`
+}
+
+// SaveDesignNotesRequest is the PUT /items/:id/design-notes payload: the notes, and optionally
+// the version they were based on, so saving over notes changed since is refused
+type SaveDesignNotesRequest struct {
+	DesignNotes
+	Version *int `json:"version,omitempty" binding:"omitempty,min=1"`
+}
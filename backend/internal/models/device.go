@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+)
+
+// DevicePlatform identifies which push service delivers to a device
+type DevicePlatform string
+
+const (
+	DevicePlatformWeb     DevicePlatform = "web"     // Web Push (VAPID)
+	DevicePlatformAndroid DevicePlatform = "android" // Firebase Cloud Messaging
+	DevicePlatformIOS     DevicePlatform = "ios"     // Apple Push Notification service
+)
+
+// IsValidDevicePlatform checks if the platform is supported
+func IsValidDevicePlatform(platform DevicePlatform) bool {
+	switch platform {
+	case DevicePlatformWeb, DevicePlatformAndroid, DevicePlatformIOS:
+		return true
+	default:
+		return false
+	}
+}
+
+// Device represents a push destination registered by a user.
+// Token holds the FCM/APNs device token, or the subscription endpoint for web push.
+type Device struct {
+	ID         int            `json:"id" db:"id"`
+	UserID     int            `json:"user_id" db:"user_id"`
+	Platform   DevicePlatform `json:"platform" db:"platform"`
+	Token      string         `json:"-" db:"token"`
+	P256dh     string         `json:"-" db:"p256dh"`
+	Auth       string         `json:"-" db:"auth"`
+	Label      string         `json:"label,omitempty" db:"label"`
+	CreatedAt  time.Time      `json:"created_at" db:"created_at"`
+	LastSeenAt time.Time      `json:"last_seen_at" db:"last_seen_at"`
+}
+
+// WebPushKeys holds the keys from a browser PushSubscription
+type WebPushKeys struct {
+	P256dh string `json:"p256dh" binding:"required"`
+	Auth   string `json:"auth" binding:"required"`
+}
+
+// RegisterDeviceRequest represents the request payload for registering a push device.
+// Mobile apps send a token; browsers send their PushSubscription endpoint and keys.
+type RegisterDeviceRequest struct {
+	Platform DevicePlatform `json:"platform" binding:"required,device_platform"`
+	Token    string         `json:"token,omitempty"`
+	Endpoint string         `json:"endpoint,omitempty"`
+	Keys     *WebPushKeys   `json:"keys,omitempty"`
+	Label    string         `json:"label,omitempty" binding:"max=100"`
+}
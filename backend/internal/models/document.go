@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+)
+
+// DocumentKind is what a document holds
+type DocumentKind string
+
+const (
+	// DocumentRevisionSheet is a condensed summary of a user's notes to revise from
+	DocumentRevisionSheet DocumentKind = "revision_sheet"
+)
+
+// Document is a piece of text saved for a user, such as a revision sheet the AI wrote from their
+// notes. SourceItems counts the items whose notes went into it; Truncated is set when some notes
+// were left out to stay within the token budget.
+type Document struct {
+	ID          int          `json:"id" db:"id"`
+	UserID      int          `json:"user_id" db:"user_id"`
+	Kind        DocumentKind `json:"kind" db:"kind"`
+	Title       string       `json:"title" db:"title"`
+	Category    Category     `json:"category" db:"category"`
+	Subcategory string       `json:"subcategory,omitempty" db:"subcategory"`
+	Content     string       `json:"content" db:"content"`
+	SourceItems int          `json:"source_items" db:"source_items"`
+	Truncated   bool         `json:"truncated" db:"truncated"`
+	Provider    string       `json:"provider,omitempty" db:"provider"`
+	Model       string       `json:"model,omitempty" db:"model"`
+	CreatedAt   time.Time    `json:"created_at" db:"created_at"`
+}
+
+// DocumentsResponse is a user's documents, newest first
+type DocumentsResponse struct {
+	Documents []*Document `json:"documents"`
+}
+
+// SummarizeNotesRequest represents the request payload for condensing a user's notes in a
+// category, or one of its subcategories, into a revision sheet
+type SummarizeNotesRequest struct {
+	Category    Category `json:"category" binding:"required,notblank,max=50"`
+	Subcategory string   `json:"subcategory,omitempty" binding:"max=100"`
+	Title       string   `json:"title,omitempty" binding:"max=255"`
+}
+
+// ItemNote is the notes a user wrote on an item
+type ItemNote struct {
+	ItemID      int    `json:"item_id"`
+	Title       string `json:"title"`
+	Subcategory string `json:"subcategory"`
+	Notes       string `json:"notes"`
+}
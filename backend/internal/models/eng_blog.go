@@ -6,10 +6,11 @@ import (
 
 // EngBlogProblem represents a practice problem/article within an engineering blog
 type EngBlogProblem struct {
-	ID           string `json:"id"`
-	Title        string `json:"title"`
-	OrderIdx     int    `json:"order_idx"`
-	ExternalLink string `json:"external_link"`
+	ID           string     `json:"id"`
+	Title        string     `json:"title"`
+	OrderIdx     int        `json:"order_idx"`
+	ExternalLink string     `json:"external_link"`
+	ArchivedAt   *time.Time `json:"archived_at,omitempty"`
 }
 
 // EngBlog represents an engineering blog company with its articles
@@ -18,6 +19,7 @@ type EngBlog struct {
 	Name             string           `json:"name"`
 	Link             string           `json:"link"`
 	OrderIdx         int              `json:"order_idx"`
+	ArchivedAt       *time.Time       `json:"archived_at,omitempty"`
 	PracticeProblems []EngBlogProblem `json:"practice_problems"`
 }
 
@@ -31,23 +33,25 @@ type EngBlogsResponse struct {
 
 // EngBlogDB represents an engineering blog in the database
 type EngBlogDB struct {
-	ID        int       `json:"id" db:"id"`
-	Name      string    `json:"name" db:"name"`
-	Link      string    `json:"link" db:"link"`
-	OrderIdx  int       `json:"order_idx" db:"order_idx"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	ID         int        `json:"id" db:"id"`
+	Name       string     `json:"name" db:"name"`
+	Link       string     `json:"link" db:"link"`
+	OrderIdx   int        `json:"order_idx" db:"order_idx"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at" db:"updated_at"`
+	ArchivedAt *time.Time `json:"archived_at,omitempty" db:"archived_at"`
 }
 
 // EngBlogArticleDB represents an engineering blog article in the database
 type EngBlogArticleDB struct {
-	ID           int       `json:"id" db:"id"`
-	BlogID       int       `json:"blog_id" db:"blog_id"`
-	Title        string    `json:"title" db:"title"`
-	OrderIdx     int       `json:"order_idx" db:"order_idx"`
-	ExternalLink string    `json:"external_link" db:"external_link"`
-	CreatedAt    time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+	ID           int        `json:"id" db:"id"`
+	BlogID       int        `json:"blog_id" db:"blog_id"`
+	Title        string     `json:"title" db:"title"`
+	OrderIdx     int        `json:"order_idx" db:"order_idx"`
+	ExternalLink string     `json:"external_link" db:"external_link"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`
+	ArchivedAt   *time.Time `json:"archived_at,omitempty" db:"archived_at"`
 }
 
 // EngBlogWithArticles represents a blog with its articles from the database
@@ -55,3 +59,16 @@ type EngBlogWithArticles struct {
 	EngBlogDB
 	Articles []EngBlogArticleDB `json:"articles"`
 }
+
+// Defaults for items promoted from an eng blog article
+const (
+	PromotedArticleCategory    = CategoryHLD
+	PromotedArticleSubcategory = "case studies"
+)
+
+// PromoteArticleRequest represents the optional payload for promoting an eng blog article to an
+// item; the category and subcategory default to hld / "case studies"
+type PromoteArticleRequest struct {
+	Category    Category `json:"category,omitempty" binding:"omitempty,category"`
+	Subcategory string   `json:"subcategory,omitempty"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// Feature flags checked by the backend and frontend
+const (
+	FlagStudyPlanner = "study_planner"
+)
+
+// FeatureFlag gates a feature during its rollout. A disabled flag is off for everyone; an
+// enabled one is on for the listed users and for RolloutPercent percent of the rest.
+type FeatureFlag struct {
+	Key            string    `json:"key" db:"key"`
+	Description    string    `json:"description" db:"description"`
+	Enabled        bool      `json:"enabled" db:"enabled"`
+	RolloutPercent int       `json:"rollout_percent" db:"rollout_percent"`
+	UserIDs        []int64   `json:"user_ids" db:"user_ids"`
+	Override       *bool     `json:"override,omitempty"` // forced on or off by the server's FEATURE_FLAGS
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// UpdateFeatureFlagRequest creates a flag or changes an existing one; fields left out keep
+// their current values (off, 0% and nobody for a new flag)
+type UpdateFeatureFlagRequest struct {
+	Description    *string `json:"description"`
+	Enabled        *bool   `json:"enabled"`
+	RolloutPercent *int    `json:"rollout_percent" binding:"omitempty,min=0,max=100"`
+	UserIDs        []int64 `json:"user_ids" binding:"omitempty,dive,gt=0"`
+}
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"time"
+)
+
+// FeedbackCategory is what a user is reporting about an item
+type FeedbackCategory string
+
+const (
+	FeedbackDeadLink      FeedbackCategory = "dead_link"
+	FeedbackWrongCategory FeedbackCategory = "wrong_category"
+	FeedbackDuplicate     FeedbackCategory = "duplicate"
+	FeedbackOther         FeedbackCategory = "other"
+)
+
+// FeedbackStatus represents where a report sits in the admin review queue
+type FeedbackStatus string
+
+const (
+	FeedbackStatusOpen      FeedbackStatus = "open"
+	FeedbackStatusResolved  FeedbackStatus = "resolved"
+	FeedbackStatusDismissed FeedbackStatus = "dismissed"
+)
+
+// ItemFeedback is a user's report of a problem with an item
+type ItemFeedback struct {
+	ID           int              `json:"id" db:"id"`
+	ItemID       int              `json:"item_id" db:"item_id"`
+	UserID       *int             `json:"user_id,omitempty" db:"user_id"` // nil once the reporter's account is deleted
+	ReporterName string           `json:"reporter_name,omitempty"`
+	Category     FeedbackCategory `json:"category" db:"category"`
+	Comment      string           `json:"comment,omitempty" db:"comment"`
+	Status       FeedbackStatus   `json:"status" db:"status"`
+	ReviewedBy   *int             `json:"reviewed_by,omitempty" db:"reviewed_by"`
+	ReviewNote   string           `json:"review_note,omitempty" db:"review_note"`
+	CreatedAt    time.Time        `json:"created_at" db:"created_at"`
+	ReviewedAt   *time.Time       `json:"reviewed_at,omitempty" db:"reviewed_at"`
+	Item         *Item            `json:"item,omitempty"`
+}
+
+// CreateFeedbackRequest represents the request payload for reporting a problem with an item
+type CreateFeedbackRequest struct {
+	Category FeedbackCategory `json:"category" binding:"required,feedback_category"`
+	Comment  string           `json:"comment,omitempty" binding:"max=2000"`
+}
+
+// ReviewFeedbackRequest represents the request payload for resolving or dismissing a report
+type ReviewFeedbackRequest struct {
+	Note string `json:"note,omitempty" binding:"max=2000"`
+}
+
+// FeedbackQueueResponse is a page of the admin feedback review queue
+type FeedbackQueueResponse struct {
+	Feedback   []*ItemFeedback `json:"feedback"`
+	Pagination PaginationMeta  `json:"pagination"`
+}
+
+// IsValidFeedbackCategory checks if a feedback category is valid
+func IsValidFeedbackCategory(category FeedbackCategory) bool {
+	switch category {
+	case FeedbackDeadLink, FeedbackWrongCategory, FeedbackDuplicate, FeedbackOther:
+		return true
+	}
+	return false
+}
+
+// IsValidFeedbackStatus checks if a feedback status is valid
+func IsValidFeedbackStatus(status FeedbackStatus) bool {
+	switch status {
+	case FeedbackStatusOpen, FeedbackStatusResolved, FeedbackStatusDismissed:
+		return true
+	}
+	return false
+}
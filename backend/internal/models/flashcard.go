@@ -0,0 +1,69 @@
+package models
+
+import (
+	"time"
+)
+
+// FlashcardGrade is how well a user recalled a flashcard's answer
+type FlashcardGrade string
+
+const (
+	FlashcardGradeAgain FlashcardGrade = "again" // forgot; start the schedule over
+	FlashcardGradeHard  FlashcardGrade = "hard"  // recalled with effort; short intervals
+	FlashcardGradeGood  FlashcardGrade = "good"  // recalled easily; long intervals
+)
+
+// ValidFlashcardGrades returns all valid flashcard grades
+func ValidFlashcardGrades() []FlashcardGrade {
+	return []FlashcardGrade{FlashcardGradeAgain, FlashcardGradeHard, FlashcardGradeGood}
+}
+
+// IsValidFlashcardGrade checks if a flashcard grade is valid
+func IsValidFlashcardGrade(grade FlashcardGrade) bool {
+	for _, valid := range ValidFlashcardGrades() {
+		if grade == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// Flashcard is a user's question/answer card attached to an item
+type Flashcard struct {
+	ID             int             `json:"id" db:"id"`
+	UserID         int             `json:"user_id" db:"user_id"`
+	ItemID         int             `json:"item_id" db:"item_id"`
+	Question       string          `json:"question" db:"question"`
+	Answer         string          `json:"answer" db:"answer"`
+	ReviewCount    int             `json:"review_count" db:"review_count"`
+	LastGrade      *FlashcardGrade `json:"last_grade,omitempty" db:"last_grade"`
+	LastReviewedAt *time.Time      `json:"last_reviewed_at,omitempty" db:"last_reviewed_at"`
+	NextReviewAt   time.Time       `json:"next_review_at" db:"next_review_at"`
+	CreatedAt      time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at" db:"updated_at"`
+
+	ItemTitle string `json:"item_title,omitempty"`
+}
+
+// CreateFlashcardRequest represents the request payload for adding a flashcard to an item
+type CreateFlashcardRequest struct {
+	Question string `json:"question" binding:"required,notblank,max=2000"`
+	Answer   string `json:"answer" binding:"required,notblank,max=5000"`
+}
+
+// UpdateFlashcardRequest represents the request payload for editing a flashcard
+type UpdateFlashcardRequest struct {
+	Question *string `json:"question,omitempty" binding:"omitempty,notblank,max=2000"`
+	Answer   *string `json:"answer,omitempty" binding:"omitempty,notblank,max=5000"`
+}
+
+// GradeFlashcardRequest represents the request payload for grading a flashcard review
+type GradeFlashcardRequest struct {
+	Grade FlashcardGrade `json:"grade" binding:"required,flashcard_grade"`
+}
+
+// FlashcardReviewResponse is the set of cards due for review today
+type FlashcardReviewResponse struct {
+	Cards    []*Flashcard `json:"cards"`
+	DueCount int          `json:"due_count"`
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"time"
+)
+
+// FocusSessionStatus represents the state of a timer session
+type FocusSessionStatus string
+
+const (
+	FocusSessionRunning FocusSessionStatus = "running"
+	FocusSessionPaused  FocusSessionStatus = "paused"
+	FocusSessionStopped FocusSessionStatus = "stopped"
+)
+
+// FocusSessionEventKind is a timer action recorded against a session
+type FocusSessionEventKind string
+
+const (
+	FocusEventStart  FocusSessionEventKind = "start"
+	FocusEventPause  FocusSessionEventKind = "pause"
+	FocusEventResume FocusSessionEventKind = "resume"
+	FocusEventStop   FocusSessionEventKind = "stop"
+)
+
+// MaxFocusStatsDays caps how far back the time-spent breakdown looks
+const MaxFocusStatsDays = 365
+
+// FocusSession is a timed, focused practice session on an item or a test.
+// FocusedSeconds covers the time up to the last pause; while running, the time since
+// RunningSince is added on top.
+type FocusSession struct {
+	ID             int                `json:"id" db:"id"`
+	UserID         int                `json:"user_id" db:"user_id"`
+	ItemID         *int               `json:"item_id,omitempty" db:"item_id"`
+	TestSessionID  *string            `json:"test_session_id,omitempty" db:"test_session_id"`
+	Status         FocusSessionStatus `json:"status" db:"status"`
+	StartedAt      time.Time          `json:"started_at" db:"started_at"`
+	RunningSince   *time.Time         `json:"running_since,omitempty" db:"running_since"`
+	FocusedSeconds int                `json:"focused_seconds" db:"focused_seconds"`
+	EndedAt        *time.Time         `json:"ended_at,omitempty" db:"ended_at"`
+}
+
+// ElapsedSeconds returns the focused time of the session as of now
+func (s *FocusSession) ElapsedSeconds(now time.Time) int {
+	if s.Status == FocusSessionRunning && s.RunningSince != nil {
+		return s.FocusedSeconds + int(now.Sub(*s.RunningSince).Seconds())
+	}
+	return s.FocusedSeconds
+}
+
+// StartFocusSessionRequest represents the request payload for starting a timer session.
+// At least one of ItemID and TestSessionID is required; when both are given the item must
+// belong to the test.
+type StartFocusSessionRequest struct {
+	ItemID        *int   `json:"item_id,omitempty"`
+	TestSessionID string `json:"test_session_id,omitempty" binding:"max=255"`
+}
+
+// ItemFocusTime is the total focused time spent on one item
+type ItemFocusTime struct {
+	ItemID         int      `json:"item_id"`
+	Title          string   `json:"title"`
+	Category       Category `json:"category"`
+	FocusedSeconds int      `json:"focused_seconds"`
+}
+
+// DailyFocusTime is the total focused time on one day
+type DailyFocusTime struct {
+	Date           string `json:"date"`
+	FocusedSeconds int    `json:"focused_seconds"`
+}
+
+// FocusTimeSummary breaks down a user's focused time over a window of days
+type FocusTimeSummary struct {
+	Days         int               `json:"days"`
+	TotalSeconds int               `json:"total_seconds"`
+	ByItem       []*ItemFocusTime  `json:"by_item"`
+	ByDay        []*DailyFocusTime `json:"by_day"`
+}
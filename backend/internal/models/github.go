@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+)
+
+// DefaultGitHubPathTemplate is where solutions are committed unless the user picks another path.
+// Placeholders are replaced with slugs of the item's fields and the solution's file extension.
+const DefaultGitHubPathTemplate = "{category}/{subcategory}/{title}.{ext}"
+
+// GitHubConnection is a user's connected GitHub account and the repository solutions are
+// committed to. The access token is stored encrypted and never serialized.
+type GitHubConnection struct {
+	UserID       int       `json:"-" db:"user_id"`
+	Login        string    `json:"login" db:"github_login"`
+	Repository   string    `json:"repository" db:"repository"` // "owner/name"; empty until chosen
+	Branch       string    `json:"branch" db:"branch"`
+	PathTemplate string    `json:"path_template" db:"path_template"`
+	ConnectedAt  time.Time `json:"connected_at" db:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+
+	AccessToken []byte `json:"-" db:"access_token"`
+}
+
+// GitHubAuthorizeResponse is where to send the user to connect their GitHub account
+type GitHubAuthorizeResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+}
+
+// UpdateGitHubRepositoryRequest represents the request payload for choosing the repository
+// solutions are committed to. An empty branch uses the repository's default branch.
+type UpdateGitHubRepositoryRequest struct {
+	Repository   string `json:"repository" binding:"required,max=200"`
+	Branch       string `json:"branch,omitempty" binding:"max=100"`
+	PathTemplate string `json:"path_template,omitempty" binding:"max=200"`
+}
+
+// CompleteGitHubAuthorizationRequest carries the code and state GitHub redirected back with
+type CompleteGitHubAuthorizationRequest struct {
+	Code  string `json:"code" binding:"required,max=200"`
+	State string `json:"state" binding:"required,max=200"`
+}
@@ -0,0 +1,20 @@
+package models
+
+// MaxDailyGoal caps how many items a user can target per day
+const MaxDailyGoal = 50
+
+// DailyGoalProgress represents a user's daily target and how they're tracking against it
+type DailyGoalProgress struct {
+	DailyGoal         int  `json:"daily_goal"`
+	CompletedToday    int  `json:"completed_today"`
+	GoalMet           bool `json:"goal_met"`
+	GoalStreak        int  `json:"goal_streak"`
+	LongestGoalStreak int  `json:"longest_goal_streak"`
+	GoalsMetCount     int  `json:"goals_met_count"`
+}
+
+// UpdateDailyGoalRequest represents the request payload for setting a daily goal.
+// A goal of 0 turns goal tracking off.
+type UpdateDailyGoalRequest struct {
+	DailyGoal *int `json:"daily_goal" binding:"required,min=0,max=50"`
+}
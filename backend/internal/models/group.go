@@ -0,0 +1,78 @@
+package models
+
+import (
+	"time"
+)
+
+// StudyGroup represents a group of friends preparing together
+type StudyGroup struct {
+	ID          int       `json:"id" db:"id"`
+	Name        string    `json:"name" db:"name"`
+	Description string    `json:"description,omitempty" db:"description"`
+	OwnerID     int       `json:"owner_id" db:"owner_id"`
+	InviteCode  string    `json:"invite_code,omitempty" db:"invite_code"`
+	MemberCount int       `json:"member_count"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+// GroupMember represents a user's membership in a study group
+type GroupMember struct {
+	UserID   int       `json:"user_id" db:"user_id"`
+	Name     string    `json:"name" db:"name"`
+	Avatar   string    `json:"avatar,omitempty" db:"avatar"`
+	Role     OrgRole   `json:"role" db:"role"`
+	JoinedAt time.Time `json:"joined_at" db:"joined_at"`
+}
+
+// GroupLeaderboardEntry represents a member's standing on the group leaderboard
+type GroupLeaderboardEntry struct {
+	Rank           int    `json:"rank"`
+	UserID         int    `json:"user_id"`
+	Name           string `json:"name"`
+	Avatar         string `json:"avatar,omitempty"`
+	CompletedItems int    `json:"completed_items"`
+	CurrentStreak  int    `json:"current_streak"`
+}
+
+// GroupProgress represents aggregate progress across a group's members
+type GroupProgress struct {
+	GroupID                   int     `json:"group_id"`
+	MemberCount               int     `json:"member_count"`
+	TotalItems                int     `json:"total_items"`
+	TotalCompleted            int     `json:"total_completed"`
+	AverageCompleted          float64 `json:"average_completed"`
+	AverageProgressPercentage float64 `json:"average_progress_percentage"`
+	ActiveToday               int     `json:"active_today"`
+}
+
+// GroupItemList represents a list of items shared within a study group
+type GroupItemList struct {
+	ID        int       `json:"id" db:"id"`
+	GroupID   int       `json:"group_id" db:"group_id"`
+	CreatedBy int       `json:"created_by" db:"created_by"`
+	Name      string    `json:"name" db:"name"`
+	Items     []Item    `json:"items"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// CreateGroupRequest represents the request payload for creating a study group
+type CreateGroupRequest struct {
+	Name        string `json:"name" binding:"required,notblank,max=100"`
+	Description string `json:"description,omitempty" binding:"max=500"`
+}
+
+// JoinGroupRequest represents the request payload for joining a group by code
+type JoinGroupRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// InviteToGroupRequest represents the request payload for inviting someone by email
+type InviteToGroupRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// CreateGroupItemListRequest represents the request payload for sharing an item list with a group
+type CreateGroupItemListRequest struct {
+	Name    string `json:"name" binding:"required,notblank,max=100"`
+	ItemIDs []int  `json:"item_ids" binding:"required,min=1,max=100"`
+}
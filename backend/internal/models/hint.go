@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+)
+
+// ItemHint represents an admin-authored hint on an item's hint ladder
+type ItemHint struct {
+	ID        int       `json:"id" db:"id"`
+	ItemID    int       `json:"item_id" db:"item_id"`
+	Position  int       `json:"position" db:"position"`
+	Content   string    `json:"content" db:"content"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// CreateHintRequest represents the request payload for authoring a hint
+type CreateHintRequest struct {
+	Content  string `json:"content" binding:"required,notblank"`
+	Position *int   `json:"position,omitempty" binding:"omitempty,min=0"`
+}
+
+// UpdateHintRequest represents the request payload for editing a hint
+type UpdateHintRequest struct {
+	Content  *string `json:"content,omitempty" binding:"omitempty,notblank"`
+	Position *int    `json:"position,omitempty" binding:"omitempty,min=0"`
+}
+
+// HintLadderResponse represents a user's view of an item's hint ladder
+type HintLadderResponse struct {
+	ItemID           int        `json:"item_id"`
+	TotalHints       int        `json:"total_hints"`
+	RevealedCount    int        `json:"revealed_count"`
+	CreditMultiplier float64    `json:"credit_multiplier"`
+	Hints            []ItemHint `json:"hints"`
+}
+
+// MinHintCreditMultiplier is the credit left once every hint has been revealed
+const MinHintCreditMultiplier = 0.5
+
+// HintCreditMultiplier returns how much credit a completion earns after revealing
+// some of an item's hints. Each hint costs an equal share, down to MinHintCreditMultiplier.
+func HintCreditMultiplier(revealed, total int) float64 {
+	if total <= 0 || revealed <= 0 {
+		return 1
+	}
+	if revealed > total {
+		revealed = total
+	}
+	return 1 - (1-MinHintCreditMultiplier)*float64(revealed)/float64(total)
+}
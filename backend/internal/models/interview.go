@@ -0,0 +1,159 @@
+package models
+
+import (
+	"time"
+)
+
+// InterviewStatus tracks where an application stands overall
+type InterviewStatus string
+
+const (
+	InterviewStatusApplied      InterviewStatus = "applied"
+	InterviewStatusInterviewing InterviewStatus = "interviewing"
+	InterviewStatusOffer        InterviewStatus = "offer"
+	InterviewStatusAccepted     InterviewStatus = "accepted"
+	InterviewStatusRejected     InterviewStatus = "rejected"
+	InterviewStatusWithdrawn    InterviewStatus = "withdrawn"
+)
+
+// ValidInterviewStatuses returns all valid interview statuses
+func ValidInterviewStatuses() []InterviewStatus {
+	return []InterviewStatus{
+		InterviewStatusApplied, InterviewStatusInterviewing, InterviewStatusOffer,
+		InterviewStatusAccepted, InterviewStatusRejected, InterviewStatusWithdrawn,
+	}
+}
+
+// IsValidInterviewStatus checks if an interview status is valid
+func IsValidInterviewStatus(status InterviewStatus) bool {
+	for _, valid := range ValidInterviewStatuses() {
+		if status == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// InterviewStageKind is the type of round in an interview loop
+type InterviewStageKind string
+
+const (
+	InterviewStagePhoneScreen  InterviewStageKind = "phone_screen"
+	InterviewStageTechnical    InterviewStageKind = "technical"
+	InterviewStageSystemDesign InterviewStageKind = "system_design"
+	InterviewStageBehavioral   InterviewStageKind = "behavioral"
+	InterviewStageOnsite       InterviewStageKind = "onsite"
+	InterviewStageOther        InterviewStageKind = "other"
+)
+
+// ValidInterviewStageKinds returns all valid stage kinds
+func ValidInterviewStageKinds() []InterviewStageKind {
+	return []InterviewStageKind{
+		InterviewStagePhoneScreen, InterviewStageTechnical, InterviewStageSystemDesign,
+		InterviewStageBehavioral, InterviewStageOnsite, InterviewStageOther,
+	}
+}
+
+// IsValidInterviewStageKind checks if a stage kind is valid
+func IsValidInterviewStageKind(kind InterviewStageKind) bool {
+	for _, valid := range ValidInterviewStageKinds() {
+		if kind == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// InterviewStageOutcome records how a round went
+type InterviewStageOutcome string
+
+const (
+	InterviewOutcomePending   InterviewStageOutcome = "pending"
+	InterviewOutcomePassed    InterviewStageOutcome = "passed"
+	InterviewOutcomeFailed    InterviewStageOutcome = "failed"
+	InterviewOutcomeCancelled InterviewStageOutcome = "cancelled"
+)
+
+// IsValidInterviewStageOutcome checks if a stage outcome is valid
+func IsValidInterviewStageOutcome(outcome InterviewStageOutcome) bool {
+	switch outcome {
+	case InterviewOutcomePending, InterviewOutcomePassed, InterviewOutcomeFailed, InterviewOutcomeCancelled:
+		return true
+	}
+	return false
+}
+
+// Interview is a user's application to a company, with the rounds of its interview loop
+type Interview struct {
+	ID          int               `json:"id" db:"id"`
+	UserID      int               `json:"user_id" db:"user_id"`
+	CompanyID   *int              `json:"company_id,omitempty" db:"company_id"` // set when the company is in the catalog
+	CompanyName string            `json:"company_name" db:"company_name"`
+	Role        string            `json:"role" db:"role"`
+	Status      InterviewStatus   `json:"status" db:"status"`
+	AppliedAt   *time.Time        `json:"applied_at,omitempty" db:"applied_at"`
+	Notes       string            `json:"notes" db:"notes"`
+	Stages      []*InterviewStage `json:"stages"`
+	CreatedAt   time.Time         `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at" db:"updated_at"`
+}
+
+// InterviewStage is one round of an interview loop with the items to prepare for it
+type InterviewStage struct {
+	ID          int                   `json:"id" db:"id"`
+	InterviewID int                   `json:"interview_id" db:"interview_id"`
+	Kind        InterviewStageKind    `json:"kind" db:"kind"`
+	Name        string                `json:"name" db:"name"`
+	Position    int                   `json:"position" db:"position"`
+	ScheduledAt *time.Time            `json:"scheduled_at,omitempty" db:"scheduled_at"`
+	Outcome     InterviewStageOutcome `json:"outcome" db:"outcome"`
+	Notes       string                `json:"notes" db:"notes"`
+	PrepItems   []*ItemWithProgress   `json:"prep_items"`
+	CreatedAt   time.Time             `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time             `json:"updated_at" db:"updated_at"`
+}
+
+// CreateInterviewRequest represents the request payload for tracking an interview.
+// Company is matched against the company catalog by name or slug; unknown companies are
+// stored by name only.
+type CreateInterviewRequest struct {
+	Company   string          `json:"company" binding:"required,notblank,max=255"`
+	Role      string          `json:"role,omitempty" binding:"max=255"`
+	Status    InterviewStatus `json:"status,omitempty" binding:"omitempty,interview_status"`
+	AppliedAt *time.Time      `json:"applied_at,omitempty"`
+	Notes     string          `json:"notes,omitempty" binding:"max=10000"`
+}
+
+// UpdateInterviewRequest represents the request payload for updating an interview
+type UpdateInterviewRequest struct {
+	Company   *string          `json:"company,omitempty" binding:"omitempty,notblank,max=255"`
+	Role      *string          `json:"role,omitempty" binding:"omitempty,max=255"`
+	Status    *InterviewStatus `json:"status,omitempty" binding:"omitempty,interview_status"`
+	AppliedAt *time.Time       `json:"applied_at,omitempty"`
+	Notes     *string          `json:"notes,omitempty" binding:"omitempty,max=10000"`
+}
+
+// CreateInterviewStageRequest represents the request payload for adding a round to an interview
+type CreateInterviewStageRequest struct {
+	Kind        InterviewStageKind    `json:"kind" binding:"required,interview_stage_kind"`
+	Name        string                `json:"name,omitempty" binding:"max=255"`
+	ScheduledAt *time.Time            `json:"scheduled_at,omitempty"`
+	Outcome     InterviewStageOutcome `json:"outcome,omitempty" binding:"omitempty,interview_stage_outcome"`
+	Notes       string                `json:"notes,omitempty" binding:"max=10000"`
+	ItemIDs     []int                 `json:"item_ids,omitempty" binding:"max=100"`
+}
+
+// UpdateInterviewStageRequest represents the request payload for updating a round
+type UpdateInterviewStageRequest struct {
+	Kind        *InterviewStageKind    `json:"kind,omitempty" binding:"omitempty,interview_stage_kind"`
+	Name        *string                `json:"name,omitempty" binding:"omitempty,max=255"`
+	Position    *int                   `json:"position,omitempty" binding:"omitempty,min=0"`
+	ScheduledAt *time.Time             `json:"scheduled_at,omitempty"`
+	Outcome     *InterviewStageOutcome `json:"outcome,omitempty" binding:"omitempty,interview_stage_outcome"`
+	Notes       *string                `json:"notes,omitempty" binding:"omitempty,max=10000"`
+}
+
+// SetStagePrepItemsRequest represents the request payload for replacing a round's prep list
+type SetStagePrepItemsRequest struct {
+	ItemIDs []int `json:"item_ids" binding:"max=100"`
+}
@@ -7,7 +7,8 @@ import (
 	"time"
 )
 
-// Category represents the different types of interview prep categories
+// Category is the slug of an interview prep category. Categories are stored in the categories
+// table; the built-in ones below are seeded by migration and some have special handling.
 type Category string
 
 const (
@@ -26,6 +27,19 @@ const (
 	StatusDone       Status = "done"
 )
 
+// CompletionQuality records how an item was completed
+type CompletionQuality string
+
+const (
+	CompletionSolved           CompletionQuality = "solved"
+	CompletionReviewedSolution CompletionQuality = "reviewed_solution"
+)
+
+// IsValidCompletionQuality checks if a completion quality is valid
+func IsValidCompletionQuality(quality CompletionQuality) bool {
+	return quality == CompletionSolved || quality == CompletionReviewedSolution
+}
+
 // Special subcategory constants
 const (
 	Test_n_revise = "test_n_revise"
@@ -57,6 +71,21 @@ func (a *Attachments) Scan(value interface{}) error {
 	return json.Unmarshal(bytes, a)
 }
 
+// Attachment keys prefilled from an item's page when link enrichment is enabled
+const (
+	AttachmentPageTitle   = "page_title"
+	AttachmentDescription = "description"
+)
+
+// AttachmentSourceBlog names the eng blog an item was promoted from
+const AttachmentSourceBlog = "source_blog"
+
+// LinkMetadata is what an item's page says about itself
+type LinkMetadata struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
 // Item represents an interview preparation item
 type Item struct {
 	ID          int         `json:"id" db:"id"`
@@ -65,7 +94,13 @@ type Item struct {
 	Category    Category    `json:"category" db:"category"`
 	Subcategory string      `json:"subcategory" db:"subcategory"`
 	Attachments Attachments `json:"attachments" db:"attachments"`
+	Version     int         `json:"version,omitempty" db:"version"`
 	CreatedAt   time.Time   `json:"created_at" db:"created_at"`
+
+	// Draft is set on items that aren't published yet; only editors see them. A draft with
+	// PublishAt is published automatically at that time.
+	Draft     bool       `json:"draft,omitempty" db:"-"`
+	PublishAt *time.Time `json:"publish_at,omitempty" db:"publish_at"`
 }
 
 // ItemWithProgress represents an item with user-specific progress data
@@ -78,27 +113,140 @@ type ItemWithProgress struct {
 	Status      Status      `json:"status" db:"status"`
 	Starred     bool        `json:"starred" db:"starred"`
 	Attachments Attachments `json:"attachments" db:"attachments"`
+	Version     int         `json:"version,omitempty" db:"version"`
 	CreatedAt   time.Time   `json:"created_at" db:"created_at"`
 	CompletedAt *time.Time  `json:"completed_at,omitempty" db:"completed_at"`
 	Notes       string      `json:"notes,omitempty" db:"notes"`
+
+	CompletionQuality *CompletionQuality `json:"completion_quality,omitempty" db:"completion_quality"`
+	NextReviewAt      *time.Time         `json:"next_review_at,omitempty" db:"next_review_at"`
+}
+
+// CompleteItemRequest represents the optional payload when completing an item
+type CompleteItemRequest struct {
+	Quality CompletionQuality `json:"quality,omitempty" binding:"omitempty,completion_quality"`
+}
+
+// ReviewItemRequest represents the payload for recording a review of a completed item
+type ReviewItemRequest struct {
+	Quality CompletionQuality `json:"quality" binding:"required,completion_quality"`
+}
+
+// MaxBatchStarItems caps how many items a single batch star request may touch
+const MaxBatchStarItems = 500
+
+// BatchStarRequest represents the payload for starring or unstarring many items at once
+type BatchStarRequest struct {
+	ItemIDs []int `json:"item_ids" binding:"required,min=1"`
+	Starred *bool `json:"starred" binding:"required"`
+}
+
+// BatchStarResult reports the outcome of a batch star request. Items already in the requested
+// state count as unchanged; IDs that don't match an item are listed in NotFound.
+type BatchStarResult struct {
+	Starred   bool  `json:"starred"`
+	Updated   int   `json:"updated"`
+	Unchanged int   `json:"unchanged"`
+	NotFound  []int `json:"not_found"`
 }
 
 // CreateItemRequest represents the request payload for creating an item
 type CreateItemRequest struct {
-	Title       string      `json:"title" binding:"required"`
-	Link        string      `json:"link" binding:"required"`
-	Category    Category    `json:"category" binding:"required"`
-	Subcategory string      `json:"subcategory" binding:"required"`
+	Title       string      `json:"title" binding:"required,notblank"`
+	Link        string      `json:"link" binding:"required,weburl"`
+	Category    Category    `json:"category" binding:"required,category"`
+	Subcategory string      `json:"subcategory" binding:"required,notblank"`
 	Attachments Attachments `json:"attachments,omitempty"`
+	// Draft creates the item unpublished. Items created without content:publish are always drafts.
+	Draft bool `json:"draft,omitempty"`
+
+	// SourceArticleID links an item promoted from an eng blog article back to the article
+	SourceArticleID *int `json:"-"`
+}
+
+// ItemConflictReason is why a new item looks like a duplicate of an existing one
+type ItemConflictReason string
+
+const (
+	ItemConflictLink  ItemConflictReason = "link"  // both links point at the same page
+	ItemConflictTitle ItemConflictReason = "title" // the titles are nearly identical
+)
+
+// ItemConflict is an existing item that a new item appears to duplicate
+type ItemConflict struct {
+	ItemID     int                `json:"item_id"`
+	Title      string             `json:"title"`
+	Link       string             `json:"link"`
+	Category   Category           `json:"category"`
+	Reason     ItemConflictReason `json:"reason"`
+	Similarity float64            `json:"similarity"`
+}
+
+// BulkCreateItemsRequest represents the request payload for importing several items at once
+type BulkCreateItemsRequest struct {
+	Items []CreateItemRequest `json:"items" binding:"required,min=1,max=500"`
+}
+
+// SkippedItem is an imported item that wasn't created, with the reason why
+type SkippedItem struct {
+	Index     int            `json:"index"` // position in the request's items
+	Title     string         `json:"title"`
+	Link      string         `json:"link"`
+	Reason    string         `json:"reason"`
+	Conflicts []ItemConflict `json:"conflicts,omitempty"`
+}
+
+// BulkCreateItemsResponse summarizes the outcome of a bulk item import
+type BulkCreateItemsResponse struct {
+	Created []*Item       `json:"created"`
+	Skipped []SkippedItem `json:"skipped"`
 }
 
 // UpdateItemRequest represents the request payload for updating an item
 type UpdateItemRequest struct {
-	Title       *string      `json:"title,omitempty"`
-	Link        *string      `json:"link,omitempty"`
-	Category    *Category    `json:"category,omitempty"`
-	Subcategory *string      `json:"subcategory,omitempty"`
+	Title       *string      `json:"title,omitempty" binding:"omitempty,notblank"`
+	Link        *string      `json:"link,omitempty" binding:"omitempty,weburl"`
+	Category    *Category    `json:"category,omitempty" binding:"omitempty,category"`
+	Subcategory *string      `json:"subcategory,omitempty" binding:"omitempty,notblank"`
 	Attachments *Attachments `json:"attachments,omitempty"`
+
+	// Version is the version the edit was based on; the update is refused if the item has
+	// changed since. An If-Match header can carry it instead.
+	Version *int `json:"version,omitempty" binding:"omitempty,min=1"`
+}
+
+// PublishItemRequest publishes a draft item or, with published false, takes an item back to draft
+type PublishItemRequest struct {
+	Published *bool `json:"published,omitempty"`
+}
+
+// ScheduleItemsRequest schedules draft items, such as a weekly drop, to be published together at
+// PublishAt. A null publish_at unschedules them, leaving them drafts.
+type ScheduleItemsRequest struct {
+	ItemIDs   []int      `json:"item_ids" binding:"required,min=1,max=100,dive,min=1"`
+	PublishAt *time.Time `json:"publish_at"`
+}
+
+// UpcomingRelease is a scheduled item as users see it before it's published: without its link
+type UpcomingRelease struct {
+	ID          int       `json:"id"`
+	Title       string    `json:"title"`
+	Category    Category  `json:"category"`
+	Subcategory string    `json:"subcategory"`
+	PublishAt   time.Time `json:"publish_at"`
+}
+
+// UpcomingReleasesResponse lists scheduled items, soonest first
+type UpcomingReleasesResponse struct {
+	Releases []*UpcomingRelease `json:"releases"`
+}
+
+// MaxUpcomingReleases caps how many upcoming releases are listed at once
+const MaxUpcomingReleases = 100
+
+// ItemDraftsResponse lists the items waiting to be published, newest first
+type ItemDraftsResponse struct {
+	Items []*Item `json:"items"`
 }
 
 // ItemFilter represents filters for querying items
@@ -106,6 +254,9 @@ type ItemFilter struct {
 	Category    *Category `json:"category,omitempty"`
 	Subcategory *string   `json:"subcategory,omitempty"`
 	Status      *Status   `json:"status,omitempty"`
+	Company     *string   `json:"company,omitempty"` // company slug
+	Starred     *bool     `json:"starred,omitempty"`
+	HasNotes    *bool     `json:"has_notes,omitempty"`
 	Limit       *int      `json:"limit,omitempty"`
 	Offset      *int      `json:"offset,omitempty"`
 	RandomOrder *bool     `json:"random_order,omitempty"`
@@ -128,21 +279,6 @@ type PaginationMeta struct {
 	Page       int  `json:"page"`
 }
 
-// ValidCategories returns a slice of all valid categories
-func ValidCategories() []Category {
-	return []Category{CategoryDSA, CategoryLLD, CategoryHLD, CategoryMiscellaneous}
-}
-
-// IsValidCategory checks if a category is valid
-func IsValidCategory(category Category) bool {
-	for _, valid := range ValidCategories() {
-		if category == valid {
-			return true
-		}
-	}
-	return false
-}
-
 // ValidStatuses returns a slice of all valid statuses
 func ValidStatuses() []Status {
 	return []Status{StatusPending, StatusInProgress, StatusDone}
@@ -157,109 +293,3 @@ func IsValidStatus(status Status) bool {
 	}
 	return false
 }
-
-// Common subcategories for different categories
-var CommonSubcategories = map[Category][]string{
-	CategoryDSA: {
-		"arrays",
-		"strings",
-		"two-pointers",
-		"sliding window - fixed size",
-		"sliding window - dynamic size",
-		"prefix-sum",
-		"kadane's algorithm",
-		"matrix (2d array)",
-		"linked-lists",
-		"linkedList in-place reversal",
-		"fast and slow pointers",
-		"stacks",
-		"monotonic stack",
-		"queues",
-		"monotonic queue",
-		"hashing",
-		"bit-manipulation",
-		"bucket sort",
-		"recursion",
-		"divide-conquer",
-		"merge sort",
-		"quickSort / quickSelect",
-		"binary search",
-		"backtracking",
-		"tree traversal - level order",
-		"tree traversal - pre order",
-		"tree traversal - in order",
-		"tree traversal - post-order",
-		"bst / ordered set",
-		"tries",
-		"heaps",
-		"two heaps",
-		"top k elements",
-		"intervals",
-		"k-way merge",
-		"data structure design",
-		"graphs",
-		"depth first search (dfs)",
-		"breadth first search (bfs)",
-		"topological sort",
-		"union find",
-		"minimum spanning tree",
-		"shortest path",
-		"eulerian circuit",
-		"greedy",
-		"1-d dp",
-		"knapsack dp",
-		"unbounded knapsack dp",
-		"longest increasing subsequence dp",
-		"2d (grid) dp",
-		"string dp",
-		"tree / graph dp",
-		"bitmask dp",
-		"digit dp",
-		"probability dp",
-		"state machine dp",
-		"string matching",
-		"binary indexed tree / segment tree",
-		"maths / geometry",
-		"line sweep",
-		"suffix array",
-		"other",
-	},
-	CategoryLLD: {
-		"object-oriented-programming",
-		"design-principles",
-		"uml",
-		"design-patterns-creational",
-		"design-patterns-structural",
-		"design-patterns-behavioral",
-		"lld-interview-tips",
-		"lld-interview-questions",
-	},
-	CategoryHLD: {
-		"introduction",
-		"core concepts",
-		"databases and storage",
-		"database scaling techniques",
-		"caching",
-		"networking",
-		"api",
-		"asynchronous communications",
-		"tradeoffs",
-		"distributed system concepts",
-		"microservices",
-		"big data processing",
-		"architectural patterns",
-		"observability",
-		"security",
-		"interview tips",
-		"interview questions",
-	},
-	CategoryMiscellaneous: {
-		"gre",
-		"finance",
-		"development",
-		"sql",
-		"books",
-		Test_n_revise,
-		"other",
-	},
-}
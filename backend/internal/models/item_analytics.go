@@ -0,0 +1,73 @@
+package models
+
+import (
+	"time"
+)
+
+// Sort keys for item analytics
+const (
+	ItemAnalyticsSortCompletionRate       = "completion_rate"
+	ItemAnalyticsSortReviewedSolutionRate = "reviewed_solution_rate"
+	ItemAnalyticsSortSkipRate             = "skip_rate"
+	ItemAnalyticsSortStarCount            = "star_count"
+	ItemAnalyticsSortAvgTimeToComplete    = "avg_time_to_complete"
+	ItemAnalyticsSortAttemptedUsers       = "attempted_users"
+)
+
+// Page sizes for the content analytics report
+const (
+	DefaultItemAnalyticsLimit = 50
+	MaxItemAnalyticsLimit     = 500
+)
+
+// ItemAnalytics is how every user has fared with one item. Rates are percentages of the users
+// who attempted the item: started it, completed it or skipped it at least once.
+type ItemAnalytics struct {
+	ItemID               int      `json:"item_id"`
+	Title                string   `json:"title"`
+	Category             Category `json:"category"`
+	Subcategory          string   `json:"subcategory"`
+	AttemptedUsers       int      `json:"attempted_users"`
+	CompletedUsers       int      `json:"completed_users"`
+	InProgressUsers      int      `json:"in_progress_users"`
+	CompletionRate       float64  `json:"completion_rate"`
+	ReviewedSolutionRate float64  `json:"reviewed_solution_rate"` // of completions, how many needed the solution
+	SkippedUsers         int      `json:"skipped_users"`
+	TotalSkips           int      `json:"total_skips"`
+	SkipRate             float64  `json:"skip_rate"`
+	StarCount            int      `json:"star_count"`
+
+	// Wall-clock time from starting to completing, for completions that were started first
+	AvgTimeToCompleteSeconds *int `json:"avg_time_to_complete_seconds,omitempty"`
+	// Timer time per user who ran a focus session on the item
+	AvgFocusedSeconds *int `json:"avg_focused_seconds,omitempty"`
+}
+
+// ItemAnalyticsFilter selects and orders items for the content analytics report
+type ItemAnalyticsFilter struct {
+	ItemID      *int
+	Category    *Category
+	Subcategory *string
+	MinAttempts int
+	Sort        string
+	Descending  bool
+	Limit       int
+	Offset      int
+}
+
+// ItemAnalyticsResponse is a page of the content analytics report
+type ItemAnalyticsResponse struct {
+	Items       []*ItemAnalytics `json:"items"`
+	Pagination  PaginationMeta   `json:"pagination"`
+	GeneratedAt time.Time        `json:"generated_at"`
+}
+
+// IsValidItemAnalyticsSort checks if a sort key is supported by the analytics report
+func IsValidItemAnalyticsSort(sort string) bool {
+	switch sort {
+	case ItemAnalyticsSortCompletionRate, ItemAnalyticsSortReviewedSolutionRate, ItemAnalyticsSortSkipRate,
+		ItemAnalyticsSortStarCount, ItemAnalyticsSortAvgTimeToComplete, ItemAnalyticsSortAttemptedUsers:
+		return true
+	}
+	return false
+}
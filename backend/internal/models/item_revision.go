@@ -0,0 +1,103 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ItemContent is the editable content of an item, as it was at a revision
+type ItemContent struct {
+	Title       string      `json:"title"`
+	Link        string      `json:"link"`
+	Category    Category    `json:"category"`
+	Subcategory string      `json:"subcategory"`
+	Attachments Attachments `json:"attachments"`
+}
+
+// Content returns the item's editable content
+func (i *Item) Content() ItemContent {
+	return ItemContent{
+		Title:       i.Title,
+		Link:        i.Link,
+		Category:    i.Category,
+		Subcategory: i.Subcategory,
+		Attachments: i.Attachments,
+	}
+}
+
+// Value implements the driver.Valuer interface for database storage
+func (c ItemContent) Value() (driver.Value, error) {
+	return json.Marshal(c)
+}
+
+// Scan implements the sql.Scanner interface for database retrieval
+func (c *ItemContent) Scan(value interface{}) error {
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into ItemContent", value)
+	}
+
+	return json.Unmarshal(bytes, c)
+}
+
+// ItemFieldChange is a field's value before and after an edit
+type ItemFieldChange struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// ItemChanges maps the fields an edit changed, by their JSON name, to how they changed
+type ItemChanges map[string]ItemFieldChange
+
+// Value implements the driver.Valuer interface for database storage
+func (c ItemChanges) Value() (driver.Value, error) {
+	if c == nil {
+		return "{}", nil
+	}
+	return json.Marshal(c)
+}
+
+// Scan implements the sql.Scanner interface for database retrieval
+func (c *ItemChanges) Scan(value interface{}) error {
+	if value == nil {
+		*c = make(ItemChanges)
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into ItemChanges", value)
+	}
+
+	return json.Unmarshal(bytes, c)
+}
+
+// ItemRevision is an item's content after an edit, with what the edit changed and who made it.
+// The first revision of an item is its content from before its first recorded edit: it has no
+// editor and no changes. RevertedFrom is set on revisions made by reverting to an earlier one.
+type ItemRevision struct {
+	ID           int         `json:"id"`
+	ItemID       int         `json:"item_id"`
+	Version      int         `json:"version"`
+	EditorID     *int        `json:"editor_id,omitempty"`
+	EditorName   string      `json:"editor_name,omitempty"`
+	Changes      ItemChanges `json:"changes"`
+	Content      ItemContent `json:"content"`
+	RevertedFrom *int        `json:"reverted_from,omitempty"`
+	CreatedAt    time.Time   `json:"created_at"`
+}
+
+// ItemRevisionsResponse lists an item's revisions, newest first
+type ItemRevisionsResponse struct {
+	ItemID    int             `json:"item_id"`
+	Revisions []*ItemRevision `json:"revisions"`
+}
+
+// RevertItemRequest restores an item to its content at an earlier revision
+type RevertItemRequest struct {
+	// Version is the version the revert was based on; it's refused if the item has changed since.
+	// An If-Match header can carry it instead.
+	Version *int `json:"version,omitempty" binding:"omitempty,min=1"`
+}
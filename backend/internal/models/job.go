@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+)
+
+// JobTrigger is what started a background job run
+type JobTrigger string
+
+const (
+	JobTriggerSchedule JobTrigger = "schedule"
+	JobTriggerManual   JobTrigger = "manual"
+)
+
+// JobRunStatus is where a background job run is, or how it ended
+type JobRunStatus string
+
+const (
+	JobRunRunning   JobRunStatus = "running"
+	JobRunSucceeded JobRunStatus = "succeeded"
+	JobRunFailed    JobRunStatus = "failed"
+)
+
+// JobRun records one run of a background job on one server instance
+type JobRun struct {
+	ID         int          `json:"id" db:"id"`
+	Name       string       `json:"name" db:"name"`
+	Instance   string       `json:"instance" db:"instance"`
+	Trigger    JobTrigger   `json:"trigger" db:"triggered_by"`
+	Status     JobRunStatus `json:"status" db:"status"`
+	Error      string       `json:"error,omitempty" db:"error"`
+	StartedAt  time.Time    `json:"started_at" db:"started_at"`
+	FinishedAt *time.Time   `json:"finished_at,omitempty" db:"finished_at"`
+	DurationMs *int64       `json:"duration_ms,omitempty" db:"duration_ms"`
+}
+
+// JobStatus describes a registered background job and how its last run went
+type JobStatus struct {
+	Name            string  `json:"name"`
+	IntervalSeconds int64   `json:"interval_seconds"`
+	LastRun         *JobRun `json:"last_run"`
+}
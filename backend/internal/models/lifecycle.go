@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+)
+
+// LifecycleRun records what one archival pass over inactive users cleaned up
+type LifecycleRun struct {
+	ID             int              `json:"id" db:"id"`
+	InactiveBefore time.Time        `json:"inactive_before" db:"inactive_before"`
+	UsersArchived  int              `json:"users_archived" db:"users_archived"`
+	RowsDeleted    map[string]int64 `json:"rows_deleted" db:"rows_deleted"` // keyed by table
+	BytesReclaimed int64            `json:"bytes_reclaimed" db:"bytes_reclaimed"`
+	StartedAt      time.Time        `json:"started_at" db:"started_at"`
+	FinishedAt     time.Time        `json:"finished_at" db:"finished_at"`
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+)
+
+// LinkTargetType is the kind of content a checked link belongs to
+type LinkTargetType string
+
+const (
+	LinkTargetItem           LinkTargetType = "item"
+	LinkTargetEngBlogArticle LinkTargetType = "eng_blog_article"
+)
+
+// LinkToCheck is an item or eng blog article URL due for a check
+type LinkToCheck struct {
+	TargetType LinkTargetType
+	TargetID   int
+	URL        string
+}
+
+// DeadLink is a link that has failed enough checks in a row to be reported to admins
+type DeadLink struct {
+	TargetType LinkTargetType `json:"target_type"`
+	TargetID   int            `json:"target_id"`
+	BlogID     *int           `json:"blog_id,omitempty"` // set for eng blog articles
+	Title      string         `json:"title"`
+	URL        string         `json:"url"`
+	StatusCode *int           `json:"status_code,omitempty"` // nil when the request got no response
+	Error      string         `json:"error,omitempty"`
+	Failures   int            `json:"failures"`
+	CheckedAt  time.Time      `json:"checked_at"`
+	DeadSince  time.Time      `json:"dead_since"`
+}
+
+// DeadLinksResponse is a page of the admin dead link report
+type DeadLinksResponse struct {
+	Links      []*DeadLink    `json:"links"`
+	Pagination PaginationMeta `json:"pagination"`
+}
+
+// IsValidLinkTargetType checks if a link target type is valid
+func IsValidLinkTargetType(targetType LinkTargetType) bool {
+	return targetType == LinkTargetItem || targetType == LinkTargetEngBlogArticle
+}
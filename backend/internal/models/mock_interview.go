@@ -0,0 +1,70 @@
+package models
+
+import (
+	"time"
+)
+
+// MockInterviewStatus tracks whether a mock interview is still going
+type MockInterviewStatus string
+
+const (
+	MockInterviewActive MockInterviewStatus = "active"
+	MockInterviewEnded  MockInterviewStatus = "ended"
+)
+
+// MockInterviewRole is who said a message in a mock interview
+type MockInterviewRole string
+
+const (
+	MockInterviewerRole MockInterviewRole = "interviewer"
+	MockCandidateRole   MockInterviewRole = "candidate"
+)
+
+// MaxMockInterviewTurns caps how many replies a candidate can give in one mock interview
+const MaxMockInterviewTurns = 20
+
+// MockInterview is a conversation where a language model interviews the user on an item. One
+// started from a test names it in TestSessionID, and the test history links back to it. Once
+// ended, Feedback holds the interviewer's assessment of the candidate.
+type MockInterview struct {
+	ID            int                    `json:"id" db:"id"`
+	UserID        int                    `json:"user_id" db:"user_id"`
+	ItemID        int                    `json:"item_id" db:"item_id"`
+	ItemTitle     string                 `json:"item_title"`
+	Category      Category               `json:"category"`
+	TestSessionID *string                `json:"test_session_id,omitempty" db:"test_session_id"`
+	Status        MockInterviewStatus    `json:"status" db:"status"`
+	Turns         int                    `json:"turns"` // replies the candidate has given
+	Feedback      string                 `json:"feedback,omitempty" db:"feedback"`
+	Messages      []MockInterviewMessage `json:"messages,omitempty"`
+	Provider      string                 `json:"provider" db:"provider"`
+	Model         string                 `json:"model" db:"model"`
+	CreatedAt     time.Time              `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time              `json:"updated_at" db:"updated_at"`
+	EndedAt       *time.Time             `json:"ended_at,omitempty" db:"ended_at"`
+}
+
+// MockInterviewMessage is one message of a mock interview's transcript
+type MockInterviewMessage struct {
+	ID        int               `json:"id" db:"id"`
+	Role      MockInterviewRole `json:"role" db:"role"`
+	Content   string            `json:"content" db:"content"`
+	CreatedAt time.Time         `json:"created_at" db:"created_at"`
+}
+
+// StartMockInterviewRequest represents the request payload for starting a mock interview on an
+// item. When TestSessionID is given, the item must belong to that test.
+type StartMockInterviewRequest struct {
+	ItemID        int    `json:"item_id" binding:"required,min=1"`
+	TestSessionID string `json:"test_session_id,omitempty" binding:"max=255"`
+}
+
+// MockInterviewReplyRequest represents the candidate's next message in a mock interview
+type MockInterviewReplyRequest struct {
+	Content string `json:"content" binding:"required,notblank,max=5000"`
+}
+
+// MockInterviewsResponse lists mock interviews without their transcripts
+type MockInterviewsResponse struct {
+	Interviews []*MockInterview `json:"interviews"`
+}
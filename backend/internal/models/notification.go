@@ -0,0 +1,69 @@
+package models
+
+import (
+	"time"
+)
+
+// ReminderKind identifies the kind of reminder sent to a user
+type ReminderKind string
+
+const (
+	ReminderDailyGoal  ReminderKind = "daily_goal"
+	ReminderStuckItems ReminderKind = "stuck_items"
+	ReminderReviewsDue ReminderKind = "reviews_due"
+	ReminderStreakRisk ReminderKind = "streak_at_risk"
+)
+
+// DefaultReminderTime is the local time of day the daily reminder goes out unless the user picks another
+const DefaultReminderTime = "19:00"
+
+// NotificationPreferences represents which reminders a user wants and when
+type NotificationPreferences struct {
+	UserID        int       `json:"user_id" db:"user_id"`
+	EmailEnabled  bool      `json:"email_enabled" db:"email_enabled"`
+	PushEnabled   bool      `json:"push_enabled" db:"push_enabled"` // push devices and plugin channels
+	DailyReminder bool      `json:"daily_reminder" db:"daily_reminder"`
+	ReminderTime  string    `json:"reminder_time" db:"reminder_time"` // HH:MM in the user's timezone
+	Timezone      string    `json:"timezone" db:"timezone"`
+	Locale        string    `json:"locale" db:"locale"` // empty to follow the Accept-Language header
+	StuckItems    bool      `json:"stuck_items" db:"stuck_items"`
+	ReviewsDue    bool      `json:"reviews_due" db:"reviews_due"`
+	StreakAlerts  bool      `json:"streak_alerts" db:"streak_alerts"`
+	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// DefaultNotificationPreferences returns the preferences used for users who haven't saved any
+func DefaultNotificationPreferences(userID int) *NotificationPreferences {
+	return &NotificationPreferences{
+		UserID:        userID,
+		EmailEnabled:  true,
+		PushEnabled:   true,
+		DailyReminder: true,
+		ReminderTime:  DefaultReminderTime,
+		Timezone:      "UTC",
+		StuckItems:    true,
+		ReviewsDue:    true,
+		StreakAlerts:  true,
+	}
+}
+
+// UpdateNotificationPreferencesRequest represents the request payload for updating notification preferences
+type UpdateNotificationPreferencesRequest struct {
+	EmailEnabled  *bool   `json:"email_enabled,omitempty"`
+	PushEnabled   *bool   `json:"push_enabled,omitempty"`
+	DailyReminder *bool   `json:"daily_reminder,omitempty"`
+	ReminderTime  *string `json:"reminder_time,omitempty" binding:"omitempty,datetime=15:04"`
+	Timezone      *string `json:"timezone,omitempty" binding:"omitempty,timezone"`
+	Locale        *string `json:"locale,omitempty" binding:"omitempty,locale"`
+	StuckItems    *bool   `json:"stuck_items,omitempty"`
+	ReviewsDue    *bool   `json:"reviews_due,omitempty"`
+	StreakAlerts  *bool   `json:"streak_alerts,omitempty"`
+}
+
+// ReminderRecipient is a user who may receive reminders, along with their preferences
+type ReminderRecipient struct {
+	UserID      int
+	Email       string
+	Name        string
+	Preferences *NotificationPreferences
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+)
+
+// CohortMember is one member's line in an organization's cohort analytics
+type CohortMember struct {
+	UserID            int     `json:"user_id,omitempty"`
+	Name              string  `json:"name"`
+	Email             string  `json:"email,omitempty"`
+	CompletedItems    int     `json:"completed_items"`
+	CompletionRate    float64 `json:"completion_rate"`
+	CurrentStreak     int     `json:"current_streak"`
+	CompletedThisWeek int     `json:"completed_this_week"`
+}
+
+// CohortSubcategory is a subcategory's completion rate across all members of a cohort
+type CohortSubcategory struct {
+	Category       Category `json:"category"`
+	Subcategory    string   `json:"subcategory"`
+	TotalItems     int      `json:"total_items"`
+	Completions    int      `json:"completions"` // completions across all members
+	CompletionRate float64  `json:"completion_rate"`
+}
+
+// CohortSnapshot is the aggregated state of an organization's cohort on a given day
+type CohortSnapshot struct {
+	MemberCount           int                 `json:"member_count"`
+	ActiveMembers         int                 `json:"active_members"` // active in the last 7 days
+	CompletedItems        int                 `json:"completed_items"`
+	AverageCompletionRate float64             `json:"average_completion_rate"`
+	AverageStreak         float64             `json:"average_streak"`
+	Members               []CohortMember      `json:"members"`
+	WeakestSubcategories  []CohortSubcategory `json:"weakest_subcategories"`
+}
+
+// CohortMovement is the change in cohort totals since an earlier snapshot
+type CohortMovement struct {
+	ComparedTo            time.Time `json:"compared_to"`
+	CompletedItems        int       `json:"completed_items"`
+	ActiveMembers         int       `json:"active_members"`
+	AverageCompletionRate float64   `json:"average_completion_rate"`
+	AverageStreak         float64   `json:"average_streak"`
+	MemberCount           int       `json:"member_count"`
+}
+
+// OrgCohortAnalytics is the response for an organization's cohort analytics
+type OrgCohortAnalytics struct {
+	OrgID        int             `json:"org_id"`
+	SnapshotDate time.Time       `json:"snapshot_date"`
+	GeneratedAt  time.Time       `json:"generated_at"`
+	Anonymized   bool            `json:"anonymized"`
+	WeekOverWeek *CohortMovement `json:"week_over_week,omitempty"`
+	CohortSnapshot
+}
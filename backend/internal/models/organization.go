@@ -0,0 +1,175 @@
+package models
+
+import (
+	"time"
+)
+
+// OrgRole represents a member's role within an organization
+type OrgRole string
+
+// Owners run the organization: they manage roles, service accounts and identified analytics.
+// Admins help run it, inviting members and curating the catalog. Neither is a site admin.
+const (
+	OrgRoleOwner  OrgRole = "owner"
+	OrgRoleAdmin  OrgRole = "admin"
+	OrgRoleMember OrgRole = "member"
+)
+
+// IsValidOrgRole checks if the organization role is valid
+func IsValidOrgRole(role OrgRole) bool {
+	return role == OrgRoleOwner || role == OrgRoleAdmin || role == OrgRoleMember
+}
+
+// CanManage reports whether the role may invite members and curate the organization's catalog
+func (r OrgRole) CanManage() bool {
+	return r == OrgRoleOwner || r == OrgRoleAdmin
+}
+
+// InvitationStatus represents the state of an organization invitation
+type InvitationStatus string
+
+const (
+	InvitationStatusPending  InvitationStatus = "pending"
+	InvitationStatusAccepted InvitationStatus = "accepted"
+	InvitationStatusRevoked  InvitationStatus = "revoked"
+)
+
+// Organization represents a team of users preparing together
+type Organization struct {
+	ID        int       `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name"`
+	CreatedBy int       `json:"created_by" db:"created_by"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// OrgMember represents a user's membership in an organization
+type OrgMember struct {
+	OrgID    int       `json:"org_id" db:"org_id"`
+	UserID   int       `json:"user_id" db:"user_id"`
+	Name     string    `json:"name,omitempty" db:"name"`
+	Email    string    `json:"email,omitempty" db:"email"`
+	Role     OrgRole   `json:"role" db:"role"`
+	JoinedAt time.Time `json:"joined_at" db:"joined_at"`
+}
+
+// OrgMembership represents an organization as seen by one of its members
+type OrgMembership struct {
+	Organization
+	Role        OrgRole `json:"role"`
+	MemberCount int     `json:"member_count"`
+}
+
+// OrgInvitation represents an emailed invitation to join an organization
+type OrgInvitation struct {
+	ID             int              `json:"id" db:"id"`
+	OrgID          int              `json:"org_id" db:"org_id"`
+	Email          string           `json:"email" db:"email"`
+	Role           OrgRole          `json:"role" db:"role"`
+	TokenHash      string           `json:"-" db:"token_hash"`
+	Status         InvitationStatus `json:"status" db:"status"`
+	InvitedBy      int              `json:"invited_by" db:"invited_by"`
+	AcceptedUserID *int             `json:"accepted_user_id,omitempty" db:"accepted_user_id"`
+	ExpiresAt      time.Time        `json:"expires_at" db:"expires_at"`
+	AcceptedAt     *time.Time       `json:"accepted_at,omitempty" db:"accepted_at"`
+	CreatedAt      time.Time        `json:"created_at" db:"created_at"`
+}
+
+// CreateOrganizationRequest represents the request payload for creating an organization
+type CreateOrganizationRequest struct {
+	Name string `json:"name" binding:"required,notblank,max=255"`
+}
+
+// UpdateOrgMemberRequest represents the request payload for changing a member's role
+type UpdateOrgMemberRequest struct {
+	Role OrgRole `json:"role" binding:"required,org_role"`
+}
+
+// AcceptInvitationRequest represents the request payload for accepting an invitation
+type AcceptInvitationRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// SkippedInvitation describes a CSV row that did not produce an invitation
+type SkippedInvitation struct {
+	Row    int    `json:"row"`
+	Email  string `json:"email,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// BulkInvitationResponse summarizes the outcome of a CSV invitation upload
+type BulkInvitationResponse struct {
+	Invited []*OrgInvitation    `json:"invited"`
+	Skipped []SkippedInvitation `json:"skipped"`
+}
+
+// ScopeAnalyticsRead grants read-only access to an organization's analytics endpoints
+const ScopeAnalyticsRead = "analytics:read"
+
+// ServiceAccount represents a non-human API client owned by an organization
+type ServiceAccount struct {
+	ID         int        `json:"id" db:"id"`
+	OrgID      int        `json:"org_id" db:"org_id"`
+	Name       string     `json:"name" db:"name"`
+	KeyPrefix  string     `json:"key_prefix" db:"key_prefix"`
+	KeyHash    string     `json:"-" db:"key_hash"`
+	Scopes     []string   `json:"scopes" db:"scopes"`
+	CreatedBy  int        `json:"created_by" db:"created_by"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" db:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+}
+
+// HasScope checks whether the service account was granted a scope
+func (a *ServiceAccount) HasScope(scope string) bool {
+	for _, s := range a.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateServiceAccountRequest represents the request payload for creating a service account
+type CreateServiceAccountRequest struct {
+	Name string `json:"name" binding:"required,notblank,max=100"`
+}
+
+// CreateServiceAccountResponse includes the API key, which is only ever shown once
+type CreateServiceAccountResponse struct {
+	ServiceAccount *ServiceAccount `json:"service_account"`
+	APIKey         string          `json:"api_key"`
+}
+
+// OrgMemberProgress represents one member's progress within an organization
+type OrgMemberProgress struct {
+	UserID           int        `json:"user_id"`
+	Name             string     `json:"name"`
+	Email            string     `json:"email"`
+	Role             OrgRole    `json:"role"`
+	CompletedItems   int        `json:"completed_items"`
+	InProgressItems  int        `json:"in_progress_items"`
+	CurrentStreak    int        `json:"current_streak"`
+	LastActivityDate *time.Time `json:"last_activity_date,omitempty"`
+	JoinedAt         time.Time  `json:"joined_at"`
+}
+
+// OrgCatalogItem represents an item an organization asks its members to work on, with the
+// requesting member's progress on it
+type OrgCatalogItem struct {
+	ItemID      int       `json:"item_id" db:"item_id"`
+	Title       string    `json:"title" db:"title"`
+	Link        string    `json:"link" db:"link"`
+	Category    Category  `json:"category" db:"category"`
+	Subcategory string    `json:"subcategory" db:"subcategory"`
+	Position    int       `json:"position" db:"position"`
+	Note        string    `json:"note" db:"note"`
+	Status      Status    `json:"status" db:"status"`
+	AddedAt     time.Time `json:"added_at" db:"created_at"`
+}
+
+// SetOrgCatalogItemRequest represents the request payload for adding an item to an organization's
+// catalog or changing its place and note
+type SetOrgCatalogItemRequest struct {
+	Position *int   `json:"position,omitempty" binding:"omitempty,min=0"`
+	Note     string `json:"note,omitempty" binding:"max=1000"`
+}
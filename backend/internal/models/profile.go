@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+)
+
+// PublicProfileSettings controls a user's opt-in public profile. The profile is served at its
+// handle, a random share token the user can regenerate to revoke links they've handed out.
+type PublicProfileSettings struct {
+	Enabled    bool       `json:"enabled" db:"enabled"`
+	Handle     string     `json:"handle,omitempty" db:"handle"`
+	ShowName   bool       `json:"show_name" db:"show_name"`
+	ShowStreak bool       `json:"show_streak" db:"show_streak"`
+	ShowTotals bool       `json:"show_totals" db:"show_totals"`
+	ShowBadges bool       `json:"show_badges" db:"show_badges"`
+	UpdatedAt  *time.Time `json:"updated_at,omitempty" db:"updated_at"`
+}
+
+// UpdatePublicProfileRequest changes public profile settings; omitted fields are left as they are
+type UpdatePublicProfileRequest struct {
+	Enabled    *bool `json:"enabled,omitempty"`
+	ShowName   *bool `json:"show_name,omitempty"`
+	ShowStreak *bool `json:"show_streak,omitempty"`
+	ShowTotals *bool `json:"show_totals,omitempty"`
+	ShowBadges *bool `json:"show_badges,omitempty"`
+}
+
+// PublicProfile is what anyone holding a profile's handle sees. Sections the owner hasn't
+// chosen to show are omitted.
+type PublicProfile struct {
+	Handle      string        `json:"handle"`
+	Name        string        `json:"name,omitempty"`
+	MemberSince time.Time     `json:"member_since"`
+	Streak      *PublicStreak `json:"streak,omitempty"`
+	Totals      *PublicTotals `json:"totals,omitempty"`
+	Badges      []Badge       `json:"badges,omitempty"`
+}
+
+// PublicStreak is the streak section of a public profile
+type PublicStreak struct {
+	Current int `json:"current"`
+	Longest int `json:"longest"`
+}
+
+// PublicTotals is the completed-items section of a public profile
+type PublicTotals struct {
+	Completed    int `json:"completed"`
+	DSACompleted int `json:"dsa_completed"`
+	LLDCompleted int `json:"lld_completed"`
+	HLDCompleted int `json:"hld_completed"`
+	CompletedAll int `json:"completed_all_count"`
+}
+
+// Badge is a milestone a user has reached
+type Badge struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
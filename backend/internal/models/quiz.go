@@ -0,0 +1,109 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	// MaxQuizQuestions caps how many questions a quiz holds
+	MaxQuizQuestions = 50
+	// MaxQuizChoices caps how many choices a question offers
+	MaxQuizChoices = 6
+)
+
+// Quiz is a set of multiple-choice questions on the concepts of a subcategory, such as caching
+// in hld. LastAttempt is the requesting user's latest score on it.
+type Quiz struct {
+	ID            int       `json:"id" db:"id"`
+	Title         string    `json:"title" db:"title"`
+	Description   string    `json:"description" db:"description"`
+	Category      Category  `json:"category" db:"category"`
+	Subcategory   string    `json:"subcategory" db:"subcategory"`
+	QuestionCount int       `json:"question_count"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
+
+	Questions   []QuizQuestion `json:"questions,omitempty"`
+	LastAttempt *QuizAttempt   `json:"last_attempt,omitempty"`
+}
+
+// QuizQuestion is a multiple-choice question with one correct choice. CorrectChoice, an index
+// into Choices, and Explanation are only shown to content editors; users see them once an
+// attempt is graded.
+type QuizQuestion struct {
+	ID            int      `json:"id" db:"id"`
+	Prompt        string   `json:"prompt" db:"prompt"`
+	Choices       []string `json:"choices" db:"choices"`
+	CorrectChoice *int     `json:"correct_choice,omitempty" db:"correct_choice"`
+	Explanation   string   `json:"explanation,omitempty" db:"explanation"`
+}
+
+// QuizQuestionRequest is a question in the payload for saving a quiz
+type QuizQuestionRequest struct {
+	Prompt        string   `json:"prompt" binding:"required,notblank,max=2000"`
+	Choices       []string `json:"choices" binding:"required,min=2,max=6,dive,notblank,max=500"`
+	CorrectChoice int      `json:"correct_choice" binding:"min=0,max=5"`
+	Explanation   string   `json:"explanation" binding:"max=2000"`
+}
+
+// SaveQuizRequest represents the request payload for creating a quiz, or replacing one along
+// with all of its questions
+type SaveQuizRequest struct {
+	Title       string                `json:"title" binding:"required,notblank,max=255"`
+	Description string                `json:"description" binding:"max=2000"`
+	Category    Category              `json:"category" binding:"required,notblank,max=50"`
+	Subcategory string                `json:"subcategory" binding:"required,notblank,max=100"`
+	Questions   []QuizQuestionRequest `json:"questions" binding:"required,min=1,max=50,dive"`
+}
+
+// QuizFilter narrows the quiz listing
+type QuizFilter struct {
+	Category    *Category
+	Subcategory *string
+}
+
+// QuizzesResponse is the list of quizzes
+type QuizzesResponse struct {
+	Quizzes []*Quiz `json:"quizzes"`
+}
+
+// QuizAnswer is the choice a user picked for a question
+type QuizAnswer struct {
+	QuestionID int `json:"question_id" binding:"required,min=1"`
+	Choice     int `json:"choice" binding:"min=0,max=5"`
+}
+
+// SubmitQuizAttemptRequest represents the request payload for taking a quiz. Questions left
+// unanswered are graded as wrong.
+type SubmitQuizAttemptRequest struct {
+	Answers []QuizAnswer `json:"answers" binding:"required,max=50,dive"`
+}
+
+// QuizAttempt is a graded run through a quiz. Score is the share of questions answered
+// correctly, from 0 to 1.
+type QuizAttempt struct {
+	ID        int       `json:"id" db:"id"`
+	QuizID    int       `json:"quiz_id" db:"quiz_id"`
+	Correct   int       `json:"correct" db:"correct"`
+	Total     int       `json:"total" db:"total"`
+	Score     float64   `json:"score"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+
+	Results []QuizAnswerResult `json:"results,omitempty"`
+}
+
+// QuizAnswerResult is how one question of an attempt was graded. Choice is nil when the user
+// left the question unanswered.
+type QuizAnswerResult struct {
+	QuestionID    int    `json:"question_id"`
+	Prompt        string `json:"prompt"`
+	Choice        *int   `json:"choice"`
+	CorrectChoice int    `json:"correct_choice"`
+	Correct       bool   `json:"correct"`
+	Explanation   string `json:"explanation,omitempty"`
+}
+
+// QuizAttemptsResponse is a user's attempts at a quiz, newest first
+type QuizAttemptsResponse struct {
+	Attempts []*QuizAttempt `json:"attempts"`
+}
@@ -0,0 +1,47 @@
+package models
+
+// Recommendation limits
+const (
+	DefaultRecommendations  = 5
+	MaxRecommendations      = 20
+	DefaultSuggestedItems   = 3
+	MaxSuggestedItems       = 10
+	RecommendationSkipCap   = 10 // skips beyond this stop raising a subcategory's score
+	RecommendationSkipScale = 0.5
+	TestPartialWeight       = 0.5 // a partly solved test item counts as half a failure
+	QuizMissWeight          = 0.5 // getting every quiz question in a subcategory wrong adds this much
+)
+
+// SubcategorySignals is the raw per-subcategory activity recommendations are computed from
+type SubcategorySignals struct {
+	Category        Category
+	Subcategory     string
+	TotalItems      int
+	CompletedItems  int
+	CompletedCredit float64 // completed items weighted by the hint credit each completion earned
+	Skips           int
+	TestAttempts    int
+	TestFailures    int
+	TestPartials    int
+	QuizCorrect     int // questions answered correctly in the latest attempt at each quiz
+	QuizQuestions   int
+}
+
+// SubcategoryRecommendation is a subcategory the user should focus on, with the reasons it
+// was picked and items to start with
+type SubcategoryRecommendation struct {
+	Category        Category            `json:"category"`
+	Subcategory     string              `json:"subcategory"`
+	TotalItems      int                 `json:"total_items"`
+	CompletedItems  int                 `json:"completed_items"`
+	CompletionRatio float64             `json:"completion_ratio"`
+	Skips           int                 `json:"skips"`
+	TestAttempts    int                 `json:"test_attempts"`
+	TestFailures    int                 `json:"test_failures"`
+	TestPartials    int                 `json:"test_partials"`
+	QuizCorrect     int                 `json:"quiz_correct"`
+	QuizQuestions   int                 `json:"quiz_questions"`
+	Score           float64             `json:"score"`
+	Reasons         []string            `json:"reasons"`
+	SuggestedItems  []*ItemWithProgress `json:"suggested_items"`
+}
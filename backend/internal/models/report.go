@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+)
+
+// Shared progress report limits, in days
+const (
+	DefaultReportExpiryDays = 7
+	MaxReportExpiryDays     = 30
+)
+
+// ReportActivityDays is how far back a report's recent activity goes
+const ReportActivityDays = 14
+
+// ShareReportRequest represents the request to share a progress report
+type ShareReportRequest struct {
+	ExpiresInDays int `json:"expires_in_days,omitempty" binding:"omitempty,min=1,max=30"`
+}
+
+// SharedReportLink is a signed link to a progress report snapshot. Anyone holding it can view
+// the report until it expires, without logging in.
+type SharedReportLink struct {
+	ReportID  int       `json:"report_id"`
+	URL       string    `json:"url"`      // the report as an HTML page
+	JSONURL   string    `json:"json_url"` // the same report as JSON
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// ProgressReport is a snapshot of a user's progress taken when it was shared
+type ProgressReport struct {
+	ID             int              `json:"id"`
+	Name           string           `json:"name"`
+	GeneratedAt    time.Time        `json:"generated_at"`
+	ExpiresAt      time.Time        `json:"expires_at"`
+	Overall        ReportOverall    `json:"overall"`
+	Categories     []CategoryStats  `json:"categories"`
+	RecentActivity []ReportActivity `json:"recent_activity"`
+}
+
+// ReportOverall is the headline progress of a report
+type ReportOverall struct {
+	TotalItems         int     `json:"total_items"`
+	CompletedItems     int     `json:"completed_items"`
+	ProgressPercentage float64 `json:"progress_percentage"`
+	CurrentStreak      int     `json:"current_streak"`
+	LongestStreak      int     `json:"longest_streak"`
+}
+
+// ReportActivity is an item completed in the days before a report was taken
+type ReportActivity struct {
+	Title       string    `json:"title"`
+	Category    Category  `json:"category"`
+	Subcategory string    `json:"subcategory"`
+	CompletedAt time.Time `json:"completed_at"`
+}
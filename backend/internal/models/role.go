@@ -0,0 +1,64 @@
+package models
+
+import "time"
+
+// Permission names something a role allows, as "<area>:<action>"
+type Permission string
+
+const (
+	// PermissionContentWrite allows editing items, categories, companies, hints, test cases,
+	// behavioral questions and engineering blogs, importing the catalog and viewing item analytics
+	PermissionContentWrite Permission = "content:write"
+	// PermissionContentPublish allows publishing draft items so users see them. Items created
+	// without it start as drafts.
+	PermissionContentPublish Permission = "content:publish"
+	// PermissionFeedbackModerate allows reviewing the feedback users report on items
+	PermissionFeedbackModerate Permission = "feedback:moderate"
+	// PermissionUsersManage allows assigning roles and plans and defining roles
+	PermissionUsersManage Permission = "users:manage"
+	// PermissionSystemManage allows running jobs, changing feature flags and using the debug endpoints
+	PermissionSystemManage Permission = "system:manage"
+)
+
+// ValidPermissions returns every permission a role can be granted
+func ValidPermissions() []Permission {
+	return []Permission{PermissionContentWrite, PermissionContentPublish, PermissionFeedbackModerate, PermissionUsersManage, PermissionSystemManage}
+}
+
+// IsValidPermission checks if the permission exists
+func IsValidPermission(permission Permission) bool {
+	for _, p := range ValidPermissions() {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// IsBuiltInRole reports whether the role ships with the app and so can't be deleted
+func IsBuiltInRole(role Role) bool {
+	return role == RoleUser || role == RoleAdmin || role == RoleContentEditor || role == RoleModerator
+}
+
+// RoleDefinition represents a role and the permissions it grants. Admins hold every
+// permission whatever their row lists.
+type RoleDefinition struct {
+	Name        Role         `json:"name" db:"name"`
+	Description string       `json:"description" db:"description"`
+	Permissions []Permission `json:"permissions"`
+	BuiltIn     bool         `json:"built_in"`
+	UserCount   int          `json:"user_count"`
+	CreatedAt   time.Time    `json:"created_at" db:"created_at"`
+}
+
+// SaveRoleRequest represents the request payload for creating a role or changing its
+// description or permissions. Omitted fields keep their current values.
+type SaveRoleRequest struct {
+	Description *string      `json:"description,omitempty" binding:"omitempty,max=255"`
+	Permissions []Permission `json:"permissions,omitempty" binding:"omitempty,dive,permission"`
+}
+
+// UpdateUserRoleRequest represents the request payload for assigning a user a role
+type UpdateUserRoleRequest struct {
+	Role Role `json:"role" binding:"required,notblank"`
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+)
+
+// ShareStatus represents where a shared item sits in the recipient's inbox
+type ShareStatus string
+
+const (
+	ShareStatusPending   ShareStatus = "pending"
+	ShareStatusAccepted  ShareStatus = "accepted"
+	ShareStatusDismissed ShareStatus = "dismissed"
+)
+
+// ItemShare represents an item one user has sent to another user or email address
+type ItemShare struct {
+	ID              int         `json:"id" db:"id"`
+	ItemID          int         `json:"item_id" db:"item_id"`
+	SenderID        int         `json:"sender_id" db:"sender_id"`
+	SenderName      string      `json:"sender_name,omitempty"`
+	RecipientUserID *int        `json:"recipient_user_id,omitempty" db:"recipient_user_id"`
+	RecipientEmail  string      `json:"recipient_email" db:"recipient_email"`
+	Note            string      `json:"note,omitempty" db:"note"`
+	Status          ShareStatus `json:"status" db:"status"`
+	CreatedAt       time.Time   `json:"created_at" db:"created_at"`
+	RespondedAt     *time.Time  `json:"responded_at,omitempty" db:"responded_at"`
+	Item            *Item       `json:"item,omitempty"`
+}
+
+// ShareItemRequest represents the request payload for sharing an item
+type ShareItemRequest struct {
+	Email string `json:"email" binding:"required,email"`
+	Note  string `json:"note,omitempty" binding:"max=1000"`
+}
@@ -0,0 +1,27 @@
+package models
+
+// MaxSimilarItems caps how many similar items are returned at once
+const MaxSimilarItems = 50
+
+// SimilarItem is an item related in meaning to another. Similarity is the cosine similarity of
+// their embeddings: 1 for the same meaning, lower for less related items.
+type SimilarItem struct {
+	Item
+	Similarity float64 `json:"similarity"`
+}
+
+// SimilarItemsResponse lists the items most similar to an item, most similar first
+type SimilarItemsResponse struct {
+	ItemID int            `json:"item_id"`
+	Items  []*SimilarItem `json:"items"`
+}
+
+// ItemEmbeddingState is what an item's embedding was computed from. SourceHash is empty when the
+// item has no embedding from the current model.
+type ItemEmbeddingState struct {
+	ItemID      int
+	Title       string
+	Category    Category
+	Subcategory string
+	SourceHash  string
+}
@@ -7,8 +7,19 @@ type Stats struct {
 	PendingItems       int     `json:"pending_items"`
 	ProgressPercentage float64 `json:"progress_percentage"`
 	CompletedAllCount  int     `json:"completed_all_count"`
+	SolvedItems        int     `json:"solved_items"`
+	ReviewedSolutions  int     `json:"reviewed_solution_items"`
+	ReviewsDue         int     `json:"reviews_due"`
 	CurrentStreak      int     `json:"current_streak"`
 	LongestStreak      int     `json:"longest_streak"`
+	DailyGoal          int     `json:"daily_goal"`
+	CompletedToday     int     `json:"completed_today"`
+	DailyGoalMet       bool    `json:"daily_goal_met"`
+	GoalStreak         int     `json:"goal_streak"`
+
+	// Focused time from timer sessions, in seconds
+	TimeSpentToday int `json:"time_spent_today_seconds"`
+	TotalTimeSpent int `json:"total_time_spent_seconds"`
 }
 
 // AppStats represents the application-level statistics stored in database
@@ -47,6 +58,37 @@ type CategoryWithSubcategoryStats struct {
 
 // DetailedStats represents comprehensive statistics including category breakdown
 type DetailedStats struct {
-	Overall    Stats                          `json:"overall"`
-	Categories []CategoryWithSubcategoryStats `json:"categories"`
+	Overall     Stats                          `json:"overall"`
+	Categories  []CategoryWithSubcategoryStats `json:"categories"`
+	TimeSpent   TimeSpentStats                 `json:"time_spent"`
+	Submissions SubmissionStats                `json:"submissions"`
+}
+
+// TimeSpentWeeks is how many weeks the weekly time trend covers
+const TimeSpentWeeks = 12
+
+// TimeSpentStats shows where a user's focused prep time goes, from their timer sessions
+type TimeSpentStats struct {
+	TotalSeconds          int                 `json:"total_seconds"`
+	TotalHours            float64             `json:"total_hours"`
+	ItemsTracked          int                 `json:"items_tracked"`
+	AverageSecondsPerItem float64             `json:"average_seconds_per_item"`
+	Categories            []CategoryTimeSpent `json:"categories"`
+	WeeklyTrend           []WeeklyTimeSpent   `json:"weekly_trend"`
+}
+
+// CategoryTimeSpent is the focused time spent on items of one category
+type CategoryTimeSpent struct {
+	Category              Category `json:"category"`
+	TotalSeconds          int      `json:"total_seconds"`
+	TotalHours            float64  `json:"total_hours"`
+	ItemsTracked          int      `json:"items_tracked"`
+	AverageSecondsPerItem float64  `json:"average_seconds_per_item"`
+}
+
+// WeeklyTimeSpent is the focused time spent in the week starting on WeekStart (a Monday)
+type WeeklyTimeSpent struct {
+	WeekStart    string  `json:"week_start"`
+	TotalSeconds int     `json:"total_seconds"`
+	TotalHours   float64 `json:"total_hours"`
 }
@@ -0,0 +1,99 @@
+package models
+
+import (
+	"time"
+)
+
+// MaxItemTestCases caps how many test cases one item may have, since every submission runs them all
+const MaxItemTestCases = 50
+
+// ItemTestCase is an input and the output a correct solution to a DSA item prints for it. Sample
+// cases are shown to users; the rest stay hidden and only their verdicts are reported.
+type ItemTestCase struct {
+	ID             int       `json:"id" db:"id"`
+	ItemID         int       `json:"item_id" db:"item_id"`
+	Input          string    `json:"input" db:"input"`
+	ExpectedOutput string    `json:"expected_output" db:"expected_output"`
+	IsSample       bool      `json:"is_sample" db:"is_sample"`
+	OrderIdx       int       `json:"order_idx" db:"order_idx"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// CreateItemTestCaseRequest represents the request payload for adding a test case to an item
+type CreateItemTestCaseRequest struct {
+	Input          string `json:"input" binding:"max=65536"`
+	ExpectedOutput string `json:"expected_output" binding:"required,notblank,max=65536"`
+	IsSample       bool   `json:"is_sample"`
+}
+
+// UpdateItemTestCaseRequest represents the request payload for editing a test case
+type UpdateItemTestCaseRequest struct {
+	Input          *string `json:"input,omitempty" binding:"omitempty,max=65536"`
+	ExpectedOutput *string `json:"expected_output,omitempty" binding:"omitempty,notblank,max=65536"`
+	IsSample       *bool   `json:"is_sample,omitempty"`
+	OrderIdx       *int    `json:"order_idx,omitempty"`
+}
+
+// SubmitSolutionRequest represents the request payload for judging a solution to a DSA item.
+// With commit_to_github set, the solution is also committed to the user's connected repository.
+type SubmitSolutionRequest struct {
+	Language       string `json:"language" binding:"required"`
+	Code           string `json:"code" binding:"required,notblank,max=65536"`
+	CommitToGitHub bool   `json:"commit_to_github,omitempty"`
+}
+
+// TestCaseVerdict is the outcome of running a solution against one test case
+type TestCaseVerdict string
+
+const (
+	VerdictPassed       TestCaseVerdict = "passed"
+	VerdictWrongAnswer  TestCaseVerdict = "wrong_answer"
+	VerdictRuntimeError TestCaseVerdict = "runtime_error"
+	VerdictCompileError TestCaseVerdict = "compile_error"
+	VerdictTimeLimit    TestCaseVerdict = "time_limit_exceeded"
+)
+
+// TestCaseResult is a solution's verdict on one test case. Input and outputs are only included for
+// sample cases.
+type TestCaseResult struct {
+	TestCaseID     int             `json:"test_case_id"`
+	IsSample       bool            `json:"is_sample"`
+	Verdict        TestCaseVerdict `json:"verdict"`
+	Input          *string         `json:"input,omitempty"`
+	ExpectedOutput *string         `json:"expected_output,omitempty"`
+	ActualOutput   *string         `json:"actual_output,omitempty"`
+	Error          string          `json:"error,omitempty"`
+}
+
+// SubmissionAttempt records one judged submission of a solution to an item
+type SubmissionAttempt struct {
+	ID          int       `json:"id" db:"id"`
+	UserID      int       `json:"user_id" db:"user_id"`
+	ItemID      int       `json:"item_id" db:"item_id"`
+	Language    string    `json:"language" db:"language"`
+	Code        string    `json:"code,omitempty" db:"code"`
+	Passed      bool      `json:"passed" db:"passed"`
+	PassedCases int       `json:"passed_cases" db:"passed_cases"`
+	TotalCases  int       `json:"total_cases" db:"total_cases"`
+	CommitSHA   *string   `json:"commit_sha,omitempty" db:"commit_sha"` // set once committed to GitHub
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+// SubmissionResult is a judged submission with its per-case verdicts
+type SubmissionResult struct {
+	Attempt SubmissionAttempt `json:"attempt"`
+	Results []TestCaseResult  `json:"results"`
+
+	// Why a requested GitHub commit didn't happen; the submission itself is still recorded
+	GitHubError string `json:"github_error,omitempty"`
+}
+
+// SubmissionStats summarizes a user's judged submissions
+type SubmissionStats struct {
+	TotalAttempts  int     `json:"total_attempts"`
+	PassedAttempts int     `json:"passed_attempts"`
+	PassRate       float64 `json:"pass_rate"`
+	ItemsAttempted int     `json:"items_attempted"`
+	ItemsSolved    int     `json:"items_solved"`
+}
@@ -38,16 +38,88 @@ type TestWithItem struct {
 
 // CreateTestResponse represents the response when creating a test
 type CreateTestResponse struct {
-	SessionID string             `json:"session_id"`
-	Items     []ItemWithProgress `json:"items"`
-	Message   string             `json:"message"`
+	SessionID       string             `json:"session_id"`
+	ParentSessionID string             `json:"parent_session_id,omitempty"`
+	Items           []ItemWithProgress `json:"items"`
+	Message         string             `json:"message"`
 }
 
 // ActiveTestResponse represents the current active test
 type ActiveTestResponse struct {
-	SessionID string             `json:"session_id"`
-	Items     []ItemWithProgress `json:"items"`
-	CreatedAt time.Time          `json:"created_at"`
+	SessionID string            `json:"session_id"`
+	Items     []TestSessionItem `json:"items"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// TestSession is a past or current test with its items, as listed in the test history. A retake
+// names the session it retakes in ParentSessionID.
+type TestSession struct {
+	SessionID       string             `json:"session_id"`
+	ParentSessionID string             `json:"parent_session_id,omitempty"`
+	Items           []TestSessionItem  `json:"items"`
+	Summary         TestSessionSummary `json:"summary"`
+	CreatedAt       time.Time          `json:"created_at"`
+}
+
+// TestSessionItem is an item in a test, with the result the user recorded for it if any and the
+// latest mock interview the user started on it from the test
+type TestSessionItem struct {
+	ItemWithProgress
+	Result          *TestItemResult `json:"result,omitempty"`
+	MockInterviewID *int            `json:"mock_interview_id,omitempty"`
+}
+
+// Missed reports whether the user didn't get the item in the test: they abandoned it or judged
+// their answer failed
+func (i *TestSessionItem) Missed() bool {
+	return string(i.Status) == string(TestStatusAbandoned) || (i.Result != nil && i.Result.Outcome == TestOutcomeFailed)
+}
+
+// TestSessionSummary adds up the results recorded in a test
+type TestSessionSummary struct {
+	Solved            int      `json:"solved"`
+	Partial           int      `json:"partial"`
+	Failed            int      `json:"failed"`
+	Unrecorded        int      `json:"unrecorded"`
+	TotalTimeTaken    int      `json:"total_time_taken"`
+	AverageSelfRating *float64 `json:"average_self_rating,omitempty"`
+}
+
+// TestOutcome is how the user judges their answer to a test item
+type TestOutcome string
+
+const (
+	TestOutcomeSolved  TestOutcome = "solved"
+	TestOutcomePartial TestOutcome = "partial"
+	TestOutcomeFailed  TestOutcome = "failed"
+)
+
+// IsValidTestOutcome checks if a test outcome is valid
+func IsValidTestOutcome(outcome TestOutcome) bool {
+	switch outcome {
+	case TestOutcomeSolved, TestOutcomePartial, TestOutcomeFailed:
+		return true
+	}
+	return false
+}
+
+// TestItemResult is the user's self-assessment of a test item. TimeTaken is in seconds and
+// SelfRating runs from 1 (struggled) to 5 (confident).
+type TestItemResult struct {
+	Outcome    TestOutcome `json:"outcome"`
+	TimeTaken  *int        `json:"time_taken,omitempty"`
+	SelfRating *int        `json:"self_rating,omitempty"`
+	Notes      string      `json:"notes,omitempty"`
+	RecordedAt time.Time   `json:"recorded_at"`
+}
+
+// RecordTestResultRequest represents the payload for recording a test item's result; recording
+// again replaces the earlier result
+type RecordTestResultRequest struct {
+	Outcome    TestOutcome `json:"outcome" binding:"required,test_outcome"`
+	TimeTaken  *int        `json:"time_taken" binding:"omitempty,min=0,max=86400"`
+	SelfRating *int        `json:"self_rating" binding:"omitempty,min=1,max=5"`
+	Notes      string      `json:"notes" binding:"max=5000"`
 }
 
 // IsValidTestStatus checks if a test status is valid
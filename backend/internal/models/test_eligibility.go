@@ -0,0 +1,67 @@
+package models
+
+import "time"
+
+// TestRuleKind is a kind of rule a user must meet to start a test
+type TestRuleKind string
+
+const (
+	// TestRuleInProgress needs an item of the category, and subcategory when set, in progress
+	TestRuleInProgress TestRuleKind = "in_progress"
+	// TestRuleMinCompleted needs Count completed items of the category, or of any when unset
+	TestRuleMinCompleted TestRuleKind = "min_completed"
+	// TestRuleCooldown needs CooldownHours to have passed since the user's last test started
+	TestRuleCooldown TestRuleKind = "cooldown"
+)
+
+// MaxTestRules caps how many rules an eligibility policy holds
+const MaxTestRules = 20
+
+// IsValidTestRuleKind checks if a test rule kind is valid
+func IsValidTestRuleKind(kind TestRuleKind) bool {
+	switch kind {
+	case TestRuleInProgress, TestRuleMinCompleted, TestRuleCooldown:
+		return true
+	}
+	return false
+}
+
+// TestEligibilityRule is one requirement for starting a test. Which fields apply depends on
+// the kind.
+type TestEligibilityRule struct {
+	Kind          TestRuleKind `json:"kind" binding:"required,test_rule_kind"`
+	Category      Category     `json:"category,omitempty" binding:"max=100"`
+	Subcategory   string       `json:"subcategory,omitempty" binding:"max=100"`
+	Count         int          `json:"count,omitempty" binding:"min=0,max=10000"`
+	CooldownHours int          `json:"cooldown_hours,omitempty" binding:"min=0,max=8760"`
+}
+
+// DefaultTestEligibilityRules are the rules used until an admin sets some: a
+// miscellaneous test_n_revise item must be in progress
+func DefaultTestEligibilityRules() []TestEligibilityRule {
+	return []TestEligibilityRule{
+		{Kind: TestRuleInProgress, Category: CategoryMiscellaneous, Subcategory: Test_n_revise},
+	}
+}
+
+// TestEligibilityPolicy is the set of rules every user must meet to start a test. No rules
+// means anyone can. Default is set while no admin has changed the rules.
+type TestEligibilityPolicy struct {
+	Rules     []TestEligibilityRule `json:"rules"`
+	Default   bool                  `json:"default"`
+	UpdatedAt *time.Time            `json:"updated_at,omitempty"`
+}
+
+// UpdateTestEligibilityRequest replaces the eligibility rules; an empty list turns gating off
+type UpdateTestEligibilityRequest struct {
+	Rules []TestEligibilityRule `json:"rules" binding:"required,max=20,dive"`
+}
+
+// TestEligibility is whether a user can start a test now, with a reason for every rule they
+// don't meet yet. AvailableAt is when a cooldown ends.
+type TestEligibility struct {
+	CanCreate   bool       `json:"can_create"`
+	Reason      string     `json:"reason"`
+	Unmet       []string   `json:"unmet"`
+	AvailableAt *time.Time `json:"available_at,omitempty"`
+}
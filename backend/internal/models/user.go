@@ -14,12 +14,20 @@ const (
 	AuthProviderApple    AuthProvider = "apple"
 )
 
+// IsValidOAuthProvider checks if a provider can be used for OAuth login
+func IsValidOAuthProvider(provider AuthProvider) bool {
+	return provider == AuthProviderGoogle || provider == AuthProviderFacebook || provider == AuthProviderApple
+}
+
 // Role represents user roles in the system
 type Role string
 
+// Built-in roles. Admins can define more; a role's permissions live in the roles tables.
 const (
-	RoleUser  Role = "user"
-	RoleAdmin Role = "admin"
+	RoleUser          Role = "user"
+	RoleAdmin         Role = "admin"
+	RoleContentEditor Role = "content_editor"
+	RoleModerator     Role = "moderator"
 )
 
 // User represents a user in the system
@@ -40,12 +48,13 @@ type User struct {
 
 // CreateUserRequest represents the request to create a new user
 type CreateUserRequest struct {
-	Email        string       `json:"email" binding:"required,email"`
-	Name         string       `json:"name" binding:"required"`
-	Password     string       `json:"password,omitempty" binding:"omitempty,min=6"`
-	AuthProvider AuthProvider `json:"auth_provider,omitempty"`
-	ProviderID   string       `json:"provider_id,omitempty"`
-	Avatar       string       `json:"avatar,omitempty"`
+	Email           string       `json:"email" binding:"required,email"`
+	Name            string       `json:"name" binding:"required,notblank,max=255"`
+	Password        string       `json:"password,omitempty" binding:"omitempty,min=6"`
+	AuthProvider    AuthProvider `json:"auth_provider,omitempty"`
+	ProviderID      string       `json:"provider_id,omitempty"`
+	Avatar          string       `json:"avatar,omitempty"`
+	InvitationToken string       `json:"invitation_token,omitempty"`
 }
 
 // UpdateUserRequest represents the request to update a user
@@ -54,6 +63,18 @@ type UpdateUserRequest struct {
 	Avatar string `json:"avatar,omitempty"`
 }
 
+// DeleteAccountRequest confirms an account deletion: email accounts confirm with their password,
+// social login accounts with a fresh access token from their provider
+type DeleteAccountRequest struct {
+	Password    string `json:"password,omitempty"`
+	AccessToken string `json:"access_token,omitempty"`
+}
+
+// DeleteAccountResponse tells a user when their deleted account's data will be purged
+type DeleteAccountResponse struct {
+	PurgeAt time.Time `json:"purge_at"`
+}
+
 // LoginRequest represents the login request
 type LoginRequest struct {
 	Email    string `json:"email" binding:"required,email"`
@@ -62,12 +83,13 @@ type LoginRequest struct {
 
 // OAuthLoginRequest represents OAuth login request
 type OAuthLoginRequest struct {
-	Provider    AuthProvider `json:"provider" binding:"required"`
-	AccessToken string       `json:"access_token" binding:"required"`
-	Email       string       `json:"email,omitempty"`
-	Name        string       `json:"name,omitempty"`
-	Avatar      string       `json:"avatar,omitempty"`
-	ProviderID  string       `json:"provider_id,omitempty"`
+	Provider        AuthProvider `json:"provider" binding:"required,oauth_provider"`
+	AccessToken     string       `json:"access_token" binding:"required"`
+	Email           string       `json:"email,omitempty" binding:"omitempty,email"`
+	Name            string       `json:"name,omitempty" binding:"max=255"`
+	Avatar          string       `json:"avatar,omitempty" binding:"omitempty,weburl"`
+	ProviderID      string       `json:"provider_id,omitempty"`
+	InvitationToken string       `json:"invitation_token,omitempty"`
 }
 
 // LoginResponse represents the login response
@@ -92,6 +114,63 @@ type UserProgress struct {
 	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
 }
 
+// ProgressSortFields are the columns progress records can be sorted by
+var ProgressSortFields = []string{"created_at", "updated_at", "started_at", "completed_at"}
+
+// ProgressFilter represents filters, sorting and paging for a user's raw progress records
+type ProgressFilter struct {
+	Status    *Status `json:"status,omitempty"`
+	SortBy    string  `json:"sort_by,omitempty"`    // one of ProgressSortFields; defaults to created_at
+	SortOrder string  `json:"sort_order,omitempty"` // asc or desc; defaults to desc
+	Limit     int     `json:"limit,omitempty"`
+	Offset    int     `json:"offset,omitempty"`
+}
+
+// PaginatedProgressResponse represents a page of a user's progress records
+type PaginatedProgressResponse struct {
+	Progress   []*UserProgress `json:"progress"`
+	Pagination PaginationMeta  `json:"pagination"`
+}
+
+// Outcomes of importing one progress row
+const (
+	ImportResultCreated        = "created"
+	ImportResultAlreadyTracked = "already_tracked"
+	ImportResultUnmatched      = "unmatched"
+	ImportResultInvalid        = "invalid"
+)
+
+// ProgressImportEntry is a progress record to create from an import
+type ProgressImportEntry struct {
+	ItemID      int
+	Status      Status
+	Starred     bool
+	Notes       string
+	CompletedAt *time.Time
+}
+
+// ProgressImportRow reports what happened to one row of an import file
+type ProgressImportRow struct {
+	Row    int    `json:"row"`
+	Link   string `json:"link,omitempty"`
+	Status Status `json:"status,omitempty"`
+	ItemID int    `json:"item_id,omitempty"`
+	Result string `json:"result"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// ProgressImportReport summarizes a progress import. In a dry run nothing is written and
+// Created counts the rows that would be.
+type ProgressImportReport struct {
+	DryRun         bool                `json:"dry_run"`
+	TotalRows      int                 `json:"total_rows"`
+	Created        int                 `json:"created"`
+	AlreadyTracked int                 `json:"already_tracked"`
+	Unmatched      int                 `json:"unmatched"`
+	Invalid        int                 `json:"invalid"`
+	Rows           []ProgressImportRow `json:"rows"`
+}
+
 // RefreshToken represents a refresh token
 type RefreshToken struct {
 	ID        int       `json:"id" db:"id"`
@@ -100,6 +179,35 @@ type RefreshToken struct {
 	ExpiresAt time.Time `json:"expires_at" db:"expires_at"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 	IsRevoked bool      `json:"is_revoked" db:"is_revoked"`
+	IPAddress string    `json:"ip_address,omitempty" db:"ip_address"`
+	UserAgent string    `json:"user_agent,omitempty" db:"user_agent"`
+}
+
+// SessionMetadata describes where a login came from
+type SessionMetadata struct {
+	IPAddress string
+	UserAgent string
+}
+
+// LoginHistory reports whether a user has logged in before from a device or IP
+type LoginHistory struct {
+	HasSessions bool
+	KnownDevice bool
+	KnownIP     bool
+}
+
+// DenyLoginResponse is returned when a user reports that a login wasn't them. Email users get
+// a token to choose a new password with; until they do, password logins are refused.
+type DenyLoginResponse struct {
+	SessionsRevoked        bool       `json:"sessions_revoked"`
+	PasswordResetToken     string     `json:"password_reset_token,omitempty"`
+	PasswordResetExpiresAt *time.Time `json:"password_reset_expires_at,omitempty"`
+}
+
+// ResetPasswordRequest represents the payload for choosing a new password with a reset token
+type ResetPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=6"`
 }
 
 // UserStats represents user-specific statistics
@@ -118,4 +226,10 @@ type UserStats struct {
 	LastActivityDate  *time.Time `json:"last_activity_date,omitempty" db:"last_activity_date"`
 	CreatedAt         time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt         time.Time  `json:"updated_at" db:"updated_at"`
+
+	// Aggregates kept current as progress changes (excluding miscellaneous category).
+	// A nil StatsRefreshedAt means the counts are stale and must be recomputed before use.
+	SolvedItems           int        `json:"solved_items" db:"solved_items"`
+	ReviewedSolutionItems int        `json:"reviewed_solution_items" db:"reviewed_solution_items"`
+	StatsRefreshedAt      *time.Time `json:"stats_refreshed_at,omitempty" db:"stats_refreshed_at"`
 }
@@ -0,0 +1,131 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// WebhookEvent names a progress event users can subscribe to
+type WebhookEvent string
+
+const (
+	WebhookEventItemCompleted WebhookEvent = "item.completed"
+	WebhookEventStreakChanged WebhookEvent = "streak.changed"
+	WebhookEventTestCompleted WebhookEvent = "test.completed"
+	WebhookEventWeeklyDigest  WebhookEvent = "digest.weekly"
+)
+
+// ValidWebhookEvents returns all events a webhook can subscribe to
+func ValidWebhookEvents() []WebhookEvent {
+	return []WebhookEvent{WebhookEventItemCompleted, WebhookEventStreakChanged, WebhookEventTestCompleted, WebhookEventWeeklyDigest}
+}
+
+// IsValidWebhookEvent checks if the event is one users can subscribe to
+func IsValidWebhookEvent(event WebhookEvent) bool {
+	for _, valid := range ValidWebhookEvents() {
+		if event == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookFormat is how deliveries to a webhook are shaped: signed JSON events, or chat messages
+// for a Slack or Discord incoming webhook
+type WebhookFormat string
+
+const (
+	WebhookFormatJSON    WebhookFormat = "json"
+	WebhookFormatSlack   WebhookFormat = "slack"
+	WebhookFormatDiscord WebhookFormat = "discord"
+)
+
+// IsChat reports whether deliveries are posted as chat messages rather than signed events
+func (f WebhookFormat) IsChat() bool {
+	return f == WebhookFormatSlack || f == WebhookFormatDiscord
+}
+
+// WebhookDeliveryStatus represents where a delivery is in its retry lifecycle
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+// Webhook is a URL a user has registered to receive signed progress events.
+// The secret is only returned when the webhook is created.
+type Webhook struct {
+	ID        int            `json:"id" db:"id"`
+	UserID    int            `json:"user_id" db:"user_id"`
+	URL       string         `json:"url" db:"url"`
+	Secret    string         `json:"secret,omitempty" db:"secret"`
+	Events    []WebhookEvent `json:"events" db:"events"`
+	Format    WebhookFormat  `json:"format" db:"format"`
+	Active    bool           `json:"active" db:"active"`
+	CreatedAt time.Time      `json:"created_at" db:"created_at"`
+}
+
+// WebhookDelivery is one event queued for (or delivered to) a webhook
+type WebhookDelivery struct {
+	ID             int                   `json:"id" db:"id"`
+	WebhookID      int                   `json:"webhook_id" db:"webhook_id"`
+	Event          WebhookEvent          `json:"event" db:"event"`
+	Payload        json.RawMessage       `json:"payload" db:"payload"`
+	Status         WebhookDeliveryStatus `json:"status" db:"status"`
+	Attempts       int                   `json:"attempts" db:"attempts"`
+	NextAttemptAt  *time.Time            `json:"next_attempt_at,omitempty" db:"next_attempt_at"`
+	LastStatusCode *int                  `json:"last_status_code,omitempty" db:"last_status_code"`
+	LastError      string                `json:"last_error,omitempty" db:"last_error"`
+	CreatedAt      time.Time             `json:"created_at" db:"created_at"`
+	DeliveredAt    *time.Time            `json:"delivered_at,omitempty" db:"delivered_at"`
+
+	// Filled in when claiming a delivery to send; never serialized
+	URL    string        `json:"-"`
+	Secret string        `json:"-"`
+	Format WebhookFormat `json:"-"`
+}
+
+// WebhookPayload is the JSON body POSTed to a webhook
+type WebhookPayload struct {
+	Event     WebhookEvent `json:"event"`
+	UserID    int          `json:"user_id"`
+	CreatedAt time.Time    `json:"created_at"`
+	Data      interface{}  `json:"data"`
+}
+
+// CreateWebhookRequest represents the request payload for registering a webhook.
+// Leaving events empty subscribes to every event.
+type CreateWebhookRequest struct {
+	URL    string         `json:"url" binding:"required,weburl,max=2000"`
+	Events []WebhookEvent `json:"events,omitempty" binding:"dive,webhook_event"`
+}
+
+// ConnectIntegrationRequest represents the request payload for connecting a Slack or Discord
+// incoming webhook. Leaving events empty posts every event.
+type ConnectIntegrationRequest struct {
+	WebhookURL string         `json:"webhook_url" binding:"required,weburl,max=2000"`
+	Events     []WebhookEvent `json:"events,omitempty" binding:"dive,webhook_event"`
+}
+
+// StreakChangedData is the payload data for streak.changed events
+type StreakChangedData struct {
+	PreviousStreak int `json:"previous_streak"`
+	CurrentStreak  int `json:"current_streak"`
+	LongestStreak  int `json:"longest_streak"`
+}
+
+// TestCompletedData is the payload data for test.completed events
+type TestCompletedData struct {
+	SessionID string  `json:"session_id"`
+	Items     []*Test `json:"items"`
+}
+
+// WeeklyDigestData is the payload data for digest.weekly events
+type WeeklyDigestData struct {
+	WeekStart     time.Time `json:"week_start"`
+	Completed     int       `json:"completed"`
+	CurrentStreak int       `json:"current_streak"`
+	LongestStreak int       `json:"longest_streak"`
+}
@@ -0,0 +1,56 @@
+package openapi
+
+import (
+	"reflect"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+)
+
+// enums lists the values of string types limited to a fixed set. Categories and roles aren't
+// here: admins can add them.
+var enums = map[reflect.Type][]string{
+	reflect.TypeOf(models.Status("")):                toStrings(models.ValidStatuses()),
+	reflect.TypeOf(models.Competency("")):            toStrings(models.ValidCompetencies()),
+	reflect.TypeOf(models.AIQuestionKind("")):        toStrings(models.ValidAIQuestionKinds()),
+	reflect.TypeOf(models.DocumentKind("")):          {string(models.DocumentRevisionSheet)},
+	reflect.TypeOf(models.MockInterviewStatus("")):   {string(models.MockInterviewActive), string(models.MockInterviewEnded)},
+	reflect.TypeOf(models.MockInterviewRole("")):     {string(models.MockInterviewerRole), string(models.MockCandidateRole)},
+	reflect.TypeOf(models.AdminSearchType("")):       toStrings(models.ValidAdminSearchTypes()),
+	reflect.TypeOf(models.FlashcardGrade("")):        toStrings(models.ValidFlashcardGrades()),
+	reflect.TypeOf(models.InterviewStatus("")):       toStrings(models.ValidInterviewStatuses()),
+	reflect.TypeOf(models.InterviewStageKind("")):    toStrings(models.ValidInterviewStageKinds()),
+	reflect.TypeOf(models.TestStatus("")):            toStrings(models.ValidTestStatuses()),
+	reflect.TypeOf(models.WebhookEvent("")):          toStrings(models.ValidWebhookEvents()),
+	reflect.TypeOf(models.CompletionQuality("")):     {string(models.CompletionSolved), string(models.CompletionReviewedSolution)},
+	reflect.TypeOf(models.AuthProvider("")):          {string(models.AuthProviderEmail), string(models.AuthProviderGoogle), string(models.AuthProviderFacebook), string(models.AuthProviderApple)},
+	reflect.TypeOf(models.Permission("")):            toStrings(models.ValidPermissions()),
+	reflect.TypeOf(models.Plan("")):                  {string(models.PlanFree), string(models.PlanPro)},
+	reflect.TypeOf(models.DevicePlatform("")):        {string(models.DevicePlatformWeb), string(models.DevicePlatformAndroid), string(models.DevicePlatformIOS)},
+	reflect.TypeOf(models.FeedbackCategory("")):      {string(models.FeedbackDeadLink), string(models.FeedbackWrongCategory), string(models.FeedbackDuplicate), string(models.FeedbackOther)},
+	reflect.TypeOf(models.FeedbackStatus("")):        {string(models.FeedbackStatusOpen), string(models.FeedbackStatusResolved), string(models.FeedbackStatusDismissed)},
+	reflect.TypeOf(models.InterviewStageOutcome("")): {string(models.InterviewOutcomePending), string(models.InterviewOutcomePassed), string(models.InterviewOutcomeFailed), string(models.InterviewOutcomeCancelled)},
+	reflect.TypeOf(models.OrgRole("")):               {string(models.OrgRoleOwner), string(models.OrgRoleAdmin), string(models.OrgRoleMember)},
+	reflect.TypeOf(models.TestRuleKind("")):          {string(models.TestRuleInProgress), string(models.TestRuleMinCompleted), string(models.TestRuleCooldown)},
+	reflect.TypeOf(models.TestOutcome("")):           {string(models.TestOutcomeSolved), string(models.TestOutcomePartial), string(models.TestOutcomeFailed)},
+	reflect.TypeOf(apperr.Kind("")): {
+		string(apperr.KindValidation), string(apperr.KindUnauthorized), string(apperr.KindPaymentRequired),
+		string(apperr.KindForbidden), string(apperr.KindNotFound), string(apperr.KindConflict),
+		string(apperr.KindRateLimited), string(apperr.KindInternal), string(apperr.KindUpstream),
+		string(apperr.KindUnavailable),
+	},
+}
+
+// enumValues returns the values of a string type, or nil if any string is allowed
+func enumValues(t reflect.Type) []string {
+	return enums[t]
+}
+
+// toStrings converts a list of string-typed values
+func toStrings[T ~string](values []T) []string {
+	converted := make([]string, len(values))
+	for i, v := range values {
+		converted[i] = string(v)
+	}
+	return converted
+}
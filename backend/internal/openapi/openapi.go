@@ -0,0 +1,472 @@
+// Package openapi builds the API's OpenAPI 3 document from a table of operations. Request and
+// response schemas are derived from the Go types handlers bind and return, including their json
+// and binding tags, so the document follows the models without separate annotations; only the
+// table of routes is maintained by hand, and the server tests check it against the router.
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"interview-prep-app/pkg/apperr"
+)
+
+// Operation documents one route
+type Operation struct {
+	Method  string
+	Path    string // gin syntax, e.g. /api/v1/items/:id
+	Tag     string
+	Summary string
+
+	// Public operations need no bearer token
+	Public     bool
+	Deprecated bool
+
+	// StringParams names path parameters that aren't numeric IDs. Parameters named id or
+	// ending in _id or Id are documented as integers, any other as strings.
+	StringParams []string
+	Query        []Param
+
+	// Body is a value of the JSON request body's type. Upload instead names the file field
+	// of a multipart body.
+	Body   interface{}
+	Upload string
+
+	// Response is a value of the JSON response's type, or an Object for an inline one.
+	// ContentType replaces JSON for operations returning something else, such as a file.
+	Response    interface{}
+	ContentType string
+	Status      int // success status, 200 when zero
+}
+
+// Param is a query parameter
+type Param struct {
+	Name        string
+	Type        string // string, integer, boolean or number
+	Description string
+	Required    bool
+}
+
+// Query documents an optional query parameter
+func Query(name, typ, description string) Param {
+	return Param{Name: name, Type: typ, Description: description}
+}
+
+// Object is an inline response object, for handlers that answer with gin.H. Values are
+// examples of each property's type.
+type Object map[string]interface{}
+
+// Message is the response of operations that only confirm they succeeded
+var Message = Object{"message": ""}
+
+// bearerAuth is the name of the security scheme for JWTs, personal API keys and service account keys
+const bearerAuth = "bearerAuth"
+
+// Build assembles the document for the given operations
+func Build(info Info, operations []Operation) (*Document, error) {
+	doc := &Document{
+		OpenAPI: "3.0.3",
+		Info:    info,
+		Paths:   map[string]PathItem{},
+		Components: Components{
+			Schemas: map[string]*Schema{},
+			SecuritySchemes: map[string]SecurityScheme{
+				bearerAuth: {
+					Type:         "http",
+					Scheme:       "bearer",
+					BearerFormat: "JWT",
+					Description:  "An access token from /api/v1/auth/login, or a service account key for the analytics routes",
+				},
+			},
+		},
+		Security: []SecurityRequirement{{bearerAuth: {}}},
+	}
+
+	b := &builder{schemas: doc.Components.Schemas, types: map[string]reflect.Type{}}
+	errorSchema, err := b.schemaFor(errorType)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, op := range operations {
+		path, params := convertPath(op.Path, op.StringParams)
+		method := strings.ToLower(op.Method)
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = PathItem{}
+		}
+		if doc.Paths[path][method] != nil {
+			return nil, fmt.Errorf("operation %s %s is documented twice", op.Method, op.Path)
+		}
+
+		operation, err := b.operation(op, params, errorSchema)
+		if err != nil {
+			return nil, fmt.Errorf("operation %s %s: %w", op.Method, op.Path, err)
+		}
+		doc.Paths[path][method] = operation
+	}
+
+	return doc, nil
+}
+
+// builder collects the component schemas of the types operations use
+type builder struct {
+	schemas map[string]*Schema
+	types   map[string]reflect.Type
+}
+
+func (b *builder) operation(op Operation, params []Parameter, errorSchema *Schema) (*OperationObject, error) {
+	operation := &OperationObject{
+		OperationID: operationID(op.Method, op.Path),
+		Summary:     op.Summary,
+		Deprecated:  op.Deprecated,
+		Parameters:  params,
+		Responses:   map[string]Response{},
+	}
+	if op.Tag != "" {
+		operation.Tags = []string{op.Tag}
+	}
+	if op.Public {
+		operation.Security = &[]SecurityRequirement{}
+	}
+
+	for _, q := range op.Query {
+		operation.Parameters = append(operation.Parameters, Parameter{
+			Name:        q.Name,
+			In:          "query",
+			Description: q.Description,
+			Required:    q.Required,
+			Schema:      &Schema{Type: q.Type},
+		})
+	}
+
+	switch {
+	case op.Body != nil:
+		schema, err := b.schemaFor(reflect.TypeOf(op.Body))
+		if err != nil {
+			return nil, err
+		}
+		operation.RequestBody = &RequestBody{Required: true, Content: map[string]MediaType{"application/json": {Schema: schema}}}
+	case op.Upload != "":
+		operation.RequestBody = &RequestBody{Required: true, Content: map[string]MediaType{
+			"multipart/form-data": {Schema: &Schema{
+				Type:       "object",
+				Properties: map[string]*Schema{op.Upload: {Type: "string", Format: "binary"}},
+				Required:   []string{op.Upload},
+			}},
+		}}
+	}
+
+	status := op.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	success := Response{Description: http.StatusText(status)}
+	switch {
+	case op.ContentType != "":
+		success.Content = map[string]MediaType{op.ContentType: {Schema: &Schema{Type: "string", Format: "binary"}}}
+	case op.Response != nil:
+		schema, err := b.responseSchema(op.Response)
+		if err != nil {
+			return nil, err
+		}
+		success.Content = map[string]MediaType{"application/json": {Schema: schema}}
+	}
+	operation.Responses[strconv.Itoa(status)] = success
+	operation.Responses["default"] = Response{
+		Description: "Error",
+		Content:     map[string]MediaType{"application/json": {Schema: errorSchema}},
+	}
+
+	return operation, nil
+}
+
+func (b *builder) responseSchema(response interface{}) (*Schema, error) {
+	object, ok := response.(Object)
+	if !ok {
+		return b.schemaFor(reflect.TypeOf(response))
+	}
+
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for name, value := range object {
+		property, err := b.responseSchema(value)
+		if err != nil {
+			return nil, err
+		}
+		schema.Properties[name] = property
+		schema.Required = append(schema.Required, name)
+	}
+	sort.Strings(schema.Required)
+	return schema, nil
+}
+
+var (
+	errorType      = reflect.TypeOf(apperr.Response{})
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// schemaFor describes a Go type, adding named structs to the components and referring to them
+func (b *builder) schemaFor(t reflect.Type) (*Schema, error) {
+	if t == nil {
+		return &Schema{}, nil
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}, nil
+	case t == rawMessageType:
+		return &Schema{}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if t.Name() == "" {
+			return b.structSchema(t)
+		}
+		return b.componentRef(t)
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}, nil
+		}
+		items, err := b.schemaFor(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "array", Items: items}, nil
+	case reflect.Map:
+		values, err := b.schemaFor(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "object", AdditionalProperties: values}, nil
+	case reflect.String:
+		return &Schema{Type: "string", Enum: enumValues(t)}, nil
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}, nil
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}, nil
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}, nil
+	case reflect.Interface:
+		return &Schema{}, nil
+	}
+
+	return nil, fmt.Errorf("can't describe type %s", t)
+}
+
+// componentRef refers to the component schema of a named struct, adding it on first use
+func (b *builder) componentRef(t reflect.Type) (*Schema, error) {
+	name := componentName(t)
+	ref := &Schema{Ref: "#/components/schemas/" + name}
+	if existing, ok := b.types[name]; ok {
+		if existing != t {
+			return nil, fmt.Errorf("types %s and %s would share the schema name %s", existing, t, name)
+		}
+		return ref, nil
+	}
+
+	// Registered before the fields are described so recursive types terminate
+	b.types[name] = t
+	b.schemas[name] = &Schema{}
+	schema, err := b.structSchema(t)
+	if err != nil {
+		return nil, err
+	}
+	*b.schemas[name] = *schema
+	return ref, nil
+}
+
+// componentName names a struct's schema. Models keep their own names; types from other
+// packages are prefixed with the package, e.g. HealthReport.
+func componentName(t reflect.Type) string {
+	if t == errorType {
+		return "Error"
+	}
+	pkg := t.PkgPath()
+	pkg = pkg[strings.LastIndex(pkg, "/")+1:]
+	name := strings.ToUpper(t.Name()[:1]) + t.Name()[1:]
+	if pkg == "models" || pkg == "" {
+		return name
+	}
+	return strings.ToUpper(pkg[:1]) + pkg[1:] + name
+}
+
+// structSchema describes a struct's JSON encoding, flattening embedded structs the way
+// encoding/json does
+func (b *builder) structSchema(t reflect.Type) (*Schema, error) {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				inner, err := b.structSchema(embedded)
+				if err != nil {
+					return nil, err
+				}
+				for property, propertySchema := range inner.Properties {
+					schema.Properties[property] = propertySchema
+				}
+				schema.Required = append(schema.Required, inner.Required...)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property, err := b.schemaFor(field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("field %s.%s: %w", t.Name(), field.Name, err)
+		}
+		if property.Ref == "" {
+			applyBindingRules(property, field.Tag.Get("binding"))
+		}
+		schema.Properties[name] = property
+
+		if isRequired(field, options) {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	sort.Strings(schema.Required)
+	return schema, nil
+}
+
+// isRequired reports whether a field is always present: for request fields with binding rules
+// when they're required, and otherwise when encoding/json always writes them
+func isRequired(field reflect.StructField, jsonOptions string) bool {
+	if binding, ok := field.Tag.Lookup("binding"); ok {
+		for _, rule := range strings.Split(binding, ",") {
+			if rule == "required" {
+				return true
+			}
+			if rule == "dive" {
+				break
+			}
+		}
+		return false
+	}
+	return !strings.Contains(jsonOptions, "omitempty") && field.Type.Kind() != reflect.Ptr
+}
+
+// applyBindingRules copies the length, size and format rules of a binding tag into the schema.
+// Rules after dive apply to the elements.
+func applyBindingRules(schema *Schema, binding string) {
+	target := schema
+	for _, rule := range strings.Split(binding, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "dive":
+			if target.Items == nil {
+				return
+			}
+			target = target.Items
+		case "email":
+			target.Format = "email"
+		case "url", "weburl":
+			target.Format = "uri"
+		case "min", "max":
+			n, err := strconv.Atoi(param)
+			if err != nil {
+				continue
+			}
+			setBound(target, name == "min", n)
+		}
+	}
+}
+
+func setBound(schema *Schema, lower bool, n int) {
+	switch schema.Type {
+	case "string":
+		if lower {
+			schema.MinLength = &n
+		} else {
+			schema.MaxLength = &n
+		}
+	case "array":
+		if lower {
+			schema.MinItems = &n
+		} else {
+			schema.MaxItems = &n
+		}
+	case "integer", "number":
+		f := float64(n)
+		if lower {
+			schema.Minimum = &f
+		} else {
+			schema.Maximum = &f
+		}
+	}
+}
+
+// convertPath turns a gin path into an OpenAPI one and describes its parameters
+func convertPath(ginPath string, stringParams []string) (string, []Parameter) {
+	var params []Parameter
+	segments := strings.Split(ginPath, "/")
+	for i, segment := range segments {
+		if !strings.HasPrefix(segment, ":") && !strings.HasPrefix(segment, "*") {
+			continue
+		}
+		name := segment[1:]
+		segments[i] = "{" + name + "}"
+
+		schema := &Schema{Type: "string"}
+		if isNumericParam(name) && !contains(stringParams, name) {
+			schema = &Schema{Type: "integer"}
+		}
+		params = append(params, Parameter{Name: name, In: "path", Required: true, Schema: schema})
+	}
+	return strings.Join(segments, "/"), params
+}
+
+func isNumericParam(name string) bool {
+	return name == "id" || strings.HasSuffix(name, "_id") || strings.HasSuffix(name, "Id")
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// operationID names an operation for generated clients, e.g. put_api_v1_items_id_complete
+func operationID(method, ginPath string) string {
+	var parts []string
+	for _, segment := range strings.Split(ginPath, "/") {
+		segment = strings.TrimLeft(segment, ":*")
+		if segment == "" {
+			continue
+		}
+		segment = strings.NewReplacer("-", "_", ".", "_").Replace(segment)
+		parts = append(parts, segment)
+	}
+	return strings.ToLower(method) + "_" + strings.Join(parts, "_")
+}
@@ -0,0 +1,118 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"interview-prep-app/internal/models"
+)
+
+type testBase struct {
+	ID        int       `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type testRequest struct {
+	testBase
+	Title   string              `json:"title" binding:"required,notblank,max=200"`
+	Email   *string             `json:"email,omitempty" binding:"omitempty,email"`
+	Status  models.Status       `json:"status" binding:"omitempty,status"`
+	Tags    []string            `json:"tags" binding:"max=5,dive,min=1"`
+	Secret  string              `json:"-"`
+	Parent  *testRequest        `json:"parent,omitempty"`
+	Extra   map[string]int      `json:"extra,omitempty"`
+	Options []models.Competency `json:"options,omitempty"`
+}
+
+func build(t *testing.T, ops ...Operation) *Document {
+	t.Helper()
+	doc, err := Build(Info{Title: "Test", Version: "1"}, ops)
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	return doc
+}
+
+func TestBuildStructSchema(t *testing.T) {
+	doc := build(t, Operation{Method: "POST", Path: "/things", Body: testRequest{}, Response: testRequest{}, Status: http.StatusCreated})
+
+	schema := doc.Components.Schemas["OpenapiTestRequest"]
+	if schema == nil {
+		t.Fatalf("Expected an OpenapiTestRequest component, got %v", reflect.ValueOf(doc.Components.Schemas).MapKeys())
+	}
+
+	if !reflect.DeepEqual(schema.Required, []string{"created_at", "id", "title"}) {
+		t.Errorf("Unexpected required fields %v", schema.Required)
+	}
+	if _, ok := schema.Properties["Secret"]; ok {
+		t.Error("Expected json:\"-\" fields to be skipped")
+	}
+	if p := schema.Properties["created_at"]; p == nil || p.Format != "date-time" {
+		t.Errorf("Expected embedded created_at as a date-time, got %+v", p)
+	}
+	if p := schema.Properties["title"]; p.MaxLength == nil || *p.MaxLength != 200 {
+		t.Errorf("Expected title to be limited to 200 characters, got %+v", p)
+	}
+	if p := schema.Properties["email"]; p.Format != "email" {
+		t.Errorf("Expected email format, got %+v", p)
+	}
+	if p := schema.Properties["status"]; len(p.Enum) != len(models.ValidStatuses()) {
+		t.Errorf("Expected the status values, got %+v", p)
+	}
+	if p := schema.Properties["tags"]; p.MaxItems == nil || *p.MaxItems != 5 || p.Items.MinLength == nil || *p.Items.MinLength != 1 {
+		t.Errorf("Expected bounds on the list and its elements, got %+v", p)
+	}
+	if p := schema.Properties["parent"]; p.Ref != "#/components/schemas/OpenapiTestRequest" {
+		t.Errorf("Expected a recursive reference, got %+v", p)
+	}
+
+	responses := doc.Paths["/things"]["post"].Responses
+	if _, ok := responses["201"]; !ok {
+		t.Errorf("Expected a 201 response, got %v", responses)
+	}
+	if responses["default"].Content["application/json"].Schema.Ref != "#/components/schemas/Error" {
+		t.Error("Expected errors to use the Error schema")
+	}
+}
+
+func TestBuildPaths(t *testing.T) {
+	doc := build(t,
+		Operation{Method: "GET", Path: "/blogs/:id/articles/:articleId", StringParams: []string{"id"}, Response: Object{"message": "", "count": 0}},
+		Operation{Method: "GET", Path: "/login", Public: true, Query: []Param{Query("next", "string", "Where to go")}},
+	)
+
+	op := doc.Paths["/blogs/{id}/articles/{articleId}"]["get"]
+	if op == nil {
+		t.Fatalf("Expected gin parameters to be converted, got paths %v", reflect.ValueOf(doc.Paths).MapKeys())
+	}
+	if op.OperationID != "get_blogs_id_articles_articleId" {
+		t.Errorf("Unexpected operation ID %q", op.OperationID)
+	}
+	if op.Parameters[0].Schema.Type != "string" || op.Parameters[1].Schema.Type != "integer" {
+		t.Errorf("Expected a string id and an integer articleId, got %+v %+v", op.Parameters[0].Schema, op.Parameters[1].Schema)
+	}
+	if op.Security != nil {
+		t.Error("Expected a protected operation to use the document's security")
+	}
+	response := op.Responses["200"].Content["application/json"].Schema
+	if !reflect.DeepEqual(response.Required, []string{"count", "message"}) || response.Properties["count"].Type != "integer" {
+		t.Errorf("Unexpected inline response %+v", response)
+	}
+
+	login := doc.Paths["/login"]["get"]
+	if login.Security == nil || len(*login.Security) != 0 {
+		t.Error("Expected a public operation to clear security")
+	}
+	if len(login.Parameters) != 1 || login.Parameters[0].In != "query" {
+		t.Errorf("Expected a query parameter, got %+v", login.Parameters)
+	}
+}
+
+func TestBuildRejectsDuplicates(t *testing.T) {
+	_, err := Build(Info{}, []Operation{{Method: "GET", Path: "/a"}, {Method: "GET", Path: "/a"}})
+	if err == nil {
+		t.Error("Expected an operation documented twice to fail")
+	}
+}
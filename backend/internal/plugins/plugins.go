@@ -0,0 +1,111 @@
+// Package plugins lets forks add their own integrations without patching core services.
+//
+// Extensions register themselves at build time from an init function, the same way
+// database/sql drivers do, and are enabled by blank-importing their package from a
+// new file in cmd/server:
+//
+//	package main
+//
+//	import _ "interview-prep-app/internal/plugins/wiki"
+//
+// Item sources are synced on a schedule and their items added to the catalog.
+// Notification channels receive every reminder alongside the email.
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"interview-prep-app/internal/models"
+)
+
+// ItemSource supplies catalog items from an external system (internal wiki, LMS, ...)
+type ItemSource interface {
+	// Name identifies the source in logs; it must be unique
+	Name() string
+	// Fetch returns the items the source currently offers. Items whose link is
+	// already in the catalog are skipped, so returning everything each time is fine.
+	Fetch(ctx context.Context) ([]models.CreateItemRequest, error)
+}
+
+// Notification is a message for a single user, delivered through every registered channel
+type Notification struct {
+	UserID  int
+	Email   string
+	Name    string
+	Kind    string
+	Subject string
+	Body    string
+	Link    string
+}
+
+// NotificationChannel delivers notifications somewhere other than email (chat, LMS inbox, ...)
+type NotificationChannel interface {
+	// Name identifies the channel in logs; it must be unique
+	Name() string
+	Notify(ctx context.Context, n Notification) error
+}
+
+var (
+	mu       sync.RWMutex
+	sources  = make(map[string]ItemSource)
+	channels = make(map[string]NotificationChannel)
+)
+
+// RegisterItemSource makes an item source available. It panics on a duplicate
+// name so misconfigured builds fail at startup rather than silently.
+func RegisterItemSource(source ItemSource) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if source == nil {
+		panic("plugins: RegisterItemSource source is nil")
+	}
+	if _, dup := sources[source.Name()]; dup {
+		panic(fmt.Sprintf("plugins: RegisterItemSource called twice for %s", source.Name()))
+	}
+	sources[source.Name()] = source
+}
+
+// RegisterNotificationChannel makes a notification channel available. It panics on a
+// duplicate name so misconfigured builds fail at startup rather than silently.
+func RegisterNotificationChannel(channel NotificationChannel) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if channel == nil {
+		panic("plugins: RegisterNotificationChannel channel is nil")
+	}
+	if _, dup := channels[channel.Name()]; dup {
+		panic(fmt.Sprintf("plugins: RegisterNotificationChannel called twice for %s", channel.Name()))
+	}
+	channels[channel.Name()] = channel
+}
+
+// ItemSources returns the registered item sources sorted by name
+func ItemSources() []ItemSource {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	list := make([]ItemSource, 0, len(sources))
+	for _, source := range sources {
+		list = append(list, source)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name() < list[j].Name() })
+	return list
+}
+
+// NotificationChannels returns the registered notification channels sorted by name
+func NotificationChannels() []NotificationChannel {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	list := make([]NotificationChannel, 0, len(channels))
+	for _, channel := range channels {
+		list = append(list, channel)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name() < list[j].Name() })
+	return list
+}
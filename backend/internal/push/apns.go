@@ -0,0 +1,154 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	apnsProductionHost = "https://api.push.apple.com"
+	apnsSandboxHost    = "https://api.sandbox.push.apple.com"
+	// Apple rejects provider tokens older than an hour and throttles refreshing more often than every 20 minutes
+	apnsTokenLifetime = 50 * time.Minute
+)
+
+// APNSSender delivers to iOS devices using token-based (.p8 key) authentication
+type APNSSender struct {
+	key    *ecdsa.PrivateKey
+	keyID  string
+	teamID string
+	topic  string
+	host   string
+	client *http.Client
+
+	mu       sync.Mutex
+	token    string
+	issuedAt time.Time
+}
+
+// NewAPNSSender creates an APNs sender from a .p8 signing key
+func NewAPNSSender(keyFile, keyID, teamID, topic string, production bool) (*APNSSender, error) {
+	if keyID == "" || teamID == "" || topic == "" {
+		return nil, fmt.Errorf("APNs requires a key ID, team ID and topic (bundle ID)")
+	}
+
+	raw, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, apperr.Errorf("failed to read APNs key: %w", err)
+	}
+
+	key, err := jwt.ParseECPrivateKeyFromPEM(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid APNs key: %w", err)
+	}
+
+	host := apnsSandboxHost
+	if production {
+		host = apnsProductionHost
+	}
+
+	return &APNSSender{
+		key:    key,
+		keyID:  keyID,
+		teamID: teamID,
+		topic:  topic,
+		host:   host,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// Send posts an alert notification to the device token
+func (s *APNSSender) Send(ctx context.Context, device *models.Device, msg Message) error {
+	token, err := s.providerToken()
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"aps": map[string]interface{}{
+			"alert": map[string]string{
+				"title": msg.Title,
+				"body":  msg.Body,
+			},
+			"sound": "default",
+		},
+		"link": msg.Link,
+		"kind": msg.Kind,
+	})
+	if err != nil {
+		return apperr.Errorf("failed to encode APNs payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.host+"/3/device/"+device.Token, bytes.NewReader(body))
+	if err != nil {
+		return apperr.Errorf("failed to create APNs request: %w", err)
+	}
+	req.Header.Set("authorization", "bearer "+token)
+	req.Header.Set("apns-topic", s.topic)
+	req.Header.Set("apns-push-type", "alert")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return apperr.Errorf("failed to send APNs notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	var result struct {
+		Reason string `json:"reason"`
+	}
+	detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	_ = json.Unmarshal(detail, &result)
+
+	switch result.Reason {
+	case "BadDeviceToken", "Unregistered", "DeviceTokenNotForTopic":
+		return ErrInvalidToken
+	}
+	if resp.StatusCode == http.StatusGone {
+		return ErrInvalidToken
+	}
+
+	return fmt.Errorf("APNs rejected notification with status %d: %s", resp.StatusCode, detail)
+}
+
+// providerToken returns the cached provider JWT, re-signing it once it nears Apple's one-hour limit
+func (s *APNSSender) providerToken() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && time.Since(s.issuedAt) < apnsTokenLifetime {
+		return s.token, nil
+	}
+
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"iss": s.teamID,
+		"iat": now.Unix(),
+	})
+	token.Header["kid"] = s.keyID
+
+	signed, err := token.SignedString(s.key)
+	if err != nil {
+		return "", apperr.Errorf("failed to sign APNs token: %w", err)
+	}
+
+	s.token = signed
+	s.issuedAt = now
+	return s.token, nil
+}
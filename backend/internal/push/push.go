@@ -0,0 +1,60 @@
+// Package push delivers notifications to registered devices through FCM, APNs and Web Push.
+package push
+
+import (
+	"context"
+	"errors"
+
+	"interview-prep-app/internal/config"
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+)
+
+// ErrInvalidToken is returned when the push service reports the device is gone
+// (uninstalled app, expired subscription); the device should be forgotten
+var ErrInvalidToken = errors.New("push token is no longer valid")
+
+// Message is a notification shown on a device
+type Message struct {
+	Title string
+	Body  string
+	Link  string
+	Kind  string
+}
+
+// Sender delivers messages to devices on one platform
+type Sender interface {
+	Send(ctx context.Context, device *models.Device, msg Message) error
+}
+
+// NewSenders builds a sender for each platform that has credentials configured.
+// Platforms without configuration are simply absent from the map.
+func NewSenders(cfg *config.Config) (map[models.DevicePlatform]Sender, error) {
+	senders := make(map[models.DevicePlatform]Sender)
+
+	if cfg.VAPIDPrivateKey != "" {
+		sender, err := NewWebPushSender(cfg.VAPIDPublicKey, cfg.VAPIDPrivateKey, cfg.VAPIDSubject)
+		if err != nil {
+			return nil, apperr.Errorf("failed to configure web push: %w", err)
+		}
+		senders[models.DevicePlatformWeb] = sender
+	}
+
+	if cfg.FCMCredentialsFile != "" {
+		sender, err := NewFCMSender(cfg.FCMCredentialsFile)
+		if err != nil {
+			return nil, apperr.Errorf("failed to configure FCM: %w", err)
+		}
+		senders[models.DevicePlatformAndroid] = sender
+	}
+
+	if cfg.APNSKeyFile != "" {
+		sender, err := NewAPNSSender(cfg.APNSKeyFile, cfg.APNSKeyID, cfg.APNSTeamID, cfg.APNSTopic, cfg.APNSProduction)
+		if err != nil {
+			return nil, apperr.Errorf("failed to configure APNs: %w", err)
+		}
+		senders[models.DevicePlatformIOS] = sender
+	}
+
+	return senders, nil
+}
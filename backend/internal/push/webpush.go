@@ -0,0 +1,219 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"time"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// webPushRecordSize is the aes128gcm record size; payloads are small enough for a single record
+const webPushRecordSize = 4096
+
+// WebPushSender delivers to browser push subscriptions using VAPID (RFC 8292)
+// and aes128gcm payload encryption (RFC 8291)
+type WebPushSender struct {
+	privateKey *ecdsa.PrivateKey
+	publicKey  string // base64url uncompressed P-256 point, as given to PushManager.subscribe
+	subject    string
+	client     *http.Client
+}
+
+// NewWebPushSender creates a web push sender from base64url-encoded VAPID keys
+func NewWebPushSender(publicKey, privateKey, subject string) (*WebPushSender, error) {
+	d, err := decodeBase64URL(privateKey)
+	if err != nil || len(d) != 32 {
+		return nil, fmt.Errorf("VAPID private key must be a base64url-encoded 32-byte P-256 scalar")
+	}
+
+	key, err := ecdh.P256().NewPrivateKey(d)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VAPID private key: %w", err)
+	}
+	point := key.PublicKey().Bytes()
+
+	if publicKey != "" {
+		expected, err := decodeBase64URL(publicKey)
+		if err != nil || !bytes.Equal(expected, point) {
+			return nil, fmt.Errorf("VAPID public key does not match the private key")
+		}
+	}
+
+	return &WebPushSender{
+		privateKey: &ecdsa.PrivateKey{
+			PublicKey: ecdsa.PublicKey{
+				Curve: elliptic.P256(),
+				X:     new(big.Int).SetBytes(point[1:33]),
+				Y:     new(big.Int).SetBytes(point[33:65]),
+			},
+			D: new(big.Int).SetBytes(d),
+		},
+		publicKey: base64.RawURLEncoding.EncodeToString(point),
+		subject:   subject,
+		client:    &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// PublicKey returns the VAPID application server key browsers subscribe with
+func (s *WebPushSender) PublicKey() string {
+	return s.publicKey
+}
+
+// Send encrypts the message for the subscription and posts it to the push service
+func (s *WebPushSender) Send(ctx context.Context, device *models.Device, msg Message) error {
+	payload, err := json.Marshal(map[string]string{
+		"title": msg.Title,
+		"body":  msg.Body,
+		"link":  msg.Link,
+		"kind":  msg.Kind,
+	})
+	if err != nil {
+		return apperr.Errorf("failed to encode web push payload: %w", err)
+	}
+
+	body, err := encryptWebPush(payload, device.P256dh, device.Auth)
+	if err != nil {
+		return err
+	}
+
+	endpoint, err := url.Parse(device.Token)
+	if err != nil || endpoint.Scheme != "https" {
+		return ErrInvalidToken
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"aud": endpoint.Scheme + "://" + endpoint.Host,
+		"exp": time.Now().Add(12 * time.Hour).Unix(),
+		"sub": s.subject,
+	}).SignedString(s.privateKey)
+	if err != nil {
+		return apperr.Errorf("failed to sign VAPID token: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, device.Token, bytes.NewReader(body))
+	if err != nil {
+		return apperr.Errorf("failed to create web push request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("TTL", "86400")
+	req.Header.Set("Authorization", fmt.Sprintf("vapid t=%s, k=%s", token, s.publicKey))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return apperr.Errorf("failed to send web push: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return ErrInvalidToken
+	case resp.StatusCode >= 300:
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("web push rejected with status %d: %s", resp.StatusCode, detail)
+	}
+
+	return nil
+}
+
+// encryptWebPush encrypts a payload for a subscription as a single aes128gcm record (RFC 8291)
+func encryptWebPush(payload []byte, p256dh, authSecret string) ([]byte, error) {
+	uaPublicBytes, err := decodeBase64URL(p256dh)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	uaPublic, err := ecdh.P256().NewPublicKey(uaPublicBytes)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	auth, err := decodeBase64URL(authSecret)
+	if err != nil || len(auth) == 0 {
+		return nil, ErrInvalidToken
+	}
+
+	// Fresh application server key pair per message
+	asPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, apperr.Errorf("failed to generate web push key: %w", err)
+	}
+	asPublicBytes := asPrivate.PublicKey().Bytes()
+
+	sharedSecret, err := asPrivate.ECDH(uaPublic)
+	if err != nil {
+		return nil, apperr.Errorf("failed to derive web push secret: %w", err)
+	}
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, apperr.Errorf("failed to generate web push salt: %w", err)
+	}
+
+	keyInfo := append([]byte("WebPush: info\x00"), uaPublicBytes...)
+	keyInfo = append(keyInfo, asPublicBytes...)
+	ikm := hkdf(auth, sharedSecret, keyInfo, 32)
+	cek := hkdf(salt, ikm, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	nonce := hkdf(salt, ikm, []byte("Content-Encoding: nonce\x00"), 12)
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, apperr.Errorf("failed to create web push cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, apperr.Errorf("failed to create web push cipher: %w", err)
+	}
+
+	// 0x02 marks the last (and only) record
+	plaintext := append(append([]byte{}, payload...), 0x02)
+	if len(plaintext)+gcm.Overhead() > webPushRecordSize {
+		return nil, fmt.Errorf("web push payload too large")
+	}
+
+	header := make([]byte, 0, 16+4+1+len(asPublicBytes))
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, webPushRecordSize)
+	header = append(header, byte(len(asPublicBytes)))
+	header = append(header, asPublicBytes...)
+
+	return gcm.Seal(header, nonce, plaintext, nil), nil
+}
+
+// hkdf derives length bytes (at most one SHA-256 block) using HKDF extract-and-expand
+func hkdf(salt, secret, info []byte, length int) []byte {
+	extract := hmac.New(sha256.New, salt)
+	extract.Write(secret)
+	prk := extract.Sum(nil)
+
+	expand := hmac.New(sha256.New, prk)
+	expand.Write(info)
+	expand.Write([]byte{0x01})
+	return expand.Sum(nil)[:length]
+}
+
+// decodeBase64URL accepts base64url with or without padding, as browsers vary
+func decodeBase64URL(value string) ([]byte, error) {
+	if decoded, err := base64.RawURLEncoding.DecodeString(value); err == nil {
+		return decoded, nil
+	}
+	return base64.URLEncoding.DecodeString(value)
+}
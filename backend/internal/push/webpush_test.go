@@ -0,0 +1,109 @@
+package push
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"testing"
+)
+
+// decryptWebPush plays the browser's side of RFC 8291 to check what encryptWebPush produces
+func decryptWebPush(t *testing.T, body []byte, uaPrivate *ecdh.PrivateKey, auth []byte) []byte {
+	t.Helper()
+
+	salt := body[:16]
+	if rs := binary.BigEndian.Uint32(body[16:20]); rs != webPushRecordSize {
+		t.Fatalf("record size = %d, want %d", rs, webPushRecordSize)
+	}
+	idLen := int(body[20])
+	asPublicBytes := body[21 : 21+idLen]
+	ciphertext := body[21+idLen:]
+
+	asPublic, err := ecdh.P256().NewPublicKey(asPublicBytes)
+	if err != nil {
+		t.Fatalf("invalid server key in header: %v", err)
+	}
+	sharedSecret, err := uaPrivate.ECDH(asPublic)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyInfo := append([]byte("WebPush: info\x00"), uaPrivate.PublicKey().Bytes()...)
+	keyInfo = append(keyInfo, asPublicBytes...)
+	ikm := hkdf(auth, sharedSecret, keyInfo, 32)
+	cek := hkdf(salt, ikm, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	nonce := hkdf(salt, ikm, []byte("Content-Encoding: nonce\x00"), 12)
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		t.Fatalf("decrypt failed: %v", err)
+	}
+	if plaintext[len(plaintext)-1] != 0x02 {
+		t.Fatalf("missing last-record delimiter")
+	}
+	return plaintext[:len(plaintext)-1]
+}
+
+func TestEncryptWebPushRoundTrip(t *testing.T) {
+	uaPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	auth := make([]byte, 16)
+	if _, err := rand.Read(auth); err != nil {
+		t.Fatal(err)
+	}
+
+	payload := []byte(`{"title":"3 reviews due"}`)
+	body, err := encryptWebPush(payload,
+		base64.RawURLEncoding.EncodeToString(uaPrivate.PublicKey().Bytes()),
+		base64.RawURLEncoding.EncodeToString(auth))
+	if err != nil {
+		t.Fatalf("encryptWebPush: %v", err)
+	}
+
+	if got := decryptWebPush(t, body, uaPrivate, auth); !bytes.Equal(got, payload) {
+		t.Fatalf("decrypted %q, want %q", got, payload)
+	}
+}
+
+func TestEncryptWebPushRejectsBadSubscriptionKeys(t *testing.T) {
+	if _, err := encryptWebPush([]byte("x"), "not-a-key", "c2VjcmV0"); err != ErrInvalidToken {
+		t.Fatalf("err = %v, want ErrInvalidToken", err)
+	}
+}
+
+func TestNewWebPushSenderChecksKeyPair(t *testing.T) {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	private := base64.RawURLEncoding.EncodeToString(key.Bytes())
+	public := base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes())
+
+	sender, err := NewWebPushSender(public, private, "mailto:admin@example.com")
+	if err != nil {
+		t.Fatalf("NewWebPushSender: %v", err)
+	}
+	if sender.PublicKey() != public {
+		t.Fatalf("PublicKey() = %q, want %q", sender.PublicKey(), public)
+	}
+
+	other, _ := ecdh.P256().GenerateKey(rand.Reader)
+	mismatched := base64.RawURLEncoding.EncodeToString(other.PublicKey().Bytes())
+	if _, err := NewWebPushSender(mismatched, private, "mailto:admin@example.com"); err == nil {
+		t.Fatal("expected an error for a public key that doesn't match the private key")
+	}
+}
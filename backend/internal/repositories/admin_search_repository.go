@@ -0,0 +1,97 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+)
+
+// AdminSearchRepository finds items, users, engineering blogs and feedback for admin search.
+// Every search matches case-insensitive substrings, and an ID when the query is a number.
+type AdminSearchRepository struct {
+	db *sql.DB
+}
+
+// NewAdminSearchRepository creates a new admin search repository
+func NewAdminSearchRepository(db *sql.DB) *AdminSearchRepository {
+	return &AdminSearchRepository{db: db}
+}
+
+// adminSearchQueries select type-tagged results, with the ILIKE pattern as $1, the query as an
+// ID (or 0) as $2 and the limit as $3. Exact ID matches come first, then the newest.
+var adminSearchQueries = map[models.AdminSearchType]string{
+	models.AdminSearchItem: `
+		SELECT id, title, category || ' / ' || subcategory, CASE WHEN published THEN '' ELSE 'draft' END, created_at
+		FROM items
+		WHERE title ILIKE $1 OR link ILIKE $1 OR subcategory ILIKE $1 OR id = $2
+		ORDER BY id = $2 DESC, created_at DESC, id DESC
+		LIMIT $3`,
+	models.AdminSearchUser: `
+		SELECT id, name, email,
+			CASE
+				WHEN purge_at IS NOT NULL THEN 'deleted'
+				WHEN archived_at IS NOT NULL THEN 'archived'
+				ELSE role
+			END,
+			created_at
+		FROM users
+		WHERE email ILIKE $1 OR name ILIKE $1 OR id = $2
+		ORDER BY id = $2 DESC, created_at DESC, id DESC
+		LIMIT $3`,
+	models.AdminSearchEngBlog: `
+		SELECT eb.id, eb.name, eb.link, CASE WHEN eb.archived_at IS NULL THEN 'active' ELSE 'archived' END, eb.created_at
+		FROM eng_blogs eb
+		WHERE eb.name ILIKE $1 OR eb.link ILIKE $1 OR eb.id = $2 OR EXISTS (
+			SELECT 1 FROM eng_blog_articles a WHERE a.blog_id = eb.id AND (a.title ILIKE $1 OR a.external_link ILIKE $1)
+		)
+		ORDER BY eb.id = $2 DESC, eb.created_at DESC, eb.id DESC
+		LIMIT $3`,
+	models.AdminSearchFeedback: `
+		SELECT f.id, CASE WHEN f.comment = '' THEN f.category ELSE f.comment END,
+			f.category || ' on ' || i.title, f.status, f.created_at
+		FROM item_feedback f
+		INNER JOIN items i ON i.id = f.item_id
+		LEFT JOIN users u ON u.id = f.user_id
+		WHERE f.comment ILIKE $1 OR f.review_note ILIKE $1 OR i.title ILIKE $1 OR u.email ILIKE $1 OR f.id = $2
+		ORDER BY f.id = $2 DESC, f.created_at DESC, f.id DESC
+		LIMIT $3`,
+}
+
+// Search returns up to limit entities of a type matching the query
+func (r *AdminSearchRepository) Search(searchType models.AdminSearchType, query string, limit int) ([]models.AdminSearchResult, error) {
+	sqlQuery, ok := adminSearchQueries[searchType]
+	if !ok {
+		return nil, apperr.Validation(fmt.Sprintf("unsupported search type: %s", searchType))
+	}
+
+	pattern := "%" + likeEscaper.Replace(query) + "%"
+	id, err := strconv.Atoi(strings.TrimPrefix(query, "#"))
+	if err != nil || id < 0 {
+		id = 0
+	}
+
+	rows, err := r.db.Query(sqlQuery, pattern, id, limit)
+	if err != nil {
+		return nil, apperr.Errorf("failed to search %s: %w", searchType, err)
+	}
+	defer rows.Close()
+
+	results := []models.AdminSearchResult{}
+	for rows.Next() {
+		result := models.AdminSearchResult{Type: searchType}
+		if err := rows.Scan(&result.ID, &result.Title, &result.Subtitle, &result.Status, &result.CreatedAt); err != nil {
+			return nil, apperr.Errorf("failed to scan %s search result: %w", searchType, err)
+		}
+		results = append(results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating %s search results: %w", searchType, err)
+	}
+
+	return results, nil
+}
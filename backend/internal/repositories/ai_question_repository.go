@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"database/sql"
+	"time"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/lib/pq"
+)
+
+// AIQuestionRepository handles database operations for stored AI-generated practice questions
+type AIQuestionRepository struct {
+	db *sql.DB
+}
+
+// NewAIQuestionRepository creates a new AI question repository
+func NewAIQuestionRepository(db *sql.DB) *AIQuestionRepository {
+	return &AIQuestionRepository{db: db}
+}
+
+// GetLatest returns the newest questions generated for an item and kind since a time with at
+// least minCount questions, or nil when there are none
+func (r *AIQuestionRepository) GetLatest(itemID int, kind models.AIQuestionKind, minCount int, since time.Time) (*models.GeneratedQuestions, error) {
+	query := `
+		SELECT id, item_id, kind, questions, provider, model, created_at
+		FROM ai_generated_questions
+		WHERE item_id = $1 AND kind = $2 AND cardinality(questions) >= $3 AND created_at >= $4
+		ORDER BY created_at DESC, id DESC
+		LIMIT 1`
+
+	var generated models.GeneratedQuestions
+	err := r.db.QueryRow(query, itemID, kind, minCount, since).Scan(
+		&generated.ID, &generated.ItemID, &generated.Kind, pq.Array(&generated.Questions),
+		&generated.Provider, &generated.Model, &generated.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, apperr.Errorf("failed to get generated questions: %w", err)
+	}
+
+	return &generated, nil
+}
+
+// CountForUserSince counts the generations a user asked the model for since a time
+func (r *AIQuestionRepository) CountForUserSince(userID int, since time.Time) (int, error) {
+	var count int
+	err := r.db.QueryRow(
+		"SELECT COUNT(*) FROM ai_generated_questions WHERE user_id = $1 AND created_at >= $2", userID, since,
+	).Scan(&count)
+	if err != nil {
+		return 0, apperr.Errorf("failed to count generated questions: %w", err)
+	}
+
+	return count, nil
+}
+
+// Create stores questions the model generated at a user's request, filling in their ID and time
+func (r *AIQuestionRepository) Create(userID int, generated *models.GeneratedQuestions, tokens int64) error {
+	query := `
+		INSERT INTO ai_generated_questions (item_id, user_id, kind, questions, provider, model, tokens)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at`
+
+	err := r.db.QueryRow(query, generated.ItemID, userID, generated.Kind, pq.Array(generated.Questions), generated.Provider, generated.Model, tokens).
+		Scan(&generated.ID, &generated.CreatedAt)
+	if err != nil {
+		return apperr.Errorf("failed to store generated questions: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package repositories
+
+import (
+	"database/sql"
+	"time"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+)
+
+// AIUsageRepository handles database operations for per-user monthly AI usage and AI settings
+type AIUsageRepository struct {
+	db *sql.DB
+}
+
+// NewAIUsageRepository creates a new AI usage repository
+func NewAIUsageRepository(db *sql.DB) *AIUsageRepository {
+	return &AIUsageRepository{db: db}
+}
+
+// ReserveCall counts a call against the user's usage for the period, but only while the user is
+// under both limits (0 means unlimited). It reports false, without counting the call, when the
+// budget is used up. The check and increment happen in one statement so concurrent calls can't
+// overshoot the call budget.
+func (r *AIUsageRepository) ReserveCall(userID int, periodStart time.Time, budget models.AIBudget) (bool, error) {
+	query := `
+		INSERT INTO ai_usage (user_id, period_start, calls)
+		VALUES ($1, $2, 1)
+		ON CONFLICT (user_id, period_start) DO UPDATE
+		SET calls = ai_usage.calls + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE ($3 = 0 OR ai_usage.calls < $3) AND ($4 = 0 OR ai_usage.tokens < $4)
+		RETURNING calls`
+
+	var calls int64
+	err := r.db.QueryRow(query, userID, periodStart, budget.MonthlyCalls, budget.MonthlyTokens).Scan(&calls)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, apperr.Errorf("failed to reserve AI call: %w", err)
+	}
+
+	return true, nil
+}
+
+// RecordTokens adds the tokens a reserved call consumed to the user's usage for the period
+func (r *AIUsageRepository) RecordTokens(userID int, periodStart time.Time, feature string, tokens int64) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return apperr.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		UPDATE ai_usage
+		SET tokens = tokens + $3, updated_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1 AND period_start = $2`,
+		userID, periodStart, tokens)
+	if err != nil {
+		return apperr.Errorf("failed to record AI usage: %w", err)
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO ai_usage_features (user_id, period_start, feature, calls, tokens)
+		VALUES ($1, $2, $3, 1, $4)
+		ON CONFLICT (user_id, period_start, feature) DO UPDATE
+		SET calls = ai_usage_features.calls + 1, tokens = ai_usage_features.tokens + EXCLUDED.tokens`,
+		userID, periodStart, feature, tokens)
+	if err != nil {
+		return apperr.Errorf("failed to record AI feature usage: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return apperr.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetUsage returns the user's total calls and tokens for the period with a per-feature breakdown
+func (r *AIUsageRepository) GetUsage(userID int, periodStart time.Time) (int64, int64, []*models.AIFeatureUsage, error) {
+	var calls, tokens int64
+	err := r.db.QueryRow("SELECT calls, tokens FROM ai_usage WHERE user_id = $1 AND period_start = $2", userID, periodStart).
+		Scan(&calls, &tokens)
+	if err != nil && err != sql.ErrNoRows {
+		return 0, 0, nil, apperr.Errorf("failed to get AI usage: %w", err)
+	}
+
+	rows, err := r.db.Query(`
+		SELECT feature, calls, tokens
+		FROM ai_usage_features
+		WHERE user_id = $1 AND period_start = $2
+		ORDER BY tokens DESC, feature`, userID, periodStart)
+	if err != nil {
+		return 0, 0, nil, apperr.Errorf("failed to get AI feature usage: %w", err)
+	}
+	defer rows.Close()
+
+	features := []*models.AIFeatureUsage{}
+	for rows.Next() {
+		usage := &models.AIFeatureUsage{}
+		if err := rows.Scan(&usage.Feature, &usage.Calls, &usage.Tokens); err != nil {
+			return 0, 0, nil, apperr.Errorf("failed to scan AI feature usage: %w", err)
+		}
+		features = append(features, usage)
+	}
+
+	if err := rows.Err(); err != nil {
+		return 0, 0, nil, apperr.Errorf("error iterating AI feature usage: %w", err)
+	}
+
+	return calls, tokens, features, nil
+}
+
+// GetSettings returns the user's AI settings, with nothing opted into when they've saved none
+func (r *AIUsageRepository) GetSettings(userID int) (*models.AISettings, error) {
+	settings := &models.AISettings{}
+	err := r.db.QueryRow("SELECT notes_opt_in, updated_at FROM user_ai_settings WHERE user_id = $1", userID).
+		Scan(&settings.NotesOptIn, &settings.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return &models.AISettings{}, nil
+	}
+	if err != nil {
+		return nil, apperr.Errorf("failed to get AI settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// SaveSettings creates or replaces the user's AI settings
+func (r *AIUsageRepository) SaveSettings(userID int, settings *models.AISettings) error {
+	query := `
+		INSERT INTO user_ai_settings (user_id, notes_opt_in, updated_at)
+		VALUES ($1, $2, CURRENT_TIMESTAMP)
+		ON CONFLICT (user_id) DO UPDATE SET
+			notes_opt_in = EXCLUDED.notes_opt_in,
+			updated_at = EXCLUDED.updated_at
+		RETURNING updated_at`
+
+	if err := r.db.QueryRow(query, userID, settings.NotesOptIn).Scan(&settings.UpdatedAt); err != nil {
+		return apperr.Errorf("failed to save AI settings: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,143 @@
+package repositories
+
+import (
+	"database/sql"
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+	"time"
+)
+
+// APIKeyRepository handles database operations for personal API keys
+type APIKeyRepository struct {
+	db *sql.DB
+}
+
+// NewAPIKeyRepository creates a new APIKeyRepository
+func NewAPIKeyRepository(db *sql.DB) *APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+// Create records a new API key
+func (r *APIKeyRepository) Create(key *models.APIKey) error {
+	query := `
+		INSERT INTO user_api_keys (user_id, name, key_prefix, key_hash)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at`
+
+	err := r.db.QueryRow(query, key.UserID, key.Name, key.KeyPrefix, key.KeyHash).Scan(&key.ID, &key.CreatedAt)
+	if err != nil {
+		return apperr.Errorf("failed to create API key: %w", err)
+	}
+
+	return nil
+}
+
+// GetForUser lists a user's API keys, newest first, including revoked ones
+func (r *APIKeyRepository) GetForUser(userID int) ([]*models.APIKey, error) {
+	query := `
+		SELECT id, user_id, name, key_prefix, key_hash, created_at, last_used_at, revoked_at
+		FROM user_api_keys
+		WHERE user_id = $1
+		ORDER BY created_at DESC`
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get API keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []*models.APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating API keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// CountActive counts a user's unrevoked API keys
+func (r *APIKeyRepository) CountActive(userID int) (int, error) {
+	var count int
+	err := r.db.QueryRow(
+		"SELECT COUNT(*) FROM user_api_keys WHERE user_id = $1 AND revoked_at IS NULL", userID,
+	).Scan(&count)
+	if err != nil {
+		return 0, apperr.Errorf("failed to count API keys: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetActiveByKeyHash retrieves an unrevoked API key by its hash
+func (r *APIKeyRepository) GetActiveByKeyHash(keyHash string) (*models.APIKey, error) {
+	query := `
+		SELECT id, user_id, name, key_prefix, key_hash, created_at, last_used_at, revoked_at
+		FROM user_api_keys
+		WHERE key_hash = $1 AND revoked_at IS NULL`
+
+	key, err := scanAPIKey(r.db.QueryRow(query, keyHash))
+	if err == sql.ErrNoRows {
+		return nil, apperr.NotFound("API key not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// Touch records that an API key was just used
+func (r *APIKeyRepository) Touch(id int) error {
+	_, err := r.db.Exec("UPDATE user_api_keys SET last_used_at = $1 WHERE id = $2", time.Now(), id)
+	if err != nil {
+		return apperr.Errorf("failed to update API key usage: %w", err)
+	}
+
+	return nil
+}
+
+// Revoke revokes one of a user's API keys
+func (r *APIKeyRepository) Revoke(userID, id int) error {
+	result, err := r.db.Exec(
+		"UPDATE user_api_keys SET revoked_at = $1 WHERE id = $2 AND user_id = $3 AND revoked_at IS NULL",
+		time.Now(), id, userID,
+	)
+	if err != nil {
+		return apperr.Errorf("failed to revoke API key: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperr.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperr.NotFound("API key not found")
+	}
+
+	return nil
+}
+
+// scanAPIKey scans a single API key row
+func scanAPIKey(row rowScanner) (*models.APIKey, error) {
+	var key models.APIKey
+	err := row.Scan(
+		&key.ID, &key.UserID, &key.Name, &key.KeyPrefix, &key.KeyHash,
+		&key.CreatedAt, &key.LastUsedAt, &key.RevokedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, err
+	}
+	if err != nil {
+		return nil, apperr.Errorf("failed to scan API key: %w", err)
+	}
+
+	return &key, nil
+}
@@ -0,0 +1,154 @@
+package repositories
+
+import (
+	"database/sql"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+)
+
+// AttachmentRepository handles database operations for item attachments and the per-user keys
+// that encrypt secret attachments
+type AttachmentRepository struct {
+	db *sql.DB
+}
+
+// NewAttachmentRepository creates a new attachment repository
+func NewAttachmentRepository(db *sql.DB) *AttachmentRepository {
+	return &AttachmentRepository{db: db}
+}
+
+// Create records an attachment: the metadata of an uploaded file or an encrypted secret
+func (r *AttachmentRepository) Create(attachment *models.FileAttachment) error {
+	query := `
+		INSERT INTO item_attachments (item_id, user_id, kind, file_name, content_type, size_bytes, storage_key, ciphertext)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), $8)
+		RETURNING id, created_at`
+
+	if attachment.Kind == "" {
+		attachment.Kind = models.AttachmentKindFile
+	}
+
+	err := r.db.QueryRow(
+		query,
+		attachment.ItemID,
+		attachment.UserID,
+		attachment.Kind,
+		attachment.FileName,
+		attachment.ContentType,
+		attachment.SizeBytes,
+		attachment.StorageKey,
+		attachment.Ciphertext,
+	).Scan(&attachment.ID, &attachment.CreatedAt)
+
+	if err != nil {
+		return apperr.Errorf("failed to create attachment: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves an attachment owned by a user
+func (r *AttachmentRepository) GetByID(userID, attachmentID int) (*models.FileAttachment, error) {
+	query := `
+		SELECT id, item_id, user_id, kind, file_name, content_type, size_bytes, COALESCE(storage_key, ''), ciphertext, created_at
+		FROM item_attachments
+		WHERE id = $1 AND user_id = $2`
+
+	var attachment models.FileAttachment
+	err := r.db.QueryRow(query, attachmentID, userID).Scan(
+		&attachment.ID, &attachment.ItemID, &attachment.UserID, &attachment.Kind, &attachment.FileName,
+		&attachment.ContentType, &attachment.SizeBytes, &attachment.StorageKey, &attachment.Ciphertext, &attachment.CreatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, apperr.NotFound("attachment not found")
+	}
+	if err != nil {
+		return nil, apperr.Errorf("failed to get attachment: %w", err)
+	}
+
+	return &attachment, nil
+}
+
+// GetByItemForUser retrieves all attachments a user added to an item
+func (r *AttachmentRepository) GetByItemForUser(userID, itemID int) ([]*models.FileAttachment, error) {
+	query := `
+		SELECT id, item_id, user_id, kind, file_name, content_type, size_bytes, COALESCE(storage_key, ''), ciphertext, created_at
+		FROM item_attachments
+		WHERE item_id = $1 AND user_id = $2
+		ORDER BY created_at DESC`
+
+	rows, err := r.db.Query(query, itemID, userID)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get attachments: %w", err)
+	}
+	defer rows.Close()
+
+	attachments := []*models.FileAttachment{}
+	for rows.Next() {
+		var attachment models.FileAttachment
+		err := rows.Scan(
+			&attachment.ID, &attachment.ItemID, &attachment.UserID, &attachment.Kind, &attachment.FileName,
+			&attachment.ContentType, &attachment.SizeBytes, &attachment.StorageKey, &attachment.Ciphertext, &attachment.CreatedAt,
+		)
+		if err != nil {
+			return nil, apperr.Errorf("failed to scan attachment: %w", err)
+		}
+		attachments = append(attachments, &attachment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating attachments: %w", err)
+	}
+
+	return attachments, nil
+}
+
+// Delete removes an attachment record owned by a user
+func (r *AttachmentRepository) Delete(userID, attachmentID int) error {
+	result, err := r.db.Exec("DELETE FROM item_attachments WHERE id = $1 AND user_id = $2", attachmentID, userID)
+	if err != nil {
+		return apperr.Errorf("failed to delete attachment: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperr.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperr.NotFound("attachment not found")
+	}
+
+	return nil
+}
+
+// GetUserKey returns the user's wrapped data key, or nil if they don't have one yet
+func (r *AttachmentRepository) GetUserKey(userID int) ([]byte, error) {
+	var wrappedKey []byte
+	err := r.db.QueryRow("SELECT wrapped_key FROM user_encryption_keys WHERE user_id = $1", userID).Scan(&wrappedKey)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, apperr.Errorf("failed to get encryption key: %w", err)
+	}
+
+	return wrappedKey, nil
+}
+
+// CreateUserKey stores a wrapped data key for the user unless one already exists, and returns
+// whichever key is stored so concurrent first uses agree on a single key
+func (r *AttachmentRepository) CreateUserKey(userID int, wrappedKey []byte) ([]byte, error) {
+	query := `
+		INSERT INTO user_encryption_keys (user_id, wrapped_key)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO NOTHING`
+
+	if _, err := r.db.Exec(query, userID, wrappedKey); err != nil {
+		return nil, apperr.Errorf("failed to create encryption key: %w", err)
+	}
+
+	return r.GetUserKey(userID)
+}
@@ -0,0 +1,290 @@
+package repositories
+
+import (
+	"database/sql"
+	"time"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/lib/pq"
+)
+
+// BehavioralRepository handles database operations for the behavioral question bank and users'
+// STAR answer drafts
+type BehavioralRepository struct {
+	db *sql.DB
+}
+
+// NewBehavioralRepository creates a new behavioral repository
+func NewBehavioralRepository(db *sql.DB) *BehavioralRepository {
+	return &BehavioralRepository{db: db}
+}
+
+// behavioralQuestionColumns selects a question and, through a LEFT JOIN on behavioral_answers a,
+// the user's answer to it
+const behavioralQuestionColumns = `q.id, q.question, q.competencies, q.created_at, q.updated_at,
+	a.id, a.situation, a.task, a.action, a.result, a.practice_count, a.last_practiced_at, a.created_at, a.updated_at`
+
+// scanBehavioralQuestion scans a row selected with behavioralQuestionColumns
+func scanBehavioralQuestion(scanner interface{ Scan(...interface{}) error }, userID int) (*models.BehavioralQuestion, error) {
+	var (
+		question                    models.BehavioralQuestion
+		competencies                []string
+		answerID, practiceCount     sql.NullInt64
+		situation, task             sql.NullString
+		action, result              sql.NullString
+		lastPracticedAt             *time.Time
+		answerCreated, answerUpdate sql.NullTime
+	)
+
+	err := scanner.Scan(
+		&question.ID, &question.Question, pq.Array(&competencies), &question.CreatedAt, &question.UpdatedAt,
+		&answerID, &situation, &task, &action, &result, &practiceCount, &lastPracticedAt, &answerCreated, &answerUpdate,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	question.Competencies = make([]models.Competency, 0, len(competencies))
+	for _, competency := range competencies {
+		question.Competencies = append(question.Competencies, models.Competency(competency))
+	}
+
+	if answerID.Valid {
+		question.Answer = &models.BehavioralAnswer{
+			ID:              int(answerID.Int64),
+			UserID:          userID,
+			QuestionID:      question.ID,
+			Situation:       situation.String,
+			Task:            task.String,
+			Action:          action.String,
+			Result:          result.String,
+			PracticeCount:   int(practiceCount.Int64),
+			LastPracticedAt: lastPracticedAt,
+			CreatedAt:       answerCreated.Time,
+			UpdatedAt:       answerUpdate.Time,
+		}
+	}
+
+	return &question, nil
+}
+
+// competencyArray converts competencies for a TEXT[] parameter
+func competencyArray(competencies []models.Competency) interface{} {
+	values := make([]string, 0, len(competencies))
+	for _, competency := range competencies {
+		values = append(values, string(competency))
+	}
+	return pq.Array(values)
+}
+
+// GetQuestions returns a page of the question bank, oldest first, with the user's answers, and the
+// total number of matching questions
+func (r *BehavioralRepository) GetQuestions(userID int, filter *models.BehavioralQuestionFilter) ([]*models.BehavioralQuestion, int, error) {
+	var competency *string
+	if filter.Competency != nil {
+		value := string(*filter.Competency)
+		competency = &value
+	}
+
+	conds := `
+		WHERE ($2::TEXT IS NULL OR $2::TEXT = ANY(q.competencies))
+		  AND (NOT $3 OR a.id IS NOT NULL)`
+
+	var total int
+	countQuery := `
+		SELECT COUNT(*)
+		FROM behavioral_questions q
+		LEFT JOIN behavioral_answers a ON a.question_id = q.id AND a.user_id = $1` + conds
+	if err := r.db.QueryRow(countQuery, userID, competency, filter.AnsweredOnly).Scan(&total); err != nil {
+		return nil, 0, apperr.Errorf("failed to count behavioral questions: %w", err)
+	}
+
+	query := `
+		SELECT ` + behavioralQuestionColumns + `
+		FROM behavioral_questions q
+		LEFT JOIN behavioral_answers a ON a.question_id = q.id AND a.user_id = $1` + conds + `
+		ORDER BY q.id ASC
+		LIMIT $4 OFFSET $5`
+
+	questions, err := r.queryQuestions(userID, query, userID, competency, filter.AnsweredOnly, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return questions, total, nil
+}
+
+// GetPracticeQuestions returns up to count random questions, the ones the user has practiced
+// least coming first
+func (r *BehavioralRepository) GetPracticeQuestions(userID int, competency *models.Competency, count int) ([]*models.BehavioralQuestion, error) {
+	var competencyValue *string
+	if competency != nil {
+		value := string(*competency)
+		competencyValue = &value
+	}
+
+	query := `
+		SELECT ` + behavioralQuestionColumns + `
+		FROM behavioral_questions q
+		LEFT JOIN behavioral_answers a ON a.question_id = q.id AND a.user_id = $1
+		WHERE ($2::TEXT IS NULL OR $2::TEXT = ANY(q.competencies))
+		ORDER BY COALESCE(a.practice_count, 0) ASC, RANDOM()
+		LIMIT $3`
+
+	return r.queryQuestions(userID, query, userID, competencyValue, count)
+}
+
+// queryQuestions runs a query selecting behavioralQuestionColumns
+func (r *BehavioralRepository) queryQuestions(userID int, query string, args ...interface{}) ([]*models.BehavioralQuestion, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get behavioral questions: %w", err)
+	}
+	defer rows.Close()
+
+	questions := []*models.BehavioralQuestion{}
+	for rows.Next() {
+		question, err := scanBehavioralQuestion(rows, userID)
+		if err != nil {
+			return nil, apperr.Errorf("failed to scan behavioral question: %w", err)
+		}
+		questions = append(questions, question)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating behavioral questions: %w", err)
+	}
+
+	return questions, nil
+}
+
+// GetQuestion retrieves a question with the user's answer
+func (r *BehavioralRepository) GetQuestion(userID, questionID int) (*models.BehavioralQuestion, error) {
+	query := `
+		SELECT ` + behavioralQuestionColumns + `
+		FROM behavioral_questions q
+		LEFT JOIN behavioral_answers a ON a.question_id = q.id AND a.user_id = $1
+		WHERE q.id = $2`
+
+	question, err := scanBehavioralQuestion(r.db.QueryRow(query, userID, questionID), userID)
+	if err == sql.ErrNoRows {
+		return nil, apperr.NotFound("behavioral question not found")
+	}
+	if err != nil {
+		return nil, apperr.Errorf("failed to get behavioral question: %w", err)
+	}
+
+	return question, nil
+}
+
+// CreateQuestion adds a question to the bank
+func (r *BehavioralRepository) CreateQuestion(question string, competencies []models.Competency) (*models.BehavioralQuestion, error) {
+	query := `
+		INSERT INTO behavioral_questions (question, competencies)
+		VALUES ($1, $2)
+		RETURNING id, created_at, updated_at`
+
+	created := &models.BehavioralQuestion{Question: question, Competencies: competencies}
+	err := r.db.QueryRow(query, question, competencyArray(competencies)).Scan(&created.ID, &created.CreatedAt, &created.UpdatedAt)
+	if err != nil {
+		return nil, apperr.Errorf("failed to create behavioral question: %w", err)
+	}
+
+	return created, nil
+}
+
+// UpdateQuestion replaces a question's text and competencies
+func (r *BehavioralRepository) UpdateQuestion(questionID int, question string, competencies []models.Competency) error {
+	query := `UPDATE behavioral_questions SET question = $1, competencies = $2, updated_at = $3 WHERE id = $4`
+
+	result, err := r.db.Exec(query, question, competencyArray(competencies), time.Now(), questionID)
+	if err != nil {
+		return apperr.Errorf("failed to update behavioral question: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperr.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperr.NotFound("behavioral question not found")
+	}
+
+	return nil
+}
+
+// DeleteQuestion removes a question and every user's answer to it
+func (r *BehavioralRepository) DeleteQuestion(questionID int) error {
+	result, err := r.db.Exec("DELETE FROM behavioral_questions WHERE id = $1", questionID)
+	if err != nil {
+		return apperr.Errorf("failed to delete behavioral question: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperr.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperr.NotFound("behavioral question not found")
+	}
+
+	return nil
+}
+
+// SaveAnswer creates or replaces the user's STAR draft for a question, keeping its practice history
+func (r *BehavioralRepository) SaveAnswer(userID, questionID int, req *models.SaveBehavioralAnswerRequest) error {
+	query := `
+		INSERT INTO behavioral_answers (user_id, question_id, situation, task, action, result)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id, question_id) DO UPDATE SET
+			situation = EXCLUDED.situation,
+			task = EXCLUDED.task,
+			action = EXCLUDED.action,
+			result = EXCLUDED.result,
+			updated_at = CURRENT_TIMESTAMP`
+
+	if _, err := r.db.Exec(query, userID, questionID, req.Situation, req.Task, req.Action, req.Result); err != nil {
+		return apperr.Errorf("failed to save behavioral answer: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteAnswer removes the user's draft and practice history for a question
+func (r *BehavioralRepository) DeleteAnswer(userID, questionID int) error {
+	result, err := r.db.Exec("DELETE FROM behavioral_answers WHERE user_id = $1 AND question_id = $2", userID, questionID)
+	if err != nil {
+		return apperr.Errorf("failed to delete behavioral answer: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperr.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperr.NotFound("behavioral answer not found")
+	}
+
+	return nil
+}
+
+// RecordPractice counts a practice run of a question, starting an empty draft if the user has none
+func (r *BehavioralRepository) RecordPractice(userID, questionID int, at time.Time) error {
+	query := `
+		INSERT INTO behavioral_answers (user_id, question_id, practice_count, last_practiced_at)
+		VALUES ($1, $2, 1, $3)
+		ON CONFLICT (user_id, question_id) DO UPDATE SET
+			practice_count = behavioral_answers.practice_count + 1,
+			last_practiced_at = EXCLUDED.last_practiced_at`
+
+	if _, err := r.db.Exec(query, userID, questionID, at); err != nil {
+		return apperr.Errorf("failed to record behavioral practice: %w", err)
+	}
+
+	return nil
+}
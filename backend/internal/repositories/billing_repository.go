@@ -0,0 +1,256 @@
+package repositories
+
+import (
+	"database/sql"
+	"time"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+)
+
+// BillingRepository handles database operations for subscriptions and processed Stripe events
+type BillingRepository struct {
+	db *sql.DB
+}
+
+// NewBillingRepository creates a new billing repository
+func NewBillingRepository(db *sql.DB) *BillingRepository {
+	return &BillingRepository{db: db}
+}
+
+// GetSubscription returns the user's subscription, or a free one if they never subscribed
+func (r *BillingRepository) GetSubscription(userID int) (*models.Subscription, error) {
+	query := `SELECT ` + subscriptionColumns + ` FROM subscriptions WHERE user_id = $1`
+
+	sub, err := scanSubscription(r.db.QueryRow(query, userID))
+	if err == sql.ErrNoRows {
+		return &models.Subscription{UserID: userID, Plan: models.PlanFree, Status: models.SubscriptionStatusNone}, nil
+	}
+	if err != nil {
+		return nil, apperr.Errorf("failed to get subscription: %w", err)
+	}
+
+	return sub, nil
+}
+
+// SetCustomerID links the user to their Stripe customer
+func (r *BillingRepository) SetCustomerID(userID int, customerID string) error {
+	query := `
+		INSERT INTO subscriptions (user_id, stripe_customer_id)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET stripe_customer_id = EXCLUDED.stripe_customer_id, updated_at = CURRENT_TIMESTAMP`
+
+	if _, err := r.db.Exec(query, userID, customerID); err != nil {
+		return apperr.Errorf("failed to save Stripe customer: %w", err)
+	}
+
+	return nil
+}
+
+// GetUserIDByCustomerID finds the user a Stripe customer belongs to
+func (r *BillingRepository) GetUserIDByCustomerID(customerID string) (int, error) {
+	var userID int
+	err := r.db.QueryRow("SELECT user_id FROM subscriptions WHERE stripe_customer_id = $1", customerID).Scan(&userID)
+	if err == sql.ErrNoRows {
+		return 0, apperr.NotFound("subscription not found")
+	}
+	if err != nil {
+		return 0, apperr.Errorf("failed to get subscription: %w", err)
+	}
+
+	return userID, nil
+}
+
+// UpdateFromStripe stores the state of the user's Stripe subscription, noting when it first
+// became past due
+func (r *BillingRepository) UpdateFromStripe(userID int, plan models.Plan, status, subscriptionID string, periodEnd *time.Time, cancelAtPeriodEnd bool) error {
+	query := `
+		UPDATE subscriptions
+		SET plan = $2, status = $3, stripe_subscription_id = $4, current_period_end = $5, cancel_at_period_end = $6,
+			past_due_since = CASE WHEN $3 = 'past_due' THEN COALESCE(past_due_since, CURRENT_TIMESTAMP) END,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1`
+
+	if _, err := r.db.Exec(query, userID, plan, status, subscriptionID, periodEnd, cancelAtPeriodEnd); err != nil {
+		return apperr.Errorf("failed to update subscription: %w", err)
+	}
+
+	return nil
+}
+
+// SetComped grants or revokes the pro plan outside of Stripe. Any Stripe subscription is left as is.
+func (r *BillingRepository) SetComped(userID int, comped bool) error {
+	query := `
+		INSERT INTO subscriptions (user_id, comped)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET comped = EXCLUDED.comped, updated_at = CURRENT_TIMESTAMP`
+
+	if _, err := r.db.Exec(query, userID, comped); err != nil {
+		return apperr.Errorf("failed to set plan: %w", err)
+	}
+
+	return nil
+}
+
+// StartTrial puts the user on the pro plan until endsAt. It returns false when the user has
+// already had a trial, so each user gets at most one.
+func (r *BillingRepository) StartTrial(userID int, endsAt time.Time) (bool, error) {
+	query := `
+		INSERT INTO subscriptions (user_id, plan, status, trial_started_at, trial_ends_at, downgraded_at)
+		VALUES ($1, 'pro', 'trialing', CURRENT_TIMESTAMP, $2, NULL)
+		ON CONFLICT (user_id) DO UPDATE
+		SET plan = 'pro', status = 'trialing', trial_started_at = CURRENT_TIMESTAMP, trial_ends_at = EXCLUDED.trial_ends_at,
+			downgraded_at = NULL, updated_at = CURRENT_TIMESTAMP
+		WHERE subscriptions.trial_started_at IS NULL`
+
+	result, err := r.db.Exec(query, userID, endsAt)
+	if err != nil {
+		return false, apperr.Errorf("failed to start trial: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, apperr.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// GetTrialsEndingBefore returns the app-granted trials that end before the given time
+func (r *BillingRepository) GetTrialsEndingBefore(before time.Time) ([]*models.Subscription, error) {
+	query := `SELECT ` + subscriptionColumns + `
+		FROM subscriptions
+		WHERE status = 'trialing' AND COALESCE(stripe_subscription_id, '') = ''
+		AND trial_ends_at IS NOT NULL AND trial_ends_at < $1
+		ORDER BY trial_ends_at`
+
+	return r.querySubscriptions(query, before)
+}
+
+// ExpireTrial ends the user's app-granted trial if it's over, recording the downgrade.
+// It returns false when there was no expired trial to end.
+func (r *BillingRepository) ExpireTrial(userID int, now time.Time) (bool, error) {
+	query := `
+		UPDATE subscriptions
+		SET status = 'expired', downgraded_at = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1 AND status = 'trialing' AND COALESCE(stripe_subscription_id, '') = ''
+		AND trial_ends_at <= $2`
+
+	result, err := r.db.Exec(query, userID, now)
+	if err != nil {
+		return false, apperr.Errorf("failed to expire trial: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, apperr.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// GetPastDue returns the subscriptions whose payments are failing
+func (r *BillingRepository) GetPastDue() ([]*models.Subscription, error) {
+	query := `SELECT ` + subscriptionColumns + `
+		FROM subscriptions
+		WHERE status = 'past_due' AND past_due_since IS NOT NULL
+		ORDER BY past_due_since`
+
+	return r.querySubscriptions(query)
+}
+
+// SetDowngradedAt records when the user lost the pro plan, or clears it with nil once they're back on it
+func (r *BillingRepository) SetDowngradedAt(userID int, downgradedAt *time.Time) error {
+	query := `
+		UPDATE subscriptions
+		SET downgraded_at = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1`
+
+	if _, err := r.db.Exec(query, userID, downgradedAt); err != nil {
+		return apperr.Errorf("failed to record downgrade: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimNotice records that a billing notice about the given reference (a trial, an invoice)
+// is being sent. It returns false when one was already recorded, so each notice goes out once
+// even with several server instances running the jobs.
+func (r *BillingRepository) ClaimNotice(userID int, kind models.BillingNoticeKind, reference string) (bool, error) {
+	query := `
+		INSERT INTO billing_notices (user_id, kind, reference)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, kind, reference) DO NOTHING`
+
+	result, err := r.db.Exec(query, userID, kind, reference)
+	if err != nil {
+		return false, apperr.Errorf("failed to record billing notice: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, apperr.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// EventProcessed reports whether a Stripe event has already been handled
+func (r *BillingRepository) EventProcessed(eventID string) (bool, error) {
+	var exists bool
+	if err := r.db.QueryRow("SELECT EXISTS(SELECT 1 FROM stripe_events WHERE id = $1)", eventID).Scan(&exists); err != nil {
+		return false, apperr.Errorf("failed to check Stripe event: %w", err)
+	}
+
+	return exists, nil
+}
+
+// MarkEventProcessed records that a Stripe event was handled so redeliveries are ignored
+func (r *BillingRepository) MarkEventProcessed(eventID, eventType string) error {
+	_, err := r.db.Exec("INSERT INTO stripe_events (id, type) VALUES ($1, $2) ON CONFLICT (id) DO NOTHING", eventID, eventType)
+	if err != nil {
+		return apperr.Errorf("failed to record Stripe event: %w", err)
+	}
+
+	return nil
+}
+
+// subscriptionColumns are the columns scanSubscription expects, in order
+const subscriptionColumns = `user_id, plan, status, COALESCE(stripe_customer_id, ''), COALESCE(stripe_subscription_id, ''),
+	current_period_end, cancel_at_period_end, comped, trial_started_at, trial_ends_at, downgraded_at, past_due_since, updated_at`
+
+// querySubscriptions runs a query selecting subscriptionColumns and scans every row
+func (r *BillingRepository) querySubscriptions(query string, args ...interface{}) ([]*models.Subscription, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	var subs []*models.Subscription
+	for rows.Next() {
+		sub, err := scanSubscription(rows)
+		if err != nil {
+			return nil, apperr.Errorf("failed to scan subscription: %w", err)
+		}
+		subs = append(subs, sub)
+	}
+
+	return subs, rows.Err()
+}
+
+// scanSubscription scans a row selected with subscriptionColumns
+func scanSubscription(scanner interface{ Scan(...interface{}) error }) (*models.Subscription, error) {
+	sub := &models.Subscription{}
+	err := scanner.Scan(
+		&sub.UserID, &sub.Plan, &sub.Status, &sub.StripeCustomerID, &sub.StripeSubscriptionID,
+		&sub.CurrentPeriodEnd, &sub.CancelAtPeriodEnd, &sub.Comped, &sub.TrialStartedAt, &sub.TrialEndsAt,
+		&sub.DowngradedAt, &sub.PastDueSince, &sub.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"database/sql"
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+)
+
+// CalendarRepository handles database operations for calendar feed tokens
+type CalendarRepository struct {
+	db *sql.DB
+}
+
+// NewCalendarRepository creates a new CalendarRepository
+func NewCalendarRepository(db *sql.DB) *CalendarRepository {
+	return &CalendarRepository{db: db}
+}
+
+// SaveToken stores the hash of the user's feed token, replacing (and so revoking) any previous one
+func (r *CalendarRepository) SaveToken(userID int, tokenHash string) (*models.CalendarFeed, error) {
+	query := `
+		INSERT INTO calendar_feeds (user_id, token_hash, created_at)
+		VALUES ($1, $2, CURRENT_TIMESTAMP)
+		ON CONFLICT (user_id)
+		DO UPDATE SET token_hash = EXCLUDED.token_hash, created_at = CURRENT_TIMESTAMP, last_accessed_at = NULL
+		RETURNING created_at`
+
+	feed := &models.CalendarFeed{Enabled: true}
+	if err := r.db.QueryRow(query, userID, tokenHash).Scan(&feed.CreatedAt); err != nil {
+		return nil, apperr.Errorf("failed to save calendar feed: %w", err)
+	}
+
+	return feed, nil
+}
+
+// GetFeed returns the state of the user's feed; a user without one gets a disabled feed
+func (r *CalendarRepository) GetFeed(userID int) (*models.CalendarFeed, error) {
+	query := `SELECT created_at, last_accessed_at FROM calendar_feeds WHERE user_id = $1`
+
+	feed := &models.CalendarFeed{}
+	err := r.db.QueryRow(query, userID).Scan(&feed.CreatedAt, &feed.LastAccessedAt)
+	if err == sql.ErrNoRows {
+		return feed, nil
+	}
+	if err != nil {
+		return nil, apperr.Errorf("failed to get calendar feed: %w", err)
+	}
+
+	feed.Enabled = true
+	return feed, nil
+}
+
+// GetUserIDByToken resolves a feed token hash to its owner and records the access
+func (r *CalendarRepository) GetUserIDByToken(tokenHash string) (int, error) {
+	query := `
+		UPDATE calendar_feeds
+		SET last_accessed_at = CURRENT_TIMESTAMP
+		WHERE token_hash = $1
+		RETURNING user_id`
+
+	var userID int
+	err := r.db.QueryRow(query, tokenHash).Scan(&userID)
+	if err == sql.ErrNoRows {
+		return 0, apperr.NotFound("calendar feed not found")
+	}
+	if err != nil {
+		return 0, apperr.Errorf("failed to get calendar feed: %w", err)
+	}
+
+	return userID, nil
+}
+
+// DeleteToken revokes the user's feed
+func (r *CalendarRepository) DeleteToken(userID int) error {
+	result, err := r.db.Exec("DELETE FROM calendar_feeds WHERE user_id = $1", userID)
+	if err != nil {
+		return apperr.Errorf("failed to delete calendar feed: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperr.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperr.NotFound("calendar feed not found")
+	}
+
+	return nil
+}
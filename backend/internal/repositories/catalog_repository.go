@@ -0,0 +1,413 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"interview-prep-app/internal/catalog"
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+)
+
+// CatalogRepository reads and writes the global content catalog as a whole: items with their
+// hints and companies, and engineering blogs with their articles
+type CatalogRepository struct {
+	db *sql.DB
+}
+
+// NewCatalogRepository creates a new catalog repository
+func NewCatalogRepository(db *sql.DB) *CatalogRepository {
+	return &CatalogRepository{db: db}
+}
+
+// Export reads the catalog into a snapshot. Categories are left for the caller to fill in.
+func (r *CatalogRepository) Export() (*models.CatalogSnapshot, error) {
+	snapshot := &models.CatalogSnapshot{
+		Version:   models.CatalogSnapshotVersion,
+		Companies: []models.CatalogCompany{},
+		Items:     []models.CatalogItem{},
+		EngBlogs:  []models.CatalogEngBlog{},
+	}
+
+	rows, err := r.db.Query("SELECT slug, name FROM companies ORDER BY slug")
+	if err != nil {
+		return nil, apperr.Errorf("failed to export companies: %w", err)
+	}
+	for rows.Next() {
+		var company models.CatalogCompany
+		if err := rows.Scan(&company.Slug, &company.Name); err != nil {
+			rows.Close()
+			return nil, apperr.Errorf("failed to scan company: %w", err)
+		}
+		snapshot.Companies = append(snapshot.Companies, company)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating companies: %w", err)
+	}
+
+	items, err := r.exportItems()
+	if err != nil {
+		return nil, err
+	}
+	snapshot.Items = items
+
+	blogs, err := r.exportEngBlogs()
+	if err != nil {
+		return nil, err
+	}
+	snapshot.EngBlogs = blogs
+
+	return snapshot, nil
+}
+
+// exportItems reads every item with its hints in ladder order and its company slugs
+func (r *CatalogRepository) exportItems() ([]models.CatalogItem, error) {
+	rows, err := r.db.Query("SELECT id, title, link, category, subcategory, attachments FROM items ORDER BY id")
+	if err != nil {
+		return nil, apperr.Errorf("failed to export items: %w", err)
+	}
+
+	items := []models.CatalogItem{}
+	index := map[int]int{}
+	for rows.Next() {
+		var id int
+		var item models.CatalogItem
+		if err := rows.Scan(&id, &item.Title, &item.Link, &item.Category, &item.Subcategory, &item.Attachments); err != nil {
+			rows.Close()
+			return nil, apperr.Errorf("failed to scan item: %w", err)
+		}
+		index[id] = len(items)
+		items = append(items, item)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating items: %w", err)
+	}
+
+	rows, err = r.db.Query("SELECT item_id, content FROM item_hints ORDER BY item_id, position, id")
+	if err != nil {
+		return nil, apperr.Errorf("failed to export hints: %w", err)
+	}
+	for rows.Next() {
+		var itemID int
+		var content string
+		if err := rows.Scan(&itemID, &content); err != nil {
+			rows.Close()
+			return nil, apperr.Errorf("failed to scan hint: %w", err)
+		}
+		if i, ok := index[itemID]; ok {
+			items[i].Hints = append(items[i].Hints, content)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating hints: %w", err)
+	}
+
+	rows, err = r.db.Query(`
+		SELECT ic.item_id, c.slug
+		FROM item_companies ic
+		INNER JOIN companies c ON c.id = ic.company_id
+		ORDER BY ic.item_id, c.slug`)
+	if err != nil {
+		return nil, apperr.Errorf("failed to export item companies: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var itemID int
+		var slug string
+		if err := rows.Scan(&itemID, &slug); err != nil {
+			return nil, apperr.Errorf("failed to scan item company: %w", err)
+		}
+		if i, ok := index[itemID]; ok {
+			items[i].Companies = append(items[i].Companies, slug)
+		}
+	}
+
+	return items, rows.Err()
+}
+
+// exportEngBlogs reads every engineering blog with its articles
+func (r *CatalogRepository) exportEngBlogs() ([]models.CatalogEngBlog, error) {
+	rows, err := r.db.Query("SELECT id, name, link, order_idx FROM eng_blogs ORDER BY order_idx, id")
+	if err != nil {
+		return nil, apperr.Errorf("failed to export blogs: %w", err)
+	}
+
+	blogs := []models.CatalogEngBlog{}
+	index := map[int]int{}
+	for rows.Next() {
+		var id int
+		blog := models.CatalogEngBlog{Articles: []models.CatalogEngBlogArticle{}}
+		if err := rows.Scan(&id, &blog.Name, &blog.Link, &blog.OrderIdx); err != nil {
+			rows.Close()
+			return nil, apperr.Errorf("failed to scan blog: %w", err)
+		}
+		index[id] = len(blogs)
+		blogs = append(blogs, blog)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating blogs: %w", err)
+	}
+
+	rows, err = r.db.Query("SELECT blog_id, title, external_link, order_idx FROM eng_blog_articles ORDER BY blog_id, order_idx, id")
+	if err != nil {
+		return nil, apperr.Errorf("failed to export articles: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var blogID int
+		var article models.CatalogEngBlogArticle
+		if err := rows.Scan(&blogID, &article.Title, &article.ExternalLink, &article.OrderIdx); err != nil {
+			return nil, apperr.Errorf("failed to scan article: %w", err)
+		}
+		if i, ok := index[blogID]; ok {
+			blogs[i].Articles = append(blogs[i].Articles, article)
+		}
+	}
+
+	return blogs, rows.Err()
+}
+
+// Apply writes a snapshot into the catalog in one transaction, matching entries by natural key:
+// companies by slug, items and blogs by link, articles by link within their blog. Entries that
+// differ are updated and missing ones created; nothing is deleted, so applying the same
+// snapshot twice changes nothing the second time.
+func (r *CatalogRepository) Apply(snapshot *models.CatalogSnapshot) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return apperr.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	companyIDs, err := applyCatalogCompanies(tx, snapshot.Companies)
+	if err != nil {
+		return err
+	}
+
+	if err := applyCatalogItems(tx, snapshot.Items, companyIDs); err != nil {
+		return err
+	}
+
+	if err := applyCatalogEngBlogs(tx, snapshot.EngBlogs); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return apperr.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// applyCatalogCompanies upserts companies and returns the ID of every company by slug
+func applyCatalogCompanies(tx *sql.Tx, companies []models.CatalogCompany) (map[string]int, error) {
+	query := `
+		INSERT INTO companies (slug, name)
+		VALUES ($1, $2)
+		ON CONFLICT (slug) DO UPDATE SET name = EXCLUDED.name
+		WHERE companies.name IS DISTINCT FROM EXCLUDED.name`
+
+	for _, company := range companies {
+		if _, err := tx.Exec(query, company.Slug, company.Name); err != nil {
+			return nil, apperr.Errorf("failed to apply company %s: %w", company.Slug, err)
+		}
+	}
+
+	rows, err := tx.Query("SELECT id, slug FROM companies")
+	if err != nil {
+		return nil, apperr.Errorf("failed to get companies: %w", err)
+	}
+	defer rows.Close()
+
+	ids := map[string]int{}
+	for rows.Next() {
+		var id int
+		var slug string
+		if err := rows.Scan(&id, &slug); err != nil {
+			return nil, apperr.Errorf("failed to scan company: %w", err)
+		}
+		ids[slug] = id
+	}
+
+	return ids, rows.Err()
+}
+
+// applyCatalogItems upserts items by link along with their hints and company tags
+func applyCatalogItems(tx *sql.Tx, items []models.CatalogItem, companyIDs map[string]int) error {
+	existing, err := catalogIDsByLink(tx, "SELECT id, link FROM items ORDER BY id")
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		itemID, ok := existing[catalog.NormalizeLink(item.Link)]
+		if ok {
+			query := `
+				UPDATE items
+				SET title = $2, category = $3, subcategory = $4, attachments = $5
+				WHERE id = $1 AND (title IS DISTINCT FROM $2 OR category IS DISTINCT FROM $3
+					OR subcategory IS DISTINCT FROM $4 OR COALESCE(attachments, '{}'::jsonb) IS DISTINCT FROM $5::jsonb)`
+			if _, err := tx.Exec(query, itemID, item.Title, item.Category, item.Subcategory, item.Attachments); err != nil {
+				return apperr.Errorf("failed to update item %s: %w", item.Link, err)
+			}
+		} else {
+			query := `
+				INSERT INTO items (title, link, category, subcategory, attachments)
+				VALUES ($1, $2, $3, $4, $5)
+				RETURNING id`
+			if err := tx.QueryRow(query, item.Title, item.Link, item.Category, item.Subcategory, item.Attachments).Scan(&itemID); err != nil {
+				return apperr.Errorf("failed to create item %s: %w", item.Link, err)
+			}
+		}
+
+		if err := applyCatalogHints(tx, itemID, item.Hints); err != nil {
+			return err
+		}
+
+		for _, slug := range item.Companies {
+			companyID, ok := companyIDs[slug]
+			if !ok {
+				return apperr.NotFound(fmt.Sprintf("company not found: %s", slug))
+			}
+			_, err := tx.Exec("INSERT INTO item_companies (item_id, company_id) VALUES ($1, $2) ON CONFLICT DO NOTHING", itemID, companyID)
+			if err != nil {
+				return apperr.Errorf("failed to tag item %s with %s: %w", item.Link, slug, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// applyCatalogHints makes an item's hint ladder start with the given hints, matching by position.
+// Hints are updated in place so users' reveals stay attached; extra existing hints are kept.
+func applyCatalogHints(tx *sql.Tx, itemID int, hints []string) error {
+	if len(hints) == 0 {
+		return nil
+	}
+
+	rows, err := tx.Query("SELECT id, content FROM item_hints WHERE item_id = $1 ORDER BY position, id", itemID)
+	if err != nil {
+		return apperr.Errorf("failed to get hints: %w", err)
+	}
+
+	type hintRow struct {
+		id      int
+		content string
+	}
+	var existing []hintRow
+	for rows.Next() {
+		var h hintRow
+		if err := rows.Scan(&h.id, &h.content); err != nil {
+			rows.Close()
+			return apperr.Errorf("failed to scan hint: %w", err)
+		}
+		existing = append(existing, h)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return apperr.Errorf("error iterating hints: %w", err)
+	}
+
+	for i, content := range hints {
+		if i < len(existing) {
+			if existing[i].content == content {
+				continue
+			}
+			_, err := tx.Exec("UPDATE item_hints SET content = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $1", existing[i].id, content)
+			if err != nil {
+				return apperr.Errorf("failed to update hint: %w", err)
+			}
+			continue
+		}
+
+		query := `
+			INSERT INTO item_hints (item_id, position, content)
+			VALUES ($1, (SELECT COALESCE(MAX(position), 0) + 1 FROM item_hints WHERE item_id = $1), $2)`
+		if _, err := tx.Exec(query, itemID, content); err != nil {
+			return apperr.Errorf("failed to create hint: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// applyCatalogEngBlogs upserts engineering blogs by link and their articles by link within the blog
+func applyCatalogEngBlogs(tx *sql.Tx, blogs []models.CatalogEngBlog) error {
+	existing, err := catalogIDsByLink(tx, "SELECT id, link FROM eng_blogs ORDER BY id")
+	if err != nil {
+		return err
+	}
+
+	for _, blog := range blogs {
+		blogID, ok := existing[catalog.NormalizeLink(blog.Link)]
+		if ok {
+			query := `
+				UPDATE eng_blogs
+				SET name = $2, order_idx = $3, updated_at = CURRENT_TIMESTAMP
+				WHERE id = $1 AND (name IS DISTINCT FROM $2 OR order_idx IS DISTINCT FROM $3)`
+			if _, err := tx.Exec(query, blogID, blog.Name, blog.OrderIdx); err != nil {
+				return apperr.Errorf("failed to update blog %s: %w", blog.Link, err)
+			}
+		} else {
+			query := `INSERT INTO eng_blogs (name, link, order_idx) VALUES ($1, $2, $3) RETURNING id`
+			if err := tx.QueryRow(query, blog.Name, blog.Link, blog.OrderIdx).Scan(&blogID); err != nil {
+				return apperr.Errorf("failed to create blog %s: %w", blog.Link, err)
+			}
+		}
+
+		articles, err := catalogIDsByLink(tx, "SELECT id, external_link FROM eng_blog_articles WHERE blog_id = $1 ORDER BY id", blogID)
+		if err != nil {
+			return err
+		}
+
+		for _, article := range blog.Articles {
+			articleID, ok := articles[catalog.NormalizeLink(article.ExternalLink)]
+			if ok {
+				query := `
+					UPDATE eng_blog_articles
+					SET title = $2, order_idx = $3, updated_at = CURRENT_TIMESTAMP
+					WHERE id = $1 AND (title IS DISTINCT FROM $2 OR order_idx IS DISTINCT FROM $3)`
+				if _, err := tx.Exec(query, articleID, article.Title, article.OrderIdx); err != nil {
+					return apperr.Errorf("failed to update article %s: %w", article.ExternalLink, err)
+				}
+				continue
+			}
+
+			query := `INSERT INTO eng_blog_articles (blog_id, title, external_link, order_idx) VALUES ($1, $2, $3, $4)`
+			if _, err := tx.Exec(query, blogID, article.Title, article.ExternalLink, article.OrderIdx); err != nil {
+				return apperr.Errorf("failed to create article %s: %w", article.ExternalLink, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// catalogIDsByLink runs a query selecting (id, link) and keys the IDs by normalized link,
+// keeping the oldest row when links collide
+func catalogIDsByLink(tx *sql.Tx, query string, args ...interface{}) (map[string]int, error) {
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get catalog links: %w", err)
+	}
+	defer rows.Close()
+
+	ids := map[string]int{}
+	for rows.Next() {
+		var id int
+		var link string
+		if err := rows.Scan(&id, &link); err != nil {
+			return nil, apperr.Errorf("failed to scan catalog link: %w", err)
+		}
+		key := catalog.NormalizeLink(link)
+		if _, ok := ids[key]; !ok {
+			ids[key] = id
+		}
+	}
+
+	return ids, rows.Err()
+}
@@ -0,0 +1,159 @@
+package repositories
+
+import (
+	"database/sql"
+	"time"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/lib/pq"
+)
+
+// CategoryRepository handles database operations for categories and their subcategory lists
+type CategoryRepository struct {
+	db *sql.DB
+}
+
+// NewCategoryRepository creates a new category repository
+func NewCategoryRepository(db *sql.DB) *CategoryRepository {
+	return &CategoryRepository{db: db}
+}
+
+// GetAll returns every category with its subcategories, in display order
+func (r *CategoryRepository) GetAll() ([]*models.CategoryDefinition, error) {
+	query := `
+		SELECT c.slug, c.name, c.order_idx, c.created_at, c.updated_at,
+			COALESCE(array_agg(s.name ORDER BY s.order_idx, s.name) FILTER (WHERE s.name IS NOT NULL), '{}')
+		FROM categories c
+		LEFT JOIN category_subcategories s ON s.category = c.slug
+		GROUP BY c.slug
+		ORDER BY c.order_idx, c.slug`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get categories: %w", err)
+	}
+	defer rows.Close()
+
+	categories := []*models.CategoryDefinition{}
+	for rows.Next() {
+		category := &models.CategoryDefinition{}
+		if err := rows.Scan(
+			&category.Slug, &category.Name, &category.OrderIdx, &category.CreatedAt, &category.UpdatedAt,
+			pq.Array(&category.Subcategories),
+		); err != nil {
+			return nil, apperr.Errorf("failed to scan category: %w", err)
+		}
+		categories = append(categories, category)
+	}
+
+	return categories, rows.Err()
+}
+
+// Create adds a category and its subcategories
+func (r *CategoryRepository) Create(category *models.CategoryDefinition) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return apperr.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO categories (slug, name, order_idx)
+		VALUES ($1, $2, $3)
+		RETURNING created_at, updated_at`
+
+	if err := tx.QueryRow(query, category.Slug, category.Name, category.OrderIdx).Scan(&category.CreatedAt, &category.UpdatedAt); err != nil {
+		return apperr.Errorf("failed to create category: %w", err)
+	}
+
+	if err := replaceSubcategories(tx, category.Slug, category.Subcategories); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return apperr.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Update changes a category's name and order and, when subcategories is non-nil, replaces its
+// subcategory list
+func (r *CategoryRepository) Update(slug models.Category, name string, orderIdx int, subcategories []string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return apperr.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`UPDATE categories SET name = $1, order_idx = $2, updated_at = $3 WHERE slug = $4`,
+		name, orderIdx, time.Now(), slug)
+	if err != nil {
+		return apperr.Errorf("failed to update category: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperr.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return apperr.NotFound("category not found")
+	}
+
+	if subcategories != nil {
+		if err := replaceSubcategories(tx, slug, subcategories); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return apperr.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// replaceSubcategories sets a category's subcategory list, keeping the given order
+func replaceSubcategories(tx *sql.Tx, slug models.Category, subcategories []string) error {
+	if _, err := tx.Exec("DELETE FROM category_subcategories WHERE category = $1", slug); err != nil {
+		return apperr.Errorf("failed to clear subcategories: %w", err)
+	}
+
+	for i, name := range subcategories {
+		query := `INSERT INTO category_subcategories (category, name, order_idx) VALUES ($1, $2, $3)`
+		if _, err := tx.Exec(query, slug, name, i+1); err != nil {
+			return apperr.Errorf("failed to add subcategory: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Delete removes a category that no items are filed under
+func (r *CategoryRepository) Delete(slug models.Category) error {
+	var inUse bool
+	if err := r.db.QueryRow("SELECT EXISTS(SELECT 1 FROM items WHERE category = $1)", slug).Scan(&inUse); err != nil {
+		return apperr.Errorf("failed to check category items: %w", err)
+	}
+	if inUse {
+		return apperr.Conflict("category has items")
+	}
+
+	result, err := r.db.Exec("DELETE FROM categories WHERE slug = $1", slug)
+	if err != nil {
+		return apperr.Errorf("failed to delete category: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperr.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperr.NotFound("category not found")
+	}
+
+	return nil
+}
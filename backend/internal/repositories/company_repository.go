@@ -0,0 +1,193 @@
+package repositories
+
+import (
+	"database/sql"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/lib/pq"
+)
+
+// CompanyRepository handles database operations for companies and their item tags
+type CompanyRepository struct {
+	db *sql.DB
+}
+
+// NewCompanyRepository creates a new company repository
+func NewCompanyRepository(db *sql.DB) *CompanyRepository {
+	return &CompanyRepository{db: db}
+}
+
+// Create adds a company
+func (r *CompanyRepository) Create(company *models.Company) error {
+	query := `
+		INSERT INTO companies (slug, name)
+		VALUES ($1, $2)
+		RETURNING id, created_at`
+
+	if err := r.db.QueryRow(query, company.Slug, company.Name).Scan(&company.ID, &company.CreatedAt); err != nil {
+		return apperr.Errorf("failed to create company: %w", err)
+	}
+
+	return nil
+}
+
+// GetAll returns every company ordered by name
+func (r *CompanyRepository) GetAll() ([]*models.Company, error) {
+	rows, err := r.db.Query("SELECT id, slug, name, created_at FROM companies ORDER BY name")
+	if err != nil {
+		return nil, apperr.Errorf("failed to get companies: %w", err)
+	}
+	defer rows.Close()
+
+	companies := []*models.Company{}
+	for rows.Next() {
+		company := &models.Company{}
+		if err := rows.Scan(&company.ID, &company.Slug, &company.Name, &company.CreatedAt); err != nil {
+			return nil, apperr.Errorf("failed to scan company: %w", err)
+		}
+		companies = append(companies, company)
+	}
+
+	return companies, rows.Err()
+}
+
+// GetBySlugs returns the companies matching the given slugs; unknown slugs are skipped
+func (r *CompanyRepository) GetBySlugs(slugs []string) ([]*models.Company, error) {
+	query := `
+		SELECT id, slug, name, created_at
+		FROM companies
+		WHERE slug = ANY($1)
+		ORDER BY name`
+
+	rows, err := r.db.Query(query, pq.Array(slugs))
+	if err != nil {
+		return nil, apperr.Errorf("failed to get companies: %w", err)
+	}
+	defer rows.Close()
+
+	companies := []*models.Company{}
+	for rows.Next() {
+		company := &models.Company{}
+		if err := rows.Scan(&company.ID, &company.Slug, &company.Name, &company.CreatedAt); err != nil {
+			return nil, apperr.Errorf("failed to scan company: %w", err)
+		}
+		companies = append(companies, company)
+	}
+
+	return companies, rows.Err()
+}
+
+// Delete removes a company and its item tags
+func (r *CompanyRepository) Delete(slug string) error {
+	result, err := r.db.Exec("DELETE FROM companies WHERE slug = $1", slug)
+	if err != nil {
+		return apperr.Errorf("failed to delete company: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperr.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperr.NotFound("company not found")
+	}
+
+	return nil
+}
+
+// GetForItem returns the companies an item is tagged with
+func (r *CompanyRepository) GetForItem(itemID int) ([]*models.Company, error) {
+	query := `
+		SELECT co.id, co.slug, co.name, co.created_at
+		FROM companies co
+		INNER JOIN item_companies ic ON ic.company_id = co.id
+		WHERE ic.item_id = $1
+		ORDER BY co.name`
+
+	rows, err := r.db.Query(query, itemID)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get item companies: %w", err)
+	}
+	defer rows.Close()
+
+	companies := []*models.Company{}
+	for rows.Next() {
+		company := &models.Company{}
+		if err := rows.Scan(&company.ID, &company.Slug, &company.Name, &company.CreatedAt); err != nil {
+			return nil, apperr.Errorf("failed to scan company: %w", err)
+		}
+		companies = append(companies, company)
+	}
+
+	return companies, rows.Err()
+}
+
+// SetForItem replaces an item's company tags
+func (r *CompanyRepository) SetForItem(itemID int, companyIDs []int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return apperr.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM item_companies WHERE item_id = $1", itemID); err != nil {
+		return apperr.Errorf("failed to clear item companies: %w", err)
+	}
+
+	for _, companyID := range companyIDs {
+		_, err := tx.Exec("INSERT INTO item_companies (item_id, company_id) VALUES ($1, $2) ON CONFLICT DO NOTHING", itemID, companyID)
+		if err != nil {
+			return apperr.Errorf("failed to tag item with company: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return apperr.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetStatsForUser counts a user's progress on the items tagged with each company.
+// Companies with no tagged items are left out.
+func (r *CompanyRepository) GetStatsForUser(userID int) ([]*models.CompanyStats, error) {
+	query := `
+		SELECT co.id, co.slug, co.name, co.created_at,
+			COUNT(*) as total,
+			COUNT(*) FILTER (WHERE up.status = 'done') as completed,
+			COUNT(*) FILTER (WHERE up.status = 'in-progress') as in_progress
+		FROM companies co
+		INNER JOIN item_companies ic ON ic.company_id = co.id
+		LEFT JOIN user_progress up ON up.item_id = ic.item_id AND up.user_id = $1
+		GROUP BY co.id, co.slug, co.name, co.created_at
+		ORDER BY total DESC, co.name`
+
+	stats := []*models.CompanyStats{}
+	err := withUserContext(r.db, userID, func(q dbtx) error {
+		rows, err := q.Query(query, userID)
+		if err != nil {
+			return apperr.Errorf("failed to get company stats: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			s := &models.CompanyStats{}
+			err := rows.Scan(&s.Company.ID, &s.Company.Slug, &s.Company.Name, &s.Company.CreatedAt,
+				&s.TotalItems, &s.CompletedItems, &s.InProgressItems)
+			if err != nil {
+				return apperr.Errorf("failed to scan company stats: %w", err)
+			}
+			stats = append(stats, s)
+		}
+
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"database/sql"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+)
+
+// DesignNotesRepository handles database operations for users' structured design notes on items
+type DesignNotesRepository struct {
+	db *sql.DB
+}
+
+// NewDesignNotesRepository creates a new design notes repository
+func NewDesignNotesRepository(db *sql.DB) *DesignNotesRepository {
+	return &DesignNotesRepository{db: db}
+}
+
+// Get retrieves the user's design notes for an item, or nil when they haven't written any
+func (r *DesignNotesRepository) Get(userID, itemID int) (*models.ItemDesignNotes, error) {
+	query := `SELECT item_id, notes, version, updated_at FROM item_design_notes WHERE user_id = $1 AND item_id = $2`
+
+	var notes models.ItemDesignNotes
+	err := r.db.QueryRow(query, userID, itemID).Scan(&notes.ItemID, &notes.Notes, &notes.Version, &notes.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, apperr.Errorf("failed to get design notes: %w", err)
+	}
+
+	return &notes, nil
+}
+
+// Save creates or replaces the user's design notes for an item and bumps their version. With
+// version set, only notes at that version are replaced; otherwise the conflict is returned
+// with the current notes.
+func (r *DesignNotesRepository) Save(userID, itemID int, notes models.DesignNotes, version *int) (*models.ItemDesignNotes, error) {
+	query := `
+		INSERT INTO item_design_notes (user_id, item_id, notes)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, item_id) DO UPDATE SET
+			notes = EXCLUDED.notes,
+			version = item_design_notes.version + 1,
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING item_id, notes, version, updated_at`
+	args := []interface{}{userID, itemID, notes}
+
+	if version != nil {
+		query = `
+			UPDATE item_design_notes SET
+				notes = $3,
+				version = version + 1,
+				updated_at = CURRENT_TIMESTAMP
+			WHERE user_id = $1 AND item_id = $2 AND version = $4
+			RETURNING item_id, notes, version, updated_at`
+		args = append(args, *version)
+	}
+
+	var saved models.ItemDesignNotes
+	err := r.db.QueryRow(query, args...).Scan(&saved.ItemID, &saved.Notes, &saved.Version, &saved.UpdatedAt)
+	if err == sql.ErrNoRows {
+		current, getErr := r.Get(userID, itemID)
+		if getErr != nil {
+			return nil, getErr
+		}
+		return nil, apperr.Conflict("design notes were changed elsewhere; reload them and reapply your edit").
+			WithDetails(map[string]interface{}{"current": current})
+	}
+	if err != nil {
+		return nil, apperr.Errorf("failed to save design notes: %w", err)
+	}
+
+	return &saved, nil
+}
+
+// Delete removes the user's design notes for an item
+func (r *DesignNotesRepository) Delete(userID, itemID int) error {
+	result, err := r.db.Exec("DELETE FROM item_design_notes WHERE user_id = $1 AND item_id = $2", userID, itemID)
+	if err != nil {
+		return apperr.Errorf("failed to delete design notes: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperr.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperr.NotFound("design notes not found")
+	}
+
+	return nil
+}
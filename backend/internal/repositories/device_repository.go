@@ -0,0 +1,110 @@
+package repositories
+
+import (
+	"database/sql"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+)
+
+// DeviceRepository handles database operations for push notification devices
+type DeviceRepository struct {
+	db *sql.DB
+}
+
+// NewDeviceRepository creates a new DeviceRepository
+func NewDeviceRepository(db *sql.DB) *DeviceRepository {
+	return &DeviceRepository{db: db}
+}
+
+// Upsert registers a device for the user. Re-registering a known token moves it to the
+// current user and refreshes its keys, so a shared browser never notifies a previous user.
+func (r *DeviceRepository) Upsert(device *models.Device) error {
+	query := `
+		INSERT INTO user_devices (user_id, platform, token, p256dh, auth, label)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (platform, token)
+		DO UPDATE SET
+			user_id = EXCLUDED.user_id,
+			p256dh = EXCLUDED.p256dh,
+			auth = EXCLUDED.auth,
+			label = EXCLUDED.label,
+			last_seen_at = CURRENT_TIMESTAMP
+		RETURNING id, created_at, last_seen_at`
+
+	err := r.db.QueryRow(
+		query,
+		device.UserID,
+		device.Platform,
+		device.Token,
+		device.P256dh,
+		device.Auth,
+		device.Label,
+	).Scan(&device.ID, &device.CreatedAt, &device.LastSeenAt)
+	if err != nil {
+		return apperr.Errorf("failed to register device: %w", err)
+	}
+
+	return nil
+}
+
+// GetForUser returns the user's registered devices, most recently seen first
+func (r *DeviceRepository) GetForUser(userID int) ([]*models.Device, error) {
+	query := `
+		SELECT id, user_id, platform, token, p256dh, auth, label, created_at, last_seen_at
+		FROM user_devices
+		WHERE user_id = $1
+		ORDER BY last_seen_at DESC`
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get devices: %w", err)
+	}
+	defer rows.Close()
+
+	devices := []*models.Device{}
+	for rows.Next() {
+		device := &models.Device{}
+		err := rows.Scan(
+			&device.ID, &device.UserID, &device.Platform, &device.Token, &device.P256dh,
+			&device.Auth, &device.Label, &device.CreatedAt, &device.LastSeenAt,
+		)
+		if err != nil {
+			return nil, apperr.Errorf("failed to scan device: %w", err)
+		}
+		devices = append(devices, device)
+	}
+
+	return devices, rows.Err()
+}
+
+// Delete removes one of the user's devices
+func (r *DeviceRepository) Delete(userID, deviceID int) error {
+	query := `DELETE FROM user_devices WHERE id = $1 AND user_id = $2`
+
+	result, err := r.db.Exec(query, deviceID, userID)
+	if err != nil {
+		return apperr.Errorf("failed to delete device: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperr.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperr.NotFound("device not found")
+	}
+
+	return nil
+}
+
+// DeleteByID removes a device regardless of owner (used when a push service reports it gone)
+func (r *DeviceRepository) DeleteByID(deviceID int) error {
+	_, err := r.db.Exec(`DELETE FROM user_devices WHERE id = $1`, deviceID)
+	if err != nil {
+		return apperr.Errorf("failed to delete device: %w", err)
+	}
+
+	return nil
+}
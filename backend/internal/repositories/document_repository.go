@@ -0,0 +1,160 @@
+package repositories
+
+import (
+	"database/sql"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+)
+
+// DocumentRepository handles database operations for users' saved documents
+type DocumentRepository struct {
+	db *sql.DB
+}
+
+// NewDocumentRepository creates a new document repository
+func NewDocumentRepository(db *sql.DB) *DocumentRepository {
+	return &DocumentRepository{db: db}
+}
+
+const documentColumns = `id, user_id, kind, title, category, subcategory, content, source_items, truncated, provider, model, created_at`
+
+// scanDocument scans a row selected with documentColumns
+func scanDocument(scanner interface{ Scan(...interface{}) error }) (*models.Document, error) {
+	var document models.Document
+	err := scanner.Scan(
+		&document.ID, &document.UserID, &document.Kind, &document.Title, &document.Category, &document.Subcategory,
+		&document.Content, &document.SourceItems, &document.Truncated, &document.Provider, &document.Model, &document.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &document, nil
+}
+
+// Create saves a document, filling in its ID and time
+func (r *DocumentRepository) Create(document *models.Document, tokens int64) error {
+	query := `
+		INSERT INTO documents (user_id, kind, title, category, subcategory, content, source_items, truncated, provider, model, tokens)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id, created_at`
+
+	err := r.db.QueryRow(query,
+		document.UserID, document.Kind, document.Title, document.Category, document.Subcategory, document.Content,
+		document.SourceItems, document.Truncated, document.Provider, document.Model, tokens,
+	).Scan(&document.ID, &document.CreatedAt)
+	if err != nil {
+		return apperr.Errorf("failed to create document: %w", err)
+	}
+
+	return nil
+}
+
+// GetForUser lists the user's documents, newest first, optionally of one category
+func (r *DocumentRepository) GetForUser(userID int, category *models.Category) ([]*models.Document, error) {
+	query := `
+		SELECT ` + documentColumns + `
+		FROM documents
+		WHERE user_id = $1 AND ($2::TEXT IS NULL OR category = $2)
+		ORDER BY created_at DESC, id DESC`
+
+	var categoryValue *string
+	if category != nil {
+		value := string(*category)
+		categoryValue = &value
+	}
+
+	rows, err := r.db.Query(query, userID, categoryValue)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get documents: %w", err)
+	}
+	defer rows.Close()
+
+	documents := []*models.Document{}
+	for rows.Next() {
+		document, err := scanDocument(rows)
+		if err != nil {
+			return nil, apperr.Errorf("failed to scan document: %w", err)
+		}
+		documents = append(documents, document)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating documents: %w", err)
+	}
+
+	return documents, nil
+}
+
+// GetByID retrieves one of the user's documents
+func (r *DocumentRepository) GetByID(userID, documentID int) (*models.Document, error) {
+	query := `SELECT ` + documentColumns + ` FROM documents WHERE id = $1 AND user_id = $2`
+
+	document, err := scanDocument(r.db.QueryRow(query, documentID, userID))
+	if err == sql.ErrNoRows {
+		return nil, apperr.NotFound("document not found")
+	}
+	if err != nil {
+		return nil, apperr.Errorf("failed to get document: %w", err)
+	}
+
+	return document, nil
+}
+
+// Delete removes one of the user's documents
+func (r *DocumentRepository) Delete(userID, documentID int) error {
+	result, err := r.db.Exec("DELETE FROM documents WHERE id = $1 AND user_id = $2", documentID, userID)
+	if err != nil {
+		return apperr.Errorf("failed to delete document: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperr.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperr.NotFound("document not found")
+	}
+
+	return nil
+}
+
+// GetItemNotes returns the notes the user wrote on items in a category, and a subcategory when
+// given, most recently updated first
+func (r *DocumentRepository) GetItemNotes(userID int, category models.Category, subcategory string) ([]*models.ItemNote, error) {
+	query := `
+		SELECT i.id, i.title, i.subcategory, up.notes
+		FROM user_progress up
+		INNER JOIN items i ON i.id = up.item_id
+		WHERE up.user_id = $1
+		  AND i.category = $2
+		  AND ($3 = '' OR i.subcategory = $3)
+		  AND TRIM(COALESCE(up.notes, '')) <> ''
+		ORDER BY up.updated_at DESC, i.id`
+
+	notes := []*models.ItemNote{}
+	err := withUserContext(r.db, userID, func(q dbtx) error {
+		rows, err := q.Query(query, userID, category, subcategory)
+		if err != nil {
+			return apperr.Errorf("failed to get item notes: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			note := &models.ItemNote{}
+			if err := rows.Scan(&note.ItemID, &note.Title, &note.Subcategory, &note.Notes); err != nil {
+				return apperr.Errorf("failed to scan item notes: %w", err)
+			}
+			notes = append(notes, note)
+		}
+
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return notes, nil
+}
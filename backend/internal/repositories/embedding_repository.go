@@ -0,0 +1,141 @@
+package repositories
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+)
+
+// EmbeddingRepository handles database operations for item embeddings, stored with pgvector
+type EmbeddingRepository struct {
+	db *sql.DB
+}
+
+// NewEmbeddingRepository creates a new embedding repository
+func NewEmbeddingRepository(db *sql.DB) *EmbeddingRepository {
+	return &EmbeddingRepository{db: db}
+}
+
+// Enabled reports whether the item_embeddings table exists, which it only does where the
+// pgvector extension could be created
+func (r *EmbeddingRepository) Enabled() (bool, error) {
+	var enabled bool
+	if err := r.db.QueryRow("SELECT to_regclass('item_embeddings') IS NOT NULL").Scan(&enabled); err != nil {
+		return false, apperr.Errorf("failed to check for item embeddings: %w", err)
+	}
+
+	return enabled, nil
+}
+
+// GetStates returns every item with the hash of the text its embedding from the model was
+// computed from, if it has one
+func (r *EmbeddingRepository) GetStates(model string) ([]*models.ItemEmbeddingState, error) {
+	query := `
+		SELECT i.id, i.title, i.category, i.subcategory, COALESCE(e.source_hash, '')
+		FROM items i
+		LEFT JOIN item_embeddings e ON e.item_id = i.id AND e.model = $1
+		ORDER BY i.id`
+
+	rows, err := r.db.Query(query, model)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get item embedding states: %w", err)
+	}
+	defer rows.Close()
+
+	states := []*models.ItemEmbeddingState{}
+	for rows.Next() {
+		state := &models.ItemEmbeddingState{}
+		if err := rows.Scan(&state.ItemID, &state.Title, &state.Category, &state.Subcategory, &state.SourceHash); err != nil {
+			return nil, apperr.Errorf("failed to scan item embedding state: %w", err)
+		}
+		states = append(states, state)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating item embedding states: %w", err)
+	}
+
+	return states, nil
+}
+
+// Save stores an item's embedding, replacing any earlier one
+func (r *EmbeddingRepository) Save(itemID int, model, sourceHash string, embedding []float32) error {
+	query := `
+		INSERT INTO item_embeddings (item_id, model, source_hash, embedding, updated_at)
+		VALUES ($1, $2, $3, $4::vector, CURRENT_TIMESTAMP)
+		ON CONFLICT (item_id) DO UPDATE
+		SET model = EXCLUDED.model, source_hash = EXCLUDED.source_hash, embedding = EXCLUDED.embedding,
+			updated_at = EXCLUDED.updated_at`
+
+	if _, err := r.db.Exec(query, itemID, model, sourceHash, vectorLiteral(embedding)); err != nil {
+		return apperr.Errorf("failed to save item embedding: %w", err)
+	}
+
+	return nil
+}
+
+// GetSimilar returns the items whose embeddings from the model are closest to the item's, most
+// similar first. It returns false when the item has no embedding from the model yet.
+func (r *EmbeddingRepository) GetSimilar(itemID int, model string, limit int) ([]*models.SimilarItem, bool, error) {
+	var embedded bool
+	err := r.db.QueryRow("SELECT EXISTS (SELECT 1 FROM item_embeddings WHERE item_id = $1 AND model = $2)", itemID, model).Scan(&embedded)
+	if err != nil {
+		return nil, false, apperr.Errorf("failed to check item embedding: %w", err)
+	}
+	if !embedded {
+		return nil, false, nil
+	}
+
+	query := `
+		SELECT i.id, i.title, i.link, i.category, i.subcategory, i.attachments, i.version, i.created_at,
+			1 - (e.embedding <=> source.embedding) AS similarity
+		FROM item_embeddings source
+		INNER JOIN item_embeddings e ON e.item_id <> source.item_id AND e.model = source.model
+		INNER JOIN items i ON i.id = e.item_id
+		WHERE source.item_id = $1 AND i.published
+		ORDER BY e.embedding <=> source.embedding
+		LIMIT $2`
+
+	rows, err := r.db.Query(query, itemID, limit)
+	if err != nil {
+		return nil, false, apperr.Errorf("failed to get similar items: %w", err)
+	}
+	defer rows.Close()
+
+	items := []*models.SimilarItem{}
+	for rows.Next() {
+		item := &models.SimilarItem{}
+		err := rows.Scan(
+			&item.ID, &item.Title, &item.Link, &item.Category, &item.Subcategory, &item.Attachments,
+			&item.Version, &item.CreatedAt, &item.Similarity,
+		)
+		if err != nil {
+			return nil, false, apperr.Errorf("failed to scan similar item: %w", err)
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, false, apperr.Errorf("error iterating similar items: %w", err)
+	}
+
+	return items, true, nil
+}
+
+// vectorLiteral formats an embedding in pgvector's text form, e.g. [0.1,-0.2]
+func vectorLiteral(embedding []float32) string {
+	var literal strings.Builder
+	literal.WriteByte('[')
+	for i, value := range embedding {
+		if i > 0 {
+			literal.WriteByte(',')
+		}
+		literal.WriteString(strconv.FormatFloat(float64(value), 'g', -1, 32))
+	}
+	literal.WriteByte(']')
+
+	return literal.String()
+}
@@ -0,0 +1,12 @@
+package repositories
+
+import "testing"
+
+func TestVectorLiteral(t *testing.T) {
+	if got := vectorLiteral([]float32{0.5, -1, 0.125}); got != "[0.5,-1,0.125]" {
+		t.Errorf("vectorLiteral() = %q, want %q", got, "[0.5,-1,0.125]")
+	}
+	if got := vectorLiteral(nil); got != "[]" {
+		t.Errorf("vectorLiteral(nil) = %q, want %q", got, "[]")
+	}
+}
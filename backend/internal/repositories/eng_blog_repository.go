@@ -2,12 +2,18 @@ package repositories
 
 import (
 	"database/sql"
-	"fmt"
 	"strconv"
+	"strings"
+	"time"
 
 	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
 )
 
+// normalizedArticleLink is the form article links are deduplicated on within a blog: lowercased,
+// trimmed and without a trailing slash, the same as catalog.NormalizeLink
+const normalizedArticleLink = `RTRIM(LOWER(TRIM(external_link)), '/')`
+
 // EngBlogRepository handles database operations for engineering blogs
 type EngBlogRepository struct {
 	db *sql.DB
@@ -18,42 +24,55 @@ func NewEngBlogRepository(db *sql.DB) *EngBlogRepository {
 	return &EngBlogRepository{db: db}
 }
 
-// GetAll retrieves all engineering blogs with their articles
-func (r *EngBlogRepository) GetAll(limit, offset int) ([]models.EngBlog, int, error) {
+// engBlogSearchCondition matches blogs whose name or any visible article title contains the search
+// pattern ($2), or every blog when the pattern is empty. $1 is includeArchived.
+const engBlogSearchCondition = `($2 = '' OR eb.name ILIKE $2 OR EXISTS (
+			SELECT 1 FROM eng_blog_articles a
+			WHERE a.blog_id = eb.id AND ($1 OR a.archived_at IS NULL) AND a.title ILIKE $2
+		))`
+
+// GetAll retrieves a page of engineering blogs with their articles; limit and offset count blogs,
+// not articles, and a limit of 0 returns every blog. A non-empty search keeps blogs whose name
+// contains it, with all their articles, and blogs with a matching article title, with only the
+// matching articles. Archived blogs and articles are left out unless includeArchived is set.
+func (r *EngBlogRepository) GetAll(limit, offset int, search string, includeArchived bool) ([]models.EngBlog, int, error) {
+	pattern := ""
+	if search = strings.TrimSpace(search); search != "" {
+		pattern = "%" + likeEscaper.Replace(search) + "%"
+	}
+
 	// First get the total count
 	var total int
-	countQuery := `SELECT COUNT(*) FROM eng_blogs`
-	err := r.db.QueryRow(countQuery).Scan(&total)
+	countQuery := `SELECT COUNT(*) FROM eng_blogs eb WHERE ($1 OR eb.archived_at IS NULL) AND ` + engBlogSearchCondition
+	err := r.db.QueryRow(countQuery, includeArchived, pattern).Scan(&total)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to get total count: %w", err)
+		return nil, 0, apperr.Errorf("failed to get total count: %w", err)
 	}
 
-	// Build the main query
-	query := `
-		SELECT 
-			eb.id, eb.name, eb.link, eb.order_idx,
-			eba.id, eba.title, eba.order_idx, eba.external_link
-		FROM eng_blogs eb
-		LEFT JOIN eng_blog_articles eba ON eb.id = eba.blog_id
-		ORDER BY eb.order_idx ASC, eba.order_idx ASC`
-
-	// Add pagination if specified
-	args := []interface{}{}
+	// Paginate over blogs first, then join their articles, so a page never cuts a blog's articles short
+	var pageLimit *int
 	if limit > 0 {
-		query += ` LIMIT $1`
-		args = append(args, limit)
-		if offset > 0 {
-			query += ` OFFSET $2`
-			args = append(args, offset)
-		}
-	} else if offset > 0 {
-		query += ` OFFSET $1`
-		args = append(args, offset)
+		pageLimit = &limit
 	}
-
-	rows, err := r.db.Query(query, args...)
+	query := `
+		WITH page AS (
+			SELECT eb.id, eb.name, eb.link, eb.order_idx, eb.archived_at
+			FROM eng_blogs eb
+			WHERE ($1 OR eb.archived_at IS NULL) AND ` + engBlogSearchCondition + `
+			ORDER BY eb.order_idx ASC, eb.id ASC
+			LIMIT $3 OFFSET $4
+		)
+		SELECT 
+			p.id, p.name, p.link, p.order_idx, p.archived_at,
+			eba.id, eba.title, eba.order_idx, eba.external_link, eba.archived_at
+		FROM page p
+		LEFT JOIN eng_blog_articles eba ON p.id = eba.blog_id AND ($1 OR eba.archived_at IS NULL)
+			AND ($2 = '' OR p.name ILIKE $2 OR eba.title ILIKE $2)
+		ORDER BY p.order_idx ASC, p.id ASC, eba.order_idx ASC`
+
+	rows, err := r.db.Query(query, includeArchived, pattern, pageLimit, offset)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to query engineering blogs: %w", err)
+		return nil, 0, apperr.Errorf("failed to query engineering blogs: %w", err)
 	}
 	defer rows.Close()
 
@@ -63,22 +82,24 @@ func (r *EngBlogRepository) GetAll(limit, offset int) ([]models.EngBlog, int, er
 
 	for rows.Next() {
 		var (
-			blogID       int
-			blogName     string
-			blogLink     string
-			blogOrderIdx int
-			articleID    sql.NullInt64
-			articleTitle sql.NullString
-			articleOrder sql.NullInt64
-			articleLink  sql.NullString
+			blogID          int
+			blogName        string
+			blogLink        string
+			blogOrderIdx    int
+			blogArchived    *time.Time
+			articleID       sql.NullInt64
+			articleTitle    sql.NullString
+			articleOrder    sql.NullInt64
+			articleLink     sql.NullString
+			articleArchived *time.Time
 		)
 
 		err := rows.Scan(
-			&blogID, &blogName, &blogLink, &blogOrderIdx,
-			&articleID, &articleTitle, &articleOrder, &articleLink,
+			&blogID, &blogName, &blogLink, &blogOrderIdx, &blogArchived,
+			&articleID, &articleTitle, &articleOrder, &articleLink, &articleArchived,
 		)
 		if err != nil {
-			return nil, 0, fmt.Errorf("failed to scan row: %w", err)
+			return nil, 0, apperr.Errorf("failed to scan row: %w", err)
 		}
 
 		// Get or create blog
@@ -89,6 +110,7 @@ func (r *EngBlogRepository) GetAll(limit, offset int) ([]models.EngBlog, int, er
 				Name:             blogName,
 				Link:             blogLink,
 				OrderIdx:         blogOrderIdx,
+				ArchivedAt:       blogArchived,
 				PracticeProblems: []models.EngBlogProblem{},
 			}
 			blogMap[blogID] = blog
@@ -102,13 +124,14 @@ func (r *EngBlogRepository) GetAll(limit, offset int) ([]models.EngBlog, int, er
 				Title:        articleTitle.String,
 				OrderIdx:     int(articleOrder.Int64),
 				ExternalLink: articleLink.String,
+				ArchivedAt:   articleArchived,
 			}
 			blog.PracticeProblems = append(blog.PracticeProblems, article)
 		}
 	}
 
 	if err = rows.Err(); err != nil {
-		return nil, 0, fmt.Errorf("failed to iterate rows: %w", err)
+		return nil, 0, apperr.Errorf("failed to iterate rows: %w", err)
 	}
 
 	// Convert map to slice maintaining order
@@ -120,46 +143,52 @@ func (r *EngBlogRepository) GetAll(limit, offset int) ([]models.EngBlog, int, er
 	return blogs, total, nil
 }
 
-// GetByID retrieves a specific engineering blog by ID
-func (r *EngBlogRepository) GetByID(id string) (*models.EngBlog, error) {
+// likeEscaper escapes LIKE wildcards so a search matches them literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// GetByID retrieves a specific engineering blog by ID. An archived blog is reported as not
+// found, and its archived articles left out, unless includeArchived is set.
+func (r *EngBlogRepository) GetByID(id string, includeArchived bool) (*models.EngBlog, error) {
 	blogID, err := strconv.Atoi(id)
 	if err != nil {
-		return nil, fmt.Errorf("invalid blog ID: %w", err)
+		return nil, apperr.NotFound("engineering blog not found")
 	}
 
 	query := `
 		SELECT 
-			eb.id, eb.name, eb.link, eb.order_idx,
-			eba.id, eba.title, eba.order_idx, eba.external_link
+			eb.id, eb.name, eb.link, eb.order_idx, eb.archived_at,
+			eba.id, eba.title, eba.order_idx, eba.external_link, eba.archived_at
 		FROM eng_blogs eb
-		LEFT JOIN eng_blog_articles eba ON eb.id = eba.blog_id
-		WHERE eb.id = $1
+		LEFT JOIN eng_blog_articles eba ON eb.id = eba.blog_id AND ($2 OR eba.archived_at IS NULL)
+		WHERE eb.id = $1 AND ($2 OR eb.archived_at IS NULL)
 		ORDER BY eba.order_idx ASC`
 
-	rows, err := r.db.Query(query, blogID)
+	rows, err := r.db.Query(query, blogID, includeArchived)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query engineering blog: %w", err)
+		return nil, apperr.Errorf("failed to query engineering blog: %w", err)
 	}
 	defer rows.Close()
 
 	var blog *models.EngBlog
 	for rows.Next() {
 		var (
-			blogName     string
-			blogLink     string
-			blogOrderIdx int
-			articleID    sql.NullInt64
-			articleTitle sql.NullString
-			articleOrder sql.NullInt64
-			articleLink  sql.NullString
+			blogName        string
+			blogLink        string
+			blogOrderIdx    int
+			blogArchived    *time.Time
+			articleID       sql.NullInt64
+			articleTitle    sql.NullString
+			articleOrder    sql.NullInt64
+			articleLink     sql.NullString
+			articleArchived *time.Time
 		)
 
 		err := rows.Scan(
-			&blogID, &blogName, &blogLink, &blogOrderIdx,
-			&articleID, &articleTitle, &articleOrder, &articleLink,
+			&blogID, &blogName, &blogLink, &blogOrderIdx, &blogArchived,
+			&articleID, &articleTitle, &articleOrder, &articleLink, &articleArchived,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan row: %w", err)
+			return nil, apperr.Errorf("failed to scan row: %w", err)
 		}
 
 		// Initialize blog on first row
@@ -169,6 +198,7 @@ func (r *EngBlogRepository) GetByID(id string) (*models.EngBlog, error) {
 				Name:             blogName,
 				Link:             blogLink,
 				OrderIdx:         blogOrderIdx,
+				ArchivedAt:       blogArchived,
 				PracticeProblems: []models.EngBlogProblem{},
 			}
 		}
@@ -180,22 +210,146 @@ func (r *EngBlogRepository) GetByID(id string) (*models.EngBlog, error) {
 				Title:        articleTitle.String,
 				OrderIdx:     int(articleOrder.Int64),
 				ExternalLink: articleLink.String,
+				ArchivedAt:   articleArchived,
 			}
 			blog.PracticeProblems = append(blog.PracticeProblems, article)
 		}
 	}
 
 	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+		return nil, apperr.Errorf("failed to iterate rows: %w", err)
 	}
 
 	if blog == nil {
-		return nil, fmt.Errorf("engineering blog not found")
+		return nil, apperr.NotFound("engineering blog not found")
 	}
 
 	return blog, nil
 }
 
+// LastModified returns when any blog or article last changed, or the zero time when there are
+// none. Blogs and articles are archived rather than deleted, so every change moves it forward.
+func (r *EngBlogRepository) LastModified() (time.Time, error) {
+	query := `
+		SELECT GREATEST(
+			(SELECT MAX(updated_at) FROM eng_blogs),
+			(SELECT MAX(updated_at) FROM eng_blog_articles)
+		)`
+
+	var modified sql.NullTime
+	if err := r.db.QueryRow(query).Scan(&modified); err != nil {
+		return time.Time{}, apperr.Errorf("failed to get engineering blogs' last change: %w", err)
+	}
+
+	return modified.Time, nil
+}
+
+// ArchiveBlog hides a blog and its articles from default listings without deleting anything
+func (r *EngBlogRepository) ArchiveBlog(id int) error {
+	return r.setBlogArchivedAt(id, sql.NullTime{Time: time.Now(), Valid: true})
+}
+
+// RestoreBlog brings an archived blog back into default listings
+func (r *EngBlogRepository) RestoreBlog(id int) error {
+	return r.setBlogArchivedAt(id, sql.NullTime{})
+}
+
+// setBlogArchivedAt sets or clears a blog's archived_at timestamp
+func (r *EngBlogRepository) setBlogArchivedAt(id int, archivedAt sql.NullTime) error {
+	query := `UPDATE eng_blogs SET archived_at = $1, updated_at = $2 WHERE id = $3`
+
+	result, err := r.db.Exec(query, archivedAt, time.Now(), id)
+	if err != nil {
+		return apperr.Errorf("failed to update engineering blog: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperr.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperr.NotFound("engineering blog not found")
+	}
+
+	return nil
+}
+
+// ArchiveArticle hides a single article of a blog from default listings
+func (r *EngBlogRepository) ArchiveArticle(blogID, articleID int) error {
+	return r.setArticleArchivedAt(blogID, articleID, sql.NullTime{Time: time.Now(), Valid: true})
+}
+
+// RestoreArticle brings an archived article back into default listings, unless the blog has
+// since gained an active article with the same link
+func (r *EngBlogRepository) RestoreArticle(blogID, articleID int) error {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM eng_blog_articles a
+			INNER JOIN eng_blog_articles other
+				ON other.blog_id = a.blog_id AND other.id <> a.id AND other.archived_at IS NULL
+				AND RTRIM(LOWER(TRIM(other.external_link)), '/') = RTRIM(LOWER(TRIM(a.external_link)), '/')
+			WHERE a.id = $1 AND a.blog_id = $2
+		)`
+
+	var duplicate bool
+	if err := r.db.QueryRow(query, articleID, blogID).Scan(&duplicate); err != nil {
+		return apperr.Errorf("failed to check for duplicate article: %w", err)
+	}
+	if duplicate {
+		return apperr.Conflict("engineering blog article already exists")
+	}
+
+	return r.setArticleArchivedAt(blogID, articleID, sql.NullTime{})
+}
+
+// setArticleArchivedAt sets or clears an article's archived_at timestamp
+func (r *EngBlogRepository) setArticleArchivedAt(blogID, articleID int, archivedAt sql.NullTime) error {
+	query := `UPDATE eng_blog_articles SET archived_at = $1, updated_at = $2 WHERE id = $3 AND blog_id = $4`
+
+	result, err := r.db.Exec(query, archivedAt, time.Now(), articleID, blogID)
+	if err != nil {
+		return apperr.Errorf("failed to update engineering blog article: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperr.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperr.NotFound("engineering blog article not found")
+	}
+
+	return nil
+}
+
+// GetArticle retrieves an active article of an active blog, along with the blog's name
+func (r *EngBlogRepository) GetArticle(blogID, articleID int) (*models.EngBlogArticleDB, string, error) {
+	query := `
+		SELECT eba.id, eba.blog_id, eba.title, eba.order_idx, eba.external_link,
+			eba.created_at, eba.updated_at, eb.name
+		FROM eng_blog_articles eba
+		INNER JOIN eng_blogs eb ON eb.id = eba.blog_id
+		WHERE eba.id = $1 AND eba.blog_id = $2 AND eba.archived_at IS NULL AND eb.archived_at IS NULL`
+
+	var article models.EngBlogArticleDB
+	var blogName string
+	err := r.db.QueryRow(query, articleID, blogID).Scan(
+		&article.ID, &article.BlogID, &article.Title, &article.OrderIdx, &article.ExternalLink,
+		&article.CreatedAt, &article.UpdatedAt, &blogName,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, "", apperr.NotFound("engineering blog article not found")
+	}
+	if err != nil {
+		return nil, "", apperr.Errorf("failed to get engineering blog article: %w", err)
+	}
+
+	return &article, blogName, nil
+}
+
 // CreateBlog creates a new engineering blog
 func (r *EngBlogRepository) CreateBlog(name, link string, orderIdx int) (*models.EngBlogDB, error) {
 	query := `
@@ -210,17 +364,49 @@ func (r *EngBlogRepository) CreateBlog(name, link string, orderIdx int) (*models
 	)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to create engineering blog: %w", err)
+		return nil, apperr.Errorf("failed to create engineering blog: %w", err)
 	}
 
 	return &blog, nil
 }
 
-// CreateArticle creates a new article for an engineering blog
+// GetBlogByLink retrieves a blog by its link, matched the same way article links are
+func (r *EngBlogRepository) GetBlogByLink(link string) (*models.EngBlogDB, error) {
+	query := `
+		SELECT id, name, link, order_idx, created_at, updated_at, archived_at
+		FROM eng_blogs
+		WHERE RTRIM(LOWER(TRIM(link)), '/') = RTRIM(LOWER(TRIM($1::TEXT)), '/')
+		ORDER BY id
+		LIMIT 1`
+
+	var blog models.EngBlogDB
+	err := r.db.QueryRow(query, link).Scan(
+		&blog.ID, &blog.Name, &blog.Link, &blog.OrderIdx,
+		&blog.CreatedAt, &blog.UpdatedAt, &blog.ArchivedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, apperr.NotFound("engineering blog not found")
+	}
+	if err != nil {
+		return nil, apperr.Errorf("failed to get engineering blog by link: %w", err)
+	}
+
+	return &blog, nil
+}
+
+// CreateArticle creates a new article for an engineering blog. Articles are unique per blog by
+// normalized link, archived ones included, so repeated imports get a conflict error instead of
+// adding a copy.
 func (r *EngBlogRepository) CreateArticle(blogID int, title, externalLink string, orderIdx int) (*models.EngBlogArticleDB, error) {
 	query := `
-		INSERT INTO eng_blog_articles (blog_id, title, external_link, order_idx) 
-		VALUES ($1, $2, $3, $4) 
+		INSERT INTO eng_blog_articles (blog_id, title, external_link, order_idx)
+		SELECT $1::INTEGER, $2::TEXT, $3::TEXT, $4::INTEGER
+		WHERE NOT EXISTS (
+			SELECT 1 FROM eng_blog_articles
+			WHERE blog_id = $1 AND ` + normalizedArticleLink + ` = RTRIM(LOWER(TRIM($3::TEXT)), '/')
+		)
+		ON CONFLICT (blog_id, (` + normalizedArticleLink + `)) WHERE archived_at IS NULL DO NOTHING
 		RETURNING id, blog_id, title, external_link, order_idx, created_at, updated_at`
 
 	var article models.EngBlogArticleDB
@@ -229,8 +415,11 @@ func (r *EngBlogRepository) CreateArticle(blogID int, title, externalLink string
 		&article.CreatedAt, &article.UpdatedAt,
 	)
 
+	if err == sql.ErrNoRows {
+		return nil, apperr.Conflict("engineering blog article already exists")
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to create engineering blog article: %w", err)
+		return nil, apperr.Errorf("failed to create engineering blog article: %w", err)
 	}
 
 	return &article, nil
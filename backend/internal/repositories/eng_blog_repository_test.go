@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"path/filepath"
+	"testing"
+
+	"interview-prep-app/internal/database"
+)
+
+func TestEngBlogGetAllWithoutLimitOnSQLite(t *testing.T) {
+	db, err := database.NewConnection("sqlite://" + filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to open SQLite database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := database.RunMigrations(db); err != nil {
+		t.Fatalf("RunMigrations returned error: %v", err)
+	}
+
+	repo := NewEngBlogRepository(db)
+	if blogs, total, err := repo.GetAll(0, 0, "", false); err != nil || len(blogs) != 0 || total != 0 {
+		t.Fatalf("Expected no blogs from an empty database, got %d of %d (%v)", len(blogs), total, err)
+	}
+
+	for i, name := range []string{"Netflix", "Uber", "Stripe"} {
+		blog, err := repo.CreateBlog(name, "https://example.test/"+name, i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := repo.CreateArticle(blog.ID, name+" at scale", "https://example.test/"+name+"/scale", 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	blogs, total, err := repo.GetAll(0, 0, "", false)
+	if err != nil {
+		t.Fatalf("GetAll without a limit returned error: %v", err)
+	}
+	if len(blogs) != 3 || total != 3 || len(blogs[0].PracticeProblems) != 1 {
+		t.Errorf("Expected every blog with its article, got %d of %d: %+v", len(blogs), total, blogs)
+	}
+
+	if blogs, _, err := repo.GetAll(2, 1, "", false); err != nil || len(blogs) != 2 || blogs[0].Name != "Uber" {
+		t.Errorf("Expected the second page of two to start at Uber, got %+v (%v)", blogs, err)
+	}
+}
@@ -0,0 +1,142 @@
+package repositories
+
+import (
+	"database/sql"
+	"time"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+)
+
+// FeedbackRepository handles database operations for item feedback reports
+type FeedbackRepository struct {
+	db *sql.DB
+}
+
+// NewFeedbackRepository creates a new feedback repository
+func NewFeedbackRepository(db *sql.DB) *FeedbackRepository {
+	return &FeedbackRepository{db: db}
+}
+
+// Create records a new feedback report
+func (r *FeedbackRepository) Create(feedback *models.ItemFeedback) error {
+	query := `
+		INSERT INTO item_feedback (item_id, user_id, category, comment, status)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at`
+
+	err := r.db.QueryRow(
+		query, feedback.ItemID, feedback.UserID, feedback.Category, feedback.Comment, feedback.Status,
+	).Scan(&feedback.ID, &feedback.CreatedAt)
+
+	if err != nil {
+		return apperr.Errorf("failed to create feedback: %w", err)
+	}
+
+	return nil
+}
+
+// OpenFeedbackExists checks whether the user already has an open report of this kind on the item
+func (r *FeedbackRepository) OpenFeedbackExists(itemID, userID int, category models.FeedbackCategory) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM item_feedback
+			WHERE item_id = $1 AND user_id = $2 AND category = $3 AND status = 'open'
+		)`
+
+	var exists bool
+	if err := r.db.QueryRow(query, itemID, userID, category).Scan(&exists); err != nil {
+		return false, apperr.Errorf("failed to check existing feedback: %w", err)
+	}
+
+	return exists, nil
+}
+
+// CountSubmittedSince returns how many reports a user has submitted since the given time
+func (r *FeedbackRepository) CountSubmittedSince(userID int, since time.Time) (int, error) {
+	var count int
+	err := r.db.QueryRow(
+		"SELECT COUNT(*) FROM item_feedback WHERE user_id = $1 AND created_at >= $2", userID, since,
+	).Scan(&count)
+	if err != nil {
+		return 0, apperr.Errorf("failed to count submitted feedback: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetByStatus returns a page of reports with the given status, oldest first, and how many
+// reports have that status in total
+func (r *FeedbackRepository) GetByStatus(status models.FeedbackStatus, category *models.FeedbackCategory, limit, offset int) ([]*models.ItemFeedback, int, error) {
+	var total int
+	err := r.db.QueryRow(`
+		SELECT COUNT(*) FROM item_feedback
+		WHERE status = $1 AND ($2::VARCHAR IS NULL OR category = $2)`, status, category).Scan(&total)
+	if err != nil {
+		return nil, 0, apperr.Errorf("failed to count feedback: %w", err)
+	}
+
+	query := `
+		SELECT
+			f.id, f.item_id, f.user_id, COALESCE(u.name, ''), f.category, f.comment, f.status,
+			f.reviewed_by, f.review_note, f.created_at, f.reviewed_at,
+			i.id, i.title, i.link, i.category, i.subcategory, i.attachments, i.created_at
+		FROM item_feedback f
+		INNER JOIN items i ON i.id = f.item_id
+		LEFT JOIN users u ON u.id = f.user_id
+		WHERE f.status = $1 AND ($2::VARCHAR IS NULL OR f.category = $2)
+		ORDER BY f.created_at, f.id
+		LIMIT $3 OFFSET $4`
+
+	rows, err := r.db.Query(query, status, category, limit, offset)
+	if err != nil {
+		return nil, 0, apperr.Errorf("failed to get feedback: %w", err)
+	}
+	defer rows.Close()
+
+	feedback := []*models.ItemFeedback{}
+	for rows.Next() {
+		var f models.ItemFeedback
+		var item models.Item
+		err := rows.Scan(
+			&f.ID, &f.ItemID, &f.UserID, &f.ReporterName, &f.Category, &f.Comment, &f.Status,
+			&f.ReviewedBy, &f.ReviewNote, &f.CreatedAt, &f.ReviewedAt,
+			&item.ID, &item.Title, &item.Link, &item.Category, &item.Subcategory, &item.Attachments, &item.CreatedAt,
+		)
+		if err != nil {
+			return nil, 0, apperr.Errorf("failed to scan feedback: %w", err)
+		}
+		f.Item = &item
+		feedback = append(feedback, &f)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, apperr.Errorf("error iterating feedback: %w", err)
+	}
+
+	return feedback, total, nil
+}
+
+// Review resolves or dismisses an open report
+func (r *FeedbackRepository) Review(feedbackID, adminID int, status models.FeedbackStatus, note string) error {
+	query := `
+		UPDATE item_feedback
+		SET status = $1, reviewed_by = $2, review_note = $3, reviewed_at = $4
+		WHERE id = $5 AND status = 'open'`
+
+	result, err := r.db.Exec(query, status, adminID, note, time.Now(), feedbackID)
+	if err != nil {
+		return apperr.Errorf("failed to update feedback: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperr.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperr.NotFound("feedback not found")
+	}
+
+	return nil
+}
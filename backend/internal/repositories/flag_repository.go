@@ -0,0 +1,81 @@
+package repositories
+
+import (
+	"database/sql"
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/lib/pq"
+)
+
+// FlagRepository handles database operations for feature flags
+type FlagRepository struct {
+	db *sql.DB
+}
+
+// NewFlagRepository creates a new FlagRepository
+func NewFlagRepository(db *sql.DB) *FlagRepository {
+	return &FlagRepository{db: db}
+}
+
+// GetAll returns every feature flag by key
+func (r *FlagRepository) GetAll() ([]models.FeatureFlag, error) {
+	query := `
+		SELECT key, description, enabled, rollout_percent, user_ids, created_at, updated_at
+		FROM feature_flags
+		ORDER BY key`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get feature flags: %w", err)
+	}
+	defer rows.Close()
+
+	flags := []models.FeatureFlag{}
+	for rows.Next() {
+		var flag models.FeatureFlag
+		if err := rows.Scan(
+			&flag.Key, &flag.Description, &flag.Enabled, &flag.RolloutPercent,
+			pq.Array(&flag.UserIDs), &flag.CreatedAt, &flag.UpdatedAt,
+		); err != nil {
+			return nil, apperr.Errorf("failed to scan feature flag: %w", err)
+		}
+		flags = append(flags, flag)
+	}
+
+	return flags, rows.Err()
+}
+
+// Save creates or replaces a feature flag
+func (r *FlagRepository) Save(flag *models.FeatureFlag) error {
+	query := `
+		INSERT INTO feature_flags (key, description, enabled, rollout_percent, user_ids, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		ON CONFLICT (key)
+		DO UPDATE SET description = EXCLUDED.description, enabled = EXCLUDED.enabled,
+			rollout_percent = EXCLUDED.rollout_percent, user_ids = EXCLUDED.user_ids,
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING created_at, updated_at`
+
+	err := r.db.QueryRow(query, flag.Key, flag.Description, flag.Enabled, flag.RolloutPercent, pq.Array(flag.UserIDs)).
+		Scan(&flag.CreatedAt, &flag.UpdatedAt)
+	if err != nil {
+		return apperr.Errorf("failed to save feature flag: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a feature flag
+func (r *FlagRepository) Delete(key string) error {
+	result, err := r.db.Exec("DELETE FROM feature_flags WHERE key = $1", key)
+	if err != nil {
+		return apperr.Errorf("failed to delete feature flag: %w", err)
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return apperr.NotFound("feature flag not found")
+	}
+
+	return nil
+}
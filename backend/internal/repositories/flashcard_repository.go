@@ -0,0 +1,220 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+)
+
+// FlashcardRepository handles database operations for flashcards
+type FlashcardRepository struct {
+	db *sql.DB
+}
+
+// NewFlashcardRepository creates a new flashcard repository
+func NewFlashcardRepository(db *sql.DB) *FlashcardRepository {
+	return &FlashcardRepository{db: db}
+}
+
+const flashcardColumns = `id, user_id, item_id, question, answer, review_count, last_grade, last_reviewed_at,
+	next_review_at, created_at, updated_at`
+
+// scanFlashcard scans a row selected with flashcardColumns
+func scanFlashcard(scanner interface{ Scan(...interface{}) error }, card *models.Flashcard, extra ...interface{}) error {
+	dest := []interface{}{
+		&card.ID, &card.UserID, &card.ItemID, &card.Question, &card.Answer, &card.ReviewCount,
+		&card.LastGrade, &card.LastReviewedAt, &card.NextReviewAt, &card.CreatedAt, &card.UpdatedAt,
+	}
+	return scanner.Scan(append(dest, extra...)...)
+}
+
+// Create adds a flashcard; new cards are due immediately
+func (r *FlashcardRepository) Create(userID, itemID int, req *models.CreateFlashcardRequest) (*models.Flashcard, error) {
+	query := `
+		INSERT INTO flashcards (user_id, item_id, question, answer)
+		VALUES ($1, $2, $3, $4)
+		RETURNING ` + flashcardColumns
+
+	var card models.Flashcard
+	if err := scanFlashcard(r.db.QueryRow(query, userID, itemID, req.Question, req.Answer), &card); err != nil {
+		return nil, apperr.Errorf("failed to create flashcard: %w", err)
+	}
+
+	return &card, nil
+}
+
+// GetByID retrieves a flashcard owned by a user
+func (r *FlashcardRepository) GetByID(userID, cardID int) (*models.Flashcard, error) {
+	query := `SELECT ` + flashcardColumns + ` FROM flashcards WHERE id = $1 AND user_id = $2`
+
+	var card models.Flashcard
+	err := scanFlashcard(r.db.QueryRow(query, cardID, userID), &card)
+	if err == sql.ErrNoRows {
+		return nil, apperr.NotFound("flashcard not found")
+	}
+	if err != nil {
+		return nil, apperr.Errorf("failed to get flashcard: %w", err)
+	}
+
+	return &card, nil
+}
+
+// GetForItem lists the user's flashcards on an item
+func (r *FlashcardRepository) GetForItem(userID, itemID int) ([]*models.Flashcard, error) {
+	query := `
+		SELECT ` + flashcardColumns + `
+		FROM flashcards
+		WHERE user_id = $1 AND item_id = $2
+		ORDER BY created_at ASC`
+
+	rows, err := r.db.Query(query, userID, itemID)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get flashcards: %w", err)
+	}
+	defer rows.Close()
+
+	cards := []*models.Flashcard{}
+	for rows.Next() {
+		var card models.Flashcard
+		if err := scanFlashcard(rows, &card); err != nil {
+			return nil, apperr.Errorf("failed to scan flashcard: %w", err)
+		}
+		cards = append(cards, &card)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating flashcards: %w", err)
+	}
+
+	return cards, nil
+}
+
+// GetDue returns the user's cards due by the given time, most overdue first, with their item titles
+func (r *FlashcardRepository) GetDue(userID int, dueBy time.Time, limit int) ([]*models.Flashcard, error) {
+	query := `
+		SELECT f.id, f.user_id, f.item_id, f.question, f.answer, f.review_count, f.last_grade, f.last_reviewed_at,
+			   f.next_review_at, f.created_at, f.updated_at, i.title
+		FROM flashcards f
+		INNER JOIN items i ON i.id = f.item_id
+		WHERE f.user_id = $1 AND f.next_review_at <= $2
+		ORDER BY f.next_review_at ASC
+		LIMIT $3`
+
+	rows, err := r.db.Query(query, userID, dueBy, limit)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get due flashcards: %w", err)
+	}
+	defer rows.Close()
+
+	cards := []*models.Flashcard{}
+	for rows.Next() {
+		var card models.Flashcard
+		if err := scanFlashcard(rows, &card, &card.ItemTitle); err != nil {
+			return nil, apperr.Errorf("failed to scan flashcard: %w", err)
+		}
+		cards = append(cards, &card)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating due flashcards: %w", err)
+	}
+
+	return cards, nil
+}
+
+// CountDue counts the user's cards due by the given time
+func (r *FlashcardRepository) CountDue(userID int, dueBy time.Time) (int, error) {
+	var count int
+	err := r.db.QueryRow("SELECT COUNT(*) FROM flashcards WHERE user_id = $1 AND next_review_at <= $2", userID, dueBy).Scan(&count)
+	if err != nil {
+		return 0, apperr.Errorf("failed to count due flashcards: %w", err)
+	}
+
+	return count, nil
+}
+
+// Update edits a flashcard's question or answer
+func (r *FlashcardRepository) Update(userID, cardID int, req *models.UpdateFlashcardRequest) (*models.Flashcard, error) {
+	setParts := []string{}
+	args := []interface{}{}
+	argCount := 0
+
+	if req.Question != nil {
+		argCount++
+		setParts = append(setParts, fmt.Sprintf("question = $%d", argCount))
+		args = append(args, *req.Question)
+	}
+
+	if req.Answer != nil {
+		argCount++
+		setParts = append(setParts, fmt.Sprintf("answer = $%d", argCount))
+		args = append(args, *req.Answer)
+	}
+
+	if len(setParts) == 0 {
+		return nil, apperr.Validation("no fields to update")
+	}
+
+	args = append(args, cardID, userID)
+	query := fmt.Sprintf(`
+		UPDATE flashcards
+		SET %s, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $%d AND user_id = $%d
+		RETURNING `+flashcardColumns,
+		strings.Join(setParts, ", "), argCount+1, argCount+2)
+
+	var card models.Flashcard
+	err := scanFlashcard(r.db.QueryRow(query, args...), &card)
+	if err == sql.ErrNoRows {
+		return nil, apperr.NotFound("flashcard not found")
+	}
+	if err != nil {
+		return nil, apperr.Errorf("failed to update flashcard: %w", err)
+	}
+
+	return &card, nil
+}
+
+// RecordGrade stores the outcome of a review and when the card is next due
+func (r *FlashcardRepository) RecordGrade(userID, cardID int, grade models.FlashcardGrade, reviewCount int, nextReviewAt time.Time) (*models.Flashcard, error) {
+	query := `
+		UPDATE flashcards
+		SET last_grade = $1, review_count = $2, next_review_at = $3, last_reviewed_at = CURRENT_TIMESTAMP,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $4 AND user_id = $5
+		RETURNING ` + flashcardColumns
+
+	var card models.Flashcard
+	err := scanFlashcard(r.db.QueryRow(query, grade, reviewCount, nextReviewAt, cardID, userID), &card)
+	if err == sql.ErrNoRows {
+		return nil, apperr.NotFound("flashcard not found")
+	}
+	if err != nil {
+		return nil, apperr.Errorf("failed to record flashcard grade: %w", err)
+	}
+
+	return &card, nil
+}
+
+// Delete removes a flashcard owned by a user
+func (r *FlashcardRepository) Delete(userID, cardID int) error {
+	result, err := r.db.Exec("DELETE FROM flashcards WHERE id = $1 AND user_id = $2", cardID, userID)
+	if err != nil {
+		return apperr.Errorf("failed to delete flashcard: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperr.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperr.NotFound("flashcard not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,294 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+)
+
+// focusIntervalsQuery pairs every start/resume event of a user's sessions with the event that
+// ended it, giving the focused intervals. An interval that is still running ends at $3.
+const focusIntervalsQuery = `
+	WITH intervals AS (
+		SELECT s.item_id, e.kind, e.occurred_at AS began_at,
+			COALESCE(LEAD(e.occurred_at) OVER (PARTITION BY e.session_id ORDER BY e.occurred_at, e.id), $3) AS ended_at
+		FROM focus_session_events e
+		INNER JOIN focus_sessions s ON s.id = e.session_id
+		WHERE s.user_id = $1 AND (s.ended_at IS NULL OR s.ended_at >= $2)
+	)
+	SELECT item_id, began_at, GREATEST(EXTRACT(EPOCH FROM (ended_at - began_at)), 0)::int AS seconds
+	FROM intervals
+	WHERE kind IN ('start', 'resume') AND began_at >= $2`
+
+// FocusSessionRepository handles database operations for timer sessions and their events
+type FocusSessionRepository struct {
+	db *sql.DB
+}
+
+// NewFocusSessionRepository creates a new focus session repository
+func NewFocusSessionRepository(db *sql.DB) *FocusSessionRepository {
+	return &FocusSessionRepository{db: db}
+}
+
+// Start creates a running session and records its start event
+func (r *FocusSessionRepository) Start(session *models.FocusSession, now time.Time) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return apperr.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRow(`
+		INSERT INTO focus_sessions (user_id, item_id, test_session_id, status, started_at, running_since)
+		VALUES ($1, $2, $3, 'running', $4, $4)
+		RETURNING id, status, started_at, running_since, focused_seconds`,
+		session.UserID, session.ItemID, session.TestSessionID, now,
+	).Scan(&session.ID, &session.Status, &session.StartedAt, &session.RunningSince, &session.FocusedSeconds)
+	if err != nil {
+		return apperr.Errorf("failed to start focus session: %w", err)
+	}
+
+	if err := insertFocusEvent(tx, session.ID, models.FocusEventStart, now); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return apperr.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetActive returns the user's running or paused session, or nil if there is none
+func (r *FocusSessionRepository) GetActive(userID int) (*models.FocusSession, error) {
+	query := `
+		SELECT id, user_id, item_id, test_session_id, status, started_at, running_since, focused_seconds, ended_at
+		FROM focus_sessions
+		WHERE user_id = $1 AND status <> 'stopped'`
+
+	session, err := scanFocusSession(r.db.QueryRow(query, userID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, apperr.Errorf("failed to get active focus session: %w", err)
+	}
+
+	return session, nil
+}
+
+// Transition applies a pause, resume or stop to one of the user's sessions and records the event.
+// The session's current status must allow the transition.
+func (r *FocusSessionRepository) Transition(userID, sessionID int, kind models.FocusSessionEventKind, now time.Time) (*models.FocusSession, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, apperr.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	session, err := scanFocusSession(tx.QueryRow(`
+		SELECT id, user_id, item_id, test_session_id, status, started_at, running_since, focused_seconds, ended_at
+		FROM focus_sessions
+		WHERE id = $1 AND user_id = $2
+		FOR UPDATE`, sessionID, userID))
+	if err == sql.ErrNoRows {
+		return nil, apperr.NotFound("session not found")
+	}
+	if err != nil {
+		return nil, apperr.Errorf("failed to get focus session: %w", err)
+	}
+
+	if session.Status == models.FocusSessionStopped {
+		return nil, apperr.Conflict("session already stopped")
+	}
+
+	switch kind {
+	case models.FocusEventPause:
+		if session.Status != models.FocusSessionRunning {
+			return nil, apperr.Conflict("session is not running")
+		}
+		session.FocusedSeconds = session.ElapsedSeconds(now)
+		session.Status = models.FocusSessionPaused
+		session.RunningSince = nil
+	case models.FocusEventResume:
+		if session.Status != models.FocusSessionPaused {
+			return nil, apperr.Conflict("session is not paused")
+		}
+		session.Status = models.FocusSessionRunning
+		session.RunningSince = &now
+	case models.FocusEventStop:
+		session.FocusedSeconds = session.ElapsedSeconds(now)
+		session.Status = models.FocusSessionStopped
+		session.RunningSince = nil
+		session.EndedAt = &now
+	default:
+		return nil, apperr.Validation(fmt.Sprintf("invalid session event: %s", kind))
+	}
+
+	_, err = tx.Exec(`
+		UPDATE focus_sessions
+		SET status = $1, running_since = $2, focused_seconds = $3, ended_at = $4
+		WHERE id = $5`,
+		session.Status, session.RunningSince, session.FocusedSeconds, session.EndedAt, session.ID)
+	if err != nil {
+		return nil, apperr.Errorf("failed to update focus session: %w", err)
+	}
+
+	if err := insertFocusEvent(tx, session.ID, kind, now); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, apperr.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return session, nil
+}
+
+// GetFocusedSeconds returns the user's total focused time from since up to now
+func (r *FocusSessionRepository) GetFocusedSeconds(userID int, since, now time.Time) (int, error) {
+	query := `SELECT COALESCE(SUM(seconds), 0) FROM (` + focusIntervalsQuery + `) focused`
+
+	var seconds int
+	if err := r.db.QueryRow(query, userID, since, now).Scan(&seconds); err != nil {
+		return 0, apperr.Errorf("failed to get focused time: %w", err)
+	}
+
+	return seconds, nil
+}
+
+// GetFocusedTimeByItem returns the user's focused time per item from since up to now, longest first
+func (r *FocusSessionRepository) GetFocusedTimeByItem(userID int, since, now time.Time) ([]*models.ItemFocusTime, error) {
+	query := `
+		SELECT i.id, i.title, i.category, SUM(f.seconds)
+		FROM (` + focusIntervalsQuery + `) f
+		INNER JOIN items i ON i.id = f.item_id
+		GROUP BY i.id, i.title, i.category
+		ORDER BY SUM(f.seconds) DESC, i.id`
+
+	rows, err := r.db.Query(query, userID, since, now)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get focused time by item: %w", err)
+	}
+	defer rows.Close()
+
+	totals := []*models.ItemFocusTime{}
+	for rows.Next() {
+		total := &models.ItemFocusTime{}
+		if err := rows.Scan(&total.ItemID, &total.Title, &total.Category, &total.FocusedSeconds); err != nil {
+			return nil, apperr.Errorf("failed to scan focused time: %w", err)
+		}
+		totals = append(totals, total)
+	}
+
+	return totals, rows.Err()
+}
+
+// GetFocusedTimeByDay returns the user's focused time per day from since up to now. Each
+// interval counts towards the day it began.
+func (r *FocusSessionRepository) GetFocusedTimeByDay(userID int, since, now time.Time) ([]*models.DailyFocusTime, error) {
+	query := `
+		SELECT TO_CHAR(f.began_at::date, 'YYYY-MM-DD'), SUM(f.seconds)
+		FROM (` + focusIntervalsQuery + `) f
+		GROUP BY f.began_at::date
+		ORDER BY f.began_at::date`
+
+	rows, err := r.db.Query(query, userID, since, now)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get focused time by day: %w", err)
+	}
+	defer rows.Close()
+
+	totals := []*models.DailyFocusTime{}
+	for rows.Next() {
+		total := &models.DailyFocusTime{}
+		if err := rows.Scan(&total.Date, &total.FocusedSeconds); err != nil {
+			return nil, apperr.Errorf("failed to scan focused time: %w", err)
+		}
+		totals = append(totals, total)
+	}
+
+	return totals, rows.Err()
+}
+
+// GetFocusedTimeByCategory returns the user's focused time on items per category from since up
+// to now, with how many distinct items the time was spent on
+func (r *FocusSessionRepository) GetFocusedTimeByCategory(userID int, since, now time.Time) ([]models.CategoryTimeSpent, error) {
+	query := `
+		SELECT i.category, SUM(f.seconds), COUNT(DISTINCT i.id)
+		FROM (` + focusIntervalsQuery + `) f
+		INNER JOIN items i ON i.id = f.item_id
+		GROUP BY i.category
+		ORDER BY SUM(f.seconds) DESC, i.category`
+
+	rows, err := r.db.Query(query, userID, since, now)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get focused time by category: %w", err)
+	}
+	defer rows.Close()
+
+	totals := []models.CategoryTimeSpent{}
+	for rows.Next() {
+		var total models.CategoryTimeSpent
+		if err := rows.Scan(&total.Category, &total.TotalSeconds, &total.ItemsTracked); err != nil {
+			return nil, apperr.Errorf("failed to scan focused time: %w", err)
+		}
+		totals = append(totals, total)
+	}
+
+	return totals, rows.Err()
+}
+
+// GetFocusedTimeByWeek returns the user's focused time per ISO week (starting Monday) from since
+// up to now, keyed by the week's start date
+func (r *FocusSessionRepository) GetFocusedTimeByWeek(userID int, since, now time.Time) (map[string]int, error) {
+	query := `
+		SELECT TO_CHAR(DATE_TRUNC('week', f.began_at), 'YYYY-MM-DD'), SUM(f.seconds)
+		FROM (` + focusIntervalsQuery + `) f
+		GROUP BY DATE_TRUNC('week', f.began_at)`
+
+	rows, err := r.db.Query(query, userID, since, now)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get focused time by week: %w", err)
+	}
+	defer rows.Close()
+
+	totals := map[string]int{}
+	for rows.Next() {
+		var week string
+		var seconds int
+		if err := rows.Scan(&week, &seconds); err != nil {
+			return nil, apperr.Errorf("failed to scan focused time: %w", err)
+		}
+		totals[week] = seconds
+	}
+
+	return totals, rows.Err()
+}
+
+// insertFocusEvent records a timer action against a session
+func insertFocusEvent(tx *sql.Tx, sessionID int, kind models.FocusSessionEventKind, at time.Time) error {
+	_, err := tx.Exec("INSERT INTO focus_session_events (session_id, kind, occurred_at) VALUES ($1, $2, $3)", sessionID, kind, at)
+	if err != nil {
+		return apperr.Errorf("failed to record focus session event: %w", err)
+	}
+
+	return nil
+}
+
+// scanFocusSession scans a single focus session row
+func scanFocusSession(scanner interface{ Scan(...interface{}) error }) (*models.FocusSession, error) {
+	session := &models.FocusSession{}
+	err := scanner.Scan(
+		&session.ID, &session.UserID, &session.ItemID, &session.TestSessionID, &session.Status,
+		&session.StartedAt, &session.RunningSince, &session.FocusedSeconds, &session.EndedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	"database/sql"
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+)
+
+// GitHubRepository handles database operations for connected GitHub accounts
+type GitHubRepository struct {
+	db *sql.DB
+}
+
+// NewGitHubRepository creates a new GitHubRepository
+func NewGitHubRepository(db *sql.DB) *GitHubRepository {
+	return &GitHubRepository{db: db}
+}
+
+// Get returns the user's GitHub connection with its encrypted token, or nil if they haven't connected
+func (r *GitHubRepository) Get(userID int) (*models.GitHubConnection, error) {
+	query := `
+		SELECT user_id, github_login, access_token, repository, branch, path_template, created_at, updated_at
+		FROM github_connections
+		WHERE user_id = $1`
+
+	conn := &models.GitHubConnection{}
+	err := r.db.QueryRow(query, userID).Scan(
+		&conn.UserID, &conn.Login, &conn.AccessToken, &conn.Repository, &conn.Branch,
+		&conn.PathTemplate, &conn.ConnectedAt, &conn.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, apperr.Errorf("failed to get GitHub connection: %w", err)
+	}
+
+	return conn, nil
+}
+
+// SaveAccount stores a newly authorized account and its encrypted token. Reconnecting the same
+// account keeps the chosen repository; connecting a different one clears it.
+func (r *GitHubRepository) SaveAccount(userID int, login string, encryptedToken []byte) error {
+	query := `
+		INSERT INTO github_connections (user_id, github_login, access_token, created_at, updated_at)
+		VALUES ($1, $2, $3, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		ON CONFLICT (user_id)
+		DO UPDATE SET access_token = EXCLUDED.access_token, updated_at = CURRENT_TIMESTAMP,
+			repository = CASE WHEN github_connections.github_login = EXCLUDED.github_login THEN github_connections.repository ELSE '' END,
+			branch = CASE WHEN github_connections.github_login = EXCLUDED.github_login THEN github_connections.branch ELSE '' END,
+			github_login = EXCLUDED.github_login`
+
+	if _, err := r.db.Exec(query, userID, login, encryptedToken); err != nil {
+		return apperr.Errorf("failed to save GitHub connection: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateRepository sets the repository, branch and path template solutions are committed to
+func (r *GitHubRepository) UpdateRepository(conn *models.GitHubConnection) error {
+	query := `
+		UPDATE github_connections
+		SET repository = $2, branch = $3, path_template = $4, updated_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1
+		RETURNING updated_at`
+
+	err := r.db.QueryRow(query, conn.UserID, conn.Repository, conn.Branch, conn.PathTemplate).Scan(&conn.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return apperr.NotFound("GitHub account not connected")
+	}
+	if err != nil {
+		return apperr.Errorf("failed to update GitHub repository: %w", err)
+	}
+
+	return nil
+}
+
+// Delete disconnects the user's GitHub account
+func (r *GitHubRepository) Delete(userID int) error {
+	result, err := r.db.Exec("DELETE FROM github_connections WHERE user_id = $1", userID)
+	if err != nil {
+		return apperr.Errorf("failed to delete GitHub connection: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperr.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperr.NotFound("GitHub account not connected")
+	}
+
+	return nil
+}
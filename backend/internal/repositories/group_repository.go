@@ -0,0 +1,353 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+)
+
+// GroupRepository handles database operations for study groups
+type GroupRepository struct {
+	db *sql.DB
+}
+
+// NewGroupRepository creates a new group repository
+func NewGroupRepository(db *sql.DB) *GroupRepository {
+	return &GroupRepository{db: db}
+}
+
+// Create creates a study group and adds its owner as the first member
+func (r *GroupRepository) Create(group *models.StudyGroup) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return apperr.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRow(`
+		INSERT INTO study_groups (name, description, owner_id, invite_code)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at`,
+		group.Name, group.Description, group.OwnerID, group.InviteCode,
+	).Scan(&group.ID, &group.CreatedAt)
+	if err != nil {
+		return apperr.Errorf("failed to create group: %w", err)
+	}
+
+	_, err = tx.Exec(
+		"INSERT INTO study_group_members (group_id, user_id, role) VALUES ($1, $2, $3)",
+		group.ID, group.OwnerID, models.OrgRoleOwner,
+	)
+	if err != nil {
+		return apperr.Errorf("failed to add group owner: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return apperr.Errorf("failed to commit transaction: %w", err)
+	}
+
+	group.MemberCount = 1
+	return nil
+}
+
+// GetByID retrieves a study group by its ID
+func (r *GroupRepository) GetByID(id int) (*models.StudyGroup, error) {
+	return r.getOne("g.id = $1", id)
+}
+
+// GetByInviteCode retrieves a study group by its invite code
+func (r *GroupRepository) GetByInviteCode(code string) (*models.StudyGroup, error) {
+	return r.getOne("g.invite_code = $1", code)
+}
+
+// getOne retrieves a single study group matching the condition
+func (r *GroupRepository) getOne(condition string, arg interface{}) (*models.StudyGroup, error) {
+	query := fmt.Sprintf(`
+		SELECT g.id, g.name, COALESCE(g.description, ''), g.owner_id, g.invite_code, g.created_at,
+			(SELECT COUNT(*) FROM study_group_members m WHERE m.group_id = g.id)
+		FROM study_groups g
+		WHERE %s`, condition)
+
+	var group models.StudyGroup
+	err := r.db.QueryRow(query, arg).Scan(
+		&group.ID, &group.Name, &group.Description, &group.OwnerID, &group.InviteCode, &group.CreatedAt,
+		&group.MemberCount,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, apperr.NotFound("group not found")
+	}
+	if err != nil {
+		return nil, apperr.Errorf("failed to get group: %w", err)
+	}
+
+	return &group, nil
+}
+
+// GetForUser retrieves every group the user belongs to
+func (r *GroupRepository) GetForUser(userID int) ([]*models.StudyGroup, error) {
+	query := `
+		SELECT g.id, g.name, COALESCE(g.description, ''), g.owner_id, g.invite_code, g.created_at,
+			(SELECT COUNT(*) FROM study_group_members c WHERE c.group_id = g.id)
+		FROM study_groups g
+		INNER JOIN study_group_members m ON m.group_id = g.id AND m.user_id = $1
+		ORDER BY g.created_at DESC`
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get groups: %w", err)
+	}
+	defer rows.Close()
+
+	groups := []*models.StudyGroup{}
+	for rows.Next() {
+		var group models.StudyGroup
+		err := rows.Scan(
+			&group.ID, &group.Name, &group.Description, &group.OwnerID, &group.InviteCode, &group.CreatedAt,
+			&group.MemberCount,
+		)
+		if err != nil {
+			return nil, apperr.Errorf("failed to scan group: %w", err)
+		}
+		groups = append(groups, &group)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating groups: %w", err)
+	}
+
+	return groups, nil
+}
+
+// IsMember checks whether a user belongs to a group
+func (r *GroupRepository) IsMember(groupID, userID int) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(
+		"SELECT EXISTS(SELECT 1 FROM study_group_members WHERE group_id = $1 AND user_id = $2)", groupID, userID,
+	).Scan(&exists)
+	if err != nil {
+		return false, apperr.Errorf("failed to check group membership: %w", err)
+	}
+
+	return exists, nil
+}
+
+// AddMember adds a user to a group; joining a group twice is a no-op
+func (r *GroupRepository) AddMember(groupID, userID int) error {
+	_, err := r.db.Exec(`
+		INSERT INTO study_group_members (group_id, user_id, role)
+		VALUES ($1, $2, 'member')
+		ON CONFLICT (group_id, user_id) DO NOTHING`,
+		groupID, userID)
+	if err != nil {
+		return apperr.Errorf("failed to join group: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveMember removes a user from a group
+func (r *GroupRepository) RemoveMember(groupID, userID int) error {
+	result, err := r.db.Exec("DELETE FROM study_group_members WHERE group_id = $1 AND user_id = $2", groupID, userID)
+	if err != nil {
+		return apperr.Errorf("failed to leave group: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperr.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperr.Conflict("not a group member")
+	}
+
+	return nil
+}
+
+// GetMembers retrieves a group's members in join order
+func (r *GroupRepository) GetMembers(groupID int) ([]models.GroupMember, error) {
+	query := `
+		SELECT u.id, u.name, COALESCE(u.avatar, ''), m.role, m.joined_at
+		FROM study_group_members m
+		INNER JOIN users u ON u.id = m.user_id
+		WHERE m.group_id = $1
+		ORDER BY m.joined_at ASC`
+
+	rows, err := r.db.Query(query, groupID)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get group members: %w", err)
+	}
+	defer rows.Close()
+
+	members := []models.GroupMember{}
+	for rows.Next() {
+		var member models.GroupMember
+		if err := rows.Scan(&member.UserID, &member.Name, &member.Avatar, &member.Role, &member.JoinedAt); err != nil {
+			return nil, apperr.Errorf("failed to scan group member: %w", err)
+		}
+		members = append(members, member)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating group members: %w", err)
+	}
+
+	return members, nil
+}
+
+// GetLeaderboard ranks a group's members by completed items, breaking ties on current streak
+// (excluding miscellaneous category). Completed counts come from the aggregates the stats
+// worker keeps in user_stats, so they can briefly lag a member's latest completions.
+func (r *GroupRepository) GetLeaderboard(groupID int) ([]models.GroupLeaderboardEntry, error) {
+	query := `
+		SELECT u.id, u.name, COALESCE(u.avatar, ''),
+			COALESCE(us.completed_items, 0) as completed,
+			COALESCE(us.current_streak, 0) as current_streak
+		FROM study_group_members m
+		INNER JOIN users u ON u.id = m.user_id
+		LEFT JOIN user_stats us ON us.user_id = u.id
+		WHERE m.group_id = $1
+		ORDER BY completed DESC, current_streak DESC, u.name ASC`
+
+	rows, err := r.db.Query(query, groupID)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get group leaderboard: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []models.GroupLeaderboardEntry{}
+	for rows.Next() {
+		var entry models.GroupLeaderboardEntry
+		if err := rows.Scan(&entry.UserID, &entry.Name, &entry.Avatar, &entry.CompletedItems, &entry.CurrentStreak); err != nil {
+			return nil, apperr.Errorf("failed to scan leaderboard entry: %w", err)
+		}
+		entry.Rank = len(entries) + 1
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating leaderboard: %w", err)
+	}
+
+	return entries, nil
+}
+
+// CountActiveToday returns how many group members recorded activity today
+func (r *GroupRepository) CountActiveToday(groupID int) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM study_group_members m
+		INNER JOIN user_stats us ON us.user_id = m.user_id
+		WHERE m.group_id = $1 AND us.last_activity_date = CURRENT_DATE`
+
+	var count int
+	if err := r.db.QueryRow(query, groupID).Scan(&count); err != nil {
+		return 0, apperr.Errorf("failed to count active members: %w", err)
+	}
+
+	return count, nil
+}
+
+// CreateItemList shares an ordered list of items with a group
+func (r *GroupRepository) CreateItemList(list *models.GroupItemList, itemIDs []int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return apperr.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRow(`
+		INSERT INTO study_group_item_lists (group_id, created_by, name)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at`,
+		list.GroupID, list.CreatedBy, list.Name,
+	).Scan(&list.ID, &list.CreatedAt)
+	if err != nil {
+		return apperr.Errorf("failed to create item list: %w", err)
+	}
+
+	for position, itemID := range itemIDs {
+		_, err := tx.Exec(`
+			INSERT INTO study_group_list_items (list_id, item_id, position)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (list_id, item_id) DO NOTHING`,
+			list.ID, itemID, position)
+		if err != nil {
+			return apperr.Errorf("failed to add item %d to list: %w", itemID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return apperr.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetItemLists retrieves a group's shared item lists with their items
+func (r *GroupRepository) GetItemLists(groupID int) ([]*models.GroupItemList, error) {
+	rows, err := r.db.Query(`
+		SELECT id, group_id, COALESCE(created_by, 0), name, created_at
+		FROM study_group_item_lists
+		WHERE group_id = $1
+		ORDER BY created_at DESC`, groupID)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get item lists: %w", err)
+	}
+	defer rows.Close()
+
+	lists := []*models.GroupItemList{}
+	byID := map[int]*models.GroupItemList{}
+	for rows.Next() {
+		list := &models.GroupItemList{Items: []models.Item{}}
+		if err := rows.Scan(&list.ID, &list.GroupID, &list.CreatedBy, &list.Name, &list.CreatedAt); err != nil {
+			return nil, apperr.Errorf("failed to scan item list: %w", err)
+		}
+		lists = append(lists, list)
+		byID[list.ID] = list
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating item lists: %w", err)
+	}
+
+	if len(lists) == 0 {
+		return lists, nil
+	}
+
+	itemRows, err := r.db.Query(`
+		SELECT li.list_id, i.id, i.title, i.link, i.category, i.subcategory, i.attachments, i.created_at
+		FROM study_group_list_items li
+		INNER JOIN study_group_item_lists l ON l.id = li.list_id
+		INNER JOIN items i ON i.id = li.item_id
+		WHERE l.group_id = $1
+		ORDER BY li.list_id, li.position`, groupID)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get list items: %w", err)
+	}
+	defer itemRows.Close()
+
+	for itemRows.Next() {
+		var listID int
+		var item models.Item
+		err := itemRows.Scan(
+			&listID, &item.ID, &item.Title, &item.Link, &item.Category, &item.Subcategory,
+			&item.Attachments, &item.CreatedAt,
+		)
+		if err != nil {
+			return nil, apperr.Errorf("failed to scan list item: %w", err)
+		}
+		if list, ok := byID[listID]; ok {
+			list.Items = append(list.Items, item)
+		}
+	}
+
+	if err := itemRows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating list items: %w", err)
+	}
+
+	return lists, nil
+}
@@ -0,0 +1,220 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+)
+
+// HintRepository handles database operations for item hint ladders
+type HintRepository struct {
+	db *sql.DB
+}
+
+// NewHintRepository creates a new hint repository
+func NewHintRepository(db *sql.DB) *HintRepository {
+	return &HintRepository{db: db}
+}
+
+// Create adds a hint to an item, appending it to the end of the ladder when no position is given
+func (r *HintRepository) Create(itemID int, req *models.CreateHintRequest) (*models.ItemHint, error) {
+	query := `
+		INSERT INTO item_hints (item_id, position, content)
+		VALUES ($1, COALESCE($2, (SELECT COALESCE(MAX(position), 0) + 1 FROM item_hints WHERE item_id = $1)), $3)
+		RETURNING id, item_id, position, content, created_at, updated_at`
+
+	var hint models.ItemHint
+	err := r.db.QueryRow(query, itemID, req.Position, req.Content).Scan(
+		&hint.ID, &hint.ItemID, &hint.Position, &hint.Content, &hint.CreatedAt, &hint.UpdatedAt,
+	)
+
+	if err != nil {
+		return nil, apperr.Errorf("failed to create hint: %w", err)
+	}
+
+	return &hint, nil
+}
+
+// Update edits a hint's content or position
+func (r *HintRepository) Update(itemID, hintID int, req *models.UpdateHintRequest) (*models.ItemHint, error) {
+	setParts := []string{}
+	args := []interface{}{}
+	argCount := 0
+
+	if req.Content != nil {
+		argCount++
+		setParts = append(setParts, fmt.Sprintf("content = $%d", argCount))
+		args = append(args, *req.Content)
+	}
+
+	if req.Position != nil {
+		argCount++
+		setParts = append(setParts, fmt.Sprintf("position = $%d", argCount))
+		args = append(args, *req.Position)
+	}
+
+	if len(setParts) == 0 {
+		return nil, apperr.Validation("no fields to update")
+	}
+
+	args = append(args, hintID, itemID)
+	query := fmt.Sprintf(`
+		UPDATE item_hints
+		SET %s, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $%d AND item_id = $%d
+		RETURNING id, item_id, position, content, created_at, updated_at`,
+		strings.Join(setParts, ", "), argCount+1, argCount+2)
+
+	var hint models.ItemHint
+	err := r.db.QueryRow(query, args...).Scan(
+		&hint.ID, &hint.ItemID, &hint.Position, &hint.Content, &hint.CreatedAt, &hint.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, apperr.NotFound("hint not found")
+	}
+	if err != nil {
+		return nil, apperr.Errorf("failed to update hint: %w", err)
+	}
+
+	return &hint, nil
+}
+
+// Delete removes a hint from an item
+func (r *HintRepository) Delete(itemID, hintID int) error {
+	result, err := r.db.Exec("DELETE FROM item_hints WHERE id = $1 AND item_id = $2", hintID, itemID)
+	if err != nil {
+		return apperr.Errorf("failed to delete hint: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperr.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperr.NotFound("hint not found")
+	}
+
+	return nil
+}
+
+// GetByItem retrieves every hint for an item in ladder order
+func (r *HintRepository) GetByItem(itemID int) ([]models.ItemHint, error) {
+	query := `
+		SELECT id, item_id, position, content, created_at, updated_at
+		FROM item_hints
+		WHERE item_id = $1
+		ORDER BY position ASC, id ASC`
+
+	rows, err := r.db.Query(query, itemID)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get hints: %w", err)
+	}
+	defer rows.Close()
+
+	return scanHints(rows)
+}
+
+// GetRevealedForUser retrieves the hints a user has revealed and the item's total hint count
+func (r *HintRepository) GetRevealedForUser(userID, itemID int) ([]models.ItemHint, int, error) {
+	var total int
+	err := r.db.QueryRow("SELECT COUNT(*) FROM item_hints WHERE item_id = $1", itemID).Scan(&total)
+	if err != nil {
+		return nil, 0, apperr.Errorf("failed to count hints: %w", err)
+	}
+
+	query := `
+		SELECT h.id, h.item_id, h.position, h.content, h.created_at, h.updated_at
+		FROM item_hints h
+		INNER JOIN user_hint_reveals r ON r.hint_id = h.id AND r.user_id = $1
+		WHERE h.item_id = $2
+		ORDER BY h.position ASC, h.id ASC`
+
+	rows, err := r.db.Query(query, userID, itemID)
+	if err != nil {
+		return nil, 0, apperr.Errorf("failed to get revealed hints: %w", err)
+	}
+	defer rows.Close()
+
+	hints, err := scanHints(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return hints, total, nil
+}
+
+// RevealNext reveals the next hint on the ladder for a user
+func (r *HintRepository) RevealNext(userID, itemID int) (*models.ItemHint, error) {
+	query := `
+		INSERT INTO user_hint_reveals (user_id, item_id, hint_id)
+		SELECT $1, h.item_id, h.id
+		FROM item_hints h
+		WHERE h.item_id = $2
+		AND NOT EXISTS (
+			SELECT 1 FROM user_hint_reveals r WHERE r.hint_id = h.id AND r.user_id = $1
+		)
+		ORDER BY h.position ASC, h.id ASC
+		LIMIT 1
+		ON CONFLICT (user_id, hint_id) DO NOTHING
+		RETURNING hint_id`
+
+	var hintID int
+	err := r.db.QueryRow(query, userID, itemID).Scan(&hintID)
+	if err == sql.ErrNoRows {
+		return nil, apperr.Conflict("no more hints to reveal")
+	}
+	if err != nil {
+		return nil, apperr.Errorf("failed to reveal hint: %w", err)
+	}
+
+	var hint models.ItemHint
+	err = r.db.QueryRow(
+		"SELECT id, item_id, position, content, created_at, updated_at FROM item_hints WHERE id = $1", hintID,
+	).Scan(&hint.ID, &hint.ItemID, &hint.Position, &hint.Content, &hint.CreatedAt, &hint.UpdatedAt)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get revealed hint: %w", err)
+	}
+
+	return &hint, nil
+}
+
+// CountRevealedForUser returns how many of an item's hints a user has revealed, and how many
+// hints the item has
+func (r *HintRepository) CountRevealedForUser(userID, itemID int) (int, int, error) {
+	var revealed, total int
+	err := r.db.QueryRow(`
+		SELECT COUNT(r.hint_id), COUNT(h.id)
+		FROM item_hints h
+		LEFT JOIN user_hint_reveals r ON r.hint_id = h.id AND r.user_id = $1
+		WHERE h.item_id = $2`, userID, itemID,
+	).Scan(&revealed, &total)
+	if err != nil {
+		return 0, 0, apperr.Errorf("failed to count revealed hints: %w", err)
+	}
+
+	return revealed, total, nil
+}
+
+// scanHints scans hint rows
+func scanHints(rows *sql.Rows) ([]models.ItemHint, error) {
+	hints := []models.ItemHint{}
+	for rows.Next() {
+		var hint models.ItemHint
+		err := rows.Scan(&hint.ID, &hint.ItemID, &hint.Position, &hint.Content, &hint.CreatedAt, &hint.UpdatedAt)
+		if err != nil {
+			return nil, apperr.Errorf("failed to scan hint: %w", err)
+		}
+		hints = append(hints, hint)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating hints: %w", err)
+	}
+
+	return hints, nil
+}
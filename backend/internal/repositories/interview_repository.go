@@ -0,0 +1,384 @@
+package repositories
+
+import (
+	"database/sql"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/lib/pq"
+)
+
+// InterviewRepository handles database operations for a user's interview pipeline
+type InterviewRepository struct {
+	db *sql.DB
+}
+
+// NewInterviewRepository creates a new interview repository
+func NewInterviewRepository(db *sql.DB) *InterviewRepository {
+	return &InterviewRepository{db: db}
+}
+
+// Create records a new interview
+func (r *InterviewRepository) Create(interview *models.Interview) error {
+	query := `
+		INSERT INTO interviews (user_id, company_id, company_name, role, status, applied_at, notes)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at, updated_at`
+
+	err := r.db.QueryRow(
+		query,
+		interview.UserID,
+		interview.CompanyID,
+		interview.CompanyName,
+		interview.Role,
+		interview.Status,
+		interview.AppliedAt,
+		interview.Notes,
+	).Scan(&interview.ID, &interview.CreatedAt, &interview.UpdatedAt)
+
+	if err != nil {
+		return apperr.Errorf("failed to create interview: %w", err)
+	}
+
+	return nil
+}
+
+// GetForUser retrieves all of a user's interviews with their stages and prep lists, most recent first
+func (r *InterviewRepository) GetForUser(userID int) ([]*models.Interview, error) {
+	query := `
+		SELECT id, user_id, company_id, company_name, role, status, applied_at, notes, created_at, updated_at
+		FROM interviews
+		WHERE user_id = $1
+		ORDER BY COALESCE(applied_at, created_at) DESC, id DESC`
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get interviews: %w", err)
+	}
+	defer rows.Close()
+
+	interviews := []*models.Interview{}
+	for rows.Next() {
+		interview, err := scanInterview(rows)
+		if err != nil {
+			return nil, err
+		}
+		interviews = append(interviews, interview)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating interviews: %w", err)
+	}
+
+	if err := r.loadStages(userID, interviews); err != nil {
+		return nil, err
+	}
+
+	return interviews, nil
+}
+
+// GetByID retrieves one of a user's interviews with its stages and prep lists
+func (r *InterviewRepository) GetByID(userID, interviewID int) (*models.Interview, error) {
+	query := `
+		SELECT id, user_id, company_id, company_name, role, status, applied_at, notes, created_at, updated_at
+		FROM interviews
+		WHERE id = $1 AND user_id = $2`
+
+	interview, err := scanInterview(r.db.QueryRow(query, interviewID, userID))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.loadStages(userID, []*models.Interview{interview}); err != nil {
+		return nil, err
+	}
+
+	return interview, nil
+}
+
+// Update saves the editable fields of one of a user's interviews
+func (r *InterviewRepository) Update(interview *models.Interview) error {
+	query := `
+		UPDATE interviews
+		SET company_id = $1, company_name = $2, role = $3, status = $4, applied_at = $5, notes = $6,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $7 AND user_id = $8
+		RETURNING updated_at`
+
+	err := r.db.QueryRow(
+		query,
+		interview.CompanyID,
+		interview.CompanyName,
+		interview.Role,
+		interview.Status,
+		interview.AppliedAt,
+		interview.Notes,
+		interview.ID,
+		interview.UserID,
+	).Scan(&interview.UpdatedAt)
+
+	if err == sql.ErrNoRows {
+		return apperr.NotFound("interview not found")
+	}
+	if err != nil {
+		return apperr.Errorf("failed to update interview: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes one of a user's interviews along with its stages
+func (r *InterviewRepository) Delete(userID, interviewID int) error {
+	result, err := r.db.Exec("DELETE FROM interviews WHERE id = $1 AND user_id = $2", interviewID, userID)
+	if err != nil {
+		return apperr.Errorf("failed to delete interview: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperr.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperr.NotFound("interview not found")
+	}
+
+	return nil
+}
+
+// CreateStage appends a stage to an interview, optionally with its prep list
+func (r *InterviewRepository) CreateStage(stage *models.InterviewStage, itemIDs []int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return apperr.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRow(`
+		INSERT INTO interview_stages (interview_id, kind, name, position, scheduled_at, outcome, notes)
+		VALUES ($1, $2, $3,
+			(SELECT COALESCE(MAX(position) + 1, 0) FROM interview_stages WHERE interview_id = $1),
+			$4, $5, $6)
+		RETURNING id, position, created_at, updated_at`,
+		stage.InterviewID, stage.Kind, stage.Name, stage.ScheduledAt, stage.Outcome, stage.Notes,
+	).Scan(&stage.ID, &stage.Position, &stage.CreatedAt, &stage.UpdatedAt)
+	if err != nil {
+		return apperr.Errorf("failed to create interview stage: %w", err)
+	}
+
+	if err := insertStageItems(tx, stage.ID, itemIDs); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec("UPDATE interviews SET updated_at = CURRENT_TIMESTAMP WHERE id = $1", stage.InterviewID); err != nil {
+		return apperr.Errorf("failed to touch interview: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return apperr.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateStage saves the editable fields of a stage belonging to an interview
+func (r *InterviewRepository) UpdateStage(stage *models.InterviewStage) error {
+	query := `
+		UPDATE interview_stages
+		SET kind = $1, name = $2, position = $3, scheduled_at = $4, outcome = $5, notes = $6,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $7 AND interview_id = $8
+		RETURNING updated_at`
+
+	err := r.db.QueryRow(
+		query,
+		stage.Kind,
+		stage.Name,
+		stage.Position,
+		stage.ScheduledAt,
+		stage.Outcome,
+		stage.Notes,
+		stage.ID,
+		stage.InterviewID,
+	).Scan(&stage.UpdatedAt)
+
+	if err == sql.ErrNoRows {
+		return apperr.NotFound("stage not found")
+	}
+	if err != nil {
+		return apperr.Errorf("failed to update interview stage: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteStage removes a stage from an interview
+func (r *InterviewRepository) DeleteStage(interviewID, stageID int) error {
+	result, err := r.db.Exec("DELETE FROM interview_stages WHERE id = $1 AND interview_id = $2", stageID, interviewID)
+	if err != nil {
+		return apperr.Errorf("failed to delete interview stage: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperr.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperr.NotFound("stage not found")
+	}
+
+	return nil
+}
+
+// SetStageItems replaces a stage's prep list with the given items, in order
+func (r *InterviewRepository) SetStageItems(stageID int, itemIDs []int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return apperr.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM interview_stage_items WHERE stage_id = $1", stageID); err != nil {
+		return apperr.Errorf("failed to clear stage items: %w", err)
+	}
+
+	if err := insertStageItems(tx, stageID, itemIDs); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return apperr.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// insertStageItems adds items to a stage's prep list in the given order
+func insertStageItems(tx *sql.Tx, stageID int, itemIDs []int) error {
+	for position, itemID := range itemIDs {
+		_, err := tx.Exec(`
+			INSERT INTO interview_stage_items (stage_id, item_id, position)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (stage_id, item_id) DO NOTHING`,
+			stageID, itemID, position)
+		if err != nil {
+			return apperr.Errorf("failed to add item %d to stage: %w", itemID, err)
+		}
+	}
+
+	return nil
+}
+
+// loadStages fills in the stages of the given interviews, each with its prep items and the
+// user's progress on them
+func (r *InterviewRepository) loadStages(userID int, interviews []*models.Interview) error {
+	if len(interviews) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(interviews))
+	byID := make(map[int]*models.Interview, len(interviews))
+	for i, interview := range interviews {
+		interview.Stages = []*models.InterviewStage{}
+		ids[i] = int64(interview.ID)
+		byID[interview.ID] = interview
+	}
+
+	rows, err := r.db.Query(`
+		SELECT id, interview_id, kind, name, position, scheduled_at, outcome, notes, created_at, updated_at
+		FROM interview_stages
+		WHERE interview_id = ANY($1)
+		ORDER BY interview_id, position, id`, pq.Array(ids))
+	if err != nil {
+		return apperr.Errorf("failed to get interview stages: %w", err)
+	}
+	defer rows.Close()
+
+	stageIDs := []int64{}
+	stagesByID := map[int]*models.InterviewStage{}
+	for rows.Next() {
+		stage := &models.InterviewStage{PrepItems: []*models.ItemWithProgress{}}
+		err := rows.Scan(
+			&stage.ID, &stage.InterviewID, &stage.Kind, &stage.Name, &stage.Position, &stage.ScheduledAt,
+			&stage.Outcome, &stage.Notes, &stage.CreatedAt, &stage.UpdatedAt,
+		)
+		if err != nil {
+			return apperr.Errorf("failed to scan interview stage: %w", err)
+		}
+		if interview, ok := byID[stage.InterviewID]; ok {
+			interview.Stages = append(interview.Stages, stage)
+		}
+		stageIDs = append(stageIDs, int64(stage.ID))
+		stagesByID[stage.ID] = stage
+	}
+
+	if err := rows.Err(); err != nil {
+		return apperr.Errorf("error iterating interview stages: %w", err)
+	}
+
+	if len(stageIDs) == 0 {
+		return nil
+	}
+
+	query := `
+		SELECT
+			si.stage_id, i.id, i.title, i.link, i.category, i.subcategory, i.attachments, i.created_at,
+			COALESCE(up.status, 'pending') as status,
+			COALESCE(up.starred, false) as starred,
+			up.completed_at
+		FROM interview_stage_items si
+		INNER JOIN items i ON i.id = si.item_id
+		LEFT JOIN user_progress up
+			ON i.id = up.item_id AND up.user_id = $1
+		WHERE si.stage_id = ANY($2)
+		ORDER BY si.stage_id, si.position`
+
+	return withUserContext(r.db, userID, func(q dbtx) error {
+		itemRows, err := q.Query(query, userID, pq.Array(stageIDs))
+		if err != nil {
+			return apperr.Errorf("failed to get stage items: %w", err)
+		}
+		defer itemRows.Close()
+
+		for itemRows.Next() {
+			var stageID int
+			item := &models.ItemWithProgress{}
+			err := itemRows.Scan(
+				&stageID, &item.ID, &item.Title, &item.Link, &item.Category, &item.Subcategory,
+				&item.Attachments, &item.CreatedAt, &item.Status, &item.Starred, &item.CompletedAt,
+			)
+			if err != nil {
+				return apperr.Errorf("failed to scan stage item: %w", err)
+			}
+			if stage, ok := stagesByID[stageID]; ok {
+				stage.PrepItems = append(stage.PrepItems, item)
+			}
+		}
+
+		if err := itemRows.Err(); err != nil {
+			return apperr.Errorf("error iterating stage items: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// scanInterview scans a single interview row
+func scanInterview(scanner interface{ Scan(...interface{}) error }) (*models.Interview, error) {
+	interview := &models.Interview{}
+	err := scanner.Scan(
+		&interview.ID, &interview.UserID, &interview.CompanyID, &interview.CompanyName, &interview.Role,
+		&interview.Status, &interview.AppliedAt, &interview.Notes, &interview.CreatedAt, &interview.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, apperr.NotFound("interview not found")
+	}
+	if err != nil {
+		return nil, apperr.Errorf("failed to scan interview: %w", err)
+	}
+
+	return interview, nil
+}
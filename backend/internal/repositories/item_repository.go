@@ -3,10 +3,14 @@ package repositories
 import (
 	"database/sql"
 	"fmt"
+	"math/rand"
 	"strings"
 	"time"
 
 	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/lib/pq"
 )
 
 // ItemRepository handles database operations for items
@@ -14,6 +18,10 @@ type ItemRepository struct {
 	db *sql.DB
 }
 
+// publishedCondition is appended to item queries (items aliased as i) to leave out drafts, which
+// only editors see
+const publishedCondition = " AND i.published"
+
 // NewItemRepository creates a new item repository
 func NewItemRepository(db *sql.DB) *ItemRepository {
 	return &ItemRepository{db: db}
@@ -28,99 +36,156 @@ func (r *ItemRepository) Create(req *models.CreateItemRequest) (*models.Item, er
 	}
 
 	query := `
-		INSERT INTO items (title, link, category, subcategory, attachments) 
-		VALUES ($1, $2, $3, $4, $5) 
-		RETURNING id, title, link, category, subcategory, attachments, created_at`
+		INSERT INTO items (title, link, category, subcategory, attachments, source_article_id, published) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7) 
+		RETURNING id, title, link, category, subcategory, attachments, version, created_at, NOT published, publish_at`
 
 	var item models.Item
-	err := r.db.QueryRow(query, req.Title, req.Link, req.Category, req.Subcategory, attachments).Scan(
+	err := r.db.QueryRow(query, req.Title, req.Link, req.Category, req.Subcategory, attachments, req.SourceArticleID, !req.Draft).Scan(
 		&item.ID, &item.Title, &item.Link, &item.Category, &item.Subcategory,
-		&item.Attachments, &item.CreatedAt,
+		&item.Attachments, &item.Version, &item.CreatedAt, &item.Draft, &item.PublishAt,
 	)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to create item: %w", err)
+		return nil, apperr.Errorf("failed to create item: %w", err)
 	}
 
 	return &item, nil
 }
 
-// GetByID retrieves an item by its ID
-func (r *ItemRepository) GetByID(id int) (*models.Item, error) {
+// GetBySourceArticle retrieves the item an eng blog article was promoted to
+func (r *ItemRepository) GetBySourceArticle(articleID int) (*models.Item, error) {
 	query := `
 		SELECT id, title, link, category, subcategory, attachments, created_at 
 		FROM items 
-		WHERE id = $1`
+		WHERE source_article_id = $1`
 
 	var item models.Item
-	err := r.db.QueryRow(query, id).Scan(
+	err := r.db.QueryRow(query, articleID).Scan(
 		&item.ID, &item.Title, &item.Link, &item.Category, &item.Subcategory,
 		&item.Attachments, &item.CreatedAt,
 	)
 
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("item not found")
+		return nil, apperr.NotFound("item not found")
 	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to get item: %w", err)
+		return nil, apperr.Errorf("failed to get item by source article: %w", err)
 	}
 
 	return &item, nil
 }
 
-// GetByIDWithUserProgress retrieves an item by its ID with user-specific progress data
-func (r *ItemRepository) GetByIDWithUserProgress(userID, itemID int) (*models.ItemWithProgress, error) {
+// ExistsByLink reports whether an item with the given link already exists
+func (r *ItemRepository) ExistsByLink(link string) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM items WHERE link = $1)`
+
+	var exists bool
+	if err := r.db.QueryRow(query, link).Scan(&exists); err != nil {
+		return false, apperr.Errorf("failed to check item link: %w", err)
+	}
+
+	return exists, nil
+}
+
+// GetDuplicateCandidates returns every item's ID, title, link and category for checking new
+// items against
+func (r *ItemRepository) GetDuplicateCandidates() ([]*models.Item, error) {
+	rows, err := r.db.Query("SELECT id, title, link, category FROM items ORDER BY id")
+	if err != nil {
+		return nil, apperr.Errorf("failed to get items for duplicate check: %w", err)
+	}
+	defer rows.Close()
+
+	items := []*models.Item{}
+	for rows.Next() {
+		var item models.Item
+		if err := rows.Scan(&item.ID, &item.Title, &item.Link, &item.Category); err != nil {
+			return nil, apperr.Errorf("failed to scan item: %w", err)
+		}
+		items = append(items, &item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating items: %w", err)
+	}
+
+	return items, nil
+}
+
+// GetByID retrieves an item by its ID, drafts included. It is for editors and admins; what users
+// act on goes through GetPublishedByID.
+func (r *ItemRepository) GetByID(id int) (*models.Item, error) {
+	return r.getByID(id, "")
+}
+
+// GetPublishedByID retrieves a published item by its ID; drafts are not found
+func (r *ItemRepository) GetPublishedByID(id int) (*models.Item, error) {
+	return r.getByID(id, " AND published")
+}
+
+// getByID retrieves an item by its ID matching conds, a series of " AND ..." clauses
+func (r *ItemRepository) getByID(id int, conds string) (*models.Item, error) {
 	query := `
-		SELECT 
-			i.id, i.title, i.link, i.category, i.subcategory, i.attachments, i.created_at,
-			COALESCE(up.status, 'pending') as status,
-			COALESCE(up.starred, false) as starred,
-			COALESCE(up.notes, '') as notes,
-			up.completed_at
-		FROM items i
-		LEFT JOIN user_progress up 
-			ON i.id = up.item_id AND up.user_id = $1
-		WHERE i.id = $2`
+		SELECT id, title, link, category, subcategory, attachments, version, created_at, NOT published, publish_at 
+		FROM items 
+		WHERE id = $1` + conds
 
-	var item models.ItemWithProgress
-	err := r.db.QueryRow(query, userID, itemID).Scan(
+	var item models.Item
+	err := r.db.QueryRow(query, id).Scan(
 		&item.ID, &item.Title, &item.Link, &item.Category, &item.Subcategory,
-		&item.Attachments, &item.CreatedAt, &item.Status, &item.Starred,
-		&item.Notes, &item.CompletedAt,
+		&item.Attachments, &item.Version, &item.CreatedAt, &item.Draft, &item.PublishAt,
 	)
 
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("item not found")
+		return nil, apperr.NotFound("item not found")
 	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to get item with user progress: %w", err)
+		return nil, apperr.Errorf("failed to get item: %w", err)
 	}
 
 	return &item, nil
 }
 
+// CountExistingItems returns how many of the given item IDs exist
+func (r *ItemRepository) CountExistingItems(itemIDs []int) (int, error) {
+	var count int
+	err := r.db.QueryRow("SELECT COUNT(*) FROM items WHERE id = ANY($1)", pq.Array(itemIDs)).Scan(&count)
+	if err != nil {
+		return 0, apperr.Errorf("failed to check items: %w", err)
+	}
+
+	return count, nil
+}
+
 // GetByIDWithUserProgress retrieves an item by its ID with user-specific progress data
-func (r *ItemRepository) GetItemByIDForTest(userID, itemID int, sessionID string) (*models.ItemWithProgress, error) {
+func (r *ItemRepository) GetByIDWithUserProgress(userID, itemID int) (*models.ItemWithProgress, error) {
 	query := `
 		SELECT 
-			i.id, i.title, i.link, i.category, i.subcategory, i.attachments,
-			COALESCE(t.status, 'pending') as status
+			i.id, i.title, i.link, i.category, i.subcategory, i.attachments, i.version, i.created_at,
+			COALESCE(up.status, 'pending') as status,
+			COALESCE(up.starred, false) as starred,
+			COALESCE(up.notes, '') as notes,
+			up.completed_at, up.completion_quality, up.next_review_at
 		FROM items i
-		LEFT JOIN tests t 
-			ON t.item_id = i.id AND t.user_id = $1 AND t.session_id = $2
-		WHERE i.id = $3`
+		LEFT JOIN user_progress up 
+			ON i.id = up.item_id AND up.user_id = $1
+		WHERE i.id = $2 AND i.published`
 
 	var item models.ItemWithProgress
-	err := r.db.QueryRow(query, userID, sessionID, itemID).Scan(
-		&item.ID, &item.Title, &item.Link, &item.Category, &item.Subcategory,
-		&item.Attachments, &item.Status,
-	)
+	err := withUserContext(r.db, userID, func(q dbtx) error {
+		return q.QueryRow(query, userID, itemID).Scan(
+			&item.ID, &item.Title, &item.Link, &item.Category, &item.Subcategory,
+			&item.Attachments, &item.Version, &item.CreatedAt, &item.Status, &item.Starred,
+			&item.Notes, &item.CompletedAt, &item.CompletionQuality, &item.NextReviewAt,
+		)
+	})
 
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("item not found")
+		return nil, apperr.NotFound("item not found")
 	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to get item with user progress: %w", err)
+		return nil, apperr.Errorf("failed to get item with user progress: %w", err)
 	}
 
 	return &item, nil
@@ -128,7 +193,7 @@ func (r *ItemRepository) GetItemByIDForTest(userID, itemID int, sessionID string
 
 // GetAll retrieves items with optional filtering
 func (r *ItemRepository) GetAll(filter *models.ItemFilter) ([]*models.Item, error) {
-	query := "SELECT id, title, link, category, subcategory, attachments, created_at FROM items WHERE 1=1"
+	query := "SELECT id, title, link, category, subcategory, attachments, created_at FROM items WHERE published"
 	args := []interface{}{}
 	argCount := 0
 
@@ -145,6 +210,12 @@ func (r *ItemRepository) GetAll(filter *models.ItemFilter) ([]*models.Item, erro
 		args = append(args, *filter.Subcategory)
 	}
 
+	if filter.Company != nil {
+		argCount++
+		query += companyFilterClause("items.id", argCount)
+		args = append(args, *filter.Company)
+	}
+
 	// Note: Status filtering is no longer supported in this method
 	// Use GetAllWithUserProgress for user-specific status filtering
 
@@ -164,7 +235,7 @@ func (r *ItemRepository) GetAll(filter *models.ItemFilter) ([]*models.Item, erro
 
 	rows, err := r.db.Query(query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get items: %w", err)
+		return nil, apperr.Errorf("failed to get items: %w", err)
 	}
 	defer rows.Close()
 
@@ -176,7 +247,7 @@ func (r *ItemRepository) GetAll(filter *models.ItemFilter) ([]*models.Item, erro
 			&item.Attachments, &item.CreatedAt,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan item: %w", err)
+			return nil, apperr.Errorf("failed to scan item: %w", err)
 		}
 		items = append(items, &item)
 	}
@@ -192,11 +263,11 @@ func (r *ItemRepository) GetAllWithUserProgress(userID int, filter *models.ItemF
 			COALESCE(up.status, 'pending') as status,
 			COALESCE(up.starred, false) as starred,
 			COALESCE(up.notes, '') as notes,
-			up.completed_at
+			up.completed_at, up.completion_quality, up.next_review_at
 		FROM items i
 		LEFT JOIN user_progress up 
 			ON i.id = up.item_id AND up.user_id = $1
-		WHERE 1=1`
+		WHERE i.published`
 
 	args := []interface{}{userID}
 	argCount := 1
@@ -214,12 +285,32 @@ func (r *ItemRepository) GetAllWithUserProgress(userID int, filter *models.ItemF
 		args = append(args, *filter.Subcategory)
 	}
 
+	if filter.Company != nil {
+		argCount++
+		query += companyFilterClause("i.id", argCount)
+		args = append(args, *filter.Company)
+	}
+
 	if filter.Status != nil {
 		argCount++
 		query += fmt.Sprintf(" AND COALESCE(up.status, 'pending') = $%d", argCount)
 		args = append(args, *filter.Status)
 	}
 
+	if filter.Starred != nil {
+		argCount++
+		query += fmt.Sprintf(" AND COALESCE(up.starred, false) = $%d", argCount)
+		args = append(args, *filter.Starred)
+	}
+
+	if filter.HasNotes != nil {
+		if *filter.HasNotes {
+			query += " AND COALESCE(up.notes, '') <> ''"
+		} else {
+			query += " AND COALESCE(up.notes, '') = ''"
+		}
+	}
+
 	// Add ordering - random if requested, otherwise by created_at
 	if filter.RandomOrder != nil && *filter.RandomOrder {
 		query += " ORDER BY RANDOM()"
@@ -239,24 +330,31 @@ func (r *ItemRepository) GetAllWithUserProgress(userID int, filter *models.ItemF
 		}
 	}
 
-	rows, err := r.db.Query(query, args...)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get items with user progress: %w", err)
-	}
-	defer rows.Close()
-
 	var items []*models.ItemWithProgress
-	for rows.Next() {
-		var item models.ItemWithProgress
-		err := rows.Scan(
-			&item.ID, &item.Title, &item.Link, &item.Category, &item.Subcategory,
-			&item.Attachments, &item.CreatedAt, &item.Status, &item.Starred,
-			&item.Notes, &item.CompletedAt,
-		)
+	err := withUserContext(r.db, userID, func(q dbtx) error {
+		rows, err := q.Query(query, args...)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan item with progress: %w", err)
+			return apperr.Errorf("failed to get items with user progress: %w", err)
 		}
-		items = append(items, &item)
+		defer rows.Close()
+
+		for rows.Next() {
+			var item models.ItemWithProgress
+			err := rows.Scan(
+				&item.ID, &item.Title, &item.Link, &item.Category, &item.Subcategory,
+				&item.Attachments, &item.CreatedAt, &item.Status, &item.Starred,
+				&item.Notes, &item.CompletedAt, &item.CompletionQuality, &item.NextReviewAt,
+			)
+			if err != nil {
+				return apperr.Errorf("failed to scan item with progress: %w", err)
+			}
+			items = append(items, &item)
+		}
+
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return items, nil
@@ -282,8 +380,10 @@ func (r *ItemRepository) MarkComplete(id int) (*models.Item, error) {
 	return nil, fmt.Errorf("MarkComplete is deprecated - use CompleteItemForUser instead")
 }
 
-// Update updates an existing item
-func (r *ItemRepository) Update(id int, req *models.UpdateItemRequest) (*models.Item, error) {
+// Update updates an existing item, bumps its version and records the edit as a revision by
+// editorID. With req.Version set, an item at another version is left alone and the conflict is
+// returned with the current item.
+func (r *ItemRepository) Update(editorID, id int, req *models.UpdateItemRequest) (*models.Item, error) {
 	setParts := []string{}
 	args := []interface{}{}
 	argCount := 0
@@ -319,9 +419,11 @@ func (r *ItemRepository) Update(id int, req *models.UpdateItemRequest) (*models.
 	}
 
 	if len(setParts) == 0 {
-		return nil, fmt.Errorf("no fields to update")
+		return nil, apperr.Validation("no fields to update")
 	}
 
+	setParts = append(setParts, "version = version + 1")
+
 	argCount++
 	args = append(args, id)
 
@@ -329,31 +431,438 @@ func (r *ItemRepository) Update(id int, req *models.UpdateItemRequest) (*models.
 		UPDATE items 
 		SET %s 
 		WHERE id = $%d
-		RETURNING id, title, link, category, subcategory, attachments, created_at`,
+		RETURNING id, title, link, category, subcategory, attachments, version, created_at, NOT published, publish_at`,
 		strings.Join(setParts, ", "), argCount)
 
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, apperr.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	before, err := lockItemForEdit(tx, id, req.Version)
+	if err != nil {
+		return nil, err
+	}
+
 	var item models.Item
-	err := r.db.QueryRow(query, args...).Scan(
+	err = tx.QueryRow(query, args...).Scan(
 		&item.ID, &item.Title, &item.Link, &item.Category, &item.Subcategory,
-		&item.Attachments, &item.CreatedAt,
+		&item.Attachments, &item.Version, &item.CreatedAt, &item.Draft, &item.PublishAt,
+	)
+	if err != nil {
+		return nil, apperr.Errorf("failed to update item: %w", err)
+	}
+
+	if _, err := recordItemRevision(tx, before, &item, editorID, nil); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, apperr.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &item, nil
+}
+
+// Revert restores an item's content to what it was at one of its revisions, as a new edit by
+// editorID that's recorded as a revision of its own
+func (r *ItemRepository) Revert(editorID, id, revisionID int, version *int) (*models.Item, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, apperr.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	before, err := lockItemForEdit(tx, id, version)
+	if err != nil {
+		return nil, err
+	}
+
+	var content models.ItemContent
+	err = tx.QueryRow("SELECT snapshot FROM item_revisions WHERE id = $1 AND item_id = $2", revisionID, id).Scan(&content)
+	if err == sql.ErrNoRows {
+		return nil, apperr.NotFound("revision not found")
+	}
+	if err != nil {
+		return nil, apperr.Errorf("failed to get item revision: %w", err)
+	}
+
+	query := `
+		UPDATE items
+		SET title = $1, link = $2, category = $3, subcategory = $4, attachments = $5, version = version + 1
+		WHERE id = $6
+		RETURNING id, title, link, category, subcategory, attachments, version, created_at, NOT published, publish_at`
+
+	var item models.Item
+	err = tx.QueryRow(query, content.Title, content.Link, content.Category, content.Subcategory, content.Attachments, id).Scan(
+		&item.ID, &item.Title, &item.Link, &item.Category, &item.Subcategory,
+		&item.Attachments, &item.Version, &item.CreatedAt, &item.Draft, &item.PublishAt,
+	)
+	if err != nil {
+		return nil, apperr.Errorf("failed to revert item: %w", err)
+	}
+
+	recorded, err := recordItemRevision(tx, before, &item, editorID, &revisionID)
+	if err != nil {
+		return nil, err
+	}
+	if !recorded {
+		return nil, apperr.Conflict("item already has the content of this revision")
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, apperr.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &item, nil
+}
+
+// GetRevisions lists an item's revisions, newest first
+func (r *ItemRepository) GetRevisions(id int) ([]*models.ItemRevision, error) {
+	query := `
+		SELECT r.id, r.item_id, r.version, r.editor_id, COALESCE(u.name, ''), r.changes, r.snapshot, r.reverted_from, r.created_at
+		FROM item_revisions r
+		LEFT JOIN users u ON u.id = r.editor_id
+		WHERE r.item_id = $1
+		ORDER BY r.id DESC`
+
+	rows, err := r.db.Query(query, id)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get item revisions: %w", err)
+	}
+	defer rows.Close()
+
+	revisions := []*models.ItemRevision{}
+	for rows.Next() {
+		var revision models.ItemRevision
+		err := rows.Scan(
+			&revision.ID, &revision.ItemID, &revision.Version, &revision.EditorID, &revision.EditorName,
+			&revision.Changes, &revision.Content, &revision.RevertedFrom, &revision.CreatedAt,
+		)
+		if err != nil {
+			return nil, apperr.Errorf("failed to scan item revision: %w", err)
+		}
+		revisions = append(revisions, &revision)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating item revisions: %w", err)
+	}
+
+	return revisions, nil
+}
+
+// SetPublished publishes an item, or takes it back to draft, clearing any schedule and bumping its
+// version
+func (r *ItemRepository) SetPublished(id int, published bool) (*models.Item, error) {
+	query := `
+		UPDATE items
+		SET published = $1, publish_at = NULL, version = version + 1
+		WHERE id = $2
+		RETURNING id, title, link, category, subcategory, attachments, version, created_at, NOT published, publish_at`
+
+	var item models.Item
+	err := r.db.QueryRow(query, published, id).Scan(
+		&item.ID, &item.Title, &item.Link, &item.Category, &item.Subcategory,
+		&item.Attachments, &item.Version, &item.CreatedAt, &item.Draft, &item.PublishAt,
 	)
+	if err == sql.ErrNoRows {
+		return nil, apperr.NotFound("item not found")
+	}
+	if err != nil {
+		return nil, apperr.Errorf("failed to publish item: %w", err)
+	}
+
+	return &item, nil
+}
+
+// GetDrafts lists the items that aren't published yet, newest first
+func (r *ItemRepository) GetDrafts() ([]*models.Item, error) {
+	query := `
+		SELECT id, title, link, category, subcategory, attachments, version, created_at, publish_at
+		FROM items
+		WHERE NOT published
+		ORDER BY created_at DESC, id DESC`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get draft items: %w", err)
+	}
+	defer rows.Close()
+
+	items := []*models.Item{}
+	for rows.Next() {
+		item := &models.Item{Draft: true}
+		err := rows.Scan(
+			&item.ID, &item.Title, &item.Link, &item.Category, &item.Subcategory,
+			&item.Attachments, &item.Version, &item.CreatedAt, &item.PublishAt,
+		)
+		if err != nil {
+			return nil, apperr.Errorf("failed to scan draft item: %w", err)
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating draft items: %w", err)
+	}
+
+	return items, nil
+}
+
+// Schedule sets when draft items are published, or with publishAt nil unschedules them. Items
+// already published are refused with a conflict, so a schedule never hides live content.
+func (r *ItemRepository) Schedule(itemIDs []int, publishAt *time.Time) ([]*models.Item, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, apperr.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query("SELECT id, published FROM items WHERE id = ANY($1) FOR UPDATE", pq.Array(itemIDs))
+	if err != nil {
+		return nil, apperr.Errorf("failed to get items to schedule: %w", err)
+	}
+	published := make(map[int]bool, len(itemIDs))
+	for rows.Next() {
+		var id int
+		var isPublished bool
+		if err := rows.Scan(&id, &isPublished); err != nil {
+			rows.Close()
+			return nil, apperr.Errorf("failed to scan item to schedule: %w", err)
+		}
+		published[id] = isPublished
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating items to schedule: %w", err)
+	}
+
+	for _, id := range itemIDs {
+		isPublished, ok := published[id]
+		if !ok {
+			return nil, apperr.NotFound(fmt.Sprintf("item %d not found", id))
+		}
+		if isPublished {
+			return nil, apperr.Conflict(fmt.Sprintf("item %d is already published; take it back to draft before scheduling it", id))
+		}
+	}
+
+	query := `
+		UPDATE items
+		SET publish_at = $1, version = version + 1
+		WHERE id = ANY($2)
+		RETURNING id, title, link, category, subcategory, attachments, version, created_at, NOT published, publish_at`
+
+	items, err := queryItems(tx, query, publishAt, pq.Array(itemIDs))
+	if err != nil {
+		return nil, apperr.Errorf("failed to schedule items: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, apperr.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return items, nil
+}
+
+// ReleaseDue publishes the scheduled items whose time has come by now, returning them
+func (r *ItemRepository) ReleaseDue(now time.Time) ([]*models.Item, error) {
+	query := `
+		UPDATE items
+		SET published = TRUE, publish_at = NULL, version = version + 1
+		WHERE NOT published AND publish_at <= $1
+		RETURNING id, title, link, category, subcategory, attachments, version, created_at, NOT published, publish_at`
+
+	items, err := queryItems(r.db, query, now)
+	if err != nil {
+		return nil, apperr.Errorf("failed to release scheduled items: %w", err)
+	}
+
+	return items, nil
+}
 
+// GetUpcomingReleases lists the scheduled items not yet published, soonest first
+func (r *ItemRepository) GetUpcomingReleases(limit int) ([]*models.UpcomingRelease, error) {
+	query := `
+		SELECT id, title, category, subcategory, publish_at
+		FROM items
+		WHERE NOT published AND publish_at IS NOT NULL
+		ORDER BY publish_at, id
+		LIMIT $1`
+
+	rows, err := r.db.Query(query, limit)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get upcoming releases: %w", err)
+	}
+	defer rows.Close()
+
+	releases := []*models.UpcomingRelease{}
+	for rows.Next() {
+		release := &models.UpcomingRelease{}
+		if err := rows.Scan(&release.ID, &release.Title, &release.Category, &release.Subcategory, &release.PublishAt); err != nil {
+			return nil, apperr.Errorf("failed to scan upcoming release: %w", err)
+		}
+		releases = append(releases, release)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating upcoming releases: %w", err)
+	}
+
+	return releases, nil
+}
+
+// queryItems runs a query selecting or returning items with their version, draft state and
+// schedule
+func queryItems(q dbtx, query string, args ...interface{}) ([]*models.Item, error) {
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []*models.Item{}
+	for rows.Next() {
+		var item models.Item
+		err := rows.Scan(
+			&item.ID, &item.Title, &item.Link, &item.Category, &item.Subcategory,
+			&item.Attachments, &item.Version, &item.CreatedAt, &item.Draft, &item.PublishAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, &item)
+	}
+
+	return items, rows.Err()
+}
+
+// queryIDs runs a query selecting or returning a single ID column
+func queryIDs(q dbtx, query string, args ...interface{}) ([]int, error) {
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// lockItemForEdit loads an item and holds its row until the transaction ends, so edits to it are
+// recorded one at a time. With version set, an item at another version is a conflict carrying
+// the current item.
+func lockItemForEdit(tx *sql.Tx, id int, version *int) (*models.Item, error) {
+	query := `
+		SELECT id, title, link, category, subcategory, attachments, version, created_at, NOT published, publish_at
+		FROM items
+		WHERE id = $1
+		FOR UPDATE`
+
+	var item models.Item
+	err := tx.QueryRow(query, id).Scan(
+		&item.ID, &item.Title, &item.Link, &item.Category, &item.Subcategory,
+		&item.Attachments, &item.Version, &item.CreatedAt, &item.Draft, &item.PublishAt,
+	)
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("item not found")
+		return nil, apperr.NotFound("item not found")
 	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to update item: %w", err)
+		return nil, apperr.Errorf("failed to get item: %w", err)
+	}
+
+	if version != nil && *version != item.Version {
+		// The item was changed since the version the edit was based on
+		return nil, apperr.Conflict("item was changed by someone else; reload it and reapply your edit").
+			WithDetails(map[string]interface{}{"current": &item})
 	}
 
 	return &item, nil
 }
 
+// recordItemRevision records an edit that took an item from before to after, reporting false
+// when it changed nothing. An item's first recorded edit also records its content from before.
+func recordItemRevision(tx *sql.Tx, before, after *models.Item, editorID int, revertedFrom *int) (bool, error) {
+	changes := itemChanges(before.Content(), after.Content())
+	if len(changes) == 0 {
+		return false, nil
+	}
+
+	baseline := `
+		INSERT INTO item_revisions (item_id, version, snapshot)
+		SELECT $1, $2, $3
+		WHERE NOT EXISTS (SELECT 1 FROM item_revisions WHERE item_id = $1)`
+	if _, err := tx.Exec(baseline, before.ID, before.Version, before.Content()); err != nil {
+		return false, apperr.Errorf("failed to record item baseline revision: %w", err)
+	}
+
+	var editor *int
+	if editorID > 0 {
+		editor = &editorID
+	}
+
+	query := `
+		INSERT INTO item_revisions (item_id, version, editor_id, changes, snapshot, reverted_from)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+	if _, err := tx.Exec(query, after.ID, after.Version, editor, changes, after.Content(), revertedFrom); err != nil {
+		return false, apperr.Errorf("failed to record item revision: %w", err)
+	}
+
+	return true, nil
+}
+
+// itemChanges lists the fields that differ between two versions of an item's content
+func itemChanges(before, after models.ItemContent) models.ItemChanges {
+	changes := models.ItemChanges{}
+	if before.Title != after.Title {
+		changes["title"] = models.ItemFieldChange{From: before.Title, To: after.Title}
+	}
+	if before.Link != after.Link {
+		changes["link"] = models.ItemFieldChange{From: before.Link, To: after.Link}
+	}
+	if before.Category != after.Category {
+		changes["category"] = models.ItemFieldChange{From: before.Category, To: after.Category}
+	}
+	if before.Subcategory != after.Subcategory {
+		changes["subcategory"] = models.ItemFieldChange{From: before.Subcategory, To: after.Subcategory}
+	}
+	if !sameAttachments(before.Attachments, after.Attachments) {
+		changes["attachments"] = models.ItemFieldChange{From: before.Attachments, To: after.Attachments}
+	}
+
+	return changes
+}
+
+// sameAttachments reports whether two attachment maps hold the same entries
+func sameAttachments(a, b models.Attachments) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, value := range a {
+		if other, ok := b[key]; !ok || other != value {
+			return false
+		}
+	}
+
+	return true
+}
+
 // Delete removes an item from the database and cascades to user_progress
 func (r *ItemRepository) Delete(id int) error {
 	// Start a transaction to ensure atomicity
 	tx, err := r.db.Begin()
 	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
+		return apperr.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback()
 
@@ -361,37 +870,37 @@ func (r *ItemRepository) Delete(id int) error {
 	var exists bool
 	err = tx.QueryRow("SELECT EXISTS(SELECT 1 FROM items WHERE id = $1)", id).Scan(&exists)
 	if err != nil {
-		return fmt.Errorf("failed to check if item exists: %w", err)
+		return apperr.Errorf("failed to check if item exists: %w", err)
 	}
 	if !exists {
-		return fmt.Errorf("item not found")
+		return apperr.NotFound("item not found")
 	}
 
 	// Delete user progress entries for this item (optional since CASCADE will handle this)
 	// This is explicit for clarity and potential logging
 	_, err = tx.Exec("DELETE FROM user_progress WHERE item_id = $1", id)
 	if err != nil {
-		return fmt.Errorf("failed to delete user progress entries: %w", err)
+		return apperr.Errorf("failed to delete user progress entries: %w", err)
 	}
 
 	// Delete the item (this would also cascade delete user_progress due to FK constraint)
 	result, err := tx.Exec("DELETE FROM items WHERE id = $1", id)
 	if err != nil {
-		return fmt.Errorf("failed to delete item: %w", err)
+		return apperr.Errorf("failed to delete item: %w", err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
+		return apperr.Errorf("failed to get rows affected: %w", err)
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("item not found")
+		return apperr.NotFound("item not found")
 	}
 
 	// Commit the transaction
 	if err = tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+		return apperr.Errorf("failed to commit transaction: %w", err)
 	}
 
 	return nil
@@ -434,7 +943,7 @@ func (r *ItemRepository) UpdateStatus(id int, status models.Status) (*models.Ite
 
 // GetTotalCount returns the total count of items matching the filter
 func (r *ItemRepository) GetTotalCount(filter *models.ItemFilter) (int, error) {
-	query := "SELECT COUNT(*) FROM items WHERE 1=1"
+	query := "SELECT COUNT(*) FROM items WHERE published"
 	args := []interface{}{}
 	argCount := 0
 
@@ -447,8 +956,14 @@ func (r *ItemRepository) GetTotalCount(filter *models.ItemFilter) (int, error) {
 
 	if filter.Subcategory != nil {
 		argCount++
-		query += fmt.Sprintf(" AND subcategory = $%d", argCount)
-		args = append(args, *filter.Subcategory)
+		query += fmt.Sprintf(" AND subcategory = $%d", argCount)
+		args = append(args, *filter.Subcategory)
+	}
+
+	if filter.Company != nil {
+		argCount++
+		query += companyFilterClause("items.id", argCount)
+		args = append(args, *filter.Company)
 	}
 
 	// Note: Status filtering is no longer supported in this method
@@ -457,7 +972,7 @@ func (r *ItemRepository) GetTotalCount(filter *models.ItemFilter) (int, error) {
 	var count int
 	err := r.db.QueryRow(query, args...).Scan(&count)
 	if err != nil {
-		return 0, fmt.Errorf("failed to count items: %w", err)
+		return 0, apperr.Errorf("failed to count items: %w", err)
 	}
 	return count, nil
 }
@@ -468,7 +983,7 @@ func (r *ItemRepository) GetTotalCountWithUserProgress(userID int, filter *model
 		SELECT COUNT(*) 
 		FROM items i
 		LEFT JOIN user_progress up ON i.id = up.item_id AND up.user_id = $1
-		WHERE 1=1`
+		WHERE i.published`
 
 	args := []interface{}{userID}
 	argCount := 1
@@ -486,16 +1001,36 @@ func (r *ItemRepository) GetTotalCountWithUserProgress(userID int, filter *model
 		args = append(args, *filter.Subcategory)
 	}
 
+	if filter.Company != nil {
+		argCount++
+		query += companyFilterClause("i.id", argCount)
+		args = append(args, *filter.Company)
+	}
+
 	if filter.Status != nil {
 		argCount++
 		query += fmt.Sprintf(" AND COALESCE(up.status, 'pending') = $%d", argCount)
 		args = append(args, *filter.Status)
 	}
 
+	if filter.Starred != nil {
+		argCount++
+		query += fmt.Sprintf(" AND COALESCE(up.starred, false) = $%d", argCount)
+		args = append(args, *filter.Starred)
+	}
+
+	if filter.HasNotes != nil {
+		if *filter.HasNotes {
+			query += " AND COALESCE(up.notes, '') <> ''"
+		} else {
+			query += " AND COALESCE(up.notes, '') = ''"
+		}
+	}
+
 	var count int
 	err := r.db.QueryRow(query, args...).Scan(&count)
 	if err != nil {
-		return 0, fmt.Errorf("failed to count items with user progress: %w", err)
+		return 0, apperr.Errorf("failed to count items with user progress: %w", err)
 	}
 	return count, nil
 }
@@ -522,84 +1057,70 @@ func (r *ItemRepository) GetInProgressItemWithUserProgress(userID int) (*models.
 		return nil, nil // No in-progress item
 	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to get in-progress item with user progress: %w", err)
+		return nil, apperr.Errorf("failed to get in-progress item with user progress: %w", err)
 	}
 
 	return &item, nil
 }
 
-// GetRandomPendingWithUserProgress retrieves a random pending item for a user
-// For miscellaneous category, it returns items sorted by ID in ascending order
-func (r *ItemRepository) GetRandomPendingWithUserProgress(userID int) (*models.ItemWithProgress, error) {
-	// Get distinct categories that have pending items
-	categoriesQuery := `
-		SELECT DISTINCT i.category
-		FROM items i
-		LEFT JOIN user_progress up 
-			ON i.id = up.item_id AND up.user_id = $1
-		WHERE COALESCE(up.status, 'pending') = 'pending'
-		ORDER BY i.category`
-
-	rows, err := r.db.Query(categoriesQuery, userID)
+// getCategorySlugs returns the slug of every category
+func (r *ItemRepository) getCategorySlugs() ([]models.Category, error) {
+	rows, err := r.db.Query("SELECT slug FROM categories")
 	if err != nil {
-		return nil, fmt.Errorf("failed to get categories with pending items: %w", err)
+		return nil, apperr.Errorf("failed to get categories: %w", err)
 	}
 	defer rows.Close()
 
-	var categories []models.Category
+	categories := []models.Category{}
 	for rows.Next() {
 		var category models.Category
 		if err := rows.Scan(&category); err != nil {
-			return nil, fmt.Errorf("failed to scan category: %w", err)
+			return nil, apperr.Errorf("failed to scan category: %w", err)
 		}
 		categories = append(categories, category)
 	}
 
-	if len(categories) == 0 {
-		return nil, fmt.Errorf("no pending items found")
-	}
+	return categories, rows.Err()
+}
 
-	// Try each category randomly until we find a pending item
-	// We'll shuffle the categories to randomize the order
-	for i := len(categories) - 1; i > 0; i-- {
-		j := int(time.Now().UnixNano()) % (i + 1)
-		categories[i], categories[j] = categories[j], categories[i]
+// GetRandomPendingWithUserProgress retrieves a random pending item for a user
+// For miscellaneous category, it returns items sorted by ID in ascending order
+func (r *ItemRepository) GetRandomPendingWithUserProgress(userID int) (*models.ItemWithProgress, error) {
+	columns := `
+		i.id, i.title, i.link, i.category, i.subcategory, i.attachments, i.created_at,
+		COALESCE(up.status, 'pending') as status,
+		COALESCE(up.starred, false) as starred,
+		COALESCE(up.notes, '') as notes,
+		up.completed_at`
+	conds := publishedCondition + " AND i.category = $2 AND COALESCE(up.status, 'pending') = 'pending'"
+	if hideDeadLinks {
+		conds += deadLinkCondition
+	}
+
+	// Try the categories in a random order until one has a pending item
+	categories, err := r.getCategorySlugs()
+	if err != nil {
+		return nil, err
 	}
+	rand.Shuffle(len(categories), func(i, j int) {
+		categories[i], categories[j] = categories[j], categories[i]
+	})
 
-	// Try each category in the shuffled order
 	for _, category := range categories {
 		var itemQuery string
 
 		// For miscellaneous category, sort by ID in ascending order instead of random
 		if category == models.CategoryMiscellaneous {
 			itemQuery = `
-				SELECT 
-					i.id, i.title, i.link, i.category, i.subcategory, i.attachments, i.created_at,
-					COALESCE(up.status, 'pending') as status,
-					COALESCE(up.starred, false) as starred,
-					COALESCE(up.notes, '') as notes,
-					up.completed_at
+				SELECT` + columns + `
 				FROM items i
 				LEFT JOIN user_progress up 
 					ON i.id = up.item_id AND up.user_id = $1
-				WHERE i.category = $2 AND COALESCE(up.status, 'pending') = 'pending'
+				WHERE 1=1` + conds + `
 				ORDER BY i.id ASC
 				LIMIT 1`
 		} else {
-			// For other categories, keep the random selection
-			itemQuery = `
-				SELECT 
-					i.id, i.title, i.link, i.category, i.subcategory, i.attachments, i.created_at,
-					COALESCE(up.status, 'pending') as status,
-					COALESCE(up.starred, false) as starred,
-					COALESCE(up.notes, '') as notes,
-					up.completed_at
-				FROM items i
-				LEFT JOIN user_progress up 
-					ON i.id = up.item_id AND up.user_id = $1
-				WHERE i.category = $2 AND COALESCE(up.status, 'pending') = 'pending'
-				ORDER BY RANDOM() 
-				LIMIT 1`
+			itemQuery = randomItemsQuery(columns, conds) + " LIMIT 1"
 		}
 
 		var item models.ItemWithProgress
@@ -614,15 +1135,14 @@ func (r *ItemRepository) GetRandomPendingWithUserProgress(userID int) (*models.I
 			continue
 		}
 		if err != nil {
-			return nil, fmt.Errorf("failed to get pending item from category %s: %w", category, err)
+			return nil, apperr.Errorf("failed to get pending item from category %s: %w", category, err)
 		}
 
 		// Found a pending item, return it
 		return &item, nil
 	}
 
-	// If we get here, no pending items were found in any category
-	return nil, fmt.Errorf("no pending items found in any category")
+	return nil, apperr.NotFound("no pending items found")
 }
 
 // CreateUserProgressForItem creates or updates a user progress record for an item
@@ -654,7 +1174,7 @@ func (r *ItemRepository) CreateUserProgressForItem(userID, itemID int, status mo
 	)
 
 	if err != nil {
-		return fmt.Errorf("failed to create/update user progress for item: %w", err)
+		return apperr.Errorf("failed to create/update user progress for item: %w", err)
 	}
 
 	return nil
@@ -662,11 +1182,16 @@ func (r *ItemRepository) CreateUserProgressForItem(userID, itemID int, status mo
 
 // UpsertUserProgressForItem creates or updates a user progress record preserving existing data
 func (r *ItemRepository) UpsertUserProgressForItem(userID, itemID int, status models.Status) error {
+	return upsertUserProgress(r.db, userID, itemID, status)
+}
+
+// upsertUserProgress creates or updates a user progress record within q
+func upsertUserProgress(q dbtx, userID, itemID int, status models.Status) error {
 	now := time.Now()
 
 	query := `
-		INSERT INTO user_progress (user_id, item_id, status, starred, notes, started_at, created_at, updated_at)
-		VALUES ($1, $2, $3, false, '', $4, $5, $6)
+		INSERT INTO user_progress (user_id, item_id, status, starred, notes, started_at, completed_at, created_at, updated_at)
+		VALUES ($1, $2, $3, false, '', $4, CASE WHEN $3 = 'done' THEN $7::timestamp END, $5, $6)
 		ON CONFLICT (user_id, item_id) 
 		DO UPDATE SET 
 			status = EXCLUDED.status,
@@ -679,9 +1204,21 @@ func (r *ItemRepository) UpsertUserProgressForItem(userID, itemID int, status mo
 				WHEN EXCLUDED.status != 'done' THEN NULL
 				ELSE user_progress.completed_at
 			END,
+			completion_quality = CASE 
+				WHEN EXCLUDED.status != 'done' THEN NULL
+				ELSE user_progress.completion_quality
+			END,
+			hint_credit = CASE 
+				WHEN EXCLUDED.status != 'done' THEN NULL
+				ELSE user_progress.hint_credit
+			END,
+			next_review_at = CASE 
+				WHEN EXCLUDED.status != 'done' THEN NULL
+				ELSE user_progress.next_review_at
+			END,
 			updated_at = EXCLUDED.updated_at`
 
-	_, err := r.db.Exec(
+	_, err := q.Exec(
 		query,
 		userID,
 		itemID,
@@ -693,7 +1230,7 @@ func (r *ItemRepository) UpsertUserProgressForItem(userID, itemID int, status mo
 	)
 
 	if err != nil {
-		return fmt.Errorf("failed to upsert user progress for item: %w", err)
+		return apperr.Errorf("failed to upsert user progress for item: %w", err)
 	}
 
 	return nil
@@ -708,7 +1245,22 @@ func (r *ItemRepository) ResetInProgressItemsForUser(userID int) error {
 
 	_, err := r.db.Exec(query, time.Now(), userID)
 	if err != nil {
-		return fmt.Errorf("failed to reset in-progress items for user: %w", err)
+		return apperr.Errorf("failed to reset in-progress items for user: %w", err)
+	}
+
+	return nil
+}
+
+// SkipInProgressItemsForUser moves a user's in-progress items back to pending, counting the skip
+func (r *ItemRepository) SkipInProgressItemsForUser(userID int) error {
+	query := `
+		UPDATE user_progress
+		SET status = 'pending', skip_count = skip_count + 1, updated_at = $1
+		WHERE user_id = $2 AND status = 'in-progress'`
+
+	_, err := r.db.Exec(query, time.Now(), userID)
+	if err != nil {
+		return apperr.Errorf("failed to skip in-progress items for user: %w", err)
 	}
 
 	return nil
@@ -716,6 +1268,11 @@ func (r *ItemRepository) ResetInProgressItemsForUser(userID int) error {
 
 // CountPendingForUser counts pending items for a specific user
 func (r *ItemRepository) CountPendingForUser(userID int) (int, error) {
+	return countPendingForUser(r.db, userID)
+}
+
+// countPendingForUser counts a user's pending items within q
+func countPendingForUser(q dbtx, userID int) (int, error) {
 	query := `
 		SELECT COUNT(*) 
 		FROM items i
@@ -725,50 +1282,59 @@ func (r *ItemRepository) CountPendingForUser(userID int) (int, error) {
 		AND i.category != $2`
 
 	var count int
-	err := r.db.QueryRow(query, userID, models.CategoryMiscellaneous).Scan(&count)
+	err := q.QueryRow(query, userID, models.CategoryMiscellaneous).Scan(&count)
 	if err != nil {
-		return 0, fmt.Errorf("failed to count pending items for user: %w", err)
+		return 0, apperr.Errorf("failed to count pending items for user: %w", err)
 	}
 	return count, nil
 }
 
-// CompleteItemForUser marks an item as completed for a specific user
-func (r *ItemRepository) CompleteItemForUser(userID, itemID int) (*models.ItemWithProgress, error) {
+// MarkCompleted marks an item as completed for a user within tx, recording how it was completed, the
+// hint credit it earned and when it should next be reviewed. The service completing an item
+// advances the user's streak and completed-all count in the same transaction.
+func (r *ItemRepository) MarkCompleted(tx *Tx, userID, itemID int, quality models.CompletionQuality, hintCredit float64, nextReviewAt time.Time) error {
 	// First, ensure the item exists
 	var itemExists bool
-	err := r.db.QueryRow("SELECT EXISTS(SELECT 1 FROM items WHERE id = $1)", itemID).Scan(&itemExists)
-	if err != nil {
-		return nil, fmt.Errorf("failed to check if item exists: %w", err)
+	if err := tx.QueryRow("SELECT EXISTS(SELECT 1 FROM items WHERE id = $1 AND published)", itemID).Scan(&itemExists); err != nil {
+		return apperr.Errorf("failed to check if item exists: %w", err)
 	}
 	if !itemExists {
-		return nil, fmt.Errorf("item not found")
+		return apperr.NotFound("item not found")
 	}
 
 	// Update or insert user progress to mark as completed
-	err = r.UpsertUserProgressForItem(userID, itemID, models.StatusDone)
-	if err != nil {
-		return nil, fmt.Errorf("failed to mark item as completed: %w", err)
+	if err := upsertUserProgress(tx, userID, itemID, models.StatusDone); err != nil {
+		return apperr.Errorf("failed to mark item as completed: %w", err)
 	}
 
-	// Get the completed item with user progress
-	item, err := r.GetByIDWithUserProgress(userID, itemID)
+	// Record the completion quality and hint credit and reset the review schedule
+	_, err := tx.Exec(`
+		UPDATE user_progress
+		SET completion_quality = $1, hint_credit = $2, next_review_at = $3, review_count = 0
+		WHERE user_id = $4 AND item_id = $5`,
+		quality, hintCredit, nextReviewAt, userID, itemID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get completed item: %w", err)
+		return apperr.Errorf("failed to record completion quality: %w", err)
 	}
 
-	return item, nil
+	return nil
+}
+
+// CountPendingForUserInTx counts a user's pending items within tx
+func (r *ItemRepository) CountPendingForUserInTx(tx *Tx, userID int) (int, error) {
+	return countPendingForUser(tx, userID)
 }
 
 // ToggleStarForUser toggles the starred status of an item for a specific user
 func (r *ItemRepository) ToggleStarForUser(userID, itemID int) (*models.ItemWithProgress, error) {
 	// First, ensure the item exists
 	var itemExists bool
-	err := r.db.QueryRow("SELECT EXISTS(SELECT 1 FROM items WHERE id = $1)", itemID).Scan(&itemExists)
+	err := r.db.QueryRow("SELECT EXISTS(SELECT 1 FROM items WHERE id = $1 AND published)", itemID).Scan(&itemExists)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check if item exists: %w", err)
+		return nil, apperr.Errorf("failed to check if item exists: %w", err)
 	}
 	if !itemExists {
-		return nil, fmt.Errorf("item not found")
+		return nil, apperr.NotFound("item not found")
 	}
 
 	// Get current starred status or default to false
@@ -782,7 +1348,7 @@ func (r *ItemRepository) ToggleStarForUser(userID, itemID int) (*models.ItemWith
 
 	err = r.db.QueryRow(query, userID, itemID).Scan(&currentStarred)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get current starred status: %w", err)
+		return nil, apperr.Errorf("failed to get current starred status: %w", err)
 	}
 
 	// Toggle the starred status
@@ -800,40 +1366,94 @@ func (r *ItemRepository) ToggleStarForUser(userID, itemID int) (*models.ItemWith
 
 	_, err = r.db.Exec(upsertQuery, userID, itemID, newStarred, now, now)
 	if err != nil {
-		return nil, fmt.Errorf("failed to toggle star status: %w", err)
+		return nil, apperr.Errorf("failed to toggle star status: %w", err)
 	}
 
 	// Get the updated item with user progress
 	item, err := r.GetByIDWithUserProgress(userID, itemID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get updated item: %w", err)
+		return nil, apperr.Errorf("failed to get updated item: %w", err)
 	}
 
 	return item, nil
 }
 
+// SetStarredForUser stars or unstars many items for a user with a single upsert. It returns the
+// IDs of the items that exist, split into those whose starred flag changed and those already in
+// the requested state. Unstarring never creates progress rows for items the user hasn't touched.
+func (r *ItemRepository) SetStarredForUser(userID int, itemIDs []int, starred bool) (changed, unchanged []int, err error) {
+	ids := make([]int64, len(itemIDs))
+	for i, id := range itemIDs {
+		ids[i] = int64(id)
+	}
+
+	upsert := `
+		INSERT INTO user_progress (user_id, item_id, status, starred, notes, created_at, updated_at)
+		SELECT $1, i.id, 'pending', $2, '', $3, $3
+		FROM items i
+		WHERE i.id = ANY($4) AND i.published AND ($2 OR EXISTS (
+			SELECT 1 FROM user_progress up WHERE up.user_id = $1 AND up.item_id = i.id
+		))
+		ON CONFLICT (user_id, item_id)
+		DO UPDATE SET
+			starred = EXCLUDED.starred,
+			updated_at = EXCLUDED.updated_at
+		WHERE user_progress.starred IS DISTINCT FROM EXCLUDED.starred
+		RETURNING item_id`
+
+	err = withUserContext(r.db, userID, func(q dbtx) error {
+		targets, err := queryIDs(q, "SELECT id FROM items WHERE id = ANY($1) AND published ORDER BY id", pq.Array(ids))
+		if err != nil {
+			return apperr.Errorf("failed to find items to star: %w", err)
+		}
+
+		updated, err := queryIDs(q, upsert, userID, starred, time.Now(), pq.Array(ids))
+		if err != nil {
+			return apperr.Errorf("failed to update starred status: %w", err)
+		}
+
+		wasChanged := make(map[int]bool, len(updated))
+		for _, id := range updated {
+			wasChanged[id] = true
+		}
+		for _, id := range targets {
+			if wasChanged[id] {
+				changed = append(changed, id)
+			} else {
+				unchanged = append(unchanged, id)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return changed, unchanged, nil
+}
+
 // UpdateStatusForUser updates the status of an item for a specific user
 func (r *ItemRepository) UpdateStatusForUser(userID, itemID int, status models.Status) (*models.ItemWithProgress, error) {
 	// First, ensure the item exists
 	var itemExists bool
-	err := r.db.QueryRow("SELECT EXISTS(SELECT 1 FROM items WHERE id = $1)", itemID).Scan(&itemExists)
+	err := r.db.QueryRow("SELECT EXISTS(SELECT 1 FROM items WHERE id = $1 AND published)", itemID).Scan(&itemExists)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check if item exists: %w", err)
+		return nil, apperr.Errorf("failed to check if item exists: %w", err)
 	}
 	if !itemExists {
-		return nil, fmt.Errorf("item not found")
+		return nil, apperr.NotFound("item not found")
 	}
 
 	// Use the UpsertUserProgressForItem method to update status
 	err = r.UpsertUserProgressForItem(userID, itemID, status)
 	if err != nil {
-		return nil, fmt.Errorf("failed to update status: %w", err)
+		return nil, apperr.Errorf("failed to update status: %w", err)
 	}
 
 	// Get the updated item with user progress
 	item, err := r.GetByIDWithUserProgress(userID, itemID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get updated item: %w", err)
+		return nil, apperr.Errorf("failed to get updated item: %w", err)
 	}
 
 	return item, nil
@@ -843,17 +1463,17 @@ func (r *ItemRepository) UpdateStatusForUser(userID, itemID int, status models.S
 func (r *ItemRepository) ResetAllUserProgress(userID int) (int64, error) {
 	query := `
 		UPDATE user_progress 
-		SET status = 'pending', completed_at = NULL, updated_at = $1
+		SET status = 'pending', completed_at = NULL, completion_quality = NULL, hint_credit = NULL, next_review_at = NULL, review_count = 0, updated_at = $1
 		WHERE user_id = $2 AND status IN ('done', 'in-progress')`
 
 	result, err := r.db.Exec(query, time.Now(), userID)
 	if err != nil {
-		return 0, fmt.Errorf("failed to reset user progress: %w", err)
+		return 0, apperr.Errorf("failed to reset user progress: %w", err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+		return 0, apperr.Errorf("failed to get rows affected: %w", err)
 	}
 
 	return rowsAffected, nil
@@ -863,18 +1483,18 @@ func (r *ItemRepository) ResetAllUserProgress(userID int) (int64, error) {
 func (r *ItemRepository) ResetUserProgressByCategory(userID int, category models.Category) (int64, error) {
 	query := `
 		UPDATE user_progress 
-		SET status = 'pending', completed_at = NULL, updated_at = $1
+		SET status = 'pending', completed_at = NULL, completion_quality = NULL, hint_credit = NULL, next_review_at = NULL, review_count = 0, updated_at = $1
 		WHERE user_id = $2 AND status IN ('done', 'in-progress')
 		AND item_id IN (SELECT id FROM items WHERE category = $3)`
 
 	result, err := r.db.Exec(query, time.Now(), userID, category)
 	if err != nil {
-		return 0, fmt.Errorf("failed to reset user progress for category %s: %w", category, err)
+		return 0, apperr.Errorf("failed to reset user progress for category %s: %w", category, err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+		return 0, apperr.Errorf("failed to get rows affected: %w", err)
 	}
 
 	return rowsAffected, nil
@@ -891,11 +1511,11 @@ func (r *ItemRepository) GetCountsForUser(userID int) (total, completed, pending
 		FROM items i
 		LEFT JOIN user_progress up 
 			ON i.id = up.item_id AND up.user_id = $1
-		WHERE i.category != $2`
+		WHERE i.category != $2 AND i.published`
 
 	err = r.db.QueryRow(query, userID, models.CategoryMiscellaneous).Scan(&total, &completed, &pending, &inProgress)
 	if err != nil {
-		return 0, 0, 0, 0, fmt.Errorf("failed to get user counts: %w", err)
+		return 0, 0, 0, 0, apperr.Errorf("failed to get user counts: %w", err)
 	}
 
 	return total, completed, pending, inProgress, nil
@@ -912,7 +1532,7 @@ func (r *ItemRepository) GetCountsByCategoryForUser(userID int, removeMiscellane
 		FROM items i
 		LEFT JOIN user_progress up 
 			ON i.id = up.item_id AND up.user_id = $1
-		WHERE 1=1
+		WHERE i.published
 		`
 
 	if removeMiscellaneous {
@@ -932,7 +1552,7 @@ func (r *ItemRepository) GetCountsByCategoryForUser(userID int, removeMiscellane
 	}
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user category counts: %w", err)
+		return nil, apperr.Errorf("failed to get user category counts: %w", err)
 	}
 	defer rows.Close()
 
@@ -945,7 +1565,7 @@ func (r *ItemRepository) GetCountsByCategoryForUser(userID int, removeMiscellane
 
 		err := rows.Scan(&category, &status, &count)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan category count: %w", err)
+			return nil, apperr.Errorf("failed to scan category count: %w", err)
 		}
 
 		if result[category] == nil {
@@ -968,13 +1588,13 @@ func (r *ItemRepository) GetCountsBySubcategoryForUser(userID int) (map[models.C
 		FROM items i
 		LEFT JOIN user_progress up 
 			ON i.id = up.item_id AND up.user_id = $1
-		WHERE i.category != $2
+		WHERE i.category != $2 AND i.published
 		GROUP BY i.category, i.subcategory, COALESCE(up.status, 'pending')
 		ORDER BY i.category, i.subcategory, status`
 
 	rows, err := r.db.Query(query, userID, models.CategoryMiscellaneous)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user subcategory counts: %w", err)
+		return nil, apperr.Errorf("failed to get user subcategory counts: %w", err)
 	}
 	defer rows.Close()
 
@@ -988,7 +1608,7 @@ func (r *ItemRepository) GetCountsBySubcategoryForUser(userID int) (map[models.C
 
 		err := rows.Scan(&category, &subcategory, &status, &count)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan subcategory count: %w", err)
+			return nil, apperr.Errorf("failed to scan subcategory count: %w", err)
 		}
 
 		if result[category] == nil {
@@ -1005,42 +1625,43 @@ func (r *ItemRepository) GetCountsBySubcategoryForUser(userID int) (map[models.C
 
 // GetRandomItems retrieves random items with user progress based on filters
 func (r *ItemRepository) GetRandomItems(userID int, filter *models.ItemFilter) ([]models.ItemWithProgress, error) {
-	query := `
-		SELECT 
-			i.id, i.title, i.link, i.category, i.subcategory, i.attachments, i.created_at,
-			COALESCE(up.status, 'pending') as status,
-			COALESCE(up.starred, false) as starred,
-			COALESCE(up.notes, '') as notes,
-			up.completed_at
-		FROM items i
-		LEFT JOIN user_progress up 
-			ON i.id = up.item_id AND up.user_id = $1
-		WHERE 1=1`
-
+	columns := `
+		i.id, i.title, i.link, i.category, i.subcategory, i.attachments, i.created_at,
+		COALESCE(up.status, 'pending') as status,
+		COALESCE(up.starred, false) as starred,
+		COALESCE(up.notes, '') as notes,
+		up.completed_at`
+
+	conds := publishedCondition
 	args := []interface{}{userID}
 	argCount := 1
 
 	// Build WHERE clause based on filters
 	if filter.Category != nil {
 		argCount++
-		query += fmt.Sprintf(" AND i.category = $%d", argCount)
+		conds += fmt.Sprintf(" AND i.category = $%d", argCount)
 		args = append(args, *filter.Category)
 	}
 
 	if filter.Subcategory != nil {
 		argCount++
-		query += fmt.Sprintf(" AND i.subcategory = $%d", argCount)
+		conds += fmt.Sprintf(" AND i.subcategory = $%d", argCount)
 		args = append(args, *filter.Subcategory)
 	}
 
+	if filter.Company != nil {
+		argCount++
+		conds += companyFilterClause("i.id", argCount)
+		args = append(args, *filter.Company)
+	}
+
 	if filter.Status != nil {
 		argCount++
-		query += fmt.Sprintf(" AND COALESCE(up.status, 'pending') = $%d", argCount)
+		conds += fmt.Sprintf(" AND COALESCE(up.status, 'pending') = $%d", argCount)
 		args = append(args, *filter.Status)
 	}
 
-	// Add random ordering
-	query += " ORDER BY RANDOM()"
+	query := randomItemsQuery(columns, conds)
 
 	// Add limit
 	if filter.Limit != nil {
@@ -1051,7 +1672,7 @@ func (r *ItemRepository) GetRandomItems(userID int, filter *models.ItemFilter) (
 
 	rows, err := r.db.Query(query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get random items: %w", err)
+		return nil, apperr.Errorf("failed to get random items: %w", err)
 	}
 	defer rows.Close()
 
@@ -1064,14 +1685,491 @@ func (r *ItemRepository) GetRandomItems(userID int, filter *models.ItemFilter) (
 			&item.Notes, &item.CompletedAt,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan random item: %w", err)
+			return nil, apperr.Errorf("failed to scan random item: %w", err)
 		}
 		items = append(items, item)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating random items: %w", err)
+		return nil, apperr.Errorf("error iterating random items: %w", err)
+	}
+
+	return items, nil
+}
+
+// GetReviewCountForUser returns how many times a user has reviewed a completed item
+func (r *ItemRepository) GetReviewCountForUser(userID, itemID int) (int, error) {
+	query := `
+		SELECT review_count
+		FROM user_progress
+		WHERE user_id = $1 AND item_id = $2 AND status = 'done'`
+
+	var count int
+	err := r.db.QueryRow(query, userID, itemID).Scan(&count)
+	if err == sql.ErrNoRows {
+		return 0, apperr.Conflict("item not completed")
+	}
+	if err != nil {
+		return 0, apperr.Errorf("failed to get review count: %w", err)
+	}
+
+	return count, nil
+}
+
+// RecordReviewForUser records a review of a completed item and schedules the next one
+func (r *ItemRepository) RecordReviewForUser(userID, itemID int, quality models.CompletionQuality, nextReviewAt time.Time) (*models.ItemWithProgress, error) {
+	query := `
+		UPDATE user_progress
+		SET completion_quality = $1, next_review_at = $2, review_count = review_count + 1, updated_at = $3
+		WHERE user_id = $4 AND item_id = $5 AND status = 'done'`
+
+	result, err := r.db.Exec(query, quality, nextReviewAt, time.Now(), userID, itemID)
+	if err != nil {
+		return nil, apperr.Errorf("failed to record review: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, apperr.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return nil, apperr.Conflict("item not completed")
+	}
+
+	return r.GetByIDWithUserProgress(userID, itemID)
+}
+
+// GetDueReviewsForUser retrieves completed items whose next review is due, oldest first
+func (r *ItemRepository) GetDueReviewsForUser(userID int, dueBy time.Time, limit int) ([]*models.ItemWithProgress, error) {
+	query := `
+		SELECT 
+			i.id, i.title, i.link, i.category, i.subcategory, i.attachments, i.created_at,
+			up.status, up.starred, COALESCE(up.notes, '') as notes,
+			up.completed_at, up.completion_quality, up.next_review_at
+		FROM items i
+		INNER JOIN user_progress up ON i.id = up.item_id AND up.user_id = $1
+		WHERE up.status = 'done' AND up.next_review_at <= $2
+		ORDER BY up.next_review_at ASC
+		LIMIT $3`
+
+	rows, err := r.db.Query(query, userID, dueBy, limit)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get due reviews: %w", err)
+	}
+	defer rows.Close()
+
+	items := []*models.ItemWithProgress{}
+	for rows.Next() {
+		var item models.ItemWithProgress
+		err := rows.Scan(
+			&item.ID, &item.Title, &item.Link, &item.Category, &item.Subcategory,
+			&item.Attachments, &item.CreatedAt, &item.Status, &item.Starred,
+			&item.Notes, &item.CompletedAt, &item.CompletionQuality, &item.NextReviewAt,
+		)
+		if err != nil {
+			return nil, apperr.Errorf("failed to scan due review: %w", err)
+		}
+		items = append(items, &item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating due reviews: %w", err)
+	}
+
+	return items, nil
+}
+
+// CountReviewsDueForUser counts the user's completed items due for review by dueBy
+// (excluding miscellaneous category)
+func (r *ItemRepository) CountReviewsDueForUser(userID int, dueBy time.Time) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM user_progress up
+		INNER JOIN items i ON i.id = up.item_id
+		WHERE up.user_id = $1 AND up.status = 'done' AND up.next_review_at <= $3 AND i.category != $2`
+
+	var count int
+	if err := r.db.QueryRow(query, userID, models.CategoryMiscellaneous, dueBy).Scan(&count); err != nil {
+		return 0, apperr.Errorf("failed to count reviews due: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetCompletionQualityCountsForUser returns completion counts split by quality and the number of reviews due
+// (excluding miscellaneous category)
+func (r *ItemRepository) GetCompletionQualityCountsForUser(userID int) (solved, reviewedSolution, reviewsDue int, err error) {
+	query := `
+		SELECT 
+			COUNT(CASE WHEN COALESCE(up.completion_quality, 'solved') = 'solved' THEN 1 END) as solved,
+			COUNT(CASE WHEN up.completion_quality = 'reviewed_solution' THEN 1 END) as reviewed_solution,
+			COUNT(CASE WHEN up.next_review_at <= $3 THEN 1 END) as reviews_due
+		FROM user_progress up
+		INNER JOIN items i ON i.id = up.item_id
+		WHERE up.user_id = $1 AND up.status = 'done' AND i.category != $2`
+
+	err = r.db.QueryRow(query, userID, models.CategoryMiscellaneous, time.Now()).Scan(&solved, &reviewedSolution, &reviewsDue)
+	if err != nil {
+		return 0, 0, 0, apperr.Errorf("failed to get completion quality counts: %w", err)
+	}
+
+	return solved, reviewedSolution, reviewsDue, nil
+}
+
+// randomSampleWindow is the soft limit on how many matching items a random pick shuffles
+const randomSampleWindow = 1000
+
+// randomItemsQuery selects columns from items i joined to the user's progress up (user ID in $1)
+// for the items matching conds, a series of " AND ..." clauses, in random order. Instead of
+// sorting every matching row with ORDER BY RANDOM(), it takes up to randomSampleWindow matching
+// ids walking the primary key from a random item, wrapping around to the start, and shuffles only
+// those. Result sets smaller than the window are sampled uniformly; larger ones are sampled from
+// a random stretch of the catalog, and BenchmarkGetRandomItems compares the cost with a full sort.
+//
+// The pivot is an existing item picked uniformly by offset into the primary key index, not a
+// random value between the lowest and highest id, so gaps left by deleted items don't make the
+// items after them likelier picks. Items that don't match conds still leave gaps: when more items
+// match than fit the window, the first matches after a long run of non-matching items (say,
+// pending items after a stretch the user has done) are picked more often than the rest.
+func randomItemsQuery(columns, conds string) string {
+	return fmt.Sprintf(`
+		WITH pivot AS (
+			SELECT COALESCE((
+				SELECT id FROM items
+				ORDER BY id
+				LIMIT 1 OFFSET floor(random() * (SELECT COUNT(*) FROM items))::int
+			), 0) AS id
+		), candidates AS (
+			SELECT id FROM (
+				SELECT i.id
+				FROM items i
+				LEFT JOIN user_progress up 
+					ON i.id = up.item_id AND up.user_id = $1
+				WHERE i.id >= (SELECT id FROM pivot)%[2]s
+				ORDER BY i.id
+				LIMIT %[3]d
+			) from_pivot
+			UNION ALL
+			SELECT id FROM (
+				SELECT i.id
+				FROM items i
+				LEFT JOIN user_progress up 
+					ON i.id = up.item_id AND up.user_id = $1
+				WHERE i.id < (SELECT id FROM pivot)%[2]s
+				ORDER BY i.id
+				LIMIT %[3]d
+			) before_pivot
+		)
+		SELECT %[1]s
+		FROM items i
+		LEFT JOIN user_progress up 
+			ON i.id = up.item_id AND up.user_id = $1
+		WHERE i.id IN (SELECT id FROM candidates LIMIT %[3]d)
+		ORDER BY RANDOM()`, columns, conds, randomSampleWindow)
+}
+
+// companyFilterClause restricts a query to items tagged with the company slug bound at argPos
+func companyFilterClause(itemIDColumn string, argPos int) string {
+	return fmt.Sprintf(` AND EXISTS (
+			SELECT 1 FROM item_companies ic
+			INNER JOIN companies co ON co.id = ic.company_id
+			WHERE ic.item_id = %s AND co.slug = $%d)`, itemIDColumn, argPos)
+}
+
+// GetSubcategorySignalsForUser returns, per subcategory (excluding miscellaneous), how far the
+// user has got, with completions weighted by the hint credit they earned, along with how often
+// they skipped its items, failed them in tests and how they scored on its quizzes, counting only
+// their latest attempt at each
+func (r *ItemRepository) GetSubcategorySignalsForUser(userID int) ([]*models.SubcategorySignals, error) {
+	query := `
+		SELECT
+			i.category, i.subcategory,
+			COUNT(*) AS total_items,
+			COUNT(*) FILTER (WHERE up.status = 'done') AS completed_items,
+			COALESCE(SUM(COALESCE(up.hint_credit, 1)) FILTER (WHERE up.status = 'done'), 0) AS completed_credit,
+			COALESCE(SUM(up.skip_count), 0) AS skips,
+			COALESCE(SUM(t.attempts), 0) AS test_attempts,
+			COALESCE(SUM(t.failures), 0) AS test_failures,
+			COALESCE(SUM(t.partials), 0) AS test_partials,
+			COALESCE(MAX(qz.correct), 0) AS quiz_correct,
+			COALESCE(MAX(qz.questions), 0) AS quiz_questions
+		FROM items i
+		LEFT JOIN user_progress up
+			ON i.id = up.item_id AND up.user_id = $1
+		LEFT JOIN (
+			SELECT item_id,
+				COUNT(*) FILTER (WHERE status <> 'pending' OR outcome IS NOT NULL) AS attempts,
+				COUNT(*) FILTER (WHERE status = 'abandoned' OR outcome = 'failed') AS failures,
+				COUNT(*) FILTER (WHERE status <> 'abandoned' AND outcome = 'partial') AS partials
+			FROM tests
+			WHERE user_id = $1
+			GROUP BY item_id
+		) t ON t.item_id = i.id
+		LEFT JOIN (
+			SELECT q.category, q.subcategory, SUM(a.correct) AS correct, SUM(a.total) AS questions
+			FROM (
+				SELECT quiz_id, correct, total,
+					ROW_NUMBER() OVER (PARTITION BY quiz_id ORDER BY created_at DESC, id DESC) AS recency
+				FROM quiz_attempts
+				WHERE user_id = $1
+			) a
+			INNER JOIN quizzes q ON q.id = a.quiz_id AND a.recency = 1
+			GROUP BY q.category, q.subcategory
+		) qz ON qz.category = i.category AND qz.subcategory = i.subcategory
+		WHERE i.category != $2 AND i.published
+		GROUP BY i.category, i.subcategory
+		ORDER BY i.category, i.subcategory`
+
+	var signals []*models.SubcategorySignals
+	err := withUserContext(r.db, userID, func(q dbtx) error {
+		rows, err := q.Query(query, userID, models.CategoryMiscellaneous)
+		if err != nil {
+			return apperr.Errorf("failed to get subcategory signals: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			s := &models.SubcategorySignals{}
+			err := rows.Scan(&s.Category, &s.Subcategory, &s.TotalItems, &s.CompletedItems, &s.CompletedCredit, &s.Skips, &s.TestAttempts, &s.TestFailures, &s.TestPartials, &s.QuizCorrect, &s.QuizQuestions)
+			if err != nil {
+				return apperr.Errorf("failed to scan subcategory signals: %w", err)
+			}
+			signals = append(signals, s)
+		}
+
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return signals, nil
+}
+
+// GetSuggestedItemsForUser picks items in a subcategory for the user to work on next: items
+// they failed or only partly solved in a test first, then unfinished items they've skipped least
+func (r *ItemRepository) GetSuggestedItemsForUser(userID int, category models.Category, subcategory string, limit int) ([]*models.ItemWithProgress, error) {
+	query := `
+		SELECT
+			i.id, i.title, i.link, i.category, i.subcategory, i.attachments, i.created_at,
+			COALESCE(up.status, 'pending') as status,
+			COALESCE(up.starred, false) as starred,
+			up.completed_at
+		FROM items i
+		LEFT JOIN user_progress up
+			ON i.id = up.item_id AND up.user_id = $1
+		LEFT JOIN (
+			SELECT item_id,
+				COUNT(*) FILTER (WHERE status = 'abandoned' OR outcome = 'failed') AS failures,
+				COUNT(*) FILTER (WHERE status <> 'abandoned' AND outcome = 'partial') AS partials
+			FROM tests
+			WHERE user_id = $1 AND (status = 'abandoned' OR outcome IN ('failed', 'partial'))
+			GROUP BY item_id
+		) t ON t.item_id = i.id
+		WHERE i.category = $2 AND i.subcategory = $3 AND i.published
+		AND (COALESCE(up.status, 'pending') <> 'done' OR t.item_id IS NOT NULL)
+		ORDER BY COALESCE(t.failures, 0) DESC,
+			COALESCE(t.partials, 0) DESC,
+			COALESCE(up.status, 'pending') = 'in-progress' DESC,
+			COALESCE(up.skip_count, 0),
+			i.id
+		LIMIT $4`
+
+	items := []*models.ItemWithProgress{}
+	err := withUserContext(r.db, userID, func(q dbtx) error {
+		rows, err := q.Query(query, userID, category, subcategory, limit)
+		if err != nil {
+			return apperr.Errorf("failed to get suggested items: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			item := &models.ItemWithProgress{}
+			err := rows.Scan(
+				&item.ID, &item.Title, &item.Link, &item.Category, &item.Subcategory,
+				&item.Attachments, &item.CreatedAt, &item.Status, &item.Starred, &item.CompletedAt,
+			)
+			if err != nil {
+				return apperr.Errorf("failed to scan suggested item: %w", err)
+			}
+			items = append(items, item)
+		}
+
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return items, nil
 }
+
+// GetIDsByNormalizedLinks finds the items whose link, lowercased and without a trailing
+// slash, is one of the given links. The result is keyed by normalized link.
+func (r *ItemRepository) GetIDsByNormalizedLinks(links []string) (map[string]int, error) {
+	ids := map[string]int{}
+	if len(links) == 0 {
+		return ids, nil
+	}
+
+	query := `
+		SELECT RTRIM(LOWER(TRIM(link)), '/'), MIN(id)
+		FROM items
+		WHERE RTRIM(LOWER(TRIM(link)), '/') = ANY($1)
+		GROUP BY 1`
+
+	rows, err := r.db.Query(query, pq.Array(links))
+	if err != nil {
+		return nil, apperr.Errorf("failed to get items by link: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var link string
+		var id int
+		if err := rows.Scan(&link, &id); err != nil {
+			return nil, apperr.Errorf("failed to scan item link: %w", err)
+		}
+		ids[link] = id
+	}
+
+	return ids, rows.Err()
+}
+
+// itemAnalyticsOrder maps analytics sort keys to the columns they order by
+var itemAnalyticsOrder = map[string]string{
+	models.ItemAnalyticsSortCompletionRate:       "completion_rate",
+	models.ItemAnalyticsSortReviewedSolutionRate: "reviewed_solution_rate",
+	models.ItemAnalyticsSortSkipRate:             "skip_rate",
+	models.ItemAnalyticsSortStarCount:            "star_count",
+	models.ItemAnalyticsSortAvgTimeToComplete:    "avg_time_to_complete",
+	models.ItemAnalyticsSortAttemptedUsers:       "attempted_users",
+}
+
+// GetItemAnalytics aggregates every user's progress per item, returning a page of items
+// matching the filter and the total number that match
+func (r *ItemRepository) GetItemAnalytics(filter *models.ItemAnalyticsFilter) ([]*models.ItemAnalytics, int, error) {
+	conditions := []string{"COALESCE(p.attempted_users, 0) >= $1"}
+	args := []interface{}{filter.MinAttempts}
+
+	if filter.ItemID != nil {
+		args = append(args, *filter.ItemID)
+		conditions = append(conditions, fmt.Sprintf("i.id = $%d", len(args)))
+	}
+	if filter.Category != nil {
+		args = append(args, *filter.Category)
+		conditions = append(conditions, fmt.Sprintf("i.category = $%d", len(args)))
+	}
+	if filter.Subcategory != nil {
+		args = append(args, *filter.Subcategory)
+		conditions = append(conditions, fmt.Sprintf("i.subcategory = $%d", len(args)))
+	}
+
+	orderBy, ok := itemAnalyticsOrder[filter.Sort]
+	if !ok {
+		orderBy = itemAnalyticsOrder[models.ItemAnalyticsSortCompletionRate]
+	}
+	direction := "ASC"
+	if filter.Descending {
+		direction = "DESC"
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+
+	query := fmt.Sprintf(`
+		WITH progress AS (
+			SELECT item_id,
+				COUNT(*) FILTER (WHERE status IN ('in-progress', 'done') OR skip_count > 0) AS attempted_users,
+				COUNT(*) FILTER (WHERE status = 'done') AS completed_users,
+				COUNT(*) FILTER (WHERE status = 'in-progress') AS in_progress_users,
+				COUNT(*) FILTER (WHERE status = 'done' AND completion_quality = 'reviewed_solution') AS reviewed_solution_users,
+				COUNT(*) FILTER (WHERE skip_count > 0) AS skipped_users,
+				SUM(skip_count) AS total_skips,
+				COUNT(*) FILTER (WHERE starred) AS star_count,
+				AVG(EXTRACT(EPOCH FROM completed_at - started_at))
+					FILTER (WHERE status = 'done' AND completed_at > started_at) AS avg_time_to_complete
+			FROM user_progress
+			GROUP BY item_id
+		),
+		focus AS (
+			SELECT item_id, AVG(seconds) AS avg_focused
+			FROM (
+				SELECT item_id, user_id, SUM(focused_seconds) AS seconds
+				FROM focus_sessions
+				WHERE item_id IS NOT NULL
+				GROUP BY item_id, user_id
+			) per_user
+			GROUP BY item_id
+		),
+		analytics AS (
+			SELECT i.id, i.title, i.category, i.subcategory,
+				COALESCE(p.attempted_users, 0) AS attempted_users,
+				COALESCE(p.completed_users, 0) AS completed_users,
+				COALESCE(p.in_progress_users, 0) AS in_progress_users,
+				COALESCE(ROUND(100.0 * p.completed_users / NULLIF(p.attempted_users, 0), 2), 0) AS completion_rate,
+				COALESCE(ROUND(100.0 * p.reviewed_solution_users / NULLIF(p.completed_users, 0), 2), 0) AS reviewed_solution_rate,
+				COALESCE(p.skipped_users, 0) AS skipped_users,
+				COALESCE(p.total_skips, 0) AS total_skips,
+				COALESCE(ROUND(100.0 * p.skipped_users / NULLIF(p.attempted_users, 0), 2), 0) AS skip_rate,
+				COALESCE(p.star_count, 0) AS star_count,
+				ROUND(p.avg_time_to_complete)::INTEGER AS avg_time_to_complete,
+				ROUND(f.avg_focused)::INTEGER AS avg_focused
+			FROM items i
+			LEFT JOIN progress p ON p.item_id = i.id
+			LEFT JOIN focus f ON f.item_id = i.id
+			WHERE %s
+		)
+		SELECT *, COUNT(*) OVER () AS total
+		FROM analytics
+		ORDER BY %s %s NULLS LAST, id
+		LIMIT $%d OFFSET $%d`,
+		strings.Join(conditions, " AND "), orderBy, direction, len(args)-1, len(args))
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, 0, apperr.Errorf("failed to get item analytics: %w", err)
+	}
+	defer rows.Close()
+
+	analytics := []*models.ItemAnalytics{}
+	total := 0
+	for rows.Next() {
+		var a models.ItemAnalytics
+		if err := rows.Scan(
+			&a.ItemID, &a.Title, &a.Category, &a.Subcategory,
+			&a.AttemptedUsers, &a.CompletedUsers, &a.InProgressUsers,
+			&a.CompletionRate, &a.ReviewedSolutionRate,
+			&a.SkippedUsers, &a.TotalSkips, &a.SkipRate, &a.StarCount,
+			&a.AvgTimeToCompleteSeconds, &a.AvgFocusedSeconds, &total,
+		); err != nil {
+			return nil, 0, apperr.Errorf("failed to scan item analytics: %w", err)
+		}
+		analytics = append(analytics, &a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, apperr.Errorf("failed to read item analytics: %w", err)
+	}
+
+	// A page past the end has no rows to carry the total
+	if len(analytics) == 0 && filter.Offset > 0 {
+		if err := r.db.QueryRow(
+			fmt.Sprintf(`
+				WITH progress AS (
+					SELECT item_id, COUNT(*) FILTER (WHERE status IN ('in-progress', 'done') OR skip_count > 0) AS attempted_users
+					FROM user_progress
+					GROUP BY item_id
+				)
+				SELECT COUNT(*)
+				FROM items i
+				LEFT JOIN progress p ON p.item_id = i.id
+				WHERE %s`, strings.Join(conditions, " AND ")),
+			args[:len(args)-2]...,
+		).Scan(&total); err != nil {
+			return nil, 0, apperr.Errorf("failed to count item analytics: %w", err)
+		}
+	}
+
+	return analytics, total, nil
+}
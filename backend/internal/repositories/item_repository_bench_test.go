@@ -0,0 +1,94 @@
+package repositories_test
+
+import (
+	"testing"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+	"interview-prep-app/internal/testutil"
+	"interview-prep-app/internal/testutil/factories"
+)
+
+// benchmarkItems is the catalog size GetRandomItems is benchmarked against. Every fourth item
+// is done for the benchmark user, so status filters skip over runs of non-matching items.
+const benchmarkItems = 20000
+
+// BenchmarkGetRandomItems compares the sampled id window GetRandomItems reads through with
+// sorting every matching row by RANDOM(), which it replaced. Run it against the test database:
+//
+//	TEST_DATABASE_URL=... go test -run '^$' -bench GetRandomItems ./internal/repositories
+func BenchmarkGetRandomItems(b *testing.B) {
+	db := testutil.OpenDB(b)
+	f := factories.New(b, db)
+	itemRepo := repositories.NewItemRepository(db)
+
+	user := f.User()
+	categories := []models.Category{models.CategoryDSA, models.CategoryLLD, models.CategoryHLD}
+	for i := 0; i < benchmarkItems; i++ {
+		item := f.Item(func(req *models.CreateItemRequest) {
+			req.Category = categories[i%len(categories)]
+		})
+		if i%4 == 0 {
+			if err := itemRepo.UpsertUserProgressForItem(user.ID, item.ID, models.StatusDone); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+
+	category := models.CategoryDSA
+	pending := models.StatusPending
+	limit := 5
+	filters := []struct {
+		name   string
+		filter *models.ItemFilter
+	}{
+		{"category", &models.ItemFilter{Category: &category, Limit: &limit}},
+		{"pending", &models.ItemFilter{Category: &category, Status: &pending, Limit: &limit}},
+	}
+
+	for _, tc := range filters {
+		filter := tc.filter
+		b.Run(tc.name+"/window", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				items, err := itemRepo.GetRandomItems(user.ID, filter)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if len(items) != limit {
+					b.Fatalf("Expected %d items, got %d", limit, len(items))
+				}
+			}
+		})
+
+		b.Run(tc.name+"/full sort", func(b *testing.B) {
+			query := `
+				SELECT i.id
+				FROM items i
+				LEFT JOIN user_progress up
+					ON i.id = up.item_id AND up.user_id = $1
+				WHERE i.published AND i.category = $2 AND ($3::TEXT IS NULL OR COALESCE(up.status, 'pending') = $3)
+				ORDER BY RANDOM()
+				LIMIT $4`
+			var status *string
+			if filter.Status != nil {
+				s := string(*filter.Status)
+				status = &s
+			}
+
+			for i := 0; i < b.N; i++ {
+				rows, err := db.Query(query, user.ID, category, status, limit)
+				if err != nil {
+					b.Fatal(err)
+				}
+				n := 0
+				for rows.Next() {
+					n++
+				}
+				rows.Close()
+				if n != limit {
+					b.Fatalf("Expected %d items, got %d", limit, n)
+				}
+			}
+		})
+	}
+}
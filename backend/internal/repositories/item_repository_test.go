@@ -0,0 +1,40 @@
+package repositories
+
+import (
+	"testing"
+
+	"interview-prep-app/internal/models"
+)
+
+func TestItemChanges(t *testing.T) {
+	before := models.ItemContent{
+		Title:       "Two Sum",
+		Link:        "https://example.com/two-sum",
+		Category:    models.CategoryDSA,
+		Subcategory: "arrays",
+		Attachments: models.Attachments{"difficulty": "easy"},
+	}
+
+	after := before
+	after.Title = "Two Sum II"
+	after.Attachments = models.Attachments{"difficulty": "medium"}
+
+	changes := itemChanges(before, after)
+	if len(changes) != 2 {
+		t.Fatalf("Expected title and attachments to change, got %v", changes)
+	}
+	if change := changes["title"]; change.From != "Two Sum" || change.To != "Two Sum II" {
+		t.Errorf("Expected the title change from and to, got %+v", change)
+	}
+	if _, ok := changes["attachments"]; !ok {
+		t.Error("Expected the attachments change")
+	}
+
+	// Missing and empty attachments are the same
+	before.Attachments = nil
+	after = before
+	after.Attachments = models.Attachments{}
+	if changes := itemChanges(before, after); len(changes) != 0 {
+		t.Errorf("Expected no changes, got %v", changes)
+	}
+}
@@ -0,0 +1,159 @@
+package repositories
+
+import (
+	"database/sql"
+	"time"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+)
+
+// JobRepository locks background jobs across server instances and stores their run history.
+// It implements jobs.Store.
+type JobRepository struct {
+	db *sql.DB
+}
+
+// NewJobRepository creates a new JobRepository
+func NewJobRepository(db *sql.DB) *JobRepository {
+	return &JobRepository{db: db}
+}
+
+// Acquire locks a job for one run by owner, unless another owner holds an unexpired lock or the
+// job was started within minGap of now
+func (r *JobRepository) Acquire(name, owner string, now time.Time, minGap, lease time.Duration) (bool, error) {
+	query := `
+		INSERT INTO job_locks (name, locked_by, locked_until, last_started_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (name) DO UPDATE
+		SET locked_by = EXCLUDED.locked_by, locked_until = EXCLUDED.locked_until, last_started_at = EXCLUDED.last_started_at
+		WHERE job_locks.locked_until <= $4 AND (job_locks.last_started_at IS NULL OR job_locks.last_started_at <= $5)
+		RETURNING name`
+
+	var locked string
+	err := r.db.QueryRow(query, name, owner, now.Add(lease), now, now.Add(-minGap)).Scan(&locked)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, apperr.Errorf("failed to lock job: %w", err)
+	}
+
+	return true, nil
+}
+
+// Release unlocks a job owner holds
+func (r *JobRepository) Release(name, owner string) error {
+	query := "UPDATE job_locks SET locked_until = CURRENT_TIMESTAMP WHERE name = $1 AND locked_by = $2"
+	if _, err := r.db.Exec(query, name, owner); err != nil {
+		return apperr.Errorf("failed to unlock job: %w", err)
+	}
+
+	return nil
+}
+
+// StartRun records the start of a job run and returns its ID
+func (r *JobRepository) StartRun(name, instance string, trigger models.JobTrigger, startedAt time.Time) (int, error) {
+	query := `
+		INSERT INTO job_runs (name, instance, triggered_by, status, started_at)
+		VALUES ($1, $2, $3, 'running', $4)
+		RETURNING id`
+
+	var id int
+	if err := r.db.QueryRow(query, name, instance, trigger, startedAt).Scan(&id); err != nil {
+		return 0, apperr.Errorf("failed to record job run: %w", err)
+	}
+
+	return id, nil
+}
+
+// FinishRun records how a job run ended; a nil runErr means it succeeded
+func (r *JobRepository) FinishRun(id int, runErr error, finishedAt time.Time) error {
+	status, message := models.JobRunSucceeded, ""
+	if runErr != nil {
+		status, message = models.JobRunFailed, runErr.Error()
+	}
+
+	query := `
+		UPDATE job_runs
+		SET status = $2, error = $3, finished_at = $4,
+			duration_ms = (EXTRACT(EPOCH FROM ($4::timestamp - started_at)) * 1000)::BIGINT
+		WHERE id = $1`
+
+	if _, err := r.db.Exec(query, id, status, message, finishedAt); err != nil {
+		return apperr.Errorf("failed to record job result: %w", err)
+	}
+
+	return nil
+}
+
+// GetLastRuns returns the most recent run of every job that has run, keyed by job name
+func (r *JobRepository) GetLastRuns() (map[string]*models.JobRun, error) {
+	query := `
+		SELECT id, name, instance, triggered_by, status, error, started_at, finished_at, duration_ms
+		FROM (
+			SELECT *, ROW_NUMBER() OVER (PARTITION BY name ORDER BY started_at DESC, id DESC) AS recency
+			FROM job_runs
+		) runs
+		WHERE recency = 1
+		ORDER BY name`
+
+	runs, err := r.queryRuns(query)
+	if err != nil {
+		return nil, err
+	}
+
+	last := make(map[string]*models.JobRun, len(runs))
+	for _, run := range runs {
+		last[run.Name] = run
+	}
+	return last, nil
+}
+
+// GetRecentRuns returns a job's most recent runs, newest first
+func (r *JobRepository) GetRecentRuns(name string, limit int) ([]*models.JobRun, error) {
+	query := `
+		SELECT id, name, instance, triggered_by, status, error, started_at, finished_at, duration_ms
+		FROM job_runs
+		WHERE name = $1
+		ORDER BY started_at DESC, id DESC
+		LIMIT $2`
+
+	return r.queryRuns(query, name, limit)
+}
+
+// DeleteRunsBefore removes run history older than the cutoff and returns how many runs there were
+func (r *JobRepository) DeleteRunsBefore(cutoff time.Time) (int64, error) {
+	result, err := r.db.Exec("DELETE FROM job_runs WHERE started_at < $1", cutoff)
+	if err != nil {
+		return 0, apperr.Errorf("failed to delete job runs: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+// queryRuns scans job runs selected in the column order used above
+func (r *JobRepository) queryRuns(query string, args ...interface{}) ([]*models.JobRun, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get job runs: %w", err)
+	}
+	defer rows.Close()
+
+	runs := []*models.JobRun{}
+	for rows.Next() {
+		run := &models.JobRun{}
+		err := rows.Scan(&run.ID, &run.Name, &run.Instance, &run.Trigger, &run.Status, &run.Error,
+			&run.StartedAt, &run.FinishedAt, &run.DurationMs)
+		if err != nil {
+			return nil, apperr.Errorf("failed to scan job run: %w", err)
+		}
+		runs = append(runs, run)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating job runs: %w", err)
+	}
+
+	return runs, nil
+}
@@ -0,0 +1,292 @@
+package repositories
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"interview-prep-app/internal/database"
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+	"time"
+)
+
+// LifecycleRepository handles database operations for archiving inactive users and purging deleted accounts
+type LifecycleRepository struct {
+	db      *sql.DB
+	dialect database.Dialect
+}
+
+// NewLifecycleRepository creates a new LifecycleRepository
+func NewLifecycleRepository(db *sql.DB) *LifecycleRepository {
+	return &LifecycleRepository{db: db, dialect: database.DialectFor(db)}
+}
+
+// archivalCleanups are the per-user deletes run when a user is archived. Each must select rows by
+// user ID ($1) and is wrapped so its row count and on-disk size can be reported.
+var archivalCleanups = []struct {
+	table string
+	where string
+}{
+	{"refresh_tokens", "user_id = $1"},
+	{"calendar_feeds", "user_id = $1"},
+	{"user_devices", "user_id = $1"},
+	{"sent_reminders", "user_id = $1"},
+	{"user_daily_goals", "user_id = $1"},
+	// Keep queued deliveries; only finished history is compacted
+	{"webhook_deliveries", "status <> 'pending' AND webhook_id IN (SELECT id FROM user_webhooks WHERE user_id = $1)"},
+}
+
+// GetInactiveUserIDs returns users not yet archived who haven't logged in or completed anything since before
+func (r *LifecycleRepository) GetInactiveUserIDs(before time.Time, limit int) ([]int, error) {
+	query := `
+		SELECT u.id
+		FROM users u
+		LEFT JOIN user_stats us ON us.user_id = u.id
+		WHERE u.archived_at IS NULL
+		  AND GREATEST(u.created_at, COALESCE(u.last_login_at, u.created_at), COALESCE(us.last_activity_date, u.created_at)) < $1
+		ORDER BY u.id
+		LIMIT $2`
+
+	rows, err := r.db.Query(query, before, limit)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get inactive users: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, apperr.Errorf("failed to scan inactive user: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating inactive users: %w", err)
+	}
+
+	return ids, nil
+}
+
+// ArchiveUser clears a user's cached aggregates, revokes their tokens and compacts their event history
+// in one transaction. It returns the rows deleted per table and the bytes they occupied.
+func (r *LifecycleRepository) ArchiveUser(userID int) (map[string]int64, int64, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, 0, apperr.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	deleted := make(map[string]int64)
+	var reclaimed int64
+	for _, cleanup := range archivalCleanups {
+		count, bytes, err := r.deleteMeasured(tx, cleanup.table, cleanup.where, userID)
+		if err != nil {
+			return nil, 0, apperr.Errorf("failed to clean up %s: %w", cleanup.table, err)
+		}
+		if count > 0 {
+			deleted[cleanup.table] = count
+			reclaimed += bytes
+		}
+	}
+
+	// The cached streak is stale after months away; the next completion starts a new one
+	if _, err := tx.Exec("UPDATE user_stats SET current_streak = 0, updated_at = CURRENT_TIMESTAMP WHERE user_id = $1", userID); err != nil {
+		return nil, 0, apperr.Errorf("failed to reset user streak: %w", err)
+	}
+
+	if _, err := tx.Exec("UPDATE users SET archived_at = CURRENT_TIMESTAMP WHERE id = $1", userID); err != nil {
+		return nil, 0, apperr.Errorf("failed to mark user archived: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, 0, apperr.Errorf("failed to commit archival: %w", err)
+	}
+
+	return deleted, reclaimed, nil
+}
+
+// deleteMeasured deletes a table's matching rows, returning how many there were and the bytes
+// they occupied. SQLite can't size rows, so it only counts them.
+func (r *LifecycleRepository) deleteMeasured(tx *sql.Tx, table, where string, args ...interface{}) (count, bytes int64, err error) {
+	if r.dialect == database.DialectSQLite {
+		result, err := tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE %s", table, where), args...)
+		if err != nil {
+			return 0, 0, err
+		}
+		count, err = result.RowsAffected()
+		return count, 0, err
+	}
+
+	query := fmt.Sprintf(`
+		WITH deleted AS (DELETE FROM %s WHERE %s RETURNING *)
+		SELECT COUNT(*), COALESCE(SUM(pg_column_size(deleted.*)), 0) FROM deleted`, table, where)
+	err = tx.QueryRow(query, args...).Scan(&count, &bytes)
+	return count, bytes, err
+}
+
+// ScheduleDeletion deactivates a user who deleted their account, revoking every session, and
+// marks their data to be purged at purgeAt
+func (r *LifecycleRepository) ScheduleDeletion(userID int, now, purgeAt time.Time) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return apperr.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec("UPDATE refresh_tokens SET is_revoked = true, alert_token = NULL WHERE user_id = $1", userID)
+	if err != nil {
+		return apperr.Errorf("failed to revoke user refresh tokens: %w", err)
+	}
+
+	query := `
+		UPDATE users
+		SET is_active = false, sessions_revoked_at = $2, purge_at = $3, updated_at = $2
+		WHERE id = $1 AND is_active = true
+	`
+
+	result, err := tx.Exec(query, userID, now, purgeAt)
+	if err != nil {
+		return apperr.Errorf("failed to schedule user deletion: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return apperr.NotFound("user not found")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return apperr.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetUserIDsDueForPurge returns deleted accounts whose grace period ended before now
+func (r *LifecycleRepository) GetUserIDsDueForPurge(now time.Time, limit int) ([]int, error) {
+	query := `
+		SELECT id
+		FROM users
+		WHERE purge_at IS NOT NULL AND purge_at <= $1 AND is_active = false
+		ORDER BY purge_at
+		LIMIT $2`
+
+	rows, err := r.db.Query(query, now, limit)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get users due for purge: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, apperr.Errorf("failed to scan user due for purge: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating users due for purge: %w", err)
+	}
+
+	return ids, nil
+}
+
+// PurgeUser hard-deletes a deleted account. Per-user rows (progress, stats, tests, tokens and the
+// rest) go with it through ON DELETE CASCADE; shared rows it authored, such as organizations and
+// item feedback, are kept with the user cleared. It returns the storage keys of the user's
+// attachments, whose files the caller removes.
+func (r *LifecycleRepository) PurgeUser(userID int) ([]string, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, apperr.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query("SELECT storage_key FROM item_attachments WHERE user_id = $1", userID)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get user attachments: %w", err)
+	}
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			rows.Close()
+			return nil, apperr.Errorf("failed to scan user attachment: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating user attachments: %w", err)
+	}
+
+	// Only accounts still pending deletion; one restored by hand in the meantime is left alone
+	result, err := tx.Exec("DELETE FROM users WHERE id = $1 AND purge_at IS NOT NULL AND is_active = false", userID)
+	if err != nil {
+		return nil, apperr.Errorf("failed to delete user: %w", err)
+	}
+	if deleted, _ := result.RowsAffected(); deleted == 0 {
+		return nil, nil
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, apperr.Errorf("failed to commit purge: %w", err)
+	}
+
+	return keys, nil
+}
+
+// SaveRun records the outcome of an archival pass
+func (r *LifecycleRepository) SaveRun(run *models.LifecycleRun) error {
+	rowsDeleted, err := json.Marshal(run.RowsDeleted)
+	if err != nil {
+		return apperr.Errorf("failed to encode lifecycle run: %w", err)
+	}
+
+	query := `
+		INSERT INTO lifecycle_runs (inactive_before, users_archived, rows_deleted, bytes_reclaimed, started_at, finished_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id`
+
+	err = r.db.QueryRow(query, run.InactiveBefore, run.UsersArchived, rowsDeleted, run.BytesReclaimed, run.StartedAt, run.FinishedAt).Scan(&run.ID)
+	if err != nil {
+		return apperr.Errorf("failed to save lifecycle run: %w", err)
+	}
+
+	return nil
+}
+
+// GetRecentRuns returns the most recent archival passes, newest first
+func (r *LifecycleRepository) GetRecentRuns(limit int) ([]*models.LifecycleRun, error) {
+	query := `
+		SELECT id, inactive_before, users_archived, rows_deleted, bytes_reclaimed, started_at, finished_at
+		FROM lifecycle_runs
+		ORDER BY started_at DESC
+		LIMIT $1`
+
+	rows, err := r.db.Query(query, limit)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get lifecycle runs: %w", err)
+	}
+	defer rows.Close()
+
+	runs := []*models.LifecycleRun{}
+	for rows.Next() {
+		run := &models.LifecycleRun{}
+		var rowsDeleted []byte
+		if err := rows.Scan(&run.ID, &run.InactiveBefore, &run.UsersArchived, &rowsDeleted, &run.BytesReclaimed, &run.StartedAt, &run.FinishedAt); err != nil {
+			return nil, apperr.Errorf("failed to scan lifecycle run: %w", err)
+		}
+		if err := json.Unmarshal(rowsDeleted, &run.RowsDeleted); err != nil {
+			return nil, apperr.Errorf("failed to decode lifecycle run: %w", err)
+		}
+		runs = append(runs, run)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating lifecycle runs: %w", err)
+	}
+
+	return runs, nil
+}
@@ -0,0 +1,194 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+)
+
+// hideDeadLinks controls whether next-item selection skips items whose current link is
+// reported dead. It is set once at startup from configuration.
+var hideDeadLinks bool
+
+// SetHideDeadLinks turns skipping items with dead links on or off
+func SetHideDeadLinks(enabled bool) {
+	hideDeadLinks = enabled
+}
+
+// deadLinkCondition is appended to item queries (items aliased as i) to leave out items whose
+// current link is reported dead. A link that has since been edited no longer matches the check.
+const deadLinkCondition = `
+	AND NOT EXISTS (
+		SELECT 1 FROM link_checks lc
+		WHERE lc.item_id = i.id AND lc.url = i.link AND lc.dead_since IS NOT NULL
+	)`
+
+// LinkCheckRepository handles database operations for link check results
+type LinkCheckRepository struct {
+	db *sql.DB
+}
+
+// NewLinkCheckRepository creates a new link check repository
+func NewLinkCheckRepository(db *sql.DB) *LinkCheckRepository {
+	return &LinkCheckRepository{db: db}
+}
+
+// GetDue returns http(s) item and active eng blog article links that have never been checked,
+// were edited since their last check, or were last checked before checkedBefore. Links never
+// checked come first, then the longest unchecked.
+func (r *LinkCheckRepository) GetDue(checkedBefore time.Time, limit int) ([]*models.LinkToCheck, error) {
+	query := `
+		SELECT target_type, target_id, url
+		FROM (
+			SELECT 'item' AS target_type, i.id AS target_id, i.link AS url,
+				lc.url AS checked_url, lc.checked_at
+			FROM items i
+			LEFT JOIN link_checks lc ON lc.item_id = i.id
+			WHERE i.link ~* '^https?://'
+			UNION ALL
+			SELECT 'eng_blog_article', a.id, a.external_link, lc.url, lc.checked_at
+			FROM eng_blog_articles a
+			INNER JOIN eng_blogs b ON b.id = a.blog_id
+			LEFT JOIN link_checks lc ON lc.article_id = a.id
+			WHERE a.external_link ~* '^https?://' AND a.archived_at IS NULL AND b.archived_at IS NULL
+		) links
+		WHERE checked_at IS NULL OR checked_url <> url OR checked_at < $1
+		ORDER BY checked_at NULLS FIRST
+		LIMIT $2`
+
+	rows, err := r.db.Query(query, checkedBefore, limit)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get links to check: %w", err)
+	}
+	defer rows.Close()
+
+	links := []*models.LinkToCheck{}
+	for rows.Next() {
+		var link models.LinkToCheck
+		if err := rows.Scan(&link.TargetType, &link.TargetID, &link.URL); err != nil {
+			return nil, apperr.Errorf("failed to scan link: %w", err)
+		}
+		links = append(links, &link)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating links: %w", err)
+	}
+
+	return links, nil
+}
+
+// Record stores the outcome of checking a link. Failures count up across consecutive failed
+// checks of the same URL; once they reach deadAfter the link is marked dead, and a successful
+// check or a changed URL clears it.
+func (r *LinkCheckRepository) Record(link *models.LinkToCheck, statusCode *int, checkErr string, alive bool, deadAfter int) error {
+	column := "item_id"
+	if link.TargetType == models.LinkTargetEngBlogArticle {
+		column = "article_id"
+	}
+
+	failures := 1
+	if alive {
+		failures = 0
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return apperr.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	upsert := fmt.Sprintf(`
+		INSERT INTO link_checks (%[1]s, url, status_code, error, failures, checked_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (%[1]s) DO UPDATE SET
+			url = EXCLUDED.url,
+			status_code = EXCLUDED.status_code,
+			error = EXCLUDED.error,
+			checked_at = EXCLUDED.checked_at,
+			failures = CASE
+				WHEN EXCLUDED.failures = 0 THEN 0
+				WHEN link_checks.url = EXCLUDED.url THEN link_checks.failures + 1
+				ELSE 1
+			END,
+			dead_since = CASE WHEN link_checks.url = EXCLUDED.url THEN link_checks.dead_since END`, column)
+
+	if _, err := tx.Exec(upsert, link.TargetID, link.URL, statusCode, checkErr, failures, time.Now()); err != nil {
+		return apperr.Errorf("failed to record link check: %w", err)
+	}
+
+	markDead := fmt.Sprintf(`
+		UPDATE link_checks
+		SET dead_since = CASE WHEN failures >= $2 THEN COALESCE(dead_since, checked_at) END
+		WHERE %s = $1`, column)
+
+	if _, err := tx.Exec(markDead, link.TargetID, deadAfter); err != nil {
+		return apperr.Errorf("failed to update dead link state: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return apperr.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetDead returns a page of links currently marked dead, longest dead first, and how many
+// there are in total. Links edited since they were checked are left out.
+func (r *LinkCheckRepository) GetDead(targetType *models.LinkTargetType, limit, offset int) ([]*models.DeadLink, int, error) {
+	links := `
+		SELECT 'item' AS target_type, i.id AS target_id, NULL::INTEGER AS blog_id, i.title,
+			lc.url, lc.status_code, lc.error, lc.failures, lc.checked_at, lc.dead_since
+		FROM link_checks lc
+		INNER JOIN items i ON i.id = lc.item_id AND i.link = lc.url
+		WHERE lc.dead_since IS NOT NULL
+		UNION ALL
+		SELECT 'eng_blog_article', a.id, a.blog_id, a.title,
+			lc.url, lc.status_code, lc.error, lc.failures, lc.checked_at, lc.dead_since
+		FROM link_checks lc
+		INNER JOIN eng_blog_articles a ON a.id = lc.article_id AND a.external_link = lc.url
+		WHERE lc.dead_since IS NOT NULL`
+
+	var total int
+	err := r.db.QueryRow(`
+		SELECT COUNT(*) FROM (`+links+`) dead
+		WHERE ($1::VARCHAR IS NULL OR target_type = $1)`, targetType).Scan(&total)
+	if err != nil {
+		return nil, 0, apperr.Errorf("failed to count dead links: %w", err)
+	}
+
+	query := `
+		SELECT target_type, target_id, blog_id, title, url, status_code, error, failures, checked_at, dead_since
+		FROM (` + links + `) dead
+		WHERE ($1::VARCHAR IS NULL OR target_type = $1)
+		ORDER BY dead_since, target_type, target_id
+		LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.Query(query, targetType, limit, offset)
+	if err != nil {
+		return nil, 0, apperr.Errorf("failed to get dead links: %w", err)
+	}
+	defer rows.Close()
+
+	dead := []*models.DeadLink{}
+	for rows.Next() {
+		var link models.DeadLink
+		err := rows.Scan(
+			&link.TargetType, &link.TargetID, &link.BlogID, &link.Title, &link.URL,
+			&link.StatusCode, &link.Error, &link.Failures, &link.CheckedAt, &link.DeadSince,
+		)
+		if err != nil {
+			return nil, 0, apperr.Errorf("failed to scan dead link: %w", err)
+		}
+		dead = append(dead, &link)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, apperr.Errorf("error iterating dead links: %w", err)
+	}
+
+	return dead, total, nil
+}
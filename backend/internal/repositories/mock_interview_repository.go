@@ -0,0 +1,244 @@
+package repositories
+
+import (
+	"database/sql"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+)
+
+// MockInterviewRepository handles database operations for mock interviews and their transcripts
+type MockInterviewRepository struct {
+	db *sql.DB
+}
+
+// NewMockInterviewRepository creates a new mock interview repository
+func NewMockInterviewRepository(db *sql.DB) *MockInterviewRepository {
+	return &MockInterviewRepository{db: db}
+}
+
+const mockInterviewSelect = `
+	SELECT mi.id, mi.user_id, mi.item_id, i.title, i.category, mi.test_session_id, mi.status,
+		(SELECT COUNT(*) FROM mock_interview_messages m WHERE m.interview_id = mi.id AND m.role = 'candidate'),
+		mi.feedback, mi.provider, mi.model, mi.created_at, mi.updated_at, mi.ended_at
+	FROM mock_interviews mi
+	INNER JOIN items i ON i.id = mi.item_id`
+
+// scanMockInterview scans a row selected with mockInterviewSelect
+func scanMockInterview(scanner interface{ Scan(...interface{}) error }) (*models.MockInterview, error) {
+	var interview models.MockInterview
+	err := scanner.Scan(
+		&interview.ID, &interview.UserID, &interview.ItemID, &interview.ItemTitle, &interview.Category,
+		&interview.TestSessionID, &interview.Status, &interview.Turns, &interview.Feedback,
+		&interview.Provider, &interview.Model, &interview.CreatedAt, &interview.UpdatedAt, &interview.EndedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &interview, nil
+}
+
+// Create saves a new mock interview with the interviewer's opening message, filling in their IDs
+// and times
+func (r *MockInterviewRepository) Create(interview *models.MockInterview, opening *models.MockInterviewMessage, tokens int64) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return apperr.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO mock_interviews (user_id, item_id, test_session_id, status, provider, model, tokens)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at, updated_at`
+
+	err = tx.QueryRow(query,
+		interview.UserID, interview.ItemID, interview.TestSessionID, interview.Status,
+		interview.Provider, interview.Model, tokens,
+	).Scan(&interview.ID, &interview.CreatedAt, &interview.UpdatedAt)
+	if err != nil {
+		return apperr.Errorf("failed to create mock interview: %w", err)
+	}
+
+	if err := insertMockInterviewMessages(tx, interview.ID, opening); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return apperr.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// AddTurn appends the candidate's reply and the interviewer's answer to an active mock
+// interview, adding the tokens the turn used
+func (r *MockInterviewRepository) AddTurn(userID, interviewID int, reply, answer *models.MockInterviewMessage, tokens int64) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return apperr.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE mock_interviews
+		SET tokens = tokens + $1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $2 AND user_id = $3 AND status = 'active'`
+
+	res, err := tx.Exec(query, tokens, interviewID, userID)
+	if err != nil {
+		return apperr.Errorf("failed to update mock interview: %w", err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return apperr.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperr.Conflict("mock interview has ended")
+	}
+
+	if err := insertMockInterviewMessages(tx, interviewID, reply, answer); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return apperr.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// insertMockInterviewMessages appends messages to a transcript in order, filling in their IDs and
+// times
+func insertMockInterviewMessages(tx *sql.Tx, interviewID int, messages ...*models.MockInterviewMessage) error {
+	query := `
+		INSERT INTO mock_interview_messages (interview_id, role, content)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at`
+
+	for _, message := range messages {
+		if err := tx.QueryRow(query, interviewID, message.Role, message.Content).Scan(&message.ID, &message.CreatedAt); err != nil {
+			return apperr.Errorf("failed to create mock interview message: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// End ends an active mock interview with the interviewer's feedback, adding the tokens writing it
+// used
+func (r *MockInterviewRepository) End(userID, interviewID int, feedback string, tokens int64) error {
+	query := `
+		UPDATE mock_interviews
+		SET status = 'ended', feedback = $1, tokens = tokens + $2, ended_at = CURRENT_TIMESTAMP,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $3 AND user_id = $4 AND status = 'active'`
+
+	res, err := r.db.Exec(query, feedback, tokens, interviewID, userID)
+	if err != nil {
+		return apperr.Errorf("failed to end mock interview: %w", err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return apperr.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperr.Conflict("mock interview has ended")
+	}
+
+	return nil
+}
+
+// GetByID retrieves one of the user's mock interviews with its transcript
+func (r *MockInterviewRepository) GetByID(userID, interviewID int) (*models.MockInterview, error) {
+	query := mockInterviewSelect + ` WHERE mi.id = $1 AND mi.user_id = $2`
+
+	interview, err := scanMockInterview(r.db.QueryRow(query, interviewID, userID))
+	if err == sql.ErrNoRows {
+		return nil, apperr.NotFound("mock interview not found")
+	}
+	if err != nil {
+		return nil, apperr.Errorf("failed to get mock interview: %w", err)
+	}
+
+	rows, err := r.db.Query(`
+		SELECT id, role, content, created_at
+		FROM mock_interview_messages
+		WHERE interview_id = $1
+		ORDER BY id`, interviewID)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get mock interview messages: %w", err)
+	}
+	defer rows.Close()
+
+	interview.Messages = []models.MockInterviewMessage{}
+	for rows.Next() {
+		var message models.MockInterviewMessage
+		if err := rows.Scan(&message.ID, &message.Role, &message.Content, &message.CreatedAt); err != nil {
+			return nil, apperr.Errorf("failed to scan mock interview message: %w", err)
+		}
+		interview.Messages = append(interview.Messages, message)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating mock interview messages: %w", err)
+	}
+
+	return interview, nil
+}
+
+// GetForUser lists the user's latest mock interviews without their transcripts, newest first,
+// optionally on one item or from one test
+func (r *MockInterviewRepository) GetForUser(userID int, itemID *int, testSessionID string, limit int) ([]*models.MockInterview, error) {
+	query := mockInterviewSelect + `
+		WHERE mi.user_id = $1
+		  AND ($2::INTEGER IS NULL OR mi.item_id = $2)
+		  AND ($3 = '' OR mi.test_session_id = $3)
+		ORDER BY mi.created_at DESC, mi.id DESC
+		LIMIT $4`
+
+	rows, err := r.db.Query(query, userID, itemID, testSessionID, limit)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get mock interviews: %w", err)
+	}
+	defer rows.Close()
+
+	interviews := []*models.MockInterview{}
+	for rows.Next() {
+		interview, err := scanMockInterview(rows)
+		if err != nil {
+			return nil, apperr.Errorf("failed to scan mock interview: %w", err)
+		}
+		interviews = append(interviews, interview)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating mock interviews: %w", err)
+	}
+
+	return interviews, nil
+}
+
+// Delete removes one of the user's mock interviews with its transcript
+func (r *MockInterviewRepository) Delete(userID, interviewID int) error {
+	result, err := r.db.Exec("DELETE FROM mock_interviews WHERE id = $1 AND user_id = $2", interviewID, userID)
+	if err != nil {
+		return apperr.Errorf("failed to delete mock interview: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperr.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperr.NotFound("mock interview not found")
+	}
+
+	return nil
+}
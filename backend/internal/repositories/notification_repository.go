@@ -0,0 +1,189 @@
+package repositories
+
+import (
+	"database/sql"
+	"time"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+)
+
+// NotificationRepository handles database operations for notification preferences and reminders
+type NotificationRepository struct {
+	db *sql.DB
+}
+
+// NewNotificationRepository creates a new NotificationRepository
+func NewNotificationRepository(db *sql.DB) *NotificationRepository {
+	return &NotificationRepository{db: db}
+}
+
+// GetPreferences returns the user's notification preferences, falling back to the defaults
+func (r *NotificationRepository) GetPreferences(userID int) (*models.NotificationPreferences, error) {
+	query := `
+		SELECT user_id, email_enabled, push_enabled, daily_reminder, reminder_time, timezone, locale,
+			   stuck_items, reviews_due, streak_alerts, updated_at
+		FROM user_notification_preferences
+		WHERE user_id = $1`
+
+	prefs := &models.NotificationPreferences{}
+	err := r.db.QueryRow(query, userID).Scan(
+		&prefs.UserID, &prefs.EmailEnabled, &prefs.PushEnabled, &prefs.DailyReminder, &prefs.ReminderTime,
+		&prefs.Timezone, &prefs.Locale, &prefs.StuckItems, &prefs.ReviewsDue, &prefs.StreakAlerts, &prefs.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return models.DefaultNotificationPreferences(userID), nil
+	}
+	if err != nil {
+		return nil, apperr.Errorf("failed to get notification preferences: %w", err)
+	}
+
+	return prefs, nil
+}
+
+// SavePreferences creates or replaces the user's notification preferences
+func (r *NotificationRepository) SavePreferences(prefs *models.NotificationPreferences) error {
+	query := `
+		INSERT INTO user_notification_preferences (user_id, email_enabled, push_enabled, daily_reminder, reminder_time,
+			timezone, locale, stuck_items, reviews_due, streak_alerts, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, CURRENT_TIMESTAMP)
+		ON CONFLICT (user_id)
+		DO UPDATE SET
+			email_enabled = EXCLUDED.email_enabled,
+			push_enabled = EXCLUDED.push_enabled,
+			daily_reminder = EXCLUDED.daily_reminder,
+			reminder_time = EXCLUDED.reminder_time,
+			timezone = EXCLUDED.timezone,
+			locale = EXCLUDED.locale,
+			stuck_items = EXCLUDED.stuck_items,
+			reviews_due = EXCLUDED.reviews_due,
+			streak_alerts = EXCLUDED.streak_alerts,
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING updated_at`
+
+	err := r.db.QueryRow(
+		query,
+		prefs.UserID,
+		prefs.EmailEnabled,
+		prefs.PushEnabled,
+		prefs.DailyReminder,
+		prefs.ReminderTime,
+		prefs.Timezone,
+		prefs.Locale,
+		prefs.StuckItems,
+		prefs.ReviewsDue,
+		prefs.StreakAlerts,
+	).Scan(&prefs.UpdatedAt)
+	if err != nil {
+		return apperr.Errorf("failed to save notification preferences: %w", err)
+	}
+
+	return nil
+}
+
+// GetReminderRecipients returns active users with at least one reminder channel enabled,
+// applying defaults for users who never saved preferences
+func (r *NotificationRepository) GetReminderRecipients() ([]*models.ReminderRecipient, error) {
+	query := `
+		SELECT u.id, u.email, u.name,
+			   COALESCE(p.email_enabled, true), COALESCE(p.push_enabled, true),
+			   COALESCE(p.daily_reminder, true), COALESCE(p.reminder_time, $1),
+			   COALESCE(p.timezone, 'UTC'), COALESCE(p.locale, ''), COALESCE(p.stuck_items, true), COALESCE(p.reviews_due, true),
+			   COALESCE(p.streak_alerts, true)
+		FROM users u
+		LEFT JOIN user_notification_preferences p ON p.user_id = u.id
+		WHERE u.is_active = true AND (COALESCE(p.email_enabled, true) = true OR COALESCE(p.push_enabled, true) = true)
+		ORDER BY u.id`
+
+	rows, err := r.db.Query(query, models.DefaultReminderTime)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get reminder recipients: %w", err)
+	}
+	defer rows.Close()
+
+	var recipients []*models.ReminderRecipient
+	for rows.Next() {
+		recipient := &models.ReminderRecipient{}
+		prefs := &models.NotificationPreferences{}
+		err := rows.Scan(
+			&recipient.UserID, &recipient.Email, &recipient.Name,
+			&prefs.EmailEnabled, &prefs.PushEnabled, &prefs.DailyReminder, &prefs.ReminderTime,
+			&prefs.Timezone, &prefs.Locale, &prefs.StuckItems, &prefs.ReviewsDue, &prefs.StreakAlerts,
+		)
+		if err != nil {
+			return nil, apperr.Errorf("failed to scan reminder recipient: %w", err)
+		}
+		prefs.UserID = recipient.UserID
+		recipient.Preferences = prefs
+		recipients = append(recipients, recipient)
+	}
+
+	return recipients, rows.Err()
+}
+
+// CountCompletedSince counts the items the user has completed since the given time
+func (r *NotificationRepository) CountCompletedSince(userID int, since time.Time) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM user_progress
+		WHERE user_id = $1 AND status = 'done' AND completed_at >= $2`
+
+	var count int
+	if err := r.db.QueryRow(query, userID, since).Scan(&count); err != nil {
+		return 0, apperr.Errorf("failed to count completed items: %w", err)
+	}
+
+	return count, nil
+}
+
+// CountStuckInProgress counts the user's in-progress items that were started before the given time
+func (r *NotificationRepository) CountStuckInProgress(userID int, startedBefore time.Time) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM user_progress
+		WHERE user_id = $1 AND status = 'in-progress' AND started_at < $2`
+
+	var count int
+	if err := r.db.QueryRow(query, userID, startedBefore).Scan(&count); err != nil {
+		return 0, apperr.Errorf("failed to count stuck items: %w", err)
+	}
+
+	return count, nil
+}
+
+// CountReviewsDue counts the user's completed items whose review date has passed
+func (r *NotificationRepository) CountReviewsDue(userID int, dueBy time.Time) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM user_progress
+		WHERE user_id = $1 AND status = 'done' AND next_review_at <= $2`
+
+	var count int
+	if err := r.db.QueryRow(query, userID, dueBy).Scan(&count); err != nil {
+		return 0, apperr.Errorf("failed to count due reviews: %w", err)
+	}
+
+	return count, nil
+}
+
+// MarkReminderSent records that a reminder of the given kind went out on the given day.
+// It returns false when one was already recorded, so each reminder is sent at most once per day
+// even with several server instances running the scheduler.
+func (r *NotificationRepository) MarkReminderSent(userID int, kind models.ReminderKind, day time.Time) (bool, error) {
+	query := `
+		INSERT INTO sent_reminders (user_id, kind, sent_on)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, kind, sent_on) DO NOTHING`
+
+	result, err := r.db.Exec(query, userID, kind, day)
+	if err != nil {
+		return false, apperr.Errorf("failed to record sent reminder: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, apperr.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
@@ -0,0 +1,745 @@
+package repositories
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/lib/pq"
+)
+
+// OrgRepository handles database operations for organizations, members and invitations
+type OrgRepository struct {
+	db *sql.DB
+}
+
+// NewOrgRepository creates a new organization repository
+func NewOrgRepository(db *sql.DB) *OrgRepository {
+	return &OrgRepository{db: db}
+}
+
+// Create creates an organization and makes its creator the owner
+func (r *OrgRepository) Create(org *models.Organization) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return apperr.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRow(
+		"INSERT INTO organizations (name, created_by) VALUES ($1, $2) RETURNING id, created_at",
+		org.Name, org.CreatedBy,
+	).Scan(&org.ID, &org.CreatedAt)
+	if err != nil {
+		return apperr.Errorf("failed to create organization: %w", err)
+	}
+
+	_, err = tx.Exec(
+		"INSERT INTO organization_members (org_id, user_id, role) VALUES ($1, $2, $3)",
+		org.ID, org.CreatedBy, models.OrgRoleOwner,
+	)
+	if err != nil {
+		return apperr.Errorf("failed to add organization owner: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return apperr.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves an organization by its ID
+func (r *OrgRepository) GetByID(id int) (*models.Organization, error) {
+	var org models.Organization
+	var createdBy sql.NullInt64
+	err := r.db.QueryRow(
+		"SELECT id, name, created_by, created_at FROM organizations WHERE id = $1", id,
+	).Scan(&org.ID, &org.Name, &createdBy, &org.CreatedAt)
+
+	if err == sql.ErrNoRows {
+		return nil, apperr.NotFound("organization not found")
+	}
+	if err != nil {
+		return nil, apperr.Errorf("failed to get organization: %w", err)
+	}
+
+	org.CreatedBy = int(createdBy.Int64)
+	return &org, nil
+}
+
+// IsMemberByEmail checks whether a user with the given email already belongs to the organization
+func (r *OrgRepository) IsMemberByEmail(orgID int, email string) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM organization_members m
+			INNER JOIN users u ON u.id = m.user_id
+			WHERE m.org_id = $1 AND LOWER(u.email) = LOWER($2)
+		)`
+
+	var exists bool
+	if err := r.db.QueryRow(query, orgID, email).Scan(&exists); err != nil {
+		return false, apperr.Errorf("failed to check organization membership: %w", err)
+	}
+
+	return exists, nil
+}
+
+// PendingInvitationExists checks whether an email already has a pending invitation to the organization
+func (r *OrgRepository) PendingInvitationExists(orgID int, email string) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM organization_invitations
+			WHERE org_id = $1 AND LOWER(email) = LOWER($2) AND status = 'pending'
+		)`
+
+	var exists bool
+	if err := r.db.QueryRow(query, orgID, email).Scan(&exists); err != nil {
+		return false, apperr.Errorf("failed to check pending invitation: %w", err)
+	}
+
+	return exists, nil
+}
+
+// CountSeats counts an organization's members plus its pending invitations
+func (r *OrgRepository) CountSeats(orgID int) (int, error) {
+	query := `
+		SELECT
+			(SELECT COUNT(*) FROM organization_members WHERE org_id = $1) +
+			(SELECT COUNT(*) FROM organization_invitations WHERE org_id = $1 AND status = 'pending' AND expires_at > CURRENT_TIMESTAMP)`
+
+	var count int
+	if err := r.db.QueryRow(query, orgID).Scan(&count); err != nil {
+		return 0, apperr.Errorf("failed to count seats: %w", err)
+	}
+
+	return count, nil
+}
+
+// CreateInvitation records a new invitation
+func (r *OrgRepository) CreateInvitation(inv *models.OrgInvitation) error {
+	query := `
+		INSERT INTO organization_invitations (org_id, email, role, token_hash, status, invited_by, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at`
+
+	err := r.db.QueryRow(
+		query, inv.OrgID, inv.Email, inv.Role, inv.TokenHash, inv.Status, inv.InvitedBy, inv.ExpiresAt,
+	).Scan(&inv.ID, &inv.CreatedAt)
+	if err != nil {
+		return apperr.Errorf("failed to create invitation: %w", err)
+	}
+
+	return nil
+}
+
+// GetInvitations retrieves every invitation for an organization, newest first
+func (r *OrgRepository) GetInvitations(orgID int) ([]*models.OrgInvitation, error) {
+	query := `
+		SELECT id, org_id, email, role, token_hash, status, COALESCE(invited_by, 0), accepted_user_id,
+			expires_at, accepted_at, created_at
+		FROM organization_invitations
+		WHERE org_id = $1
+		ORDER BY created_at DESC`
+
+	rows, err := r.db.Query(query, orgID)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get invitations: %w", err)
+	}
+	defer rows.Close()
+
+	return scanInvitations(rows)
+}
+
+// GetPendingInvitationByTokenHash retrieves an unexpired pending invitation by its token hash
+func (r *OrgRepository) GetPendingInvitationByTokenHash(tokenHash string) (*models.OrgInvitation, error) {
+	query := `
+		SELECT id, org_id, email, role, token_hash, status, COALESCE(invited_by, 0), accepted_user_id,
+			expires_at, accepted_at, created_at
+		FROM organization_invitations
+		WHERE token_hash = $1 AND status = 'pending' AND expires_at > $2`
+
+	rows, err := r.db.Query(query, tokenHash, time.Now())
+	if err != nil {
+		return nil, apperr.Errorf("failed to get invitation: %w", err)
+	}
+	defer rows.Close()
+
+	invitations, err := scanInvitations(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(invitations) == 0 {
+		return nil, apperr.NotFound("invitation not found")
+	}
+
+	return invitations[0], nil
+}
+
+// AcceptInvitation marks an invitation accepted and adds the user to the organization
+func (r *OrgRepository) AcceptInvitation(inv *models.OrgInvitation, userID int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return apperr.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`
+		UPDATE organization_invitations
+		SET status = 'accepted', accepted_user_id = $1, accepted_at = $2
+		WHERE id = $3 AND status = 'pending'`,
+		userID, time.Now(), inv.ID)
+	if err != nil {
+		return apperr.Errorf("failed to accept invitation: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperr.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return apperr.NotFound("invitation not found")
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO organization_members (org_id, user_id, role)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (org_id, user_id) DO NOTHING`,
+		inv.OrgID, userID, inv.Role)
+	if err != nil {
+		return apperr.Errorf("failed to add organization member: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return apperr.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// scanInvitations scans invitation rows
+func scanInvitations(rows *sql.Rows) ([]*models.OrgInvitation, error) {
+	invitations := []*models.OrgInvitation{}
+	for rows.Next() {
+		var inv models.OrgInvitation
+		err := rows.Scan(
+			&inv.ID, &inv.OrgID, &inv.Email, &inv.Role, &inv.TokenHash, &inv.Status, &inv.InvitedBy,
+			&inv.AcceptedUserID, &inv.ExpiresAt, &inv.AcceptedAt, &inv.CreatedAt,
+		)
+		if err != nil {
+			return nil, apperr.Errorf("failed to scan invitation: %w", err)
+		}
+		invitations = append(invitations, &inv)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating invitations: %w", err)
+	}
+
+	return invitations, nil
+}
+
+// GetMemberRole returns a user's role in an organization
+func (r *OrgRepository) GetMemberRole(orgID, userID int) (models.OrgRole, error) {
+	var role models.OrgRole
+	err := r.db.QueryRow(
+		"SELECT role FROM organization_members WHERE org_id = $1 AND user_id = $2", orgID, userID,
+	).Scan(&role)
+
+	if err == sql.ErrNoRows {
+		return "", apperr.NotFound("member not found")
+	}
+	if err != nil {
+		return "", apperr.Errorf("failed to get organization role: %w", err)
+	}
+
+	return role, nil
+}
+
+// GetMemberProgress retrieves each member's progress in an organization
+// (excluding miscellaneous category)
+func (r *OrgRepository) GetMemberProgress(orgID int) ([]models.OrgMemberProgress, error) {
+	query := `
+		SELECT u.id, u.name, u.email, m.role,
+			COUNT(CASE WHEN up.status = 'done' AND i.id IS NOT NULL THEN 1 END) as completed,
+			COUNT(CASE WHEN up.status = 'in-progress' AND i.id IS NOT NULL THEN 1 END) as in_progress,
+			COALESCE(us.current_streak, 0), us.last_activity_date, m.joined_at
+		FROM organization_members m
+		INNER JOIN users u ON u.id = m.user_id
+		LEFT JOIN user_progress up ON up.user_id = u.id
+		LEFT JOIN items i ON i.id = up.item_id AND i.category != $2
+		LEFT JOIN user_stats us ON us.user_id = u.id
+		WHERE m.org_id = $1
+		GROUP BY u.id, u.name, u.email, m.role, us.current_streak, us.last_activity_date, m.joined_at
+		ORDER BY completed DESC, u.name ASC`
+
+	rows, err := r.db.Query(query, orgID, models.CategoryMiscellaneous)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get member progress: %w", err)
+	}
+	defer rows.Close()
+
+	members := []models.OrgMemberProgress{}
+	for rows.Next() {
+		var m models.OrgMemberProgress
+		err := rows.Scan(
+			&m.UserID, &m.Name, &m.Email, &m.Role, &m.CompletedItems, &m.InProgressItems,
+			&m.CurrentStreak, &m.LastActivityDate, &m.JoinedAt,
+		)
+		if err != nil {
+			return nil, apperr.Errorf("failed to scan member progress: %w", err)
+		}
+		members = append(members, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating member progress: %w", err)
+	}
+
+	return members, nil
+}
+
+// GetMemberships retrieves the organizations a user belongs to, with their role in each
+func (r *OrgRepository) GetMemberships(userID int) ([]*models.OrgMembership, error) {
+	query := `
+		SELECT o.id, o.name, COALESCE(o.created_by, 0), o.created_at, m.role,
+			(SELECT COUNT(*) FROM organization_members c WHERE c.org_id = o.id)
+		FROM organization_members m
+		INNER JOIN organizations o ON o.id = m.org_id
+		WHERE m.user_id = $1
+		ORDER BY o.name ASC`
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get organizations: %w", err)
+	}
+	defer rows.Close()
+
+	memberships := []*models.OrgMembership{}
+	for rows.Next() {
+		var m models.OrgMembership
+		if err := rows.Scan(&m.ID, &m.Name, &m.CreatedBy, &m.CreatedAt, &m.Role, &m.MemberCount); err != nil {
+			return nil, apperr.Errorf("failed to scan organization: %w", err)
+		}
+		memberships = append(memberships, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating organizations: %w", err)
+	}
+
+	return memberships, nil
+}
+
+// CountMembers returns how many members an organization has
+func (r *OrgRepository) CountMembers(orgID int) (int, error) {
+	var count int
+	err := r.db.QueryRow("SELECT COUNT(*) FROM organization_members WHERE org_id = $1", orgID).Scan(&count)
+	if err != nil {
+		return 0, apperr.Errorf("failed to count members: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetMembers retrieves an organization's members, owners and admins first
+func (r *OrgRepository) GetMembers(orgID int) ([]*models.OrgMember, error) {
+	query := `
+		SELECT m.org_id, m.user_id, u.name, u.email, m.role, m.joined_at
+		FROM organization_members m
+		INNER JOIN users u ON u.id = m.user_id
+		WHERE m.org_id = $1
+		ORDER BY CASE m.role WHEN 'owner' THEN 0 WHEN 'admin' THEN 1 ELSE 2 END, u.name ASC`
+
+	rows, err := r.db.Query(query, orgID)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get members: %w", err)
+	}
+	defer rows.Close()
+
+	members := []*models.OrgMember{}
+	for rows.Next() {
+		var m models.OrgMember
+		if err := rows.Scan(&m.OrgID, &m.UserID, &m.Name, &m.Email, &m.Role, &m.JoinedAt); err != nil {
+			return nil, apperr.Errorf("failed to scan member: %w", err)
+		}
+		members = append(members, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating members: %w", err)
+	}
+
+	return members, nil
+}
+
+// CountOwners returns how many owners an organization has
+func (r *OrgRepository) CountOwners(orgID int) (int, error) {
+	var count int
+	err := r.db.QueryRow(
+		"SELECT COUNT(*) FROM organization_members WHERE org_id = $1 AND role = $2", orgID, models.OrgRoleOwner,
+	).Scan(&count)
+	if err != nil {
+		return 0, apperr.Errorf("failed to count owners: %w", err)
+	}
+
+	return count, nil
+}
+
+// UpdateMemberRole changes a member's role in an organization
+func (r *OrgRepository) UpdateMemberRole(orgID, userID int, role models.OrgRole) error {
+	result, err := r.db.Exec(
+		"UPDATE organization_members SET role = $3 WHERE org_id = $1 AND user_id = $2", orgID, userID, role,
+	)
+	if err != nil {
+		return apperr.Errorf("failed to update member role: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperr.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return apperr.NotFound("member not found")
+	}
+
+	return nil
+}
+
+// RemoveMember removes a user from an organization
+func (r *OrgRepository) RemoveMember(orgID, userID int) error {
+	result, err := r.db.Exec("DELETE FROM organization_members WHERE org_id = $1 AND user_id = $2", orgID, userID)
+	if err != nil {
+		return apperr.Errorf("failed to remove member: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperr.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return apperr.NotFound("member not found")
+	}
+
+	return nil
+}
+
+// GetCatalog retrieves an organization's curated items in order, with a user's progress on each
+func (r *OrgRepository) GetCatalog(orgID, userID int) ([]*models.OrgCatalogItem, error) {
+	query := `
+		SELECT i.id, i.title, i.link, i.category, i.subcategory, c.position, c.note,
+			COALESCE(up.status, 'pending'), c.created_at
+		FROM org_catalog_items c
+		INNER JOIN items i ON i.id = c.item_id
+		LEFT JOIN user_progress up ON up.item_id = i.id AND up.user_id = $2
+		WHERE c.org_id = $1
+		ORDER BY c.position ASC, c.created_at ASC`
+
+	rows, err := r.db.Query(query, orgID, userID)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get organization catalog: %w", err)
+	}
+	defer rows.Close()
+
+	items := []*models.OrgCatalogItem{}
+	for rows.Next() {
+		var item models.OrgCatalogItem
+		err := rows.Scan(
+			&item.ItemID, &item.Title, &item.Link, &item.Category, &item.Subcategory,
+			&item.Position, &item.Note, &item.Status, &item.AddedAt,
+		)
+		if err != nil {
+			return nil, apperr.Errorf("failed to scan catalog item: %w", err)
+		}
+		items = append(items, &item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating catalog items: %w", err)
+	}
+
+	return items, nil
+}
+
+// SaveCatalogItem adds an item to an organization's catalog or updates its position and note.
+// A new item without a position goes last; an existing one keeps its place.
+func (r *OrgRepository) SaveCatalogItem(orgID, itemID int, position *int, note string, addedBy int) error {
+	query := `
+		INSERT INTO org_catalog_items (org_id, item_id, position, note, added_by)
+		SELECT $1, i.id,
+			COALESCE($3::INTEGER, (SELECT COALESCE(MAX(position) + 1, 0) FROM org_catalog_items WHERE org_id = $1)),
+			$4, $5
+		FROM items i
+		WHERE i.id = $2
+		ON CONFLICT (org_id, item_id) DO UPDATE
+		SET position = COALESCE($3::INTEGER, org_catalog_items.position), note = EXCLUDED.note`
+
+	result, err := r.db.Exec(query, orgID, itemID, position, note, addedBy)
+	if err != nil {
+		return apperr.Errorf("failed to save catalog item: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperr.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return apperr.NotFound("item not found")
+	}
+
+	return nil
+}
+
+// RemoveCatalogItem removes an item from an organization's catalog
+func (r *OrgRepository) RemoveCatalogItem(orgID, itemID int) error {
+	result, err := r.db.Exec("DELETE FROM org_catalog_items WHERE org_id = $1 AND item_id = $2", orgID, itemID)
+	if err != nil {
+		return apperr.Errorf("failed to remove catalog item: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperr.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return apperr.NotFound("item not in the organization's catalog")
+	}
+
+	return nil
+}
+
+// CreateServiceAccount records a new service account
+func (r *OrgRepository) CreateServiceAccount(account *models.ServiceAccount) error {
+	query := `
+		INSERT INTO org_service_accounts (org_id, name, key_prefix, key_hash, scopes, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at`
+
+	err := r.db.QueryRow(
+		query, account.OrgID, account.Name, account.KeyPrefix, account.KeyHash,
+		pq.Array(account.Scopes), account.CreatedBy,
+	).Scan(&account.ID, &account.CreatedAt)
+	if err != nil {
+		return apperr.Errorf("failed to create service account: %w", err)
+	}
+
+	return nil
+}
+
+// GetServiceAccounts lists an organization's service accounts, including revoked ones
+func (r *OrgRepository) GetServiceAccounts(orgID int) ([]*models.ServiceAccount, error) {
+	query := `
+		SELECT id, org_id, name, key_prefix, key_hash, scopes, COALESCE(created_by, 0), created_at, last_used_at, revoked_at
+		FROM org_service_accounts
+		WHERE org_id = $1
+		ORDER BY created_at DESC`
+
+	rows, err := r.db.Query(query, orgID)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get service accounts: %w", err)
+	}
+	defer rows.Close()
+
+	accounts := []*models.ServiceAccount{}
+	for rows.Next() {
+		account, err := scanServiceAccount(rows)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, account)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating service accounts: %w", err)
+	}
+
+	return accounts, nil
+}
+
+// GetActiveServiceAccountByKeyHash retrieves an unrevoked service account by its key hash
+func (r *OrgRepository) GetActiveServiceAccountByKeyHash(keyHash string) (*models.ServiceAccount, error) {
+	query := `
+		SELECT id, org_id, name, key_prefix, key_hash, scopes, COALESCE(created_by, 0), created_at, last_used_at, revoked_at
+		FROM org_service_accounts
+		WHERE key_hash = $1 AND revoked_at IS NULL`
+
+	account, err := scanServiceAccount(r.db.QueryRow(query, keyHash))
+	if err == sql.ErrNoRows {
+		return nil, apperr.NotFound("service account not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// TouchServiceAccount records that a service account was just used
+func (r *OrgRepository) TouchServiceAccount(id int) error {
+	_, err := r.db.Exec("UPDATE org_service_accounts SET last_used_at = $1 WHERE id = $2", time.Now(), id)
+	if err != nil {
+		return apperr.Errorf("failed to update service account usage: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeServiceAccount revokes a service account's key
+func (r *OrgRepository) RevokeServiceAccount(orgID, id int) error {
+	result, err := r.db.Exec(
+		"UPDATE org_service_accounts SET revoked_at = $1 WHERE id = $2 AND org_id = $3 AND revoked_at IS NULL",
+		time.Now(), id, orgID,
+	)
+	if err != nil {
+		return apperr.Errorf("failed to revoke service account: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperr.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperr.NotFound("service account not found")
+	}
+
+	return nil
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanServiceAccount scans a single service account row
+func scanServiceAccount(row rowScanner) (*models.ServiceAccount, error) {
+	var account models.ServiceAccount
+	err := row.Scan(
+		&account.ID, &account.OrgID, &account.Name, &account.KeyPrefix, &account.KeyHash,
+		pq.Array(&account.Scopes), &account.CreatedBy, &account.CreatedAt, &account.LastUsedAt, &account.RevokedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, err
+	}
+	if err != nil {
+		return nil, apperr.Errorf("failed to scan service account: %w", err)
+	}
+
+	return &account, nil
+}
+
+// GetAllIDs returns the IDs of every organization
+func (r *OrgRepository) GetAllIDs() ([]int, error) {
+	rows, err := r.db.Query("SELECT id FROM organizations ORDER BY id")
+	if err != nil {
+		return nil, apperr.Errorf("failed to get organizations: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, apperr.Errorf("failed to scan organization ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// CountCatalogItems counts the items members can work through (excluding miscellaneous category)
+func (r *OrgRepository) CountCatalogItems() (int, error) {
+	var count int
+	err := r.db.QueryRow("SELECT COUNT(*) FROM items WHERE category != $1", models.CategoryMiscellaneous).Scan(&count)
+	if err != nil {
+		return 0, apperr.Errorf("failed to count items: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetSubcategoryCompletion returns, per subcategory, how many items it has and how many
+// completions the organization's members have in it (excluding miscellaneous category)
+func (r *OrgRepository) GetSubcategoryCompletion(orgID int) ([]models.CohortSubcategory, error) {
+	query := `
+		SELECT i.category, i.subcategory, COUNT(DISTINCT i.id) as total_items,
+			COUNT(CASE WHEN up.status = 'done' THEN 1 END) as completions
+		FROM items i
+		LEFT JOIN user_progress up ON up.item_id = i.id
+			AND up.user_id IN (SELECT user_id FROM organization_members WHERE org_id = $1)
+		WHERE i.category != $2
+		GROUP BY i.category, i.subcategory
+		ORDER BY i.category, i.subcategory`
+
+	rows, err := r.db.Query(query, orgID, models.CategoryMiscellaneous)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get subcategory completion: %w", err)
+	}
+	defer rows.Close()
+
+	var subcategories []models.CohortSubcategory
+	for rows.Next() {
+		var sub models.CohortSubcategory
+		if err := rows.Scan(&sub.Category, &sub.Subcategory, &sub.TotalItems, &sub.Completions); err != nil {
+			return nil, apperr.Errorf("failed to scan subcategory completion: %w", err)
+		}
+		subcategories = append(subcategories, sub)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating subcategory completion: %w", err)
+	}
+
+	return subcategories, nil
+}
+
+// SaveCohortSnapshot stores the day's aggregated cohort analytics, replacing any earlier run that day
+func (r *OrgRepository) SaveCohortSnapshot(orgID int, day time.Time, snapshot *models.CohortSnapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return apperr.Errorf("failed to encode cohort snapshot: %w", err)
+	}
+
+	query := `
+		INSERT INTO org_cohort_snapshots (org_id, snapshot_date, data)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (org_id, snapshot_date)
+		DO UPDATE SET data = EXCLUDED.data, created_at = CURRENT_TIMESTAMP`
+
+	if _, err := r.db.Exec(query, orgID, day, data); err != nil {
+		return apperr.Errorf("failed to save cohort snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// GetCohortSnapshot returns the most recent snapshot taken on or before the given day
+func (r *OrgRepository) GetCohortSnapshot(orgID int, onOrBefore time.Time) (*models.CohortSnapshot, time.Time, time.Time, error) {
+	query := `
+		SELECT data, snapshot_date, created_at
+		FROM org_cohort_snapshots
+		WHERE org_id = $1 AND snapshot_date <= $2
+		ORDER BY snapshot_date DESC
+		LIMIT 1`
+
+	var data []byte
+	var day, createdAt time.Time
+	err := r.db.QueryRow(query, orgID, onOrBefore).Scan(&data, &day, &createdAt)
+	if err == sql.ErrNoRows {
+		return nil, time.Time{}, time.Time{}, apperr.NotFound("cohort snapshot not found")
+	}
+	if err != nil {
+		return nil, time.Time{}, time.Time{}, apperr.Errorf("failed to get cohort snapshot: %w", err)
+	}
+
+	var snapshot models.CohortSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, time.Time{}, time.Time{}, apperr.Errorf("failed to decode cohort snapshot: %w", err)
+	}
+
+	return &snapshot, day, createdAt, nil
+}
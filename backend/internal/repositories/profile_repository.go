@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"database/sql"
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+)
+
+// ProfileRepository handles database operations for public profiles
+type ProfileRepository struct {
+	db *sql.DB
+}
+
+// NewProfileRepository creates a new ProfileRepository
+func NewProfileRepository(db *sql.DB) *ProfileRepository {
+	return &ProfileRepository{db: db}
+}
+
+// GetSettings returns the user's public profile settings, or nil if they never set it up
+func (r *ProfileRepository) GetSettings(userID int) (*models.PublicProfileSettings, error) {
+	query := `
+		SELECT enabled, handle, show_name, show_streak, show_totals, show_badges, updated_at
+		FROM public_profiles
+		WHERE user_id = $1`
+
+	settings := &models.PublicProfileSettings{}
+	err := r.db.QueryRow(query, userID).Scan(
+		&settings.Enabled, &settings.Handle, &settings.ShowName, &settings.ShowStreak,
+		&settings.ShowTotals, &settings.ShowBadges, &settings.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, apperr.Errorf("failed to get public profile: %w", err)
+	}
+
+	return settings, nil
+}
+
+// SaveSettings creates or replaces the user's public profile settings, handle included
+func (r *ProfileRepository) SaveSettings(userID int, settings *models.PublicProfileSettings) error {
+	query := `
+		INSERT INTO public_profiles (user_id, handle, enabled, show_name, show_streak, show_totals, show_badges, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		ON CONFLICT (user_id)
+		DO UPDATE SET handle = EXCLUDED.handle, enabled = EXCLUDED.enabled, show_name = EXCLUDED.show_name,
+			show_streak = EXCLUDED.show_streak, show_totals = EXCLUDED.show_totals, show_badges = EXCLUDED.show_badges,
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING updated_at`
+
+	err := r.db.QueryRow(query, userID, settings.Handle, settings.Enabled, settings.ShowName,
+		settings.ShowStreak, settings.ShowTotals, settings.ShowBadges).Scan(&settings.UpdatedAt)
+	if err != nil {
+		return apperr.Errorf("failed to save public profile: %w", err)
+	}
+
+	return nil
+}
+
+// GetByHandle resolves an enabled profile's handle to its owner and settings. Disabled
+// profiles and handles that were regenerated away are not found.
+func (r *ProfileRepository) GetByHandle(handle string) (int, *models.PublicProfileSettings, error) {
+	query := `
+		SELECT p.user_id, p.enabled, p.handle, p.show_name, p.show_streak, p.show_totals, p.show_badges, p.updated_at
+		FROM public_profiles p
+		JOIN users u ON u.id = p.user_id
+		WHERE p.handle = $1 AND p.enabled = true AND u.is_active = true`
+
+	var userID int
+	settings := &models.PublicProfileSettings{}
+	err := r.db.QueryRow(query, handle).Scan(
+		&userID, &settings.Enabled, &settings.Handle, &settings.ShowName, &settings.ShowStreak,
+		&settings.ShowTotals, &settings.ShowBadges, &settings.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return 0, nil, apperr.NotFound("profile not found")
+	}
+	if err != nil {
+		return 0, nil, apperr.Errorf("failed to get public profile: %w", err)
+	}
+
+	return userID, settings, nil
+}
@@ -0,0 +1,298 @@
+package repositories
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/lib/pq"
+)
+
+// QuizRepository handles database operations for quizzes, their questions and users' attempts
+type QuizRepository struct {
+	db *sql.DB
+}
+
+// NewQuizRepository creates a new quiz repository
+func NewQuizRepository(db *sql.DB) *QuizRepository {
+	return &QuizRepository{db: db}
+}
+
+// GetQuizzes lists quizzes by category, subcategory and title, each with its question count and
+// the user's latest attempt
+func (r *QuizRepository) GetQuizzes(userID int, filter *models.QuizFilter) ([]*models.Quiz, error) {
+	var category *string
+	if filter.Category != nil {
+		value := string(*filter.Category)
+		category = &value
+	}
+
+	query := `
+		SELECT q.id, q.title, q.description, q.category, q.subcategory, q.created_at, q.updated_at,
+		       (SELECT COUNT(*) FROM quiz_questions qq WHERE qq.quiz_id = q.id),
+		       a.id, a.correct, a.total, a.created_at
+		FROM quizzes q
+		LEFT JOIN quiz_attempts a ON a.id = (
+			SELECT id
+			FROM quiz_attempts
+			WHERE quiz_id = q.id AND user_id = $1
+			ORDER BY created_at DESC, id DESC
+			LIMIT 1
+		)
+		WHERE ($2::TEXT IS NULL OR q.category = $2)
+		  AND ($3::TEXT IS NULL OR q.subcategory = $3)
+		ORDER BY q.category, q.subcategory, q.title, q.id`
+
+	rows, err := r.db.Query(query, userID, category, filter.Subcategory)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get quizzes: %w", err)
+	}
+	defer rows.Close()
+
+	quizzes := []*models.Quiz{}
+	for rows.Next() {
+		var (
+			quiz                         models.Quiz
+			attemptID                    sql.NullInt64
+			attemptCorrect, attemptTotal sql.NullInt64
+			attemptCreated               sql.NullTime
+		)
+		err := rows.Scan(
+			&quiz.ID, &quiz.Title, &quiz.Description, &quiz.Category, &quiz.Subcategory, &quiz.CreatedAt, &quiz.UpdatedAt,
+			&quiz.QuestionCount, &attemptID, &attemptCorrect, &attemptTotal, &attemptCreated,
+		)
+		if err != nil {
+			return nil, apperr.Errorf("failed to scan quiz: %w", err)
+		}
+
+		if attemptID.Valid {
+			quiz.LastAttempt = &models.QuizAttempt{
+				ID:        int(attemptID.Int64),
+				QuizID:    quiz.ID,
+				Correct:   int(attemptCorrect.Int64),
+				Total:     int(attemptTotal.Int64),
+				CreatedAt: attemptCreated.Time,
+			}
+		}
+		quizzes = append(quizzes, &quiz)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating quizzes: %w", err)
+	}
+
+	return quizzes, nil
+}
+
+// GetQuiz retrieves a quiz with its questions in order, including their answers
+func (r *QuizRepository) GetQuiz(quizID int) (*models.Quiz, error) {
+	query := `
+		SELECT id, title, description, category, subcategory, created_at, updated_at
+		FROM quizzes
+		WHERE id = $1`
+
+	var quiz models.Quiz
+	err := r.db.QueryRow(query, quizID).Scan(
+		&quiz.ID, &quiz.Title, &quiz.Description, &quiz.Category, &quiz.Subcategory, &quiz.CreatedAt, &quiz.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, apperr.NotFound("quiz not found")
+	}
+	if err != nil {
+		return nil, apperr.Errorf("failed to get quiz: %w", err)
+	}
+
+	rows, err := r.db.Query(`
+		SELECT id, prompt, choices, correct_choice, explanation
+		FROM quiz_questions
+		WHERE quiz_id = $1
+		ORDER BY position`, quizID)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get quiz questions: %w", err)
+	}
+	defer rows.Close()
+
+	quiz.Questions = []models.QuizQuestion{}
+	for rows.Next() {
+		var (
+			question models.QuizQuestion
+			correct  int
+		)
+		if err := rows.Scan(&question.ID, &question.Prompt, pq.Array(&question.Choices), &correct, &question.Explanation); err != nil {
+			return nil, apperr.Errorf("failed to scan quiz question: %w", err)
+		}
+		question.CorrectChoice = &correct
+		quiz.Questions = append(quiz.Questions, question)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating quiz questions: %w", err)
+	}
+
+	quiz.QuestionCount = len(quiz.Questions)
+	return &quiz, nil
+}
+
+// CreateQuiz adds a quiz along with its questions
+func (r *QuizRepository) CreateQuiz(quiz *models.Quiz) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return apperr.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO quizzes (title, description, category, subcategory)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at, updated_at`
+
+	err = tx.QueryRow(query, quiz.Title, quiz.Description, quiz.Category, quiz.Subcategory).Scan(&quiz.ID, &quiz.CreatedAt, &quiz.UpdatedAt)
+	if err != nil {
+		return apperr.Errorf("failed to create quiz: %w", err)
+	}
+
+	if err := insertQuizQuestions(tx, quiz); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return apperr.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ReplaceQuiz overwrites a quiz's details and swaps its questions for quiz.Questions. Past
+// attempts keep their graded answers.
+func (r *QuizRepository) ReplaceQuiz(quiz *models.Quiz) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return apperr.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE quizzes
+		SET title = $1, description = $2, category = $3, subcategory = $4, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $5
+		RETURNING created_at, updated_at`
+
+	err = tx.QueryRow(query, quiz.Title, quiz.Description, quiz.Category, quiz.Subcategory, quiz.ID).Scan(&quiz.CreatedAt, &quiz.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return apperr.NotFound("quiz not found")
+	}
+	if err != nil {
+		return apperr.Errorf("failed to update quiz: %w", err)
+	}
+
+	if _, err := tx.Exec("DELETE FROM quiz_questions WHERE quiz_id = $1", quiz.ID); err != nil {
+		return apperr.Errorf("failed to delete quiz questions: %w", err)
+	}
+
+	if err := insertQuizQuestions(tx, quiz); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return apperr.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// insertQuizQuestions stores a quiz's questions in order, filling in their IDs
+func insertQuizQuestions(tx *sql.Tx, quiz *models.Quiz) error {
+	query := `
+		INSERT INTO quiz_questions (quiz_id, position, prompt, choices, correct_choice, explanation)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id`
+
+	for i := range quiz.Questions {
+		question := &quiz.Questions[i]
+		err := tx.QueryRow(query, quiz.ID, i, question.Prompt, pq.Array(question.Choices), *question.CorrectChoice, question.Explanation).Scan(&question.ID)
+		if err != nil {
+			return apperr.Errorf("failed to create quiz question: %w", err)
+		}
+	}
+
+	quiz.QuestionCount = len(quiz.Questions)
+	return nil
+}
+
+// DeleteQuiz removes a quiz, its questions and every attempt at it
+func (r *QuizRepository) DeleteQuiz(quizID int) error {
+	result, err := r.db.Exec("DELETE FROM quizzes WHERE id = $1", quizID)
+	if err != nil {
+		return apperr.Errorf("failed to delete quiz: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperr.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperr.NotFound("quiz not found")
+	}
+
+	return nil
+}
+
+// CreateAttempt stores a graded attempt, filling in its ID and time
+func (r *QuizRepository) CreateAttempt(userID int, attempt *models.QuizAttempt) error {
+	results, err := json.Marshal(attempt.Results)
+	if err != nil {
+		return apperr.Errorf("failed to encode quiz results: %w", err)
+	}
+
+	query := `
+		INSERT INTO quiz_attempts (user_id, quiz_id, correct, total, results)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at`
+
+	err = r.db.QueryRow(query, userID, attempt.QuizID, attempt.Correct, attempt.Total, results).Scan(&attempt.ID, &attempt.CreatedAt)
+	if err != nil {
+		return apperr.Errorf("failed to create quiz attempt: %w", err)
+	}
+
+	return nil
+}
+
+// GetAttempts returns the user's most recent attempts at a quiz, newest first
+func (r *QuizRepository) GetAttempts(userID, quizID, limit int) ([]*models.QuizAttempt, error) {
+	query := `
+		SELECT id, quiz_id, correct, total, results, created_at
+		FROM quiz_attempts
+		WHERE user_id = $1 AND quiz_id = $2
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3`
+
+	rows, err := r.db.Query(query, userID, quizID, limit)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get quiz attempts: %w", err)
+	}
+	defer rows.Close()
+
+	attempts := []*models.QuizAttempt{}
+	for rows.Next() {
+		var (
+			attempt models.QuizAttempt
+			results []byte
+		)
+		if err := rows.Scan(&attempt.ID, &attempt.QuizID, &attempt.Correct, &attempt.Total, &results, &attempt.CreatedAt); err != nil {
+			return nil, apperr.Errorf("failed to scan quiz attempt: %w", err)
+		}
+		if err := json.Unmarshal(results, &attempt.Results); err != nil {
+			return nil, apperr.Errorf("failed to decode quiz results: %w", err)
+		}
+		attempts = append(attempts, &attempt)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating quiz attempts: %w", err)
+	}
+
+	return attempts, nil
+}
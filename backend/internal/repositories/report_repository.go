@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"database/sql"
+	"encoding/json"
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+	"time"
+)
+
+// ReportRepository handles database operations for shared progress reports
+type ReportRepository struct {
+	db *sql.DB
+}
+
+// NewReportRepository creates a new ReportRepository
+func NewReportRepository(db *sql.DB) *ReportRepository {
+	return &ReportRepository{db: db}
+}
+
+// GetRecentCompletions returns the items the user completed since the given time, newest first
+// (excluding miscellaneous category)
+func (r *ReportRepository) GetRecentCompletions(userID int, since time.Time, limit int) ([]models.ReportActivity, error) {
+	query := `
+		SELECT i.title, i.category, i.subcategory, up.completed_at
+		FROM user_progress up
+		INNER JOIN items i ON i.id = up.item_id
+		WHERE up.user_id = $1 AND up.status = 'done' AND up.completed_at >= $2 AND i.category != $3
+		ORDER BY up.completed_at DESC
+		LIMIT $4`
+
+	rows, err := r.db.Query(query, userID, since, models.CategoryMiscellaneous, limit)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get recent completions: %w", err)
+	}
+	defer rows.Close()
+
+	activity := []models.ReportActivity{}
+	for rows.Next() {
+		var a models.ReportActivity
+		if err := rows.Scan(&a.Title, &a.Category, &a.Subcategory, &a.CompletedAt); err != nil {
+			return nil, apperr.Errorf("failed to scan recent completion: %w", err)
+		}
+		activity = append(activity, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating recent completions: %w", err)
+	}
+
+	return activity, nil
+}
+
+// Create stores a report snapshot for the user and sets its ID
+func (r *ReportRepository) Create(userID int, report *models.ProgressReport) error {
+	snapshot, err := json.Marshal(report)
+	if err != nil {
+		return apperr.Errorf("failed to encode progress report: %w", err)
+	}
+
+	query := `
+		INSERT INTO progress_reports (user_id, snapshot, created_at, expires_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id`
+
+	if err := r.db.QueryRow(query, userID, snapshot, report.GeneratedAt, report.ExpiresAt).Scan(&report.ID); err != nil {
+		return apperr.Errorf("failed to save progress report: %w", err)
+	}
+
+	return nil
+}
+
+// GetUnexpired returns a report snapshot that hasn't expired by now
+func (r *ReportRepository) GetUnexpired(id int, now time.Time) (*models.ProgressReport, error) {
+	var snapshot []byte
+	err := r.db.QueryRow("SELECT snapshot FROM progress_reports WHERE id = $1 AND expires_at > $2", id, now).Scan(&snapshot)
+	if err == sql.ErrNoRows {
+		return nil, apperr.NotFound("report not found or expired")
+	}
+	if err != nil {
+		return nil, apperr.Errorf("failed to get progress report: %w", err)
+	}
+
+	report := &models.ProgressReport{}
+	if err := json.Unmarshal(snapshot, report); err != nil {
+		return nil, apperr.Errorf("failed to decode progress report: %w", err)
+	}
+	report.ID = id
+
+	return report, nil
+}
+
+// DeleteExpired removes reports that expired before now and returns how many there were
+func (r *ReportRepository) DeleteExpired(now time.Time) (int64, error) {
+	result, err := r.db.Exec("DELETE FROM progress_reports WHERE expires_at <= $1", now)
+	if err != nil {
+		return 0, apperr.Errorf("failed to delete expired progress reports: %w", err)
+	}
+
+	return result.RowsAffected()
+}
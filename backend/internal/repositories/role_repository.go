@@ -0,0 +1,138 @@
+package repositories
+
+import (
+	"database/sql"
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/lib/pq"
+)
+
+// RoleRepository handles database operations for roles and their permissions
+type RoleRepository struct {
+	db *sql.DB
+}
+
+// NewRoleRepository creates a new RoleRepository
+func NewRoleRepository(db *sql.DB) *RoleRepository {
+	return &RoleRepository{db: db}
+}
+
+// GetAll returns every role by name, with its permissions and how many users hold it
+func (r *RoleRepository) GetAll() ([]*models.RoleDefinition, error) {
+	query := `
+		SELECT r.name, r.description, r.created_at,
+			COALESCE((SELECT array_agg(p.permission ORDER BY p.permission) FROM role_permissions p WHERE p.role = r.name), '{}'),
+			(SELECT COUNT(*) FROM users u WHERE u.role = r.name)
+		FROM roles r
+		ORDER BY r.name`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get roles: %w", err)
+	}
+	defer rows.Close()
+
+	roles := []*models.RoleDefinition{}
+	for rows.Next() {
+		var role models.RoleDefinition
+		var permissions []string
+		if err := rows.Scan(&role.Name, &role.Description, &role.CreatedAt, pq.Array(&permissions), &role.UserCount); err != nil {
+			return nil, apperr.Errorf("failed to scan role: %w", err)
+		}
+
+		role.Permissions = make([]models.Permission, len(permissions))
+		for i, permission := range permissions {
+			role.Permissions[i] = models.Permission(permission)
+		}
+		role.BuiltIn = models.IsBuiltInRole(role.Name)
+		roles = append(roles, &role)
+	}
+
+	return roles, rows.Err()
+}
+
+// GetPermissions returns the permissions each role grants
+func (r *RoleRepository) GetPermissions() (map[models.Role][]models.Permission, error) {
+	rows, err := r.db.Query("SELECT role, permission FROM role_permissions")
+	if err != nil {
+		return nil, apperr.Errorf("failed to get role permissions: %w", err)
+	}
+	defer rows.Close()
+
+	permissions := map[models.Role][]models.Permission{}
+	for rows.Next() {
+		var role models.Role
+		var permission models.Permission
+		if err := rows.Scan(&role, &permission); err != nil {
+			return nil, apperr.Errorf("failed to scan role permission: %w", err)
+		}
+		permissions[role] = append(permissions[role], permission)
+	}
+
+	return permissions, rows.Err()
+}
+
+// Exists checks whether a role is defined
+func (r *RoleRepository) Exists(name models.Role) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow("SELECT EXISTS(SELECT 1 FROM roles WHERE name = $1)", name).Scan(&exists)
+	if err != nil {
+		return false, apperr.Errorf("failed to check role: %w", err)
+	}
+
+	return exists, nil
+}
+
+// Save creates a role or replaces its description and permissions
+func (r *RoleRepository) Save(role *models.RoleDefinition) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return apperr.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRow(`
+		INSERT INTO roles (name, description) VALUES ($1, $2)
+		ON CONFLICT (name) DO UPDATE SET description = EXCLUDED.description
+		RETURNING created_at`,
+		role.Name, role.Description,
+	).Scan(&role.CreatedAt)
+	if err != nil {
+		return apperr.Errorf("failed to save role: %w", err)
+	}
+
+	if _, err := tx.Exec("DELETE FROM role_permissions WHERE role = $1", role.Name); err != nil {
+		return apperr.Errorf("failed to clear role permissions: %w", err)
+	}
+
+	for _, permission := range role.Permissions {
+		_, err := tx.Exec(
+			"INSERT INTO role_permissions (role, permission) VALUES ($1, $2) ON CONFLICT DO NOTHING",
+			role.Name, permission,
+		)
+		if err != nil {
+			return apperr.Errorf("failed to grant %s: %w", permission, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return apperr.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a role. Roles still assigned to users can't be removed.
+func (r *RoleRepository) Delete(name models.Role) error {
+	result, err := r.db.Exec("DELETE FROM roles WHERE name = $1", name)
+	if err != nil {
+		return apperr.Errorf("failed to delete role: %w", err)
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return apperr.NotFound("role not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package repositories
+
+import (
+	"database/sql"
+	"time"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+)
+
+// ShareRepository handles database operations for shared items
+type ShareRepository struct {
+	db *sql.DB
+}
+
+// NewShareRepository creates a new share repository
+func NewShareRepository(db *sql.DB) *ShareRepository {
+	return &ShareRepository{db: db}
+}
+
+// Create records a new item share
+func (r *ShareRepository) Create(share *models.ItemShare) error {
+	query := `
+		INSERT INTO item_shares (item_id, sender_id, recipient_user_id, recipient_email, note, status)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at`
+
+	err := r.db.QueryRow(
+		query, share.ItemID, share.SenderID, share.RecipientUserID,
+		share.RecipientEmail, share.Note, share.Status,
+	).Scan(&share.ID, &share.CreatedAt)
+
+	if err != nil {
+		return apperr.Errorf("failed to create share: %w", err)
+	}
+
+	return nil
+}
+
+// PendingShareExists checks whether the sender already has a pending share of the item to the recipient
+func (r *ShareRepository) PendingShareExists(itemID, senderID int, recipientEmail string) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM item_shares
+			WHERE item_id = $1 AND sender_id = $2 AND LOWER(recipient_email) = LOWER($3) AND status = 'pending'
+		)`
+
+	var exists bool
+	err := r.db.QueryRow(query, itemID, senderID, recipientEmail).Scan(&exists)
+	if err != nil {
+		return false, apperr.Errorf("failed to check existing share: %w", err)
+	}
+
+	return exists, nil
+}
+
+// CountSentSince returns how many shares a user has sent since the given time
+func (r *ShareRepository) CountSentSince(senderID int, since time.Time) (int, error) {
+	var count int
+	err := r.db.QueryRow(
+		"SELECT COUNT(*) FROM item_shares WHERE sender_id = $1 AND created_at >= $2", senderID, since,
+	).Scan(&count)
+	if err != nil {
+		return 0, apperr.Errorf("failed to count sent shares: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetInboxForUser retrieves pending shares addressed to a user, including ones sent to their
+// email address before they registered
+func (r *ShareRepository) GetInboxForUser(userID int, email string) ([]*models.ItemShare, error) {
+	query := `
+		SELECT 
+			s.id, s.item_id, s.sender_id, COALESCE(u.name, ''), s.recipient_user_id, s.recipient_email,
+			COALESCE(s.note, ''), s.status, s.created_at, s.responded_at,
+			i.id, i.title, i.link, i.category, i.subcategory, i.attachments, i.created_at
+		FROM item_shares s
+		INNER JOIN items i ON i.id = s.item_id
+		LEFT JOIN users u ON u.id = s.sender_id
+		WHERE s.status = 'pending'
+		AND (s.recipient_user_id = $1 OR (s.recipient_user_id IS NULL AND LOWER(s.recipient_email) = LOWER($2)))
+		ORDER BY s.created_at DESC`
+
+	rows, err := r.db.Query(query, userID, email)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get shared items: %w", err)
+	}
+	defer rows.Close()
+
+	shares := []*models.ItemShare{}
+	for rows.Next() {
+		var share models.ItemShare
+		var item models.Item
+		err := rows.Scan(
+			&share.ID, &share.ItemID, &share.SenderID, &share.SenderName, &share.RecipientUserID,
+			&share.RecipientEmail, &share.Note, &share.Status, &share.CreatedAt, &share.RespondedAt,
+			&item.ID, &item.Title, &item.Link, &item.Category, &item.Subcategory, &item.Attachments, &item.CreatedAt,
+		)
+		if err != nil {
+			return nil, apperr.Errorf("failed to scan shared item: %w", err)
+		}
+		share.Item = &item
+		shares = append(shares, &share)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating shared items: %w", err)
+	}
+
+	return shares, nil
+}
+
+// Respond accepts or dismisses a pending share addressed to the user, claiming email-only shares for them
+func (r *ShareRepository) Respond(shareID, userID int, email string, status models.ShareStatus) error {
+	query := `
+		UPDATE item_shares
+		SET status = $1, recipient_user_id = $2, responded_at = $3
+		WHERE id = $4 AND status = 'pending'
+		AND (recipient_user_id = $2 OR (recipient_user_id IS NULL AND LOWER(recipient_email) = LOWER($5)))`
+
+	result, err := r.db.Exec(query, status, userID, time.Now(), shareID, email)
+	if err != nil {
+		return apperr.Errorf("failed to update share: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperr.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperr.NotFound("share not found")
+	}
+
+	return nil
+}
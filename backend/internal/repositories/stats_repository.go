@@ -2,10 +2,10 @@ package repositories
 
 import (
 	"database/sql"
-	"fmt"
 	"time"
 
 	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
 )
 
 // StatsRepository handles database operations for app statistics
@@ -29,7 +29,7 @@ func (r *StatsRepository) GetAppStats() (*models.AppStats, error) {
 		return r.initializeAppStats()
 	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to get app stats: %w", err)
+		return nil, apperr.Errorf("failed to get app stats: %w", err)
 	}
 
 	return &stats, nil
@@ -41,18 +41,18 @@ func (r *StatsRepository) IncrementCompletedAllCount() error {
 
 	result, err := r.db.Exec(query)
 	if err != nil {
-		return fmt.Errorf("failed to increment completed_all_count: %w", err)
+		return apperr.Errorf("failed to increment completed_all_count: %w", err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
+		return apperr.Errorf("failed to get rows affected: %w", err)
 	}
 
 	if rowsAffected == 0 {
 		// Initialize if not exists and try again
 		if _, err := r.initializeAppStats(); err != nil {
-			return fmt.Errorf("failed to initialize app stats: %w", err)
+			return apperr.Errorf("failed to initialize app stats: %w", err)
 		}
 		return r.IncrementCompletedAllCount()
 	}
@@ -66,7 +66,7 @@ func (r *StatsRepository) ResetCompletedAllCount() error {
 
 	_, err := r.db.Exec(query)
 	if err != nil {
-		return fmt.Errorf("failed to reset completed_all_count: %w", err)
+		return apperr.Errorf("failed to reset completed_all_count: %w", err)
 	}
 
 	return nil
@@ -81,28 +81,33 @@ func (r *StatsRepository) ResetUserCompletedAllCount(userID int) error {
 
 	result, err := r.db.Exec(query, userID)
 	if err != nil {
-		return fmt.Errorf("failed to reset user completed_all_count: %w", err)
+		return apperr.Errorf("failed to reset user completed_all_count: %w", err)
 	}
 
 	// Check if user stats record exists, if not create one
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
+		return apperr.Errorf("failed to get rows affected: %w", err)
 	}
 
 	// If no rows were affected, the user doesn't have stats yet, so create them
 	if rowsAffected == 0 {
 		_, err = r.initializeUserStats(userID)
 		if err != nil {
-			return fmt.Errorf("failed to initialize user stats: %w", err)
+			return apperr.Errorf("failed to initialize user stats: %w", err)
 		}
 	}
 
 	return nil
 }
 
-// IncrementUserCompletedAllCount increments the completed_all_count for a specific user
-func (r *StatsRepository) IncrementUserCompletedAllCount(userID int) error {
+// IncrementUserCompletedAllCount increments the completed_all_count for a specific user within tx
+func (r *StatsRepository) IncrementUserCompletedAllCount(tx *Tx, userID int) error {
+	return incrementUserCompletedAllCount(tx, userID)
+}
+
+// incrementUserCompletedAllCount increments a user's completed_all_count within q
+func incrementUserCompletedAllCount(q dbtx, userID int) error {
 	query := `
 		INSERT INTO user_stats (user_id, completed_all_count, created_at, updated_at)
 		VALUES ($1, 1, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
@@ -111,9 +116,9 @@ func (r *StatsRepository) IncrementUserCompletedAllCount(userID int) error {
 			completed_all_count = user_stats.completed_all_count + 1,
 			updated_at = CURRENT_TIMESTAMP`
 
-	_, err := r.db.Exec(query, userID)
+	_, err := q.Exec(query, userID)
 	if err != nil {
-		return fmt.Errorf("failed to increment user completed_all_count: %w", err)
+		return apperr.Errorf("failed to increment user completed_all_count: %w", err)
 	}
 
 	return nil
@@ -124,7 +129,8 @@ func (r *StatsRepository) GetUserStats(userID int) (*models.UserStats, error) {
 	query := `
 		SELECT user_id, total_items, completed_items, in_progress_items, pending_items,
 			   dsa_completed, lld_completed, hld_completed, completed_all_count,
-			   current_streak, longest_streak, last_activity_date, created_at, updated_at
+			   current_streak, longest_streak, last_activity_date, created_at, updated_at,
+			   solved_items, reviewed_solution_items, stats_refreshed_at
 		FROM user_stats 
 		WHERE user_id = $1`
 
@@ -134,6 +140,7 @@ func (r *StatsRepository) GetUserStats(userID int) (*models.UserStats, error) {
 		&stats.PendingItems, &stats.DSACompleted, &stats.LLDCompleted, &stats.HLDCompleted,
 		&stats.CompletedAllCount, &stats.CurrentStreak, &stats.LongestStreak,
 		&stats.LastActivityDate, &stats.CreatedAt, &stats.UpdatedAt,
+		&stats.SolvedItems, &stats.ReviewedSolutionItems, &stats.StatsRefreshedAt,
 	)
 
 	if err == sql.ErrNoRows {
@@ -141,13 +148,13 @@ func (r *StatsRepository) GetUserStats(userID int) (*models.UserStats, error) {
 		return r.initializeUserStats(userID)
 	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user stats: %w", err)
+		return nil, apperr.Errorf("failed to get user stats: %w", err)
 	}
 
 	// Check and reset streak if there's a gap of 24+ hours
 	err = r.checkAndResetStreakIfNeeded(&stats)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check and reset streak: %w", err)
+		return nil, apperr.Errorf("failed to check and reset streak: %w", err)
 	}
 
 	return &stats, nil
@@ -160,7 +167,8 @@ func (r *StatsRepository) initializeUserStats(userID int) (*models.UserStats, er
 		VALUES ($1, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
 		RETURNING user_id, total_items, completed_items, in_progress_items, pending_items,
 				  dsa_completed, lld_completed, hld_completed, completed_all_count,
-				  current_streak, longest_streak, last_activity_date, created_at, updated_at`
+				  current_streak, longest_streak, last_activity_date, created_at, updated_at,
+				  solved_items, reviewed_solution_items, stats_refreshed_at`
 
 	var stats models.UserStats
 	err := r.db.QueryRow(query, userID).Scan(
@@ -168,10 +176,11 @@ func (r *StatsRepository) initializeUserStats(userID int) (*models.UserStats, er
 		&stats.PendingItems, &stats.DSACompleted, &stats.LLDCompleted, &stats.HLDCompleted,
 		&stats.CompletedAllCount, &stats.CurrentStreak, &stats.LongestStreak,
 		&stats.LastActivityDate, &stats.CreatedAt, &stats.UpdatedAt,
+		&stats.SolvedItems, &stats.ReviewedSolutionItems, &stats.StatsRefreshedAt,
 	)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to initialize user stats: %w", err)
+		return nil, apperr.Errorf("failed to initialize user stats: %w", err)
 	}
 
 	return &stats, nil
@@ -192,7 +201,7 @@ func (r *StatsRepository) initializeAppStats() (*models.AppStats, error) {
 		selectQuery := "SELECT id, completed_all_count FROM app_stats WHERE id = 1"
 		err = r.db.QueryRow(selectQuery).Scan(&stats.ID, &stats.CompletedAllCount)
 		if err != nil {
-			return nil, fmt.Errorf("failed to initialize app stats: %w", err)
+			return nil, apperr.Errorf("failed to initialize app stats: %w", err)
 		}
 	}
 
@@ -201,65 +210,78 @@ func (r *StatsRepository) initializeAppStats() (*models.AppStats, error) {
 
 // UpdateUserStreakOnActivity updates the user's streak when they complete an item
 func (r *StatsRepository) UpdateUserStreakOnActivity(userID int) error {
-	// First check if user already has activity today
-	hasActivityToday, err := r.HasActivityToday(userID)
+	tx, err := r.db.Begin()
 	if err != nil {
-		return fmt.Errorf("failed to check today's activity: %w", err)
+		return apperr.Errorf("failed to begin transaction: %w", err)
 	}
+	defer tx.Rollback()
 
-	// If user already completed something today, don't update streak
-	if hasActivityToday {
-		return nil
+	if _, err := advanceUserStreak(tx, userID, time.Now().UTC().Truncate(24*time.Hour)); err != nil {
+		return err
 	}
 
-	// Get current user stats
-	userStats, err := r.GetUserStats(userID)
-	if err != nil {
-		return fmt.Errorf("failed to get user stats: %w", err)
-	}
+	return tx.Commit()
+}
 
-	today := time.Now().UTC().Truncate(24 * time.Hour)
+// AdvanceStreak records activity on today for the user within tx and returns how their streak moved
+func (r *StatsRepository) AdvanceStreak(tx *Tx, userID int, today time.Time) (*models.StreakChangedData, error) {
+	return advanceUserStreak(tx, userID, today)
+}
 
-	// If this is the first activity ever, start streak at 1
-	if userStats.LastActivityDate == nil {
-		return r.updateUserStreak(userID, 1, 1, today)
+// advanceUserStreak records activity on today within q and returns how the streak moved.
+// The user_stats row is locked so concurrent completions can't both extend the streak.
+func advanceUserStreak(q dbtx, userID int, today time.Time) (*models.StreakChangedData, error) {
+	// Make sure the user_stats row exists before locking it
+	_, err := q.Exec(`
+		INSERT INTO user_stats (user_id, created_at, updated_at)
+		VALUES ($1, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		ON CONFLICT (user_id) DO NOTHING`, userID)
+	if err != nil {
+		return nil, apperr.Errorf("failed to initialize user stats: %w", err)
 	}
 
-	lastActivity := userStats.LastActivityDate.UTC().Truncate(24 * time.Hour)
+	change := &models.StreakChangedData{}
+	var lastActivityDate *time.Time
+	err = q.QueryRow(`
+		SELECT current_streak, longest_streak, last_activity_date
+		FROM user_stats
+		WHERE user_id = $1
+		FOR UPDATE`, userID).Scan(&change.PreviousStreak, &change.LongestStreak, &lastActivityDate)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get user streak info: %w", err)
+	}
+	change.CurrentStreak = change.PreviousStreak
 
-	// If user completed something yesterday, increment streak
-	yesterday := today.Add(-24 * time.Hour)
-	if lastActivity.Equal(yesterday) {
-		newStreak := userStats.CurrentStreak + 1
-		longestStreak := userStats.LongestStreak
-		if newStreak > longestStreak {
-			longestStreak = newStreak
-		}
-		return r.updateUserStreak(userID, newStreak, longestStreak, today)
+	var lastActivity time.Time
+	if lastActivityDate != nil {
+		lastActivity = lastActivityDate.UTC().Truncate(24 * time.Hour)
 	}
 
-	// If user missed days, reset streak to 1 (since they're completing an item today)
-	return r.updateUserStreak(userID, 1, userStats.LongestStreak, today)
-}
+	// If user already completed something today, don't update streak
+	if lastActivityDate != nil && lastActivity.Equal(today) {
+		return change, nil
+	}
 
-// updateUserStreak updates the streak fields in the database
-func (r *StatsRepository) updateUserStreak(userID int, currentStreak int, longestStreak int, lastActivityDate time.Time) error {
-	query := `
-		INSERT INTO user_stats (user_id, current_streak, longest_streak, last_activity_date, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
-		ON CONFLICT (user_id) 
-		DO UPDATE SET 
-			current_streak = EXCLUDED.current_streak,
-			longest_streak = EXCLUDED.longest_streak,
-			last_activity_date = EXCLUDED.last_activity_date,
-			updated_at = CURRENT_TIMESTAMP`
+	// Continue the streak if user completed something yesterday, otherwise start a new one
+	// (this also covers the first activity ever)
+	if lastActivityDate != nil && lastActivity.Equal(today.Add(-24*time.Hour)) {
+		change.CurrentStreak = change.PreviousStreak + 1
+	} else {
+		change.CurrentStreak = 1
+	}
+	if change.CurrentStreak > change.LongestStreak {
+		change.LongestStreak = change.CurrentStreak
+	}
 
-	_, err := r.db.Exec(query, userID, currentStreak, longestStreak, lastActivityDate)
+	_, err = q.Exec(`
+		UPDATE user_stats
+		SET current_streak = $2, longest_streak = $3, last_activity_date = $4, updated_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1`, userID, change.CurrentStreak, change.LongestStreak, today)
 	if err != nil {
-		return fmt.Errorf("failed to update user streak: %w", err)
+		return nil, apperr.Errorf("failed to update user streak: %w", err)
 	}
 
-	return nil
+	return change, nil
 }
 
 // GetUserStreakInfo returns just the streak information for a user
@@ -275,7 +297,7 @@ func (r *StatsRepository) GetUserStreakInfo(userID int) (currentStreak int, long
 		return 0, 0, nil, nil
 	}
 	if err != nil {
-		return 0, 0, nil, fmt.Errorf("failed to get user streak info: %w", err)
+		return 0, 0, nil, apperr.Errorf("failed to get user streak info: %w", err)
 	}
 
 	return currentStreak, longestStreak, lastActivityDate, nil
@@ -297,7 +319,7 @@ func (r *StatsRepository) HasActivityToday(userID int) (bool, error) {
 		return false, nil
 	}
 	if err != nil {
-		return false, fmt.Errorf("failed to check user activity: %w", err)
+		return false, apperr.Errorf("failed to check user activity: %w", err)
 	}
 
 	// If no activity date recorded, no activity today
@@ -329,7 +351,7 @@ func (r *StatsRepository) checkAndResetStreakIfNeeded(stats *models.UserStats) e
 		// Update the streak in the database
 		err := r.resetUserStreak(stats.UserID)
 		if err != nil {
-			return fmt.Errorf("failed to reset user streak: %w", err)
+			return apperr.Errorf("failed to reset user streak: %w", err)
 		}
 
 		// Update the stats object to reflect the reset
@@ -348,8 +370,216 @@ func (r *StatsRepository) resetUserStreak(userID int) error {
 
 	_, err := r.db.Exec(query, userID)
 	if err != nil {
-		return fmt.Errorf("failed to reset user streak: %w", err)
+		return apperr.Errorf("failed to reset user streak: %w", err)
+	}
+
+	return nil
+}
+
+// SetDailyGoal stores the user's daily completion target (0 disables goal tracking)
+func (r *StatsRepository) SetDailyGoal(userID int, goal int) error {
+	query := `
+		INSERT INTO user_stats (user_id, daily_goal, created_at, updated_at)
+		VALUES ($1, $2, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		ON CONFLICT (user_id)
+		DO UPDATE SET daily_goal = EXCLUDED.daily_goal, updated_at = CURRENT_TIMESTAMP`
+
+	_, err := r.db.Exec(query, userID, goal)
+	if err != nil {
+		return apperr.Errorf("failed to set daily goal: %w", err)
 	}
 
 	return nil
 }
+
+// RecordDailyGoalProgress adds completed items to today's tally and, the first time
+// the tally reaches the user's goal, marks the day as met and advances the goal streak.
+// An increment of 0 re-evaluates today's attainment against the current goal.
+func (r *StatsRepository) RecordDailyGoalProgress(userID int, increment int) error {
+	// Make sure the user_stats row exists before locking it
+	if _, err := r.GetUserStats(userID); err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return apperr.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var goal, goalStreak, longestGoalStreak int
+	var lastGoalMetDate *time.Time
+	err = tx.QueryRow(`
+		SELECT daily_goal, goal_streak, longest_goal_streak, last_goal_met_date
+		FROM user_stats
+		WHERE user_id = $1
+		FOR UPDATE`, userID).Scan(&goal, &goalStreak, &longestGoalStreak, &lastGoalMetDate)
+	if err != nil {
+		return apperr.Errorf("failed to get daily goal: %w", err)
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	var completed int
+	var alreadyMet bool
+	err = tx.QueryRow(`
+		INSERT INTO user_daily_goals (user_id, activity_date, completed_count, goal, updated_at)
+		VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP)
+		ON CONFLICT (user_id, activity_date)
+		DO UPDATE SET
+			completed_count = user_daily_goals.completed_count + EXCLUDED.completed_count,
+			goal = EXCLUDED.goal,
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING completed_count, goal_met`, userID, today, increment, goal).Scan(&completed, &alreadyMet)
+	if err != nil {
+		return apperr.Errorf("failed to record daily goal progress: %w", err)
+	}
+
+	// Nothing to do if goals are off, not reached yet, or already counted today
+	if goal <= 0 || completed < goal || alreadyMet {
+		return tx.Commit()
+	}
+
+	if _, err := tx.Exec(`
+		UPDATE user_daily_goals SET goal_met = true
+		WHERE user_id = $1 AND activity_date = $2`, userID, today); err != nil {
+		return apperr.Errorf("failed to mark daily goal met: %w", err)
+	}
+
+	// Extend the goal streak if yesterday's goal was met, otherwise start over
+	yesterday := today.Add(-24 * time.Hour)
+	if lastGoalMetDate != nil && lastGoalMetDate.UTC().Truncate(24*time.Hour).Equal(yesterday) {
+		goalStreak++
+	} else {
+		goalStreak = 1
+	}
+	if goalStreak > longestGoalStreak {
+		longestGoalStreak = goalStreak
+	}
+
+	if _, err := tx.Exec(`
+		UPDATE user_stats
+		SET goal_streak = $2, longest_goal_streak = $3, goals_met_count = goals_met_count + 1,
+			last_goal_met_date = $4, updated_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1`, userID, goalStreak, longestGoalStreak, today); err != nil {
+		return apperr.Errorf("failed to update goal streak: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// GetDailyGoalProgress returns the user's goal and today's attainment
+func (r *StatsRepository) GetDailyGoalProgress(userID int) (*models.DailyGoalProgress, error) {
+	query := `
+		SELECT us.daily_goal, COALESCE(dg.completed_count, 0), COALESCE(dg.goal_met, false),
+			   us.goal_streak, us.longest_goal_streak, us.goals_met_count, us.last_goal_met_date
+		FROM user_stats us
+		LEFT JOIN user_daily_goals dg ON dg.user_id = us.user_id AND dg.activity_date = $2
+		WHERE us.user_id = $1`
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	progress := &models.DailyGoalProgress{}
+	var lastGoalMetDate *time.Time
+	err := r.db.QueryRow(query, userID, today).Scan(
+		&progress.DailyGoal, &progress.CompletedToday, &progress.GoalMet,
+		&progress.GoalStreak, &progress.LongestGoalStreak, &progress.GoalsMetCount, &lastGoalMetDate,
+	)
+	if err == sql.ErrNoRows {
+		// User doesn't have stats yet, return defaults
+		return progress, nil
+	}
+	if err != nil {
+		return nil, apperr.Errorf("failed to get daily goal progress: %w", err)
+	}
+
+	// A goal streak only survives while yesterday's (or today's) goal was met
+	if lastGoalMetDate == nil || lastGoalMetDate.UTC().Truncate(24*time.Hour).Before(today.Add(-24*time.Hour)) {
+		progress.GoalStreak = 0
+	}
+
+	return progress, nil
+}
+
+// RefreshUserAggregates recomputes the user's item counts stored in user_stats (excluding
+// miscellaneous category) and marks them fresh
+func (r *StatsRepository) RefreshUserAggregates(userID int) error {
+	query := `
+		INSERT INTO user_stats (
+			user_id, total_items, completed_items, in_progress_items, pending_items,
+			dsa_completed, lld_completed, hld_completed, solved_items, reviewed_solution_items,
+			stats_refreshed_at, created_at, updated_at)
+		SELECT $1,
+			COUNT(*),
+			COUNT(*) FILTER (WHERE up.status = 'done'),
+			COUNT(*) FILTER (WHERE up.status = 'in-progress'),
+			COUNT(*) FILTER (WHERE COALESCE(up.status, 'pending') = 'pending'),
+			COUNT(*) FILTER (WHERE up.status = 'done' AND i.category = 'dsa'),
+			COUNT(*) FILTER (WHERE up.status = 'done' AND i.category = 'lld'),
+			COUNT(*) FILTER (WHERE up.status = 'done' AND i.category = 'hld'),
+			COUNT(*) FILTER (WHERE up.status = 'done' AND COALESCE(up.completion_quality, 'solved') = 'solved'),
+			COUNT(*) FILTER (WHERE up.status = 'done' AND up.completion_quality = 'reviewed_solution'),
+			CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+		FROM items i
+		LEFT JOIN user_progress up ON up.item_id = i.id AND up.user_id = $1
+		WHERE i.category != $2 AND i.published
+		ON CONFLICT (user_id) DO UPDATE SET
+			total_items = EXCLUDED.total_items,
+			completed_items = EXCLUDED.completed_items,
+			in_progress_items = EXCLUDED.in_progress_items,
+			pending_items = EXCLUDED.pending_items,
+			dsa_completed = EXCLUDED.dsa_completed,
+			lld_completed = EXCLUDED.lld_completed,
+			hld_completed = EXCLUDED.hld_completed,
+			solved_items = EXCLUDED.solved_items,
+			reviewed_solution_items = EXCLUDED.reviewed_solution_items,
+			stats_refreshed_at = EXCLUDED.stats_refreshed_at,
+			updated_at = EXCLUDED.updated_at`
+
+	return withUserContext(r.db, userID, func(q dbtx) error {
+		if _, err := q.Exec(query, userID, models.CategoryMiscellaneous); err != nil {
+			return apperr.Errorf("failed to refresh user stats aggregates: %w", err)
+		}
+		return nil
+	})
+}
+
+// MarkAllAggregatesStale flags every user's aggregates for recomputation, e.g. after the
+// item catalog changed and every total is off
+func (r *StatsRepository) MarkAllAggregatesStale() (int64, error) {
+	result, err := r.db.Exec("UPDATE user_stats SET stats_refreshed_at = NULL WHERE stats_refreshed_at IS NOT NULL")
+	if err != nil {
+		return 0, apperr.Errorf("failed to mark stats aggregates stale: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+// GetUsersWithStaleAggregates returns up to limit active users whose aggregates need recomputing,
+// including users who have no stats row yet
+func (r *StatsRepository) GetUsersWithStaleAggregates(limit int) ([]int, error) {
+	query := `
+		SELECT u.id
+		FROM users u
+		LEFT JOIN user_stats us ON us.user_id = u.id
+		WHERE us.stats_refreshed_at IS NULL AND u.archived_at IS NULL
+		ORDER BY u.id
+		LIMIT $1`
+
+	rows, err := r.db.Query(query, limit)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get users with stale stats: %w", err)
+	}
+	defer rows.Close()
+
+	var userIDs []int
+	for rows.Next() {
+		var userID int
+		if err := rows.Scan(&userID); err != nil {
+			return nil, apperr.Errorf("failed to scan user ID: %w", err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	return userIDs, rows.Err()
+}
@@ -0,0 +1,236 @@
+package repositories
+
+import (
+	"database/sql"
+	"time"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+)
+
+// SubmissionRepository handles database operations for item test cases and judged submission attempts
+type SubmissionRepository struct {
+	db *sql.DB
+}
+
+// NewSubmissionRepository creates a new submission repository
+func NewSubmissionRepository(db *sql.DB) *SubmissionRepository {
+	return &SubmissionRepository{db: db}
+}
+
+// GetTestCases lists an item's test cases in order, optionally only the sample ones
+func (r *SubmissionRepository) GetTestCases(itemID int, samplesOnly bool) ([]*models.ItemTestCase, error) {
+	query := `
+		SELECT id, item_id, input, expected_output, is_sample, order_idx, created_at, updated_at
+		FROM item_test_cases
+		WHERE item_id = $1 AND (is_sample OR NOT $2)
+		ORDER BY order_idx ASC, id ASC`
+
+	rows, err := r.db.Query(query, itemID, samplesOnly)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get test cases: %w", err)
+	}
+	defer rows.Close()
+
+	testCases := []*models.ItemTestCase{}
+	for rows.Next() {
+		var testCase models.ItemTestCase
+		err := rows.Scan(
+			&testCase.ID, &testCase.ItemID, &testCase.Input, &testCase.ExpectedOutput,
+			&testCase.IsSample, &testCase.OrderIdx, &testCase.CreatedAt, &testCase.UpdatedAt,
+		)
+		if err != nil {
+			return nil, apperr.Errorf("failed to scan test case: %w", err)
+		}
+		testCases = append(testCases, &testCase)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating test cases: %w", err)
+	}
+
+	return testCases, nil
+}
+
+// GetTestCase retrieves one of an item's test cases
+func (r *SubmissionRepository) GetTestCase(itemID, testCaseID int) (*models.ItemTestCase, error) {
+	query := `
+		SELECT id, item_id, input, expected_output, is_sample, order_idx, created_at, updated_at
+		FROM item_test_cases
+		WHERE id = $1 AND item_id = $2`
+
+	var testCase models.ItemTestCase
+	err := r.db.QueryRow(query, testCaseID, itemID).Scan(
+		&testCase.ID, &testCase.ItemID, &testCase.Input, &testCase.ExpectedOutput,
+		&testCase.IsSample, &testCase.OrderIdx, &testCase.CreatedAt, &testCase.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, apperr.NotFound("test case not found")
+	}
+	if err != nil {
+		return nil, apperr.Errorf("failed to get test case: %w", err)
+	}
+
+	return &testCase, nil
+}
+
+// CountTestCases returns how many test cases an item has
+func (r *SubmissionRepository) CountTestCases(itemID int) (int, error) {
+	var count int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM item_test_cases WHERE item_id = $1", itemID).Scan(&count); err != nil {
+		return 0, apperr.Errorf("failed to count test cases: %w", err)
+	}
+	return count, nil
+}
+
+// CreateTestCase adds a test case after the item's existing ones
+func (r *SubmissionRepository) CreateTestCase(itemID int, req *models.CreateItemTestCaseRequest) (*models.ItemTestCase, error) {
+	query := `
+		INSERT INTO item_test_cases (item_id, input, expected_output, is_sample, order_idx)
+		VALUES ($1, $2, $3, $4, (SELECT COALESCE(MAX(order_idx), -1) + 1 FROM item_test_cases WHERE item_id = $1))
+		RETURNING id, order_idx, created_at, updated_at`
+
+	testCase := &models.ItemTestCase{
+		ItemID:         itemID,
+		Input:          req.Input,
+		ExpectedOutput: req.ExpectedOutput,
+		IsSample:       req.IsSample,
+	}
+	err := r.db.QueryRow(query, itemID, req.Input, req.ExpectedOutput, req.IsSample).Scan(
+		&testCase.ID, &testCase.OrderIdx, &testCase.CreatedAt, &testCase.UpdatedAt,
+	)
+	if err != nil {
+		return nil, apperr.Errorf("failed to create test case: %w", err)
+	}
+
+	return testCase, nil
+}
+
+// UpdateTestCase saves a test case's input, expected output, sample flag and position
+func (r *SubmissionRepository) UpdateTestCase(testCase *models.ItemTestCase) error {
+	query := `
+		UPDATE item_test_cases
+		SET input = $1, expected_output = $2, is_sample = $3, order_idx = $4, updated_at = $5
+		WHERE id = $6 AND item_id = $7`
+
+	testCase.UpdatedAt = time.Now()
+	result, err := r.db.Exec(query, testCase.Input, testCase.ExpectedOutput, testCase.IsSample, testCase.OrderIdx,
+		testCase.UpdatedAt, testCase.ID, testCase.ItemID)
+	if err != nil {
+		return apperr.Errorf("failed to update test case: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperr.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperr.NotFound("test case not found")
+	}
+
+	return nil
+}
+
+// DeleteTestCase removes one of an item's test cases
+func (r *SubmissionRepository) DeleteTestCase(itemID, testCaseID int) error {
+	result, err := r.db.Exec("DELETE FROM item_test_cases WHERE id = $1 AND item_id = $2", testCaseID, itemID)
+	if err != nil {
+		return apperr.Errorf("failed to delete test case: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperr.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperr.NotFound("test case not found")
+	}
+
+	return nil
+}
+
+// CreateAttempt records a judged submission
+func (r *SubmissionRepository) CreateAttempt(attempt *models.SubmissionAttempt) error {
+	query := `
+		INSERT INTO submission_attempts (user_id, item_id, language, code, passed, passed_cases, total_cases)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at`
+
+	err := r.db.QueryRow(query, attempt.UserID, attempt.ItemID, attempt.Language, attempt.Code,
+		attempt.Passed, attempt.PassedCases, attempt.TotalCases).Scan(&attempt.ID, &attempt.CreatedAt)
+	if err != nil {
+		return apperr.Errorf("failed to record submission attempt: %w", err)
+	}
+
+	return nil
+}
+
+// SetCommitSHA records the GitHub commit a submission was saved in
+func (r *SubmissionRepository) SetCommitSHA(attemptID int, sha string) error {
+	if _, err := r.db.Exec("UPDATE submission_attempts SET commit_sha = $2 WHERE id = $1", attemptID, sha); err != nil {
+		return apperr.Errorf("failed to record commit SHA: %w", err)
+	}
+
+	return nil
+}
+
+// GetAttempts lists the user's most recent attempts on an item, newest first
+func (r *SubmissionRepository) GetAttempts(userID, itemID, limit int) ([]*models.SubmissionAttempt, error) {
+	query := `
+		SELECT id, user_id, item_id, language, code, passed, passed_cases, total_cases, commit_sha, created_at
+		FROM submission_attempts
+		WHERE user_id = $1 AND item_id = $2
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3`
+
+	rows, err := r.db.Query(query, userID, itemID, limit)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get submission attempts: %w", err)
+	}
+	defer rows.Close()
+
+	attempts := []*models.SubmissionAttempt{}
+	for rows.Next() {
+		var attempt models.SubmissionAttempt
+		err := rows.Scan(
+			&attempt.ID, &attempt.UserID, &attempt.ItemID, &attempt.Language, &attempt.Code,
+			&attempt.Passed, &attempt.PassedCases, &attempt.TotalCases, &attempt.CommitSHA, &attempt.CreatedAt,
+		)
+		if err != nil {
+			return nil, apperr.Errorf("failed to scan submission attempt: %w", err)
+		}
+		attempts = append(attempts, &attempt)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating submission attempts: %w", err)
+	}
+
+	return attempts, nil
+}
+
+// GetStatsForUser summarizes the user's judged submissions
+func (r *SubmissionRepository) GetStatsForUser(userID int) (*models.SubmissionStats, error) {
+	query := `
+		SELECT
+			COUNT(*),
+			COUNT(*) FILTER (WHERE passed),
+			COUNT(DISTINCT item_id),
+			COUNT(DISTINCT item_id) FILTER (WHERE passed)
+		FROM submission_attempts
+		WHERE user_id = $1`
+
+	var stats models.SubmissionStats
+	err := r.db.QueryRow(query, userID).Scan(&stats.TotalAttempts, &stats.PassedAttempts, &stats.ItemsAttempted, &stats.ItemsSolved)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get submission stats: %w", err)
+	}
+
+	if stats.TotalAttempts > 0 {
+		stats.PassRate = float64(stats.PassedAttempts) / float64(stats.TotalAttempts) * 100
+	}
+
+	return &stats, nil
+}
@@ -2,10 +2,11 @@ package repositories
 
 import (
 	"database/sql"
-	"fmt"
+	"encoding/json"
 	"time"
 
 	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
 
 	"github.com/lib/pq"
 )
@@ -22,32 +23,47 @@ func NewTestRepository(db *sql.DB) *TestRepository {
 
 // CreateTestItems creates multiple test items with the same session ID
 func (r *TestRepository) CreateTestItems(userID int, itemIDs []int) (string, error) {
+	return r.createSession(userID, itemIDs, nil)
+}
+
+// CreateRetake creates a session retaking items from an earlier one, linked to it by
+// parent_session_id
+func (r *TestRepository) CreateRetake(userID int, parentSessionID string, itemIDs []int) (string, error) {
+	return r.createSession(userID, itemIDs, &parentSessionID)
+}
+
+// createSession inserts the items of a new session, with parentSessionID when it's a retake
+func (r *TestRepository) createSession(userID int, itemIDs []int, parentSessionID *string) (string, error) {
 	// Generate a UUID using PostgreSQL's gen_random_uuid() function
 	var sessionID string
 	err := r.db.QueryRow("SELECT gen_random_uuid()::text").Scan(&sessionID)
 	if err != nil {
-		return "", fmt.Errorf("failed to generate session ID: %w", err)
+		return "", apperr.Errorf("failed to generate session ID: %w", err)
 	}
 
 	tx, err := r.db.Begin()
 	if err != nil {
-		return "", fmt.Errorf("failed to begin transaction: %w", err)
+		return "", apperr.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback()
 
+	if err := setUserContext(tx, userID); err != nil {
+		return "", err
+	}
+
 	query := `
-		INSERT INTO tests (session_id, user_id, item_id, status)
-		VALUES ($1, $2, $3, 'pending')`
+		INSERT INTO tests (session_id, user_id, item_id, status, parent_session_id)
+		VALUES ($1, $2, $3, 'pending', $4)`
 
 	for _, itemID := range itemIDs {
-		_, err := tx.Exec(query, sessionID, userID, itemID)
+		_, err := tx.Exec(query, sessionID, userID, itemID, parentSessionID)
 		if err != nil {
-			return "", fmt.Errorf("failed to create test item: %w", err)
+			return "", apperr.Errorf("failed to create test item: %w", err)
 		}
 	}
 
 	if err := tx.Commit(); err != nil {
-		return "", fmt.Errorf("failed to commit transaction: %w", err)
+		return "", apperr.Errorf("failed to commit transaction: %w", err)
 	}
 
 	return sessionID, nil
@@ -63,38 +79,45 @@ func (r *TestRepository) GetTestByUserWithStatus(userID int, itemStatus []string
 		LIMIT 1`
 
 	var sessionID string
-	err := r.db.QueryRow(query, userID, pq.Array(itemStatus)).Scan(&sessionID)
-	if err == sql.ErrNoRows {
-		return "", nil, nil // No test found
-	}
-	if err != nil {
-		return "", nil, fmt.Errorf("failed to get test: %w", err)
-	}
+	var itemIDs []int
+	err := withUserContext(r.db, userID, func(q dbtx) error {
+		err := q.QueryRow(query, userID, pq.Array(itemStatus)).Scan(&sessionID)
+		if err == sql.ErrNoRows {
+			return nil // No test found
+		}
+		if err != nil {
+			return apperr.Errorf("failed to get test: %w", err)
+		}
 
-	// Get all item IDs for this session with the specified statuses
-	itemQuery := `
-		SELECT item_id
-		FROM tests
-		WHERE user_id = $1 AND session_id = $2 AND status = ANY($3)
-		ORDER BY id`
+		// Get all item IDs for this session with the specified statuses
+		itemQuery := `
+			SELECT item_id
+			FROM tests
+			WHERE user_id = $1 AND session_id = $2 AND status = ANY($3)
+			ORDER BY id`
 
-	rows, err := r.db.Query(itemQuery, userID, sessionID, pq.Array(itemStatus))
-	if err != nil {
-		return "", nil, fmt.Errorf("failed to get test items: %w", err)
-	}
-	defer rows.Close()
+		rows, err := q.Query(itemQuery, userID, sessionID, pq.Array(itemStatus))
+		if err != nil {
+			return apperr.Errorf("failed to get test items: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var itemID int
+			if err := rows.Scan(&itemID); err != nil {
+				return apperr.Errorf("failed to scan item ID: %w", err)
+			}
+			itemIDs = append(itemIDs, itemID)
+		}
 
-	var itemIDs []int
-	for rows.Next() {
-		var itemID int
-		if err := rows.Scan(&itemID); err != nil {
-			return "", nil, fmt.Errorf("failed to scan item ID: %w", err)
+		if err := rows.Err(); err != nil {
+			return apperr.Errorf("error iterating test items: %w", err)
 		}
-		itemIDs = append(itemIDs, itemID)
-	}
 
-	if err := rows.Err(); err != nil {
-		return "", nil, fmt.Errorf("error iterating test items: %w", err)
+		return nil
+	})
+	if err != nil {
+		return "", nil, err
 	}
 
 	return sessionID, itemIDs, nil
@@ -108,59 +131,68 @@ func (r *TestRepository) GetTestsBySessionID(userID int, sessionID string) ([]*m
 		WHERE user_id = $1 AND session_id = $2
 		ORDER BY id`
 
-	rows, err := r.db.Query(query, userID, sessionID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get tests by session: %w", err)
-	}
-	defer rows.Close()
-
 	var tests []*models.Test
-	for rows.Next() {
-		var test models.Test
-		err := rows.Scan(
-			&test.ID,
-			&test.SessionID,
-			&test.UserID,
-			&test.ItemID,
-			&test.Status,
-			&test.CreatedAt,
-			&test.UpdatedAt,
-		)
+	err := withUserContext(r.db, userID, func(q dbtx) error {
+		rows, err := q.Query(query, userID, sessionID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan test: %w", err)
+			return apperr.Errorf("failed to get tests by session: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var test models.Test
+			err := rows.Scan(
+				&test.ID,
+				&test.SessionID,
+				&test.UserID,
+				&test.ItemID,
+				&test.Status,
+				&test.CreatedAt,
+				&test.UpdatedAt,
+			)
+			if err != nil {
+				return apperr.Errorf("failed to scan test: %w", err)
+			}
+			tests = append(tests, &test)
+		}
+
+		if err := rows.Err(); err != nil {
+			return apperr.Errorf("error iterating tests: %w", err)
 		}
-		tests = append(tests, &test)
-	}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating tests: %w", err)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return tests, nil
 }
 
-// UpdateTestStatus updates the status of all tests in a session
-func (r *TestRepository) UpdateTestStatus(userID int, sessionID string, item_id string, status models.TestStatus) error {
+// UpdateTestStatus updates the status of an item in a session
+func (r *TestRepository) UpdateTestStatus(userID int, sessionID string, itemID int, status models.TestStatus) error {
 	query := `
 		UPDATE tests
 		SET status = $1, updated_at = $2
 		WHERE user_id = $3 AND session_id = $4 AND item_id = $5`
 
-	result, err := r.db.Exec(query, status, time.Now(), userID, sessionID, item_id)
-	if err != nil {
-		return fmt.Errorf("failed to update test status: %w", err)
-	}
+	return withUserContext(r.db, userID, func(q dbtx) error {
+		result, err := q.Exec(query, status, time.Now(), userID, sessionID, itemID)
+		if err != nil {
+			return apperr.Errorf("failed to update test status: %w", err)
+		}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
-	}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return apperr.Errorf("failed to get rows affected: %w", err)
+		}
 
-	if rowsAffected == 0 {
-		return fmt.Errorf("no tests found for session")
-	}
+		if rowsAffected == 0 {
+			return apperr.NotFound("item not found in this test")
+		}
 
-	return nil
+		return nil
+	})
 }
 
 // DeleteTestsBySessionID deletes all tests for a specific session
@@ -169,21 +201,23 @@ func (r *TestRepository) DeleteTestsBySessionID(userID int, sessionID string) er
 		DELETE FROM tests
 		WHERE user_id = $1 AND session_id = $2`
 
-	result, err := r.db.Exec(query, userID, sessionID)
-	if err != nil {
-		return fmt.Errorf("failed to delete tests: %w", err)
-	}
+	return withUserContext(r.db, userID, func(q dbtx) error {
+		result, err := q.Exec(query, userID, sessionID)
+		if err != nil {
+			return apperr.Errorf("failed to delete tests: %w", err)
+		}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
-	}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return apperr.Errorf("failed to get rows affected: %w", err)
+		}
 
-	if rowsAffected == 0 {
-		return fmt.Errorf("no tests found for session")
-	}
+		if rowsAffected == 0 {
+			return apperr.NotFound("test not found")
+		}
 
-	return nil
+		return nil
+	})
 }
 
 // GetTestCreatedAt retrieves the created_at timestamp for a session
@@ -196,17 +230,195 @@ func (r *TestRepository) GetTestCreatedAt(userID int, sessionID string) (time.Ti
 		LIMIT 1`
 
 	var createdAt time.Time
-	err := r.db.QueryRow(query, userID, sessionID).Scan(&createdAt)
-	if err == sql.ErrNoRows {
-		return time.Time{}, fmt.Errorf("no tests found for session")
-	}
+	err := withUserContext(r.db, userID, func(q dbtx) error {
+		err := q.QueryRow(query, userID, sessionID).Scan(&createdAt)
+		if err == sql.ErrNoRows {
+			return apperr.NotFound("no tests found for session")
+		}
+		if err != nil {
+			return apperr.Errorf("failed to get test created_at: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
-		return time.Time{}, fmt.Errorf("failed to get test created_at: %w", err)
+		return time.Time{}, err
 	}
 
 	return createdAt, nil
 }
 
+// GetSessionItemsWithProgress retrieves the items of the given sessions in one query, each with
+// its status in the test, the user's starred flag, any recorded result and the latest mock
+// interview started on it from the test, keyed by session and in the order they were added. Only
+// tests with one of the given statuses are included.
+func (r *TestRepository) GetSessionItemsWithProgress(userID int, sessionIDs []string, itemStatus []string) (map[string][]models.TestSessionItem, error) {
+	query := `
+		SELECT
+			t.session_id,
+			i.id, i.title, i.link, i.category, i.subcategory, i.attachments, i.created_at,
+			t.status,
+			COALESCE(up.starred, false) as starred,
+			t.outcome, t.time_taken_seconds, t.self_rating, t.result_notes, t.result_recorded_at,
+			(
+				SELECT mi.id FROM mock_interviews mi
+				WHERE mi.user_id = t.user_id AND mi.test_session_id = t.session_id::text AND mi.item_id = t.item_id
+				ORDER BY mi.created_at DESC, mi.id DESC
+				LIMIT 1
+			)
+		FROM tests t
+		JOIN items i ON i.id = t.item_id
+		LEFT JOIN user_progress up
+			ON up.item_id = t.item_id AND up.user_id = t.user_id
+		WHERE t.user_id = $1 AND t.session_id = ANY($2) AND t.status = ANY($3)
+		ORDER BY t.id`
+
+	items := make(map[string][]models.TestSessionItem, len(sessionIDs))
+	err := withUserContext(r.db, userID, func(q dbtx) error {
+		rows, err := q.Query(query, userID, pq.Array(sessionIDs), pq.Array(itemStatus))
+		if err != nil {
+			return apperr.Errorf("failed to get session items: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var sessionID string
+			var item models.TestSessionItem
+			var outcome sql.NullString
+			var timeTaken, selfRating, mockInterviewID sql.NullInt64
+			var notes string
+			var recordedAt sql.NullTime
+			err := rows.Scan(
+				&sessionID,
+				&item.ID, &item.Title, &item.Link, &item.Category, &item.Subcategory,
+				&item.Attachments, &item.CreatedAt, &item.Status, &item.Starred,
+				&outcome, &timeTaken, &selfRating, &notes, &recordedAt, &mockInterviewID,
+			)
+			if err != nil {
+				return apperr.Errorf("failed to scan session item: %w", err)
+			}
+
+			if outcome.Valid {
+				item.Result = &models.TestItemResult{
+					Outcome:    models.TestOutcome(outcome.String),
+					Notes:      notes,
+					RecordedAt: recordedAt.Time,
+				}
+				if timeTaken.Valid {
+					seconds := int(timeTaken.Int64)
+					item.Result.TimeTaken = &seconds
+				}
+				if selfRating.Valid {
+					rating := int(selfRating.Int64)
+					item.Result.SelfRating = &rating
+				}
+			}
+			if mockInterviewID.Valid {
+				id := int(mockInterviewID.Int64)
+				item.MockInterviewID = &id
+			}
+			items[sessionID] = append(items[sessionID], item)
+		}
+
+		if err := rows.Err(); err != nil {
+			return apperr.Errorf("error iterating session items: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// SaveTestResult records the user's result for an item in a test, replacing any earlier one
+func (r *TestRepository) SaveTestResult(userID int, sessionID string, itemID int, result *models.TestItemResult) error {
+	query := `
+		UPDATE tests
+		SET outcome = $1, time_taken_seconds = $2, self_rating = $3, result_notes = $4,
+			result_recorded_at = $5, updated_at = $5
+		WHERE user_id = $6 AND session_id = $7 AND item_id = $8`
+
+	return withUserContext(r.db, userID, func(q dbtx) error {
+		res, err := q.Exec(query, result.Outcome, result.TimeTaken, result.SelfRating, result.Notes,
+			result.RecordedAt, userID, sessionID, itemID)
+		if err != nil {
+			return apperr.Errorf("failed to save test result: %w", err)
+		}
+
+		rowsAffected, err := res.RowsAffected()
+		if err != nil {
+			return apperr.Errorf("failed to get rows affected: %w", err)
+		}
+
+		if rowsAffected == 0 {
+			return apperr.NotFound("item not found in this test")
+		}
+
+		return nil
+	})
+}
+
+// GetRecentSessions retrieves the user's latest test sessions, newest first, without their items
+func (r *TestRepository) GetRecentSessions(userID int, limit int) ([]models.TestSession, error) {
+	query := `
+		SELECT session_id, MIN(parent_session_id::text), MIN(created_at) as created_at
+		FROM tests
+		WHERE user_id = $1
+		GROUP BY session_id
+		ORDER BY created_at DESC
+		LIMIT $2`
+
+	sessions := []models.TestSession{}
+	err := withUserContext(r.db, userID, func(q dbtx) error {
+		rows, err := q.Query(query, userID, limit)
+		if err != nil {
+			return apperr.Errorf("failed to get test sessions: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var session models.TestSession
+			var parentSessionID sql.NullString
+			if err := rows.Scan(&session.SessionID, &parentSessionID, &session.CreatedAt); err != nil {
+				return apperr.Errorf("failed to scan test session: %w", err)
+			}
+			session.ParentSessionID = parentSessionID.String
+			sessions = append(sessions, session)
+		}
+
+		if err := rows.Err(); err != nil {
+			return apperr.Errorf("error iterating test sessions: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// CountSessionsSince counts the tests the user has started since the given time
+func (r *TestRepository) CountSessionsSince(userID int, since time.Time) (int, error) {
+	query := `
+		SELECT COUNT(DISTINCT session_id)
+		FROM tests
+		WHERE user_id = $1 AND created_at >= $2`
+
+	var count int
+	err := withUserContext(r.db, userID, func(q dbtx) error {
+		return q.QueryRow(query, userID, since).Scan(&count)
+	})
+	if err != nil {
+		return 0, apperr.Errorf("failed to count tests: %w", err)
+	}
+
+	return count, nil
+}
+
 // IsItemInPendingTest checks if an item is part of an pending test for a user
 func (r *TestRepository) IsItemInPendingTest(userID int) (bool, error) {
 	query := `
@@ -217,10 +429,58 @@ func (r *TestRepository) IsItemInPendingTest(userID int) (bool, error) {
 		)`
 
 	var exists bool
-	err := r.db.QueryRow(query, userID).Scan(&exists)
+	err := withUserContext(r.db, userID, func(q dbtx) error {
+		if err := q.QueryRow(query, userID).Scan(&exists); err != nil {
+			return apperr.Errorf("failed to check if item is in pending test: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
-		return false, fmt.Errorf("failed to check if item is in pending test: %w", err)
+		return false, err
 	}
 
 	return exists, nil
 }
+
+// GetEligibilityPolicy returns the test eligibility rules an admin set, or nil while none have
+// been set
+func (r *TestRepository) GetEligibilityPolicy() (*models.TestEligibilityPolicy, error) {
+	var rules []byte
+	var updatedAt time.Time
+	err := r.db.QueryRow("SELECT rules, updated_at FROM test_eligibility_policy WHERE id").Scan(&rules, &updatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, apperr.Errorf("failed to get test eligibility rules: %w", err)
+	}
+
+	policy := &models.TestEligibilityPolicy{Rules: []models.TestEligibilityRule{}, UpdatedAt: &updatedAt}
+	if err := json.Unmarshal(rules, &policy.Rules); err != nil {
+		return nil, apperr.Errorf("failed to decode test eligibility rules: %w", err)
+	}
+
+	return policy, nil
+}
+
+// SaveEligibilityRules replaces the test eligibility rules
+func (r *TestRepository) SaveEligibilityRules(rules []models.TestEligibilityRule) (*models.TestEligibilityPolicy, error) {
+	encoded, err := json.Marshal(rules)
+	if err != nil {
+		return nil, apperr.Errorf("failed to encode test eligibility rules: %w", err)
+	}
+
+	query := `
+		INSERT INTO test_eligibility_policy (id, rules, updated_at)
+		VALUES (true, $1, CURRENT_TIMESTAMP)
+		ON CONFLICT (id)
+		DO UPDATE SET rules = EXCLUDED.rules, updated_at = CURRENT_TIMESTAMP
+		RETURNING updated_at`
+
+	var updatedAt time.Time
+	if err := r.db.QueryRow(query, encoded).Scan(&updatedAt); err != nil {
+		return nil, apperr.Errorf("failed to save test eligibility rules: %w", err)
+	}
+
+	return &models.TestEligibilityPolicy{Rules: rules, UpdatedAt: &updatedAt}, nil
+}
@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"interview-prep-app/pkg/apperr"
+)
+
+// Tx is a unit of work: a database transaction that repository methods taking a *Tx run their
+// queries in, so a service can compose calls across repositories atomically
+type Tx struct {
+	ctx context.Context
+	tx  *sql.Tx
+}
+
+// Exec runs a statement in the transaction
+func (t *Tx) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return t.tx.ExecContext(t.ctx, query, args...)
+}
+
+// Query runs a query in the transaction
+func (t *Tx) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return t.tx.QueryContext(t.ctx, query, args...)
+}
+
+// QueryRow runs a single-row query in the transaction
+func (t *Tx) QueryRow(query string, args ...interface{}) *sql.Row {
+	return t.tx.QueryRowContext(t.ctx, query, args...)
+}
+
+// TxManager starts units of work on the connection pool
+type TxManager struct {
+	db *sql.DB
+}
+
+// NewTxManager creates a new transaction manager
+func NewTxManager(db *sql.DB) *TxManager {
+	return &TxManager{db: db}
+}
+
+// WithTx runs fn in a transaction, committing when it returns nil and rolling back otherwise.
+// Cancelling ctx aborts the transaction.
+func (m *TxManager) WithTx(ctx context.Context, fn func(tx *Tx) error) error {
+	return m.run(ctx, 0, fn)
+}
+
+// WithUserTx is WithTx with the transaction scoped to a user for row-level security
+func (m *TxManager) WithUserTx(ctx context.Context, userID int, fn func(tx *Tx) error) error {
+	return m.run(ctx, userID, fn)
+}
+
+func (m *TxManager) run(ctx context.Context, userID int, fn func(tx *Tx) error) error {
+	sqlTx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return apperr.Errorf("failed to begin transaction: %w", err)
+	}
+	defer sqlTx.Rollback()
+
+	if userID > 0 {
+		if err := setUserContext(sqlTx, userID); err != nil {
+			return err
+		}
+	}
+
+	if err := fn(&Tx{ctx: ctx, tx: sqlTx}); err != nil {
+		return err
+	}
+
+	if err := sqlTx.Commit(); err != nil {
+		return apperr.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
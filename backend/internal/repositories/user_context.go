@@ -0,0 +1,68 @@
+package repositories
+
+import (
+	"database/sql"
+	"strconv"
+
+	"interview-prep-app/pkg/apperr"
+)
+
+// UserContextSetting is the Postgres setting row-level security policies read the current user from
+const UserContextSetting = "app.user_id"
+
+// rowSecurityEnabled controls whether user-scoped queries run inside a transaction
+// carrying the user context. It is set once at startup from configuration.
+var rowSecurityEnabled bool
+
+// SetRowSecurityEnabled turns per-transaction user context on or off
+func SetRowSecurityEnabled(enabled bool) {
+	rowSecurityEnabled = enabled
+}
+
+// dbtx is the subset of *sql.DB and *sql.Tx that repository queries use
+type dbtx interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// withUserContext runs fn with the user context set for row-level security.
+// When row security is disabled fn runs directly against the pool with no extra round trips.
+func withUserContext(db *sql.DB, userID int, fn func(q dbtx) error) error {
+	if !rowSecurityEnabled {
+		return fn(db)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return apperr.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := setUserContext(tx, userID); err != nil {
+		return err
+	}
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return apperr.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// setUserContext scopes an existing transaction to a user for row-level security
+func setUserContext(tx *sql.Tx, userID int) error {
+	if !rowSecurityEnabled {
+		return nil
+	}
+
+	if _, err := tx.Exec("SELECT set_config($1, $2, true)", UserContextSetting, strconv.Itoa(userID)); err != nil {
+		return apperr.Errorf("failed to set user context: %w", err)
+	}
+
+	return nil
+}
@@ -4,7 +4,11 @@ import (
 	"database/sql"
 	"fmt"
 	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+	"strings"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 // UserProgressRepository handles database operations for user progress
@@ -29,21 +33,23 @@ func (r *UserProgressRepository) Create(progress *models.UserProgress) error {
 	progress.CreatedAt = now
 	progress.UpdatedAt = now
 
-	err := r.db.QueryRow(
-		query,
-		progress.UserID,
-		progress.ItemID,
-		progress.Status,
-		progress.Starred,
-		progress.Notes,
-		progress.StartedAt,
-		progress.CompletedAt,
-		progress.CreatedAt,
-		progress.UpdatedAt,
-	).Scan(&progress.ID, &progress.CreatedAt, &progress.UpdatedAt)
+	err := withUserContext(r.db, progress.UserID, func(q dbtx) error {
+		return q.QueryRow(
+			query,
+			progress.UserID,
+			progress.ItemID,
+			progress.Status,
+			progress.Starred,
+			progress.Notes,
+			progress.StartedAt,
+			progress.CompletedAt,
+			progress.CreatedAt,
+			progress.UpdatedAt,
+		).Scan(&progress.ID, &progress.CreatedAt, &progress.UpdatedAt)
+	})
 
 	if err != nil {
-		return fmt.Errorf("failed to create user progress: %w", err)
+		return apperr.Errorf("failed to create user progress: %w", err)
 	}
 
 	return nil
@@ -58,24 +64,26 @@ func (r *UserProgressRepository) GetByUserAndItem(userID, itemID int) (*models.U
 	`
 
 	progress := &models.UserProgress{}
-	err := r.db.QueryRow(query, userID, itemID).Scan(
-		&progress.ID,
-		&progress.UserID,
-		&progress.ItemID,
-		&progress.Status,
-		&progress.Starred,
-		&progress.Notes,
-		&progress.StartedAt,
-		&progress.CompletedAt,
-		&progress.CreatedAt,
-		&progress.UpdatedAt,
-	)
+	err := withUserContext(r.db, userID, func(q dbtx) error {
+		return q.QueryRow(query, userID, itemID).Scan(
+			&progress.ID,
+			&progress.UserID,
+			&progress.ItemID,
+			&progress.Status,
+			&progress.Starred,
+			&progress.Notes,
+			&progress.StartedAt,
+			&progress.CompletedAt,
+			&progress.CreatedAt,
+			&progress.UpdatedAt,
+		)
+	})
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("user progress not found")
+			return nil, apperr.NotFound("user progress not found")
 		}
-		return nil, fmt.Errorf("failed to get user progress: %w", err)
+		return nil, apperr.Errorf("failed to get user progress: %w", err)
 	}
 
 	return progress, nil
@@ -103,49 +111,79 @@ func (r *UserProgressRepository) Update(progress *models.UserProgress) error {
 	)
 
 	if err != nil {
-		return fmt.Errorf("failed to update user progress: %w", err)
+		return apperr.Errorf("failed to update user progress: %w", err)
 	}
 
 	return nil
 }
 
-// GetByUserID retrieves all progress records for a user
-func (r *UserProgressRepository) GetByUserID(userID int) ([]*models.UserProgress, error) {
-	query := `
-		SELECT id, user_id, item_id, status, starred, notes, started_at, completed_at, created_at, updated_at
-		FROM user_progress
-		WHERE user_id = $1
-		ORDER BY created_at DESC
-	`
+// GetByUserID retrieves a page of a user's progress records and the total matching the filter.
+// filter.SortBy and filter.SortOrder must already be validated against models.ProgressSortFields.
+func (r *UserProgressRepository) GetByUserID(userID int, filter *models.ProgressFilter) ([]*models.UserProgress, int, error) {
+	where := "WHERE user_id = $1"
+	args := []interface{}{userID}
+	if filter.Status != nil {
+		args = append(args, *filter.Status)
+		where += fmt.Sprintf(" AND status = $%d", len(args))
+	}
 
-	rows, err := r.db.Query(query, userID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get user progress: %w", err)
+	sortBy := "created_at"
+	if filter.SortBy != "" {
+		sortBy = filter.SortBy
+	}
+	sortOrder := "DESC"
+	if strings.EqualFold(filter.SortOrder, "asc") {
+		sortOrder = "ASC"
 	}
-	defer rows.Close()
 
-	var progressList []*models.UserProgress
-	for rows.Next() {
-		progress := &models.UserProgress{}
-		err := rows.Scan(
-			&progress.ID,
-			&progress.UserID,
-			&progress.ItemID,
-			&progress.Status,
-			&progress.Starred,
-			&progress.Notes,
-			&progress.StartedAt,
-			&progress.CompletedAt,
-			&progress.CreatedAt,
-			&progress.UpdatedAt,
-		)
+	countQuery := "SELECT COUNT(*) FROM user_progress " + where
+	query := fmt.Sprintf(`
+		SELECT id, user_id, item_id, status, starred, COALESCE(notes, ''), started_at, completed_at, created_at, updated_at
+		FROM user_progress
+		%s
+		ORDER BY %s %s NULLS LAST, id %s
+		LIMIT $%d OFFSET $%d`, where, sortBy, sortOrder, sortOrder, len(args)+1, len(args)+2)
+
+	var total int
+	progressList := []*models.UserProgress{}
+	err := withUserContext(r.db, userID, func(q dbtx) error {
+		if err := q.QueryRow(countQuery, args...).Scan(&total); err != nil {
+			return apperr.Errorf("failed to count user progress: %w", err)
+		}
+
+		rows, err := q.Query(query, append(args, filter.Limit, filter.Offset)...)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan user progress: %w", err)
+			return apperr.Errorf("failed to get user progress: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			progress := &models.UserProgress{}
+			err := rows.Scan(
+				&progress.ID,
+				&progress.UserID,
+				&progress.ItemID,
+				&progress.Status,
+				&progress.Starred,
+				&progress.Notes,
+				&progress.StartedAt,
+				&progress.CompletedAt,
+				&progress.CreatedAt,
+				&progress.UpdatedAt,
+			)
+			if err != nil {
+				return apperr.Errorf("failed to scan user progress: %w", err)
+			}
+			progressList = append(progressList, progress)
 		}
-		progressList = append(progressList, progress)
+
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, 0, err
 	}
 
-	return progressList, nil
+	return progressList, total, nil
 }
 
 // Delete deletes a user progress record
@@ -154,8 +192,87 @@ func (r *UserProgressRepository) Delete(id int) error {
 
 	_, err := r.db.Exec(query, id)
 	if err != nil {
-		return fmt.Errorf("failed to delete user progress: %w", err)
+		return apperr.Errorf("failed to delete user progress: %w", err)
 	}
 
 	return nil
 }
+
+// GetTrackedItemIDs returns which of the given items the user already has progress on
+func (r *UserProgressRepository) GetTrackedItemIDs(userID int, itemIDs []int) (map[int]bool, error) {
+	tracked := map[int]bool{}
+	if len(itemIDs) == 0 {
+		return tracked, nil
+	}
+
+	ids := make([]int64, len(itemIDs))
+	for i, id := range itemIDs {
+		ids[i] = int64(id)
+	}
+
+	err := withUserContext(r.db, userID, func(q dbtx) error {
+		rows, err := q.Query("SELECT item_id FROM user_progress WHERE user_id = $1 AND item_id = ANY($2)", userID, pq.Array(ids))
+		if err != nil {
+			return apperr.Errorf("failed to get tracked items: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var itemID int
+			if err := rows.Scan(&itemID); err != nil {
+				return apperr.Errorf("failed to scan tracked item: %w", err)
+			}
+			tracked[itemID] = true
+		}
+
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tracked, nil
+}
+
+// CreateMissing creates progress records for the entries the user doesn't track yet, all in
+// one transaction, and returns the IDs of the items it created records for. Existing
+// progress is never overwritten.
+func (r *UserProgressRepository) CreateMissing(userID int, entries []models.ProgressImportEntry) (map[int]bool, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, apperr.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := setUserContext(tx, userID); err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO user_progress (user_id, item_id, status, starred, notes, started_at, completed_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $6, $6)
+		ON CONFLICT (user_id, item_id) DO NOTHING`
+
+	now := time.Now()
+	created := map[int]bool{}
+	for _, entry := range entries {
+		result, err := tx.Exec(query, userID, entry.ItemID, entry.Status, entry.Starred, entry.Notes, now, entry.CompletedAt)
+		if err != nil {
+			return nil, apperr.Errorf("failed to import progress for item %d: %w", entry.ItemID, err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return nil, apperr.Errorf("failed to get rows affected: %w", err)
+		}
+		if rowsAffected > 0 {
+			created[entry.ItemID] = true
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, apperr.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return created, nil
+}
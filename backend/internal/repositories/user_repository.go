@@ -2,8 +2,8 @@ package repositories
 
 import (
 	"database/sql"
-	"fmt"
 	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
 	"time"
 )
 
@@ -58,7 +58,7 @@ func (r *UserRepository) Create(user *models.User) error {
 	).Scan(&user.ID, &user.CreatedAt, &user.UpdatedAt)
 
 	if err != nil {
-		return fmt.Errorf("failed to create user: %w", err)
+		return apperr.Errorf("failed to create user: %w", err)
 	}
 
 	return nil
@@ -101,9 +101,9 @@ func (r *UserRepository) GetByID(id int) (*models.User, error) {
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("user not found")
+			return nil, apperr.NotFound("user not found")
 		}
-		return nil, fmt.Errorf("failed to get user: %w", err)
+		return nil, apperr.Errorf("failed to get user: %w", err)
 	}
 
 	return user, nil
@@ -146,9 +146,9 @@ func (r *UserRepository) GetByEmail(email string) (*models.User, error) {
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("user not found")
+			return nil, apperr.NotFound("user not found")
 		}
-		return nil, fmt.Errorf("failed to get user: %w", err)
+		return nil, apperr.Errorf("failed to get user: %w", err)
 	}
 
 	return user, nil
@@ -191,9 +191,9 @@ func (r *UserRepository) GetByProviderID(provider models.AuthProvider, providerI
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("user not found")
+			return nil, apperr.NotFound("user not found")
 		}
-		return nil, fmt.Errorf("failed to get user: %w", err)
+		return nil, apperr.Errorf("failed to get user: %w", err)
 	}
 
 	return user, nil
@@ -219,29 +219,67 @@ func (r *UserRepository) Update(user *models.User) error {
 	).Scan(&user.UpdatedAt)
 
 	if err != nil {
-		return fmt.Errorf("failed to update user: %w", err)
+		return apperr.Errorf("failed to update user: %w", err)
 	}
 
 	return nil
 }
 
-// UpdateLastLogin updates the last login time for a user
+// UpdateRole assigns a user a role
+func (r *UserRepository) UpdateRole(userID int, role models.Role) error {
+	result, err := r.db.Exec(
+		"UPDATE users SET role = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $1 AND is_active = true",
+		userID, role,
+	)
+	if err != nil {
+		return apperr.Errorf("failed to update user role: %w", err)
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return apperr.NotFound("user not found")
+	}
+
+	return nil
+}
+
+// UpdateLastLogin updates the last login time for a user and brings them back out of archival
 func (r *UserRepository) UpdateLastLogin(userID int) error {
 	query := `
 		UPDATE users
-		SET last_login_at = $2, updated_at = $2
+		SET last_login_at = $2, updated_at = $2, archived_at = NULL
 		WHERE id = $1 AND is_active = true
 	`
 
 	now := time.Now()
 	_, err := r.db.Exec(query, userID, now)
 	if err != nil {
-		return fmt.Errorf("failed to update last login: %w", err)
+		return apperr.Errorf("failed to update last login: %w", err)
 	}
 
 	return nil
 }
 
+// MarkFirstCompletion records when a user first completed an item. It reports whether this was
+// their first completion and, if so, when they signed up.
+func (r *UserRepository) MarkFirstCompletion(userID int, completedAt time.Time) (time.Time, bool, error) {
+	query := `
+		UPDATE users
+		SET first_completed_at = $2
+		WHERE id = $1 AND first_completed_at IS NULL
+		RETURNING created_at`
+
+	var createdAt time.Time
+	err := r.db.QueryRow(query, userID, completedAt).Scan(&createdAt)
+	if err == sql.ErrNoRows {
+		return time.Time{}, false, nil
+	}
+	if err != nil {
+		return time.Time{}, false, apperr.Errorf("failed to mark first completion: %w", err)
+	}
+
+	return createdAt, true, nil
+}
+
 // EmailExists checks if an email already exists
 func (r *UserRepository) EmailExists(email string) (bool, error) {
 	query := `SELECT COUNT(*) FROM users WHERE email = $1 AND is_active = true`
@@ -249,7 +287,7 @@ func (r *UserRepository) EmailExists(email string) (bool, error) {
 	var count int
 	err := r.db.QueryRow(query, email).Scan(&count)
 	if err != nil {
-		return false, fmt.Errorf("failed to check email existence: %w", err)
+		return false, apperr.Errorf("failed to check email existence: %w", err)
 	}
 
 	return count > 0, nil
@@ -262,7 +300,7 @@ func (r *UserRepository) ProviderUserExists(provider models.AuthProvider, provid
 	var count int
 	err := r.db.QueryRow(query, provider, providerID).Scan(&count)
 	if err != nil {
-		return false, fmt.Errorf("failed to check provider user existence: %w", err)
+		return false, apperr.Errorf("failed to check provider user existence: %w", err)
 	}
 
 	return count > 0, nil
@@ -278,22 +316,23 @@ func (r *UserRepository) Deactivate(userID int) error {
 
 	_, err := r.db.Exec(query, userID, time.Now())
 	if err != nil {
-		return fmt.Errorf("failed to deactivate user: %w", err)
+		return apperr.Errorf("failed to deactivate user: %w", err)
 	}
 
 	return nil
 }
 
-// CreateRefreshToken creates a new refresh token
-func (r *UserRepository) CreateRefreshToken(userID int, token string, expiresAt time.Time) error {
+// CreateRefreshToken creates a new refresh token, recording where the login came from.
+// alertToken, when set, identifies the token in a new-login alert.
+func (r *UserRepository) CreateRefreshToken(userID int, token string, expiresAt time.Time, meta models.SessionMetadata, alertToken string) error {
 	query := `
-		INSERT INTO refresh_tokens (user_id, token, expires_at, created_at, is_revoked)
-		VALUES ($1, $2, $3, $4, false)
+		INSERT INTO refresh_tokens (user_id, token, expires_at, created_at, is_revoked, ip_address, user_agent, alert_token)
+		VALUES ($1, $2, $3, $4, false, $5, $6, NULLIF($7, ''))
 	`
 
-	_, err := r.db.Exec(query, userID, token, expiresAt, time.Now())
+	_, err := r.db.Exec(query, userID, token, expiresAt, time.Now(), meta.IPAddress, meta.UserAgent, alertToken)
 	if err != nil {
-		return fmt.Errorf("failed to create refresh token: %w", err)
+		return apperr.Errorf("failed to create refresh token: %w", err)
 	}
 
 	return nil
@@ -319,14 +358,166 @@ func (r *UserRepository) GetRefreshToken(token string) (*models.RefreshToken, er
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("refresh token not found")
+			return nil, apperr.NotFound("refresh token not found")
 		}
-		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+		return nil, apperr.Errorf("failed to get refresh token: %w", err)
 	}
 
 	return refreshToken, nil
 }
 
+// GetLoginHistory reports whether a user has logged in before, and whether from this device or IP
+func (r *UserRepository) GetLoginHistory(userID int, meta models.SessionMetadata) (*models.LoginHistory, error) {
+	query := `
+		SELECT COUNT(*) > 0,
+			COALESCE(BOOL_OR(user_agent = $2), false),
+			COALESCE(BOOL_OR(ip_address = $3), false)
+		FROM refresh_tokens
+		WHERE user_id = $1
+	`
+
+	history := &models.LoginHistory{}
+	err := r.db.QueryRow(query, userID, meta.UserAgent, meta.IPAddress).Scan(&history.HasSessions, &history.KnownDevice, &history.KnownIP)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get login history: %w", err)
+	}
+
+	return history, nil
+}
+
+// GetUserIDByAlertToken returns the user a new-login alert was sent to
+func (r *UserRepository) GetUserIDByAlertToken(alertToken string) (int, error) {
+	var userID int
+	err := r.db.QueryRow("SELECT user_id FROM refresh_tokens WHERE alert_token = $1", alertToken).Scan(&userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, apperr.NotFound("login alert not found")
+		}
+		return 0, apperr.Errorf("failed to get login alert: %w", err)
+	}
+
+	return userID, nil
+}
+
+// RevokeSessions revokes every refresh token and API key of a user and invalidates access
+// tokens issued before now. A non-empty resetToken also locks password logins until the password is reset.
+func (r *UserRepository) RevokeSessions(userID int, now time.Time, resetToken string, resetExpiresAt time.Time) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return apperr.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := revokeCredentials(tx, userID, now); err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE users
+		SET sessions_revoked_at = $2,
+			password_reset_required = password_reset_required OR $3 <> '',
+			password_reset_token = COALESCE(NULLIF($3, ''), password_reset_token),
+			password_reset_expires_at = CASE WHEN $3 <> '' THEN $4 ELSE password_reset_expires_at END,
+			updated_at = $2
+		WHERE id = $1
+	`
+
+	_, err = tx.Exec(query, userID, now, resetToken, resetExpiresAt)
+	if err != nil {
+		return apperr.Errorf("failed to revoke user sessions: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return apperr.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// revokeCredentials revokes every refresh token and API key of a user, so nothing issued
+// before their sessions were revoked keeps working
+func revokeCredentials(q dbtx, userID int, now time.Time) error {
+	_, err := q.Exec("UPDATE refresh_tokens SET is_revoked = true, alert_token = NULL WHERE user_id = $1", userID)
+	if err != nil {
+		return apperr.Errorf("failed to revoke user refresh tokens: %w", err)
+	}
+
+	_, err = q.Exec("UPDATE user_api_keys SET revoked_at = $2 WHERE user_id = $1 AND revoked_at IS NULL", userID, now)
+	if err != nil {
+		return apperr.Errorf("failed to revoke user API keys: %w", err)
+	}
+
+	return nil
+}
+
+// GetSessionState returns a user's current role and when their sessions were last revoked (nil
+// if never), read together so an access check sees both as of the same moment
+func (r *UserRepository) GetSessionState(userID int) (models.Role, *time.Time, error) {
+	var role models.Role
+	var revokedAt *time.Time
+	err := r.db.QueryRow("SELECT role, sessions_revoked_at FROM users WHERE id = $1", userID).Scan(&role, &revokedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil, apperr.NotFound("user not found")
+		}
+		return "", nil, apperr.Errorf("failed to get session revocation: %w", err)
+	}
+
+	return role, revokedAt, nil
+}
+
+// IsPasswordResetRequired reports whether a user must reset their password before logging in
+func (r *UserRepository) IsPasswordResetRequired(userID int) (bool, error) {
+	var required bool
+	err := r.db.QueryRow("SELECT password_reset_required FROM users WHERE id = $1", userID).Scan(&required)
+	if err != nil {
+		return false, apperr.Errorf("failed to check password reset: %w", err)
+	}
+
+	return required, nil
+}
+
+// ResetPassword sets a new password hash for the user holding an unexpired reset token, clearing
+// the reset and invalidating every existing session and API key. It returns the user's ID.
+func (r *UserRepository) ResetPassword(resetToken, passwordHash string, now time.Time) (int, error) {
+	query := `
+		UPDATE users
+		SET password_hash = $2,
+			password_reset_required = false,
+			password_reset_token = NULL,
+			password_reset_expires_at = NULL,
+			sessions_revoked_at = $3,
+			updated_at = $3
+		WHERE password_reset_token = $1 AND password_reset_expires_at > $3
+		RETURNING id
+	`
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, apperr.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var userID int
+	err = tx.QueryRow(query, resetToken, passwordHash, now).Scan(&userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, apperr.Validation("invalid or expired reset token")
+		}
+		return 0, apperr.Errorf("failed to reset password: %w", err)
+	}
+
+	if err := revokeCredentials(tx, userID, now); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, apperr.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return userID, nil
+}
+
 // RevokeRefreshToken revokes a refresh token
 func (r *UserRepository) RevokeRefreshToken(token string) error {
 	query := `
@@ -337,7 +528,7 @@ func (r *UserRepository) RevokeRefreshToken(token string) error {
 
 	_, err := r.db.Exec(query, token)
 	if err != nil {
-		return fmt.Errorf("failed to revoke refresh token: %w", err)
+		return apperr.Errorf("failed to revoke refresh token: %w", err)
 	}
 
 	return nil
@@ -353,23 +544,42 @@ func (r *UserRepository) RevokeAllUserRefreshTokens(userID int) error {
 
 	_, err := r.db.Exec(query, userID)
 	if err != nil {
-		return fmt.Errorf("failed to revoke user refresh tokens: %w", err)
+		return apperr.Errorf("failed to revoke user refresh tokens: %w", err)
 	}
 
 	return nil
 }
 
-// CleanupExpiredRefreshTokens removes expired refresh tokens
-func (r *UserRepository) CleanupExpiredRefreshTokens() error {
+// CleanupExpiredRefreshTokens removes expired and revoked refresh tokens and returns how many
+// of each it removed
+func (r *UserRepository) CleanupExpiredRefreshTokens(now time.Time) (expired, revoked int64, err error) {
 	query := `
 		DELETE FROM refresh_tokens
 		WHERE expires_at < $1 OR is_revoked = true
+		RETURNING is_revoked
 	`
 
-	_, err := r.db.Exec(query, time.Now())
+	rows, err := r.db.Query(query, now)
 	if err != nil {
-		return fmt.Errorf("failed to cleanup expired refresh tokens: %w", err)
+		return 0, 0, apperr.Errorf("failed to cleanup expired refresh tokens: %w", err)
 	}
+	defer rows.Close()
 
-	return nil
+	for rows.Next() {
+		var wasRevoked bool
+		if err := rows.Scan(&wasRevoked); err != nil {
+			return 0, 0, apperr.Errorf("failed to scan removed refresh token: %w", err)
+		}
+		if wasRevoked {
+			revoked++
+		} else {
+			expired++
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return 0, 0, apperr.Errorf("failed to cleanup expired refresh tokens: %w", err)
+	}
+
+	return expired, revoked, nil
 }
@@ -0,0 +1,409 @@
+package repositories
+
+import (
+	"database/sql"
+	"time"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/lib/pq"
+)
+
+// webhookClaimLease keeps a claimed delivery from being picked up by another worker while it's being sent
+const webhookClaimLease = 5 * time.Minute
+
+// WebhookRepository handles database operations for user webhooks and their deliveries
+type WebhookRepository struct {
+	db *sql.DB
+}
+
+// NewWebhookRepository creates a new WebhookRepository
+func NewWebhookRepository(db *sql.DB) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+// Create registers a webhook
+func (r *WebhookRepository) Create(webhook *models.Webhook) error {
+	query := `
+		INSERT INTO user_webhooks (user_id, url, secret, events, format, active)
+		VALUES ($1, $2, $3, $4, $5, true)
+		RETURNING id, active, created_at`
+
+	err := r.db.QueryRow(query, webhook.UserID, webhook.URL, webhook.Secret, pq.Array(eventStrings(webhook.Events)), webhook.Format).
+		Scan(&webhook.ID, &webhook.Active, &webhook.CreatedAt)
+	if err != nil {
+		return apperr.Errorf("failed to create webhook: %w", err)
+	}
+
+	return nil
+}
+
+// ReplaceIntegration connects a chat integration, replacing the user's existing integration for
+// the same platform (and its delivery log)
+func (r *WebhookRepository) ReplaceIntegration(webhook *models.Webhook) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return apperr.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM user_webhooks WHERE user_id = $1 AND format = $2", webhook.UserID, webhook.Format); err != nil {
+		return apperr.Errorf("failed to remove existing integration: %w", err)
+	}
+
+	query := `
+		INSERT INTO user_webhooks (user_id, url, secret, events, format, active)
+		VALUES ($1, $2, $3, $4, $5, true)
+		RETURNING id, active, created_at`
+
+	err = tx.QueryRow(query, webhook.UserID, webhook.URL, webhook.Secret, pq.Array(eventStrings(webhook.Events)), webhook.Format).
+		Scan(&webhook.ID, &webhook.Active, &webhook.CreatedAt)
+	if err != nil {
+		return apperr.Errorf("failed to create integration: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return apperr.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetIntegration returns the user's chat integration for a platform, including its URL
+func (r *WebhookRepository) GetIntegration(userID int, format models.WebhookFormat) (*models.Webhook, error) {
+	query := `
+		SELECT id, user_id, url, events, format, active, created_at
+		FROM user_webhooks
+		WHERE user_id = $1 AND format = $2`
+
+	webhook := &models.Webhook{}
+	var events pq.StringArray
+	err := r.db.QueryRow(query, userID, format).
+		Scan(&webhook.ID, &webhook.UserID, &webhook.URL, &events, &webhook.Format, &webhook.Active, &webhook.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, apperr.NotFound("integration not connected")
+	}
+	if err != nil {
+		return nil, apperr.Errorf("failed to get integration: %w", err)
+	}
+	webhook.Events = webhookEvents(events)
+
+	return webhook, nil
+}
+
+// DeleteIntegration disconnects the user's chat integration for a platform
+func (r *WebhookRepository) DeleteIntegration(userID int, format models.WebhookFormat) error {
+	result, err := r.db.Exec("DELETE FROM user_webhooks WHERE user_id = $1 AND format = $2", userID, format)
+	if err != nil {
+		return apperr.Errorf("failed to delete integration: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperr.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperr.NotFound("integration not connected")
+	}
+
+	return nil
+}
+
+// CountForUser counts the webhooks a user has registered
+func (r *WebhookRepository) CountForUser(userID int) (int, error) {
+	var count int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM user_webhooks WHERE user_id = $1", userID).Scan(&count); err != nil {
+		return 0, apperr.Errorf("failed to count webhooks: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetForUser returns the user's webhooks without their secrets
+func (r *WebhookRepository) GetForUser(userID int) ([]*models.Webhook, error) {
+	query := `
+		SELECT id, user_id, url, events, format, active, created_at
+		FROM user_webhooks
+		WHERE user_id = $1
+		ORDER BY created_at DESC`
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	webhooks := []*models.Webhook{}
+	for rows.Next() {
+		webhook := &models.Webhook{}
+		var events pq.StringArray
+		if err := rows.Scan(&webhook.ID, &webhook.UserID, &webhook.URL, &events, &webhook.Format, &webhook.Active, &webhook.CreatedAt); err != nil {
+			return nil, apperr.Errorf("failed to scan webhook: %w", err)
+		}
+		webhook.Events = webhookEvents(events)
+		webhooks = append(webhooks, webhook)
+	}
+
+	return webhooks, rows.Err()
+}
+
+// GetActiveForEvent returns the user's active webhooks subscribed to an event, with secrets for signing
+func (r *WebhookRepository) GetActiveForEvent(userID int, event models.WebhookEvent) ([]*models.Webhook, error) {
+	query := `
+		SELECT id, user_id, url, secret, events, format, active, created_at
+		FROM user_webhooks
+		WHERE user_id = $1 AND active = true AND $2 = ANY(events)`
+
+	rows, err := r.db.Query(query, userID, string(event))
+	if err != nil {
+		return nil, apperr.Errorf("failed to get webhooks for event: %w", err)
+	}
+	defer rows.Close()
+
+	var webhooks []*models.Webhook
+	for rows.Next() {
+		webhook := &models.Webhook{}
+		var events pq.StringArray
+		if err := rows.Scan(&webhook.ID, &webhook.UserID, &webhook.URL, &webhook.Secret, &events, &webhook.Format, &webhook.Active, &webhook.CreatedAt); err != nil {
+			return nil, apperr.Errorf("failed to scan webhook: %w", err)
+		}
+		webhook.Events = webhookEvents(events)
+		webhooks = append(webhooks, webhook)
+	}
+
+	return webhooks, rows.Err()
+}
+
+// Delete removes one of the user's webhooks along with its delivery log
+func (r *WebhookRepository) Delete(userID, webhookID int) error {
+	result, err := r.db.Exec("DELETE FROM user_webhooks WHERE id = $1 AND user_id = $2", webhookID, userID)
+	if err != nil {
+		return apperr.Errorf("failed to delete webhook: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperr.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperr.NotFound("webhook not found")
+	}
+
+	return nil
+}
+
+// EnqueueDelivery queues an event for delivery to a webhook, due immediately
+func (r *WebhookRepository) EnqueueDelivery(webhookID int, event models.WebhookEvent, payload []byte) error {
+	query := `
+		INSERT INTO webhook_deliveries (webhook_id, event, payload, next_attempt_at)
+		VALUES ($1, $2, $3, CURRENT_TIMESTAMP)`
+
+	if _, err := r.db.Exec(query, webhookID, event, payload); err != nil {
+		return apperr.Errorf("failed to enqueue webhook delivery: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimDueDeliveries locks up to limit pending deliveries whose next attempt is due and pushes
+// their next attempt out by a lease, so concurrent workers never send the same delivery twice
+func (r *WebhookRepository) ClaimDueDeliveries(limit int) ([]*models.WebhookDelivery, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, apperr.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		SELECT d.id, d.webhook_id, d.event, d.payload, d.status, d.attempts, d.created_at, w.url, w.secret, w.format
+		FROM webhook_deliveries d
+		INNER JOIN user_webhooks w ON w.id = d.webhook_id
+		WHERE d.status = 'pending' AND d.next_attempt_at <= $1 AND w.active = true
+		ORDER BY d.next_attempt_at
+		LIMIT $2
+		FOR UPDATE OF d SKIP LOCKED`
+
+	now := time.Now()
+	rows, err := tx.Query(query, now, limit)
+	if err != nil {
+		return nil, apperr.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+
+	var deliveries []*models.WebhookDelivery
+	var ids []int64
+	for rows.Next() {
+		d := &models.WebhookDelivery{}
+		var payload []byte
+		if err := rows.Scan(&d.ID, &d.WebhookID, &d.Event, &payload, &d.Status, &d.Attempts, &d.CreatedAt, &d.URL, &d.Secret, &d.Format); err != nil {
+			rows.Close()
+			return nil, apperr.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		d.Payload = payload
+		deliveries = append(deliveries, d)
+		ids = append(ids, int64(d.ID))
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, apperr.Errorf("error iterating webhook deliveries: %w", err)
+	}
+
+	if len(ids) > 0 {
+		_, err := tx.Exec("UPDATE webhook_deliveries SET next_attempt_at = $1 WHERE id = ANY($2)", now.Add(webhookClaimLease), pq.Array(ids))
+		if err != nil {
+			return nil, apperr.Errorf("failed to lease webhook deliveries: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, apperr.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// ClaimDueDigests marks up to limit active webhooks subscribed to the weekly digest whose last
+// digest went out before the cutoff as sent now, and returns them. Claiming first means a failure
+// to queue a digest skips that week rather than sending it twice.
+func (r *WebhookRepository) ClaimDueDigests(cutoff, now time.Time, limit int) ([]*models.Webhook, error) {
+	query := `
+		UPDATE user_webhooks SET last_digest_at = $1
+		WHERE id IN (
+			SELECT id FROM user_webhooks
+			WHERE active = true AND $2 = ANY(events) AND (last_digest_at IS NULL OR last_digest_at <= $3)
+			ORDER BY last_digest_at NULLS FIRST
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, user_id, format`
+
+	rows, err := r.db.Query(query, now, string(models.WebhookEventWeeklyDigest), cutoff, limit)
+	if err != nil {
+		return nil, apperr.Errorf("failed to claim weekly digests: %w", err)
+	}
+	defer rows.Close()
+
+	var webhooks []*models.Webhook
+	for rows.Next() {
+		webhook := &models.Webhook{}
+		if err := rows.Scan(&webhook.ID, &webhook.UserID, &webhook.Format); err != nil {
+			return nil, apperr.Errorf("failed to scan webhook: %w", err)
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	return webhooks, rows.Err()
+}
+
+// GetWeeklyDigest summarizes a user's progress since the start of the week
+// (excluding miscellaneous category)
+func (r *WebhookRepository) GetWeeklyDigest(userID int, weekStart time.Time) (*models.WeeklyDigestData, error) {
+	query := `
+		SELECT
+			(SELECT COUNT(*) FROM user_progress up
+			 INNER JOIN items i ON i.id = up.item_id
+			 WHERE up.user_id = $1 AND up.status = 'done' AND up.completed_at >= $2 AND i.category != $3),
+			COALESCE((SELECT current_streak FROM user_stats WHERE user_id = $1), 0),
+			COALESCE((SELECT longest_streak FROM user_stats WHERE user_id = $1), 0)`
+
+	digest := &models.WeeklyDigestData{WeekStart: weekStart}
+	err := r.db.QueryRow(query, userID, weekStart, models.CategoryMiscellaneous).
+		Scan(&digest.Completed, &digest.CurrentStreak, &digest.LongestStreak)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get weekly digest: %w", err)
+	}
+
+	return digest, nil
+}
+
+// GetUserLocale returns the locale a user picked for notifications, or "" if they haven't
+func (r *WebhookRepository) GetUserLocale(userID int) (string, error) {
+	var locale string
+	err := r.db.QueryRow(`SELECT locale FROM user_notification_preferences WHERE user_id = $1`, userID).Scan(&locale)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", apperr.Errorf("failed to get user locale: %w", err)
+	}
+
+	return locale, nil
+}
+
+// RecordAttempt stores the outcome of a delivery attempt. A nil nextAttemptAt on a failed
+// attempt means retries are exhausted.
+func (r *WebhookRepository) RecordAttempt(deliveryID int, status models.WebhookDeliveryStatus, statusCode int, lastError string, nextAttemptAt *time.Time) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = attempts + 1, last_status_code = NULLIF($3, 0), last_error = $4,
+			next_attempt_at = $5,
+			delivered_at = CASE WHEN $2 = 'succeeded' THEN CURRENT_TIMESTAMP ELSE delivered_at END
+		WHERE id = $1`
+
+	if _, err := r.db.Exec(query, deliveryID, status, statusCode, lastError, nextAttemptAt); err != nil {
+		return apperr.Errorf("failed to record webhook attempt: %w", err)
+	}
+
+	return nil
+}
+
+// GetDeliveries returns the most recent deliveries for one of the user's webhooks
+func (r *WebhookRepository) GetDeliveries(userID, webhookID, limit int) ([]*models.WebhookDelivery, error) {
+	var exists bool
+	err := r.db.QueryRow("SELECT EXISTS(SELECT 1 FROM user_webhooks WHERE id = $1 AND user_id = $2)", webhookID, userID).Scan(&exists)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get webhook: %w", err)
+	}
+	if !exists {
+		return nil, apperr.NotFound("webhook not found")
+	}
+
+	query := `
+		SELECT id, webhook_id, event, payload, status, attempts, next_attempt_at, last_status_code,
+			   last_error, created_at, delivered_at
+		FROM webhook_deliveries
+		WHERE webhook_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2`
+
+	rows, err := r.db.Query(query, webhookID, limit)
+	if err != nil {
+		return nil, apperr.Errorf("failed to get webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []*models.WebhookDelivery{}
+	for rows.Next() {
+		d := &models.WebhookDelivery{}
+		var payload []byte
+		err := rows.Scan(&d.ID, &d.WebhookID, &d.Event, &payload, &d.Status, &d.Attempts, &d.NextAttemptAt,
+			&d.LastStatusCode, &d.LastError, &d.CreatedAt, &d.DeliveredAt)
+		if err != nil {
+			return nil, apperr.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		d.Payload = payload
+		deliveries = append(deliveries, d)
+	}
+
+	return deliveries, rows.Err()
+}
+
+// eventStrings converts webhook events for storage in a TEXT[] column
+func eventStrings(events []models.WebhookEvent) []string {
+	values := make([]string, len(events))
+	for i, event := range events {
+		values[i] = string(event)
+	}
+	return values
+}
+
+// webhookEvents converts a TEXT[] column back into webhook events
+func webhookEvents(values []string) []models.WebhookEvent {
+	events := make([]models.WebhookEvent, len(values))
+	for i, value := range values {
+		events[i] = models.WebhookEvent(value)
+	}
+	return events
+}
@@ -0,0 +1,90 @@
+// Package requestuser loads the authenticated user of a request at most once, and only if
+// something asks for it. The auth middleware attaches a Loader to each request; handlers and
+// services read the user from the request context with Get instead of looking it up themselves.
+package requestuser
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"interview-prep-app/internal/models"
+)
+
+// ContextKey is the gin context key the Loader is stored under
+const ContextKey = "requestUser"
+
+// ErrNoLoader is returned by Get when the request has no user loader attached
+var ErrNoLoader = errors.New("request user loader not configured")
+
+// User is the user a request is made by, with the settings request handling depends on
+type User struct {
+	*models.User
+	Settings *models.NotificationPreferences
+	Location *time.Location // the user's timezone, UTC when unset or unknown
+}
+
+// LoadFunc loads a user and their settings
+type LoadFunc func(userID int) (*User, error)
+
+// New builds a request user from a user and their settings, resolving their timezone
+func New(user *models.User, settings *models.NotificationPreferences) *User {
+	location := time.UTC
+	if settings != nil {
+		if loc, err := time.LoadLocation(settings.Timezone); err == nil && settings.Timezone != "" {
+			location = loc
+		}
+	}
+
+	return &User{User: user, Settings: settings, Location: location}
+}
+
+// Loader loads one request's user on first use and remembers the result
+type Loader struct {
+	userID int
+	load   LoadFunc
+	once   sync.Once
+	user   *User
+	err    error
+}
+
+// NewLoader creates a loader for userID that hasn't loaded anything yet
+func NewLoader(userID int, load LoadFunc) *Loader {
+	return &Loader{userID: userID, load: load}
+}
+
+// UserID returns the ID of the user the loader loads
+func (l *Loader) UserID() int {
+	return l.userID
+}
+
+// User loads the user, or returns the result of the earlier load
+func (l *Loader) User() (*User, error) {
+	l.once.Do(func() {
+		l.user, l.err = l.load(l.userID)
+	})
+	return l.user, l.err
+}
+
+type loaderKey struct{}
+
+// NewContext returns a copy of ctx carrying l, for code given the request's context.Context
+func NewContext(ctx context.Context, l *Loader) context.Context {
+	return context.WithValue(ctx, loaderKey{}, l)
+}
+
+// Get returns the user of the request ctx belongs to. ctx may be the *gin.Context or the
+// request's context.Context. It returns ErrNoLoader when user loading isn't enabled, so
+// callers can fall back to looking the user up themselves.
+func Get(ctx context.Context) (*User, error) {
+	l, ok := ctx.Value(ContextKey).(*Loader)
+	if !ok {
+		l, ok = ctx.Value(loaderKey{}).(*Loader)
+	}
+	if !ok {
+		return nil, ErrNoLoader
+	}
+
+	return l.User()
+}
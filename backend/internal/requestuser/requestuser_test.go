@@ -0,0 +1,60 @@
+package requestuser
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"interview-prep-app/internal/models"
+)
+
+func TestLoaderLoadsOnce(t *testing.T) {
+	calls := 0
+	loader := NewLoader(7, func(userID int) (*User, error) {
+		calls++
+		return New(&models.User{ID: userID}, nil), nil
+	})
+	ctx := NewContext(context.Background(), loader)
+
+	if calls != 0 {
+		t.Fatalf("expected no load before the user is read, got %d", calls)
+	}
+
+	for i := 0; i < 3; i++ {
+		user, err := Get(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if user.ID != 7 {
+			t.Fatalf("expected user 7, got %d", user.ID)
+		}
+	}
+
+	if calls != 1 {
+		t.Fatalf("expected one load, got %d", calls)
+	}
+}
+
+func TestGetWithoutLoader(t *testing.T) {
+	if _, err := Get(context.Background()); err != ErrNoLoader {
+		t.Fatalf("expected ErrNoLoader, got %v", err)
+	}
+}
+
+func TestNewResolvesTimezone(t *testing.T) {
+	user := New(&models.User{}, &models.NotificationPreferences{Timezone: "Asia/Kolkata"})
+	if user.Location.String() != "Asia/Kolkata" {
+		t.Fatalf("expected Asia/Kolkata, got %s", user.Location)
+	}
+
+	for _, timezone := range []string{"", "Not/AZone"} {
+		user := New(&models.User{}, &models.NotificationPreferences{Timezone: timezone})
+		if user.Location != time.UTC {
+			t.Fatalf("expected UTC for %q, got %s", timezone, user.Location)
+		}
+	}
+
+	if user := New(&models.User{}, nil); user.Location != time.UTC {
+		t.Fatalf("expected UTC without settings, got %s", user.Location)
+	}
+}
@@ -0,0 +1,176 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"interview-prep-app/internal/config"
+	"interview-prep-app/pkg/apperr"
+)
+
+// maxResponseBytes caps how much of a runner response is read, so runaway output can't exhaust memory
+const maxResponseBytes = 1 << 20
+
+// Execution is a program to run once against one input
+type Execution struct {
+	Language string
+	Code     string
+	Stdin    string
+}
+
+// Result is the outcome of an execution
+type Result struct {
+	Stdout       string
+	Stderr       string
+	ExitCode     int
+	CompileError string // compiler output when the program failed to build
+	TimedOut     bool
+}
+
+// Runner executes untrusted code in an isolated sandbox
+type Runner interface {
+	Run(ctx context.Context, exec Execution) (*Result, error)
+}
+
+// New returns a runner for the configured code execution service, or nil when none is configured
+func New(cfg *config.Config) Runner {
+	if cfg.CodeRunnerURL == "" {
+		return nil
+	}
+
+	return &PistonRunner{
+		baseURL:    strings.TrimRight(cfg.CodeRunnerURL, "/"),
+		apiKey:     cfg.CodeRunnerAPIKey,
+		runTimeout: time.Duration(cfg.CodeRunnerTimeoutSeconds) * time.Second,
+		client:     &http.Client{Timeout: time.Duration(cfg.CodeRunnerTimeoutSeconds)*time.Second + 10*time.Second},
+	}
+}
+
+// pistonLanguages maps this app's language names to the runtimes the execution service knows
+var pistonLanguages = map[string]string{
+	"python":     "python",
+	"javascript": "javascript",
+	"typescript": "typescript",
+	"go":         "go",
+	"java":       "java",
+	"cpp":        "c++",
+	"c":          "c",
+}
+
+// PistonRunner runs code through a Piston-compatible execution API (POST /api/v2/execute)
+type PistonRunner struct {
+	baseURL    string
+	apiKey     string
+	runTimeout time.Duration
+	client     *http.Client
+}
+
+type pistonFile struct {
+	Content string `json:"content"`
+}
+
+type pistonRequest struct {
+	Language   string       `json:"language"`
+	Version    string       `json:"version"`
+	Files      []pistonFile `json:"files"`
+	Stdin      string       `json:"stdin"`
+	RunTimeout int64        `json:"run_timeout,omitempty"`
+}
+
+type pistonStage struct {
+	Stdout string  `json:"stdout"`
+	Stderr string  `json:"stderr"`
+	Code   *int    `json:"code"`
+	Signal *string `json:"signal"`
+}
+
+type pistonResponse struct {
+	Run     pistonStage  `json:"run"`
+	Compile *pistonStage `json:"compile"`
+	Message string       `json:"message"`
+}
+
+// Run executes the program with the latest runtime the service has for its language
+func (r *PistonRunner) Run(ctx context.Context, exec Execution) (*Result, error) {
+	language, ok := pistonLanguages[exec.Language]
+	if !ok {
+		return nil, fmt.Errorf("unsupported language: %s", exec.Language)
+	}
+
+	body, err := json.Marshal(pistonRequest{
+		Language:   language,
+		Version:    "*",
+		Files:      []pistonFile{{Content: exec.Code}},
+		Stdin:      exec.Stdin,
+		RunTimeout: r.runTimeout.Milliseconds(),
+	})
+	if err != nil {
+		return nil, apperr.Errorf("failed to encode execution: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.baseURL+"/api/v2/execute", bytes.NewReader(body))
+	if err != nil {
+		return nil, apperr.Errorf("failed to create execution request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if r.apiKey != "" {
+		req.Header.Set("Authorization", r.apiKey)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, apperr.Errorf("failed to reach code runner: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var decoded pistonResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(&decoded); err != nil {
+		return nil, apperr.Errorf("failed to decode code runner response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, apperr.Errorf("failed to run code: runner returned %d: %s", resp.StatusCode, decoded.Message)
+	}
+
+	if decoded.Compile != nil && decoded.Compile.Code != nil && *decoded.Compile.Code != 0 {
+		output := decoded.Compile.Stderr + decoded.Compile.Stdout
+		if output == "" {
+			output = "compilation failed"
+		}
+		return &Result{CompileError: output, ExitCode: *decoded.Compile.Code}, nil
+	}
+
+	result := &Result{Stdout: decoded.Run.Stdout, Stderr: decoded.Run.Stderr}
+	if decoded.Run.Code != nil {
+		result.ExitCode = *decoded.Run.Code
+	}
+	// The service kills programs that overrun run_timeout with SIGKILL
+	if decoded.Run.Signal != nil && *decoded.Run.Signal == "SIGKILL" {
+		result.TimedOut = true
+	}
+
+	return result, nil
+}
+
+// SupportedLanguages lists the languages Run accepts
+func SupportedLanguages() []string {
+	languages := make([]string, 0, len(pistonLanguages))
+	for language := range pistonLanguages {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// IsSupportedLanguage checks if Run accepts a language
+func IsSupportedLanguage(language string) bool {
+	_, ok := pistonLanguages[language]
+	return ok
+}
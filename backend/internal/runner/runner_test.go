@@ -0,0 +1,81 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newTestRunner(t *testing.T, handler http.HandlerFunc) *PistonRunner {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return &PistonRunner{baseURL: server.URL, runTimeout: 3 * time.Second, client: server.Client()}
+}
+
+func TestPistonRunnerRun(t *testing.T) {
+	var got pistonRequest
+	r := newTestRunner(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/api/v2/execute" {
+			t.Errorf("path = %s, want /api/v2/execute", req.URL.Path)
+		}
+		if err := json.NewDecoder(req.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(`{"run":{"stdout":"3\n","stderr":"","code":0,"signal":null}}`))
+	})
+
+	result, err := r.Run(context.Background(), Execution{Language: "cpp", Code: "int main(){}", Stdin: "1 2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Language != "c++" || got.Stdin != "1 2" || len(got.Files) != 1 || got.RunTimeout != 3000 {
+		t.Errorf("unexpected request: %+v", got)
+	}
+	if result.Stdout != "3\n" || result.ExitCode != 0 || result.TimedOut || result.CompileError != "" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+func TestPistonRunnerCompileErrorAndTimeout(t *testing.T) {
+	r := newTestRunner(t, func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(`{"compile":{"stdout":"","stderr":"syntax error","code":1},"run":{}}`))
+	})
+	result, err := r.Run(context.Background(), Execution{Language: "go", Code: "package"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.CompileError != "syntax error" {
+		t.Errorf("CompileError = %q, want %q", result.CompileError, "syntax error")
+	}
+
+	r = newTestRunner(t, func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(`{"run":{"stdout":"","stderr":"","code":null,"signal":"SIGKILL"}}`))
+	})
+	result, err = r.Run(context.Background(), Execution{Language: "python", Code: "while True: pass"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.TimedOut {
+		t.Error("expected a killed run to be reported as timed out")
+	}
+}
+
+func TestPistonRunnerErrors(t *testing.T) {
+	r := newTestRunner(t, func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"message":"python-* runtime is unknown"}`))
+	})
+	if _, err := r.Run(context.Background(), Execution{Language: "python"}); err == nil {
+		t.Error("expected an error for a non-200 response")
+	}
+
+	if _, err := r.Run(context.Background(), Execution{Language: "cobol"}); err == nil {
+		t.Error("expected an error for an unsupported language")
+	}
+}
@@ -0,0 +1,118 @@
+// Package secrets encrypts small user secrets with per-user data keys. Each user's data key is
+// stored wrapped (encrypted) by a master key that only lives in the server's configuration,
+// so a database dump alone reveals nothing.
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"interview-prep-app/pkg/apperr"
+)
+
+// keySize is the AES-256 key length used for both the master key and data keys
+const keySize = 32
+
+// ErrDecrypt is returned when a ciphertext fails authentication (wrong key, wrong context or tampering)
+var ErrDecrypt = errors.New("failed to decrypt secret")
+
+// Keyring wraps per-user data keys with the master key and encrypts values with them
+type Keyring struct {
+	master cipher.AEAD
+}
+
+// NewKeyring creates a keyring from a base64-encoded 32-byte master key
+func NewKeyring(masterKey string) (*Keyring, error) {
+	key, err := base64.StdEncoding.DecodeString(masterKey)
+	if err != nil {
+		return nil, fmt.Errorf("master key must be base64: %w", err)
+	}
+	if len(key) != keySize {
+		return nil, fmt.Errorf("master key must be %d bytes, got %d", keySize, len(key))
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Keyring{master: aead}, nil
+}
+
+// NewDataKey generates a fresh data key and returns it wrapped for storage
+func (k *Keyring) NewDataKey() ([]byte, error) {
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, apperr.Errorf("failed to generate data key: %w", err)
+	}
+
+	return seal(k.master, key, []byte("data-key"))
+}
+
+// Encrypt seals plaintext with a wrapped data key. context is authenticated but not stored,
+// binding the ciphertext to where it belongs (e.g. its owner and item).
+func (k *Keyring) Encrypt(wrappedKey, plaintext []byte, context string) ([]byte, error) {
+	aead, err := k.unwrap(wrappedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return seal(aead, plaintext, []byte(context))
+}
+
+// Decrypt opens a ciphertext produced by Encrypt with the same wrapped key and context
+func (k *Keyring) Decrypt(wrappedKey, ciphertext []byte, context string) ([]byte, error) {
+	aead, err := k.unwrap(wrappedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return open(aead, ciphertext, []byte(context))
+}
+
+// unwrap decrypts a stored data key and returns a cipher for it
+func (k *Keyring) unwrap(wrappedKey []byte) (cipher.AEAD, error) {
+	key, err := open(k.master, wrappedKey, []byte("data-key"))
+	if err != nil {
+		return nil, err
+	}
+
+	return newAEAD(key)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, apperr.Errorf("failed to create cipher: %w", err)
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts with a random nonce, which is prepended to the ciphertext
+func seal(aead cipher.AEAD, plaintext, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, apperr.Errorf("failed to generate nonce: %w", err)
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func open(aead cipher.AEAD, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, ErrDecrypt
+	}
+
+	nonce, sealed := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, sealed, additionalData)
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+
+	return plaintext, nil
+}
@@ -0,0 +1,47 @@
+package secrets
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestKeyringRoundTrip(t *testing.T) {
+	keyring, err := NewKeyring(base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", keySize))))
+	if err != nil {
+		t.Fatalf("Failed to create keyring: %v", err)
+	}
+
+	dataKey, err := keyring.NewDataKey()
+	if err != nil {
+		t.Fatalf("Failed to create data key: %v", err)
+	}
+
+	ciphertext, err := keyring.Encrypt(dataKey, []byte("https://gist.github.com/private"), "attachment:1:2")
+	if err != nil {
+		t.Fatalf("Failed to encrypt: %v", err)
+	}
+
+	plaintext, err := keyring.Decrypt(dataKey, ciphertext, "attachment:1:2")
+	if err != nil {
+		t.Fatalf("Failed to decrypt: %v", err)
+	}
+	if string(plaintext) != "https://gist.github.com/private" {
+		t.Errorf("Expected original plaintext, got %q", plaintext)
+	}
+
+	if _, err := keyring.Decrypt(dataKey, ciphertext, "attachment:3:2"); err != ErrDecrypt {
+		t.Errorf("Expected decrypting under another owner's context to fail, got %v", err)
+	}
+
+	otherKey, _ := keyring.NewDataKey()
+	if _, err := keyring.Decrypt(otherKey, ciphertext, "attachment:1:2"); err != ErrDecrypt {
+		t.Errorf("Expected decrypting with another user's key to fail, got %v", err)
+	}
+}
+
+func TestNewKeyringRejectsShortKey(t *testing.T) {
+	if _, err := NewKeyring(base64.StdEncoding.EncodeToString([]byte("short"))); err == nil {
+		t.Error("Expected a short master key to be rejected")
+	}
+}
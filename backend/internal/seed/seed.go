@@ -0,0 +1,59 @@
+// Package seed loads the starter content bundled into the binary for self-hosted installs.
+package seed
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+	"interview-prep-app/pkg/apperr"
+)
+
+//go:embed data/*.json
+var data embed.FS
+
+// EngBlogs inserts the bundled engineering blogs when the eng_blogs table is empty,
+// so running it on every start never duplicates or overwrites curated content
+func EngBlogs(engBlogRepo *repositories.EngBlogRepository) error {
+	_, total, err := engBlogRepo.GetAll(1, 0, "", true)
+	if err != nil {
+		return err
+	}
+	if total > 0 {
+		return nil
+	}
+
+	raw, err := data.ReadFile("data/eng-blogs.json")
+	if err != nil {
+		return fmt.Errorf("failed to read seed data: %w", err)
+	}
+
+	var blogs []models.EngBlog
+	if err := json.Unmarshal(raw, &blogs); err != nil {
+		return fmt.Errorf("failed to parse seed data: %w", err)
+	}
+
+	for _, blog := range blogs {
+		blogDB, err := engBlogRepo.CreateBlog(blog.Name, blog.Link, blog.OrderIdx)
+		if err != nil {
+			return err
+		}
+
+		for _, article := range blog.PracticeProblems {
+			if _, err := engBlogRepo.CreateArticle(blogDB.ID, article.Title, article.ExternalLink, article.OrderIdx); err != nil {
+				if errors.Is(err, apperr.ErrConflict) {
+					log.Printf("Skipped duplicate article %q for blog %s", article.Title, blog.Name)
+					continue
+				}
+				return err
+			}
+		}
+	}
+
+	log.Printf("Seeded %d engineering blogs", len(blogs))
+	return nil
+}
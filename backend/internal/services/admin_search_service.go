@@ -0,0 +1,85 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+	"interview-prep-app/pkg/apperr"
+)
+
+// defaultAdminSearchResults is how many results of each type a search returns when no limit is given
+const defaultAdminSearchResults = 10
+
+// adminSearchPermissions is the permission needed to search each type, the same one that
+// guards the type's admin endpoints
+var adminSearchPermissions = map[models.AdminSearchType]models.Permission{
+	models.AdminSearchItem:     models.PermissionContentWrite,
+	models.AdminSearchUser:     models.PermissionUsersManage,
+	models.AdminSearchEngBlog:  models.PermissionContentWrite,
+	models.AdminSearchFeedback: models.PermissionFeedbackModerate,
+}
+
+// AdminSearchService searches several kinds of entities at once for support tasks
+type AdminSearchService struct {
+	searchRepo *repositories.AdminSearchRepository
+}
+
+// NewAdminSearchService creates a new admin search service
+func NewAdminSearchService(searchRepo *repositories.AdminSearchRepository) *AdminSearchService {
+	return &AdminSearchService{
+		searchRepo: searchRepo,
+	}
+}
+
+// Search finds entities of the requested types, or of every type when none are, matching the
+// query. Types the admin lacks the permission for (per allowed) are skipped; when that leaves
+// none, the search is forbidden. limit applies to each type.
+func (s *AdminSearchService) Search(query string, types []models.AdminSearchType, limit int, allowed func(models.Permission) bool) (*models.AdminSearchResponse, error) {
+	query = strings.TrimSpace(query)
+	if _, err := strconv.Atoi(strings.TrimPrefix(query, "#")); err != nil && utf8.RuneCountInString(query) < models.MinAdminSearchLength {
+		return nil, fmt.Errorf("q must be at least %d characters", models.MinAdminSearchLength)
+	}
+
+	requested := map[models.AdminSearchType]bool{}
+	for _, searchType := range types {
+		if !models.IsValidAdminSearchType(searchType) {
+			return nil, fmt.Errorf("invalid search type: %s", searchType)
+		}
+		requested[searchType] = true
+	}
+
+	if limit <= 0 {
+		limit = defaultAdminSearchResults
+	}
+	if limit > models.MaxAdminSearchResults {
+		limit = models.MaxAdminSearchResults
+	}
+
+	response := &models.AdminSearchResponse{Query: query, Types: []models.AdminSearchType{}, Results: []models.AdminSearchResult{}}
+	for _, searchType := range models.ValidAdminSearchTypes() {
+		if len(requested) > 0 && !requested[searchType] {
+			continue
+		}
+		if !allowed(adminSearchPermissions[searchType]) {
+			continue
+		}
+		response.Types = append(response.Types, searchType)
+	}
+	if len(response.Types) == 0 {
+		return nil, apperr.Forbidden("no permission to search these types")
+	}
+
+	for _, searchType := range response.Types {
+		results, err := s.searchRepo.Search(searchType, query, limit)
+		if err != nil {
+			return nil, err
+		}
+		response.Results = append(response.Results, results...)
+	}
+
+	return response, nil
+}
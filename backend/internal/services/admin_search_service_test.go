@@ -0,0 +1,28 @@
+package services
+
+import (
+	"testing"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+)
+
+func TestAdminSearchChecksQueryAndPermissions(t *testing.T) {
+	service := NewAdminSearchService(nil)
+	moderator := func(permission models.Permission) bool {
+		return permission == models.PermissionFeedbackModerate
+	}
+
+	if _, err := service.Search("a", nil, 0, moderator); err == nil {
+		t.Error("Expected an error for a one-character query")
+	}
+
+	if _, err := service.Search("alice", []models.AdminSearchType{"orders"}, 0, moderator); err == nil {
+		t.Error("Expected an error for an unknown type")
+	}
+
+	_, err := service.Search("alice", []models.AdminSearchType{models.AdminSearchUser}, 0, moderator)
+	if apperr.From(err).Kind != apperr.KindForbidden {
+		t.Errorf("Expected a moderator to be forbidden from searching users, got %v", err)
+	}
+}
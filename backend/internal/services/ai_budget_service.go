@@ -0,0 +1,128 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+	"interview-prep-app/pkg/apperr"
+)
+
+// AIBudgetService tracks per-user monthly AI usage and enforces the budget of the user's role.
+// AI features reserve a call before contacting the model and record the tokens it used after.
+type AIBudgetService struct {
+	usageRepo *repositories.AIUsageRepository
+	userRepo  *repositories.UserRepository
+	billing   *BillingService
+	budgets   map[models.Role]models.AIBudget
+}
+
+// NewAIBudgetService creates a new AI budget service. Roles without a budget of their own
+// use the budget of regular users.
+func NewAIBudgetService(usageRepo *repositories.AIUsageRepository, userRepo *repositories.UserRepository, billing *BillingService, budgets map[models.Role]models.AIBudget) *AIBudgetService {
+	return &AIBudgetService{
+		usageRepo: usageRepo,
+		userRepo:  userRepo,
+		billing:   billing,
+		budgets:   budgets,
+	}
+}
+
+// ReserveCall counts an AI call against the user's monthly budget, failing with a rate limit
+// error once either the call or token budget is used up. AI features
+// need a plan that includes them.
+func (s *AIBudgetService) ReserveCall(userID int) error {
+	if err := s.billing.RequireFeature(userID, models.FeatureAIHints); err != nil {
+		return err
+	}
+
+	budget, err := s.budgetForUser(userID)
+	if err != nil {
+		return err
+	}
+
+	ok, err := s.usageRepo.ReserveCall(userID, aiPeriodStart(time.Now()), budget)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return apperr.RateLimited("AI budget exceeded")
+	}
+
+	return nil
+}
+
+// RecordUsage adds the tokens a reserved call consumed to the user's monthly usage
+func (s *AIBudgetService) RecordUsage(userID int, feature string, tokens int64) error {
+	if userID <= 0 {
+		return fmt.Errorf("invalid user ID")
+	}
+
+	feature = strings.TrimSpace(feature)
+	if feature == "" {
+		return fmt.Errorf("feature is required")
+	}
+	if tokens < 0 {
+		tokens = 0
+	}
+
+	return s.usageRepo.RecordTokens(userID, aiPeriodStart(time.Now()), feature, tokens)
+}
+
+// GetUsage returns the user's AI usage this month against their budget
+func (s *AIBudgetService) GetUsage(userID int) (*models.AIUsage, error) {
+	budget, err := s.budgetForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	periodStart := aiPeriodStart(time.Now())
+	calls, tokens, features, err := s.usageRepo.GetUsage(userID, periodStart)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &models.AIUsage{
+		PeriodStart: periodStart,
+		PeriodEnd:   periodStart.AddDate(0, 1, 0),
+		Calls:       calls,
+		Tokens:      tokens,
+		Budget:      budget,
+		Features:    features,
+	}
+	if budget.MonthlyCalls > 0 {
+		remaining := max(budget.MonthlyCalls-calls, 0)
+		usage.RemainingCalls = &remaining
+	}
+	if budget.MonthlyTokens > 0 {
+		remaining := max(budget.MonthlyTokens-tokens, 0)
+		usage.RemainingTokens = &remaining
+	}
+
+	return usage, nil
+}
+
+// budgetForUser looks up the budget for the user's role
+func (s *AIBudgetService) budgetForUser(userID int) (models.AIBudget, error) {
+	if userID <= 0 {
+		return models.AIBudget{}, fmt.Errorf("invalid user ID")
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return models.AIBudget{}, err
+	}
+
+	if budget, ok := s.budgets[user.Role]; ok {
+		return budget, nil
+	}
+	return s.budgets[models.RoleUser], nil
+}
+
+// aiPeriodStart returns the first day of t's month in UTC, the period AI budgets reset on
+func aiPeriodStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"interview-prep-app/internal/llm"
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+	"interview-prep-app/internal/validation"
+	"interview-prep-app/pkg/apperr"
+)
+
+const (
+	// defaultGeneratedQuestions is how many questions a generation asks for when no count is given
+	defaultGeneratedQuestions = 3
+	// maxQuestionGenerationsPerHour caps how often a user can ask the model for new questions;
+	// stored questions don't count
+	maxQuestionGenerationsPerHour = 10
+	// generatedQuestionsTTL is how long stored questions are served before the model is asked again
+	generatedQuestionsTTL = 30 * 24 * time.Hour
+	// generateQuestionMaxTokens caps the length of the model's reply
+	generateQuestionMaxTokens = 800
+	// generateQuestionFeature is what generations are recorded as in AI usage
+	generateQuestionFeature = "generate_question"
+)
+
+// AIQuestionService generates follow-up questions and variations of items with a language model,
+// storing them so later requests for the same item are served without calling it again
+type AIQuestionService struct {
+	questionRepo  *repositories.AIQuestionRepository
+	itemRepo      ItemStore
+	budgetService *AIBudgetService
+	provider      llm.Provider
+}
+
+// NewAIQuestionService creates a new AI question service. Without a provider, generating
+// questions is unavailable.
+func NewAIQuestionService(questionRepo *repositories.AIQuestionRepository, itemRepo ItemStore, budgetService *AIBudgetService, provider llm.Provider) *AIQuestionService {
+	return &AIQuestionService{
+		questionRepo:  questionRepo,
+		itemRepo:      itemRepo,
+		budgetService: budgetService,
+		provider:      provider,
+	}
+}
+
+// Generate returns practice questions for an item: stored ones when there are recent enough ones
+// of the kind, unless the request asks for a refresh, and otherwise new ones from the model.
+// Asking the model counts against the user's hourly limit and monthly AI budget.
+func (s *AIQuestionService) Generate(ctx context.Context, userID int, req *models.GenerateQuestionRequest) (*models.GeneratedQuestions, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+	if req.Kind == "" {
+		req.Kind = models.AIQuestionFollowUp
+	}
+	if req.Count == 0 {
+		req.Count = defaultGeneratedQuestions
+	}
+
+	if s.provider == nil {
+		return nil, apperr.Unavailable("AI question generation is not configured")
+	}
+
+	item, err := s.itemRepo.GetPublishedByID(req.ItemID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !req.Refresh {
+		stored, err := s.questionRepo.GetLatest(item.ID, req.Kind, req.Count, time.Now().Add(-generatedQuestionsTTL))
+		if err != nil {
+			return nil, err
+		}
+		if stored != nil {
+			stored.Questions = stored.Questions[:req.Count]
+			stored.Cached = true
+			return stored, nil
+		}
+	}
+
+	generated, err := s.questionRepo.CountForUserSince(userID, time.Now().Add(-time.Hour))
+	if err != nil {
+		return nil, err
+	}
+	if generated >= maxQuestionGenerationsPerHour {
+		return nil, apperr.RateLimited("question generation limit reached: try again later")
+	}
+
+	if err := s.budgetService.ReserveCall(userID); err != nil {
+		return nil, err
+	}
+
+	completion, err := s.provider.Complete(ctx, questionPrompt(item, req.Kind, req.Count))
+	if err != nil {
+		fmt.Printf("Warning: failed to generate questions for item %d: %v\n", item.ID, err)
+		return nil, apperr.Upstream("failed to generate questions: the AI provider is unavailable")
+	}
+
+	if err := s.budgetService.RecordUsage(userID, generateQuestionFeature, completion.Tokens); err != nil {
+		fmt.Printf("Warning: failed to record AI usage for user %d: %v\n", userID, err)
+	}
+
+	questions := parseQuestions(completion.Text, req.Count)
+	if len(questions) == 0 {
+		return nil, apperr.Upstream("failed to generate questions: the AI provider returned none")
+	}
+
+	result := &models.GeneratedQuestions{
+		ItemID:    item.ID,
+		Kind:      req.Kind,
+		Questions: questions,
+		Provider:  s.provider.Name(),
+		Model:     s.provider.Model(),
+	}
+	if err := s.questionRepo.Create(userID, result, completion.Tokens); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// questionPrompt asks for count questions of a kind about an item, one per line
+func questionPrompt(item *models.Item, kind models.AIQuestionKind, count int) llm.Prompt {
+	task := "follow-up questions an interviewer would ask after a candidate solved it, probing edge cases, complexity and trade-offs"
+	if kind == models.AIQuestionVariation {
+		task = "variations of it: related problems that change a constraint or requirement so the same ideas must be adapted"
+	}
+
+	return llm.Prompt{
+		System: "You write practice questions for software engineering interview preparation. " +
+			"Reply with the questions only, one per line, without numbering or commentary.",
+		User: fmt.Sprintf("Write %d %s.\n\nTitle: %s\nCategory: %s\nSubcategory: %s\nLink: %s",
+			count, task, item.Title, item.Category, item.Subcategory, item.Link),
+		MaxTokens: generateQuestionMaxTokens,
+	}
+}
+
+// questionPrefix matches the numbering or bullet models often put before a question despite
+// being asked not to
+var questionPrefix = regexp.MustCompile(`^(\d+[.)]|[-*•])\s*`)
+
+// parseQuestions takes up to count questions from a reply, one per non-blank line
+func parseQuestions(text string, count int) []string {
+	questions := []string{}
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(questionPrefix.ReplaceAllString(strings.TrimSpace(line), ""))
+		if line == "" {
+			continue
+		}
+		questions = append(questions, line)
+		if len(questions) == count {
+			break
+		}
+	}
+
+	return questions
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+)
+
+func TestParseQuestions(t *testing.T) {
+	reply := "1. How would you handle duplicates?\n\n2) What if the input streams in?\n- Can you do it in O(1) space?\n* One more?"
+
+	got := parseQuestions(reply, 3)
+	want := []string{
+		"How would you handle duplicates?",
+		"What if the input streams in?",
+		"Can you do it in O(1) space?",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseQuestions() = %q, want %q", got, want)
+	}
+}
+
+func TestQuestionPromptDescribesItem(t *testing.T) {
+	item := &models.Item{Title: "Two Sum", Category: models.CategoryDSA, Subcategory: "arrays", Link: "https://example.com/two-sum"}
+
+	prompt := questionPrompt(item, models.AIQuestionVariation, 2)
+	for _, want := range []string{"Write 2 variations", "Two Sum", "arrays", "https://example.com/two-sum"} {
+		if !strings.Contains(prompt.User, want) {
+			t.Errorf("Expected the prompt to contain %q, got %q", want, prompt.User)
+		}
+	}
+}
+
+func TestGenerateWithoutProvider(t *testing.T) {
+	service := NewAIQuestionService(nil, nil, nil, nil)
+
+	_, err := service.Generate(context.Background(), 1, &models.GenerateQuestionRequest{ItemID: 1})
+	if apperr.From(err).Kind != apperr.KindUnavailable {
+		t.Errorf("Expected an unavailable error without a provider, got %v", err)
+	}
+
+	_, err = service.Generate(context.Background(), 1, &models.GenerateQuestionRequest{ItemID: 1, Kind: "riddle"})
+	if apperr.From(err).Kind != apperr.KindValidation {
+		t.Errorf("Expected a validation error for an unknown kind, got %v", err)
+	}
+}
@@ -0,0 +1,196 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"interview-prep-app/internal/llm"
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+	"interview-prep-app/internal/validation"
+	"interview-prep-app/pkg/apperr"
+)
+
+const (
+	// maxSummaryInputChars caps how much of a user's notes one summary sends to the model; the
+	// most recently updated notes go first
+	maxSummaryInputChars = 24000
+	// summaryMaxTokens caps the length of the revision sheet the model writes
+	summaryMaxTokens = 1500
+	// summarizeFeature is what summaries are recorded as in AI usage
+	summarizeFeature = "summarize"
+)
+
+// AISummaryService condenses a user's notes into revision sheets with a language model, once
+// they've opted in to their notes being sent to it
+type AISummaryService struct {
+	documentRepo    *repositories.DocumentRepository
+	usageRepo       *repositories.AIUsageRepository
+	budgetService   *AIBudgetService
+	categoryService *CategoryService
+	provider        llm.Provider
+}
+
+// NewAISummaryService creates a new AI summary service. Without a provider, summarizing is
+// unavailable.
+func NewAISummaryService(documentRepo *repositories.DocumentRepository, usageRepo *repositories.AIUsageRepository, budgetService *AIBudgetService, categoryService *CategoryService, provider llm.Provider) *AISummaryService {
+	return &AISummaryService{
+		documentRepo:    documentRepo,
+		usageRepo:       usageRepo,
+		budgetService:   budgetService,
+		categoryService: categoryService,
+		provider:        provider,
+	}
+}
+
+// GetSettings returns the user's AI settings
+func (s *AISummaryService) GetSettings(userID int) (*models.AISettings, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	return s.usageRepo.GetSettings(userID)
+}
+
+// UpdateSettings saves the user's AI settings
+func (s *AISummaryService) UpdateSettings(userID int, req *models.UpdateAISettingsRequest) (*models.AISettings, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	settings := &models.AISettings{NotesOptIn: *req.NotesOptIn}
+	if err := s.usageRepo.SaveSettings(userID, settings); err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+// Summarize writes a revision sheet from the user's notes on items in a category, or one of its
+// subcategories, and saves it as a document. Notes past maxSummaryInputChars are left out, and
+// the call is refused up front when the user's remaining monthly tokens can't cover it.
+func (s *AISummaryService) Summarize(ctx context.Context, userID int, req *models.SummarizeNotesRequest) (*models.Document, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+	category := models.Category(strings.TrimSpace(string(req.Category)))
+	subcategory := strings.TrimSpace(req.Subcategory)
+	if err := s.categoryService.ValidateCategory(category); err != nil {
+		return nil, err
+	}
+
+	if s.provider == nil {
+		return nil, apperr.Unavailable("AI summaries are not configured")
+	}
+
+	settings, err := s.usageRepo.GetSettings(userID)
+	if err != nil {
+		return nil, err
+	}
+	if !settings.NotesOptIn {
+		return nil, apperr.Forbidden("summarizing sends your notes to the AI provider: opt in with notes_opt_in in PUT /user/ai-settings first")
+	}
+
+	notes, err := s.documentRepo.GetItemNotes(userID, category, subcategory)
+	if err != nil {
+		return nil, err
+	}
+	if len(notes) == 0 {
+		return nil, apperr.NotFound("no notes to summarize in this category")
+	}
+
+	input, included, truncated := summaryInput(notes, maxSummaryInputChars)
+	needed := estimateTokens(input) + summaryMaxTokens
+	usage, err := s.budgetService.GetUsage(userID)
+	if err != nil {
+		return nil, err
+	}
+	if usage.RemainingTokens != nil && *usage.RemainingTokens < needed {
+		return nil, apperr.RateLimited(fmt.Sprintf("not enough AI budget left: this summary needs about %d tokens and %d remain this month", needed, *usage.RemainingTokens))
+	}
+
+	if err := s.budgetService.ReserveCall(userID); err != nil {
+		return nil, err
+	}
+
+	topic := string(category)
+	if subcategory != "" {
+		topic += " / " + subcategory
+	}
+	completion, err := s.provider.Complete(ctx, llm.Prompt{
+		System: "You turn a student's interview preparation notes into a concise revision sheet in Markdown. " +
+			"Group related ideas under headings, keep key facts, complexities and trade-offs, and drop repetition. " +
+			"Only use what the notes say.",
+		User:      fmt.Sprintf("Topic: %s\n\n%s", topic, input),
+		MaxTokens: summaryMaxTokens,
+	})
+	if err != nil {
+		fmt.Printf("Warning: failed to summarize notes for user %d: %v\n", userID, err)
+		return nil, apperr.Upstream("failed to summarize notes: the AI provider is unavailable")
+	}
+
+	if err := s.budgetService.RecordUsage(userID, summarizeFeature, completion.Tokens); err != nil {
+		fmt.Printf("Warning: failed to record AI usage for user %d: %v\n", userID, err)
+	}
+
+	content := strings.TrimSpace(completion.Text)
+	if content == "" {
+		return nil, apperr.Upstream("failed to summarize notes: the AI provider returned nothing")
+	}
+
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		title = "Revision sheet: " + topic
+	}
+	document := &models.Document{
+		UserID:      userID,
+		Kind:        models.DocumentRevisionSheet,
+		Title:       title,
+		Category:    category,
+		Subcategory: subcategory,
+		Content:     content,
+		SourceItems: included,
+		Truncated:   truncated,
+		Provider:    s.provider.Name(),
+		Model:       s.provider.Model(),
+	}
+	if err := s.documentRepo.Create(document, completion.Tokens); err != nil {
+		return nil, err
+	}
+
+	return document, nil
+}
+
+// summaryInput lays out notes item by item until the next one would pass maxChars, returning the
+// text, how many items it holds and whether any notes were left out. The first item is always
+// included, cut to fit.
+func summaryInput(notes []*models.ItemNote, maxChars int) (string, int, bool) {
+	var input strings.Builder
+	for i, note := range notes {
+		section := fmt.Sprintf("## %s (%s)\n%s\n\n", note.Title, note.Subcategory, strings.TrimSpace(note.Notes))
+		if input.Len()+len(section) > maxChars {
+			if i == 0 {
+				input.WriteString(strings.ToValidUTF8(section[:maxChars], ""))
+				return input.String(), 1, true
+			}
+			return input.String(), i, true
+		}
+		input.WriteString(section)
+	}
+
+	return input.String(), len(notes), false
+}
+
+// estimateTokens roughly counts the tokens a text takes, at about four characters a token
+func estimateTokens(text string) int64 {
+	return int64(len(text)/4 + 1)
+}
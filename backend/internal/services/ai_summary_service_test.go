@@ -0,0 +1,35 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"interview-prep-app/internal/models"
+)
+
+func TestSummaryInput(t *testing.T) {
+	notes := []*models.ItemNote{
+		{Title: "LRU Cache", Subcategory: "caching", Notes: "  Hash map plus a doubly linked list  "},
+		{Title: "CDN", Subcategory: "caching", Notes: "Edge caches close to users"},
+	}
+
+	input, included, truncated := summaryInput(notes, 1000)
+	if included != 2 || truncated {
+		t.Errorf("Expected both notes without truncation, got %d, truncated=%v", included, truncated)
+	}
+	if !strings.Contains(input, "## LRU Cache (caching)\nHash map plus a doubly linked list\n") {
+		t.Errorf("Expected each note under its item's heading, got %q", input)
+	}
+
+	// Only the first note fits
+	input, included, truncated = summaryInput(notes, 60)
+	if included != 1 || !truncated || strings.Contains(input, "CDN") {
+		t.Errorf("Expected only the first note, got %d, truncated=%v: %q", included, truncated, input)
+	}
+
+	// Even the first note is too long, so it's cut
+	input, included, truncated = summaryInput(notes, 20)
+	if included != 1 || !truncated || len(input) != 20 {
+		t.Errorf("Expected the first note cut to 20 characters, got %d, truncated=%v: %q", included, truncated, input)
+	}
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+	"interview-prep-app/internal/validation"
+	"interview-prep-app/pkg/apperr"
+)
+
+const (
+	// apiKeyPrefix marks personal API keys, telling them apart from access tokens and service
+	// account keys
+	apiKeyPrefix = "pmk_"
+	// maxAPIKeysPerUser limits how many unrevoked keys one account can hold
+	maxAPIKeysPerUser = 10
+)
+
+// APIKeyService manages personal API keys, which act as their user for scripts and prepcli
+type APIKeyService struct {
+	apiKeyRepo *repositories.APIKeyRepository
+	userRepo   *repositories.UserRepository
+}
+
+// NewAPIKeyService creates a new API key service
+func NewAPIKeyService(apiKeyRepo *repositories.APIKeyRepository, userRepo *repositories.UserRepository) *APIKeyService {
+	return &APIKeyService{apiKeyRepo: apiKeyRepo, userRepo: userRepo}
+}
+
+// IsAPIKey reports whether a bearer token is a personal API key rather than an access token
+func IsAPIKey(token string) bool {
+	return strings.HasPrefix(token, apiKeyPrefix)
+}
+
+// CreateAPIKey issues a key for the user. The raw key is returned once and only its hash is stored.
+func (s *APIKeyService) CreateAPIKey(userID int, req *models.CreateAPIKeyRequest) (*models.CreateAPIKeyResponse, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	count, err := s.apiKeyRepo.CountActive(userID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxAPIKeysPerUser {
+		return nil, fmt.Errorf("API key limit reached: revoke one before adding another")
+	}
+
+	secret, err := generateSecretToken()
+	if err != nil {
+		return nil, err
+	}
+	raw := apiKeyPrefix + secret
+
+	key := &models.APIKey{
+		UserID:    userID,
+		Name:      strings.TrimSpace(req.Name),
+		KeyPrefix: raw[:len(apiKeyPrefix)+6],
+		KeyHash:   hashSecretToken(raw),
+	}
+
+	if err := s.apiKeyRepo.Create(key); err != nil {
+		return nil, err
+	}
+
+	return &models.CreateAPIKeyResponse{APIKey: key, Key: raw}, nil
+}
+
+// GetAPIKeys lists the user's API keys
+func (s *APIKeyService) GetAPIKeys(userID int) ([]*models.APIKey, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	return s.apiKeyRepo.GetForUser(userID)
+}
+
+// RevokeAPIKey disables one of the user's API keys
+func (s *APIKeyService) RevokeAPIKey(userID, keyID int) error {
+	if userID <= 0 {
+		return fmt.Errorf("invalid user ID")
+	}
+
+	if keyID <= 0 {
+		return fmt.Errorf("invalid API key ID")
+	}
+
+	return s.apiKeyRepo.Revoke(userID, keyID)
+}
+
+// Authenticate resolves an API key to its key and active user
+func (s *APIKeyService) Authenticate(raw string) (*models.APIKey, *models.User, error) {
+	if !IsAPIKey(raw) {
+		return nil, nil, apperr.NotFound("API key not found")
+	}
+
+	key, err := s.apiKeyRepo.GetActiveByKeyHash(hashSecretToken(raw))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Deactivated users aren't found, so their keys stop working with them
+	user, err := s.userRepo.GetByID(key.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.apiKeyRepo.Touch(key.ID); err != nil {
+		// Log error but don't fail the request
+		fmt.Printf("Warning: failed to record usage for API key %d: %v\n", key.ID, err)
+	}
+
+	return key, user, nil
+}
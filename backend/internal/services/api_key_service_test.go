@@ -0,0 +1,46 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+	"interview-prep-app/internal/testutil"
+	"interview-prep-app/pkg/apperr"
+)
+
+func TestRevokingSessionsRevokesAPIKeys(t *testing.T) {
+	db := testutil.OpenDB(t)
+	userRepo := repositories.NewUserRepository(db)
+	service := NewAPIKeyService(repositories.NewAPIKeyRepository(db), userRepo)
+
+	user := &models.User{Email: "ada@example.test", Name: "Ada", Role: models.RoleUser, AuthProvider: models.AuthProviderEmail}
+	if err := userRepo.Create(user); err != nil {
+		t.Fatal(err)
+	}
+
+	created, err := service.CreateAPIKey(user.ID, &models.CreateAPIKeyRequest{Name: "laptop"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := service.Authenticate(created.Key); err != nil {
+		t.Fatalf("Expected the new key to work, got %v", err)
+	}
+
+	if err := userRepo.RevokeSessions(user.ID, time.Now(), "", time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := service.Authenticate(created.Key); apperr.From(err).Kind != apperr.KindNotFound {
+		t.Errorf("Expected the key to stop working once sessions are revoked, got %v", err)
+	}
+
+	keys, err := service.GetAPIKeys(user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 1 || keys[0].RevokedAt == nil {
+		t.Errorf("Expected the key to be listed as revoked, got %+v", keys)
+	}
+}
@@ -0,0 +1,306 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+	"interview-prep-app/internal/secrets"
+	"interview-prep-app/internal/storage"
+	"interview-prep-app/internal/validation"
+	"interview-prep-app/pkg/apperr"
+)
+
+// downloadURLExpiry is how long signed download URLs stay valid
+const downloadURLExpiry = 15 * time.Minute
+
+// AttachmentService handles business logic for uploaded file attachments
+type AttachmentService struct {
+	attachmentRepo *repositories.AttachmentRepository
+	itemRepo       *repositories.ItemRepository
+	storage        storage.Storage
+	maxBytes       int64
+	allowedTypes   []string
+	keyring        *secrets.Keyring
+}
+
+// NewAttachmentService creates a new attachment service. keyring may be nil, in which case
+// encrypted (secret) attachments are unavailable.
+func NewAttachmentService(attachmentRepo *repositories.AttachmentRepository, itemRepo *repositories.ItemRepository, store storage.Storage, maxBytes int64, allowedTypes []string, keyring *secrets.Keyring) *AttachmentService {
+	return &AttachmentService{
+		attachmentRepo: attachmentRepo,
+		itemRepo:       itemRepo,
+		storage:        store,
+		maxBytes:       maxBytes,
+		allowedTypes:   allowedTypes,
+		keyring:        keyring,
+	}
+}
+
+// Upload validates and stores a file for an item, recording its metadata. A file of the wrong
+// size or type is a validation error; failing to read or store it is an internal one.
+func (s *AttachmentService) Upload(ctx context.Context, userID, itemID int, fileHeader *multipart.FileHeader) (*models.FileAttachment, error) {
+	if userID <= 0 {
+		return nil, apperr.Validation("invalid user ID")
+	}
+
+	if itemID <= 0 {
+		return nil, apperr.Validation("invalid item ID")
+	}
+
+	if fileHeader.Size <= 0 {
+		return nil, apperr.Validation("file is empty")
+	}
+
+	if fileHeader.Size > s.maxBytes {
+		return nil, apperr.Validation(fmt.Sprintf("file too large: maximum size is %d bytes", s.maxBytes))
+	}
+
+	if _, err := s.itemRepo.GetPublishedByID(itemID); err != nil {
+		return nil, err
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, apperr.Errorf("failed to open uploaded file: %w", err)
+	}
+	defer file.Close()
+
+	// Sniff the content type from the file itself rather than trusting the client header
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return nil, apperr.Errorf("failed to read uploaded file: %w", err)
+	}
+	contentType := strings.Split(http.DetectContentType(head[:n]), ";")[0]
+	if !s.isAllowedType(contentType) {
+		return nil, apperr.Validation(fmt.Sprintf("unsupported file type: %s. Allowed types are: %v", contentType, s.allowedTypes))
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, apperr.Errorf("failed to rewind uploaded file: %w", err)
+	}
+
+	key, err := storageKey(userID, itemID, fileHeader.Filename)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.storage.Put(ctx, key, file, fileHeader.Size, contentType); err != nil {
+		// Whatever the store reports, the upload failed on our side
+		fmt.Printf("Error: failed to store upload %s: %v\n", key, err)
+		return nil, apperr.Internal("Failed to store file")
+	}
+
+	attachment := &models.FileAttachment{
+		ItemID:      itemID,
+		UserID:      userID,
+		Kind:        models.AttachmentKindFile,
+		FileName:    filepath.Base(fileHeader.Filename),
+		ContentType: contentType,
+		SizeBytes:   fileHeader.Size,
+		StorageKey:  key,
+	}
+
+	if err := s.attachmentRepo.Create(attachment); err != nil {
+		// Don't leave orphaned objects behind when the metadata insert fails
+		if delErr := s.storage.Delete(ctx, key); delErr != nil {
+			fmt.Printf("Warning: failed to remove orphaned upload %s: %v\n", key, delErr)
+		}
+		return nil, err
+	}
+
+	return s.withDownloadURL(attachment)
+}
+
+// CreateSecret encrypts a value (e.g. a private Gist link) with the user's data key and attaches it to an item
+func (s *AttachmentService) CreateSecret(userID, itemID int, req *models.CreateSecretAttachmentRequest) (*models.FileAttachment, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if itemID <= 0 {
+		return nil, fmt.Errorf("invalid item ID")
+	}
+
+	if s.keyring == nil {
+		return nil, fmt.Errorf("encrypted attachments are not enabled on this server")
+	}
+
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+	label := strings.TrimSpace(req.Label)
+
+	if _, err := s.itemRepo.GetPublishedByID(itemID); err != nil {
+		return nil, err
+	}
+
+	wrappedKey, err := s.userKey(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext, err := s.keyring.Encrypt(wrappedKey, []byte(req.Value), secretContext(userID, itemID))
+	if err != nil {
+		return nil, apperr.Errorf("failed to encrypt attachment: %w", err)
+	}
+
+	attachment := &models.FileAttachment{
+		ItemID:      itemID,
+		UserID:      userID,
+		Kind:        models.AttachmentKindSecret,
+		FileName:    label,
+		ContentType: "text/plain",
+		SizeBytes:   int64(len(req.Value)),
+		Ciphertext:  ciphertext,
+	}
+
+	if err := s.attachmentRepo.Create(attachment); err != nil {
+		return nil, err
+	}
+
+	attachment.Secret = req.Value
+	return attachment, nil
+}
+
+// GetItemAttachments lists a user's attachments for an item with signed download URLs.
+// Secret attachments are decrypted here, the only path that returns them, and only for their owner.
+func (s *AttachmentService) GetItemAttachments(userID, itemID int) ([]*models.FileAttachment, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if itemID <= 0 {
+		return nil, fmt.Errorf("invalid item ID")
+	}
+
+	attachments, err := s.attachmentRepo.GetByItemForUser(userID, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, attachment := range attachments {
+		if attachment.Kind == models.AttachmentKindSecret {
+			s.decryptSecret(attachment)
+			continue
+		}
+		if _, err := s.withDownloadURL(attachment); err != nil {
+			return nil, err
+		}
+	}
+
+	return attachments, nil
+}
+
+// DeleteAttachment removes an attachment and its stored object
+func (s *AttachmentService) DeleteAttachment(ctx context.Context, userID, attachmentID int) error {
+	attachment, err := s.attachmentRepo.GetByID(userID, attachmentID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.attachmentRepo.Delete(userID, attachmentID); err != nil {
+		return err
+	}
+
+	if attachment.Kind == models.AttachmentKindSecret {
+		return nil
+	}
+
+	if err := s.storage.Delete(ctx, attachment.StorageKey); err != nil {
+		// The record is gone; a leftover object is harmless but worth noting
+		fmt.Printf("Warning: failed to delete stored object %s: %v\n", attachment.StorageKey, err)
+	}
+
+	return nil
+}
+
+// decryptSecret fills in the plaintext of a secret attachment. Failures leave it blank rather than
+// failing the whole list, e.g. after the master key has been rotated away.
+func (s *AttachmentService) decryptSecret(attachment *models.FileAttachment) {
+	if s.keyring == nil {
+		return
+	}
+
+	wrappedKey, err := s.attachmentRepo.GetUserKey(attachment.UserID)
+	if err != nil || wrappedKey == nil {
+		fmt.Printf("Warning: no encryption key for user %d: %v\n", attachment.UserID, err)
+		return
+	}
+
+	plaintext, err := s.keyring.Decrypt(wrappedKey, attachment.Ciphertext, secretContext(attachment.UserID, attachment.ItemID))
+	if err != nil {
+		fmt.Printf("Warning: failed to decrypt attachment %d: %v\n", attachment.ID, err)
+		return
+	}
+
+	attachment.Secret = string(plaintext)
+}
+
+// userKey returns the user's wrapped data key, creating it on first use
+func (s *AttachmentService) userKey(userID int) ([]byte, error) {
+	return userDataKey(s.keyring, s.attachmentRepo, userID)
+}
+
+// userDataKey returns the user's wrapped data key, creating it on first use. Every secret a
+// user stores (attachments, connected account tokens) is encrypted with this one key.
+func userDataKey(keyring *secrets.Keyring, attachmentRepo *repositories.AttachmentRepository, userID int) ([]byte, error) {
+	wrappedKey, err := attachmentRepo.GetUserKey(userID)
+	if err != nil || wrappedKey != nil {
+		return wrappedKey, err
+	}
+
+	wrappedKey, err = keyring.NewDataKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return attachmentRepo.CreateUserKey(userID, wrappedKey)
+}
+
+// secretContext binds a secret's ciphertext to its owner and item so rows can't be swapped between them
+func secretContext(userID, itemID int) string {
+	return fmt.Sprintf("attachment:%d:%d", userID, itemID)
+}
+
+// withDownloadURL fills in a signed download URL for the attachment
+func (s *AttachmentService) withDownloadURL(attachment *models.FileAttachment) (*models.FileAttachment, error) {
+	url, err := s.storage.SignedURL(attachment.StorageKey, downloadURLExpiry)
+	if err != nil {
+		return nil, apperr.Errorf("failed to sign download URL: %w", err)
+	}
+
+	attachment.DownloadURL = url
+	return attachment, nil
+}
+
+// isAllowedType checks a MIME type against the configured allow-list
+func (s *AttachmentService) isAllowedType(contentType string) bool {
+	for _, allowed := range s.allowedTypes {
+		if strings.EqualFold(allowed, contentType) {
+			return true
+		}
+	}
+	return false
+}
+
+// storageKey builds a unique, unguessable object key for an upload
+func storageKey(userID, itemID int, fileName string) (string, error) {
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return "", apperr.Errorf("failed to generate storage key: %w", err)
+	}
+
+	ext := strings.ToLower(filepath.Ext(fileName))
+	return fmt.Sprintf("items/%d/users/%d/%s%s", itemID, userID, hex.EncodeToString(random), ext), nil
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+	"interview-prep-app/internal/testutil"
+	"interview-prep-app/pkg/apperr"
+)
+
+// brokenStorage is a Storage whose writes always fail
+type brokenStorage struct{}
+
+func (brokenStorage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	return apperr.Validation("bucket rejected the object")
+}
+
+func (brokenStorage) Delete(ctx context.Context, key string) error { return nil }
+
+func (brokenStorage) SignedURL(key string, expiry time.Duration) (string, error) { return "", nil }
+
+func (brokenStorage) Check(ctx context.Context) error { return nil }
+
+// uploadedFile builds the header of a multipart file upload with the given contents
+func uploadedFile(t *testing.T, name string, content []byte) *multipart.FileHeader {
+	t.Helper()
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	part, err := w.CreateFormFile("file", name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	part.Write(content)
+	w.Close()
+
+	req := httptest.NewRequest("POST", "/upload", &body)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	_, header, err := req.FormFile("file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return header
+}
+
+func TestUploadErrorKinds(t *testing.T) {
+	db := testutil.OpenDB(t)
+	itemRepo := repositories.NewItemRepository(db)
+	userRepo := repositories.NewUserRepository(db)
+
+	user := &models.User{Email: "grace@example.test", Name: "Grace", Role: models.RoleUser, AuthProvider: models.AuthProviderEmail}
+	if err := userRepo.Create(user); err != nil {
+		t.Fatal(err)
+	}
+	item, err := itemRepo.Create(&models.CreateItemRequest{
+		Title: "Two Sum", Link: "https://example.test/two-sum", Category: models.CategoryDSA, Subcategory: "arrays",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	service := NewAttachmentService(repositories.NewAttachmentRepository(db), itemRepo, brokenStorage{}, 64, []string{"text/plain"}, nil)
+	testCases := []struct {
+		name    string
+		file    *multipart.FileHeader
+		kind    apperr.Kind
+		message string
+	}{
+		{"Empty files are rejected", uploadedFile(t, "empty.txt", nil), apperr.KindValidation, "file is empty"},
+		{"Oversized files are rejected", uploadedFile(t, "big.txt", bytes.Repeat([]byte("a"), 65)), apperr.KindValidation, "file too large: maximum size is 64 bytes"},
+		{"Disallowed types are rejected", uploadedFile(t, "page.html", []byte("<html><body>hi</body></html>")), apperr.KindValidation, ""},
+		{"Storage failures are internal", uploadedFile(t, "notes.txt", []byte("notes")), apperr.KindInternal, "Failed to store file"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := service.Upload(context.Background(), user.ID, item.ID, tc.file)
+			var appErr *apperr.Error
+			if !errors.As(err, &appErr) || appErr.Kind != tc.kind {
+				t.Fatalf("Expected a %s error, got %v", tc.kind, err)
+			}
+			if tc.message != "" && appErr.Message != tc.message {
+				t.Errorf("Expected message %q, got %q", tc.message, appErr.Message)
+			}
+		})
+	}
+}
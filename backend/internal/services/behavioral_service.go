@@ -0,0 +1,241 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+	"interview-prep-app/internal/validation"
+)
+
+const (
+	// defaultBehavioralQuestionsLimit is the question bank page size when none is given
+	defaultBehavioralQuestionsLimit = 20
+	// maxBehavioralQuestionsLimit caps the question bank page size
+	maxBehavioralQuestionsLimit = 100
+	// defaultBehavioralPracticeCount is how many questions a practice round serves by default
+	defaultBehavioralPracticeCount = 3
+	// maxBehavioralPracticeCount caps how many questions one practice round serves
+	maxBehavioralPracticeCount = 10
+)
+
+// BehavioralService handles business logic for the behavioral question bank, users' STAR answer
+// drafts and practice rounds
+type BehavioralService struct {
+	behavioralRepo *repositories.BehavioralRepository
+}
+
+// NewBehavioralService creates a new behavioral service
+func NewBehavioralService(behavioralRepo *repositories.BehavioralRepository) *BehavioralService {
+	return &BehavioralService{behavioralRepo: behavioralRepo}
+}
+
+// GetQuestions returns a page of the question bank with the user's drafts
+func (s *BehavioralService) GetQuestions(userID int, filter *models.BehavioralQuestionFilter) (*models.BehavioralQuestionsResponse, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if filter.Competency != nil && !models.IsValidCompetency(*filter.Competency) {
+		return nil, fmt.Errorf("invalid competency: %s. Valid competencies are: %v", *filter.Competency, models.ValidCompetencies())
+	}
+
+	if filter.Limit == 0 {
+		filter.Limit = defaultBehavioralQuestionsLimit
+	}
+	if filter.Limit < 0 || filter.Limit > maxBehavioralQuestionsLimit {
+		return nil, fmt.Errorf("limit must be between 1 and %d", maxBehavioralQuestionsLimit)
+	}
+
+	if filter.Offset < 0 {
+		return nil, fmt.Errorf("offset cannot be negative")
+	}
+
+	questions, total, err := s.behavioralRepo.GetQuestions(userID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.BehavioralQuestionsResponse{
+		Questions: questions,
+		Pagination: models.PaginationMeta{
+			Page:       filter.Offset/filter.Limit + 1,
+			Limit:      filter.Limit,
+			Offset:     filter.Offset,
+			Total:      total,
+			TotalPages: (total + filter.Limit - 1) / filter.Limit,
+			HasNext:    filter.Offset+filter.Limit < total,
+			HasPrev:    filter.Offset > 0,
+		},
+	}, nil
+}
+
+// GetQuestion returns a question with the user's draft
+func (s *BehavioralService) GetQuestion(userID, questionID int) (*models.BehavioralQuestion, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if questionID <= 0 {
+		return nil, fmt.Errorf("invalid question ID")
+	}
+
+	return s.behavioralRepo.GetQuestion(userID, questionID)
+}
+
+// GetPracticeQuestions serves a random practice round, favoring the questions the user has
+// practiced least, optionally limited to one competency
+func (s *BehavioralService) GetPracticeQuestions(userID int, competency *models.Competency, count int) (*models.BehavioralPracticeResponse, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if competency != nil && !models.IsValidCompetency(*competency) {
+		return nil, fmt.Errorf("invalid competency: %s. Valid competencies are: %v", *competency, models.ValidCompetencies())
+	}
+
+	if count == 0 {
+		count = defaultBehavioralPracticeCount
+	}
+	if count < 0 || count > maxBehavioralPracticeCount {
+		return nil, fmt.Errorf("count must be between 1 and %d", maxBehavioralPracticeCount)
+	}
+
+	questions, err := s.behavioralRepo.GetPracticeQuestions(userID, competency, count)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.BehavioralPracticeResponse{Questions: questions}, nil
+}
+
+// CreateQuestion adds a question to the bank
+func (s *BehavioralService) CreateQuestion(req *models.CreateBehavioralQuestionRequest) (*models.BehavioralQuestion, error) {
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	return s.behavioralRepo.CreateQuestion(strings.TrimSpace(req.Question), uniqueCompetencies(req.Competencies))
+}
+
+// UpdateQuestion edits a bank question's text or competencies
+func (s *BehavioralService) UpdateQuestion(questionID int, req *models.UpdateBehavioralQuestionRequest) (*models.BehavioralQuestion, error) {
+	if questionID <= 0 {
+		return nil, fmt.Errorf("invalid question ID")
+	}
+
+	if req.Question == nil && req.Competencies == nil {
+		return nil, fmt.Errorf("at least one field must be provided for update")
+	}
+
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.behavioralRepo.GetQuestion(0, questionID)
+	if err != nil {
+		return nil, err
+	}
+
+	question, competencies := existing.Question, existing.Competencies
+	if req.Question != nil {
+		question = strings.TrimSpace(*req.Question)
+	}
+	if req.Competencies != nil {
+		competencies = uniqueCompetencies(*req.Competencies)
+	}
+
+	if err := s.behavioralRepo.UpdateQuestion(questionID, question, competencies); err != nil {
+		return nil, err
+	}
+
+	return s.behavioralRepo.GetQuestion(0, questionID)
+}
+
+// DeleteQuestion removes a question from the bank along with every user's draft for it
+func (s *BehavioralService) DeleteQuestion(questionID int) error {
+	if questionID <= 0 {
+		return fmt.Errorf("invalid question ID")
+	}
+
+	return s.behavioralRepo.DeleteQuestion(questionID)
+}
+
+// SaveAnswer saves the user's STAR draft for a question and returns the question with it
+func (s *BehavioralService) SaveAnswer(userID, questionID int, req *models.SaveBehavioralAnswerRequest) (*models.BehavioralQuestion, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if questionID <= 0 {
+		return nil, fmt.Errorf("invalid question ID")
+	}
+
+	req.Situation = strings.TrimSpace(req.Situation)
+	req.Task = strings.TrimSpace(req.Task)
+	req.Action = strings.TrimSpace(req.Action)
+	req.Result = strings.TrimSpace(req.Result)
+	if req.Situation == "" && req.Task == "" && req.Action == "" && req.Result == "" {
+		return nil, fmt.Errorf("at least one part of the answer must be provided")
+	}
+
+	if _, err := s.behavioralRepo.GetQuestion(userID, questionID); err != nil {
+		return nil, err
+	}
+
+	if err := s.behavioralRepo.SaveAnswer(userID, questionID, req); err != nil {
+		return nil, err
+	}
+
+	return s.behavioralRepo.GetQuestion(userID, questionID)
+}
+
+// DeleteAnswer removes the user's draft and practice history for a question
+func (s *BehavioralService) DeleteAnswer(userID, questionID int) error {
+	if userID <= 0 {
+		return fmt.Errorf("invalid user ID")
+	}
+
+	if questionID <= 0 {
+		return fmt.Errorf("invalid question ID")
+	}
+
+	return s.behavioralRepo.DeleteAnswer(userID, questionID)
+}
+
+// RecordPractice counts a practice run of a question and returns the question with the user's draft
+func (s *BehavioralService) RecordPractice(userID, questionID int, now time.Time) (*models.BehavioralQuestion, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if questionID <= 0 {
+		return nil, fmt.Errorf("invalid question ID")
+	}
+
+	if _, err := s.behavioralRepo.GetQuestion(userID, questionID); err != nil {
+		return nil, err
+	}
+
+	if err := s.behavioralRepo.RecordPractice(userID, questionID, now); err != nil {
+		return nil, err
+	}
+
+	return s.behavioralRepo.GetQuestion(userID, questionID)
+}
+
+// uniqueCompetencies drops repeated competency tags, keeping the order
+func uniqueCompetencies(competencies []models.Competency) []models.Competency {
+	unique := []models.Competency{}
+	seen := map[models.Competency]bool{}
+	for _, competency := range competencies {
+		if !seen[competency] {
+			seen[competency] = true
+			unique = append(unique, competency)
+		}
+	}
+
+	return unique
+}
@@ -0,0 +1,493 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"interview-prep-app/internal/billing"
+	"interview-prep-app/internal/mailer"
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/plugins"
+	"interview-prep-app/internal/repositories"
+	"interview-prep-app/pkg/apperr"
+)
+
+// dunningReminderDays are the days after a subscription first goes past due on which the
+// user is reminded to fix their payment method
+var dunningReminderDays = []int{3, 7}
+
+// BillingPlans configures billing: the Stripe price of the pro plan, the limits of the free
+// plan and the length of the free pro trial
+type BillingPlans struct {
+	ProPriceID       string
+	FreeMonthlyTests int
+	FreeOrgSeats     int
+	TrialDays        int // 0 disables trials
+	TrialNoticeDays  int
+}
+
+// BillingService handles plans, Stripe subscriptions and feature entitlements, and notifies
+// users about trials ending and failed payments
+type BillingService struct {
+	billingRepo   *repositories.BillingRepository
+	userRepo      *repositories.UserRepository
+	stripe        *billing.Client // nil when billing is disabled
+	webhookSecret string
+	plans         BillingPlans
+	mailer        mailer.Mailer
+	channels      []plugins.NotificationChannel
+	appBaseURL    string
+}
+
+// NewBillingService creates a new billing service. With a nil Stripe client billing is
+// disabled and every user is entitled to every feature.
+func NewBillingService(billingRepo *repositories.BillingRepository, userRepo *repositories.UserRepository, stripe *billing.Client, webhookSecret string, plans BillingPlans, m mailer.Mailer, channels []plugins.NotificationChannel, appBaseURL string) *BillingService {
+	return &BillingService{
+		billingRepo:   billingRepo,
+		userRepo:      userRepo,
+		stripe:        stripe,
+		webhookSecret: webhookSecret,
+		plans:         plans,
+		mailer:        m,
+		channels:      channels,
+		appBaseURL:    strings.TrimRight(appBaseURL, "/"),
+	}
+}
+
+// Enabled reports whether plans are enforced
+func (s *BillingService) Enabled() bool {
+	return s.stripe != nil
+}
+
+// GetEntitlements returns the user's plan and what it allows
+func (s *BillingService) GetEntitlements(userID int) (*models.Entitlements, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if !s.Enabled() {
+		return &models.Entitlements{Plan: models.PlanPro, Features: models.ProFeatures, ReadOnlyFeatures: []models.Feature{}}, nil
+	}
+
+	sub, err := s.billingRepo.GetSubscription(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	entitlements := &models.Entitlements{Plan: sub.EffectivePlan(), Subscription: sub, ReadOnlyFeatures: []models.Feature{}}
+	if entitlements.Plan == models.PlanPro {
+		entitlements.Features = models.ProFeatures
+	} else {
+		entitlements.Features = []models.Feature{}
+		entitlements.MonthlyTests = &s.plans.FreeMonthlyTests
+		entitlements.OrgSeats = &s.plans.FreeOrgSeats
+
+		// Users who had pro keep read access to what they created with it
+		if sub.DowngradedAt != nil || sub.TrialStartedAt != nil {
+			entitlements.ReadOnlyFeatures = models.ProFeatures
+		}
+	}
+
+	return entitlements, nil
+}
+
+// RequireFeature fails with an "upgrade required" error unless the user's plan includes the feature
+func (s *BillingService) RequireFeature(userID int, feature models.Feature) error {
+	entitlements, err := s.GetEntitlements(userID)
+	if err != nil {
+		return err
+	}
+
+	if !entitlements.HasFeature(feature) {
+		return apperr.PaymentRequired(fmt.Sprintf("upgrade required: %s is available on the pro plan", feature))
+	}
+
+	return nil
+}
+
+// StartTrial puts the user on the pro plan for the configured trial length. Each user gets one trial.
+func (s *BillingService) StartTrial(userID int) (*models.Entitlements, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+	if !s.Enabled() {
+		return nil, apperr.NotFound("billing is not enabled")
+	}
+	if s.plans.TrialDays <= 0 {
+		return nil, apperr.NotFound("trials are not offered")
+	}
+
+	sub, err := s.billingRepo.GetSubscription(userID)
+	if err != nil {
+		return nil, err
+	}
+	if sub.EffectivePlan() == models.PlanPro {
+		return nil, apperr.Conflict("already subscribed to the pro plan")
+	}
+	if sub.StripeSubscriptionID != "" {
+		return nil, apperr.Conflict("trial already used")
+	}
+
+	started, err := s.billingRepo.StartTrial(userID, time.Now().AddDate(0, 0, s.plans.TrialDays))
+	if err != nil {
+		return nil, err
+	}
+	if !started {
+		return nil, apperr.Conflict("trial already used")
+	}
+
+	return s.GetEntitlements(userID)
+}
+
+// CreateCheckoutURL starts a Stripe Checkout for the pro plan, creating the Stripe customer on first use
+func (s *BillingService) CreateCheckoutURL(ctx context.Context, userID int) (string, error) {
+	if !s.Enabled() {
+		return "", apperr.NotFound("billing is not enabled")
+	}
+	if s.plans.ProPriceID == "" {
+		return "", fmt.Errorf("billing is not configured: missing pro price")
+	}
+
+	sub, err := s.billingRepo.GetSubscription(userID)
+	if err != nil {
+		return "", err
+	}
+	if sub.EffectivePlan() == models.PlanPro {
+		return "", apperr.Conflict("already subscribed to the pro plan")
+	}
+
+	customerID := sub.StripeCustomerID
+	if customerID == "" {
+		user, err := s.userRepo.GetByID(userID)
+		if err != nil {
+			return "", err
+		}
+		customerID, err = s.stripe.CreateCustomer(ctx, user.Email, userID)
+		if err != nil {
+			return "", err
+		}
+		if err := s.billingRepo.SetCustomerID(userID, customerID); err != nil {
+			return "", err
+		}
+	}
+
+	return s.stripe.CreateCheckoutSession(ctx, customerID, s.plans.ProPriceID,
+		s.appBaseURL+"/billing?checkout=success", s.appBaseURL+"/billing?checkout=cancelled")
+}
+
+// CreatePortalURL opens the Stripe customer portal where the user manages their subscription
+func (s *BillingService) CreatePortalURL(ctx context.Context, userID int) (string, error) {
+	if !s.Enabled() {
+		return "", apperr.NotFound("billing is not enabled")
+	}
+
+	sub, err := s.billingRepo.GetSubscription(userID)
+	if err != nil {
+		return "", err
+	}
+	if sub.StripeCustomerID == "" {
+		return "", apperr.Validation("no billing account: subscribe first")
+	}
+
+	return s.stripe.CreatePortalSession(ctx, sub.StripeCustomerID, s.appBaseURL+"/billing")
+}
+
+// HandleStripeWebhook verifies and applies a Stripe webhook event. Redelivered events are ignored.
+func (s *BillingService) HandleStripeWebhook(payload []byte, signatureHeader string) error {
+	if !s.Enabled() || s.webhookSecret == "" {
+		return apperr.NotFound("billing is not enabled")
+	}
+
+	event, err := billing.ParseWebhook(payload, signatureHeader, s.webhookSecret, time.Now())
+	if err != nil {
+		return err
+	}
+
+	processed, err := s.billingRepo.EventProcessed(event.ID)
+	if err != nil {
+		return err
+	}
+	if processed {
+		return nil
+	}
+
+	switch event.Type {
+	case "customer.subscription.created", "customer.subscription.updated", "customer.subscription.deleted":
+		if err := s.applySubscription(event); err != nil {
+			return err
+		}
+	case "invoice.payment_failed":
+		if err := s.notifyPaymentFailed(event); err != nil {
+			return err
+		}
+	}
+
+	return s.billingRepo.MarkEventProcessed(event.ID, event.Type)
+}
+
+// applySubscription stores the state of a Stripe subscription on its customer's user
+func (s *BillingService) applySubscription(event *billing.Event) error {
+	var stripeSub billing.Subscription
+	if err := decodeStripeObject(event, &stripeSub); err != nil {
+		return err
+	}
+
+	userID, err := s.billingRepo.GetUserIDByCustomerID(stripeSub.Customer)
+	if err != nil {
+		// Customers created outside the app aren't ours to track
+		fmt.Printf("Warning: ignoring Stripe subscription %s for unknown customer %s\n", stripeSub.ID, stripeSub.Customer)
+		return nil
+	}
+
+	status := stripeSub.Status
+	if event.Type == "customer.subscription.deleted" {
+		status = models.SubscriptionStatusCanceled
+	}
+
+	plan := models.PlanFree
+	if s.isProSubscription(&stripeSub) {
+		plan = models.PlanPro
+	}
+
+	var periodEnd *time.Time
+	if stripeSub.CurrentPeriodEnd > 0 {
+		end := time.Unix(stripeSub.CurrentPeriodEnd, 0)
+		periodEnd = &end
+	}
+
+	previous, err := s.billingRepo.GetSubscription(userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.billingRepo.UpdateFromStripe(userID, plan, status, stripeSub.ID, periodEnd, stripeSub.CancelAtPeriodEnd); err != nil {
+		return err
+	}
+
+	current, err := s.billingRepo.GetSubscription(userID)
+	if err != nil {
+		return err
+	}
+
+	return s.recordPlanChange(previous, current)
+}
+
+// recordPlanChange tracks when a user loses or regains the pro plan, telling them when they lose it
+func (s *BillingService) recordPlanChange(previous, current *models.Subscription) error {
+	wasPro := previous.EffectivePlan() == models.PlanPro
+	isPro := current.EffectivePlan() == models.PlanPro
+
+	switch {
+	case wasPro && !isPro:
+		now := time.Now()
+		if err := s.billingRepo.SetDowngradedAt(current.UserID, &now); err != nil {
+			return err
+		}
+		s.notify(current.UserID, models.BillingNoticeDowngraded, now.UTC().Format(time.RFC3339),
+			"Your pro plan has ended",
+			"Your account is now on the free plan. Everything you created with pro features is still there to view; upgrade again any time to keep using them.")
+	case !wasPro && isPro && current.DowngradedAt != nil:
+		return s.billingRepo.SetDowngradedAt(current.UserID, nil)
+	}
+
+	return nil
+}
+
+// notifyPaymentFailed tells a user that Stripe couldn't charge them for their subscription
+func (s *BillingService) notifyPaymentFailed(event *billing.Event) error {
+	var invoice billing.Invoice
+	if err := decodeStripeObject(event, &invoice); err != nil {
+		return err
+	}
+
+	userID, err := s.billingRepo.GetUserIDByCustomerID(invoice.Customer)
+	if err != nil {
+		fmt.Printf("Warning: ignoring failed Stripe invoice %s for unknown customer %s\n", invoice.ID, invoice.Customer)
+		return nil
+	}
+
+	body := "We couldn't charge your card for your pro subscription. Update your payment method to keep your pro features."
+	if invoice.NextPaymentAttempt > 0 {
+		body += fmt.Sprintf(" We'll try again on %s.", time.Unix(invoice.NextPaymentAttempt, 0).UTC().Format("January 2"))
+	}
+
+	s.notify(userID, models.BillingNoticePaymentFailed, fmt.Sprintf("%s/%d", invoice.ID, invoice.AttemptCount),
+		"Your payment failed", body)
+	return nil
+}
+
+// ExpireTrials warns users whose trial ends soon and moves those whose trial has ended to the free plan
+func (s *BillingService) ExpireTrials(ctx context.Context) error {
+	if !s.Enabled() {
+		return nil
+	}
+
+	now := time.Now()
+	noticeWindow := time.Duration(s.plans.TrialNoticeDays) * 24 * time.Hour
+	trials, err := s.billingRepo.GetTrialsEndingBefore(now.Add(noticeWindow))
+	if err != nil {
+		return err
+	}
+
+	for _, trial := range trials {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		reference := trial.TrialEndsAt.UTC().Format(time.DateOnly)
+		if trial.TrialEndsAt.After(now) {
+			s.notify(trial.UserID, models.BillingNoticeTrialEnding, reference,
+				"Your pro trial ends soon",
+				fmt.Sprintf("Your pro trial ends on %s. Upgrade to keep AI hints, unlimited tests and extra organization seats.", trial.TrialEndsAt.UTC().Format("January 2")))
+			continue
+		}
+
+		expired, err := s.billingRepo.ExpireTrial(trial.UserID, now)
+		if err != nil {
+			fmt.Printf("Warning: failed to expire trial for user %d: %v\n", trial.UserID, err)
+			continue
+		}
+		if expired {
+			s.notify(trial.UserID, models.BillingNoticeTrialExpired, reference,
+				"Your pro trial has ended",
+				"Your account is now on the free plan. Everything you created during the trial is still there to view; upgrade any time to keep using pro features.")
+		}
+	}
+
+	return nil
+}
+
+// SendDunningReminders reminds users whose subscription is past due to update their payment method
+func (s *BillingService) SendDunningReminders(ctx context.Context) error {
+	if !s.Enabled() {
+		return nil
+	}
+
+	subs, err := s.billingRepo.GetPastDue()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, sub := range subs {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		daysPastDue := int(now.Sub(*sub.PastDueSince).Hours() / 24)
+		for i := len(dunningReminderDays) - 1; i >= 0; i-- {
+			day := dunningReminderDays[i]
+			if daysPastDue < day {
+				continue
+			}
+			s.notify(sub.UserID, models.BillingNoticePaymentOverdue,
+				fmt.Sprintf("%s/day-%d", sub.PastDueSince.UTC().Format(time.DateOnly), day),
+				"Your subscription payment is overdue",
+				fmt.Sprintf("Your pro subscription payment has been overdue for %d days. Update your payment method to avoid losing your pro features.", daysPastDue))
+			break
+		}
+	}
+
+	return nil
+}
+
+// notify claims a billing notice and sends it by email and over the notification channels;
+// failures are logged rather than surfaced
+func (s *BillingService) notify(userID int, kind models.BillingNoticeKind, reference, subject, body string) {
+	claimed, err := s.billingRepo.ClaimNotice(userID, kind, reference)
+	if err != nil {
+		fmt.Printf("Warning: failed to record %s billing notice for user %d: %v\n", kind, userID, err)
+		return
+	}
+	if !claimed {
+		return
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		fmt.Printf("Warning: failed to load user %d for %s billing notice: %v\n", userID, kind, err)
+		return
+	}
+
+	link := s.appBaseURL + "/billing"
+	if s.mailer != nil {
+		err = s.mailer.Send(mailer.Message{
+			To:      user.Email,
+			Subject: subject,
+			Body:    fmt.Sprintf("Hi %s,\n\n%s\n\nManage your plan: %s\n", user.Name, body, link),
+		})
+		if err != nil {
+			fmt.Printf("Warning: failed to send %s billing notice to user %d: %v\n", kind, userID, err)
+		}
+	}
+
+	for _, channel := range s.channels {
+		err := channel.Notify(context.Background(), plugins.Notification{
+			UserID:  userID,
+			Email:   user.Email,
+			Name:    user.Name,
+			Kind:    string(kind),
+			Subject: subject,
+			Body:    body,
+			Link:    link,
+		})
+		if err != nil {
+			fmt.Printf("Warning: failed to send %s billing notice to user %d via %s: %v\n", kind, userID, channel.Name(), err)
+		}
+	}
+}
+
+// isProSubscription reports whether a Stripe subscription is for the pro plan's price
+func (s *BillingService) isProSubscription(sub *billing.Subscription) bool {
+	for _, priceID := range sub.PriceIDs() {
+		if priceID == s.plans.ProPriceID {
+			return true
+		}
+	}
+	return false
+}
+
+// SetPlan lets an admin grant the pro plan without a Stripe subscription, or revoke that grant.
+// Revoking leaves the user on whatever plan their Stripe subscription pays for.
+func (s *BillingService) SetPlan(userID int, plan models.Plan) (*models.Entitlements, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+	if !models.IsValidPlan(plan) {
+		return nil, fmt.Errorf("invalid plan: %s", plan)
+	}
+
+	if _, err := s.userRepo.GetByID(userID); err != nil {
+		return nil, err
+	}
+
+	previous, err := s.billingRepo.GetSubscription(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.billingRepo.SetComped(userID, plan == models.PlanPro); err != nil {
+		return nil, err
+	}
+
+	current, err := s.billingRepo.GetSubscription(userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.recordPlanChange(previous, current); err != nil {
+		return nil, err
+	}
+
+	return s.GetEntitlements(userID)
+}
+
+// decodeStripeObject decodes the object a Stripe event is about
+func decodeStripeObject(event *billing.Event, out interface{}) error {
+	if err := json.Unmarshal(event.Data.Object, out); err != nil {
+		return fmt.Errorf("invalid %s event: %w", event.Type, err)
+	}
+	return nil
+}
@@ -0,0 +1,275 @@
+package services
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+	"interview-prep-app/pkg/apperr"
+)
+
+const (
+	// calendarHorizonDays is how far ahead the feed schedules plan blocks and reviews
+	calendarHorizonDays = 14
+	// calendarMaxReviews caps how many scheduled reviews are pulled into one feed
+	calendarMaxReviews = 500
+	// minutes budgeted per item in a daily-plan block and per item in a review session
+	planMinutesPerItem   = 25
+	reviewMinutesPerItem = 10
+	maxBlockMinutes      = 4 * 60
+)
+
+// CalendarService builds the tokenized ICS feed of a user's daily plan and scheduled reviews
+type CalendarService struct {
+	calendarRepo     *repositories.CalendarRepository
+	itemRepo         *repositories.ItemRepository
+	statsRepo        *repositories.StatsRepository
+	notificationRepo *repositories.NotificationRepository
+	feedBaseURL      string
+	appBaseURL       string
+}
+
+// NewCalendarService creates a new calendar service. feedBaseURL is the public URL of this API,
+// appBaseURL the frontend that event descriptions link back to.
+func NewCalendarService(calendarRepo *repositories.CalendarRepository, itemRepo *repositories.ItemRepository, statsRepo *repositories.StatsRepository, notificationRepo *repositories.NotificationRepository, feedBaseURL, appBaseURL string) *CalendarService {
+	return &CalendarService{
+		calendarRepo:     calendarRepo,
+		itemRepo:         itemRepo,
+		statsRepo:        statsRepo,
+		notificationRepo: notificationRepo,
+		feedBaseURL:      strings.TrimRight(feedBaseURL, "/"),
+		appBaseURL:       strings.TrimRight(appBaseURL, "/"),
+	}
+}
+
+// GetFeed returns whether the user has a feed; the URL itself is only shown when it's created
+func (s *CalendarService) GetFeed(userID int) (*models.CalendarFeed, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	return s.calendarRepo.GetFeed(userID)
+}
+
+// CreateFeed issues a new feed URL for the user, revoking any previous one
+func (s *CalendarService) CreateFeed(userID int) (*models.CalendarFeed, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	token, err := generateSecretToken()
+	if err != nil {
+		return nil, err
+	}
+
+	feed, err := s.calendarRepo.SaveToken(userID, hashSecretToken(token))
+	if err != nil {
+		return nil, err
+	}
+
+	feed.URL = s.feedBaseURL + "/api/v1/calendar.ics?token=" + url.QueryEscape(token)
+	return feed, nil
+}
+
+// DeleteFeed revokes the user's feed so calendar apps stop receiving updates
+func (s *CalendarService) DeleteFeed(userID int) error {
+	if userID <= 0 {
+		return fmt.Errorf("invalid user ID")
+	}
+
+	return s.calendarRepo.DeleteToken(userID)
+}
+
+// RenderFeed resolves a feed token and returns the owner's calendar as an ICS document
+func (s *CalendarService) RenderFeed(token string, now time.Time) ([]byte, error) {
+	if token == "" {
+		return nil, apperr.NotFound("calendar feed not found")
+	}
+
+	userID, err := s.calendarRepo.GetUserIDByToken(hashSecretToken(token))
+	if err != nil {
+		return nil, err
+	}
+
+	prefs, err := s.notificationRepo.GetPreferences(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	goal, err := s.statsRepo.GetDailyGoalProgress(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	reviews, err := s.itemRepo.GetDueReviewsForUser(userID, now.AddDate(0, 0, calendarHorizonDays), calendarMaxReviews)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := time.LoadLocation(prefs.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	localNow := now.In(loc)
+	startHour, startMinute := 19, 0
+	if t, err := time.Parse("15:04", prefs.ReminderTime); err == nil {
+		startHour, startMinute = t.Hour(), t.Minute()
+	}
+
+	// Overdue reviews are rolled onto today so they stay visible until done
+	today := time.Date(localNow.Year(), localNow.Month(), localNow.Day(), 0, 0, 0, 0, loc)
+	reviewsByDay := make(map[string][]*models.ItemWithProgress)
+	for _, item := range reviews {
+		day := today
+		if item.NextReviewAt != nil && item.NextReviewAt.After(now) {
+			due := item.NextReviewAt.In(loc)
+			day = time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, loc)
+		}
+		key := day.Format("20060102")
+		reviewsByDay[key] = append(reviewsByDay[key], item)
+	}
+
+	cal := newICSCalendar()
+	for i := 0; i < calendarHorizonDays; i++ {
+		day := today.AddDate(0, 0, i)
+		key := day.Format("20060102")
+		start := time.Date(day.Year(), day.Month(), day.Day(), startHour, startMinute, 0, 0, loc)
+
+		if goal.DailyGoal > 0 {
+			duration := blockDuration(goal.DailyGoal, planMinutesPerItem)
+			cal.addEvent(icsEvent{
+				UID:         fmt.Sprintf("plan-%d-%s@prepmaster", userID, key),
+				Start:       start,
+				End:         start.Add(duration),
+				Summary:     fmt.Sprintf("Interview prep: %d %s", goal.DailyGoal, pluralize(goal.DailyGoal, "item", "items")),
+				Description: fmt.Sprintf("Work through today's goal of %d %s.\n%s", goal.DailyGoal, pluralize(goal.DailyGoal, "item", "items"), s.appBaseURL),
+				URL:         s.appBaseURL,
+			})
+			start = start.Add(duration)
+		}
+
+		due := reviewsByDay[key]
+		if len(due) == 0 {
+			continue
+		}
+
+		var lines []string
+		for _, item := range due {
+			lines = append(lines, fmt.Sprintf("- %s: %s", item.Title, item.Link))
+		}
+		cal.addEvent(icsEvent{
+			UID:         fmt.Sprintf("reviews-%d-%s@prepmaster", userID, key),
+			Start:       start,
+			End:         start.Add(blockDuration(len(due), reviewMinutesPerItem)),
+			Summary:     fmt.Sprintf("Review %d %s", len(due), pluralize(len(due), "item", "items")),
+			Description: strings.Join(lines, "\n"),
+			URL:         s.appBaseURL,
+		})
+	}
+
+	return cal.encode(now), nil
+}
+
+// blockDuration sizes a calendar block for n items, capped so a big backlog doesn't fill the day
+func blockDuration(n, minutesPerItem int) time.Duration {
+	minutes := n * minutesPerItem
+	if minutes > maxBlockMinutes {
+		minutes = maxBlockMinutes
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// icsEvent is a single VEVENT in the feed
+type icsEvent struct {
+	UID         string
+	Start       time.Time
+	End         time.Time
+	Summary     string
+	Description string
+	URL         string
+}
+
+// icsCalendar is a minimal RFC 5545 writer covering what the feed needs
+type icsCalendar struct {
+	events []icsEvent
+}
+
+func newICSCalendar() *icsCalendar {
+	return &icsCalendar{}
+}
+
+func (c *icsCalendar) addEvent(e icsEvent) {
+	c.events = append(c.events, e)
+}
+
+// encode renders the calendar. Times are written in UTC so no VTIMEZONE blocks are needed;
+// each event was already placed on the user's local clock before conversion.
+func (c *icsCalendar) encode(now time.Time) []byte {
+	sort.SliceStable(c.events, func(i, j int) bool { return c.events[i].Start.Before(c.events[j].Start) })
+
+	var b strings.Builder
+	writeICSLine(&b, "BEGIN:VCALENDAR")
+	writeICSLine(&b, "VERSION:2.0")
+	writeICSLine(&b, "PRODID:-//PrepMaster//Study Calendar//EN")
+	writeICSLine(&b, "CALSCALE:GREGORIAN")
+	writeICSLine(&b, "METHOD:PUBLISH")
+	writeICSLine(&b, "X-WR-CALNAME:PrepMaster")
+	writeICSLine(&b, "REFRESH-INTERVAL;VALUE=DURATION:PT6H")
+	writeICSLine(&b, "X-PUBLISHED-TTL:PT6H")
+
+	stamp := formatICSTime(now)
+	for _, e := range c.events {
+		writeICSLine(&b, "BEGIN:VEVENT")
+		writeICSLine(&b, "UID:"+e.UID)
+		writeICSLine(&b, "DTSTAMP:"+stamp)
+		writeICSLine(&b, "DTSTART:"+formatICSTime(e.Start))
+		writeICSLine(&b, "DTEND:"+formatICSTime(e.End))
+		writeICSLine(&b, "SUMMARY:"+escapeICSText(e.Summary))
+		if e.Description != "" {
+			writeICSLine(&b, "DESCRIPTION:"+escapeICSText(e.Description))
+		}
+		if e.URL != "" {
+			writeICSLine(&b, "URL:"+e.URL)
+		}
+		writeICSLine(&b, "TRANSP:TRANSPARENT")
+		writeICSLine(&b, "END:VEVENT")
+	}
+	writeICSLine(&b, "END:VCALENDAR")
+
+	return []byte(b.String())
+}
+
+func formatICSTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// escapeICSText escapes a TEXT property value
+func escapeICSText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// writeICSLine writes a content line, folding it at 75 octets without splitting UTF-8 sequences
+func writeICSLine(b *strings.Builder, line string) {
+	// Continuation lines lose one octet to the leading space
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isUTF8Start(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = 74
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+func isUTF8Start(c byte) bool {
+	return c&0xC0 != 0x80
+}
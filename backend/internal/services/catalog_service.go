@@ -0,0 +1,95 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"interview-prep-app/internal/catalog"
+	"interview-prep-app/internal/events"
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+	"interview-prep-app/pkg/apperr"
+)
+
+// CatalogService copies the global content catalog between environments: it exports a snapshot
+// from one and applies it to another, reporting what would change before anything is written
+type CatalogService struct {
+	catalogRepo     *repositories.CatalogRepository
+	categoryService *CategoryService
+	bus             events.Bus
+	environment     string
+}
+
+// NewCatalogService creates a new catalog service for the named environment
+func NewCatalogService(catalogRepo *repositories.CatalogRepository, categoryService *CategoryService, bus events.Bus, environment string) *CatalogService {
+	return &CatalogService{
+		catalogRepo:     catalogRepo,
+		categoryService: categoryService,
+		bus:             bus,
+		environment:     environment,
+	}
+}
+
+// Export returns a snapshot of the catalog
+func (s *CatalogService) Export() (*models.CatalogSnapshot, error) {
+	snapshot, err := s.catalogRepo.Export()
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot.Source = s.environment
+	snapshot.ExportedAt = time.Now().UTC()
+	if snapshot.Categories, err = s.categoryService.GetCatalogCategories(); err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// Apply diffs a snapshot against the catalog and, unless dryRun, writes the differences.
+// Applying is idempotent: a snapshot that's already been applied reports no changes.
+func (s *CatalogService) Apply(snapshot *models.CatalogSnapshot, dryRun bool) (*models.CatalogDiff, error) {
+	if snapshot == nil {
+		return nil, fmt.Errorf("snapshot is required")
+	}
+
+	if err := catalog.Validate(snapshot); err != nil {
+		return nil, err
+	}
+
+	// Categories are managed per environment, so a snapshot may use ones this environment lacks
+	categories := map[models.Category]bool{}
+	for _, category := range snapshot.Categories {
+		categories[category.Category] = true
+	}
+	for _, item := range snapshot.Items {
+		categories[item.Category] = true
+	}
+	for category := range categories {
+		if _, err := s.categoryService.GetCategory(category); err != nil {
+			if errors.Is(err, apperr.ErrNotFound) {
+				return nil, fmt.Errorf("category %s does not exist in this environment", category)
+			}
+			return nil, err
+		}
+	}
+
+	current, err := s.catalogRepo.Export()
+	if err != nil {
+		return nil, err
+	}
+
+	diff := catalog.Diff(current, snapshot)
+	diff.DryRun = dryRun
+	if dryRun || len(diff.Changes) == 0 {
+		return diff, nil
+	}
+
+	if err := s.catalogRepo.Apply(snapshot); err != nil {
+		return nil, err
+	}
+	publishEvent(s.bus, events.CatalogChanged, 0, map[string]int{"created": diff.Created, "updated": diff.Updated})
+
+	return diff, nil
+}
@@ -0,0 +1,230 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/validation"
+	"interview-prep-app/pkg/apperr"
+)
+
+const (
+	// categoryCacheTTL is how long categories are served from memory. Changes made through this
+	// instance take effect immediately; other instances pick them up within the TTL.
+	categoryCacheTTL = time.Minute
+	// maxCategorySubcategories caps how many subcategories a category can list
+	maxCategorySubcategories = 200
+)
+
+// CategoryService manages the categories items are filed under and validates categories against
+// them, keeping a short-lived in-memory copy since validation runs on most item requests
+type CategoryService struct {
+	categoryRepo CategoryStore
+
+	mu         sync.Mutex
+	categories []*models.CategoryDefinition
+	loadedAt   time.Time
+}
+
+// NewCategoryService creates a new category service
+func NewCategoryService(categoryRepo CategoryStore) *CategoryService {
+	return &CategoryService{categoryRepo: categoryRepo}
+}
+
+// GetCategories returns every category with its subcategories, in display order
+func (s *CategoryService) GetCategories() ([]*models.CategoryDefinition, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.categories != nil && time.Since(s.loadedAt) < categoryCacheTTL {
+		return s.categories, nil
+	}
+
+	categories, err := s.categoryRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	s.categories = categories
+	s.loadedAt = time.Now()
+	return categories, nil
+}
+
+// GetCategory returns a single category
+func (s *CategoryService) GetCategory(slug models.Category) (*models.CategoryDefinition, error) {
+	categories, err := s.GetCategories()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, category := range categories {
+		if category.Slug == slug {
+			return category, nil
+		}
+	}
+
+	return nil, apperr.NotFound("category not found")
+}
+
+// ValidateCategory returns an "invalid category" error listing the valid ones unless the category exists
+func (s *CategoryService) ValidateCategory(category models.Category) error {
+	categories, err := s.GetCategories()
+	if err != nil {
+		return err
+	}
+
+	slugs := make([]models.Category, 0, len(categories))
+	for _, existing := range categories {
+		if existing.Slug == category {
+			return nil
+		}
+		slugs = append(slugs, existing.Slug)
+	}
+
+	return validation.Field("category", "category", fmt.Sprintf("invalid category: %s. Valid categories are: %v", category, slugs))
+}
+
+// GetSubcategories returns the subcategories listed for a category
+func (s *CategoryService) GetSubcategories(category models.Category) ([]string, error) {
+	existing, err := s.GetCategory(category)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return nil, fmt.Errorf("invalid category: %s", category)
+		}
+		return nil, err
+	}
+
+	return existing.Subcategories, nil
+}
+
+// GetCatalogCategories lists every category with its subcategories for catalog snapshots and the client config
+func (s *CategoryService) GetCatalogCategories() ([]models.CatalogCategory, error) {
+	categories, err := s.GetCategories()
+	if err != nil {
+		return nil, err
+	}
+
+	catalogCategories := make([]models.CatalogCategory, 0, len(categories))
+	for _, category := range categories {
+		catalogCategories = append(catalogCategories, models.CatalogCategory{
+			Category:      category.Slug,
+			Subcategories: category.Subcategories,
+		})
+	}
+
+	return catalogCategories, nil
+}
+
+// CreateCategory adds a category
+func (s *CategoryService) CreateCategory(req *models.CreateCategoryRequest) (*models.CategoryDefinition, error) {
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+	slug := req.Slug
+	name := strings.TrimSpace(req.Name)
+
+	subcategories, err := cleanSubcategories(req.Subcategories)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.GetCategory(slug); err == nil {
+		return nil, apperr.Conflict("category already exists")
+	}
+
+	category := &models.CategoryDefinition{
+		Slug:          slug,
+		Name:          name,
+		OrderIdx:      req.OrderIdx,
+		Subcategories: subcategories,
+	}
+	if err := s.categoryRepo.Create(category); err != nil {
+		return nil, err
+	}
+
+	s.invalidate()
+	return category, nil
+}
+
+// UpdateCategory renames or reorders a category, or replaces its subcategory list
+func (s *CategoryService) UpdateCategory(slug models.Category, req *models.UpdateCategoryRequest) (*models.CategoryDefinition, error) {
+	if req.Name == nil && req.OrderIdx == nil && req.Subcategories == nil {
+		return nil, fmt.Errorf("no fields to update")
+	}
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	s.invalidate()
+	existing, err := s.GetCategory(slug)
+	if err != nil {
+		return nil, err
+	}
+
+	name, orderIdx := existing.Name, existing.OrderIdx
+	if req.Name != nil {
+		name = strings.TrimSpace(*req.Name)
+	}
+	if req.OrderIdx != nil {
+		orderIdx = *req.OrderIdx
+	}
+
+	var subcategories []string
+	if req.Subcategories != nil {
+		if subcategories, err = cleanSubcategories(*req.Subcategories); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.categoryRepo.Update(slug, name, orderIdx, subcategories); err != nil {
+		return nil, err
+	}
+
+	s.invalidate()
+	return s.GetCategory(slug)
+}
+
+// DeleteCategory removes a category no items are filed under
+func (s *CategoryService) DeleteCategory(slug models.Category) error {
+	if slug == "" {
+		return apperr.NotFound("category not found")
+	}
+
+	if err := s.categoryRepo.Delete(slug); err != nil {
+		return err
+	}
+
+	s.invalidate()
+	return nil
+}
+
+// invalidate drops the cached categories so the next read reloads them
+func (s *CategoryService) invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.categories = nil
+}
+
+// cleanSubcategories trims a subcategory list and drops blanks and repeats, keeping the order
+func cleanSubcategories(subcategories []string) ([]string, error) {
+	cleaned := []string{}
+	seen := map[string]bool{}
+	for _, name := range subcategories {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		cleaned = append(cleaned, name)
+	}
+
+	if len(cleaned) > maxCategorySubcategories {
+		return nil, fmt.Errorf("a category can list at most %d subcategories", maxCategorySubcategories)
+	}
+
+	return cleaned, nil
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"interview-prep-app/internal/i18n"
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+)
+
+// isStreakMilestone reports whether a streak change reached a milestone. Chat integrations only
+// post these; every other day's streak change would just be noise in the channel.
+func isStreakMilestone(data models.StreakChangedData) bool {
+	return data.CurrentStreak > data.PreviousStreak && streakMilestones[data.CurrentStreak]
+}
+
+// validIntegrationURL checks that a chat integration URL is an incoming webhook on the platform
+// it's being connected to, so the integration endpoints can't be used to post anywhere else
+func validIntegrationURL(format models.WebhookFormat, rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "https" || u.User != nil || u.Port() != "" {
+		return false
+	}
+
+	switch format {
+	case models.WebhookFormatSlack:
+		return u.Host == "hooks.slack.com" && strings.HasPrefix(u.Path, "/services/")
+	case models.WebhookFormatDiscord:
+		return (u.Host == "discord.com" || u.Host == "discordapp.com") && strings.HasPrefix(u.Path, "/api/webhooks/")
+	}
+	return false
+}
+
+// chatMessage renders an event as the message body for a Slack or Discord incoming webhook.
+// It returns false for events the channel shouldn't hear about. The text is written in locale.
+func chatMessage(format models.WebhookFormat, locale string, event models.WebhookEvent, data interface{}) ([]byte, bool, error) {
+	// Slack and Discord disagree on bold markers and which characters need escaping
+	bold, escape := "*", slackEscaper.Replace
+	if format == models.WebhookFormatDiscord {
+		bold, escape = "**", discordEscaper.Replace
+	}
+
+	var text string
+	switch d := data.(type) {
+	case *models.ItemWithProgress:
+		text = i18n.T(locale, "Completed %s%s%s (%s / %s)", bold, escape(d.Title), bold, d.Category, escape(d.Subcategory))
+	case models.StreakChangedData:
+		if !isStreakMilestone(d) {
+			return nil, false, nil
+		}
+		text = i18n.T(locale, "Reached a %s%d-day%s streak!", bold, d.CurrentStreak, bold)
+	case models.TestCompletedData:
+		completed := 0
+		for _, item := range d.Items {
+			if item.Status == models.TestStatusCompleted {
+				completed++
+			}
+		}
+		text = i18n.T(locale, "Finished a test: %s%d of %d%s items completed", bold, completed, len(d.Items), bold)
+	case *models.WeeklyDigestData:
+		text = i18n.N(locale,
+			"%sWeekly digest%s: %d item completed since %s, %d-day streak (longest %d)",
+			"%sWeekly digest%s: %d items completed since %s, %d-day streak (longest %d)",
+			d.Completed, bold, bold, d.Completed, d.WeekStart.UTC().Format("Jan 2"), d.CurrentStreak, d.LongestStreak)
+	default:
+		text = i18n.T(locale, "Prep Master event: %s", event)
+	}
+
+	var body interface{}
+	if format == models.WebhookFormatDiscord {
+		// Titles come from users and feeds; never let them ping the channel
+		body = map[string]interface{}{"content": text, "allowed_mentions": map[string][]string{"parse": {}}}
+	} else {
+		body = map[string]string{"text": text}
+	}
+
+	message, err := json.Marshal(body)
+	if err != nil {
+		return nil, false, apperr.Errorf("failed to encode %s message: %w", format, err)
+	}
+	return message, true, nil
+}
+
+// slackEscaper escapes the characters Slack treats as control sequences in message text
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// discordEscaper escapes Discord markdown so titles render literally
+var discordEscaper = strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "~", `\~`, "`", "\\`", "|", `\|`, ">", `\>`)
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"interview-prep-app/internal/models"
+)
+
+func TestChatMessage(t *testing.T) {
+	item := &models.ItemWithProgress{Title: "<Two Sum> *fast*", Category: models.CategoryDSA, Subcategory: "arrays"}
+
+	body, post, err := chatMessage(models.WebhookFormatSlack, "en", models.WebhookEventItemCompleted, item)
+	if err != nil || !post {
+		t.Fatalf("Expected a Slack message, got post=%v err=%v", post, err)
+	}
+	var slack struct {
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(body, &slack); err != nil {
+		t.Fatalf("Invalid Slack message: %v", err)
+	}
+	if slack.Text != "Completed *&lt;Two Sum&gt; *fast** (dsa / arrays)" {
+		t.Errorf("Unexpected Slack text %q", slack.Text)
+	}
+
+	body, _, err = chatMessage(models.WebhookFormatDiscord, "en", models.WebhookEventItemCompleted, item)
+	if err != nil {
+		t.Fatalf("chatMessage returned error: %v", err)
+	}
+	var discord struct {
+		Content         string              `json:"content"`
+		AllowedMentions map[string][]string `json:"allowed_mentions"`
+	}
+	if err := json.Unmarshal(body, &discord); err != nil {
+		t.Fatalf("Invalid Discord message: %v", err)
+	}
+	if discord.Content != `Completed **<Two Sum\> \*fast\*** (dsa / arrays)` {
+		t.Errorf("Unexpected Discord content %q", discord.Content)
+	}
+	if parse, ok := discord.AllowedMentions["parse"]; !ok || len(parse) != 0 {
+		t.Errorf("Expected Discord mentions to be disabled, got %v", discord.AllowedMentions)
+	}
+
+	digest := &models.WeeklyDigestData{WeekStart: time.Date(2026, 3, 7, 9, 0, 0, 0, time.UTC), Completed: 1, CurrentStreak: 4, LongestStreak: 9}
+	body, _, _ = chatMessage(models.WebhookFormatSlack, "en", models.WebhookEventWeeklyDigest, digest)
+	if !strings.Contains(string(body), "*Weekly digest*: 1 item completed since Mar 7, 4-day streak (longest 9)") {
+		t.Errorf("Unexpected digest message %s", body)
+	}
+}
+
+func TestChatMessageStreakMilestones(t *testing.T) {
+	for _, tc := range []struct {
+		previous, current int
+		post              bool
+	}{
+		{6, 7, true},
+		{29, 30, true},
+		{7, 8, false},
+		{30, 0, false},
+		{0, 1, false},
+	} {
+		data := models.StreakChangedData{PreviousStreak: tc.previous, CurrentStreak: tc.current, LongestStreak: tc.current}
+		_, post, err := chatMessage(models.WebhookFormatDiscord, "en", models.WebhookEventStreakChanged, data)
+		if err != nil || post != tc.post {
+			t.Errorf("%d -> %d: expected post=%v, got post=%v err=%v", tc.previous, tc.current, tc.post, post, err)
+		}
+	}
+}
+
+func TestValidIntegrationURL(t *testing.T) {
+	for _, tc := range []struct {
+		format models.WebhookFormat
+		url    string
+		valid  bool
+	}{
+		{models.WebhookFormatSlack, "https://hooks.slack.com/services/T000/B000/XXXX", true},
+		{models.WebhookFormatSlack, "http://hooks.slack.com/services/T000/B000/XXXX", false},
+		{models.WebhookFormatSlack, "https://hooks.slack.com.example.com/services/T000", false},
+		{models.WebhookFormatSlack, "https://discord.com/api/webhooks/1/abc", false},
+		{models.WebhookFormatDiscord, "https://discord.com/api/webhooks/1/abc", true},
+		{models.WebhookFormatDiscord, "https://discordapp.com/api/webhooks/1/abc", true},
+		{models.WebhookFormatDiscord, "https://discord.com:8443/api/webhooks/1/abc", false},
+		{models.WebhookFormatDiscord, "https://user@discord.com/api/webhooks/1/abc", false},
+		{models.WebhookFormatJSON, "https://example.com/hook", false},
+	} {
+		if got := validIntegrationURL(tc.format, tc.url); got != tc.valid {
+			t.Errorf("%s %s: expected %v, got %v", tc.format, tc.url, tc.valid, got)
+		}
+	}
+}
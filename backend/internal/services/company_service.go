@@ -0,0 +1,156 @@
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+	"interview-prep-app/internal/validation"
+	"interview-prep-app/pkg/apperr"
+)
+
+// maxItemCompanies caps how many companies a single item can be tagged with
+const maxItemCompanies = 50
+
+var (
+	companySlugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	nonSlugChars       = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// CompanyService handles business logic for company-tagged question tracks
+type CompanyService struct {
+	companyRepo *repositories.CompanyRepository
+	itemRepo    *repositories.ItemRepository
+}
+
+// NewCompanyService creates a new company service
+func NewCompanyService(companyRepo *repositories.CompanyRepository, itemRepo *repositories.ItemRepository) *CompanyService {
+	return &CompanyService{
+		companyRepo: companyRepo,
+		itemRepo:    itemRepo,
+	}
+}
+
+// GetCompanies returns every company
+func (s *CompanyService) GetCompanies() ([]*models.Company, error) {
+	return s.companyRepo.GetAll()
+}
+
+// CreateCompany adds a company, deriving its slug from the name when none is given
+func (s *CompanyService) CreateCompany(req *models.CreateCompanyRequest) (*models.Company, error) {
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(req.Name)
+
+	slug := strings.TrimSpace(req.Slug)
+	if slug == "" {
+		slug = companySlug(name)
+	}
+	if len(slug) > 100 || !companySlugPattern.MatchString(slug) {
+		return nil, fmt.Errorf("invalid slug: use lowercase letters, digits and single hyphens")
+	}
+
+	existing, err := s.companyRepo.GetBySlugs([]string{slug})
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) > 0 {
+		return nil, apperr.Conflict("company already exists")
+	}
+
+	company := &models.Company{Slug: slug, Name: name}
+	if err := s.companyRepo.Create(company); err != nil {
+		return nil, err
+	}
+
+	return company, nil
+}
+
+// DeleteCompany removes a company and untags its items
+func (s *CompanyService) DeleteCompany(slug string) error {
+	if slug == "" {
+		return apperr.NotFound("company not found")
+	}
+
+	return s.companyRepo.Delete(slug)
+}
+
+// GetItemCompanies returns the companies an item is tagged with
+func (s *CompanyService) GetItemCompanies(itemID int) ([]*models.Company, error) {
+	if itemID <= 0 {
+		return nil, fmt.Errorf("invalid item ID")
+	}
+
+	if _, err := s.itemRepo.GetPublishedByID(itemID); err != nil {
+		return nil, err
+	}
+
+	return s.companyRepo.GetForItem(itemID)
+}
+
+// SetItemCompanies replaces an item's company tags with the given company slugs
+func (s *CompanyService) SetItemCompanies(itemID int, req *models.SetItemCompaniesRequest) ([]*models.Company, error) {
+	if itemID <= 0 {
+		return nil, fmt.Errorf("invalid item ID")
+	}
+
+	if len(req.Companies) > maxItemCompanies {
+		return nil, fmt.Errorf("an item can be tagged with at most %d companies", maxItemCompanies)
+	}
+
+	if _, err := s.itemRepo.GetByID(itemID); err != nil {
+		return nil, err
+	}
+
+	companies, err := s.companyRepo.GetBySlugs(req.Companies)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]bool, len(companies))
+	companyIDs := make([]int, 0, len(companies))
+	for _, company := range companies {
+		found[company.Slug] = true
+		companyIDs = append(companyIDs, company.ID)
+	}
+	for _, slug := range req.Companies {
+		if !found[slug] {
+			return nil, fmt.Errorf("unknown company: %s", slug)
+		}
+	}
+
+	if err := s.companyRepo.SetForItem(itemID, companyIDs); err != nil {
+		return nil, err
+	}
+
+	return companies, nil
+}
+
+// GetCompanyStatsForUser returns the user's progress on each company's tagged items
+func (s *CompanyService) GetCompanyStatsForUser(userID int) ([]*models.CompanyStats, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	stats, err := s.companyRepo.GetStatsForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, stat := range stats {
+		stat.PendingItems = stat.TotalItems - stat.CompletedItems - stat.InProgressItems
+		if stat.TotalItems > 0 {
+			stat.ProgressPercentage = float64(stat.CompletedItems) / float64(stat.TotalItems) * 100
+		}
+	}
+
+	return stats, nil
+}
+
+// companySlug turns a company name into a URL-friendly slug, e.g. "Jane Street" -> "jane-street"
+func companySlug(name string) string {
+	return strings.Trim(nonSlugChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
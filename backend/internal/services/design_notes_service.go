@@ -0,0 +1,159 @@
+package services
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+)
+
+// DesignNotesService handles business logic for users' structured design notes on HLD items
+type DesignNotesService struct {
+	designNotesRepo *repositories.DesignNotesRepository
+	itemRepo        *repositories.ItemRepository
+}
+
+// NewDesignNotesService creates a new design notes service
+func NewDesignNotesService(designNotesRepo *repositories.DesignNotesRepository, itemRepo *repositories.ItemRepository) *DesignNotesService {
+	return &DesignNotesService{
+		designNotesRepo: designNotesRepo,
+		itemRepo:        itemRepo,
+	}
+}
+
+// GetDesignNotes returns the user's design notes for an HLD item; an item without notes gets an
+// empty template
+func (s *DesignNotesService) GetDesignNotes(userID, itemID int) (*models.ItemDesignNotes, error) {
+	if err := s.checkItem(userID, itemID); err != nil {
+		return nil, err
+	}
+
+	notes, err := s.designNotesRepo.Get(userID, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if notes == nil {
+		notes = &models.ItemDesignNotes{ItemID: itemID}
+	}
+
+	return notes, nil
+}
+
+// SaveDesignNotes validates and replaces the user's design notes for an HLD item. With a
+// version, notes changed since that version are left alone and a conflict is returned.
+func (s *DesignNotesService) SaveDesignNotes(userID, itemID int, req *models.SaveDesignNotesRequest) (*models.ItemDesignNotes, error) {
+	if err := s.checkItem(userID, itemID); err != nil {
+		return nil, err
+	}
+
+	cleaned, err := cleanDesignNotes(&req.DesignNotes)
+	if err != nil {
+		return nil, err
+	}
+
+	if cleaned.IsEmpty() {
+		return nil, fmt.Errorf("design notes cannot be empty")
+	}
+
+	return s.designNotesRepo.Save(userID, itemID, cleaned, req.Version)
+}
+
+// DeleteDesignNotes removes the user's design notes for an item
+func (s *DesignNotesService) DeleteDesignNotes(userID, itemID int) error {
+	if userID <= 0 {
+		return fmt.Errorf("invalid user ID")
+	}
+
+	if itemID <= 0 {
+		return fmt.Errorf("invalid item ID")
+	}
+
+	return s.designNotesRepo.Delete(userID, itemID)
+}
+
+// checkItem validates the IDs and that the item exists and is an HLD item
+func (s *DesignNotesService) checkItem(userID, itemID int) error {
+	if userID <= 0 {
+		return fmt.Errorf("invalid user ID")
+	}
+
+	if itemID <= 0 {
+		return fmt.Errorf("invalid item ID")
+	}
+
+	item, err := s.itemRepo.GetPublishedByID(itemID)
+	if err != nil {
+		return err
+	}
+
+	if item.Category != models.CategoryHLD {
+		return fmt.Errorf("design notes are only available for hld items")
+	}
+
+	return nil
+}
+
+// cleanDesignNotes trims every field, drops blank list entries and checks the API methods, paths
+// and diagram link
+func cleanDesignNotes(notes *models.DesignNotes) (models.DesignNotes, error) {
+	cleaned := models.DesignNotes{
+		Requirements: models.DesignRequirements{
+			Functional:    cleanDesignList(notes.Requirements.Functional),
+			NonFunctional: cleanDesignList(notes.Requirements.NonFunctional),
+		},
+		Estimation: strings.TrimSpace(notes.Estimation),
+		API:        []models.DesignAPIEndpoint{},
+		DataModel:  strings.TrimSpace(notes.DataModel),
+	}
+
+	for i, endpoint := range notes.API {
+		method := strings.ToUpper(strings.TrimSpace(endpoint.Method))
+		if !isDesignAPIMethod(method) {
+			return models.DesignNotes{}, fmt.Errorf("api[%d]: invalid method: %s. Valid methods are: %v", i, endpoint.Method, models.DesignAPIMethods)
+		}
+
+		path := strings.TrimSpace(endpoint.Path)
+		if !strings.HasPrefix(path, "/") {
+			return models.DesignNotes{}, fmt.Errorf("api[%d]: path must start with /", i)
+		}
+
+		cleaned.API = append(cleaned.API, models.DesignAPIEndpoint{
+			Method:      method,
+			Path:        path,
+			Description: strings.TrimSpace(endpoint.Description),
+		})
+	}
+
+	if link := strings.TrimSpace(notes.DiagramLink); link != "" {
+		target, err := url.Parse(link)
+		if err != nil || (target.Scheme != "https" && target.Scheme != "http") || target.Host == "" {
+			return models.DesignNotes{}, fmt.Errorf("diagram link must be an http or https URL")
+		}
+		cleaned.DiagramLink = link
+	}
+
+	return cleaned, nil
+}
+
+// cleanDesignList trims entries and drops the blank ones
+func cleanDesignList(entries []string) []string {
+	cleaned := []string{}
+	for _, entry := range entries {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			cleaned = append(cleaned, entry)
+		}
+	}
+	return cleaned
+}
+
+// isDesignAPIMethod checks if method is one of models.DesignAPIMethods
+func isDesignAPIMethod(method string) bool {
+	for _, valid := range models.DesignAPIMethods {
+		if method == valid {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"fmt"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+)
+
+// DocumentService handles business logic for users' saved documents, such as revision sheets
+type DocumentService struct {
+	documentRepo *repositories.DocumentRepository
+}
+
+// NewDocumentService creates a new document service
+func NewDocumentService(documentRepo *repositories.DocumentRepository) *DocumentService {
+	return &DocumentService{documentRepo: documentRepo}
+}
+
+// GetDocuments lists the user's documents, newest first, optionally of one category
+func (s *DocumentService) GetDocuments(userID int, category *models.Category) (*models.DocumentsResponse, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	documents, err := s.documentRepo.GetForUser(userID, category)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.DocumentsResponse{Documents: documents}, nil
+}
+
+// GetDocument returns one of the user's documents
+func (s *DocumentService) GetDocument(userID, documentID int) (*models.Document, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if documentID <= 0 {
+		return nil, fmt.Errorf("invalid document ID")
+	}
+
+	return s.documentRepo.GetByID(userID, documentID)
+}
+
+// DeleteDocument removes one of the user's documents
+func (s *DocumentService) DeleteDocument(userID, documentID int) error {
+	if userID <= 0 {
+		return fmt.Errorf("invalid user ID")
+	}
+
+	if documentID <= 0 {
+		return fmt.Errorf("invalid document ID")
+	}
+
+	return s.documentRepo.Delete(userID, documentID)
+}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"interview-prep-app/internal/events"
+	"interview-prep-app/internal/llm"
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+	"interview-prep-app/pkg/apperr"
+)
+
+const (
+	// embeddingBatchSize is how many items one refresh embeds, in a single request to the model
+	embeddingBatchSize = 100
+	// defaultSimilarItems is how many similar items are returned when no limit is given
+	defaultSimilarItems = 10
+)
+
+// EmbeddingService keeps an embedding of every item's title and categories and finds items
+// related in meaning from them. Personal notes are left out: embeddings are shared by every user.
+type EmbeddingService struct {
+	embeddingRepo *repositories.EmbeddingRepository
+	itemRepo      ItemStore
+	embedder      llm.Embedder
+}
+
+// NewEmbeddingService creates a new embedding service. Without an embedder, similar items are
+// unavailable.
+func NewEmbeddingService(embeddingRepo *repositories.EmbeddingRepository, itemRepo ItemStore, embedder llm.Embedder) *EmbeddingService {
+	return &EmbeddingService{
+		embeddingRepo: embeddingRepo,
+		itemRepo:      itemRepo,
+		embedder:      embedder,
+	}
+}
+
+// Enabled reports whether an embedder is configured
+func (s *EmbeddingService) Enabled() bool {
+	return s.embedder != nil
+}
+
+// Subscribe embeds items as they're created or edited
+func (s *EmbeddingService) Subscribe(bus events.Bus) {
+	if s.embedder == nil {
+		return
+	}
+
+	refresh := func(ctx context.Context, e events.Event) error {
+		return s.RefreshEmbeddings(ctx)
+	}
+	bus.Subscribe(events.CatalogChanged, "embeddings", refresh)
+	bus.Subscribe(events.ItemUpdated, "embeddings", refresh)
+}
+
+// RefreshEmbeddings embeds a batch of the items without an embedding of their current text
+// (run on catalog changes and on a schedule). This backfills items that predate embeddings and
+// catches up after an embedding model change.
+func (s *EmbeddingService) RefreshEmbeddings(ctx context.Context) error {
+	if s.embedder == nil {
+		return nil
+	}
+
+	states, err := s.embeddingRepo.GetStates(s.embedder.Model())
+	if err != nil {
+		return err
+	}
+
+	stale := []*models.ItemEmbeddingState{}
+	for _, state := range states {
+		if state.SourceHash != embeddingHash(itemEmbeddingText(state)) {
+			stale = append(stale, state)
+		}
+		if len(stale) == embeddingBatchSize {
+			break
+		}
+	}
+	if len(stale) == 0 {
+		return nil
+	}
+
+	if err := s.embed(ctx, stale); err != nil {
+		return err
+	}
+
+	fmt.Printf("Info: embedded %d items\n", len(stale))
+	return nil
+}
+
+// GetSimilar returns the items closest in meaning to an item across all categories, most similar
+// first. An item not embedded yet is embedded on the spot.
+func (s *EmbeddingService) GetSimilar(ctx context.Context, itemID, limit int) (*models.SimilarItemsResponse, error) {
+	if s.embedder == nil {
+		return nil, apperr.Unavailable("similar items are not configured")
+	}
+
+	if limit <= 0 {
+		limit = defaultSimilarItems
+	}
+	if limit > models.MaxSimilarItems {
+		limit = models.MaxSimilarItems
+	}
+
+	item, err := s.itemRepo.GetPublishedByID(itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, embedded, err := s.embeddingRepo.GetSimilar(item.ID, s.embedder.Model(), limit)
+	if err != nil {
+		return nil, err
+	}
+	if !embedded {
+		state := &models.ItemEmbeddingState{ItemID: item.ID, Title: item.Title, Category: item.Category, Subcategory: item.Subcategory}
+		if err := s.embed(ctx, []*models.ItemEmbeddingState{state}); err != nil {
+			return nil, err
+		}
+
+		if items, _, err = s.embeddingRepo.GetSimilar(item.ID, s.embedder.Model(), limit); err != nil {
+			return nil, err
+		}
+	}
+
+	return &models.SimilarItemsResponse{ItemID: item.ID, Items: items}, nil
+}
+
+// embed computes and stores the embeddings of items' current text
+func (s *EmbeddingService) embed(ctx context.Context, states []*models.ItemEmbeddingState) error {
+	texts := make([]string, len(states))
+	for i, state := range states {
+		texts[i] = itemEmbeddingText(state)
+	}
+
+	vectors, err := s.embedder.Embed(ctx, texts)
+	if err != nil {
+		fmt.Printf("Warning: failed to embed %d items: %v\n", len(states), err)
+		return apperr.Upstream("failed to embed items: the embeddings API is unavailable")
+	}
+
+	for i, state := range states {
+		if err := s.embeddingRepo.Save(state.ItemID, s.embedder.Model(), embeddingHash(texts[i]), vectors[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// itemEmbeddingText is the text an item's embedding is computed from
+func itemEmbeddingText(state *models.ItemEmbeddingState) string {
+	return fmt.Sprintf("%s\n%s: %s", state.Title, state.Category, state.Subcategory)
+}
+
+// embeddingHash fingerprints embedded text, so edits that change it are re-embedded
+func embeddingHash(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
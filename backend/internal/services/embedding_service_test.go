@@ -0,0 +1,36 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+)
+
+func TestItemEmbeddingTextChangesWithEdits(t *testing.T) {
+	state := &models.ItemEmbeddingState{ItemID: 1, Title: "LRU Cache", Category: models.CategoryLLD, Subcategory: "caching"}
+
+	text := itemEmbeddingText(state)
+	if text != "LRU Cache\nlld: caching" {
+		t.Errorf("itemEmbeddingText() = %q", text)
+	}
+
+	hash := embeddingHash(text)
+	state.Subcategory = "data structures"
+	if embeddingHash(itemEmbeddingText(state)) == hash {
+		t.Error("Expected a recategorized item to need a new embedding")
+	}
+}
+
+func TestGetSimilarWithoutEmbedder(t *testing.T) {
+	service := NewEmbeddingService(nil, nil, nil)
+
+	_, err := service.GetSimilar(context.Background(), 1, 0)
+	if apperr.From(err).Kind != apperr.KindUnavailable {
+		t.Errorf("Expected an unavailable error without an embedder, got %v", err)
+	}
+	if err := service.RefreshEmbeddings(context.Background()); err != nil {
+		t.Errorf("Expected refreshing without an embedder to do nothing, got %v", err)
+	}
+}
@@ -0,0 +1,20 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"interview-prep-app/internal/events"
+)
+
+// publishEvent publishes to the event bus if one is configured. Failures are logged rather
+// than surfaced so subscribers never block the action that triggered the event.
+func publishEvent(bus events.Bus, name events.Name, userID int, data interface{}) {
+	if bus == nil {
+		return
+	}
+
+	if err := bus.Publish(context.Background(), name, userID, data); err != nil {
+		fmt.Printf("Warning: failed to publish %s for user %d: %v\n", name, userID, err)
+	}
+}
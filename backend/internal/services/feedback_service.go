@@ -0,0 +1,153 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+	"interview-prep-app/internal/validation"
+	"interview-prep-app/pkg/apperr"
+)
+
+const (
+	// maxFeedbackPerHour limits how many reports a user can submit in an hour
+	maxFeedbackPerHour = 20
+	// defaultFeedbackQueueLimit is the review queue page size when none is given
+	defaultFeedbackQueueLimit = 50
+	// maxFeedbackQueueLimit caps the review queue page size
+	maxFeedbackQueueLimit = 200
+)
+
+// FeedbackService handles business logic for users reporting problems with items and
+// admins reviewing those reports
+type FeedbackService struct {
+	feedbackRepo *repositories.FeedbackRepository
+	itemRepo     *repositories.ItemRepository
+}
+
+// NewFeedbackService creates a new feedback service
+func NewFeedbackService(feedbackRepo *repositories.FeedbackRepository, itemRepo *repositories.ItemRepository) *FeedbackService {
+	return &FeedbackService{
+		feedbackRepo: feedbackRepo,
+		itemRepo:     itemRepo,
+	}
+}
+
+// SubmitFeedback records a user's report of a broken link or a correction to an item
+func (s *FeedbackService) SubmitFeedback(userID, itemID int, req *models.CreateFeedbackRequest) (*models.ItemFeedback, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if itemID <= 0 {
+		return nil, fmt.Errorf("invalid item ID")
+	}
+
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	comment := strings.TrimSpace(req.Comment)
+	if req.Category == models.FeedbackOther && comment == "" {
+		return nil, fmt.Errorf("comment is required for other feedback")
+	}
+
+	item, err := s.itemRepo.GetPublishedByID(itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	submitted, err := s.feedbackRepo.CountSubmittedSince(userID, time.Now().Add(-time.Hour))
+	if err != nil {
+		return nil, err
+	}
+	if submitted >= maxFeedbackPerHour {
+		return nil, apperr.RateLimited("feedback limit reached: try again later")
+	}
+
+	exists, err := s.feedbackRepo.OpenFeedbackExists(itemID, userID, req.Category)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, apperr.Conflict("feedback already submitted for this item")
+	}
+
+	feedback := &models.ItemFeedback{
+		ItemID:   itemID,
+		UserID:   &userID,
+		Category: req.Category,
+		Comment:  comment,
+		Status:   models.FeedbackStatusOpen,
+		Item:     item,
+	}
+
+	if err := s.feedbackRepo.Create(feedback); err != nil {
+		return nil, err
+	}
+
+	return feedback, nil
+}
+
+// GetQueue returns a page of reports with the given status, oldest first. Open reports are
+// the review queue; resolved and dismissed ones are its history.
+func (s *FeedbackService) GetQueue(status models.FeedbackStatus, category *models.FeedbackCategory, limit, offset int) (*models.FeedbackQueueResponse, error) {
+	if status == "" {
+		status = models.FeedbackStatusOpen
+	}
+	if !models.IsValidFeedbackStatus(status) {
+		return nil, fmt.Errorf("invalid feedback status: %s", status)
+	}
+
+	if category != nil && !models.IsValidFeedbackCategory(*category) {
+		return nil, fmt.Errorf("invalid feedback category: %s", *category)
+	}
+
+	if limit == 0 {
+		limit = defaultFeedbackQueueLimit
+	}
+	if limit < 0 || limit > maxFeedbackQueueLimit {
+		return nil, fmt.Errorf("limit must be between 1 and %d", maxFeedbackQueueLimit)
+	}
+
+	if offset < 0 {
+		return nil, fmt.Errorf("offset cannot be negative")
+	}
+
+	feedback, total, err := s.feedbackRepo.GetByStatus(status, category, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.FeedbackQueueResponse{
+		Feedback: feedback,
+		Pagination: models.PaginationMeta{
+			Page:       offset/limit + 1,
+			Limit:      limit,
+			Offset:     offset,
+			Total:      total,
+			TotalPages: (total + limit - 1) / limit,
+			HasNext:    offset+limit < total,
+			HasPrev:    offset > 0,
+		},
+	}, nil
+}
+
+// ReviewFeedback resolves or dismisses an open report on behalf of an admin
+func (s *FeedbackService) ReviewFeedback(adminID, feedbackID int, status models.FeedbackStatus, note string) error {
+	if adminID <= 0 {
+		return fmt.Errorf("invalid user ID")
+	}
+
+	if feedbackID <= 0 {
+		return fmt.Errorf("invalid feedback ID")
+	}
+
+	if status != models.FeedbackStatusResolved && status != models.FeedbackStatusDismissed {
+		return fmt.Errorf("invalid feedback status: %s", status)
+	}
+
+	return s.feedbackRepo.Review(feedbackID, adminID, status, strings.TrimSpace(note))
+}
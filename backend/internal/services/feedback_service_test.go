@@ -0,0 +1,59 @@
+package services
+
+import (
+	"testing"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+	"interview-prep-app/internal/testutil"
+	"interview-prep-app/pkg/apperr"
+)
+
+func TestDraftItemsAreNotFoundForUsers(t *testing.T) {
+	db := testutil.OpenDB(t)
+	itemRepo := repositories.NewItemRepository(db)
+	userRepo := repositories.NewUserRepository(db)
+
+	user := &models.User{Email: "ada@example.test", Name: "Ada", Role: models.RoleUser, AuthProvider: models.AuthProviderEmail}
+	if err := userRepo.Create(user); err != nil {
+		t.Fatal(err)
+	}
+	draft, err := itemRepo.Create(&models.CreateItemRequest{
+		Title: "Unreleased", Link: "https://example.test/unreleased", Category: models.CategoryDSA, Subcategory: "graphs", Draft: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hintService := NewHintService(repositories.NewHintRepository(db), itemRepo)
+	for name, act := range map[string]func() error{
+		"feedback": func() error {
+			_, err := NewFeedbackService(repositories.NewFeedbackRepository(db), itemRepo).
+				SubmitFeedback(user.ID, draft.ID, &models.CreateFeedbackRequest{Category: models.FeedbackDeadLink})
+			return err
+		},
+		"flashcard": func() error {
+			_, err := NewFlashcardService(repositories.NewFlashcardRepository(db), itemRepo).
+				CreateFlashcard(user.ID, draft.ID, &models.CreateFlashcardRequest{Question: "Q", Answer: "A"})
+			return err
+		},
+		"focus session": func() error {
+			_, err := NewFocusSessionService(repositories.NewFocusSessionRepository(db), itemRepo, repositories.NewTestRepository(db)).
+				StartSession(user.ID, &models.StartFocusSessionRequest{ItemID: &draft.ID})
+			return err
+		},
+		"hint": func() error {
+			_, err := hintService.RevealNextHint(user.ID, draft.ID)
+			return err
+		},
+	} {
+		if err := act(); apperr.From(err).Kind != apperr.KindNotFound {
+			t.Errorf("%s: expected a draft item not to be found, got %v", name, err)
+		}
+	}
+
+	// Editors still work on drafts
+	if _, err := hintService.CreateHint(draft.ID, &models.CreateHintRequest{Content: "Think in layers"}); err != nil {
+		t.Errorf("Expected editors to add hints to drafts, got %v", err)
+	}
+}
@@ -0,0 +1,159 @@
+package services
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"interview-prep-app/internal/flags"
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+	"interview-prep-app/internal/validation"
+)
+
+// flagCacheTTL is how long flags are read from memory before the table is queried again, so a
+// change reaches every server instance within this long
+const flagCacheTTL = 30 * time.Second
+
+var flagKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+// FlagService manages feature flags and decides which are on for a user. FEATURE_FLAGS
+// overrides win over the table, so a deployment can pin a flag on in development or off
+// in an emergency.
+type FlagService struct {
+	flagRepo  *repositories.FlagRepository
+	overrides map[string]bool
+
+	mu       sync.Mutex
+	cached   []models.FeatureFlag
+	cachedAt time.Time
+}
+
+// NewFlagService creates a new flag service
+func NewFlagService(flagRepo *repositories.FlagRepository, overrides map[string]bool) *FlagService {
+	return &FlagService{flagRepo: flagRepo, overrides: overrides}
+}
+
+// Evaluate returns every known flag and whether it's on for the user
+func (s *FlagService) Evaluate(userID int) (map[string]bool, error) {
+	all, err := s.flags()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]bool, len(all)+len(s.overrides))
+	for i := range all {
+		result[all[i].Key] = flags.On(&all[i], userID)
+	}
+	for key, on := range s.overrides {
+		result[key] = on
+	}
+
+	return result, nil
+}
+
+// ListFlags returns every flag in the table, and flags only set by FEATURE_FLAGS, with their overrides
+func (s *FlagService) ListFlags() ([]models.FeatureFlag, error) {
+	all, err := s.flagRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	listed := map[string]bool{}
+	for i := range all {
+		listed[all[i].Key] = true
+		if on, ok := s.overrides[all[i].Key]; ok {
+			all[i].Override = &on
+		}
+	}
+	for key, on := range s.overrides {
+		if !listed[key] {
+			on := on
+			all = append(all, models.FeatureFlag{Key: key, UserIDs: []int64{}, Override: &on})
+		}
+	}
+
+	sort.Slice(all, func(i, j int) bool { return all[i].Key < all[j].Key })
+	return all, nil
+}
+
+// UpdateFlag creates a flag or changes the fields the request sets
+func (s *FlagService) UpdateFlag(key string, req *models.UpdateFeatureFlagRequest) (*models.FeatureFlag, error) {
+	if !flagKeyPattern.MatchString(key) {
+		return nil, validation.Field("key", "format", "key must be lowercase letters, digits and underscores, starting with a letter")
+	}
+
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	flag := &models.FeatureFlag{Key: key, UserIDs: []int64{}}
+	all, err := s.flagRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	for i := range all {
+		if all[i].Key == key {
+			flag = &all[i]
+		}
+	}
+
+	if req.Description != nil {
+		flag.Description = strings.TrimSpace(*req.Description)
+	}
+	if req.Enabled != nil {
+		flag.Enabled = *req.Enabled
+	}
+	if req.RolloutPercent != nil {
+		flag.RolloutPercent = *req.RolloutPercent
+	}
+	if req.UserIDs != nil {
+		flag.UserIDs = req.UserIDs
+	}
+
+	if err := s.flagRepo.Save(flag); err != nil {
+		return nil, err
+	}
+	s.invalidate()
+
+	if on, ok := s.overrides[key]; ok {
+		flag.Override = &on
+	}
+	return flag, nil
+}
+
+// DeleteFlag removes a flag, turning it off for everyone not covered by an override
+func (s *FlagService) DeleteFlag(key string) error {
+	if err := s.flagRepo.Delete(key); err != nil {
+		return err
+	}
+	s.invalidate()
+	return nil
+}
+
+// flags returns the table's flags, from memory when read within flagCacheTTL
+func (s *FlagService) flags() ([]models.FeatureFlag, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cached != nil && time.Since(s.cachedAt) < flagCacheTTL {
+		return s.cached, nil
+	}
+
+	all, err := s.flagRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	s.cached, s.cachedAt = all, time.Now()
+	return all, nil
+}
+
+// invalidate makes the next evaluation on this instance read the table again
+func (s *FlagService) invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cached = nil
+}
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+	"interview-prep-app/internal/validation"
+)
+
+// maxFlashcardsPerReview caps how many due cards one review session returns
+const maxFlashcardsPerReview = 50
+
+// FlashcardService handles business logic for flashcards and their review schedule
+type FlashcardService struct {
+	flashcardRepo *repositories.FlashcardRepository
+	itemRepo      *repositories.ItemRepository
+}
+
+// NewFlashcardService creates a new flashcard service
+func NewFlashcardService(flashcardRepo *repositories.FlashcardRepository, itemRepo *repositories.ItemRepository) *FlashcardService {
+	return &FlashcardService{
+		flashcardRepo: flashcardRepo,
+		itemRepo:      itemRepo,
+	}
+}
+
+// CreateFlashcard attaches a new card to an item; it is due for review right away
+func (s *FlashcardService) CreateFlashcard(userID, itemID int, req *models.CreateFlashcardRequest) (*models.Flashcard, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if itemID <= 0 {
+		return nil, fmt.Errorf("invalid item ID")
+	}
+
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	req.Question = strings.TrimSpace(req.Question)
+	req.Answer = strings.TrimSpace(req.Answer)
+
+	if _, err := s.itemRepo.GetPublishedByID(itemID); err != nil {
+		return nil, err
+	}
+
+	return s.flashcardRepo.Create(userID, itemID, req)
+}
+
+// GetItemFlashcards lists the user's cards on an item
+func (s *FlashcardService) GetItemFlashcards(userID, itemID int) ([]*models.Flashcard, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if itemID <= 0 {
+		return nil, fmt.Errorf("invalid item ID")
+	}
+
+	return s.flashcardRepo.GetForItem(userID, itemID)
+}
+
+// UpdateFlashcard edits a card's question or answer without touching its schedule
+func (s *FlashcardService) UpdateFlashcard(userID, cardID int, req *models.UpdateFlashcardRequest) (*models.Flashcard, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if cardID <= 0 {
+		return nil, fmt.Errorf("invalid flashcard ID")
+	}
+
+	if req.Question == nil && req.Answer == nil {
+		return nil, fmt.Errorf("at least one field must be provided for update")
+	}
+
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	return s.flashcardRepo.Update(userID, cardID, req)
+}
+
+// DeleteFlashcard removes a card
+func (s *FlashcardService) DeleteFlashcard(userID, cardID int) error {
+	if userID <= 0 {
+		return fmt.Errorf("invalid user ID")
+	}
+
+	if cardID <= 0 {
+		return fmt.Errorf("invalid flashcard ID")
+	}
+
+	return s.flashcardRepo.Delete(userID, cardID)
+}
+
+// GetDueCards returns the cards due by the end of today (UTC, matching streak days)
+func (s *FlashcardService) GetDueCards(userID int, now time.Time) (*models.FlashcardReviewResponse, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	endOfToday := now.UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)
+
+	cards, err := s.flashcardRepo.GetDue(userID, endOfToday, maxFlashcardsPerReview)
+	if err != nil {
+		return nil, err
+	}
+
+	dueCount, err := s.flashcardRepo.CountDue(userID, endOfToday)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.FlashcardReviewResponse{Cards: cards, DueCount: dueCount}, nil
+}
+
+// GradeFlashcard records a review and reschedules the card with the same spaced-repetition
+// intervals used for item reviews: "good" follows the solved ladder, "hard" the shorter
+// reviewed-solution ladder, and "again" restarts from its first step.
+func (s *FlashcardService) GradeFlashcard(userID, cardID int, grade models.FlashcardGrade, now time.Time) (*models.Flashcard, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if cardID <= 0 {
+		return nil, fmt.Errorf("invalid flashcard ID")
+	}
+
+	if !models.IsValidFlashcardGrade(grade) {
+		return nil, fmt.Errorf("invalid grade: %s. Valid grades are: %v", grade, models.ValidFlashcardGrades())
+	}
+
+	card, err := s.flashcardRepo.GetByID(userID, cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	var nextReviewAt time.Time
+	reviewCount := card.ReviewCount
+	switch grade {
+	case models.FlashcardGradeAgain:
+		reviewCount = 0
+		nextReviewAt = NextReviewAt(models.CompletionReviewedSolution, 0, now)
+	case models.FlashcardGradeHard:
+		nextReviewAt = NextReviewAt(models.CompletionReviewedSolution, reviewCount, now)
+		reviewCount++
+	case models.FlashcardGradeGood:
+		nextReviewAt = NextReviewAt(models.CompletionSolved, reviewCount, now)
+		reviewCount++
+	}
+
+	return s.flashcardRepo.RecordGrade(userID, cardID, grade, reviewCount, nextReviewAt)
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+	"interview-prep-app/pkg/apperr"
+)
+
+// defaultFocusStatsDays is the window of the time-spent breakdown when none is requested
+const defaultFocusStatsDays = 30
+
+// FocusSessionService handles business logic for timed practice sessions
+type FocusSessionService struct {
+	focusRepo *repositories.FocusSessionRepository
+	itemRepo  *repositories.ItemRepository
+	testRepo  *repositories.TestRepository
+}
+
+// NewFocusSessionService creates a new focus session service
+func NewFocusSessionService(focusRepo *repositories.FocusSessionRepository, itemRepo *repositories.ItemRepository, testRepo *repositories.TestRepository) *FocusSessionService {
+	return &FocusSessionService{
+		focusRepo: focusRepo,
+		itemRepo:  itemRepo,
+		testRepo:  testRepo,
+	}
+}
+
+// StartSession starts a timer on an item or a test. A user can only have one session
+// running or paused at a time.
+func (s *FocusSessionService) StartSession(userID int, req *models.StartFocusSessionRequest) (*models.FocusSession, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	testSessionID := strings.TrimSpace(req.TestSessionID)
+	if req.ItemID == nil && testSessionID == "" {
+		return nil, fmt.Errorf("item_id or test_session_id is required")
+	}
+
+	session := &models.FocusSession{UserID: userID, ItemID: req.ItemID}
+
+	if req.ItemID != nil {
+		if _, err := s.itemRepo.GetPublishedByID(*req.ItemID); err != nil {
+			return nil, err
+		}
+	}
+
+	if testSessionID != "" {
+		tests, err := s.testRepo.GetTestsBySessionID(userID, testSessionID)
+		if err != nil {
+			return nil, err
+		}
+		if len(tests) == 0 {
+			return nil, apperr.NotFound("test not found")
+		}
+		if req.ItemID != nil && !testHasItem(tests, *req.ItemID) {
+			return nil, fmt.Errorf("item is not part of this test")
+		}
+		session.TestSessionID = &testSessionID
+	}
+
+	active, err := s.focusRepo.GetActive(userID)
+	if err != nil {
+		return nil, err
+	}
+	if active != nil {
+		return nil, apperr.Conflict("a focus session is already active")
+	}
+
+	if err := s.focusRepo.Start(session, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// GetActiveSession returns the user's running or paused session, or nil if there is none
+func (s *FocusSessionService) GetActiveSession(userID int) (*models.FocusSession, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	return s.focusRepo.GetActive(userID)
+}
+
+// PauseSession pauses a running session
+func (s *FocusSessionService) PauseSession(userID, sessionID int) (*models.FocusSession, error) {
+	return s.transition(userID, sessionID, models.FocusEventPause)
+}
+
+// ResumeSession resumes a paused session
+func (s *FocusSessionService) ResumeSession(userID, sessionID int) (*models.FocusSession, error) {
+	return s.transition(userID, sessionID, models.FocusEventResume)
+}
+
+// StopSession ends a session, fixing its focused time
+func (s *FocusSessionService) StopSession(userID, sessionID int) (*models.FocusSession, error) {
+	return s.transition(userID, sessionID, models.FocusEventStop)
+}
+
+// GetTimeSummary breaks down the user's focused time over the last days, per item and per day
+func (s *FocusSessionService) GetTimeSummary(userID, days int) (*models.FocusTimeSummary, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if days == 0 {
+		days = defaultFocusStatsDays
+	}
+	if days < 1 || days > models.MaxFocusStatsDays {
+		return nil, fmt.Errorf("days must be between 1 and %d", models.MaxFocusStatsDays)
+	}
+
+	now := time.Now()
+	since := startOfDay(now).AddDate(0, 0, -(days - 1))
+
+	byItem, err := s.focusRepo.GetFocusedTimeByItem(userID, since, now)
+	if err != nil {
+		return nil, err
+	}
+
+	byDay, err := s.focusRepo.GetFocusedTimeByDay(userID, since, now)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &models.FocusTimeSummary{Days: days, ByItem: byItem, ByDay: byDay}
+	for _, day := range byDay {
+		summary.TotalSeconds += day.FocusedSeconds
+	}
+
+	return summary, nil
+}
+
+// transition validates IDs and applies a timer action to a session
+func (s *FocusSessionService) transition(userID, sessionID int, kind models.FocusSessionEventKind) (*models.FocusSession, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+	if sessionID <= 0 {
+		return nil, fmt.Errorf("invalid session ID")
+	}
+
+	return s.focusRepo.Transition(userID, sessionID, kind, time.Now())
+}
+
+// testHasItem reports whether the item is one of the test's items
+func testHasItem(tests []*models.Test, itemID int) bool {
+	for _, test := range tests {
+		if test.ItemID == itemID {
+			return true
+		}
+	}
+	return false
+}
+
+// startOfDay returns midnight at the start of t's day, in t's location
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
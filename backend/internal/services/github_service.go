@@ -0,0 +1,357 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"interview-prep-app/internal/github"
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+	"interview-prep-app/internal/secrets"
+	"interview-prep-app/internal/validation"
+	"interview-prep-app/pkg/apperr"
+)
+
+// githubStateTTL is how long the user has to approve the app on GitHub
+const githubStateTTL = 10 * time.Minute
+
+// solutionExtensions maps runner languages to the file extension their solutions are committed with
+var solutionExtensions = map[string]string{
+	"python":     "py",
+	"javascript": "js",
+	"typescript": "ts",
+	"go":         "go",
+	"java":       "java",
+	"cpp":        "cpp",
+	"c":          "c",
+}
+
+var (
+	githubRepositoryPattern = regexp.MustCompile(`^[A-Za-z0-9-]+/[A-Za-z0-9._-]+$`)
+	pathPlaceholderPattern  = regexp.MustCompile(`\{[a-z]+\}`)
+	pathSlugPattern         = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// GitHubService connects users' GitHub accounts and commits their solutions to a repository
+// they pick. Tokens are encrypted with the user's data key, so the integration needs the
+// encryption keyring as well as a GitHub OAuth app.
+type GitHubService struct {
+	githubRepo     *repositories.GitHubRepository
+	attachmentRepo *repositories.AttachmentRepository
+	client         *github.Client // nil when the integration is disabled
+	keyring        *secrets.Keyring
+	stateKey       []byte
+	redirectURI    string
+}
+
+// NewGitHubService creates a new GitHub service. GitHub sends users back to the app's
+// /integrations/github/callback page, which passes the code and state on to CompleteAuthorization.
+func NewGitHubService(githubRepo *repositories.GitHubRepository, attachmentRepo *repositories.AttachmentRepository, client *github.Client, keyring *secrets.Keyring, stateKey, appBaseURL string) *GitHubService {
+	return &GitHubService{
+		githubRepo:     githubRepo,
+		attachmentRepo: attachmentRepo,
+		client:         client,
+		keyring:        keyring,
+		stateKey:       []byte(stateKey),
+		redirectURI:    strings.TrimRight(appBaseURL, "/") + "/integrations/github/callback",
+	}
+}
+
+// Enabled reports whether users can connect GitHub accounts
+func (s *GitHubService) Enabled() bool {
+	return s.client != nil && s.keyring != nil
+}
+
+// AuthorizeURL returns the GitHub page where the user approves the app. The state names the
+// user, so a code can only be completed by the account that started the flow.
+func (s *GitHubService) AuthorizeURL(userID int) (*models.GitHubAuthorizeResponse, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if !s.Enabled() {
+		return nil, apperr.Unavailable("GitHub integration is not configured")
+	}
+
+	expires := time.Now().Add(githubStateTTL).Unix()
+	state := strconv.Itoa(userID) + "." + strconv.FormatInt(expires, 10) + "." + s.signState(userID, expires)
+
+	return &models.GitHubAuthorizeResponse{AuthorizationURL: s.client.AuthorizeURL(state, s.redirectURI)}, nil
+}
+
+// CompleteAuthorization exchanges the code GitHub redirected back with for a token and stores it
+func (s *GitHubService) CompleteAuthorization(ctx context.Context, userID int, req *models.CompleteGitHubAuthorizationRequest) (*models.GitHubConnection, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if !s.Enabled() {
+		return nil, apperr.Unavailable("GitHub integration is not configured")
+	}
+
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	if !s.validState(userID, req.State, time.Now()) {
+		return nil, apperr.Validation("GitHub authorization expired or was started by another account; connect again")
+	}
+
+	token, err := s.client.ExchangeCode(ctx, req.Code, s.redirectURI)
+	if err != nil {
+		return nil, apperr.Upstream(err.Error())
+	}
+
+	user, err := s.client.GetUser(ctx, token)
+	if err != nil {
+		return nil, apperr.Upstream(err.Error())
+	}
+
+	wrappedKey, err := userDataKey(s.keyring, s.attachmentRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted, err := s.keyring.Encrypt(wrappedKey, []byte(token), githubTokenContext(userID))
+	if err != nil {
+		return nil, apperr.Errorf("failed to encrypt GitHub token: %w", err)
+	}
+
+	if err := s.githubRepo.SaveAccount(userID, user.Login, encrypted); err != nil {
+		return nil, err
+	}
+
+	return s.GetConnection(userID)
+}
+
+// GetConnection returns the user's connected GitHub account and repository settings
+func (s *GitHubService) GetConnection(userID int) (*models.GitHubConnection, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	conn, err := s.githubRepo.Get(userID)
+	if err != nil {
+		return nil, err
+	}
+	if conn == nil {
+		return nil, apperr.NotFound("GitHub account not connected")
+	}
+
+	if conn.PathTemplate == "" {
+		conn.PathTemplate = models.DefaultGitHubPathTemplate
+	}
+	return conn, nil
+}
+
+// UpdateRepository chooses the repository solutions are committed to, after checking the
+// connected account can push to it
+func (s *GitHubService) UpdateRepository(ctx context.Context, userID int, req *models.UpdateGitHubRepositoryRequest) (*models.GitHubConnection, error) {
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	repository := strings.TrimSpace(req.Repository)
+	if !githubRepositoryPattern.MatchString(repository) {
+		return nil, validation.Field("repository", "format", "repository must be in the form owner/name")
+	}
+
+	template := strings.TrimSpace(req.PathTemplate)
+	if template != "" {
+		if err := validatePathTemplate(template); err != nil {
+			return nil, err
+		}
+	}
+
+	conn, err := s.GetConnection(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := s.token(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, name, _ := strings.Cut(repository, "/")
+	repo, err := s.client.GetRepository(ctx, token, owner, name)
+	if errors.Is(err, github.ErrNotFound) {
+		return nil, apperr.NotFound("repository not found, or the connected GitHub account can't see it")
+	}
+	if err != nil {
+		return nil, apperr.Upstream(err.Error())
+	}
+	if !repo.Permissions.Push {
+		return nil, apperr.Forbidden("the connected GitHub account can't push to this repository")
+	}
+
+	conn.Repository = repo.FullName
+	conn.Branch = strings.TrimSpace(req.Branch)
+	if conn.Branch == "" {
+		conn.Branch = repo.DefaultBranch
+	}
+	conn.PathTemplate = template
+
+	if err := s.githubRepo.UpdateRepository(conn); err != nil {
+		return nil, err
+	}
+
+	if conn.PathTemplate == "" {
+		conn.PathTemplate = models.DefaultGitHubPathTemplate
+	}
+	return conn, nil
+}
+
+// Disconnect forgets the user's GitHub account and token. The app's authorization stays listed
+// on GitHub until the user revokes it there.
+func (s *GitHubService) Disconnect(userID int) error {
+	if userID <= 0 {
+		return fmt.Errorf("invalid user ID")
+	}
+
+	return s.githubRepo.Delete(userID)
+}
+
+// CommitSolution commits a submitted solution to the user's repository and returns the commit SHA
+func (s *GitHubService) CommitSolution(ctx context.Context, item *models.Item, attempt *models.SubmissionAttempt) (string, error) {
+	if !s.Enabled() {
+		return "", apperr.Unavailable("GitHub integration is not configured")
+	}
+
+	conn, err := s.GetConnection(attempt.UserID)
+	if err != nil {
+		return "", err
+	}
+	if conn.Repository == "" {
+		return "", apperr.Validation("choose a GitHub repository for solutions first")
+	}
+
+	token, err := s.token(conn)
+	if err != nil {
+		return "", err
+	}
+
+	path := SolutionPath(conn.PathTemplate, item, attempt.Language)
+	message := fmt.Sprintf("Add %s solution for %s (%d/%d test cases passed)", attempt.Language, item.Title, attempt.PassedCases, attempt.TotalCases)
+
+	owner, name, _ := strings.Cut(conn.Repository, "/")
+	sha, err := s.client.PutFile(ctx, token, owner, name, conn.Branch, path, message, []byte(attempt.Code))
+	if errors.Is(err, github.ErrNotFound) {
+		return "", apperr.NotFound("repository or branch not found; check your GitHub settings")
+	}
+	if err != nil {
+		return "", apperr.Upstream(fmt.Sprintf("failed to commit to GitHub: %v", err))
+	}
+
+	return sha, nil
+}
+
+// token decrypts the connection's access token
+func (s *GitHubService) token(conn *models.GitHubConnection) (string, error) {
+	if !s.Enabled() {
+		return "", apperr.Unavailable("GitHub integration is not configured")
+	}
+
+	wrappedKey, err := userDataKey(s.keyring, s.attachmentRepo, conn.UserID)
+	if err != nil {
+		return "", err
+	}
+
+	token, err := s.keyring.Decrypt(wrappedKey, conn.AccessToken, githubTokenContext(conn.UserID))
+	if err != nil {
+		return "", apperr.Errorf("failed to decrypt GitHub token: %w", err)
+	}
+
+	return string(token), nil
+}
+
+// signState computes the signature binding an authorization state to a user and expiry
+func (s *GitHubService) signState(userID int, expires int64) string {
+	mac := hmac.New(sha256.New, s.stateKey)
+	mac.Write([]byte("github-state\n" + strconv.Itoa(userID) + "\n" + strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// validState checks a state was issued to this user and hasn't expired
+func (s *GitHubService) validState(userID int, state string, now time.Time) bool {
+	parts := strings.Split(state, ".")
+	if len(parts) != 3 || parts[0] != strconv.Itoa(userID) {
+		return false
+	}
+
+	expires, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || now.Unix() > expires {
+		return false
+	}
+
+	return hmac.Equal([]byte(s.signState(userID, expires)), []byte(parts[2]))
+}
+
+// githubTokenContext binds an encrypted GitHub token to its owner
+func githubTokenContext(userID int) string {
+	return fmt.Sprintf("github-token:%d", userID)
+}
+
+// validatePathTemplate checks a path template only uses known placeholders, names a file per
+// item, and stays inside the repository
+func validatePathTemplate(template string) error {
+	for _, placeholder := range pathPlaceholderPattern.FindAllString(template, -1) {
+		switch placeholder {
+		case "{category}", "{subcategory}", "{title}", "{ext}":
+		default:
+			return validation.Field("path_template", "placeholder", fmt.Sprintf("path_template has unknown placeholder %s", placeholder))
+		}
+	}
+
+	if !strings.Contains(template, "{title}") {
+		return validation.Field("path_template", "title", "path_template must include {title} so each item gets its own file")
+	}
+
+	for _, segment := range strings.Split(template, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return validation.Field("path_template", "path", "path_template must be a relative path without empty, . or .. segments")
+		}
+	}
+
+	return nil
+}
+
+// SolutionPath fills in a path template for an item's solution. Item fields become lowercase
+// slugs so titles can't add directories or odd characters.
+func SolutionPath(template string, item *models.Item, language string) string {
+	if template == "" {
+		template = models.DefaultGitHubPathTemplate
+	}
+
+	ext, ok := solutionExtensions[language]
+	if !ok {
+		ext = "txt"
+	}
+
+	return strings.NewReplacer(
+		"{category}", pathSlug(string(item.Category)),
+		"{subcategory}", pathSlug(item.Subcategory),
+		"{title}", pathSlug(item.Title),
+		"{ext}", ext,
+	).Replace(template)
+}
+
+// pathSlug lowercases a value and collapses everything but letters and digits into dashes
+func pathSlug(value string) string {
+	slug := strings.Trim(pathSlugPattern.ReplaceAllString(strings.ToLower(value), "-"), "-")
+	if len(slug) > 80 {
+		slug = strings.TrimRight(slug[:80], "-")
+	}
+	if slug == "" {
+		return "untitled"
+	}
+	return slug
+}
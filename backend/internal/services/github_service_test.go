@@ -0,0 +1,67 @@
+package services
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"interview-prep-app/internal/models"
+)
+
+func TestSolutionPath(t *testing.T) {
+	item := &models.Item{Title: "Two Sum / ../../etc", Category: models.CategoryDSA, Subcategory: "Arrays & Hashing"}
+
+	for _, tc := range []struct {
+		template, language, expected string
+	}{
+		{"", "python", "dsa/arrays-hashing/two-sum-etc.py"},
+		{"solutions/{title}.{ext}", "cpp", "solutions/two-sum-etc.cpp"},
+		{"{category}/{title}/solution.{ext}", "cobol", "dsa/two-sum-etc/solution.txt"},
+	} {
+		if got := SolutionPath(tc.template, item, tc.language); got != tc.expected {
+			t.Errorf("SolutionPath(%q, %q) = %q, expected %q", tc.template, tc.language, got, tc.expected)
+		}
+	}
+}
+
+func TestValidatePathTemplate(t *testing.T) {
+	for template, valid := range map[string]bool{
+		models.DefaultGitHubPathTemplate: true,
+		"leetcode/{title}.{ext}":         true,
+		"{category}/solution.{ext}":      false, // every item would overwrite the same file
+		"{title}.{language}":             false,
+		"../{title}.{ext}":               false,
+		"/{title}.{ext}":                 false,
+		"a//{title}":                     false,
+	} {
+		if err := validatePathTemplate(template); (err == nil) != valid {
+			t.Errorf("validatePathTemplate(%q): expected valid=%v, got %v", template, valid, err)
+		}
+	}
+}
+
+func TestGitHubState(t *testing.T) {
+	service := NewGitHubService(nil, nil, nil, nil, "state key", "https://app.example.com/")
+	if service.redirectURI != "https://app.example.com/integrations/github/callback" {
+		t.Errorf("Unexpected redirect URI %q", service.redirectURI)
+	}
+
+	now := time.Now()
+	expires := now.Add(time.Minute).Unix()
+	state := "7." + strconv.FormatInt(expires, 10) + "." + service.signState(7, expires)
+
+	if !service.validState(7, state, now) {
+		t.Error("Expected the state to be valid for the user it was issued to")
+	}
+	if service.validState(8, state, now) {
+		t.Error("Expected the state to be rejected for another user")
+	}
+	if service.validState(7, state, now.Add(2*time.Minute)) {
+		t.Error("Expected an expired state to be rejected")
+	}
+
+	other := NewGitHubService(nil, nil, nil, nil, "another key", "")
+	if other.validState(7, state, now) {
+		t.Error("Expected states to depend on the signing key")
+	}
+}
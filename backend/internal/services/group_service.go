@@ -0,0 +1,302 @@
+package services
+
+import (
+	"crypto/rand"
+	"fmt"
+	"strings"
+
+	"interview-prep-app/internal/mailer"
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+	"interview-prep-app/internal/validation"
+	"interview-prep-app/pkg/apperr"
+)
+
+// inviteCodeAlphabet avoids characters that are easy to confuse when read aloud or typed
+const inviteCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// GroupService handles business logic for study groups
+type GroupService struct {
+	groupRepo  *repositories.GroupRepository
+	itemRepo   *repositories.ItemRepository
+	userRepo   *repositories.UserRepository
+	mailer     mailer.Mailer
+	appBaseURL string
+}
+
+// NewGroupService creates a new group service
+func NewGroupService(groupRepo *repositories.GroupRepository, itemRepo *repositories.ItemRepository, userRepo *repositories.UserRepository, m mailer.Mailer, appBaseURL string) *GroupService {
+	return &GroupService{
+		groupRepo:  groupRepo,
+		itemRepo:   itemRepo,
+		userRepo:   userRepo,
+		mailer:     m,
+		appBaseURL: strings.TrimRight(appBaseURL, "/"),
+	}
+}
+
+// CreateGroup creates a study group owned by the user
+func (s *GroupService) CreateGroup(userID int, req *models.CreateGroupRequest) (*models.StudyGroup, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(req.Name)
+
+	code, err := generateInviteCode()
+	if err != nil {
+		return nil, err
+	}
+
+	group := &models.StudyGroup{
+		Name:        name,
+		Description: strings.TrimSpace(req.Description),
+		OwnerID:     userID,
+		InviteCode:  code,
+	}
+
+	if err := s.groupRepo.Create(group); err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+// GetGroupsForUser lists the groups the user belongs to
+func (s *GroupService) GetGroupsForUser(userID int) ([]*models.StudyGroup, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	return s.groupRepo.GetForUser(userID)
+}
+
+// GetGroup returns a group and its members to one of its members
+func (s *GroupService) GetGroup(userID, groupID int) (*models.StudyGroup, []models.GroupMember, error) {
+	group, err := s.requireMember(userID, groupID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	members, err := s.groupRepo.GetMembers(groupID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return group, members, nil
+}
+
+// JoinByCode adds the user to the group with the given invite code
+func (s *GroupService) JoinByCode(userID int, code string) (*models.StudyGroup, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	group, err := s.groupRepo.GetByInviteCode(strings.ToUpper(strings.TrimSpace(code)))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.groupRepo.AddMember(group.ID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.groupRepo.GetByID(group.ID)
+}
+
+// LeaveGroup removes the user from a group. Owners can't leave their own group.
+func (s *GroupService) LeaveGroup(userID, groupID int) error {
+	group, err := s.requireMember(userID, groupID)
+	if err != nil {
+		return err
+	}
+
+	if group.OwnerID == userID {
+		return apperr.Conflict("group owner cannot leave the group")
+	}
+
+	return s.groupRepo.RemoveMember(groupID, userID)
+}
+
+// InviteByEmail emails the group's invite code to someone
+func (s *GroupService) InviteByEmail(userID, groupID int, email string) error {
+	group, err := s.requireMember(userID, groupID)
+	if err != nil {
+		return err
+	}
+
+	inviter, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		err := s.mailer.Send(mailer.Message{
+			To:      strings.TrimSpace(email),
+			Subject: fmt.Sprintf("%s invited you to the study group %s", inviter.Name, group.Name),
+			Body: fmt.Sprintf(
+				"%s invited you to prepare together in the study group %s.\n\nJoin with the code %s at %s/groups/join?code=%s\n",
+				inviter.Name, group.Name, group.InviteCode, s.appBaseURL, group.InviteCode,
+			),
+		})
+		if err != nil {
+			fmt.Printf("Warning: failed to send group invitation for group %d: %v\n", group.ID, err)
+		}
+	}()
+
+	return nil
+}
+
+// GetLeaderboard ranks a group's members by progress
+func (s *GroupService) GetLeaderboard(userID, groupID int) ([]models.GroupLeaderboardEntry, error) {
+	if _, err := s.requireMember(userID, groupID); err != nil {
+		return nil, err
+	}
+
+	return s.groupRepo.GetLeaderboard(groupID)
+}
+
+// GetProgress aggregates progress across a group's members
+func (s *GroupService) GetProgress(userID, groupID int) (*models.GroupProgress, error) {
+	if _, err := s.requireMember(userID, groupID); err != nil {
+		return nil, err
+	}
+
+	entries, err := s.groupRepo.GetLeaderboard(groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	totalItems, _, _, _, err := s.itemRepo.GetCountsForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	activeToday, err := s.groupRepo.CountActiveToday(groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := &models.GroupProgress{
+		GroupID:     groupID,
+		MemberCount: len(entries),
+		TotalItems:  totalItems,
+		ActiveToday: activeToday,
+	}
+
+	for _, entry := range entries {
+		progress.TotalCompleted += entry.CompletedItems
+	}
+
+	if progress.MemberCount > 0 {
+		progress.AverageCompleted = float64(progress.TotalCompleted) / float64(progress.MemberCount)
+		if totalItems > 0 {
+			progress.AverageProgressPercentage = progress.AverageCompleted / float64(totalItems) * 100
+		}
+	}
+
+	return progress, nil
+}
+
+// CreateItemList shares a list of items with the group
+func (s *GroupService) CreateItemList(userID, groupID int, req *models.CreateGroupItemListRequest) (*models.GroupItemList, error) {
+	if _, err := s.requireMember(userID, groupID); err != nil {
+		return nil, err
+	}
+
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(req.Name)
+
+	itemIDs := uniqueInts(req.ItemIDs)
+	found, err := s.itemRepo.CountExistingItems(itemIDs)
+	if err != nil {
+		return nil, err
+	}
+	if found != len(itemIDs) {
+		return nil, apperr.NotFound("one or more items not found")
+	}
+
+	list := &models.GroupItemList{GroupID: groupID, CreatedBy: userID, Name: name}
+	if err := s.groupRepo.CreateItemList(list, itemIDs); err != nil {
+		return nil, err
+	}
+
+	lists, err := s.groupRepo.GetItemLists(groupID)
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range lists {
+		if l.ID == list.ID {
+			return l, nil
+		}
+	}
+
+	return list, nil
+}
+
+// GetItemLists returns the item lists shared within a group
+func (s *GroupService) GetItemLists(userID, groupID int) ([]*models.GroupItemList, error) {
+	if _, err := s.requireMember(userID, groupID); err != nil {
+		return nil, err
+	}
+
+	return s.groupRepo.GetItemLists(groupID)
+}
+
+// requireMember loads a group, failing unless the user belongs to it
+func (s *GroupService) requireMember(userID, groupID int) (*models.StudyGroup, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if groupID <= 0 {
+		return nil, fmt.Errorf("invalid group ID")
+	}
+
+	group, err := s.groupRepo.GetByID(groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	isMember, err := s.groupRepo.IsMember(groupID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember {
+		// Hide the group's existence from non-members
+		return nil, apperr.NotFound("group not found")
+	}
+
+	return group, nil
+}
+
+// generateInviteCode creates a short, human-friendly invite code
+func generateInviteCode() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", apperr.Errorf("failed to generate invite code: %w", err)
+	}
+
+	for i := range b {
+		b[i] = inviteCodeAlphabet[int(b[i])%len(inviteCodeAlphabet)]
+	}
+	return string(b), nil
+}
+
+// uniqueInts removes duplicates while preserving order
+func uniqueInts(values []int) []int {
+	seen := map[int]bool{}
+	result := make([]int, 0, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
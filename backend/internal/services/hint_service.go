@@ -0,0 +1,120 @@
+package services
+
+import (
+	"fmt"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+	"interview-prep-app/internal/validation"
+)
+
+// HintService handles business logic for item hint ladders
+type HintService struct {
+	hintRepo *repositories.HintRepository
+	itemRepo *repositories.ItemRepository
+}
+
+// NewHintService creates a new hint service
+func NewHintService(hintRepo *repositories.HintRepository, itemRepo *repositories.ItemRepository) *HintService {
+	return &HintService{
+		hintRepo: hintRepo,
+		itemRepo: itemRepo,
+	}
+}
+
+// CreateHint authors a new hint for an item
+func (s *HintService) CreateHint(itemID int, req *models.CreateHintRequest) (*models.ItemHint, error) {
+	if itemID <= 0 {
+		return nil, fmt.Errorf("invalid item ID")
+	}
+
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.itemRepo.GetByID(itemID); err != nil {
+		return nil, err
+	}
+
+	return s.hintRepo.Create(itemID, req)
+}
+
+// UpdateHint edits an existing hint
+func (s *HintService) UpdateHint(itemID, hintID int, req *models.UpdateHintRequest) (*models.ItemHint, error) {
+	if itemID <= 0 || hintID <= 0 {
+		return nil, fmt.Errorf("invalid hint ID")
+	}
+
+	if req.Content == nil && req.Position == nil {
+		return nil, fmt.Errorf("at least one field must be provided for update")
+	}
+
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	return s.hintRepo.Update(itemID, hintID, req)
+}
+
+// DeleteHint removes a hint
+func (s *HintService) DeleteHint(itemID, hintID int) error {
+	if itemID <= 0 || hintID <= 0 {
+		return fmt.Errorf("invalid hint ID")
+	}
+
+	return s.hintRepo.Delete(itemID, hintID)
+}
+
+// GetAllHints returns the full ladder for an item (admin view)
+func (s *HintService) GetAllHints(itemID int) ([]models.ItemHint, error) {
+	if itemID <= 0 {
+		return nil, fmt.Errorf("invalid item ID")
+	}
+
+	return s.hintRepo.GetByItem(itemID)
+}
+
+// GetHintLadder returns only the hints the user has revealed so far
+func (s *HintService) GetHintLadder(userID, itemID int) (*models.HintLadderResponse, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if itemID <= 0 {
+		return nil, fmt.Errorf("invalid item ID")
+	}
+
+	revealed, total, err := s.hintRepo.GetRevealedForUser(userID, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.HintLadderResponse{
+		ItemID:           itemID,
+		TotalHints:       total,
+		RevealedCount:    len(revealed),
+		CreditMultiplier: models.HintCreditMultiplier(len(revealed), total),
+		Hints:            revealed,
+	}, nil
+}
+
+// RevealNextHint reveals the next hint for the user and returns the updated ladder
+func (s *HintService) RevealNextHint(userID, itemID int) (*models.HintLadderResponse, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if itemID <= 0 {
+		return nil, fmt.Errorf("invalid item ID")
+	}
+
+	if _, err := s.itemRepo.GetPublishedByID(itemID); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.hintRepo.RevealNext(userID, itemID); err != nil {
+		return nil, err
+	}
+
+	return s.GetHintLadder(userID, itemID)
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"interview-prep-app/internal/plugins"
+	"interview-prep-app/internal/repositories"
+)
+
+// IngestionService pulls items from plugin item sources into the catalog
+type IngestionService struct {
+	itemService *ItemService
+	itemRepo    *repositories.ItemRepository
+	sources     []plugins.ItemSource
+}
+
+// NewIngestionService creates a new ingestion service
+func NewIngestionService(itemService *ItemService, itemRepo *repositories.ItemRepository, sources []plugins.ItemSource) *IngestionService {
+	return &IngestionService{
+		itemService: itemService,
+		itemRepo:    itemRepo,
+		sources:     sources,
+	}
+}
+
+// HasSources reports whether any item sources are registered
+func (s *IngestionService) HasSources() bool {
+	return len(s.sources) > 0
+}
+
+// SyncSources fetches every registered source and adds items whose link isn't in the catalog yet.
+// Items that look like duplicates of existing ones are logged and skipped.
+// A failing source is logged and skipped so it can't hold up the others.
+func (s *IngestionService) SyncSources(ctx context.Context) error {
+	for _, source := range s.sources {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		created, err := s.syncSource(ctx, source)
+		if err != nil {
+			fmt.Printf("Warning: failed to sync item source %s: %v\n", source.Name(), err)
+			continue
+		}
+		if created > 0 {
+			fmt.Printf("Item source %s: added %d items\n", source.Name(), created)
+		}
+	}
+
+	return nil
+}
+
+// syncSource ingests a single source and returns how many items were created
+func (s *IngestionService) syncSource(ctx context.Context, source plugins.ItemSource) (int, error) {
+	items, err := source.Fetch(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	for i := range items {
+		req := items[i]
+		req.Link = strings.TrimSpace(req.Link)
+		req.Title = strings.TrimSpace(req.Title)
+
+		exists, err := s.itemRepo.ExistsByLink(req.Link)
+		if err != nil {
+			return created, err
+		}
+		if exists {
+			continue
+		}
+
+		if _, err := s.itemService.CreateItem(&req, false); err != nil {
+			fmt.Printf("Warning: skipping item %q from source %s: %v\n", req.Title, source.Name(), err)
+			continue
+		}
+		created++
+	}
+
+	return created, nil
+}
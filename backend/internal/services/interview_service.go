@@ -0,0 +1,294 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+	"interview-prep-app/internal/validation"
+	"interview-prep-app/pkg/apperr"
+)
+
+// maxInterviewStages caps how many rounds a single interview can track
+const maxInterviewStages = 30
+
+// InterviewService handles business logic for the interview pipeline tracker
+type InterviewService struct {
+	interviewRepo *repositories.InterviewRepository
+	itemRepo      *repositories.ItemRepository
+	companyRepo   *repositories.CompanyRepository
+}
+
+// NewInterviewService creates a new interview service
+func NewInterviewService(interviewRepo *repositories.InterviewRepository, itemRepo *repositories.ItemRepository, companyRepo *repositories.CompanyRepository) *InterviewService {
+	return &InterviewService{
+		interviewRepo: interviewRepo,
+		itemRepo:      itemRepo,
+		companyRepo:   companyRepo,
+	}
+}
+
+// GetInterviews returns the user's interview pipeline
+func (s *InterviewService) GetInterviews(userID int) ([]*models.Interview, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	return s.interviewRepo.GetForUser(userID)
+}
+
+// GetInterview returns one of the user's interviews with its stages and prep lists
+func (s *InterviewService) GetInterview(userID, interviewID int) (*models.Interview, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+	if interviewID <= 0 {
+		return nil, fmt.Errorf("invalid interview ID")
+	}
+
+	return s.interviewRepo.GetByID(userID, interviewID)
+}
+
+// CreateInterview starts tracking an interview at a company
+func (s *InterviewService) CreateInterview(userID int, req *models.CreateInterviewRequest) (*models.Interview, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	status := req.Status
+	if status == "" {
+		status = models.InterviewStatusApplied
+	}
+
+	interview := &models.Interview{
+		UserID:    userID,
+		Role:      strings.TrimSpace(req.Role),
+		Status:    status,
+		AppliedAt: req.AppliedAt,
+		Notes:     req.Notes,
+	}
+	if err := s.setCompany(interview, req.Company); err != nil {
+		return nil, err
+	}
+
+	if err := s.interviewRepo.Create(interview); err != nil {
+		return nil, err
+	}
+	interview.Stages = []*models.InterviewStage{}
+
+	return interview, nil
+}
+
+// UpdateInterview updates the company, role, status, dates or notes of an interview
+func (s *InterviewService) UpdateInterview(userID, interviewID int, req *models.UpdateInterviewRequest) (*models.Interview, error) {
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	interview, err := s.GetInterview(userID, interviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Company != nil {
+		if err := s.setCompany(interview, *req.Company); err != nil {
+			return nil, err
+		}
+	}
+	if req.Role != nil {
+		interview.Role = strings.TrimSpace(*req.Role)
+	}
+	if req.Status != nil {
+		interview.Status = *req.Status
+	}
+	if req.AppliedAt != nil {
+		interview.AppliedAt = req.AppliedAt
+	}
+	if req.Notes != nil {
+		interview.Notes = *req.Notes
+	}
+
+	if err := s.interviewRepo.Update(interview); err != nil {
+		return nil, err
+	}
+
+	return interview, nil
+}
+
+// DeleteInterview stops tracking an interview
+func (s *InterviewService) DeleteInterview(userID, interviewID int) error {
+	if userID <= 0 {
+		return fmt.Errorf("invalid user ID")
+	}
+	if interviewID <= 0 {
+		return fmt.Errorf("invalid interview ID")
+	}
+
+	return s.interviewRepo.Delete(userID, interviewID)
+}
+
+// AddStage appends a round to an interview, optionally with the items to prepare for it
+func (s *InterviewService) AddStage(userID, interviewID int, req *models.CreateInterviewStageRequest) (*models.InterviewStage, error) {
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	interview, err := s.GetInterview(userID, interviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(interview.Stages) >= maxInterviewStages {
+		return nil, fmt.Errorf("an interview can have at most %d stages", maxInterviewStages)
+	}
+
+	outcome := req.Outcome
+	if outcome == "" {
+		outcome = models.InterviewOutcomePending
+	}
+
+	itemIDs, err := s.validateItems(req.ItemIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	stage := &models.InterviewStage{
+		InterviewID: interview.ID,
+		Kind:        req.Kind,
+		Name:        strings.TrimSpace(req.Name),
+		ScheduledAt: req.ScheduledAt,
+		Outcome:     outcome,
+		Notes:       req.Notes,
+	}
+	if err := s.interviewRepo.CreateStage(stage, itemIDs); err != nil {
+		return nil, err
+	}
+
+	return s.getStage(userID, interviewID, stage.ID)
+}
+
+// UpdateStage updates the kind, name, order, schedule, outcome or notes of a round
+func (s *InterviewService) UpdateStage(userID, interviewID, stageID int, req *models.UpdateInterviewStageRequest) (*models.InterviewStage, error) {
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	stage, err := s.getStage(userID, interviewID, stageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Kind != nil {
+		stage.Kind = *req.Kind
+	}
+	if req.Name != nil {
+		stage.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Position != nil {
+		stage.Position = *req.Position
+	}
+	if req.ScheduledAt != nil {
+		stage.ScheduledAt = req.ScheduledAt
+	}
+	if req.Outcome != nil {
+		stage.Outcome = *req.Outcome
+	}
+	if req.Notes != nil {
+		stage.Notes = *req.Notes
+	}
+
+	if err := s.interviewRepo.UpdateStage(stage); err != nil {
+		return nil, err
+	}
+
+	return stage, nil
+}
+
+// DeleteStage removes a round from an interview
+func (s *InterviewService) DeleteStage(userID, interviewID, stageID int) error {
+	if _, err := s.GetInterview(userID, interviewID); err != nil {
+		return err
+	}
+
+	return s.interviewRepo.DeleteStage(interviewID, stageID)
+}
+
+// SetStageItems replaces the prep list of a round
+func (s *InterviewService) SetStageItems(userID, interviewID, stageID int, req *models.SetStagePrepItemsRequest) (*models.InterviewStage, error) {
+	if _, err := s.getStage(userID, interviewID, stageID); err != nil {
+		return nil, err
+	}
+
+	itemIDs, err := s.validateItems(req.ItemIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.interviewRepo.SetStageItems(stageID, itemIDs); err != nil {
+		return nil, err
+	}
+
+	return s.getStage(userID, interviewID, stageID)
+}
+
+// getStage loads a round of one of the user's interviews
+func (s *InterviewService) getStage(userID, interviewID, stageID int) (*models.InterviewStage, error) {
+	interview, err := s.GetInterview(userID, interviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, stage := range interview.Stages {
+		if stage.ID == stageID {
+			return stage, nil
+		}
+	}
+
+	return nil, apperr.NotFound("stage not found")
+}
+
+// setCompany links the interview to a catalog company when the name or slug matches one,
+// and otherwise keeps the name as given
+func (s *InterviewService) setCompany(interview *models.Interview, company string) error {
+	name := strings.TrimSpace(company)
+	if name == "" {
+		return fmt.Errorf("company is required")
+	}
+
+	interview.CompanyID = nil
+	interview.CompanyName = name
+
+	matches, err := s.companyRepo.GetBySlugs([]string{companySlug(name)})
+	if err != nil {
+		return err
+	}
+	if len(matches) > 0 {
+		interview.CompanyID = &matches[0].ID
+		interview.CompanyName = matches[0].Name
+	}
+
+	return nil
+}
+
+// validateItems de-duplicates a prep list and checks that every item exists
+func (s *InterviewService) validateItems(itemIDs []int) ([]int, error) {
+	itemIDs = uniqueInts(itemIDs)
+	if len(itemIDs) == 0 {
+		return itemIDs, nil
+	}
+
+	found, err := s.itemRepo.CountExistingItems(itemIDs)
+	if err != nil {
+		return nil, err
+	}
+	if found != len(itemIDs) {
+		return nil, apperr.NotFound("one or more items not found")
+	}
+
+	return itemIDs, nil
+}
@@ -1,47 +1,248 @@
 package services
 
 import (
+	"context"
 	"fmt"
+	"sync"
+	"time"
 
+	"interview-prep-app/internal/catalog"
+	"interview-prep-app/internal/events"
+	"interview-prep-app/internal/links"
 	"interview-prep-app/internal/models"
 	"interview-prep-app/internal/repositories"
+	"interview-prep-app/internal/validation"
+	"interview-prep-app/pkg/apperr"
+)
+
+// DuplicateItemError is returned when a new item looks like a copy of existing items
+type DuplicateItemError struct {
+	Conflicts []models.ItemConflict
+}
+
+func (e *DuplicateItemError) Error() string {
+	return "item duplicates an existing item"
+}
+
+const (
+	// linkEnrichmentWorkers is how many links a bulk import enriches concurrently
+	linkEnrichmentWorkers = 8
+	// defaultUpcomingReleases is how many upcoming releases are listed when no limit is given
+	defaultUpcomingReleases = 20
 )
 
 // ItemService handles business logic for items
 type ItemService struct {
-	itemRepo  *repositories.ItemRepository
-	statsRepo *repositories.StatsRepository
-	testRepo  *repositories.TestRepository
+	itemRepo        ItemStore
+	testRepo        TestStore
+	hintRepo        HintStore
+	statsRepo       StatsStore
+	txManager       TxRunner
+	bus             events.Bus
+	linkEnricher    *LinkEnricher
+	categoryService *CategoryService
 }
 
-// NewItemService creates a new item service
-func NewItemService(itemRepo *repositories.ItemRepository, statsRepo *repositories.StatsRepository, testRepo *repositories.TestRepository) *ItemService {
+// NewItemService creates a new item service. linkEnricher may be nil, in which case new items
+// aren't prefilled from their pages.
+func NewItemService(itemRepo ItemStore, testRepo TestStore, hintRepo HintStore, statsRepo StatsStore, txManager TxRunner, categoryService *CategoryService, bus events.Bus, linkEnricher *LinkEnricher) *ItemService {
 	return &ItemService{
-		itemRepo:  itemRepo,
-		statsRepo: statsRepo,
-		testRepo:  testRepo,
+		itemRepo:        itemRepo,
+		testRepo:        testRepo,
+		hintRepo:        hintRepo,
+		statsRepo:       statsRepo,
+		txManager:       txManager,
+		bus:             bus,
+		linkEnricher:    linkEnricher,
+		categoryService: categoryService,
+	}
+}
+
+// CreateItem creates a new item with validation. Unless force is set, an item whose link points at
+// the same page as an existing item, or whose title is nearly identical, is refused with a
+// *DuplicateItemError listing the existing items.
+func (s *ItemService) CreateItem(req *models.CreateItemRequest, force bool) (*models.Item, error) {
+	if err := s.validateCreateItemRequest(req); err != nil {
+		return nil, err
+	}
+	req.Link = links.Normalize(req.Link)
+
+	if !force {
+		existing, err := s.itemRepo.GetDuplicateCandidates()
+		if err != nil {
+			return nil, err
+		}
+		if conflicts := catalog.FindItemDuplicates(req.Title, req.Link, existing); len(conflicts) > 0 {
+			return nil, &DuplicateItemError{Conflicts: conflicts}
+		}
+	}
+
+	s.prefillAttachments(req)
+
+	item, err := s.itemRepo.Create(req)
+	if err != nil {
+		return nil, err
+	}
+
+	publishEvent(s.bus, events.CatalogChanged, 0, item)
+	return item, nil
+}
+
+// BulkCreateItems imports several items, skipping invalid ones and, unless force is set, ones that
+// duplicate an existing item or an earlier item in the same import
+func (s *ItemService) BulkCreateItems(reqs []models.CreateItemRequest, force bool) (*models.BulkCreateItemsResponse, error) {
+	if len(reqs) == 0 {
+		return nil, fmt.Errorf("no items to import")
+	}
+
+	existing, err := s.itemRepo.GetDuplicateCandidates()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.BulkCreateItemsResponse{
+		Created: []*models.Item{},
+		Skipped: []models.SkippedItem{},
+	}
+
+	invalid := make(map[int]error)
+	valid := make([]*models.CreateItemRequest, 0, len(reqs))
+	for i := range reqs {
+		if err := s.validateCreateItemRequest(&reqs[i]); err != nil {
+			invalid[i] = err
+			continue
+		}
+		reqs[i].Link = links.Normalize(reqs[i].Link)
+		valid = append(valid, &reqs[i])
+	}
+
+	// Pages are fetched up front and in parallel; duplicates skipped below are fetched too,
+	// which is cheaper than fetching the rest one by one
+	s.prefillAllAttachments(valid)
+
+	for i := range reqs {
+		req := &reqs[i]
+		skipped := models.SkippedItem{Index: i, Title: req.Title, Link: req.Link}
+
+		if err, ok := invalid[i]; ok {
+			skipped.Reason = err.Error()
+			response.Skipped = append(response.Skipped, skipped)
+			continue
+		}
+
+		if !force {
+			if conflicts := catalog.FindItemDuplicates(req.Title, req.Link, existing); len(conflicts) > 0 {
+				skipped.Reason = "duplicates an existing item"
+				skipped.Conflicts = conflicts
+				response.Skipped = append(response.Skipped, skipped)
+				continue
+			}
+		}
+
+		item, err := s.itemRepo.Create(req)
+		if err != nil {
+			return nil, err
+		}
+		response.Created = append(response.Created, item)
+		existing = append(existing, item)
+	}
+
+	if len(response.Created) > 0 {
+		publishEvent(s.bus, events.CatalogChanged, 0, map[string]int{"created": len(response.Created)})
+	}
+
+	return response, nil
+}
+
+// PromoteEngBlogArticle creates an item from an eng blog article, linked back to the article, so
+// it shows up in progress tracking and next-item selection. Each article can be promoted once;
+// otherwise the item goes through the same duplicate checks as CreateItem.
+func (s *ItemService) PromoteEngBlogArticle(article *models.EngBlogArticleDB, blogName string, req *models.PromoteArticleRequest, force bool) (*models.Item, error) {
+	if promoted, err := s.itemRepo.GetBySourceArticle(article.ID); err == nil {
+		return nil, apperr.Conflict(fmt.Sprintf("article already promoted to item %d", promoted.ID))
+	} else if err.Error() != "item not found" {
+		return nil, err
+	}
+
+	createReq := &models.CreateItemRequest{
+		Title:           article.Title,
+		Link:            article.ExternalLink,
+		Category:        models.PromotedArticleCategory,
+		Subcategory:     models.PromotedArticleSubcategory,
+		Attachments:     models.Attachments{models.AttachmentSourceBlog: blogName},
+		SourceArticleID: &article.ID,
+	}
+	if req.Category != "" {
+		createReq.Category = req.Category
+	}
+	if req.Subcategory != "" {
+		createReq.Subcategory = req.Subcategory
+	}
+
+	return s.CreateItem(createReq, force)
+}
+
+// prefillAttachments adds the title and description of a new item's page to its attachments,
+// keeping any the request already sets. Enrichment is best effort: a page that can't be fetched
+// only logs a warning.
+func (s *ItemService) prefillAttachments(req *models.CreateItemRequest) {
+	if s.linkEnricher == nil {
+		return
+	}
+
+	metadata, err := s.linkEnricher.Enrich(context.Background(), req.Link)
+	if err != nil {
+		fmt.Printf("Warning: failed to enrich link %s: %v\n", req.Link, err)
+		return
+	}
+
+	prefill := map[string]string{
+		models.AttachmentPageTitle:   metadata.Title,
+		models.AttachmentDescription: metadata.Description,
+	}
+	for key, value := range prefill {
+		if _, exists := req.Attachments[key]; exists || value == "" {
+			continue
+		}
+		if req.Attachments == nil {
+			req.Attachments = models.Attachments{}
+		}
+		req.Attachments[key] = value
 	}
 }
 
-// CreateItem creates a new item with validation
-func (s *ItemService) CreateItem(req *models.CreateItemRequest) (*models.Item, error) {
-	// Validate category
-	if !models.IsValidCategory(req.Category) {
-		return nil, fmt.Errorf("invalid category: %s. Valid categories are: %v", req.Category, models.ValidCategories())
+// prefillAllAttachments prefills several new items concurrently
+func (s *ItemService) prefillAllAttachments(reqs []*models.CreateItemRequest) {
+	if s.linkEnricher == nil {
+		return
 	}
 
-	// Validate required fields
-	if req.Title == "" {
-		return nil, fmt.Errorf("title is required")
+	queue := make(chan *models.CreateItemRequest)
+	var wg sync.WaitGroup
+	for i := 0; i < linkEnrichmentWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for req := range queue {
+				s.prefillAttachments(req)
+			}
+		}()
 	}
-	if req.Link == "" {
-		return nil, fmt.Errorf("link is required")
+
+	for _, req := range reqs {
+		queue <- req
 	}
-	if req.Subcategory == "" {
-		return nil, fmt.Errorf("subcategory is required")
+	close(queue)
+	wg.Wait()
+}
+
+// validateCreateItemRequest checks the category and required fields of a new item
+func (s *ItemService) validateCreateItemRequest(req *models.CreateItemRequest) error {
+	if err := validation.Struct(req); err != nil {
+		return err
 	}
 
-	return s.itemRepo.Create(req)
+	return s.categoryService.ValidateCategory(req.Category)
 }
 
 // GetItem retrieves an item by ID
@@ -69,8 +270,10 @@ func (s *ItemService) GetItemWithUserProgress(userID, itemID int) (*models.ItemW
 // GetItems retrieves items with filtering and validation
 func (s *ItemService) GetItems(filter *models.ItemFilter) ([]*models.Item, error) {
 	// Validate filter parameters
-	if filter.Category != nil && !models.IsValidCategory(*filter.Category) {
-		return nil, fmt.Errorf("invalid category: %s", *filter.Category)
+	if filter.Category != nil {
+		if err := s.categoryService.ValidateCategory(*filter.Category); err != nil {
+			return nil, err
+		}
 	}
 
 	if filter.Status != nil && !models.IsValidStatus(*filter.Status) {
@@ -91,8 +294,10 @@ func (s *ItemService) GetItems(filter *models.ItemFilter) ([]*models.Item, error
 // GetItemsWithUserProgress retrieves items with user-specific progress data
 func (s *ItemService) GetItemsWithUserProgress(userID int, filter *models.ItemFilter) ([]*models.ItemWithProgress, error) {
 	// Validate filter parameters
-	if filter.Category != nil && !models.IsValidCategory(*filter.Category) {
-		return nil, fmt.Errorf("invalid category: %s", *filter.Category)
+	if filter.Category != nil {
+		if err := s.categoryService.ValidateCategory(*filter.Category); err != nil {
+			return nil, err
+		}
 	}
 
 	if filter.Status != nil && !models.IsValidStatus(*filter.Status) {
@@ -117,8 +322,10 @@ func (s *ItemService) GetItemsWithUserProgress(userID int, filter *models.ItemFi
 // GetItemsPaginated retrieves items with filtering, validation and pagination metadata
 func (s *ItemService) GetItemsPaginated(filter *models.ItemFilter) (*models.PaginatedItemsResponse, error) {
 	// Validate filter parameters
-	if filter.Category != nil && !models.IsValidCategory(*filter.Category) {
-		return nil, fmt.Errorf("invalid category: %s", *filter.Category)
+	if filter.Category != nil {
+		if err := s.categoryService.ValidateCategory(*filter.Category); err != nil {
+			return nil, err
+		}
 	}
 
 	if filter.Status != nil && !models.IsValidStatus(*filter.Status) {
@@ -147,7 +354,7 @@ func (s *ItemService) GetItemsPaginated(filter *models.ItemFilter) (*models.Pagi
 	// Get total count
 	totalCount, err := s.itemRepo.GetTotalCount(filter)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get total count: %w", err)
+		return nil, apperr.Errorf("failed to get total count: %w", err)
 	}
 
 	// Get items
@@ -197,8 +404,10 @@ func (s *ItemService) GetItemsPaginated(filter *models.ItemFilter) (*models.Pagi
 // GetItemsPaginatedWithUserProgress retrieves items with user-specific progress data, filtering, validation and pagination metadata
 func (s *ItemService) GetItemsPaginatedWithUserProgress(userID int, filter *models.ItemFilter) (*models.PaginatedItemsResponse, error) {
 	// Validate filter parameters
-	if filter.Category != nil && !models.IsValidCategory(*filter.Category) {
-		return nil, fmt.Errorf("invalid category: %s", *filter.Category)
+	if filter.Category != nil {
+		if err := s.categoryService.ValidateCategory(*filter.Category); err != nil {
+			return nil, err
+		}
 	}
 
 	if filter.Status != nil && !models.IsValidStatus(*filter.Status) {
@@ -231,7 +440,7 @@ func (s *ItemService) GetItemsPaginatedWithUserProgress(userID int, filter *mode
 	// Get total count with user progress
 	totalCount, err := s.itemRepo.GetTotalCountWithUserProgress(userID, filter)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get total count: %w", err)
+		return nil, apperr.Errorf("failed to get total count: %w", err)
 	}
 
 	// Get items with user progress
@@ -276,7 +485,7 @@ func (s *ItemService) GetNextItemWithUserProgress(userID int) (*models.ItemWithP
 	// First check if there's already an in-progress item for this user
 	inProgressItem, err := s.itemRepo.GetInProgressItemWithUserProgress(userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check for in-progress item: %w", err)
+		return nil, apperr.Errorf("failed to check for in-progress item: %w", err)
 	}
 
 	// If there's an in-progress item, return it
@@ -293,14 +502,15 @@ func (s *ItemService) GetNextItemWithUserProgress(userID int) (*models.ItemWithP
 	// Reset any existing in-progress items for this user
 	err = s.itemRepo.ResetInProgressItemsForUser(userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to reset in-progress items: %w", err)
+		return nil, apperr.Errorf("failed to reset in-progress items: %w", err)
 	}
 
 	// Create or update user progress record to set it as in-progress
 	err = s.itemRepo.UpsertUserProgressForItem(userID, pendingItem.ID, models.StatusInProgress)
 	if err != nil {
-		return nil, fmt.Errorf("failed to upsert user progress: %w", err)
+		return nil, apperr.Errorf("failed to upsert user progress: %w", err)
 	}
+	publishEvent(s.bus, events.ProgressChanged, userID, pendingItem)
 
 	// Update the item status to in-progress and return it
 	pendingItem.Status = models.StatusInProgress
@@ -320,17 +530,17 @@ func (s *ItemService) SkipItemWithUserProgress(userID int) (*models.ItemWithProg
 
 	isInTest, err := s.testRepo.IsItemInPendingTest(userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check if test is active: %w", err)
+		return nil, apperr.Errorf("failed to check if test is active: %w", err)
 	}
 
 	if isInTest {
 		return nil, fmt.Errorf("cannot skip item: test is active")
 	}
 
-	// First, reset any existing in-progress items for this user back to pending
-	err = s.itemRepo.ResetInProgressItemsForUser(userID)
+	// First, move any existing in-progress items for this user back to pending, counting the skip
+	err = s.itemRepo.SkipInProgressItemsForUser(userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to reset in-progress items: %w", err)
+		return nil, apperr.Errorf("failed to reset in-progress items: %w", err)
 	}
 
 	// Get a new random pending item for this user
@@ -342,8 +552,9 @@ func (s *ItemService) SkipItemWithUserProgress(userID int) (*models.ItemWithProg
 	// Set the new item as in-progress
 	err = s.itemRepo.UpsertUserProgressForItem(userID, pendingItem.ID, models.StatusInProgress)
 	if err != nil {
-		return nil, fmt.Errorf("failed to upsert user progress: %w", err)
+		return nil, apperr.Errorf("failed to upsert user progress: %w", err)
 	}
+	publishEvent(s.bus, events.ProgressChanged, userID, pendingItem)
 
 	// Update the item status to in-progress and return it
 	pendingItem.Status = models.StatusInProgress
@@ -355,8 +566,11 @@ func (s *ItemService) CompleteItem(id int) (*models.Item, error) {
 	return nil, fmt.Errorf("CompleteItem is deprecated - use CompleteItemWithUserProgress instead")
 }
 
-// CompleteItemWithUserProgress marks an item as completed for a specific user and handles user stats
-func (s *ItemService) CompleteItemWithUserProgress(userID, itemID int) (*models.ItemWithProgress, error) {
+// CompleteItemWithUserProgress marks an item as completed for a specific user and handles user stats.
+// When no quality is given, it defaults to reviewed_solution if the user revealed any hints, otherwise solved.
+// Revealed hints also cut the credit the completion earns, which brings the first review forward and
+// counts for less in recommendations.
+func (s *ItemService) CompleteItemWithUserProgress(ctx context.Context, userID, itemID int, quality models.CompletionQuality) (*models.ItemWithProgress, error) {
 	if userID <= 0 {
 		return nil, fmt.Errorf("invalid user ID")
 	}
@@ -365,44 +579,40 @@ func (s *ItemService) CompleteItemWithUserProgress(userID, itemID int) (*models.
 		return nil, fmt.Errorf("invalid item ID")
 	}
 
+	revealed, credit := s.hintCredit(userID, itemID)
+	if quality == "" {
+		quality = models.CompletionSolved
+		if revealed > 0 {
+			quality = models.CompletionReviewedSolution
+		}
+	}
+
+	if !models.IsValidCompletionQuality(quality) {
+		return nil, fmt.Errorf("invalid completion quality: %s. Valid values are: %s, %s", quality, models.CompletionSolved, models.CompletionReviewedSolution)
+	}
+
 	// Check if the item is part of an active test
 	isInTest, err := s.testRepo.IsItemInPendingTest(userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check if test is active: %w", err)
+		return nil, apperr.Errorf("failed to check if test is active: %w", err)
 	}
 
 	if isInTest {
 		return nil, fmt.Errorf("cannot complete item: test is active")
 	}
 
-	// Mark item as complete for the user
-	item, err := s.itemRepo.CompleteItemForUser(userID, itemID)
+	// Mark item as complete for the user, advancing their streak and completed-all count with it
+	item, streak, err := s.RecordCompletion(ctx, userID, itemID, quality, credit, CompletionReviewAt(quality, credit, time.Now()))
 	if err != nil {
 		return nil, err
 	}
 
 	fmt.Println("itemID---------", itemID)
 
-	// Update user's daily streak
-	if err := s.statsRepo.UpdateUserStreakOnActivity(userID); err != nil {
-		// Log error but don't fail the completion
-		fmt.Printf("Warning: failed to update user streak for user %d: %v\n", userID, err)
-	}
-
-	// Check if all items are now completed for this user
-	pendingCount, err := s.itemRepo.CountPendingForUser(userID)
-	if err != nil {
-		// Log error but don't fail the completion
-		fmt.Printf("Warning: failed to count pending items for user %d: %v\n", userID, err)
-		return item, nil
-	}
-
-	// If all items are completed for this user, increment their completed_all_count
-	if pendingCount == 0 {
-		if err := s.statsRepo.IncrementUserCompletedAllCount(userID); err != nil {
-			// Log error but don't fail the completion
-			fmt.Printf("Warning: failed to increment user completed_all_count for user %d: %v\n", userID, err)
-		}
+	// Daily goal and stats aggregates are updated by the stats worker when it receives this event
+	publishEvent(s.bus, events.ItemCompleted, userID, item)
+	if streak != nil {
+		publishEvent(s.bus, events.StreakChanged, userID, *streak)
 	}
 
 	// Check if all miscellaneous items are completed for this user
@@ -436,15 +646,53 @@ func (s *ItemService) CompleteItemWithUserProgress(userID, itemID int) (*models.
 	return item, nil
 }
 
-// UpdateItem updates an existing item with validation
-func (s *ItemService) UpdateItem(id int, req *models.UpdateItemRequest) (*models.Item, error) {
-	if id <= 0 {
-		return nil, fmt.Errorf("invalid item ID")
+// RecordCompletion writes an item's completion for a user in one transaction: the progress update,
+// the user's streak and, when nothing is left pending, their completed-all count. Unlike
+// CompleteItemWithUserProgress it runs no checks and publishes no events. The returned streak change
+// is nil when the user had already been active today.
+func (s *ItemService) RecordCompletion(ctx context.Context, userID, itemID int, quality models.CompletionQuality, hintCredit float64, nextReviewAt time.Time) (*models.ItemWithProgress, *models.StreakChangedData, error) {
+	var streak *models.StreakChangedData
+	err := s.txManager.WithUserTx(ctx, userID, func(tx *repositories.Tx) error {
+		if err := s.itemRepo.MarkCompleted(tx, userID, itemID, quality, hintCredit, nextReviewAt); err != nil {
+			return err
+		}
+
+		var err error
+		streak, err = s.statsRepo.AdvanceStreak(tx, userID, time.Now().UTC().Truncate(24*time.Hour))
+		if err != nil {
+			return err
+		}
+		if streak.CurrentStreak == streak.PreviousStreak {
+			streak = nil
+		}
+
+		// Completing the last pending item counts as completing everything
+		pendingCount, err := s.itemRepo.CountPendingForUserInTx(tx, userID)
+		if err != nil {
+			return err
+		}
+		if pendingCount == 0 {
+			return s.statsRepo.IncrementUserCompletedAllCount(tx, userID)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
 	}
 
-	// Validate category if provided
-	if req.Category != nil && !models.IsValidCategory(*req.Category) {
-		return nil, fmt.Errorf("invalid category: %s", *req.Category)
+	item, err := s.itemRepo.GetByIDWithUserProgress(userID, itemID)
+	if err != nil {
+		return nil, nil, apperr.Errorf("failed to get completed item: %w", err)
+	}
+
+	return item, streak, nil
+}
+
+// UpdateItem updates an existing item with validation, recording the edit as a revision by editorID
+func (s *ItemService) UpdateItem(editorID, id int, req *models.UpdateItemRequest) (*models.Item, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("invalid item ID")
 	}
 
 	// Validate that at least one field is being updated
@@ -452,18 +700,150 @@ func (s *ItemService) UpdateItem(id int, req *models.UpdateItemRequest) (*models
 		return nil, fmt.Errorf("at least one field must be provided for update")
 	}
 
-	// Validate non-empty strings
-	if req.Title != nil && *req.Title == "" {
-		return nil, fmt.Errorf("title cannot be empty")
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	// Validate category if provided
+	if req.Category != nil {
+		if err := s.categoryService.ValidateCategory(*req.Category); err != nil {
+			return nil, err
+		}
+	}
+
+	item, err := s.itemRepo.Update(editorID, id, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Moving an item in or out of the miscellaneous category changes everyone's totals
+	if req.Category != nil {
+		publishEvent(s.bus, events.CatalogChanged, 0, item)
+	}
+	publishEvent(s.bus, events.ItemUpdated, 0, item)
+	return item, nil
+}
+
+// PublishItem publishes a draft item so users see it, or with published false takes it back to
+// draft and hides it from them
+func (s *ItemService) PublishItem(id int, req *models.PublishItemRequest) (*models.Item, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("invalid item ID")
+	}
+
+	published := req.Published == nil || *req.Published
+	item, err := s.itemRepo.SetPublished(id, published)
+	if err != nil {
+		return nil, err
+	}
+
+	// Publishing changes everyone's totals
+	publishEvent(s.bus, events.CatalogChanged, 0, item)
+	return item, nil
+}
+
+// GetDrafts lists the items waiting to be published
+func (s *ItemService) GetDrafts() (*models.ItemDraftsResponse, error) {
+	items, err := s.itemRepo.GetDrafts()
+	if err != nil {
+		return nil, err
 	}
-	if req.Link != nil && *req.Link == "" {
-		return nil, fmt.Errorf("link cannot be empty")
+
+	return &models.ItemDraftsResponse{Items: items}, nil
+}
+
+// ScheduleItems sets when draft items are published, such as a weekly drop going live together.
+// The time must be in the future; without one, the items are unscheduled and stay drafts.
+func (s *ItemService) ScheduleItems(req *models.ScheduleItemsRequest) ([]*models.Item, error) {
+	if err := validation.Struct(req); err != nil {
+		return nil, err
 	}
-	if req.Subcategory != nil && *req.Subcategory == "" {
-		return nil, fmt.Errorf("subcategory cannot be empty")
+	if req.PublishAt != nil && !req.PublishAt.After(time.Now()) {
+		return nil, apperr.Validation("publish_at must be in the future")
 	}
 
-	return s.itemRepo.Update(id, req)
+	return s.itemRepo.Schedule(req.ItemIDs, req.PublishAt)
+}
+
+// ReleaseScheduledItems publishes the scheduled items that are due
+func (s *ItemService) ReleaseScheduledItems(ctx context.Context) error {
+	items, err := s.itemRepo.ReleaseDue(time.Now())
+	if err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		return nil
+	}
+
+	fmt.Printf("Released %d scheduled items\n", len(items))
+	publishEvent(s.bus, events.CatalogChanged, 0, map[string]int{"released": len(items)})
+	return nil
+}
+
+// GetUpcomingReleases lists the items scheduled to be published, soonest first
+func (s *ItemService) GetUpcomingReleases(limit int) (*models.UpcomingReleasesResponse, error) {
+	if limit == 0 {
+		limit = defaultUpcomingReleases
+	}
+	if limit < 0 || limit > models.MaxUpcomingReleases {
+		return nil, fmt.Errorf("limit must be between 1 and %d", models.MaxUpcomingReleases)
+	}
+
+	releases, err := s.itemRepo.GetUpcomingReleases(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.UpcomingReleasesResponse{Releases: releases}, nil
+}
+
+// GetItemRevisions lists the recorded revisions of an item's content, newest first
+func (s *ItemService) GetItemRevisions(id int) (*models.ItemRevisionsResponse, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("invalid item ID")
+	}
+
+	if _, err := s.itemRepo.GetByID(id); err != nil {
+		return nil, err
+	}
+
+	revisions, err := s.itemRepo.GetRevisions(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ItemRevisionsResponse{ItemID: id, Revisions: revisions}, nil
+}
+
+// RevertItem restores an item's content to what it was at one of its revisions. The revert is
+// an edit by editorID like any other, so it can itself be reverted.
+func (s *ItemService) RevertItem(editorID, id, revisionID int, req *models.RevertItemRequest) (*models.Item, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("invalid item ID")
+	}
+	if revisionID <= 0 {
+		return nil, fmt.Errorf("invalid revision ID")
+	}
+
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	current, err := s.itemRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := s.itemRepo.Revert(editorID, id, revisionID, req.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	if item.Category != current.Category {
+		publishEvent(s.bus, events.CatalogChanged, 0, item)
+	}
+	publishEvent(s.bus, events.ItemUpdated, 0, item)
+	return item, nil
 }
 
 // DeleteItem removes an item
@@ -472,7 +852,12 @@ func (s *ItemService) DeleteItem(id int) error {
 		return fmt.Errorf("invalid item ID")
 	}
 
-	return s.itemRepo.Delete(id)
+	if err := s.itemRepo.Delete(id); err != nil {
+		return err
+	}
+
+	publishEvent(s.bus, events.CatalogChanged, 0, map[string]int{"item_id": id})
+	return nil
 }
 
 // ResetAllItems marks all items as pending
@@ -486,7 +871,13 @@ func (s *ItemService) ResetAllItemsWithUserProgress(userID int) (int64, error) {
 		return 0, fmt.Errorf("invalid user ID")
 	}
 
-	return s.itemRepo.ResetAllUserProgress(userID)
+	rowsAffected, err := s.itemRepo.ResetAllUserProgress(userID)
+	if err != nil {
+		return 0, err
+	}
+
+	publishEvent(s.bus, events.ProgressChanged, userID, nil)
+	return rowsAffected, nil
 }
 
 // ResetItemsByCategoryWithUserProgress resets all user progress for a specific category back to pending
@@ -495,11 +886,20 @@ func (s *ItemService) ResetItemsByCategoryWithUserProgress(userID int, category
 		return 0, fmt.Errorf("invalid user ID")
 	}
 
-	if !models.IsValidCategory(category) {
-		return 0, fmt.Errorf("invalid category: %s", category)
+	if err := s.categoryService.ValidateCategory(category); err != nil {
+		return 0, err
+	}
+
+	rowsAffected, err := s.itemRepo.ResetUserProgressByCategory(userID, category)
+	if err != nil {
+		return 0, err
 	}
 
-	return s.itemRepo.ResetUserProgressByCategory(userID, category)
+	// Miscellaneous items aren't part of the stats aggregates
+	if category != models.CategoryMiscellaneous {
+		publishEvent(s.bus, events.ProgressChanged, userID, nil)
+	}
+	return rowsAffected, nil
 }
 
 // GetItemCounts returns basic item statistics
@@ -507,14 +907,17 @@ func (s *ItemService) GetItemCounts() (total, completed, pending int, err error)
 	return 0, 0, 0, fmt.Errorf("GetItemCounts is deprecated - use GetCountsForUser instead")
 }
 
-// GetCommonSubcategories returns the list of common subcategories for a given category
+// GetCommonSubcategories returns the subcategories listed for a given category
 func (s *ItemService) GetCommonSubcategories(category models.Category) ([]string, error) {
-	if !models.IsValidCategory(category) {
-		return nil, fmt.Errorf("invalid category: %s", category)
+	if err := s.categoryService.ValidateCategory(category); err != nil {
+		return nil, err
 	}
 
-	subcategories, exists := models.CommonSubcategories[category]
-	if !exists {
+	subcategories, err := s.categoryService.GetSubcategories(category)
+	if err != nil {
+		return nil, err
+	}
+	if len(subcategories) == 0 {
 		return []string{"other"}, nil
 	}
 
@@ -539,13 +942,61 @@ func (s *ItemService) ToggleStarWithUserProgress(userID, itemID int) (*models.It
 	return s.itemRepo.ToggleStarForUser(userID, itemID)
 }
 
+// SetStarredBatch stars or unstars many items for a user at once
+func (s *ItemService) SetStarredBatch(userID int, req *models.BatchStarRequest) (*models.BatchStarResult, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	itemIDs := uniqueInts(req.ItemIDs)
+	if len(itemIDs) > models.MaxBatchStarItems {
+		return nil, fmt.Errorf("at most %d items can be starred at once", models.MaxBatchStarItems)
+	}
+	for _, id := range itemIDs {
+		if id <= 0 {
+			return nil, fmt.Errorf("invalid item ID")
+		}
+	}
+
+	changed, unchanged, err := s.itemRepo.SetStarredForUser(userID, itemIDs, *req.Starred)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[int]bool, len(changed)+len(unchanged))
+	for _, id := range changed {
+		found[id] = true
+	}
+	for _, id := range unchanged {
+		found[id] = true
+	}
+
+	result := &models.BatchStarResult{
+		Starred:   *req.Starred,
+		Updated:   len(changed),
+		Unchanged: len(unchanged),
+		NotFound:  []int{},
+	}
+	for _, id := range itemIDs {
+		if !found[id] {
+			result.NotFound = append(result.NotFound, id)
+		}
+	}
+
+	return result, nil
+}
+
 // UpdateStatus updates the status of an item
 func (s *ItemService) UpdateStatus(id int, status models.Status) (*models.Item, error) {
 	return nil, fmt.Errorf("UpdateStatus is deprecated - use UpdateStatusWithUserProgress instead")
 }
 
 // UpdateStatusWithUserProgress updates the status of an item for a specific user
-func (s *ItemService) UpdateStatusWithUserProgress(userID, itemID int, status models.Status) (*models.ItemWithProgress, error) {
+func (s *ItemService) UpdateStatusWithUserProgress(ctx context.Context, userID, itemID int, status models.Status) (*models.ItemWithProgress, error) {
 	if userID <= 0 {
 		return nil, fmt.Errorf("invalid user ID")
 	}
@@ -568,9 +1019,149 @@ func (s *ItemService) UpdateStatusWithUserProgress(userID, itemID int, status mo
 	// If setting to done, check if all items will be completed and update stats
 	if status == models.StatusDone {
 		// Use the CompleteItemWithUserProgress method which handles the stats logic
-		return s.CompleteItemWithUserProgress(userID, itemID)
+		return s.CompleteItemWithUserProgress(ctx, userID, itemID, "")
 	}
 
 	// For other statuses (pending), just update the status
-	return s.itemRepo.UpdateStatusForUser(userID, itemID, status)
+	item, err := s.itemRepo.UpdateStatusForUser(userID, itemID, status)
+	if err != nil {
+		return nil, err
+	}
+
+	publishEvent(s.bus, events.ProgressChanged, userID, item)
+	return item, nil
+}
+
+// GetDueReviews returns completed items that are due to be resurfaced for the user
+func (s *ItemService) GetDueReviews(userID, limit int) ([]*models.ItemWithProgress, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	return s.itemRepo.GetDueReviewsForUser(userID, time.Now(), limit)
+}
+
+// ReviewItem records a review of a completed item and reschedules it based on the review quality
+func (s *ItemService) ReviewItem(userID, itemID int, quality models.CompletionQuality) (*models.ItemWithProgress, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if itemID <= 0 {
+		return nil, fmt.Errorf("invalid item ID")
+	}
+
+	if !models.IsValidCompletionQuality(quality) {
+		return nil, fmt.Errorf("invalid completion quality: %s. Valid values are: %s, %s", quality, models.CompletionSolved, models.CompletionReviewedSolution)
+	}
+
+	reviewCount, err := s.itemRepo.GetReviewCountForUser(userID, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Needing the solution again restarts the schedule from the shortest interval
+	if quality == models.CompletionReviewedSolution {
+		reviewCount = 0
+	} else {
+		reviewCount++
+	}
+
+	item, err := s.itemRepo.RecordReviewForUser(userID, itemID, quality, NextReviewAt(quality, reviewCount, time.Now()))
+	if err != nil {
+		return nil, err
+	}
+
+	// The review quality moves the item between the solved and reviewed-solution counts
+	publishEvent(s.bus, events.ProgressChanged, userID, item)
+	return item, nil
+}
+
+// hintCredit returns how many of an item's hints the user revealed and the credit multiplier
+// completing it earns as a result
+func (s *ItemService) hintCredit(userID, itemID int) (int, float64) {
+	if s.hintRepo == nil {
+		return 0, 1
+	}
+
+	revealed, total, err := s.hintRepo.CountRevealedForUser(userID, itemID)
+	if err != nil {
+		// Log error but don't fail the completion
+		fmt.Printf("Warning: failed to count revealed hints for user %d item %d: %v\n", userID, itemID, err)
+		return 0, 1
+	}
+
+	return revealed, models.HintCreditMultiplier(revealed, total)
+}
+
+// GetItemAnalytics returns a page of per-item stats aggregated across all users, leaving out
+// items attempted by fewer than MinAttempts users. Lowest completion rates come first by default.
+func (s *ItemService) GetItemAnalytics(filter *models.ItemAnalyticsFilter) (*models.ItemAnalyticsResponse, error) {
+	if filter.Category != nil {
+		if err := s.categoryService.ValidateCategory(*filter.Category); err != nil {
+			return nil, err
+		}
+	}
+
+	if filter.Sort == "" {
+		filter.Sort = models.ItemAnalyticsSortCompletionRate
+	}
+	if !models.IsValidItemAnalyticsSort(filter.Sort) {
+		return nil, fmt.Errorf("invalid sort: %s", filter.Sort)
+	}
+
+	if filter.MinAttempts < 0 {
+		return nil, fmt.Errorf("min_attempts cannot be negative")
+	}
+
+	if filter.Limit == 0 {
+		filter.Limit = models.DefaultItemAnalyticsLimit
+	}
+	if filter.Limit < 0 || filter.Limit > models.MaxItemAnalyticsLimit {
+		return nil, fmt.Errorf("limit must be between 1 and %d", models.MaxItemAnalyticsLimit)
+	}
+
+	if filter.Offset < 0 {
+		return nil, fmt.Errorf("offset cannot be negative")
+	}
+
+	items, total, err := s.itemRepo.GetItemAnalytics(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ItemAnalyticsResponse{
+		Items: items,
+		Pagination: models.PaginationMeta{
+			Page:       filter.Offset/filter.Limit + 1,
+			Limit:      filter.Limit,
+			Offset:     filter.Offset,
+			Total:      total,
+			TotalPages: (total + filter.Limit - 1) / filter.Limit,
+			HasNext:    filter.Offset+filter.Limit < total,
+			HasPrev:    filter.Offset > 0,
+		},
+		GeneratedAt: time.Now(),
+	}, nil
+}
+
+// GetItemAnalyticsByID returns one item's stats aggregated across all users
+func (s *ItemService) GetItemAnalyticsByID(itemID int) (*models.ItemAnalytics, error) {
+	if itemID <= 0 {
+		return nil, fmt.Errorf("invalid item ID")
+	}
+
+	items, _, err := s.itemRepo.GetItemAnalytics(&models.ItemAnalyticsFilter{ItemID: &itemID, Limit: 1})
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, apperr.NotFound("item not found")
+	}
+
+	return items[0], nil
 }
@@ -0,0 +1,293 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+	"interview-prep-app/internal/services/mocks"
+)
+
+// passthroughTx runs units of work without a database
+func passthroughTx() *mocks.TxRunner {
+	return &mocks.TxRunner{
+		WithUserTxFunc: func(ctx context.Context, userID int, fn func(tx *repositories.Tx) error) error {
+			return fn(nil)
+		},
+	}
+}
+
+func TestRecordCompletion(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		streak               models.StreakChangedData
+		pending              int
+		expectStreakChange   bool
+		expectCompletedCount bool
+	}{
+		{
+			name:               "First completion today advances the streak",
+			streak:             models.StreakChangedData{PreviousStreak: 2, CurrentStreak: 3, LongestStreak: 3},
+			pending:            4,
+			expectStreakChange: true,
+		},
+		{
+			name:    "Later completions today leave the streak alone",
+			streak:  models.StreakChangedData{PreviousStreak: 3, CurrentStreak: 3, LongestStreak: 3},
+			pending: 4,
+		},
+		{
+			name:                 "Completing the last pending item counts as completing everything",
+			streak:               models.StreakChangedData{PreviousStreak: 3, CurrentStreak: 3, LongestStreak: 3},
+			pending:              0,
+			expectCompletedCount: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var marked, completedCounted bool
+			itemRepo := &mocks.ItemStore{
+				MarkCompletedFunc: func(tx *repositories.Tx, userID, itemID int, quality models.CompletionQuality, hintCredit float64, nextReviewAt time.Time) error {
+					marked = userID == 1 && itemID == 7 && quality == models.CompletionSolved
+					return nil
+				},
+				CountPendingForUserInTxFunc: func(tx *repositories.Tx, userID int) (int, error) {
+					return tc.pending, nil
+				},
+				GetByIDWithUserProgressFunc: func(userID, itemID int) (*models.ItemWithProgress, error) {
+					return &models.ItemWithProgress{ID: itemID, Status: models.StatusDone}, nil
+				},
+			}
+			statsRepo := &mocks.StatsStore{
+				AdvanceStreakFunc: func(tx *repositories.Tx, userID int, today time.Time) (*models.StreakChangedData, error) {
+					streak := tc.streak
+					return &streak, nil
+				},
+				IncrementUserCompletedAllCountFunc: func(tx *repositories.Tx, userID int) error {
+					completedCounted = true
+					return nil
+				},
+			}
+			service := NewItemService(itemRepo, &mocks.TestStore{}, nil, statsRepo, passthroughTx(), nil, nil, nil)
+
+			item, streak, err := service.RecordCompletion(context.Background(), 1, 7, models.CompletionSolved, 1, time.Now())
+			if err != nil {
+				t.Fatalf("RecordCompletion returned error: %v", err)
+			}
+
+			if !marked {
+				t.Error("Expected the item to be marked completed for the user")
+			}
+			if item.ID != 7 {
+				t.Errorf("Expected item 7, got %d", item.ID)
+			}
+			if tc.expectStreakChange != (streak != nil) {
+				t.Errorf("Expected streak change %v, got %+v", tc.expectStreakChange, streak)
+			}
+			if tc.expectCompletedCount != completedCounted {
+				t.Errorf("Expected completed-all count incremented %v, got %v", tc.expectCompletedCount, completedCounted)
+			}
+		})
+	}
+}
+
+func TestRecordCompletionRollsBackOnError(t *testing.T) {
+	itemRepo := &mocks.ItemStore{
+		MarkCompletedFunc: func(tx *repositories.Tx, userID, itemID int, quality models.CompletionQuality, hintCredit float64, nextReviewAt time.Time) error {
+			return nil
+		},
+	}
+	statsRepo := &mocks.StatsStore{
+		AdvanceStreakFunc: func(tx *repositories.Tx, userID int, today time.Time) (*models.StreakChangedData, error) {
+			return nil, fmt.Errorf("failed to advance streak: connection reset")
+		},
+	}
+	service := NewItemService(itemRepo, &mocks.TestStore{}, nil, statsRepo, passthroughTx(), nil, nil, nil)
+
+	// The completed item isn't read back when the unit of work fails
+	if _, _, err := service.RecordCompletion(context.Background(), 1, 7, models.CompletionSolved, 1, time.Now()); err == nil {
+		t.Fatal("Expected an error when the streak can't be advanced")
+	}
+}
+
+func TestCompleteItemWithUserProgressDuringActiveTest(t *testing.T) {
+	testRepo := &mocks.TestStore{
+		IsItemInPendingTestFunc: func(userID int) (bool, error) {
+			return true, nil
+		},
+	}
+	// Any write would panic on the unset mock functions
+	service := NewItemService(&mocks.ItemStore{}, testRepo, nil, &mocks.StatsStore{}, &mocks.TxRunner{}, nil, nil, nil)
+
+	_, err := service.CompleteItemWithUserProgress(context.Background(), 1, 7, models.CompletionSolved)
+	if err == nil || err.Error() != "cannot complete item: test is active" {
+		t.Fatalf("Expected active test error, got %v", err)
+	}
+}
+
+func TestCompleteItemWithUserProgressHintCredit(t *testing.T) {
+	testCases := []struct {
+		name       string
+		quality    models.CompletionQuality
+		revealed   int
+		expected   models.CompletionQuality
+		credit     float64
+		reviewDays int
+	}{
+		{name: "No hints revealed", revealed: 0, expected: models.CompletionSolved, credit: 1, reviewDays: 7},
+		{name: "Hints revealed", revealed: 2, expected: models.CompletionReviewedSolution, credit: 0.75, reviewDays: 1},
+		{name: "Solved after revealing hints", quality: models.CompletionSolved, revealed: 4, expected: models.CompletionSolved, credit: 0.5, reviewDays: 4},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var quality models.CompletionQuality
+			var credit float64
+			var nextReview time.Time
+			itemRepo := &mocks.ItemStore{
+				MarkCompletedFunc: func(tx *repositories.Tx, userID, itemID int, q models.CompletionQuality, hintCredit float64, nextReviewAt time.Time) error {
+					quality, credit, nextReview = q, hintCredit, nextReviewAt
+					return nil
+				},
+				CountPendingForUserInTxFunc: func(tx *repositories.Tx, userID int) (int, error) {
+					return 1, nil
+				},
+				GetByIDWithUserProgressFunc: func(userID, itemID int) (*models.ItemWithProgress, error) {
+					return &models.ItemWithProgress{ID: itemID, Status: models.StatusDone}, nil
+				},
+				GetCountsByCategoryForUserFunc: func(userID int, removeMiscellaneous bool) (map[models.Category]map[models.Status]int, error) {
+					return map[models.Category]map[models.Status]int{}, nil
+				},
+			}
+			testRepo := &mocks.TestStore{
+				IsItemInPendingTestFunc: func(userID int) (bool, error) {
+					return false, nil
+				},
+			}
+			hintRepo := &mocks.HintStore{
+				CountRevealedForUserFunc: func(userID, itemID int) (int, int, error) {
+					return tc.revealed, 4, nil
+				},
+			}
+			statsRepo := &mocks.StatsStore{
+				AdvanceStreakFunc: func(tx *repositories.Tx, userID int, today time.Time) (*models.StreakChangedData, error) {
+					return &models.StreakChangedData{PreviousStreak: 1, CurrentStreak: 1, LongestStreak: 1}, nil
+				},
+			}
+			service := NewItemService(itemRepo, testRepo, hintRepo, statsRepo, passthroughTx(), nil, nil, nil)
+
+			start := time.Now()
+			if _, err := service.CompleteItemWithUserProgress(context.Background(), 1, 7, tc.quality); err != nil {
+				t.Fatalf("CompleteItemWithUserProgress returned error: %v", err)
+			}
+
+			if quality != tc.expected {
+				t.Errorf("Expected quality %s, got %s", tc.expected, quality)
+			}
+			if credit != tc.credit {
+				t.Errorf("Expected hint credit %v, got %v", tc.credit, credit)
+			}
+			if want := start.AddDate(0, 0, tc.reviewDays); nextReview.Before(want) || nextReview.After(want.Add(time.Minute)) {
+				t.Errorf("Expected the first review %d days out, got %v", tc.reviewDays, nextReview.Sub(start))
+			}
+		})
+	}
+}
+
+func TestRevertItemPassesEditorAndVersion(t *testing.T) {
+	version := 3
+	itemRepo := &mocks.ItemStore{
+		GetByIDFunc: func(id int) (*models.Item, error) {
+			return &models.Item{ID: id, Category: models.CategoryDSA, Version: 3}, nil
+		},
+		RevertFunc: func(editorID, id, revisionID int, got *int) (*models.Item, error) {
+			if editorID != 7 || id != 42 || revisionID != 5 || got == nil || *got != version {
+				t.Errorf("Revert(%d, %d, %d, %v), want editor 7, item 42, revision 5 at version %d", editorID, id, revisionID, got, version)
+			}
+			return &models.Item{ID: id, Category: models.CategoryDSA, Version: 4}, nil
+		},
+	}
+	service := NewItemService(itemRepo, &mocks.TestStore{}, nil, &mocks.StatsStore{}, &mocks.TxRunner{}, nil, nil, nil)
+
+	item, err := service.RevertItem(7, 42, 5, &models.RevertItemRequest{Version: &version})
+	if err != nil {
+		t.Fatalf("RevertItem() error = %v", err)
+	}
+	if item.Version != 4 {
+		t.Errorf("Expected the reverted item at version 4, got %d", item.Version)
+	}
+
+	if _, err := service.RevertItem(7, 42, 0, &models.RevertItemRequest{}); err == nil {
+		t.Error("Expected an error for an invalid revision ID")
+	}
+}
+
+func TestPublishItem(t *testing.T) {
+	var got []bool
+	itemRepo := &mocks.ItemStore{
+		SetPublishedFunc: func(id int, published bool) (*models.Item, error) {
+			got = append(got, published)
+			return &models.Item{ID: id, Draft: !published}, nil
+		},
+	}
+	service := NewItemService(itemRepo, &mocks.TestStore{}, nil, &mocks.StatsStore{}, &mocks.TxRunner{}, nil, nil, nil)
+
+	unpublish := false
+	for _, req := range []*models.PublishItemRequest{{}, {Published: &unpublish}} {
+		if _, err := service.PublishItem(42, req); err != nil {
+			t.Fatalf("PublishItem() error = %v", err)
+		}
+	}
+	if len(got) != 2 || !got[0] || got[1] {
+		t.Errorf("Expected publishing by default and then taking back to draft, got %v", got)
+	}
+}
+
+func TestScheduleItemsRequiresFutureTime(t *testing.T) {
+	var scheduled []int
+	itemRepo := &mocks.ItemStore{
+		ScheduleFunc: func(itemIDs []int, publishAt *time.Time) ([]*models.Item, error) {
+			scheduled = itemIDs
+			return []*models.Item{}, nil
+		},
+	}
+	service := NewItemService(itemRepo, &mocks.TestStore{}, nil, &mocks.StatsStore{}, &mocks.TxRunner{}, nil, nil, nil)
+
+	past := time.Now().Add(-time.Hour)
+	if _, err := service.ScheduleItems(&models.ScheduleItemsRequest{ItemIDs: []int{1}, PublishAt: &past}); err == nil {
+		t.Error("Expected an error for a publish time in the past")
+	}
+	if scheduled != nil {
+		t.Errorf("Expected nothing scheduled, got %v", scheduled)
+	}
+
+	// Unscheduling needs no time
+	if _, err := service.ScheduleItems(&models.ScheduleItemsRequest{ItemIDs: []int{1, 2}}); err != nil {
+		t.Fatalf("ScheduleItems() error = %v", err)
+	}
+	if len(scheduled) != 2 {
+		t.Errorf("Expected both items unscheduled, got %v", scheduled)
+	}
+}
+
+func TestGetUpcomingReleasesLimit(t *testing.T) {
+	var got int
+	itemRepo := &mocks.ItemStore{
+		GetUpcomingReleasesFunc: func(limit int) ([]*models.UpcomingRelease, error) {
+			got = limit
+			return []*models.UpcomingRelease{}, nil
+		},
+	}
+	service := NewItemService(itemRepo, &mocks.TestStore{}, nil, &mocks.StatsStore{}, &mocks.TxRunner{}, nil, nil, nil)
+
+	if _, err := service.GetUpcomingReleases(0); err != nil || got != defaultUpcomingReleases {
+		t.Errorf("Expected the default limit, got %d (error %v)", got, err)
+	}
+	if _, err := service.GetUpcomingReleases(models.MaxUpcomingReleases + 1); err == nil {
+		t.Error("Expected an error for a limit over the maximum")
+	}
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"interview-prep-app/internal/jobs"
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+	"interview-prep-app/pkg/apperr"
+)
+
+const (
+	// jobRunsLimit is how many recent runs a job's history shows
+	jobRunsLimit = 50
+	// jobRunRetention is how long job run history is kept
+	jobRunRetention = 30 * 24 * time.Hour
+)
+
+// JobService lets admins inspect and trigger the background jobs
+type JobService struct {
+	jobRepo   *repositories.JobRepository
+	scheduler *jobs.Scheduler
+}
+
+// NewJobService creates a new job service
+func NewJobService(jobRepo *repositories.JobRepository, scheduler *jobs.Scheduler) *JobService {
+	return &JobService{
+		jobRepo:   jobRepo,
+		scheduler: scheduler,
+	}
+}
+
+// ListJobs returns every registered job with its last run on any instance
+func (s *JobService) ListJobs() ([]models.JobStatus, error) {
+	lastRuns, err := s.jobRepo.GetLastRuns()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := []models.JobStatus{}
+	for _, job := range s.scheduler.Jobs() {
+		statuses = append(statuses, models.JobStatus{
+			Name:            job.Name,
+			IntervalSeconds: int64(job.Interval / time.Second),
+			LastRun:         lastRuns[job.Name],
+		})
+	}
+	return statuses, nil
+}
+
+// JobNames lists the registered jobs by name
+func (s *JobService) JobNames() []string {
+	names := []string{}
+	for _, job := range s.scheduler.Jobs() {
+		names = append(names, job.Name)
+	}
+	return names
+}
+
+// GetRuns returns a registered job's recent runs, newest first
+func (s *JobService) GetRuns(name string) ([]*models.JobRun, error) {
+	if !s.registered(name) {
+		return nil, apperr.NotFound("job not found")
+	}
+
+	return s.jobRepo.GetRecentRuns(name, jobRunsLimit)
+}
+
+// Trigger starts a job now; its outcome shows up in the job's run history
+func (s *JobService) Trigger(name string) error {
+	err := s.scheduler.Trigger(name)
+	switch {
+	case errors.Is(err, jobs.ErrNotFound):
+		return apperr.NotFound("job not found")
+	case errors.Is(err, jobs.ErrRunning):
+		return apperr.Conflict("job is already running")
+	}
+	return err
+}
+
+// RunNow runs a job and waits for it to finish. The run is recorded in the job's history like
+// a triggered one; if it fails, its error comes back as an internal error.
+func (s *JobService) RunNow(ctx context.Context, name string) error {
+	err := s.scheduler.RunNow(ctx, name)
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, jobs.ErrNotFound):
+		return apperr.NotFound("job not found")
+	case errors.Is(err, jobs.ErrRunning):
+		return apperr.Conflict("job is already running")
+	}
+	return apperr.Wrap(apperr.KindInternal, err)
+}
+
+// PruneRuns deletes run history past the retention period (run on a schedule)
+func (s *JobService) PruneRuns() error {
+	deleted, err := s.jobRepo.DeleteRunsBefore(time.Now().Add(-jobRunRetention))
+	if err != nil {
+		return err
+	}
+
+	if deleted > 0 {
+		fmt.Printf("Info: deleted %d old job runs\n", deleted)
+	}
+	return nil
+}
+
+// registered reports whether a job is registered with the scheduler
+func (s *JobService) registered(name string) bool {
+	for _, job := range s.scheduler.Jobs() {
+		if job.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+	"interview-prep-app/internal/storage"
+)
+
+// archivalBatchSize bounds how many users one pass archives so a backlog is worked off over several runs
+const archivalBatchSize = 500
+
+// LifecycleService archives users who have been inactive for a while, trimming the per-user
+// rows (tokens, reminder ledgers, daily goal history, webhook logs) that otherwise grow forever,
+// and purges the data of users who deleted their account once its grace period is over
+type LifecycleService struct {
+	lifecycleRepo  *repositories.LifecycleRepository
+	store          storage.Storage
+	inactiveMonths int
+	deletionGrace  time.Duration
+}
+
+// NewLifecycleService creates a new lifecycle service. Users with no login or completion in
+// inactiveMonths are archived; zero or less disables archival. Deleted accounts are purged
+// deletionGrace after deletion, along with their attachments in store.
+func NewLifecycleService(lifecycleRepo *repositories.LifecycleRepository, store storage.Storage, inactiveMonths int, deletionGrace time.Duration) *LifecycleService {
+	return &LifecycleService{
+		lifecycleRepo:  lifecycleRepo,
+		store:          store,
+		inactiveMonths: inactiveMonths,
+		deletionGrace:  deletionGrace,
+	}
+}
+
+// ArchiveInactiveUsers runs one archival pass and records how much it reclaimed (run on a schedule).
+// A user is brought back out of archival the next time they log in.
+func (s *LifecycleService) ArchiveInactiveUsers(ctx context.Context) error {
+	if s.inactiveMonths <= 0 {
+		return nil
+	}
+
+	run := &models.LifecycleRun{
+		StartedAt:      time.Now(),
+		InactiveBefore: time.Now().AddDate(0, -s.inactiveMonths, 0),
+		RowsDeleted:    make(map[string]int64),
+	}
+
+	userIDs, err := s.lifecycleRepo.GetInactiveUserIDs(run.InactiveBefore, archivalBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, userID := range userIDs {
+		if ctx.Err() != nil {
+			break
+		}
+
+		deleted, reclaimed, err := s.lifecycleRepo.ArchiveUser(userID)
+		if err != nil {
+			// Log error but keep going so one user can't stall the whole pass
+			fmt.Printf("Warning: failed to archive user %d: %v\n", userID, err)
+			continue
+		}
+
+		run.UsersArchived++
+		run.BytesReclaimed += reclaimed
+		for table, count := range deleted {
+			run.RowsDeleted[table] += count
+		}
+	}
+
+	run.FinishedAt = time.Now()
+	if err := s.lifecycleRepo.SaveRun(run); err != nil {
+		return err
+	}
+
+	fmt.Printf("Info: archived %d inactive users, reclaimed %d bytes across %v\n", run.UsersArchived, run.BytesReclaimed, run.RowsDeleted)
+	return ctx.Err()
+}
+
+// DeleteAccount deactivates a user's account and signs out every session. Their data is kept for
+// the grace period, so support can restore an account deleted by mistake, then purged.
+func (s *LifecycleService) DeleteAccount(userID int) (*models.DeleteAccountResponse, error) {
+	now := time.Now()
+	purgeAt := now.Add(s.deletionGrace)
+	if err := s.lifecycleRepo.ScheduleDeletion(userID, now, purgeAt); err != nil {
+		return nil, err
+	}
+
+	return &models.DeleteAccountResponse{PurgeAt: purgeAt}, nil
+}
+
+// PurgeDeletedAccounts hard-deletes accounts whose grace period is over, with their attachment
+// files (run on a schedule)
+func (s *LifecycleService) PurgeDeletedAccounts(ctx context.Context) error {
+	userIDs, err := s.lifecycleRepo.GetUserIDsDueForPurge(time.Now(), archivalBatchSize)
+	if err != nil {
+		return err
+	}
+
+	purged := 0
+	for _, userID := range userIDs {
+		if ctx.Err() != nil {
+			break
+		}
+
+		keys, err := s.lifecycleRepo.PurgeUser(userID)
+		if err != nil {
+			// Log error but keep going so one user can't stall the whole pass
+			fmt.Printf("Warning: failed to purge user %d: %v\n", userID, err)
+			continue
+		}
+		purged++
+
+		for _, key := range keys {
+			if err := s.store.Delete(ctx, key); err != nil {
+				fmt.Printf("Warning: failed to delete attachment %s of purged user %d: %v\n", key, userID, err)
+			}
+		}
+	}
+
+	if purged > 0 {
+		fmt.Printf("Info: purged %d deleted accounts\n", purged)
+	}
+	return ctx.Err()
+}
+
+// GetRecentRuns returns recent archival passes for admins
+func (s *LifecycleService) GetRecentRuns(limit int) ([]*models.LifecycleRun, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	return s.lifecycleRepo.GetRecentRuns(limit)
+}
@@ -0,0 +1,182 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+)
+
+const (
+	// linkCheckBatchSize caps how many links one run of the checker looks at
+	linkCheckBatchSize = 500
+	// linkCheckWorkers is how many links are checked concurrently
+	linkCheckWorkers = 8
+	// deadLinkFailureThreshold is how many checks in a row must fail before a link is reported dead,
+	// so a single timeout or outage doesn't flag it
+	deadLinkFailureThreshold = 2
+	// defaultDeadLinksLimit is the dead link report page size when none is given
+	defaultDeadLinksLimit = 50
+	// maxDeadLinksLimit caps the dead link report page size
+	maxDeadLinksLimit = 200
+)
+
+// LinkCheckService periodically checks item and eng blog article URLs and reports the dead ones
+type LinkCheckService struct {
+	linkCheckRepo *repositories.LinkCheckRepository
+	client        *http.Client
+	recheckAfter  time.Duration
+}
+
+// NewLinkCheckService creates a new link check service that re-checks each link once per
+// recheckAfter. Checks never connect to loopback, private or link-local addresses.
+func NewLinkCheckService(linkCheckRepo *repositories.LinkCheckRepository, recheckAfter time.Duration) *LinkCheckService {
+	dialer := &net.Dialer{Timeout: 5 * time.Second, Control: rejectPrivateAddress}
+
+	return &LinkCheckService{
+		linkCheckRepo: linkCheckRepo,
+		recheckAfter:  recheckAfter,
+		client: &http.Client{
+			Timeout:   10 * time.Second,
+			Transport: &http.Transport{DialContext: dialer.DialContext, Proxy: http.ProxyFromEnvironment},
+		},
+	}
+}
+
+// CheckLinks checks a batch of links that are due and records the results. It is meant to be run
+// as a background job; links left over when the batch is full are picked up by the next run.
+func (s *LinkCheckService) CheckLinks(ctx context.Context) error {
+	links, err := s.linkCheckRepo.GetDue(time.Now().Add(-s.recheckAfter), linkCheckBatchSize)
+	if err != nil {
+		return err
+	}
+
+	queue := make(chan *models.LinkToCheck)
+	var wg sync.WaitGroup
+	for i := 0; i < linkCheckWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for link := range queue {
+				s.checkAndRecord(ctx, link)
+			}
+		}()
+	}
+
+	for _, link := range links {
+		if ctx.Err() != nil {
+			break
+		}
+		queue <- link
+	}
+	close(queue)
+	wg.Wait()
+
+	return ctx.Err()
+}
+
+// checkAndRecord checks a single link and stores the outcome
+func (s *LinkCheckService) checkAndRecord(ctx context.Context, link *models.LinkToCheck) {
+	statusCode, err := s.check(ctx, link.URL)
+	if ctx.Err() != nil {
+		// Shutting down; don't count the aborted request against the link
+		return
+	}
+
+	var status *int
+	if statusCode > 0 {
+		status = &statusCode
+	}
+
+	checkErr := ""
+	if err != nil {
+		checkErr = err.Error()
+	}
+
+	if err := s.linkCheckRepo.Record(link, status, checkErr, err == nil, deadLinkFailureThreshold); err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	}
+}
+
+// check requests a URL and returns the final status code after redirects. Many sites reject
+// HEAD, so those are retried with GET. A 429 means the page exists but is rate limiting us.
+func (s *LinkCheckService) check(ctx context.Context, url string) (int, error) {
+	statusCode, err := s.request(ctx, http.MethodHead, url)
+	if err != nil {
+		return 0, err
+	}
+
+	switch statusCode {
+	case http.StatusForbidden, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		if statusCode, err = s.request(ctx, http.MethodGet, url); err != nil {
+			return 0, err
+		}
+	}
+
+	if statusCode >= 400 && statusCode != http.StatusTooManyRequests {
+		return statusCode, fmt.Errorf("responded with status %d", statusCode)
+	}
+
+	return statusCode, nil
+}
+
+// request sends a single request and returns the response status code
+func (s *LinkCheckService) request(ctx context.Context, method, url string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return 0, fmt.Errorf("invalid link: %w", err)
+	}
+	req.Header.Set("User-Agent", "PrepMaster-LinkChecker/1.0")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	return resp.StatusCode, nil
+}
+
+// GetDeadLinks returns a page of the admin dead link report, optionally limited to one kind of link
+func (s *LinkCheckService) GetDeadLinks(targetType *models.LinkTargetType, limit, offset int) (*models.DeadLinksResponse, error) {
+	if targetType != nil && !models.IsValidLinkTargetType(*targetType) {
+		return nil, fmt.Errorf("invalid link type: %s. Valid values are: %s, %s", *targetType,
+			models.LinkTargetItem, models.LinkTargetEngBlogArticle)
+	}
+
+	if limit == 0 {
+		limit = defaultDeadLinksLimit
+	}
+	if limit < 0 || limit > maxDeadLinksLimit {
+		return nil, fmt.Errorf("limit must be between 1 and %d", maxDeadLinksLimit)
+	}
+
+	if offset < 0 {
+		return nil, fmt.Errorf("offset cannot be negative")
+	}
+
+	links, total, err := s.linkCheckRepo.GetDead(targetType, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.DeadLinksResponse{
+		Links: links,
+		Pagination: models.PaginationMeta{
+			Page:       offset/limit + 1,
+			Limit:      limit,
+			Offset:     offset,
+			Total:      total,
+			TotalPages: (total + limit - 1) / limit,
+			HasNext:    offset+limit < total,
+			HasPrev:    offset > 0,
+		},
+	}, nil
+}
@@ -0,0 +1,211 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/html"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+)
+
+const (
+	// linkMetadataMaxBytes caps how much of a page is read looking for its metadata
+	linkMetadataMaxBytes = 512 << 10
+	// linkMetadataCacheTTL is how long fetched metadata is reused for the same link
+	linkMetadataCacheTTL = 24 * time.Hour
+	// linkMetadataFailureTTL is how long a failed fetch is remembered, so a bulk import of one
+	// unreachable site doesn't wait on it for every item
+	linkMetadataFailureTTL = 10 * time.Minute
+	// linkMetadataCacheSize caps how many links are cached
+	linkMetadataCacheSize = 1000
+	// linkMetadataMaxLength caps the length of a fetched title or description
+	linkMetadataMaxLength = 500
+)
+
+// LinkEnricher fetches the title and description of an item's page, so new items can be prefilled
+// with them. Results are cached per link. Fetches never connect to loopback, private or link-local
+// addresses.
+type LinkEnricher struct {
+	client *http.Client
+
+	mu    sync.Mutex
+	cache map[string]linkMetadataEntry
+}
+
+// linkMetadataEntry is a cached fetch result; metadata is nil when the fetch failed
+type linkMetadataEntry struct {
+	metadata  *models.LinkMetadata
+	expiresAt time.Time
+}
+
+// NewLinkEnricher creates a link enricher whose fetches give up after timeout
+func NewLinkEnricher(timeout time.Duration) *LinkEnricher {
+	dialer := &net.Dialer{Timeout: timeout, Control: rejectPrivateAddress}
+
+	return &LinkEnricher{
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: &http.Transport{DialContext: dialer.DialContext, Proxy: http.ProxyFromEnvironment},
+		},
+		cache: map[string]linkMetadataEntry{},
+	}
+}
+
+// Enrich returns the metadata of the page a link points at, from the cache when it was fetched recently
+func (e *LinkEnricher) Enrich(ctx context.Context, link string) (*models.LinkMetadata, error) {
+	if entry, ok := e.cached(link); ok {
+		if entry.metadata == nil {
+			return nil, apperr.Errorf("failed to fetch %s recently", link)
+		}
+		return entry.metadata, nil
+	}
+
+	metadata, err := e.fetch(ctx, link)
+	if ctx.Err() != nil {
+		// Cancelled by the caller; the link may be fine
+		return nil, err
+	}
+
+	ttl := linkMetadataCacheTTL
+	if err != nil {
+		ttl = linkMetadataFailureTTL
+	}
+	e.store(link, linkMetadataEntry{metadata: metadata, expiresAt: time.Now().Add(ttl)})
+
+	return metadata, err
+}
+
+// cached looks up an unexpired cache entry
+func (e *LinkEnricher) cached(link string) (linkMetadataEntry, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	entry, ok := e.cache[link]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return linkMetadataEntry{}, false
+	}
+	return entry, true
+}
+
+// store caches an entry, dropping expired entries first when the cache is full and starting over
+// if that isn't enough
+func (e *LinkEnricher) store(link string, entry linkMetadataEntry) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if len(e.cache) >= linkMetadataCacheSize {
+		now := time.Now()
+		for key, existing := range e.cache {
+			if now.After(existing.expiresAt) {
+				delete(e.cache, key)
+			}
+		}
+		if len(e.cache) >= linkMetadataCacheSize {
+			e.cache = map[string]linkMetadataEntry{}
+		}
+	}
+	e.cache[link] = entry
+}
+
+// fetch downloads the start of a page and reads its title and description
+func (e *LinkEnricher) fetch(ctx context.Context, link string) (*models.LinkMetadata, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid link: %w", err)
+	}
+	req.Header.Set("User-Agent", "PrepMaster-LinkEnricher/1.0")
+	req.Header.Set("Accept", "text/html")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("responded with status %d", resp.StatusCode)
+	}
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType != "text/html" {
+		return nil, fmt.Errorf("not an HTML page: %s", mediaType)
+	}
+
+	return parseLinkMetadata(io.LimitReader(resp.Body, linkMetadataMaxBytes)), nil
+}
+
+// parseLinkMetadata reads the <title> and description meta tags from an HTML page, preferring the
+// Open Graph ones. It stops at <body>, since metadata belongs in <head>.
+func parseLinkMetadata(r io.Reader) *models.LinkMetadata {
+	var title, ogTitle, description, ogDescription string
+	var inTitle bool
+
+	tokenizer := html.NewTokenizer(r)
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return newLinkMetadata(title, ogTitle, description, ogDescription)
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+			switch token.Data {
+			case "body":
+				return newLinkMetadata(title, ogTitle, description, ogDescription)
+			case "title":
+				inTitle = title == ""
+			case "meta":
+				var key, content string
+				for _, attr := range token.Attr {
+					switch attr.Key {
+					case "name", "property":
+						key = strings.ToLower(attr.Val)
+					case "content":
+						content = attr.Val
+					}
+				}
+				switch key {
+				case "og:title":
+					ogTitle = content
+				case "og:description":
+					ogDescription = content
+				case "description":
+					description = content
+				}
+			}
+
+		case html.TextToken:
+			if inTitle {
+				title += string(tokenizer.Text())
+			}
+
+		case html.EndTagToken:
+			inTitle = false
+		}
+	}
+}
+
+// newLinkMetadata picks the Open Graph values over the plain ones and tidies them up
+func newLinkMetadata(title, ogTitle, description, ogDescription string) *models.LinkMetadata {
+	pick := func(preferred, fallback string) string {
+		value := strings.Join(strings.Fields(preferred), " ")
+		if value == "" {
+			value = strings.Join(strings.Fields(fallback), " ")
+		}
+		if runes := []rune(value); len(runes) > linkMetadataMaxLength {
+			value = string(runes[:linkMetadataMaxLength])
+		}
+		return value
+	}
+
+	return &models.LinkMetadata{
+		Title:       pick(ogTitle, title),
+		Description: pick(ogDescription, description),
+	}
+}
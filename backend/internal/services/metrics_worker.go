@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+
+	"interview-prep-app/internal/events"
+	"interview-prep-app/internal/metrics"
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+)
+
+// Domain metrics for product dashboards; see METRICS.md for how to query them
+var (
+	itemCompletions = metrics.NewCounter(
+		"prepmaster_item_completions_total",
+		"Items completed, by category.",
+		"category",
+	)
+	streakEndedLength = metrics.NewHistogram(
+		"prepmaster_streak_ended_length_days",
+		"Length in days of daily streaks when they were broken.",
+		[]float64{1, 2, 3, 5, 7, 14, 30, 60, 100, 365},
+	)
+	testSessionsFinished = metrics.NewCounter(
+		"prepmaster_test_sessions_finished_total",
+		"Finished test sessions, by result: passed when every item was completed, failed otherwise.",
+		"result",
+	)
+	userFirstCompletionDelay = metrics.NewHistogram(
+		"prepmaster_user_first_completion_delay_seconds",
+		"Time from sign-up to a user's first completed item.",
+		[]float64{60, 600, 3600, 6 * 3600, 86400, 3 * 86400, 7 * 86400, 30 * 86400},
+	)
+)
+
+// MetricsWorker records domain metrics from published events
+type MetricsWorker struct {
+	userRepo *repositories.UserRepository
+}
+
+// NewMetricsWorker creates a new metrics worker
+func NewMetricsWorker(userRepo *repositories.UserRepository) *MetricsWorker {
+	return &MetricsWorker{
+		userRepo: userRepo,
+	}
+}
+
+// Subscribe registers the worker for the events its metrics are built from
+func (w *MetricsWorker) Subscribe(bus events.Bus) {
+	bus.Subscribe(events.ItemCompleted, "metrics", w.handleItemCompleted)
+	bus.Subscribe(events.StreakChanged, "metrics", w.handleStreakChanged)
+	bus.Subscribe(events.TestFinished, "metrics", w.handleTestFinished)
+}
+
+// handleItemCompleted counts the completion and, for a user's first one, how long it took them
+func (w *MetricsWorker) handleItemCompleted(ctx context.Context, e events.Event) error {
+	var item models.ItemWithProgress
+	if err := e.Decode(&item); err != nil {
+		return err
+	}
+	itemCompletions.Inc(string(item.Category))
+
+	signedUpAt, first, err := w.userRepo.MarkFirstCompletion(e.UserID, e.OccurredAt)
+	if err != nil {
+		return err
+	}
+	if first {
+		userFirstCompletionDelay.Observe(e.OccurredAt.Sub(signedUpAt).Seconds())
+	}
+
+	return nil
+}
+
+// handleStreakChanged records a streak's length when it breaks: a streak restarting at one means
+// the previous one ended. A one-day streak followed by another doesn't change the streak, so no
+// event is published and it isn't counted.
+func (w *MetricsWorker) handleStreakChanged(ctx context.Context, e events.Event) error {
+	var streak models.StreakChangedData
+	if err := e.Decode(&streak); err != nil {
+		return err
+	}
+
+	if streak.CurrentStreak == 1 && streak.PreviousStreak > 0 {
+		streakEndedLength.Observe(float64(streak.PreviousStreak))
+	}
+
+	return nil
+}
+
+// handleTestFinished counts a finished test session as passed or failed
+func (w *MetricsWorker) handleTestFinished(ctx context.Context, e events.Event) error {
+	var session models.TestCompletedData
+	if err := e.Decode(&session); err != nil {
+		return err
+	}
+
+	result := "passed"
+	for _, test := range session.Items {
+		if test.Status != models.TestStatusCompleted {
+			result = "failed"
+			break
+		}
+	}
+	testSessionsFinished.Inc(result)
+
+	return nil
+}
@@ -0,0 +1,316 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"interview-prep-app/internal/llm"
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+	"interview-prep-app/internal/validation"
+	"interview-prep-app/pkg/apperr"
+)
+
+const (
+	// defaultMockInterviewLimit is how many mock interviews are listed when no limit is given
+	defaultMockInterviewLimit = 20
+	// maxMockInterviewLimit caps how many mock interviews are listed at once
+	maxMockInterviewLimit = 100
+	// mockInterviewMaxTokens caps the length of each interviewer message
+	mockInterviewMaxTokens = 600
+	// mockFeedbackMaxTokens caps the length of the feedback that ends an interview
+	mockFeedbackMaxTokens = 1200
+	// mockInterviewFeature is what mock interview turns are recorded as in AI usage
+	mockInterviewFeature = "mock_interview"
+	// mockInterviewKickoff is the first user turn sent to the model, which opens the interview in
+	// reply; it isn't part of the stored transcript
+	mockInterviewKickoff = "I'm ready. Please start the interview."
+	// mockFeedbackRequest asks the model to step out of the interview and assess the candidate
+	mockFeedbackRequest = "The interview is over. Step out of the interviewer role and give me feedback in Markdown: " +
+		"what went well, what was missing or wrong, and what to study next. Be specific to my answers."
+)
+
+// MockInterviewService runs mock interviews where a language model plays the interviewer on an
+// item, keeping the transcript so the user can review it later
+type MockInterviewService struct {
+	interviewRepo *repositories.MockInterviewRepository
+	itemRepo      ItemStore
+	testRepo      TestStore
+	budgetService *AIBudgetService
+	provider      llm.Provider
+}
+
+// NewMockInterviewService creates a new mock interview service. Without a provider, mock
+// interviews are unavailable, though past ones can still be read.
+func NewMockInterviewService(interviewRepo *repositories.MockInterviewRepository, itemRepo ItemStore, testRepo TestStore, budgetService *AIBudgetService, provider llm.Provider) *MockInterviewService {
+	return &MockInterviewService{
+		interviewRepo: interviewRepo,
+		itemRepo:      itemRepo,
+		testRepo:      testRepo,
+		budgetService: budgetService,
+		provider:      provider,
+	}
+}
+
+// StartInterview starts a mock interview on an item, with the interviewer's opening question.
+// Started from a test, the item must be part of it.
+func (s *MockInterviewService) StartInterview(ctx context.Context, userID int, req *models.StartMockInterviewRequest) (*models.MockInterview, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	if s.provider == nil {
+		return nil, apperr.Unavailable("AI mock interviews are not configured")
+	}
+
+	item, err := s.itemRepo.GetPublishedByID(req.ItemID)
+	if err != nil {
+		return nil, err
+	}
+
+	interview := &models.MockInterview{
+		UserID:    userID,
+		ItemID:    item.ID,
+		ItemTitle: item.Title,
+		Category:  item.Category,
+		Status:    models.MockInterviewActive,
+		Provider:  s.provider.Name(),
+		Model:     s.provider.Model(),
+	}
+
+	if testSessionID := strings.TrimSpace(req.TestSessionID); testSessionID != "" {
+		tests, err := s.testRepo.GetTestsBySessionID(userID, testSessionID)
+		if err != nil {
+			return nil, err
+		}
+		if len(tests) == 0 {
+			return nil, apperr.NotFound("test not found")
+		}
+		if !testHasItem(tests, item.ID) {
+			return nil, fmt.Errorf("item is not part of this test")
+		}
+		interview.TestSessionID = &testSessionID
+	}
+
+	completion, err := s.complete(ctx, userID, llm.Prompt{
+		System:    interviewerPrompt(item),
+		User:      mockInterviewKickoff,
+		MaxTokens: mockInterviewMaxTokens,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	opening := &models.MockInterviewMessage{Role: models.MockInterviewerRole, Content: completion.Text}
+	if err := s.interviewRepo.Create(interview, opening, completion.Tokens); err != nil {
+		return nil, err
+	}
+	interview.Messages = []models.MockInterviewMessage{*opening}
+
+	return interview, nil
+}
+
+// Reply sends the candidate's next message in an active mock interview and returns the interview
+// with the interviewer's answer
+func (s *MockInterviewService) Reply(ctx context.Context, userID, interviewID int, req *models.MockInterviewReplyRequest) (*models.MockInterview, error) {
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	if s.provider == nil {
+		return nil, apperr.Unavailable("AI mock interviews are not configured")
+	}
+
+	interview, item, err := s.getActive(userID, interviewID)
+	if err != nil {
+		return nil, err
+	}
+	if interview.Turns >= models.MaxMockInterviewTurns {
+		return nil, apperr.Conflict(fmt.Sprintf("mock interviews are limited to %d replies: end this one for feedback", models.MaxMockInterviewTurns))
+	}
+
+	content := strings.TrimSpace(req.Content)
+	completion, err := s.complete(ctx, userID, llm.Prompt{
+		System:    interviewerPrompt(item),
+		History:   mockInterviewHistory(interview.Messages),
+		User:      content,
+		MaxTokens: mockInterviewMaxTokens,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &models.MockInterviewMessage{Role: models.MockCandidateRole, Content: content}
+	answer := &models.MockInterviewMessage{Role: models.MockInterviewerRole, Content: completion.Text}
+	if err := s.interviewRepo.AddTurn(userID, interview.ID, reply, answer, completion.Tokens); err != nil {
+		return nil, err
+	}
+	interview.Messages = append(interview.Messages, *reply, *answer)
+	interview.Turns++
+	interview.UpdatedAt = answer.CreatedAt
+
+	return interview, nil
+}
+
+// EndInterview ends an active mock interview with the interviewer's feedback on the candidate's
+// answers. An interview the candidate never replied in ends without feedback.
+func (s *MockInterviewService) EndInterview(ctx context.Context, userID, interviewID int) (*models.MockInterview, error) {
+	interview, item, err := s.getActive(userID, interviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	var feedback string
+	var tokens int64
+	if interview.Turns > 0 {
+		if s.provider == nil {
+			return nil, apperr.Unavailable("AI mock interviews are not configured")
+		}
+
+		completion, err := s.complete(ctx, userID, llm.Prompt{
+			System:    interviewerPrompt(item),
+			History:   mockInterviewHistory(interview.Messages),
+			User:      mockFeedbackRequest,
+			MaxTokens: mockFeedbackMaxTokens,
+		})
+		if err != nil {
+			return nil, err
+		}
+		feedback, tokens = completion.Text, completion.Tokens
+	}
+
+	if err := s.interviewRepo.End(userID, interview.ID, feedback, tokens); err != nil {
+		return nil, err
+	}
+
+	return s.interviewRepo.GetByID(userID, interview.ID)
+}
+
+// GetInterview returns one of the user's mock interviews with its transcript
+func (s *MockInterviewService) GetInterview(userID, interviewID int) (*models.MockInterview, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	return s.interviewRepo.GetByID(userID, interviewID)
+}
+
+// GetInterviews lists the user's latest mock interviews, optionally on one item or from one test
+func (s *MockInterviewService) GetInterviews(userID int, itemID *int, testSessionID string, limit int) (*models.MockInterviewsResponse, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if limit <= 0 {
+		limit = defaultMockInterviewLimit
+	}
+	if limit > maxMockInterviewLimit {
+		limit = maxMockInterviewLimit
+	}
+
+	interviews, err := s.interviewRepo.GetForUser(userID, itemID, strings.TrimSpace(testSessionID), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.MockInterviewsResponse{Interviews: interviews}, nil
+}
+
+// DeleteInterview deletes one of the user's mock interviews with its transcript
+func (s *MockInterviewService) DeleteInterview(userID, interviewID int) error {
+	if userID <= 0 {
+		return fmt.Errorf("invalid user ID")
+	}
+
+	return s.interviewRepo.Delete(userID, interviewID)
+}
+
+// getActive loads an active mock interview with its transcript, along with its item
+func (s *MockInterviewService) getActive(userID, interviewID int) (*models.MockInterview, *models.Item, error) {
+	if userID <= 0 {
+		return nil, nil, fmt.Errorf("invalid user ID")
+	}
+
+	interview, err := s.interviewRepo.GetByID(userID, interviewID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if interview.Status != models.MockInterviewActive {
+		return nil, nil, apperr.Conflict("mock interview has ended")
+	}
+
+	item, err := s.itemRepo.GetPublishedByID(interview.ItemID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return interview, item, nil
+}
+
+// complete asks the model for the interviewer's next message, counting the call against the
+// user's AI budget
+func (s *MockInterviewService) complete(ctx context.Context, userID int, prompt llm.Prompt) (*llm.Completion, error) {
+	if err := s.budgetService.ReserveCall(userID); err != nil {
+		return nil, err
+	}
+
+	completion, err := s.provider.Complete(ctx, prompt)
+	if err != nil {
+		fmt.Printf("Warning: failed to continue mock interview for user %d: %v\n", userID, err)
+		return nil, apperr.Upstream("failed to reach the interviewer: the AI provider is unavailable")
+	}
+
+	if err := s.budgetService.RecordUsage(userID, mockInterviewFeature, completion.Tokens); err != nil {
+		fmt.Printf("Warning: failed to record AI usage for user %d: %v\n", userID, err)
+	}
+
+	completion.Text = strings.TrimSpace(completion.Text)
+	if completion.Text == "" {
+		return nil, apperr.Upstream("failed to reach the interviewer: the AI provider returned nothing")
+	}
+
+	return completion, nil
+}
+
+// interviewerPrompt sets the model up to interview on an item, in the style of the item's
+// category
+func interviewerPrompt(item *models.Item) string {
+	style := "a technical interview"
+	switch item.Category {
+	case models.CategoryHLD:
+		style = "a system design interview: clarify requirements and scale, then dig into the high-level " +
+			"architecture, data model, bottlenecks and trade-offs"
+	case models.CategoryLLD:
+		style = "a low-level design interview: ask for the classes, interfaces and their interactions, then " +
+			"probe extensibility and design patterns"
+	case models.CategoryDSA:
+		style = "a coding interview: have the candidate explain their approach, its complexity and edge cases " +
+			"before and after coding"
+	}
+
+	return fmt.Sprintf("You are an experienced interviewer conducting %s. The topic is %q (%s / %s, %s). "+
+		"Ask one question at a time and keep each message short. Build on the candidate's answers, push back "+
+		"on weak reasoning and give hints only when they are stuck. Don't reveal a full solution.",
+		style, item.Title, item.Category, item.Subcategory, item.Link)
+}
+
+// mockInterviewHistory turns a transcript into the conversation sent to the model, after the
+// kickoff turn that opened it
+func mockInterviewHistory(messages []models.MockInterviewMessage) []llm.Message {
+	history := make([]llm.Message, 0, len(messages)+1)
+	history = append(history, llm.Message{Role: llm.RoleUser, Content: mockInterviewKickoff})
+	for _, message := range messages {
+		role := llm.RoleUser
+		if message.Role == models.MockInterviewerRole {
+			role = llm.RoleAssistant
+		}
+		history = append(history, llm.Message{Role: role, Content: message.Content})
+	}
+
+	return history
+}
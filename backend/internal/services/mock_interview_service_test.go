@@ -0,0 +1,53 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"interview-prep-app/internal/llm"
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+)
+
+func TestMockInterviewHistory(t *testing.T) {
+	history := mockInterviewHistory([]models.MockInterviewMessage{
+		{Role: models.MockInterviewerRole, Content: "How would you design a URL shortener?"},
+		{Role: models.MockCandidateRole, Content: "Start with the write path"},
+	})
+
+	want := []llm.Message{
+		{Role: llm.RoleUser, Content: mockInterviewKickoff},
+		{Role: llm.RoleAssistant, Content: "How would you design a URL shortener?"},
+		{Role: llm.RoleUser, Content: "Start with the write path"},
+	}
+	if len(history) != len(want) {
+		t.Fatalf("Expected %d messages, got %+v", len(want), history)
+	}
+	for i := range want {
+		if history[i] != want[i] {
+			t.Errorf("message %d = %+v, want %+v", i, history[i], want[i])
+		}
+	}
+}
+
+func TestInterviewerPromptFollowsCategory(t *testing.T) {
+	prompt := interviewerPrompt(&models.Item{Title: "Design a URL Shortener", Category: models.CategoryHLD, Subcategory: "web"})
+	if !strings.Contains(prompt, "system design interview") || !strings.Contains(prompt, `"Design a URL Shortener"`) {
+		t.Errorf("Expected a system design interview on the item, got %q", prompt)
+	}
+}
+
+func TestStartInterviewWithoutProvider(t *testing.T) {
+	service := NewMockInterviewService(nil, nil, nil, nil, nil)
+
+	_, err := service.StartInterview(context.Background(), 1, &models.StartMockInterviewRequest{ItemID: 1})
+	if apperr.From(err).Kind != apperr.KindUnavailable {
+		t.Errorf("Expected an unavailable error without a provider, got %v", err)
+	}
+
+	_, err = service.StartInterview(context.Background(), 1, &models.StartMockInterviewRequest{})
+	if apperr.From(err).Kind != apperr.KindValidation {
+		t.Errorf("Expected a validation error without an item, got %v", err)
+	}
+}
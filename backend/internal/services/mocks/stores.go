@@ -0,0 +1,705 @@
+// Code generated by mockgen from stores.go. DO NOT EDIT.
+
+// Package mocks holds test doubles for the interfaces in stores.go
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+)
+
+// ItemStore is a mock ItemStore
+type ItemStore struct {
+	CreateFunc                            func(req *models.CreateItemRequest) (*models.Item, error)
+	UpdateFunc                            func(editorID, id int, req *models.UpdateItemRequest) (*models.Item, error)
+	RevertFunc                            func(editorID, id, revisionID int, version *int) (*models.Item, error)
+	GetRevisionsFunc                      func(id int) ([]*models.ItemRevision, error)
+	SetPublishedFunc                      func(id int, published bool) (*models.Item, error)
+	GetDraftsFunc                         func() ([]*models.Item, error)
+	ScheduleFunc                          func(itemIDs []int, publishAt *time.Time) ([]*models.Item, error)
+	ReleaseDueFunc                        func(now time.Time) ([]*models.Item, error)
+	GetUpcomingReleasesFunc               func(limit int) ([]*models.UpcomingRelease, error)
+	DeleteFunc                            func(id int) error
+	GetByIDFunc                           func(id int) (*models.Item, error)
+	GetPublishedByIDFunc                  func(id int) (*models.Item, error)
+	GetBySourceArticleFunc                func(articleID int) (*models.Item, error)
+	GetDuplicateCandidatesFunc            func() ([]*models.Item, error)
+	GetAllFunc                            func(filter *models.ItemFilter) ([]*models.Item, error)
+	GetTotalCountFunc                     func(filter *models.ItemFilter) (int, error)
+	GetItemAnalyticsFunc                  func(filter *models.ItemAnalyticsFilter) ([]*models.ItemAnalytics, int, error)
+	GetByIDWithUserProgressFunc           func(userID, itemID int) (*models.ItemWithProgress, error)
+	GetAllWithUserProgressFunc            func(userID int, filter *models.ItemFilter) ([]*models.ItemWithProgress, error)
+	GetTotalCountWithUserProgressFunc     func(userID int, filter *models.ItemFilter) (int, error)
+	GetInProgressItemWithUserProgressFunc func(userID int) (*models.ItemWithProgress, error)
+	GetRandomPendingWithUserProgressFunc  func(userID int) (*models.ItemWithProgress, error)
+	GetRandomItemsFunc                    func(userID int, filter *models.ItemFilter) ([]models.ItemWithProgress, error)
+	GetCountsByCategoryForUserFunc        func(userID int, removeMiscellaneous bool) (map[models.Category]map[models.Status]int, error)
+	GetCountsBySubcategoryForUserFunc     func(userID int) (map[models.Category]map[string]map[models.Status]int, error)
+	UpsertUserProgressForItemFunc         func(userID, itemID int, status models.Status) error
+	UpdateStatusForUserFunc               func(userID, itemID int, status models.Status) (*models.ItemWithProgress, error)
+	MarkCompletedFunc                     func(tx *repositories.Tx, userID, itemID int, quality models.CompletionQuality, hintCredit float64, nextReviewAt time.Time) error
+	CountPendingForUserInTxFunc           func(tx *repositories.Tx, userID int) (int, error)
+	ToggleStarForUserFunc                 func(userID, itemID int) (*models.ItemWithProgress, error)
+	SetStarredForUserFunc                 func(userID int, itemIDs []int, starred bool) (changed, unchanged []int, err error)
+	ResetInProgressItemsForUserFunc       func(userID int) error
+	SkipInProgressItemsForUserFunc        func(userID int) error
+	ResetAllUserProgressFunc              func(userID int) (int64, error)
+	ResetUserProgressByCategoryFunc       func(userID int, category models.Category) (int64, error)
+	GetReviewCountForUserFunc             func(userID, itemID int) (int, error)
+	RecordReviewForUserFunc               func(userID, itemID int, quality models.CompletionQuality, nextReviewAt time.Time) (*models.ItemWithProgress, error)
+	GetDueReviewsForUserFunc              func(userID int, dueBy time.Time, limit int) ([]*models.ItemWithProgress, error)
+	CountReviewsDueForUserFunc            func(userID int, dueBy time.Time) (int, error)
+}
+
+// Create calls CreateFunc
+func (m *ItemStore) Create(req *models.CreateItemRequest) (*models.Item, error) {
+	if m.CreateFunc == nil {
+		panic("unexpected call to ItemStore.Create")
+	}
+	return m.CreateFunc(req)
+}
+
+// Update calls UpdateFunc
+func (m *ItemStore) Update(editorID, id int, req *models.UpdateItemRequest) (*models.Item, error) {
+	if m.UpdateFunc == nil {
+		panic("unexpected call to ItemStore.Update")
+	}
+	return m.UpdateFunc(editorID, id, req)
+}
+
+// Revert calls RevertFunc
+func (m *ItemStore) Revert(editorID, id, revisionID int, version *int) (*models.Item, error) {
+	if m.RevertFunc == nil {
+		panic("unexpected call to ItemStore.Revert")
+	}
+	return m.RevertFunc(editorID, id, revisionID, version)
+}
+
+// GetRevisions calls GetRevisionsFunc
+func (m *ItemStore) GetRevisions(id int) ([]*models.ItemRevision, error) {
+	if m.GetRevisionsFunc == nil {
+		panic("unexpected call to ItemStore.GetRevisions")
+	}
+	return m.GetRevisionsFunc(id)
+}
+
+// SetPublished calls SetPublishedFunc
+func (m *ItemStore) SetPublished(id int, published bool) (*models.Item, error) {
+	if m.SetPublishedFunc == nil {
+		panic("unexpected call to ItemStore.SetPublished")
+	}
+	return m.SetPublishedFunc(id, published)
+}
+
+// GetDrafts calls GetDraftsFunc
+func (m *ItemStore) GetDrafts() ([]*models.Item, error) {
+	if m.GetDraftsFunc == nil {
+		panic("unexpected call to ItemStore.GetDrafts")
+	}
+	return m.GetDraftsFunc()
+}
+
+// Schedule calls ScheduleFunc
+func (m *ItemStore) Schedule(itemIDs []int, publishAt *time.Time) ([]*models.Item, error) {
+	if m.ScheduleFunc == nil {
+		panic("unexpected call to ItemStore.Schedule")
+	}
+	return m.ScheduleFunc(itemIDs, publishAt)
+}
+
+// ReleaseDue calls ReleaseDueFunc
+func (m *ItemStore) ReleaseDue(now time.Time) ([]*models.Item, error) {
+	if m.ReleaseDueFunc == nil {
+		panic("unexpected call to ItemStore.ReleaseDue")
+	}
+	return m.ReleaseDueFunc(now)
+}
+
+// GetUpcomingReleases calls GetUpcomingReleasesFunc
+func (m *ItemStore) GetUpcomingReleases(limit int) ([]*models.UpcomingRelease, error) {
+	if m.GetUpcomingReleasesFunc == nil {
+		panic("unexpected call to ItemStore.GetUpcomingReleases")
+	}
+	return m.GetUpcomingReleasesFunc(limit)
+}
+
+// Delete calls DeleteFunc
+func (m *ItemStore) Delete(id int) error {
+	if m.DeleteFunc == nil {
+		panic("unexpected call to ItemStore.Delete")
+	}
+	return m.DeleteFunc(id)
+}
+
+// GetByID calls GetByIDFunc
+func (m *ItemStore) GetByID(id int) (*models.Item, error) {
+	if m.GetByIDFunc == nil {
+		panic("unexpected call to ItemStore.GetByID")
+	}
+	return m.GetByIDFunc(id)
+}
+
+// GetPublishedByID calls GetPublishedByIDFunc
+func (m *ItemStore) GetPublishedByID(id int) (*models.Item, error) {
+	if m.GetPublishedByIDFunc == nil {
+		panic("unexpected call to ItemStore.GetPublishedByID")
+	}
+	return m.GetPublishedByIDFunc(id)
+}
+
+// GetBySourceArticle calls GetBySourceArticleFunc
+func (m *ItemStore) GetBySourceArticle(articleID int) (*models.Item, error) {
+	if m.GetBySourceArticleFunc == nil {
+		panic("unexpected call to ItemStore.GetBySourceArticle")
+	}
+	return m.GetBySourceArticleFunc(articleID)
+}
+
+// GetDuplicateCandidates calls GetDuplicateCandidatesFunc
+func (m *ItemStore) GetDuplicateCandidates() ([]*models.Item, error) {
+	if m.GetDuplicateCandidatesFunc == nil {
+		panic("unexpected call to ItemStore.GetDuplicateCandidates")
+	}
+	return m.GetDuplicateCandidatesFunc()
+}
+
+// GetAll calls GetAllFunc
+func (m *ItemStore) GetAll(filter *models.ItemFilter) ([]*models.Item, error) {
+	if m.GetAllFunc == nil {
+		panic("unexpected call to ItemStore.GetAll")
+	}
+	return m.GetAllFunc(filter)
+}
+
+// GetTotalCount calls GetTotalCountFunc
+func (m *ItemStore) GetTotalCount(filter *models.ItemFilter) (int, error) {
+	if m.GetTotalCountFunc == nil {
+		panic("unexpected call to ItemStore.GetTotalCount")
+	}
+	return m.GetTotalCountFunc(filter)
+}
+
+// GetItemAnalytics calls GetItemAnalyticsFunc
+func (m *ItemStore) GetItemAnalytics(filter *models.ItemAnalyticsFilter) ([]*models.ItemAnalytics, int, error) {
+	if m.GetItemAnalyticsFunc == nil {
+		panic("unexpected call to ItemStore.GetItemAnalytics")
+	}
+	return m.GetItemAnalyticsFunc(filter)
+}
+
+// GetByIDWithUserProgress calls GetByIDWithUserProgressFunc
+func (m *ItemStore) GetByIDWithUserProgress(userID, itemID int) (*models.ItemWithProgress, error) {
+	if m.GetByIDWithUserProgressFunc == nil {
+		panic("unexpected call to ItemStore.GetByIDWithUserProgress")
+	}
+	return m.GetByIDWithUserProgressFunc(userID, itemID)
+}
+
+// GetAllWithUserProgress calls GetAllWithUserProgressFunc
+func (m *ItemStore) GetAllWithUserProgress(userID int, filter *models.ItemFilter) ([]*models.ItemWithProgress, error) {
+	if m.GetAllWithUserProgressFunc == nil {
+		panic("unexpected call to ItemStore.GetAllWithUserProgress")
+	}
+	return m.GetAllWithUserProgressFunc(userID, filter)
+}
+
+// GetTotalCountWithUserProgress calls GetTotalCountWithUserProgressFunc
+func (m *ItemStore) GetTotalCountWithUserProgress(userID int, filter *models.ItemFilter) (int, error) {
+	if m.GetTotalCountWithUserProgressFunc == nil {
+		panic("unexpected call to ItemStore.GetTotalCountWithUserProgress")
+	}
+	return m.GetTotalCountWithUserProgressFunc(userID, filter)
+}
+
+// GetInProgressItemWithUserProgress calls GetInProgressItemWithUserProgressFunc
+func (m *ItemStore) GetInProgressItemWithUserProgress(userID int) (*models.ItemWithProgress, error) {
+	if m.GetInProgressItemWithUserProgressFunc == nil {
+		panic("unexpected call to ItemStore.GetInProgressItemWithUserProgress")
+	}
+	return m.GetInProgressItemWithUserProgressFunc(userID)
+}
+
+// GetRandomPendingWithUserProgress calls GetRandomPendingWithUserProgressFunc
+func (m *ItemStore) GetRandomPendingWithUserProgress(userID int) (*models.ItemWithProgress, error) {
+	if m.GetRandomPendingWithUserProgressFunc == nil {
+		panic("unexpected call to ItemStore.GetRandomPendingWithUserProgress")
+	}
+	return m.GetRandomPendingWithUserProgressFunc(userID)
+}
+
+// GetRandomItems calls GetRandomItemsFunc
+func (m *ItemStore) GetRandomItems(userID int, filter *models.ItemFilter) ([]models.ItemWithProgress, error) {
+	if m.GetRandomItemsFunc == nil {
+		panic("unexpected call to ItemStore.GetRandomItems")
+	}
+	return m.GetRandomItemsFunc(userID, filter)
+}
+
+// GetCountsByCategoryForUser calls GetCountsByCategoryForUserFunc
+func (m *ItemStore) GetCountsByCategoryForUser(userID int, removeMiscellaneous bool) (map[models.Category]map[models.Status]int, error) {
+	if m.GetCountsByCategoryForUserFunc == nil {
+		panic("unexpected call to ItemStore.GetCountsByCategoryForUser")
+	}
+	return m.GetCountsByCategoryForUserFunc(userID, removeMiscellaneous)
+}
+
+// GetCountsBySubcategoryForUser calls GetCountsBySubcategoryForUserFunc
+func (m *ItemStore) GetCountsBySubcategoryForUser(userID int) (map[models.Category]map[string]map[models.Status]int, error) {
+	if m.GetCountsBySubcategoryForUserFunc == nil {
+		panic("unexpected call to ItemStore.GetCountsBySubcategoryForUser")
+	}
+	return m.GetCountsBySubcategoryForUserFunc(userID)
+}
+
+// UpsertUserProgressForItem calls UpsertUserProgressForItemFunc
+func (m *ItemStore) UpsertUserProgressForItem(userID, itemID int, status models.Status) error {
+	if m.UpsertUserProgressForItemFunc == nil {
+		panic("unexpected call to ItemStore.UpsertUserProgressForItem")
+	}
+	return m.UpsertUserProgressForItemFunc(userID, itemID, status)
+}
+
+// UpdateStatusForUser calls UpdateStatusForUserFunc
+func (m *ItemStore) UpdateStatusForUser(userID, itemID int, status models.Status) (*models.ItemWithProgress, error) {
+	if m.UpdateStatusForUserFunc == nil {
+		panic("unexpected call to ItemStore.UpdateStatusForUser")
+	}
+	return m.UpdateStatusForUserFunc(userID, itemID, status)
+}
+
+// MarkCompleted calls MarkCompletedFunc
+func (m *ItemStore) MarkCompleted(tx *repositories.Tx, userID, itemID int, quality models.CompletionQuality, hintCredit float64, nextReviewAt time.Time) error {
+	if m.MarkCompletedFunc == nil {
+		panic("unexpected call to ItemStore.MarkCompleted")
+	}
+	return m.MarkCompletedFunc(tx, userID, itemID, quality, hintCredit, nextReviewAt)
+}
+
+// CountPendingForUserInTx calls CountPendingForUserInTxFunc
+func (m *ItemStore) CountPendingForUserInTx(tx *repositories.Tx, userID int) (int, error) {
+	if m.CountPendingForUserInTxFunc == nil {
+		panic("unexpected call to ItemStore.CountPendingForUserInTx")
+	}
+	return m.CountPendingForUserInTxFunc(tx, userID)
+}
+
+// ToggleStarForUser calls ToggleStarForUserFunc
+func (m *ItemStore) ToggleStarForUser(userID, itemID int) (*models.ItemWithProgress, error) {
+	if m.ToggleStarForUserFunc == nil {
+		panic("unexpected call to ItemStore.ToggleStarForUser")
+	}
+	return m.ToggleStarForUserFunc(userID, itemID)
+}
+
+// SetStarredForUser calls SetStarredForUserFunc
+func (m *ItemStore) SetStarredForUser(userID int, itemIDs []int, starred bool) (changed, unchanged []int, err error) {
+	if m.SetStarredForUserFunc == nil {
+		panic("unexpected call to ItemStore.SetStarredForUser")
+	}
+	return m.SetStarredForUserFunc(userID, itemIDs, starred)
+}
+
+// ResetInProgressItemsForUser calls ResetInProgressItemsForUserFunc
+func (m *ItemStore) ResetInProgressItemsForUser(userID int) error {
+	if m.ResetInProgressItemsForUserFunc == nil {
+		panic("unexpected call to ItemStore.ResetInProgressItemsForUser")
+	}
+	return m.ResetInProgressItemsForUserFunc(userID)
+}
+
+// SkipInProgressItemsForUser calls SkipInProgressItemsForUserFunc
+func (m *ItemStore) SkipInProgressItemsForUser(userID int) error {
+	if m.SkipInProgressItemsForUserFunc == nil {
+		panic("unexpected call to ItemStore.SkipInProgressItemsForUser")
+	}
+	return m.SkipInProgressItemsForUserFunc(userID)
+}
+
+// ResetAllUserProgress calls ResetAllUserProgressFunc
+func (m *ItemStore) ResetAllUserProgress(userID int) (int64, error) {
+	if m.ResetAllUserProgressFunc == nil {
+		panic("unexpected call to ItemStore.ResetAllUserProgress")
+	}
+	return m.ResetAllUserProgressFunc(userID)
+}
+
+// ResetUserProgressByCategory calls ResetUserProgressByCategoryFunc
+func (m *ItemStore) ResetUserProgressByCategory(userID int, category models.Category) (int64, error) {
+	if m.ResetUserProgressByCategoryFunc == nil {
+		panic("unexpected call to ItemStore.ResetUserProgressByCategory")
+	}
+	return m.ResetUserProgressByCategoryFunc(userID, category)
+}
+
+// GetReviewCountForUser calls GetReviewCountForUserFunc
+func (m *ItemStore) GetReviewCountForUser(userID, itemID int) (int, error) {
+	if m.GetReviewCountForUserFunc == nil {
+		panic("unexpected call to ItemStore.GetReviewCountForUser")
+	}
+	return m.GetReviewCountForUserFunc(userID, itemID)
+}
+
+// RecordReviewForUser calls RecordReviewForUserFunc
+func (m *ItemStore) RecordReviewForUser(userID, itemID int, quality models.CompletionQuality, nextReviewAt time.Time) (*models.ItemWithProgress, error) {
+	if m.RecordReviewForUserFunc == nil {
+		panic("unexpected call to ItemStore.RecordReviewForUser")
+	}
+	return m.RecordReviewForUserFunc(userID, itemID, quality, nextReviewAt)
+}
+
+// GetDueReviewsForUser calls GetDueReviewsForUserFunc
+func (m *ItemStore) GetDueReviewsForUser(userID int, dueBy time.Time, limit int) ([]*models.ItemWithProgress, error) {
+	if m.GetDueReviewsForUserFunc == nil {
+		panic("unexpected call to ItemStore.GetDueReviewsForUser")
+	}
+	return m.GetDueReviewsForUserFunc(userID, dueBy, limit)
+}
+
+// CountReviewsDueForUser calls CountReviewsDueForUserFunc
+func (m *ItemStore) CountReviewsDueForUser(userID int, dueBy time.Time) (int, error) {
+	if m.CountReviewsDueForUserFunc == nil {
+		panic("unexpected call to ItemStore.CountReviewsDueForUser")
+	}
+	return m.CountReviewsDueForUserFunc(userID, dueBy)
+}
+
+// TestStore is a mock TestStore
+type TestStore struct {
+	CreateTestItemsFunc             func(userID int, itemIDs []int) (string, error)
+	CreateRetakeFunc                func(userID int, parentSessionID string, itemIDs []int) (string, error)
+	GetTestByUserWithStatusFunc     func(userID int, itemStatus []string) (string, []int, error)
+	GetTestsBySessionIDFunc         func(userID int, sessionID string) ([]*models.Test, error)
+	GetTestCreatedAtFunc            func(userID int, sessionID string) (time.Time, error)
+	GetSessionItemsWithProgressFunc func(userID int, sessionIDs []string, itemStatus []string) (map[string][]models.TestSessionItem, error)
+	GetRecentSessionsFunc           func(userID int, limit int) ([]models.TestSession, error)
+	SaveTestResultFunc              func(userID int, sessionID string, itemID int, result *models.TestItemResult) error
+	UpdateTestStatusFunc            func(userID int, sessionID string, itemID int, status models.TestStatus) error
+	DeleteTestsBySessionIDFunc      func(userID int, sessionID string) error
+	CountSessionsSinceFunc          func(userID int, since time.Time) (int, error)
+	IsItemInPendingTestFunc         func(userID int) (bool, error)
+	GetEligibilityPolicyFunc        func() (*models.TestEligibilityPolicy, error)
+	SaveEligibilityRulesFunc        func(rules []models.TestEligibilityRule) (*models.TestEligibilityPolicy, error)
+}
+
+// CreateTestItems calls CreateTestItemsFunc
+func (m *TestStore) CreateTestItems(userID int, itemIDs []int) (string, error) {
+	if m.CreateTestItemsFunc == nil {
+		panic("unexpected call to TestStore.CreateTestItems")
+	}
+	return m.CreateTestItemsFunc(userID, itemIDs)
+}
+
+// CreateRetake calls CreateRetakeFunc
+func (m *TestStore) CreateRetake(userID int, parentSessionID string, itemIDs []int) (string, error) {
+	if m.CreateRetakeFunc == nil {
+		panic("unexpected call to TestStore.CreateRetake")
+	}
+	return m.CreateRetakeFunc(userID, parentSessionID, itemIDs)
+}
+
+// GetTestByUserWithStatus calls GetTestByUserWithStatusFunc
+func (m *TestStore) GetTestByUserWithStatus(userID int, itemStatus []string) (string, []int, error) {
+	if m.GetTestByUserWithStatusFunc == nil {
+		panic("unexpected call to TestStore.GetTestByUserWithStatus")
+	}
+	return m.GetTestByUserWithStatusFunc(userID, itemStatus)
+}
+
+// GetTestsBySessionID calls GetTestsBySessionIDFunc
+func (m *TestStore) GetTestsBySessionID(userID int, sessionID string) ([]*models.Test, error) {
+	if m.GetTestsBySessionIDFunc == nil {
+		panic("unexpected call to TestStore.GetTestsBySessionID")
+	}
+	return m.GetTestsBySessionIDFunc(userID, sessionID)
+}
+
+// GetTestCreatedAt calls GetTestCreatedAtFunc
+func (m *TestStore) GetTestCreatedAt(userID int, sessionID string) (time.Time, error) {
+	if m.GetTestCreatedAtFunc == nil {
+		panic("unexpected call to TestStore.GetTestCreatedAt")
+	}
+	return m.GetTestCreatedAtFunc(userID, sessionID)
+}
+
+// GetSessionItemsWithProgress calls GetSessionItemsWithProgressFunc
+func (m *TestStore) GetSessionItemsWithProgress(userID int, sessionIDs []string, itemStatus []string) (map[string][]models.TestSessionItem, error) {
+	if m.GetSessionItemsWithProgressFunc == nil {
+		panic("unexpected call to TestStore.GetSessionItemsWithProgress")
+	}
+	return m.GetSessionItemsWithProgressFunc(userID, sessionIDs, itemStatus)
+}
+
+// GetRecentSessions calls GetRecentSessionsFunc
+func (m *TestStore) GetRecentSessions(userID int, limit int) ([]models.TestSession, error) {
+	if m.GetRecentSessionsFunc == nil {
+		panic("unexpected call to TestStore.GetRecentSessions")
+	}
+	return m.GetRecentSessionsFunc(userID, limit)
+}
+
+// SaveTestResult calls SaveTestResultFunc
+func (m *TestStore) SaveTestResult(userID int, sessionID string, itemID int, result *models.TestItemResult) error {
+	if m.SaveTestResultFunc == nil {
+		panic("unexpected call to TestStore.SaveTestResult")
+	}
+	return m.SaveTestResultFunc(userID, sessionID, itemID, result)
+}
+
+// UpdateTestStatus calls UpdateTestStatusFunc
+func (m *TestStore) UpdateTestStatus(userID int, sessionID string, itemID int, status models.TestStatus) error {
+	if m.UpdateTestStatusFunc == nil {
+		panic("unexpected call to TestStore.UpdateTestStatus")
+	}
+	return m.UpdateTestStatusFunc(userID, sessionID, itemID, status)
+}
+
+// DeleteTestsBySessionID calls DeleteTestsBySessionIDFunc
+func (m *TestStore) DeleteTestsBySessionID(userID int, sessionID string) error {
+	if m.DeleteTestsBySessionIDFunc == nil {
+		panic("unexpected call to TestStore.DeleteTestsBySessionID")
+	}
+	return m.DeleteTestsBySessionIDFunc(userID, sessionID)
+}
+
+// CountSessionsSince calls CountSessionsSinceFunc
+func (m *TestStore) CountSessionsSince(userID int, since time.Time) (int, error) {
+	if m.CountSessionsSinceFunc == nil {
+		panic("unexpected call to TestStore.CountSessionsSince")
+	}
+	return m.CountSessionsSinceFunc(userID, since)
+}
+
+// IsItemInPendingTest calls IsItemInPendingTestFunc
+func (m *TestStore) IsItemInPendingTest(userID int) (bool, error) {
+	if m.IsItemInPendingTestFunc == nil {
+		panic("unexpected call to TestStore.IsItemInPendingTest")
+	}
+	return m.IsItemInPendingTestFunc(userID)
+}
+
+// GetEligibilityPolicy calls GetEligibilityPolicyFunc
+func (m *TestStore) GetEligibilityPolicy() (*models.TestEligibilityPolicy, error) {
+	if m.GetEligibilityPolicyFunc == nil {
+		panic("unexpected call to TestStore.GetEligibilityPolicy")
+	}
+	return m.GetEligibilityPolicyFunc()
+}
+
+// SaveEligibilityRules calls SaveEligibilityRulesFunc
+func (m *TestStore) SaveEligibilityRules(rules []models.TestEligibilityRule) (*models.TestEligibilityPolicy, error) {
+	if m.SaveEligibilityRulesFunc == nil {
+		panic("unexpected call to TestStore.SaveEligibilityRules")
+	}
+	return m.SaveEligibilityRulesFunc(rules)
+}
+
+// StatsStore is a mock StatsStore
+type StatsStore struct {
+	GetUserStatsFunc                   func(userID int) (*models.UserStats, error)
+	RefreshUserAggregatesFunc          func(userID int) error
+	AdvanceStreakFunc                  func(tx *repositories.Tx, userID int, today time.Time) (*models.StreakChangedData, error)
+	IncrementUserCompletedAllCountFunc func(tx *repositories.Tx, userID int) error
+	ResetUserCompletedAllCountFunc     func(userID int) error
+	SetDailyGoalFunc                   func(userID int, goal int) error
+	RecordDailyGoalProgressFunc        func(userID int, increment int) error
+	GetDailyGoalProgressFunc           func(userID int) (*models.DailyGoalProgress, error)
+}
+
+// GetUserStats calls GetUserStatsFunc
+func (m *StatsStore) GetUserStats(userID int) (*models.UserStats, error) {
+	if m.GetUserStatsFunc == nil {
+		panic("unexpected call to StatsStore.GetUserStats")
+	}
+	return m.GetUserStatsFunc(userID)
+}
+
+// RefreshUserAggregates calls RefreshUserAggregatesFunc
+func (m *StatsStore) RefreshUserAggregates(userID int) error {
+	if m.RefreshUserAggregatesFunc == nil {
+		panic("unexpected call to StatsStore.RefreshUserAggregates")
+	}
+	return m.RefreshUserAggregatesFunc(userID)
+}
+
+// AdvanceStreak calls AdvanceStreakFunc
+func (m *StatsStore) AdvanceStreak(tx *repositories.Tx, userID int, today time.Time) (*models.StreakChangedData, error) {
+	if m.AdvanceStreakFunc == nil {
+		panic("unexpected call to StatsStore.AdvanceStreak")
+	}
+	return m.AdvanceStreakFunc(tx, userID, today)
+}
+
+// IncrementUserCompletedAllCount calls IncrementUserCompletedAllCountFunc
+func (m *StatsStore) IncrementUserCompletedAllCount(tx *repositories.Tx, userID int) error {
+	if m.IncrementUserCompletedAllCountFunc == nil {
+		panic("unexpected call to StatsStore.IncrementUserCompletedAllCount")
+	}
+	return m.IncrementUserCompletedAllCountFunc(tx, userID)
+}
+
+// ResetUserCompletedAllCount calls ResetUserCompletedAllCountFunc
+func (m *StatsStore) ResetUserCompletedAllCount(userID int) error {
+	if m.ResetUserCompletedAllCountFunc == nil {
+		panic("unexpected call to StatsStore.ResetUserCompletedAllCount")
+	}
+	return m.ResetUserCompletedAllCountFunc(userID)
+}
+
+// SetDailyGoal calls SetDailyGoalFunc
+func (m *StatsStore) SetDailyGoal(userID int, goal int) error {
+	if m.SetDailyGoalFunc == nil {
+		panic("unexpected call to StatsStore.SetDailyGoal")
+	}
+	return m.SetDailyGoalFunc(userID, goal)
+}
+
+// RecordDailyGoalProgress calls RecordDailyGoalProgressFunc
+func (m *StatsStore) RecordDailyGoalProgress(userID int, increment int) error {
+	if m.RecordDailyGoalProgressFunc == nil {
+		panic("unexpected call to StatsStore.RecordDailyGoalProgress")
+	}
+	return m.RecordDailyGoalProgressFunc(userID, increment)
+}
+
+// GetDailyGoalProgress calls GetDailyGoalProgressFunc
+func (m *StatsStore) GetDailyGoalProgress(userID int) (*models.DailyGoalProgress, error) {
+	if m.GetDailyGoalProgressFunc == nil {
+		panic("unexpected call to StatsStore.GetDailyGoalProgress")
+	}
+	return m.GetDailyGoalProgressFunc(userID)
+}
+
+// HintStore is a mock HintStore
+type HintStore struct {
+	CountRevealedForUserFunc func(userID, itemID int) (int, int, error)
+}
+
+// CountRevealedForUser calls CountRevealedForUserFunc
+func (m *HintStore) CountRevealedForUser(userID, itemID int) (int, int, error) {
+	if m.CountRevealedForUserFunc == nil {
+		panic("unexpected call to HintStore.CountRevealedForUser")
+	}
+	return m.CountRevealedForUserFunc(userID, itemID)
+}
+
+// FocusStore is a mock FocusStore
+type FocusStore struct {
+	GetFocusedSecondsFunc        func(userID int, since, now time.Time) (int, error)
+	GetFocusedTimeByCategoryFunc func(userID int, since, now time.Time) ([]models.CategoryTimeSpent, error)
+	GetFocusedTimeByWeekFunc     func(userID int, since, now time.Time) (map[string]int, error)
+}
+
+// GetFocusedSeconds calls GetFocusedSecondsFunc
+func (m *FocusStore) GetFocusedSeconds(userID int, since, now time.Time) (int, error) {
+	if m.GetFocusedSecondsFunc == nil {
+		panic("unexpected call to FocusStore.GetFocusedSeconds")
+	}
+	return m.GetFocusedSecondsFunc(userID, since, now)
+}
+
+// GetFocusedTimeByCategory calls GetFocusedTimeByCategoryFunc
+func (m *FocusStore) GetFocusedTimeByCategory(userID int, since, now time.Time) ([]models.CategoryTimeSpent, error) {
+	if m.GetFocusedTimeByCategoryFunc == nil {
+		panic("unexpected call to FocusStore.GetFocusedTimeByCategory")
+	}
+	return m.GetFocusedTimeByCategoryFunc(userID, since, now)
+}
+
+// GetFocusedTimeByWeek calls GetFocusedTimeByWeekFunc
+func (m *FocusStore) GetFocusedTimeByWeek(userID int, since, now time.Time) (map[string]int, error) {
+	if m.GetFocusedTimeByWeekFunc == nil {
+		panic("unexpected call to FocusStore.GetFocusedTimeByWeek")
+	}
+	return m.GetFocusedTimeByWeekFunc(userID, since, now)
+}
+
+// SubmissionStatsStore is a mock SubmissionStatsStore
+type SubmissionStatsStore struct {
+	GetStatsForUserFunc func(userID int) (*models.SubmissionStats, error)
+}
+
+// GetStatsForUser calls GetStatsForUserFunc
+func (m *SubmissionStatsStore) GetStatsForUser(userID int) (*models.SubmissionStats, error) {
+	if m.GetStatsForUserFunc == nil {
+		panic("unexpected call to SubmissionStatsStore.GetStatsForUser")
+	}
+	return m.GetStatsForUserFunc(userID)
+}
+
+// CategoryStore is a mock CategoryStore
+type CategoryStore struct {
+	GetAllFunc func() ([]*models.CategoryDefinition, error)
+	CreateFunc func(category *models.CategoryDefinition) error
+	UpdateFunc func(slug models.Category, name string, orderIdx int, subcategories []string) error
+	DeleteFunc func(slug models.Category) error
+}
+
+// GetAll calls GetAllFunc
+func (m *CategoryStore) GetAll() ([]*models.CategoryDefinition, error) {
+	if m.GetAllFunc == nil {
+		panic("unexpected call to CategoryStore.GetAll")
+	}
+	return m.GetAllFunc()
+}
+
+// Create calls CreateFunc
+func (m *CategoryStore) Create(category *models.CategoryDefinition) error {
+	if m.CreateFunc == nil {
+		panic("unexpected call to CategoryStore.Create")
+	}
+	return m.CreateFunc(category)
+}
+
+// Update calls UpdateFunc
+func (m *CategoryStore) Update(slug models.Category, name string, orderIdx int, subcategories []string) error {
+	if m.UpdateFunc == nil {
+		panic("unexpected call to CategoryStore.Update")
+	}
+	return m.UpdateFunc(slug, name, orderIdx, subcategories)
+}
+
+// Delete calls DeleteFunc
+func (m *CategoryStore) Delete(slug models.Category) error {
+	if m.DeleteFunc == nil {
+		panic("unexpected call to CategoryStore.Delete")
+	}
+	return m.DeleteFunc(slug)
+}
+
+// EntitlementStore is a mock EntitlementStore
+type EntitlementStore struct {
+	GetEntitlementsFunc func(userID int) (*models.Entitlements, error)
+}
+
+// GetEntitlements calls GetEntitlementsFunc
+func (m *EntitlementStore) GetEntitlements(userID int) (*models.Entitlements, error) {
+	if m.GetEntitlementsFunc == nil {
+		panic("unexpected call to EntitlementStore.GetEntitlements")
+	}
+	return m.GetEntitlementsFunc(userID)
+}
+
+// TxRunner is a mock TxRunner
+type TxRunner struct {
+	WithTxFunc     func(ctx context.Context, fn func(tx *repositories.Tx) error) error
+	WithUserTxFunc func(ctx context.Context, userID int, fn func(tx *repositories.Tx) error) error
+}
+
+// WithTx calls WithTxFunc
+func (m *TxRunner) WithTx(ctx context.Context, fn func(tx *repositories.Tx) error) error {
+	if m.WithTxFunc == nil {
+		panic("unexpected call to TxRunner.WithTx")
+	}
+	return m.WithTxFunc(ctx, fn)
+}
+
+// WithUserTx calls WithUserTxFunc
+func (m *TxRunner) WithUserTx(ctx context.Context, userID int, fn func(tx *repositories.Tx) error) error {
+	if m.WithUserTxFunc == nil {
+		panic("unexpected call to TxRunner.WithUserTx")
+	}
+	return m.WithUserTxFunc(ctx, userID, fn)
+}
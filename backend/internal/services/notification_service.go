@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"interview-prep-app/internal/events"
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/plugins"
+	"interview-prep-app/internal/push"
+	"interview-prep-app/internal/repositories"
+	"interview-prep-app/internal/validation"
+)
+
+// maxDeviceTokenLength guards the devices table against junk tokens
+const maxDeviceTokenLength = 4096
+
+// streakMilestones are the streak lengths that earn a congratulatory push
+var streakMilestones = map[int]bool{7: true, 30: true, 100: true, 365: true}
+
+// NotificationService handles push device registration and delivery
+type NotificationService struct {
+	deviceRepo *repositories.DeviceRepository
+	senders    map[models.DevicePlatform]push.Sender
+}
+
+// NewNotificationService creates a new notification service with the configured push senders
+func NewNotificationService(deviceRepo *repositories.DeviceRepository, senders map[models.DevicePlatform]push.Sender) *NotificationService {
+	return &NotificationService{
+		deviceRepo: deviceRepo,
+		senders:    senders,
+	}
+}
+
+// RegisterDevice stores a device token or web push subscription for the user
+func (s *NotificationService) RegisterDevice(userID int, req *models.RegisterDeviceRequest) (*models.Device, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	if _, ok := s.senders[req.Platform]; !ok {
+		return nil, fmt.Errorf("push notifications are not configured for %s", req.Platform)
+	}
+
+	device := &models.Device{
+		UserID:   userID,
+		Platform: req.Platform,
+		Label:    strings.TrimSpace(req.Label),
+	}
+
+	if req.Platform == models.DevicePlatformWeb {
+		endpoint, err := url.Parse(req.Endpoint)
+		if err != nil || endpoint.Scheme != "https" || endpoint.Host == "" {
+			return nil, fmt.Errorf("a valid https subscription endpoint is required for web push")
+		}
+		if req.Keys == nil || req.Keys.P256dh == "" || req.Keys.Auth == "" {
+			return nil, fmt.Errorf("subscription keys are required for web push")
+		}
+		device.Token = req.Endpoint
+		device.P256dh = req.Keys.P256dh
+		device.Auth = req.Keys.Auth
+	} else {
+		device.Token = strings.TrimSpace(req.Token)
+		if device.Token == "" {
+			return nil, fmt.Errorf("device token is required")
+		}
+	}
+
+	if len(device.Token) > maxDeviceTokenLength {
+		return nil, fmt.Errorf("device token is too long")
+	}
+
+	if err := s.deviceRepo.Upsert(device); err != nil {
+		return nil, err
+	}
+
+	return device, nil
+}
+
+// GetDevices returns the user's registered devices
+func (s *NotificationService) GetDevices(userID int) ([]*models.Device, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	return s.deviceRepo.GetForUser(userID)
+}
+
+// RemoveDevice unregisters one of the user's devices
+func (s *NotificationService) RemoveDevice(userID, deviceID int) error {
+	if userID <= 0 {
+		return fmt.Errorf("invalid user ID")
+	}
+
+	if deviceID <= 0 {
+		return fmt.Errorf("invalid device ID")
+	}
+
+	return s.deviceRepo.Delete(userID, deviceID)
+}
+
+// VAPIDPublicKey returns the key browsers need to subscribe, or "" when web push is off
+func (s *NotificationService) VAPIDPublicKey() string {
+	if sender, ok := s.senders[models.DevicePlatformWeb].(*push.WebPushSender); ok {
+		return sender.PublicKey()
+	}
+	return ""
+}
+
+// NotifyUser pushes a message to every device the user has registered. Devices the push
+// service reports as gone are removed; other failures are logged so one bad device
+// doesn't stop the rest.
+func (s *NotificationService) NotifyUser(ctx context.Context, userID int, msg push.Message) error {
+	devices, err := s.deviceRepo.GetForUser(userID)
+	if err != nil {
+		return err
+	}
+
+	for _, device := range devices {
+		sender, ok := s.senders[device.Platform]
+		if !ok {
+			continue
+		}
+
+		err := sender.Send(ctx, device, msg)
+		if errors.Is(err, push.ErrInvalidToken) {
+			if err := s.deviceRepo.DeleteByID(device.ID); err != nil {
+				fmt.Printf("Warning: failed to remove stale device %d: %v\n", device.ID, err)
+			}
+			continue
+		}
+		if err != nil {
+			fmt.Printf("Warning: failed to push to device %d for user %d: %v\n", device.ID, userID, err)
+		}
+	}
+
+	return nil
+}
+
+// Name identifies push as a notification channel
+func (s *NotificationService) Name() string {
+	return "push"
+}
+
+// Notify delivers a reminder as a push notification, so push plugs into the same
+// channel list as plugin integrations
+func (s *NotificationService) Notify(ctx context.Context, n plugins.Notification) error {
+	return s.NotifyUser(ctx, n.UserID, push.Message{
+		Title: n.Subject,
+		Body:  n.Body,
+		Link:  n.Link,
+		Kind:  n.Kind,
+	})
+}
+
+// Subscribe pushes notifications for bus events users want to hear about on their devices
+func (s *NotificationService) Subscribe(bus events.Bus) {
+	bus.Subscribe(events.StreakChanged, "notifications", s.notifyStreakMilestone)
+}
+
+// notifyStreakMilestone congratulates a user whose streak just reached a milestone
+func (s *NotificationService) notifyStreakMilestone(ctx context.Context, e events.Event) error {
+	var data models.StreakChangedData
+	if err := e.Decode(&data); err != nil {
+		return err
+	}
+
+	if data.CurrentStreak <= data.PreviousStreak || !streakMilestones[data.CurrentStreak] {
+		return nil
+	}
+
+	return s.NotifyUser(ctx, e.UserID, push.Message{
+		Title: fmt.Sprintf("%d-day streak!", data.CurrentStreak),
+		Body:  fmt.Sprintf("You've practiced %d days in a row. Keep it going!", data.CurrentStreak),
+		Kind:  "streak_milestone",
+	})
+}
@@ -0,0 +1,195 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"interview-prep-app/internal/models"
+)
+
+const (
+	// weakestSubcategoryCount is how many of the cohort's weakest subcategories are reported
+	weakestSubcategoryCount = 5
+	// activeMemberWindow is how recently a member must have completed something to count as active
+	activeMemberWindow = 7 * 24 * time.Hour
+)
+
+// AggregateCohorts takes today's analytics snapshot for every organization (run on a schedule)
+func (s *OrgService) AggregateCohorts(ctx context.Context) error {
+	orgIDs, err := s.orgRepo.GetAllIDs()
+	if err != nil {
+		return err
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	for _, orgID := range orgIDs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if _, err := s.snapshotCohort(orgID, today); err != nil {
+			fmt.Printf("Warning: failed to aggregate analytics for organization %d: %v\n", orgID, err)
+		}
+	}
+
+	return nil
+}
+
+// GetCohortAnalytics returns the latest cohort snapshot for an organization with week-over-week
+// movement. Anonymized results drop member names, emails and IDs; organization admins only ever
+// see anonymized results.
+func (s *OrgService) GetCohortAnalytics(userID, orgID int, anonymize bool) (*models.OrgCohortAnalytics, error) {
+	if orgID <= 0 {
+		return nil, fmt.Errorf("invalid organization ID")
+	}
+
+	role, err := s.requireOrgAdmin(userID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if role != models.OrgRoleOwner {
+		anonymize = true
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	snapshot, day, generatedAt, err := s.orgRepo.GetCohortSnapshot(orgID, today)
+	if err != nil {
+		if err.Error() != "cohort snapshot not found" {
+			return nil, err
+		}
+
+		// First request before the scheduled aggregation has run for this organization
+		if snapshot, err = s.snapshotCohort(orgID, today); err != nil {
+			return nil, err
+		}
+		day, generatedAt = today, time.Now()
+	}
+
+	analytics := &models.OrgCohortAnalytics{
+		OrgID:          orgID,
+		SnapshotDate:   day,
+		GeneratedAt:    generatedAt,
+		Anonymized:     anonymize,
+		CohortSnapshot: *snapshot,
+	}
+
+	previous, previousDay, _, err := s.orgRepo.GetCohortSnapshot(orgID, day.AddDate(0, 0, -7))
+	if err == nil {
+		analytics.WeekOverWeek = &models.CohortMovement{
+			ComparedTo:            previousDay,
+			CompletedItems:        snapshot.CompletedItems - previous.CompletedItems,
+			ActiveMembers:         snapshot.ActiveMembers - previous.ActiveMembers,
+			AverageCompletionRate: roundTo(snapshot.AverageCompletionRate-previous.AverageCompletionRate, 2),
+			AverageStreak:         roundTo(snapshot.AverageStreak-previous.AverageStreak, 2),
+			MemberCount:           snapshot.MemberCount - previous.MemberCount,
+		}
+	} else if err.Error() != "cohort snapshot not found" {
+		return nil, err
+	}
+
+	if anonymize {
+		members := make([]models.CohortMember, len(snapshot.Members))
+		for i, member := range snapshot.Members {
+			member.UserID = 0
+			member.Email = ""
+			member.Name = fmt.Sprintf("Member %d", i+1)
+			members[i] = member
+		}
+		analytics.Members = members
+	}
+
+	return analytics, nil
+}
+
+// snapshotCohort aggregates an organization's current progress and stores it as the day's snapshot
+func (s *OrgService) snapshotCohort(orgID int, day time.Time) (*models.CohortSnapshot, error) {
+	progress, err := s.orgRepo.GetMemberProgress(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	totalItems, err := s.orgRepo.CountCatalogItems()
+	if err != nil {
+		return nil, err
+	}
+
+	subcategories, err := s.orgRepo.GetSubcategoryCompletion(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Completions a week ago, to report each member's movement
+	lastWeek := make(map[int]int)
+	if previous, _, _, err := s.orgRepo.GetCohortSnapshot(orgID, day.AddDate(0, 0, -7)); err == nil {
+		for _, member := range previous.Members {
+			lastWeek[member.UserID] = member.CompletedItems
+		}
+	}
+
+	snapshot := &models.CohortSnapshot{
+		MemberCount:          len(progress),
+		Members:              make([]models.CohortMember, 0, len(progress)),
+		WeakestSubcategories: []models.CohortSubcategory{},
+	}
+
+	activeSince := time.Now().Add(-activeMemberWindow)
+	var rateSum float64
+	var streakSum int
+	for _, p := range progress {
+		member := models.CohortMember{
+			UserID:         p.UserID,
+			Name:           p.Name,
+			Email:          p.Email,
+			CompletedItems: p.CompletedItems,
+			CurrentStreak:  p.CurrentStreak,
+		}
+		if totalItems > 0 {
+			member.CompletionRate = roundTo(float64(p.CompletedItems)/float64(totalItems)*100, 2)
+		}
+		if previous, ok := lastWeek[p.UserID]; ok {
+			member.CompletedThisWeek = p.CompletedItems - previous
+		}
+
+		snapshot.Members = append(snapshot.Members, member)
+		snapshot.CompletedItems += p.CompletedItems
+		rateSum += member.CompletionRate
+		streakSum += p.CurrentStreak
+		if p.LastActivityDate != nil && p.LastActivityDate.After(activeSince) {
+			snapshot.ActiveMembers++
+		}
+	}
+
+	if len(progress) > 0 {
+		snapshot.AverageCompletionRate = roundTo(rateSum/float64(len(progress)), 2)
+		snapshot.AverageStreak = roundTo(float64(streakSum)/float64(len(progress)), 2)
+
+		for i := range subcategories {
+			possible := subcategories[i].TotalItems * len(progress)
+			if possible > 0 {
+				subcategories[i].CompletionRate = roundTo(float64(subcategories[i].Completions)/float64(possible)*100, 2)
+			}
+		}
+		sort.SliceStable(subcategories, func(i, j int) bool {
+			return subcategories[i].CompletionRate < subcategories[j].CompletionRate
+		})
+		if len(subcategories) > weakestSubcategoryCount {
+			subcategories = subcategories[:weakestSubcategoryCount]
+		}
+		snapshot.WeakestSubcategories = subcategories
+	}
+
+	if err := s.orgRepo.SaveCohortSnapshot(orgID, day, snapshot); err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// roundTo rounds a value to the given number of decimal places
+func roundTo(value float64, places int) float64 {
+	factor := math.Pow(10, float64(places))
+	return math.Round(value*factor) / factor
+}
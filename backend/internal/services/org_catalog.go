@@ -0,0 +1,57 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/validation"
+)
+
+// GetOrgCatalog returns the items an organization curated for its members, with the user's
+// progress on each. The organization's catalog sits on top of the main one rather than
+// replacing it.
+func (s *OrgService) GetOrgCatalog(userID, orgID int) ([]*models.OrgCatalogItem, error) {
+	if _, err := s.requireOrgMember(userID, orgID); err != nil {
+		return nil, err
+	}
+
+	return s.orgRepo.GetCatalog(orgID, userID)
+}
+
+// SetOrgCatalogItem adds an item to an organization's catalog, or changes the position and note
+// of one already in it
+func (s *OrgService) SetOrgCatalogItem(userID, orgID, itemID int, req *models.SetOrgCatalogItemRequest) (*models.OrgCatalogItem, error) {
+	if _, err := s.requireOrgAdmin(userID, orgID); err != nil {
+		return nil, err
+	}
+
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	if err := s.orgRepo.SaveCatalogItem(orgID, itemID, req.Position, strings.TrimSpace(req.Note), userID); err != nil {
+		return nil, err
+	}
+
+	items, err := s.orgRepo.GetCatalog(orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		if item.ItemID == itemID {
+			return item, nil
+		}
+	}
+
+	return nil, fmt.Errorf("catalog item %d missing after save", itemID)
+}
+
+// RemoveOrgCatalogItem takes an item out of an organization's catalog
+func (s *OrgService) RemoveOrgCatalogItem(userID, orgID, itemID int) error {
+	if _, err := s.requireOrgAdmin(userID, orgID); err != nil {
+		return err
+	}
+
+	return s.orgRepo.RemoveCatalogItem(orgID, itemID)
+}
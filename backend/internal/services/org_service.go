@@ -0,0 +1,601 @@
+package services
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"interview-prep-app/internal/mailer"
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+	"interview-prep-app/internal/validation"
+	"interview-prep-app/pkg/apperr"
+)
+
+const (
+	// invitationExpiry is how long an invitation link stays valid
+	invitationExpiry = 14 * 24 * time.Hour
+
+	// maxInvitationRows caps the size of a single CSV upload
+	maxInvitationRows = 500
+)
+
+// OrgService handles business logic for organizations and their invitations
+type OrgService struct {
+	orgRepo    *repositories.OrgRepository
+	userRepo   *repositories.UserRepository
+	billing    *BillingService
+	mailer     mailer.Mailer
+	appBaseURL string
+}
+
+// NewOrgService creates a new organization service
+func NewOrgService(orgRepo *repositories.OrgRepository, userRepo *repositories.UserRepository, billing *BillingService, m mailer.Mailer, appBaseURL string) *OrgService {
+	return &OrgService{
+		orgRepo:    orgRepo,
+		userRepo:   userRepo,
+		billing:    billing,
+		mailer:     m,
+		appBaseURL: strings.TrimRight(appBaseURL, "/"),
+	}
+}
+
+// CreateOrganization creates an organization owned by the given user
+func (s *OrgService) CreateOrganization(userID int, req *models.CreateOrganizationRequest) (*models.Organization, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(req.Name)
+
+	org := &models.Organization{Name: name, CreatedBy: userID}
+	if err := s.orgRepo.Create(org); err != nil {
+		return nil, err
+	}
+
+	return org, nil
+}
+
+// GetInvitations lists an organization's invitations and their acceptance state
+func (s *OrgService) GetInvitations(userID, orgID int) ([]*models.OrgInvitation, error) {
+	if orgID <= 0 {
+		return nil, fmt.Errorf("invalid organization ID")
+	}
+
+	if _, err := s.requireOrgAdmin(userID, orgID); err != nil {
+		return nil, err
+	}
+
+	return s.orgRepo.GetInvitations(orgID)
+}
+
+// BulkInvite creates and emails invitations from a CSV of "email[,role]" rows.
+// A header row is optional. Rows that can't be invited are reported rather than failing the upload.
+// Only owners can invite other owners.
+func (s *OrgService) BulkInvite(orgID, inviterID int, r io.Reader) (*models.BulkInvitationResponse, error) {
+	if orgID <= 0 {
+		return nil, fmt.Errorf("invalid organization ID")
+	}
+
+	inviterRole, err := s.requireOrgAdmin(inviterID, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	org, err := s.orgRepo.GetByID(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+
+	if len(records) > 0 && len(records[0]) > 0 && strings.EqualFold(strings.TrimSpace(records[0][0]), "email") {
+		records = records[1:]
+	}
+
+	if len(records) == 0 {
+		return nil, fmt.Errorf("CSV contains no invitations")
+	}
+
+	if len(records) > maxInvitationRows {
+		return nil, fmt.Errorf("too many rows: maximum is %d", maxInvitationRows)
+	}
+
+	seatsLeft, err := s.seatsLeft(org)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.BulkInvitationResponse{
+		Invited: []*models.OrgInvitation{},
+		Skipped: []models.SkippedInvitation{},
+	}
+	seen := map[string]bool{}
+
+	for i, record := range records {
+		row := i + 1
+		email, role, reason := parseInvitationRow(record)
+		if reason == "" && seen[email] {
+			reason = "duplicate email in file"
+		}
+		if reason == "" && role == models.OrgRoleOwner && inviterRole != models.OrgRoleOwner {
+			reason = "only owners can invite owners"
+		}
+		if reason == "" && seatsLeft == 0 {
+			reason = "no seats left on the organization's plan"
+		}
+		if reason != "" {
+			response.Skipped = append(response.Skipped, models.SkippedInvitation{Row: row, Email: email, Reason: reason})
+			continue
+		}
+		seen[email] = true
+
+		inv, token, reason, err := s.invite(org.ID, inviterID, email, role)
+		if err != nil {
+			return nil, err
+		}
+		if reason != "" {
+			response.Skipped = append(response.Skipped, models.SkippedInvitation{Row: row, Email: email, Reason: reason})
+			continue
+		}
+
+		go s.sendInvitation(org, inv, token)
+		response.Invited = append(response.Invited, inv)
+		if seatsLeft > 0 {
+			seatsLeft--
+		}
+	}
+
+	return response, nil
+}
+
+// AcceptInvitation lets an existing user join an organization with an invitation token
+func (s *OrgService) AcceptInvitation(userID int, token string) (*models.Organization, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	inv, err := s.orgRepo.GetPendingInvitationByTokenHash(hashSecretToken(token))
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.EqualFold(user.Email, inv.Email) {
+		return nil, apperr.Forbidden("invitation was sent to a different email address")
+	}
+
+	// Invitations sent before a downgrade can't take the organization further over its seats;
+	// existing members keep their access
+	org, err := s.orgRepo.GetByID(inv.OrgID)
+	if err != nil {
+		return nil, err
+	}
+	overLimit, err := s.overSeatLimit(org)
+	if err != nil {
+		return nil, err
+	}
+	if overLimit {
+		return nil, apperr.PaymentRequired("upgrade required: the organization has more members than its plan allows")
+	}
+
+	if err := s.orgRepo.AcceptInvitation(inv, userID); err != nil {
+		return nil, err
+	}
+
+	return s.orgRepo.GetByID(inv.OrgID)
+}
+
+// GetMyOrganizations lists the organizations a user belongs to, with their role in each
+func (s *OrgService) GetMyOrganizations(userID int) ([]*models.OrgMembership, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	return s.orgRepo.GetMemberships(userID)
+}
+
+// GetOrganization returns an organization with the user's role in it
+func (s *OrgService) GetOrganization(userID, orgID int) (*models.OrgMembership, error) {
+	role, err := s.requireOrgMember(userID, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	org, err := s.orgRepo.GetByID(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.orgRepo.CountMembers(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.OrgMembership{Organization: *org, Role: role, MemberCount: count}, nil
+}
+
+// MemberRole returns the user's role in an organization, failing with not found when they
+// aren't in it. Site admins act as owners of every organization.
+func (s *OrgService) MemberRole(userID, orgID int) (models.OrgRole, error) {
+	return s.requireOrgMember(userID, orgID)
+}
+
+// GetMembers lists an organization's members
+func (s *OrgService) GetMembers(userID, orgID int) ([]*models.OrgMember, error) {
+	if _, err := s.requireOrgAdmin(userID, orgID); err != nil {
+		return nil, err
+	}
+
+	return s.orgRepo.GetMembers(orgID)
+}
+
+// UpdateMemberRole changes a member's role. Only owners can change roles, and the last owner
+// can't step down.
+func (s *OrgService) UpdateMemberRole(userID, orgID, memberID int, req *models.UpdateOrgMemberRequest) error {
+	if err := s.requireOrgOwner(userID, orgID); err != nil {
+		return err
+	}
+
+	if err := validation.Struct(req); err != nil {
+		return err
+	}
+
+	current, err := s.orgRepo.GetMemberRole(orgID, memberID)
+	if err != nil {
+		return err
+	}
+	if current == req.Role {
+		return nil
+	}
+
+	if current == models.OrgRoleOwner {
+		if err := s.requireAnotherOwner(orgID); err != nil {
+			return err
+		}
+	}
+
+	return s.orgRepo.UpdateMemberRole(orgID, memberID, req.Role)
+}
+
+// RemoveMember removes a member from an organization. Members can leave on their own; admins can
+// remove members, and owners anyone. The last owner can't be removed.
+func (s *OrgService) RemoveMember(userID, orgID, memberID int) error {
+	role, err := s.requireOrgMember(userID, orgID)
+	if err != nil {
+		return err
+	}
+
+	target, err := s.orgRepo.GetMemberRole(orgID, memberID)
+	if err != nil {
+		return err
+	}
+
+	if memberID != userID {
+		if !role.CanManage() {
+			return apperr.Forbidden("organization admin access required")
+		}
+		if role != models.OrgRoleOwner && target != models.OrgRoleMember {
+			return apperr.Forbidden("only owners can remove owners and admins")
+		}
+	}
+
+	if target == models.OrgRoleOwner {
+		if err := s.requireAnotherOwner(orgID); err != nil {
+			return err
+		}
+	}
+
+	return s.orgRepo.RemoveMember(orgID, memberID)
+}
+
+// requireAnotherOwner fails when an organization has a single owner, who therefore can't step
+// down or leave
+func (s *OrgService) requireAnotherOwner(orgID int) error {
+	owners, err := s.orgRepo.CountOwners(orgID)
+	if err != nil {
+		return err
+	}
+	if owners <= 1 {
+		return apperr.Conflict("an organization needs at least one owner")
+	}
+
+	return nil
+}
+
+// seatsLeft returns how many more people can be invited under the plan of the organization's
+// creator, or -1 when seats are unlimited
+func (s *OrgService) seatsLeft(org *models.Organization) (int, error) {
+	if org.CreatedBy <= 0 {
+		return -1, nil
+	}
+
+	entitlements, err := s.billing.GetEntitlements(org.CreatedBy)
+	if err != nil {
+		return 0, err
+	}
+	if entitlements.OrgSeats == nil {
+		return -1, nil
+	}
+
+	used, err := s.orgRepo.CountSeats(org.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	return max(*entitlements.OrgSeats-used, 0), nil
+}
+
+// overSeatLimit reports whether the organization holds more seats, members and pending
+// invitations together, than its creator's plan allows, as happens after a downgrade
+func (s *OrgService) overSeatLimit(org *models.Organization) (bool, error) {
+	if org.CreatedBy <= 0 {
+		return false, nil
+	}
+
+	entitlements, err := s.billing.GetEntitlements(org.CreatedBy)
+	if err != nil {
+		return false, err
+	}
+	if entitlements.OrgSeats == nil {
+		return false, nil
+	}
+
+	used, err := s.orgRepo.CountSeats(org.ID)
+	if err != nil {
+		return false, err
+	}
+
+	return used > *entitlements.OrgSeats, nil
+}
+
+// invite creates a single invitation, returning a skip reason instead when the email is already covered
+func (s *OrgService) invite(orgID, inviterID int, email string, role models.OrgRole) (*models.OrgInvitation, string, string, error) {
+	isMember, err := s.orgRepo.IsMemberByEmail(orgID, email)
+	if err != nil {
+		return nil, "", "", err
+	}
+	if isMember {
+		return nil, "", "already a member", nil
+	}
+
+	pending, err := s.orgRepo.PendingInvitationExists(orgID, email)
+	if err != nil {
+		return nil, "", "", err
+	}
+	if pending {
+		return nil, "", "invitation already pending", nil
+	}
+
+	token, err := generateSecretToken()
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	inv := &models.OrgInvitation{
+		OrgID:     orgID,
+		Email:     email,
+		Role:      role,
+		TokenHash: hashSecretToken(token),
+		Status:    models.InvitationStatusPending,
+		InvitedBy: inviterID,
+		ExpiresAt: time.Now().Add(invitationExpiry),
+	}
+
+	if err := s.orgRepo.CreateInvitation(inv); err != nil {
+		return nil, "", "", err
+	}
+
+	return inv, token, "", nil
+}
+
+// sendInvitation emails the invitation link; failures are logged rather than surfaced
+func (s *OrgService) sendInvitation(org *models.Organization, inv *models.OrgInvitation, token string) {
+	link := fmt.Sprintf("%s/invite?token=%s&email=%s", s.appBaseURL, token, url.QueryEscape(inv.Email))
+
+	err := s.mailer.Send(mailer.Message{
+		To:      inv.Email,
+		Subject: fmt.Sprintf("You're invited to join %s", org.Name),
+		Body: fmt.Sprintf(
+			"You've been invited to join %s on Prep Master.\n\nSign up with this email address from the link below, or accept the invitation there if you already have an account:\n%s\n\nThis invitation expires on %s.\n",
+			org.Name, link, inv.ExpiresAt.Format("January 2, 2006"),
+		),
+	})
+	if err != nil {
+		fmt.Printf("Warning: failed to send invitation %d: %v\n", inv.ID, err)
+	}
+}
+
+// parseInvitationRow validates a CSV row, returning a skip reason when the row is unusable
+func parseInvitationRow(record []string) (string, models.OrgRole, string) {
+	if len(record) == 0 || strings.TrimSpace(record[0]) == "" {
+		return "", "", "missing email"
+	}
+
+	email := strings.ToLower(strings.TrimSpace(record[0]))
+	if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+		return email, "", "invalid email"
+	}
+
+	role := models.OrgRoleMember
+	if len(record) > 1 && strings.TrimSpace(record[1]) != "" {
+		role = models.OrgRole(strings.ToLower(strings.TrimSpace(record[1])))
+		if !models.IsValidOrgRole(role) {
+			return email, "", fmt.Sprintf("invalid role: %s", role)
+		}
+	}
+
+	return email, role, ""
+}
+
+// serviceAccountKeyPrefix marks API keys issued to service accounts
+const serviceAccountKeyPrefix = "pmsa_"
+
+// CreateServiceAccount issues a read-only analytics API key for an organization.
+// The raw key is returned once and only its hash is stored.
+func (s *OrgService) CreateServiceAccount(userID, orgID int, req *models.CreateServiceAccountRequest) (*models.CreateServiceAccountResponse, error) {
+	if err := s.requireOrgOwner(userID, orgID); err != nil {
+		return nil, err
+	}
+
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(req.Name)
+
+	secret, err := generateSecretToken()
+	if err != nil {
+		return nil, err
+	}
+	key := serviceAccountKeyPrefix + secret
+
+	account := &models.ServiceAccount{
+		OrgID:     orgID,
+		Name:      name,
+		KeyPrefix: key[:len(serviceAccountKeyPrefix)+6],
+		KeyHash:   hashSecretToken(key),
+		Scopes:    []string{models.ScopeAnalyticsRead},
+		CreatedBy: userID,
+	}
+
+	if err := s.orgRepo.CreateServiceAccount(account); err != nil {
+		return nil, err
+	}
+
+	return &models.CreateServiceAccountResponse{ServiceAccount: account, APIKey: key}, nil
+}
+
+// GetServiceAccounts lists an organization's service accounts
+func (s *OrgService) GetServiceAccounts(userID, orgID int) ([]*models.ServiceAccount, error) {
+	if err := s.requireOrgOwner(userID, orgID); err != nil {
+		return nil, err
+	}
+
+	return s.orgRepo.GetServiceAccounts(orgID)
+}
+
+// RevokeServiceAccount disables a service account's API key
+func (s *OrgService) RevokeServiceAccount(userID, orgID, accountID int) error {
+	if err := s.requireOrgOwner(userID, orgID); err != nil {
+		return err
+	}
+
+	return s.orgRepo.RevokeServiceAccount(orgID, accountID)
+}
+
+// AuthenticateServiceAccount resolves an API key to an active service account
+func (s *OrgService) AuthenticateServiceAccount(key string) (*models.ServiceAccount, error) {
+	if !strings.HasPrefix(key, serviceAccountKeyPrefix) {
+		return nil, apperr.NotFound("service account not found")
+	}
+
+	account, err := s.orgRepo.GetActiveServiceAccountByKeyHash(hashSecretToken(key))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.orgRepo.TouchServiceAccount(account.ID); err != nil {
+		// Log error but don't fail the request
+		fmt.Printf("Warning: failed to record usage for service account %d: %v\n", account.ID, err)
+	}
+
+	return account, nil
+}
+
+// GetMemberProgress returns per-member progress for an organization's analytics
+func (s *OrgService) GetMemberProgress(orgID int) ([]models.OrgMemberProgress, error) {
+	if orgID <= 0 {
+		return nil, fmt.Errorf("invalid organization ID")
+	}
+
+	if _, err := s.orgRepo.GetByID(orgID); err != nil {
+		return nil, err
+	}
+
+	return s.orgRepo.GetMemberProgress(orgID)
+}
+
+// orgRole returns the user's role in an organization, or "" when they aren't a member. Site
+// admins act as owners of every organization.
+func (s *OrgService) orgRole(userID, orgID int) (models.OrgRole, error) {
+	if userID <= 0 {
+		return "", fmt.Errorf("invalid user ID")
+	}
+
+	if _, err := s.orgRepo.GetByID(orgID); err != nil {
+		return "", err
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return "", err
+	}
+	if user.Role == models.RoleAdmin {
+		return models.OrgRoleOwner, nil
+	}
+
+	role, err := s.orgRepo.GetMemberRole(orgID, userID)
+	if errors.Is(err, apperr.ErrNotFound) {
+		return "", nil
+	}
+
+	return role, err
+}
+
+// requireOrgMember allows anyone in the organization, hiding it from everyone else
+func (s *OrgService) requireOrgMember(userID, orgID int) (models.OrgRole, error) {
+	role, err := s.orgRole(userID, orgID)
+	if err != nil {
+		return "", err
+	}
+	if role == "" {
+		return "", apperr.NotFound("organization not found")
+	}
+
+	return role, nil
+}
+
+// requireOrgAdmin allows organization owners and admins, and site admins
+func (s *OrgService) requireOrgAdmin(userID, orgID int) (models.OrgRole, error) {
+	role, err := s.orgRole(userID, orgID)
+	if err != nil {
+		return "", err
+	}
+	if !role.CanManage() {
+		return "", apperr.Forbidden("organization admin access required")
+	}
+
+	return role, nil
+}
+
+// requireOrgOwner allows organization owners and site admins
+func (s *OrgService) requireOrgOwner(userID, orgID int) error {
+	role, err := s.orgRole(userID, orgID)
+	if err != nil {
+		return err
+	}
+	if role != models.OrgRoleOwner {
+		return apperr.Forbidden("organization owner access required")
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package services
+
+import (
+	"testing"
+
+	"interview-prep-app/internal/models"
+)
+
+func TestParseInvitationRow(t *testing.T) {
+	for name, tc := range map[string]struct {
+		record []string
+		email  string
+		role   models.OrgRole
+		reason string
+	}{
+		"default role":  {[]string{" Ada@Example.com "}, "ada@example.com", models.OrgRoleMember, ""},
+		"admin":         {[]string{"ada@example.com", "Admin"}, "ada@example.com", models.OrgRoleAdmin, ""},
+		"owner":         {[]string{"ada@example.com", "owner"}, "ada@example.com", models.OrgRoleOwner, ""},
+		"unknown role":  {[]string{"ada@example.com", "coach"}, "ada@example.com", "", "invalid role: coach"},
+		"missing email": {[]string{""}, "", "", "missing email"},
+		"invalid email": {[]string{"ada"}, "ada", "", "invalid email"},
+	} {
+		email, role, reason := parseInvitationRow(tc.record)
+		if email != tc.email || role != tc.role || reason != tc.reason {
+			t.Errorf("%s: got (%q, %q, %q), want (%q, %q, %q)", name, email, role, reason, tc.email, tc.role, tc.reason)
+		}
+	}
+}
+
+func TestOrgRoleCanManage(t *testing.T) {
+	for role, want := range map[models.OrgRole]bool{
+		models.OrgRoleOwner:  true,
+		models.OrgRoleAdmin:  true,
+		models.OrgRoleMember: false,
+		"":                   false,
+	} {
+		if got := role.CanManage(); got != want {
+			t.Errorf("OrgRole(%q).CanManage() = %v, want %v", role, got, want)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+	"interview-prep-app/pkg/apperr"
+)
+
+// badges are the milestones a public profile can show, in display order
+var badges = []struct {
+	badge  models.Badge
+	earned func(stats *models.UserStats) bool
+}{
+	{models.Badge{ID: "first_item", Name: "First Steps", Description: "Completed a first item"},
+		func(s *models.UserStats) bool { return s.CompletedItems >= 1 }},
+	{models.Badge{ID: "items_25", Name: "Getting Serious", Description: "Completed 25 items"},
+		func(s *models.UserStats) bool { return s.CompletedItems >= 25 }},
+	{models.Badge{ID: "items_100", Name: "Centurion", Description: "Completed 100 items"},
+		func(s *models.UserStats) bool { return s.CompletedItems >= 100 }},
+	{models.Badge{ID: "streak_7", Name: "Week Streak", Description: "Practiced 7 days in a row"},
+		func(s *models.UserStats) bool { return s.LongestStreak >= 7 }},
+	{models.Badge{ID: "streak_30", Name: "Month Streak", Description: "Practiced 30 days in a row"},
+		func(s *models.UserStats) bool { return s.LongestStreak >= 30 }},
+	{models.Badge{ID: "well_rounded", Name: "Well Rounded", Description: "Completed DSA, LLD and HLD items"},
+		func(s *models.UserStats) bool { return s.DSACompleted > 0 && s.LLDCompleted > 0 && s.HLDCompleted > 0 }},
+	{models.Badge{ID: "completed_all", Name: "Finisher", Description: "Completed every item in the list"},
+		func(s *models.UserStats) bool { return s.CompletedAllCount > 0 }},
+}
+
+// earnedBadges returns the badges a user's stats have earned
+func earnedBadges(stats *models.UserStats) []models.Badge {
+	earned := []models.Badge{}
+	for _, b := range badges {
+		if b.earned(stats) {
+			earned = append(earned, b.badge)
+		}
+	}
+	return earned
+}
+
+// ProfileService manages users' opt-in public profiles, which share selected stats with anyone
+// holding the profile's handle, such as a mentor
+type ProfileService struct {
+	profileRepo *repositories.ProfileRepository
+	userRepo    *repositories.UserRepository
+	statsRepo   StatsStore
+}
+
+// NewProfileService creates a new profile service
+func NewProfileService(profileRepo *repositories.ProfileRepository, userRepo *repositories.UserRepository, statsRepo StatsStore) *ProfileService {
+	return &ProfileService{
+		profileRepo: profileRepo,
+		userRepo:    userRepo,
+		statsRepo:   statsRepo,
+	}
+}
+
+// GetSettings returns the user's public profile settings. A user who never set it up gets the
+// defaults: disabled, with stats but not their name shown once enabled.
+func (s *ProfileService) GetSettings(userID int) (*models.PublicProfileSettings, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	settings, err := s.profileRepo.GetSettings(userID)
+	if err != nil || settings != nil {
+		return settings, err
+	}
+
+	return &models.PublicProfileSettings{ShowStreak: true, ShowTotals: true, ShowBadges: true}, nil
+}
+
+// UpdateSettings applies the given settings. A handle is issued the first time they're saved.
+func (s *ProfileService) UpdateSettings(userID int, req *models.UpdatePublicProfileRequest) (*models.PublicProfileSettings, error) {
+	settings, err := s.GetSettings(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Enabled != nil {
+		settings.Enabled = *req.Enabled
+	}
+	if req.ShowName != nil {
+		settings.ShowName = *req.ShowName
+	}
+	if req.ShowStreak != nil {
+		settings.ShowStreak = *req.ShowStreak
+	}
+	if req.ShowTotals != nil {
+		settings.ShowTotals = *req.ShowTotals
+	}
+	if req.ShowBadges != nil {
+		settings.ShowBadges = *req.ShowBadges
+	}
+
+	if settings.Handle == "" {
+		if settings.Handle, err = generateProfileHandle(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.profileRepo.SaveSettings(userID, settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// RegenerateHandle gives the profile a new handle, so links shared with the old one stop working
+func (s *ProfileService) RegenerateHandle(userID int) (*models.PublicProfileSettings, error) {
+	settings, err := s.GetSettings(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if settings.Handle, err = generateProfileHandle(); err != nil {
+		return nil, err
+	}
+
+	if err := s.profileRepo.SaveSettings(userID, settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// GetPublicProfile returns the sections of an enabled profile its owner chose to show
+func (s *ProfileService) GetPublicProfile(handle string) (*models.PublicProfile, error) {
+	userID, settings, err := s.profileRepo.GetByHandle(handle)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := &models.PublicProfile{Handle: settings.Handle, MemberSince: user.CreatedAt}
+	if settings.ShowName {
+		profile.Name = user.Name
+	}
+	if !settings.ShowStreak && !settings.ShowTotals && !settings.ShowBadges {
+		return profile, nil
+	}
+
+	stats, err := s.statsRepo.GetUserStats(userID)
+	if err != nil {
+		return nil, err
+	}
+	if stats.StatsRefreshedAt == nil {
+		if err := s.statsRepo.RefreshUserAggregates(userID); err != nil {
+			return nil, err
+		}
+		if stats, err = s.statsRepo.GetUserStats(userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if settings.ShowStreak {
+		profile.Streak = &models.PublicStreak{Current: stats.CurrentStreak, Longest: stats.LongestStreak}
+	}
+	if settings.ShowTotals {
+		profile.Totals = &models.PublicTotals{
+			Completed:    stats.CompletedItems,
+			DSACompleted: stats.DSACompleted,
+			LLDCompleted: stats.LLDCompleted,
+			HLDCompleted: stats.HLDCompleted,
+			CompletedAll: stats.CompletedAllCount,
+		}
+	}
+	if settings.ShowBadges {
+		profile.Badges = earnedBadges(stats)
+	}
+
+	return profile, nil
+}
+
+// generateProfileHandle returns a random, URL-safe profile handle that's impractical to guess
+func generateProfileHandle() (string, error) {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return "", apperr.Errorf("failed to generate profile handle: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"interview-prep-app/internal/models"
+)
+
+func TestEarnedBadges(t *testing.T) {
+	testCases := []struct {
+		name     string
+		stats    models.UserStats
+		expected []string
+	}{
+		{"New user", models.UserStats{}, []string{}},
+		{"First completion", models.UserStats{CompletedItems: 1, DSACompleted: 1, LongestStreak: 1}, []string{"first_item"}},
+		{
+			"Every track and a week streak",
+			models.UserStats{CompletedItems: 30, DSACompleted: 20, LLDCompleted: 5, HLDCompleted: 5, LongestStreak: 9},
+			[]string{"first_item", "items_25", "streak_7", "well_rounded"},
+		},
+		{
+			"Finished the list",
+			models.UserStats{CompletedItems: 120, DSACompleted: 100, LLDCompleted: 10, HLDCompleted: 10, LongestStreak: 45, CompletedAllCount: 1},
+			[]string{"first_item", "items_25", "items_100", "streak_7", "streak_30", "well_rounded", "completed_all"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ids := []string{}
+			for _, badge := range earnedBadges(&tc.stats) {
+				ids = append(ids, badge.ID)
+			}
+			if !reflect.DeepEqual(ids, tc.expected) {
+				t.Errorf("Expected badges %v, got %v", tc.expected, ids)
+			}
+		})
+	}
+}
+
+func TestGenerateProfileHandle(t *testing.T) {
+	first, err := generateProfileHandle()
+	if err != nil {
+		t.Fatalf("generateProfileHandle returned error: %v", err)
+	}
+	second, _ := generateProfileHandle()
+
+	if len(first) != 16 || first == second {
+		t.Errorf("Expected distinct 16-character handles, got %q and %q", first, second)
+	}
+}
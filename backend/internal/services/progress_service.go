@@ -0,0 +1,355 @@
+package services
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"interview-prep-app/internal/events"
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+)
+
+const (
+	defaultProgressPageSize = 20
+	maxProgressPageSize     = 100
+
+	// maxImportRows caps the size of a progress import file
+	maxImportRows = 5000
+)
+
+// importStatusAliases maps the status spellings other trackers use onto ours
+var importStatusAliases = map[string]models.Status{
+	"":            models.StatusDone,
+	"done":        models.StatusDone,
+	"completed":   models.StatusDone,
+	"complete":    models.StatusDone,
+	"solved":      models.StatusDone,
+	"in-progress": models.StatusInProgress,
+	"in progress": models.StatusInProgress,
+	"in_progress": models.StatusInProgress,
+	"attempted":   models.StatusInProgress,
+	"pending":     models.StatusPending,
+	"todo":        models.StatusPending,
+}
+
+// ProgressService handles listing and importing a user's raw progress records
+type ProgressService struct {
+	userProgressRepo *repositories.UserProgressRepository
+	itemRepo         *repositories.ItemRepository
+	bus              events.Bus
+}
+
+// NewProgressService creates a new progress service
+func NewProgressService(userProgressRepo *repositories.UserProgressRepository, itemRepo *repositories.ItemRepository, bus events.Bus) *ProgressService {
+	return &ProgressService{
+		userProgressRepo: userProgressRepo,
+		itemRepo:         itemRepo,
+		bus:              bus,
+	}
+}
+
+// GetProgress returns a filtered, sorted page of the user's progress records with pagination metadata
+func (s *ProgressService) GetProgress(userID int, filter *models.ProgressFilter) (*models.PaginatedProgressResponse, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if filter.Status != nil && !models.IsValidStatus(*filter.Status) {
+		return nil, fmt.Errorf("invalid status: %s", *filter.Status)
+	}
+
+	if filter.SortBy != "" && !isProgressSortField(filter.SortBy) {
+		return nil, fmt.Errorf("invalid sort_by: %s. Valid values are: %v", filter.SortBy, models.ProgressSortFields)
+	}
+
+	if filter.SortOrder != "" && !strings.EqualFold(filter.SortOrder, "asc") && !strings.EqualFold(filter.SortOrder, "desc") {
+		return nil, fmt.Errorf("invalid sort_order: %s. Valid values are: asc, desc", filter.SortOrder)
+	}
+
+	if filter.Limit < 0 {
+		return nil, fmt.Errorf("limit cannot be negative")
+	}
+
+	if filter.Offset < 0 {
+		return nil, fmt.Errorf("offset cannot be negative")
+	}
+
+	if filter.Limit == 0 {
+		filter.Limit = defaultProgressPageSize
+	}
+	if filter.Limit > maxProgressPageSize {
+		filter.Limit = maxProgressPageSize
+	}
+
+	progress, total, err := s.userProgressRepo.GetByUserID(userID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.PaginatedProgressResponse{
+		Progress: progress,
+		Pagination: models.PaginationMeta{
+			Total:      total,
+			Limit:      filter.Limit,
+			Offset:     filter.Offset,
+			HasNext:    filter.Offset+filter.Limit < total,
+			HasPrev:    filter.Offset > 0,
+			TotalPages: (total + filter.Limit - 1) / filter.Limit,
+			Page:       (filter.Offset / filter.Limit) + 1,
+		},
+	}, nil
+}
+
+// ImportProgress creates progress records from a CSV, matching items by link. It accepts the
+// item export (a header row naming link, status, starred, completed_at and notes columns) or a
+// plain "url[,status]" file with an optional header; a missing status means done. Items the
+// user already tracks are left untouched. With dryRun nothing is written.
+func (s *ProgressService) ImportProgress(userID int, r io.Reader, dryRun bool) (*models.ProgressImportReport, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+
+	columns, hasHeader := importColumnsFor(records)
+	if hasHeader {
+		records = records[1:]
+	}
+	if columns.link < 0 {
+		return nil, fmt.Errorf("CSV has no link or url column")
+	}
+
+	if len(records) == 0 {
+		return nil, fmt.Errorf("CSV contains no progress")
+	}
+	if len(records) > maxImportRows {
+		return nil, fmt.Errorf("too many rows: maximum is %d", maxImportRows)
+	}
+
+	report := &models.ProgressImportReport{
+		DryRun:    dryRun,
+		TotalRows: len(records),
+		Rows:      make([]models.ProgressImportRow, 0, len(records)),
+	}
+
+	// Parse every row before touching the database so links can be matched in one query
+	entries := make([]*models.ProgressImportEntry, len(records))
+	links := []string{}
+	for i, record := range records {
+		row := models.ProgressImportRow{Row: i + 1}
+		if hasHeader {
+			row.Row++
+		}
+
+		entry, link, reason := columns.parse(record)
+		row.Link = link
+		if reason != "" {
+			row.Result = models.ImportResultInvalid
+			row.Reason = reason
+		} else {
+			row.Status = entry.Status
+			entries[i] = entry
+			links = append(links, normalizeImportLink(link))
+		}
+		report.Rows = append(report.Rows, row)
+	}
+
+	itemIDs, err := s.itemRepo.GetIDsByNormalizedLinks(links)
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []int
+	for i := range report.Rows {
+		row := &report.Rows[i]
+		if entries[i] == nil {
+			continue
+		}
+
+		itemID, ok := itemIDs[normalizeImportLink(row.Link)]
+		if !ok {
+			row.Result = models.ImportResultUnmatched
+			row.Reason = "no item with this link"
+			entries[i] = nil
+			continue
+		}
+		row.ItemID = itemID
+		entries[i].ItemID = itemID
+		matched = append(matched, itemID)
+	}
+
+	tracked, err := s.userProgressRepo.GetTrackedItemIDs(userID, matched)
+	if err != nil {
+		return nil, err
+	}
+
+	toCreate := []models.ProgressImportEntry{}
+	seen := map[int]bool{}
+	for i := range report.Rows {
+		row := &report.Rows[i]
+		if entries[i] == nil {
+			continue
+		}
+
+		switch {
+		case tracked[row.ItemID]:
+			row.Result = models.ImportResultAlreadyTracked
+		case seen[row.ItemID]:
+			row.Result = models.ImportResultAlreadyTracked
+			row.Reason = "duplicate item in file"
+		default:
+			row.Result = models.ImportResultCreated
+			seen[row.ItemID] = true
+			toCreate = append(toCreate, *entries[i])
+		}
+	}
+
+	if !dryRun && len(toCreate) > 0 {
+		created, err := s.userProgressRepo.CreateMissing(userID, toCreate)
+		if err != nil {
+			return nil, err
+		}
+
+		// Progress recorded concurrently since the check above wins; report those rows as tracked
+		for i := range report.Rows {
+			row := &report.Rows[i]
+			if row.Result == models.ImportResultCreated && !created[row.ItemID] {
+				row.Result = models.ImportResultAlreadyTracked
+			}
+		}
+
+		if len(created) > 0 {
+			publishEvent(s.bus, events.ProgressChanged, userID, nil)
+		}
+	}
+
+	for _, row := range report.Rows {
+		switch row.Result {
+		case models.ImportResultCreated:
+			report.Created++
+		case models.ImportResultAlreadyTracked:
+			report.AlreadyTracked++
+		case models.ImportResultUnmatched:
+			report.Unmatched++
+		case models.ImportResultInvalid:
+			report.Invalid++
+		}
+	}
+
+	return report, nil
+}
+
+// importColumns locates the columns of a progress import file; -1 means absent
+type importColumns struct {
+	link, status, starred, completedAt, notes int
+}
+
+// importColumnsFor reads the header row if the file has one, falling back to "url,status"
+func importColumnsFor(records [][]string) (importColumns, bool) {
+	columns := importColumns{link: 0, status: 1, starred: -1, completedAt: -1, notes: -1}
+	if len(records) == 0 {
+		return columns, false
+	}
+
+	header := importColumns{link: -1, status: -1, starred: -1, completedAt: -1, notes: -1}
+	isHeader := false
+	for i, cell := range records[0] {
+		switch strings.ToLower(strings.TrimSpace(cell)) {
+		case "link", "url":
+			header.link = i
+			isHeader = true
+		case "status":
+			header.status = i
+			isHeader = true
+		case "starred":
+			header.starred = i
+		case "completed_at":
+			header.completedAt = i
+		case "notes":
+			header.notes = i
+		}
+	}
+
+	if !isHeader {
+		return columns, false
+	}
+	return header, true
+}
+
+// parse turns a record into an import entry, returning a reason instead when the row is unusable
+func (c importColumns) parse(record []string) (*models.ProgressImportEntry, string, string) {
+	cell := func(i int) string {
+		if i < 0 || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	link := cell(c.link)
+	if link == "" {
+		return nil, "", "link is required"
+	}
+	if !strings.HasPrefix(link, "http://") && !strings.HasPrefix(link, "https://") {
+		return nil, link, "link must be an http(s) URL"
+	}
+
+	status, ok := importStatusAliases[strings.ToLower(cell(c.status))]
+	if !ok {
+		return nil, link, fmt.Sprintf("invalid status: %s", cell(c.status))
+	}
+
+	entry := &models.ProgressImportEntry{Status: status, Notes: cell(c.notes)}
+
+	// The export prefixes formula-like notes with a quote so spreadsheets don't evaluate them
+	if len(entry.Notes) > 1 && entry.Notes[0] == '\'' && strings.ContainsRune("=+-@", rune(entry.Notes[1])) {
+		entry.Notes = entry.Notes[1:]
+	}
+
+	if starred := cell(c.starred); starred != "" {
+		value, err := strconv.ParseBool(starred)
+		if err != nil {
+			return nil, link, fmt.Sprintf("invalid starred value: %s", starred)
+		}
+		entry.Starred = value
+	}
+
+	if status == models.StatusDone {
+		completedAt := time.Now()
+		if value := cell(c.completedAt); value != "" {
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return nil, link, fmt.Sprintf("invalid completed_at: %s", value)
+			}
+			completedAt = parsed
+		}
+		entry.CompletedAt = &completedAt
+	}
+
+	return entry, link, ""
+}
+
+// normalizeImportLink puts a link in the form items are matched on
+func normalizeImportLink(link string) string {
+	return strings.TrimRight(strings.ToLower(strings.TrimSpace(link)), "/")
+}
+
+// isProgressSortField checks a sort column against the allow-list (it is interpolated into SQL)
+func isProgressSortField(field string) bool {
+	for _, valid := range models.ProgressSortFields {
+		if field == valid {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,287 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+	"interview-prep-app/internal/validation"
+)
+
+// maxQuizAttempts caps how many of a user's attempts at a quiz are listed
+const maxQuizAttempts = 20
+
+// QuizService handles business logic for authoring quizzes and grading users' attempts
+type QuizService struct {
+	quizRepo        *repositories.QuizRepository
+	categoryService *CategoryService
+}
+
+// NewQuizService creates a new quiz service
+func NewQuizService(quizRepo *repositories.QuizRepository, categoryService *CategoryService) *QuizService {
+	return &QuizService{quizRepo: quizRepo, categoryService: categoryService}
+}
+
+// GetQuizzes lists quizzes with the user's latest score on each
+func (s *QuizService) GetQuizzes(userID int, filter *models.QuizFilter) (*models.QuizzesResponse, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if filter.Category != nil {
+		if err := s.categoryService.ValidateCategory(*filter.Category); err != nil {
+			return nil, err
+		}
+	}
+
+	quizzes, err := s.quizRepo.GetQuizzes(userID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, quiz := range quizzes {
+		if quiz.LastAttempt != nil {
+			quiz.LastAttempt.Score = quizScore(quiz.LastAttempt.Correct, quiz.LastAttempt.Total)
+		}
+	}
+
+	return &models.QuizzesResponse{Quizzes: quizzes}, nil
+}
+
+// GetQuiz returns a quiz with its questions. The correct choices and explanations are left out
+// unless withAnswers is set, for content editors.
+func (s *QuizService) GetQuiz(quizID int, withAnswers bool) (*models.Quiz, error) {
+	if quizID <= 0 {
+		return nil, fmt.Errorf("invalid quiz ID")
+	}
+
+	quiz, err := s.quizRepo.GetQuiz(quizID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !withAnswers {
+		for i := range quiz.Questions {
+			quiz.Questions[i].CorrectChoice = nil
+			quiz.Questions[i].Explanation = ""
+		}
+	}
+
+	return quiz, nil
+}
+
+// CreateQuiz adds a quiz with its questions
+func (s *QuizService) CreateQuiz(req *models.SaveQuizRequest) (*models.Quiz, error) {
+	quiz, err := s.quizFromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.quizRepo.CreateQuiz(quiz); err != nil {
+		return nil, err
+	}
+
+	return quiz, nil
+}
+
+// UpdateQuiz replaces a quiz's details and all of its questions. Earlier attempts keep the
+// answers they were graded against.
+func (s *QuizService) UpdateQuiz(quizID int, req *models.SaveQuizRequest) (*models.Quiz, error) {
+	if quizID <= 0 {
+		return nil, fmt.Errorf("invalid quiz ID")
+	}
+
+	quiz, err := s.quizFromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	quiz.ID = quizID
+	if err := s.quizRepo.ReplaceQuiz(quiz); err != nil {
+		return nil, err
+	}
+
+	return quiz, nil
+}
+
+// DeleteQuiz removes a quiz along with every attempt at it
+func (s *QuizService) DeleteQuiz(quizID int) error {
+	if quizID <= 0 {
+		return fmt.Errorf("invalid quiz ID")
+	}
+
+	return s.quizRepo.DeleteQuiz(quizID)
+}
+
+// quizFromRequest validates a quiz payload, checking each correct choice is one of its
+// question's choices
+func (s *QuizService) quizFromRequest(req *models.SaveQuizRequest) (*models.Quiz, error) {
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	category := models.Category(strings.TrimSpace(string(req.Category)))
+	if err := s.categoryService.ValidateCategory(category); err != nil {
+		return nil, err
+	}
+
+	quiz := &models.Quiz{
+		Title:       strings.TrimSpace(req.Title),
+		Description: strings.TrimSpace(req.Description),
+		Category:    category,
+		Subcategory: strings.TrimSpace(req.Subcategory),
+		Questions:   make([]models.QuizQuestion, len(req.Questions)),
+	}
+
+	var invalid []validation.FieldError
+	for i, question := range req.Questions {
+		if question.CorrectChoice >= len(question.Choices) {
+			invalid = append(invalid, validation.FieldError{
+				Field:   fmt.Sprintf("questions[%d].correct_choice", i),
+				Rule:    "max",
+				Message: fmt.Sprintf("correct_choice must be the index of one of the %d choices", len(question.Choices)),
+			})
+		}
+
+		choices := make([]string, len(question.Choices))
+		for j, choice := range question.Choices {
+			choices[j] = strings.TrimSpace(choice)
+		}
+		correct := question.CorrectChoice
+		quiz.Questions[i] = models.QuizQuestion{
+			Prompt:        strings.TrimSpace(question.Prompt),
+			Choices:       choices,
+			CorrectChoice: &correct,
+			Explanation:   strings.TrimSpace(question.Explanation),
+		}
+	}
+	if len(invalid) > 0 {
+		return nil, validation.Fields(invalid...)
+	}
+
+	return quiz, nil
+}
+
+// SubmitAttempt grades the user's answers to a quiz and records the attempt, which then counts
+// toward their weak-area recommendations
+func (s *QuizService) SubmitAttempt(userID, quizID int, req *models.SubmitQuizAttemptRequest) (*models.QuizAttempt, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if quizID <= 0 {
+		return nil, fmt.Errorf("invalid quiz ID")
+	}
+
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	quiz, err := s.quizRepo.GetQuiz(quizID)
+	if err != nil {
+		return nil, err
+	}
+
+	attempt, err := gradeQuiz(quiz, req.Answers)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.quizRepo.CreateAttempt(userID, attempt); err != nil {
+		return nil, err
+	}
+
+	return attempt, nil
+}
+
+// GetAttempts returns the user's most recent graded attempts at a quiz
+func (s *QuizService) GetAttempts(userID, quizID int) (*models.QuizAttemptsResponse, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if quizID <= 0 {
+		return nil, fmt.Errorf("invalid quiz ID")
+	}
+
+	if _, err := s.quizRepo.GetQuiz(quizID); err != nil {
+		return nil, err
+	}
+
+	attempts, err := s.quizRepo.GetAttempts(userID, quizID, maxQuizAttempts)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, attempt := range attempts {
+		attempt.Score = quizScore(attempt.Correct, attempt.Total)
+	}
+
+	return &models.QuizAttemptsResponse{Attempts: attempts}, nil
+}
+
+// gradeQuiz checks answers against a quiz's questions. Every question is graded, unanswered
+// ones as wrong; answering a question twice, one not in the quiz, or with a choice it doesn't
+// have is a validation error.
+func gradeQuiz(quiz *models.Quiz, answers []models.QuizAnswer) (*models.QuizAttempt, error) {
+	questions := make(map[int]*models.QuizQuestion, len(quiz.Questions))
+	for i := range quiz.Questions {
+		questions[quiz.Questions[i].ID] = &quiz.Questions[i]
+	}
+
+	chosen := make(map[int]int, len(answers))
+	var invalid []validation.FieldError
+	for i, answer := range answers {
+		field := func(name string) string { return fmt.Sprintf("answers[%d].%s", i, name) }
+
+		question, ok := questions[answer.QuestionID]
+		switch {
+		case !ok:
+			invalid = append(invalid, validation.FieldError{Field: field("question_id"), Rule: "exists", Message: fmt.Sprintf("question %d is not part of this quiz", answer.QuestionID)})
+		case answer.Choice >= len(question.Choices):
+			invalid = append(invalid, validation.FieldError{Field: field("choice"), Rule: "max", Message: fmt.Sprintf("question %d has %d choices", answer.QuestionID, len(question.Choices))})
+		}
+		if _, seen := chosen[answer.QuestionID]; seen {
+			invalid = append(invalid, validation.FieldError{Field: field("question_id"), Rule: "unique", Message: fmt.Sprintf("question %d is answered more than once", answer.QuestionID)})
+		}
+		chosen[answer.QuestionID] = answer.Choice
+	}
+	if len(invalid) > 0 {
+		return nil, validation.Fields(invalid...)
+	}
+
+	attempt := &models.QuizAttempt{
+		QuizID:  quiz.ID,
+		Total:   len(quiz.Questions),
+		Results: make([]models.QuizAnswerResult, len(quiz.Questions)),
+	}
+	for i, question := range quiz.Questions {
+		result := models.QuizAnswerResult{
+			QuestionID:    question.ID,
+			Prompt:        question.Prompt,
+			CorrectChoice: *question.CorrectChoice,
+			Explanation:   question.Explanation,
+		}
+		if choice, ok := chosen[question.ID]; ok {
+			result.Choice = &choice
+			result.Correct = choice == result.CorrectChoice
+		}
+		if result.Correct {
+			attempt.Correct++
+		}
+		attempt.Results[i] = result
+	}
+	attempt.Score = quizScore(attempt.Correct, attempt.Total)
+
+	return attempt, nil
+}
+
+// quizScore is the share of a quiz's questions answered correctly, rounded to two decimals
+func quizScore(correct, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(correct)/float64(total)*100) / 100
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"testing"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/services/mocks"
+	"interview-prep-app/pkg/apperr"
+)
+
+// sampleQuiz has three questions whose correct choices are 1, 0 and 2
+func sampleQuiz() *models.Quiz {
+	quiz := &models.Quiz{ID: 7}
+	for i, correct := range []int{1, 0, 2} {
+		correct := correct
+		quiz.Questions = append(quiz.Questions, models.QuizQuestion{
+			ID:            10 + i,
+			Prompt:        "Question",
+			Choices:       []string{"a", "b", "c"},
+			CorrectChoice: &correct,
+		})
+	}
+	return quiz
+}
+
+func TestGradeQuiz(t *testing.T) {
+	attempt, err := gradeQuiz(sampleQuiz(), []models.QuizAnswer{
+		{QuestionID: 12, Choice: 2},
+		{QuestionID: 10, Choice: 0},
+	})
+	if err != nil {
+		t.Fatalf("gradeQuiz returned error: %v", err)
+	}
+
+	if attempt.QuizID != 7 || attempt.Correct != 1 || attempt.Total != 3 || attempt.Score != 0.33 {
+		t.Errorf("Expected 1 of 3 correct on quiz 7 scoring 0.33, got %d of %d on quiz %d scoring %v", attempt.Correct, attempt.Total, attempt.QuizID, attempt.Score)
+	}
+
+	// Results follow the quiz's question order, with the unanswered question graded wrong
+	expectCorrect := []bool{false, false, true}
+	for i, result := range attempt.Results {
+		if result.QuestionID != 10+i || result.Correct != expectCorrect[i] {
+			t.Errorf("Result %d: expected question %d correct=%v, got question %d correct=%v", i, 10+i, expectCorrect[i], result.QuestionID, result.Correct)
+		}
+	}
+	if attempt.Results[1].Choice != nil {
+		t.Errorf("Expected no choice for the unanswered question, got %d", *attempt.Results[1].Choice)
+	}
+}
+
+func TestGradeQuizRejections(t *testing.T) {
+	for name, answers := range map[string][]models.QuizAnswer{
+		"unknown question":    {{QuestionID: 99, Choice: 0}},
+		"choice out of range": {{QuestionID: 10, Choice: 3}},
+		"answered twice":      {{QuestionID: 10, Choice: 0}, {QuestionID: 10, Choice: 1}},
+	} {
+		if _, err := gradeQuiz(sampleQuiz(), answers); apperr.From(err).Kind != apperr.KindValidation {
+			t.Errorf("%s: expected a validation error, got %v", name, err)
+		}
+	}
+}
+
+func TestQuizFromRequestChecksCorrectChoice(t *testing.T) {
+	service := NewQuizService(nil, NewCategoryService(&mocks.CategoryStore{
+		GetAllFunc: func() ([]*models.CategoryDefinition, error) {
+			return []*models.CategoryDefinition{{Slug: models.CategoryHLD, Name: "HLD"}}, nil
+		},
+	}))
+	req := &models.SaveQuizRequest{
+		Title:       "Caching",
+		Category:    "hld",
+		Subcategory: "caching",
+		Questions: []models.QuizQuestionRequest{
+			{Prompt: "Which policy evicts the least recently used entry?", Choices: []string{"FIFO", "LRU"}, CorrectChoice: 2},
+		},
+	}
+
+	if _, err := service.quizFromRequest(req); apperr.From(err).Kind != apperr.KindValidation {
+		t.Errorf("Expected a validation error for a correct choice past the last choice, got %v", err)
+	}
+}
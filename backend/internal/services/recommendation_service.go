@@ -0,0 +1,135 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+)
+
+// RecommendationService points users at their weakest subcategories, combining how much of
+// each they've completed with how often they skip its items and fail or only partly solve them
+// in tests
+type RecommendationService struct {
+	itemRepo *repositories.ItemRepository
+}
+
+// NewRecommendationService creates a new recommendation service
+func NewRecommendationService(itemRepo *repositories.ItemRepository) *RecommendationService {
+	return &RecommendationService{
+		itemRepo: itemRepo,
+	}
+}
+
+// GetRecommendations returns up to limit subcategories for the user to focus on, weakest
+// first, each with up to itemsPerSubcategory suggested items
+func (s *RecommendationService) GetRecommendations(userID, limit, itemsPerSubcategory int) ([]*models.SubcategoryRecommendation, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if limit <= 0 {
+		limit = models.DefaultRecommendations
+	}
+	if limit > models.MaxRecommendations {
+		return nil, fmt.Errorf("limit must be at most %d", models.MaxRecommendations)
+	}
+	if itemsPerSubcategory <= 0 {
+		itemsPerSubcategory = models.DefaultSuggestedItems
+	}
+	if itemsPerSubcategory > models.MaxSuggestedItems {
+		return nil, fmt.Errorf("items must be at most %d", models.MaxSuggestedItems)
+	}
+
+	signals, err := s.itemRepo.GetSubcategorySignalsForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	recommendations := []*models.SubcategoryRecommendation{}
+	for _, signal := range signals {
+		rec := scoreSubcategory(signal)
+		if rec.Score > 0 {
+			recommendations = append(recommendations, rec)
+		}
+	}
+
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		return recommendations[i].Score > recommendations[j].Score
+	})
+	if len(recommendations) > limit {
+		recommendations = recommendations[:limit]
+	}
+
+	for _, rec := range recommendations {
+		items, err := s.itemRepo.GetSuggestedItemsForUser(userID, rec.Category, rec.Subcategory, itemsPerSubcategory)
+		if err != nil {
+			return nil, err
+		}
+		rec.SuggestedItems = items
+	}
+
+	return recommendations, nil
+}
+
+// scoreSubcategory weighs a subcategory's signals: the share still to do, with completions that
+// leaned on hints only partly counting as done, skips (capped so a
+// few habitual skips don't dominate), the share of test attempts that failed, with partly
+// solved ones counting half, and the share of quiz questions answered wrong
+func scoreSubcategory(signal *models.SubcategorySignals) *models.SubcategoryRecommendation {
+	rec := &models.SubcategoryRecommendation{
+		Category:       signal.Category,
+		Subcategory:    signal.Subcategory,
+		TotalItems:     signal.TotalItems,
+		CompletedItems: signal.CompletedItems,
+		Skips:          signal.Skips,
+		TestAttempts:   signal.TestAttempts,
+		TestFailures:   signal.TestFailures,
+		TestPartials:   signal.TestPartials,
+		QuizCorrect:    signal.QuizCorrect,
+		QuizQuestions:  signal.QuizQuestions,
+		Reasons:        []string{},
+		SuggestedItems: []*models.ItemWithProgress{},
+	}
+
+	if signal.TotalItems > 0 {
+		rec.CompletionRatio = signal.CompletedCredit / float64(signal.TotalItems)
+	}
+
+	score := 1 - rec.CompletionRatio
+	if signal.CompletedItems < signal.TotalItems {
+		rec.Reasons = append(rec.Reasons, fmt.Sprintf("%d of %d items completed", signal.CompletedItems, signal.TotalItems))
+	}
+	if signal.CompletedCredit < float64(signal.CompletedItems) {
+		rec.Reasons = append(rec.Reasons, "completed items leaned on hints")
+	}
+
+	if signal.Skips > 0 {
+		skips := math.Min(float64(signal.Skips), models.RecommendationSkipCap)
+		score += models.RecommendationSkipScale * skips / models.RecommendationSkipCap
+		rec.Reasons = append(rec.Reasons, fmt.Sprintf("skipped %d times", signal.Skips))
+	}
+
+	if signal.TestFailures > 0 || signal.TestPartials > 0 {
+		missed := float64(signal.TestFailures) + models.TestPartialWeight*float64(signal.TestPartials)
+		score += missed / math.Max(float64(signal.TestAttempts), 1)
+	}
+	if signal.TestFailures > 0 {
+		rec.Reasons = append(rec.Reasons, fmt.Sprintf("%d of %d test attempts failed", signal.TestFailures, signal.TestAttempts))
+	}
+	if signal.TestPartials > 0 {
+		rec.Reasons = append(rec.Reasons, fmt.Sprintf("%d of %d test attempts only partly solved", signal.TestPartials, signal.TestAttempts))
+	}
+
+	if signal.QuizCorrect < signal.QuizQuestions {
+		missed := float64(signal.QuizQuestions-signal.QuizCorrect) / float64(signal.QuizQuestions)
+		score += models.QuizMissWeight * missed
+		rec.Reasons = append(rec.Reasons, fmt.Sprintf("%d of %d quiz questions answered correctly", signal.QuizCorrect, signal.QuizQuestions))
+	}
+
+	rec.CompletionRatio = math.Round(rec.CompletionRatio*100) / 100
+	rec.Score = math.Round(score*100) / 100
+	return rec
+}
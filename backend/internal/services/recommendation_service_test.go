@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+	"interview-prep-app/internal/testutil"
+)
+
+func TestScoreSubcategoryWeighsQuizScores(t *testing.T) {
+	signal := &models.SubcategorySignals{
+		Category:        models.CategoryHLD,
+		Subcategory:     "caching",
+		TotalItems:      4,
+		CompletedItems:  4,
+		CompletedCredit: 4,
+		QuizCorrect:     2,
+		QuizQuestions:   5,
+	}
+
+	rec := scoreSubcategory(signal)
+	if rec.Score != 0.3 {
+		t.Errorf("Expected a finished subcategory with 2 of 5 quiz questions right to score 0.3, got %v", rec.Score)
+	}
+	if len(rec.Reasons) != 1 || rec.Reasons[0] != "2 of 5 quiz questions answered correctly" {
+		t.Errorf("Expected the quiz score as the only reason, got %v", rec.Reasons)
+	}
+
+	signal.QuizCorrect = 5
+	if rec := scoreSubcategory(signal); rec.Score != 0 {
+		t.Errorf("Expected a perfect quiz score to add nothing, got %v", rec.Score)
+	}
+}
+
+func TestScoreSubcategoryWeighsHintCredit(t *testing.T) {
+	signal := &models.SubcategorySignals{
+		Category:        models.CategoryDSA,
+		Subcategory:     "graphs",
+		TotalItems:      4,
+		CompletedItems:  4,
+		CompletedCredit: 3,
+	}
+
+	rec := scoreSubcategory(signal)
+	if rec.CompletionRatio != 0.75 || rec.Score != 0.25 {
+		t.Errorf("Expected completions that lost a quarter of their credit to hints to score 0.25, got ratio %v, score %v", rec.CompletionRatio, rec.Score)
+	}
+	if len(rec.Reasons) != 1 || rec.Reasons[0] != "completed items leaned on hints" {
+		t.Errorf("Expected hints as the only reason, got %v", rec.Reasons)
+	}
+}
+
+func TestGetRecommendationsWeighsHintCredit(t *testing.T) {
+	db := testutil.OpenDB(t)
+	itemRepo := repositories.NewItemRepository(db)
+	userRepo := repositories.NewUserRepository(db)
+	hintRepo := repositories.NewHintRepository(db)
+	itemService := NewItemService(itemRepo, repositories.NewTestRepository(db), hintRepo, repositories.NewStatsRepository(db), repositories.NewTxManager(db), nil, nil, nil)
+
+	user := &models.User{Email: "ada@example.test", Name: "Ada", Role: models.RoleUser, AuthProvider: models.AuthProviderEmail}
+	if err := userRepo.Create(user); err != nil {
+		t.Fatal(err)
+	}
+	var itemIDs []int
+	for _, title := range []string{"Clone Graph", "Course Schedule"} {
+		item, err := itemRepo.Create(&models.CreateItemRequest{
+			Title: title, Link: "https://example.test/" + title, Category: models.CategoryDSA, Subcategory: "graphs",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		itemIDs = append(itemIDs, item.ID)
+	}
+
+	// Revealing both of the first item's hints halves its credit
+	for _, content := range []string{"Use a map", "Recurse on neighbours"} {
+		if _, err := hintRepo.Create(itemIDs[0], &models.CreateHintRequest{Content: content}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := hintRepo.RevealNext(user.ID, itemIDs[0]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, itemID := range itemIDs {
+		if _, err := itemService.CompleteItemWithUserProgress(context.Background(), user.ID, itemID, models.CompletionSolved); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	recommendations, err := NewRecommendationService(itemRepo).GetRecommendations(user.ID, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recommendations) != 1 || recommendations[0].CompletedItems != 2 || recommendations[0].CompletionRatio != 0.75 {
+		t.Fatalf("Expected both items completed for three quarters of the credit, got %+v", recommendations)
+	}
+
+	// Resetting progress clears the credit along with the completion
+	if _, err := itemRepo.ResetUserProgressByCategory(user.ID, models.CategoryDSA); err != nil {
+		t.Fatal(err)
+	}
+	var credits int
+	if err := db.QueryRow("SELECT COUNT(hint_credit) FROM user_progress WHERE user_id = $1", user.ID).Scan(&credits); err != nil || credits != 0 {
+		t.Errorf("Expected resetting to clear hint credit, got %d (%v)", credits, err)
+	}
+}
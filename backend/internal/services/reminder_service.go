@@ -0,0 +1,277 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"interview-prep-app/internal/i18n"
+	"interview-prep-app/internal/mailer"
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/plugins"
+	"interview-prep-app/internal/repositories"
+	"interview-prep-app/internal/validation"
+)
+
+// stuckItemThreshold is how long an item can stay in progress before the user gets nudged about it
+const stuckItemThreshold = 48 * time.Hour
+
+// streakAlertWindow is how long before the streak day ends (UTC midnight) an at-risk streak triggers an alert
+const streakAlertWindow = 3 * time.Hour
+
+// ReminderService handles notification preferences and sending reminder emails
+type ReminderService struct {
+	notificationRepo *repositories.NotificationRepository
+	statsRepo        *repositories.StatsRepository
+	mailer           mailer.Mailer
+	channels         []plugins.NotificationChannel
+	appBaseURL       string
+}
+
+// NewReminderService creates a new reminder service
+func NewReminderService(notificationRepo *repositories.NotificationRepository, statsRepo *repositories.StatsRepository, m mailer.Mailer, channels []plugins.NotificationChannel, appBaseURL string) *ReminderService {
+	return &ReminderService{
+		notificationRepo: notificationRepo,
+		statsRepo:        statsRepo,
+		mailer:           m,
+		channels:         channels,
+		appBaseURL:       strings.TrimRight(appBaseURL, "/"),
+	}
+}
+
+// GetPreferences returns the user's notification preferences
+func (s *ReminderService) GetPreferences(userID int) (*models.NotificationPreferences, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	return s.notificationRepo.GetPreferences(userID)
+}
+
+// UpdatePreferences applies the provided fields to the user's notification preferences
+func (s *ReminderService) UpdatePreferences(userID int, req *models.UpdateNotificationPreferencesRequest) (*models.NotificationPreferences, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	prefs, err := s.notificationRepo.GetPreferences(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.EmailEnabled != nil {
+		prefs.EmailEnabled = *req.EmailEnabled
+	}
+	if req.PushEnabled != nil {
+		prefs.PushEnabled = *req.PushEnabled
+	}
+	if req.StreakAlerts != nil {
+		prefs.StreakAlerts = *req.StreakAlerts
+	}
+	if req.DailyReminder != nil {
+		prefs.DailyReminder = *req.DailyReminder
+	}
+	if req.StuckItems != nil {
+		prefs.StuckItems = *req.StuckItems
+	}
+	if req.ReviewsDue != nil {
+		prefs.ReviewsDue = *req.ReviewsDue
+	}
+	if req.ReminderTime != nil {
+		prefs.ReminderTime = *req.ReminderTime
+	}
+	if req.Timezone != nil {
+		prefs.Timezone = *req.Timezone
+	}
+	if req.Locale != nil {
+		prefs.Locale = *req.Locale
+	}
+
+	if err := s.notificationRepo.SavePreferences(prefs); err != nil {
+		return nil, err
+	}
+
+	return prefs, nil
+}
+
+// SendDueReminders checks every opted-in user and emails the reminders that are due as of now.
+// Each kind of reminder goes out at most once per user per local day.
+func (s *ReminderService) SendDueReminders(now time.Time) error {
+	recipients, err := s.notificationRepo.GetReminderRecipients()
+	if err != nil {
+		return err
+	}
+
+	for _, recipient := range recipients {
+		if err := s.remindUser(recipient, now); err != nil {
+			// Log error but keep going so one user can't block everyone else's reminders
+			fmt.Printf("Warning: failed to send reminders to user %d: %v\n", recipient.UserID, err)
+		}
+	}
+
+	return nil
+}
+
+// remindUser sends whichever reminders are due for a single user
+func (s *ReminderService) remindUser(recipient *models.ReminderRecipient, now time.Time) error {
+	prefs := recipient.Preferences
+	locale := reminderLocale(recipient)
+
+	loc, err := time.LoadLocation(prefs.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	localNow := now.In(loc)
+	localMidnight := time.Date(localNow.Year(), localNow.Month(), localNow.Day(), 0, 0, 0, 0, loc)
+	// sent_reminders is keyed by the user's calendar day, not the UTC one
+	day := time.Date(localNow.Year(), localNow.Month(), localNow.Day(), 0, 0, 0, 0, time.UTC)
+
+	if prefs.DailyReminder && reminderTimeReached(prefs.ReminderTime, localNow) {
+		completed, err := s.notificationRepo.CountCompletedSince(recipient.UserID, localMidnight)
+		if err != nil {
+			return err
+		}
+		if completed == 0 {
+			s.send(recipient, models.ReminderDailyGoal, day,
+				i18n.T(locale, "Keep your streak going"),
+				i18n.T(locale, "You haven't completed any items today. Pick one up now to keep your streak alive:\n%s\n", s.appBaseURL))
+		}
+	}
+
+	if prefs.StuckItems {
+		stuck, err := s.notificationRepo.CountStuckInProgress(recipient.UserID, now.Add(-stuckItemThreshold))
+		if err != nil {
+			return err
+		}
+		if stuck > 0 {
+			s.send(recipient, models.ReminderStuckItems, day,
+				i18n.N(locale, "%d item waiting for you", "%d items waiting for you", stuck, stuck),
+				i18n.N(locale,
+					"You have %d item that has been in progress for more than two days. Finish it off or move on:\n%s\n",
+					"You have %d items that have been in progress for more than two days. Finish them off or move on:\n%s\n",
+					stuck, stuck, s.appBaseURL))
+		}
+	}
+
+	if prefs.ReviewsDue {
+		due, err := s.notificationRepo.CountReviewsDue(recipient.UserID, now)
+		if err != nil {
+			return err
+		}
+		if due > 0 {
+			s.send(recipient, models.ReminderReviewsDue, day,
+				i18n.N(locale, "%d review due", "%d reviews due", due, due),
+				i18n.N(locale,
+					"You have %d completed item due for review. Revisit it while it's still fresh:\n%s\n",
+					"You have %d completed items due for review. Revisit them while it's still fresh:\n%s\n",
+					due, due, s.appBaseURL))
+		}
+	}
+
+	if prefs.StreakAlerts {
+		if err := s.alertStreakAtRisk(recipient, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// alertStreakAtRisk warns users whose streak will break at the end of the streak day unless
+// they complete something. Streaks are counted in UTC days, so the window is relative to UTC midnight.
+func (s *ReminderService) alertStreakAtRisk(recipient *models.ReminderRecipient, now time.Time) error {
+	today := now.UTC().Truncate(24 * time.Hour)
+	if now.UTC().Before(today.Add(24*time.Hour - streakAlertWindow)) {
+		return nil
+	}
+
+	currentStreak, _, lastActivityDate, err := s.statsRepo.GetUserStreakInfo(recipient.UserID)
+	if err != nil {
+		return err
+	}
+
+	// Only streaks kept alive yesterday and not yet extended today are at risk
+	if currentStreak == 0 || lastActivityDate == nil || !lastActivityDate.UTC().Truncate(24*time.Hour).Equal(today.Add(-24*time.Hour)) {
+		return nil
+	}
+
+	locale := reminderLocale(recipient)
+	s.send(recipient, models.ReminderStreakRisk, today,
+		i18n.T(locale, "Your %d-day streak is about to end", currentStreak),
+		i18n.T(locale, "Complete one item in the next few hours to keep your %d-day streak:\n%s\n", currentStreak, s.appBaseURL))
+	return nil
+}
+
+// send claims today's slot for the reminder and delivers it over the channels the user has enabled;
+// failures are logged rather than surfaced
+func (s *ReminderService) send(recipient *models.ReminderRecipient, kind models.ReminderKind, day time.Time, subject, body string) {
+	claimed, err := s.notificationRepo.MarkReminderSent(recipient.UserID, kind, day)
+	if err != nil {
+		fmt.Printf("Warning: failed to record %s reminder for user %d: %v\n", kind, recipient.UserID, err)
+		return
+	}
+	if !claimed {
+		return
+	}
+
+	if recipient.Preferences.EmailEnabled {
+		err = s.mailer.Send(mailer.Message{
+			To:      recipient.Email,
+			Subject: subject,
+			Body:    i18n.T(reminderLocale(recipient), "Hi %s,\n\n%s\nYou can change which reminders you get in your notification settings.\n", recipient.Name, body),
+		})
+		if err != nil {
+			fmt.Printf("Warning: failed to send %s reminder to user %d: %v\n", kind, recipient.UserID, err)
+		}
+	}
+
+	if !recipient.Preferences.PushEnabled {
+		return
+	}
+
+	// Fan out to push devices and any notification channels added by plugins
+	for _, channel := range s.channels {
+		err := channel.Notify(context.Background(), plugins.Notification{
+			UserID:  recipient.UserID,
+			Email:   recipient.Email,
+			Name:    recipient.Name,
+			Kind:    string(kind),
+			Subject: subject,
+			Body:    body,
+			Link:    s.appBaseURL,
+		})
+		if err != nil {
+			fmt.Printf("Warning: failed to send %s reminder to user %d via %s: %v\n", kind, recipient.UserID, channel.Name(), err)
+		}
+	}
+}
+
+// reminderLocale is the locale a user's reminders are written in. Reminders aren't sent in
+// response to a request, so without a saved preference they're in the default locale.
+func reminderLocale(recipient *models.ReminderRecipient) string {
+	return i18n.Resolve(recipient.Preferences.Locale, "")
+}
+
+// reminderTimeReached reports whether the local clock has passed the user's HH:MM reminder time
+func reminderTimeReached(reminderTime string, localNow time.Time) bool {
+	parsed, err := time.Parse("15:04", reminderTime)
+	if err != nil {
+		parsed, _ = time.Parse("15:04", models.DefaultReminderTime)
+	}
+
+	nowMinutes := localNow.Hour()*60 + localNow.Minute()
+	return nowMinutes >= parsed.Hour()*60+parsed.Minute()
+}
+
+// pluralize picks the singular or plural form for a count
+func pluralize(count int, singular, plural string) string {
+	if count == 1 {
+		return singular
+	}
+	return plural
+}
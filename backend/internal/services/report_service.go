@@ -0,0 +1,213 @@
+package services
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"html/template"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+	"interview-prep-app/pkg/apperr"
+)
+
+// reportActivityLimit caps how many recent completions a report lists
+const reportActivityLimit = 50
+
+// ReportService shares snapshots of a user's progress through signed, expiring links, so
+// accountability partners can follow along without an account
+type ReportService struct {
+	reportRepo   *repositories.ReportRepository
+	userRepo     *repositories.UserRepository
+	statsService *StatsService
+	signingKey   []byte
+	baseURL      string
+}
+
+// NewReportService creates a new report service. baseURL is the public URL of this API, which
+// serves the shared links.
+func NewReportService(reportRepo *repositories.ReportRepository, userRepo *repositories.UserRepository, statsService *StatsService, signingKey, baseURL string) *ReportService {
+	return &ReportService{
+		reportRepo:   reportRepo,
+		userRepo:     userRepo,
+		statsService: statsService,
+		signingKey:   []byte(signingKey),
+		baseURL:      strings.TrimRight(baseURL, "/"),
+	}
+}
+
+// ShareReport takes a snapshot of the user's progress and returns signed links to it. Later
+// progress doesn't change a shared report; sharing again takes a new snapshot.
+func (s *ReportService) ShareReport(userID int, req *models.ShareReportRequest) (*models.SharedReportLink, error) {
+	days := req.ExpiresInDays
+	if days == 0 {
+		days = models.DefaultReportExpiryDays
+	}
+	if days < 1 || days > models.MaxReportExpiryDays {
+		return nil, apperr.Validation(fmt.Sprintf("expires_in_days must be between 1 and %d", models.MaxReportExpiryDays))
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	detailed, err := s.statsService.GetDetailedStatsForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	activity, err := s.reportRepo.GetRecentCompletions(userID, now.AddDate(0, 0, -models.ReportActivityDays), reportActivityLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.ProgressReport{
+		Name:        user.Name,
+		GeneratedAt: now,
+		ExpiresAt:   now.AddDate(0, 0, days),
+		Overall: models.ReportOverall{
+			TotalItems:         detailed.Overall.TotalItems,
+			CompletedItems:     detailed.Overall.CompletedItems,
+			ProgressPercentage: detailed.Overall.ProgressPercentage,
+			CurrentStreak:      detailed.Overall.CurrentStreak,
+			LongestStreak:      detailed.Overall.LongestStreak,
+		},
+		Categories:     []models.CategoryStats{},
+		RecentActivity: activity,
+	}
+	for _, category := range detailed.Categories {
+		report.Categories = append(report.Categories, models.CategoryStats{
+			Category:           category.Category,
+			TotalItems:         category.TotalItems,
+			CompletedItems:     category.CompletedItems,
+			PendingItems:       category.PendingItems,
+			ProgressPercentage: category.ProgressPercentage,
+		})
+	}
+	sort.Slice(report.Categories, func(i, j int) bool {
+		return report.Categories[i].Category < report.Categories[j].Category
+	})
+
+	if err := s.reportRepo.Create(userID, report); err != nil {
+		return nil, err
+	}
+
+	return s.link(report.ID, report.ExpiresAt), nil
+}
+
+// GetSharedReport returns the report behind a signed link, if the link is genuine and unexpired
+func (s *ReportService) GetSharedReport(id int, expiresStr, signature string) (*models.ProgressReport, error) {
+	expires, err := strconv.ParseInt(expiresStr, 10, 64)
+	if err != nil || !hmac.Equal([]byte(s.sign(id, expires)), []byte(signature)) {
+		return nil, apperr.NotFound("report not found or expired")
+	}
+
+	now := time.Now()
+	if now.Unix() > expires {
+		return nil, apperr.NotFound("report not found or expired")
+	}
+
+	return s.reportRepo.GetUnexpired(id, now)
+}
+
+// RenderHTML renders a report as a standalone page
+func (s *ReportService) RenderHTML(report *models.ProgressReport) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := reportTemplate.Execute(&buf, report); err != nil {
+		return nil, apperr.Errorf("failed to render progress report: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// DeleteExpiredReports removes reports whose links have expired (run on a schedule)
+func (s *ReportService) DeleteExpiredReports() error {
+	deleted, err := s.reportRepo.DeleteExpired(time.Now())
+	if err != nil {
+		return err
+	}
+
+	if deleted > 0 {
+		fmt.Printf("Info: deleted %d expired progress reports\n", deleted)
+	}
+	return nil
+}
+
+// link builds the signed HTML and JSON links to a report
+func (s *ReportService) link(id int, expiresAt time.Time) *models.SharedReportLink {
+	expires := expiresAt.Unix()
+
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("signature", s.sign(id, expires))
+
+	base := s.baseURL + "/api/v1/reports/" + strconv.Itoa(id) + "?" + query.Encode()
+	return &models.SharedReportLink{
+		ReportID:  id,
+		URL:       base + "&format=html",
+		JSONURL:   base,
+		ExpiresAt: expiresAt,
+	}
+}
+
+// sign computes the signature for a report ID and expiry
+func (s *ReportService) sign(id int, expires int64) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write([]byte("report\n" + strconv.Itoa(id) + "\n" + strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+var reportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"percent": func(p float64) string { return strconv.FormatFloat(p, 'f', 0, 64) + "%" },
+	"date":    func(t time.Time) string { return t.UTC().Format("Jan 2, 2006") },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>{{.Name}}'s interview prep progress</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", sans-serif; max-width: 720px; margin: 2rem auto; padding: 0 1rem; color: #1f2937; }
+h1 { font-size: 1.5rem; margin-bottom: 0.25rem; }
+.meta { color: #6b7280; font-size: 0.875rem; }
+.headline { display: flex; gap: 2rem; margin: 1.5rem 0; }
+.headline div { font-size: 0.875rem; color: #6b7280; }
+.headline strong { display: block; font-size: 1.5rem; color: #111827; }
+table { width: 100%; border-collapse: collapse; margin-bottom: 1.5rem; }
+th, td { text-align: left; padding: 0.5rem; border-bottom: 1px solid #e5e7eb; }
+</style>
+</head>
+<body>
+<h1>{{.Name}}'s interview prep progress</h1>
+<p class="meta">Snapshot taken {{date .GeneratedAt}}</p>
+
+<div class="headline">
+<div><strong>{{.Overall.CompletedItems}} / {{.Overall.TotalItems}}</strong>items completed ({{percent .Overall.ProgressPercentage}})</div>
+<div><strong>{{.Overall.CurrentStreak}}</strong>day streak</div>
+<div><strong>{{.Overall.LongestStreak}}</strong>longest streak</div>
+</div>
+
+<h2>By category</h2>
+<table>
+<tr><th>Category</th><th>Completed</th><th>Progress</th></tr>
+{{range .Categories}}<tr><td>{{.Category}}</td><td>{{.CompletedItems}} / {{.TotalItems}}</td><td>{{percent .ProgressPercentage}}</td></tr>
+{{end}}</table>
+
+<h2>Recent activity</h2>
+{{if .RecentActivity}}<table>
+<tr><th>Completed</th><th>Item</th><th>Category</th></tr>
+{{range .RecentActivity}}<tr><td>{{date .CompletedAt}}</td><td>{{.Title}}</td><td>{{.Category}} / {{.Subcategory}}</td></tr>
+{{end}}</table>
+{{else}}<p>No items completed in the last two weeks.</p>
+{{end}}</body>
+</html>
+`))
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+)
+
+func TestSharedReportLinkSignature(t *testing.T) {
+	service := NewReportService(nil, nil, nil, "signing key", "https://api.example.com/")
+	link := service.link(42, time.Now().Add(time.Hour))
+
+	if !strings.HasPrefix(link.URL, "https://api.example.com/api/v1/reports/42?") || !strings.HasSuffix(link.URL, "&format=html") {
+		t.Errorf("Unexpected report URL %q", link.URL)
+	}
+
+	parsed, err := url.Parse(link.JSONURL)
+	if err != nil {
+		t.Fatalf("Invalid report URL: %v", err)
+	}
+	expires, signature := parsed.Query().Get("expires"), parsed.Query().Get("signature")
+	if expires != strconv.FormatInt(link.ExpiresAt.Unix(), 10) || signature != service.sign(42, link.ExpiresAt.Unix()) {
+		t.Errorf("Unexpected report link query %q", parsed.RawQuery)
+	}
+
+	// Rejected before the report is looked up
+	expired := strconv.FormatInt(time.Now().Add(-time.Minute).Unix(), 10)
+	for name, args := range map[string][3]string{
+		"other report":  {"43", expires, signature},
+		"bad signature": {"42", expires, strings.Repeat("0", len(signature))},
+		"bad expiry":    {"42", "soon", signature},
+		"expired":       {"42", expired, service.sign(42, time.Now().Add(-time.Minute).Unix())},
+	} {
+		id, _ := strconv.Atoi(args[0])
+		if _, err := service.GetSharedReport(id, args[1], args[2]); apperr.From(err).Kind != apperr.KindNotFound {
+			t.Errorf("%s: expected not found, got %v", name, err)
+		}
+	}
+
+	other := NewReportService(nil, nil, nil, "another key", "https://api.example.com")
+	if other.sign(42, link.ExpiresAt.Unix()) == signature {
+		t.Error("Expected signatures to depend on the signing key")
+	}
+}
+
+func TestRenderReportHTML(t *testing.T) {
+	service := NewReportService(nil, nil, nil, "signing key", "")
+	report := &models.ProgressReport{
+		Name:        "<script>alert(1)</script>",
+		GeneratedAt: time.Date(2026, 3, 14, 12, 0, 0, 0, time.UTC),
+		Overall:     models.ReportOverall{TotalItems: 40, CompletedItems: 10, ProgressPercentage: 25, CurrentStreak: 3},
+		Categories:  []models.CategoryStats{{Category: models.CategoryDSA, TotalItems: 30, CompletedItems: 9, ProgressPercentage: 30}},
+		RecentActivity: []models.ReportActivity{
+			{Title: "Two Sum", Category: models.CategoryDSA, Subcategory: "arrays", CompletedAt: time.Date(2026, 3, 13, 9, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	body, err := service.RenderHTML(report)
+	if err != nil {
+		t.Fatalf("RenderHTML returned error: %v", err)
+	}
+
+	html := string(body)
+	for _, expected := range []string{"10 / 40", "25%", "Mar 14, 2026", "Two Sum", "dsa / arrays", "&lt;script&gt;"} {
+		if !strings.Contains(html, expected) {
+			t.Errorf("Expected the report to contain %q", expected)
+		}
+	}
+	if strings.Contains(html, "<script>") {
+		t.Error("Expected the user's name to be escaped")
+	}
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"math"
+	"time"
+
+	"interview-prep-app/internal/models"
+)
+
+// Review intervals in days, indexed by how many times the item has been reviewed.
+// Items completed by reading the solution come back much sooner than ones solved unaided.
+var (
+	solvedReviewIntervals           = []int{7, 14, 30, 60, 120}
+	reviewedSolutionReviewIntervals = []int{1, 3, 7, 14, 30}
+)
+
+// NextReviewAt computes when a completed item should next be resurfaced
+func NextReviewAt(quality models.CompletionQuality, reviewCount int, from time.Time) time.Time {
+	intervals := solvedReviewIntervals
+	if quality == models.CompletionReviewedSolution {
+		intervals = reviewedSolutionReviewIntervals
+	}
+
+	if reviewCount < 0 {
+		reviewCount = 0
+	}
+	if reviewCount >= len(intervals) {
+		reviewCount = len(intervals) - 1
+	}
+
+	return from.AddDate(0, 0, intervals[reviewCount])
+}
+
+// CompletionReviewAt computes when a just-completed item should first be resurfaced. The first
+// interval shrinks with the hint credit the completion earned, to no less than a day.
+func CompletionReviewAt(quality models.CompletionQuality, hintCredit float64, from time.Time) time.Time {
+	intervals := solvedReviewIntervals
+	if quality == models.CompletionReviewedSolution {
+		intervals = reviewedSolutionReviewIntervals
+	}
+
+	days := math.Round(float64(intervals[0]) * math.Min(hintCredit, 1))
+	return from.AddDate(0, 0, int(math.Max(days, 1)))
+}
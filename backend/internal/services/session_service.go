@@ -0,0 +1,189 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"interview-prep-app/internal/mailer"
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/plugins"
+	"interview-prep-app/internal/repositories"
+	"interview-prep-app/pkg/apperr"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	refreshTokenLifetime  = 7 * 24 * time.Hour
+	passwordResetLifetime = time.Hour
+	loginAlertKind        = "login_alert"
+)
+
+// SessionService issues refresh tokens tagged with the device and IP they were issued to,
+// alerts users to logins from a device or IP they haven't logged in from before, and lets
+// them shut every session down when an alert wasn't them
+type SessionService struct {
+	userRepo   *repositories.UserRepository
+	mailer     mailer.Mailer
+	channels   []plugins.NotificationChannel
+	appBaseURL string
+}
+
+// NewSessionService creates a new session service
+func NewSessionService(userRepo *repositories.UserRepository, m mailer.Mailer, channels []plugins.NotificationChannel, appBaseURL string) *SessionService {
+	return &SessionService{
+		userRepo:   userRepo,
+		mailer:     m,
+		channels:   channels,
+		appBaseURL: strings.TrimRight(appBaseURL, "/"),
+	}
+}
+
+// StartSession stores a refresh token for a login and returns it. Logins from a new device
+// or IP by a user who has logged in before trigger an alert.
+func (s *SessionService) StartSession(user *models.User, meta models.SessionMetadata) (string, error) {
+	token, err := generateSessionToken()
+	if err != nil {
+		return "", apperr.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	alert := false
+	history, err := s.userRepo.GetLoginHistory(user.ID, meta)
+	if err != nil {
+		fmt.Printf("Warning: failed to check login history for user %d: %v\n", user.ID, err)
+	} else {
+		alert = history.HasSessions && (!history.KnownDevice || !history.KnownIP)
+	}
+
+	alertToken := ""
+	if alert {
+		if alertToken, err = generateSessionToken(); err != nil {
+			return "", apperr.Errorf("failed to generate login alert token: %w", err)
+		}
+	}
+
+	if err := s.userRepo.CreateRefreshToken(user.ID, token, time.Now().Add(refreshTokenLifetime), meta, alertToken); err != nil {
+		return "", err
+	}
+
+	if alert {
+		s.sendLoginAlert(user, meta, alertToken)
+	}
+
+	return token, nil
+}
+
+// DenyLogin handles a user reporting that an alerted login wasn't them: every session is
+// revoked and, for email accounts, password logins are locked until the password is reset
+func (s *SessionService) DenyLogin(alertToken string) (*models.DenyLoginResponse, error) {
+	if alertToken == "" {
+		return nil, apperr.NotFound("login alert not found")
+	}
+
+	userID, err := s.userRepo.GetUserIDByAlertToken(alertToken)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	response := &models.DenyLoginResponse{SessionsRevoked: true}
+
+	resetToken := ""
+	resetExpiresAt := now.Add(passwordResetLifetime)
+	if user.AuthProvider == models.AuthProviderEmail {
+		if resetToken, err = generateSessionToken(); err != nil {
+			return nil, apperr.Errorf("failed to generate password reset token: %w", err)
+		}
+		response.PasswordResetToken = resetToken
+		response.PasswordResetExpiresAt = &resetExpiresAt
+	}
+
+	if err := s.userRepo.RevokeSessions(userID, now, resetToken, resetExpiresAt); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// ResetPassword sets a new password with a reset token and signs out every existing session
+func (s *SessionService) ResetPassword(req *models.ResetPasswordRequest) error {
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return apperr.Errorf("failed to hash password: %w", err)
+	}
+
+	_, err = s.userRepo.ResetPassword(req.Token, string(hash), time.Now())
+	return err
+}
+
+// CheckSession returns the user's current role, which replaces the one their access token was
+// issued with so role changes apply at once, and whether a token issued at issuedAt was revoked
+// afterwards. Tokens without an issue time are never counted as revoked.
+func (s *SessionService) CheckSession(userID int, issuedAt *time.Time) (models.Role, bool, error) {
+	role, revokedAt, err := s.userRepo.GetSessionState(userID)
+	if err != nil {
+		return "", false, err
+	}
+
+	// Access tokens carry whole-second issue times
+	revoked := issuedAt != nil && revokedAt != nil && issuedAt.Before(revokedAt.Truncate(time.Second))
+	return role, revoked, nil
+}
+
+// sendLoginAlert tells a user about a login from somewhere new, with a link to report it
+func (s *SessionService) sendLoginAlert(user *models.User, meta models.SessionMetadata, alertToken string) {
+	device := meta.UserAgent
+	if device == "" {
+		device = "an unknown device"
+	}
+	subject := "New login to your account"
+	body := fmt.Sprintf("Your account was just signed in to from %s (IP address %s) at %s.",
+		device, meta.IPAddress, time.Now().UTC().Format("January 2, 15:04 MST"))
+	link := s.appBaseURL + "/security/not-me?token=" + url.QueryEscape(alertToken)
+
+	if s.mailer != nil {
+		err := s.mailer.Send(mailer.Message{
+			To:      user.Email,
+			Subject: subject,
+			Body: fmt.Sprintf("Hi %s,\n\n%s\n\nIf this was you, there's nothing to do. If it wasn't, sign out every session and secure your account: %s\n",
+				user.Name, body, link),
+		})
+		if err != nil {
+			fmt.Printf("Warning: failed to send login alert to user %d: %v\n", user.ID, err)
+		}
+	}
+
+	for _, channel := range s.channels {
+		err := channel.Notify(context.Background(), plugins.Notification{
+			UserID:  user.ID,
+			Email:   user.Email,
+			Name:    user.Name,
+			Kind:    loginAlertKind,
+			Subject: subject,
+			Body:    body + " If this wasn't you, follow the link to sign out every session.",
+			Link:    link,
+		})
+		if err != nil {
+			fmt.Printf("Warning: failed to send login alert to user %d via %s: %v\n", user.ID, channel.Name(), err)
+		}
+	}
+}
+
+// generateSessionToken returns a random URL-safe token
+func generateSessionToken() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+	"interview-prep-app/internal/testutil"
+)
+
+func TestCheckSessionReadsCurrentRole(t *testing.T) {
+	db := testutil.OpenDB(t)
+	userRepo := repositories.NewUserRepository(db)
+	service := NewSessionService(userRepo, nil, nil, "")
+
+	user := &models.User{Email: "ada@example.test", Name: "Ada", Role: models.RoleAdmin, AuthProvider: models.AuthProviderEmail}
+	if err := userRepo.Create(user); err != nil {
+		t.Fatal(err)
+	}
+	issuedAt := time.Now().Add(-time.Minute)
+
+	// A demotion takes effect for tokens issued while the user was an admin
+	if err := userRepo.UpdateRole(user.ID, models.RoleUser); err != nil {
+		t.Fatal(err)
+	}
+	role, revoked, err := service.CheckSession(user.ID, &issuedAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if role != models.RoleUser || revoked {
+		t.Errorf("Expected the current role and a live session, got %q, revoked %v", role, revoked)
+	}
+
+	if err := userRepo.RevokeSessions(user.ID, time.Now(), "", time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, revoked, err := service.CheckSession(user.ID, &issuedAt); err != nil || !revoked {
+		t.Errorf("Expected a token issued before revoking to be revoked, got %v, %v", revoked, err)
+	}
+	if _, revoked, err := service.CheckSession(user.ID, nil); err != nil || revoked {
+		t.Errorf("Expected a token without an issue time not to count as revoked, got %v, %v", revoked, err)
+	}
+}
@@ -0,0 +1,167 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"interview-prep-app/internal/mailer"
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+	"interview-prep-app/internal/validation"
+	"interview-prep-app/pkg/apperr"
+)
+
+// maxSharesPerHour limits how many items a user can share in an hour to keep the feature from being used for spam
+const maxSharesPerHour = 30
+
+// ShareService handles business logic for sharing items between users
+type ShareService struct {
+	shareRepo  *repositories.ShareRepository
+	itemRepo   *repositories.ItemRepository
+	userRepo   *repositories.UserRepository
+	mailer     mailer.Mailer
+	appBaseURL string
+}
+
+// NewShareService creates a new share service
+func NewShareService(shareRepo *repositories.ShareRepository, itemRepo *repositories.ItemRepository, userRepo *repositories.UserRepository, m mailer.Mailer, appBaseURL string) *ShareService {
+	return &ShareService{
+		shareRepo:  shareRepo,
+		itemRepo:   itemRepo,
+		userRepo:   userRepo,
+		mailer:     m,
+		appBaseURL: strings.TrimRight(appBaseURL, "/"),
+	}
+}
+
+// ShareItem sends an item to a registered user or an email address and notifies the recipient
+func (s *ShareService) ShareItem(senderID, itemID int, req *models.ShareItemRequest) (*models.ItemShare, error) {
+	if senderID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if itemID <= 0 {
+		return nil, fmt.Errorf("invalid item ID")
+	}
+
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+
+	item, err := s.itemRepo.GetPublishedByID(itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	sender, err := s.userRepo.GetByID(senderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.EqualFold(sender.Email, email) {
+		return nil, fmt.Errorf("cannot share an item with yourself")
+	}
+
+	sent, err := s.shareRepo.CountSentSince(senderID, time.Now().Add(-time.Hour))
+	if err != nil {
+		return nil, err
+	}
+	if sent >= maxSharesPerHour {
+		return nil, apperr.RateLimited("share limit reached: try again later")
+	}
+
+	exists, err := s.shareRepo.PendingShareExists(itemID, senderID, email)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, apperr.Conflict("item already shared with this recipient")
+	}
+
+	share := &models.ItemShare{
+		ItemID:         itemID,
+		SenderID:       senderID,
+		SenderName:     sender.Name,
+		RecipientEmail: email,
+		Note:           strings.TrimSpace(req.Note),
+		Status:         models.ShareStatusPending,
+		Item:           item,
+	}
+
+	// Link the share to an existing account when the recipient is already registered
+	recipient, err := s.userRepo.GetByEmail(email)
+	if err == nil {
+		share.RecipientUserID = &recipient.ID
+	} else if err.Error() != "user not found" {
+		return nil, err
+	}
+
+	if err := s.shareRepo.Create(share); err != nil {
+		return nil, err
+	}
+
+	go s.notifyRecipient(share, recipient != nil)
+
+	return share, nil
+}
+
+// GetInbox returns the pending items shared with the user
+func (s *ShareService) GetInbox(userID int) ([]*models.ItemShare, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.shareRepo.GetInboxForUser(userID, user.Email)
+}
+
+// RespondToShare accepts or dismisses an item shared with the user
+func (s *ShareService) RespondToShare(userID, shareID int, status models.ShareStatus) error {
+	if userID <= 0 {
+		return fmt.Errorf("invalid user ID")
+	}
+
+	if shareID <= 0 {
+		return fmt.Errorf("invalid share ID")
+	}
+
+	if status != models.ShareStatusAccepted && status != models.ShareStatusDismissed {
+		return fmt.Errorf("invalid share status: %s", status)
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return err
+	}
+
+	return s.shareRepo.Respond(shareID, userID, user.Email, status)
+}
+
+// notifyRecipient emails the recipient about a new share; failures are logged rather than surfaced
+func (s *ShareService) notifyRecipient(share *models.ItemShare, registered bool) {
+	var body strings.Builder
+	fmt.Fprintf(&body, "%s shared an interview prep item with you: %s\n%s\n", share.SenderName, share.Item.Title, share.Item.Link)
+	if share.Note != "" {
+		fmt.Fprintf(&body, "\nTheir note:\n%s\n", share.Note)
+	}
+	if registered {
+		fmt.Fprintf(&body, "\nFind it in your shared inbox: %s\n", s.appBaseURL)
+	} else {
+		fmt.Fprintf(&body, "\nSign up with this email address to keep track of it: %s\n", s.appBaseURL)
+	}
+
+	err := s.mailer.Send(mailer.Message{
+		To:      share.RecipientEmail,
+		Subject: fmt.Sprintf("%s shared \"%s\" with you", share.SenderName, share.Item.Title),
+		Body:    body.String(),
+	})
+	if err != nil {
+		fmt.Printf("Warning: failed to send share notification for share %d: %v\n", share.ID, err)
+	}
+}
@@ -3,20 +3,27 @@ package services
 import (
 	"fmt"
 	"interview-prep-app/internal/models"
-	"interview-prep-app/internal/repositories"
+	"math"
+	"time"
 )
 
 // StatsService handles business logic for statistics
 type StatsService struct {
-	itemRepo  *repositories.ItemRepository
-	statsRepo *repositories.StatsRepository
+	itemRepo        ItemStore
+	statsRepo       StatsStore
+	focusRepo       FocusStore
+	submissionRepo  SubmissionStatsStore
+	categoryService *CategoryService
 }
 
 // NewStatsService creates a new stats service
-func NewStatsService(itemRepo *repositories.ItemRepository, statsRepo *repositories.StatsRepository) *StatsService {
+func NewStatsService(itemRepo ItemStore, statsRepo StatsStore, focusRepo FocusStore, submissionRepo SubmissionStatsStore, categoryService *CategoryService) *StatsService {
 	return &StatsService{
-		itemRepo:  itemRepo,
-		statsRepo: statsRepo,
+		itemRepo:        itemRepo,
+		statsRepo:       statsRepo,
+		focusRepo:       focusRepo,
+		submissionRepo:  submissionRepo,
+		categoryService: categoryService,
 	}
 }
 
@@ -25,22 +32,54 @@ func (s *StatsService) GetOverallStats() (*models.Stats, error) {
 	return nil, fmt.Errorf("GetOverallStats is deprecated - use GetOverallStatsForUser instead")
 }
 
-// GetOverallStatsForUser retrieves comprehensive statistics for a specific user
+// GetOverallStatsForUser retrieves comprehensive statistics for a specific user.
+// Item counts are read from the aggregates kept in user_stats; they're only recomputed here
+// when stale.
 func (s *StatsService) GetOverallStatsForUser(userID int) (*models.Stats, error) {
-	// Get user-specific item counts
-	total, completed, pending, _, err := s.itemRepo.GetCountsForUser(userID)
+	// Get user-specific aggregates, completed all count and streak info
+	userStats, err := s.statsRepo.GetUserStats(userID)
 	if err != nil {
 		return nil, err
 	}
 
+	if userStats.StatsRefreshedAt == nil {
+		if err := s.statsRepo.RefreshUserAggregates(userID); err != nil {
+			return nil, err
+		}
+		if userStats, err = s.statsRepo.GetUserStats(userID); err != nil {
+			return nil, err
+		}
+	}
+
+	total := userStats.TotalItems
+	completed := userStats.CompletedItems
+	pending := userStats.PendingItems
+
 	// Calculate progress percentage
 	var progressPercentage float64
 	if total > 0 {
 		progressPercentage = float64(completed) / float64(total) * 100
 	}
 
-	// Get user-specific completed all count and streak info
-	userStats, err := s.statsRepo.GetUserStats(userID)
+	// Reviews come due with time, so they can't be kept as an aggregate
+	reviewsDue, err := s.itemRepo.CountReviewsDueForUser(userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	// Get today's progress towards the daily goal
+	goalProgress, err := s.statsRepo.GetDailyGoalProgress(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Time spent in timer sessions
+	now := time.Now()
+	timeSpentToday, err := s.focusRepo.GetFocusedSeconds(userID, startOfDay(now), now)
+	if err != nil {
+		return nil, err
+	}
+	totalTimeSpent, err := s.focusRepo.GetFocusedSeconds(userID, time.Time{}, now)
 	if err != nil {
 		return nil, err
 	}
@@ -49,13 +88,53 @@ func (s *StatsService) GetOverallStatsForUser(userID int) (*models.Stats, error)
 		TotalItems:         total,
 		CompletedItems:     completed,
 		PendingItems:       pending,
+		SolvedItems:        userStats.SolvedItems,
+		ReviewedSolutions:  userStats.ReviewedSolutionItems,
+		ReviewsDue:         reviewsDue,
 		ProgressPercentage: progressPercentage,
 		CompletedAllCount:  userStats.CompletedAllCount,
 		CurrentStreak:      userStats.CurrentStreak,
 		LongestStreak:      userStats.LongestStreak,
+		DailyGoal:          goalProgress.DailyGoal,
+		CompletedToday:     goalProgress.CompletedToday,
+		DailyGoalMet:       goalProgress.GoalMet,
+		GoalStreak:         goalProgress.GoalStreak,
+		TimeSpentToday:     timeSpentToday,
+		TotalTimeSpent:     totalTimeSpent,
 	}, nil
 }
 
+// GetDailyGoalProgress returns the user's daily goal and today's attainment
+func (s *StatsService) GetDailyGoalProgress(userID int) (*models.DailyGoalProgress, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	return s.statsRepo.GetDailyGoalProgress(userID)
+}
+
+// SetDailyGoal updates the user's daily target and re-evaluates today against it
+func (s *StatsService) SetDailyGoal(userID int, goal int) (*models.DailyGoalProgress, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if goal < 0 || goal > models.MaxDailyGoal {
+		return nil, fmt.Errorf("daily goal must be between 0 and %d", models.MaxDailyGoal)
+	}
+
+	if err := s.statsRepo.SetDailyGoal(userID, goal); err != nil {
+		return nil, err
+	}
+
+	// Lowering the goal below what's already been done today counts as meeting it
+	if err := s.statsRepo.RecordDailyGoalProgress(userID, 0); err != nil {
+		return nil, err
+	}
+
+	return s.statsRepo.GetDailyGoalProgress(userID)
+}
+
 // GetDetailedStats returns detailed statistics with category breakdown
 func (s *StatsService) GetDetailedStats() (*models.DetailedStats, error) {
 	return nil, fmt.Errorf("GetDetailedStats is deprecated - use GetDetailedStatsForUser instead")
@@ -127,12 +206,82 @@ func (s *StatsService) GetDetailedStatsForUser(userID int) (*models.DetailedStat
 		})
 	}
 
+	timeSpent, err := s.getTimeSpentStats(userID, overall.TotalTimeSpent)
+	if err != nil {
+		return nil, err
+	}
+
+	submissions, err := s.submissionRepo.GetStatsForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
 	return &models.DetailedStats{
-		Overall:    *overall,
-		Categories: categories,
+		Overall:     *overall,
+		Categories:  categories,
+		TimeSpent:   *timeSpent,
+		Submissions: *submissions,
 	}, nil
 }
 
+// getTimeSpentStats breaks the user's focused time down by category and into a weekly trend.
+// Averages per item only count time spent on a specific item, not on a whole test.
+func (s *StatsService) getTimeSpentStats(userID, totalSeconds int) (*models.TimeSpentStats, error) {
+	now := time.Now()
+
+	categories, err := s.focusRepo.GetFocusedTimeByCategory(userID, time.Time{}, now)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &models.TimeSpentStats{
+		TotalSeconds: totalSeconds,
+		TotalHours:   secondsToHours(totalSeconds),
+		Categories:   categories,
+	}
+
+	itemSeconds := 0
+	for i := range categories {
+		category := &categories[i]
+		category.TotalHours = secondsToHours(category.TotalSeconds)
+		if category.ItemsTracked > 0 {
+			category.AverageSecondsPerItem = float64(category.TotalSeconds) / float64(category.ItemsTracked)
+		}
+		itemSeconds += category.TotalSeconds
+		stats.ItemsTracked += category.ItemsTracked
+	}
+	if stats.ItemsTracked > 0 {
+		stats.AverageSecondsPerItem = float64(itemSeconds) / float64(stats.ItemsTracked)
+	}
+
+	// Weekly trend over the last few weeks, oldest first, including weeks with no time
+	today := startOfDay(now)
+	thisWeek := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+	firstWeek := thisWeek.AddDate(0, 0, -7*(models.TimeSpentWeeks-1))
+
+	weekly, err := s.focusRepo.GetFocusedTimeByWeek(userID, firstWeek, now)
+	if err != nil {
+		return nil, err
+	}
+
+	stats.WeeklyTrend = make([]models.WeeklyTimeSpent, 0, models.TimeSpentWeeks)
+	for week := firstWeek; !week.After(thisWeek); week = week.AddDate(0, 0, 7) {
+		key := week.Format("2006-01-02")
+		stats.WeeklyTrend = append(stats.WeeklyTrend, models.WeeklyTimeSpent{
+			WeekStart:    key,
+			TotalSeconds: weekly[key],
+			TotalHours:   secondsToHours(weekly[key]),
+		})
+	}
+
+	return stats, nil
+}
+
+// secondsToHours converts seconds to hours rounded to two decimals
+func secondsToHours(seconds int) float64 {
+	return math.Round(float64(seconds)/3600*100) / 100
+}
+
 // GetCategoryStats returns statistics for a specific category
 func (s *StatsService) GetCategoryStats(category models.Category) (*models.CategoryStats, error) {
 	return nil, fmt.Errorf("GetCategoryStats is deprecated - use GetCategoryStatsForUser instead")
@@ -141,8 +290,8 @@ func (s *StatsService) GetCategoryStats(category models.Category) (*models.Categ
 // GetCategoryStatsForUser retrieves statistics for a specific category and user
 func (s *StatsService) GetCategoryStatsForUser(userID int, category models.Category) (*models.CategoryStats, error) {
 	// Validate category
-	if !models.IsValidCategory(category) {
-		return nil, fmt.Errorf("invalid category: %s", category)
+	if err := s.categoryService.ValidateCategory(category); err != nil {
+		return nil, err
 	}
 
 	// Get user-specific category counts
@@ -178,8 +327,8 @@ func (s *StatsService) GetSubcategoryStats(category models.Category, subcategory
 // GetSubcategoryStatsForUser retrieves statistics for a specific category, subcategory, and user
 func (s *StatsService) GetSubcategoryStatsForUser(userID int, category models.Category, subcategory string) (*models.SubcategoryStats, error) {
 	// Validate category
-	if !models.IsValidCategory(category) {
-		return nil, fmt.Errorf("invalid category: %s", category)
+	if err := s.categoryService.ValidateCategory(category); err != nil {
+		return nil, err
 	}
 
 	// Get user-specific subcategory counts
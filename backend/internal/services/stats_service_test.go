@@ -0,0 +1,175 @@
+package services
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/services/mocks"
+)
+
+// overallStatsStores returns the mocks GetOverallStatsForUser reads from, serving userStats until
+// the aggregates are refreshed and refreshed afterwards
+func overallStatsStores(userStats, refreshed *models.UserStats) (*mocks.ItemStore, *mocks.StatsStore, *mocks.FocusStore, *bool) {
+	refreshCalled := false
+	itemRepo := &mocks.ItemStore{
+		CountReviewsDueForUserFunc: func(userID int, dueBy time.Time) (int, error) {
+			return 3, nil
+		},
+	}
+	statsRepo := &mocks.StatsStore{
+		GetUserStatsFunc: func(userID int) (*models.UserStats, error) {
+			if refreshCalled {
+				return refreshed, nil
+			}
+			return userStats, nil
+		},
+		RefreshUserAggregatesFunc: func(userID int) error {
+			refreshCalled = true
+			return nil
+		},
+		GetDailyGoalProgressFunc: func(userID int) (*models.DailyGoalProgress, error) {
+			return &models.DailyGoalProgress{DailyGoal: 3, CompletedToday: 1}, nil
+		},
+	}
+	focusRepo := &mocks.FocusStore{
+		GetFocusedSecondsFunc: func(userID int, since, now time.Time) (int, error) {
+			if since.IsZero() {
+				return 7200, nil
+			}
+			return 600, nil
+		},
+	}
+	return itemRepo, statsRepo, focusRepo, &refreshCalled
+}
+
+func TestGetOverallStatsForUser(t *testing.T) {
+	refreshedAt := time.Now()
+	userStats := &models.UserStats{TotalItems: 40, CompletedItems: 10, PendingItems: 30, CurrentStreak: 4, StatsRefreshedAt: &refreshedAt}
+	itemRepo, statsRepo, focusRepo, refreshCalled := overallStatsStores(userStats, nil)
+	service := NewStatsService(itemRepo, statsRepo, focusRepo, &mocks.SubmissionStatsStore{}, nil)
+
+	stats, err := service.GetOverallStatsForUser(1)
+	if err != nil {
+		t.Fatalf("GetOverallStatsForUser returned error: %v", err)
+	}
+
+	if *refreshCalled {
+		t.Error("Expected current aggregates not to be refreshed")
+	}
+	if stats.TotalItems != 40 || stats.CompletedItems != 10 || stats.PendingItems != 30 {
+		t.Errorf("Unexpected item counts: %+v", stats)
+	}
+	if math.Abs(stats.ProgressPercentage-25) > 0.001 {
+		t.Errorf("Expected 25%% progress, got %f", stats.ProgressPercentage)
+	}
+	if stats.ReviewsDue != 3 || stats.CurrentStreak != 4 || stats.DailyGoal != 3 || stats.CompletedToday != 1 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+	if stats.TimeSpentToday != 600 || stats.TotalTimeSpent != 7200 {
+		t.Errorf("Expected 600s today and 7200s in total, got %d and %d", stats.TimeSpentToday, stats.TotalTimeSpent)
+	}
+}
+
+func TestGetOverallStatsForUserRefreshesStaleAggregates(t *testing.T) {
+	refreshedAt := time.Now()
+	stale := &models.UserStats{}
+	refreshed := &models.UserStats{TotalItems: 8, CompletedItems: 2, PendingItems: 6, StatsRefreshedAt: &refreshedAt}
+	itemRepo, statsRepo, focusRepo, refreshCalled := overallStatsStores(stale, refreshed)
+	service := NewStatsService(itemRepo, statsRepo, focusRepo, &mocks.SubmissionStatsStore{}, nil)
+
+	stats, err := service.GetOverallStatsForUser(1)
+	if err != nil {
+		t.Fatalf("GetOverallStatsForUser returned error: %v", err)
+	}
+
+	if !*refreshCalled {
+		t.Error("Expected never-computed aggregates to be refreshed")
+	}
+	if stats.TotalItems != 8 || stats.CompletedItems != 2 {
+		t.Errorf("Expected the refreshed counts, got %+v", stats)
+	}
+}
+
+func TestSetDailyGoal(t *testing.T) {
+	testCases := []struct {
+		name        string
+		userID      int
+		goal        int
+		expectError bool
+	}{
+		{name: "Valid goal", userID: 1, goal: 5},
+		{name: "Goal turned off", userID: 1, goal: 0},
+		{name: "Negative goal", userID: 1, goal: -1, expectError: true},
+		{name: "Goal above maximum", userID: 1, goal: models.MaxDailyGoal + 1, expectError: true},
+		{name: "Invalid user", userID: 0, goal: 5, expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			savedGoal := -1
+			statsRepo := &mocks.StatsStore{
+				SetDailyGoalFunc: func(userID int, goal int) error {
+					savedGoal = goal
+					return nil
+				},
+				RecordDailyGoalProgressFunc: func(userID int, increment int) error {
+					return nil
+				},
+				GetDailyGoalProgressFunc: func(userID int) (*models.DailyGoalProgress, error) {
+					return &models.DailyGoalProgress{DailyGoal: savedGoal}, nil
+				},
+			}
+			service := NewStatsService(&mocks.ItemStore{}, statsRepo, &mocks.FocusStore{}, &mocks.SubmissionStatsStore{}, nil)
+
+			progress, err := service.SetDailyGoal(tc.userID, tc.goal)
+			if tc.expectError {
+				if err == nil {
+					t.Fatal("Expected an error")
+				}
+				if savedGoal != -1 {
+					t.Errorf("Expected no goal to be saved, got %d", savedGoal)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("SetDailyGoal returned error: %v", err)
+			}
+			if progress.DailyGoal != tc.goal {
+				t.Errorf("Expected goal %d, got %d", tc.goal, progress.DailyGoal)
+			}
+		})
+	}
+}
+
+func TestGetCategoryStatsForUser(t *testing.T) {
+	categoryService := NewCategoryService(&mocks.CategoryStore{
+		GetAllFunc: func() ([]*models.CategoryDefinition, error) {
+			return []*models.CategoryDefinition{{Slug: models.CategoryDSA, Name: "DSA"}}, nil
+		},
+	})
+	itemRepo := &mocks.ItemStore{
+		GetCountsByCategoryForUserFunc: func(userID int, removeMiscellaneous bool) (map[models.Category]map[models.Status]int, error) {
+			return map[models.Category]map[models.Status]int{
+				models.CategoryDSA: {models.StatusDone: 3, models.StatusPending: 4, models.StatusInProgress: 1},
+			}, nil
+		},
+	}
+	service := NewStatsService(itemRepo, &mocks.StatsStore{}, &mocks.FocusStore{}, &mocks.SubmissionStatsStore{}, categoryService)
+
+	stats, err := service.GetCategoryStatsForUser(1, models.CategoryDSA)
+	if err != nil {
+		t.Fatalf("GetCategoryStatsForUser returned error: %v", err)
+	}
+
+	// In-progress items count as pending
+	if stats.TotalItems != 8 || stats.CompletedItems != 3 || stats.PendingItems != 5 {
+		t.Errorf("Unexpected category stats: %+v", stats)
+	}
+
+	if _, err := service.GetCategoryStatsForUser(1, models.CategoryHLD); err == nil {
+		t.Error("Expected an error for a category that doesn't exist")
+	}
+}
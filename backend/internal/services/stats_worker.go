@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"interview-prep-app/internal/events"
+	"interview-prep-app/internal/repositories"
+)
+
+// staleStatsBatchSize is how many users one run of the refresh job recomputes
+const staleStatsBatchSize = 500
+
+// StatsWorker maintains user_stats off the request path: daily goal tallies, and recomputing
+// the item-count aggregates when they go stale. Progress writes keep fresh aggregates current
+// through the user_progress_stats trigger.
+type StatsWorker struct {
+	statsRepo *repositories.StatsRepository
+}
+
+// NewStatsWorker creates a new stats worker
+func NewStatsWorker(statsRepo *repositories.StatsRepository) *StatsWorker {
+	return &StatsWorker{
+		statsRepo: statsRepo,
+	}
+}
+
+// Subscribe registers the worker for the events that change stats
+func (w *StatsWorker) Subscribe(bus events.Bus) {
+	bus.Subscribe(events.ItemCompleted, "stats", w.handleItemCompleted)
+	bus.Subscribe(events.CatalogChanged, "stats", w.handleCatalogChanged)
+}
+
+// handleItemCompleted counts a completion towards today's goal. The streak, completed-all
+// count and item counts are updated with the completion itself.
+func (w *StatsWorker) handleItemCompleted(ctx context.Context, e events.Event) error {
+	return w.statsRepo.RecordDailyGoalProgress(e.UserID, 1)
+}
+
+// handleCatalogChanged marks everyone's aggregates stale, since every user's totals moved.
+// Stale aggregates are recomputed on the user's next /stats read or by RefreshStaleAggregates.
+func (w *StatsWorker) handleCatalogChanged(ctx context.Context, e events.Event) error {
+	marked, err := w.statsRepo.MarkAllAggregatesStale()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Info: item catalog changed, marked stats stale for %d users\n", marked)
+	return nil
+}
+
+// RefreshStaleAggregates recomputes a batch of stale aggregates (run on a schedule). This
+// backfills users whose stats predate the worker and catches up after catalog changes.
+func (w *StatsWorker) RefreshStaleAggregates(ctx context.Context) error {
+	userIDs, err := w.statsRepo.GetUsersWithStaleAggregates(staleStatsBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, userID := range userIDs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := w.statsRepo.RefreshUserAggregates(userID); err != nil {
+			fmt.Printf("Warning: %v (user %d)\n", err, userID)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+)
+
+// The interfaces below are what the item, test, stats and category services need from storage.
+// The repositories implement them; tests substitute the generated mocks in ./mocks so the services
+// can be unit tested without a database. Keep each interface to the methods the services call.
+
+//go:generate go run ../testutil/mockgen -source stores.go -out mocks/stores.go -package mocks
+
+// ItemStore reads and writes items and users' progress on them
+type ItemStore interface {
+	Create(req *models.CreateItemRequest) (*models.Item, error)
+	Update(editorID, id int, req *models.UpdateItemRequest) (*models.Item, error)
+	Revert(editorID, id, revisionID int, version *int) (*models.Item, error)
+	GetRevisions(id int) ([]*models.ItemRevision, error)
+	SetPublished(id int, published bool) (*models.Item, error)
+	GetDrafts() ([]*models.Item, error)
+	Schedule(itemIDs []int, publishAt *time.Time) ([]*models.Item, error)
+	ReleaseDue(now time.Time) ([]*models.Item, error)
+	GetUpcomingReleases(limit int) ([]*models.UpcomingRelease, error)
+	Delete(id int) error
+	GetByID(id int) (*models.Item, error)
+	GetPublishedByID(id int) (*models.Item, error)
+	GetBySourceArticle(articleID int) (*models.Item, error)
+	GetDuplicateCandidates() ([]*models.Item, error)
+	GetAll(filter *models.ItemFilter) ([]*models.Item, error)
+	GetTotalCount(filter *models.ItemFilter) (int, error)
+	GetItemAnalytics(filter *models.ItemAnalyticsFilter) ([]*models.ItemAnalytics, int, error)
+
+	GetByIDWithUserProgress(userID, itemID int) (*models.ItemWithProgress, error)
+	GetAllWithUserProgress(userID int, filter *models.ItemFilter) ([]*models.ItemWithProgress, error)
+	GetTotalCountWithUserProgress(userID int, filter *models.ItemFilter) (int, error)
+	GetInProgressItemWithUserProgress(userID int) (*models.ItemWithProgress, error)
+	GetRandomPendingWithUserProgress(userID int) (*models.ItemWithProgress, error)
+	GetRandomItems(userID int, filter *models.ItemFilter) ([]models.ItemWithProgress, error)
+	GetCountsByCategoryForUser(userID int, removeMiscellaneous bool) (map[models.Category]map[models.Status]int, error)
+	GetCountsBySubcategoryForUser(userID int) (map[models.Category]map[string]map[models.Status]int, error)
+
+	UpsertUserProgressForItem(userID, itemID int, status models.Status) error
+	UpdateStatusForUser(userID, itemID int, status models.Status) (*models.ItemWithProgress, error)
+	MarkCompleted(tx *repositories.Tx, userID, itemID int, quality models.CompletionQuality, hintCredit float64, nextReviewAt time.Time) error
+	CountPendingForUserInTx(tx *repositories.Tx, userID int) (int, error)
+	ToggleStarForUser(userID, itemID int) (*models.ItemWithProgress, error)
+	SetStarredForUser(userID int, itemIDs []int, starred bool) (changed, unchanged []int, err error)
+	ResetInProgressItemsForUser(userID int) error
+	SkipInProgressItemsForUser(userID int) error
+	ResetAllUserProgress(userID int) (int64, error)
+	ResetUserProgressByCategory(userID int, category models.Category) (int64, error)
+
+	GetReviewCountForUser(userID, itemID int) (int, error)
+	RecordReviewForUser(userID, itemID int, quality models.CompletionQuality, nextReviewAt time.Time) (*models.ItemWithProgress, error)
+	GetDueReviewsForUser(userID int, dueBy time.Time, limit int) ([]*models.ItemWithProgress, error)
+	CountReviewsDueForUser(userID int, dueBy time.Time) (int, error)
+}
+
+// TestStore reads and writes test sessions
+type TestStore interface {
+	CreateTestItems(userID int, itemIDs []int) (string, error)
+	CreateRetake(userID int, parentSessionID string, itemIDs []int) (string, error)
+	GetTestByUserWithStatus(userID int, itemStatus []string) (string, []int, error)
+	GetTestsBySessionID(userID int, sessionID string) ([]*models.Test, error)
+	GetTestCreatedAt(userID int, sessionID string) (time.Time, error)
+	GetSessionItemsWithProgress(userID int, sessionIDs []string, itemStatus []string) (map[string][]models.TestSessionItem, error)
+	GetRecentSessions(userID int, limit int) ([]models.TestSession, error)
+	SaveTestResult(userID int, sessionID string, itemID int, result *models.TestItemResult) error
+	UpdateTestStatus(userID int, sessionID string, itemID int, status models.TestStatus) error
+	DeleteTestsBySessionID(userID int, sessionID string) error
+	CountSessionsSince(userID int, since time.Time) (int, error)
+	IsItemInPendingTest(userID int) (bool, error)
+	GetEligibilityPolicy() (*models.TestEligibilityPolicy, error)
+	SaveEligibilityRules(rules []models.TestEligibilityRule) (*models.TestEligibilityPolicy, error)
+}
+
+// StatsStore reads and writes users' stats, streaks and daily goals
+type StatsStore interface {
+	GetUserStats(userID int) (*models.UserStats, error)
+	RefreshUserAggregates(userID int) error
+	AdvanceStreak(tx *repositories.Tx, userID int, today time.Time) (*models.StreakChangedData, error)
+	IncrementUserCompletedAllCount(tx *repositories.Tx, userID int) error
+	ResetUserCompletedAllCount(userID int) error
+	SetDailyGoal(userID int, goal int) error
+	RecordDailyGoalProgress(userID int, increment int) error
+	GetDailyGoalProgress(userID int) (*models.DailyGoalProgress, error)
+}
+
+// HintStore reads users' hint reveals
+type HintStore interface {
+	CountRevealedForUser(userID, itemID int) (int, int, error)
+}
+
+// FocusStore reads users' focused time
+type FocusStore interface {
+	GetFocusedSeconds(userID int, since, now time.Time) (int, error)
+	GetFocusedTimeByCategory(userID int, since, now time.Time) ([]models.CategoryTimeSpent, error)
+	GetFocusedTimeByWeek(userID int, since, now time.Time) (map[string]int, error)
+}
+
+// SubmissionStatsStore reads users' judged submission totals
+type SubmissionStatsStore interface {
+	GetStatsForUser(userID int) (*models.SubmissionStats, error)
+}
+
+// CategoryStore reads and writes categories
+type CategoryStore interface {
+	GetAll() ([]*models.CategoryDefinition, error)
+	Create(category *models.CategoryDefinition) error
+	Update(slug models.Category, name string, orderIdx int, subcategories []string) error
+	Delete(slug models.Category) error
+}
+
+// EntitlementStore reports what a user's plan includes
+type EntitlementStore interface {
+	GetEntitlements(userID int) (*models.Entitlements, error)
+}
+
+// TxRunner runs units of work spanning several stores
+type TxRunner interface {
+	WithTx(ctx context.Context, fn func(tx *repositories.Tx) error) error
+	WithUserTx(ctx context.Context, userID int, fn func(tx *repositories.Tx) error) error
+}
+
+var (
+	_ ItemStore            = (*repositories.ItemRepository)(nil)
+	_ TestStore            = (*repositories.TestRepository)(nil)
+	_ StatsStore           = (*repositories.StatsRepository)(nil)
+	_ HintStore            = (*repositories.HintRepository)(nil)
+	_ FocusStore           = (*repositories.FocusSessionRepository)(nil)
+	_ SubmissionStatsStore = (*repositories.SubmissionRepository)(nil)
+	_ CategoryStore        = (*repositories.CategoryRepository)(nil)
+	_ EntitlementStore     = (*BillingService)(nil)
+	_ TxRunner             = (*repositories.TxManager)(nil)
+)
@@ -0,0 +1,335 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+	"interview-prep-app/internal/runner"
+	"interview-prep-app/internal/validation"
+	"interview-prep-app/pkg/apperr"
+)
+
+const (
+	// submissionAttemptsLimit is how many recent attempts an item's submission history shows
+	submissionAttemptsLimit = 20
+	// maxJudgeErrorLength caps the compiler or stderr output returned with a verdict
+	maxJudgeErrorLength = 2000
+)
+
+// SubmissionService handles business logic for DSA item test cases and judging submitted solutions
+type SubmissionService struct {
+	submissionRepo *repositories.SubmissionRepository
+	itemRepo       *repositories.ItemRepository
+	runner         runner.Runner
+	github         *GitHubService
+}
+
+// NewSubmissionService creates a new submission service. Submissions are refused when codeRunner is nil.
+func NewSubmissionService(submissionRepo *repositories.SubmissionRepository, itemRepo *repositories.ItemRepository, codeRunner runner.Runner, githubService *GitHubService) *SubmissionService {
+	return &SubmissionService{
+		submissionRepo: submissionRepo,
+		itemRepo:       itemRepo,
+		runner:         codeRunner,
+		github:         githubService,
+	}
+}
+
+// GetTestCases lists an item's test cases; non-admins only see the sample ones, and only on
+// published items
+func (s *SubmissionService) GetTestCases(itemID int, includeHidden bool) ([]*models.ItemTestCase, error) {
+	if itemID <= 0 {
+		return nil, fmt.Errorf("invalid item ID")
+	}
+
+	getItem := s.itemRepo.GetPublishedByID
+	if includeHidden {
+		getItem = s.itemRepo.GetByID
+	}
+	if _, err := getItem(itemID); err != nil {
+		return nil, err
+	}
+
+	return s.submissionRepo.GetTestCases(itemID, !includeHidden)
+}
+
+// CreateTestCase adds a test case to a DSA item
+func (s *SubmissionService) CreateTestCase(itemID int, req *models.CreateItemTestCaseRequest) (*models.ItemTestCase, error) {
+	if err := s.checkDSAItem(itemID, true); err != nil {
+		return nil, err
+	}
+
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	count, err := s.submissionRepo.CountTestCases(itemID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= models.MaxItemTestCases {
+		return nil, fmt.Errorf("an item can have at most %d test cases", models.MaxItemTestCases)
+	}
+
+	return s.submissionRepo.CreateTestCase(itemID, req)
+}
+
+// UpdateTestCase edits a test case's input, expected output, sample flag or position
+func (s *SubmissionService) UpdateTestCase(itemID, testCaseID int, req *models.UpdateItemTestCaseRequest) (*models.ItemTestCase, error) {
+	if itemID <= 0 {
+		return nil, fmt.Errorf("invalid item ID")
+	}
+
+	if testCaseID <= 0 {
+		return nil, fmt.Errorf("invalid test case ID")
+	}
+
+	if req.Input == nil && req.ExpectedOutput == nil && req.IsSample == nil && req.OrderIdx == nil {
+		return nil, fmt.Errorf("at least one field must be provided for update")
+	}
+
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	testCase, err := s.submissionRepo.GetTestCase(itemID, testCaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Input != nil {
+		testCase.Input = *req.Input
+	}
+	if req.ExpectedOutput != nil {
+		testCase.ExpectedOutput = *req.ExpectedOutput
+	}
+	if req.IsSample != nil {
+		testCase.IsSample = *req.IsSample
+	}
+	if req.OrderIdx != nil {
+		if *req.OrderIdx < 0 {
+			return nil, fmt.Errorf("order index cannot be negative")
+		}
+		testCase.OrderIdx = *req.OrderIdx
+	}
+
+	if err := s.submissionRepo.UpdateTestCase(testCase); err != nil {
+		return nil, err
+	}
+
+	return testCase, nil
+}
+
+// DeleteTestCase removes a test case from an item
+func (s *SubmissionService) DeleteTestCase(itemID, testCaseID int) error {
+	if itemID <= 0 {
+		return fmt.Errorf("invalid item ID")
+	}
+
+	if testCaseID <= 0 {
+		return fmt.Errorf("invalid test case ID")
+	}
+
+	return s.submissionRepo.DeleteTestCase(itemID, testCaseID)
+}
+
+// Submit judges a solution against every test case of a DSA item and records the attempt. Hidden
+// cases only report their verdict.
+func (s *SubmissionService) Submit(ctx context.Context, userID, itemID int, req *models.SubmitSolutionRequest) (*models.SubmissionResult, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if s.runner == nil {
+		return nil, apperr.Unavailable("code runner is not configured")
+	}
+
+	if !runner.IsSupportedLanguage(req.Language) {
+		return nil, fmt.Errorf("invalid language: %s. Valid languages are: %v", req.Language, runner.SupportedLanguages())
+	}
+
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkDSAItem(itemID, false); err != nil {
+		return nil, err
+	}
+
+	testCases, err := s.submissionRepo.GetTestCases(itemID, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(testCases) == 0 {
+		return nil, fmt.Errorf("item has no test cases")
+	}
+
+	results := make([]models.TestCaseResult, 0, len(testCases))
+	passed := 0
+	for _, testCase := range testCases {
+		result, err := s.judge(ctx, req, testCase)
+		if err != nil {
+			return nil, err
+		}
+		if result.Verdict == models.VerdictPassed {
+			passed++
+		}
+		results = append(results, *result)
+
+		// A program that doesn't build fails every case the same way
+		if result.Verdict == models.VerdictCompileError {
+			for _, remaining := range testCases[len(results):] {
+				results = append(results, models.TestCaseResult{
+					TestCaseID: remaining.ID,
+					IsSample:   remaining.IsSample,
+					Verdict:    models.VerdictCompileError,
+				})
+			}
+			break
+		}
+	}
+
+	attempt := &models.SubmissionAttempt{
+		UserID:      userID,
+		ItemID:      itemID,
+		Language:    req.Language,
+		Code:        req.Code,
+		Passed:      passed == len(testCases),
+		PassedCases: passed,
+		TotalCases:  len(testCases),
+	}
+	if err := s.submissionRepo.CreateAttempt(attempt); err != nil {
+		return nil, err
+	}
+
+	result := &models.SubmissionResult{Attempt: *attempt, Results: results}
+	if req.CommitToGitHub {
+		sha, err := s.commitToGitHub(ctx, attempt)
+		if err != nil {
+			appErr := apperr.From(err)
+			if appErr.Kind == apperr.KindInternal {
+				fmt.Printf("Warning: failed to commit submission %d to GitHub: %v\n", attempt.ID, err)
+			}
+			result.GitHubError = appErr.Message
+		} else {
+			result.Attempt.CommitSHA = &sha
+		}
+	}
+
+	return result, nil
+}
+
+// commitToGitHub commits a recorded attempt to the user's repository and records the commit on it
+func (s *SubmissionService) commitToGitHub(ctx context.Context, attempt *models.SubmissionAttempt) (string, error) {
+	if s.github == nil {
+		return "", apperr.Unavailable("GitHub integration is not configured")
+	}
+
+	item, err := s.itemRepo.GetByID(attempt.ItemID)
+	if err != nil {
+		return "", err
+	}
+
+	sha, err := s.github.CommitSolution(ctx, item, attempt)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.submissionRepo.SetCommitSHA(attempt.ID, sha); err != nil {
+		// The commit is on GitHub either way; only the link back to it is lost
+		fmt.Printf("Warning: %v\n", err)
+	}
+
+	return sha, nil
+}
+
+// GetAttempts lists the user's recent attempts on an item, newest first
+func (s *SubmissionService) GetAttempts(userID, itemID int) ([]*models.SubmissionAttempt, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if itemID <= 0 {
+		return nil, fmt.Errorf("invalid item ID")
+	}
+
+	return s.submissionRepo.GetAttempts(userID, itemID, submissionAttemptsLimit)
+}
+
+// judge runs the solution against one test case
+func (s *SubmissionService) judge(ctx context.Context, req *models.SubmitSolutionRequest, testCase *models.ItemTestCase) (*models.TestCaseResult, error) {
+	run, err := s.runner.Run(ctx, runner.Execution{Language: req.Language, Code: req.Code, Stdin: testCase.Input})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.TestCaseResult{TestCaseID: testCase.ID, IsSample: testCase.IsSample}
+	switch {
+	case run.CompileError != "":
+		result.Verdict = models.VerdictCompileError
+		result.Error = truncateJudgeOutput(run.CompileError)
+	case run.TimedOut:
+		result.Verdict = models.VerdictTimeLimit
+	case run.ExitCode != 0:
+		result.Verdict = models.VerdictRuntimeError
+	case normalizeJudgeOutput(run.Stdout) == normalizeJudgeOutput(testCase.ExpectedOutput):
+		result.Verdict = models.VerdictPassed
+	default:
+		result.Verdict = models.VerdictWrongAnswer
+	}
+
+	// Hidden cases don't reveal their input through the output or stderr
+	if testCase.IsSample {
+		actual := truncateJudgeOutput(run.Stdout)
+		result.Input = &testCase.Input
+		result.ExpectedOutput = &testCase.ExpectedOutput
+		result.ActualOutput = &actual
+		if result.Verdict == models.VerdictRuntimeError {
+			result.Error = truncateJudgeOutput(run.Stderr)
+		}
+	}
+
+	return result, nil
+}
+
+// checkDSAItem validates the item ID and that the item exists and is a DSA item. Only editors
+// managing test cases pass includeDrafts.
+func (s *SubmissionService) checkDSAItem(itemID int, includeDrafts bool) error {
+	if itemID <= 0 {
+		return fmt.Errorf("invalid item ID")
+	}
+
+	getItem := s.itemRepo.GetPublishedByID
+	if includeDrafts {
+		getItem = s.itemRepo.GetByID
+	}
+	item, err := getItem(itemID)
+	if err != nil {
+		return err
+	}
+
+	if item.Category != models.CategoryDSA {
+		return fmt.Errorf("test cases are only available for dsa items")
+	}
+
+	return nil
+}
+
+// normalizeJudgeOutput makes output comparison ignore line endings and trailing whitespace
+func normalizeJudgeOutput(output string) string {
+	lines := strings.Split(strings.ReplaceAll(output, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
+// truncateJudgeOutput shortens program or compiler output for a response
+func truncateJudgeOutput(output string) string {
+	if len(output) <= maxJudgeErrorLength {
+		return output
+	}
+	return output[:maxJudgeErrorLength] + "..."
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/validation"
+)
+
+// GetEligibilityPolicy returns the rules users must meet to start a test, falling back to the
+// default rules until an admin sets some
+func (s *TestService) GetEligibilityPolicy() (*models.TestEligibilityPolicy, error) {
+	policy, err := s.testRepo.GetEligibilityPolicy()
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		return &models.TestEligibilityPolicy{Rules: models.DefaultTestEligibilityRules(), Default: true}, nil
+	}
+
+	return policy, nil
+}
+
+// UpdateEligibilityRules replaces the rules users must meet to start a test. An empty list lets
+// anyone start one.
+func (s *TestService) UpdateEligibilityRules(req *models.UpdateTestEligibilityRequest) (*models.TestEligibilityPolicy, error) {
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	rules := make([]models.TestEligibilityRule, len(req.Rules))
+	var invalid []validation.FieldError
+	for i, rule := range req.Rules {
+		rule.Category = models.Category(strings.TrimSpace(string(rule.Category)))
+		rule.Subcategory = strings.TrimSpace(rule.Subcategory)
+		invalid = append(invalid, checkEligibilityRule(i, &rule)...)
+		rules[i] = rule
+	}
+	if len(invalid) > 0 {
+		return nil, validation.Fields(invalid...)
+	}
+
+	return s.testRepo.SaveEligibilityRules(rules)
+}
+
+// checkEligibilityRule reports the fields a rule's kind needs but doesn't have, or has but
+// doesn't use
+func checkEligibilityRule(i int, rule *models.TestEligibilityRule) []validation.FieldError {
+	field := func(name string) string { return fmt.Sprintf("rules[%d].%s", i, name) }
+	var invalid []validation.FieldError
+
+	if rule.Subcategory != "" && rule.Category == "" {
+		invalid = append(invalid, validation.FieldError{Field: field("subcategory"), Rule: "required_with", Message: "subcategory needs a category"})
+	}
+
+	switch rule.Kind {
+	case models.TestRuleInProgress:
+		if rule.Category == "" {
+			invalid = append(invalid, validation.FieldError{Field: field("category"), Rule: "required", Message: "in_progress rules need a category"})
+		}
+	case models.TestRuleMinCompleted:
+		if rule.Count < 1 {
+			invalid = append(invalid, validation.FieldError{Field: field("count"), Rule: "min", Message: "min_completed rules need a count of at least 1"})
+		}
+	case models.TestRuleCooldown:
+		if rule.CooldownHours < 1 {
+			invalid = append(invalid, validation.FieldError{Field: field("cooldown_hours"), Rule: "min", Message: "cooldown rules need cooldown_hours of at least 1"})
+		}
+		if rule.Category != "" {
+			invalid = append(invalid, validation.FieldError{Field: field("category"), Rule: "excluded_with", Message: "cooldown rules apply to every test, not a category"})
+		}
+	}
+
+	return invalid
+}
+
+// testFacts looks up what eligibility rules are checked against in the item and test stores
+type testFacts struct {
+	itemRepo ItemStore
+	testRepo TestStore
+}
+
+func (f *testFacts) CountItems(userID int, status models.Status, category models.Category, subcategory string) (int, error) {
+	filter := &models.ItemFilter{Status: &status}
+	if category != "" {
+		filter.Category = &category
+	}
+	if subcategory != "" {
+		filter.Subcategory = &subcategory
+	}
+
+	return f.itemRepo.GetTotalCountWithUserProgress(userID, filter)
+}
+
+func (f *testFacts) LastTestAt(userID int) (*time.Time, error) {
+	sessions, err := f.testRepo.GetRecentSessions(userID, 1)
+	if err != nil || len(sessions) == 0 {
+		return nil, err
+	}
+
+	return &sessions[0].CreatedAt, nil
+}
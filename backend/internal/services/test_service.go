@@ -2,22 +2,37 @@ package services
 
 import (
 	"fmt"
+	"math"
+	"strings"
+	"time"
 
+	"interview-prep-app/internal/eligibility"
+	"interview-prep-app/internal/events"
 	"interview-prep-app/internal/models"
-	"interview-prep-app/internal/repositories"
+	"interview-prep-app/internal/validation"
+	"interview-prep-app/pkg/apperr"
 )
 
+// maxTestHistory is the most test sessions the history returns
+const maxTestHistory = 50
+
 // TestService handles business logic for tests
 type TestService struct {
-	testRepo *repositories.TestRepository
-	itemRepo *repositories.ItemRepository
+	testRepo TestStore
+	itemRepo ItemStore
+	billing  EntitlementStore
+	bus      events.Bus
+	rules    *eligibility.RuleEngine
 }
 
 // NewTestService creates a new test service
-func NewTestService(testRepo *repositories.TestRepository, itemRepo *repositories.ItemRepository) *TestService {
+func NewTestService(testRepo TestStore, itemRepo ItemStore, billing EntitlementStore, bus events.Bus) *TestService {
 	return &TestService{
 		testRepo: testRepo,
 		itemRepo: itemRepo,
+		billing:  billing,
+		bus:      bus,
+		rules:    eligibility.NewRuleEngine(&testFacts{itemRepo: itemRepo, testRepo: testRepo}),
 	}
 }
 
@@ -26,11 +41,16 @@ func (s *TestService) CreateTest(userID int) (*models.CreateTestResponse, error)
 	// Check if user already has an active test
 	existingSessionID, _, err := s.testRepo.GetTestByUserWithStatus(userID, []string{"pending"})
 	if err != nil {
-		return nil, fmt.Errorf("failed to check for existing test: %w", err)
+		return nil, apperr.Errorf("failed to check for existing test: %w", err)
 	}
 
 	if existingSessionID != "" {
-		return nil, fmt.Errorf("user already has an active test")
+		return nil, apperr.Conflict("user already has an active test")
+	}
+
+	// The free plan only includes a few tests per month
+	if err := s.checkMonthlyTestLimit(userID); err != nil {
+		return nil, err
 	}
 
 	// Get 2 random completed items from DSA
@@ -44,7 +64,7 @@ func (s *TestService) CreateTest(userID int) (*models.CreateTestResponse, error)
 	}
 	dsaItems, err := s.itemRepo.GetRandomItems(userID, dsaFilter)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get DSA items: %w", err)
+		return nil, apperr.Errorf("failed to get DSA items: %w", err)
 	}
 	if len(dsaItems) < 2 {
 		return nil, fmt.Errorf("not enough completed DSA items (need 2, found %d)", len(dsaItems))
@@ -62,7 +82,7 @@ func (s *TestService) CreateTest(userID int) (*models.CreateTestResponse, error)
 	}
 	lldItems, err := s.itemRepo.GetRandomItems(userID, lldFilter)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get LLD items: %w", err)
+		return nil, apperr.Errorf("failed to get LLD items: %w", err)
 	}
 	if len(lldItems) < 1 {
 		return nil, fmt.Errorf("not enough completed LLD items (need 1, found %d)", len(lldItems))
@@ -80,7 +100,7 @@ func (s *TestService) CreateTest(userID int) (*models.CreateTestResponse, error)
 	}
 	hldItems, err := s.itemRepo.GetRandomItems(userID, hldFilter)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get HLD items: %w", err)
+		return nil, apperr.Errorf("failed to get HLD items: %w", err)
 	}
 	if len(hldItems) < 1 {
 		return nil, fmt.Errorf("not enough completed HLD items with subcategory 'interview questions' (need 1, found %d)", len(hldItems))
@@ -104,7 +124,7 @@ func (s *TestService) CreateTest(userID int) (*models.CreateTestResponse, error)
 	// Create test items in database
 	sessionID, err := s.testRepo.CreateTestItems(userID, itemIDs)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create test items: %w", err)
+		return nil, apperr.Errorf("failed to create test items: %w", err)
 	}
 
 	return &models.CreateTestResponse{
@@ -114,39 +134,85 @@ func (s *TestService) CreateTest(userID int) (*models.CreateTestResponse, error)
 	}, nil
 }
 
+// RetakeTest starts a new test holding only the items the user missed in one of their tests,
+// linked to it as its parent. It's refused while another test is active, counts towards the
+// monthly limit like any test, and needs the original to have missed items.
+func (s *TestService) RetakeTest(userID int, sessionID string) (*models.CreateTestResponse, error) {
+	existingSessionID, _, err := s.testRepo.GetTestByUserWithStatus(userID, []string{"pending"})
+	if err != nil {
+		return nil, apperr.Errorf("failed to check for existing test: %w", err)
+	}
+	if existingSessionID != "" {
+		return nil, apperr.Conflict("user already has an active test")
+	}
+
+	sessionItems, err := s.testRepo.GetSessionItemsWithProgress(userID, []string{sessionID}, []string{"pending", "completed", "abandoned"})
+	if err != nil {
+		return nil, apperr.Errorf("failed to get test items: %w", err)
+	}
+	if len(sessionItems[sessionID]) == 0 {
+		return nil, apperr.NotFound("test not found")
+	}
+
+	items := []models.ItemWithProgress{}
+	itemIDs := []int{}
+	for _, item := range sessionItems[sessionID] {
+		if !item.Missed() {
+			continue
+		}
+		item.Status = models.StatusPending
+		items = append(items, item.ItemWithProgress)
+		itemIDs = append(itemIDs, item.ID)
+	}
+	if len(itemIDs) == 0 {
+		return nil, apperr.Conflict("test has no abandoned or failed items to retake")
+	}
+
+	if err := s.checkMonthlyTestLimit(userID); err != nil {
+		return nil, err
+	}
+
+	retakeID, err := s.testRepo.CreateRetake(userID, sessionID, itemIDs)
+	if err != nil {
+		return nil, apperr.Errorf("failed to create retake: %w", err)
+	}
+
+	return &models.CreateTestResponse{
+		SessionID:       retakeID,
+		ParentSessionID: sessionID,
+		Items:           items,
+		Message:         fmt.Sprintf("Retake created with the %d missed %s", len(items), pluralize(len(items), "item", "items")),
+	}, nil
+}
+
 // GetActiveTest retrieves the current active test for a user
 func (s *TestService) GetActiveTest(userID int) (*models.ActiveTestResponse, error) {
 	
 	// check if there is pending session_id
-	sessionID, itemIDs, err := s.testRepo.GetTestByUserWithStatus(userID, []string{"pending"})
+	sessionID, _, err := s.testRepo.GetTestByUserWithStatus(userID, []string{"pending"})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get active test: %w", err)
+		return nil, apperr.Errorf("failed to get active test: %w", err)
 	}
 
 	if sessionID == "" {
 		return nil, nil // No active test
 	}
 
-	// it means there is testing active and some items are in pending
-	sessionID, itemIDs, err = s.testRepo.GetTestByUserWithStatus(userID, []string{"pending", "completed"})
+	// it means there is testing active; answered items stay in it alongside the pending ones
+	sessionItems, err := s.testRepo.GetSessionItemsWithProgress(userID, []string{sessionID}, []string{"pending", "completed"})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get active test: %w", err)
+		return nil, apperr.Errorf("failed to get active test items: %w", err)
 	}
 
-	// Get items with user progress
-	items := make([]models.ItemWithProgress, 0, len(itemIDs))
-	for _, itemID := range itemIDs {
-		item, err := s.itemRepo.GetItemByIDForTest(userID, itemID, sessionID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get item %d: %w", itemID, err)
-		}
-		items = append(items, *item)
+	items := sessionItems[sessionID]
+	if items == nil {
+		items = []models.TestSessionItem{}
 	}
 
 	// Get created_at timestamp
 	createdAt, err := s.testRepo.GetTestCreatedAt(userID, sessionID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get test created_at: %w", err)
+		return nil, apperr.Errorf("failed to get test created_at: %w", err)
 	}
 
 	return &models.ActiveTestResponse{
@@ -156,14 +222,132 @@ func (s *TestService) GetActiveTest(userID int) (*models.ActiveTestResponse, err
 	}, nil
 }
 
+// GetTestHistory retrieves the user's latest test sessions, newest first, with every item each
+// one held, how it ended and a summary of the recorded results
+func (s *TestService) GetTestHistory(userID int, limit int) ([]models.TestSession, error) {
+	if limit <= 0 || limit > maxTestHistory {
+		limit = maxTestHistory
+	}
+
+	sessions, err := s.testRepo.GetRecentSessions(userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(sessions) == 0 {
+		return sessions, nil
+	}
+
+	sessionIDs := make([]string, len(sessions))
+	for i := range sessions {
+		sessionIDs[i] = sessions[i].SessionID
+	}
+
+	sessionItems, err := s.testRepo.GetSessionItemsWithProgress(userID, sessionIDs, []string{"pending", "completed", "abandoned"})
+	if err != nil {
+		return nil, apperr.Errorf("failed to get test history items: %w", err)
+	}
+
+	for i := range sessions {
+		sessions[i].Items = sessionItems[sessions[i].SessionID]
+		if sessions[i].Items == nil {
+			sessions[i].Items = []models.TestSessionItem{}
+		}
+		sessions[i].Summary = summarizeResults(sessions[i].Items)
+	}
+
+	return sessions, nil
+}
+
+// summarizeResults adds up the results recorded for a test's items
+func summarizeResults(items []models.TestSessionItem) models.TestSessionSummary {
+	var summary models.TestSessionSummary
+	ratings, rated := 0, 0
+	for _, item := range items {
+		if item.Result == nil {
+			summary.Unrecorded++
+			continue
+		}
+
+		switch item.Result.Outcome {
+		case models.TestOutcomeSolved:
+			summary.Solved++
+		case models.TestOutcomePartial:
+			summary.Partial++
+		case models.TestOutcomeFailed:
+			summary.Failed++
+		}
+		if item.Result.TimeTaken != nil {
+			summary.TotalTimeTaken += *item.Result.TimeTaken
+		}
+		if item.Result.SelfRating != nil {
+			ratings += *item.Result.SelfRating
+			rated++
+		}
+	}
+
+	if rated > 0 {
+		average := math.Round(float64(ratings)/float64(rated)*100) / 100
+		summary.AverageSelfRating = &average
+	}
+	return summary
+}
+
+// RecordTestResult records the user's self-assessment of an item in one of their tests
+func (s *TestService) RecordTestResult(userID int, sessionID string, itemID int, req *models.RecordTestResultRequest) (*models.TestItemResult, error) {
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	result := &models.TestItemResult{
+		Outcome:    req.Outcome,
+		TimeTaken:  req.TimeTaken,
+		SelfRating: req.SelfRating,
+		Notes:      strings.TrimSpace(req.Notes),
+		RecordedAt: time.Now(),
+	}
+	if err := s.testRepo.SaveTestResult(userID, sessionID, itemID, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
 // CompleteTest marks a test as completed
-func (s *TestService) CompleteTest(userID int, sessionID string, item_id string) error {
-	return s.testRepo.UpdateTestStatus(userID, sessionID, item_id, models.TestStatusCompleted)
+func (s *TestService) CompleteTest(userID int, sessionID string, itemID int) error {
+	if err := s.testRepo.UpdateTestStatus(userID, sessionID, itemID, models.TestStatusCompleted); err != nil {
+		return err
+	}
+
+	if s.bus != nil {
+		s.publishIfSessionFinished(userID, sessionID)
+	}
+
+	return nil
+}
+
+// publishIfSessionFinished publishes test.finished once no item in the session is still pending
+func (s *TestService) publishIfSessionFinished(userID int, sessionID string) {
+	tests, err := s.testRepo.GetTestsBySessionID(userID, sessionID)
+	if err != nil {
+		fmt.Printf("Warning: failed to load test session %s for user %d: %v\n", sessionID, userID, err)
+		return
+	}
+
+	for _, test := range tests {
+		if test.Status == models.TestStatusPending {
+			return
+		}
+	}
+
+	publishEvent(s.bus, events.TestFinished, userID, models.TestCompletedData{
+		SessionID: sessionID,
+		Items:     tests,
+	})
 }
 
 // AbandonTest marks a test as abandoned
-func (s *TestService) AbandonTest(userID int, sessionID string, item_id string) error {
-	return s.testRepo.UpdateTestStatus(userID, sessionID, item_id, models.TestStatusAbandoned)
+func (s *TestService) AbandonTest(userID int, sessionID string, itemID int) error {
+	return s.testRepo.UpdateTestStatus(userID, sessionID, itemID, models.TestStatusAbandoned)
 }
 
 // DeleteTest deletes a test
@@ -171,24 +355,36 @@ func (s *TestService) DeleteTest(userID int, sessionID string) error {
 	return s.testRepo.DeleteTestsBySessionID(userID, sessionID)
 }
 
-// CheckCanCreateTest checks if a user can create a test (has miscellaneous item in progress)
-func (s *TestService) CheckCanCreateTest(userID int) (bool, error) {
-	// Get in-progress items
-	inProgressStatus := models.StatusInProgress
-	miscCategory := models.CategoryMiscellaneous
-	subcategory := models.Test_n_revise
+// CheckTestEligibility checks whether the user meets the eligibility rules for starting a test
+func (s *TestService) CheckTestEligibility(userID int) (*models.TestEligibility, error) {
+	policy, err := s.GetEligibilityPolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.rules.Evaluate(userID, policy.Rules)
+}
 
-	filter := &models.ItemFilter{
-		Status:      &inProgressStatus,
-		Category:    &miscCategory,
-		Subcategory: &subcategory,
+// checkMonthlyTestLimit fails with an "upgrade required" error once the user's plan has used up its tests this month
+func (s *TestService) checkMonthlyTestLimit(userID int) error {
+	entitlements, err := s.billing.GetEntitlements(userID)
+	if err != nil {
+		return err
+	}
+	if entitlements.MonthlyTests == nil {
+		return nil
 	}
 
-	items, err := s.itemRepo.GetAllWithUserProgress(userID, filter)
+	now := time.Now()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	count, err := s.testRepo.CountSessionsSince(userID, monthStart)
 	if err != nil {
-		return false, fmt.Errorf("failed to check for in-progress miscellaneous items: %w", err)
+		return err
+	}
+
+	if count >= *entitlements.MonthlyTests {
+		return apperr.PaymentRequired(fmt.Sprintf("upgrade required: the free plan includes %d tests per month", *entitlements.MonthlyTests))
 	}
 
-	// If there's at least one in-progress miscellaneous item, user can create a test
-	return len(items) > 0, nil
+	return nil
 }
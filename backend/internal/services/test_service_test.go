@@ -0,0 +1,407 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/services/mocks"
+)
+
+// entitlements returns a billing mock for a plan allowing monthlyTests tests, or unlimited when nil
+func entitlements(monthlyTests *int) *mocks.EntitlementStore {
+	return &mocks.EntitlementStore{
+		GetEntitlementsFunc: func(userID int) (*models.Entitlements, error) {
+			return &models.Entitlements{MonthlyTests: monthlyTests}, nil
+		},
+	}
+}
+
+// completedItems returns a random-items mock serving done items per category, numbering them from 1
+func completedItems(perCategory map[models.Category]int) func(userID int, filter *models.ItemFilter) ([]models.ItemWithProgress, error) {
+	nextID := 1
+	return func(userID int, filter *models.ItemFilter) ([]models.ItemWithProgress, error) {
+		items := []models.ItemWithProgress{}
+		for i := 0; i < perCategory[*filter.Category] && i < *filter.Limit; i++ {
+			items = append(items, models.ItemWithProgress{ID: nextID, Category: *filter.Category, Status: models.StatusDone})
+			nextID++
+		}
+		return items, nil
+	}
+}
+
+func TestCreateTest(t *testing.T) {
+	var createdWith []int
+	testRepo := &mocks.TestStore{
+		GetTestByUserWithStatusFunc: func(userID int, itemStatus []string) (string, []int, error) {
+			return "", nil, nil
+		},
+		CreateTestItemsFunc: func(userID int, itemIDs []int) (string, error) {
+			createdWith = itemIDs
+			return "session-1", nil
+		},
+	}
+	itemRepo := &mocks.ItemStore{
+		GetRandomItemsFunc: completedItems(map[models.Category]int{models.CategoryDSA: 5, models.CategoryLLD: 5, models.CategoryHLD: 5}),
+	}
+	service := NewTestService(testRepo, itemRepo, entitlements(nil), nil)
+
+	response, err := service.CreateTest(1)
+	if err != nil {
+		t.Fatalf("CreateTest returned error: %v", err)
+	}
+
+	if response.SessionID != "session-1" {
+		t.Errorf("Expected session-1, got %s", response.SessionID)
+	}
+	if len(createdWith) != 4 || len(response.Items) != 4 {
+		t.Fatalf("Expected 4 test items, created %v and returned %d", createdWith, len(response.Items))
+	}
+	for _, item := range response.Items {
+		if item.Status != models.StatusPending {
+			t.Errorf("Expected test item %d to be pending, got %s", item.ID, item.Status)
+		}
+	}
+}
+
+func TestCreateTestRefusals(t *testing.T) {
+	two := 2
+
+	testCases := []struct {
+		name          string
+		activeSession string
+		monthlyTests  *int
+		sessionsSoFar int
+		perCategory   map[models.Category]int
+		expectedError string
+	}{
+		{
+			name:          "Active test",
+			activeSession: "session-1",
+			expectedError: "user already has an active test",
+		},
+		{
+			name:          "Monthly limit used up",
+			monthlyTests:  &two,
+			sessionsSoFar: 2,
+			expectedError: "upgrade required",
+		},
+		{
+			name:          "Not enough DSA items",
+			perCategory:   map[models.Category]int{models.CategoryDSA: 1, models.CategoryLLD: 1, models.CategoryHLD: 1},
+			expectedError: "not enough completed DSA items",
+		},
+		{
+			name:          "No HLD interview question",
+			monthlyTests:  &two,
+			sessionsSoFar: 1,
+			perCategory:   map[models.Category]int{models.CategoryDSA: 2, models.CategoryLLD: 1},
+			expectedError: "not enough completed HLD items",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			testRepo := &mocks.TestStore{
+				GetTestByUserWithStatusFunc: func(userID int, itemStatus []string) (string, []int, error) {
+					return tc.activeSession, nil, nil
+				},
+				CountSessionsSinceFunc: func(userID int, since time.Time) (int, error) {
+					return tc.sessionsSoFar, nil
+				},
+			}
+			itemRepo := &mocks.ItemStore{GetRandomItemsFunc: completedItems(tc.perCategory)}
+			service := NewTestService(testRepo, itemRepo, entitlements(tc.monthlyTests), nil)
+
+			_, err := service.CreateTest(1)
+			if err == nil || !strings.HasPrefix(err.Error(), tc.expectedError) {
+				t.Fatalf("Expected error starting %q, got %v", tc.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestGetActiveTest(t *testing.T) {
+	createdAt := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	testRepo := &mocks.TestStore{
+		GetTestByUserWithStatusFunc: func(userID int, itemStatus []string) (string, []int, error) {
+			return "session-1", []int{2}, nil
+		},
+		GetSessionItemsWithProgressFunc: func(userID int, sessionIDs []string, itemStatus []string) (map[string][]models.TestSessionItem, error) {
+			// Answered items stay in the active test
+			if len(sessionIDs) != 1 || sessionIDs[0] != "session-1" || len(itemStatus) != 2 {
+				t.Errorf("Unexpected session items query: %v %v", sessionIDs, itemStatus)
+			}
+			return map[string][]models.TestSessionItem{
+				"session-1": {
+					{ItemWithProgress: models.ItemWithProgress{ID: 1, Status: models.StatusDone}},
+					{ItemWithProgress: models.ItemWithProgress{ID: 2, Status: models.StatusPending}},
+				},
+			}, nil
+		},
+		GetTestCreatedAtFunc: func(userID int, sessionID string) (time.Time, error) {
+			return createdAt, nil
+		},
+	}
+	service := NewTestService(testRepo, &mocks.ItemStore{}, entitlements(nil), nil)
+
+	active, err := service.GetActiveTest(1)
+	if err != nil {
+		t.Fatalf("GetActiveTest returned error: %v", err)
+	}
+
+	if active.SessionID != "session-1" || len(active.Items) != 2 || !active.CreatedAt.Equal(createdAt) {
+		t.Errorf("Unexpected active test: %+v", active)
+	}
+}
+
+func TestGetActiveTestWithoutPendingItems(t *testing.T) {
+	testRepo := &mocks.TestStore{
+		GetTestByUserWithStatusFunc: func(userID int, itemStatus []string) (string, []int, error) {
+			return "", nil, nil
+		},
+	}
+	service := NewTestService(testRepo, &mocks.ItemStore{}, entitlements(nil), nil)
+
+	active, err := service.GetActiveTest(1)
+	if err != nil {
+		t.Fatalf("GetActiveTest returned error: %v", err)
+	}
+	if active != nil {
+		t.Errorf("Expected no active test, got %+v", active)
+	}
+}
+
+func TestGetTestHistory(t *testing.T) {
+	solvedIn, partlyIn, four, three := 600, 1500, 4, 3
+	var limited int
+	queries := 0
+	testRepo := &mocks.TestStore{
+		GetRecentSessionsFunc: func(userID int, limit int) ([]models.TestSession, error) {
+			limited = limit
+			return []models.TestSession{{SessionID: "session-2"}, {SessionID: "session-1"}}, nil
+		},
+		GetSessionItemsWithProgressFunc: func(userID int, sessionIDs []string, itemStatus []string) (map[string][]models.TestSessionItem, error) {
+			queries++
+			return map[string][]models.TestSessionItem{
+				"session-2": {
+					{ItemWithProgress: models.ItemWithProgress{ID: 3}, Result: &models.TestItemResult{Outcome: models.TestOutcomeSolved, TimeTaken: &solvedIn, SelfRating: &four}},
+					{ItemWithProgress: models.ItemWithProgress{ID: 4}, Result: &models.TestItemResult{Outcome: models.TestOutcomePartial, TimeTaken: &partlyIn, SelfRating: &three}},
+					{ItemWithProgress: models.ItemWithProgress{ID: 5}},
+				},
+			}, nil
+		},
+	}
+	service := NewTestService(testRepo, &mocks.ItemStore{}, entitlements(nil), nil)
+
+	history, err := service.GetTestHistory(1, 500)
+	if err != nil {
+		t.Fatalf("GetTestHistory returned error: %v", err)
+	}
+
+	if limited != maxTestHistory {
+		t.Errorf("Expected the limit capped at %d, got %d", maxTestHistory, limited)
+	}
+	if queries != 1 {
+		t.Errorf("Expected the items of every session in one query, got %d", queries)
+	}
+	if len(history) != 2 || len(history[0].Items) != 3 || history[1].Items == nil || len(history[1].Items) != 0 {
+		t.Fatalf("Unexpected history: %+v", history)
+	}
+
+	summary := history[0].Summary
+	if summary.Solved != 1 || summary.Partial != 1 || summary.Failed != 0 || summary.Unrecorded != 1 || summary.TotalTimeTaken != 2100 {
+		t.Errorf("Unexpected summary: %+v", summary)
+	}
+	if summary.AverageSelfRating == nil || *summary.AverageSelfRating != 3.5 {
+		t.Errorf("Expected an average self rating of 3.5, got %v", summary.AverageSelfRating)
+	}
+	if history[1].Summary.AverageSelfRating != nil {
+		t.Errorf("Expected no average self rating without ratings, got %v", *history[1].Summary.AverageSelfRating)
+	}
+}
+
+func TestRecordTestResult(t *testing.T) {
+	var saved *models.TestItemResult
+	testRepo := &mocks.TestStore{
+		SaveTestResultFunc: func(userID int, sessionID string, itemID int, result *models.TestItemResult) error {
+			saved = result
+			return nil
+		},
+	}
+	service := NewTestService(testRepo, &mocks.ItemStore{}, entitlements(nil), nil)
+
+	rating := 6
+	if _, err := service.RecordTestResult(1, "session-1", 2, &models.RecordTestResultRequest{Outcome: models.TestOutcomeFailed, SelfRating: &rating}); err == nil {
+		t.Fatal("Expected a self rating above 5 to be refused")
+	}
+	if _, err := service.RecordTestResult(1, "session-1", 2, &models.RecordTestResultRequest{Outcome: "gave_up"}); err == nil {
+		t.Fatal("Expected an unknown outcome to be refused")
+	}
+	if saved != nil {
+		t.Fatalf("Expected nothing saved for invalid results, got %+v", saved)
+	}
+
+	result, err := service.RecordTestResult(1, "session-1", 2, &models.RecordTestResultRequest{Outcome: models.TestOutcomePartial, Notes: "  missed the edge case  "})
+	if err != nil {
+		t.Fatalf("RecordTestResult returned error: %v", err)
+	}
+	if saved != result || result.Notes != "missed the edge case" || result.RecordedAt.IsZero() {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+}
+
+func TestRetakeTest(t *testing.T) {
+	var retaken []int
+	testRepo := &mocks.TestStore{
+		GetTestByUserWithStatusFunc: func(userID int, itemStatus []string) (string, []int, error) {
+			return "", nil, nil
+		},
+		GetSessionItemsWithProgressFunc: func(userID int, sessionIDs []string, itemStatus []string) (map[string][]models.TestSessionItem, error) {
+			return map[string][]models.TestSessionItem{
+				"session-1": {
+					{ItemWithProgress: models.ItemWithProgress{ID: 1, Status: models.Status(models.TestStatusCompleted)}, Result: &models.TestItemResult{Outcome: models.TestOutcomeSolved}},
+					{ItemWithProgress: models.ItemWithProgress{ID: 2, Status: models.Status(models.TestStatusAbandoned)}},
+					{ItemWithProgress: models.ItemWithProgress{ID: 3, Status: models.Status(models.TestStatusCompleted)}, Result: &models.TestItemResult{Outcome: models.TestOutcomeFailed}},
+					{ItemWithProgress: models.ItemWithProgress{ID: 4, Status: models.Status(models.TestStatusCompleted)}, Result: &models.TestItemResult{Outcome: models.TestOutcomePartial}},
+				},
+			}, nil
+		},
+		CountSessionsSinceFunc: func(userID int, since time.Time) (int, error) {
+			return 0, nil
+		},
+		CreateRetakeFunc: func(userID int, parentSessionID string, itemIDs []int) (string, error) {
+			if parentSessionID != "session-1" {
+				t.Errorf("Expected the retake linked to session-1, got %s", parentSessionID)
+			}
+			retaken = itemIDs
+			return "session-2", nil
+		},
+	}
+	service := NewTestService(testRepo, &mocks.ItemStore{}, entitlements(nil), nil)
+
+	response, err := service.RetakeTest(1, "session-1")
+	if err != nil {
+		t.Fatalf("RetakeTest returned error: %v", err)
+	}
+
+	if len(retaken) != 2 || retaken[0] != 2 || retaken[1] != 3 {
+		t.Errorf("Expected the abandoned and failed items 2 and 3 retaken, got %v", retaken)
+	}
+	if response.SessionID != "session-2" || response.ParentSessionID != "session-1" || len(response.Items) != 2 {
+		t.Fatalf("Unexpected retake: %+v", response)
+	}
+	for _, item := range response.Items {
+		if item.Status != models.StatusPending {
+			t.Errorf("Expected retake item %d to be pending, got %s", item.ID, item.Status)
+		}
+	}
+}
+
+func TestRetakeTestRefusals(t *testing.T) {
+	testCases := []struct {
+		name          string
+		activeSession string
+		items         []models.TestSessionItem
+		expectedError string
+	}{
+		{
+			name:          "Active test",
+			activeSession: "session-3",
+			expectedError: "user already has an active test",
+		},
+		{
+			name:          "Unknown test",
+			expectedError: "test not found",
+		},
+		{
+			name:          "Nothing missed",
+			items:         []models.TestSessionItem{{ItemWithProgress: models.ItemWithProgress{ID: 1, Status: models.Status(models.TestStatusCompleted)}}},
+			expectedError: "test has no abandoned or failed items to retake",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			testRepo := &mocks.TestStore{
+				GetTestByUserWithStatusFunc: func(userID int, itemStatus []string) (string, []int, error) {
+					return tc.activeSession, nil, nil
+				},
+				GetSessionItemsWithProgressFunc: func(userID int, sessionIDs []string, itemStatus []string) (map[string][]models.TestSessionItem, error) {
+					return map[string][]models.TestSessionItem{"session-1": tc.items}, nil
+				},
+			}
+			service := NewTestService(testRepo, &mocks.ItemStore{}, entitlements(nil), nil)
+
+			_, err := service.RetakeTest(1, "session-1")
+			if err == nil || err.Error() != tc.expectedError {
+				t.Fatalf("Expected error %q, got %v", tc.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestCheckTestEligibilityDefaultsToInProgressRule(t *testing.T) {
+	testRepo := &mocks.TestStore{
+		GetEligibilityPolicyFunc: func() (*models.TestEligibilityPolicy, error) {
+			return nil, nil
+		},
+	}
+	var counted *models.ItemFilter
+	itemRepo := &mocks.ItemStore{
+		GetTotalCountWithUserProgressFunc: func(userID int, filter *models.ItemFilter) (int, error) {
+			counted = filter
+			return 0, nil
+		},
+	}
+	service := NewTestService(testRepo, itemRepo, entitlements(nil), nil)
+
+	eligibility, err := service.CheckTestEligibility(1)
+	if err != nil {
+		t.Fatalf("CheckTestEligibility returned error: %v", err)
+	}
+
+	if eligibility.CanCreate || len(eligibility.Unmet) != 1 {
+		t.Errorf("Expected the default rule unmet, got %+v", eligibility)
+	}
+	if counted == nil || *counted.Status != models.StatusInProgress || *counted.Category != models.CategoryMiscellaneous || *counted.Subcategory != models.Test_n_revise {
+		t.Errorf("Expected in-progress miscellaneous test_n_revise items counted, got %+v", counted)
+	}
+}
+
+func TestUpdateEligibilityRules(t *testing.T) {
+	var saved []models.TestEligibilityRule
+	testRepo := &mocks.TestStore{
+		SaveEligibilityRulesFunc: func(rules []models.TestEligibilityRule) (*models.TestEligibilityPolicy, error) {
+			saved = rules
+			return &models.TestEligibilityPolicy{Rules: rules}, nil
+		},
+	}
+	service := NewTestService(testRepo, &mocks.ItemStore{}, entitlements(nil), nil)
+
+	invalid := []models.TestEligibilityRule{
+		{Kind: models.TestRuleInProgress},
+		{Kind: models.TestRuleMinCompleted, Subcategory: "arrays", Count: 2},
+		{Kind: models.TestRuleCooldown, Category: models.CategoryDSA, CooldownHours: 12},
+	}
+	if _, err := service.UpdateEligibilityRules(&models.UpdateTestEligibilityRequest{Rules: invalid}); err == nil {
+		t.Fatal("Expected rules missing what their kind needs to be refused")
+	} else if !strings.Contains(err.Error(), "in_progress rules need a category") || !strings.Contains(err.Error(), "subcategory needs a category") || !strings.Contains(err.Error(), "cooldown rules apply to every test") {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if _, err := service.UpdateEligibilityRules(&models.UpdateTestEligibilityRequest{Rules: []models.TestEligibilityRule{{Kind: "streak"}}}); err == nil {
+		t.Fatal("Expected an unknown rule kind to be refused")
+	}
+	if saved != nil {
+		t.Fatalf("Expected nothing saved for invalid rules, got %+v", saved)
+	}
+
+	policy, err := service.UpdateEligibilityRules(&models.UpdateTestEligibilityRequest{Rules: []models.TestEligibilityRule{}})
+	if err != nil {
+		t.Fatalf("UpdateEligibilityRules returned error: %v", err)
+	}
+	if saved == nil || len(policy.Rules) != 0 {
+		t.Errorf("Expected an empty rule list saved to turn gating off, got %+v", policy)
+	}
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/validation"
+	"interview-prep-app/pkg/apperr"
+)
+
+// permissionCacheTTL is how long role permissions are read from memory before the table is
+// queried again, so a change reaches every server instance within this long
+const permissionCacheTTL = 30 * time.Second
+
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,19}$`)
+
+// HasPermission reports whether a role grants a permission. Admins hold every permission.
+func (s *UserService) HasPermission(role models.Role, permission models.Permission) (bool, error) {
+	if role == models.RoleAdmin {
+		return true, nil
+	}
+
+	all, err := s.rolePermissions()
+	if err != nil {
+		return false, err
+	}
+
+	for _, p := range all[role] {
+		if p == permission {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ListRoles returns every role with its permissions and how many users hold it
+func (s *UserService) ListRoles() ([]*models.RoleDefinition, error) {
+	roles, err := s.roleRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, role := range roles {
+		if role.Name == models.RoleAdmin {
+			role.Permissions = models.ValidPermissions()
+		}
+	}
+	return roles, nil
+}
+
+// SaveRole creates a role or changes the fields the request sets. The admin role always holds
+// every permission, so it can't be edited.
+func (s *UserService) SaveRole(name models.Role, req *models.SaveRoleRequest) (*models.RoleDefinition, error) {
+	if !roleNamePattern.MatchString(string(name)) {
+		return nil, validation.Field("name", "format", "name must be up to 20 lowercase letters, digits and underscores, starting with a letter")
+	}
+	if name == models.RoleAdmin {
+		return nil, apperr.Conflict("the admin role always has every permission")
+	}
+
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	role := &models.RoleDefinition{Name: name, Permissions: []models.Permission{}}
+	roles, err := s.roleRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	for _, existing := range roles {
+		if existing.Name == name {
+			role = existing
+		}
+	}
+
+	if req.Description != nil {
+		role.Description = strings.TrimSpace(*req.Description)
+	}
+	if req.Permissions != nil {
+		role.Permissions = uniquePermissions(req.Permissions)
+	}
+
+	if err := s.roleRepo.Save(role); err != nil {
+		return nil, err
+	}
+	s.invalidatePermissions()
+
+	role.BuiltIn = models.IsBuiltInRole(name)
+	return role, nil
+}
+
+// DeleteRole removes a role nobody holds. Built-in roles can't be deleted.
+func (s *UserService) DeleteRole(name models.Role) error {
+	if models.IsBuiltInRole(name) {
+		return apperr.Conflict("built-in roles can't be deleted")
+	}
+
+	roles, err := s.roleRepo.GetAll()
+	if err != nil {
+		return err
+	}
+	for _, role := range roles {
+		if role.Name == name && role.UserCount > 0 {
+			return apperr.Conflict(fmt.Sprintf("role is assigned to %d users", role.UserCount))
+		}
+	}
+
+	if err := s.roleRepo.Delete(name); err != nil {
+		return err
+	}
+	s.invalidatePermissions()
+	return nil
+}
+
+// SetUserRole assigns a user a role. Users can't change their own role, so the last admin can't
+// demote themselves by mistake. The change applies once the user's access token is renewed.
+func (s *UserService) SetUserRole(actorID, userID int, req *models.UpdateUserRoleRequest) (*models.User, error) {
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	if actorID == userID {
+		return nil, apperr.Conflict("you can't change your own role")
+	}
+
+	exists, err := s.roleRepo.Exists(req.Role)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, validation.Field("role", "exists", fmt.Sprintf("role %s doesn't exist", req.Role))
+	}
+
+	if err := s.userRepo.UpdateRole(userID, req.Role); err != nil {
+		return nil, err
+	}
+
+	return s.userRepo.GetByID(userID)
+}
+
+// rolePermissions returns every role's permissions, from memory when read within permissionCacheTTL
+func (s *UserService) rolePermissions() (map[models.Role][]models.Permission, error) {
+	s.permissionsMu.Lock()
+	defer s.permissionsMu.Unlock()
+
+	if s.permissions != nil && time.Since(s.permissionsCachedAt) < permissionCacheTTL {
+		return s.permissions, nil
+	}
+
+	all, err := s.roleRepo.GetPermissions()
+	if err != nil {
+		return nil, err
+	}
+
+	s.permissions, s.permissionsCachedAt = all, time.Now()
+	return all, nil
+}
+
+// invalidatePermissions makes the next permission check on this instance read the table again
+func (s *UserService) invalidatePermissions() {
+	s.permissionsMu.Lock()
+	defer s.permissionsMu.Unlock()
+	s.permissions = nil
+}
+
+// uniquePermissions drops repeated permissions, keeping the first of each
+func uniquePermissions(permissions []models.Permission) []models.Permission {
+	seen := map[models.Permission]bool{}
+	unique := []models.Permission{}
+	for _, p := range permissions {
+		if !seen[p] {
+			seen[p] = true
+			unique = append(unique, p)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,37 @@
+package services
+
+import (
+	"testing"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+)
+
+func TestAdminsHoldEveryPermission(t *testing.T) {
+	// Admins never reach the roles table
+	service := &UserService{}
+	for _, permission := range models.ValidPermissions() {
+		if allowed, err := service.HasPermission(models.RoleAdmin, permission); err != nil || !allowed {
+			t.Errorf("Expected admin to hold %s, got %v, %v", permission, allowed, err)
+		}
+	}
+}
+
+func TestSaveRoleRejections(t *testing.T) {
+	service := &UserService{}
+	for name, want := range map[models.Role]apperr.Kind{
+		"admin":                   apperr.KindConflict,
+		"Editor":                  apperr.KindValidation,
+		"1st_line":                apperr.KindValidation,
+		"a_role_name_over_twenty": apperr.KindValidation,
+	} {
+		_, err := service.SaveRole(name, &models.SaveRoleRequest{})
+		if apperr.From(err).Kind != want {
+			t.Errorf("SaveRole(%q): expected %s error, got %v", name, want, err)
+		}
+	}
+
+	if err := service.DeleteRole(models.RoleModerator); apperr.From(err).Kind != apperr.KindConflict {
+		t.Errorf("Expected deleting a built-in role to conflict, got %v", err)
+	}
+}
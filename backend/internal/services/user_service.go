@@ -2,29 +2,58 @@ package services
 
 import (
 	"crypto/rand"
+	"crypto/rsa"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"interview-prep-app/internal/config"
+	"interview-prep-app/internal/events"
+	"interview-prep-app/internal/metrics"
 	"interview-prep-app/internal/models"
 	"interview-prep-app/internal/repositories"
+	"interview-prep-app/internal/validation"
+	"interview-prep-app/pkg/apperr"
+	"math/big"
 	"net/http"
+	"net/url"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/golang-jwt/jwt/v4"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// OAuthProviders holds this app's registration with each social login provider
+type OAuthProviders struct {
+	Google   config.OAuthProviderConfig
+	Facebook config.OAuthProviderConfig
+	Apple    config.OAuthProviderConfig
+}
+
 // UserService handles user-related business logic
 type UserService struct {
 	userRepo  *repositories.UserRepository
 	statsRepo *repositories.StatsRepository
+	orgRepo   *repositories.OrgRepository
+	roleRepo  *repositories.RoleRepository
+	bus       events.Bus
+	oauth     OAuthProviders
+
+	permissionsMu       sync.Mutex
+	permissions         map[models.Role][]models.Permission
+	permissionsCachedAt time.Time
 }
 
 // NewUserService creates a new UserService
-func NewUserService(userRepo *repositories.UserRepository, statsRepo *repositories.StatsRepository) *UserService {
+func NewUserService(userRepo *repositories.UserRepository, statsRepo *repositories.StatsRepository, orgRepo *repositories.OrgRepository, roleRepo *repositories.RoleRepository, bus events.Bus, oauth OAuthProviders) *UserService {
 	return &UserService{
 		userRepo:  userRepo,
 		statsRepo: statsRepo,
+		orgRepo:   orgRepo,
+		roleRepo:  roleRepo,
+		bus:       bus,
+		oauth:     oauth,
 	}
 }
 
@@ -33,7 +62,7 @@ func (s *UserService) RegisterWithEmail(req *models.CreateUserRequest) (*models.
 	// Check if email already exists
 	exists, err := s.userRepo.EmailExists(req.Email)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check email existence: %w", err)
+		return nil, apperr.Errorf("failed to check email existence: %w", err)
 	}
 	if exists {
 		return nil, fmt.Errorf("email already exists")
@@ -42,7 +71,7 @@ func (s *UserService) RegisterWithEmail(req *models.CreateUserRequest) (*models.
 	// Hash password
 	hashedPassword, err := s.hashPassword(req.Password)
 	if err != nil {
-		return nil, fmt.Errorf("failed to hash password: %w", err)
+		return nil, apperr.Errorf("failed to hash password: %w", err)
 	}
 
 	// Create user
@@ -58,7 +87,7 @@ func (s *UserService) RegisterWithEmail(req *models.CreateUserRequest) (*models.
 
 	err = s.userRepo.Create(user)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create user: %w", err)
+		return nil, apperr.Errorf("failed to create user: %w", err)
 	}
 
 	// Create initial user stats
@@ -69,8 +98,12 @@ func (s *UserService) RegisterWithEmail(req *models.CreateUserRequest) (*models.
 		fmt.Printf("Warning: failed to initialize user stats for user %d: %v\n", user.ID, err)
 	}
 
+	s.joinInvitedOrganization(user, req.InvitationToken)
+
 	// Remove password hash from returned user
 	user.PasswordHash = ""
+
+	publishEvent(s.bus, events.UserRegistered, user.ID, user)
 	return user, nil
 }
 
@@ -87,6 +120,15 @@ func (s *UserService) LoginWithEmail(email, password string) (*models.User, erro
 		return nil, fmt.Errorf("invalid credentials")
 	}
 
+	// Logins stay locked after a user reported one that wasn't them until they choose a new password
+	resetRequired, err := s.userRepo.IsPasswordResetRequired(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if resetRequired {
+		return nil, fmt.Errorf("password reset required")
+	}
+
 	// Update last login
 	err = s.userRepo.UpdateLastLogin(user.ID)
 	if err != nil {
@@ -101,6 +143,10 @@ func (s *UserService) LoginWithEmail(email, password string) (*models.User, erro
 
 // LoginWithOAuth authenticates or registers a user with OAuth
 func (s *UserService) LoginWithOAuth(req *models.OAuthLoginRequest) (*models.User, error) {
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
 	// Validate OAuth token and get user info
 	userInfo, err := s.validateOAuthToken(req)
 	if err != nil {
@@ -138,7 +184,7 @@ func (s *UserService) LoginWithOAuth(req *models.OAuthLoginRequest) (*models.Use
 
 	err = s.userRepo.Create(user)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create user: %w", err)
+		return nil, apperr.Errorf("failed to create user: %w", err)
 	}
 
 	// Create initial user stats
@@ -149,9 +195,37 @@ func (s *UserService) LoginWithOAuth(req *models.OAuthLoginRequest) (*models.Use
 		fmt.Printf("Warning: failed to initialize user stats for user %d: %v\n", user.ID, err)
 	}
 
+	s.joinInvitedOrganization(user, req.InvitationToken)
+
+	publishEvent(s.bus, events.UserRegistered, user.ID, user)
 	return user, nil
 }
 
+// joinInvitedOrganization adds a newly registered user to the organization whose invitation
+// token they signed up with. Emails aren't verified at signup, so only the emailed token proves
+// the user owns the invited address; invitations for an email alone wait to be accepted.
+func (s *UserService) joinInvitedOrganization(user *models.User, token string) {
+	if s.orgRepo == nil || token == "" {
+		return
+	}
+
+	inv, err := s.orgRepo.GetPendingInvitationByTokenHash(hashSecretToken(token))
+	if err != nil {
+		// Log the error but don't fail the registration
+		fmt.Printf("Warning: failed to look up invitation for user %d: %v\n", user.ID, err)
+		return
+	}
+
+	if !strings.EqualFold(inv.Email, user.Email) {
+		fmt.Printf("Warning: invitation %d was sent to a different email than user %d's\n", inv.ID, user.ID)
+		return
+	}
+
+	if err := s.orgRepo.AcceptInvitation(inv, user.ID); err != nil {
+		fmt.Printf("Warning: failed to accept invitation %d for user %d: %v\n", inv.ID, user.ID, err)
+	}
+}
+
 // GetByID retrieves a user by ID
 func (s *UserService) GetByID(id int) (*models.User, error) {
 	user, err := s.userRepo.GetByID(id)
@@ -194,7 +268,7 @@ func (s *UserService) GenerateRefreshToken() (string, error) {
 	bytes := make([]byte, 32)
 	_, err := rand.Read(bytes)
 	if err != nil {
-		return "", fmt.Errorf("failed to generate refresh token: %w", err)
+		return "", apperr.Errorf("failed to generate refresh token: %w", err)
 	}
 	return base64.URLEncoding.EncodeToString(bytes), nil
 }
@@ -207,7 +281,7 @@ func (s *UserService) CreateRefreshToken(userID int) (string, error) {
 	}
 
 	expiresAt := time.Now().Add(7 * 24 * time.Hour) // 7 days
-	err = s.userRepo.CreateRefreshToken(userID, token, expiresAt)
+	err = s.userRepo.CreateRefreshToken(userID, token, expiresAt, models.SessionMetadata{}, "")
 	if err != nil {
 		return "", err
 	}
@@ -232,7 +306,7 @@ func (s *UserService) ValidateRefreshToken(token string) (*models.User, error) {
 
 	user, err := s.userRepo.GetByID(refreshToken.UserID)
 	if err != nil {
-		return nil, fmt.Errorf("user not found")
+		return nil, apperr.NotFound("user not found")
 	}
 
 	// Remove password hash from returned user
@@ -240,6 +314,34 @@ func (s *UserService) ValidateRefreshToken(token string) (*models.User, error) {
 	return user, nil
 }
 
+// ConfirmIdentity re-checks a signed-in user's credentials before a destructive action: the
+// password for email accounts, or a fresh provider access token for social login accounts
+func (s *UserService) ConfirmIdentity(userID int, req *models.DeleteAccountRequest) error {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if user.AuthProvider == models.AuthProviderEmail {
+		if req.Password == "" {
+			return apperr.Validation("password is required")
+		}
+		if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
+			return apperr.Unauthorized("invalid credentials")
+		}
+		return nil
+	}
+
+	if req.AccessToken == "" {
+		return apperr.Validation("access_token is required")
+	}
+	userInfo, err := s.validateOAuthToken(&models.OAuthLoginRequest{Provider: user.AuthProvider, AccessToken: req.AccessToken})
+	if err != nil || userInfo.ProviderID != user.ProviderID {
+		return apperr.Unauthorized("invalid credentials")
+	}
+	return nil
+}
+
 // RevokeRefreshToken revokes a refresh token
 func (s *UserService) RevokeRefreshToken(token string) error {
 	return s.userRepo.RevokeRefreshToken(token)
@@ -278,12 +380,41 @@ func (s *UserService) validateOAuthToken(req *models.OAuthLoginRequest) (*OAuthU
 
 // validateGoogleToken validates Google OAuth token
 func (s *UserService) validateGoogleToken(token string) (*OAuthUserInfo, error) {
+	if !s.oauth.Google.Enabled() {
+		return nil, fmt.Errorf("Google login is not configured")
+	}
+
+	// Check the token was issued to this app before trusting the profile behind it
+	infoURL := "https://oauth2.googleapis.com/tokeninfo?" + url.Values{"access_token": {token}}.Encode()
+	infoResp, err := http.Get(infoURL)
+	if err != nil {
+		return nil, apperr.Errorf("failed to validate Google token: %w", err)
+	}
+	defer infoResp.Body.Close()
+
+	if infoResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("invalid Google token")
+	}
+
+	var tokenInfo struct {
+		Audience        string `json:"aud"`
+		AuthorizedParty string `json:"azp"`
+		Subject         string `json:"sub"`
+	}
+	if err := json.NewDecoder(infoResp.Body).Decode(&tokenInfo); err != nil {
+		return nil, apperr.Errorf("failed to decode Google token info: %w", err)
+	}
+
+	if !s.oauth.Google.AllowsAudience(tokenInfo.Audience) && !s.oauth.Google.AllowsAudience(tokenInfo.AuthorizedParty) {
+		return nil, fmt.Errorf("Google token was issued to another app")
+	}
+
 	// Google OAuth token validation
-	url := fmt.Sprintf("https://www.googleapis.com/oauth2/v2/userinfo?access_token=%s", token)
+	userInfoURL := fmt.Sprintf("https://www.googleapis.com/oauth2/v2/userinfo?access_token=%s", token)
 
-	resp, err := http.Get(url)
+	resp, err := http.Get(userInfoURL)
 	if err != nil {
-		return nil, fmt.Errorf("failed to validate Google token: %w", err)
+		return nil, apperr.Errorf("failed to validate Google token: %w", err)
 	}
 	defer resp.Body.Close()
 
@@ -300,7 +431,11 @@ func (s *UserService) validateGoogleToken(token string) (*OAuthUserInfo, error)
 
 	err = json.NewDecoder(resp.Body).Decode(&googleUser)
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode Google user info: %w", err)
+		return nil, apperr.Errorf("failed to decode Google user info: %w", err)
+	}
+
+	if tokenInfo.Subject != "" && tokenInfo.Subject != googleUser.ID {
+		return nil, fmt.Errorf("invalid Google token")
 	}
 
 	return &OAuthUserInfo{
@@ -313,12 +448,49 @@ func (s *UserService) validateGoogleToken(token string) (*OAuthUserInfo, error)
 
 // validateFacebookToken validates Facebook OAuth token
 func (s *UserService) validateFacebookToken(token string) (*OAuthUserInfo, error) {
+	if !s.oauth.Facebook.Enabled() {
+		return nil, fmt.Errorf("Facebook login is not configured")
+	}
+
+	// Inspect the token with the app's credentials to learn which app it was issued to
+	debugURL := "https://graph.facebook.com/debug_token?" + url.Values{
+		"input_token":  {token},
+		"access_token": {s.oauth.Facebook.ClientID + "|" + s.oauth.Facebook.ClientSecret},
+	}.Encode()
+	debugResp, err := http.Get(debugURL)
+	if err != nil {
+		return nil, apperr.Errorf("failed to validate Facebook token: %w", err)
+	}
+	defer debugResp.Body.Close()
+
+	if debugResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("invalid Facebook token")
+	}
+
+	var debugInfo struct {
+		Data struct {
+			AppID   string `json:"app_id"`
+			IsValid bool   `json:"is_valid"`
+			UserID  string `json:"user_id"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(debugResp.Body).Decode(&debugInfo); err != nil {
+		return nil, apperr.Errorf("failed to decode Facebook token info: %w", err)
+	}
+
+	if !debugInfo.Data.IsValid {
+		return nil, fmt.Errorf("invalid Facebook token")
+	}
+	if !s.oauth.Facebook.AllowsAudience(debugInfo.Data.AppID) {
+		return nil, fmt.Errorf("Facebook token was issued to another app")
+	}
+
 	// Facebook OAuth token validation
-	url := fmt.Sprintf("https://graph.facebook.com/me?fields=id,email,name,picture&access_token=%s", token)
+	profileURL := fmt.Sprintf("https://graph.facebook.com/me?fields=id,email,name,picture&access_token=%s", token)
 
-	resp, err := http.Get(url)
+	resp, err := http.Get(profileURL)
 	if err != nil {
-		return nil, fmt.Errorf("failed to validate Facebook token: %w", err)
+		return nil, apperr.Errorf("failed to validate Facebook token: %w", err)
 	}
 	defer resp.Body.Close()
 
@@ -339,7 +511,11 @@ func (s *UserService) validateFacebookToken(token string) (*OAuthUserInfo, error
 
 	err = json.NewDecoder(resp.Body).Decode(&facebookUser)
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode Facebook user info: %w", err)
+		return nil, apperr.Errorf("failed to decode Facebook user info: %w", err)
+	}
+
+	if debugInfo.Data.UserID != "" && debugInfo.Data.UserID != facebookUser.ID {
+		return nil, fmt.Errorf("invalid Facebook token")
 	}
 
 	return &OAuthUserInfo{
@@ -350,38 +526,114 @@ func (s *UserService) validateFacebookToken(token string) (*OAuthUserInfo, error
 	}, nil
 }
 
-// validateAppleToken validates Apple OAuth token
+const (
+	appleIssuer  = "https://appleid.apple.com"
+	appleKeysURL = "https://appleid.apple.com/auth/keys"
+)
+
+// validateAppleToken validates an Apple identity token: a JWT signed with one of Apple's
+// published keys whose audience is this app
 func (s *UserService) validateAppleToken(token string) (*OAuthUserInfo, error) {
-	// Apple OAuth token validation is more complex and requires JWT verification
-	// For now, we'll implement a basic validation
-	// In production, you should use Apple's JWT verification
+	if !s.oauth.Apple.Enabled() {
+		return nil, fmt.Errorf("Apple login is not configured")
+	}
 
 	if token == "" {
 		return nil, fmt.Errorf("empty Apple token")
 	}
 
-	// Parse JWT token to extract user info
-	parts := strings.Split(token, ".")
-	if len(parts) != 3 {
-		return nil, fmt.Errorf("invalid Apple token format")
+	claims := jwt.MapClaims{}
+	parsed, err := jwt.ParseWithClaims(token, claims, appleSigningKey)
+	if err != nil || !parsed.Valid {
+		return nil, fmt.Errorf("invalid Apple token")
 	}
 
-	// In a real implementation, you would:
-	// 1. Verify the JWT signature using Apple's public keys
-	// 2. Validate the token claims (iss, aud, exp, etc.)
-	// 3. Extract user information from the token
+	if !claims.VerifyIssuer(appleIssuer, true) {
+		return nil, fmt.Errorf("invalid Apple token")
+	}
 
-	// For now, we'll return a placeholder implementation
-	// This should be replaced with proper Apple JWT verification
+	audience, _ := claims["aud"].(string)
+	if !s.oauth.Apple.AllowsAudience(audience) {
+		return nil, fmt.Errorf("Apple token was issued to another app")
+	}
+
+	subject, _ := claims["sub"].(string)
+	if subject == "" {
+		return nil, fmt.Errorf("invalid Apple token")
+	}
+	email, _ := claims["email"].(string)
+
+	// Apple doesn't put names or avatars in identity tokens
 	return &OAuthUserInfo{
-		ProviderID: "apple_user_id",    // This should come from the JWT sub claim
-		Email:      "user@example.com", // This should come from the JWT email claim
-		Name:       "Apple User",       // This might not be available in Apple tokens
-		Avatar:     "",                 // Apple doesn't provide avatar URLs
-	}, fmt.Errorf("Apple OAuth not fully implemented - please implement JWT verification")
+		ProviderID: subject,
+		Email:      email,
+	}, nil
 }
 
-// CleanupExpiredTokens removes expired refresh tokens
+// appleSigningKey fetches the public key an Apple identity token names in its kid header
+func appleSigningKey(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	kid, _ := token.Header["kid"].(string)
+
+	resp, err := http.Get(appleKeysURL)
+	if err != nil {
+		return nil, apperr.Errorf("failed to fetch Apple keys: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, apperr.Errorf("failed to fetch Apple keys: status %d", resp.StatusCode)
+	}
+
+	var keySet struct {
+		Keys []struct {
+			Kid string `json:"kid"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&keySet); err != nil {
+		return nil, apperr.Errorf("failed to decode Apple keys: %w", err)
+	}
+
+	for _, key := range keySet.Keys {
+		if key.Kid != kid {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(key.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Apple key: %w", err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(key.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Apple key: %w", err)
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	}
+
+	return nil, fmt.Errorf("unknown Apple key %q", kid)
+}
+
+// refreshTokensRemoved counts refresh tokens deleted by the cleanup job
+var refreshTokensRemoved = metrics.NewCounter(
+	"prepmaster_user_refresh_tokens_removed_total",
+	"Refresh tokens removed by the cleanup job, by reason: expired or revoked.",
+	"reason",
+)
+
+// CleanupExpiredTokens removes expired and revoked refresh tokens (run on a schedule)
 func (s *UserService) CleanupExpiredTokens() error {
-	return s.userRepo.CleanupExpiredRefreshTokens()
+	expired, revoked, err := s.userRepo.CleanupExpiredRefreshTokens(time.Now())
+	if err != nil {
+		return err
+	}
+
+	refreshTokensRemoved.Add(float64(expired), "expired")
+	refreshTokensRemoved.Add(float64(revoked), "revoked")
+	if expired+revoked > 0 {
+		fmt.Printf("Info: removed %d expired and %d revoked refresh tokens\n", expired, revoked)
+	}
+	return nil
 }
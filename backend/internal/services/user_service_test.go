@@ -0,0 +1,70 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+	"interview-prep-app/internal/testutil"
+)
+
+func TestRegisterJoinsOnlyWithInvitationToken(t *testing.T) {
+	db := testutil.OpenDB(t)
+	userRepo := repositories.NewUserRepository(db)
+	orgRepo := repositories.NewOrgRepository(db)
+	service := NewUserService(userRepo, repositories.NewStatsRepository(db), orgRepo, nil, nil, OAuthProviders{})
+
+	owner, err := service.RegisterWithEmail(&models.CreateUserRequest{Email: "owner@example.test", Name: "Owner", Password: "password"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	org := &models.Organization{Name: "Acme", CreatedBy: owner.ID}
+	if err := orgRepo.Create(org); err != nil {
+		t.Fatal(err)
+	}
+
+	invite := func(email, token string) {
+		t.Helper()
+		err := orgRepo.CreateInvitation(&models.OrgInvitation{
+			OrgID:     org.ID,
+			Email:     email,
+			Role:      models.OrgRoleMember,
+			TokenHash: hashSecretToken(token),
+			Status:    models.InvitationStatusPending,
+			InvitedBy: owner.ID,
+			ExpiresAt: time.Now().Add(time.Hour),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	invite("ada@example.test", "ada-token")
+	invite("bob@example.test", "bob-token")
+
+	for _, tc := range []struct {
+		name   string
+		email  string
+		token  string
+		member bool
+	}{
+		{"email alone", "bob@example.test", "", false},
+		{"someone else's token", "eve@example.test", "ada-token", false},
+		{"own token", "Ada@Example.test", "ada-token", true},
+	} {
+		user, err := service.RegisterWithEmail(&models.CreateUserRequest{
+			Email: tc.email, Name: tc.name, Password: "password", InvitationToken: tc.token,
+		})
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+
+		memberships, err := orgRepo.GetMemberships(user.ID)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		if member := len(memberships) == 1; member != tc.member {
+			t.Errorf("%s: expected membership %v, got %d organizations", tc.name, tc.member, len(memberships))
+		}
+	}
+}
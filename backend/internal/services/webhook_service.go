@@ -0,0 +1,454 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"interview-prep-app/internal/events"
+	"interview-prep-app/internal/i18n"
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+	"interview-prep-app/internal/validation"
+	"interview-prep-app/pkg/apperr"
+)
+
+const (
+	// maxWebhooksPerUser keeps a single account from fanning events out to unbounded endpoints
+	maxWebhooksPerUser = 10
+	// webhookBatchSize is how many due deliveries one run of the delivery job sends
+	webhookBatchSize = 50
+	// webhookDeliveryLogLimit is how many recent deliveries the delivery log shows
+	webhookDeliveryLogLimit = 50
+	// webhookDigestBatchSize is how many weekly digests one run of the digest job queues
+	webhookDigestBatchSize = 200
+)
+
+// webhookRetryBackoff is the wait before each retry; a delivery fails for good once it runs out
+var webhookRetryBackoff = []time.Duration{
+	time.Minute,
+	5 * time.Minute,
+	30 * time.Minute,
+	2 * time.Hour,
+	12 * time.Hour,
+}
+
+// WebhookService handles user webhooks and Slack/Discord integrations: registration, event fan-out
+// and delivery with retries
+type WebhookService struct {
+	webhookRepo *repositories.WebhookRepository
+	client      *http.Client
+}
+
+// NewWebhookService creates a new webhook service. Unless allowPrivateTargets is set, deliveries
+// refuse to connect to loopback, private and link-local addresses.
+func NewWebhookService(webhookRepo *repositories.WebhookRepository, allowPrivateTargets bool) *WebhookService {
+	dialer := &net.Dialer{Timeout: 5 * time.Second}
+	if !allowPrivateTargets {
+		dialer.Control = rejectPrivateAddress
+	}
+
+	return &WebhookService{
+		webhookRepo: webhookRepo,
+		client: &http.Client{
+			Timeout:   10 * time.Second,
+			Transport: &http.Transport{DialContext: dialer.DialContext, Proxy: http.ProxyFromEnvironment},
+			// Redirects could bounce a delivery to an address the dialer check never saw by name
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// CreateWebhook registers a webhook and returns it with its signing secret (shown only once)
+func (s *WebhookService) CreateWebhook(userID int, req *models.CreateWebhookRequest) (*models.Webhook, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	unique := uniqueWebhookEvents(req.Events)
+	if len(unique) == 0 {
+		unique = models.ValidWebhookEvents()
+	}
+
+	count, err := s.webhookRepo.CountForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxWebhooksPerUser {
+		return nil, fmt.Errorf("webhook limit reached: delete one before adding another")
+	}
+
+	secret := make([]byte, 24)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, apperr.Errorf("failed to generate webhook secret: %w", err)
+	}
+
+	webhook := &models.Webhook{
+		UserID: userID,
+		URL:    strings.TrimSpace(req.URL),
+		Secret: "whsec_" + hex.EncodeToString(secret),
+		Events: unique,
+		Format: models.WebhookFormatJSON,
+	}
+
+	if err := s.webhookRepo.Create(webhook); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+// ConnectIntegration connects a Slack or Discord incoming webhook, replacing any the user had
+// connected for that platform. Deliveries reuse the webhook queue and its retries.
+func (s *WebhookService) ConnectIntegration(userID int, format models.WebhookFormat, req *models.ConnectIntegrationRequest) (*models.Webhook, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	webhookURL := strings.TrimSpace(req.WebhookURL)
+	if !validIntegrationURL(format, webhookURL) {
+		return nil, apperr.Validation(fmt.Sprintf("webhook_url must be a %s incoming webhook URL", format))
+	}
+
+	events := uniqueWebhookEvents(req.Events)
+	if len(events) == 0 {
+		events = models.ValidWebhookEvents()
+	}
+
+	// Chat messages aren't signed, but every webhook row carries a secret
+	secret := make([]byte, 24)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, apperr.Errorf("failed to generate webhook secret: %w", err)
+	}
+
+	webhook := &models.Webhook{
+		UserID: userID,
+		URL:    webhookURL,
+		Secret: "whsec_" + hex.EncodeToString(secret),
+		Events: events,
+		Format: format,
+	}
+
+	if err := s.webhookRepo.ReplaceIntegration(webhook); err != nil {
+		return nil, err
+	}
+
+	webhook.Secret = ""
+	return webhook, nil
+}
+
+// GetIntegration returns the user's Slack or Discord integration
+func (s *WebhookService) GetIntegration(userID int, format models.WebhookFormat) (*models.Webhook, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	return s.webhookRepo.GetIntegration(userID, format)
+}
+
+// DisconnectIntegration removes the user's Slack or Discord integration
+func (s *WebhookService) DisconnectIntegration(userID int, format models.WebhookFormat) error {
+	if userID <= 0 {
+		return fmt.Errorf("invalid user ID")
+	}
+
+	return s.webhookRepo.DeleteIntegration(userID, format)
+}
+
+// TestIntegration posts a test message to the user's Slack or Discord integration right away,
+// so they can check it's connected to the right channel
+func (s *WebhookService) TestIntegration(ctx context.Context, userID int, format models.WebhookFormat) error {
+	webhook, err := s.GetIntegration(userID, format)
+	if err != nil {
+		return err
+	}
+
+	text := i18n.T(s.userLocale(userID), "Prep Master is connected. Progress updates will be posted here.")
+	var body interface{} = map[string]string{"text": text}
+	if format == models.WebhookFormatDiscord {
+		body = map[string]string{"content": text}
+	}
+	message, err := json.Marshal(body)
+	if err != nil {
+		return apperr.Errorf("failed to encode %s test message: %w", format, err)
+	}
+
+	_, err = s.send(ctx, &models.WebhookDelivery{URL: webhook.URL, Format: format, Payload: message})
+	if err != nil {
+		return apperr.Upstream(fmt.Sprintf("%s didn't accept the test message: %v", format, err))
+	}
+
+	return nil
+}
+
+// uniqueWebhookEvents drops repeated events, keeping their order
+func uniqueWebhookEvents(events []models.WebhookEvent) []models.WebhookEvent {
+	seen := make(map[models.WebhookEvent]bool)
+	var unique []models.WebhookEvent
+	for _, event := range events {
+		if !seen[event] {
+			seen[event] = true
+			unique = append(unique, event)
+		}
+	}
+	return unique
+}
+
+// GetWebhooks returns the user's webhooks
+func (s *WebhookService) GetWebhooks(userID int) ([]*models.Webhook, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	return s.webhookRepo.GetForUser(userID)
+}
+
+// DeleteWebhook removes one of the user's webhooks
+func (s *WebhookService) DeleteWebhook(userID, webhookID int) error {
+	if userID <= 0 {
+		return fmt.Errorf("invalid user ID")
+	}
+
+	if webhookID <= 0 {
+		return fmt.Errorf("invalid webhook ID")
+	}
+
+	return s.webhookRepo.Delete(userID, webhookID)
+}
+
+// GetDeliveries returns the recent delivery log for one of the user's webhooks
+func (s *WebhookService) GetDeliveries(userID, webhookID int) ([]*models.WebhookDelivery, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	if webhookID <= 0 {
+		return nil, fmt.Errorf("invalid webhook ID")
+	}
+
+	return s.webhookRepo.GetDeliveries(userID, webhookID, webhookDeliveryLogLimit)
+}
+
+// Subscribe forwards bus events to the webhooks subscribed to them
+func (s *WebhookService) Subscribe(bus events.Bus) {
+	forward := func(event models.WebhookEvent) events.Handler {
+		return func(ctx context.Context, e events.Event) error {
+			s.enqueue(e.UserID, event, e.OccurredAt, e.Data)
+			return nil
+		}
+	}
+
+	bus.Subscribe(events.ItemCompleted, "webhooks", forward(models.WebhookEventItemCompleted))
+	bus.Subscribe(events.StreakChanged, "webhooks", forward(models.WebhookEventStreakChanged))
+	bus.Subscribe(events.TestFinished, "webhooks", forward(models.WebhookEventTestCompleted))
+}
+
+// enqueue queues an event for every webhook the user has subscribed to it. Failures are logged
+// since there's no caller left to report them to.
+func (s *WebhookService) enqueue(userID int, event models.WebhookEvent, occurredAt time.Time, data interface{}) {
+	webhooks, err := s.webhookRepo.GetActiveForEvent(userID, event)
+	if err != nil {
+		fmt.Printf("Warning: failed to look up webhooks for user %d: %v\n", userID, err)
+		return
+	}
+	if len(webhooks) == 0 {
+		return
+	}
+
+	s.enqueueTo(webhooks, userID, event, occurredAt, data)
+}
+
+// enqueueTo queues an event for the given webhooks. Chat integrations get a message formatted for
+// their platform in place of the signed JSON payload.
+func (s *WebhookService) enqueueTo(webhooks []*models.Webhook, userID int, event models.WebhookEvent, occurredAt time.Time, data interface{}) {
+	payloads := make(map[models.WebhookFormat][]byte)
+	for _, webhook := range webhooks {
+		payload, encoded := payloads[webhook.Format]
+		if !encoded {
+			var err error
+			payload, err = s.encodePayload(webhook.Format, userID, event, occurredAt, data)
+			if err != nil {
+				fmt.Printf("Warning: %v\n", err)
+			}
+			// A nil payload (an error, or a chat message nobody needs) skips this format
+			payloads[webhook.Format] = payload
+		}
+		if payload == nil {
+			continue
+		}
+
+		if err := s.webhookRepo.EnqueueDelivery(webhook.ID, event, payload); err != nil {
+			fmt.Printf("Warning: failed to queue %s for webhook %d: %v\n", event, webhook.ID, err)
+		}
+	}
+}
+
+// encodePayload builds the delivery body for a webhook format, or nil if the event shouldn't be delivered
+func (s *WebhookService) encodePayload(format models.WebhookFormat, userID int, event models.WebhookEvent, occurredAt time.Time, data interface{}) ([]byte, error) {
+	if format.IsChat() {
+		message, post, err := chatMessage(format, s.userLocale(userID), event, data)
+		if err != nil || !post {
+			return nil, err
+		}
+		return message, nil
+	}
+
+	payload, err := json.Marshal(models.WebhookPayload{
+		Event:     event,
+		UserID:    userID,
+		CreatedAt: occurredAt,
+		Data:      data,
+	})
+	if err != nil {
+		return nil, apperr.Errorf("failed to encode %s webhook payload: %w", event, err)
+	}
+	return payload, nil
+}
+
+// userLocale returns the locale to write a user's chat messages in, falling back to English when
+// their preference can't be read
+func (s *WebhookService) userLocale(userID int) string {
+	locale, err := s.webhookRepo.GetUserLocale(userID)
+	if err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	}
+	return i18n.Resolve(locale, "")
+}
+
+// EnqueueWeeklyDigests queues a summary of the past week for webhooks subscribed to the weekly
+// digest that haven't had one in a week (run on a schedule)
+func (s *WebhookService) EnqueueWeeklyDigests(ctx context.Context) error {
+	now := time.Now()
+	weekStart := now.AddDate(0, 0, -7)
+
+	webhooks, err := s.webhookRepo.ClaimDueDigests(weekStart, now, webhookDigestBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, webhook := range webhooks {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		digest, err := s.webhookRepo.GetWeeklyDigest(webhook.UserID, weekStart)
+		if err != nil {
+			fmt.Printf("Warning: skipping weekly digest for webhook %d: %v\n", webhook.ID, err)
+			continue
+		}
+		s.enqueueTo([]*models.Webhook{webhook}, webhook.UserID, models.WebhookEventWeeklyDigest, now, digest)
+	}
+
+	return nil
+}
+
+// DeliverDue sends the deliveries that are due and schedules retries for failures (run on a schedule)
+func (s *WebhookService) DeliverDue(ctx context.Context) error {
+	deliveries, err := s.webhookRepo.ClaimDueDeliveries(webhookBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, delivery := range deliveries {
+		statusCode, err := s.send(ctx, delivery)
+		if err == nil {
+			if err := s.webhookRepo.RecordAttempt(delivery.ID, models.WebhookDeliverySucceeded, statusCode, "", nil); err != nil {
+				fmt.Printf("Warning: %v\n", err)
+			}
+			continue
+		}
+
+		status := models.WebhookDeliveryPending
+		var nextAttemptAt *time.Time
+		if delivery.Attempts < len(webhookRetryBackoff) {
+			next := time.Now().Add(webhookRetryBackoff[delivery.Attempts])
+			nextAttemptAt = &next
+		} else {
+			status = models.WebhookDeliveryFailed
+		}
+
+		if err := s.webhookRepo.RecordAttempt(delivery.ID, status, statusCode, err.Error(), nextAttemptAt); err != nil {
+			fmt.Printf("Warning: %v\n", err)
+		}
+	}
+
+	return nil
+}
+
+// send POSTs a delivery and returns the response status code. JSON deliveries are signed; chat
+// messages go to Slack or Discord, which authenticate by the secret URL instead.
+func (s *WebhookService) send(ctx context.Context, delivery *models.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("invalid webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "PrepMaster-Webhooks/1.0")
+	if !delivery.Format.IsChat() {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set("X-PrepMaster-Event", string(delivery.Event))
+		req.Header.Set("X-PrepMaster-Delivery", strconv.Itoa(delivery.ID))
+		req.Header.Set("X-PrepMaster-Timestamp", timestamp)
+		req.Header.Set("X-PrepMaster-Signature", "sha256="+SignWebhookPayload(delivery.Secret, timestamp, delivery.Payload))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("endpoint responded with status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// SignWebhookPayload computes the hex HMAC-SHA256 receivers use to verify a delivery:
+// the secret keys a hash of "<timestamp>.<body>", where timestamp is the X-PrepMaster-Timestamp header
+func SignWebhookPayload(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// rejectPrivateAddress stops webhook deliveries, link checks and link enrichment from reaching internal services
+func rejectPrivateAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsUnspecified() {
+		return fmt.Errorf("target %s is not a public address", host)
+	}
+
+	return nil
+}
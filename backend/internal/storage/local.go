@@ -0,0 +1,147 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"interview-prep-app/pkg/apperr"
+)
+
+// LocalDownloadPath is the route that serves signed local-disk downloads
+const LocalDownloadPath = "/api/v1/attachments/download"
+
+// LocalStorage stores objects on the local filesystem
+type LocalStorage struct {
+	baseDir    string
+	baseURL    string
+	signingKey []byte
+}
+
+// NewLocalStorage creates a local-disk storage rooted at baseDir
+func NewLocalStorage(baseDir, baseURL, signingKey string) (*LocalStorage, error) {
+	if err := os.MkdirAll(baseDir, 0o755); err != nil {
+		return nil, apperr.Errorf("failed to create storage directory: %w", err)
+	}
+
+	return &LocalStorage{
+		baseDir:    baseDir,
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		signingKey: []byte(signingKey),
+	}, nil
+}
+
+// Put writes the object to disk
+func (s *LocalStorage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return apperr.Errorf("failed to create object directory: %w", err)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return apperr.Errorf("failed to create object file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(file, body); err != nil {
+		os.Remove(path)
+		return apperr.Errorf("failed to write object: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes the object from disk
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return apperr.Errorf("failed to delete object: %w", err)
+	}
+
+	return nil
+}
+
+// Check verifies the storage directory accepts writes
+func (s *LocalStorage) Check(ctx context.Context) error {
+	file, err := os.CreateTemp(s.baseDir, ".check-*")
+	if err != nil {
+		return fmt.Errorf("storage directory is not writable: %w", err)
+	}
+	file.Close()
+	return os.Remove(file.Name())
+}
+
+// Open opens the object for reading
+func (s *LocalStorage) Open(key string) (*os.File, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return os.Open(path)
+}
+
+// SignedURL returns an HMAC-signed URL served by the download route
+func (s *LocalStorage) SignedURL(key string, expiry time.Duration) (string, error) {
+	expires := time.Now().Add(expiry).Unix()
+
+	query := url.Values{}
+	query.Set("key", key)
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("signature", s.sign(key, expires))
+
+	return s.baseURL + LocalDownloadPath + "?" + query.Encode(), nil
+}
+
+// VerifySignature checks a signed download request
+func (s *LocalStorage) VerifySignature(key, expiresStr, signature string) error {
+	expires, err := strconv.ParseInt(expiresStr, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid expiry")
+	}
+
+	if time.Now().Unix() > expires {
+		return fmt.Errorf("download link expired")
+	}
+
+	if !hmac.Equal([]byte(s.sign(key, expires)), []byte(signature)) {
+		return fmt.Errorf("invalid signature")
+	}
+
+	return nil
+}
+
+// sign computes the signature for a key and expiry
+func (s *LocalStorage) sign(key string, expires int64) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write([]byte(key + "\n" + strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// path resolves a key to a file path, rejecting keys that escape the base directory
+func (s *LocalStorage) path(key string) (string, error) {
+	cleaned := filepath.Clean("/" + key)
+	if cleaned == "/" {
+		return "", fmt.Errorf("invalid object key")
+	}
+
+	return filepath.Join(s.baseDir, cleaned), nil
+}
@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestLocalStorageSignedURL(t *testing.T) {
+	store, err := NewLocalStorage(t.TempDir(), "http://localhost:8080/", "secret")
+	if err != nil {
+		t.Fatalf("Failed to create local storage: %v", err)
+	}
+
+	signed, err := store.SignedURL("items/1/users/2/file.png", time.Minute)
+	if err != nil {
+		t.Fatalf("Failed to sign URL: %v", err)
+	}
+
+	parsed, err := url.Parse(signed)
+	if err != nil {
+		t.Fatalf("Signed URL is not parseable: %v", err)
+	}
+	if parsed.Path != LocalDownloadPath {
+		t.Errorf("Expected path %s, got %s", LocalDownloadPath, parsed.Path)
+	}
+
+	query := parsed.Query()
+	if err := store.VerifySignature(query.Get("key"), query.Get("expires"), query.Get("signature")); err != nil {
+		t.Errorf("Expected valid signature, got %v", err)
+	}
+
+	if err := store.VerifySignature("items/1/users/3/file.png", query.Get("expires"), query.Get("signature")); err == nil {
+		t.Error("Expected signature for a different key to be rejected")
+	}
+
+	if err := store.VerifySignature(query.Get("key"), "1", query.Get("signature")); err == nil {
+		t.Error("Expected expired link to be rejected")
+	}
+}
+
+func TestLocalStoragePathRejectsTraversal(t *testing.T) {
+	store, err := NewLocalStorage(t.TempDir(), "http://localhost:8080", "secret")
+	if err != nil {
+		t.Fatalf("Failed to create local storage: %v", err)
+	}
+
+	path, err := store.path("../../etc/passwd")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if path != store.baseDir+"/etc/passwd" {
+		t.Errorf("Expected traversal to be confined to base dir, got %s", path)
+	}
+}
@@ -0,0 +1,34 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"interview-prep-app/internal/config"
+)
+
+// Storage abstracts the object store used for uploaded files
+type Storage interface {
+	// Put stores the object under key
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	// Delete removes the object stored under key
+	Delete(ctx context.Context, key string) error
+	// SignedURL returns a time-limited download URL for the object
+	SignedURL(key string, expiry time.Duration) (string, error)
+	// Check verifies the store is reachable and accepts writes
+	Check(ctx context.Context) error
+}
+
+// New creates the storage backend selected in the configuration
+func New(cfg *config.Config) (Storage, error) {
+	switch cfg.StorageBackend {
+	case "", "local":
+		return NewLocalStorage(cfg.StorageLocalDir, cfg.PublicBaseURL, cfg.StorageSigningKey)
+	case "s3", "gcs":
+		return NewS3Storage(cfg.S3Endpoint, cfg.S3Region, cfg.S3Bucket, cfg.S3AccessKeyID, cfg.S3SecretAccessKey)
+	default:
+		return nil, fmt.Errorf("unsupported storage backend: %s", cfg.StorageBackend)
+	}
+}
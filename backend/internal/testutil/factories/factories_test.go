@@ -0,0 +1,43 @@
+package factories
+
+import (
+	"testing"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/repositories"
+	"interview-prep-app/internal/testutil"
+)
+
+func TestFactoriesCreateRecords(t *testing.T) {
+	db := testutil.OpenDB(t)
+	f := New(t, db)
+
+	user := f.User()
+	if user.ID == 0 || user.Email != "user1@example.test" || user.Role != models.RoleUser {
+		t.Fatalf("unexpected default user: %+v", user)
+	}
+
+	admin := f.Admin(func(u *models.User) { u.Name = "Root" })
+	if admin.Role != models.RoleAdmin || admin.Name != "Root" {
+		t.Fatalf("overrides not applied: %+v", admin)
+	}
+
+	item := f.Item(func(req *models.CreateItemRequest) { req.Category = models.CategoryHLD })
+	if item.Category != models.CategoryHLD {
+		t.Fatalf("expected an hld item, got %s", item.Category)
+	}
+
+	progress := f.Progress(user.ID, item.ID, models.StatusDone)
+	if progress.Status != models.StatusDone || progress.CompletedAt == nil {
+		t.Fatalf("expected item to be completed: %+v", progress)
+	}
+
+	sessionID := f.TestSession(user.ID)
+	active, err := repositories.NewTestRepository(db).IsItemInPendingTest(user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sessionID == "" || !active {
+		t.Fatalf("expected a pending test session, got %q", sessionID)
+	}
+}
@@ -0,0 +1,239 @@
+// Command mockgen writes a mock for every interface declared in a Go source file. Each mock has a
+// <Method>Func field per method; calling a method whose field is unset panics, so a test only sets
+// up the calls it expects. It is run through go:generate, e.g. in internal/services:
+//
+//	go run ../testutil/mockgen -source stores.go -out mocks/stores.go -package mocks
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	source := flag.String("source", "", "Go file declaring the interfaces to mock")
+	out := flag.String("out", "", "file to write the mocks to")
+	pkg := flag.String("package", "mocks", "package name of the generated file")
+	flag.Parse()
+
+	if *source == "" || *out == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	code, err := generate(*source, *pkg)
+	if err != nil {
+		log.Fatalf("mockgen: %v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(*out), 0o755); err != nil {
+		log.Fatalf("mockgen: failed to create output directory: %v", err)
+	}
+	if err := os.WriteFile(*out, code, 0o644); err != nil {
+		log.Fatalf("mockgen: failed to write mocks: %v", err)
+	}
+}
+
+// generate parses source and returns the formatted mocks for its interfaces
+func generate(source, pkg string) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, source, nil, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", source, err)
+	}
+
+	g := &generator{fset: fset, imports: importPaths(file), used: map[string]bool{}}
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			if iface, ok := typeSpec.Type.(*ast.InterfaceType); ok {
+				if err := g.mock(typeSpec.Name.Name, iface); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by mockgen from %s. DO NOT EDIT.\n\n", filepath.Base(source))
+	fmt.Fprintf(&buf, "// Package %s holds test doubles for the interfaces in %s\n", pkg, filepath.Base(source))
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+
+	// Imports keep the source's order and grouping
+	buf.WriteString("import (\n")
+	lastLine := 0
+	for _, spec := range file.Imports {
+		name := importName(spec)
+		if !g.used[name] {
+			continue
+		}
+		line := fset.Position(spec.Pos()).Line
+		if lastLine > 0 && line > lastLine+1 {
+			buf.WriteString("\n")
+		}
+		lastLine = line
+		fmt.Fprintf(&buf, "\t%s\n", g.imports[name])
+	}
+	buf.WriteString(")\n")
+	buf.Write(g.body.Bytes())
+
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format mocks: %w", err)
+	}
+	return code, nil
+}
+
+type generator struct {
+	fset *token.FileSet
+	// imports maps a package name used in the source to its import spec
+	imports map[string]string
+	// used records which of those packages the mocks refer to
+	used map[string]bool
+	body bytes.Buffer
+}
+
+// mock writes the mock struct and methods for one interface
+func (g *generator) mock(name string, iface *ast.InterfaceType) error {
+	type method struct {
+		name string
+		fn   *ast.FuncType
+	}
+	var methods []method
+	for _, field := range iface.Methods.List {
+		fn, ok := field.Type.(*ast.FuncType)
+		if !ok {
+			return fmt.Errorf("%s embeds %s; only plain methods are supported", name, g.print(field.Type))
+		}
+		for _, ident := range field.Names {
+			methods = append(methods, method{name: ident.Name, fn: fn})
+		}
+	}
+
+	fmt.Fprintf(&g.body, "\n// %s is a mock %s\ntype %s struct {\n", name, name, name)
+	for _, m := range methods {
+		signature, err := g.signature(m.fn)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", name, m.name, err)
+		}
+		fmt.Fprintf(&g.body, "\t%sFunc func%s\n", m.name, signature)
+	}
+	g.body.WriteString("}\n")
+
+	for _, m := range methods {
+		params, args := g.params(m.fn)
+		signature, _ := g.signature(&ast.FuncType{Params: params, Results: m.fn.Results})
+		fmt.Fprintf(&g.body, "\n// %s calls %sFunc\n", m.name, m.name)
+		fmt.Fprintf(&g.body, "func (m *%s) %s%s {\n", name, m.name, signature)
+		fmt.Fprintf(&g.body, "\tif m.%sFunc == nil {\n\t\tpanic(%q)\n\t}\n", m.name, "unexpected call to "+name+"."+m.name)
+		call := fmt.Sprintf("m.%sFunc(%s)", m.name, strings.Join(args, ", "))
+		if m.fn.Results == nil || len(m.fn.Results.List) == 0 {
+			fmt.Fprintf(&g.body, "\t%s\n}\n", call)
+		} else {
+			fmt.Fprintf(&g.body, "\treturn %s\n}\n", call)
+		}
+	}
+
+	return nil
+}
+
+// params names every parameter so the method can forward them, returning the renamed list and
+// the call arguments
+func (g *generator) params(fn *ast.FuncType) (*ast.FieldList, []string) {
+	params := &ast.FieldList{}
+	var args []string
+	for _, field := range fn.Params.List {
+		names := field.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{nil}
+		}
+		renamed := &ast.Field{Type: field.Type}
+		for _, ident := range names {
+			name := "arg" + strconv.Itoa(len(args))
+			if ident != nil && ident.Name != "_" {
+				name = ident.Name
+			}
+			renamed.Names = append(renamed.Names, ast.NewIdent(name))
+			if _, variadic := field.Type.(*ast.Ellipsis); variadic {
+				name += "..."
+			}
+			args = append(args, name)
+		}
+		params.List = append(params.List, renamed)
+	}
+	return params, args
+}
+
+// signature prints a function's parameters and results, checking every type it refers to is
+// reachable from the mocks package
+func (g *generator) signature(fn *ast.FuncType) (string, error) {
+	var err error
+	ast.Inspect(fn, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.SelectorExpr:
+			pkg, ok := n.X.(*ast.Ident)
+			if !ok {
+				return true
+			}
+			if _, known := g.imports[pkg.Name]; !known && err == nil {
+				err = fmt.Errorf("unknown package %s", pkg.Name)
+			}
+			g.used[pkg.Name] = true
+			return false
+		case *ast.Ident:
+			if ast.IsExported(n.Name) && err == nil {
+				err = fmt.Errorf("type %s is declared in the source package; qualify it or move it", n.Name)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimPrefix(g.print(fn), "func"), nil
+}
+
+func (g *generator) print(node ast.Node) string {
+	var buf bytes.Buffer
+	printer.Fprint(&buf, g.fset, node)
+	return buf.String()
+}
+
+// importPaths maps each import's package name to the spec to write in the generated file
+func importPaths(file *ast.File) map[string]string {
+	imports := map[string]string{}
+	for _, spec := range file.Imports {
+		if spec.Name != nil {
+			imports[spec.Name.Name] = spec.Name.Name + " " + spec.Path.Value
+			continue
+		}
+		imports[importName(spec)] = spec.Path.Value
+	}
+	return imports
+}
+
+// importName is the name an import is referred to by, assuming packages are named after their
+// directory
+func importName(spec *ast.ImportSpec) string {
+	if spec.Name != nil {
+		return spec.Name.Name
+	}
+	path, _ := strconv.Unquote(spec.Path.Value)
+	return filepath.Base(path)
+}
@@ -0,0 +1,91 @@
+// Package testutil connects tests to the test database. Tests that need one are skipped
+// unless TEST_DATABASE_URL is set, so `go test ./...` stays runnable without Postgres.
+package testutil
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+
+	"interview-prep-app/internal/database"
+)
+
+// DatabaseURLEnv names the environment variable holding the test database URL
+const DatabaseURLEnv = "TEST_DATABASE_URL"
+
+// OpenDB connects to the test database, migrates it and empties it, skipping the test when
+// no test database is configured. The connection is closed when the test finishes.
+// Tests sharing the database must not run in parallel.
+func OpenDB(t testing.TB) *sql.DB {
+	t.Helper()
+
+	url := os.Getenv(DatabaseURLEnv)
+	if url == "" {
+		t.Skipf("%s is not set", DatabaseURLEnv)
+	}
+
+	db, err := database.NewConnection(url)
+	if err != nil {
+		t.Fatalf("failed to connect to test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if err := database.RunMigrations(db); err != nil {
+		t.Fatalf("failed to migrate test database: %v", err)
+	}
+
+	Reset(t, db)
+	return db
+}
+
+// Reset deletes every user and item, and everything hanging off them, and restarts their IDs
+func Reset(t testing.TB, db *sql.DB) {
+	t.Helper()
+
+	if database.DialectFor(db) == database.DialectSQLite {
+		resetSQLite(t, db)
+		return
+	}
+
+	if _, err := db.Exec("TRUNCATE users, items RESTART IDENTITY CASCADE"); err != nil {
+		t.Fatalf("failed to reset test database: %v", err)
+	}
+}
+
+// resetSQLite empties users, items and every table referencing them, as TRUNCATE ... CASCADE
+// does, and restarts those tables' IDs
+func resetSQLite(t testing.TB, db *sql.DB) {
+	t.Helper()
+
+	tables := []string{"users", "items"}
+	cleared := map[string]bool{"users": true, "items": true}
+	for i := 0; i < len(tables); i++ {
+		rows, err := db.Query(`
+			SELECT m.name FROM sqlite_master m, pragma_foreign_key_list(m.name) fk
+			WHERE m.type = 'table' AND fk."table" = $1`, tables[i])
+		if err != nil {
+			t.Fatalf("failed to list tables referencing %s: %v", tables[i], err)
+		}
+		for rows.Next() {
+			var name string
+			if err := rows.Scan(&name); err != nil {
+				t.Fatalf("failed to scan table name: %v", err)
+			}
+			if !cleared[name] {
+				cleared[name] = true
+				tables = append(tables, name)
+			}
+		}
+		rows.Close()
+	}
+
+	// Children first, so deleting doesn't trip foreign keys from tables not yet emptied
+	for i := len(tables) - 1; i >= 0; i-- {
+		if _, err := db.Exec("DELETE FROM " + tables[i]); err != nil {
+			t.Fatalf("failed to reset %s: %v", tables[i], err)
+		}
+		if _, err := db.Exec("DELETE FROM sqlite_sequence WHERE name = $1", tables[i]); err != nil {
+			t.Fatalf("failed to restart %s IDs: %v", tables[i], err)
+		}
+	}
+}
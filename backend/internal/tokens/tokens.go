@@ -0,0 +1,226 @@
+// Package tokens issues and verifies the API's access tokens. Tokens are JWTs naming the user and
+// their role, with the issuer and audience the API expects, signed by the newest of a set of keys
+// and carrying its ID in the kid header. Older keys stay accepted until their tokens expire, so
+// keys rotate without signing anyone out. Keys are HS256 secrets, or RS256 key pairs whose public
+// halves other services can fetch as a JWKS to verify tokens themselves.
+package tokens
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"interview-prep-app/internal/config"
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// Claims are the claims of an access token
+type Claims struct {
+	UserID   int         `json:"user_id"`
+	Email    string      `json:"email"`
+	Username string      `json:"username"` // Keep for backward compatibility
+	Role     models.Role `json:"role,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// key is one signing key
+type key struct {
+	id      string
+	method  jwt.SigningMethod
+	private interface{} // []byte for HS256, *rsa.PrivateKey for RS256
+	public  interface{} // []byte for HS256, *rsa.PublicKey for RS256
+}
+
+// Issuer signs and verifies access tokens
+type Issuer struct {
+	keys     []key // newest first; the first signs
+	byID     map[string]key
+	legacy   []byte    // JWT_SECRET, for tokens issued before tokens carried a kid
+	until    time.Time // when tokens without a kid stop being accepted; never accepted when zero
+	issuer   string
+	audience string
+	ttl      time.Duration
+}
+
+// TTL is how long access tokens last
+const TTL = 24 * time.Hour
+
+// NewIssuer creates an issuer from the JWT settings. RS256 keys are used when configured,
+// then HS256 keys, then JWT_SECRET alone under the key ID "default".
+func NewIssuer(cfg *config.Config) (*Issuer, error) {
+	until, err := cfg.JWTLegacyCutoff()
+	if err != nil {
+		return nil, err
+	}
+
+	i := &Issuer{
+		byID:     map[string]key{},
+		legacy:   []byte(cfg.JWTSecret),
+		until:    until,
+		issuer:   cfg.JWTIssuer,
+		audience: cfg.JWTAudience,
+		ttl:      TTL,
+	}
+
+	switch {
+	case len(cfg.JWTRSAKeyFiles) > 0:
+		for _, entry := range cfg.JWTRSAKeyFiles {
+			id, path, err := splitKey(entry)
+			if err != nil {
+				return nil, fmt.Errorf("JWT_RSA_KEY_FILES: %w", err)
+			}
+			raw, err := os.ReadFile(path)
+			if err != nil {
+				return nil, apperr.Errorf("failed to read RSA key %s: %w", id, err)
+			}
+			private, err := jwt.ParseRSAPrivateKeyFromPEM(raw)
+			if err != nil {
+				return nil, apperr.Errorf("failed to parse RSA key %s: %w", id, err)
+			}
+			i.keys = append(i.keys, key{id: id, method: jwt.SigningMethodRS256, private: private, public: &private.PublicKey})
+		}
+	case len(cfg.JWTKeys) > 0:
+		for _, entry := range cfg.JWTKeys {
+			id, secret, err := splitKey(entry)
+			if err != nil {
+				return nil, fmt.Errorf("JWT_KEYS: %w", err)
+			}
+			i.keys = append(i.keys, key{id: id, method: jwt.SigningMethodHS256, private: []byte(secret), public: []byte(secret)})
+		}
+	default:
+		i.keys = []key{{id: "default", method: jwt.SigningMethodHS256, private: i.legacy, public: i.legacy}}
+	}
+
+	for _, k := range i.keys {
+		if _, exists := i.byID[k.id]; exists {
+			return nil, fmt.Errorf("JWT key ID %q is used twice", k.id)
+		}
+		i.byID[k.id] = k
+	}
+	return i, nil
+}
+
+// splitKey splits a "kid:value" key setting
+func splitKey(entry string) (string, string, error) {
+	id, value, ok := strings.Cut(entry, ":")
+	if !ok || id == "" || value == "" {
+		return "", "", fmt.Errorf("expected kid:value, got %q", entry)
+	}
+	return id, value, nil
+}
+
+// Issue signs a token for the user with the newest key
+func (i *Issuer) Issue(user *models.User) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(i.ttl)
+	claims := &Claims{
+		UserID:   user.ID,
+		Email:    user.Email,
+		Username: user.Email, // For backward compatibility
+		Role:     user.Role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    i.issuer,
+			Subject:   fmt.Sprint(user.ID),
+			Audience:  jwt.ClaimStrings{i.audience},
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	}
+
+	signing := i.keys[0]
+	token := jwt.NewWithClaims(signing.method, claims)
+	token.Header["kid"] = signing.id
+	signed, err := token.SignedString(signing.private)
+	return signed, expiresAt, err
+}
+
+// Verify checks a token's signature, expiry, issuer and audience and returns its claims. Tokens
+// without a kid predate key rotation: until the JWT_LEGACY_UNTIL cutoff they're checked against
+// JWT_SECRET and accepted without an issuer or audience, and after it (or without one) they're
+// rejected, so a retired JWT_SECRET can't keep minting tokens.
+func (i *Issuer) Verify(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	legacy := false
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		id, _ := token.Header["kid"].(string)
+		if id == "" {
+			if i.until.IsZero() || !time.Now().Before(i.until) {
+				return nil, errors.New("tokens without a key ID are no longer accepted")
+			}
+			if token.Method != jwt.SigningMethodHS256 {
+				return nil, errors.New("token without a key ID must be HS256")
+			}
+			legacy = true
+			return i.legacy, nil
+		}
+
+		k, ok := i.byID[id]
+		if !ok {
+			return nil, fmt.Errorf("unknown key ID %q", id)
+		}
+		if token.Method.Alg() != k.method.Alg() {
+			return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+		}
+		return k.public, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, jwt.ErrSignatureInvalid
+	}
+
+	if !legacy {
+		if !claims.VerifyIssuer(i.issuer, true) {
+			return nil, errors.New("unexpected token issuer")
+		}
+		if !claims.VerifyAudience(i.audience, true) {
+			return nil, errors.New("unexpected token audience")
+		}
+	}
+	return claims, nil
+}
+
+// JWK is a public key in JSON Web Key form
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	Modulus   string `json:"n"`
+	Exponent  string `json:"e"`
+}
+
+// JWKS is a JSON Web Key Set
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public keys other services verify tokens with. It's empty for HS256 keys,
+// which are secrets.
+func (i *Issuer) JWKS() JWKS {
+	set := JWKS{Keys: []JWK{}}
+	for _, k := range i.keys {
+		public, ok := k.public.(*rsa.PublicKey)
+		if !ok {
+			continue
+		}
+		set.Keys = append(set.Keys, JWK{
+			KeyType:   "RSA",
+			KeyID:     k.id,
+			Use:       "sig",
+			Algorithm: k.method.Alg(),
+			Modulus:   base64.RawURLEncoding.EncodeToString(public.N.Bytes()),
+			Exponent:  base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes()),
+		})
+	}
+	return set
+}
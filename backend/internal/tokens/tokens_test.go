@@ -0,0 +1,157 @@
+package tokens
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"interview-prep-app/internal/config"
+	"interview-prep-app/internal/models"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+var admin = &models.User{ID: 7, Email: "admin@example.com", Role: models.RoleAdmin}
+
+func newIssuer(t *testing.T, cfg config.Config) *Issuer {
+	t.Helper()
+	if cfg.JWTIssuer == "" {
+		cfg.JWTIssuer, cfg.JWTAudience = "prepmaster", "prepmaster-api"
+	}
+	issuer, err := NewIssuer(&cfg)
+	if err != nil {
+		t.Fatalf("NewIssuer failed: %v", err)
+	}
+	return issuer
+}
+
+func TestIssueAndVerify(t *testing.T) {
+	issuer := newIssuer(t, config.Config{JWTSecret: "secret"})
+
+	token, expiresAt, err := issuer.Issue(admin)
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+	if time.Until(expiresAt) < TTL-time.Minute {
+		t.Errorf("Expected the token to last %s, expires at %s", TTL, expiresAt)
+	}
+
+	claims, err := issuer.Verify(token)
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if claims.UserID != admin.ID || claims.Role != models.RoleAdmin || claims.Issuer != "prepmaster" || claims.Subject != "7" {
+		t.Errorf("Unexpected claims %+v", claims)
+	}
+}
+
+func TestKeyRotation(t *testing.T) {
+	before := newIssuer(t, config.Config{JWTKeys: []string{"2026-01:old secret"}})
+	after := newIssuer(t, config.Config{JWTKeys: []string{"2026-07:new secret", "2026-01:old secret"}})
+	retired := newIssuer(t, config.Config{JWTKeys: []string{"2026-07:new secret"}})
+
+	oldToken, _, _ := before.Issue(admin)
+	if _, err := after.Verify(oldToken); err != nil {
+		t.Errorf("Expected a token signed with a previous key to verify, got %v", err)
+	}
+	if _, err := retired.Verify(oldToken); err == nil {
+		t.Error("Expected a token signed with a retired key to fail")
+	}
+
+	newToken, _, _ := after.Issue(admin)
+	parsed, _, _ := new(jwt.Parser).ParseUnverified(newToken, &Claims{})
+	if parsed.Header["kid"] != "2026-07" {
+		t.Errorf("Expected the newest key to sign, got kid %v", parsed.Header["kid"])
+	}
+}
+
+func TestVerifyRejectsOtherAudiences(t *testing.T) {
+	other := newIssuer(t, config.Config{JWTSecret: "secret", JWTIssuer: "prepmaster", JWTAudience: "billing"})
+	issuer := newIssuer(t, config.Config{JWTSecret: "secret"})
+
+	token, _, _ := other.Issue(admin)
+	if _, err := issuer.Verify(token); err == nil {
+		t.Error("Expected a token for another audience to fail")
+	}
+}
+
+func TestVerifyLegacyToken(t *testing.T) {
+	tomorrow := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+	issuer := newIssuer(t, config.Config{JWTSecret: "secret", JWTKeys: []string{"2026-07:new secret"}, JWTLegacyUntil: tomorrow})
+
+	// Tokens from before key IDs had no kid, issuer, audience or role
+	legacy := jwt.NewWithClaims(jwt.SigningMethodHS256, &Claims{
+		UserID:           7,
+		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))},
+	})
+	token, _ := legacy.SignedString([]byte("secret"))
+
+	claims, err := issuer.Verify(token)
+	if err != nil {
+		t.Fatalf("Expected a legacy token to verify before the cutoff, got %v", err)
+	}
+	if claims.Role != "" {
+		t.Errorf("Expected no role in a legacy token, got %q", claims.Role)
+	}
+
+	yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+	for name, cfg := range map[string]config.Config{
+		"no cutoff":   {JWTSecret: "secret", JWTKeys: []string{"2026-07:new secret"}},
+		"past cutoff": {JWTSecret: "secret", JWTKeys: []string{"2026-07:new secret"}, JWTLegacyUntil: yesterday},
+	} {
+		if _, err := newIssuer(t, cfg).Verify(token); err == nil {
+			t.Errorf("%s: expected a legacy token to be rejected", name)
+		}
+	}
+
+	if _, err := NewIssuer(&config.Config{JWTSecret: "secret", JWTLegacyUntil: "soon"}); err == nil {
+		t.Error("Expected an invalid JWT_LEGACY_UNTIL to be rejected")
+	}
+}
+
+func TestRS256AndJWKS(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "key.pem")
+	pemBytes := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	if err := os.WriteFile(path, pemBytes, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	issuer := newIssuer(t, config.Config{JWTSecret: "secret", JWTRSAKeyFiles: []string{"rsa-1:" + path}})
+	token, _, err := issuer.Issue(admin)
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+	if _, err := issuer.Verify(token); err != nil {
+		t.Errorf("Verify failed: %v", err)
+	}
+
+	// An HS256 token signed with the RSA public key must not pass as RS256
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, &Claims{UserID: 7})
+	forged.Header["kid"] = "rsa-1"
+	forgedToken, _ := forged.SignedString(x509.MarshalPKCS1PublicKey(&key.PublicKey))
+	if _, err := issuer.Verify(forgedToken); err == nil {
+		t.Error("Expected an HS256 token with an RSA key ID to fail")
+	}
+
+	jwks := issuer.JWKS()
+	if len(jwks.Keys) != 1 || jwks.Keys[0].KeyID != "rsa-1" || jwks.Keys[0].Algorithm != "RS256" || jwks.Keys[0].Exponent != "AQAB" {
+		t.Errorf("Unexpected JWKS %+v", jwks)
+	}
+}
+
+func TestNewIssuerRejectsBadKeys(t *testing.T) {
+	for _, keys := range [][]string{{"no-separator"}, {"a:one", "a:two"}} {
+		if _, err := NewIssuer(&config.Config{JWTKeys: keys}); err == nil {
+			t.Errorf("Expected keys %v to be rejected", keys)
+		}
+	}
+}
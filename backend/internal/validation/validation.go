@@ -0,0 +1,187 @@
+// Package validation checks request models against the rules in their binding tags and reports
+// every broken rule by field, so clients can point at the inputs to fix. Handlers run it when
+// binding a body and services run it on requests that reach them from elsewhere (bulk imports,
+// ingestion), so the tags are the one place a request's shape is described.
+package validation
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"interview-prep-app/internal/i18n"
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError is a rule a request field broke
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// Details is the details object of a validation error
+type Details struct {
+	Fields []FieldError `json:"fields"`
+}
+
+// slugPattern matches category slugs: lowercase words joined by single dashes or underscores
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+([_-][a-z0-9]+)*$`)
+
+// enums are the rules for values limited to a fixed set, named after the set
+var enums = map[string]func(value string) bool{
+	"ai_question_kind":        func(v string) bool { return models.IsValidAIQuestionKind(models.AIQuestionKind(v)) },
+	"status":                  func(v string) bool { return models.IsValidStatus(models.Status(v)) },
+	"completion_quality":      func(v string) bool { return models.IsValidCompletionQuality(models.CompletionQuality(v)) },
+	"competency":              func(v string) bool { return models.IsValidCompetency(models.Competency(v)) },
+	"plan":                    func(v string) bool { return models.IsValidPlan(models.Plan(v)) },
+	"device_platform":         func(v string) bool { return models.IsValidDevicePlatform(models.DevicePlatform(v)) },
+	"feedback_category":       func(v string) bool { return models.IsValidFeedbackCategory(models.FeedbackCategory(v)) },
+	"flashcard_grade":         func(v string) bool { return models.IsValidFlashcardGrade(models.FlashcardGrade(v)) },
+	"interview_status":        func(v string) bool { return models.IsValidInterviewStatus(models.InterviewStatus(v)) },
+	"interview_stage_kind":    func(v string) bool { return models.IsValidInterviewStageKind(models.InterviewStageKind(v)) },
+	"interview_stage_outcome": func(v string) bool { return models.IsValidInterviewStageOutcome(models.InterviewStageOutcome(v)) },
+	"locale":                  func(v string) bool { return v == "" || i18n.IsSupported(v) },
+	"oauth_provider":          func(v string) bool { return models.IsValidOAuthProvider(models.AuthProvider(v)) },
+	"org_role":                func(v string) bool { return models.IsValidOrgRole(models.OrgRole(v)) },
+	"permission":              func(v string) bool { return models.IsValidPermission(models.Permission(v)) },
+	"test_rule_kind":          func(v string) bool { return models.IsValidTestRuleKind(models.TestRuleKind(v)) },
+	"test_outcome":            func(v string) bool { return models.IsValidTestOutcome(models.TestOutcome(v)) },
+	"webhook_event":           func(v string) bool { return models.IsValidWebhookEvent(models.WebhookEvent(v)) },
+}
+
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New()
+	v.SetTagName("binding")
+
+	// Fields are reported by the names clients send
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+
+	must(v.RegisterValidation("notblank", func(fl validator.FieldLevel) bool {
+		return strings.TrimSpace(fl.Field().String()) != ""
+	}))
+	must(v.RegisterValidation("category", func(fl validator.FieldLevel) bool {
+		return slugPattern.MatchString(fl.Field().String())
+	}))
+	must(v.RegisterValidation("weburl", func(fl validator.FieldLevel) bool {
+		return IsWebURL(fl.Field().String())
+	}))
+	for name, valid := range enums {
+		valid := valid
+		must(v.RegisterValidation(name, func(fl validator.FieldLevel) bool {
+			return valid(fl.Field().String())
+		}))
+	}
+
+	return v
+}
+
+func must(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
+
+// IsWebURL reports whether link is an absolute http or https URL
+func IsWebURL(link string) bool {
+	target, err := url.Parse(strings.TrimSpace(link))
+	return err == nil && (target.Scheme == "http" || target.Scheme == "https") && target.Host != ""
+}
+
+// Struct checks obj against its binding tags, returning a validation error listing every field
+// that broke a rule
+func Struct(obj interface{}) error {
+	err := validate.Struct(obj)
+	if err == nil {
+		return nil
+	}
+
+	invalid, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return apperr.Wrap(apperr.KindValidation, err)
+	}
+
+	fields := make([]FieldError, 0, len(invalid))
+	for _, fe := range invalid {
+		fields = append(fields, toFieldError(fe))
+	}
+	return Fields(fields...)
+}
+
+// Field reports a single invalid field, for rules the tags can't express such as ones that
+// need the database
+func Field(field, rule, message string) error {
+	return Fields(FieldError{Field: field, Rule: rule, Message: message})
+}
+
+// Fields reports invalid fields. The error message joins the field messages.
+func Fields(fields ...FieldError) error {
+	messages := make([]string, len(fields))
+	for i, field := range fields {
+		messages[i] = field.Message
+	}
+	return apperr.Validation(strings.Join(messages, "; ")).WithDetails(Details{Fields: fields})
+}
+
+// toFieldError describes a broken rule, naming the field by its path in the request
+func toFieldError(fe validator.FieldError) FieldError {
+	field := fe.Namespace()
+	if i := strings.Index(field, "."); i >= 0 {
+		field = field[i+1:]
+	}
+	return FieldError{Field: field, Rule: fe.Tag(), Message: field + " " + describe(fe)}
+}
+
+// describe words the rule a field broke
+func describe(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required", "notblank":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "url", "weburl":
+		return "must be an http or https URL"
+	case "category":
+		return "must be a category slug of lowercase letters, digits, dashes and underscores"
+	case "datetime":
+		return "must be in the " + fe.Param() + " format"
+	case "timezone":
+		return "must be an IANA time zone such as Europe/Berlin"
+	case "oneof":
+		return "must be one of: " + strings.ReplaceAll(fe.Param(), " ", ", ")
+	case "min", "max":
+		bound := "at least"
+		if fe.Tag() == "max" {
+			bound = "at most"
+		}
+		switch fe.Kind() {
+		case reflect.String:
+			return fmt.Sprintf("must be %s %s characters", bound, fe.Param())
+		case reflect.Slice, reflect.Array, reflect.Map:
+			return fmt.Sprintf("must have %s %s entries", bound, fe.Param())
+		default:
+			return fmt.Sprintf("must be %s %s", bound, fe.Param())
+		}
+	}
+
+	if _, ok := enums[fe.Tag()]; ok {
+		return "must be a valid " + strings.ReplaceAll(fe.Tag(), "_", " ")
+	}
+	return fmt.Sprintf("failed the %s rule", fe.Tag())
+}
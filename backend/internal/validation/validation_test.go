@@ -0,0 +1,118 @@
+package validation
+
+import (
+	"errors"
+	"testing"
+
+	"interview-prep-app/internal/models"
+	"interview-prep-app/pkg/apperr"
+)
+
+// fieldsOf returns the field errors a validation error carries
+func fieldsOf(t *testing.T, err error) []FieldError {
+	t.Helper()
+
+	var appErr *apperr.Error
+	if !errors.As(err, &appErr) || appErr.Kind != apperr.KindValidation {
+		t.Fatalf("Expected a validation error, got %v", err)
+	}
+	details, ok := appErr.Details.(Details)
+	if !ok {
+		t.Fatalf("Expected field details, got %#v", appErr.Details)
+	}
+	return details.Fields
+}
+
+func TestStructCreateItemRequest(t *testing.T) {
+	testCases := []struct {
+		name          string
+		req           models.CreateItemRequest
+		expectedField string
+		expectedRule  string
+	}{
+		{
+			name: "Valid item",
+			req:  models.CreateItemRequest{Title: "Two Sum", Link: "https://leetcode.com/problems/two-sum/", Category: models.CategoryDSA, Subcategory: "arrays"},
+		},
+		{
+			name:          "Missing title",
+			req:           models.CreateItemRequest{Link: "https://leetcode.com/problems/two-sum/", Category: models.CategoryDSA, Subcategory: "arrays"},
+			expectedField: "title",
+			expectedRule:  "required",
+		},
+		{
+			name:          "Blank subcategory",
+			req:           models.CreateItemRequest{Title: "Two Sum", Link: "https://leetcode.com/problems/two-sum/", Category: models.CategoryDSA, Subcategory: "  "},
+			expectedField: "subcategory",
+			expectedRule:  "notblank",
+		},
+		{
+			name:          "Link without a scheme",
+			req:           models.CreateItemRequest{Title: "Two Sum", Link: "leetcode.com/problems/two-sum", Category: models.CategoryDSA, Subcategory: "arrays"},
+			expectedField: "link",
+			expectedRule:  "weburl",
+		},
+		{
+			name:          "Malformed category",
+			req:           models.CreateItemRequest{Title: "Two Sum", Link: "https://leetcode.com/problems/two-sum/", Category: "Data Structures", Subcategory: "arrays"},
+			expectedField: "category",
+			expectedRule:  "category",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Struct(&tc.req)
+			if tc.expectedField == "" {
+				if err != nil {
+					t.Fatalf("Expected no error, got %v", err)
+				}
+				return
+			}
+
+			fields := fieldsOf(t, err)
+			if len(fields) != 1 || fields[0].Field != tc.expectedField || fields[0].Rule != tc.expectedRule {
+				t.Errorf("Expected %s to break %s, got %+v", tc.expectedField, tc.expectedRule, fields)
+			}
+		})
+	}
+}
+
+func TestStructReportsEveryField(t *testing.T) {
+	err := Struct(&models.OAuthLoginRequest{Provider: "github", Email: "not-an-email"})
+
+	fields := fieldsOf(t, err)
+	expected := map[string]string{"provider": "oauth_provider", "access_token": "required", "email": "email"}
+	if len(fields) != len(expected) {
+		t.Fatalf("Expected %d field errors, got %+v", len(expected), fields)
+	}
+	for _, field := range fields {
+		if expected[field.Field] != field.Rule {
+			t.Errorf("Unexpected field error %+v", field)
+		}
+	}
+}
+
+func TestStructNestedFields(t *testing.T) {
+	err := Struct(&models.CreateWebhookRequest{URL: "https://example.com/hook", Events: []models.WebhookEvent{"item.completed", "item.exploded"}})
+
+	fields := fieldsOf(t, err)
+	if len(fields) != 1 || fields[0].Field != "events[1]" {
+		t.Errorf("Expected the second event to be reported, got %+v", fields)
+	}
+	if fields[0].Message != "events[1] must be a valid webhook event" {
+		t.Errorf("Unexpected message %q", fields[0].Message)
+	}
+}
+
+func TestStructOptionalFields(t *testing.T) {
+	blank := ""
+	if err := Struct(&models.UpdateItemRequest{}); err != nil {
+		t.Errorf("Expected absent fields to be skipped, got %v", err)
+	}
+
+	fields := fieldsOf(t, Struct(&models.UpdateItemRequest{Title: &blank}))
+	if len(fields) != 1 || fields[0].Field != "title" {
+		t.Errorf("Expected an empty title to be reported, got %+v", fields)
+	}
+}
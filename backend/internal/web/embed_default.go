@@ -0,0 +1,9 @@
+//go:build !standalone
+
+package web
+
+import (
+	"io/fs"
+)
+
+var embedded fs.FS
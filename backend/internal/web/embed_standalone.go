@@ -0,0 +1,13 @@
+//go:build standalone
+
+package web
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed all:dist
+var dist embed.FS
+
+var embedded fs.FS = dist
@@ -0,0 +1,23 @@
+// Package web exposes the frontend build bundled into standalone binaries.
+//
+// The assets are only embedded when building with the "standalone" tag after
+// copying the frontend build into internal/web/dist (see `make standalone`).
+package web
+
+import (
+	"io/fs"
+)
+
+// Assets returns the embedded frontend build, or false when the binary was built without it
+func Assets() (fs.FS, bool) {
+	if embedded == nil {
+		return nil, false
+	}
+
+	assets, err := fs.Sub(embedded, "dist")
+	if err != nil {
+		return nil, false
+	}
+
+	return assets, true
+}
@@ -0,0 +1,207 @@
+// Package apperr defines the errors the API reports to clients. An *Error carries a Kind that
+// decides the HTTP status and the code in the response envelope, so repositories and services
+// say what went wrong and the error middleware alone decides how it looks on the wire.
+package apperr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Kind classifies an error. It is also the code clients see.
+type Kind string
+
+const (
+	KindValidation      Kind = "validation_error"
+	KindUnauthorized    Kind = "unauthorized"
+	KindPaymentRequired Kind = "upgrade_required"
+	KindForbidden       Kind = "forbidden"
+	KindNotFound        Kind = "not_found"
+	KindConflict        Kind = "conflict"
+	KindRateLimited     Kind = "rate_limited"
+	KindInternal        Kind = "internal_error"
+	KindUpstream        Kind = "upstream_error"
+	KindUnavailable     Kind = "unavailable"
+)
+
+// Sentinels for errors.Is; every *Error matches the sentinel of its kind
+var (
+	ErrValidation      = errors.New("validation error")
+	ErrUnauthorized    = errors.New("unauthorized")
+	ErrPaymentRequired = errors.New("upgrade required")
+	ErrForbidden       = errors.New("forbidden")
+	ErrNotFound        = errors.New("not found")
+	ErrConflict        = errors.New("conflict")
+	ErrRateLimited     = errors.New("rate limited")
+	ErrInternal        = errors.New("internal error")
+	ErrUpstream        = errors.New("upstream error")
+	ErrUnavailable     = errors.New("unavailable")
+)
+
+var kinds = map[Kind]struct {
+	status   int
+	sentinel error
+}{
+	KindValidation:      {http.StatusBadRequest, ErrValidation},
+	KindUnauthorized:    {http.StatusUnauthorized, ErrUnauthorized},
+	KindPaymentRequired: {http.StatusPaymentRequired, ErrPaymentRequired},
+	KindForbidden:       {http.StatusForbidden, ErrForbidden},
+	KindNotFound:        {http.StatusNotFound, ErrNotFound},
+	KindConflict:        {http.StatusConflict, ErrConflict},
+	KindRateLimited:     {http.StatusTooManyRequests, ErrRateLimited},
+	KindInternal:        {http.StatusInternalServerError, ErrInternal},
+	KindUpstream:        {http.StatusBadGateway, ErrUpstream},
+	KindUnavailable:     {http.StatusServiceUnavailable, ErrUnavailable},
+}
+
+// internalMessage is all clients learn of an internal error
+const internalMessage = "Internal server error"
+
+// Error is an error with a kind, a client-facing message and optional structured details
+type Error struct {
+	Kind    Kind
+	Message string
+	Details interface{}
+	// Err is the underlying cause, if any. It is logged, never sent to clients.
+	Err error
+}
+
+// Error returns the message, so errors compare and wrap like the plain errors they replace. An
+// internal error hiding its cause from clients returns the cause's text, for the logs.
+func (e *Error) Error() string {
+	if e.Kind == KindInternal && e.Message == internalMessage && e.Err != nil {
+		return e.Err.Error()
+	}
+	return e.Message
+}
+
+// Unwrap returns the underlying cause
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is the sentinel for the error's kind
+func (e *Error) Is(target error) bool {
+	return target == kinds[e.Kind].sentinel
+}
+
+// Status returns the HTTP status for the error's kind
+func (e *Error) Status() int {
+	if k, ok := kinds[e.Kind]; ok {
+		return k.status
+	}
+	return http.StatusInternalServerError
+}
+
+// WithDetails returns a copy of the error carrying details for the response envelope
+func (e *Error) WithDetails(details interface{}) *Error {
+	copied := *e
+	copied.Details = details
+	return &copied
+}
+
+// New creates an error of the given kind
+func New(kind Kind, message string) *Error {
+	return &Error{Kind: kind, Message: message}
+}
+
+// Wrap creates an error of the given kind from a cause, using its text as the message
+func Wrap(kind Kind, err error) *Error {
+	return &Error{Kind: kind, Message: err.Error(), Err: err}
+}
+
+// Errorf formats an error like fmt.Errorf, for failures on our side such as storage errors. The
+// error is internal: its text is logged, never sent to clients. An error wrapping one that
+// already has a kind keeps that kind.
+func Errorf(format string, args ...interface{}) error {
+	err := fmt.Errorf(format, args...)
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return err
+	}
+	return &Error{Kind: KindInternal, Message: internalMessage, Err: err}
+}
+
+// Classify returns err as an *Error, for handlers calling services that report the client's
+// mistakes as plain errors. An error that already has a kind keeps it; anything else is given kind.
+func Classify(kind Kind, err error) *Error {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	return Wrap(kind, err)
+}
+
+// Validation reports a request the client must fix
+func Validation(message string) *Error {
+	return New(KindValidation, message)
+}
+
+// Unauthorized reports a missing or invalid credential
+func Unauthorized(message string) *Error {
+	return New(KindUnauthorized, message)
+}
+
+// PaymentRequired reports a feature or quota outside the user's plan
+func PaymentRequired(message string) *Error {
+	return New(KindPaymentRequired, message)
+}
+
+// Forbidden reports an authenticated user lacking permission
+func Forbidden(message string) *Error {
+	return New(KindForbidden, message)
+}
+
+// NotFound reports a missing resource
+func NotFound(message string) *Error {
+	return New(KindNotFound, message)
+}
+
+// Conflict reports a request clashing with existing state
+func Conflict(message string) *Error {
+	return New(KindConflict, message)
+}
+
+// RateLimited reports a client sending too many requests
+func RateLimited(message string) *Error {
+	return New(KindRateLimited, message)
+}
+
+// Internal reports a failure on our side
+func Internal(message string) *Error {
+	return New(KindInternal, message)
+}
+
+// Upstream reports a failure of a service we depend on
+func Upstream(message string) *Error {
+	return New(KindUpstream, message)
+}
+
+// Unavailable reports a feature that is switched off or temporarily unavailable
+func Unavailable(message string) *Error {
+	return New(KindUnavailable, message)
+}
+
+// From returns err as an *Error. Errors without a kind are internal, their text kept as the cause
+// only, so unexpected failures never leak to clients.
+func From(err error) *Error {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	return &Error{Kind: KindInternal, Message: internalMessage, Err: err}
+}
+
+// Response is the envelope every API error is returned in
+type Response struct {
+	Code    Kind        `json:"code"`
+	Message string      `json:"message"`
+	Details interface{} `json:"details"`
+}
+
+// ToResponse returns the envelope for an error
+func ToResponse(err error) (int, Response) {
+	appErr := From(err)
+	return appErr.Status(), Response{Code: appErr.Kind, Message: appErr.Message, Details: appErr.Details}
+}
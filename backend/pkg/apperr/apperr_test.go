@@ -0,0 +1,76 @@
+package apperr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestClassify(t *testing.T) {
+	testCases := []struct {
+		name            string
+		err             error
+		expectedKind    Kind
+		expectedMessage string
+	}{
+		{name: "Typed error keeps its kind", err: NotFound("item not found"), expectedKind: KindNotFound, expectedMessage: "item not found"},
+		{name: "Wrapped typed error keeps its kind", err: fmt.Errorf("loading item: %w", Conflict("item exists")), expectedKind: KindConflict, expectedMessage: "item exists"},
+		{name: "Storage failure is internal", err: Errorf("failed to get item: %w", errors.New("connection reset")), expectedKind: KindInternal, expectedMessage: "Internal server error"},
+		{name: "Wrapped storage failure is internal", err: fmt.Errorf("loading item: %w", Errorf("failed to get item")), expectedKind: KindInternal, expectedMessage: "Internal server error"},
+		{name: "Plain error gets the given kind", err: fmt.Errorf("invalid item ID"), expectedKind: KindValidation, expectedMessage: "invalid item ID"},
+		{name: "Plain error worded like a storage failure gets the given kind", err: fmt.Errorf("failed to parse limit"), expectedKind: KindValidation, expectedMessage: "failed to parse limit"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			classified := Classify(KindValidation, tc.err)
+			if classified.Kind != tc.expectedKind || classified.Message != tc.expectedMessage {
+				t.Errorf("Expected %s %q, got %s %q", tc.expectedKind, tc.expectedMessage, classified.Kind, classified.Message)
+			}
+		})
+	}
+}
+
+func TestErrorf(t *testing.T) {
+	cause := errors.New("connection reset")
+	err := Errorf("failed to get item %d: %w", 7, cause)
+
+	if err.Error() != "failed to get item 7: connection reset" {
+		t.Errorf("Expected the formatted text for the logs, got %q", err.Error())
+	}
+	if !errors.Is(err, cause) || !errors.Is(err, ErrInternal) {
+		t.Error("Expected the error to match its cause and ErrInternal")
+	}
+
+	err = Errorf("failed to load item: %w", NotFound("item not found"))
+	if !errors.Is(err, ErrNotFound) || errors.Is(err, ErrInternal) {
+		t.Errorf("Expected a wrapped not found error to stay not found, got %v", From(err).Kind)
+	}
+}
+
+func TestErrorsIs(t *testing.T) {
+	err := fmt.Errorf("loading item: %w", NotFound("item not found"))
+
+	if !errors.Is(err, ErrNotFound) {
+		t.Error("Expected a not found error to match ErrNotFound")
+	}
+	if errors.Is(err, ErrConflict) {
+		t.Error("Expected a not found error not to match ErrConflict")
+	}
+}
+
+func TestToResponse(t *testing.T) {
+	status, response := ToResponse(Conflict("item exists").WithDetails(map[string]int{"item_id": 7}))
+	if status != http.StatusConflict || response.Code != KindConflict || response.Message != "item exists" {
+		t.Errorf("Unexpected conflict response: %d %+v", status, response)
+	}
+	if response.Details == nil {
+		t.Error("Expected details in the conflict response")
+	}
+
+	status, response = ToResponse(errors.New("pq: connection refused"))
+	if status != http.StatusInternalServerError || response.Message != "Internal server error" {
+		t.Errorf("Expected an untyped error to be hidden behind a 500, got %d %+v", status, response)
+	}
+}
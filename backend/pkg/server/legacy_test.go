@@ -0,0 +1,75 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"interview-prep-app/internal/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestLegacyRoutesAreDeprecated(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	s := New(&config.Config{Environment: "test", LegacyRoutesEnabled: true, LegacyRoutesSunset: "2027-01-31"}, Handlers{}, nil)
+	s.setupMiddleware()
+	s.setupRoutes()
+
+	for _, route := range s.router.Routes() {
+		if documented(route.Path) {
+			continue
+		}
+		if _, ok := legacySuccessors[route.Method+" "+route.Path]; !ok {
+			t.Errorf("Legacy route %s %s has no successor", route.Method, route.Path)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/items/42/complete", nil))
+
+	// Unauthenticated, but old clients still learn where to move
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 without a token, got %d", w.Code)
+	}
+	if w.Header().Get("Deprecation") != "true" {
+		t.Errorf("Expected a Deprecation header, got %q", w.Header().Get("Deprecation"))
+	}
+	if link := w.Header().Get("Link"); link != `</api/v1/items/42/complete>; rel="successor-version"` {
+		t.Errorf("Unexpected Link header %q", link)
+	}
+	if sunset := w.Header().Get("Sunset"); sunset != "Sun, 31 Jan 2027 00:00:00 GMT" {
+		t.Errorf("Unexpected Sunset header %q", sunset)
+	}
+}
+
+func TestLegacyRoutesCanBeDisabled(t *testing.T) {
+	s := newTestServer()
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stats", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected disabled legacy routes to be missing, got %d", w.Code)
+	}
+	if w.Header().Get("Deprecation") != "" {
+		t.Error("Expected no Deprecation header on a missing route")
+	}
+}
+
+func TestDeprecatedTestItemRoutesHaveSuccessors(t *testing.T) {
+	s := newTestServer()
+
+	routes := map[string]bool{}
+	for _, route := range s.router.Routes() {
+		routes[route.Method+" "+route.Path] = true
+	}
+
+	for route, successor := range testItemSuccessors {
+		if !routes[route] {
+			t.Errorf("Deprecated route %s isn't registered", route)
+		}
+		if !routes[http.MethodPut+" "+successor] {
+			t.Errorf("Successor %s of %s isn't registered", successor, route)
+		}
+	}
+}
@@ -0,0 +1,447 @@
+package server
+
+import (
+	"net/http"
+	"sync"
+
+	"interview-prep-app/internal/health"
+	"interview-prep-app/internal/models"
+	"interview-prep-app/internal/openapi"
+	"interview-prep-app/internal/storage"
+	"interview-prep-app/internal/tokens"
+	"interview-prep-app/pkg/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// apiDocument builds the OpenAPI document once, on first request
+var apiDocument = sync.OnceValues(func() (*openapi.Document, error) {
+	return openapi.Build(openapi.Info{
+		Title:       "Interview Prep API",
+		Version:     "2.0",
+		Description: "Errors use the envelope in the Error schema. Paths under /api/v1 need a bearer token unless marked otherwise.",
+	}, apiOperations())
+})
+
+// serveOpenAPI serves the OpenAPI document clients generate their API code from
+func (s *Server) serveOpenAPI(c *gin.Context) {
+	doc, err := apiDocument()
+	if err != nil {
+		c.Error(apperr.Wrap(apperr.KindInternal, err))
+		return
+	}
+	c.JSON(http.StatusOK, doc)
+}
+
+// swaggerUI renders the OpenAPI document with Swagger UI from a CDN
+const swaggerUI = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Interview Prep API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: "/api/v1/openapi.json", dom_id: "#swagger-ui" });
+  </script>
+</body>
+</html>
+`
+
+// serveAPIDocs serves Swagger UI for browsing and trying the API
+func (s *Server) serveAPIDocs(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(swaggerUI))
+}
+
+// Query parameters shared by the item listing routes
+var itemFilters = []openapi.Param{
+	openapi.Query("category", "string", "Only items in this category"),
+	openapi.Query("subcategory", "string", "Only items in this subcategory"),
+	openapi.Query("company", "string", "Only items tagged with this company slug"),
+	openapi.Query("status", "string", "Only items with this status"),
+	openapi.Query("starred", "boolean", "Only starred or unstarred items"),
+	openapi.Query("has_notes", "boolean", "Only items with or without notes"),
+	openapi.Query("limit", "integer", "Maximum number of items"),
+	openapi.Query("offset", "integer", "Number of items to skip"),
+}
+
+// Query parameter of routes that return only the fields asked for
+var fieldsParam = openapi.Query("fields", "string", "Comma-separated fields to return, of each item for lists; the rest are left out")
+
+// Query parameters of paginated routes
+var page = []openapi.Param{
+	openapi.Query("limit", "integer", "Maximum number of results"),
+	openapi.Query("offset", "integer", "Number of results to skip"),
+}
+
+func withParams(params []openapi.Param, more ...openapi.Param) []openapi.Param {
+	return append(append([]openapi.Param{}, params...), more...)
+}
+
+// apiOperations documents every route under /api and the health checks. Keep it in step with
+// setupRoutes; TestOpenAPIMatchesRoutes fails when they differ.
+func apiOperations() []openapi.Operation {
+	message := openapi.Message
+	return []openapi.Operation{
+		// Health
+		{Method: "GET", Path: "/health", Tag: "health", Summary: "Check the API is running", Public: true, Response: openapi.Object{"status": "", "message": "", "version": ""}},
+		{Method: "GET", Path: "/healthz/ready", Tag: "health", Summary: "Check required dependencies are up", Public: true, Response: openapi.Object{"status": ""}},
+		{Method: "GET", Path: "/healthz/details", Tag: "health", Summary: "Report the state of every dependency", Response: health.Report{}},
+
+		// Documentation
+		{Method: "GET", Path: "/api/v1/openapi.json", Tag: "docs", Summary: "Get this document", Public: true, Response: map[string]interface{}{}},
+		{Method: "GET", Path: "/api/v1/docs", Tag: "docs", Summary: "Browse this document in Swagger UI", Public: true, ContentType: "text/html"},
+
+		// Auth
+		{Method: "POST", Path: "/api/v1/auth/register", Tag: "auth", Summary: "Register with email and password", Public: true, Body: models.CreateUserRequest{}, Response: models.LoginResponse{}, Status: http.StatusCreated},
+		{Method: "POST", Path: "/api/v1/auth/login", Tag: "auth", Summary: "Log in with email and password", Public: true, Body: models.LoginRequest{}, Response: models.LoginResponse{}},
+		{Method: "POST", Path: "/api/v1/auth/oauth/login", Tag: "auth", Summary: "Log in with an OAuth provider", Public: true, Body: models.OAuthLoginRequest{}, Response: models.LoginResponse{}},
+		{Method: "POST", Path: "/api/v1/auth/login-alerts/:token/deny", Tag: "auth", Summary: "Deny a login from a new device alert", Public: true, Response: models.DenyLoginResponse{}},
+		{Method: "POST", Path: "/api/v1/auth/password/reset", Tag: "auth", Summary: "Reset a password with a reset token", Public: true, Body: models.ResetPasswordRequest{}, Response: message},
+		{Method: "GET", Path: "/api/v1/auth/csrf", Tag: "auth", Summary: "Get a CSRF token for cookie-authenticated requests", Public: true, Response: openapi.Object{"csrf_token": ""}},
+		{Method: "GET", Path: "/.well-known/jwks.json", Tag: "auth", Summary: "Get the public keys access tokens are signed with", Public: true, Response: tokens.JWKS{}},
+
+		// Public
+		{Method: "GET", Path: "/api/v1/config/client", Tag: "config", Summary: "Get the client configuration", Public: true, Response: models.ClientConfig{}},
+		{Method: "POST", Path: "/api/v1/leetcode/proxy", Tag: "config", Summary: "Forward a GraphQL query to LeetCode", Public: true, Body: map[string]interface{}{}, Response: map[string]interface{}{}},
+		{Method: "GET", Path: storage.LocalDownloadPath, Tag: "attachments", Summary: "Download an attachment with a signed link", Public: true, ContentType: "application/octet-stream", Query: []openapi.Param{
+			{Name: "key", Type: "string", Required: true},
+			{Name: "expires", Type: "integer", Required: true},
+			{Name: "signature", Type: "string", Required: true},
+		}},
+		{Method: "GET", Path: "/api/v1/calendar.ics", Tag: "calendar", Summary: "Get the review calendar feed", Public: true, ContentType: "text/calendar", Query: []openapi.Param{
+			{Name: "token", Type: "string", Description: "The feed token from /api/v1/user/calendar", Required: true},
+		}},
+		{Method: "GET", Path: "/api/v1/reports/:id", Tag: "reports", Summary: "View a shared progress report", Public: true, Response: models.ProgressReport{}, Query: []openapi.Param{
+			{Name: "expires", Type: "integer", Required: true},
+			{Name: "signature", Type: "string", Required: true},
+			openapi.Query("format", "string", "html for a web page instead of JSON"),
+		}},
+		{Method: "GET", Path: "/api/v1/public/profiles/:handle", Tag: "profiles", Summary: "Get a user's public profile by its handle", Public: true, Response: models.PublicProfile{}},
+		{Method: "POST", Path: "/api/v1/billing/stripe/webhook", Tag: "billing", Summary: "Receive a Stripe event", Public: true, Response: openapi.Object{"received": true}},
+
+		// Service accounts
+		{Method: "GET", Path: "/api/v1/analytics/orgs/:id/progress", Tag: "analytics", Summary: "Get the progress of an organization's members", Response: openapi.Object{"org_id": 0, "members": []models.OrgMemberProgress{}}},
+
+		// Feature flags
+		{Method: "GET", Path: "/api/v1/flags", Tag: "flags", Summary: "Get which feature flags are on for the current user", Response: openapi.Object{"flags": map[string]bool{}}},
+
+		// User
+		{Method: "GET", Path: "/api/v1/user/profile", Tag: "user", Summary: "Get the current user", Response: openapi.Object{"user": models.User{}}},
+		{Method: "PUT", Path: "/api/v1/user/profile", Tag: "user", Summary: "Update the current user", Body: models.UpdateUserRequest{}, Response: openapi.Object{"user": models.User{}}},
+		{Method: "DELETE", Path: "/api/v1/user/account", Tag: "user", Summary: "Delete the current user's account after confirming their credentials", Body: models.DeleteAccountRequest{}, Response: models.DeleteAccountResponse{}},
+		{Method: "GET", Path: "/api/v1/user/progress", Tag: "user", Summary: "List the current user's progress", Response: models.PaginatedProgressResponse{}, Query: withParams(page,
+			openapi.Query("sort_by", "string", "Field to sort by"),
+			openapi.Query("sort_order", "string", "asc or desc"),
+			openapi.Query("status", "string", "Only progress with this status"),
+		)},
+		{Method: "POST", Path: "/api/v1/user/import", Tag: "user", Summary: "Import progress from a CSV file", Upload: "file", Response: models.ProgressImportReport{}, Query: []openapi.Param{
+			openapi.Query("dry_run", "boolean", "Report what would change without saving"),
+		}},
+		{Method: "GET", Path: "/api/v1/user/ai-usage", Tag: "user", Summary: "Get the current user's AI usage", Response: models.AIUsage{}},
+		{Method: "GET", Path: "/api/v1/user/ai-settings", Tag: "user", Summary: "Get the current user's AI settings", Response: models.AISettings{}},
+		{Method: "PUT", Path: "/api/v1/user/ai-settings", Tag: "user", Summary: "Update the current user's AI settings", Body: models.UpdateAISettingsRequest{}, Response: models.AISettings{}},
+		{Method: "GET", Path: "/api/v1/user/goals", Tag: "user", Summary: "Get today's progress toward the daily goal", Response: models.DailyGoalProgress{}},
+		{Method: "PUT", Path: "/api/v1/user/goals", Tag: "user", Summary: "Set the daily goal", Body: models.UpdateDailyGoalRequest{}, Response: models.DailyGoalProgress{}},
+		{Method: "GET", Path: "/api/v1/user/notifications", Tag: "notifications", Summary: "Get notification preferences", Response: models.NotificationPreferences{}},
+		{Method: "PUT", Path: "/api/v1/user/notifications", Tag: "notifications", Summary: "Update notification preferences", Body: models.UpdateNotificationPreferencesRequest{}, Response: models.NotificationPreferences{}},
+		{Method: "GET", Path: "/api/v1/user/devices", Tag: "notifications", Summary: "List push devices", Response: openapi.Object{"devices": []models.Device{}, "vapid_public_key": ""}},
+		{Method: "POST", Path: "/api/v1/user/devices", Tag: "notifications", Summary: "Register a push device", Body: models.RegisterDeviceRequest{}, Response: models.Device{}, Status: http.StatusCreated},
+		{Method: "DELETE", Path: "/api/v1/user/devices/:id", Tag: "notifications", Summary: "Remove a push device", Response: message},
+		{Method: "GET", Path: "/api/v1/user/webhooks", Tag: "webhooks", Summary: "List webhooks", Response: openapi.Object{"webhooks": []models.Webhook{}}},
+		{Method: "POST", Path: "/api/v1/user/webhooks", Tag: "webhooks", Summary: "Create a webhook", Body: models.CreateWebhookRequest{}, Response: models.Webhook{}, Status: http.StatusCreated},
+		{Method: "DELETE", Path: "/api/v1/user/webhooks/:id", Tag: "webhooks", Summary: "Delete a webhook", Response: message},
+		{Method: "GET", Path: "/api/v1/user/webhooks/:id/deliveries", Tag: "webhooks", Summary: "List a webhook's recent deliveries", Response: openapi.Object{"deliveries": []models.WebhookDelivery{}}},
+		{Method: "GET", Path: "/api/v1/user/api-keys", Tag: "api-keys", Summary: "List personal API keys", Response: openapi.Object{"api_keys": []models.APIKey{}}},
+		{Method: "POST", Path: "/api/v1/user/api-keys", Tag: "api-keys", Summary: "Create a personal API key; the key is only shown in this response", Body: models.CreateAPIKeyRequest{}, Response: models.CreateAPIKeyResponse{}, Status: http.StatusCreated},
+		{Method: "DELETE", Path: "/api/v1/user/api-keys/:id", Tag: "api-keys", Summary: "Revoke a personal API key", Response: message},
+		{Method: "GET", Path: "/api/v1/integrations/github", Tag: "github", Summary: "Get the connected GitHub account and solution repository", Response: models.GitHubConnection{}},
+		{Method: "PUT", Path: "/api/v1/integrations/github", Tag: "github", Summary: "Choose the repository solutions are committed to", Body: models.UpdateGitHubRepositoryRequest{}, Response: models.GitHubConnection{}},
+		{Method: "DELETE", Path: "/api/v1/integrations/github", Tag: "github", Summary: "Disconnect the GitHub account", Response: message},
+		{Method: "POST", Path: "/api/v1/integrations/github/authorize", Tag: "github", Summary: "Start connecting a GitHub account", Response: models.GitHubAuthorizeResponse{}},
+		{Method: "POST", Path: "/api/v1/integrations/github/callback", Tag: "github", Summary: "Finish connecting a GitHub account with the code GitHub returned", Body: models.CompleteGitHubAuthorizationRequest{}, Response: models.GitHubConnection{}},
+		{Method: "GET", Path: "/api/v1/integrations/:platform", Tag: "webhooks", Summary: "Get the Slack or Discord integration", Response: models.Webhook{}},
+		{Method: "PUT", Path: "/api/v1/integrations/:platform", Tag: "webhooks", Summary: "Connect a Slack or Discord incoming webhook", Body: models.ConnectIntegrationRequest{}, Response: models.Webhook{}},
+		{Method: "DELETE", Path: "/api/v1/integrations/:platform", Tag: "webhooks", Summary: "Disconnect a Slack or Discord integration", Response: message},
+		{Method: "POST", Path: "/api/v1/integrations/:platform/test", Tag: "webhooks", Summary: "Post a test message to a Slack or Discord integration", Response: message},
+		{Method: "GET", Path: "/api/v1/user/calendar", Tag: "calendar", Summary: "Get the calendar feed", Response: models.CalendarFeed{}},
+		{Method: "POST", Path: "/api/v1/user/calendar", Tag: "calendar", Summary: "Create or rotate the calendar feed", Response: models.CalendarFeed{}, Status: http.StatusCreated},
+		{Method: "DELETE", Path: "/api/v1/user/calendar", Tag: "calendar", Summary: "Revoke the calendar feed", Response: message},
+		{Method: "GET", Path: "/api/v1/user/public-profile", Tag: "profiles", Summary: "Get your public profile settings", Response: models.PublicProfileSettings{}},
+		{Method: "PUT", Path: "/api/v1/user/public-profile", Tag: "profiles", Summary: "Update your public profile settings", Body: models.UpdatePublicProfileRequest{}, Response: models.PublicProfileSettings{}},
+		{Method: "POST", Path: "/api/v1/user/reports/share", Tag: "reports", Summary: "Share a snapshot of your progress through a signed, expiring link", Body: models.ShareReportRequest{}, Response: models.SharedReportLink{}, Status: http.StatusCreated},
+		{Method: "POST", Path: "/api/v1/user/public-profile/handle", Tag: "profiles", Summary: "Regenerate your public profile handle, revoking the old one", Response: models.PublicProfileSettings{}},
+
+		// Items
+		{Method: "POST", Path: "/api/v1/items", Tag: "items", Summary: "Create an item", Body: models.CreateItemRequest{}, Response: models.Item{}, Status: http.StatusCreated},
+		{Method: "POST", Path: "/api/v1/items/bulk", Tag: "items", Summary: "Create items in bulk", Body: models.BulkCreateItemsRequest{}, Response: models.BulkCreateItemsResponse{}, Status: http.StatusCreated},
+		{Method: "GET", Path: "/api/v1/items", Tag: "items", Summary: "List items with the current user's progress", Query: withParams(itemFilters, fieldsParam), Response: []models.ItemWithProgress{}},
+		{Method: "GET", Path: "/api/v1/items/paginated", Tag: "items", Summary: "List a page of items", Response: models.PaginatedItemsResponse{}, Query: withParams(itemFilters,
+			openapi.Query("random_order", "boolean", "Shuffle the items"), fieldsParam,
+		)},
+		{Method: "GET", Path: "/api/v1/items/drafts", Tag: "items", Summary: "List draft items waiting to be published", Response: models.ItemDraftsResponse{}},
+		{Method: "GET", Path: "/api/v1/items/upcoming", Tag: "items", Summary: "List items scheduled to be published", Response: models.UpcomingReleasesResponse{}, Query: []openapi.Param{
+			openapi.Query("limit", "integer", "How many releases to list (default 20, max 100)"),
+		}},
+		{Method: "PUT", Path: "/api/v1/items/schedule", Tag: "items", Summary: "Schedule draft items to be published", Body: models.ScheduleItemsRequest{}, Response: openapi.Object{"items": []models.Item{}}},
+		{Method: "GET", Path: "/api/v1/items/export", Tag: "items", Summary: "Export items as a spreadsheet", ContentType: "application/octet-stream", Query: withParams(itemFilters,
+			openapi.Query("format", "string", "csv (the default) or xlsx"),
+		)},
+		{Method: "GET", Path: "/api/v1/items/next", Tag: "items", Summary: "Get the next item to practise", Query: []openapi.Param{fieldsParam}, Response: models.ItemWithProgress{}},
+		{Method: "POST", Path: "/api/v1/items/skip", Tag: "items", Summary: "Skip the current item", Response: models.ItemWithProgress{}},
+		{Method: "GET", Path: "/api/v1/items/subcategories/:category", Tag: "items", Summary: "List a category's subcategories", Response: openapi.Object{"category": "", "subcategories": []string{}}},
+		{Method: "GET", Path: "/api/v1/items/reviews/due", Tag: "items", Summary: "List items due for review", Query: []openapi.Param{openapi.Query("limit", "integer", "Maximum number of items")}, Response: openapi.Object{"items": []models.ItemWithProgress{}, "count": 0}},
+		{Method: "GET", Path: "/api/v1/items/:id", Tag: "items", Summary: "Get an item", Query: []openapi.Param{fieldsParam}, Response: models.ItemWithProgress{}},
+		{Method: "PUT", Path: "/api/v1/items/:id", Tag: "items", Summary: "Update an item", Body: models.UpdateItemRequest{}, Response: models.Item{}},
+		{Method: "PUT", Path: "/api/v1/items/:id/publish", Tag: "items", Summary: "Publish a draft item or take it back to draft", Body: models.PublishItemRequest{}, Response: models.Item{}},
+		{Method: "GET", Path: "/api/v1/items/:id/revisions", Tag: "items", Summary: "List the revisions of an item's content", Response: models.ItemRevisionsResponse{}},
+		{Method: "POST", Path: "/api/v1/items/:id/revisions/:revision_id/revert", Tag: "items", Summary: "Revert an item to one of its revisions", Body: models.RevertItemRequest{}, Response: models.Item{}},
+		{Method: "PUT", Path: "/api/v1/items/:id/complete", Tag: "items", Summary: "Complete an item", Body: models.CompleteItemRequest{}, Response: models.ItemWithProgress{}},
+		{Method: "PUT", Path: "/api/v1/items/:id/review", Tag: "items", Summary: "Record a review of an item", Body: models.ReviewItemRequest{}, Response: models.ItemWithProgress{}},
+		{Method: "PUT", Path: "/api/v1/items/:id/star", Tag: "items", Summary: "Toggle an item's star", Response: models.ItemWithProgress{}},
+		{Method: "PUT", Path: "/api/v1/items/star/batch", Tag: "items", Summary: "Star or unstar items in bulk", Body: models.BatchStarRequest{}, Response: models.BatchStarResult{}},
+		{Method: "PUT", Path: "/api/v1/items/:id/status", Tag: "items", Summary: "Set an item's status", Body: struct {
+			Status models.Status `json:"status" binding:"required"`
+		}{}, Response: models.ItemWithProgress{}},
+		{Method: "DELETE", Path: "/api/v1/items/:id", Tag: "items", Summary: "Delete an item", Response: message},
+		{Method: "GET", Path: "/api/v1/items/:id/similar", Tag: "items", Summary: "List items related in meaning to an item", Response: models.SimilarItemsResponse{}, Query: []openapi.Param{
+			openapi.Query("limit", "integer", "Most items to return (default 10, max 50)"),
+		}},
+		{Method: "POST", Path: "/api/v1/items/reset", Tag: "items", Summary: "Reset the current user's progress", Response: openapi.Object{"message": "", "items_updated": int64(0)}},
+		{Method: "GET", Path: "/api/v1/items/:id/attachments", Tag: "attachments", Summary: "List an item's attachments", Response: openapi.Object{"attachments": []models.FileAttachment{}}},
+		{Method: "POST", Path: "/api/v1/items/:id/attachments/upload", Tag: "attachments", Summary: "Upload an attachment", Upload: "file", Response: models.FileAttachment{}, Status: http.StatusCreated},
+		{Method: "POST", Path: "/api/v1/items/:id/attachments/secret", Tag: "attachments", Summary: "Attach an encrypted secret", Body: models.CreateSecretAttachmentRequest{}, Response: models.FileAttachment{}, Status: http.StatusCreated},
+		{Method: "DELETE", Path: "/api/v1/items/:id/attachments/:attachment_id", Tag: "attachments", Summary: "Delete an attachment", Response: message},
+		{Method: "GET", Path: "/api/v1/items/:id/hints", Tag: "hints", Summary: "Get the hints revealed so far", Response: models.HintLadderResponse{}},
+		{Method: "GET", Path: "/api/v1/items/:id/hints/all", Tag: "hints", Summary: "List all of an item's hints", Response: openapi.Object{"item_id": 0, "hints": []models.ItemHint{}}},
+		{Method: "POST", Path: "/api/v1/items/:id/hints", Tag: "hints", Summary: "Add a hint", Body: models.CreateHintRequest{}, Response: models.ItemHint{}, Status: http.StatusCreated},
+		{Method: "POST", Path: "/api/v1/items/:id/hints/reveal", Tag: "hints", Summary: "Reveal the next hint", Response: models.HintLadderResponse{}},
+		{Method: "PUT", Path: "/api/v1/items/:id/hints/:hint_id", Tag: "hints", Summary: "Update a hint", Body: models.UpdateHintRequest{}, Response: models.ItemHint{}},
+		{Method: "DELETE", Path: "/api/v1/items/:id/hints/:hint_id", Tag: "hints", Summary: "Delete a hint", Response: message},
+		{Method: "POST", Path: "/api/v1/items/:id/share", Tag: "shares", Summary: "Share an item", Body: models.ShareItemRequest{}, Response: models.ItemShare{}, Status: http.StatusCreated},
+		{Method: "POST", Path: "/api/v1/items/:id/feedback", Tag: "feedback", Summary: "Report a problem with an item", Body: models.CreateFeedbackRequest{}, Response: models.ItemFeedback{}, Status: http.StatusCreated},
+		{Method: "GET", Path: "/api/v1/items/:id/flashcards", Tag: "flashcards", Summary: "List an item's flashcards", Response: openapi.Object{"flashcards": []models.Flashcard{}}},
+		{Method: "POST", Path: "/api/v1/items/:id/flashcards", Tag: "flashcards", Summary: "Create a flashcard", Body: models.CreateFlashcardRequest{}, Response: models.Flashcard{}, Status: http.StatusCreated},
+		{Method: "GET", Path: "/api/v1/items/:id/companies", Tag: "companies", Summary: "List the companies that ask an item", Response: []models.Company{}},
+		{Method: "PUT", Path: "/api/v1/items/:id/companies", Tag: "companies", Summary: "Set the companies that ask an item", Body: models.SetItemCompaniesRequest{}, Response: []models.Company{}},
+		{Method: "GET", Path: "/api/v1/items/:id/design-notes", Tag: "design notes", Summary: "Get an item's design notes", Response: models.ItemDesignNotes{}},
+		{Method: "PUT", Path: "/api/v1/items/:id/design-notes", Tag: "design notes", Summary: "Save an item's design notes", Body: models.SaveDesignNotesRequest{}, Response: models.ItemDesignNotes{}},
+		{Method: "DELETE", Path: "/api/v1/items/:id/design-notes", Tag: "design notes", Summary: "Delete an item's design notes", Response: message},
+		{Method: "GET", Path: "/api/v1/items/:id/test-cases", Tag: "submissions", Summary: "List an item's test cases", Response: openapi.Object{"test_cases": []models.ItemTestCase{}}},
+		{Method: "POST", Path: "/api/v1/items/:id/test-cases", Tag: "submissions", Summary: "Add a test case", Body: models.CreateItemTestCaseRequest{}, Response: models.ItemTestCase{}, Status: http.StatusCreated},
+		{Method: "PUT", Path: "/api/v1/items/:id/test-cases/:case_id", Tag: "submissions", Summary: "Update a test case", Body: models.UpdateItemTestCaseRequest{}, Response: models.ItemTestCase{}},
+		{Method: "DELETE", Path: "/api/v1/items/:id/test-cases/:case_id", Tag: "submissions", Summary: "Delete a test case", Response: message},
+		{Method: "POST", Path: "/api/v1/items/:id/submit", Tag: "submissions", Summary: "Run a solution against the test cases", Body: models.SubmitSolutionRequest{}, Response: models.SubmissionResult{}},
+		{Method: "GET", Path: "/api/v1/items/:id/submissions", Tag: "submissions", Summary: "List past submissions", Response: openapi.Object{"submissions": []models.SubmissionAttempt{}}},
+
+		// Companies and categories
+		{Method: "GET", Path: "/api/v1/companies", Tag: "companies", Summary: "List companies", Response: []models.Company{}},
+		{Method: "POST", Path: "/api/v1/companies", Tag: "companies", Summary: "Create a company", Body: models.CreateCompanyRequest{}, Response: models.Company{}, Status: http.StatusCreated},
+		{Method: "DELETE", Path: "/api/v1/companies/:slug", Tag: "companies", Summary: "Delete a company", Response: message},
+		{Method: "GET", Path: "/api/v1/categories", Tag: "categories", Summary: "List categories", Response: []models.CategoryDefinition{}},
+		{Method: "POST", Path: "/api/v1/categories", Tag: "categories", Summary: "Create a category", Body: models.CreateCategoryRequest{}, Response: models.CategoryDefinition{}, Status: http.StatusCreated},
+		{Method: "PUT", Path: "/api/v1/categories/:slug", Tag: "categories", Summary: "Update a category", Body: models.UpdateCategoryRequest{}, Response: models.CategoryDefinition{}},
+		{Method: "DELETE", Path: "/api/v1/categories/:slug", Tag: "categories", Summary: "Delete a category", Response: message},
+
+		// Behavioral questions
+		{Method: "GET", Path: "/api/v1/behavioral/competencies", Tag: "behavioral", Summary: "List competencies", Response: openapi.Object{"competencies": []models.Competency{}}},
+		{Method: "GET", Path: "/api/v1/behavioral/practice", Tag: "behavioral", Summary: "Pick questions to practise", Query: []openapi.Param{openapi.Query("count", "integer", "Number of questions")}, Response: models.BehavioralPracticeResponse{}},
+		{Method: "GET", Path: "/api/v1/behavioral/questions", Tag: "behavioral", Summary: "List behavioral questions", Response: models.BehavioralQuestionsResponse{}, Query: withParams(page,
+			openapi.Query("answered", "boolean", "Only answered or unanswered questions"),
+		)},
+		{Method: "POST", Path: "/api/v1/behavioral/questions", Tag: "behavioral", Summary: "Create a behavioral question", Body: models.CreateBehavioralQuestionRequest{}, Response: models.BehavioralQuestion{}, Status: http.StatusCreated},
+		{Method: "GET", Path: "/api/v1/behavioral/questions/:id", Tag: "behavioral", Summary: "Get a behavioral question", Response: models.BehavioralQuestion{}},
+		{Method: "PUT", Path: "/api/v1/behavioral/questions/:id", Tag: "behavioral", Summary: "Update a behavioral question", Body: models.UpdateBehavioralQuestionRequest{}, Response: models.BehavioralQuestion{}},
+		{Method: "DELETE", Path: "/api/v1/behavioral/questions/:id", Tag: "behavioral", Summary: "Delete a behavioral question", Response: message},
+		{Method: "PUT", Path: "/api/v1/behavioral/questions/:id/answer", Tag: "behavioral", Summary: "Save an answer", Body: models.SaveBehavioralAnswerRequest{}, Response: models.BehavioralQuestion{}},
+		{Method: "DELETE", Path: "/api/v1/behavioral/questions/:id/answer", Tag: "behavioral", Summary: "Delete an answer", Response: message},
+		{Method: "POST", Path: "/api/v1/behavioral/questions/:id/practice", Tag: "behavioral", Summary: "Record a practice run", Response: models.BehavioralQuestion{}},
+
+		// AI
+		{Method: "POST", Path: "/api/v1/ai/generate-question", Tag: "ai", Summary: "Generate follow-up questions or variations of an item", Body: models.GenerateQuestionRequest{}, Response: models.GeneratedQuestions{}},
+		{Method: "POST", Path: "/api/v1/ai/summarize", Tag: "ai", Summary: "Summarize your notes into a revision sheet", Body: models.SummarizeNotesRequest{}, Response: models.Document{}, Status: http.StatusCreated},
+		{Method: "GET", Path: "/api/v1/ai/mock-interviews", Tag: "ai", Summary: "List your mock interviews", Response: models.MockInterviewsResponse{}, Query: []openapi.Param{
+			openapi.Query("item_id", "integer", "Only interviews on this item"),
+			openapi.Query("test_session_id", "string", "Only interviews started from this test"),
+			openapi.Query("limit", "integer", "Most interviews to return (default 20, max 100)"),
+		}},
+		{Method: "POST", Path: "/api/v1/ai/mock-interviews", Tag: "ai", Summary: "Start a mock interview on an item", Body: models.StartMockInterviewRequest{}, Response: models.MockInterview{}, Status: http.StatusCreated},
+		{Method: "GET", Path: "/api/v1/ai/mock-interviews/:id", Tag: "ai", Summary: "Get a mock interview with its transcript", Response: models.MockInterview{}},
+		{Method: "POST", Path: "/api/v1/ai/mock-interviews/:id/messages", Tag: "ai", Summary: "Reply to the interviewer", Body: models.MockInterviewReplyRequest{}, Response: models.MockInterview{}},
+		{Method: "POST", Path: "/api/v1/ai/mock-interviews/:id/end", Tag: "ai", Summary: "End a mock interview with feedback", Response: models.MockInterview{}},
+		{Method: "DELETE", Path: "/api/v1/ai/mock-interviews/:id", Tag: "ai", Summary: "Delete a mock interview", Response: message},
+
+		// Documents
+		{Method: "GET", Path: "/api/v1/documents", Tag: "documents", Summary: "List your documents", Response: models.DocumentsResponse{}, Query: []openapi.Param{
+			openapi.Query("category", "string", "Only documents about this category"),
+		}},
+		{Method: "GET", Path: "/api/v1/documents/:id", Tag: "documents", Summary: "Get a document", Response: models.Document{}},
+		{Method: "DELETE", Path: "/api/v1/documents/:id", Tag: "documents", Summary: "Delete a document", Response: message},
+
+		// Quizzes
+		{Method: "GET", Path: "/api/v1/quizzes", Tag: "quizzes", Summary: "List quizzes", Response: models.QuizzesResponse{}, Query: []openapi.Param{
+			openapi.Query("category", "string", "Only quizzes in this category"),
+			openapi.Query("subcategory", "string", "Only quizzes in this subcategory"),
+		}},
+		{Method: "POST", Path: "/api/v1/quizzes", Tag: "quizzes", Summary: "Create a quiz", Body: models.SaveQuizRequest{}, Response: models.Quiz{}, Status: http.StatusCreated},
+		{Method: "GET", Path: "/api/v1/quizzes/:id", Tag: "quizzes", Summary: "Get a quiz", Response: models.Quiz{}},
+		{Method: "PUT", Path: "/api/v1/quizzes/:id", Tag: "quizzes", Summary: "Replace a quiz and its questions", Body: models.SaveQuizRequest{}, Response: models.Quiz{}},
+		{Method: "DELETE", Path: "/api/v1/quizzes/:id", Tag: "quizzes", Summary: "Delete a quiz", Response: message},
+		{Method: "GET", Path: "/api/v1/quizzes/:id/attempts", Tag: "quizzes", Summary: "List your attempts at a quiz", Response: models.QuizAttemptsResponse{}},
+		{Method: "POST", Path: "/api/v1/quizzes/:id/attempts", Tag: "quizzes", Summary: "Submit answers to a quiz", Body: models.SubmitQuizAttemptRequest{}, Response: models.QuizAttempt{}, Status: http.StatusCreated},
+
+		// Interviews
+		{Method: "GET", Path: "/api/v1/interviews", Tag: "interviews", Summary: "List interviews", Response: openapi.Object{"interviews": []models.Interview{}}},
+		{Method: "POST", Path: "/api/v1/interviews", Tag: "interviews", Summary: "Create an interview", Body: models.CreateInterviewRequest{}, Response: models.Interview{}, Status: http.StatusCreated},
+		{Method: "GET", Path: "/api/v1/interviews/:id", Tag: "interviews", Summary: "Get an interview", Response: models.Interview{}},
+		{Method: "PUT", Path: "/api/v1/interviews/:id", Tag: "interviews", Summary: "Update an interview", Body: models.UpdateInterviewRequest{}, Response: models.Interview{}},
+		{Method: "DELETE", Path: "/api/v1/interviews/:id", Tag: "interviews", Summary: "Delete an interview", Response: message},
+		{Method: "POST", Path: "/api/v1/interviews/:id/stages", Tag: "interviews", Summary: "Add a stage", Body: models.CreateInterviewStageRequest{}, Response: models.InterviewStage{}, Status: http.StatusCreated},
+		{Method: "PUT", Path: "/api/v1/interviews/:id/stages/:stage_id", Tag: "interviews", Summary: "Update a stage", Body: models.UpdateInterviewStageRequest{}, Response: models.InterviewStage{}},
+		{Method: "DELETE", Path: "/api/v1/interviews/:id/stages/:stage_id", Tag: "interviews", Summary: "Delete a stage", Response: message},
+		{Method: "PUT", Path: "/api/v1/interviews/:id/stages/:stage_id/items", Tag: "interviews", Summary: "Set the items to prepare for a stage", Body: models.SetStagePrepItemsRequest{}, Response: models.InterviewStage{}},
+
+		// Billing
+		{Method: "GET", Path: "/api/v1/billing/subscription", Tag: "billing", Summary: "Get the current plan and entitlements", Response: openapi.Object{"billing_enabled": true, "entitlements": models.Entitlements{}}},
+		{Method: "POST", Path: "/api/v1/billing/trial", Tag: "billing", Summary: "Start a trial of the Pro plan", Response: openapi.Object{"billing_enabled": true, "entitlements": models.Entitlements{}}},
+		{Method: "POST", Path: "/api/v1/billing/checkout", Tag: "billing", Summary: "Start a checkout session", Response: models.BillingURLResponse{}},
+		{Method: "POST", Path: "/api/v1/billing/portal", Tag: "billing", Summary: "Open the billing portal", Response: models.BillingURLResponse{}},
+
+		// Focus sessions
+		{Method: "POST", Path: "/api/v1/sessions/start", Tag: "sessions", Summary: "Start a focus session", Body: models.StartFocusSessionRequest{}, Response: models.FocusSession{}, Status: http.StatusCreated},
+		{Method: "GET", Path: "/api/v1/sessions/active", Tag: "sessions", Summary: "Get the active focus session", Response: openapi.Object{"session": models.FocusSession{}}},
+		{Method: "GET", Path: "/api/v1/sessions/time", Tag: "sessions", Summary: "Summarize time spent", Query: []openapi.Param{openapi.Query("days", "integer", "Number of days to summarize")}, Response: models.FocusTimeSummary{}},
+		{Method: "POST", Path: "/api/v1/sessions/:id/pause", Tag: "sessions", Summary: "Pause a focus session", Response: models.FocusSession{}},
+		{Method: "POST", Path: "/api/v1/sessions/:id/resume", Tag: "sessions", Summary: "Resume a focus session", Response: models.FocusSession{}},
+		{Method: "POST", Path: "/api/v1/sessions/:id/stop", Tag: "sessions", Summary: "Stop a focus session", Response: models.FocusSession{}},
+
+		{Method: "GET", Path: "/api/v1/recommendations", Tag: "recommendations", Summary: "Recommend subcategories to practise", Response: openapi.Object{"recommendations": []models.SubcategoryRecommendation{}}, Query: []openapi.Param{
+			openapi.Query("limit", "integer", "Maximum number of recommendations"),
+			openapi.Query("items", "integer", "Number of items to suggest per recommendation"),
+		}},
+
+		// Flashcards
+		{Method: "GET", Path: "/api/v1/flashcards/review", Tag: "flashcards", Summary: "Get the flashcards due for review", Response: models.FlashcardReviewResponse{}},
+		{Method: "POST", Path: "/api/v1/flashcards/:id/grade", Tag: "flashcards", Summary: "Grade a flashcard review", Body: models.GradeFlashcardRequest{}, Response: models.Flashcard{}},
+		{Method: "PUT", Path: "/api/v1/flashcards/:id", Tag: "flashcards", Summary: "Update a flashcard", Body: models.UpdateFlashcardRequest{}, Response: models.Flashcard{}},
+		{Method: "DELETE", Path: "/api/v1/flashcards/:id", Tag: "flashcards", Summary: "Delete a flashcard", Response: message},
+
+		// Shares
+		{Method: "GET", Path: "/api/v1/shares/inbox", Tag: "shares", Summary: "List items shared with the current user", Response: openapi.Object{"shares": []models.ItemShare{}, "count": 0}},
+		{Method: "PUT", Path: "/api/v1/shares/:id/accept", Tag: "shares", Summary: "Accept a shared item", Response: openapi.Object{"message": "", "status": models.ShareStatus("")}},
+		{Method: "PUT", Path: "/api/v1/shares/:id/dismiss", Tag: "shares", Summary: "Dismiss a shared item", Response: openapi.Object{"message": "", "status": models.ShareStatus("")}},
+
+		// Study groups
+		{Method: "POST", Path: "/api/v1/groups", Tag: "groups", Summary: "Create a study group", Body: models.CreateGroupRequest{}, Response: models.StudyGroup{}, Status: http.StatusCreated},
+		{Method: "GET", Path: "/api/v1/groups", Tag: "groups", Summary: "List the current user's study groups", Response: openapi.Object{"groups": []models.StudyGroup{}}},
+		{Method: "POST", Path: "/api/v1/groups/join", Tag: "groups", Summary: "Join a study group with an invite code", Body: models.JoinGroupRequest{}, Response: models.StudyGroup{}},
+		{Method: "GET", Path: "/api/v1/groups/:id", Tag: "groups", Summary: "Get a study group and its members", Response: openapi.Object{"group": models.StudyGroup{}, "members": []models.GroupMember{}}},
+		{Method: "POST", Path: "/api/v1/groups/:id/leave", Tag: "groups", Summary: "Leave a study group", Response: message},
+		{Method: "POST", Path: "/api/v1/groups/:id/invite", Tag: "groups", Summary: "Invite someone to a study group", Body: models.InviteToGroupRequest{}, Response: message},
+		{Method: "GET", Path: "/api/v1/groups/:id/leaderboard", Tag: "groups", Summary: "Get a study group's leaderboard", Response: openapi.Object{"group_id": 0, "leaderboard": []models.GroupLeaderboardEntry{}}},
+		{Method: "GET", Path: "/api/v1/groups/:id/progress", Tag: "groups", Summary: "Get a study group's progress", Response: models.GroupProgress{}},
+		{Method: "GET", Path: "/api/v1/groups/:id/lists", Tag: "groups", Summary: "List a study group's item lists", Response: openapi.Object{"lists": []models.GroupItemList{}}},
+		{Method: "POST", Path: "/api/v1/groups/:id/lists", Tag: "groups", Summary: "Create an item list", Body: models.CreateGroupItemListRequest{}, Response: models.GroupItemList{}, Status: http.StatusCreated},
+
+		// Organizations
+		{Method: "GET", Path: "/api/v1/orgs", Tag: "organizations", Summary: "List your organizations", Response: openapi.Object{"organizations": []models.OrgMembership{}}},
+		{Method: "POST", Path: "/api/v1/orgs", Tag: "organizations", Summary: "Create an organization", Body: models.CreateOrganizationRequest{}, Response: models.Organization{}, Status: http.StatusCreated},
+		{Method: "GET", Path: "/api/v1/orgs/:id", Tag: "organizations", Summary: "Get an organization", Response: models.OrgMembership{}},
+		{Method: "GET", Path: "/api/v1/orgs/:id/members", Tag: "organizations", Summary: "List an organization's members", Response: openapi.Object{"members": []models.OrgMember{}}},
+		{Method: "PUT", Path: "/api/v1/orgs/:id/members/:user_id", Tag: "organizations", Summary: "Change a member's role", Body: models.UpdateOrgMemberRequest{}, Response: message},
+		{Method: "DELETE", Path: "/api/v1/orgs/:id/members/:user_id", Tag: "organizations", Summary: "Remove a member or leave an organization", Response: message},
+		{Method: "GET", Path: "/api/v1/orgs/:id/invitations", Tag: "organizations", Summary: "List an organization's invitations", Response: openapi.Object{"invitations": []models.OrgInvitation{}}},
+		{Method: "POST", Path: "/api/v1/orgs/:id/invitations", Tag: "organizations", Summary: "Invite members from a CSV file", Upload: "file", Response: models.BulkInvitationResponse{}, Status: http.StatusCreated},
+		{Method: "GET", Path: "/api/v1/orgs/:id/analytics", Tag: "organizations", Summary: "Get the organization's anonymized cohort analytics", Query: []openapi.Param{openapi.Query("anonymize", "boolean", "Owners can pass false to show member names")}, Response: models.OrgCohortAnalytics{}},
+		{Method: "GET", Path: "/api/v1/orgs/:id/catalog", Tag: "organizations", Summary: "List the organization's curated items with your progress", Response: openapi.Object{"items": []models.OrgCatalogItem{}}},
+		{Method: "PUT", Path: "/api/v1/orgs/:id/catalog/:item_id", Tag: "organizations", Summary: "Add an item to the organization's catalog", Body: models.SetOrgCatalogItemRequest{}, Response: models.OrgCatalogItem{}},
+		{Method: "DELETE", Path: "/api/v1/orgs/:id/catalog/:item_id", Tag: "organizations", Summary: "Remove an item from the organization's catalog", Response: message},
+		{Method: "POST", Path: "/api/v1/orgs/invitations/accept", Tag: "organizations", Summary: "Accept an organization invitation", Body: models.AcceptInvitationRequest{}, Response: openapi.Object{"message": "", "organization": models.Organization{}}},
+		{Method: "GET", Path: "/api/v1/orgs/:id/service-accounts", Tag: "organizations", Summary: "List service accounts", Response: openapi.Object{"service_accounts": []models.ServiceAccount{}}},
+		{Method: "POST", Path: "/api/v1/orgs/:id/service-accounts", Tag: "organizations", Summary: "Create a service account", Body: models.CreateServiceAccountRequest{}, Response: models.CreateServiceAccountResponse{}, Status: http.StatusCreated},
+		{Method: "DELETE", Path: "/api/v1/orgs/:id/service-accounts/:account_id", Tag: "organizations", Summary: "Revoke a service account", Response: message},
+
+		// Admin
+		{Method: "PUT", Path: "/api/v1/admin/users/:id/role", Tag: "admin", Summary: "Assign a user a role", Body: models.UpdateUserRoleRequest{}, Response: models.User{}},
+		{Method: "GET", Path: "/api/v1/admin/roles", Tag: "admin", Summary: "List roles and the permissions they grant", Response: openapi.Object{"roles": []models.RoleDefinition{}, "permissions": []models.Permission{}}},
+		{Method: "PUT", Path: "/api/v1/admin/roles/:name", Tag: "admin", Summary: "Create a role or change its permissions", Body: models.SaveRoleRequest{}, Response: models.RoleDefinition{}},
+		{Method: "DELETE", Path: "/api/v1/admin/roles/:name", Tag: "admin", Summary: "Delete a role nobody holds", Response: message},
+		{Method: "POST", Path: "/api/v1/admin/orgs", Tag: "admin", Summary: "Create an organization", Body: models.CreateOrganizationRequest{}, Response: models.Organization{}, Status: http.StatusCreated},
+		{Method: "GET", Path: "/api/v1/admin/orgs/:id/invitations", Tag: "admin", Summary: "List an organization's invitations", Response: openapi.Object{"invitations": []models.OrgInvitation{}}},
+		{Method: "POST", Path: "/api/v1/admin/orgs/:id/invitations", Tag: "admin", Summary: "Invite members from a CSV file", Upload: "file", Response: models.BulkInvitationResponse{}, Status: http.StatusCreated},
+		{Method: "GET", Path: "/api/v1/admin/orgs/:id/analytics", Tag: "admin", Summary: "Get an organization's cohort analytics", Query: []openapi.Param{openapi.Query("anonymize", "boolean", "Hide member names")}, Response: models.OrgCohortAnalytics{}},
+		{Method: "GET", Path: "/api/v1/admin/search", Tag: "admin", Summary: "Search items, users, engineering blogs and feedback", Response: models.AdminSearchResponse{}, Query: []openapi.Param{
+			openapi.Query("q", "string", "Text to find, at least 2 characters, or an ID"),
+			openapi.Query("types", "string", "Comma-separated types to search: item, user, eng_blog, feedback (default all you may search)"),
+			openapi.Query("limit", "integer", "Most results of each type (default 10, max 50)"),
+		}},
+		{Method: "GET", Path: "/api/v1/admin/lifecycle/runs", Tag: "admin", Summary: "List recent inactive-user archival runs", Query: []openapi.Param{openapi.Query("limit", "integer", "Maximum number of runs")}, Response: openapi.Object{"runs": []models.LifecycleRun{}}},
+		{Method: "PUT", Path: "/api/v1/admin/users/:id/plan", Tag: "admin", Summary: "Set a user's plan", Body: models.SetPlanRequest{}, Response: models.Entitlements{}},
+		{Method: "GET", Path: "/api/v1/admin/catalog/export", Tag: "admin", Summary: "Export the catalog", Response: models.CatalogSnapshot{}},
+		{Method: "POST", Path: "/api/v1/admin/catalog/apply", Tag: "admin", Summary: "Apply a catalog snapshot", Body: models.CatalogSnapshot{}, Response: models.CatalogDiff{}, Query: []openapi.Param{
+			openapi.Query("dry_run", "boolean", "Report the changes without applying them"),
+		}},
+		{Method: "GET", Path: "/api/v1/admin/analytics/items", Tag: "admin", Summary: "List item analytics", Response: models.ItemAnalyticsResponse{}, Query: withParams(page,
+			openapi.Query("sort", "string", "Metric to sort by"),
+			openapi.Query("order", "string", "asc or desc"),
+			openapi.Query("category", "string", "Only items in this category"),
+			openapi.Query("subcategory", "string", "Only items in this subcategory"),
+			openapi.Query("min_attempts", "integer", "Only items attempted at least this often"),
+		)},
+		{Method: "GET", Path: "/api/v1/admin/analytics/items/:id", Tag: "admin", Summary: "Get an item's analytics", Response: models.ItemAnalytics{}},
+		{Method: "GET", Path: "/api/v1/admin/feedback", Tag: "admin", Summary: "List reported problems", Response: models.FeedbackQueueResponse{}, Query: withParams(page,
+			openapi.Query("status", "string", "Only feedback with this status"),
+			openapi.Query("category", "string", "Only feedback in this category"),
+		)},
+		{Method: "PUT", Path: "/api/v1/admin/feedback/:id/resolve", Tag: "admin", Summary: "Resolve a reported problem", Body: models.ReviewFeedbackRequest{}, Response: openapi.Object{"message": "", "status": models.FeedbackStatus("")}},
+		{Method: "PUT", Path: "/api/v1/admin/feedback/:id/dismiss", Tag: "admin", Summary: "Dismiss a reported problem", Body: models.ReviewFeedbackRequest{}, Response: openapi.Object{"message": "", "status": models.FeedbackStatus("")}},
+		{Method: "GET", Path: "/api/v1/admin/links/dead", Tag: "admin", Summary: "List dead links", Response: models.DeadLinksResponse{}, Query: withParams(page,
+			openapi.Query("type", "string", "Only links of this target type"),
+		)},
+		{Method: "GET", Path: "/api/v1/admin/jobs", Tag: "admin", Summary: "List background jobs with their last runs", Response: openapi.Object{"jobs": []models.JobStatus{}}},
+		{Method: "GET", Path: "/api/v1/admin/jobs/:name/runs", Tag: "admin", Summary: "List a background job's recent runs", Response: openapi.Object{"runs": []models.JobRun{}}},
+		{Method: "POST", Path: "/api/v1/admin/jobs/:name/run", Tag: "admin", Summary: "Start a background job now", Response: openapi.Object{"message": "", "job": ""}, Status: http.StatusAccepted},
+		{Method: "GET", Path: "/api/v1/admin/flags", Tag: "admin", Summary: "List feature flags with their rollouts", Response: openapi.Object{"flags": []models.FeatureFlag{}}},
+		{Method: "PUT", Path: "/api/v1/admin/flags/:key", Tag: "admin", Summary: "Create a feature flag or change its rollout", Body: models.UpdateFeatureFlagRequest{}, Response: models.FeatureFlag{}},
+		{Method: "DELETE", Path: "/api/v1/admin/flags/:key", Tag: "admin", Summary: "Delete a feature flag", Response: message},
+		{Method: "GET", Path: "/api/v1/admin/test-eligibility", Tag: "admin", Summary: "Get the rules for starting a test", Response: models.TestEligibilityPolicy{}},
+		{Method: "PUT", Path: "/api/v1/admin/test-eligibility", Tag: "admin", Summary: "Replace the rules for starting a test", Body: models.UpdateTestEligibilityRequest{}, Response: models.TestEligibilityPolicy{}},
+
+		// Stats
+		{Method: "GET", Path: "/api/v1/stats", Tag: "stats", Summary: "Get overall stats", Query: []openapi.Param{fieldsParam}, Response: models.Stats{}},
+		{Method: "GET", Path: "/api/v1/stats/detailed", Tag: "stats", Summary: "Get stats by category and subcategory", Query: []openapi.Param{fieldsParam}, Response: models.DetailedStats{}},
+		{Method: "GET", Path: "/api/v1/stats/category/:category", Tag: "stats", Summary: "Get a category's stats", Query: []openapi.Param{fieldsParam}, Response: models.CategoryStats{}},
+		{Method: "GET", Path: "/api/v1/stats/category/:category/subcategory/:subcategory", Tag: "stats", Summary: "Get a subcategory's stats", Query: []openapi.Param{fieldsParam}, Response: models.SubcategoryStats{}},
+		{Method: "GET", Path: "/api/v1/stats/companies", Tag: "stats", Summary: "Get stats by company", Response: []models.CompanyStats{}},
+		{Method: "POST", Path: "/api/v1/stats/reset-completed-all", Tag: "stats", Summary: "Reset the completed all count", Response: message},
+
+		// Engineering blogs
+		{Method: "GET", Path: "/api/v1/eng-blogs", Tag: "eng blogs", Summary: "List engineering blogs", Response: models.EngBlogsResponse{}, Query: withParams(page,
+			openapi.Query("q", "string", "Search text"),
+		)},
+		{Method: "GET", Path: "/api/v1/eng-blogs/:id", Tag: "eng blogs", Summary: "Get an engineering blog", Response: models.EngBlog{}, StringParams: []string{"id"}},
+		{Method: "DELETE", Path: "/api/v1/eng-blogs/:id", Tag: "eng blogs", Summary: "Archive an engineering blog", Response: message},
+		{Method: "POST", Path: "/api/v1/eng-blogs/:id/restore", Tag: "eng blogs", Summary: "Restore an engineering blog", Response: message},
+		{Method: "DELETE", Path: "/api/v1/eng-blogs/:id/articles/:articleId", Tag: "eng blogs", Summary: "Archive an article", Response: message},
+		{Method: "POST", Path: "/api/v1/eng-blogs/:id/articles/:articleId/restore", Tag: "eng blogs", Summary: "Restore an article", Response: message},
+		{Method: "POST", Path: "/api/v1/eng-blogs/:id/articles/:articleId/promote", Tag: "eng blogs", Summary: "Turn an article into an item", Body: models.PromoteArticleRequest{}, Response: models.Item{}, Status: http.StatusCreated},
+
+		// Tests
+		{Method: "POST", Path: "/api/v1/tests", Tag: "tests", Summary: "Start a test", Response: models.CreateTestResponse{}, Status: http.StatusCreated},
+		{Method: "GET", Path: "/api/v1/tests/active", Tag: "tests", Summary: "Get the active test", Response: models.ActiveTestResponse{}},
+		{Method: "GET", Path: "/api/v1/tests/history", Tag: "tests", Summary: "List the latest tests with their items", Response: openapi.Object{"sessions": []models.TestSession{}}, Query: []openapi.Param{
+			openapi.Query("limit", "integer", "Maximum number of tests, at most 50"),
+		}},
+		{Method: "GET", Path: "/api/v1/tests/can-create", Tag: "tests", Summary: "Check whether a test can be started", Response: models.TestEligibility{}},
+		{Method: "PUT", Path: "/api/v1/tests/:session_id/items/:item_id/complete", Tag: "tests", Summary: "Complete an item in a test", Response: openapi.Object{"message": "", "session_id": ""}, StringParams: []string{"session_id"}},
+		{Method: "PUT", Path: "/api/v1/tests/:session_id/items/:item_id/abandon", Tag: "tests", Summary: "Abandon an item in a test", Response: openapi.Object{"message": "", "session_id": ""}, StringParams: []string{"session_id"}},
+		{Method: "PUT", Path: "/api/v1/tests/:session_id/:item_id/complete", Tag: "tests", Summary: "Complete an item in a test (use /tests/{session_id}/items/{item_id}/complete)", Deprecated: true, Response: openapi.Object{"message": "", "session_id": ""}, StringParams: []string{"session_id"}},
+		{Method: "PUT", Path: "/api/v1/tests/:session_id/:item_id/abandon", Tag: "tests", Summary: "Abandon an item in a test (use /tests/{session_id}/items/{item_id}/abandon)", Deprecated: true, Response: openapi.Object{"message": "", "session_id": ""}, StringParams: []string{"session_id"}},
+		{Method: "PUT", Path: "/api/v1/tests/:session_id/items/:item_id/result", Tag: "tests", Summary: "Record how a test item went", Body: models.RecordTestResultRequest{}, Response: models.TestItemResult{}, StringParams: []string{"session_id"}},
+		{Method: "POST", Path: "/api/v1/tests/:session_id/retake", Tag: "tests", Summary: "Retake the items missed in a test", Response: models.CreateTestResponse{}, Status: http.StatusCreated, StringParams: []string{"session_id"}},
+		{Method: "DELETE", Path: "/api/v1/tests/:session_id", Tag: "tests", Summary: "Delete a test", Response: openapi.Object{"message": "", "session_id": ""}, StringParams: []string{"session_id"}},
+	}
+}
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"interview-prep-app/internal/config"
+	"interview-prep-app/internal/openapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newTestServer() *Server {
+	gin.SetMode(gin.TestMode)
+	s := New(&config.Config{Environment: "test"}, Handlers{}, nil)
+	s.setupMiddleware()
+	s.setupRoutes()
+	return s
+}
+
+// documented reports whether the OpenAPI document should cover a route
+func documented(path string) bool {
+	return strings.HasPrefix(path, "/api/") || strings.HasPrefix(path, "/health") || strings.HasPrefix(path, "/.well-known/")
+}
+
+func TestOpenAPIMatchesRoutes(t *testing.T) {
+	s := newTestServer()
+
+	routes := map[string]bool{}
+	for _, route := range s.router.Routes() {
+		if documented(route.Path) {
+			routes[route.Method+" "+route.Path] = true
+		}
+	}
+
+	operations := map[string]bool{}
+	for _, op := range apiOperations() {
+		key := op.Method + " " + op.Path
+		operations[key] = true
+		if !routes[key] {
+			t.Errorf("%s is documented but not routed", key)
+		}
+	}
+	for key := range routes {
+		if !operations[key] {
+			t.Errorf("%s is routed but not documented; add it to apiOperations", key)
+		}
+	}
+}
+
+var refPattern = regexp.MustCompile(`"\$ref":"#/components/schemas/(\w+)"`)
+
+func TestOpenAPIDocument(t *testing.T) {
+	s := newTestServer()
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/openapi.json", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var doc openapi.Document
+	if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("Expected a JSON document, got %v", err)
+	}
+	if doc.OpenAPI == "" || len(doc.Paths) == 0 {
+		t.Fatalf("Expected a populated document, got version %q with %d paths", doc.OpenAPI, len(doc.Paths))
+	}
+
+	for _, match := range refPattern.FindAllStringSubmatch(w.Body.String(), -1) {
+		if _, ok := doc.Components.Schemas[match[1]]; !ok {
+			t.Errorf("Schema %s is referenced but not defined", match[1])
+		}
+	}
+
+	login := doc.Paths["/api/v1/auth/login"]["post"]
+	if login == nil || login.Security == nil || len(*login.Security) != 0 {
+		t.Error("Expected login to be documented as public")
+	}
+	item := doc.Paths["/api/v1/items/{id}"]["get"]
+	if item == nil || len(item.Parameters) != 2 || item.Parameters[0].Schema.Type != "integer" || item.Parameters[1].Name != "fields" {
+		t.Errorf("Expected an integer id parameter and a fields query parameter, got %+v", item)
+	}
+}
@@ -0,0 +1,89 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"interview-prep-app/internal/config"
+	"interview-prep-app/internal/csrf"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestSecurityHeaders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	s := New(&config.Config{Environment: "test", HSTSMaxAgeSeconds: 600, FrameOptions: "DENY"}, Handlers{}, nil)
+	s.setupMiddleware()
+	s.setupRoutes()
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	expected := map[string]string{
+		"X-Content-Type-Options":    "nosniff",
+		"X-Frame-Options":           "DENY",
+		"Strict-Transport-Security": "max-age=600; includeSubDomains",
+	}
+	for header, value := range expected {
+		if got := w.Header().Get(header); got != value {
+			t.Errorf("Expected %s %q, got %q", header, value, got)
+		}
+	}
+}
+
+func TestCSRF(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	s := New(&config.Config{Environment: "test", CSRFEnabled: true, CSRFSessionCookies: []string{"prepmaster_session"}}, Handlers{}, nil)
+	s.setupMiddleware()
+	s.setupRoutes()
+
+	w := httptest.NewRecorder()
+	issue := httptest.NewRequest(http.MethodGet, "/api/v1/auth/csrf", nil)
+	issue.AddCookie(&http.Cookie{Name: "prepmaster_session", Value: "session"})
+	s.router.ServeHTTP(w, issue)
+	var body struct {
+		Token string `json:"csrf_token"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil || body.Token == "" {
+		t.Fatalf("Expected a token, got %d %s", w.Code, w.Body.String())
+	}
+
+	testCases := []struct {
+		name      string
+		session   string
+		cookie    string
+		header    string
+		forbidden bool
+	}{
+		{name: "Bearer clients need no token"},
+		{name: "Session without a token", session: "session", forbidden: true},
+		{name: "Session with a mismatched token", session: "session", cookie: body.Token, header: "forged", forbidden: true},
+		{name: "Another session with the token", session: "other-session", cookie: body.Token, header: body.Token, forbidden: true},
+		{name: "Session with the token", session: "session", cookie: body.Token, header: body.Token},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/items", nil)
+			if tc.session != "" {
+				req.AddCookie(&http.Cookie{Name: "prepmaster_session", Value: tc.session})
+			}
+			if tc.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: csrf.CookieName, Value: tc.cookie})
+			}
+			if tc.header != "" {
+				req.Header.Set(csrf.HeaderName, tc.header)
+			}
+
+			w := httptest.NewRecorder()
+			s.router.ServeHTTP(w, req)
+
+			// Requests that pass the CSRF check go on to fail authentication
+			if forbidden := w.Code == http.StatusForbidden; forbidden != tc.forbidden {
+				t.Errorf("Expected forbidden %v, got %d %s", tc.forbidden, w.Code, w.Body.String())
+			}
+		})
+	}
+}
@@ -1,29 +1,138 @@
 package server
 
 import (
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
 	"interview-prep-app/internal/config"
+	"interview-prep-app/internal/csrf"
+	"interview-prep-app/internal/flags"
 	"interview-prep-app/internal/handlers"
 	"interview-prep-app/internal/middleware"
+	"interview-prep-app/internal/models"
 	"interview-prep-app/internal/repositories"
+	"interview-prep-app/internal/requestuser"
+	"interview-prep-app/internal/storage"
+	"interview-prep-app/pkg/apperr"
 
 	"github.com/gin-gonic/gin"
 )
 
 // Server represents the HTTP server
 type Server struct {
-	config           *config.Config
-	router           *gin.Engine
-	itemHandler      *handlers.ItemHandler
-	statsHandler     *handlers.StatsHandler
-	authHandler      *handlers.AuthHandler
-	engBlogHandler   *handlers.EngBlogHandler
-	testHandler      *handlers.TestHandler
-	userProgressRepo *repositories.UserProgressRepository
+	config             *config.Config
+	router             *gin.Engine
+	itemHandler        *handlers.ItemHandler
+	statsHandler       *handlers.StatsHandler
+	authHandler        *handlers.AuthHandler
+	engBlogHandler     *handlers.EngBlogHandler
+	testHandler        *handlers.TestHandler
+	attachmentHandler  *handlers.AttachmentHandler
+	hintHandler        *handlers.HintHandler
+	shareHandler       *handlers.ShareHandler
+	orgHandler         *handlers.OrgHandler
+	groupHandler       *handlers.GroupHandler
+	notifyHandler      *handlers.NotificationHandler
+	webhookHandler     *handlers.WebhookHandler
+	apiKeyHandler      *handlers.APIKeyHandler
+	calendarHandler    *handlers.CalendarHandler
+	profileHandler     *handlers.ProfileHandler
+	reportHandler      *handlers.ReportHandler
+	githubHandler      *handlers.GitHubHandler
+	jobHandler         *handlers.JobHandler
+	flagHandler        *handlers.FlagHandler
+	adminHandler       *handlers.AdminHandler
+	lifecycleHandler   *handlers.LifecycleHandler
+	flashcardHandler   *handlers.FlashcardHandler
+	progressHandler    *handlers.ProgressHandler
+	companyHandler     *handlers.CompanyHandler
+	categoryHandler    *handlers.CategoryHandler
+	behavioralHandler  *handlers.BehavioralHandler
+	quizHandler        *handlers.QuizHandler
+	documentHandler    *handlers.DocumentHandler
+	interviewerHandler *handlers.MockInterviewHandler
+	similarHandler     *handlers.SimilarItemHandler
+	designNotesHandler *handlers.DesignNotesHandler
+	submissionHandler  *handlers.SubmissionHandler
+	interviewHandler   *handlers.InterviewHandler
+	focusHandler       *handlers.FocusSessionHandler
+	aiHandler          *handlers.AIHandler
+	billingHandler     *handlers.BillingHandler
+	recommendHandler   *handlers.RecommendationHandler
+	catalogHandler     *handlers.CatalogHandler
+	feedbackHandler    *handlers.FeedbackHandler
+	linkCheckHandler   *handlers.LinkCheckHandler
+	configHandler      *handlers.ConfigHandler
+	healthHandler      *handlers.HealthHandler
+	debugHandler       *handlers.DebugHandler
+	metricsHandler     *handlers.MetricsHandler
+	loadUser           requestuser.LoadFunc
+	evaluateFlags      flags.EvaluateFunc
+	userProgressRepo   *repositories.UserProgressRepository
+	frontend           fs.FS
+	csrf               *csrf.Protector
 }
 
+// Handlers groups the HTTP handlers the server routes requests to
+type Handlers struct {
+	Item        *handlers.ItemHandler
+	Stats       *handlers.StatsHandler
+	Auth        *handlers.AuthHandler
+	EngBlog     *handlers.EngBlogHandler
+	Test        *handlers.TestHandler
+	Attachment  *handlers.AttachmentHandler
+	Hint        *handlers.HintHandler
+	Share       *handlers.ShareHandler
+	Org         *handlers.OrgHandler
+	Group       *handlers.GroupHandler
+	Notify      *handlers.NotificationHandler
+	Webhook     *handlers.WebhookHandler
+	APIKey      *handlers.APIKeyHandler
+	Calendar    *handlers.CalendarHandler
+	Profile     *handlers.ProfileHandler
+	Report      *handlers.ReportHandler
+	GitHub      *handlers.GitHubHandler
+	Job         *handlers.JobHandler
+	Flag        *handlers.FlagHandler
+	Admin       *handlers.AdminHandler
+	Lifecycle   *handlers.LifecycleHandler
+	Flashcard   *handlers.FlashcardHandler
+	Progress    *handlers.ProgressHandler
+	Company     *handlers.CompanyHandler
+	Category    *handlers.CategoryHandler
+	Behavioral  *handlers.BehavioralHandler
+	Quiz        *handlers.QuizHandler
+	Document    *handlers.DocumentHandler
+	Interviewer *handlers.MockInterviewHandler
+	Similar     *handlers.SimilarItemHandler
+	DesignNotes *handlers.DesignNotesHandler
+	Submission  *handlers.SubmissionHandler
+	Interview   *handlers.InterviewHandler
+	Focus       *handlers.FocusSessionHandler
+	AI          *handlers.AIHandler
+	Billing     *handlers.BillingHandler
+	Recommend   *handlers.RecommendationHandler
+	Catalog     *handlers.CatalogHandler
+	Feedback    *handlers.FeedbackHandler
+	LinkCheck   *handlers.LinkCheckHandler
+	Config      *handlers.ConfigHandler
+	Health      *handlers.HealthHandler
+	Debug       *handlers.DebugHandler   // nil unless debug endpoints are enabled
+	Metrics     *handlers.MetricsHandler // nil unless a metrics token is configured
+
+	// LoadUser loads the authenticated user once per request for handlers that need it;
+	// nil leaves each handler to look the user up itself
+	LoadUser requestuser.LoadFunc
+	// EvaluateFlags works out the authenticated user's feature flags for handlers that check
+	// one; nil leaves every flag off
+	EvaluateFlags flags.EvaluateFunc
+}
 
 // New creates a new server instance
-func New(cfg *config.Config, itemHandler *handlers.ItemHandler, statsHandler *handlers.StatsHandler, authHandler *handlers.AuthHandler, engBlogHandler *handlers.EngBlogHandler, testHandler *handlers.TestHandler, userProgressRepo *repositories.UserProgressRepository) *Server {
+func New(cfg *config.Config, h Handlers, userProgressRepo *repositories.UserProgressRepository) *Server {
 	// Set Gin mode based on environment
 	if cfg.IsProduction() {
 		gin.SetMode(gin.ReleaseMode)
@@ -32,24 +141,76 @@ func New(cfg *config.Config, itemHandler *handlers.ItemHandler, statsHandler *ha
 	router := gin.Default()
 
 	return &Server{
-		config:           cfg,
-		router:           router,
-		itemHandler:      itemHandler,
-		statsHandler:     statsHandler,
-		authHandler:      authHandler,
-		engBlogHandler:   engBlogHandler,
-		testHandler:      testHandler,
-		userProgressRepo: userProgressRepo,
+		config:             cfg,
+		router:             router,
+		itemHandler:        h.Item,
+		statsHandler:       h.Stats,
+		authHandler:        h.Auth,
+		engBlogHandler:     h.EngBlog,
+		testHandler:        h.Test,
+		attachmentHandler:  h.Attachment,
+		hintHandler:        h.Hint,
+		shareHandler:       h.Share,
+		orgHandler:         h.Org,
+		groupHandler:       h.Group,
+		notifyHandler:      h.Notify,
+		webhookHandler:     h.Webhook,
+		apiKeyHandler:      h.APIKey,
+		calendarHandler:    h.Calendar,
+		profileHandler:     h.Profile,
+		reportHandler:      h.Report,
+		githubHandler:      h.GitHub,
+		jobHandler:         h.Job,
+		flagHandler:        h.Flag,
+		adminHandler:       h.Admin,
+		lifecycleHandler:   h.Lifecycle,
+		flashcardHandler:   h.Flashcard,
+		progressHandler:    h.Progress,
+		companyHandler:     h.Company,
+		categoryHandler:    h.Category,
+		behavioralHandler:  h.Behavioral,
+		quizHandler:        h.Quiz,
+		documentHandler:    h.Document,
+		interviewerHandler: h.Interviewer,
+		similarHandler:     h.Similar,
+		designNotesHandler: h.DesignNotes,
+		submissionHandler:  h.Submission,
+		interviewHandler:   h.Interview,
+		focusHandler:       h.Focus,
+		aiHandler:          h.AI,
+		billingHandler:     h.Billing,
+		recommendHandler:   h.Recommend,
+		catalogHandler:     h.Catalog,
+		feedbackHandler:    h.Feedback,
+		linkCheckHandler:   h.LinkCheck,
+		configHandler:      h.Config,
+		healthHandler:      h.Health,
+		debugHandler:       h.Debug,
+		metricsHandler:     h.Metrics,
+		loadUser:           h.LoadUser,
+		evaluateFlags:      h.EvaluateFlags,
+		userProgressRepo:   userProgressRepo,
+		csrf:               csrf.NewProtector(cfg.JWTSecret),
 	}
 }
 
+// ServeFrontend serves the given frontend build for any route the API doesn't handle.
+// Unknown paths fall back to index.html so client-side routes survive a page reload.
+func (s *Server) ServeFrontend(assets fs.FS) {
+	s.frontend = assets
+}
+
 // setupMiddleware configures middleware for the server
 func (s *Server) setupMiddleware() {
+	// Security headers
+	s.router.Use(middleware.SecurityHeaders(s.config.HSTSMaxAgeSeconds, s.config.FrameOptions))
+
 	// CORS middleware
 	s.router.Use(func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization, If-None-Match, If-Modified-Since, If-Match, "+csrf.HeaderName)
+		c.Header("Access-Control-Expose-Headers", "ETag, Last-Modified")
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
@@ -59,13 +220,47 @@ func (s *Server) setupMiddleware() {
 		c.Next()
 	})
 
+	// Gzip compression, outside recovery so the responses it writes are compressed too
+	s.router.Use(middleware.Compress(int(s.config.CompressionMinBytes)))
+
 	// Recovery middleware
 	s.router.Use(gin.Recovery())
 
+	// Error envelope for errors handlers report with c.Error
+	s.router.Use(middleware.ErrorHandler())
+
+	// CSRF tokens for requests authenticated by a session cookie
+	if s.config.CSRFEnabled {
+		s.router.Use(middleware.CSRF(s.csrf, s.config.CSRFSessionCookies))
+	}
+
 	// Logger middleware (only in development)
 	if s.config.IsDevelopment() {
 		s.router.Use(gin.Logger())
 	}
+
+	// Injected latency (only when debug endpoints are enabled)
+	if s.debugHandler != nil {
+		s.router.Use(middleware.ChaosLatency(s.debugHandler))
+	}
+}
+
+// legacySuccessors maps each legacy route to the /api/v1 route replacing it
+var legacySuccessors = map[string]string{
+	"POST /items":             "/api/v1/items",
+	"GET /items":              "/api/v1/items",
+	"GET /items/next":         "/api/v1/items/next",
+	"POST /items/skip":        "/api/v1/items/skip",
+	"PUT /items/:id/complete": "/api/v1/items/:id/complete",
+	"GET /stats":              "/api/v1/stats",
+	"POST /reset":             "/api/v1/items/reset",
+}
+
+// testItemSuccessors maps the test item routes that named the item without an items segment to
+// the routes replacing them
+var testItemSuccessors = map[string]string{
+	"PUT /api/v1/tests/:session_id/:item_id/complete": "/api/v1/tests/:session_id/items/:item_id/complete",
+	"PUT /api/v1/tests/:session_id/:item_id/abandon":  "/api/v1/tests/:session_id/items/:item_id/abandon",
 }
 
 // setupRoutes configures all routes for the server
@@ -73,64 +268,405 @@ func (s *Server) setupRoutes() {
 	// Health check (public)
 	s.router.GET("/health", s.healthCheck)
 
+	// Dependency health: readiness is public, per-dependency details are admin-only
+	s.router.GET("/healthz/ready", s.healthHandler.Ready)
+
+	// Public keys for services verifying access tokens (public)
+	s.router.GET("/.well-known/jwks.json", s.authHandler.GetJWKS)
+	healthz := s.router.Group("/healthz")
+	healthz.Use(middleware.AuthMiddleware(s.authHandler), middleware.LoadUser(s.loadUser))
+	{
+		healthz.GET("/details", s.healthHandler.GetDetails)
+	}
+
+	// Domain metrics for Prometheus (only when a scrape token is configured)
+	if s.metricsHandler != nil {
+		s.router.GET("/metrics", s.metricsHandler.ServeMetrics)
+	}
+
 	// Authentication routes (public) - Updated
 	auth := s.router.Group("/api/v1/auth")
 	{
 		auth.POST("/register", s.authHandler.Register)
 		auth.POST("/login", s.authHandler.Login)
 		auth.POST("/oauth/login", s.authHandler.OAuthLogin)
+		auth.POST("/login-alerts/:token/deny", s.authHandler.DenyLogin)
+		auth.POST("/password/reset", s.authHandler.ResetPassword)
+		auth.GET("/csrf", s.issueCSRFToken)
 	}
 
+	// Frontend configuration (public, needed before login)
+	s.router.GET("/api/v1/config/client", s.configHandler.GetClientConfig)
+
 	// LeetCode proxy route (public)
 	s.router.POST("/api/v1/leetcode/proxy", func(c *gin.Context) {
 		// Convert Gin context to http.ResponseWriter and http.Request
 		handlers.LeetCodeProxyHandler(c.Writer, c.Request)
 	})
 
+	// Signed attachment downloads (public, authorized by the URL signature)
+	s.router.GET(storage.LocalDownloadPath, s.attachmentHandler.DownloadAttachment)
+
+	// Calendar subscription feed (public, authorized by the feed token)
+	s.router.GET("/api/v1/calendar.ics", s.calendarHandler.ServeFeed)
+
+	// Public profiles (public, authorized by the profile handle)
+	s.router.GET("/api/v1/public/profiles/:handle", s.profileHandler.GetPublicProfile)
+
+	// Shared progress reports (public, authorized by the link signature)
+	s.router.GET("/api/v1/reports/:id", s.reportHandler.GetReport)
+
+	// Stripe webhooks (public, authorized by the Stripe signature)
+	s.router.POST("/api/v1/billing/stripe/webhook", s.billingHandler.StripeWebhook)
+
+	// API description for client codegen, and Swagger UI to browse it (public)
+	s.router.GET("/api/v1/openapi.json", s.serveOpenAPI)
+	s.router.GET("/api/v1/docs", s.serveAPIDocs)
+
+	// Fault-injection endpoints for staging (only when enabled)
+	if s.debugHandler != nil {
+		debug := s.router.Group("/debug")
+		debug.Use(middleware.AuthMiddleware(s.authHandler), middleware.LoadUser(s.loadUser))
+		{
+			debug.GET("/chaos", s.debugHandler.GetChaos)
+			debug.PUT("/chaos/latency", s.debugHandler.SetLatency)
+			debug.PUT("/chaos/db-errors", s.debugHandler.SetDBErrors)
+			debug.DELETE("/chaos", s.debugHandler.ResetChaos)
+			debug.GET("/jobs", s.debugHandler.GetJobs)
+			debug.POST("/jobs/:name/run", s.debugHandler.RunJob)
+		}
+	}
+
+	// Read-only analytics for organization service accounts (API key auth)
+	analytics := s.router.Group("/api/v1/analytics")
+	analytics.Use(middleware.ServiceAccountAuth(s.orgHandler, models.ScopeAnalyticsRead))
+	{
+		analytics.GET("/orgs/:id/progress", s.orgHandler.GetMemberProgress)
+	}
+
 	// Protected API v1 routes
 	v1 := s.router.Group("/api/v1")
-	v1.Use(middleware.AuthMiddleware(s.authHandler)) // Apply JWT middleware to all v1 routes
+	v1.Use(middleware.AuthMiddleware(s.authHandler), middleware.LoadUser(s.loadUser), middleware.FeatureFlags(s.evaluateFlags)) // Apply JWT middleware to all v1 routes
 	{
+		// Listings clients poll answer with 304 when nothing changed
+		conditional := middleware.ConditionalGET()
+
+		// Feature flags for the current user
+		v1.GET("/flags", s.flagHandler.GetFlags)
+
 		// User routes
 		user := v1.Group("/user")
 		{
 			user.GET("/profile", s.authHandler.GetCurrentUser)
 			user.PUT("/profile", s.authHandler.UpdateProfile)
+			user.DELETE("/account", s.lifecycleHandler.DeleteAccount)
+			user.GET("/progress", s.progressHandler.GetProgress)
+			user.POST("/import", s.progressHandler.ImportProgress)
+			user.GET("/ai-usage", s.aiHandler.GetUsage)
+			user.GET("/ai-settings", s.aiHandler.GetSettings)
+			user.PUT("/ai-settings", s.aiHandler.UpdateSettings)
+			user.GET("/goals", s.statsHandler.GetGoals)
+			user.PUT("/goals", s.statsHandler.UpdateGoals)
+			user.GET("/notifications", s.notifyHandler.GetPreferences)
+			user.PUT("/notifications", s.notifyHandler.UpdatePreferences)
+			user.GET("/devices", s.notifyHandler.GetDevices)
+			user.POST("/devices", s.notifyHandler.RegisterDevice)
+			user.DELETE("/devices/:id", s.notifyHandler.RemoveDevice)
+			user.GET("/webhooks", s.webhookHandler.GetWebhooks)
+			user.POST("/webhooks", s.webhookHandler.CreateWebhook)
+			user.DELETE("/webhooks/:id", s.webhookHandler.DeleteWebhook)
+			user.GET("/webhooks/:id/deliveries", s.webhookHandler.GetDeliveries)
+			user.GET("/api-keys", s.apiKeyHandler.GetAPIKeys)
+			user.POST("/api-keys", s.apiKeyHandler.CreateAPIKey)
+			user.DELETE("/api-keys/:id", s.apiKeyHandler.RevokeAPIKey)
+			user.GET("/calendar", s.calendarHandler.GetFeed)
+			user.POST("/calendar", s.calendarHandler.CreateFeed)
+			user.DELETE("/calendar", s.calendarHandler.DeleteFeed)
+			user.GET("/public-profile", s.profileHandler.GetSettings)
+			user.PUT("/public-profile", s.profileHandler.UpdateSettings)
+			user.POST("/public-profile/handle", s.profileHandler.RegenerateHandle)
+			user.POST("/reports/share", s.reportHandler.ShareReport)
 		}
 
 		// Item routes
 		items := v1.Group("/items")
 		{
 			items.POST("", s.itemHandler.CreateItem)
-			items.GET("", s.itemHandler.GetItems)
-			items.GET("/paginated", s.itemHandler.GetItemsPaginated)
+			items.POST("/bulk", s.itemHandler.BulkCreateItems)
+			items.GET("", conditional, s.itemHandler.GetItems)
+			items.GET("/paginated", conditional, s.itemHandler.GetItemsPaginated)
+			items.GET("/export", s.itemHandler.ExportItems)
+			items.GET("/drafts", s.itemHandler.GetDrafts)
+			items.GET("/upcoming", s.itemHandler.GetUpcomingReleases)
+			items.PUT("/schedule", s.itemHandler.ScheduleItems)
 			items.GET("/next", s.itemHandler.GetNextItem)
 			items.POST("/skip", s.itemHandler.SkipItem)
 			items.GET("/subcategories/:category", s.itemHandler.GetSubcategories)
+			items.GET("/reviews/due", s.itemHandler.GetDueReviews)
 			items.GET("/:id", s.itemHandler.GetItem)
 			items.PUT("/:id", s.itemHandler.UpdateItem)
+			items.PUT("/:id/publish", s.itemHandler.PublishItem)
+			items.GET("/:id/revisions", s.itemHandler.GetItemRevisions)
+			items.POST("/:id/revisions/:revision_id/revert", s.itemHandler.RevertItem)
 			items.PUT("/:id/complete", s.itemHandler.CompleteItem)
+			items.PUT("/:id/review", s.itemHandler.ReviewItem)
 			items.PUT("/:id/star", s.itemHandler.ToggleStar)
+			items.PUT("/star/batch", s.itemHandler.BatchStar)
 			items.PUT("/:id/status", s.itemHandler.UpdateStatus)
 			items.DELETE("/:id", s.itemHandler.DeleteItem)
 			items.POST("/reset", s.itemHandler.ResetItems)
+			items.GET("/:id/attachments", s.attachmentHandler.GetAttachments)
+			items.POST("/:id/attachments/upload", s.attachmentHandler.UploadAttachment)
+			items.POST("/:id/attachments/secret", s.attachmentHandler.CreateSecretAttachment)
+			items.DELETE("/:id/attachments/:attachment_id", s.attachmentHandler.DeleteAttachment)
+			items.GET("/:id/hints", s.hintHandler.GetHints)
+			items.GET("/:id/hints/all", s.hintHandler.GetAllHints)
+			items.POST("/:id/hints", s.hintHandler.CreateHint)
+			items.POST("/:id/hints/reveal", s.hintHandler.RevealHint)
+			items.PUT("/:id/hints/:hint_id", s.hintHandler.UpdateHint)
+			items.DELETE("/:id/hints/:hint_id", s.hintHandler.DeleteHint)
+			items.POST("/:id/share", s.shareHandler.ShareItem)
+			items.POST("/:id/feedback", s.feedbackHandler.SubmitFeedback)
+			items.GET("/:id/flashcards", s.flashcardHandler.GetItemFlashcards)
+			items.POST("/:id/flashcards", s.flashcardHandler.CreateFlashcard)
+			items.GET("/:id/companies", s.companyHandler.GetItemCompanies)
+			items.GET("/:id/similar", s.similarHandler.GetSimilarItems)
+			items.PUT("/:id/companies", s.companyHandler.SetItemCompanies)
+			items.GET("/:id/design-notes", s.designNotesHandler.GetDesignNotes)
+			items.PUT("/:id/design-notes", s.designNotesHandler.SaveDesignNotes)
+			items.DELETE("/:id/design-notes", s.designNotesHandler.DeleteDesignNotes)
+			items.GET("/:id/test-cases", s.submissionHandler.GetTestCases)
+			items.POST("/:id/test-cases", s.submissionHandler.CreateTestCase)
+			items.PUT("/:id/test-cases/:case_id", s.submissionHandler.UpdateTestCase)
+			items.DELETE("/:id/test-cases/:case_id", s.submissionHandler.DeleteTestCase)
+			items.POST("/:id/submit", s.submissionHandler.Submit)
+			items.GET("/:id/submissions", s.submissionHandler.GetSubmissions)
+		}
+
+		// Company routes
+		companies := v1.Group("/companies")
+		{
+			companies.GET("", s.companyHandler.GetCompanies)
+			companies.POST("", s.companyHandler.CreateCompany)
+			companies.DELETE("/:slug", s.companyHandler.DeleteCompany)
+		}
+
+		// Category routes
+		categories := v1.Group("/categories")
+		{
+			categories.GET("", s.categoryHandler.GetCategories)
+			categories.POST("", s.categoryHandler.CreateCategory)
+			categories.PUT("/:slug", s.categoryHandler.UpdateCategory)
+			categories.DELETE("/:slug", s.categoryHandler.DeleteCategory)
+		}
+
+		// Behavioral question bank routes
+		behavioral := v1.Group("/behavioral")
+		{
+			behavioral.GET("/competencies", s.behavioralHandler.GetCompetencies)
+			behavioral.GET("/practice", s.behavioralHandler.GetPractice)
+			behavioral.GET("/questions", s.behavioralHandler.GetQuestions)
+			behavioral.POST("/questions", s.behavioralHandler.CreateQuestion)
+			behavioral.GET("/questions/:id", s.behavioralHandler.GetQuestion)
+			behavioral.PUT("/questions/:id", s.behavioralHandler.UpdateQuestion)
+			behavioral.DELETE("/questions/:id", s.behavioralHandler.DeleteQuestion)
+			behavioral.PUT("/questions/:id/answer", s.behavioralHandler.SaveAnswer)
+			behavioral.DELETE("/questions/:id/answer", s.behavioralHandler.DeleteAnswer)
+			behavioral.POST("/questions/:id/practice", s.behavioralHandler.RecordPractice)
+		}
+
+		// AI routes; generations count against the user's AI budget
+		ai := v1.Group("/ai")
+		{
+			ai.POST("/generate-question", s.aiHandler.GenerateQuestion)
+			ai.POST("/summarize", s.aiHandler.Summarize)
+			ai.GET("/mock-interviews", s.interviewerHandler.GetInterviews)
+			ai.POST("/mock-interviews", s.interviewerHandler.StartInterview)
+			ai.GET("/mock-interviews/:id", s.interviewerHandler.GetInterview)
+			ai.POST("/mock-interviews/:id/messages", s.interviewerHandler.Reply)
+			ai.POST("/mock-interviews/:id/end", s.interviewerHandler.EndInterview)
+			ai.DELETE("/mock-interviews/:id", s.interviewerHandler.DeleteInterview)
+		}
+
+		// Saved document routes, such as AI revision sheets
+		documents := v1.Group("/documents")
+		{
+			documents.GET("", s.documentHandler.GetDocuments)
+			documents.GET("/:id", s.documentHandler.GetDocument)
+			documents.DELETE("/:id", s.documentHandler.DeleteDocument)
+		}
+
+		// Quiz routes; authoring a quiz requires content:write
+		quizzes := v1.Group("/quizzes")
+		{
+			quizzes.GET("", s.quizHandler.GetQuizzes)
+			quizzes.POST("", s.quizHandler.CreateQuiz)
+			quizzes.GET("/:id", s.quizHandler.GetQuiz)
+			quizzes.PUT("/:id", s.quizHandler.UpdateQuiz)
+			quizzes.DELETE("/:id", s.quizHandler.DeleteQuiz)
+			quizzes.GET("/:id/attempts", s.quizHandler.GetAttempts)
+			quizzes.POST("/:id/attempts", s.quizHandler.SubmitAttempt)
+		}
+
+		// Interview pipeline routes
+		interviews := v1.Group("/interviews")
+		{
+			interviews.GET("", s.interviewHandler.GetInterviews)
+			interviews.POST("", s.interviewHandler.CreateInterview)
+			interviews.GET("/:id", s.interviewHandler.GetInterview)
+			interviews.PUT("/:id", s.interviewHandler.UpdateInterview)
+			interviews.DELETE("/:id", s.interviewHandler.DeleteInterview)
+			interviews.POST("/:id/stages", s.interviewHandler.CreateStage)
+			interviews.PUT("/:id/stages/:stage_id", s.interviewHandler.UpdateStage)
+			interviews.DELETE("/:id/stages/:stage_id", s.interviewHandler.DeleteStage)
+			interviews.PUT("/:id/stages/:stage_id/items", s.interviewHandler.SetStageItems)
+		}
+
+		// Billing routes
+		billing := v1.Group("/billing")
+		{
+			billing.GET("/subscription", s.billingHandler.GetSubscription)
+			billing.POST("/trial", s.billingHandler.StartTrial)
+			billing.POST("/checkout", s.billingHandler.CreateCheckout)
+			billing.POST("/portal", s.billingHandler.CreatePortal)
+		}
+
+		// Slack, Discord and GitHub integration routes
+		integrations := v1.Group("/integrations")
+		{
+			integrations.GET("/github", s.githubHandler.GetConnection)
+			integrations.PUT("/github", s.githubHandler.UpdateRepository)
+			integrations.DELETE("/github", s.githubHandler.Disconnect)
+			integrations.POST("/github/authorize", s.githubHandler.Authorize)
+			integrations.POST("/github/callback", s.githubHandler.CompleteAuthorization)
+			integrations.GET("/:platform", s.webhookHandler.GetIntegration)
+			integrations.PUT("/:platform", s.webhookHandler.ConnectIntegration)
+			integrations.DELETE("/:platform", s.webhookHandler.DisconnectIntegration)
+			integrations.POST("/:platform/test", s.webhookHandler.TestIntegration)
+		}
+
+		// Timer session routes
+		sessions := v1.Group("/sessions")
+		{
+			sessions.POST("/start", s.focusHandler.StartSession)
+			sessions.GET("/active", s.focusHandler.GetActiveSession)
+			sessions.GET("/time", s.focusHandler.GetTimeSummary)
+			sessions.POST("/:id/pause", s.focusHandler.PauseSession)
+			sessions.POST("/:id/resume", s.focusHandler.ResumeSession)
+			sessions.POST("/:id/stop", s.focusHandler.StopSession)
+		}
+
+		// Weak-area recommendations
+		v1.GET("/recommendations", s.recommendHandler.GetRecommendations)
+
+		// Flashcard routes
+		flashcards := v1.Group("/flashcards")
+		{
+			flashcards.GET("/review", s.flashcardHandler.GetReview)
+			flashcards.POST("/:id/grade", s.flashcardHandler.GradeFlashcard)
+			flashcards.PUT("/:id", s.flashcardHandler.UpdateFlashcard)
+			flashcards.DELETE("/:id", s.flashcardHandler.DeleteFlashcard)
+		}
+
+		// Shared item inbox routes
+		shares := v1.Group("/shares")
+		{
+			shares.GET("/inbox", s.shareHandler.GetInbox)
+			shares.PUT("/:id/accept", s.shareHandler.AcceptShare)
+			shares.PUT("/:id/dismiss", s.shareHandler.DismissShare)
+		}
+
+		// Study group routes
+		groups := v1.Group("/groups")
+		{
+			groups.POST("", s.groupHandler.CreateGroup)
+			groups.GET("", s.groupHandler.GetGroups)
+			groups.POST("/join", s.groupHandler.JoinGroup)
+			groups.GET("/:id", s.groupHandler.GetGroup)
+			groups.POST("/:id/leave", s.groupHandler.LeaveGroup)
+			groups.POST("/:id/invite", s.groupHandler.InviteToGroup)
+			groups.GET("/:id/leaderboard", s.groupHandler.GetLeaderboard)
+			groups.GET("/:id/progress", s.groupHandler.GetProgress)
+			groups.GET("/:id/lists", s.groupHandler.GetItemLists)
+			groups.POST("/:id/lists", s.groupHandler.CreateItemList)
+		}
+
+		// Organization routes
+		v1.GET("/orgs", s.orgHandler.GetMyOrganizations)
+		v1.POST("/orgs", s.orgHandler.CreateOrganization)
+		v1.POST("/orgs/invitations/accept", s.orgHandler.AcceptInvitation)
+		org := v1.Group("/orgs/:id", middleware.OrgScope(s.orgHandler))
+		{
+			org.GET("", s.orgHandler.GetOrganization)
+			org.GET("/members", s.orgHandler.GetMembers)
+			org.PUT("/members/:user_id", s.orgHandler.UpdateMember)
+			org.DELETE("/members/:user_id", s.orgHandler.RemoveMember)
+			org.GET("/invitations", s.orgHandler.GetInvitations)
+			org.POST("/invitations", s.orgHandler.BulkInvite)
+			org.GET("/analytics", s.orgHandler.GetOrgAnalytics)
+			org.GET("/catalog", s.orgHandler.GetCatalog)
+			org.PUT("/catalog/:item_id", s.orgHandler.SetCatalogItem)
+			org.DELETE("/catalog/:item_id", s.orgHandler.RemoveCatalogItem)
+			org.GET("/service-accounts", s.orgHandler.GetServiceAccounts)
+			org.POST("/service-accounts", s.orgHandler.CreateServiceAccount)
+			org.DELETE("/service-accounts/:account_id", s.orgHandler.RevokeServiceAccount)
+		}
+
+		// Admin routes (handlers enforce the permission each one needs)
+		manageUsers := middleware.RequirePermission(s.adminHandler, models.PermissionUsersManage)
+		manageSystem := middleware.RequirePermission(s.adminHandler, models.PermissionSystemManage)
+		admin := v1.Group("/admin")
+		{
+			admin.POST("/orgs", s.orgHandler.CreateOrganization)
+			admin.GET("/orgs/:id/invitations", s.orgHandler.GetInvitations)
+			admin.POST("/orgs/:id/invitations", s.orgHandler.BulkInvite)
+			admin.GET("/orgs/:id/analytics", s.orgHandler.GetCohortAnalytics)
+			admin.GET("/search", s.adminHandler.Search)
+			admin.GET("/lifecycle/runs", s.lifecycleHandler.GetRuns)
+			admin.PUT("/users/:id/plan", s.billingHandler.SetUserPlan)
+			admin.PUT("/users/:id/role", manageUsers, s.adminHandler.UpdateUserRole)
+			admin.GET("/roles", manageUsers, s.adminHandler.ListRoles)
+			admin.PUT("/roles/:name", manageUsers, s.adminHandler.SaveRole)
+			admin.DELETE("/roles/:name", manageUsers, s.adminHandler.DeleteRole)
+			admin.GET("/catalog/export", s.catalogHandler.ExportCatalog)
+			admin.POST("/catalog/apply", s.catalogHandler.ApplyCatalog)
+			admin.GET("/analytics/items", s.itemHandler.GetItemAnalytics)
+			admin.GET("/analytics/items/:id", s.itemHandler.GetItemAnalyticsByID)
+			admin.GET("/feedback", s.feedbackHandler.GetQueue)
+			admin.PUT("/feedback/:id/resolve", s.feedbackHandler.ResolveFeedback)
+			admin.PUT("/feedback/:id/dismiss", s.feedbackHandler.DismissFeedback)
+			admin.GET("/links/dead", s.linkCheckHandler.GetDeadLinks)
+			admin.GET("/jobs", s.jobHandler.GetJobs)
+			admin.GET("/jobs/:name/runs", s.jobHandler.GetRuns)
+			admin.POST("/jobs/:name/run", s.jobHandler.TriggerJob)
+			admin.GET("/flags", s.flagHandler.ListFlags)
+			admin.PUT("/flags/:key", s.flagHandler.UpdateFlag)
+			admin.DELETE("/flags/:key", s.flagHandler.DeleteFlag)
+			admin.GET("/test-eligibility", manageSystem, s.testHandler.GetTestEligibility)
+			admin.PUT("/test-eligibility", manageSystem, s.testHandler.UpdateTestEligibility)
 		}
 
 		// Stats routes
 		stats := v1.Group("/stats")
 		{
-			stats.GET("", s.statsHandler.GetStats)
-			stats.GET("/detailed", s.statsHandler.GetDetailedStats)
-			stats.GET("/category/:category", s.statsHandler.GetCategoryStats)
-			stats.GET("/category/:category/subcategory/:subcategory", s.statsHandler.GetSubcategoryStats)
+			stats.GET("", conditional, s.statsHandler.GetStats)
+			stats.GET("/detailed", conditional, s.statsHandler.GetDetailedStats)
+			stats.GET("/category/:category", conditional, s.statsHandler.GetCategoryStats)
+			stats.GET("/category/:category/subcategory/:subcategory", conditional, s.statsHandler.GetSubcategoryStats)
+			stats.GET("/companies", conditional, s.companyHandler.GetCompanyStats)
 			stats.POST("/reset-completed-all", s.statsHandler.ResetCompletedAllCount)
 		}
 
 		// Engineering Blogs routes
 		engBlogs := v1.Group("/eng-blogs")
 		{
-			engBlogs.GET("", s.engBlogHandler.GetEngBlogs)
+			engBlogs.GET("", conditional, s.engBlogHandler.GetEngBlogs)
 			engBlogs.GET("/:id", s.engBlogHandler.GetEngBlog)
+			engBlogs.DELETE("/:id", s.engBlogHandler.ArchiveEngBlog)
+			engBlogs.POST("/:id/restore", s.engBlogHandler.RestoreEngBlog)
+			engBlogs.DELETE("/:id/articles/:articleId", s.engBlogHandler.ArchiveArticle)
+			engBlogs.POST("/:id/articles/:articleId/restore", s.engBlogHandler.RestoreArticle)
+			engBlogs.POST("/:id/articles/:articleId/promote", s.engBlogHandler.PromoteArticle)
 		}
 
 		// Test routes
@@ -138,16 +674,35 @@ func (s *Server) setupRoutes() {
 		{
 			tests.POST("", s.testHandler.CreateTest)
 			tests.GET("/active", s.testHandler.GetActiveTest)
+			tests.GET("/history", s.testHandler.GetTestHistory)
 			tests.GET("/can-create", s.testHandler.CheckCanCreateTest)
-			tests.PUT("/:session_id/:item_id/complete", s.testHandler.CompleteTest)
-			tests.PUT("/:session_id/:item_id/abandon", s.testHandler.AbandonTest)
+			tests.PUT("/:session_id/items/:item_id/complete", s.testHandler.CompleteTest)
+			tests.PUT("/:session_id/items/:item_id/abandon", s.testHandler.AbandonTest)
+			tests.PUT("/:session_id/items/:item_id/result", s.testHandler.RecordTestResult)
+			tests.POST("/:session_id/retake", s.testHandler.RetakeTest)
 			tests.DELETE("/:session_id", s.testHandler.DeleteTest)
+
+			deprecatedItemRoutes := middleware.Deprecated(testItemSuccessors, time.Time{})
+			tests.PUT("/:session_id/:item_id/complete", deprecatedItemRoutes, s.testHandler.CompleteTest)
+			tests.PUT("/:session_id/:item_id/abandon", deprecatedItemRoutes, s.testHandler.AbandonTest)
 		}
 	}
 
-	// Legacy routes (for backward compatibility) - also protected
+	// Embedded frontend (standalone mode only). The legacy routes are left out because
+	// their paths collide with the frontend's /items and /stats pages.
+	if s.frontend != nil {
+		s.router.NoRoute(s.serveFrontend)
+		return
+	}
+
+	// Legacy routes (for backward compatibility) - also protected. They answer with
+	// Deprecation headers pointing at their /api/v1 successors until they're switched off.
+	if !s.config.LegacyRoutesEnabled {
+		return
+	}
+	sunset, _ := s.config.LegacySunset()
 	legacyProtected := s.router.Group("")
-	legacyProtected.Use(middleware.AuthMiddleware(s.authHandler))
+	legacyProtected.Use(middleware.Deprecated(legacySuccessors, sunset), middleware.AuthMiddleware(s.authHandler), middleware.LoadUser(s.loadUser))
 	{
 		legacyProtected.POST("/items", s.itemHandler.CreateItem)
 		legacyProtected.GET("/items", s.itemHandler.GetItems)
@@ -175,3 +730,49 @@ func (s *Server) healthCheck(c *gin.Context) {
 		"version": "2.0",
 	})
 }
+
+// issueCSRFToken sets a CSRF token cookie for browser flows authenticated by a session cookie and
+// returns the token, which state-changing requests echo in the X-CSRF-Token header. The token
+// only works with the session cookie the request carries, so it's fetched again after login.
+func (s *Server) issueCSRFToken(c *gin.Context) {
+	session, _ := middleware.SessionCookie(c, s.config.CSRFSessionCookies)
+	token, err := s.csrf.Issue(session)
+	if err != nil {
+		c.Error(apperr.Wrap(apperr.KindInternal, err))
+		return
+	}
+
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(csrf.CookieName, token, int(csrfTokenTTL.Seconds()), "/", "", s.config.IsProduction(), false)
+	c.JSON(http.StatusOK, gin.H{"csrf_token": token})
+}
+
+// csrfTokenTTL is how long a CSRF token cookie lasts
+const csrfTokenTTL = 12 * time.Hour
+
+// serveFrontend serves static frontend files, falling back to index.html for client-side routes
+func (s *Server) serveFrontend(c *gin.Context) {
+	if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+		c.Error(apperr.NotFound("Not found"))
+		return
+	}
+
+	if strings.HasPrefix(c.Request.URL.Path, "/api/") {
+		c.Error(apperr.NotFound("Not found"))
+		return
+	}
+
+	name := strings.TrimPrefix(path.Clean(c.Request.URL.Path), "/")
+	if info, err := fs.Stat(s.frontend, name); err == nil && !info.IsDir() && name != "index.html" {
+		c.FileFromFS(name, http.FS(s.frontend))
+		return
+	}
+
+	index, err := fs.ReadFile(s.frontend, "index.html")
+	if err != nil {
+		c.Error(apperr.NotFound("Not found"))
+		return
+	}
+
+	c.Data(http.StatusOK, "text/html; charset=utf-8", index)
+}
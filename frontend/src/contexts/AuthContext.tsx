@@ -104,7 +104,7 @@ export const AuthProvider: React.FC<AuthProviderProps> = ({ children }) => {
         return true;
       } else {
         const errorData = await response.json();
-        console.error('Login error:', errorData.error);
+        console.error('Login error:', errorData.message);
         return false;
       }
     } catch (error) {
@@ -134,7 +134,7 @@ export const AuthProvider: React.FC<AuthProviderProps> = ({ children }) => {
         return true;
       } else {
         const errorData = await response.json();
-        console.error('Registration error:', errorData.error);
+        console.error('Registration error:', errorData.message);
         return false;
       }
     } catch (error) {
@@ -277,7 +277,7 @@ export const AuthProvider: React.FC<AuthProviderProps> = ({ children }) => {
         return true;
       } else {
         const errorData = await response.json();
-        console.error('OAuth login error:', errorData.error);
+        console.error('OAuth login error:', errorData.message);
         return false;
       }
     } catch (error) {
@@ -86,7 +86,7 @@ const AddItem: React.FC = () => {
       await itemsApi.createItem(formData);
       navigate('/items');
     } catch (err: any) {
-      setError(err.response?.data?.error || 'Failed to create item');
+      setError(err.response?.data?.message || 'Failed to create item');
       console.error(err);
     } finally {
       setLoading(false);
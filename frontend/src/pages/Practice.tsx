@@ -188,7 +188,7 @@ const Practice: React.FC = () => {
       });
       setCanCreateTest(false);
     } catch (err: any) {
-      setTestError(err.response?.data?.error || 'Failed to create test');
+      setTestError(err.response?.data?.message || 'Failed to create test');
       console.error('Failed to create test:', err);
     } finally {
       setCreatingTest(false);
@@ -207,7 +207,7 @@ const Practice: React.FC = () => {
       // Dispatch custom event for widget to refresh
       window.dispatchEvent(new CustomEvent('itemCompleted'));
     } catch (err: any) {
-      setTestError(err.response?.data?.error || 'Failed to complete test item');
+      setTestError(err.response?.data?.message || 'Failed to complete test item');
       console.error('Failed to complete test item:', err);
     }
   };
@@ -257,7 +257,7 @@ const Practice: React.FC = () => {
       // Check if user can create a test after completing an item
       await checkCanCreateTest();
     } catch (err: any) {
-      setError(err.response?.data?.error || 'Failed to mark item as complete');
+      setError(err.response?.data?.message || 'Failed to mark item as complete');
       console.error(err);
     } finally {
       setCompleting(false);
@@ -279,7 +279,7 @@ const Practice: React.FC = () => {
         setNoItems(true);
         setCurrentItem(null);
       } else {
-        setError(err.response?.data?.error || 'Failed to skip item');
+        setError(err.response?.data?.message || 'Failed to skip item');
         console.error(err);
       }
     } finally {
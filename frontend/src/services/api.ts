@@ -376,13 +376,13 @@ export const testsApi = {
 
   // Mark test item as complete
   completeTestItem: async (sessionId: string, itemId: number) => {
-    const response = await api.put(`/tests/${sessionId}/${itemId}/complete`);
+    const response = await api.put(`/tests/${sessionId}/items/${itemId}/complete`);
     return response.data;
   },
 
   // Mark test item as abandoned
   abandonTestItem: async (sessionId: string, itemId: number) => {
-    const response = await api.put(`/tests/${sessionId}/${itemId}/abandon`);
+    const response = await api.put(`/tests/${sessionId}/items/${itemId}/abandon`);
     return response.data;
   },
 